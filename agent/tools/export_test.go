@@ -23,6 +23,7 @@ var (
 )
 
 const (
-	ToolsFile      = toolsFile
-	GUIArchiveFile = guiArchiveFile
+	ToolsFile        = toolsFile
+	ToolsArchiveFile = toolsArchiveFile
+	GUIArchiveFile   = guiArchiveFile
 )
@@ -5,11 +5,13 @@ package tools_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -19,6 +21,7 @@ import (
 	agenttools "github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/testing"
 	coretest "github.com/juju/juju/tools"
+	"github.com/juju/juju/tools/delta"
 )
 
 type ToolsSuite struct {
@@ -40,7 +43,7 @@ func (t *ToolsSuite) TestPackageDependencies(c *gc.C) {
 	// resulting slice has that prefix removed to keep the output short.
 	c.Assert(testing.FindJujuCoreImports(c, "github.com/juju/juju/agent/tools"),
 		gc.DeepEquals,
-		[]string{"tools"})
+		[]string{"tools", "tools/delta"})
 }
 
 // gzyesses holds the result of running:
@@ -148,6 +151,123 @@ func (t *ToolsSuite) TestUnpackToolsContents(c *gc.C) {
 	t.assertToolsContents(c, testTools, files)
 }
 
+func (t *ToolsSuite) TestUnpackToolsKeepsArchive(c *gc.C) {
+	data, checksum := testing.TarGz(testing.NewTarFile("jujud", agenttools.DirPerm, "jujud contents"))
+	testTools := &coretest.Tools{
+		URL:     "http://foo/bar",
+		Version: version.MustParseBinary("1.2.3-quantal-amd64"),
+		Size:    int64(len(data)),
+		SHA256:  checksum,
+	}
+	c.Assert(agenttools.HasToolsArchive(t.dataDir, testTools.Version), jc.IsFalse)
+
+	err := agenttools.UnpackTools(t.dataDir, testTools, bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agenttools.HasToolsArchive(t.dataDir, testTools.Version), jc.IsTrue)
+	dir := agenttools.SharedToolsDir(t.dataDir, testTools.Version)
+	archive, err := ioutil.ReadFile(filepath.Join(dir, agenttools.ToolsArchiveFile))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archive, jc.DeepEquals, data)
+}
+
+func (t *ToolsSuite) TestUnpackToolsDelta(c *gc.C) {
+	baseData, baseChecksum := testing.TarGz(
+		testing.NewTarFile("jujud", agenttools.DirPerm, strings.Repeat("jujud 1.2.3 ", 2000)),
+	)
+	baseTools := &coretest.Tools{
+		Version: version.MustParseBinary("1.2.3-quantal-amd64"),
+		Size:    int64(len(baseData)),
+		SHA256:  baseChecksum,
+	}
+	err := agenttools.UnpackTools(t.dataDir, baseTools, bytes.NewReader(baseData))
+	c.Assert(err, jc.ErrorIsNil)
+
+	files := []*testing.TarFile{
+		testing.NewTarFile("jujud", agenttools.DirPerm, strings.Repeat("jujud 1.2.3 ", 1000)+"jujud 1.2.4"),
+		testing.NewTarFile("juju-run", agenttools.DirPerm, "juju-run contents"),
+	}
+	data, checksum := testing.TarGz(files...)
+	testTools := &coretest.Tools{
+		URL:     "http://foo/bar",
+		Version: version.MustParseBinary("1.2.4-quantal-amd64"),
+		Size:    int64(len(data)),
+		SHA256:  checksum,
+	}
+	var buf bytes.Buffer
+	err = delta.Diff(&buf, gunzip(c, baseData), gunzip(c, data))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = agenttools.UnpackToolsDelta(t.dataDir, baseTools.Version, testTools, checksum, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	assertDirNames(c, t.toolsDir(), []string{"1.2.3-quantal-amd64", "1.2.4-quantal-amd64"})
+	t.assertToolsContents(c, testTools, files)
+	c.Assert(agenttools.HasToolsArchive(t.dataDir, testTools.Version), jc.IsTrue)
+}
+
+func (t *ToolsSuite) TestUnpackToolsDeltaNoBase(c *gc.C) {
+	testTools := &coretest.Tools{
+		Version: version.MustParseBinary("1.2.4-quantal-amd64"),
+		SHA256:  "abcd",
+	}
+	err := agenttools.UnpackToolsDelta(
+		t.dataDir, version.MustParseBinary("1.2.3-quantal-amd64"), testTools, "abcd", &bytes.Buffer{},
+	)
+	c.Assert(err, gc.ErrorMatches, "tools archive for 1.2.3-quantal-amd64 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (t *ToolsSuite) TestUnpackToolsDeltaMismatch(c *gc.C) {
+	baseData, baseChecksum := testing.TarGz(
+		testing.NewTarFile("jujud", agenttools.DirPerm, strings.Repeat("jujud 1.2.3 ", 2000)),
+	)
+	baseTools := &coretest.Tools{
+		Version: version.MustParseBinary("1.2.3-quantal-amd64"),
+		Size:    int64(len(baseData)),
+		SHA256:  baseChecksum,
+	}
+	err := agenttools.UnpackTools(t.dataDir, baseTools, bytes.NewReader(baseData))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Compute a delta against a different base.
+	otherData, _ := testing.TarGz(
+		testing.NewTarFile("jujud", agenttools.DirPerm, strings.Repeat("jujud 1.2.2 ", 2000)),
+	)
+	data, checksum := testing.TarGz(
+		testing.NewTarFile("jujud", agenttools.DirPerm, strings.Repeat("jujud 1.2.2 ", 2000)+"1.2.4"),
+	)
+	var buf bytes.Buffer
+	err = delta.Diff(&buf, gunzip(c, otherData), gunzip(c, data))
+	c.Assert(err, jc.ErrorIsNil)
+
+	testTools := &coretest.Tools{
+		Version: version.MustParseBinary("1.2.4-quantal-amd64"),
+		SHA256:  checksum,
+	}
+	err = agenttools.UnpackToolsDelta(t.dataDir, baseTools.Version, testTools, checksum, &buf)
+	c.Assert(err, gc.ErrorMatches, "applying tools delta: .*")
+	assertDirNames(c, t.toolsDir(), []string{"1.2.3-quantal-amd64"})
+}
+
+func (t *ToolsSuite) TestUnpackToolsDeltaForOtherTools(c *gc.C) {
+	testTools := &coretest.Tools{
+		Version: version.MustParseBinary("1.2.4-quantal-amd64"),
+		SHA256:  "abcd",
+	}
+	err := agenttools.UnpackToolsDelta(
+		t.dataDir, version.MustParseBinary("1.2.3-quantal-amd64"), testTools, "ef01", &bytes.Buffer{},
+	)
+	c.Assert(err, gc.ErrorMatches, "tools delta sha256 mismatch, expected abcd, got ef01")
+}
+
+func gunzip(c *gc.C, data []byte) []byte {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	defer zr.Close()
+	result, err := ioutil.ReadAll(zr)
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
 func (t *ToolsSuite) TestReadToolsErrors(c *gc.C) {
 	vers := version.MustParseBinary("1.2.3-precise-amd64")
 	testTools, err := agenttools.ReadTools(t.dataDir, vers)
@@ -269,7 +389,7 @@ func (t *ToolsSuite) assertToolsContents(c *gc.C, testTools *coretest.Tools, fil
 	for _, f := range files {
 		wantNames = append(wantNames, f.Header.Name)
 	}
-	wantNames = append(wantNames, agenttools.ToolsFile)
+	wantNames = append(wantNames, agenttools.ToolsFile, agenttools.ToolsArchiveFile)
 	dir := agenttools.SharedToolsDir(t.dataDir, testTools.Version)
 	assertDirNames(c, dir, wantNames)
 	expectedURLFileContents, err := json.Marshal(testTools)
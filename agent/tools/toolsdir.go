@@ -20,12 +20,18 @@ import (
 	"github.com/juju/version"
 
 	coretools "github.com/juju/juju/tools"
+	"github.com/juju/juju/tools/delta"
 )
 
 const (
 	dirPerm        = 0755
 	guiArchiveFile = "downloaded-gui.txt"
 	toolsFile      = "downloaded-tools.txt"
+
+	// toolsArchiveFile holds the gzipped tar archive the tools
+	// were unpacked from. Cloud-init keeps the archive of the
+	// tools a machine is provisioned with under the same name.
+	toolsArchiveFile = "tools.tar.gz"
 )
 
 // SharedToolsDir returns the directory that is used to
@@ -55,11 +61,20 @@ func ToolsDir(dataDir, agentName string) string {
 // UnpackTools reads a set of juju tools in gzipped tar-archive
 // format and unpacks them into the appropriate tools directory
 // within dataDir. If a valid tools directory already exists,
-// UnpackTools returns without error.
+// UnpackTools returns without error. The archive itself is kept
+// in the tools directory, so that later upgrades may be
+// distributed as deltas against it.
 func UnpackTools(dataDir string, tools *coretools.Tools, r io.Reader) (err error) {
+	archive, err := ioutil.TempFile(os.TempDir(), "tools-archive")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
 	// Unpack the gzip file and compute the checksum.
 	sha256hash := sha256.New()
-	zr, err := gzip.NewReader(io.TeeReader(r, sha256hash))
+	zr, err := gzip.NewReader(io.TeeReader(r, io.MultiWriter(sha256hash, archive)))
 	if err != nil {
 		return err
 	}
@@ -68,20 +83,107 @@ func UnpackTools(dataDir string, tools *coretools.Tools, r io.Reader) (err error
 	if err != nil {
 		return err
 	}
+	defer os.Remove(f.Name())
+	defer f.Close()
 	_, err = io.Copy(f, zr)
 	if err != nil {
 		return err
 	}
-	defer os.Remove(f.Name())
 	gzipSHA256 := fmt.Sprintf("%x", sha256hash.Sum(nil))
 	if tools.SHA256 != gzipSHA256 {
 		return fmt.Errorf("tarball sha256 mismatch, expected %s, got %s", tools.SHA256, gzipSHA256)
 	}
+	return unpackTar(dataDir, tools, f, archive)
+}
+
+// UnpackToolsDelta reads a delta, as served by the controller, that
+// transforms the tools archive kept for the base version into the
+// archive for the given tools, and unpacks the result into the
+// appropriate tools directory within dataDir. archiveSHA256 is the
+// hash of the tarball the controller computed the delta for, which
+// must match the tools' SHA256.
+func UnpackToolsDelta(dataDir string, base version.Binary, tools *coretools.Tools, archiveSHA256 string, r io.Reader) error {
+	// The delta reconstructs the uncompressed tar archive, so the
+	// tools' SHA256, which is that of the gzipped tarball, cannot be
+	// checked against the result; check that the delta was computed
+	// for the expected tarball instead.
+	if archiveSHA256 != tools.SHA256 {
+		return errors.Errorf("tools delta sha256 mismatch, expected %s, got %s", tools.SHA256, archiveSHA256)
+	}
+	baseTar, err := readToolsArchive(dataDir, base)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The delta verifies the size and hash of
+	// the tar archive it reconstructs.
+	targetTar, err := delta.Patch(baseTar, r)
+	if err != nil {
+		return errors.Annotate(err, "applying tools delta")
+	}
+
+	f, err := ioutil.TempFile(os.TempDir(), "tools-tar")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err := f.Write(targetTar); err != nil {
+		return err
+	}
+	archive, err := ioutil.TempFile(os.TempDir(), "tools-archive")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+	zw := gzip.NewWriter(archive)
+	if _, err := zw.Write(targetTar); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return unpackTar(dataDir, tools, f, archive)
+}
 
+// HasToolsArchive reports whether the tools archive for the given
+// version is available in dataDir, to be used as the base of a
+// delta.
+func HasToolsArchive(dataDir string, vers version.Binary) bool {
+	_, err := os.Stat(path.Join(SharedToolsDir(dataDir, vers), toolsArchiveFile))
+	return err == nil
+}
+
+// readToolsArchive returns the uncompressed contents of
+// the tools archive for the given version.
+func readToolsArchive(dataDir string, vers version.Binary) ([]byte, error) {
+	f, err := os.Open(path.Join(SharedToolsDir(dataDir, vers), toolsArchiveFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("tools archive for %s", vers)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading tools archive for %s", vers)
+	}
+	defer zr.Close()
+	data, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading tools archive for %s", vers)
+	}
+	return data, nil
+}
+
+// unpackTar unpacks the verified tar archive f into the tools
+// directory for the given tools, along with the gzipped archive
+// it was read from.
+func unpackTar(dataDir string, tools *coretools.Tools, f, archive *os.File) error {
 	// Make a temporary directory in the tools directory,
 	// first ensuring that the tools directory exists.
 	toolsDir := path.Join(dataDir, "tools")
-	err = os.MkdirAll(toolsDir, dirPerm)
+	err := os.MkdirAll(toolsDir, dirPerm)
 	if err != nil {
 		return err
 	}
@@ -116,6 +218,12 @@ func UnpackTools(dataDir string, tools *coretools.Tools, r io.Reader) (err error
 			return errors.Annotatef(err, "tar extract %q failed", name)
 		}
 	}
+	if _, err := archive.Seek(0, 0); err != nil {
+		return err
+	}
+	if err := writeFile(path.Join(dir, toolsArchiveFile), 0644, archive); err != nil {
+		return errors.Annotate(err, "cannot keep tools archive")
+	}
 	toolsMetadataData, err := json.Marshal(tools)
 	if err != nil {
 		return err
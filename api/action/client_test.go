@@ -109,6 +109,21 @@ func (s *actionSuite) TestApplicationCharmActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestRunLeaderNotSupported(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected facade call %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.Run(params.RunParams{
+		Commands: "hostname",
+		Units:    []string{"mysql/0", "mysql/leader"},
+	})
+	c.Assert(err, gc.ErrorMatches, "running commands on application leaders not supported")
+}
+
 // replace sCharmActions" facade call with required results and error
 // if desired
 func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults []params.ApplicationCharmActionsResult, err string) func() {
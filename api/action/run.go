@@ -4,8 +4,10 @@
 package action
 
 import (
+	"strings"
 	"time"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -19,8 +21,18 @@ func (c *Client) RunOnAllMachines(commands string, timeout time.Duration) ([]par
 }
 
 // Run the Commands specified on the machines identified through the ids
-// provided in the machines, services and units slices.
+// provided in the machines, services and units slices. A unit may be
+// given as "<application>/leader" to run on the application's leader;
+// this requires Action facade version 3.
 func (c *Client) Run(run params.RunParams) ([]params.ActionResult, error) {
+	for _, unit := range run.Units {
+		if strings.HasSuffix(unit, "/leader") {
+			if err := base.RequireVersion(c.facade, 3, "running commands on application leaders"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
 	var results params.ActionResults
 	err := c.facade.FacadeCall("Run", run, &results)
 	return results.Results, err
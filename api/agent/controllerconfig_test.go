@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/agent"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type controllerConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&controllerConfigSuite{})
+
+func (s *controllerConfigSuite) TestWatchControllerConfig(c *gc.C) {
+	var called int
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			called++
+			c.Check(objType, gc.Equals, "Agent")
+			c.Check(version, gc.Equals, 3)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchControllerConfig")
+			c.Check(args, gc.IsNil)
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		},
+	}
+	st := agent.NewState(apiCaller)
+
+	_, err := st.WatchControllerConfig()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, gc.Equals, 1)
+}
+
+func (s *controllerConfigSuite) TestWatchControllerConfigNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatal("unexpected API call")
+			return nil
+		},
+	}
+	st := agent.NewState(apiCaller)
+
+	_, err := st.WatchControllerConfig()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "watching controller config not supported")
+}
@@ -25,6 +25,7 @@ type State struct {
 	*common.ModelWatcher
 	*cloudspec.CloudSpecAPI
 	*common.ControllerConfigAPI
+	controllerConfigWatcher *common.ControllerConfigWatcher
 }
 
 // NewState returns a version of the state that provides functionality
@@ -32,13 +33,24 @@ type State struct {
 func NewState(caller base.APICaller) *State {
 	facadeCaller := base.NewFacadeCaller(caller, "Agent")
 	return &State{
-		facade:              facadeCaller,
-		ModelWatcher:        common.NewModelWatcher(facadeCaller),
-		CloudSpecAPI:        cloudspec.NewCloudSpecAPI(facadeCaller),
-		ControllerConfigAPI: common.NewControllerConfig(facadeCaller),
+		facade:                  facadeCaller,
+		ModelWatcher:            common.NewModelWatcher(facadeCaller),
+		CloudSpecAPI:            cloudspec.NewCloudSpecAPI(facadeCaller),
+		ControllerConfigAPI:     common.NewControllerConfig(facadeCaller),
+		controllerConfigWatcher: common.NewControllerConfigWatcher(facadeCaller),
 	}
 }
 
+// WatchControllerConfig returns a NotifyWatcher waiting for the
+// controller configuration to change. It requires version 3 of the
+// Agent facade.
+func (st *State) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	if err := base.RequireVersion(st.facade, 3, "watching controller config"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.controllerConfigWatcher.WatchControllerConfig()
+}
+
 func (st *State) getEntity(tag names.Tag) (*params.AgentGetEntitiesResult, error) {
 	var results params.AgentGetEntitiesResults
 	args := params.Entities{
@@ -6,6 +6,8 @@ package api
 import (
 	"sort"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state/multiwatcher"
@@ -51,13 +53,35 @@ func newAllWatcher(objType string, caller base.APICaller, id *string) *AllWatche
 // by the WatchAll or WatchAllModels API calls. It will block until
 // there are deltas to return.
 func (watcher *AllWatcher) Next() ([]multiwatcher.Delta, error) {
+	return watcher.next(nil)
+}
+
+// filterVersions holds the first version of each AllWatcher facade
+// whose Next call takes a filter.
+var filterVersions = map[string]int{
+	"AllWatcher":      2,
+	"AllModelWatcher": 3,
+}
+
+// NextFiltered is like Next, but returns only the deltas for the
+// kinds of entity and the applications given in filter. The filter
+// may be different for each call. It returns a NotSupported error if
+// the controller cannot filter deltas.
+func (watcher *AllWatcher) NextFiltered(filter params.AllWatcherNextArgs) ([]multiwatcher.Delta, error) {
+	if watcher.caller.BestFacadeVersion(watcher.objType) < filterVersions[watcher.objType] {
+		return nil, errors.NotSupportedf("filtering deltas")
+	}
+	return watcher.next(filter)
+}
+
+func (watcher *AllWatcher) next(args interface{}) ([]multiwatcher.Delta, error) {
 	var info params.AllWatcherNextResults
 	err := watcher.caller.APICall(
 		watcher.objType,
 		watcher.caller.BestFacadeVersion(watcher.objType),
 		*watcher.id,
 		"Next",
-		nil, &info,
+		args, &info,
 	)
 	// We'll order the deltas so relation changes come last.
 	// This allows the callers like the GUI to process changes
@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+type allWatcherSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&allWatcherSuite{})
+
+func (s *allWatcherSuite) TestNextFiltered(c *gc.C) {
+	filter := params.AllWatcherNextArgs{
+		Kinds:        []string{"unit"},
+		Applications: []string{"wordpress"},
+	}
+	unit := multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{Name: "wordpress/0", Application: "wordpress"}}
+	called := false
+	caller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "AllWatcher")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "w1")
+			c.Check(request, gc.Equals, "Next")
+			c.Check(args, jc.DeepEquals, filter)
+			*(response.(*params.AllWatcherNextResults)) = params.AllWatcherNextResults{
+				Deltas: []multiwatcher.Delta{unit},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	id := "w1"
+	deltas, err := api.NewAllWatcher(caller, &id).NextFiltered(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(deltas, jc.DeepEquals, []multiwatcher.Delta{unit})
+}
+
+func (s *allWatcherSuite) TestNextFilteredNotSupported(c *gc.C) {
+	caller := basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 2,
+	}
+	id := "w1"
+	_, err := api.NewAllModelWatcher(caller, &id).NextFiltered(params.AllWatcherNextArgs{})
+	c.Assert(err, gc.ErrorMatches, "filtering deltas not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -41,6 +41,27 @@ func (c *Client) Set(annotations map[string]map[string]string) ([]params.ErrorRe
 	return results.Results, nil
 }
 
+// Query returns, in a single call, the annotations whose keys start
+// with keyPrefix for either the given entities or all the entities of
+// the given kinds, such as "application". Only one of tags and kinds
+// may be given; when querying by kind, only entities with matching
+// annotations are returned.
+func (c *Client) Query(tags, kinds []string, keyPrefix string) ([]params.AnnotationsGetResult, error) {
+	if err := base.RequireVersion(c, 3, "querying annotations"); err != nil {
+		return nil, err
+	}
+	args := params.AnnotationsQuery{
+		Entities:  entitiesFromTags(tags).Entities,
+		Kinds:     kinds,
+		KeyPrefix: keyPrefix,
+	}
+	var annotations params.AnnotationsGetResults
+	if err := c.facade.FacadeCall("Query", args, &annotations); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return annotations.Results, nil
+}
+
 func entitiesFromTags(tags []string) params.Entities {
 	entities := []params.Entity{}
 	for _, tag := range tags {
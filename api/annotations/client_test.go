@@ -4,10 +4,12 @@
 package annotations_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/annotations"
+	"github.com/juju/juju/api/base"
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver/params"
 	coretesting "github.com/juju/juju/testing"
@@ -91,3 +93,57 @@ func (s *annotationsMockSuite) TestGetEntitiesAnnotations(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+type versionedAPICaller struct {
+	base.APICallCloser
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(facade string) int {
+	return c.version
+}
+
+func (s *annotationsMockSuite) TestQuery(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(
+			objType string,
+			version int,
+			id, request string,
+			a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Annotations")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, params.AnnotationsQuery{
+				Entities:  []params.Entity{},
+				Kinds:     []string{"application"},
+				KeyPrefix: "gui-",
+			})
+			result := response.(*params.AnnotationsGetResults)
+			result.Results = []params.AnnotationsGetResult{{
+				EntityTag:   "application-mysql",
+				Annotations: map[string]string{"gui-x": "10"},
+			}}
+			return nil
+		})
+	annotationsClient := annotations.NewClient(versionedAPICaller{apiCaller, 3})
+	found, err := annotationsClient.Query(nil, []string{"application"}, "gui-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(found, jc.DeepEquals, []params.AnnotationsGetResult{{
+		EntityTag:   "application-mysql",
+		Annotations: map[string]string{"gui-x": "10"},
+	}})
+}
+
+func (s *annotationsMockSuite) TestQueryNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatal("unexpected API call")
+			return nil
+		})
+	annotationsClient := annotations.NewClient(versionedAPICaller{apiCaller, 2})
+	_, err := annotationsClient.Query(nil, []string{"application"}, "gui-")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
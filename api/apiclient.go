@@ -516,6 +516,12 @@ func dialAPI(info *Info, opts DialOpts) (*websocket.Conn, *tls.Config, error) {
 	}
 	conn, err := dialWebsocketMulti(info.Addrs, path, tlsConfig, opts)
 	if err != nil {
+		if opts.Diagnostics != nil {
+			err = &DialError{
+				Err:      err,
+				Attempts: opts.Diagnostics.Attempts(),
+			}
+		}
 		return nil, nil, errors.Trace(err)
 	}
 	logger.Infof("connection established to %q", conn.RemoteAddr())
@@ -563,6 +569,10 @@ func startDialWebsocket(try *parallel.Try, addr, path string, opts DialOpts, tls
 		return errors.Trace(err)
 	}
 	cfg.TlsConfig = tlsConfig
+	// The codec used for API connections accepts compressed
+	// responses, so let the server send them.
+	cfg.Header = http.Header{}
+	cfg.Header.Set(params.AcceptCompressionHeader, params.CompressionGzip)
 	return try.Start(newWebsocketDialer(cfg, opts))
 }
 
@@ -588,7 +598,11 @@ func newWebsocketDialer(cfg *websocket.Config, opts DialOpts) func(<-chan struct
 			default:
 			}
 			logger.Debugf("dialing %q", cfg.Location)
+			start := time.Now()
 			conn, err := opts.DialWebsocket(cfg)
+			if opts.Diagnostics != nil {
+				opts.Diagnostics.record(cfg.Location.Host, start, err)
+			}
 			if err == nil {
 				logger.Debugf("successfully dialed %q", cfg.Location)
 				return conn, nil
@@ -4,6 +4,7 @@
 package api_test
 
 import (
+	"crypto/x509"
 	"net"
 	"sync/atomic"
 	"time"
@@ -14,6 +15,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/parallel"
+	"github.com/juju/utils/set"
 	"golang.org/x/net/websocket"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -285,6 +287,63 @@ func (s *apiclientSuite) TestOpenWithNoCACert(c *gc.C) {
 	}
 }
 
+func (s *apiclientSuite) TestOpenWithDiagnostics(c *gc.C) {
+	fakeDialer := func(cfg *websocket.Config) (*websocket.Conn, error) {
+		if cfg.Location.Host == "foo.invalid:1234" {
+			return nil, &websocket.DialError{Config: cfg, Err: &net.OpError{
+				Op:  "dial",
+				Net: "tcp",
+				Err: &net.DNSError{Err: "no such host", Name: "foo.invalid"},
+			}}
+		}
+		return nil, &websocket.DialError{Config: cfg, Err: x509.UnknownAuthorityError{}}
+	}
+	diagnostics := &api.DialDiagnostics{}
+	_, err := api.Open(&api.Info{
+		Addrs:     []string{"foo.invalid:1234", "0.1.2.3:1234"},
+		SkipLogin: true,
+		CACert:    jtesting.CACert,
+	}, api.DialOpts{
+		DialWebsocket: fakeDialer,
+		Diagnostics:   diagnostics,
+	})
+	c.Assert(err, gc.ErrorMatches, `(?s)unable to connect to API: .*
+connection attempts:
+  .*: .* failure after .*
+  .*: .* failure after .*`)
+
+	dialErr, ok := errors.Cause(err).(*api.DialError)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(dialErr.Attempts, jc.DeepEquals, diagnostics.Attempts())
+	failures := make(map[string]string)
+	for _, attempt := range dialErr.Attempts {
+		failures[attempt.Address] = attempt.Failure
+	}
+	c.Assert(failures, jc.DeepEquals, map[string]string{
+		"foo.invalid:1234": api.DialFailureDNS,
+		"0.1.2.3:1234":     api.DialFailureTLS,
+	})
+}
+
+func (s *apiclientSuite) TestOpenWithDiagnosticsRecordsSuccess(c *gc.C) {
+	info := s.APIInfo(c)
+	diagnostics := &api.DialDiagnostics{}
+	conn, err := api.Open(info, api.DialOpts{Diagnostics: diagnostics})
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	addrs := set.NewStrings(info.Addrs...)
+	var connected int
+	for _, attempt := range diagnostics.Attempts() {
+		c.Check(addrs.Contains(attempt.Address), jc.IsTrue)
+		if attempt.Err == nil {
+			c.Check(attempt.Failure, gc.Equals, "")
+			connected++
+		}
+	}
+	c.Assert(connected, gc.Equals, 1)
+}
+
 func (s *apiclientSuite) TestOpenWithRedirect(c *gc.C) {
 	redirectToHosts := []string{"0.1.2.3:1234", "0.1.2.4:1235"}
 	redirectToCACert := "fake CA cert"
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -176,10 +177,20 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// AutoRollback, if set, asks the controller to revert the upgrade
+	// if too many units fail it. This field is only understood by
+	// Application facade version 6 and greater.
+	AutoRollback *params.CharmRollbackPolicy
 }
 
 // SetCharm sets the charm for a given service.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if cfg.AutoRollback != nil {
+		if err := base.RequireVersion(c.facade, 6, "automatic charm rollback"); err != nil {
+			return err
+		}
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -209,6 +220,7 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
+		AutoRollback:       cfg.AutoRollback,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
@@ -232,6 +244,28 @@ func (c *Client) AddUnits(application string, numUnits int, placement []*instanc
 	return results.Units, err
 }
 
+// Scale adds or removes units of an application so that it has the
+// given number of units. New units are placed using the given placement
+// directives, and units to remove are chosen using the given removal
+// policy; the names of the added and removed units are returned.
+// Controllers older than version 7 of the facade cannot scale
+// applications, so an error satisfying errors.IsNotSupported is
+// returned for them instead.
+func (c *Client) Scale(application string, scale int, placement []*instance.Placement, removalPolicy string) (params.ScaleApplicationResult, error) {
+	var result params.ScaleApplicationResult
+	if err := base.RequireVersion(c.facade, 7, "scaling applications"); err != nil {
+		return result, err
+	}
+	args := params.ScaleApplication{
+		ApplicationName: application,
+		Scale:           scale,
+		Placement:       placement,
+		RemovalPolicy:   removalPolicy,
+	}
+	err := c.facade.FacadeCall("Scale", args, &result)
+	return result, err
+}
+
 // DestroyUnits decreases the number of units dedicated to an application.
 func (c *Client) DestroyUnits(unitNames ...string) error {
 	params := params.DestroyApplicationUnits{unitNames}
@@ -269,6 +303,22 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeToCIDRs changes the juju-managed firewall to expose any ports
+// that were also explicitly marked by units as open, only allowing
+// access from the given CIDRs. Controllers older than version 4 of
+// the facade would expose the ports to everyone, so an error
+// satisfying errors.IsNotSupported is returned for them instead.
+func (c *Client) ExposeToCIDRs(application string, cidrs []string) error {
+	if err := base.RequireVersion(c.facade, 4, "exposing to CIDRs"); err != nil {
+		return err
+	}
+	params := params.ApplicationExpose{
+		ApplicationName: application,
+		ToCIDRs:         cidrs,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -276,6 +326,35 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// PinLeadership prevents the leadership lease of the named
+// application from expiring until UnpinLeadership is called.
+func (c *Client) PinLeadership(application string) error {
+	return c.setLeadershipPinned("PinLeadership", application)
+}
+
+// UnpinLeadership allows the leadership lease of the named
+// application to expire again.
+func (c *Client) UnpinLeadership(application string) error {
+	return c.setLeadershipPinned("UnpinLeadership", application)
+}
+
+func (c *Client) setLeadershipPinned(request, application string) error {
+	if err := base.RequireVersion(c, 4, request); err != nil {
+		return err
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(request, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
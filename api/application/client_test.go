@@ -4,6 +4,9 @@
 package application_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -76,6 +79,108 @@ func (s *applicationSuite) TestSetServiceMetricCredentialsNoMocks(c *gc.C) {
 	c.Assert(application.MetricCredentials(), gc.DeepEquals, []byte("creds"))
 }
 
+func (s *applicationSuite) TestExposeToCIDRs(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "application",
+			ToCIDRs:         []string{"10.0.0.0/8"},
+		})
+		return nil
+	})
+	err := s.client.ExposeToCIDRs("application", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeToCIDRsNotSupported(c *gc.C) {
+	application.PatchBestAPIVersion(s, s.client, 3)
+	application.PatchFacadeCall(s, s.client, func(string, interface{}, interface{}) error {
+		c.Fatal("unexpected API call")
+		return nil
+	})
+	err := s.client.ExposeToCIDRs("application", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "exposing to CIDRs not supported")
+}
+
+func (s *applicationSuite) TestScale(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Scale")
+		c.Assert(a, jc.DeepEquals, params.ScaleApplication{
+			ApplicationName: "application",
+			Scale:           3,
+			Placement:       []*instance.Placement{{Scope: "lxd", Directive: "1"}},
+			RemovalPolicy:   params.ScaleRemoveEmptiestMachineFirst,
+		})
+		result := response.(*params.ScaleApplicationResult)
+		result.Added = []string{"application/4"}
+		return nil
+	})
+	result, err := s.client.Scale(
+		"application", 3,
+		[]*instance.Placement{{Scope: "lxd", Directive: "1"}},
+		params.ScaleRemoveEmptiestMachineFirst,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, params.ScaleApplicationResult{
+		Added: []string{"application/4"},
+	})
+}
+
+func (s *applicationSuite) TestScaleNotSupported(c *gc.C) {
+	application.PatchBestAPIVersion(s, s.client, 6)
+	application.PatchFacadeCall(s, s.client, func(string, interface{}, interface{}) error {
+		c.Fatal("unexpected API call")
+		return nil
+	})
+	_, err := s.client.Scale("application", 3, nil, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "scaling applications not supported")
+}
+
+func (s *applicationSuite) TestPinLeadership(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "PinLeadership")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		return nil
+	})
+	err := s.client.PinLeadership("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestPinLeadershipInvalidName(c *gc.C) {
+	err := s.client.PinLeadership("mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
+
+func (s *applicationSuite) TestPinLeadershipNoMocks(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.client.PinLeadership(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.LeadershipPinned(), jc.IsTrue)
+
+	err = s.client.UnpinLeadership(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.LeadershipPinned(), jc.IsFalse)
+}
+
 func (s *applicationSuite) TestSetServiceDeploy(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -188,6 +293,45 @@ func (s *applicationSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestServiceSetCharmAutoRollback(c *gc.C) {
+	policy := &params.CharmRollbackPolicy{Threshold: 25, Window: time.Hour}
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharm")
+		args, ok := a.(params.ApplicationSetCharm)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.AutoRollback, jc.DeepEquals, policy)
+		return nil
+	})
+	err := s.client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		AutoRollback: policy,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestServiceSetCharmAutoRollbackNotSupported(c *gc.C) {
+	application.PatchBestAPIVersion(s, s.client, 5)
+	application.PatchFacadeCall(s, s.client, func(string, interface{}, interface{}) error {
+		c.Fatal("unexpected API call")
+		return nil
+	})
+	err := s.client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		AutoRollback: &params.CharmRollbackPolicy{Threshold: 25, Window: time.Hour},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "automatic charm rollback not supported")
+}
+
 func (s *applicationSuite) TestConsume(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base
+
+import (
+	"github.com/juju/errors"
+)
+
+// VersionedFacade is implemented by FacadeCaller and ClientFacade, and
+// by any client facade that embeds one of them.
+type VersionedFacade interface {
+	// BestAPIVersion returns the API version that we were able to
+	// determine is supported by both the client and the API Server
+	BestAPIVersion() int
+}
+
+// RequireVersion returns a NotSupported error describing the given
+// operation if the facade's best version is older than minVersion.
+// It is intended to be called at the start of client methods that
+// wrap facade methods added in later versions:
+//
+//	if err := base.RequireVersion(c, 3, "querying annotations"); err != nil {
+//		return nil, err
+//	}
+func RequireVersion(facade VersionedFacade, minVersion int, operation string) error {
+	if facade.BestAPIVersion() < minVersion {
+		return errors.NotSupportedf("%s", operation)
+	}
+	return nil
+}
+
+// CallWithFallback calls call if the facade's best version is at
+// least minVersion, and fallback otherwise, returning the error from
+// whichever was called. If fallback is nil, a NotSupported error
+// describing the operation is returned instead; use RequireVersion
+// directly when there is never a fallback.
+func CallWithFallback(facade VersionedFacade, minVersion int, operation string, call, fallback func() error) error {
+	if facade.BestAPIVersion() >= minVersion {
+		return call()
+	}
+	if fallback == nil {
+		return errors.NotSupportedf("%s", operation)
+	}
+	return fallback()
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+)
+
+type VersionsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&VersionsSuite{})
+
+type fakeFacade int
+
+func (f fakeFacade) BestAPIVersion() int {
+	return int(f)
+}
+
+func (s *VersionsSuite) TestRequireVersion(c *gc.C) {
+	c.Assert(base.RequireVersion(fakeFacade(3), 3, "frobbing"), jc.ErrorIsNil)
+	c.Assert(base.RequireVersion(fakeFacade(4), 3, "frobbing"), jc.ErrorIsNil)
+
+	err := base.RequireVersion(fakeFacade(2), 3, "frobbing")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "frobbing not supported")
+}
+
+func (s *VersionsSuite) TestCallWithFallback(c *gc.C) {
+	var called []string
+	call := func() error {
+		called = append(called, "call")
+		return nil
+	}
+	fallback := func() error {
+		called = append(called, "fallback")
+		return errors.New("boom")
+	}
+
+	err := base.CallWithFallback(fakeFacade(3), 3, "frobbing", call, fallback)
+	c.Assert(err, jc.ErrorIsNil)
+	err = base.CallWithFallback(fakeFacade(2), 3, "frobbing", call, fallback)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.DeepEquals, []string{"call", "fallback"})
+}
+
+func (s *VersionsSuite) TestCallWithFallbackNoFallback(c *gc.C) {
+	err := base.CallWithFallback(fakeFacade(2), 3, "frobbing", func() error {
+		c.Fatalf("unexpected call")
+		return nil
+	}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "frobbing not supported")
+}
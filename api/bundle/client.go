@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundle provides access to the bundle API facade.
+package bundle
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the bundle API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the bundle API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Bundle")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ExportBundle returns, as YAML, a bundle describing the applications,
+// machines and relations of the current model.
+func (c *Client) ExportBundle() (string, error) {
+	if err := base.RequireVersion(c, 2, "exporting bundles"); err != nil {
+		return "", err
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// DiffBundle returns the differences between the given bundle YAML and
+// the current model.
+func (c *Client) DiffBundle(bundleYAML string) (*params.BundleDiff, error) {
+	if err := base.RequireVersion(c, 3, "comparing bundles"); err != nil {
+		return nil, err
+	}
+	args := params.BundleDiffParams{BundleDataYAML: bundleYAML}
+	var result params.BundleDiffResults
+	if err := c.facade.FacadeCall("DiffBundle", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Errors) > 0 {
+		return nil, errors.Errorf("invalid bundle: %s", strings.Join(result.Errors, "; "))
+	}
+	return result.Diff, nil
+}
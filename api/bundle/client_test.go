@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bundleMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&bundleMockSuite{})
+
+func (s *bundleMockSuite) TestExportBundle(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Bundle")
+				c.Check(version, gc.Equals, 2)
+				c.Check(request, gc.Equals, "ExportBundle")
+				c.Check(a, gc.IsNil)
+				result := response.(*params.StringResult)
+				result.Result = "applications: {}\n"
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := bundle.NewClient(apiCaller)
+	out, err := client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(out, gc.Equals, "applications: {}\n")
+}
+
+func (s *bundleMockSuite) TestExportBundleNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatal("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 1,
+	}
+	client := bundle.NewClient(apiCaller)
+	_, err := client.ExportBundle()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *bundleMockSuite) TestDiffBundle(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Check(objType, gc.Equals, "Bundle")
+				c.Check(version, gc.Equals, 3)
+				c.Check(request, gc.Equals, "DiffBundle")
+				c.Check(a, jc.DeepEquals, params.BundleDiffParams{BundleDataYAML: "applications: {}"})
+				result := response.(*params.BundleDiffResults)
+				result.Diff = &params.BundleDiff{
+					Applications: map[string]*params.ApplicationDiff{
+						"mysql": {Missing: params.BundleDiffMissingBundle},
+					},
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := bundle.NewClient(apiCaller)
+	diff, err := client.DiffBundle("applications: {}")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diff, jc.DeepEquals, &params.BundleDiff{
+		Applications: map[string]*params.ApplicationDiff{
+			"mysql": {Missing: params.BundleDiffMissingBundle},
+		},
+	})
+}
+
+func (s *bundleMockSuite) TestDiffBundleVerificationErrors(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				result := response.(*params.BundleDiffResults)
+				result.Errors = []string{"bad", "worse"}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := bundle.NewClient(apiCaller)
+	_, err := client.DiffBundle("applications: {}")
+	c.Assert(err, gc.ErrorMatches, "invalid bundle: bad; worse")
+}
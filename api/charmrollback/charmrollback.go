@@ -0,0 +1,39 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrollback provides the client for the CharmRollback
+// facade.
+package charmrollback
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "CharmRollback"
+
+// Facade allows calls to "CharmRollback" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a new CharmRollback facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{base.NewFacadeCaller(caller, apiName)}
+}
+
+// CheckRollbacks reverts any charm upgrades that too many units have
+// failed, and returns the names of the applications whose upgrades
+// were reverted.
+func (f *Facade) CheckRollbacks() ([]string, error) {
+	var result params.StringsResult
+	if err := f.facade.FacadeCall("CheckRollbacks", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
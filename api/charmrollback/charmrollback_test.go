@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollback_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/charmrollback"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CharmRollbackSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&CharmRollbackSuite{})
+
+func (s *CharmRollbackSuite) TestCheckRollbacks(c *gc.C) {
+	var called bool
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "CharmRollback")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "CheckRollbacks")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.StringsResult{})
+		*(result.(*params.StringsResult)) = params.StringsResult{
+			Result: []string{"mysql"},
+		}
+		return nil
+	})
+	rolledBack, err := charmrollback.NewFacade(apiCaller).CheckRollbacks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(rolledBack, jc.DeepEquals, []string{"mysql"})
+}
+
+func (s *CharmRollbackSuite) TestCheckRollbacksError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := charmrollback.NewFacade(apiCaller).CheckRollbacks()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cleanups provides access to the Cleanups facade, used to
+// inspect a model's pending state cleanups and retry those that have
+// been given up on.
+package cleanups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Cleanups facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Client based on an existing API connection.
+func NewClient(callCloser base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(callCloser, "Cleanups")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// List returns the model's pending cleanups, with the details of any
+// failed attempts to run them.
+func (c *Client) List() ([]params.CleanupInfo, error) {
+	var result params.CleanupsResult
+	if err := c.facade.FacadeCall("ListCleanups", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Cleanups, nil
+}
+
+// Retry returns the model's dead-lettered cleanups to the queue, and
+// reports how many there were.
+func (c *Client) Retry() (int, error) {
+	var result params.RetryCleanupsResult
+	if err := c.facade.FacadeCall("RetryCleanups", nil, &result); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return result.Retried, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cleanups_test
+
+import (
+	"errors"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/cleanups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ClientSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestList(c *gc.C) {
+	expected := []params.CleanupInfo{{
+		Id:         "abc",
+		Kind:       "machine",
+		Prefix:     "2",
+		Attempts:   10,
+		LastError:  "boom",
+		DeadLetter: true,
+	}}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Cleanups")
+		c.Check(request, gc.Equals, "ListCleanups")
+		c.Check(arg, gc.IsNil)
+		*result.(*params.CleanupsResult) = params.CleanupsResult{Cleanups: expected}
+		return nil
+	})
+	cleanups, err := cleanups.NewClient(apiCaller).List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups, jc.DeepEquals, expected)
+}
+
+func (s *ClientSuite) TestRetry(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Cleanups")
+		c.Check(request, gc.Equals, "RetryCleanups")
+		*result.(*params.RetryCleanupsResult) = params.RetryCleanupsResult{Retried: 2}
+		return nil
+	})
+	retried, err := cleanups.NewClient(apiCaller).Retry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retried, gc.Equals, 2)
+}
+
+func (s *ClientSuite) TestError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := cleanups.NewClient(apiCaller).List()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	_, err = cleanups.NewClient(apiCaller).Retry()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
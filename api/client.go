@@ -12,6 +12,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -48,6 +49,18 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// StatusFields returns the status of the juju model, limited to the
+// given parts of params.FullStatus (see params.StatusParams). Older
+// controllers ignore the fields and return the full status.
+func (c *Client) StatusFields(patterns, fields []string) (*params.FullStatus, error) {
+	var result params.FullStatus
+	p := params.StatusParams{Patterns: patterns, Fields: fields}
+	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // StatusHistory retrieves the last <size> results of
 // <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
 // for <name> unit
@@ -97,6 +110,30 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 	return history, nil
 }
 
+// ModelStatusHistory returns the recorded status history of the machines
+// and units in the model, oldest entry first. The history may be
+// restricted to a time range, to the given machines and units and the
+// units of the given applications, and to the given number of most
+// recent entries; zero values impose no restriction.
+func (c *Client) ModelStatusHistory(from, to *time.Time, entities []names.Tag, size int) ([]params.EntityStatusHistoryEntry, error) {
+	if err := base.RequireVersion(c.facade, 3, "ModelStatusHistory"); err != nil {
+		return nil, err
+	}
+	args := params.ModelStatusHistoryRequest{
+		From: from,
+		To:   to,
+		Size: size,
+	}
+	for _, tag := range entities {
+		args.Tags = append(args.Tags, tag.String())
+	}
+	var result params.ModelStatusHistoryResult
+	if err := c.facade.FacadeCall("ModelStatusHistory", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
+
 // Resolved clears errors on a unit.
 func (c *Client) Resolved(unit string, retry bool) error {
 	p := params.Resolved{
@@ -106,6 +143,42 @@ func (c *Client) Resolved(unit string, retry bool) error {
 	return c.facade.FacadeCall("Resolved", p, nil)
 }
 
+// ResolveOperation marks the operation pending in the unit's agent to
+// be skipped, if skip is true, or failed otherwise. A running hook is
+// interrupted. The unit need not be in an error state.
+func (c *Client) ResolveOperation(unit string, skip bool) error {
+	if err := base.RequireVersion(c.facade, 4, "ResolveOperation"); err != nil {
+		return err
+	}
+	p := params.ResolveOperation{
+		UnitName: unit,
+		Skip:     skip,
+	}
+	return c.facade.FacadeCall("ResolveOperation", p, nil)
+}
+
+// ResolveUnitErrors marks the errors of the units of the given
+// applications, or of every application in the model if all is true,
+// as resolved. If noRetry is true, the failed hooks are skipped rather
+// than run again. Units that are not in an error state are left alone.
+// The results hold an entry for each unit that was resolved or could
+// not be, and for each application whose units could not be read.
+func (c *Client) ResolveUnitErrors(applications []string, all, noRetry bool) ([]params.ResolveUnitErrorsResult, error) {
+	if err := base.RequireVersion(c.facade, 5, "ResolveUnitErrors"); err != nil {
+		return nil, err
+	}
+	args := params.ResolveUnitErrors{
+		Applications: applications,
+		All:          all,
+		NoRetry:      noRetry,
+	}
+	var results params.ResolveUnitErrorsResults
+	if err := c.facade.FacadeCall("ResolveUnitErrors", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
 // RetryProvisioning updates the provisioning status of a machine allowing the
 // provisioner to retry.
 func (c *Client) RetryProvisioning(machines ...names.MachineTag) ([]params.ErrorResult, error) {
@@ -222,6 +295,20 @@ func (c *Client) WatchAll() (*AllWatcher, error) {
 	return NewAllWatcher(c.st, &info.AllWatcherId), nil
 }
 
+// WatchFullStatus returns a FullStatusWatcher that reports the status
+// of the juju model, filtered by the given patterns, whenever it changes.
+func (c *Client) WatchFullStatus(patterns []string) (*FullStatusWatcher, error) {
+	if err := base.RequireVersion(c.facade, 2, "WatchFullStatus"); err != nil {
+		return nil, err
+	}
+	var info params.FullStatusWatcherId
+	p := params.StatusParams{Patterns: patterns}
+	if err := c.facade.FacadeCall("WatchFullStatus", p, &info); err != nil {
+		return nil, err
+	}
+	return NewFullStatusWatcher(c.st, &info.FullStatusWatcherId), nil
+}
+
 // Close closes the Client's underlying State connection
 // Client is unique among the api.State facades in closing its own State
 // connection, but it is conventional to use a Client object without any access
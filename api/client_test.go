@@ -33,6 +33,7 @@ import (
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
@@ -353,6 +354,34 @@ func (s *clientSuite) TestClientModelUsers(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestStatusFields(c *gc.C) {
+	s.Factory.MakeUnit(c, nil)
+	fullStatus, err := s.APIState.Client().StatusFields(nil, []string{"applications"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fullStatus.Model.Name, gc.Equals, "controller")
+	c.Assert(fullStatus.Applications, gc.HasLen, 1)
+	c.Assert(fullStatus.Machines, gc.HasLen, 0)
+	c.Assert(fullStatus.Relations, gc.HasLen, 0)
+
+	_, err = s.APIState.Client().StatusFields(nil, []string{"units"})
+	c.Assert(err, gc.ErrorMatches, `status fields \["units"\] not valid`)
+}
+
+func (s *clientSuite) TestModelStatusHistory(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	since := time.Now().Add(time.Hour)
+	err := machine.SetStatus(status.StatusInfo{Status: status.Started, Message: "up", Since: &since})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.APIState.Client().ModelStatusHistory(&since, nil, []names.Tag{machine.Tag()}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Tag, gc.Equals, machine.Tag().String())
+	c.Assert(entries[0].Status.Status, gc.Equals, "started")
+	c.Assert(entries[0].Status.Info, gc.Equals, "up")
+	c.Assert(entries[0].Status.Kind, gc.Equals, "juju-machine")
+}
+
 func (s *clientSuite) TestWatchDebugLogConnected(c *gc.C) {
 	client := s.APIState.Client()
 	// Use the no tail option so we don't try to start a tailing cursor
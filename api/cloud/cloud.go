@@ -80,9 +80,86 @@ func cloudFromParams(p params.Cloud) jujucloud.Cloud {
 		IdentityEndpoint: p.IdentityEndpoint,
 		StorageEndpoint:  p.StorageEndpoint,
 		Regions:          regions,
+		CACertificates:   p.CACertificates,
 	}
 }
 
+func cloudToParams(cloud jujucloud.Cloud) params.Cloud {
+	authTypes := make([]string, len(cloud.AuthTypes))
+	for i, authType := range cloud.AuthTypes {
+		authTypes[i] = string(authType)
+	}
+	regions := make([]params.CloudRegion, len(cloud.Regions))
+	for i, region := range cloud.Regions {
+		regions[i] = params.CloudRegion{
+			Name:             region.Name,
+			Endpoint:         region.Endpoint,
+			IdentityEndpoint: region.IdentityEndpoint,
+			StorageEndpoint:  region.StorageEndpoint,
+		}
+	}
+	return params.Cloud{
+		Type:             cloud.Type,
+		AuthTypes:        authTypes,
+		Endpoint:         cloud.Endpoint,
+		IdentityEndpoint: cloud.IdentityEndpoint,
+		StorageEndpoint:  cloud.StorageEndpoint,
+		Regions:          regions,
+		CACertificates:   cloud.CACertificates,
+	}
+}
+
+// AddCloud adds a new cloud definition to the controller.
+func (c *Client) AddCloud(name string, cloud jujucloud.Cloud) error {
+	if err := base.RequireVersion(c.facade, 2, "adding clouds"); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.AddCloudArgs{
+		Name:  name,
+		Cloud: cloudToParams(cloud),
+	}
+	if err := c.facade.FacadeCall("AddCloud", args, nil); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UpdateCloud replaces the definition of an existing cloud, e.g. to
+// change its endpoints, regions or CA certificates.
+func (c *Client) UpdateCloud(name string, cloud jujucloud.Cloud) error {
+	if err := base.RequireVersion(c.facade, 2, "updating clouds"); err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	args := params.UpdateCloudArgs{
+		Clouds: []params.AddCloudArgs{{
+			Name:  name,
+			Cloud: cloudToParams(cloud),
+		}},
+	}
+	if err := c.facade.FacadeCall("UpdateCloud", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveCloud removes the cloud with the given name from the controller.
+func (c *Client) RemoveCloud(name string) error {
+	if err := base.RequireVersion(c.facade, 2, "removing clouds"); err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{
+			Tag: names.NewCloudTag(name).String(),
+		}},
+	}
+	if err := c.facade.FacadeCall("RemoveCloud", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // DefaultCloud returns the tag of the cloud that models will be
 // created in by default.
 func (c *Client) DefaultCloud() (names.CloudTag, error) {
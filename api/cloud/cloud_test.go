@@ -4,6 +4,7 @@
 package cloud_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -228,6 +229,141 @@ func (s *cloudSuite) TestRevokeCredential(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *cloudSuite) TestAddCloud(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Cloud")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AddCloud")
+			c.Assert(a, jc.DeepEquals, params.AddCloudArgs{
+				Name: "foo",
+				Cloud: params.Cloud{
+					Type:           "maas",
+					AuthTypes:      []string{"oauth1"},
+					Endpoint:       "http://maas.example.com",
+					Regions:        []params.CloudRegion{},
+					CACertificates: []string{"cert"},
+				},
+			})
+			called = true
+			return nil
+		},
+	}
+
+	client := cloudapi.NewClient(apiCaller)
+	err := client.AddCloud("foo", cloud.Cloud{
+		Type:           "maas",
+		AuthTypes:      []cloud.AuthType{cloud.OAuth1AuthType},
+		Endpoint:       "http://maas.example.com",
+		CACertificates: []string{"cert"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestUpdateCloud(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Cloud")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpdateCloud")
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			c.Assert(a, jc.DeepEquals, params.UpdateCloudArgs{
+				Clouds: []params.AddCloudArgs{{
+					Name: "foo",
+					Cloud: params.Cloud{
+						Type:      "dummy",
+						AuthTypes: []string{"empty"},
+						Regions:   []params.CloudRegion{{Name: "nether", Endpoint: "endpoint"}},
+					},
+				}},
+			})
+			*result.(*params.ErrorResults) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "cannot remove region"},
+				}},
+			}
+			called = true
+			return nil
+		},
+	}
+
+	client := cloudapi.NewClient(apiCaller)
+	err := client.UpdateCloud("foo", cloud.Cloud{
+		Type:      "dummy",
+		AuthTypes: []cloud.AuthType{cloud.EmptyAuthType},
+		Regions:   []cloud.Region{{Name: "nether", Endpoint: "endpoint"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot remove region")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestRemoveCloud(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Cloud")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RemoveCloud")
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			c.Assert(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{{
+				Tag: "cloud-foo",
+			}}})
+			*result.(*params.ErrorResults) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			called = true
+			return nil
+		},
+	}
+
+	client := cloudapi.NewClient(apiCaller)
+	err := client.RemoveCloud("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestManageCloudsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 1,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatal("unexpected API call")
+			return nil
+		},
+	}
+	client := cloudapi.NewClient(apiCaller)
+
+	err := client.AddCloud("foo", cloud.Cloud{Type: "dummy"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(err, gc.ErrorMatches, "adding clouds not supported")
+	err = client.UpdateCloud("foo", cloud.Cloud{Type: "dummy"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(err, gc.ErrorMatches, "updating clouds not supported")
+	err = client.RemoveCloud("foo")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(err, gc.ErrorMatches, "removing clouds not supported")
+}
+
 func (s *cloudSuite) TestCredentials(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
@@ -5,8 +5,10 @@ package common
 
 import (
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/watcher"
 )
 
 // ControllerConfigAPI provides common client-side API functions
@@ -30,3 +32,29 @@ func (e *ControllerConfigAPI) ControllerConfig() (controller.Config, error) {
 	}
 	return controller.Config(result.Config), nil
 }
+
+// ControllerConfigWatcher provides common client-side API functions
+// to call into apiserver.common.ControllerConfigWatcher.
+type ControllerConfigWatcher struct {
+	facade base.FacadeCaller
+}
+
+// NewControllerConfigWatcher creates a ControllerConfigWatcher on the
+// specified facade, and uses this name when calling through the caller.
+func NewControllerConfigWatcher(facade base.FacadeCaller) *ControllerConfigWatcher {
+	return &ControllerConfigWatcher{facade}
+}
+
+// WatchControllerConfig returns a NotifyWatcher waiting for the
+// controller configuration to change.
+func (e *ControllerConfigWatcher) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := e.facade.FacadeCall("WatchControllerConfig", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(e.facade.RawAPICaller(), result), nil
+}
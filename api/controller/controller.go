@@ -66,6 +66,17 @@ func (c *Client) AllModels() ([]base.UserModel, error) {
 	return result, nil
 }
 
+// ConfigSet changes the value of the specified controller
+// configuration attributes. Only attributes that may be changed after
+// bootstrap are accepted.
+func (c *Client) ConfigSet(values map[string]interface{}) error {
+	if err := base.RequireVersion(c.facade, 4, "setting controller config"); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ControllerConfigSet{Config: values}
+	return c.facade.FacadeCall("ConfigSet", args, nil)
+}
+
 // ModelConfig returns all model settings for the
 // controller model.
 func (c *Client) ModelConfig() (map[string]interface{}, error) {
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -229,3 +230,41 @@ func makeSpec() controller.MigrationSpec {
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
+
+func (s *Suite) TestConfigSet(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(version, gc.Equals, 4)
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ConfigSet(map[string]interface{}{
+		"auditing-enabled": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ConfigSet", []interface{}{params.ControllerConfigSet{
+			Config: map[string]interface{}{"auditing-enabled": true},
+		}}},
+	})
+}
+
+func (s *Suite) TestConfigSetNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatal("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ConfigSet(map[string]interface{}{
+		"auditing-enabled": true,
+	})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "setting controller config not supported")
+}
@@ -31,6 +31,14 @@ type ApplicationOffersAPI interface {
 	// satisfying the specified filter. The directory is the same as the service
 	// URL scheme and is used to determine which backend to query.
 	ListOffers(directory string, filters ...crossmodel.ApplicationOfferFilter) ([]crossmodel.ApplicationOffer, error)
+
+	// GrantAccess allows the specified users to consume the
+	// application offer at the specified URL.
+	GrantAccess(url string, users ...string) error
+
+	// RevokeAccess prevents the specified users from consuming the
+	// application offer at the specified URL.
+	RevokeAccess(url string, users ...string) error
 }
 
 // NewApplicationOffers creates a new client for accessing a controller application directory API.
@@ -70,6 +78,42 @@ func (s *applicationOffersAPI) AddOffer(offer crossmodel.ApplicationOffer, users
 	return results.Results[0].Error
 }
 
+// GrantAccess allows the specified users to consume the application
+// offer at the specified URL.
+func (s *applicationOffersAPI) GrantAccess(url string, users ...string) error {
+	return s.modifyAccess(url, params.GrantOfferAccess, users)
+}
+
+// RevokeAccess prevents the specified users from consuming the
+// application offer at the specified URL.
+func (s *applicationOffersAPI) RevokeAccess(url string, users ...string) error {
+	return s.modifyAccess(url, params.RevokeOfferAccess, users)
+}
+
+func (s *applicationOffersAPI) modifyAccess(url string, action params.OfferAction, users []string) error {
+	if s.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("ModifyOfferAccess")
+	}
+	args := params.ModifyOfferAccessRequest{
+		Changes: make([]params.ModifyOfferAccess, len(users)),
+	}
+	for i, user := range users {
+		if !names.IsValidUser(user) {
+			return errors.NotValidf("user name %q", user)
+		}
+		args.Changes[i] = params.ModifyOfferAccess{
+			UserTag:        names.NewUserTag(user).String(),
+			Action:         action,
+			ApplicationURL: url,
+		}
+	}
+	var results params.ErrorResults
+	if err := s.facade.FacadeCall("ModifyOfferAccess", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
 // MakeParamsFromOffer creates api parameters from a ApplicationOffer.
 func MakeParamsFromOffer(offer crossmodel.ApplicationOffer) params.ApplicationOffer {
 	eps := make([]params.RemoteEndpoint, len(offer.Endpoints))
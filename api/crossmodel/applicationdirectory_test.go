@@ -9,6 +9,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/api/base"
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/crossmodel"
 	"github.com/juju/juju/apiserver/common"
@@ -300,3 +301,67 @@ func (s *serviceDirectorySuite) TestAddOfferFacadeCallError(c *gc.C) {
 	err := client.AddOffer(jujucrossmodel.ApplicationOffer{}, nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, "facade failure")
 }
+
+type versionedAPICaller struct {
+	base.APICallCloser
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(facade string) int {
+	return c.version
+}
+
+func modifyOfferAccessCaller(c *gc.C, expected []params.ModifyOfferAccess) base.APICallCloser {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ApplicationOffers")
+			c.Check(version, gc.Equals, 2)
+			c.Check(request, gc.Equals, "ModifyOfferAccess")
+			c.Check(a, jc.DeepEquals, params.ModifyOfferAccessRequest{Changes: expected})
+			if results, ok := result.(*params.ErrorResults); ok {
+				results.Results = make([]params.ErrorResult, len(expected))
+			}
+			return nil
+		})
+	return versionedAPICaller{apiCaller, 2}
+}
+
+func (s *serviceDirectorySuite) TestGrantAccess(c *gc.C) {
+	client := crossmodel.NewApplicationOffers(modifyOfferAccessCaller(c, []params.ModifyOfferAccess{{
+		UserTag:        "user-fred",
+		Action:         params.GrantOfferAccess,
+		ApplicationURL: "local:/u/user/servicename",
+	}, {
+		UserTag:        "user-everyone@external",
+		Action:         params.GrantOfferAccess,
+		ApplicationURL: "local:/u/user/servicename",
+	}}))
+	err := client.GrantAccess("local:/u/user/servicename", "fred", "everyone@external")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *serviceDirectorySuite) TestRevokeAccess(c *gc.C) {
+	client := crossmodel.NewApplicationOffers(modifyOfferAccessCaller(c, []params.ModifyOfferAccess{{
+		UserTag:        "user-fred",
+		Action:         params.RevokeOfferAccess,
+		ApplicationURL: "local:/u/user/servicename",
+	}}))
+	err := client.RevokeAccess("local:/u/user/servicename", "fred")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *serviceDirectorySuite) TestGrantAccessInvalidUser(c *gc.C) {
+	client := crossmodel.NewApplicationOffers(modifyOfferAccessCaller(c, nil))
+	err := client.GrantAccess("local:/u/user/servicename", "foo/23")
+	c.Assert(err, gc.ErrorMatches, `user name "foo/23" not valid`)
+}
+
+func (s *serviceDirectorySuite) TestGrantAccessNotSupported(c *gc.C) {
+	client := crossmodel.NewApplicationOffers(apiCallerWithError(c, "ApplicationOffers", "ModifyOfferAccess"))
+	err := client.GrantAccess("local:/u/user/servicename", "fred")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
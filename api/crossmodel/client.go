@@ -25,8 +25,17 @@ func NewClient(st base.APICallCloser) *Client {
 	return &Client{ClientFacade: frontend, facade: backend}
 }
 
-// Offer prepares application's endpoints for consumption.
-func (c *Client) Offer(modelUUID, application string, endpoints []string, url string, desc string) ([]params.ErrorResult, error) {
+// Offer prepares application's endpoints for consumption by the
+// specified users. If no users are specified, only the offering
+// user may consume the offer.
+func (c *Client) Offer(modelUUID, application string, endpoints []string, url string, users []string, desc string) ([]params.ErrorResult, error) {
+	userTags := make([]string, len(users))
+	for i, user := range users {
+		if !names.IsValidUser(user) {
+			return nil, errors.NotValidf("user name %q", user)
+		}
+		userTags[i] = names.NewUserTag(user).String()
+	}
 	offers := []params.ApplicationOfferParams{
 		{
 			ModelTag:               names.NewModelTag(modelUUID).String(),
@@ -34,6 +43,7 @@ func (c *Client) Offer(modelUUID, application string, endpoints []string, url st
 			ApplicationDescription: desc,
 			Endpoints:              endpoints,
 			ApplicationURL:         url,
+			AllowedUserTags:        userTags,
 		},
 	}
 	out := params.ErrorResults{}
@@ -53,6 +53,7 @@ func (s *crossmodelMockSuite) TestOffer(c *gc.C) {
 			c.Assert(offer.Endpoints, jc.SameContents, []string{endPointA, endPointB})
 			c.Assert(offer.ApplicationURL, gc.DeepEquals, url)
 			c.Assert(offer.ApplicationDescription, gc.DeepEquals, desc)
+			c.Assert(offer.AllowedUserTags, jc.DeepEquals, []string{"user-fred", "user-everyone@external"})
 
 			if results, ok := result.(*params.ErrorResults); ok {
 				all := make([]params.ErrorResult, len(args.Offers))
@@ -66,7 +67,7 @@ func (s *crossmodelMockSuite) TestOffer(c *gc.C) {
 		})
 
 	client := crossmodel.NewClient(apiCaller)
-	results, err := client.Offer("uuid", application, []string{endPointA, endPointB}, url, desc)
+	results, err := client.Offer("uuid", application, []string{endPointA, endPointB}, url, []string{"fred", "everyone@external"}, desc)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, 2)
 	c.Assert(results, jc.DeepEquals,
@@ -91,11 +92,26 @@ func (s *crossmodelMockSuite) TestOfferFacadeCallError(c *gc.C) {
 			return errors.New(msg)
 		})
 	client := crossmodel.NewClient(apiCaller)
-	results, err := client.Offer("", "", nil, "", "")
+	results, err := client.Offer("", "", nil, "", nil, "")
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(results, gc.IsNil)
 }
 
+func (s *crossmodelMockSuite) TestOfferInvalidUser(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		})
+	client := crossmodel.NewClient(apiCaller)
+	_, err := client.Offer("uuid", "shared", []string{"db"}, "url", []string{"foo/23"}, "")
+	c.Assert(err, gc.ErrorMatches, `user name "foo/23" not valid`)
+}
+
 func (s *crossmodelMockSuite) TestShow(c *gc.C) {
 	url := "local:/u/fred/db2"
 
@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/websocket"
+)
+
+// Kinds of dial failure recorded in DialAttempt.Failure.
+const (
+	// DialFailureDNS indicates that the host name of the
+	// address could not be resolved.
+	DialFailureDNS = "dns"
+
+	// DialFailureTLS indicates that the TLS handshake failed,
+	// for example because the server's certificate could not
+	// be verified.
+	DialFailureTLS = "tls"
+
+	// DialFailureConnect indicates any other failure to connect,
+	// such as a refused connection or a timeout.
+	DialFailureConnect = "connect"
+)
+
+// DialAttempt records a single attempt to connect to an API server
+// address.
+type DialAttempt struct {
+	// Address holds the host:port that was dialed.
+	Address string
+
+	// Start holds the time the attempt started.
+	Start time.Time
+
+	// Duration holds how long the attempt took.
+	Duration time.Duration
+
+	// Failure holds the kind of failure, one of the DialFailure
+	// constants, or the empty string if the attempt succeeded.
+	Failure string
+
+	// Err holds the error that the attempt failed with, if any.
+	Err error
+}
+
+// String returns a one-line description of the attempt.
+func (a DialAttempt) String() string {
+	duration := a.Duration - a.Duration%time.Millisecond
+	if a.Err == nil {
+		return fmt.Sprintf("%s: connected after %v", a.Address, duration)
+	}
+	return fmt.Sprintf("%s: %s failure after %v: %v", a.Address, a.Failure, duration, a.Err)
+}
+
+// DialDiagnostics records the attempts made to connect to API servers
+// when it is set in DialOpts. It is safe for concurrent use.
+type DialDiagnostics struct {
+	mu       sync.Mutex
+	attempts []DialAttempt
+}
+
+// Attempts returns the attempts recorded so far, in the order in
+// which they finished.
+func (d *DialDiagnostics) Attempts() []DialAttempt {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	attempts := make([]DialAttempt, len(d.attempts))
+	copy(attempts, d.attempts)
+	return attempts
+}
+
+func (d *DialDiagnostics) record(address string, start time.Time, err error) {
+	attempt := DialAttempt{
+		Address:  address,
+		Start:    start,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if err != nil {
+		attempt.Failure = dialFailure(err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.attempts = append(d.attempts, attempt)
+}
+
+// DialError is returned by Open when DialOpts.Diagnostics is set and
+// no API server could be reached. Its message describes every
+// connection attempt that was made.
+type DialError struct {
+	// Err holds the error from the last attempt.
+	Err error
+
+	// Attempts holds all the attempts that were made.
+	Attempts []DialAttempt
+}
+
+// Error implements error.
+func (e *DialError) Error() string {
+	var buf bytes.Buffer
+	buf.WriteString(e.Err.Error())
+	buf.WriteString("\nconnection attempts:")
+	for _, a := range e.Attempts {
+		fmt.Fprintf(&buf, "\n  %s", a)
+	}
+	return buf.String()
+}
+
+// dialFailure classifies an error returned when dialing an API
+// server's websocket.
+func dialFailure(err error) string {
+	cause := errors.Cause(err)
+	if wsErr, ok := cause.(*websocket.DialError); ok {
+		cause = wsErr.Err
+	}
+	if opErr, ok := cause.(*net.OpError); ok {
+		cause = opErr.Err
+	}
+	switch cause.(type) {
+	case *net.DNSError:
+		return DialFailureDNS
+	case tls.RecordHeaderError:
+		return DialFailureTLS
+	}
+	if isX509Error(err) || strings.HasPrefix(cause.Error(), "tls: ") {
+		return DialFailureTLS
+	}
+	return DialFailureConnect
+}
@@ -55,6 +55,20 @@ func (api *API) CreateSpaces(args params.CreateSpacesParams) (results params.Err
 	return result, nil
 }
 
+// RemoveSubnets removes the subnets with the given tags. It returns
+// an error satisfying errors.IsNotSupported if the controller is too
+// old to remove subnets.
+func (api *API) RemoveSubnets(args params.Entities) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := base.RequireVersion(api.facade, 3, "removing subnets"); err != nil {
+		return result, err
+	}
+	if err := api.facade.FacadeCall("RemoveSubnets", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
 func (api *API) ListSubnets(args params.SubnetsFilters) (params.ListSubnetsResults, error) {
 	var result params.ListSubnetsResults
 	if err := api.facade.FacadeCall("ListSubnets", args, &result); err != nil {
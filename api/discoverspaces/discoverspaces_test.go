@@ -6,6 +6,7 @@ package discoverspaces_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -95,6 +96,52 @@ func (s *DiscoverSpacesSuite) TestAddSubnets(c *gc.C) {
 	c.Assert(called, gc.Equals, 1)
 }
 
+func (s *DiscoverSpacesSuite) TestRemoveSubnets(c *gc.C) {
+	var called int
+	expectedResult := params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	}
+	expectedArgs := params.Entities{
+		Entities: []params.Entity{{Tag: "subnet-10.0.0.0/24"}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			called++
+			c.Check(objType, gc.Equals, "DiscoverSpaces")
+			c.Check(version, gc.Equals, 3)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RemoveSubnets")
+			c.Check(args, jc.DeepEquals, expectedArgs)
+			*(result.(*params.ErrorResults)) = expectedResult
+			return nil
+		},
+	}
+	api := discoverspaces.NewAPI(apiCaller)
+
+	result, err := api.RemoveSubnets(expectedArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expectedResult)
+	c.Assert(called, gc.Equals, 1)
+}
+
+func (s *DiscoverSpacesSuite) TestRemoveSubnetsNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatal("unexpected API call")
+			return nil
+		},
+	}
+	api := discoverspaces.NewAPI(apiCaller)
+
+	_, err := api.RemoveSubnets(params.Entities{
+		Entities: []params.Entity{{Tag: "subnet-10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "removing subnets not supported")
+}
+
 func (s *DiscoverSpacesSuite) TestCreateSpaces(c *gc.C) {
 	var called int
 	expectedResult := params.ErrorResults{
@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package enginereport implements the client-side API facade used to
+// send dependency engine reports to the controller, and to read them
+// back.
+package enginereport
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the EngineReport API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side EngineReport facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "EngineReport"),
+	}
+}
+
+// SetReport sends the dependency engine report of the agent with
+// the given tag to the controller.
+func (f *Facade) SetReport(tag names.Tag, report map[string]interface{}) error {
+	args := params.AgentEngineReports{Reports: []params.AgentEngineReport{{
+		Tag:    tag.String(),
+		Report: report,
+	}}}
+	var result params.ErrorResults
+	err := f.caller.FacadeCall("SetReports", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// Report holds the most recent dependency engine report sent by an
+// agent.
+type Report struct {
+	Report  map[string]interface{}
+	Updated time.Time
+}
+
+// Report returns the most recent dependency engine report sent by the
+// agent with the given tag.
+func (f *Facade) Report(tag names.Tag) (Report, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.AgentEngineReportResults
+	err := f.caller.FacadeCall("Reports", args, &results)
+	if err != nil {
+		return Report{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return Report{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return Report{}, errors.Trace(result.Error)
+	}
+	return Report{
+		Report:  result.Report,
+		Updated: result.Updated,
+	}, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginereport_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/enginereport"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestSetReport(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "EngineReport")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	facade := enginereport.NewFacade(apiCaller)
+
+	report := map[string]interface{}{"state": "started"}
+	err := facade.SetReport(names.NewMachineTag("42"), report)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"SetReports", []interface{}{params.AgentEngineReports{
+			Reports: []params.AgentEngineReport{{
+				Tag:    "machine-42",
+				Report: report,
+			}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestSetReportCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := enginereport.NewFacade(apiCaller)
+
+	err := facade.SetReport(names.NewMachineTag("42"), nil)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestSetReportInnerError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := enginereport.NewFacade(apiCaller)
+
+	err := facade.SetReport(names.NewMachineTag("42"), nil)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestReport(c *gc.C) {
+	updated := time.Date(2016, 11, 1, 12, 0, 0, 0, time.UTC)
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "EngineReport")
+		stub.AddCall(request, args)
+		*response.(*params.AgentEngineReportResults) = params.AgentEngineReportResults{
+			Results: []params.AgentEngineReportResult{{
+				Report:  map[string]interface{}{"state": "started"},
+				Updated: updated,
+			}},
+		}
+		return nil
+	})
+	facade := enginereport.NewFacade(apiCaller)
+
+	report, err := facade.Report(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report, jc.DeepEquals, enginereport.Report{
+		Report:  map[string]interface{}{"state": "started"},
+		Updated: updated,
+	})
+	stub.CheckCalls(c, []testing.StubCall{{
+		"Reports", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestReportNotFound(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.AgentEngineReportResults) = params.AgentEngineReportResults{
+			Results: []params.AgentEngineReportResult{{
+				Error: &params.Error{
+					Code:    params.CodeNotFound,
+					Message: "engine report for unit mysql/0 not found",
+				},
+			}},
+		}
+		return nil
+	})
+	facade := enginereport.NewFacade(apiCaller)
+
+	_, err := facade.Report(names.NewUnitTag("mysql/0"))
+	c.Assert(err, gc.ErrorMatches, "engine report for unit mysql/0 not found")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
@@ -11,44 +11,48 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
-	"Agent":                        2,
+	"Action":                       3,
+	"Agent":                        3,
 	"AgentTools":                   1,
-	"AllModelWatcher":              2,
-	"AllWatcher":                   1,
-	"Annotations":                  2,
-	"Application":                  3,
+	"AllModelWatcher":              3,
+	"AllWatcher":                   2,
+	"Annotations":                  3,
+	"Application":                  7,
 	"ApplicationScaler":            1,
-	"ApplicationOffers":            1,
+	"ApplicationOffers":            2,
 	"Backups":                      1,
 	"Block":                        2,
-	"Bundle":                       1,
+	"Bundle":                       3,
 	"CharmRevisionUpdater":         2,
+	"CharmRollback":                1,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
-	"Cloud":                        1,
-	"Controller":                   3,
+	"Cleanups":                     1,
+	"Client":                       5,
+	"Cloud":                        2,
+	"Controller":                   4,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
-	"DiscoverSpaces":               2,
+	"DiscoverSpaces":               3,
 	"DiskManager":                  2,
+	"EngineReport":                 1,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   3,
-	"HighAvailability":             2,
+	"Firewaller":                   4,
+	"FullStatusWatcher":            1,
+	"HighAvailability":             3,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
-	"ImageMetadata":                2,
+	"ImageMetadata":                3,
 	"InstancePoller":               3,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
-	"LeadershipService":            2,
+	"LeadershipService":            3,
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               2,
+	"MachineManager":               6,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -61,7 +65,7 @@ var facadeVersions = map[string]int{
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
 	"ModelConfig":                  1,
-	"ModelManager":                 2,
+	"ModelManager":                 5,
 	"NotifyWatcher":                1,
 	"OfferedApplications":          1,
 	"Payloads":                     1,
@@ -69,7 +73,7 @@ var facadeVersions = map[string]int{
 	"Pinger":                       1,
 	"Provisioner":                  3,
 	"ProxyUpdater":                 1,
-	"Reboot":                       2,
+	"Reboot":                       3,
 	"RelationUnitsWatcher":         1,
 	"RemoteApplicationWatcher":     1,
 	"RemoteRelations":              1,
@@ -79,17 +83,18 @@ var facadeVersions = map[string]int{
 	"RetryStrategy":                1,
 	"Singular":                     1,
 	"Spaces":                       2,
-	"SSHClient":                    2,
+	"SSHClient":                    3,
+	"StagedConfig":                 1,
 	"StatusHistory":                2,
-	"Storage":                      3,
-	"StorageProvisioner":           3,
+	"Storage":                      6,
+	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
-	"Undertaker":                   1,
+	"Undertaker":                   2,
 	"UnitAssigner":                 1,
-	"Uniter":                       4,
+	"Uniter":                       12,
 	"Upgrader":                     1,
-	"UserManager":                  1,
+	"UserManager":                  3,
 	"VolumeAttachmentsWatcher":     2,
 }
 
@@ -8,6 +8,7 @@ import (
 
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/watcher"
@@ -75,3 +76,32 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposedCIDRs returns the CIDRs from which the explicitly open ports
+// of the exposed service may be accessed. No CIDRs means the ports
+// may be accessed from anywhere, which is always the case for
+// controllers that predate GetExposedCIDRs.
+func (s *Application) ExposedCIDRs() ([]string, error) {
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	call := func() error {
+		return s.st.facade.FacadeCall("GetExposedCIDRs", args, &results)
+	}
+	noCIDRs := func() error {
+		results.Results = []params.StringsResult{{}}
+		return nil
+	}
+	if err := base.CallWithFallback(s.st.facade, 4, "getting exposed CIDRs", call, noCIDRs); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
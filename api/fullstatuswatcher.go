@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// FullStatusWatcher holds information allowing us to get the status
+// of a model each time it changes. The server only sends the parts
+// of the status that have changed; the watcher applies them to its
+// copy of the status.
+type FullStatusWatcher struct {
+	caller base.APICaller
+	id     *string
+	status params.FullStatus
+}
+
+// NewFullStatusWatcher returns a FullStatusWatcher instance which
+// interacts with a watcher created by the WatchFullStatus API call.
+//
+// There should be no need to call this from outside of the api
+// package. It is only used by Client.WatchFullStatus in this package.
+func NewFullStatusWatcher(caller base.APICaller, id *string) *FullStatusWatcher {
+	return &FullStatusWatcher{
+		caller: caller,
+		id:     id,
+	}
+}
+
+// Next returns the status of the model. The first call returns
+// immediately; subsequent calls block until the status changes.
+func (watcher *FullStatusWatcher) Next() (*params.FullStatus, error) {
+	var delta params.FullStatusDelta
+	err := watcher.caller.APICall(
+		"FullStatusWatcher",
+		watcher.caller.BestFacadeVersion("FullStatusWatcher"),
+		*watcher.id,
+		"Next",
+		nil, &delta,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	applyFullStatusDelta(&watcher.status, delta)
+	status := copyFullStatus(watcher.status)
+	return &status, nil
+}
+
+// Stop shuts down a watcher previously created by the
+// WatchFullStatus API call.
+func (watcher *FullStatusWatcher) Stop() error {
+	return watcher.caller.APICall(
+		"FullStatusWatcher",
+		watcher.caller.BestFacadeVersion("FullStatusWatcher"),
+		*watcher.id,
+		"Stop",
+		nil, nil,
+	)
+}
+
+// applyFullStatusDelta updates status with the changes in delta.
+func applyFullStatusDelta(status *params.FullStatus, delta params.FullStatusDelta) {
+	if delta.Model != nil {
+		status.Model = *delta.Model
+	}
+	for id, m := range delta.Machines {
+		if status.Machines == nil {
+			status.Machines = make(map[string]params.MachineStatus)
+		}
+		if m == nil {
+			delete(status.Machines, id)
+		} else {
+			status.Machines[id] = *m
+		}
+	}
+	for name, app := range delta.Applications {
+		if status.Applications == nil {
+			status.Applications = make(map[string]params.ApplicationStatus)
+		}
+		if app == nil {
+			delete(status.Applications, name)
+		} else {
+			status.Applications[name] = *app
+		}
+	}
+	for name, app := range delta.RemoteApplications {
+		if status.RemoteApplications == nil {
+			status.RemoteApplications = make(map[string]params.RemoteApplicationStatus)
+		}
+		if app == nil {
+			delete(status.RemoteApplications, name)
+		} else {
+			status.RemoteApplications[name] = *app
+		}
+	}
+	if delta.RelationsChanged {
+		status.Relations = delta.Relations
+	}
+}
+
+// copyFullStatus returns a copy of status whose maps may be
+// modified without affecting the original.
+func copyFullStatus(status params.FullStatus) params.FullStatus {
+	result := status
+	result.Machines = make(map[string]params.MachineStatus)
+	for id, m := range status.Machines {
+		result.Machines[id] = m
+	}
+	result.Applications = make(map[string]params.ApplicationStatus)
+	for name, app := range status.Applications {
+		result.Applications[name] = app
+	}
+	result.RemoteApplications = make(map[string]params.RemoteApplicationStatus)
+	for name, app := range status.RemoteApplications {
+		result.RemoteApplications[name] = app
+	}
+	return result
+}
@@ -83,3 +83,46 @@ func (c *Client) ResumeHAReplicationAfterUpgrade(members []replicaset.Member) er
 	}
 	return nil
 }
+
+// ReplicaSetStatus returns the status of the controller's mongo
+// replica set.
+func (c *Client) ReplicaSetStatus() (params.ReplicaSetStatus, error) {
+	if err := base.RequireVersion(c.facade, 3, "replica set status"); err != nil {
+		return params.ReplicaSetStatus{}, err
+	}
+	var result params.ReplicaSetStatus
+	if err := c.facade.FacadeCall("ReplicaSetStatus", nil, &result); err != nil {
+		return params.ReplicaSetStatus{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// StepDownPrimary asks the primary of the controller's mongo replica
+// set to step down, so that another member is elected.
+func (c *Client) StepDownPrimary() error {
+	if err := base.RequireVersion(c.facade, 3, "stepping down the replica set primary"); err != nil {
+		return err
+	}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("StepDownPrimary", nil, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// RemoveReplicaSetMember removes the member with the given id from the
+// controller's mongo replica set.
+func (c *Client) RemoveReplicaSetMember(id int) error {
+	if err := base.RequireVersion(c.facade, 3, "removing replica set members"); err != nil {
+		return err
+	}
+	var results params.ErrorResults
+	args := params.ReplicaSetMemberIds{Ids: []int{id}}
+	if err := c.facade.FacadeCall("RemoveReplicaSetMembers", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -9,7 +9,9 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -79,3 +81,42 @@ func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
 	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
 }
+
+func (s *clientSuite) TestRemoveReplicaSetMember(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Check(objType, gc.Equals, "HighAvailability")
+				c.Check(request, gc.Equals, "RemoveReplicaSetMembers")
+				c.Check(arg, jc.DeepEquals, params.ReplicaSetMemberIds{Ids: []int{2}})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := highavailability.NewClient(apiCaller)
+	err := client.RemoveReplicaSetMember(2)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestReplicaSetNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := highavailability.NewClient(apiCaller)
+	_, err := client.ReplicaSetStatus()
+	c.Assert(err, gc.ErrorMatches, "replica set status not supported")
+	err = client.StepDownPrimary()
+	c.Assert(err, gc.ErrorMatches, "stepping down the replica set primary not supported")
+	err = client.RemoveReplicaSetMember(1)
+	c.Assert(err, gc.ErrorMatches, "removing replica set members not supported")
+}
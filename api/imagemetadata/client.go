@@ -4,6 +4,8 @@
 package imagemetadata
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/base"
@@ -91,3 +93,17 @@ func (c *Client) Delete(imageId string) error {
 	}
 	return nil
 }
+
+// Expire removes the cached image metadata older than maxAge, other
+// than custom image metadata, and returns how many were removed.
+func (c *Client) Expire(maxAge time.Duration) (int, error) {
+	if err := base.RequireVersion(c.facade, 3, "Expire"); err != nil {
+		return 0, errors.Trace(err)
+	}
+	in := params.ExpireImageMetadataParams{MaxAge: maxAge}
+	var out params.ExpireImageMetadataResult
+	if err := c.facade.FacadeCall("Expire", in, &out); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return out.Expired, nil
+}
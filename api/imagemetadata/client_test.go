@@ -5,6 +5,7 @@ package imagemetadata_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -355,3 +356,45 @@ func (s *imagemetadataSuite) TestDeleteFacadeCallError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, msg)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *imagemetadataSuite) TestExpire(c *gc.C) {
+	called := false
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Check(objType, gc.Equals, "ImageMetadata")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "Expire")
+				c.Assert(a, jc.DeepEquals, params.ExpireImageMetadataParams{MaxAge: time.Hour})
+				*(result.(*params.ExpireImageMetadataResult)) = params.ExpireImageMetadataResult{Expired: 2}
+				return nil
+			}),
+		BestVersion: 3,
+	}
+
+	client := imagemetadata.NewClient(apiCaller)
+	expired, err := client.Expire(time.Hour)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(expired, gc.Equals, 2)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *imagemetadataSuite) TestExpireNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			}),
+		BestVersion: 2,
+	}
+
+	client := imagemetadata.NewClient(apiCaller)
+	_, err := client.Expire(time.Hour)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
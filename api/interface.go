@@ -150,6 +150,11 @@ type DialOpts struct {
 	//
 	// This field is provided for testing purposes only.
 	DialWebsocket func(cfg *websocket.Config) (*websocket.Conn, error)
+
+	// Diagnostics, if not nil, records every attempt made to
+	// connect to an API server address. If no connection can be
+	// made, Open returns a *DialError describing the attempts.
+	Diagnostics *DialDiagnostics
 }
 
 // DefaultDialOpts returns a DialOpts representing the default
@@ -60,6 +60,36 @@ func (c *client) BlockUntilLeadershipReleased(serviceId string) error {
 	return nil
 }
 
+// LeaseSettings holds the leadership lease settings configured for a
+// model.
+type LeaseSettings struct {
+
+	// LeaseDuration is the duration for which units should claim
+	// leadership.
+	LeaseDuration time.Duration
+
+	// RenewalInterval is the time between a leader's lease renewals.
+	RenewalInterval time.Duration
+}
+
+// GetLeaseSettings returns the leadership lease settings configured for
+// the model. An error satisfying errors.IsNotSupported is returned if
+// the controller is too old to report them.
+func GetLeaseSettings(caller base.APICaller) (LeaseSettings, error) {
+	facadeCaller := base.NewFacadeCaller(caller, "LeadershipService")
+	if facadeCaller.BestAPIVersion() < 3 {
+		return LeaseSettings{}, errors.NotSupportedf("leadership lease settings")
+	}
+	var result params.LeadershipLeaseSettings
+	if err := facadeCaller.FacadeCall("LeaseSettings", nil, &result); err != nil {
+		return LeaseSettings{}, errors.Annotate(err, "cannot get leadership lease settings")
+	}
+	return LeaseSettings{
+		LeaseDuration:   time.Duration(result.LeaseDurationSeconds * float64(time.Second)),
+		RenewalInterval: time.Duration(result.RenewalIntervalSeconds * float64(time.Second)),
+	}, nil
+}
+
 //
 // Prepare functions for building bulk-calls.
 //
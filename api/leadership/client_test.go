@@ -191,3 +191,52 @@ func (s *ClientSuite) TestBlockUntilLeadershipReleasedFacadeCallError(c *gc.C) {
 	c.Check(numStubCalls, gc.Equals, 1)
 	c.Check(err, gc.ErrorMatches, "error blocking on leadership release: "+errMsg)
 }
+
+type versionedAPICaller struct {
+	base.APICaller
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(facade string) int {
+	return c.version
+}
+
+func (s *ClientSuite) TestGetLeaseSettings(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "LeadershipService")
+		c.Check(version, gc.Equals, 3)
+		c.Check(request, gc.Equals, "LeaseSettings")
+		c.Check(arg, gc.IsNil)
+		*result.(*params.LeadershipLeaseSettings) = params.LeadershipLeaseSettings{
+			LeaseDurationSeconds:   20,
+			RenewalIntervalSeconds: 7.5,
+		}
+		return nil
+	})
+
+	settings, err := leadership.GetLeaseSettings(versionedAPICaller{apiCaller, 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings, jc.DeepEquals, leadership.LeaseSettings{
+		LeaseDuration:   20 * time.Second,
+		RenewalInterval: 7500 * time.Millisecond,
+	})
+}
+
+func (s *ClientSuite) TestGetLeaseSettingsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+
+	_, err := leadership.GetLeaseSettings(versionedAPICaller{apiCaller, 3})
+	c.Assert(err, gc.ErrorMatches, "cannot get leadership lease settings: boom")
+}
+
+func (s *ClientSuite) TestGetLeaseSettingsNotSupported(c *gc.C) {
+	apiCaller := s.apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	_, err := leadership.GetLeaseSettings(versionedAPICaller{apiCaller, 2})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -5,6 +5,7 @@ package machinemanager
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -36,3 +37,70 @@ func (client *Client) AddMachines(machineParams []params.AddMachineParams) ([]pa
 	}
 	return results.Machines, err
 }
+
+// ScheduleReboot requests that the given machines reboot during the
+// model's maintenance window.
+func (client *Client) ScheduleReboot(machines ...string) ([]params.ErrorResult, error) {
+	if err := base.RequireVersion(client, 4, "scheduling reboots"); err != nil {
+		return nil, err
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(machines)),
+	}
+	for i, id := range machines {
+		if !names.IsValidMachine(id) {
+			return nil, errors.NotValidf("machine ID %q", id)
+		}
+		args.Entities[i].Tag = names.NewMachineTag(id).String()
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("ScheduleReboot", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(machines) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(machines), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ListCloudInstances returns the instances that the cloud runs for the
+// model, with their tags, and the provisioned machines whose instances
+// the cloud does not report.
+func (client *Client) ListCloudInstances() (params.CloudInstancesResult, error) {
+	var result params.CloudInstancesResult
+	if err := base.RequireVersion(client, 5, "listing cloud instances"); err != nil {
+		return result, err
+	}
+	if err := client.facade.FacadeCall("ListCloudInstances", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// ForceDestroyMachines force-destroys the given machines, removing
+// their containers, units and storage attachments along with them, and
+// reports what was removed from each. If dryRun is true, it reports
+// what would be removed without removing anything.
+func (client *Client) ForceDestroyMachines(dryRun bool, machines ...string) ([]params.DestroyMachineResult, error) {
+	if err := base.RequireVersion(client, 6, "reporting what force-destroying machines removes"); err != nil {
+		return nil, err
+	}
+	args := params.ForceDestroyMachines{
+		Entities: make([]params.Entity, len(machines)),
+		DryRun:   dryRun,
+	}
+	for i, id := range machines {
+		if !names.IsValidMachine(id) {
+			return nil, errors.NotValidf("machine ID %q", id)
+		}
+		args.Entities[i].Tag = names.NewMachineTag(id).String()
+	}
+	var results params.DestroyMachineResults
+	if err := client.facade.FacadeCall("ForceDestroyMachines", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(machines) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(machines), len(results.Results))
+	}
+	return results.Results, nil
+}
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
@@ -116,3 +117,131 @@ func (s *MachinemanagerSuite) TestAddMachinesResultCountInvalid(c *gc.C) {
 		c.Check(err, gc.ErrorMatches, fmt.Sprintf("expected 1 result, got %d", n))
 	}
 }
+
+type versionedAPICaller struct {
+	base.APICallCloser
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
+func (s *MachinemanagerSuite) TestScheduleReboot(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(version, gc.Equals, 4)
+		c.Check(request, gc.Equals, "ScheduleReboot")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1-lxd-0"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	st := machinemanager.NewClient(versionedAPICaller{apiCaller, 4})
+	results, err := st.ScheduleReboot("0", "1/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{}, {Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestScheduleRebootNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	st := machinemanager.NewClient(versionedAPICaller{apiCaller, 3})
+	_, err := st.ScheduleReboot("0")
+	c.Assert(err, gc.ErrorMatches, "scheduling reboots not supported")
+}
+
+func (s *MachinemanagerSuite) TestScheduleRebootInvalidMachine(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	st := machinemanager.NewClient(versionedAPICaller{apiCaller, 4})
+	_, err := st.ScheduleReboot("mysql/0")
+	c.Assert(err, gc.ErrorMatches, `machine ID "mysql/0" not valid`)
+}
+
+func (s *MachinemanagerSuite) TestListCloudInstances(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(version, gc.Equals, 5)
+		c.Check(request, gc.Equals, "ListCloudInstances")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.CloudInstancesResult)) = params.CloudInstancesResult{
+			Instances: []params.CloudInstance{{
+				InstanceId: "i-0",
+				Status:     "running",
+				MachineId:  "0",
+			}},
+			MissingMachines: []string{"1"},
+		}
+		return nil
+	})
+	st := machinemanager.NewClient(versionedAPICaller{apiCaller, 5})
+	result, err := st.ListCloudInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudInstancesResult{
+		Instances: []params.CloudInstance{{
+			InstanceId: "i-0",
+			Status:     "running",
+			MachineId:  "0",
+		}},
+		MissingMachines: []string{"1"},
+	})
+}
+
+func (s *MachinemanagerSuite) TestListCloudInstancesNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	st := machinemanager.NewClient(versionedAPICaller{apiCaller, 4})
+	_, err := st.ListCloudInstances()
+	c.Assert(err, gc.ErrorMatches, "listing cloud instances not supported")
+}
+
+func (s *MachinemanagerSuite) TestForceDestroyMachines(c *gc.C) {
+	info := &params.DestroyMachineInfo{
+		DestroyedUnits: []params.Entity{{Tag: "unit-mysql-0"}},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(version, gc.Equals, 6)
+		c.Check(request, gc.Equals, "ForceDestroyMachines")
+		c.Check(arg, jc.DeepEquals, params.ForceDestroyMachines{
+			Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1-lxd-0"}},
+			DryRun:   true,
+		})
+		*(result.(*params.DestroyMachineResults)) = params.DestroyMachineResults{
+			Results: []params.DestroyMachineResult{
+				{Info: info},
+				{Error: &params.Error{Message: "boom"}},
+			},
+		}
+		return nil
+	})
+	st := machinemanager.NewClient(versionedAPICaller{apiCaller, 6})
+	results, err := st.ForceDestroyMachines(true, "0", "1/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.DestroyMachineResult{
+		{Info: info},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestForceDestroyMachinesNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	st := machinemanager.NewClient(versionedAPICaller{apiCaller, 5})
+	_, err := st.ForceDestroyMachines(false, "0")
+	c.Assert(err, gc.ErrorMatches, "reporting what force-destroying machines removes not supported")
+}
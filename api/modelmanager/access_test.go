@@ -114,6 +114,50 @@ func (s *accessSuite) adminUser(c *gc.C, action params.ModelAction) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *accessSuite) TestGrantModelOperatorUser(c *gc.C) {
+	s.operatorUser(c, params.GrantModelAccess)
+}
+
+func (s *accessSuite) TestRevokeModelOperatorUser(c *gc.C) {
+	s.operatorUser(c, params.RevokeModelAccess)
+}
+
+func (s *accessSuite) operatorUser(c *gc.C, action params.ModelAction) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			checkCall(c, objType, id, request)
+
+			req := assertRequest(c, a)
+			c.Assert(req.Changes, gc.HasLen, 1)
+			c.Assert(string(req.Changes[0].Action), gc.Equals, string(action))
+			c.Assert(string(req.Changes[0].Access), gc.Equals, string(params.ModelOperatorAccess))
+			c.Assert(req.Changes[0].ModelTag, gc.Equals, someModelTag)
+
+			resp := assertResponse(c, result)
+			*resp = params.ErrorResults{Results: []params.ErrorResult{{Error: nil}}}
+
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := accessCall(client, action, "bob", "operator", someModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *accessSuite) TestGrantModelOperatorNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantModel("bob", "operator", someModelUUID)
+	c.Assert(err, gc.ErrorMatches, "operator model access not supported")
+}
+
 func (s *accessSuite) TestGrantThreeModels(c *gc.C) {
 	s.threeModels(c, params.GrantModelAccess)
 }
@@ -48,6 +48,28 @@ func (c *Client) CreateModel(
 	name, owner, cloud, cloudRegion string,
 	cloudCredential names.CloudCredentialTag,
 	config map[string]interface{},
+) (base.ModelInfo, error) {
+	return c.createModel("", name, owner, cloud, cloudRegion, cloudCredential, config)
+}
+
+// CreateModelFromTemplate is like CreateModel, but also applies the
+// config and constraints of the named model template to the new
+// model. Values in config take precedence over those in the template.
+func (c *Client) CreateModelFromTemplate(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
+) (base.ModelInfo, error) {
+	if err := base.RequireVersion(c.facade, 5, "creating models from templates"); err != nil {
+		return base.ModelInfo{}, errors.Trace(err)
+	}
+	return c.createModel(template, name, owner, cloud, cloudRegion, cloudCredential, config)
+}
+
+func (c *Client) createModel(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
 ) (base.ModelInfo, error) {
 	var result base.ModelInfo
 	if !names.IsValidUser(owner) {
@@ -71,6 +93,7 @@ func (c *Client) CreateModel(
 		CloudTag:           cloudTag,
 		CloudRegion:        cloudRegion,
 		CloudCredentialTag: cloudCredentialTag,
+		Template:           template,
 	}
 	var modelInfo params.ModelInfo
 	err := c.facade.FacadeCall("CreateModel", createArgs, &modelInfo)
@@ -246,11 +269,36 @@ func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, erro
 // cause the model's resources to be cleaned up, after which the model will
 // be removed.
 func (c *Client) DestroyModel(tag names.ModelTag) error {
-	var results params.ErrorResults
-	entities := params.Entities{
-		Entities: []params.Entity{{Tag: tag.String()}},
+	return c.destroyModel(tag, nil)
+}
+
+// DestroyModelWithStorage is like DestroyModel, but states explicitly
+// whether the model's volumes are to be destroyed, or released: removed
+// from the model, but left in the cloud so that they may be attached
+// again later.
+func (c *Client) DestroyModelWithStorage(tag names.ModelTag, destroyStorage bool) error {
+	if err := base.RequireVersion(c.facade, 3, "choosing whether to destroy or release model storage"); err != nil {
+		return errors.Trace(err)
+	}
+	return c.destroyModel(tag, &destroyStorage)
+}
+
+func (c *Client) destroyModel(tag names.ModelTag, destroyStorage *bool) error {
+	var args interface{}
+	if c.BestAPIVersion() < 3 {
+		args = params.Entities{
+			Entities: []params.Entity{{Tag: tag.String()}},
+		}
+	} else {
+		args = params.DestroyModelsParams{
+			Models: []params.DestroyModelParams{{
+				ModelTag:       tag.String(),
+				DestroyStorage: destroyStorage,
+			}},
+		}
 	}
-	if err := c.facade.FacadeCall("DestroyModels", entities, &results); err != nil {
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("DestroyModels", args, &results); err != nil {
 		return errors.Trace(err)
 	}
 	if n := len(results.Results); n != 1 {
@@ -284,6 +332,11 @@ func (c *Client) modifyModelUser(action params.ModelAction, user, access string,
 	if err := permission.ValidateModelAccess(modelAccess); err != nil {
 		return errors.Trace(err)
 	}
+	if modelAccess == permission.OperatorAccess {
+		if err := base.RequireVersion(c.facade, 4, "operator model access"); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	for _, model := range modelUUIDs {
 		if !names.IsValidModel(model) {
 			return errors.Errorf("invalid model: %q", model)
@@ -380,3 +433,44 @@ func (c *Client) UnsetModelDefaults(cloud, region string, keys ...string) error
 	}
 	return result.OneError()
 }
+
+// ModelTemplates returns the controller's model templates.
+func (c *Client) ModelTemplates() ([]params.ModelTemplate, error) {
+	if err := base.RequireVersion(c.facade, 5, "model templates"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result params.ModelTemplates
+	if err := c.facade.FacadeCall("ModelTemplates", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Templates, nil
+}
+
+// SetModelTemplate creates the given model template, or replaces the
+// existing template with the same name.
+func (c *Client) SetModelTemplate(template params.ModelTemplate) error {
+	if err := base.RequireVersion(c.facade, 5, "model templates"); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ModelTemplates{
+		Templates: []params.ModelTemplate{template},
+	}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("SetModelTemplates", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// RemoveModelTemplate removes the named model template.
+func (c *Client) RemoveModelTemplate(name string) error {
+	if err := base.RequireVersion(c.facade, 5, "model templates"); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ModelTemplateNames{Names: []string{name}}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveModelTemplates", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
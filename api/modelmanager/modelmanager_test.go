@@ -102,8 +102,10 @@ func (s *modelmanagerSuite) TestDestroyModel(c *gc.C) {
 	modelmanager.PatchFacadeCall(&s.CleanupSuite, modelManager,
 		func(req string, args interface{}, resp interface{}) error {
 			c.Assert(req, gc.Equals, "DestroyModels")
-			c.Assert(args, jc.DeepEquals, params.Entities{
-				Entities: []params.Entity{{testing.ModelTag.String()}},
+			c.Assert(args, jc.DeepEquals, params.DestroyModelsParams{
+				Models: []params.DestroyModelParams{{
+					ModelTag: testing.ModelTag.String(),
+				}},
 			})
 			results := resp.(*params.ErrorResults)
 			*results = params.ErrorResults{
@@ -118,6 +120,67 @@ func (s *modelmanagerSuite) TestDestroyModel(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestDestroyModelV2(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, resp interface{}) error {
+			c.Check(request, gc.Equals, "DestroyModels")
+			c.Check(args, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{testing.ModelTag.String()}},
+			})
+			*(resp.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			called = true
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.DestroyModel(testing.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestDestroyModelWithStorage(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, resp interface{}) error {
+			c.Check(request, gc.Equals, "DestroyModels")
+			destroyStorage := false
+			c.Check(args, jc.DeepEquals, params.DestroyModelsParams{
+				Models: []params.DestroyModelParams{{
+					ModelTag:       testing.ModelTag.String(),
+					DestroyStorage: &destroyStorage,
+				}},
+			})
+			*(resp.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			called = true
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.DestroyModelWithStorage(testing.ModelTag, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestDestroyModelWithStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, resp interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.DestroyModelWithStorage(testing.ModelTag, true)
+	c.Assert(err, gc.ErrorMatches, "choosing whether to destroy or release model storage not supported")
+}
+
 func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
@@ -0,0 +1,158 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+type templatesSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&templatesSuite{})
+
+func (s *templatesSuite) TestCreateModelFromTemplate(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, resp interface{}) error {
+			c.Check(request, gc.Equals, "CreateModel")
+			c.Check(args, jc.DeepEquals, params.ModelCreateArgs{
+				Name:     "mymodel",
+				OwnerTag: "user-bob",
+				Config:   map[string]interface{}{"foo": "bar"},
+				Template: "prod",
+			})
+			*(resp.(*params.ModelInfo)) = params.ModelInfo{
+				Name:     "mymodel",
+				UUID:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				OwnerTag: "user-bob",
+				CloudTag: "cloud-dummy",
+			}
+			called = true
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	info, err := client.CreateModelFromTemplate(
+		"prod", "mymodel", "bob", "", "", names.CloudCredentialTag{},
+		map[string]interface{}{"foo": "bar"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(info.Name, gc.Equals, "mymodel")
+}
+
+func (s *templatesSuite) TestCreateModelFromTemplateNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, resp interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.CreateModelFromTemplate(
+		"prod", "mymodel", "bob", "", "", names.CloudCredentialTag{}, nil,
+	)
+	c.Assert(err, gc.ErrorMatches, "creating models from templates not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *templatesSuite) TestModelTemplates(c *gc.C) {
+	prod := params.ModelTemplate{
+		Name:        "prod",
+		Config:      map[string]interface{}{"default-series": "xenial"},
+		Constraints: constraints.MustParse("spaces=dmz"),
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, resp interface{}) error {
+			c.Check(request, gc.Equals, "ModelTemplates")
+			c.Check(args, gc.IsNil)
+			*(resp.(*params.ModelTemplates)) = params.ModelTemplates{
+				Templates: []params.ModelTemplate{prod},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	templates, err := client.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, jc.DeepEquals, []params.ModelTemplate{prod})
+}
+
+func (s *templatesSuite) TestSetModelTemplate(c *gc.C) {
+	prod := params.ModelTemplate{
+		Name:        "prod",
+		Constraints: constraints.MustParse("mem=4G"),
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, resp interface{}) error {
+			c.Check(request, gc.Equals, "SetModelTemplates")
+			c.Check(args, jc.DeepEquals, params.ModelTemplates{
+				Templates: []params.ModelTemplate{prod},
+			})
+			*(resp.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelTemplate(prod)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *templatesSuite) TestRemoveModelTemplate(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, resp interface{}) error {
+			c.Check(request, gc.Equals, "RemoveModelTemplates")
+			c.Check(args, jc.DeepEquals, params.ModelTemplateNames{
+				Names: []string{"prod"},
+			})
+			*(resp.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			called = true
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.RemoveModelTemplate("prod")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *templatesSuite) TestModelTemplatesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, resp interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ModelTemplates()
+	c.Assert(err, gc.ErrorMatches, "model templates not supported")
+	err = client.SetModelTemplate(params.ModelTemplate{Name: "prod"})
+	c.Assert(err, gc.ErrorMatches, "model templates not supported")
+	err = client.RemoveModelTemplate("prod")
+	c.Assert(err, gc.ErrorMatches, "model templates not supported")
+}
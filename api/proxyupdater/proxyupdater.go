@@ -72,21 +72,23 @@ func proxySettingsParamToProxySettings(cfg params.ProxyConfig) proxy.Settings {
 	}
 }
 
-// ProxyConfig returns the proxy settings for the current environment
-func (api *API) ProxyConfig() (proxySettings, APTProxySettings proxy.Settings, err error) {
+// ProxyConfig returns the proxy settings for the current environment.
+// Servers that predate snap proxy settings return empty ones.
+func (api *API) ProxyConfig() (proxySettings, APTProxySettings, snapProxySettings proxy.Settings, err error) {
 	var results params.ProxyConfigResults
 	args := params.Entities{
 		Entities: []params.Entity{{Tag: api.tag.String()}},
 	}
 	err = api.facade.FacadeCall("ProxyConfig", args, &results)
 	if err != nil {
-		return proxySettings, APTProxySettings, err
+		return proxySettings, APTProxySettings, snapProxySettings, err
 	}
 	if len(results.Results) != 1 {
-		return proxySettings, APTProxySettings, errors.NotFoundf("ProxyConfig for %q", api.tag)
+		return proxySettings, APTProxySettings, snapProxySettings, errors.NotFoundf("ProxyConfig for %q", api.tag)
 	}
 	result := results.Results[0]
 	proxySettings = proxySettingsParamToProxySettings(result.ProxySettings)
 	APTProxySettings = proxySettingsParamToProxySettings(result.APTProxySettings)
-	return proxySettings, APTProxySettings, nil
+	snapProxySettings = proxySettingsParamToProxySettings(result.SnapProxySettings)
+	return proxySettings, APTProxySettings, snapProxySettings, nil
 }
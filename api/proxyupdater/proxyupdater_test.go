@@ -95,6 +95,10 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 			FTP:     "ftp-apt",
 			NoProxy: "NoProxy-apt",
 		},
+		SnapProxySettings: params.ProxyConfig{
+			HTTP:  "http-snap",
+			HTTPS: "https-snap",
+		},
 	}
 	expected := params.ProxyConfigResults{
 		Results: []params.ProxyConfigResult{conf},
@@ -107,7 +111,7 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 	}}
 	called, api := newAPI(c, args)
 
-	proxySettings, APTProxySettings, err := api.ProxyConfig()
+	proxySettings, APTProxySettings, snapProxySettings, err := api.ProxyConfig()
 	c.Assert(*called, gc.Equals, 1)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(proxySettings, jc.DeepEquals, proxy.Settings{
@@ -122,4 +126,8 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 		Ftp:     "ftp-apt",
 		NoProxy: "NoProxy-apt",
 	})
+	c.Check(snapProxySettings, jc.DeepEquals, proxy.Settings{
+		Http:  "http-snap",
+		Https: "https-snap",
+	})
 }
@@ -30,6 +30,11 @@ type State interface {
 
 	// GetRebootAction returns the reboot action for the calling machine.
 	GetRebootAction() (params.RebootAction, error)
+
+	// GetRebootSchedule reports whether the reboot action for the
+	// calling machine has been scheduled, and if so the maintenance
+	// window, in the form "HH:MM-HH:MM" UTC, in which it should happen.
+	GetRebootSchedule() (scheduled bool, maintenanceWindow string, err error)
 }
 
 var _ State = (*state)(nil)
@@ -129,3 +134,30 @@ func (st *state) GetRebootAction() (params.RebootAction, error) {
 
 	return results.Results[0].Result, nil
 }
+
+// GetRebootSchedule implements State.GetRebootSchedule
+func (st *state) GetRebootSchedule() (bool, string, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		// Older controllers do not support scheduled reboots,
+		// so any reboot action should happen immediately.
+		return false, "", nil
+	}
+	var results params.RebootScheduleResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.machineTag.String()}},
+	}
+
+	err := st.facade.FacadeCall("GetRebootSchedule", args, &results)
+	if err != nil {
+		return false, "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return false, "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, "", errors.Trace(result.Error)
+	}
+	return result.Scheduled, result.MaintenanceWindow, nil
+}
@@ -179,3 +179,35 @@ func (s *machineRebootSuite) TestClearRebootError(c *gc.C) {
 	err := s.reboot.ClearReboot()
 	c.Assert(err.Error(), gc.Equals, "Some error.")
 }
+
+func (s *machineRebootSuite) TestGetRebootSchedule(c *gc.C) {
+	reboot.PatchFacadeCall(s, s.reboot, func(request string, p interface{}, resp interface{}) error {
+		c.Check(request, gc.Equals, "GetRebootSchedule")
+		c.Check(p, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: s.machine.Tag().String()}},
+		})
+		if resp, ok := resp.(*params.RebootScheduleResults); ok {
+			resp.Results = []params.RebootScheduleResult{
+				{Scheduled: true, MaintenanceWindow: "02:00-04:00"},
+			}
+		}
+		return nil
+	})
+	scheduled, window, err := s.reboot.GetRebootSchedule()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsTrue)
+	c.Assert(window, gc.Equals, "02:00-04:00")
+}
+
+func (s *machineRebootSuite) TestGetRebootScheduleError(c *gc.C) {
+	reboot.PatchFacadeCall(s, s.reboot, func(request string, p interface{}, resp interface{}) error {
+		if resp, ok := resp.(*params.RebootScheduleResults); ok {
+			resp.Results = []params.RebootScheduleResult{{
+				Error: &params.Error{Message: "Some error."},
+			}}
+		}
+		return nil
+	})
+	_, _, err := s.reboot.GetRebootSchedule()
+	c.Assert(err, gc.ErrorMatches, "Some error.")
+}
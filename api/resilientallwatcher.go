@@ -0,0 +1,231 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// DeltaWatcher is the interface of AllWatcher used by
+// ResilientAllWatcher.
+type DeltaWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// ResilientAllWatcherConfig holds the configuration of a
+// ResilientAllWatcher.
+type ResilientAllWatcherConfig struct {
+	// Watch starts an AllWatcher. It is called to start the first
+	// watcher, and again each time the connection is lost, so it
+	// should open a new API connection if the old one has gone.
+	Watch func() (DeltaWatcher, error)
+
+	// Clock is used to wait between attempts to start a watcher.
+	Clock clock.Clock
+
+	// RetryDelay is how long to wait after failing to start a
+	// watcher before trying again.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot drive a
+// ResilientAllWatcher.
+func (config ResilientAllWatcherConfig) Validate() error {
+	if config.Watch == nil {
+		return errors.NotValidf("nil Watch")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// ResilientAllWatcher behaves like an AllWatcher that survives the loss
+// of its API connection. When the connection is lost it starts a new
+// watcher, retrying until it succeeds, and its next deltas bring the
+// caller back in sync: they remove every entity that has gone while
+// the connection was down, followed by the current state of all the
+// others. This suits clients, such as the GUI, that keep a cache of
+// the model built from the deltas.
+type ResilientAllWatcher struct {
+	config ResilientAllWatcherConfig
+
+	mu      sync.Mutex
+	current DeltaWatcher
+	stopped bool
+	stop    chan struct{}
+
+	// known holds the latest information about every entity that
+	// has been reported and not removed.
+	known map[multiwatcher.EntityId]multiwatcher.EntityInfo
+
+	// resync is true when the next deltas from the current watcher
+	// are the complete state, to be reconciled with known.
+	resync bool
+}
+
+// NewResilientAllWatcher returns a ResilientAllWatcher that starts
+// watchers as configured.
+func NewResilientAllWatcher(config ResilientAllWatcherConfig) (*ResilientAllWatcher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ResilientAllWatcher{
+		config: config,
+		stop:   make(chan struct{}),
+		known:  make(map[multiwatcher.EntityId]multiwatcher.EntityInfo),
+	}, nil
+}
+
+// Next returns the next set of deltas, starting a new watcher if the
+// connection has been lost. It blocks until there are deltas to
+// return or the watcher is stopped.
+func (w *ResilientAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	for {
+		watcher, err := w.watcher()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		deltas, err := watcher.Next()
+		if err == nil {
+			return w.update(deltas), nil
+		}
+		if w.isStopped() || !isConnectionLost(err) {
+			return nil, errors.Trace(err)
+		}
+		logger.Infof("lost watcher connection, reconnecting: %v", err)
+		w.mu.Lock()
+		if w.current == watcher {
+			w.current = nil
+		}
+		w.mu.Unlock()
+	}
+}
+
+// Stop stops the current watcher. Any blocked call to Next returns
+// an error.
+func (w *ResilientAllWatcher) Stop() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return nil
+	}
+	w.stopped = true
+	close(w.stop)
+	if w.current == nil {
+		return nil
+	}
+	err := w.current.Stop()
+	if isConnectionLost(err) {
+		// There is nothing left to stop.
+		err = nil
+	}
+	return errors.Trace(err)
+}
+
+func (w *ResilientAllWatcher) isStopped() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.stopped
+}
+
+// watcher returns the current watcher, starting one if necessary.
+func (w *ResilientAllWatcher) watcher() (DeltaWatcher, error) {
+	for {
+		w.mu.Lock()
+		if w.stopped {
+			w.mu.Unlock()
+			return nil, errors.New("watcher was stopped")
+		}
+		if w.current != nil {
+			current := w.current
+			w.mu.Unlock()
+			return current, nil
+		}
+		w.mu.Unlock()
+
+		watcher, err := w.config.Watch()
+		if err == nil {
+			w.mu.Lock()
+			if w.stopped {
+				w.mu.Unlock()
+				watcher.Stop()
+				continue
+			}
+			w.current = watcher
+			// The first deltas from an AllWatcher are the
+			// complete state.
+			w.resync = len(w.known) > 0
+			w.mu.Unlock()
+			continue
+		}
+		if params.IsCodeUnauthorized(err) {
+			return nil, errors.Trace(err)
+		}
+		logger.Warningf("cannot start watcher, retrying in %v: %v", w.config.RetryDelay, err)
+		select {
+		case <-w.stop:
+		case <-w.config.Clock.After(w.config.RetryDelay):
+		}
+	}
+}
+
+// update records the given deltas in known, and returns them. If they
+// are the complete state of a new watcher, removals are added for the
+// entities that have gone since the connection was lost.
+func (w *ResilientAllWatcher) update(deltas []multiwatcher.Delta) []multiwatcher.Delta {
+	var result []multiwatcher.Delta
+	if w.resync {
+		w.resync = false
+		present := make(map[multiwatcher.EntityId]bool)
+		for _, delta := range deltas {
+			if !delta.Removed {
+				present[delta.Entity.EntityId()] = true
+			}
+		}
+		for id, info := range w.known {
+			if !present[id] {
+				result = append(result, multiwatcher.Delta{Removed: true, Entity: info})
+			}
+		}
+	}
+	for _, delta := range result {
+		delete(w.known, delta.Entity.EntityId())
+	}
+	for _, delta := range deltas {
+		id := delta.Entity.EntityId()
+		if delta.Removed {
+			delete(w.known, id)
+		} else {
+			w.known[id] = delta.Entity
+		}
+	}
+	if len(result) == 0 {
+		return deltas
+	}
+	// Keep relation changes last, as AllWatcher does.
+	result = append(result, deltas...)
+	sort.Sort(orderedDeltas(result))
+	return result
+}
+
+// isConnectionLost returns whether err, returned by a watcher, means
+// that the watcher's API connection or the controller has gone away.
+func isConnectionLost(err error) bool {
+	// The controller stops all its watchers when it shuts down.
+	return rpc.IsShutdownErr(err) || params.IsCodeStopped(err)
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ResilientAllWatcherSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	watchers []*fakeDeltaWatcher
+	watchErr []error
+	started  int
+}
+
+var _ = gc.Suite(&ResilientAllWatcherSuite{})
+
+func (s *ResilientAllWatcherSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.watchers = nil
+	s.watchErr = nil
+	s.started = 0
+}
+
+func (s *ResilientAllWatcherSuite) newWatcher(c *gc.C) *api.ResilientAllWatcher {
+	w, err := api.NewResilientAllWatcher(api.ResilientAllWatcherConfig{
+		Watch: func() (api.DeltaWatcher, error) {
+			if len(s.watchErr) > 0 {
+				err := s.watchErr[0]
+				s.watchErr = s.watchErr[1:]
+				return nil, err
+			}
+			c.Assert(s.started < len(s.watchers), jc.IsTrue)
+			s.started++
+			return s.watchers[s.started-1], nil
+		},
+		Clock:      s.clock,
+		RetryDelay: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func machineDelta(id, series string) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+		ModelUUID: "uuid",
+		Id:        id,
+		Series:    series,
+	}}
+}
+
+func (s *ResilientAllWatcherSuite) TestValidate(c *gc.C) {
+	_, err := api.NewResilientAllWatcher(api.ResilientAllWatcherConfig{
+		Clock:      s.clock,
+		RetryDelay: time.Second,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Watch not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ResilientAllWatcherSuite) TestReconnectRemovesMissingEntities(c *gc.C) {
+	s.watchers = []*fakeDeltaWatcher{{
+		results: []fakeNextResult{
+			{deltas: []multiwatcher.Delta{machineDelta("0", "xenial"), machineDelta("1", "xenial")}},
+			{err: rpc.ErrShutdown},
+		},
+	}, {
+		results: []fakeNextResult{
+			{deltas: []multiwatcher.Delta{machineDelta("0", "trusty")}},
+		},
+	}}
+	w := s.newWatcher(c)
+
+	deltas, err := w.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deltas, gc.HasLen, 2)
+
+	deltas, err = w.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.started, gc.Equals, 2)
+	c.Assert(deltas, jc.DeepEquals, []multiwatcher.Delta{
+		machineDelta("0", "trusty"),
+		{Removed: true, Entity: machineDelta("1", "xenial").Entity},
+	})
+
+	c.Assert(w.Stop(), jc.ErrorIsNil)
+	c.Assert(s.watchers[0].stopped, jc.IsFalse)
+	c.Assert(s.watchers[1].stopped, jc.IsTrue)
+}
+
+func (s *ResilientAllWatcherSuite) TestOtherErrorsReturned(c *gc.C) {
+	s.watchers = []*fakeDeltaWatcher{{
+		results: []fakeNextResult{{err: errors.New("boom")}},
+	}}
+	w := s.newWatcher(c)
+
+	_, err := w.Next()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(s.started, gc.Equals, 1)
+}
+
+func (s *ResilientAllWatcherSuite) TestRetriesWatch(c *gc.C) {
+	s.watchErr = []error{errors.New("no controller")}
+	s.watchers = []*fakeDeltaWatcher{{
+		results: []fakeNextResult{{deltas: []multiwatcher.Delta{machineDelta("0", "xenial")}}},
+	}}
+	w := s.newWatcher(c)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := w.Next()
+		done <- err
+	}()
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for retry")
+	}
+	s.clock.Advance(time.Second)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for deltas")
+	}
+	c.Assert(s.started, gc.Equals, 1)
+}
+
+func (s *ResilientAllWatcherSuite) TestUnauthorizedNotRetried(c *gc.C) {
+	s.watchErr = []error{&params.Error{Code: params.CodeUnauthorized, Message: "bad password"}}
+	w := s.newWatcher(c)
+
+	_, err := w.Next()
+	c.Assert(err, gc.ErrorMatches, "bad password")
+}
+
+type fakeNextResult struct {
+	deltas []multiwatcher.Delta
+	err    error
+}
+
+type fakeDeltaWatcher struct {
+	results []fakeNextResult
+	stopped bool
+}
+
+func (w *fakeDeltaWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.results) == 0 {
+		return nil, errors.New("no more results")
+	}
+	result := w.results[0]
+	w.results = w.results[1:]
+	return result.deltas, result.err
+}
+
+func (w *fakeDeltaWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
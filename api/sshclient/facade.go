@@ -111,6 +111,21 @@ func (facade *Facade) Proxy() (bool, error) {
 	return out.UseProxy, nil
 }
 
+// Bastion returns the host, in the form [user@]host[:port], through
+// which SSH connections to the associated model's machines should be
+// made, or the empty string if there is none.
+func (facade *Facade) Bastion() (string, error) {
+	if err := base.RequireVersion(facade, 3, "SSH bastions"); err != nil {
+		return "", errors.Trace(err)
+	}
+	var out params.SSHBastionResult
+	err := facade.caller.FacadeCall("Bastion", nil, &out)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return out.Bastion, nil
+}
+
 func targetToEntities(target string) (params.Entities, error) {
 	tag, err := targetToTag(target)
 	if err != nil {
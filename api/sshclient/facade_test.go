@@ -272,6 +272,38 @@ func checkProxy(c *gc.C, useProxy bool) {
 	stub.CheckCalls(c, []jujutesting.StubCall{{"SSHClient.Proxy", []interface{}{nil}}})
 }
 
+func (s *FacadeSuite) TestBastion(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.SSHBastionResult) = params.SSHBastionResult{
+				Bastion: "admin@jump.example.com",
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	bastion, err := facade.Bastion()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(bastion, gc.Equals, "admin@jump.example.com")
+	stub.CheckCalls(c, []jujutesting.StubCall{{"SSHClient.Bastion", []interface{}{nil}}})
+}
+
+func (s *FacadeSuite) TestBastionNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call %s.%s", objType, request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	_, err := facade.Bastion()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *FacadeSuite) TestProxyError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		return errors.New("boom")
@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package stagedconfig provides access to the StagedConfig facade, used
+// to stage several application config changes and apply them together.
+package stagedconfig
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the StagedConfig facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Client based on an existing API connection.
+func NewClient(callCloser base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(callCloser, "StagedConfig")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Stage validates and stages config changes for the application
+// without applying them. Options are parsed as by "juju config" and
+// settingsYAML as a config file; reset names options to be returned
+// to their defaults.
+func (c *Client) Stage(application string, options map[string]string, settingsYAML string, reset []string) error {
+	args := params.ApplicationStageConfig{
+		ApplicationName: application,
+		Options:         options,
+		SettingsYAML:    settingsYAML,
+		Reset:           reset,
+	}
+	return errors.Trace(c.facade.FacadeCall("Stage", args, nil))
+}
+
+// Staged returns the config changes staged for the application.
+func (c *Client) Staged(application string) (params.ApplicationStagedConfigResults, error) {
+	var result params.ApplicationStagedConfigResults
+	args := params.ApplicationStagedConfig{ApplicationName: application}
+	err := c.facade.FacadeCall("Staged", args, &result)
+	return result, errors.Trace(err)
+}
+
+// Commit applies the application's staged config changes together.
+func (c *Client) Commit(application string) error {
+	args := params.ApplicationStagedConfig{ApplicationName: application}
+	return errors.Trace(c.facade.FacadeCall("Commit", args, nil))
+}
+
+// Discard forgets the application's staged config changes.
+func (c *Client) Discard(application string) error {
+	args := params.ApplicationStagedConfig{ApplicationName: application}
+	return errors.Trace(c.facade.FacadeCall("Discard", args, nil))
+}
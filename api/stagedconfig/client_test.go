@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stagedconfig_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/stagedconfig"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ClientSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestCalls(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		c.Check(id, gc.Equals, "")
+		if request == "Staged" {
+			*result.(*params.ApplicationStagedConfigResults) = params.ApplicationStagedConfigResults{
+				Application: "mysql",
+				Settings:    map[string]interface{}{"dataset-size": "80%"},
+			}
+		}
+		return stub.NextErr()
+	})
+	client := stagedconfig.NewClient(apiCaller)
+
+	err := client.Stage("mysql", map[string]string{"dataset-size": "80%"}, "", []string{"backup_dir"})
+	c.Assert(err, jc.ErrorIsNil)
+	staged, err := client.Staged("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(staged.Settings, jc.DeepEquals, map[string]interface{}{"dataset-size": "80%"})
+	err = client.Commit("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Discard("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := params.ApplicationStagedConfig{ApplicationName: "mysql"}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"StagedConfig.Stage", []interface{}{params.ApplicationStageConfig{
+			ApplicationName: "mysql",
+			Options:         map[string]string{"dataset-size": "80%"},
+			Reset:           []string{"backup_dir"},
+		}}},
+		{"StagedConfig.Staged", []interface{}{app}},
+		{"StagedConfig.Commit", []interface{}{app}},
+		{"StagedConfig.Discard", []interface{}{app}},
+	})
+}
@@ -79,6 +79,31 @@ func (c *Client) ListPools(providers, names []string) ([]params.StoragePool, err
 	return results.Results[0].Result, nil
 }
 
+// ListPoolUsage returns the usage of the pools that match the given
+// filter. If no filter was provided, the usage of all pools is returned.
+func (c *Client) ListPoolUsage(providers, names []string) ([]params.StoragePoolUsage, error) {
+	if err := base.RequireVersion(c.facade, 6, "listing storage pool usage"); err != nil {
+		return nil, err
+	}
+	args := params.StoragePoolFilters{
+		Filters: []params.StoragePoolFilter{{
+			Names:     names,
+			Providers: providers,
+		}},
+	}
+	var results params.StoragePoolUsageResults
+	if err := c.facade.FacadeCall("ListPoolUsage", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
 // CreatePool creates pool with specified parameters.
 func (c *Client) CreatePool(pname, provider string, attrs map[string]interface{}) error {
 	args := params.StoragePool{
@@ -147,3 +172,46 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// ResizeStorage requests that the specified storage instances be grown
+// to the sizes given in the corresponding parameters.
+func (c *Client) ResizeStorage(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+	if err := base.RequireVersion(c.facade, 5, "resizing storage"); err != nil {
+		return nil, err
+	}
+	in := params.StoragesResizeParams{Storages: storages}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storages) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storages), len(out.Results),
+		)
+	}
+	return out.Results, nil
+}
+
+// SnapshotUnitStorage takes a snapshot of the volumes backing the
+// storage attached to each of the specified units or applications.
+func (c *Client) SnapshotUnitStorage(tags []names.Tag) ([]params.SnapshotUnitStorageResult, error) {
+	if err := base.RequireVersion(c.facade, 4, "snapshotting unit storage"); err != nil {
+		return nil, err
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.SnapshotUnitStorageResults
+	if err := c.facade.FacadeCall("SnapshotUnitStorage", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(tags), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
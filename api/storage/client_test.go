@@ -235,6 +235,76 @@ func (s *storageMockSuite) TestListPoolsFacadeCallError(c *gc.C) {
 	c.Assert(found, gc.HasLen, 0)
 }
 
+func (s *storageMockSuite) TestListPoolUsage(c *gc.C) {
+	expected := []params.StoragePoolUsage{{
+		Name:     "ebs-ssd",
+		Provider: "ebs",
+		Total: params.StorageUsage{
+			Provisioned:     1,
+			ProvisionedSize: 1024,
+			Detached:        1,
+			DetachedSize:    1024,
+		},
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListPoolUsage")
+			c.Check(a, jc.DeepEquals, params.StoragePoolFilters{
+				Filters: []params.StoragePoolFilter{{
+					Names:     []string{"ebs-ssd"},
+					Providers: []string{"ebs"},
+				}},
+			})
+			results := result.(*params.StoragePoolUsageResults)
+			results.Results = []params.StoragePoolUsageResult{{
+				Result: expected,
+			}}
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	found, err := storageClient.ListPoolUsage([]string{"ebs"}, []string{"ebs-ssd"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, expected)
+}
+
+func (s *storageMockSuite) TestListPoolUsageResultCount(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.ListPoolUsage(nil, nil)
+	c.Assert(err, gc.ErrorMatches, "expected 1 result, got 0")
+}
+
+func (s *storageMockSuite) TestListPoolUsageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatal("unexpected API call")
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.ListPoolUsage(nil, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "listing storage pool usage not supported")
+}
+
 func (s *storageMockSuite) TestCreatePool(c *gc.C) {
 	var called bool
 	poolName := "poolName"
@@ -581,3 +651,105 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestSnapshotUnitStorage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SnapshotUnitStorage")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.SnapshotUnitStorageResults{})
+			*(result.(*params.SnapshotUnitStorageResults)) = params.SnapshotUnitStorageResults{
+				Results: []params.SnapshotUnitStorageResult{{
+					Snapshots: []params.VolumeSnapshot{{
+						VolumeTag:  "volume-0",
+						VolumeId:   "vol-0",
+						SnapshotId: "snap-0",
+					}},
+				}},
+			}
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.SnapshotUnitStorage([]names.Tag{names.NewUnitTag("mysql/0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.SnapshotUnitStorageResult{{
+		Snapshots: []params.VolumeSnapshot{{
+			VolumeTag:  "volume-0",
+			VolumeId:   "vol-0",
+			SnapshotId: "snap-0",
+		}},
+	}})
+}
+
+func (s *storageMockSuite) TestSnapshotUnitStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatal("unexpected API call")
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.SnapshotUnitStorage([]names.Tag{names.NewUnitTag("mysql/0")})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "snapshotting unit storage not supported")
+}
+
+func (s *storageMockSuite) TestResizeStorage(c *gc.C) {
+	args := []params.StorageResizeParams{{
+		StorageTag: "storage-data-0",
+		Size:       2048,
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ResizeStorage")
+			c.Check(a, jc.DeepEquals, params.StoragesResizeParams{Storages: args})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "too small"},
+				}},
+			}
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.ResizeStorage(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "too small")
+}
+
+func (s *storageMockSuite) TestResizeStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatal("unexpected API call")
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.ResizeStorage([]params.StorageResizeParams{{
+		StorageTag: "storage-data-0",
+		Size:       2048,
+	}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "resizing storage not supported")
+}
@@ -107,6 +107,35 @@ func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, er
 	return w, nil
 }
 
+// WatchVolumeResizes watches for changes to model-scoped volumes,
+// including requests to resize them. The watcher may only be used
+// if the scope passed to NewState is a model tag.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
+// ReleaseStorage reports whether the model's volumes are to be released,
+// rather than destroyed, when they are deprovisioned. It may only be
+// called if the scope passed to NewState is a model tag.
+func (st *State) ReleaseStorage() (bool, error) {
+	var results params.BoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.scope.String()}},
+	}
+	err := st.facade.FacadeCall("ReleaseStorage", args, &results)
+	if err != nil {
+		return false, err
+	}
+	if len(results.Results) != 1 {
+		return false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
 // WatchVolumeAttachments watches for changes to volume attachments
 // scoped to the entity with the tag passed to NewState.
 func (st *State) WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error) {
@@ -243,6 +272,26 @@ func (st *State) VolumeParams(tags []names.VolumeTag) ([]params.VolumeParamsResu
 	return results.Results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
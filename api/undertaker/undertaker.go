@@ -100,3 +100,16 @@ func (c *Client) WatchModelResources() (watcher.NotifyWatcher, error) {
 	w := c.newWatcher(c.caller.RawAPICaller(), result)
 	return w, nil
 }
+
+// KnownCloudResources returns the ids of the machines, instances and
+// volumes that the model's state records.
+func (c *Client) KnownCloudResources() (params.KnownCloudResources, error) {
+	var result params.KnownCloudResources
+	if err := base.RequireVersion(c.caller, 2, "listing known cloud resources"); err != nil {
+		return result, err
+	}
+	if err := c.caller.FacadeCall("KnownCloudResources", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
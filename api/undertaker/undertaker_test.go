@@ -135,6 +135,52 @@ func (s *UndertakerSuite) TestWatchModelResourcesError(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *UndertakerSuite) TestKnownCloudResources(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(
+			objType string,
+			version int,
+			id, request string,
+			args, response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Undertaker")
+			c.Check(version, gc.Equals, 2)
+			c.Check(request, gc.Equals, "KnownCloudResources")
+			c.Check(args, gc.IsNil)
+			*(response.(*params.KnownCloudResources)) = params.KnownCloudResources{
+				MachineIds:  []string{"0"},
+				InstanceIds: []string{"i-0"},
+				VolumeIds:   []string{"vol-0"},
+			}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client, err := undertaker.NewClient(apiCaller, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := client.KnownCloudResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.KnownCloudResources{
+		MachineIds:  []string{"0"},
+		InstanceIds: []string{"i-0"},
+		VolumeIds:   []string{"vol-0"},
+	})
+}
+
+func (s *UndertakerSuite) TestKnownCloudResourcesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}),
+		BestVersion: 1,
+	}
+	client, err := undertaker.NewClient(apiCaller, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.KnownCloudResources()
+	c.Assert(err, gc.ErrorMatches, "listing known cloud resources not supported")
+}
+
 type fakeWatcher struct {
 	watcher.NotifyWatcher
 }
@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -39,3 +41,27 @@ func (s *environSuite) TestUUID(c *gc.C) {
 func (s *environSuite) TestName(c *gc.C) {
 	c.Assert(s.apiEnviron.Name(), gc.Equals, s.stateEnviron.Name())
 }
+
+func (s *environSuite) TestMaxRelationDataSize(c *gc.C) {
+	size, err := s.uniter.MaxRelationDataSize()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, 0)
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"max-relation-data-size": 4096}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	size, err = s.uniter.MaxRelationDataSize()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, 4096)
+}
+
+func (s *environSuite) TestUnitDrainTimeout(c *gc.C) {
+	timeout, err := s.uniter.UnitDrainTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, 10*time.Minute)
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"unit-drain-timeout": "90s"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	timeout, err = s.uniter.UnitDrainTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, 90*time.Second)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/watcher"
 )
@@ -162,6 +163,54 @@ func (ru *RelationUnit) ReadSettings(uname string) (params.Settings, error) {
 	return result.Settings, nil
 }
 
+// ReadApplicationSettings returns the settings that the leader of the
+// named application has published in the relation; the application may
+// be the unit's own, or the one it is related to. Controllers older
+// than version 11 of the facade do not store application settings, so
+// an error satisfying errors.IsNotSupported is returned for them
+// instead.
+func (ru *RelationUnit) ReadApplicationSettings(appName string) (params.Settings, error) {
+	if err := base.RequireVersion(ru.st.facade, 11, "application relation settings"); err != nil {
+		return nil, err
+	}
+	if !names.IsValidApplication(appName) {
+		return nil, errors.Errorf("%q is not a valid application", appName)
+	}
+	var results params.SettingsResults
+	args := params.RelationUnitApplications{
+		RelationUnitApplications: []params.RelationUnitApplication{{
+			Relation:    ru.relation.tag.String(),
+			Unit:        ru.unit.tag.String(),
+			Application: names.NewApplicationTag(appName).String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("ReadApplicationSettings", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Settings, nil
+}
+
+// ApplicationSettings returns a Settings which allows access to the
+// settings that the unit's application publishes in the relation.
+// Only the application's leader may write them. As with
+// ReadApplicationSettings, controllers older than version 11 of the
+// facade cause an error satisfying errors.IsNotSupported.
+func (ru *RelationUnit) ApplicationSettings() (*Settings, error) {
+	settings, err := ru.ReadApplicationSettings(ru.unit.ApplicationName())
+	if err != nil {
+		return nil, err
+	}
+	return newApplicationSettings(ru.st, ru.relation.tag.String(), ru.unit.tag.String(), settings), nil
+}
+
 // Watch returns a watcher that notifies of changes to counterpart
 // units in the relation.
 func (ru *RelationUnit) Watch() (watcher.RelationUnitsWatcher, error) {
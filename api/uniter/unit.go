@@ -5,11 +5,13 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
@@ -72,6 +74,106 @@ func (u *Unit) SetUnitStatus(unitStatus status.Status, info string, data map[str
 	return result.OneError()
 }
 
+// AddStatusEvent adds an event to the unit's workload status history
+// without changing its status. Controllers older than version 8 of
+// the facade cannot record events, so an error satisfying
+// errors.IsNotSupported is returned for them instead.
+func (u *Unit) AddStatusEvent(message string, data map[string]interface{}) error {
+	if err := base.RequireVersion(u.st.facade, 8, "status events"); err != nil {
+		return err
+	}
+	var result params.ErrorResults
+	args := params.StatusEvents{
+		Events: []params.StatusEvent{
+			{Tag: u.tag.String(), Message: message, Data: data},
+		},
+	}
+	err := u.st.facade.FacadeCall("AddStatusEvents", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// Payloads returns the payloads tracked by the unit. Controllers older
+// than version 12 of the facade cannot report them, so an error
+// satisfying errors.IsNotSupported is returned for them instead.
+func (u *Unit) Payloads() ([]params.UnitPayload, error) {
+	if err := base.RequireVersion(u.st.facade, 12, "unit payloads"); err != nil {
+		return nil, err
+	}
+	var results params.UnitPayloadsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UnitPayloads", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Payloads, nil
+}
+
+// SetSecret stores value as the named secret of the unit's
+// application. If rotateInterval is not nil, it sets how often the
+// secret should be given a new value; zero means that it need not be.
+// Controllers older than version 10 of the facade cannot store
+// secrets, so an error satisfying errors.IsNotSupported is returned
+// for them instead.
+func (u *Unit) SetSecret(name, value string, rotateInterval *time.Duration) error {
+	if err := base.RequireVersion(u.st.facade, 10, "secrets"); err != nil {
+		return err
+	}
+	arg := params.SetSecretArg{
+		UnitTag: u.tag.String(),
+		Name:    name,
+		Value:   value,
+	}
+	if rotateInterval != nil {
+		seconds := rotateInterval.Seconds()
+		arg.RotateIntervalSeconds = &seconds
+	}
+	var result params.ErrorResults
+	args := params.SetSecretArgs{Args: []params.SetSecretArg{arg}}
+	err := u.st.facade.FacadeCall("SetSecrets", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// Secret returns the named secret of the unit's application. As with
+// SetSecret, controllers older than version 10 of the facade cause an
+// error satisfying errors.IsNotSupported.
+func (u *Unit) Secret(name string) (params.Secret, error) {
+	if err := base.RequireVersion(u.st.facade, 10, "secrets"); err != nil {
+		return params.Secret{}, err
+	}
+	var results params.SecretResults
+	args := params.GetSecretArgs{Args: []params.GetSecretArg{{
+		UnitTag: u.tag.String(),
+		Name:    name,
+	}}}
+	err := u.st.facade.FacadeCall("GetSecrets", args, &results)
+	if err != nil {
+		return params.Secret{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.Secret{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.Secret{}, result.Error
+	}
+	return *result.Result, nil
+}
+
 // UnitStatus gets the status details of the unit.
 func (u *Unit) UnitStatus() (params.StatusResult, error) {
 	var results params.StatusResults
@@ -612,6 +714,24 @@ func (u *Unit) RequestReboot() error {
 	return result.OneError()
 }
 
+// ScheduleReboot sets the reboot flag for its machine agent, requesting
+// that the machine reboot during the model's maintenance window.
+func (u *Unit) ScheduleReboot() error {
+	machineId, err := u.AssignedMachine()
+	if err != nil {
+		return err
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: machineId.String()}},
+	}
+	err = u.st.facade.FacadeCall("ScheduleReboot", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // JoinedRelations returns the tags of the relations the unit has joined.
 func (u *Unit) JoinedRelations() ([]names.RelationTag, error) {
 	var results params.StringsResults
@@ -738,3 +858,24 @@ func (u *Unit) NetworkConfig(bindingName string) ([]params.NetworkConfig, error)
 
 	return result.Config, nil
 }
+
+// NetworkInfo returns the network interfaces, ingress addresses and
+// egress subnets of the unit for each of the given binding names.
+// Errors for individual bindings are reported in their results.
+// Controllers older than version 9 of the facade cannot answer, so an
+// error satisfying errors.IsNotSupported is returned for them instead.
+func (u *Unit) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	if err := base.RequireVersion(u.st.facade, 9, "network info"); err != nil {
+		return nil, err
+	}
+	var results params.NetworkInfoResults
+	args := params.NetworkInfoParams{
+		Unit:     u.tag.String(),
+		Bindings: bindingNames,
+	}
+	err := u.st.facade.FacadeCall("NetworkInfo", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
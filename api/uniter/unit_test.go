@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	jujufactory "github.com/juju/juju/testing/factory"
@@ -51,6 +52,17 @@ func (s *unitSuite) TestRequestReboot(c *gc.C) {
 	c.Assert(rFlag, jc.IsTrue)
 }
 
+func (s *unitSuite) TestScheduleReboot(c *gc.C) {
+	err := s.apiUnit.ScheduleReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	rFlag, err := s.wordpressMachine.GetRebootFlag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rFlag, jc.IsTrue)
+	scheduled, err := s.wordpressMachine.RebootScheduled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsTrue)
+}
+
 func (s *unitSuite) TestUnitAndUnitTag(c *gc.C) {
 	apiUnitFoo, err := s.uniter.Unit(names.NewUnitTag("foo/42"))
 	c.Assert(err, gc.ErrorMatches, "permission denied")
@@ -122,6 +134,61 @@ func (s *unitSuite) TestSetUnitStatus(c *gc.C) {
 	c.Assert(agentStatusInfo.Data, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestAddStatusEvent(c *gc.C) {
+	err := s.apiUnit.AddStatusEvent("cluster joined", map[string]interface{}{"peers": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.wordpressUnit.StatusHistory(status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Status, gc.Equals, status.Event)
+	c.Assert(history[0].Message, gc.Equals, "cluster joined")
+	c.Assert(history[0].Data, jc.DeepEquals, map[string]interface{}{"peers": "3"})
+
+	err = s.apiUnit.AddStatusEvent("", nil)
+	c.Assert(err, gc.ErrorMatches, "empty status event message not valid")
+}
+
+func (s *unitSuite) TestSecrets(c *gc.C) {
+	day := 24 * time.Hour
+	err := s.apiUnit.SetSecret("db-password", "hunter2", &day)
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.apiUnit.Secret("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Value, gc.Equals, "hunter2")
+	c.Assert(secret.Revision, gc.Equals, 1)
+	c.Assert(secret.RotateIntervalSeconds, gc.Equals, day.Seconds())
+
+	_, err = s.apiUnit.Secret("missing")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *unitSuite) TestPayloads(c *gc.C) {
+	payloads, err := s.apiUnit.Payloads()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(payloads, gc.HasLen, 0)
+
+	unitPayloads, err := s.State.UnitPayloads(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unitPayloads.Track(payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "database",
+			Type: "docker",
+		},
+		Status: payload.StateRunning,
+		ID:     "abc123",
+		Unit:   s.wordpressUnit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	payloads, err = s.apiUnit.Payloads()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(payloads, jc.DeepEquals, []params.UnitPayload{
+		{Class: "database", Type: "docker", ID: "abc123"},
+	})
+}
+
 func (s *unitSuite) TestUnitStatus(c *gc.C) {
 	now := time.Now()
 	sInfo := status.StatusInfo{
@@ -309,6 +376,13 @@ func (s *unitSuite) TestPrivateAddress(c *gc.C) {
 	c.Assert(address, gc.Equals, "1.2.3.4")
 }
 
+func (s *unitSuite) TestNetworkInfoUnknownBinding(c *gc.C) {
+	results, err := s.apiUnit.NetworkInfo([]string{"unknown"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results["unknown"].Error, gc.ErrorMatches, `binding name "unknown" not defined by the unit's charm`)
+}
+
 func (s *unitSuite) TestNetworkConfig(c *gc.C) {
 	c.Skip("dimitern: temporarily disabled to pass a CI run until it can be fixed like its apiserver/uniter counterpart")
 
@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -72,9 +73,33 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 // newStateV4 creates a new client-side Uniter facade, version 4.
 var newStateV4 = newStateForVersionFn(4)
 
+// newStateV5 creates a new client-side Uniter facade, version 5.
+var newStateV5 = newStateForVersionFn(5)
+
+// newStateV6 creates a new client-side Uniter facade, version 6.
+var newStateV6 = newStateForVersionFn(6)
+
+// newStateV7 creates a new client-side Uniter facade, version 7.
+var newStateV7 = newStateForVersionFn(7)
+
+// newStateV8 creates a new client-side Uniter facade, version 8.
+var newStateV8 = newStateForVersionFn(8)
+
+// newStateV9 creates a new client-side Uniter facade, version 9.
+var newStateV9 = newStateForVersionFn(9)
+
+// newStateV10 creates a new client-side Uniter facade, version 10.
+var newStateV10 = newStateForVersionFn(10)
+
+// newStateV11 creates a new client-side Uniter facade, version 11.
+var newStateV11 = newStateForVersionFn(11)
+
+// newStateV12 creates a new client-side Uniter facade, version 12.
+var newStateV12 = newStateForVersionFn(12)
+
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV4
+var NewState = newStateV12
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -317,6 +342,36 @@ func (st *State) Model() (*Model, error) {
 	}, nil
 }
 
+// MaxRelationDataSize returns the maximum size, in bytes, of the
+// settings the unit may write to a relation. Zero means there is no
+// limit.
+func (st *State) MaxRelationDataSize() (int, error) {
+	var result params.IntResult
+	if err := st.facade.FacadeCall("MaxRelationDataSize", nil, &result); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.Result, nil
+}
+
+// UnitDrainTimeout returns how long the pre-remove hook of a dying unit
+// may run before it is killed. Zero means that there is no limit.
+// Controllers older than version 7 of the facade cannot report the
+// timeout, so an error satisfying errors.IsNotSupported is returned
+// for them instead.
+func (st *State) UnitDrainTimeout() (time.Duration, error) {
+	if err := base.RequireVersion(st.facade, 7, "unit drain timeouts"); err != nil {
+		return 0, err
+	}
+	var result params.UnitDrainTimeoutResult
+	if err := st.facade.FacadeCall("UnitDrainTimeout", nil, &result); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return time.Duration(result.TimeoutSeconds * float64(time.Second)), nil
+}
+
 // AllMachinePorts returns all port ranges currently open on the given
 // machine, mapped to the tags of the unit that opened them and the
 // relation that applies.
@@ -5,7 +5,11 @@ package upgrader
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 
+	"github.com/juju/errors"
 	"github.com/juju/version"
 
 	"github.com/juju/juju/api/base"
@@ -93,6 +97,33 @@ func (st *State) Tools(tag string) (tools.List, error) {
 	return result.ToolsList, nil
 }
 
+// ToolsDelta fetches the agent binaries with version "to" from the
+// controller, asking for them as a delta against those with version
+// "from". If the controller sends a delta, archiveSHA256 holds the
+// SHA256 hash of the tools tarball the delta reconstructs; if it
+// cannot supply one, the complete tarball is returned and
+// archiveSHA256 is empty. The caller must close the returned reader.
+func (st *State) ToolsDelta(from, to version.Binary) (_ io.ReadCloser, archiveSHA256 string, err error) {
+	httpClient, err := st.facade.RawAPICaller().HTTPClient()
+	if err != nil {
+		return nil, "", errors.Annotate(err, "cannot retrieve HTTP client")
+	}
+	query := url.Values{"from": {from.String()}}
+	var resp *http.Response
+	if err := httpClient.Get("/tools/"+to.String()+"?"+query.Encode(), &resp); err != nil {
+		return nil, "", errors.Annotatef(err, "cannot fetch %s tools", to)
+	}
+	if resp.Header.Get("Content-Type") != params.ContentTypeToolsDelta {
+		return resp.Body, "", nil
+	}
+	archiveSHA256 = resp.Header.Get(params.ToolsSHA256Header)
+	if archiveSHA256 == "" {
+		resp.Body.Close()
+		return nil, "", errors.Errorf("tools delta for %s has no tarball hash", to)
+	}
+	return resp.Body, archiveSHA256, nil
+}
+
 func (st *State) WatchAPIVersion(agentTag string) (watcher.NotifyWatcher, error) {
 	var results params.NotifyWatchResults
 	args := params.Entities{
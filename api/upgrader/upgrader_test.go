@@ -4,7 +4,11 @@
 package upgrader_test
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -16,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/binarystorage"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/tools"
@@ -105,6 +110,52 @@ func (s *machineUpgraderSuite) TestTools(c *gc.C) {
 	c.Assert(stateTools.URL, gc.Equals, url)
 }
 
+func (s *machineUpgraderSuite) storeTools(c *gc.C, vers string, files ...*coretesting.TarFile) []byte {
+	data, checksum := coretesting.TarGz(files...)
+	storage, err := s.State.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer storage.Close()
+	err = storage.Add(bytes.NewReader(data), binarystorage.Metadata{
+		Version: vers,
+		Size:    int64(len(data)),
+		SHA256:  checksum,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *machineUpgraderSuite) TestToolsDelta(c *gc.C) {
+	jujud := strings.Repeat("jujud 1.2.3 ", 5000)
+	s.storeTools(c, "1.2.3-trusty-amd64", coretesting.NewTarFile("jujud", 0755, jujud))
+	data := s.storeTools(c, "1.2.4-trusty-amd64", coretesting.NewTarFile("jujud", 0755, jujud+"1.2.4"))
+
+	r, archiveSHA256, err := s.st.ToolsDelta(
+		version.MustParseBinary("1.2.3-trusty-amd64"),
+		version.MustParseBinary("1.2.4-trusty-amd64"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	c.Assert(archiveSHA256, gc.Equals, fmt.Sprintf("%x", sha256.Sum256(data)))
+	body, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(body) < len(data), jc.IsTrue)
+}
+
+func (s *machineUpgraderSuite) TestToolsDeltaUnknownBase(c *gc.C) {
+	data := s.storeTools(c, "1.2.4-trusty-amd64", coretesting.NewTarFile("jujud", 0755, "jujud 1.2.4"))
+
+	r, archiveSHA256, err := s.st.ToolsDelta(
+		version.MustParseBinary("1.2.3-trusty-amd64"),
+		version.MustParseBinary("1.2.4-trusty-amd64"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	c.Assert(archiveSHA256, gc.Equals, "")
+	body, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(body, jc.DeepEquals, data)
+}
+
 func (s *machineUpgraderSuite) TestWatchAPIVersion(c *gc.C) {
 	w, err := s.st.WatchAPIVersion(s.rawMachine.Tag().String())
 	c.Assert(err, jc.ErrorIsNil)
@@ -6,10 +6,12 @@ package usermanager
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -180,3 +182,66 @@ func (c *Client) SetPassword(username, password string) error {
 	}
 	return results.OneError()
 }
+
+// RefreshSession returns a macaroon that authenticates the current
+// user until the returned expiry time, without requiring a password
+// or a discharge. A long-running client may log in with it, or store
+// it in its cookie jar, before its current session expires.
+func (c *Client) RefreshSession() (macaroon.Slice, time.Time, error) {
+	if err := base.RequireVersion(c.facade, 2, "session refresh"); err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	var result params.RefreshSessionResult
+	if err := c.facade.FacadeCall("RefreshSession", nil, &result); err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	if result.Macaroon == nil {
+		return nil, time.Time{}, errors.New("no macaroon returned")
+	}
+	return macaroon.Slice{result.Macaroon}, result.Expiry, nil
+}
+
+// UserQuota returns the quota on the total machines, units and
+// storage instances in the models owned by the named user, and the
+// usage counted against it.
+func (c *Client) UserQuota(username string) (params.UserQuota, params.QuotaUsage, error) {
+	if err := base.RequireVersion(c.facade, 3, "user quotas"); err != nil {
+		return params.UserQuota{}, params.QuotaUsage{}, errors.Trace(err)
+	}
+	if !names.IsValidUser(username) {
+		return params.UserQuota{}, params.QuotaUsage{}, errors.Errorf("%q is not a valid username", username)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewUserTag(username).String()}},
+	}
+	var results params.UserQuotaResults
+	if err := c.facade.FacadeCall("UserQuotas", args, &results); err != nil {
+		return params.UserQuota{}, params.QuotaUsage{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.UserQuota{}, params.QuotaUsage{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.UserQuota{}, params.QuotaUsage{}, errors.Trace(result.Error)
+	}
+	if result.Quota == nil || result.Usage == nil {
+		return params.UserQuota{}, params.QuotaUsage{}, errors.New("no quota returned")
+	}
+	return *result.Quota, *result.Usage, nil
+}
+
+// SetUserQuota sets the quota on the total machines, units and
+// storage instances in the models owned by the user. A zero limit
+// means no limit.
+func (c *Client) SetUserQuota(quota params.UserQuota) error {
+	if err := base.RequireVersion(c.facade, 3, "user quotas"); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.UserQuotas{Quotas: []params.UserQuota{quota}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetUserQuotas", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
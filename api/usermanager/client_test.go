@@ -4,10 +4,15 @@
 package usermanager_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/api"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/usermanager"
 	"github.com/juju/juju/apiserver/params"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -215,3 +220,74 @@ func (s *usermanagerSuite) TestSetUserPasswordBadName(c *gc.C) {
 	err := s.usermanager.SetPassword("not!good", "new-password")
 	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
 }
+
+func (s *usermanagerSuite) TestRefreshSession(c *gc.C) {
+	ms, expiry, err := s.usermanager.RefreshSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ms, gc.HasLen, 1)
+	c.Assert(expiry.After(time.Now().Add(23*time.Hour)), jc.IsTrue)
+
+	// The macaroon alone is enough to log in.
+	info := s.APIInfo(c)
+	info.Password = ""
+	info.Macaroons = []macaroon.Slice{ms}
+	conn, err := api.Open(info, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	conn.Close()
+}
+
+func (s *usermanagerSuite) TestRefreshSessionNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 1,
+	}
+	client := usermanager.NewClient(apiCaller)
+	_, _, err := client.RefreshSession()
+	c.Assert(err, gc.ErrorMatches, "session refresh not supported")
+}
+
+func (s *usermanagerSuite) TestUserQuota(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := s.usermanager.SetUserQuota(params.UserQuota{
+		UserTag:     "user-bob",
+		MaxMachines: 4,
+		MaxStorage:  8,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	quota, usage, err := s.usermanager.UserQuota("bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, jc.DeepEquals, params.UserQuota{
+		UserTag:     "user-bob",
+		MaxMachines: 4,
+		MaxStorage:  8,
+	})
+	c.Assert(usage, jc.DeepEquals, params.QuotaUsage{})
+}
+
+func (s *usermanagerSuite) TestUserQuotaInvalidUser(c *gc.C) {
+	_, _, err := s.usermanager.UserQuota("not!good")
+	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
+}
+
+func (s *usermanagerSuite) TestUserQuotasNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := usermanager.NewClient(apiCaller)
+	_, _, err := client.UserQuota("bob")
+	c.Assert(err, gc.ErrorMatches, "user quotas not supported")
+	err = client.SetUserQuota(params.UserQuota{UserTag: "user-bob"})
+	c.Assert(err, gc.ErrorMatches, "user quotas not supported")
+}
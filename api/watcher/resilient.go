@@ -0,0 +1,157 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/watcher"
+)
+
+// ResilientNotifyWatcherConfig holds the configuration of a watcher
+// returned by NewResilientNotifyWatcher.
+type ResilientNotifyWatcherConfig struct {
+	// Watch starts a NotifyWatcher. It is called to start the first
+	// watcher, and again each time the connection is lost, so it
+	// should open a new API connection if the old one has gone.
+	Watch func() (watcher.NotifyWatcher, error)
+
+	// Clock is used to wait between attempts to start a watcher.
+	Clock clock.Clock
+
+	// RetryDelay is how long to wait after failing to start a
+	// watcher before trying again.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot drive a resilient
+// NotifyWatcher.
+func (config ResilientNotifyWatcherConfig) Validate() error {
+	if config.Watch == nil {
+		return errors.NotValidf("nil Watch")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// NewResilientNotifyWatcher returns a NotifyWatcher that survives the
+// loss of its API connection. When the connection is lost it starts a
+// new watcher, retrying until it succeeds, and sends the new watcher's
+// initial event so that the client knows to re-read whatever it is
+// watching.
+func NewResilientNotifyWatcher(config ResilientNotifyWatcherConfig) (watcher.NotifyWatcher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &resilientNotifyWatcher{
+		config: config,
+		out:    make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop())
+	}()
+	return w, nil
+}
+
+type resilientNotifyWatcher struct {
+	tomb   tomb.Tomb
+	config ResilientNotifyWatcherConfig
+	out    chan struct{}
+}
+
+func (w *resilientNotifyWatcher) loop() error {
+	var (
+		inner   watcher.NotifyWatcher
+		changes watcher.NotifyChannel
+		dead    chan error
+		retry   <-chan time.Time
+		out     chan struct{}
+	)
+	defer func() {
+		if inner != nil {
+			inner.Kill()
+			inner.Wait()
+		}
+	}()
+	for {
+		if inner == nil && retry == nil {
+			var err error
+			inner, err = w.config.Watch()
+			if err != nil {
+				if params.IsCodeUnauthorized(err) {
+					return errors.Trace(err)
+				}
+				logger.Warningf("cannot start watcher, retrying in %v: %v", w.config.RetryDelay, err)
+				inner = nil
+				retry = w.config.Clock.After(w.config.RetryDelay)
+			} else {
+				changes = inner.Changes()
+				// A watcher's Changes channel is not closed when
+				// it dies, so wait for it separately.
+				dead = make(chan error, 1)
+				go func(inner watcher.NotifyWatcher, dead chan<- error) {
+					dead <- inner.Wait()
+				}(inner, dead)
+			}
+		}
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-retry:
+			retry = nil
+		case err := <-dead:
+			if !isConnectionLost(err) {
+				if err == nil {
+					err = errors.New("watcher stopped unexpectedly")
+				}
+				inner = nil
+				return errors.Trace(err)
+			}
+			logger.Infof("lost watcher connection, reconnecting: %v", err)
+			inner, changes, dead = nil, nil, nil
+		case _, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w *resilientNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.out
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *resilientNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *resilientNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+// isConnectionLost returns whether err, from a watcher, means that the
+// watcher's API connection or the controller has gone away.
+func isConnectionLost(err error) bool {
+	// The controller stops all its watchers when it shuts down.
+	return rpc.IsShutdownErr(err) || params.IsCodeStopped(err)
+}
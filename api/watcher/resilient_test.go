@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/tomb.v1"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+)
+
+type resilientNotifyWatcherSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	watchers chan *fakeNotifyWatcher
+}
+
+var _ = gc.Suite(&resilientNotifyWatcherSuite{})
+
+func (s *resilientNotifyWatcherSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.watchers = make(chan *fakeNotifyWatcher, 10)
+}
+
+func (s *resilientNotifyWatcherSuite) newWatcher(c *gc.C, watchErrs ...error) watcher.NotifyWatcher {
+	w, err := apiwatcher.NewResilientNotifyWatcher(apiwatcher.ResilientNotifyWatcherConfig{
+		Watch: func() (watcher.NotifyWatcher, error) {
+			if len(watchErrs) > 0 {
+				err := watchErrs[0]
+				watchErrs = watchErrs[1:]
+				return nil, err
+			}
+			w := newFakeNotifyWatcher()
+			s.watchers <- w
+			return w, nil
+		},
+		Clock:      s.clock,
+		RetryDelay: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		w.Kill()
+		w.Wait()
+	})
+	return w
+}
+
+func (s *resilientNotifyWatcherSuite) nextWatcher(c *gc.C) *fakeNotifyWatcher {
+	select {
+	case w := <-s.watchers:
+		return w
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for watcher to start")
+	}
+	panic("unreachable")
+}
+
+func assertNotifyEvent(c *gc.C, w watcher.NotifyWatcher) {
+	select {
+	case _, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for event")
+	}
+}
+
+func (s *resilientNotifyWatcherSuite) TestReconnects(c *gc.C) {
+	w := s.newWatcher(c)
+	first := s.nextWatcher(c)
+	first.changes <- struct{}{}
+	assertNotifyEvent(c, w)
+
+	first.tomb.Kill(rpc.ErrShutdown)
+	second := s.nextWatcher(c)
+	second.changes <- struct{}{}
+	assertNotifyEvent(c, w)
+
+	w.Kill()
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+	c.Assert(second.Wait(), jc.ErrorIsNil)
+}
+
+func (s *resilientNotifyWatcherSuite) TestRetriesWatch(c *gc.C) {
+	w := s.newWatcher(c, errors.New("no controller"))
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for retry")
+	}
+	s.clock.Advance(time.Second)
+	s.nextWatcher(c).changes <- struct{}{}
+	assertNotifyEvent(c, w)
+}
+
+func (s *resilientNotifyWatcherSuite) TestOtherErrorsReturned(c *gc.C) {
+	w := s.newWatcher(c)
+	s.nextWatcher(c).tomb.Kill(errors.New("boom"))
+	c.Assert(w.Wait(), gc.ErrorMatches, "boom")
+}
+
+type fakeNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newFakeNotifyWatcher() *fakeNotifyWatcher {
+	w := &fakeNotifyWatcher{changes: make(chan struct{})}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return w
+}
+
+func (w *fakeNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
+func (w *fakeNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *fakeNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
@@ -15,7 +15,9 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("Action", 2, NewActionAPI)
+	common.RegisterStandardFacade("Action", 2, NewActionAPIV2)
+	// Facade version 3 adds "<application>/leader" unit names to Run.
+	common.RegisterStandardFacade("Action", 3, NewActionAPI)
 }
 
 // ActionAPI implements the client API for interacting with Actions
@@ -40,6 +42,21 @@ func NewActionAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// ActionAPIV2 implements version 2 of the Action facade, whose Run
+// doesn't accept "<application>/leader" unit names.
+type ActionAPIV2 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ActionAPIV2{api}, nil
+}
+
 func (a *ActionAPI) checkCanRead() error {
 	canRead, err := a.authorizer.HasPermission(permission.ReadAccess, a.state.ModelTag())
 	if err != nil {
@@ -51,6 +68,17 @@ func (a *ActionAPI) checkCanRead() error {
 	return nil
 }
 
+func (a *ActionAPI) checkCanOperate() error {
+	canOperate, err := a.authorizer.HasPermission(permission.OperatorAccess, a.state.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canOperate {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (a *ActionAPI) checkCanWrite() error {
 	canWrite, err := a.authorizer.HasPermission(permission.WriteAccess, a.state.ModelTag())
 	if err != nil {
@@ -160,7 +188,7 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // enqueued Action, or an error if there was a problem enqueueing the
 // Action.
 func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
-	if err := a.checkCanWrite(); err != nil {
+	if err := a.checkCanOperate(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
@@ -234,7 +262,7 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 
 // Cancel attempts to cancel enqueued Actions from running.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
-	if err := a.checkCanWrite(); err != nil {
+	if err := a.checkCanOperate(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
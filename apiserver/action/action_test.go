@@ -273,6 +273,40 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueOperatorAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("operator")
+	api, err := action.NewActionAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+		},
+	}
+	res, err := api.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 1)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+}
+
+func (s *actionSuite) TestEnqueueReadAccessDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	api, err := action.NewActionAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+		},
+	}
+	_, err = api.Enqueue(arg)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
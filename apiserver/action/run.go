@@ -4,6 +4,7 @@
 package action
 
 import (
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -15,10 +16,35 @@ import (
 	"github.com/juju/juju/state"
 )
 
+// leaderSuffix, when appended to an application name in place of a
+// unit number, refers to the application's leader unit.
+const leaderSuffix = "/leader"
+
 // getAllUnitNames returns a sequence of valid Unit objects from state. If any
 // of the application names or unit names are not found, an error is returned.
+// A unit name of the form "<application>/leader" is replaced by the name of
+// the application's current leader.
 func getAllUnitNames(st *state.State, units, services []string) (result []names.Tag, err error) {
-	unitsSet := set.NewStrings(units...)
+	unitsSet := set.NewStrings()
+	var leaders map[string]string
+	for _, name := range units {
+		if !strings.HasSuffix(name, leaderSuffix) {
+			unitsSet.Add(name)
+			continue
+		}
+		if leaders == nil {
+			leaders, err = st.ApplicationLeaders()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		application := strings.TrimSuffix(name, leaderSuffix)
+		leader, ok := leaders[application]
+		if !ok {
+			return nil, errors.NotFoundf("leader for application %q", application)
+		}
+		unitsSet.Add(leader)
+	}
 	for _, name := range services {
 		service, err := st.Application(name)
 		if err != nil {
@@ -69,6 +95,18 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err
 	return queueActions(a, actionParams)
 }
 
+// Run the commands specified on the machines identified through the ids
+// provided in the machines, applications and units slices. Version 2 of
+// the facade rejects leader unit names, which it predates.
+func (a *ActionAPIV2) Run(run params.RunParams) (results params.ActionResults, err error) {
+	for _, unit := range run.Units {
+		if strings.HasSuffix(unit, leaderSuffix) {
+			return results, errors.Errorf("invalid unit name %q", unit)
+		}
+	}
+	return a.ActionAPI.Run(run)
+}
+
 // RunOnAllMachines attempts to run the specified command on all the machines.
 func (a *ActionAPI) RunOnAllMachines(run params.RunParams) (results params.ActionResults, err error) {
 	if err := a.checkCanAdmin(); err != nil {
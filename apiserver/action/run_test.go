@@ -4,6 +4,8 @@
 package action_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -84,6 +86,9 @@ func (s *runSuite) TestGetAllUnitNames(c *gc.C) {
 	err = ru.EnterScope(nil)
 	c.Assert(err, jc.ErrorIsNil)
 
+	err = s.State.LeadershipClaimer().ClaimLeadership("magic", "magic/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
 	for i, test := range []struct {
 		message  string
 		expected []string
@@ -124,6 +129,19 @@ func (s *runSuite) TestGetAllUnitNames(c *gc.C) {
 		services: []string{"magic"},
 		units:    []string{"magic/0"},
 		expected: []string{"magic/0", "magic/1"},
+	}, {
+		message:  "Asking for the leader of a service",
+		units:    []string{"magic/leader"},
+		expected: []string{"magic/1"},
+	}, {
+		message:  "Asking for the leader and the service",
+		services: []string{"magic"},
+		units:    []string{"magic/leader"},
+		expected: []string{"magic/0", "magic/1"},
+	}, {
+		message: "Asking for the leader of a service without one",
+		units:   []string{"no-units/leader"},
+		error:   `leader for application "no-units" not found`,
 	}} {
 		c.Logf("%v: %s", i, test.message)
 		result, err := action.GetAllUnitNames(s.State, test.units, test.services)
@@ -243,6 +261,19 @@ func (s *runSuite) TestRunOnAllMachines(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *runSuite) TestRunV2RejectsLeaderUnits(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	client, err := action.NewActionAPIV2(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.Run(params.RunParams{
+		Commands: "hostname",
+		Units:    []string{"magic/leader"},
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid unit name "magic/leader"`)
+}
+
 func (s *runSuite) TestRunRequiresAdmin(c *gc.C) {
 	alpha := names.NewUserTag("alpha@bravo")
 	auth := apiservertesting.FakeAuthorizer{
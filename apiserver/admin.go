@@ -83,7 +83,7 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 			// Users are not rate limited, all other entities are.
 			if !a.srv.limiter.Acquire() {
 				logger.Debugf("rate limiting for agent %s", req.AuthTag)
-				return fail, common.ErrTryAgain
+				return fail, common.TryAgainAfterError("too many concurrent agent logins", loginRetryDelay)
 			}
 			defer a.srv.limiter.Release()
 		}
@@ -285,7 +285,7 @@ func filterFacades(allowFacade func(name string) bool) []params.FacadeVersions {
 }
 
 func (a *admin) checkCreds(req params.LoginRequest, lookForModelUser bool) (state.Entity, *time.Time, error) {
-	return doCheckCreds(a.root.state, req, lookForModelUser, a.authenticator())
+	return doCheckCreds(a.root.state, req, lookForModelUser, a.authenticator(), a.srv.lastConnections)
 }
 
 func (a *admin) checkControllerMachineCreds(req params.LoginRequest) (state.Entity, error) {
@@ -321,13 +321,20 @@ var doCheckCreds = checkCreds
 // If the entity is a user, and lookForModelUser is true, a model user must exist
 // for the model.  In the case of a user logging in to the controller, but
 // not a model, there is no env user needed.  While we have the env
-// user, if we do have it, update the last login time.
+// user, if we do have it, update the last login time. If lastConnections
+// is not nil, the model user's connection time is recorded through it.
 //
 // Note that when logging in with lookForModelUser true, the returned
 // entity will be modelUserEntity, not *state.User (external users
 // don't have user entries) or *state.ModelUser (we
 // don't want to lose the local user information associated with that).
-func checkCreds(st *state.State, req params.LoginRequest, lookForModelUser bool, authenticator authentication.EntityAuthenticator) (state.Entity, *time.Time, error) {
+func checkCreds(
+	st *state.State,
+	req params.LoginRequest,
+	lookForModelUser bool,
+	authenticator authentication.EntityAuthenticator,
+	lastConnections *lastConnectionRecorder,
+) (state.Entity, *time.Time, error) {
 	var tag names.Tag
 	if req.AuthTag != "" {
 		var err error
@@ -341,7 +348,7 @@ func checkCreds(st *state.State, req params.LoginRequest, lookForModelUser bool,
 		// When looking up model users, use a custom
 		// entity finder that looks up both the local user (if the user
 		// tag is in the local domain) and the model user.
-		entityFinder = modelUserEntityFinder{st}
+		entityFinder = modelUserEntityFinder{st, lastConnections}
 	}
 	entity, err := authenticator.Authenticate(entityFinder, tag, req)
 	if err != nil {
@@ -369,7 +376,7 @@ func checkControllerMachineCreds(
 	req params.LoginRequest,
 	authenticator authentication.EntityAuthenticator,
 ) (state.Entity, error) {
-	entity, _, err := doCheckCreds(controllerSt, req, false, authenticator)
+	entity, _, err := doCheckCreds(controllerSt, req, false, authenticator, nil)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -397,7 +404,8 @@ type loginEntity interface {
 // state's current model as well as retrieving more global
 // authentication details such as the password.
 type modelUserEntityFinder struct {
-	st *state.State
+	st              *state.State
+	lastConnections *lastConnectionRecorder
 }
 
 // FindEntity implements authentication.EntityFinder.FindEntity.
@@ -412,9 +420,10 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 		return nil, errors.Trace(err)
 	}
 	u := &modelUserEntity{
-		st:             f.st,
-		modelUser:      modelUser,
-		controllerUser: controllerUser,
+		st:              f.st,
+		lastConnections: f.lastConnections,
+		modelUser:       modelUser,
+		controllerUser:  controllerUser,
 	}
 	if utag.IsLocal() {
 		user, err := f.st.User(utag)
@@ -436,6 +445,10 @@ var _ loginEntity = &modelUserEntity{}
 type modelUserEntity struct {
 	st *state.State
 
+	// lastConnections, if not nil, records the model user's
+	// connection time in place of writing it directly.
+	lastConnections *lastConnectionRecorder
+
 	controllerUser permission.UserAccess
 	modelUser      permission.UserAccess
 	user           *state.User
@@ -510,7 +523,11 @@ func (u *modelUserEntity) UpdateLastLogin() error {
 			return errors.NotValidf("%s as model user", u.modelUser.Object.Kind())
 		}
 
-		err = u.st.UpdateLastModelConnection(u.modelUser.UserTag)
+		if u.lastConnections != nil {
+			err = u.lastConnections.record(u.modelUser.Object.Id(), u.modelUser.UserTag)
+		} else {
+			err = u.st.UpdateLastModelConnection(u.modelUser.UserTag)
+		}
 	}
 
 	if u.user != nil {
@@ -23,6 +23,8 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Agent", 2, NewAgentAPIV2)
+	// Version 3 adds WatchControllerConfig.
+	common.RegisterStandardFacade("Agent", 3, NewAgentAPIV3)
 }
 
 // AgentAPIV2 implements the version 2 of the API provided to an agent.
@@ -61,6 +63,25 @@ func NewAgentAPIV2(st *state.State, resources facade.Resources, auth facade.Auth
 	}, nil
 }
 
+// AgentAPIV3 implements the version 3 of the API provided to an agent.
+type AgentAPIV3 struct {
+	*AgentAPIV2
+	*common.ControllerConfigWatcher
+}
+
+// NewAgentAPIV3 returns an object implementing version 3 of the Agent API
+// with the given authorizer representing the currently logged in client.
+func NewAgentAPIV3(st *state.State, resources facade.Resources, auth facade.Authorizer) (*AgentAPIV3, error) {
+	v2, err := NewAgentAPIV2(st, resources, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &AgentAPIV3{
+		AgentAPIV2:              v2,
+		ControllerConfigWatcher: common.NewControllerConfigWatcher(st, resources),
+	}, nil
+}
+
 func (api *AgentAPIV2) GetEntities(args params.Entities) params.AgentGetEntitiesResults {
 	results := params.AgentGetEntitiesResults{
 		Entities: make([]params.AgentGetEntitiesResult, len(args.Entities)),
@@ -20,8 +20,10 @@ import (
 	_ "github.com/juju/juju/apiserver/block"   // ModelUser Write
 	_ "github.com/juju/juju/apiserver/bundle"
 	_ "github.com/juju/juju/apiserver/charmrevisionupdater"
+	_ "github.com/juju/juju/apiserver/charmrollback"
 	_ "github.com/juju/juju/apiserver/charms" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/cleaner"
+	_ "github.com/juju/juju/apiserver/cleanups"   // ModelUser Admin
 	_ "github.com/juju/juju/apiserver/client"     // ModelUser Write
 	_ "github.com/juju/juju/apiserver/cloud"      // ModelUser Read
 	_ "github.com/juju/juju/apiserver/controller" // ModelUser Admin (although some methods check for read only)
@@ -29,6 +31,7 @@ import (
 	_ "github.com/juju/juju/apiserver/deployer"
 	_ "github.com/juju/juju/apiserver/discoverspaces"
 	_ "github.com/juju/juju/apiserver/diskmanager"
+	_ "github.com/juju/juju/apiserver/enginereport" // ModelUser Admin (Reports)
 	_ "github.com/juju/juju/apiserver/firewaller"
 	_ "github.com/juju/juju/apiserver/highavailability" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/hostkeyreporter"
@@ -62,8 +65,9 @@ import (
 	_ "github.com/juju/juju/apiserver/resumer"
 	_ "github.com/juju/juju/apiserver/retrystrategy"
 	_ "github.com/juju/juju/apiserver/singular"
-	_ "github.com/juju/juju/apiserver/spaces"    // ModelUser Write
-	_ "github.com/juju/juju/apiserver/sshclient" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/spaces"       // ModelUser Write
+	_ "github.com/juju/juju/apiserver/sshclient"    // ModelUser Write
+	_ "github.com/juju/juju/apiserver/stagedconfig" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/statushistory"
 	_ "github.com/juju/juju/apiserver/storage" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/storageprovisioner"
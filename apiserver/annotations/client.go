@@ -4,7 +4,11 @@
 package annotations
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -16,6 +20,8 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Annotations", 2, NewAPI)
+	// Version 3 adds Query.
+	common.RegisterStandardFacade("Annotations", 3, NewAPI)
 }
 
 var getState = func(st *state.State) annotationAccess {
@@ -26,8 +32,19 @@ var getState = func(st *state.State) annotationAccess {
 type Annotations interface {
 	Get(args params.Entities) params.AnnotationsGetResults
 	Set(args params.AnnotationsSet) params.ErrorResults
+	Query(args params.AnnotationsQuery) (params.AnnotationsGetResults, error)
 }
 
+// annotatableKinds holds the kinds of entity that may be
+// annotated.
+var annotatableKinds = set.NewStrings(
+	names.ModelTagKind,
+	names.MachineTagKind,
+	names.ApplicationTagKind,
+	names.UnitTagKind,
+	names.CharmTagKind,
+)
+
 // API implements the service interface and is the concrete
 // implementation of the api end point.
 type API struct {
@@ -119,6 +136,70 @@ func (api *API) Set(args params.AnnotationsSet) params.ErrorResults {
 	return params.ErrorResults{Results: setErrors}
 }
 
+// Query returns, in a single call, the annotations whose keys start
+// with the given prefix for either the given entities or all entities
+// of the given kinds. When querying by kind, only entities that have
+// matching annotations are included in the results.
+func (api *API) Query(args params.AnnotationsQuery) (params.AnnotationsGetResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.AnnotationsGetResults{}, err
+	}
+	if len(args.Entities) > 0 && len(args.Kinds) > 0 {
+		return params.AnnotationsGetResults{}, errors.New("cannot query annotations by both entity and kind")
+	}
+	if len(args.Entities) > 0 {
+		results := api.Get(params.Entities{args.Entities})
+		for i, result := range results.Results {
+			if result.Error.Error == nil {
+				results.Results[i].Annotations = annotationsWithPrefix(result.Annotations, args.KeyPrefix)
+			}
+		}
+		return results, nil
+	}
+
+	for _, kind := range args.Kinds {
+		if !annotatableKinds.Contains(kind) {
+			return params.AnnotationsGetResults{}, errors.NotValidf("entity kind %q", kind)
+		}
+	}
+	all, err := api.access.AnnotationsByKind(args.Kinds...)
+	if err != nil {
+		return params.AnnotationsGetResults{}, errors.Trace(err)
+	}
+	tags := make([]string, 0, len(all))
+	for tag := range all {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	results := []params.AnnotationsGetResult{}
+	for _, tag := range tags {
+		annotations := annotationsWithPrefix(all[tag], args.KeyPrefix)
+		if len(annotations) == 0 {
+			continue
+		}
+		results = append(results, params.AnnotationsGetResult{
+			EntityTag:   tag,
+			Annotations: annotations,
+		})
+	}
+	return params.AnnotationsGetResults{Results: results}, nil
+}
+
+// annotationsWithPrefix returns the annotations whose keys start
+// with the given prefix.
+func annotationsWithPrefix(annotations map[string]string, prefix string) map[string]string {
+	if prefix == "" {
+		return annotations
+	}
+	result := make(map[string]string)
+	for key, value := range annotations {
+		if strings.HasPrefix(key, prefix) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
 func annotateError(err error, tag, op string) *params.Error {
 	return common.ServerError(
 		errors.Trace(
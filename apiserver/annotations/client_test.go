@@ -231,6 +231,87 @@ func (s *annotationSuite) TestMultipleEntitiesAnnotations(c *gc.C) {
 	c.Assert(rGet, jc.IsTrue)
 }
 
+func (s *annotationSuite) TestQueryByKind(c *gc.C) {
+	charm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"})
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: charm,
+	})
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	machine := s.Factory.MakeMachine(c, nil)
+	setResult := s.annotationsAPI.Set(params.AnnotationsSet{Annotations: []params.EntityAnnotations{{
+		EntityTag:   wordpress.Tag().String(),
+		Annotations: map[string]string{"gui-x": "10", "gui-y": "20", "other": "a"},
+	}, {
+		EntityTag:   mysql.Tag().String(),
+		Annotations: map[string]string{"other": "b"},
+	}, {
+		EntityTag:   machine.Tag().String(),
+		Annotations: map[string]string{"gui-x": "30"},
+	}}})
+	c.Assert(setResult.Combine(), jc.ErrorIsNil)
+
+	result, err := s.annotationsAPI.Query(params.AnnotationsQuery{
+		Kinds:     []string{names.ApplicationTagKind},
+		KeyPrefix: "gui-",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.AnnotationsGetResult{{
+		EntityTag:   wordpress.Tag().String(),
+		Annotations: map[string]string{"gui-x": "10", "gui-y": "20"},
+	}})
+
+	result, err = s.annotationsAPI.Query(params.AnnotationsQuery{
+		Kinds: []string{names.ApplicationTagKind, names.MachineTagKind},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.AnnotationsGetResult{{
+		EntityTag:   mysql.Tag().String(),
+		Annotations: map[string]string{"other": "b"},
+	}, {
+		EntityTag:   wordpress.Tag().String(),
+		Annotations: map[string]string{"gui-x": "10", "gui-y": "20", "other": "a"},
+	}, {
+		EntityTag:   machine.Tag().String(),
+		Annotations: map[string]string{"gui-x": "30"},
+	}})
+}
+
+func (s *annotationSuite) TestQueryByEntity(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	setResult := s.annotationsAPI.Set(params.AnnotationsSet{Annotations: []params.EntityAnnotations{{
+		EntityTag:   machine.Tag().String(),
+		Annotations: map[string]string{"gui-x": "30", "other": "a"},
+	}}})
+	c.Assert(setResult.Combine(), jc.ErrorIsNil)
+
+	result, err := s.annotationsAPI.Query(params.AnnotationsQuery{
+		Entities:  []params.Entity{{machine.Tag().String()}},
+		KeyPrefix: "gui-",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.AnnotationsGetResult{{
+		EntityTag:   machine.Tag().String(),
+		Annotations: map[string]string{"gui-x": "30"},
+	}})
+}
+
+func (s *annotationSuite) TestQueryInvalid(c *gc.C) {
+	_, err := s.annotationsAPI.Query(params.AnnotationsQuery{
+		Kinds: []string{"relation"},
+	})
+	c.Assert(err, gc.ErrorMatches, `entity kind "relation" not valid`)
+
+	_, err = s.annotationsAPI.Query(params.AnnotationsQuery{
+		Entities: []params.Entity{{"machine-0"}},
+		Kinds:    []string{names.MachineTagKind},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot query annotations by both entity and kind")
+}
+
 func (s *annotationSuite) testSetGetEntitiesAnnotations(c *gc.C, tag names.Tag) {
 	entity := tag.String()
 	entities := []string{entity}
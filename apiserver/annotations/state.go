@@ -12,6 +12,7 @@ import (
 type annotationAccess interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 	GetAnnotations(entity state.GlobalEntity) (map[string]string, error)
+	AnnotationsByKind(kinds ...string) (map[string]map[string]string, error)
 	SetAnnotations(entity state.GlobalEntity, annotations map[string]string) error
 	ModelTag() names.ModelTag
 }
@@ -28,6 +29,10 @@ func (s stateShim) GetAnnotations(entity state.GlobalEntity) (map[string]string,
 	return s.state.Annotations(entity)
 }
 
+func (s stateShim) AnnotationsByKind(kinds ...string) (map[string]map[string]string, error) {
+	return s.state.AnnotationsByKind(kinds...)
+}
+
 func (s stateShim) SetAnnotations(entity state.GlobalEntity, annotations map[string]string) error {
 	return s.state.SetAnnotations(entity, annotations)
 }
@@ -14,6 +14,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/bmizerany/pat"
 	"github.com/juju/errors"
@@ -21,6 +22,8 @@ import (
 	"github.com/juju/pubsub"
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 	"golang.org/x/net/websocket"
@@ -44,6 +47,10 @@ var logger = loggo.GetLogger("juju.apiserver")
 // accept
 const loginRateLimit = 10
 
+// loginRetryDelay is how long agents whose logins are rate limited
+// are asked to wait before trying again.
+const loginRetryDelay = 5 * time.Second
+
 // Server holds the server side of the API.
 type Server struct {
 	tomb              tomb.Tomb
@@ -69,6 +76,20 @@ type Server struct {
 	tlsConfig         *tls.Config
 	allowModelAccess  bool
 	logSinkWriter     io.WriteCloser
+	keepalive         *websocketKeepalive
+	lastConnections   *lastConnectionRecorder
+
+	// compressionThreshold holds the size above which responses
+	// are compressed for clients that accept compression.
+	compressionThreshold int
+
+	// prometheusGatherer, if not nil, provides the metrics
+	// served at /metrics.
+	prometheusGatherer prometheus.Gatherer
+
+	// logSinkConfig holds the limits applied to the logs that
+	// agents send to the logsink endpoint.
+	logSinkConfig logSinkConfig
 
 	// mu guards the fields below it.
 	mu sync.Mutex
@@ -118,6 +139,44 @@ type ServerConfig struct {
 	// notified of key events during API requests.
 	NewObserver observer.ObserverFactory
 
+	// WebsocketPingInterval holds how often the clients of websocket
+	// connections, such as API connections and log streams, are
+	// pinged. If it is zero, DefaultWebsocketPingInterval is used.
+	WebsocketPingInterval time.Duration
+
+	// CompressionThreshold holds the size, in bytes, above which
+	// the responses sent on API connections are compressed, for
+	// clients that accept compressed responses. If it is zero,
+	// responses are never compressed.
+	CompressionThreshold int
+
+	// WebsocketMaxMissedPongs holds how many pings in a row a
+	// websocket client may leave unanswered before its connection
+	// is closed. If it is zero, DefaultWebsocketMaxMissedPongs is
+	// used.
+	WebsocketMaxMissedPongs int
+
+	// LogSinkRateLimit holds the number of log messages per second
+	// that each agent may send to the controller, on average; further
+	// messages are dropped. If it is zero, there is no limit.
+	LogSinkRateLimit int
+
+	// LogSinkRateLimitBurst holds the number of log messages that an
+	// agent may send in a burst faster than LogSinkRateLimit. If it
+	// is zero, LogSinkRateLimit is used.
+	LogSinkRateLimitBurst int
+
+	// LogSinkBufferSize holds the number of log messages from each
+	// agent that may wait to be written; when the buffer is full,
+	// further messages are dropped. If it is zero,
+	// DefaultLogSinkBufferSize is used.
+	LogSinkBufferSize int
+
+	// PrometheusGatherer, if not nil, provides the metrics served
+	// at /metrics when the controller's metrics-endpoint-enabled
+	// config attribute is true.
+	PrometheusGatherer prometheus.Gatherer
+
 	// StatePool only exists to support testing.
 	StatePool *state.StatePool
 }
@@ -136,6 +195,18 @@ func (c *ServerConfig) Validate() error {
 	return nil
 }
 
+func (c *ServerConfig) logSinkConfig() logSinkConfig {
+	bufferSize := c.LogSinkBufferSize
+	if bufferSize == 0 {
+		bufferSize = DefaultLogSinkBufferSize
+	}
+	return logSinkConfig{
+		RateLimit:      c.LogSinkRateLimit,
+		RateLimitBurst: c.LogSinkRateLimitBurst,
+		BufferSize:     bufferSize,
+	}
+}
+
 func (c *ServerConfig) pingClock() clock.Clock {
 	if c.PingClock == nil {
 		return c.Clock
@@ -191,7 +262,18 @@ func newServer(s *state.State, lis net.Listener, cfg ServerConfig) (_ *Server, e
 		centralHub:       cfg.Hub,
 		certChanged:      cfg.CertChanged,
 		allowModelAccess: cfg.AllowModelAccess,
+
+		compressionThreshold: cfg.CompressionThreshold,
+		prometheusGatherer:   cfg.PrometheusGatherer,
+		logSinkConfig:        cfg.logSinkConfig(),
 	}
+	srv.keepalive = newWebsocketKeepalive(
+		srv.clock, cfg.WebsocketPingInterval, cfg.WebsocketMaxMissedPongs,
+	)
+
+	srv.lastConnections = newLastConnectionRecorder(
+		s, srv.clock, lastConnectionFlushInterval,
+	)
 
 	srv.tlsConfig = srv.newTLSConfig(cfg)
 	srv.lis = tls.NewListener(lis, srv.tlsConfig)
@@ -322,6 +404,12 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.processModelRemovals())
 	}()
 
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.lastConnections.loop(srv.tomb.Dying()))
+	}()
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -386,11 +474,12 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/model/:modeluuid/logstream", logStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
 
-	logSinkHandler := newLogSinkHandler(httpCtxt, srv.logSinkWriter, newAgentLoggingStrategy)
+	logSinkHandler := newLogSinkHandler(httpCtxt, srv.logSinkWriter, newAgentLoggingStrategy, srv.logSinkConfig, srv.clock)
 	add("/model/:modeluuid/logsink", srv.trackRequests(logSinkHandler))
 
 	// We don't need to save the migrated logs to a logfile as well as to the DB.
-	logTransferHandler := newLogSinkHandler(httpCtxt, ioutil.Discard, newMigrationLoggingStrategy)
+	// Migrated logs are never dropped, so no limits are applied.
+	logTransferHandler := newLogSinkHandler(httpCtxt, ioutil.Discard, newMigrationLoggingStrategy, logSinkConfig{}, srv.clock)
 	add("/migrate/logtransfer", srv.trackRequests(logTransferHandler))
 
 	modelRestHandler := &modelRestHandler{
@@ -483,6 +572,15 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			ctxt: httpCtxt,
 		},
 	)
+	add("/health", &healthHandler{
+		checks: srv.healthChecks(),
+	})
+	if srv.prometheusGatherer != nil {
+		add("/metrics", &metricsHandler{
+			enabled: srv.metricsEndpointEnabled,
+			handler: promhttp.HandlerFor(srv.prometheusGatherer, promhttp.HandlerOpts{}),
+		})
+	}
 	add("/api", mainAPIHandler)
 	// Serve the API at / (only) for backward compatiblity. Note that the
 	// pat muxer special-cases / so that it does not serve all
@@ -598,20 +696,21 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 	apiObserver.Join(req, connectionID)
 	defer apiObserver.Leave()
 
-	wsServer := websocket.Server{
-		Handler: func(conn *websocket.Conn) {
-			modelUUID := req.URL.Query().Get(":modeluuid")
-			logger.Tracef("got a request for model %q", modelUUID)
-			if err := srv.serveConn(conn, modelUUID, apiObserver, req.Host); err != nil {
-				logger.Errorf("error serving RPCs: %v", err)
-			}
-		},
-	}
-	wsServer.ServeHTTP(w, req)
+	srv.keepalive.serve(w, req, false, func(conn *websocket.Conn) {
+		modelUUID := req.URL.Query().Get(":modeluuid")
+		logger.Tracef("got a request for model %q", modelUUID)
+		threshold := 0
+		if req.Header.Get(params.AcceptCompressionHeader) == params.CompressionGzip {
+			threshold = srv.compressionThreshold
+		}
+		if err := srv.serveConn(conn, modelUUID, apiObserver, req.Host, threshold); err != nil {
+			logger.Errorf("error serving RPCs: %v", err)
+		}
+	})
 }
 
-func (srv *Server) serveConn(wsConn *websocket.Conn, modelUUID string, apiObserver observer.Observer, host string) error {
-	codec := jsoncodec.NewWebsocket(wsConn)
+func (srv *Server) serveConn(wsConn *websocket.Conn, modelUUID string, apiObserver observer.Observer, host string, compressionThreshold int) error {
+	codec := jsoncodec.NewWebsocketCompressing(wsConn, compressionThreshold)
 
 	conn := rpc.NewConn(codec, apiObserver)
 
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	jjj "github.com/juju/juju/juju"
@@ -42,6 +43,18 @@ func init() {
 
 	// Version 3 adds support for cross model relations.
 	common.RegisterStandardFacade("Application", 3, newAPI)
+
+	// Version 4 adds ToCIDRs to Expose.
+	common.RegisterStandardFacade("Application", 4, newAPIV4)
+
+	// Version 5 adds PinLeadership and UnpinLeadership.
+	common.RegisterStandardFacade("Application", 5, newAPIV5)
+
+	// Version 6 adds AutoRollback to SetCharm.
+	common.RegisterStandardFacade("Application", 6, newAPIV6)
+
+	// Version 7 adds Scale.
+	common.RegisterStandardFacade("Application", 7, newAPIV7)
 }
 
 // API implements the application interface and is the concrete
@@ -60,6 +73,78 @@ type API struct {
 	stateCharm func(Charm) *state.Charm
 }
 
+// APIV4 implements version 4 of the application facade, whose Expose
+// honours ToCIDRs.
+type APIV4 struct {
+	*API
+}
+
+// APIV5 implements version 5 of the application facade, which
+// adds leadership pinning.
+type APIV5 struct {
+	*APIV4
+}
+
+// APIV6 implements version 6 of the application facade, whose SetCharm
+// honours AutoRollback.
+type APIV6 struct {
+	*APIV5
+}
+
+// APIV7 implements version 7 of the application facade, which adds
+// Scale.
+type APIV7 struct {
+	*APIV6
+}
+
+func newAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV4, error) {
+	api, err := newAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV4{api}, nil
+}
+
+func newAPIV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV5, error) {
+	api, err := newAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV5{api}, nil
+}
+
+func newAPIV6(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV6, error) {
+	api, err := newAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV6{api}, nil
+}
+
+func newAPIV7(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV7, error) {
+	api, err := newAPIV6(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV7{api}, nil
+}
+
 func newAPI(
 	st *state.State,
 	resources facade.Resources,
@@ -202,6 +287,9 @@ func deployApplication(
 	if err := checkMinVersion(ch); err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkLXDProfile(backend, curl, ch); err != nil {
+		return errors.Trace(err)
+	}
 
 	var settings charm.Settings
 	if len(args.ConfigYAML) > 0 {
@@ -214,6 +302,11 @@ func deployApplication(
 		return errors.Trace(err)
 	}
 
+	usage := unitsQuotaUsage(args.NumUnits, args.Placement, deployStorageCount(ch.Meta(), args.Storage))
+	if err := backend.CheckQuotas(usage); err != nil {
+		return errors.Trace(err)
+	}
+
 	channel := csparams.Channel(args.Channel)
 
 	_, err = jjj.DeployApplication(backend,
@@ -233,6 +326,27 @@ func deployApplication(
 	return errors.Trace(err)
 }
 
+// checkLXDProfile returns an error if the charm ships an LXD profile
+// that fails the controller's safety checks, unless the model allows
+// unsafe profiles.
+func checkLXDProfile(backend Backend, curl *charm.URL, ch Charm) error {
+	validateErr := ch.LXDProfile().ValidateSafe()
+	if validateErr == nil {
+		return nil
+	}
+	cfg, err := backend.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.AllowUnsafeLXDProfiles() {
+		return nil
+	}
+	return errors.Annotatef(validateErr,
+		"charm %q has an unsafe lxd-profile (set %s to allow it)",
+		curl, config.AllowUnsafeLXDProfilesKey,
+	)
+}
+
 // ApplicationSetSettingsStrings updates the settings for the given application,
 // taking the configuration from a map of strings.
 func ApplicationSetSettingsStrings(application Application, settings map[string]string) error {
@@ -313,6 +427,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 			args.ForceCharmURL,
 			nil, // resource IDs
 			nil, // storage constraints
+			nil, // auto rollback
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -342,6 +457,19 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 
 // SetCharm sets the charm for a given for the application.
 func (api *API) SetCharm(args params.ApplicationSetCharm) error {
+	if args.AutoRollback != nil {
+		return errors.NotSupportedf("automatic charm rollback")
+	}
+	return api.setCharm(args)
+}
+
+// SetCharm sets the charm for a given for the application, optionally
+// recording how to revert the upgrade if too many units fail it.
+func (api *APIV6) SetCharm(args params.ApplicationSetCharm) error {
+	return api.setCharm(args)
+}
+
+func (api *API) setCharm(args params.ApplicationSetCharm) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
@@ -367,6 +495,7 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
+		args.AutoRollback,
 	)
 }
 
@@ -382,6 +511,7 @@ func (api *API) applicationSetCharm(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
+	autoRollback *params.CharmRollbackPolicy,
 ) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
@@ -400,6 +530,9 @@ func (api *API) applicationSetCharm(
 	if err != nil {
 		return errors.Annotate(err, "parsing config settings")
 	}
+	if err := checkLXDProfile(api.backend, curl, sch); err != nil {
+		return errors.Trace(err)
+	}
 	var stateStorageConstraints map[string]state.StorageConstraints
 	if len(storageConstraints) > 0 {
 		stateStorageConstraints = make(map[string]state.StorageConstraints)
@@ -423,6 +556,12 @@ func (api *API) applicationSetCharm(
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
 	}
+	if autoRollback != nil {
+		cfg.AutoRollback = &state.CharmRollbackPolicy{
+			Threshold: autoRollback.Threshold,
+			Window:    autoRollback.Window,
+		}
+	}
 	return application.SetCharm(cfg)
 }
 
@@ -569,6 +708,20 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Expose(args params.ApplicationExpose) error {
+	if len(args.ToCIDRs) > 0 {
+		return errors.NotSupportedf("exposing to CIDRs")
+	}
+	return api.expose(args)
+}
+
+// Expose changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open, optionally only to
+// the given CIDRs.
+func (api *APIV4) Expose(args params.ApplicationExpose) error {
+	return api.expose(args)
+}
+
+func (api *API) expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
@@ -579,7 +732,7 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return app.SetExposed()
+	return app.SetExposedToCIDRs(args.ToCIDRs)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -598,6 +751,45 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	return app.ClearExposed()
 }
 
+// PinLeadership prevents the leadership leases of the given
+// applications from expiring, so that their current leaders remain
+// in place while the controllers are upgraded or otherwise disrupted.
+func (api *APIV5) PinLeadership(args params.Entities) (params.ErrorResults, error) {
+	return api.setLeadershipPinned(args, Application.PinLeadership)
+}
+
+// UnpinLeadership allows the leadership leases of the given
+// applications to expire again.
+func (api *APIV5) UnpinLeadership(args params.Entities) (params.ErrorResults, error) {
+	return api.setLeadershipPinned(args, Application.UnpinLeadership)
+}
+
+func (api *API) setLeadershipPinned(args params.Entities, pin func(Application) error) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Error = common.ServerError(pin(app))
+	}
+	return result, nil
+}
+
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]*state.Unit, error) {
 	application, err := backend.Application(args.ApplicationName)
@@ -607,6 +799,13 @@ func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]*s
 	if args.NumUnits < 1 {
 		return nil, errors.New("must add at least one unit")
 	}
+	storageCount, err := unitStorageCount(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := backend.CheckQuotas(unitsQuotaUsage(args.NumUnits, args.Placement, storageCount)); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return jjj.AddUnits(backend, application, args.ApplicationName, args.NumUnits, args.Placement)
 }
 
@@ -885,11 +1084,16 @@ func (api *API) processRemoteApplication(url jujucrossmodel.ApplicationURL, alia
 	if err != nil {
 		return nil, names.ModelTag{}, errors.Trace(err)
 	}
+	consumerTags, err := api.offerConsumerTags()
+	if err != nil {
+		return nil, names.ModelTag{}, errors.Trace(err)
+	}
 	offers, err := offersAPI.ListOffers(params.OfferFilters{
 		Directory: url.Directory,
 		Filters: []params.OfferFilter{
 			{
-				ApplicationURL: url.String(),
+				ApplicationURL:  url.String(),
+				AllowedUserTags: consumerTags,
 			},
 		},
 	})
@@ -911,6 +1115,20 @@ func (api *API) processRemoteApplication(url jujucrossmodel.ApplicationURL, alia
 	return remoteApp, sourceModelTag, err
 }
 
+// offerConsumerTags returns the tags of the user and groups that the
+// authenticated user may consume application offers as.
+func (api *API) offerConsumerTags() ([]string, error) {
+	userTag, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	tags := []string{userTag.String()}
+	if !userTag.IsLocal() {
+		tags = append(tags, names.NewUserTag(common.EveryoneTagName).String())
+	}
+	return tags, nil
+}
+
 func (api *API) sameControllerSourceModel(userName, modelName string) (names.ModelTag, error) {
 	// Look up the model by qualified name, ie user/model.
 	var sourceModelTag names.ModelTag
@@ -1065,11 +1283,16 @@ func (api *API) oneRemoteApplicationInfo(urlStr string) (*params.RemoteApplicati
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	consumerTags, err := api.offerConsumerTags()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	offers, err := offersAPI.ListOffers(params.OfferFilters{
 		Directory: url.Directory,
 		Filters: []params.OfferFilter{
 			{
-				ApplicationURL: url.String(),
+				ApplicationURL:  url.String(),
+				AllowedUserTags: consumerTags,
 			},
 		},
 	})
@@ -33,6 +33,7 @@ import (
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/objectstore"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
@@ -429,8 +430,8 @@ func (s *serviceSuite) TestClientServicesDeployWithDefaultBindings(c *gc.C) {
 
 func (s *serviceSuite) TestAddCharm(c *gc.C) {
 	var blobs blobs
-	s.PatchValue(application.NewStateStorage, func(uuid string, session *mgo.Session) statestorage.Storage {
-		storage := statestorage.NewStorage(uuid, session)
+	s.PatchValue(application.NewStateStorage, func(uuid string, session *mgo.Session, stores *objectstore.Stores) statestorage.Storage {
+		storage := statestorage.NewStorage(uuid, session, stores)
 		return &recordingStorage{Storage: storage, blobs: &blobs}
 	})
 
@@ -469,7 +470,7 @@ func (s *serviceSuite) TestAddCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Verify it's in state and it got uploaded.
-	storage := statestorage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	storage := statestorage.NewStorage(s.State.ModelUUID(), s.State.MongoSession(), s.State.ObjectStores())
 	sch, err = s.State.Charm(curl)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertUploaded(c, storage, sch.StoragePath(), sch.BundleSha256())
@@ -522,8 +523,8 @@ func (s *serviceSuite) TestAddCharmConcurrently(c *gc.C) {
 
 	var putBarrier sync.WaitGroup
 	var blobs blobs
-	s.PatchValue(application.NewStateStorage, func(uuid string, session *mgo.Session) statestorage.Storage {
-		storage := statestorage.NewStorage(uuid, session)
+	s.PatchValue(application.NewStateStorage, func(uuid string, session *mgo.Session, stores *objectstore.Stores) statestorage.Storage {
+		storage := statestorage.NewStorage(uuid, session, stores)
 		return &recordingStorage{Storage: storage, blobs: &blobs, putBarrier: &putBarrier}
 	})
 
@@ -568,7 +569,7 @@ func (s *serviceSuite) TestAddCharmConcurrently(c *gc.C) {
 		}
 	}
 
-	storage := statestorage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	storage := statestorage.NewStorage(s.State.ModelUUID(), s.State.MongoSession(), s.State.ObjectStores())
 	s.assertUploaded(c, storage, sch.StoragePath(), sch.BundleSha256())
 }
 
@@ -647,6 +648,72 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(force, jc.IsFalse)
 }
 
+func (s *serviceSuite) deployForAutoRollback(c *gc.C) (oldURL, newURL *charm.URL) {
+	oldURL, _ = s.UploadCharm(c, "precise/dummy-0", "dummy")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: oldURL.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			CharmURL:        oldURL.String(),
+			ApplicationName: "application",
+			NumUnits:        2,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	newURL, _ = s.UploadCharm(c, "precise/dummy-1", "dummy")
+	err = application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: newURL.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return oldURL, newURL
+}
+
+func (s *serviceSuite) TestServiceSetCharmAutoRollback(c *gc.C) {
+	oldURL, newURL := s.deployForAutoRollback(c)
+	api := &application.APIV6{&application.APIV5{&application.APIV4{s.applicationAPI}}}
+	err := api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "application",
+		CharmURL:        newURL.String(),
+		AutoRollback: &params.CharmRollbackPolicy{
+			Threshold: 25,
+			Window:    time.Hour,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl, jc.DeepEquals, newURL)
+	rollback, ok := app.CharmRollback()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(rollback.PreviousCharmURL, jc.DeepEquals, oldURL)
+	c.Assert(rollback.CharmRollbackPolicy, jc.DeepEquals, state.CharmRollbackPolicy{
+		Threshold: 25,
+		Window:    time.Hour,
+	})
+}
+
+func (s *serviceSuite) TestServiceSetCharmAutoRollbackNotSupportedBeforeV6(c *gc.C) {
+	oldURL, newURL := s.deployForAutoRollback(c)
+	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "application",
+		CharmURL:        newURL.String(),
+		AutoRollback: &params.CharmRollbackPolicy{
+			Threshold: 25,
+			Window:    time.Hour,
+		},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	app, err := s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl, jc.DeepEquals, oldURL)
+}
+
 func (s *serviceSuite) setupServiceSetCharm(c *gc.C) {
 	curl, _ := s.UploadCharm(c, "precise/dummy-0", "dummy")
 	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -1748,6 +1815,182 @@ func (s *serviceSuite) TestAddServiceUnits(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) scaleAPI() *application.APIV7 {
+	return &application.APIV7{&application.APIV6{&application.APIV5{&application.APIV4{s.applicationAPI}}}}
+}
+
+// assertScaledDown checks that the named units are no longer alive.
+func (s *serviceSuite) assertScaledDown(c *gc.C, unitNames []string) {
+	for _, name := range unitNames {
+		unit, err := s.State.Unit(name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(unit.Life(), gc.Not(gc.Equals), state.Alive)
+	}
+}
+
+func (s *serviceSuite) TestScaleUp(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.scaleAPI().Scale(params.ScaleApplication{
+		ApplicationName: "dummy",
+		Scale:           3,
+		Placement:       []*instance.Placement{instance.MustParsePlacement(machine.Id())},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ScaleApplicationResult{
+		Added: []string{"dummy/1", "dummy/2"},
+	})
+	unit, err := s.State.Unit("dummy/1")
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, machine.Id())
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 3)
+}
+
+func (s *serviceSuite) TestScaleUnchanged(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	result, err := s.scaleAPI().Scale(params.ScaleApplication{
+		ApplicationName: "dummy",
+		Scale:           1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ScaleApplicationResult{})
+}
+
+func (s *serviceSuite) TestScaleDownYoungestFirst(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	for i := 0; i < 3; i++ {
+		s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	}
+	result, err := s.scaleAPI().Scale(params.ScaleApplication{
+		ApplicationName: "dummy",
+		Scale:           1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ScaleApplicationResult{
+		Removed: []string{"dummy/2", "dummy/1"},
+	})
+	s.assertScaledDown(c, result.Removed)
+}
+
+func (s *serviceSuite) TestScaleDownEmptiestMachineFirst(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	// dummy/0 has a machine to itself; dummy/1 shares one.
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	shared := s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application, Machine: shared})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: shared})
+
+	result, err := s.scaleAPI().Scale(params.ScaleApplication{
+		ApplicationName: "dummy",
+		Scale:           1,
+		RemovalPolicy:   params.ScaleRemoveEmptiestMachineFirst,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ScaleApplicationResult{
+		Removed: []string{"dummy/0"},
+	})
+	s.assertScaledDown(c, result.Removed)
+}
+
+func (s *serviceSuite) TestScaleErrors(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	for i, test := range []struct {
+		args params.ScaleApplication
+		err  string
+	}{{
+		args: params.ScaleApplication{ApplicationName: "dummy", Scale: -1},
+		err:  "scale -1 not valid",
+	}, {
+		args: params.ScaleApplication{ApplicationName: "dummy", RemovalPolicy: "oldest-first"},
+		err:  `removal policy "oldest-first" not valid`,
+	}, {
+		args: params.ScaleApplication{
+			ApplicationName: "dummy",
+			Placement:       []*instance.Placement{instance.MustParsePlacement("0")},
+		},
+		err: "placement directives can only be used when adding units",
+	}, {
+		args: params.ScaleApplication{ApplicationName: "logging", Scale: 2},
+		err:  `cannot scale subordinate application "logging"`,
+	}, {
+		args: params.ScaleApplication{ApplicationName: "unknown", Scale: 2},
+		err:  `application "unknown" not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.scaleAPI().Scale(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *serviceSuite) TestAddServiceUnitsQuotaExceeded(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err := s.State.UpdateModelConfig(map[string]interface{}{"max-units": 2}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.applicationAPI.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "dummy",
+		NumUnits:        2,
+	})
+	c.Assert(err, gc.ErrorMatches, `quota "max-units" exceeded \(limit 2\)`)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
+func (s *serviceSuite) TestScaleUserQuotaExceeded(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserQuota(model.Owner(), state.Quota{MaxMachines: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Placing a unit on an existing machine adds no machine.
+	_, err = s.scaleAPI().Scale(params.ScaleApplication{
+		ApplicationName: "dummy",
+		Scale:           2,
+		Placement:       []*instance.Placement{instance.MustParsePlacement(machine.Id())},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.scaleAPI().Scale(params.ScaleApplication{
+		ApplicationName: "dummy",
+		Scale:           3,
+	})
+	c.Assert(err, gc.ErrorMatches, `quota "max-machines" for user ".*" exceeded \(limit 2\)`)
+}
+
+func (s *serviceSuite) TestScaleBlocked(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	s.BlockRemoveObject(c, "TestScaleBlocked")
+	_, err := s.scaleAPI().Scale(params.ScaleApplication{ApplicationName: "dummy"})
+	s.AssertBlocked(c, err, "TestScaleBlocked")
+}
+
+func (s *serviceSuite) TestScaleNotInV6(c *gc.C) {
+	_, ok := interface{}(&application.APIV6{}).(interface {
+		Scale(params.ScaleApplication) (params.ScaleApplicationResult, error)
+	})
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *serviceSuite) assertAddServiceUnits(c *gc.C) {
 	result, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
 		ApplicationName: "dummy",
@@ -1832,7 +2075,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1844,6 +2087,42 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeToCIDRs(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingService(c, "dummy-service", charm)
+
+	api := &application.APIV4{s.applicationAPI}
+	err := api.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = api.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"bad"},
+	})
+	c.Assert(err, gc.ErrorMatches, `CIDR "bad" not valid`)
+}
+
+func (s *serviceSuite) TestServiceExposeToCIDRsNotSupportedBeforeV4(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingService(c, "dummy-service", charm)
+
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsFalse)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1884,7 +2163,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1899,7 +2178,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2545,23 +2824,23 @@ func (s *serviceSuite) TestAddAlreadyAddedRelation(c *gc.C) {
 
 type mockApplicationOffersFactory struct {
 	facade.Resource
-	offers []params.ApplicationOffer
+	offers  []params.ApplicationOffer
+	filters []params.OfferFilters
 }
 
 type mockApplicationOffersAPI struct {
 	crossmodel.ApplicationOffersAPI
-	offers []params.ApplicationOffer
+	factory *mockApplicationOffersFactory
 }
 
 func (m *mockApplicationOffersFactory) ApplicationOffers(directory string) (crossmodel.ApplicationOffersAPI, error) {
-	return &mockApplicationOffersAPI{
-		offers: m.offers,
-	}, nil
+	return &mockApplicationOffersAPI{factory: m}, nil
 }
 
 func (m *mockApplicationOffersAPI) ListOffers(filters params.OfferFilters) (params.ApplicationOfferResults, error) {
+	m.factory.filters = append(m.factory.filters, filters)
 	return params.ApplicationOfferResults{
-		Offers: m.offers,
+		Offers: m.factory.offers,
 	}, nil
 }
 
@@ -2957,6 +3236,23 @@ func (s *serviceSuite) TestConsumeDirectAndURL(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *serviceSuite) TestConsumeFiltersOnAllowedUsers(c *gc.C) {
+	s.offersApiFactory.offers = remoteOffers()
+	_, err := s.applicationAPI.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{
+			{ApplicationURL: "local:/u/me/hosted-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.offersApiFactory.filters, jc.DeepEquals, []params.OfferFilters{{
+		Directory: "local",
+		Filters: []params.OfferFilter{{
+			ApplicationURL:  "local:/u/me/hosted-mysql",
+			AllowedUserTags: []string{s.authorizer.Tag.String()},
+		}},
+	}})
+}
+
 func (s *serviceSuite) TestConsumeAlreadyExists(c *gc.C) {
 	_, err := s.otherModel.AddApplication(state.AddApplicationArgs{
 		Name:  "mysql",
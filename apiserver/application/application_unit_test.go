@@ -15,6 +15,8 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -104,7 +106,7 @@ func (s *ApplicationSuite) TestSetCharmConfigSettings(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm")
-	s.charm.CheckCallNames(c, "Config")
+	s.charm.CheckCallNames(c, "Config", "LXDProfile")
 	s.application.CheckCallNames(c, "SetCharm")
 	s.application.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
 		Charm:          &state.Charm{},
@@ -123,7 +125,7 @@ postgresql:
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm")
-	s.charm.CheckCallNames(c, "Config")
+	s.charm.CheckCallNames(c, "Config", "LXDProfile")
 	s.application.CheckCallNames(c, "SetCharm")
 	s.application.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
 		Charm:          &state.Charm{},
@@ -131,11 +133,111 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmUnsafeLXDProfile(c *gc.C) {
+	s.charm.lxdProfile = &lxdprofile.Profile{
+		Config: map[string]string{"boot.autostart": "false"},
+	}
+	s.backend.modelConfig = coretesting.ModelConfig(c)
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.ErrorMatches, `charm "cs:postgresql" has an unsafe lxd-profile \(set allow-unsafe-lxd-profiles to allow it\): lxd-profile config "boot.autostart" not valid`)
+	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm", "ModelConfig")
+	s.application.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetCharmUnsafeLXDProfileAllowed(c *gc.C) {
+	s.charm.lxdProfile = &lxdprofile.Profile{
+		Config: map[string]string{"boot.autostart": "false"},
+	}
+	cfg, err := coretesting.ModelConfig(c).Apply(map[string]interface{}{
+		"allow-unsafe-lxd-profiles": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.modelConfig = cfg
+	err = s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.application.CheckCallNames(c, "SetCharm")
+}
+
+func (s *ApplicationSuite) TestSetCharmSafeLXDProfile(c *gc.C) {
+	s.charm.lxdProfile = &lxdprofile.Profile{
+		Config: map[string]string{"user.vendor-data": "{}"},
+		Devices: map[string]map[string]string{
+			"vfio": {"type": "unix-char", "path": "/dev/vfio/vfio"},
+		},
+	}
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm")
+	s.application.CheckCallNames(c, "SetCharm")
+}
+
+func (s *ApplicationSuite) TestPinLeadership(c *gc.C) {
+	api := &application.APIV5{&application.APIV4{s.api}}
+	results, err := api.PinLeadership(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+	s.backend.CheckCallNames(c, "ModelTag", "Application")
+	s.backend.CheckCall(c, 1, "Application", "postgresql")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.application.CheckCallNames(c, "PinLeadership")
+}
+
+func (s *ApplicationSuite) TestUnpinLeadership(c *gc.C) {
+	s.application.SetErrors(errors.New("boom"))
+	api := &application.APIV5{&application.APIV4{s.api}}
+	results, err := api.UnpinLeadership(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+	s.application.CheckCallNames(c, "UnpinLeadership")
+}
+
+func (s *ApplicationSuite) TestPinLeadershipBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(common.OperationBlockedError("TestPinLeadershipBlocked"))
+	api := &application.APIV5{&application.APIV4{s.api}}
+	_, err := api.PinLeadership(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "TestPinLeadershipBlocked")
+	s.application.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestPinLeadershipNotInV4(c *gc.C) {
+	_, ok := interface{}(&application.APIV4{s.api}).(interface {
+		PinLeadership(params.Entities) (params.ErrorResults, error)
+	})
+	c.Assert(ok, jc.IsFalse)
+}
+
 type mockBackend struct {
 	application.Backend
 	testing.Stub
 	application *mockApplication
 	charm       *mockCharm
+	modelConfig *config.Config
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	return b.modelConfig, b.NextErr()
 }
 
 func (b *mockBackend) ModelTag() names.ModelTag {
@@ -176,10 +278,21 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) PinLeadership() error {
+	a.MethodCall(a, "PinLeadership")
+	return a.NextErr()
+}
+
+func (a *mockApplication) UnpinLeadership() error {
+	a.MethodCall(a, "UnpinLeadership")
+	return a.NextErr()
+}
+
 type mockCharm struct {
 	application.Charm
 	testing.Stub
-	config *charm.Config
+	config     *charm.Config
+	lxdProfile *lxdprofile.Profile
 }
 
 func (c *mockCharm) Config() *charm.Config {
@@ -188,6 +301,12 @@ func (c *mockCharm) Config() *charm.Config {
 	return c.config
 }
 
+func (c *mockCharm) LXDProfile() *lxdprofile.Profile {
+	c.MethodCall(c, "LXDProfile")
+	c.PopNoErr()
+	return c.lxdProfile
+}
+
 type mockBlockChecker struct {
 	testing.Stub
 }
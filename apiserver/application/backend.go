@@ -9,6 +9,8 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -28,9 +30,11 @@ type Backend interface {
 	AssignUnit(*state.Unit, state.AssignmentPolicy) error
 	AssignUnitWithPlacement(*state.Unit, *instance.Placement) error
 	Charm(*charm.URL) (Charm, error)
+	CheckQuotas(state.QuotaUsage) error
 	EndpointsRelation(...state.Endpoint) (Relation, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
 	Machine(string) (Machine, error)
+	ModelConfig() (*config.Config, error)
 	ModelTag() names.ModelTag
 	Unit(string) (Unit, error)
 	NewStorage() storage.Storage
@@ -50,6 +54,7 @@ type BlockChecker interface {
 // the same names.
 type Application interface {
 	AddUnit() (*state.Unit, error)
+	AllUnits() ([]*state.Unit, error)
 	Charm() (Charm, bool, error)
 	CharmURL() (*charm.URL, bool)
 	Channel() csparams.Channel
@@ -59,12 +64,16 @@ type Application interface {
 	Destroy() error
 	Endpoints() ([]state.Endpoint, error)
 	IsPrincipal() bool
+	PinLeadership() error
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	SetExposed() error
+	SetExposedToCIDRs([]string) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	StorageConstraints() (map[string]state.StorageConstraints, error)
+	UnpinLeadership() error
 	UpdateConfigSettings(charm.Settings) error
 }
 
@@ -74,6 +83,7 @@ type Application interface {
 // the same names.
 type Charm interface {
 	charm.Charm
+	LXDProfile() *lxdprofile.Profile
 	StoragePath() string
 }
 
@@ -82,6 +92,7 @@ type Charm interface {
 // details on the methods, see the methods on state.Machine with
 // the same names.
 type Machine interface {
+	Units() ([]*state.Unit, error)
 }
 
 // Relation defines a subset of the functionality provided by the
@@ -131,7 +142,7 @@ func CharmToStateCharm(ch Charm) *state.Charm {
 }
 
 func (s stateShim) NewStorage() storage.Storage {
-	return storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	return storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession(), s.State.ObjectStores())
 }
 
 func (s stateShim) Application(name string) (Application, error) {
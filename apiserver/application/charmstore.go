@@ -20,6 +20,9 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/charmfeatures"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -67,7 +70,7 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	}
 
 	// Open a charm store client.
-	repo, err := openCSRepo(args)
+	repo, err := openCSRepo(st, args)
 	if err != nil {
 		return err
 	}
@@ -124,8 +127,12 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	return StoreCharmArchive(st, ca)
 }
 
-func openCSRepo(args params.AddCharmWithAuthorization) (charmrepo.Interface, error) {
-	csClient, err := openCSClient(args)
+func openCSRepo(st *state.State, args params.AddCharmWithAuthorization) (charmrepo.Interface, error) {
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	csClient, err := openCSClient(controllerConfig, args)
 	if err != nil {
 		return nil, err
 	}
@@ -133,8 +140,14 @@ func openCSRepo(args params.AddCharmWithAuthorization) (charmrepo.Interface, err
 	return repo, nil
 }
 
-func openCSClient(args params.AddCharmWithAuthorization) (*csclient.Client, error) {
-	csURL, err := url.Parse(csclient.ServerURL)
+// openCSClient returns a client for the charm store configured for
+// the controller, falling back to the standard juju charmstore.
+func openCSClient(controllerConfig controller.Config, args params.AddCharmWithAuthorization) (*csclient.Client, error) {
+	server := controllerConfig.CharmStoreURL()
+	if server == "" {
+		server = csclient.ServerURL
+	}
+	csURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
@@ -201,7 +214,18 @@ type CharmArchive struct {
 
 // StoreCharmArchive stores a charm archive in environment storage.
 func StoreCharmArchive(st *state.State, archive CharmArchive) error {
-	storage := newStateStorage(st.ModelUUID(), st.MongoSession())
+	lxdProfile, err := readLXDProfile(archive.Charm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	features, err := readJujuFeatures(archive.Charm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := charmfeatures.Check(features); err != nil {
+		return errors.Trace(err)
+	}
+	storage := newStateStorage(st.ModelUUID(), st.MongoSession(), st.ObjectStores())
 	storagePath, err := charmArchiveStoragePath(archive.ID)
 	if err != nil {
 		return errors.Annotate(err, "cannot generate charm archive name")
@@ -211,11 +235,13 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 	}
 
 	info := state.CharmInfo{
-		Charm:       archive.Charm,
-		ID:          archive.ID,
-		StoragePath: storagePath,
-		SHA256:      archive.SHA256,
-		Macaroon:    archive.Macaroon,
+		Charm:        archive.Charm,
+		ID:           archive.ID,
+		StoragePath:  storagePath,
+		SHA256:       archive.SHA256,
+		Macaroon:     archive.Macaroon,
+		LXDProfile:   lxdProfile,
+		JujuFeatures: features,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
@@ -240,6 +266,34 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 	return nil
 }
 
+// readLXDProfile returns the LXD profile shipped with the given
+// charm, or nil if it does not have one.
+func readLXDProfile(ch charm.Charm) (*lxdprofile.Profile, error) {
+	archive, ok := ch.(*charm.CharmArchive)
+	if !ok {
+		return nil, nil
+	}
+	profile, err := lxdprofile.ReadArchive(archive.Path)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm lxd-profile")
+	}
+	return profile, nil
+}
+
+// readJujuFeatures returns the juju features that the given charm
+// declares it requires.
+func readJujuFeatures(ch charm.Charm) ([]string, error) {
+	archive, ok := ch.(*charm.CharmArchive)
+	if !ok {
+		return nil, nil
+	}
+	features, err := charmfeatures.ReadArchive(archive.Path)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm juju-features")
+	}
+	return features, nil
+}
+
 // charmArchiveStoragePath returns a string that is suitable as a
 // storage path, using a random UUID to avoid colliding with concurrent
 // uploads.
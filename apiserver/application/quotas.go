@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+// unitsQuotaUsage returns what adding n units, placed as directed
+// and each with the given number of storage instances, counts
+// against the model's quotas. A unit placed on an existing machine
+// adds no machine; any other unit is counted as adding one, even
+// though it may be assigned to a clean, empty machine.
+func unitsQuotaUsage(n int, placement []*instance.Placement, storagePerUnit int) state.QuotaUsage {
+	usage := state.QuotaUsage{
+		Units:   n,
+		Storage: n * storagePerUnit,
+	}
+	for i := 0; i < n; i++ {
+		if i >= len(placement) || placement[i] == nil {
+			usage.Machines++
+			continue
+		}
+		p := placement[i]
+		if p.Scope == instance.MachineScope {
+			continue
+		}
+		usage.Machines++
+		if _, err := instance.ParseContainerType(p.Scope); err == nil && p.Directive == "" {
+			// A container on a new machine.
+			usage.Machines++
+		}
+	}
+	return usage
+}
+
+// deployStorageCount returns the number of storage instances each
+// unit of an application deployed with the given charm and storage
+// constraints will have. Shared storage is not created per unit, and
+// is not counted.
+func deployStorageCount(meta *charm.Meta, cons map[string]storage.Constraints) int {
+	var count int
+	for name, s := range meta.Storage {
+		if s.Shared {
+			continue
+		}
+		if c, ok := cons[name]; ok && c.Count > 0 {
+			count += int(c.Count)
+		} else {
+			count += s.CountMin
+		}
+	}
+	return count
+}
+
+// unitStorageCount returns the number of storage instances each new
+// unit of the application will have.
+func unitStorageCount(app Application) (int, error) {
+	cons, err := app.StorageConstraints()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var count int
+	for _, c := range cons {
+		count += int(c.Count)
+	}
+	return count, nil
+}
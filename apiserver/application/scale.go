@@ -0,0 +1,207 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	jjj "github.com/juju/juju/juju"
+	"github.com/juju/juju/state"
+)
+
+// scaleCandidate describes a unit that may be removed when scaling an
+// application down.
+type scaleCandidate struct {
+	name   string
+	number int
+
+	// machineUnits holds the number of principal units on the
+	// unit's machine, or 0 if the unit is not yet assigned.
+	machineUnits int
+}
+
+// removalOrder sorts scale candidates into the order in which they
+// should be removed.
+type removalOrder struct {
+	candidates []scaleCandidate
+
+	// emptiestMachineFirst, if true, orders candidates on machines
+	// hosting fewer units first.
+	emptiestMachineFirst bool
+}
+
+// Len is part of sort.Interface.
+func (o removalOrder) Len() int {
+	return len(o.candidates)
+}
+
+// Swap is part of sort.Interface.
+func (o removalOrder) Swap(i, j int) {
+	o.candidates[i], o.candidates[j] = o.candidates[j], o.candidates[i]
+}
+
+// Less is part of sort.Interface.
+func (o removalOrder) Less(i, j int) bool {
+	ci, cj := o.candidates[i], o.candidates[j]
+	if o.emptiestMachineFirst && ci.machineUnits != cj.machineUnits {
+		return ci.machineUnits < cj.machineUnits
+	}
+	// The youngest units have the highest numbers.
+	return ci.number > cj.number
+}
+
+// chooseUnitsToRemove returns the names of n of the candidates, chosen
+// according to the given removal policy.
+func chooseUnitsToRemove(candidates []scaleCandidate, n int, policy string) ([]string, error) {
+	order := removalOrder{candidates: make([]scaleCandidate, len(candidates))}
+	copy(order.candidates, candidates)
+	switch policy {
+	case "", params.ScaleRemoveYoungestFirst:
+	case params.ScaleRemoveEmptiestMachineFirst:
+		order.emptiestMachineFirst = true
+	default:
+		return nil, errors.NotValidf("removal policy %q", policy)
+	}
+	sort.Sort(order)
+	if n > len(order.candidates) {
+		n = len(order.candidates)
+	}
+	names := make([]string, n)
+	for i := range names {
+		names[i] = order.candidates[i].name
+	}
+	return names, nil
+}
+
+// Scale adds or removes units of an application so that it has the
+// requested number of alive units. Units are added using the given
+// placement directives, and chosen for removal using the given policy.
+func (api *APIV7) Scale(args params.ScaleApplication) (params.ScaleApplicationResult, error) {
+	var result params.ScaleApplicationResult
+	if err := api.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.Scale < 0 {
+		return result, errors.NotValidf("scale %d", args.Scale)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !app.IsPrincipal() {
+		return result, errors.Errorf("cannot scale subordinate application %q", args.ApplicationName)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var alive []*state.Unit
+	for _, unit := range units {
+		if unit.Life() == state.Alive {
+			alive = append(alive, unit)
+		}
+	}
+
+	switch delta := args.Scale - len(alive); {
+	case delta > 0:
+		if err := api.check.ChangeAllowed(); err != nil {
+			return result, errors.Trace(err)
+		}
+		storageCount, err := unitStorageCount(app)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if err := api.backend.CheckQuotas(unitsQuotaUsage(delta, args.Placement, storageCount)); err != nil {
+			return result, errors.Trace(err)
+		}
+		added, err := jjj.AddUnits(api.backend, app, args.ApplicationName, delta, args.Placement)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		for _, unit := range added {
+			result.Added = append(result.Added, unit.Name())
+		}
+	case delta < 0:
+		if len(args.Placement) > 0 {
+			return result, errors.New("placement directives can only be used when adding units")
+		}
+		if err := api.check.RemoveAllowed(); err != nil {
+			return result, errors.Trace(err)
+		}
+		candidates, err := api.scaleCandidates(alive)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		names, err := chooseUnitsToRemove(candidates, -delta, args.RemovalPolicy)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		for _, name := range names {
+			unit, err := api.backend.Unit(name)
+			if err != nil {
+				return result, errors.Trace(err)
+			}
+			if err := unit.Destroy(); err != nil {
+				return result, errors.Annotatef(err, "removing unit %q", name)
+			}
+			result.Removed = append(result.Removed, name)
+		}
+	}
+	return result, nil
+}
+
+// scaleCandidates describes the given units for chooseUnitsToRemove.
+func (api *API) scaleCandidates(units []*state.Unit) ([]scaleCandidate, error) {
+	machineUnits := make(map[string]int)
+	candidates := make([]scaleCandidate, len(units))
+	for i, unit := range units {
+		number, err := unitNumber(unit.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		candidates[i] = scaleCandidate{
+			name:   unit.Name(),
+			number: number,
+		}
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		count, ok := machineUnits[machineId]
+		if !ok {
+			machine, err := api.backend.Machine(machineId)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			onMachine, err := machine.Units()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, u := range onMachine {
+				if u.IsPrincipal() {
+					count++
+				}
+			}
+			machineUnits[machineId] = count
+		}
+		candidates[i].machineUnits = count
+	}
+	return candidates, nil
+}
+
+// unitNumber returns the sequence number in the given unit name.
+func unitNumber(unitName string) (int, error) {
+	number, err := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	if err != nil {
+		return 0, errors.NotValidf("unit name %q", unitName)
+	}
+	return number, nil
+}
@@ -107,7 +107,40 @@ func (b bakeryServicePublicKeyLocator) PublicKeyForLocation(string) (*bakery.Pub
 // macaroon may then be used to obtain a discharge macaroon so that the user
 // can log in without presenting their password for a set amount of time.
 func (ctxt *authContext) CreateLocalLoginMacaroon(tag names.UserTag) (*macaroon.Macaroon, error) {
-	return authentication.CreateLocalLoginMacaroon(tag, ctxt.localUserThirdPartyBakeryService, ctxt.clock)
+	controllerCfg, err := ctxt.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller config")
+	}
+	return authentication.CreateLocalLoginMacaroon(
+		tag, ctxt.localUserThirdPartyBakeryService, ctxt.clock,
+		controllerCfg.LoginDischargeTimeout(),
+	)
+}
+
+// RefreshSession returns a macaroon that authenticates the given local
+// user, without a discharge, for the configured local login expiry
+// time, along with the time at which it expires.
+func (ctxt *authContext) RefreshSession(tag names.UserTag) (*macaroon.Macaroon, time.Time, error) {
+	if !tag.IsLocal() {
+		return nil, time.Time{}, errors.NotSupportedf("refreshing sessions of external users")
+	}
+	controllerCfg, err := ctxt.st.ControllerConfig()
+	if err != nil {
+		return nil, time.Time{}, errors.Annotate(err, "cannot get controller config")
+	}
+	expiryTime := ctxt.clock.Now().Add(controllerCfg.LocalLoginExpiry())
+	m, err := authentication.CreateSessionMacaroon(tag, ctxt.localUserBakeryService, expiryTime)
+	if err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	return m, expiryTime, nil
+}
+
+// Stop is part of the facade.Resource interface. The authContext
+// is made available to facades as the "sessionRefresher" resource,
+// but it outlives the connections that use it.
+func (ctxt *authContext) Stop() error {
+	return nil
 }
 
 // CheckLocalLoginCaveat parses and checks that the given caveat string is
@@ -124,7 +157,14 @@ func (ctxt *authContext) CheckLocalLoginCaveat(caveat string) (names.UserTag, er
 // verification failed. If the macaroon is valid, CheckLocalLoginRequest returns
 // a list of caveats to add to the discharge macaroon.
 func (ctxt *authContext) CheckLocalLoginRequest(req *http.Request, tag names.UserTag) ([]checkers.Caveat, error) {
-	return authentication.CheckLocalLoginRequest(ctxt.localUserThirdPartyBakeryService, req, tag, ctxt.clock)
+	controllerCfg, err := ctxt.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller config")
+	}
+	return authentication.CheckLocalLoginRequest(
+		ctxt.localUserThirdPartyBakeryService, req, tag, ctxt.clock,
+		controllerCfg.LocalLoginExpiry(),
+	)
 }
 
 // authenticator returns an authenticator.EntityAuthenticator for the API
@@ -172,12 +212,40 @@ func (a authenticator) authenticatorForTag(tag names.Tag) (authentication.Entity
 	case names.UnitTagKind, names.MachineTagKind:
 		return &a.ctxt.agentAuth, nil
 	case names.UserTagKind:
-		return a.localUserAuth(), nil
+		return a.userAuth()
 	default:
 		return nil, errors.Annotatef(common.ErrBadRequest, "unexpected login entity tag")
 	}
 }
 
+// userAuth returns an authenticator that can authenticate logins for
+// users. If an LDAP directory is configured for the controller, user
+// passwords are checked against the directory before falling back to
+// local user authentication.
+func (a authenticator) userAuth() (authentication.EntityAuthenticator, error) {
+	// The controller config is read for each login so that changes
+	// to the LDAP and login expiry configuration take effect without
+	// a restart.
+	controllerCfg, err := a.ctxt.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller config")
+	}
+	localUserAuth := a.localUserAuth()
+	localUserAuth.LoginExpiry = controllerCfg.LocalLoginExpiry()
+	checker, err := newLDAPChecker(controllerCfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot configure LDAP authentication")
+	}
+	if checker == nil {
+		return localUserAuth, nil
+	}
+	return &authentication.ExternalPasswordAuthenticator{
+		Checker:     checker,
+		Provisioner: externalUserProvisioner{a.ctxt.st},
+		Fallback:    localUserAuth,
+	}, nil
+}
+
 // localUserAuth returns an authenticator that can authenticate logins for
 // local users with either passwords or macaroons.
 func (a authenticator) localUserAuth() *authentication.UserAuthenticator {
@@ -202,7 +270,15 @@ func (ctxt *authContext) externalMacaroonAuth() (authentication.EntityAuthentica
 	if ctxt._macaroonAuth == nil {
 		return nil, errors.Trace(ctxt._macaroonAuthError)
 	}
-	return ctxt._macaroonAuth, nil
+	controllerCfg, err := ctxt.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller config")
+	}
+	// The authenticator is shared, so copy it before
+	// applying the currently configured expiry.
+	auth := *ctxt._macaroonAuth
+	auth.LoginExpiry = controllerCfg.ExternalLoginExpiry()
+	return &auth, nil
 }
 
 var errMacaroonAuthNotConfigured = errors.New("macaroon authentication is not configured")
@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// PasswordChecker checks users' passwords against an external
+// identity store, such as an LDAP directory.
+type PasswordChecker interface {
+	// CheckPassword checks the password of the user with the
+	// given name. It returns the user's identity if the password
+	// is valid, and an error satisfying errors.IsUnauthorized if
+	// the store does not accept it.
+	CheckPassword(username, password string) (*ExternalIdentity, error)
+}
+
+// ExternalIdentity describes a user authenticated by a
+// PasswordChecker.
+type ExternalIdentity struct {
+	// DisplayName holds the user's display name, if known.
+	DisplayName string
+
+	// Access holds the controller access that the store grants
+	// the user, for example through group membership.
+	Access permission.Access
+}
+
+// UserProvisioner keeps the controller's users in step with the users
+// authenticated by a PasswordChecker.
+type UserProvisioner interface {
+	// CanProvision reports whether the user with the given tag
+	// may be authenticated by the external store: that is, it
+	// does not yet exist, or was itself added by ProvisionUser.
+	CanProvision(tag names.UserTag) (bool, error)
+
+	// ProvisionUser ensures that the user exists and has the
+	// controller access given by the identity.
+	ProvisionUser(tag names.UserTag, identity ExternalIdentity) error
+}
+
+// ExternalPasswordAuthenticator authenticates the passwords of local
+// users with a PasswordChecker, adding the users to the controller as
+// needed. Logins that the checker does not accept, and logins by users
+// that were added to the controller by other means, are passed to the
+// fallback authenticator.
+type ExternalPasswordAuthenticator struct {
+	Checker     PasswordChecker
+	Provisioner UserProvisioner
+	Fallback    EntityAuthenticator
+}
+
+var _ EntityAuthenticator = (*ExternalPasswordAuthenticator)(nil)
+
+// Authenticate implements EntityAuthenticator.
+func (a *ExternalPasswordAuthenticator) Authenticate(
+	entityFinder EntityFinder, tag names.Tag, req params.LoginRequest,
+) (state.Entity, error) {
+	userTag, ok := tag.(names.UserTag)
+	if !ok || !userTag.IsLocal() || req.Credentials == "" {
+		return a.Fallback.Authenticate(entityFinder, tag, req)
+	}
+	canProvision, err := a.Provisioner.CanProvision(userTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !canProvision {
+		return a.Fallback.Authenticate(entityFinder, tag, req)
+	}
+
+	identity, err := a.Checker.CheckPassword(userTag.Name(), req.Credentials)
+	if errors.IsUnauthorized(err) {
+		logger.Debugf("external authentication of %q failed: %v", userTag.Id(), err)
+		return a.Fallback.Authenticate(entityFinder, tag, req)
+	}
+	if err != nil {
+		// Users added by the provisioner have random local
+		// passwords, so they cannot log in while the external
+		// store is unavailable.
+		logger.Errorf("cannot check password of %q: %v", userTag.Id(), err)
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if identity.Access == permission.NoAccess {
+		logger.Infof("user %q authenticated but has no controller access", userTag.Id())
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	if err := a.Provisioner.ProvisionUser(userTag, *identity); err != nil {
+		return nil, errors.Annotatef(err, "provisioning user %q", userTag.Id())
+	}
+
+	entity, err := entityFinder.FindEntity(tag)
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entity, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+type externalPasswordSuite struct {
+	testing.IsolationSuite
+
+	stub        testing.Stub
+	checker     *mockPasswordChecker
+	provisioner *mockUserProvisioner
+	fallback    *mockEntityAuthenticator
+	auth        *authentication.ExternalPasswordAuthenticator
+}
+
+var _ = gc.Suite(&externalPasswordSuite{})
+
+var bobTag = names.NewUserTag("bob")
+
+func (s *externalPasswordSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+	s.checker = &mockPasswordChecker{
+		stub: &s.stub,
+		identity: &authentication.ExternalIdentity{
+			DisplayName: "Bob",
+			Access:      permission.AddModelAccess,
+		},
+	}
+	s.provisioner = &mockUserProvisioner{stub: &s.stub, canProvision: true}
+	s.fallback = &mockEntityAuthenticator{stub: &s.stub}
+	s.auth = &authentication.ExternalPasswordAuthenticator{
+		Checker:     s.checker,
+		Provisioner: s.provisioner,
+		Fallback:    s.fallback,
+	}
+}
+
+func (s *externalPasswordSuite) authenticate(tag names.Tag, password string) (state.Entity, error) {
+	return s.auth.Authenticate(stubEntityFinder{&s.stub}, tag, params.LoginRequest{
+		Credentials: password,
+	})
+}
+
+func (s *externalPasswordSuite) TestAuthenticateProvisionsUser(c *gc.C) {
+	entity, err := s.authenticate(bobTag, "hunter2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Tag(), gc.Equals, names.Tag(bobTag))
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"CanProvision", []interface{}{bobTag}},
+		{"CheckPassword", []interface{}{"bob", "hunter2"}},
+		{"ProvisionUser", []interface{}{bobTag, *s.checker.identity}},
+		{"FindEntity", []interface{}{bobTag}},
+	})
+}
+
+func (s *externalPasswordSuite) TestAuthenticateRejectedFallsBack(c *gc.C) {
+	s.checker.err = errors.Unauthorizedf("nope")
+	_, err := s.authenticate(bobTag, "hunter2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "CanProvision", "CheckPassword", "Authenticate")
+}
+
+func (s *externalPasswordSuite) TestAuthenticateLocalUserFallsBack(c *gc.C) {
+	s.provisioner.canProvision = false
+	_, err := s.authenticate(bobTag, "hunter2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "CanProvision", "Authenticate")
+}
+
+func (s *externalPasswordSuite) TestAuthenticateMacaroonFallsBack(c *gc.C) {
+	_, err := s.authenticate(bobTag, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "Authenticate")
+}
+
+func (s *externalPasswordSuite) TestAuthenticateExternalUserFallsBack(c *gc.C) {
+	_, err := s.authenticate(names.NewUserTag("bob@external"), "hunter2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "Authenticate")
+}
+
+func (s *externalPasswordSuite) TestAuthenticateNoAccess(c *gc.C) {
+	s.checker.identity.Access = permission.NoAccess
+	_, err := s.authenticate(bobTag, "hunter2")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.stub.CheckCallNames(c, "CanProvision", "CheckPassword")
+}
+
+func (s *externalPasswordSuite) TestAuthenticateCheckerUnavailable(c *gc.C) {
+	s.checker.err = errors.New("connection refused")
+	_, err := s.authenticate(bobTag, "hunter2")
+	c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+	s.stub.CheckCallNames(c, "CanProvision", "CheckPassword")
+}
+
+type mockPasswordChecker struct {
+	stub     *testing.Stub
+	identity *authentication.ExternalIdentity
+	err      error
+}
+
+func (m *mockPasswordChecker) CheckPassword(username, password string) (*authentication.ExternalIdentity, error) {
+	m.stub.AddCall("CheckPassword", username, password)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.identity, nil
+}
+
+type mockUserProvisioner struct {
+	stub         *testing.Stub
+	canProvision bool
+}
+
+func (m *mockUserProvisioner) CanProvision(tag names.UserTag) (bool, error) {
+	m.stub.AddCall("CanProvision", tag)
+	return m.canProvision, m.stub.NextErr()
+}
+
+func (m *mockUserProvisioner) ProvisionUser(tag names.UserTag, identity authentication.ExternalIdentity) error {
+	m.stub.AddCall("ProvisionUser", tag, identity)
+	return m.stub.NextErr()
+}
+
+type mockEntityAuthenticator struct {
+	stub *testing.Stub
+}
+
+func (m *mockEntityAuthenticator) Authenticate(
+	entityFinder authentication.EntityFinder, tag names.Tag, req params.LoginRequest,
+) (state.Entity, error) {
+	m.stub.AddCall("Authenticate", tag, req)
+	return mockEntity{tag}, m.stub.NextErr()
+}
+
+type stubEntityFinder struct {
+	stub *testing.Stub
+}
+
+func (f stubEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
+	f.stub.AddCall("FindEntity", tag)
+	return mockEntity{tag}, f.stub.NextErr()
+}
+
+type mockEntity struct {
+	tag names.Tag
+}
+
+func (e mockEntity) Tag() names.Tag {
+	return e.tag
+}
@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ldap
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/juju/errors"
+)
+
+// The LDAP protocol is encoded with ASN.1 BER. The encoding/asn1
+// package only accepts DER, which directory servers (notably Active
+// Directory, with its non-minimal lengths) do not always send, so the
+// small subset of BER needed here is implemented directly.
+
+// BER tag classes.
+const (
+	classUniversal   = 0x00
+	classApplication = 0x40
+	classContext     = 0x80
+)
+
+// Universal tag numbers.
+const (
+	tagBoolean     = 0x01
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x10
+	tagSet         = 0x11
+)
+
+// maxPacketSize limits the size of a single LDAP message read from
+// the server.
+const maxPacketSize = 1 << 20
+
+// berElement is a decoded BER element.
+type berElement struct {
+	class       byte
+	constructed bool
+	tag         int
+	content     []byte
+}
+
+// berEncode encodes a BER element with the given identifier and
+// content.
+func berEncode(class byte, constructed bool, tag int, content []byte) []byte {
+	id := class | byte(tag)
+	if constructed {
+		id |= 0x20
+	}
+	out := []byte{id}
+	n := len(content)
+	switch {
+	case n < 0x80:
+		out = append(out, byte(n))
+	default:
+		var lenBytes []byte
+		for ; n > 0; n >>= 8 {
+			lenBytes = append([]byte{byte(n)}, lenBytes...)
+		}
+		out = append(out, 0x80|byte(len(lenBytes)))
+		out = append(out, lenBytes...)
+	}
+	return append(out, content...)
+}
+
+func berSequence(elements ...[]byte) []byte {
+	var content []byte
+	for _, e := range elements {
+		content = append(content, e...)
+	}
+	return berEncode(classUniversal, true, tagSequence, content)
+}
+
+func berOctetString(s string) []byte {
+	return berEncode(classUniversal, false, tagOctetString, []byte(s))
+}
+
+func berBoolean(b bool) []byte {
+	v := byte(0)
+	if b {
+		v = 0xff
+	}
+	return berEncode(classUniversal, false, tagBoolean, []byte{v})
+}
+
+func berInteger(v int) []byte {
+	return berEncode(classUniversal, false, tagInteger, encodeInt(v))
+}
+
+func berEnumerated(v int) []byte {
+	return berEncode(classUniversal, false, tagEnumerated, encodeInt(v))
+}
+
+// encodeInt returns the minimal two's complement encoding of v.
+func encodeInt(v int) []byte {
+	out := []byte{byte(v)}
+	for v > 0x7f || v < -0x80 {
+		v >>= 8
+		out = append([]byte{byte(v)}, out...)
+	}
+	return out
+}
+
+// decodeInt decodes a two's complement integer.
+func decodeInt(b []byte) (int, error) {
+	if len(b) == 0 || len(b) > 4 {
+		return 0, errors.Errorf("invalid integer length %d", len(b))
+	}
+	v := int(int8(b[0]))
+	for _, c := range b[1:] {
+		v = v<<8 | int(c)
+	}
+	return v, nil
+}
+
+// berParse decodes the BER element at the start of b, and returns it
+// along with the bytes following it.
+func berParse(b []byte) (berElement, []byte, error) {
+	var e berElement
+	if len(b) < 2 {
+		return e, nil, errors.New("truncated element")
+	}
+	if b[0]&0x1f == 0x1f {
+		return e, nil, errors.New("multi-byte tags not supported")
+	}
+	e.class = b[0] & 0xc0
+	e.constructed = b[0]&0x20 != 0
+	e.tag = int(b[0] & 0x1f)
+	length, offset := int(b[1]), 2
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 || len(b) < 2+n {
+			return e, nil, errors.New("invalid element length")
+		}
+		length = 0
+		for _, c := range b[2 : 2+n] {
+			length = length<<8 | int(c)
+		}
+		offset += n
+	}
+	if length < 0 || len(b)-offset < length {
+		return e, nil, errors.New("truncated element")
+	}
+	e.content = b[offset : offset+length]
+	return e, b[offset+length:], nil
+}
+
+// children decodes the elements contained in a constructed element.
+func (e berElement) children() ([]berElement, error) {
+	if !e.constructed {
+		return nil, errors.New("element is not constructed")
+	}
+	var result []berElement
+	for rest := e.content; len(rest) > 0; {
+		var child berElement
+		var err error
+		child, rest, err = berParse(rest)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, child)
+	}
+	return result, nil
+}
+
+// berRead reads a single BER element from r.
+func berRead(r *bufio.Reader) (berElement, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return berElement{}, errors.Trace(err)
+	}
+	length := int(header[1])
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 {
+			return berElement{}, errors.New("invalid element length")
+		}
+		lenBytes := make([]byte, n)
+		if _, err := io.ReadFull(r, lenBytes); err != nil {
+			return berElement{}, errors.Trace(err)
+		}
+		header = append(header, lenBytes...)
+		length = 0
+		for _, c := range lenBytes {
+			length = length<<8 | int(c)
+		}
+	}
+	if length > maxPacketSize {
+		return berElement{}, errors.Errorf("message too large (%d bytes)", length)
+	}
+	packet := make([]byte, len(header)+length)
+	copy(packet, header)
+	if _, err := io.ReadFull(r, packet[len(header):]); err != nil {
+		return berElement{}, errors.Trace(err)
+	}
+	e, _, err := berParse(packet)
+	return e, errors.Trace(err)
+}
@@ -0,0 +1,400 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package ldap implements a password checker that authenticates
+// users against an LDAP directory, such as OpenLDAP or Active
+// Directory, and derives their controller access from the
+// directory groups they belong to.
+package ldap
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/permission"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.authentication.ldap")
+
+// Config holds the configuration of a Checker.
+type Config struct {
+	// URL holds the URL of the directory, of the form
+	// ldap://host[:port] or ldaps://host[:port].
+	URL string
+
+	// UserDN holds the template for the distinguished names of
+	// users' entries. The single "%s" in it is replaced with the
+	// user name.
+	UserDN string
+
+	// GroupAccess holds the controller access given to the
+	// members of each group, keyed by the group's distinguished
+	// name.
+	GroupAccess map[string]permission.Access
+
+	// TLSConfig, if not nil, is used for ldaps connections.
+	TLSConfig *tls.Config
+
+	// Timeout bounds the time spent talking to the directory
+	// for each password check. If it is zero, DefaultTimeout
+	// is used.
+	Timeout time.Duration
+}
+
+// DefaultTimeout is used when Config.Timeout is zero.
+const DefaultTimeout = 10 * time.Second
+
+// LDAP result codes.
+const (
+	resultSuccess            = 0
+	resultNoSuchObject       = 32
+	resultInvalidCredentials = 49
+)
+
+// LDAP protocol operation tags.
+const (
+	opBindRequest       = 0
+	opBindResponse      = 1
+	opUnbindRequest     = 2
+	opSearchRequest     = 3
+	opSearchResultEntry = 4
+	opSearchResultDone  = 5
+	opSearchResultRef   = 19
+)
+
+// Checker implements authentication.PasswordChecker by binding to an
+// LDAP directory as the user.
+type Checker struct {
+	config   Config
+	scheme   string
+	hostPort string
+}
+
+var _ authentication.PasswordChecker = (*Checker)(nil)
+
+// NewChecker returns a new Checker using the given configuration.
+func NewChecker(config Config) (*Checker, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing LDAP URL")
+	}
+	hostPort := u.Host
+	switch u.Scheme {
+	case "ldap":
+		if u.Port() == "" {
+			hostPort = net.JoinHostPort(u.Hostname(), "389")
+		}
+	case "ldaps":
+		if u.Port() == "" {
+			hostPort = net.JoinHostPort(u.Hostname(), "636")
+		}
+	default:
+		return nil, errors.NotValidf("LDAP URL scheme %q", u.Scheme)
+	}
+	if strings.Count(config.UserDN, "%s") != 1 {
+		return nil, errors.NotValidf("user DN template %q", config.UserDN)
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	return &Checker{
+		config:   config,
+		scheme:   u.Scheme,
+		hostPort: hostPort,
+	}, nil
+}
+
+// CheckPassword is part of the authentication.PasswordChecker
+// interface. It binds to the directory as the user, then reads the
+// groups the user belongs to from the memberOf attribute of the
+// user's entry.
+func (c *Checker) CheckPassword(username, password string) (*authentication.ExternalIdentity, error) {
+	if password == "" {
+		// An empty password makes for an unauthenticated
+		// bind, which most servers allow.
+		return nil, errors.Unauthorizedf("empty password")
+	}
+	conn, err := c.dial()
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to LDAP server")
+	}
+	defer conn.close()
+
+	dn := strings.Replace(c.config.UserDN, "%s", escapeDNValue(username), 1)
+	if err := conn.bind(dn, password); err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs, err := conn.readEntry(dn, "displayName", "memberOf")
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading entry %q", dn)
+	}
+	identity := &authentication.ExternalIdentity{
+		Access: permission.NoAccess,
+	}
+	if names := attrs["displayname"]; len(names) > 0 {
+		identity.DisplayName = names[0]
+	}
+	for _, group := range attrs["memberof"] {
+		for groupDN, access := range c.config.GroupAccess {
+			if !strings.EqualFold(normalizeDN(group), normalizeDN(groupDN)) {
+				continue
+			}
+			if access.GreaterControllerAccessThan(identity.Access) {
+				identity.Access = access
+			}
+		}
+	}
+	logger.Debugf("user %q authenticated by LDAP with %q access", username, identity.Access)
+	return identity, nil
+}
+
+func (c *Checker) dial() (*conn, error) {
+	dialer := &net.Dialer{Timeout: c.config.Timeout}
+	var netConn net.Conn
+	var err error
+	if c.scheme == "ldaps" {
+		netConn, err = tls.DialWithDialer(dialer, "tcp", c.hostPort, c.config.TLSConfig)
+	} else {
+		netConn, err = dialer.Dial("tcp", c.hostPort)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	netConn.SetDeadline(time.Now().Add(c.config.Timeout))
+	return &conn{
+		conn:   netConn,
+		reader: bufio.NewReader(netConn),
+	}, nil
+}
+
+// conn is a connection to an LDAP server.
+type conn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	messageID int
+}
+
+func (c *conn) close() {
+	// Politely unbind; the server closes the connection anyway.
+	c.send(berEncode(classApplication, false, opUnbindRequest, nil))
+	c.conn.Close()
+}
+
+// send sends the given protocol operation in a new message, and
+// returns the message's ID.
+func (c *conn) send(op []byte) (int, error) {
+	c.messageID++
+	_, err := c.conn.Write(berSequence(berInteger(c.messageID), op))
+	return c.messageID, errors.Trace(err)
+}
+
+// receive reads the next message with the given ID from the server,
+// and returns its protocol operation.
+func (c *conn) receive(id int) (berElement, error) {
+	for {
+		msg, err := berRead(c.reader)
+		if err != nil {
+			return berElement{}, errors.Trace(err)
+		}
+		parts, err := msg.children()
+		if err != nil {
+			return berElement{}, errors.Trace(err)
+		}
+		if len(parts) < 2 || parts[0].tag != tagInteger {
+			return berElement{}, errors.New("malformed LDAP message")
+		}
+		msgID, err := decodeInt(parts[0].content)
+		if err != nil {
+			return berElement{}, errors.Trace(err)
+		}
+		if msgID != id {
+			// Unsolicited notifications have ID zero;
+			// they are of no interest here.
+			continue
+		}
+		if parts[1].class != classApplication {
+			return berElement{}, errors.New("malformed LDAP message")
+		}
+		return parts[1], nil
+	}
+}
+
+// bind authenticates the connection as the given user. It returns
+// an error satisfying errors.IsUnauthorized if the directory rejects
+// the credentials.
+func (c *conn) bind(dn, password string) error {
+	id, err := c.send(berEncode(classApplication, true, opBindRequest, concat(
+		berInteger(3),
+		berOctetString(dn),
+		berEncode(classContext, false, 0, []byte(password)),
+	)))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	op, err := c.receive(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if op.tag != opBindResponse {
+		return errors.Errorf("unexpected LDAP response %d to bind request", op.tag)
+	}
+	code, message, err := parseResult(op)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch code {
+	case resultSuccess:
+		return nil
+	case resultInvalidCredentials, resultNoSuchObject:
+		return errors.Unauthorizedf("LDAP bind as %q rejected", dn)
+	}
+	return errors.Errorf("LDAP bind failed with result %d: %s", code, message)
+}
+
+// readEntry returns the named attributes of the entry with the given
+// distinguished name, keyed by their lower-cased names.
+func (c *conn) readEntry(dn string, attrs ...string) (map[string][]string, error) {
+	var attrList [][]byte
+	for _, attr := range attrs {
+		attrList = append(attrList, berOctetString(attr))
+	}
+	id, err := c.send(berEncode(classApplication, true, opSearchRequest, concat(
+		berOctetString(dn),
+		berEnumerated(0), // baseObject
+		berEnumerated(0), // neverDerefAliases
+		berInteger(1),    // sizeLimit
+		berInteger(0),    // timeLimit
+		berBoolean(false),
+		// (objectClass=*)
+		berEncode(classContext, false, 7, []byte("objectClass")),
+		berSequence(attrList...),
+	)))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string][]string)
+	for {
+		op, err := c.receive(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch op.tag {
+		case opSearchResultEntry:
+			if err := parseEntry(op, result); err != nil {
+				return nil, errors.Trace(err)
+			}
+		case opSearchResultRef:
+		case opSearchResultDone:
+			code, message, err := parseResult(op)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if code != resultSuccess {
+				return nil, errors.Errorf("LDAP search failed with result %d: %s", code, message)
+			}
+			return result, nil
+		default:
+			return nil, errors.Errorf("unexpected LDAP response %d to search request", op.tag)
+		}
+	}
+}
+
+// parseResult parses an LDAPResult, returning its result code and
+// diagnostic message.
+func parseResult(op berElement) (int, string, error) {
+	parts, err := op.children()
+	if err != nil {
+		return 0, "", errors.Trace(err)
+	}
+	if len(parts) < 3 || parts[0].tag != tagEnumerated {
+		return 0, "", errors.New("malformed LDAP result")
+	}
+	code, err := decodeInt(parts[0].content)
+	if err != nil {
+		return 0, "", errors.Trace(err)
+	}
+	return code, string(parts[2].content), nil
+}
+
+// parseEntry adds the attributes of a SearchResultEntry to attrs.
+func parseEntry(op berElement, attrs map[string][]string) error {
+	parts, err := op.children()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(parts) != 2 {
+		return errors.New("malformed LDAP search result")
+	}
+	attrList, err := parts[1].children()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, attr := range attrList {
+		typeAndVals, err := attr.children()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(typeAndVals) != 2 {
+			return errors.New("malformed LDAP attribute")
+		}
+		vals, err := typeAndVals[1].children()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		name := strings.ToLower(string(typeAndVals[0].content))
+		for _, val := range vals {
+			attrs[name] = append(attrs[name], string(val.content))
+		}
+	}
+	return nil
+}
+
+func concat(elements ...[]byte) []byte {
+	var out []byte
+	for _, e := range elements {
+		out = append(out, e...)
+	}
+	return out
+}
+
+// escapeDNValue escapes the characters that are special in the
+// value of a distinguished name's attribute, as described in
+// RFC 4514.
+func escapeDNValue(s string) string {
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case strings.IndexByte(`,+"\<>;=`, c) >= 0,
+			i == 0 && (c == ' ' || c == '#'),
+			i == len(s)-1 && c == ' ':
+			out = append(out, '\\', c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return string(out)
+}
+
+// normalizeDN removes the optional spaces around the separators of a
+// distinguished name, so that names written differently by the
+// directory and in the controller configuration compare equal.
+func normalizeDN(dn string) string {
+	rdns := strings.Split(dn, ",")
+	for i, rdn := range rdns {
+		parts := strings.SplitN(rdn, "=", 2)
+		for j, part := range parts {
+			parts[j] = strings.TrimSpace(part)
+		}
+		rdns[i] = strings.Join(parts, "=")
+	}
+	return strings.Join(rdns, ",")
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ldap
+
+import (
+	"bufio"
+	"net"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/permission"
+)
+
+type ldapSuite struct {
+	jujutesting.IsolationSuite
+
+	listener net.Listener
+	checker  *Checker
+}
+
+var _ = gc.Suite(&ldapSuite{})
+
+const (
+	testUserDN  = "uid=%s,ou=people,dc=example,dc=com"
+	testGroupDN = "cn=admins,ou=groups,dc=example,dc=com"
+)
+
+func (s *ldapSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.listener = listener
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+
+	s.checker, err = NewChecker(Config{
+		URL:    "ldap://" + listener.Addr().String(),
+		UserDN: testUserDN,
+		GroupAccess: map[string]permission.Access{
+			"cn=admins, ou=groups, dc=example, dc=com": permission.SuperuserAccess,
+			"cn=users,ou=groups,dc=example,dc=com":     permission.LoginAccess,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// serve answers the requests of a single connection as a directory
+// holding one user, bob, whose password is "hunter2".
+func (s *ldapSuite) serve(c *gc.C) {
+	conn, err := s.listener.Accept()
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		msg, err := berRead(reader)
+		if err != nil {
+			return
+		}
+		parts, err := msg.children()
+		c.Assert(err, jc.ErrorIsNil)
+		id, err := decodeInt(parts[0].content)
+		c.Assert(err, jc.ErrorIsNil)
+		reply := func(op []byte) {
+			_, err := conn.Write(berSequence(berInteger(id), op))
+			c.Assert(err, jc.ErrorIsNil)
+		}
+		req, err := parts[1].children()
+		switch parts[1].tag {
+		case opBindRequest:
+			c.Assert(err, jc.ErrorIsNil)
+			code := resultInvalidCredentials
+			if string(req[1].content) == "uid=bob,ou=people,dc=example,dc=com" &&
+				string(req[2].content) == "hunter2" {
+				code = resultSuccess
+			}
+			reply(ldapResult(opBindResponse, code))
+		case opSearchRequest:
+			c.Assert(err, jc.ErrorIsNil)
+			c.Check(string(req[0].content), gc.Equals, "uid=bob,ou=people,dc=example,dc=com")
+			reply(berEncode(classApplication, true, opSearchResultEntry, concat(
+				berOctetString(string(req[0].content)),
+				berSequence(
+					ldapAttribute("displayName", "Bob Dobbs"),
+					ldapAttribute("memberOf", testGroupDN, "cn=other,dc=example,dc=com"),
+				),
+			)))
+			reply(ldapResult(opSearchResultDone, resultSuccess))
+		case opUnbindRequest:
+			return
+		}
+	}
+}
+
+func ldapResult(op, code int) []byte {
+	return berEncode(classApplication, true, op, concat(
+		berEnumerated(code),
+		berOctetString(""),
+		berOctetString(""),
+	))
+}
+
+func ldapAttribute(name string, values ...string) []byte {
+	var encoded [][]byte
+	for _, v := range values {
+		encoded = append(encoded, berOctetString(v))
+	}
+	return berSequence(
+		berOctetString(name),
+		berEncode(classUniversal, true, tagSet, concat(encoded...)),
+	)
+}
+
+func (s *ldapSuite) TestCheckPassword(c *gc.C) {
+	go s.serve(c)
+	identity, err := s.checker.CheckPassword("bob", "hunter2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(identity.DisplayName, gc.Equals, "Bob Dobbs")
+	c.Assert(identity.Access, gc.Equals, permission.SuperuserAccess)
+}
+
+func (s *ldapSuite) TestCheckPasswordInvalid(c *gc.C) {
+	go s.serve(c)
+	_, err := s.checker.CheckPassword("bob", "wrong")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *ldapSuite) TestCheckPasswordEmpty(c *gc.C) {
+	_, err := s.checker.CheckPassword("bob", "")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *ldapSuite) TestNewCheckerInvalidConfig(c *gc.C) {
+	_, err := NewChecker(Config{URL: "http://example.com", UserDN: testUserDN})
+	c.Assert(err, gc.ErrorMatches, `LDAP URL scheme "http" not valid`)
+	_, err = NewChecker(Config{URL: "ldap://example.com", UserDN: "uid=bob"})
+	c.Assert(err, gc.ErrorMatches, `user DN template "uid=bob" not valid`)
+}
+
+func (s *ldapSuite) TestNewCheckerDefaultPort(c *gc.C) {
+	checker, err := NewChecker(Config{URL: "ldaps://example.com", UserDN: testUserDN})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checker.hostPort, gc.Equals, "example.com:636")
+}
+
+func (s *ldapSuite) TestEscapeDNValue(c *gc.C) {
+	c.Assert(escapeDNValue("bob"), gc.Equals, "bob")
+	c.Assert(escapeDNValue("a,b=c"), gc.Equals, `a\,b\=c`)
+	c.Assert(escapeDNValue(" #x "), gc.Equals, `\ #x\ `)
+}
+
+func (s *ldapSuite) TestBERRoundTrip(c *gc.C) {
+	for _, v := range []int{0, 1, 127, 128, 255, 256, -1, -129, 1 << 20} {
+		got, err := decodeInt(encodeInt(v))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(got, gc.Equals, v)
+	}
+	long := make([]byte, 300)
+	e, rest, err := berParse(berSequence(berOctetString(string(long)), berBoolean(true)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rest, gc.HasLen, 0)
+	children, err := e.children()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(children, gc.HasLen, 2)
+	c.Assert(children[0].content, gc.HasLen, 300)
+	c.Assert(children[1].content, gc.DeepEquals, []byte{0xff})
+}
@@ -33,6 +33,10 @@ type UserAuthenticator struct {
 	// Clock is used to calculate the expiry time for macaroons.
 	Clock clock.Clock
 
+	// LoginExpiry is how long the macaroons minted for local users
+	// remain valid. If it is zero, DefaultLocalLoginExpiry is used.
+	LoginExpiry time.Duration
+
 	// LocalUserIdentityLocation holds the URL of the trusted third party
 	// that is used to address the is-authenticated-user third party caveat
 	// to for local users. This always points at the same controller
@@ -47,14 +51,13 @@ const (
 	// an interactive login before it is expired.
 	LocalLoginInteractionTimeout = 2 * time.Minute
 
-	// TODO(axw) make this configurable via model config.
-	localLoginExpiryTime = 24 * time.Hour
+	// DefaultLocalLoginExpiry is how long local users' login
+	// macaroons remain valid when no other expiry is configured.
+	DefaultLocalLoginExpiry = 24 * time.Hour
 
-	// TODO(axw) check with cmars about this time limit. Seems a bit
-	// too low. Are we prompting the user every hour, or just refreshing
-	// the token every hour until the external IdM requires prompting
-	// the user?
-	externalLoginExpiryTime = 1 * time.Hour
+	// DefaultExternalLoginExpiry is how long external users' login
+	// macaroons remain valid when no other expiry is configured.
+	DefaultExternalLoginExpiry = 1 * time.Hour
 )
 
 var _ EntityAuthenticator = (*UserAuthenticator)(nil)
@@ -81,19 +84,46 @@ func (u *UserAuthenticator) Authenticate(
 // user as proof that they have logged in with a valid username and password.
 // This macaroon may then be used to obtain a discharge macaroon so that
 // the user can log in without presenting their password for a set amount
-// of time.
+// of time. The user has the given timeout in which to obtain the
+// discharge.
 func CreateLocalLoginMacaroon(
 	tag names.UserTag,
 	service BakeryService,
 	clock clock.Clock,
+	timeout time.Duration,
 ) (*macaroon.Macaroon, error) {
 	// We create the macaroon with a random ID and random root key, which
 	// enables multiple clients to login as the same user and obtain separate
 	// macaroons without having them use the same root key.
 	return service.NewMacaroon("", nil, []checkers.Caveat{
 		{Condition: "is-authenticated-user " + tag.Id()},
-		checkers.TimeBeforeCaveat(clock.Now().Add(LocalLoginInteractionTimeout)),
+		checkers.TimeBeforeCaveat(clock.Now().Add(timeout)),
+	})
+}
+
+// CreateSessionMacaroon creates a macaroon that authenticates the given
+// local user until the given expiry time, without requiring a discharge.
+// It is given to users who have already logged in, so that they may
+// extend their sessions without presenting their credentials again.
+func CreateSessionMacaroon(
+	tag names.UserTag,
+	service ExpirableStorageBakeryService,
+	expiryTime time.Time,
+) (*macaroon.Macaroon, error) {
+	// The root keys for these macaroons are stored in MongoDB.
+	// Expire the documents when the macaroon expires.
+	service, err := service.ExpireStorageAt(expiryTime)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m, err := service.NewMacaroon("", nil, []checkers.Caveat{
+		checkers.DeclaredCaveat(usernameKey, tag.Id()),
+		checkers.TimeBeforeCaveat(expiryTime),
 	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create macaroon")
+	}
+	return m, nil
 }
 
 // CheckLocalLoginCaveat parses and checks that the given caveat string is
@@ -125,12 +155,13 @@ func CheckLocalLoginCaveat(caveat string) (names.UserTag, error) {
 // CreateLocalLoginMacaroon. It returns an error with a
 // *bakery.VerificationError cause if the macaroon verification failed. If the
 // macaroon is valid, CheckLocalLoginRequest returns a list of caveats to add
-// to the discharge macaroon.
+// to the discharge macaroon, which will expire after the given duration.
 func CheckLocalLoginRequest(
 	service *bakery.Service,
 	req *http.Request,
 	tag names.UserTag,
 	clock clock.Clock,
+	expiry time.Duration,
 ) ([]checkers.Caveat, error) {
 	_, err := httpbakery.CheckRequest(service, req, nil, checkers.CheckerFunc{
 		// Having a macaroon with an is-authenticated-user
@@ -143,7 +174,7 @@ func CheckLocalLoginRequest(
 	}
 	firstPartyCaveats := []checkers.Caveat{
 		checkers.DeclaredCaveat("username", tag.Id()),
-		checkers.TimeBeforeCaveat(clock.Now().Add(localLoginExpiryTime)),
+		checkers.TimeBeforeCaveat(clock.Now().Add(expiry)),
 	}
 	return firstPartyCaveats, nil
 }
@@ -163,7 +194,7 @@ func (u *UserAuthenticator) authenticateMacaroons(
 
 		// The root keys for these macaroons are stored in MongoDB.
 		// Expire the documents after after a set amount of time.
-		expiryTime := u.Clock.Now().Add(localLoginExpiryTime)
+		expiryTime := u.Clock.Now().Add(u.loginExpiry())
 		service, err := u.Service.ExpireStorageAt(expiryTime)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -197,6 +228,13 @@ func (u *UserAuthenticator) authenticateMacaroons(
 	return entity, nil
 }
 
+func (u *UserAuthenticator) loginExpiry() time.Duration {
+	if u.LoginExpiry == 0 {
+		return DefaultLocalLoginExpiry
+	}
+	return u.LoginExpiry
+}
+
 // ExternalMacaroonAuthenticator performs authentication for external users using
 // macaroons. If the authentication fails because provided macaroons are invalid,
 // and macaroon authentiction is enabled, it will return a *common.DischargeRequiredError
@@ -215,6 +253,11 @@ type ExternalMacaroonAuthenticator struct {
 	// that is used to address the is-authenticated-user
 	// third party caveat to.
 	IdentityLocation string
+
+	// LoginExpiry is how long the macaroons given to external users
+	// remain valid. If it is zero, DefaultExternalLoginExpiry is
+	// used.
+	LoginExpiry time.Duration
 }
 
 var _ EntityAuthenticator = (*ExternalMacaroonAuthenticator)(nil)
@@ -225,7 +268,11 @@ func (m *ExternalMacaroonAuthenticator) newDischargeRequiredError(cause error) e
 	}
 	mac := m.Macaroon.Clone()
 	// TODO(fwereade): 2016-03-17 lp:1558657
-	expiryTime := time.Now().Add(externalLoginExpiryTime)
+	expiry := m.LoginExpiry
+	if expiry == 0 {
+		expiry = DefaultExternalLoginExpiry
+	}
+	expiryTime := time.Now().Add(expiry)
 	if err := addMacaroonTimeBeforeCaveat(m.Service, mac, expiryTime); err != nil {
 		return errors.Annotatef(err, "cannot create macaroon")
 	}
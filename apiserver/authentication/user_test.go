@@ -169,7 +169,7 @@ func (s *userAuthenticatorSuite) TestCreateLocalLoginMacaroon(c *gc.C) {
 	service := mockBakeryService{}
 	clock := testing.NewClock(time.Time{})
 	_, err := authentication.CreateLocalLoginMacaroon(
-		names.NewUserTag("bobbrown"), &service, clock,
+		names.NewUserTag("bobbrown"), &service, clock, 2*time.Minute,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	service.CheckCallNames(c, "NewMacaroon")
@@ -213,6 +213,44 @@ func (s *userAuthenticatorSuite) TestAuthenticateLocalLoginMacaroon(c *gc.C) {
 	})
 }
 
+func (s *userAuthenticatorSuite) TestAuthenticateLocalLoginMacaroonLoginExpiry(c *gc.C) {
+	service := mockBakeryService{}
+	clock := testing.NewClock(time.Time{})
+	authenticator := &authentication.UserAuthenticator{
+		Service:     &service,
+		Clock:       clock,
+		LoginExpiry: time.Hour,
+		LocalUserIdentityLocation: "https://testing.invalid:1234/auth",
+	}
+
+	service.SetErrors(&bakery.VerificationError{})
+	_, err := authenticator.Authenticate(
+		authentication.EntityFinder(nil),
+		names.NewUserTag("bobbrown"),
+		params.LoginRequest{},
+	)
+	c.Assert(err, gc.FitsTypeOf, &common.DischargeRequiredError{})
+
+	service.CheckCallNames(c, "CheckAny", "ExpireStorageAt", "NewMacaroon")
+	calls := service.Calls()
+	c.Assert(calls[1].Args, jc.DeepEquals, []interface{}{clock.Now().Add(time.Hour)})
+}
+
+func (s *userAuthenticatorSuite) TestCreateSessionMacaroon(c *gc.C) {
+	service := mockBakeryService{}
+	expiryTime := time.Time{}.Add(time.Hour)
+	_, err := authentication.CreateSessionMacaroon(
+		names.NewUserTag("bobbrown"), &service, expiryTime,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	service.CheckCallNames(c, "ExpireStorageAt", "NewMacaroon")
+	service.CheckCall(c, 0, "ExpireStorageAt", expiryTime)
+	service.CheckCall(c, 1, "NewMacaroon", "", []byte(nil), []checkers.Caveat{
+		checkers.DeclaredCaveat("username", "bobbrown"),
+		{Condition: "time-before 0001-01-01T01:00:00Z"},
+	})
+}
+
 type mockBakeryService struct {
 	testing.Stub
 }
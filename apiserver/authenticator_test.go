@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
@@ -48,6 +49,31 @@ func (s *agentAuthenticatorSuite) TestAuthenticatorForTag(c *gc.C) {
 	c.Assert(entity, gc.DeepEquals, user)
 }
 
+func (s *agentAuthenticatorSuite) TestUserGetsLocalAuthenticator(c *gc.C) {
+	_, srv := newServer(c, s.State)
+	defer assertStop(c, srv)
+	authenticator, err := apiserver.ServerAuthenticatorForTag(srv, names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := authenticator.(*authentication.UserAuthenticator)
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *agentAuthenticatorSuite) TestUserGetsExternalAuthenticatorWithLDAP(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.LDAPURL:    "ldaps://ldap.example.com",
+		controller.LDAPUserDN: "uid=%s,ou=people,dc=example,dc=com",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, srv := newServer(c, s.State)
+	defer assertStop(c, srv)
+	authenticator, err := apiserver.ServerAuthenticatorForTag(srv, names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	external, ok := authenticator.(*authentication.ExternalPasswordAuthenticator)
+	c.Assert(ok, jc.IsTrue)
+	_, ok = external.Fallback.(*authentication.UserAuthenticator)
+	c.Assert(ok, jc.IsTrue)
+}
+
 func (s *agentAuthenticatorSuite) TestMachineGetsAgentAuthenticator(c *gc.C) {
 	_, srv := newServer(c, s.State)
 	defer srv.Stop()
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/objectstore"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.backups")
@@ -38,6 +39,7 @@ type Backend interface {
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (state.StateServingInfo, error)
 	RestoreInfo() *state.RestoreInfo
+	ObjectStores() *objectstore.Stores
 }
 
 // API serves backup-specific API methods.
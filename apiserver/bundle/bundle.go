@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
@@ -22,12 +23,26 @@ import (
 // init registers the Bundle facade.
 func init() {
 	common.RegisterStandardFacade("Bundle", 1, newFacade)
+
+	// Version 2 adds ExportBundle.
+	common.RegisterStandardFacade("Bundle", 2, newFacadeV2)
+
+	// Version 3 adds DiffBundle.
+	common.RegisterStandardFacade("Bundle", 3, newFacadeV3)
 }
 
 func newFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
 	return NewFacade(auth)
 }
 
+func newFacadeV2(st *state.State, _ facade.Resources, auth facade.Authorizer) (BundleV2, error) {
+	return NewFacadeV2(st, auth)
+}
+
+func newFacadeV3(st *state.State, _ facade.Resources, auth facade.Authorizer) (BundleV3, error) {
+	return NewFacadeV3(st, auth)
+}
+
 // NewFacade creates and returns a new Bundle API facade.
 func NewFacade(auth facade.Authorizer) (Bundle, error) {
 	if !auth.AuthClient() {
@@ -36,6 +51,18 @@ func NewFacade(auth facade.Authorizer) (Bundle, error) {
 	return &bundleAPI{}, nil
 }
 
+// NewFacadeV2 creates and returns a new version 2 Bundle API facade.
+func NewFacadeV2(st *state.State, auth facade.Authorizer) (BundleV2, error) {
+	if !auth.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &bundleAPIV2{
+		bundleAPI: &bundleAPI{},
+		st:        st,
+		auth:      auth,
+	}, nil
+}
+
 // Bundle defines the API endpoint used to retrieve bundle changes.
 type Bundle interface {
 	// GetChanges returns the list of changes required to deploy the given
@@ -43,18 +70,134 @@ type Bundle interface {
 	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
 }
 
+// NewFacadeV3 creates and returns a new version 3 Bundle API facade.
+func NewFacadeV3(st *state.State, auth facade.Authorizer) (BundleV3, error) {
+	if !auth.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &bundleAPIV3{
+		bundleAPIV2: &bundleAPIV2{
+			bundleAPI: &bundleAPI{},
+			st:        st,
+			auth:      auth,
+		},
+	}, nil
+}
+
+// BundleV2 adds ExportBundle to the Bundle API.
+type BundleV2 interface {
+	Bundle
+
+	// ExportBundle returns a bundle describing the current model.
+	ExportBundle() (params.StringResult, error)
+}
+
+// BundleV3 adds DiffBundle to the Bundle API.
+type BundleV3 interface {
+	BundleV2
+
+	// DiffBundle returns the differences between the given bundle
+	// and the current model.
+	DiffBundle(params.BundleDiffParams) (params.BundleDiffResults, error)
+}
+
 // bundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
 type bundleAPI struct{}
 
+// bundleAPIV2 implements the BundleV2 interface.
+type bundleAPIV2 struct {
+	*bundleAPI
+	st   *state.State
+	auth facade.Authorizer
+}
+
+// bundleAPIV3 implements the BundleV3 interface.
+type bundleAPIV3 struct {
+	*bundleAPIV2
+}
+
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
 // order.
 func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
 	var results params.BundleChangesResults
-	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
+	data, verifyErrors, err := readBundle(args.BundleDataYAML)
 	if err != nil {
-		return results, errors.Annotate(err, "cannot read bundle YAML")
+		return results, errors.Trace(err)
+	}
+	if len(verifyErrors) > 0 {
+		results.Errors = verifyErrors
+		return results, nil
+	}
+	changes := bundlechanges.FromData(data)
+	results.Changes = make([]*params.BundleChange, len(changes))
+	for i, c := range changes {
+		results.Changes[i] = &params.BundleChange{
+			Id:       c.Id(),
+			Method:   c.Method(),
+			Args:     c.GUIArgs(),
+			Requires: c.Requires(),
+		}
+	}
+	return results, nil
+}
+
+// ExportBundle returns, as YAML, a bundle describing the applications,
+// machines and relations of the current model. Deploying the bundle
+// into an empty model recreates them.
+func (b *bundleAPIV2) ExportBundle() (params.StringResult, error) {
+	var result params.StringResult
+	canRead, err := b.auth.HasPermission(permission.ReadAccess, b.st.ModelTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !canRead {
+		return result, common.ErrPerm
+	}
+	data, err := exportBundle(b.st)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Result, err = marshalBundle(data)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// DiffBundle returns the differences between the given bundle and the
+// applications, machines and relations of the current model.
+func (b *bundleAPIV3) DiffBundle(args params.BundleDiffParams) (params.BundleDiffResults, error) {
+	var results params.BundleDiffResults
+	canRead, err := b.auth.HasPermission(permission.ReadAccess, b.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canRead {
+		return results, common.ErrPerm
+	}
+	data, verifyErrors, err := readBundle(args.BundleDataYAML)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if len(verifyErrors) > 0 {
+		results.Errors = verifyErrors
+		return results, nil
+	}
+	results.Diff, err = diffBundle(b.st, data)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// readBundle parses and verifies the given bundle YAML, returning the
+// bundle data along with any verification errors.
+func readBundle(bundleYAML string) (*charm.BundleData, []string, error) {
+	data, err := charm.ReadBundleData(strings.NewReader(bundleYAML))
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot read bundle YAML")
 	}
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
@@ -66,24 +209,14 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	}
 	if err := data.Verify(verifyConstraints, verifyStorage); err != nil {
 		if err, ok := err.(*charm.VerificationError); ok {
-			results.Errors = make([]string, len(err.Errors))
+			verifyErrors := make([]string, len(err.Errors))
 			for i, e := range err.Errors {
-				results.Errors[i] = e.Error()
+				verifyErrors[i] = e.Error()
 			}
-			return results, nil
+			return nil, verifyErrors, nil
 		}
 		// This should never happen as Verify only returns verification errors.
-		return results, errors.Annotate(err, "cannot verify bundle")
-	}
-	changes := bundlechanges.FromData(data)
-	results.Changes = make([]*params.BundleChange, len(changes))
-	for i, c := range changes {
-		results.Changes[i] = &params.BundleChange{
-			Id:       c.Id(),
-			Method:   c.Method(),
-			Args:     c.GUIArgs(),
-			Requires: c.Requires(),
-		}
+		return nil, nil, errors.Annotate(err, "cannot verify bundle")
 	}
-	return results, nil
+	return data, nil, nil
 }
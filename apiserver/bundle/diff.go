@@ -0,0 +1,274 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+)
+
+// diffBundle returns the differences between the given bundle and the
+// model, as exported by exportBundle.
+func diffBundle(st *state.State, bundle *charm.BundleData) (*params.BundleDiff, error) {
+	model, err := exportBundle(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	diff := &params.BundleDiff{
+		Applications: make(map[string]*params.ApplicationDiff),
+		Machines:     make(map[string]*params.MachineDiff),
+	}
+	for name, bundleApp := range bundle.Applications {
+		modelApp, ok := model.Applications[name]
+		if !ok {
+			diff.Applications[name] = &params.ApplicationDiff{Missing: params.BundleDiffMissingModel}
+			continue
+		}
+		if appDiff := diffApplication(bundle, bundleApp, modelApp); appDiff != nil {
+			diff.Applications[name] = appDiff
+		}
+	}
+	for name := range model.Applications {
+		if _, ok := bundle.Applications[name]; !ok {
+			diff.Applications[name] = &params.ApplicationDiff{Missing: params.BundleDiffMissingBundle}
+		}
+	}
+	for id, bundleMachine := range bundle.Machines {
+		modelMachine, ok := model.Machines[id]
+		if !ok {
+			diff.Machines[id] = &params.MachineDiff{Missing: params.BundleDiffMissingModel}
+			continue
+		}
+		if machineDiff := diffMachine(bundle, bundleMachine, modelMachine); machineDiff != nil {
+			diff.Machines[id] = machineDiff
+		}
+	}
+	for id := range model.Machines {
+		if _, ok := bundle.Machines[id]; !ok {
+			diff.Machines[id] = &params.MachineDiff{Missing: params.BundleDiffMissingBundle}
+		}
+	}
+	diff.Relations = diffRelations(st, bundle.Relations, model.Relations)
+
+	if len(diff.Applications) == 0 {
+		diff.Applications = nil
+	}
+	if len(diff.Machines) == 0 {
+		diff.Machines = nil
+	}
+	return diff, nil
+}
+
+// diffApplication returns the differences between an application in a
+// bundle and in the model, or nil if there are none. Fields the bundle
+// leaves unset, such as the charm's revision, are not compared.
+func diffApplication(bundle *charm.BundleData, bundleApp, modelApp *charm.ApplicationSpec) *params.ApplicationDiff {
+	var diff params.ApplicationDiff
+	changed := false
+
+	bundleCharm, modelCharm := normaliseCharmURLs(bundleApp.Charm, modelApp.Charm)
+	if bundleCharm != modelCharm {
+		diff.Charm = &params.StringDiff{Bundle: bundleCharm, Model: modelCharm}
+		changed = true
+	}
+	if series := bundleSeries(bundle, bundleApp); series != "" && series != modelApp.Series {
+		diff.Series = &params.StringDiff{Bundle: series, Model: modelApp.Series}
+		changed = true
+	}
+	if cons := normaliseConstraints(bundleApp.Constraints); cons != modelApp.Constraints {
+		diff.Constraints = &params.StringDiff{Bundle: cons, Model: modelApp.Constraints}
+		changed = true
+	}
+	if bundleApp.Expose != modelApp.Expose {
+		diff.Expose = &params.BoolDiff{Bundle: bundleApp.Expose, Model: modelApp.Expose}
+		changed = true
+	}
+	if bundleApp.NumUnits != modelApp.NumUnits {
+		diff.NumUnits = &params.IntDiff{Bundle: bundleApp.NumUnits, Model: modelApp.NumUnits}
+		changed = true
+	}
+	if len(bundleApp.To) > 0 {
+		bundleTo := append([]string(nil), bundleApp.To...)
+		sort.Strings(bundleTo)
+		if !reflect.DeepEqual(bundleTo, modelApp.To) {
+			diff.Placements = &params.StringsDiff{Bundle: bundleTo, Model: modelApp.To}
+			changed = true
+		}
+	}
+	for name, value := range bundleApp.Options {
+		if modelValue, ok := modelApp.Options[name]; !ok || !optionsEqual(value, modelValue) {
+			if diff.Options == nil {
+				diff.Options = make(map[string]params.OptionDiff)
+			}
+			diff.Options[name] = params.OptionDiff{Bundle: value, Model: modelApp.Options[name]}
+			changed = true
+		}
+	}
+	for name, value := range modelApp.Options {
+		if _, ok := bundleApp.Options[name]; !ok {
+			if diff.Options == nil {
+				diff.Options = make(map[string]params.OptionDiff)
+			}
+			diff.Options[name] = params.OptionDiff{Model: value}
+			changed = true
+		}
+	}
+	for endpoint, space := range bundleApp.EndpointBindings {
+		if modelSpace := modelApp.EndpointBindings[endpoint]; space != modelSpace {
+			if diff.EndpointBindings == nil {
+				diff.EndpointBindings = make(map[string]params.StringDiff)
+			}
+			diff.EndpointBindings[endpoint] = params.StringDiff{Bundle: space, Model: modelSpace}
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return &diff
+}
+
+// diffMachine returns the differences between a machine in a bundle
+// and in the model, or nil if there are none.
+func diffMachine(bundle *charm.BundleData, bundleMachine, modelMachine *charm.MachineSpec) *params.MachineDiff {
+	if bundleMachine == nil {
+		bundleMachine = &charm.MachineSpec{}
+	}
+	var diff params.MachineDiff
+	changed := false
+	series := bundleMachine.Series
+	if series == "" {
+		series = bundle.Series
+	}
+	if series != "" && series != modelMachine.Series {
+		diff.Series = &params.StringDiff{Bundle: series, Model: modelMachine.Series}
+		changed = true
+	}
+	if cons := normaliseConstraints(bundleMachine.Constraints); cons != modelMachine.Constraints {
+		diff.Constraints = &params.StringDiff{Bundle: cons, Model: modelMachine.Constraints}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return &diff
+}
+
+// diffRelations returns the relations found only in the bundle or only
+// in the model, or nil if they have the same relations.
+func diffRelations(st *state.State, bundleRelations, modelRelations [][]string) *params.RelationsDiff {
+	bundleSet := make(map[string][]string)
+	for _, relation := range bundleRelations {
+		relation = normaliseRelation(st, relation)
+		bundleSet[strings.Join(relation, " ")] = relation
+	}
+	modelSet := make(map[string][]string)
+	for _, relation := range modelRelations {
+		modelSet[strings.Join(relation, " ")] = relation
+	}
+	var diff params.RelationsDiff
+	for key, relation := range bundleSet {
+		if _, ok := modelSet[key]; !ok {
+			diff.BundleAdditions = append(diff.BundleAdditions, relation)
+		}
+	}
+	for key, relation := range modelSet {
+		if _, ok := bundleSet[key]; !ok {
+			diff.ModelAdditions = append(diff.ModelAdditions, relation)
+		}
+	}
+	if len(diff.BundleAdditions) == 0 && len(diff.ModelAdditions) == 0 {
+		return nil
+	}
+	sort.Sort(relationsByName(diff.BundleAdditions))
+	sort.Sort(relationsByName(diff.ModelAdditions))
+	return &diff
+}
+
+// normaliseRelation returns the given bundle relation with both
+// endpoints named, in sorted order. Endpoints a bundle leaves out are
+// inferred from the model's applications, when they can be.
+func normaliseRelation(st *state.State, relation []string) []string {
+	result := append([]string(nil), relation...)
+	if len(result) == 2 && (!strings.Contains(result[0], ":") || !strings.Contains(result[1], ":")) {
+		if eps, err := st.InferEndpoints(result...); err == nil && len(eps) == 2 {
+			for i, ep := range eps {
+				result[i] = ep.ApplicationName + ":" + ep.Name
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// bundleSeries returns the series the bundle gives the application,
+// or the empty string if it does not give one.
+func bundleSeries(bundle *charm.BundleData, app *charm.ApplicationSpec) string {
+	if app.Series != "" {
+		return app.Series
+	}
+	if curl, err := charm.ParseURL(app.Charm); err == nil && curl.Series != "" {
+		return curl.Series
+	}
+	return bundle.Series
+}
+
+// normaliseCharmURLs returns the given bundle and model charm URLs,
+// with the model's charm URL stripped of any revision or series the
+// bundle's charm URL leaves out.
+func normaliseCharmURLs(bundleCharm, modelCharm string) (string, string) {
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		// Most likely a local charm path.
+		return bundleCharm, modelCharm
+	}
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return bundleCharm, modelCharm
+	}
+	if bundleURL.Revision < 0 {
+		modelURL = modelURL.WithRevision(-1)
+	}
+	if bundleURL.Series == "" {
+		modelURL.Series = ""
+	}
+	return bundleURL.String(), modelURL.String()
+}
+
+// normaliseConstraints returns the given constraints in the form
+// exportBundle gives them.
+func normaliseConstraints(s string) string {
+	cons, err := constraints.Parse(s)
+	if err != nil {
+		return s
+	}
+	return cons.String()
+}
+
+// optionsEqual reports whether the given application config values
+// are equal, treating numbers of different types as equal when they
+// have the same value.
+func optionsEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(normaliseOption(a), normaliseOption(b))
+}
+
+func normaliseOption(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return v
+}
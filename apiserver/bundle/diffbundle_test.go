@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type diffBundleSuite struct {
+	jujutesting.JujuConnSuite
+	facade bundle.BundleV3
+
+	wordpressCharm *state.Charm
+	machine        *state.Machine
+}
+
+var _ = gc.Suite(&diffBundleSuite{})
+
+func (s *diffBundleSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	facade, err := bundle.NewFacadeV3(s.State, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+
+	s.wordpressCharm = s.AddTestingCharm(c, "wordpress")
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:        "wordpress",
+		Charm:       s.wordpressCharm,
+		Settings:    map[string]interface{}{"blog-title": "my blog"},
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: s.machine})
+}
+
+func (s *diffBundleSuite) diff(c *gc.C, bundleYAML string) params.BundleDiffResults {
+	result, err := s.facade.DiffBundle(params.BundleDiffParams{BundleDataYAML: bundleYAML})
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+func (s *diffBundleSuite) TestDiffExportedBundle(c *gc.C) {
+	exported, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	result := s.diff(c, exported.Result)
+	c.Assert(result.Errors, gc.HasLen, 0)
+	c.Assert(result.Diff, jc.DeepEquals, &params.BundleDiff{})
+}
+
+func (s *diffBundleSuite) TestDiffIgnoresUnsetCharmRevisionAndSeries(c *gc.C) {
+	result := s.diff(c, `
+applications:
+    wordpress:
+        charm: local:wordpress
+        num_units: 1
+        options:
+            blog-title: my blog
+        constraints: mem=4096M
+`)
+	c.Assert(result.Diff, jc.DeepEquals, &params.BundleDiff{
+		Machines: map[string]*params.MachineDiff{
+			s.machine.Id(): {Missing: params.BundleDiffMissingBundle},
+		},
+	})
+}
+
+func (s *diffBundleSuite) TestDiffChanges(c *gc.C) {
+	result := s.diff(c, fmt.Sprintf(`
+applications:
+    wordpress:
+        charm: local:quantal/wordpress-99
+        num_units: 2
+        to: [%q]
+        expose: true
+        options:
+            blog-title: their blog
+        constraints: mem=8G
+    mysql:
+        charm: cs:quantal/mysql
+        num_units: 1
+machines:
+    %q:
+        series: trusty
+relations:
+    - [wordpress, mysql]
+`, s.machine.Id(), s.machine.Id()))
+	c.Assert(result.Errors, gc.HasLen, 0)
+	c.Assert(result.Diff, jc.DeepEquals, &params.BundleDiff{
+		Applications: map[string]*params.ApplicationDiff{
+			"wordpress": {
+				Charm: &params.StringDiff{
+					Bundle: "local:quantal/wordpress-99",
+					Model:  s.wordpressCharm.URL().String(),
+				},
+				Constraints: &params.StringDiff{Bundle: "mem=8192M", Model: "mem=4096M"},
+				Options: map[string]params.OptionDiff{
+					"blog-title": {Bundle: "their blog", Model: "my blog"},
+				},
+				Expose:   &params.BoolDiff{Bundle: true, Model: false},
+				NumUnits: &params.IntDiff{Bundle: 2, Model: 1},
+			},
+			"mysql": {Missing: params.BundleDiffMissingModel},
+		},
+		Machines: map[string]*params.MachineDiff{
+			s.machine.Id(): {Series: &params.StringDiff{Bundle: "trusty", Model: "quantal"}},
+		},
+		Relations: &params.RelationsDiff{
+			BundleAdditions: [][]string{{"mysql", "wordpress"}},
+		},
+	})
+}
+
+func (s *diffBundleSuite) TestDiffVerificationErrors(c *gc.C) {
+	result := s.diff(c, `
+applications:
+    wordpress:
+        charm: local:quantal/wordpress
+        num_units: -1
+`)
+	c.Assert(result.Diff, gc.IsNil)
+	c.Assert(result.Errors, jc.DeepEquals, []string{
+		`negative number of units specified on application "wordpress"`,
+	})
+}
@@ -0,0 +1,211 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// exportBundle returns a bundle describing the applications, machines
+// and relations of the given model. Deploying the bundle into an empty
+// model recreates them.
+func exportBundle(st *state.State) (*charm.BundleData, error) {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := &charm.BundleData{
+		Applications: make(map[string]*charm.ApplicationSpec),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+	for _, application := range applications {
+		spec, err := exportApplication(st, application, data.Machines)
+		if err != nil {
+			return nil, errors.Annotatef(err, "exporting application %q", application.Name())
+		}
+		data.Applications[application.Name()] = spec
+	}
+	data.Relations, err = exportRelations(st, data.Applications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(data.Machines) == 0 {
+		data.Machines = nil
+	}
+	return data, nil
+}
+
+// exportApplication returns the bundle specification of the given
+// application, adding the machines its units are placed on to machines.
+func exportApplication(st *state.State, application *state.Application, machines map[string]*charm.MachineSpec) (*charm.ApplicationSpec, error) {
+	curl, _ := application.CharmURL()
+	spec := &charm.ApplicationSpec{
+		Charm:  curl.String(),
+		Series: application.Series(),
+		Expose: application.IsExposed(),
+	}
+
+	settings, err := application.ConfigSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(settings) > 0 {
+		spec.Options = settings
+	}
+
+	cons, err := application.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec.Constraints = cons.String()
+
+	storageCons, err := application.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, sc := range storageCons {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		spec.Storage[name] = fmt.Sprintf("%s,%d,%dM", sc.Pool, sc.Count, sc.Size)
+	}
+
+	bindings, err := application.EndpointBindings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+
+	if !application.IsPrincipal() {
+		// Subordinate units are placed by their relations.
+		return spec, nil
+	}
+	units, err := application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec.NumUnits = len(units)
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		placement, hostId := unitPlacement(machineId)
+		if err := exportMachine(st, hostId, machines); err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec.To = append(spec.To, placement)
+	}
+	sort.Strings(spec.To)
+	if len(spec.To) != spec.NumUnits {
+		// Bundles require every unit, or none, to be placed; let
+		// the unassigned units go wherever the deployment puts them.
+		spec.To = nil
+	}
+	return spec, nil
+}
+
+// unitPlacement returns the bundle placement of a unit assigned to the
+// machine with the given id, and the id of the top-level machine that
+// hosts it. Units in containers are placed in a new container of the
+// same type on the host.
+func unitPlacement(machineId string) (placement, hostId string) {
+	parts := strings.Split(machineId, "/")
+	hostId = parts[0]
+	if len(parts) == 1 {
+		return hostId, hostId
+	}
+	containerType := parts[len(parts)-2]
+	return containerType + ":" + hostId, hostId
+}
+
+// exportMachine adds the specification of the machine with the given
+// id to machines, if it is not already there.
+func exportMachine(st *state.State, id string, machines map[string]*charm.MachineSpec) error {
+	if _, ok := machines[id]; ok {
+		return nil
+	}
+	machine, err := st.Machine(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cons, err := machine.Constraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machines[id] = &charm.MachineSpec{
+		Constraints: cons.String(),
+		Series:      machine.Series(),
+	}
+	return nil
+}
+
+// exportRelations returns the bundle relations between the given
+// applications. Peer relations, which are established automatically,
+// and relations to applications in other models are left out.
+func exportRelations(st *state.State, applications map[string]*charm.ApplicationSpec) ([][]string, error) {
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result [][]string
+	for _, relation := range relations {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			continue
+		}
+		var pair []string
+		for _, ep := range endpoints {
+			if _, ok := applications[ep.ApplicationName]; !ok {
+				break
+			}
+			pair = append(pair, ep.ApplicationName+":"+ep.Name)
+		}
+		if len(pair) != 2 {
+			continue
+		}
+		sort.Strings(pair)
+		result = append(result, pair)
+	}
+	sort.Sort(relationsByName(result))
+	return result, nil
+}
+
+type relationsByName [][]string
+
+func (r relationsByName) Len() int      { return len(r) }
+func (r relationsByName) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByName) Less(i, j int) bool {
+	if r[i][0] != r[j][0] {
+		return r[i][0] < r[j][0]
+	}
+	return r[i][1] < r[j][1]
+}
+
+// marshalBundle returns the YAML representation of the given bundle.
+func marshalBundle(data *charm.BundleData) (string, error) {
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot marshal bundle")
+	}
+	return string(out), nil
+}
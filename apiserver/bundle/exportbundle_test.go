@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/apiserver/common"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type exportBundleSuite struct {
+	jujutesting.JujuConnSuite
+	facade bundle.BundleV2
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	facade, err := bundle.NewFacadeV2(s.State, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *exportBundleSuite) exportBundle(c *gc.C) *charm.BundleData {
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *exportBundleSuite) TestExportEmptyModel(c *gc.C) {
+	data := s.exportBundle(c)
+	c.Assert(data.Applications, gc.HasLen, 0)
+	c.Assert(data.Machines, gc.HasLen, 0)
+	c.Assert(data.Relations, gc.HasLen, 0)
+}
+
+func (s *exportBundleSuite) TestExportModel(c *gc.C) {
+	wordpressCharm := s.AddTestingCharm(c, "wordpress")
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:        "wordpress",
+		Charm:       wordpressCharm,
+		Settings:    map[string]interface{}{"blog-title": "my blog"},
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	err := wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlCharm := s.AddTestingCharm(c, "mysql")
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: mysqlCharm,
+	})
+
+	host := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("cores=2"),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: host})
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: container})
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := s.exportBundle(c)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"wordpress": {
+				Charm:       wordpressCharm.URL().String(),
+				Series:      "quantal",
+				NumUnits:    1,
+				To:          []string{host.Id()},
+				Expose:      true,
+				Options:     map[string]interface{}{"blog-title": "my blog"},
+				Constraints: "mem=4096M",
+			},
+			"mysql": {
+				Charm:    mysqlCharm.URL().String(),
+				Series:   "quantal",
+				NumUnits: 1,
+				To:       []string{"lxd:" + host.Id()},
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			host.Id(): {
+				Constraints: "cores=2",
+				Series:      "quantal",
+			},
+		},
+		Relations: [][]string{{"mysql:server", "wordpress:db"}},
+	})
+	c.Assert(data.Verify(nil, nil), jc.ErrorIsNil)
+}
+
+func (s *exportBundleSuite) TestExportRequiresReadAccess(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("nobody"),
+	}
+	facade, err := bundle.NewFacadeV2(s.State, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.ExportBundle()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
@@ -93,7 +93,15 @@ func (api *CharmRevisionUpdaterAPI) updateLatestRevisions() error {
 // NewCharmStoreClient instantiates a new charm store repository.  Exported so
 // we can change it during testing.
 var NewCharmStoreClient = func(st *state.State) (charmstore.Client, error) {
-	return charmstore.NewCachingClient(state.MacaroonCache{st}, nil)
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return charmstore.Client{}, errors.Trace(err)
+	}
+	server, err := charmstore.ControllerServerURL(controllerConfig)
+	if err != nil {
+		return charmstore.Client{}, errors.Trace(err)
+	}
+	return charmstore.NewCachingClient(state.MacaroonCache{st}, server)
 }
 
 type latestCharmInfo struct {
@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrollback provides the facade used by the model's
+// charm rollback worker to revert failed charm upgrades.
+package charmrollback
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.charmrollback")
+
+func init() {
+	common.RegisterStandardFacade("CharmRollback", 1, NewAPI)
+}
+
+// API implements the CharmRollback facade.
+type API struct {
+	st *state.State
+}
+
+// NewAPI returns a new CharmRollback facade. Only the model manager
+// may use it.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &API{st: st}, nil
+}
+
+// CheckRollbacks reverts the charm upgrades, made with automatic
+// rollback, that too many units have failed, and returns the names of
+// the applications whose upgrades were reverted.
+func (api *API) CheckRollbacks() (params.StringsResult, error) {
+	var result params.StringsResult
+	applications, err := api.st.AllApplications()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, application := range applications {
+		if _, ok := application.CharmRollback(); !ok {
+			continue
+		}
+		rolledBack, err := application.CheckCharmRollback()
+		if err != nil {
+			// Keep checking the other applications.
+			logger.Errorf("checking charm rollback for application %q: %v", application.Name(), err)
+			continue
+		}
+		if rolledBack {
+			result.Result = append(result.Result, application.Name())
+		}
+	}
+	return result, nil
+}
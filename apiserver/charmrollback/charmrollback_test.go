@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollback_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/charmrollback"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type CharmRollbackSuite struct {
+	jujutesting.JujuConnSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&CharmRollbackSuite{})
+
+func (s *CharmRollbackSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:            s.AdminUserTag(c),
+		EnvironManager: true,
+	}
+}
+
+func (s *CharmRollbackSuite) TestNewAPIRequiresModelManager(c *gc.C) {
+	s.authorizer.EnvironManager = false
+	api, err := charmrollback.NewAPI(s.State, common.NewResources(), s.authorizer)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *CharmRollbackSuite) TestCheckRollbacks(c *gc.C) {
+	oldCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql", Revision: "1"})
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql", Revision: "2"})
+	failing := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "failing", Charm: oldCharm})
+	healthy := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "healthy", Charm: oldCharm})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "plain", Charm: oldCharm})
+
+	policy := &state.CharmRollbackPolicy{Threshold: 50, Window: time.Hour}
+	for _, app := range []*state.Application{failing, healthy} {
+		s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+		err := app.SetCharm(state.SetCharmConfig{Charm: newCharm, AutoRollback: policy})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	units, err := failing.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	err = units[0].SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "upgrade-charm"`,
+		Data:    map[string]interface{}{"hook": "upgrade-charm"},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := charmrollback.NewAPI(s.State, common.NewResources(), s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.CheckRollbacks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, jc.DeepEquals, []string{"failing"})
+
+	err = failing.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := failing.CharmURL()
+	c.Assert(curl, jc.DeepEquals, oldCharm.URL())
+	err = healthy.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ = healthy.CharmURL()
+	c.Assert(curl, jc.DeepEquals, newCharm.URL())
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/application"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmfeatures"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 )
@@ -241,6 +242,13 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 	if err := charm.ValidateName(name); err != nil {
 		return nil, errors.NewBadRequest(err, "")
 	}
+	features, err := charmfeatures.ReadArchive(charmFileName)
+	if err != nil {
+		return nil, errors.BadRequestf("invalid charm archive: %v", err)
+	}
+	if err := charmfeatures.Check(features); err != nil {
+		return nil, errors.NewBadRequest(err, "")
+	}
 
 	// We got it, now let's reserve a charm URL for it in state.
 	curl := &charm.URL{
@@ -458,7 +466,7 @@ func (h *charmsHandler) processGet(r *http.Request, st *state.State) (
 		fileArg = "icon.svg"
 	}
 
-	store := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	store := storage.NewStorage(st.ModelUUID(), st.MongoSession(), st.ObjectStores())
 	// Use the storage to retrieve and save the charm archive.
 	ch, err := st.Charm(curl)
 	if err != nil {
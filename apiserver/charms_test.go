@@ -229,7 +229,7 @@ func (s *charmsSuite) TestUploadRespectsLocalRevision(c *gc.C) {
 
 	c.Assert(sch.BundleSha256(), gc.Equals, expectedSHA256)
 
-	storage := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	storage := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession(), s.State.ObjectStores())
 	reader, _, err := storage.Get(sch.StoragePath())
 	c.Assert(err, jc.ErrorIsNil)
 	defer reader.Close()
@@ -285,6 +285,41 @@ func (s *charmsSuite) TestUploadRejectsWrongModelUUIDPath(c *gc.C) {
 	s.assertErrorResponse(c, resp, http.StatusNotFound, `.*unknown model: "dead-beef-123456"$`)
 }
 
+func (s *charmsSuite) archiveDummyWithFeatures(c *gc.C, features string) string {
+	dir := testcharms.Repo.ClonedDir(c.MkDir(), "dummy")
+	metadataPath := filepath.Join(dir.Path, "metadata.yaml")
+	metadata, err := ioutil.ReadFile(metadataPath)
+	c.Assert(err, jc.ErrorIsNil)
+	metadata = append(metadata, []byte("\njuju-features: "+features+"\n")...)
+	err = ioutil.WriteFile(metadataPath, metadata, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archivePath := filepath.Join(c.MkDir(), "dummy.charm")
+	f, err := os.Create(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	err = dir.ArchiveTo(f)
+	c.Assert(err, jc.ErrorIsNil)
+	return archivePath
+}
+
+func (s *charmsSuite) TestUploadRecordsJujuFeatures(c *gc.C) {
+	archivePath := s.archiveDummyWithFeatures(c, "[storage, leadership]")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", archivePath)
+	expectedURL := charm.MustParseURL("local:quantal/dummy-1")
+	s.assertUploadResponse(c, resp, expectedURL.String())
+	sch, err := s.State.Charm(expectedURL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.JujuFeatures(), jc.DeepEquals, []string{"leadership", "storage"})
+}
+
+func (s *charmsSuite) TestUploadRejectsUnsupportedJujuFeatures(c *gc.C) {
+	archivePath := s.archiveDummyWithFeatures(c, "[storage, teleport]")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", archivePath)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest,
+		".*charm requires juju feature not supported by this controller: teleport; upgrade the controller before deploying it")
+}
+
 func (s *charmsSuite) TestUploadRepackagesNestedArchives(c *gc.C) {
 	// Make a clone of the dummy charm in a nested directory.
 	rootDir := c.MkDir()
@@ -318,7 +353,7 @@ func (s *charmsSuite) TestUploadRepackagesNestedArchives(c *gc.C) {
 	// Get it from the storage and try to read it as a bundle - it
 	// should succeed, because it was repackaged during upload to
 	// strip nested dirs.
-	storage := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	storage := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession(), s.State.ObjectStores())
 	reader, _, err := storage.Get(sch.StoragePath())
 	c.Assert(err, jc.ErrorIsNil)
 	defer reader.Close()
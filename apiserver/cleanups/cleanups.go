@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cleanups provides the facade used to inspect a model's
+// pending state cleanups and retry those that have been given up on.
+package cleanups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Cleanups", 1, NewAPI)
+}
+
+// API implements the Cleanups facade.
+type API struct {
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new Cleanups facade.
+func NewAPI(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:         st,
+		authorizer: authorizer,
+	}, nil
+}
+
+// checkCanAdmin returns an error unless the user is an administrator
+// of the model. Cleanups expose model internals, so reading them
+// requires admin access too.
+func (api *API) checkCanAdmin() error {
+	ok, err := api.authorizer.HasPermission(permission.AdminAccess, api.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ListCleanups returns the model's pending cleanups, with the details
+// of any failed attempts to run them.
+func (api *API) ListCleanups() (params.CleanupsResult, error) {
+	var result params.CleanupsResult
+	if err := api.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	cleanups, err := api.st.Cleanups()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Cleanups = make([]params.CleanupInfo, len(cleanups))
+	for i, cleanup := range cleanups {
+		info := params.CleanupInfo{
+			Id:         cleanup.Id,
+			Kind:       cleanup.Kind,
+			Prefix:     cleanup.Prefix,
+			Attempts:   cleanup.Attempts,
+			LastError:  cleanup.LastError,
+			DeadLetter: cleanup.DeadLetter,
+		}
+		if !cleanup.FirstFailure.IsZero() {
+			firstFailure := cleanup.FirstFailure
+			info.FirstFailure = &firstFailure
+		}
+		if !cleanup.NextAttempt.IsZero() {
+			nextAttempt := cleanup.NextAttempt
+			info.NextAttempt = &nextAttempt
+		}
+		result.Cleanups[i] = info
+	}
+	return result, nil
+}
+
+// RetryCleanups returns the model's dead-lettered cleanups to the
+// queue, to be run again as if they had never failed.
+func (api *API) RetryCleanups() (params.RetryCleanupsResult, error) {
+	var result params.RetryCleanupsResult
+	if err := api.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	retried, err := api.st.RetryCleanups()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Retried = retried
+	return result, nil
+}
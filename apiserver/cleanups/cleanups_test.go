@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cleanups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/cleanups"
+	"github.com/juju/juju/apiserver/common"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+)
+
+type CleanupsSuite struct {
+	jujutesting.JujuConnSuite
+	api *cleanups.API
+}
+
+var _ = gc.Suite(&CleanupsSuite{})
+
+func (s *CleanupsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	var err error
+	s.api, err = cleanups.NewAPI(s.State, common.NewResources(), apiservertesting.FakeAuthorizer{
+		Tag:      s.AdminUserTag(c),
+		AdminTag: s.AdminUserTag(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CleanupsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	api, err := cleanups.NewAPI(s.State, common.NewResources(), apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *CleanupsSuite) TestRequiresAdminAccess(c *gc.C) {
+	api, err := cleanups.NewAPI(s.State, common.NewResources(), apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ListCleanups()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = api.RetryCleanups()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *CleanupsSuite) TestListCleanups(c *gc.C) {
+	result, err := s.api.ListCleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Cleanups, gc.HasLen, 0)
+
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err = app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = s.api.ListCleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Cleanups, gc.HasLen, 1)
+	cleanup := result.Cleanups[0]
+	c.Assert(cleanup.Kind, gc.Equals, "units")
+	c.Assert(cleanup.Prefix, gc.Equals, "dummy")
+	c.Assert(cleanup.Attempts, gc.Equals, 0)
+	c.Assert(cleanup.FirstFailure, gc.IsNil)
+	c.Assert(cleanup.NextAttempt, gc.IsNil)
+	c.Assert(cleanup.DeadLetter, jc.IsFalse)
+}
+
+func (s *CleanupsSuite) TestRetryCleanups(c *gc.C) {
+	result, err := s.api.RetryCleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Retried, gc.Equals, 0)
+	needsCleanup, err := s.State.NeedsCleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(needsCleanup, jc.IsFalse)
+}
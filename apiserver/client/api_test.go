@@ -347,6 +347,7 @@ var scenarioStatus = &params.FullStatus{
 			},
 			Interface: "logging",
 			Scope:     "container",
+			Status:    "joined",
 		},
 	},
 }
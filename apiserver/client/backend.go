@@ -26,6 +26,7 @@ type Unit interface {
 	PublicAddress() (network.Address, error)
 	PrivateAddress() (network.Address, error)
 	Resolve(retryHooks bool) error
+	ResolveOperation(skip bool) error
 	AgentHistory() status.StatusHistoryGetter
 }
 
@@ -49,6 +50,7 @@ type Backend interface {
 	Application(string) (*state.Application, error)
 	ApplicationLeaders() (map[string]string, error)
 	Charm(*charm.URL) (*state.Charm, error)
+	CheckQuotas(state.QuotaUsage) error
 	EndpointsRelation(...state.Endpoint) (*state.Relation, error)
 	FindEntity(names.Tag) (state.Entity, error)
 	ForModel(tag names.ModelTag) (*state.State, error)
@@ -61,6 +63,7 @@ type Backend interface {
 	ModelConfig() (*config.Config, error)
 	ModelConfigValues() (config.ConfigValues, error)
 	ModelConstraints() (constraints.Value, error)
+	ModelStatusHistory(state.ModelStatusHistoryFilter) ([]state.EntityStatusInfo, error)
 	ModelTag() names.ModelTag
 	ModelUUID() string
 	RemoveUserAccess(names.UserTag, names.Tag) error
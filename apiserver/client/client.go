@@ -23,11 +23,20 @@ import (
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/status"
 	jujuversion "github.com/juju/juju/version"
 )
 
 func init() {
 	common.RegisterStandardFacade("Client", 1, newClient)
+	// Version 2 adds WatchFullStatus.
+	common.RegisterStandardFacade("Client", 2, newClient)
+	// Version 3 adds ModelStatusHistory.
+	common.RegisterStandardFacade("Client", 3, newClient)
+	// Version 4 adds ResolveOperation.
+	common.RegisterStandardFacade("Client", 4, newClient)
+	// Version 5 adds ResolveUnitErrors.
+	common.RegisterStandardFacade("Client", 5, newClient)
 }
 
 var logger = loggo.GetLogger("juju.apiserver.client")
@@ -72,6 +81,17 @@ func (c *Client) checkCanRead() error {
 	return nil
 }
 
+func (c *Client) checkCanOperate() error {
+	canOperate, err := c.api.auth.HasPermission(permission.OperatorAccess, c.api.stateAccessor.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canOperate {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (c *Client) checkCanWrite() error {
 	canWrite, err := c.api.auth.HasPermission(permission.WriteAccess, c.api.stateAccessor.ModelTag())
 	if err != nil {
@@ -149,7 +169,7 @@ func (c *Client) WatchAll() (params.AllWatcherId, error) {
 
 // Resolved implements the server side of Client.Resolved.
 func (c *Client) Resolved(p params.Resolved) error {
-	if err := c.checkCanWrite(); err != nil {
+	if err := c.checkCanOperate(); err != nil {
 		return err
 	}
 	if err := c.check.ChangeAllowed(); err != nil {
@@ -162,6 +182,91 @@ func (c *Client) Resolved(p params.Resolved) error {
 	return unit.Resolve(p.Retry)
 }
 
+// ResolveOperation marks the operation pending in a unit's agent to be
+// skipped or failed. Unlike Resolved, it does not require the unit to
+// be in an error state, and so can be used on a unit whose hook is
+// stuck.
+func (c *Client) ResolveOperation(p params.ResolveOperation) error {
+	if err := c.checkCanOperate(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := c.api.stateAccessor.Unit(p.UnitName)
+	if err != nil {
+		return err
+	}
+	return unit.ResolveOperation(p.Skip)
+}
+
+// ResolveUnitErrors marks the errors of the units of the given
+// applications, or of every application in the model, as resolved.
+// Units that are not in an error state are left alone; the results
+// hold an entry for each unit that was, and for each application
+// whose units could not be read.
+func (c *Client) ResolveUnitErrors(p params.ResolveUnitErrors) (params.ResolveUnitErrorsResults, error) {
+	var results params.ResolveUnitErrorsResults
+	if err := c.checkCanOperate(); err != nil {
+		return results, err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	switch {
+	case p.All && len(p.Applications) > 0:
+		return results, errors.New("cannot resolve all units and specific applications")
+	case !p.All && len(p.Applications) == 0:
+		return results, errors.New("no applications specified")
+	}
+
+	var applications []*state.Application
+	if p.All {
+		all, err := c.api.stateAccessor.AllApplications()
+		if err != nil {
+			return results, errors.Trace(err)
+		}
+		applications = all
+	} else {
+		for _, name := range p.Applications {
+			application, err := c.api.stateAccessor.Application(name)
+			if err != nil {
+				results.Results = append(results.Results, params.ResolveUnitErrorsResult{
+					Tag:   names.NewApplicationTag(name).String(),
+					Error: common.ServerError(err),
+				})
+				continue
+			}
+			applications = append(applications, application)
+		}
+	}
+
+	for _, application := range applications {
+		units, err := application.AllUnits()
+		if err != nil {
+			results.Results = append(results.Results, params.ResolveUnitErrorsResult{
+				Tag:   application.Tag().String(),
+				Error: common.ServerError(err),
+			})
+			continue
+		}
+		for _, unit := range units {
+			statusInfo, err := unit.Status()
+			if err == nil {
+				if statusInfo.Status != status.Error {
+					continue
+				}
+				err = unit.Resolve(p.NoRetry)
+			}
+			results.Results = append(results.Results, params.ResolveUnitErrorsResult{
+				Tag:   unit.Tag().String(),
+				Error: common.ServerError(err),
+			})
+		}
+	}
+	return results, nil
+}
+
 // PublicAddress implements the server side of Client.PublicAddress.
 func (c *Client) PublicAddress(p params.PublicAddress) (results params.PublicAddressResults, err error) {
 	if err := c.checkCanRead(); err != nil {
@@ -351,6 +456,14 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 		Addresses:               params.NetworkAddresses(p.Addrs...),
 		Placement:               placementDirective,
 	}
+	// A container placed on a new machine adds two machines.
+	newMachines := 1
+	if p.ContainerType != "" && p.ParentId == "" {
+		newMachines = 2
+	}
+	if err := c.api.stateAccessor.CheckQuotas(state.QuotaUsage{Machines: newMachines}); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if p.ContainerType == "" {
 		return c.api.stateAccessor.AddOneMachine(template)
 	}
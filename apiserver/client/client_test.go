@@ -547,6 +547,59 @@ func (s *clientSuite) TestClientUnitResolvedRetry(c *gc.C) {
 	s.testClientUnitResolved(c, false, state.ResolvedRetryHooks)
 }
 
+func (s *clientSuite) TestClientResolveUnitErrors(c *gc.C) {
+	// The scenario leaves wordpress/0 in an error state.
+	s.setUpScenario(c)
+	u, err := s.State.Unit("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.APIState.Client().ResolveUnitErrors([]string{"wordpress", "unknown"}, false, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Tag, gc.Equals, "application-unknown")
+	c.Assert(results[0].Error, gc.ErrorMatches, `application "unknown" not found`)
+	c.Assert(results[1], jc.DeepEquals, params.ResolveUnitErrorsResult{Tag: "unit-wordpress-0"})
+
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.Resolved(), gc.Equals, state.ResolvedNoHooks)
+	// Units without errors are left alone.
+	other, err := s.State.Unit("wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other.Resolved(), gc.Equals, state.ResolvedNone)
+}
+
+func (s *clientSuite) TestClientResolveUnitErrorsAll(c *gc.C) {
+	s.setUpScenario(c)
+	u, err := s.State.Unit("logging/0")
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	err = u.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "gaaah",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The scenario leaves wordpress/0 in an error state too.
+	results, err := s.APIState.Client().ResolveUnitErrors(nil, true, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.SameContents, []params.ResolveUnitErrorsResult{
+		{Tag: "unit-logging-0"},
+		{Tag: "unit-wordpress-0"},
+	})
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.Resolved(), gc.Equals, state.ResolvedRetryHooks)
+}
+
+func (s *clientSuite) TestClientResolveUnitErrorsInvalidArgs(c *gc.C) {
+	_, err := s.APIState.Client().ResolveUnitErrors(nil, false, false)
+	c.Assert(err, gc.ErrorMatches, "no applications specified")
+	_, err = s.APIState.Client().ResolveUnitErrors([]string{"wordpress"}, true, false)
+	c.Assert(err, gc.ErrorMatches, "cannot resolve all units and specific applications")
+}
+
 func (s *clientSuite) setupResolved(c *gc.C) *state.Unit {
 	s.setUpScenario(c)
 	u, err := s.State.Unit("wordpress/0")
@@ -676,6 +729,30 @@ func (s *clientSuite) TestClientWatchAll(c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestClientWatchFullStatus(c *gc.C) {
+	// A very simple end-to-end test; the diffing logic
+	// is tested in fullstatuswatcher_test.go.
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	watcher, err := s.APIState.Client().WatchFullStatus(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := watcher.Stop()
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+	fullStatus, err := watcher.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fullStatus.Machines, gc.HasLen, 1)
+	c.Assert(fullStatus.Machines[m.Id()].InstanceId, gc.Equals, instance.Id("pending"))
+
+	err = m.SetProvisioned("i-0", agent.BootstrapNonce, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	fullStatus, err = watcher.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fullStatus.Machines, gc.HasLen, 1)
+	c.Assert(fullStatus.Machines[m.Id()].InstanceId, gc.Equals, instance.Id("i-0"))
+}
+
 func (s *clientSuite) TestClientSetModelConstraints(c *gc.C) {
 	// Set constraints for the model.
 	cons, err := constraints.Parse("mem=4096", "cores=2")
@@ -13,6 +13,10 @@ var (
 var (
 	ProcessMachines   = processMachines
 	MakeMachineStatus = makeMachineStatus
+	DiffFullStatus    = diffFullStatus
+
+	NewFullStatusWatcher  = newFullStatusWatcher
+	FullStatusMinInterval = fullStatusMinInterval
 )
 
 type MachineAndContainers machineAndContainers
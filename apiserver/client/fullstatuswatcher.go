@@ -0,0 +1,228 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// fullStatusMinInterval is the shortest time between two computations
+// of a FullStatusWatcher's status. Computing the full status is
+// expensive, and a busy model can change many times a second, so the
+// changes seen in the meantime are coalesced into one delta.
+const fullStatusMinInterval = time.Second
+
+// WatchFullStatus returns a watcher that reports changes to the
+// status of the model, filtered by the given patterns in the same
+// way as FullStatus.
+func (c *Client) WatchFullStatus(args params.StatusParams) (params.FullStatusWatcherId, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.FullStatusWatcherId{}, err
+	}
+	fullStatus := func() (params.FullStatus, error) {
+		return c.FullStatus(params.StatusParams{Patterns: args.Patterns})
+	}
+	w := newFullStatusWatcher(c.api.stateAccessor.Watch(), fullStatus, clock.WallClock)
+	return params.FullStatusWatcherId{
+		FullStatusWatcherId: c.api.resources.Register(w),
+	}, nil
+}
+
+// modelWatcher reports changes to the entities in a model; it is
+// implemented by *state.Multiwatcher.
+type modelWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// FullStatusWatcher recomputes a model's full status when the model
+// changes, and reports only the parts that differ from the status
+// most recently reported. The status is recomputed at most once every
+// fullStatusMinInterval, however often the model changes.
+type FullStatusWatcher struct {
+	fullStatus func() (params.FullStatus, error)
+	watcher    modelWatcher
+	clock      clock.Clock
+
+	// changes is signalled when the model has changed since the
+	// status was last computed.
+	changes chan struct{}
+
+	// done is closed when the model watcher fails or is stopped,
+	// after err is set.
+	done chan struct{}
+	err  error
+
+	current  *params.FullStatus
+	computed time.Time
+}
+
+func newFullStatusWatcher(
+	watcher modelWatcher,
+	fullStatus func() (params.FullStatus, error),
+	clock clock.Clock,
+) *FullStatusWatcher {
+	w := &FullStatusWatcher{
+		fullStatus: fullStatus,
+		watcher:    watcher,
+		clock:      clock,
+		changes:    make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	go w.loop()
+	return w
+}
+
+// loop collapses the model watcher's events into signals on the
+// changes channel, so that the events arriving while the status is
+// computed or rate limited are not queued up.
+func (w *FullStatusWatcher) loop() {
+	defer close(w.done)
+	for {
+		if _, err := w.watcher.Next(); err != nil {
+			w.err = err
+			return
+		}
+		select {
+		case w.changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Next returns the changes to the model's status since the previous
+// call to Next. The first call returns the complete status. Next
+// blocks until there are changes to report.
+func (w *FullStatusWatcher) Next() (params.FullStatusDelta, error) {
+	if w.current == nil {
+		status, err := w.compute()
+		if err != nil {
+			return params.FullStatusDelta{}, errors.Trace(err)
+		}
+		w.current = &status
+		return diffFullStatus(params.FullStatus{}, status), nil
+	}
+	for {
+		select {
+		case <-w.changes:
+		case <-w.done:
+			return params.FullStatusDelta{}, errors.Trace(w.err)
+		}
+		if wait := w.computed.Add(fullStatusMinInterval).Sub(w.clock.Now()); wait > 0 {
+			select {
+			case <-w.clock.After(wait):
+			case <-w.done:
+				return params.FullStatusDelta{}, errors.Trace(w.err)
+			}
+			// The status about to be computed covers any
+			// changes made while waiting.
+			select {
+			case <-w.changes:
+			default:
+			}
+		}
+		status, err := w.compute()
+		if err != nil {
+			return params.FullStatusDelta{}, errors.Trace(err)
+		}
+		delta := diffFullStatus(*w.current, status)
+		if isEmptyDelta(delta) {
+			continue
+		}
+		w.current = &status
+		return delta, nil
+	}
+}
+
+// Stop stops the watcher.
+func (w *FullStatusWatcher) Stop() error {
+	err := w.watcher.Stop()
+	<-w.done
+	return err
+}
+
+func (w *FullStatusWatcher) compute() (params.FullStatus, error) {
+	w.computed = w.clock.Now()
+	return w.fullStatus()
+}
+
+// diffFullStatus returns the delta that transforms old into new.
+func diffFullStatus(old, new params.FullStatus) params.FullStatusDelta {
+	var delta params.FullStatusDelta
+	if !reflect.DeepEqual(old.Model, new.Model) {
+		model := new.Model
+		delta.Model = &model
+	}
+	for id, m := range new.Machines {
+		if oldM, ok := old.Machines[id]; !ok || !reflect.DeepEqual(oldM, m) {
+			if delta.Machines == nil {
+				delta.Machines = make(map[string]*params.MachineStatus)
+			}
+			m := m
+			delta.Machines[id] = &m
+		}
+	}
+	for id := range old.Machines {
+		if _, ok := new.Machines[id]; !ok {
+			if delta.Machines == nil {
+				delta.Machines = make(map[string]*params.MachineStatus)
+			}
+			delta.Machines[id] = nil
+		}
+	}
+	for name, app := range new.Applications {
+		if oldApp, ok := old.Applications[name]; !ok || !reflect.DeepEqual(oldApp, app) {
+			if delta.Applications == nil {
+				delta.Applications = make(map[string]*params.ApplicationStatus)
+			}
+			app := app
+			delta.Applications[name] = &app
+		}
+	}
+	for name := range old.Applications {
+		if _, ok := new.Applications[name]; !ok {
+			if delta.Applications == nil {
+				delta.Applications = make(map[string]*params.ApplicationStatus)
+			}
+			delta.Applications[name] = nil
+		}
+	}
+	for name, app := range new.RemoteApplications {
+		if oldApp, ok := old.RemoteApplications[name]; !ok || !reflect.DeepEqual(oldApp, app) {
+			if delta.RemoteApplications == nil {
+				delta.RemoteApplications = make(map[string]*params.RemoteApplicationStatus)
+			}
+			app := app
+			delta.RemoteApplications[name] = &app
+		}
+	}
+	for name := range old.RemoteApplications {
+		if _, ok := new.RemoteApplications[name]; !ok {
+			if delta.RemoteApplications == nil {
+				delta.RemoteApplications = make(map[string]*params.RemoteApplicationStatus)
+			}
+			delta.RemoteApplications[name] = nil
+		}
+	}
+	if !reflect.DeepEqual(old.Relations, new.Relations) {
+		delta.Relations = new.Relations
+		delta.RelationsChanged = true
+	}
+	return delta
+}
+
+func isEmptyDelta(delta params.FullStatusDelta) bool {
+	return delta.Model == nil &&
+		len(delta.Machines) == 0 &&
+		len(delta.Applications) == 0 &&
+		len(delta.RemoteApplications) == 0 &&
+		!delta.RelationsChanged
+}
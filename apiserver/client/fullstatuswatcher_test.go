@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/client"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type diffFullStatusSuite struct{}
+
+var _ = gc.Suite(&diffFullStatusSuite{})
+
+func (s *diffFullStatusSuite) TestNoChanges(c *gc.C) {
+	status := params.FullStatus{
+		Model:        params.ModelStatusInfo{Name: "foo"},
+		Machines:     map[string]params.MachineStatus{"0": {Id: "0"}},
+		Applications: map[string]params.ApplicationStatus{"mysql": {Charm: "cs:mysql-1"}},
+		Relations:    []params.RelationStatus{{Id: 1, Key: "wordpress:db mysql:server"}},
+	}
+	delta := client.DiffFullStatus(status, status)
+	c.Assert(delta, jc.DeepEquals, params.FullStatusDelta{})
+}
+
+func (s *diffFullStatusSuite) TestInitial(c *gc.C) {
+	status := params.FullStatus{
+		Model:    params.ModelStatusInfo{Name: "foo"},
+		Machines: map[string]params.MachineStatus{"0": {Id: "0"}},
+	}
+	delta := client.DiffFullStatus(params.FullStatus{}, status)
+	c.Assert(delta, jc.DeepEquals, params.FullStatusDelta{
+		Model:    &params.ModelStatusInfo{Name: "foo"},
+		Machines: map[string]*params.MachineStatus{"0": {Id: "0"}},
+	})
+}
+
+func (s *diffFullStatusSuite) TestChangesAndRemovals(c *gc.C) {
+	old := params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "foo"},
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0", InstanceId: "pending"},
+			"1": {Id: "1"},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql":     {Charm: "cs:mysql-1"},
+			"wordpress": {Charm: "cs:wordpress-1"},
+		},
+	}
+	new := params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "foo"},
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0", InstanceId: "i-0"},
+			"2": {Id: "2"},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Charm: "cs:mysql-1"},
+		},
+		Relations: []params.RelationStatus{{Id: 1}},
+	}
+	delta := client.DiffFullStatus(old, new)
+	c.Assert(delta, jc.DeepEquals, params.FullStatusDelta{
+		Machines: map[string]*params.MachineStatus{
+			"0": {Id: "0", InstanceId: "i-0"},
+			"1": nil,
+			"2": {Id: "2"},
+		},
+		Applications: map[string]*params.ApplicationStatus{
+			"wordpress": nil,
+		},
+		Relations:        []params.RelationStatus{{Id: 1}},
+		RelationsChanged: true,
+	})
+}
+
+type fullStatusWatcherSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&fullStatusWatcherSuite{})
+
+// fakeModelWatcher reports a change for each value sent on events.
+type fakeModelWatcher struct {
+	events  chan struct{}
+	stopped chan struct{}
+}
+
+func newFakeModelWatcher() *fakeModelWatcher {
+	return &fakeModelWatcher{
+		events:  make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (w *fakeModelWatcher) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case <-w.events:
+		return nil, nil
+	case <-w.stopped:
+		return nil, errors.New("watcher was stopped")
+	}
+}
+
+func (w *fakeModelWatcher) Stop() error {
+	close(w.stopped)
+	return nil
+}
+
+func (s *fullStatusWatcherSuite) TestChangesCoalesced(c *gc.C) {
+	clock := testing.NewClock(time.Now())
+	modelWatcher := newFakeModelWatcher()
+	computed := 0
+	fullStatus := func() (params.FullStatus, error) {
+		computed++
+		return params.FullStatus{
+			Model: params.ModelStatusInfo{Name: fmt.Sprint(computed)},
+		}, nil
+	}
+	w := client.NewFullStatusWatcher(modelWatcher, fullStatus, clock)
+	defer w.Stop()
+
+	delta, err := w.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(delta.Model, jc.DeepEquals, &params.ModelStatusInfo{Name: "1"})
+
+	for i := 0; i < 3; i++ {
+		modelWatcher.events <- struct{}{}
+	}
+	type result struct {
+		delta params.FullStatusDelta
+		err   error
+	}
+	results := make(chan result)
+	go func() {
+		delta, err := w.Next()
+		results <- result{delta, err}
+	}()
+
+	// The status is not recomputed until the interval has passed.
+	select {
+	case <-clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for rate limit")
+	}
+	clock.Advance(client.FullStatusMinInterval)
+
+	select {
+	case r := <-results:
+		c.Assert(r.err, jc.ErrorIsNil)
+		c.Assert(r.delta.Model, jc.DeepEquals, &params.ModelStatusInfo{Name: "2"})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for delta")
+	}
+	c.Assert(computed, gc.Equals, 2)
+}
+
+func (s *fullStatusWatcherSuite) TestStopEndsNext(c *gc.C) {
+	modelWatcher := newFakeModelWatcher()
+	fullStatus := func() (params.FullStatus, error) {
+		return params.FullStatus{}, nil
+	}
+	w := client.NewFullStatusWatcher(modelWatcher, fullStatus, testing.NewClock(time.Now()))
+	_, err := w.Next()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = w.Stop()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Next()
+	c.Assert(err, gc.ErrorMatches, "watcher was stopped")
+}
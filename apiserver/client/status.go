@@ -162,6 +162,44 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// ModelStatusHistory returns the recorded status history of the
+// machines and units in the model, oldest entry first.
+func (c *Client) ModelStatusHistory(args params.ModelStatusHistoryRequest) (params.ModelStatusHistoryResult, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.ModelStatusHistoryResult{}, err
+	}
+	filter := state.ModelStatusHistoryFilter{
+		From: args.From,
+		To:   args.To,
+		Size: args.Size,
+	}
+	for _, tagString := range args.Tags {
+		tag, err := names.ParseTag(tagString)
+		if err != nil {
+			return params.ModelStatusHistoryResult{}, errors.Trace(err)
+		}
+		filter.Entities = append(filter.Entities, tag)
+	}
+	history, err := c.api.stateAccessor.ModelStatusHistory(filter)
+	if err != nil {
+		return params.ModelStatusHistoryResult{}, errors.Annotate(err, "fetching model status history")
+	}
+	entries := make([]params.EntityStatusHistoryEntry, len(history))
+	for i, entry := range history {
+		entries[i] = params.EntityStatusHistoryEntry{
+			Tag: entry.Tag.String(),
+			Status: params.DetailedStatus{
+				Status: string(entry.Status),
+				Info:   entry.Message,
+				Data:   entry.Data,
+				Since:  entry.Since,
+				Kind:   string(entry.Kind),
+			},
+		}
+	}
+	return params.ModelStatusHistoryResult{Entries: entries}, nil
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
@@ -169,8 +207,11 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}
 
 	var noStatus params.FullStatus
+	fields, err := statusFields(args.Fields)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
 	var context statusContext
-	var err error
 	if context.applications, context.units, context.latestCharms, err =
 		fetchAllApplicationsAndUnits(c.api.stateAccessor, len(args.Patterns) <= 0); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch applications and units")
@@ -288,13 +329,53 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine model status")
 	}
-	return params.FullStatus{
-		Model:              modelStatus,
-		Machines:           processMachines(context.machines),
-		Applications:       context.processApplications(),
-		RemoteApplications: context.processRemoteApplications(),
-		Relations:          context.processRelations(),
-	}, nil
+	result := params.FullStatus{Model: modelStatus}
+	if fields.Contains(statusFieldMachines) {
+		result.Machines = processMachines(context.machines)
+	}
+	// Relation status depends on the status of the units.
+	if fields.Contains(statusFieldApplications) || fields.Contains(statusFieldRelations) {
+		applications := context.processApplications()
+		if fields.Contains(statusFieldApplications) {
+			result.Applications = applications
+		}
+		if fields.Contains(statusFieldRelations) {
+			result.Relations = context.processRelations(applications)
+		}
+	}
+	if fields.Contains(statusFieldRemoteApplications) {
+		result.RemoteApplications = context.processRemoteApplications()
+	}
+	return result, nil
+}
+
+// These are the parts of FullStatus that may be requested
+// in StatusParams.Fields.
+const (
+	statusFieldMachines           = "machines"
+	statusFieldApplications       = "applications"
+	statusFieldRemoteApplications = "remote-applications"
+	statusFieldRelations          = "relations"
+)
+
+var allStatusFields = set.NewStrings(
+	statusFieldMachines,
+	statusFieldApplications,
+	statusFieldRemoteApplications,
+	statusFieldRelations,
+)
+
+// statusFields returns the set of parts of FullStatus to fill in for
+// the given requested fields; all of them if none are requested.
+func statusFields(requested []string) (set.Strings, error) {
+	if len(requested) == 0 {
+		return allStatusFields, nil
+	}
+	fields := set.NewStrings(requested...)
+	if unknown := fields.Difference(allStatusFields); !unknown.IsEmpty() {
+		return nil, errors.NotValidf("status fields %q", unknown.SortedValues())
+	}
+	return fields, nil
 }
 
 // newToolsVersionAvailable will return a string representing a tools
@@ -575,8 +656,12 @@ func makeMachineStatus(machine *state.Machine) (status params.MachineStatus) {
 	return
 }
 
-func (context *statusContext) processRelations() []params.RelationStatus {
+// processRelations returns the status of every relation. The
+// applications' processed status is used to find the units whose
+// hooks for each relation have failed.
+func (context *statusContext) processRelations(applications map[string]params.ApplicationStatus) []params.RelationStatus {
 	var out []params.RelationStatus
+	unitsInError := relationUnitsInError(applications)
 	relations := context.getAllRelations()
 	for _, relation := range relations {
 		var eps []params.EndpointStatus
@@ -599,12 +684,59 @@ func (context *statusContext) processRelations() []params.RelationStatus {
 			Interface: relationInterface,
 			Scope:     string(scope),
 			Endpoints: eps,
+			Status:    params.RelationJoined,
+		}
+		if units := unitsInError[relation.Id()]; len(units) > 0 {
+			sort.Strings(units)
+			relStatus.Status = params.RelationError
+			relStatus.UnitsInError = units
+		} else if relation.Life() != state.Alive {
+			relStatus.Status = params.RelationBroken
 		}
 		out = append(out, relStatus)
 	}
 	return out
 }
 
+// relationUnitsInError returns the names of the units, including
+// subordinates, whose workloads are in error because of a failed
+// relation hook, keyed by relation id.
+func relationUnitsInError(applications map[string]params.ApplicationStatus) map[int][]string {
+	out := make(map[int][]string)
+	var addUnits func(map[string]params.UnitStatus)
+	addUnits = func(units map[string]params.UnitStatus) {
+		for name, unit := range units {
+			if id, ok := relationIdFromStatus(unit.WorkloadStatus); ok {
+				out[id] = append(out[id], name)
+			}
+			addUnits(unit.Subordinates)
+		}
+	}
+	for _, application := range applications {
+		addUnits(application.Units)
+	}
+	return out
+}
+
+// relationIdFromStatus returns the id of the relation whose hook put
+// the given status into error, and whether there is one.
+func relationIdFromStatus(s params.DetailedStatus) (int, bool) {
+	if s.Status != status.Error.String() {
+		return 0, false
+	}
+	// The id may have been decoded from the database as any
+	// integer type.
+	switch id := s.Data["relation-id"].(type) {
+	case int:
+		return id, true
+	case int64:
+		return int(id), true
+	case float64:
+		return int(id), true
+	}
+	return 0, false
+}
+
 // This method exists only to dedup the loaded relations as they will
 // appear multiple times in context.relations.
 func (context *statusContext) getAllRelations() []*state.Relation {
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -71,6 +72,36 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+func (s *statusSuite) TestFullStatusRelationInError(c *gc.C) {
+	rel := s.Factory.MakeRelation(c, nil)
+	app, err := s.State.Application(rel.Endpoints()[1].ApplicationName)
+	c.Assert(err, jc.ErrorIsNil)
+	u := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	now := time.Now()
+	err = u.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "db-relation-changed"`,
+		Data:    map[string]interface{}{"relation-id": rel.Id()},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	fullStatus, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fullStatus.Relations, gc.HasLen, 1)
+	c.Check(fullStatus.Relations[0].Status, gc.Equals, params.RelationError)
+	c.Check(fullStatus.Relations[0].UnitsInError, jc.DeepEquals, []string{u.Name()})
+
+	err = u.SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	fullStatus, err = client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fullStatus.Relations, gc.HasLen, 1)
+	c.Check(fullStatus.Relations[0].Status, gc.Equals, params.RelationJoined)
+	c.Check(fullStatus.Relations[0].UnitsInError, gc.HasLen, 0)
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/client"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
@@ -218,10 +219,72 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestModelStatusHistory(c *gc.C) {
+	since := time.Unix(1000, 0)
+	s.st.modelHistory = []state.EntityStatusInfo{{
+		StatusInfo: status.StatusInfo{Status: status.Active, Message: "running", Since: &since},
+		Tag:        names.NewUnitTag("unit/0"),
+		Kind:       status.KindWorkload,
+	}, {
+		StatusInfo: status.StatusInfo{Status: status.Started, Since: &since},
+		Tag:        names.NewMachineTag("0"),
+		Kind:       status.KindMachine,
+	}}
+	from := time.Unix(500, 0)
+	result, err := s.api.ModelStatusHistory(params.ModelStatusHistoryRequest{
+		From: &from,
+		Tags: []string{"unit-unit-0", "machine-0", "application-unit"},
+		Size: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.st.modelHistoryFilter, jc.DeepEquals, state.ModelStatusHistoryFilter{
+		From: &from,
+		Entities: []names.Tag{
+			names.NewUnitTag("unit/0"),
+			names.NewMachineTag("0"),
+			names.NewApplicationTag("unit"),
+		},
+		Size: 10,
+	})
+	c.Assert(result, jc.DeepEquals, params.ModelStatusHistoryResult{
+		Entries: []params.EntityStatusHistoryEntry{{
+			Tag: "unit-unit-0",
+			Status: params.DetailedStatus{
+				Status: "active",
+				Info:   "running",
+				Since:  &since,
+				Kind:   "workload",
+			},
+		}, {
+			Tag: "machine-0",
+			Status: params.DetailedStatus{
+				Status: "started",
+				Since:  &since,
+				Kind:   "juju-machine",
+			},
+		}},
+	})
+}
+
+func (s *statusHistoryTestSuite) TestModelStatusHistoryInvalidTag(c *gc.C) {
+	_, err := s.api.ModelStatusHistory(params.ModelStatusHistoryRequest{
+		Tags: []string{"unit/0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `"unit/0" is not a valid tag`)
+}
+
 type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+
+	modelHistory       []state.EntityStatusInfo
+	modelHistoryFilter state.ModelStatusHistoryFilter
+}
+
+func (m *mockState) ModelStatusHistory(filter state.ModelStatusHistoryFilter) ([]state.EntityStatusInfo, error) {
+	m.modelHistoryFilter = filter
+	return m.modelHistory, nil
 }
 
 func (m *mockState) ModelUUID() string {
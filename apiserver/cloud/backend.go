@@ -14,6 +14,9 @@ import (
 type Backend interface {
 	Clouds() (map[names.CloudTag]cloud.Cloud, error)
 	Cloud(cloudName string) (cloud.Cloud, error)
+	AddCloud(cloudName string, cloud cloud.Cloud) error
+	UpdateCloud(cloudName string, cloud cloud.Cloud) error
+	RemoveCloud(cloudName string) error
 	CloudCredentials(user names.UserTag, cloudName string) (map[string]cloud.Credential, error)
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
 	ControllerModel() (Model, error)
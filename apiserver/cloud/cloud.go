@@ -20,6 +20,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Cloud", 1, newFacade)
+	// Version 2 adds AddCloud, UpdateCloud and RemoveCloud, and
+	// CA certificates in cloud definitions.
+	common.RegisterStandardFacade("Cloud", 2, newFacadeV2)
 }
 
 // CloudAPI implements the model manager interface and is
@@ -35,6 +38,26 @@ func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Cl
 	return NewCloudAPI(NewStateBackend(st), auth)
 }
 
+func newFacadeV2(st *state.State, _ facade.Resources, auth facade.Authorizer) (*CloudAPIV2, error) {
+	return NewCloudAPIV2(NewStateBackend(st), auth)
+}
+
+// CloudAPIV2 implements version 2 of the cloud API, which adds the
+// methods for managing cloud definitions.
+type CloudAPIV2 struct {
+	*CloudAPI
+}
+
+// NewCloudAPIV2 creates a new API server endpoint for managing the
+// controller's clouds and cloud credentials.
+func NewCloudAPIV2(backend Backend, authorizer facade.Authorizer) (*CloudAPIV2, error) {
+	api, err := NewCloudAPI(backend, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudAPIV2{api}, nil
+}
+
 // NewCloudAPI creates a new API server endpoint for managing the controller's
 // cloud definition and cloud credentials.
 func NewCloudAPI(backend Backend, authorizer facade.Authorizer) (*CloudAPI, error) {
@@ -128,7 +151,91 @@ func cloudToParams(cloud cloud.Cloud) params.Cloud {
 		IdentityEndpoint: cloud.IdentityEndpoint,
 		StorageEndpoint:  cloud.StorageEndpoint,
 		Regions:          regions,
+		CACertificates:   cloud.CACertificates,
+	}
+}
+
+func cloudFromParams(p params.Cloud) cloud.Cloud {
+	authTypes := make([]cloud.AuthType, len(p.AuthTypes))
+	for i, authType := range p.AuthTypes {
+		authTypes[i] = cloud.AuthType(authType)
 	}
+	var regions []cloud.Region
+	for _, region := range p.Regions {
+		regions = append(regions, cloud.Region{
+			Name:             region.Name,
+			Endpoint:         region.Endpoint,
+			IdentityEndpoint: region.IdentityEndpoint,
+			StorageEndpoint:  region.StorageEndpoint,
+		})
+	}
+	return cloud.Cloud{
+		Type:             p.Type,
+		AuthTypes:        authTypes,
+		Endpoint:         p.Endpoint,
+		IdentityEndpoint: p.IdentityEndpoint,
+		StorageEndpoint:  p.StorageEndpoint,
+		Regions:          regions,
+		CACertificates:   p.CACertificates,
+	}
+}
+
+// checkCanManageClouds returns an error if the authenticated user
+// is not a controller superuser, and so cannot modify clouds.
+func (api *CloudAPI) checkCanManageClouds() error {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// AddCloud adds a new cloud definition to the controller.
+func (api *CloudAPIV2) AddCloud(args params.AddCloudArgs) error {
+	if err := api.checkCanManageClouds(); err != nil {
+		return err
+	}
+	return api.backend.AddCloud(args.Name, cloudFromParams(args.Cloud))
+}
+
+// UpdateCloud updates the definitions of existing clouds, e.g. to
+// change their endpoints, regions or CA certificates.
+func (api *CloudAPIV2) UpdateCloud(args params.UpdateCloudArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Clouds)),
+	}
+	if err := api.checkCanManageClouds(); err != nil {
+		return results, err
+	}
+	for i, arg := range args.Clouds {
+		err := api.backend.UpdateCloud(arg.Name, cloudFromParams(arg.Cloud))
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveCloud removes the specified clouds from the controller.
+// Clouds that are in use by models cannot be removed.
+func (api *CloudAPIV2) RemoveCloud(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := api.checkCanManageClouds(); err != nil {
+		return results, err
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseCloudTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = api.backend.RemoveCloud(tag.Id())
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
 }
 
 // DefaultCloud returns the tag of the cloud that models will be
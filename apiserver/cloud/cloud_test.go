@@ -22,7 +22,7 @@ type cloudSuite struct {
 	gitjujutesting.IsolationSuite
 	backend    mockBackend
 	authorizer apiservertesting.FakeAuthorizer
-	api        *cloudfacade.CloudAPI
+	api        *cloudfacade.CloudAPIV2
 }
 
 var _ = gc.Suite(&cloudSuite{})
@@ -47,7 +47,7 @@ func (s *cloudSuite) SetUpTest(c *gc.C) {
 		},
 	}
 	var err error
-	s.api, err = cloudfacade.NewCloudAPI(&s.backend, &s.authorizer)
+	s.api, err = cloudfacade.NewCloudAPIV2(&s.backend, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -274,6 +274,87 @@ func (s *cloudSuite) TestCredentialAdminAccess(c *gc.C) {
 	c.Assert(results.Results[0].Error, gc.IsNil)
 }
 
+func (s *cloudSuite) TestAddCloud(c *gc.C) {
+	err := s.api.AddCloud(params.AddCloudArgs{
+		Name: "newcloud",
+		Cloud: params.Cloud{
+			Type:           "maas",
+			AuthTypes:      []string{"oauth1"},
+			Endpoint:       "http://maas.example.com",
+			CACertificates: []string{"cert"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag", "AddCloud")
+	s.backend.CheckCall(c, 1, "AddCloud", "newcloud", cloud.Cloud{
+		Type:           "maas",
+		AuthTypes:      []cloud.AuthType{cloud.OAuth1AuthType},
+		Endpoint:       "http://maas.example.com",
+		CACertificates: []string{"cert"},
+	})
+}
+
+func (s *cloudSuite) TestAddCloudNotAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bruce")
+	err := s.api.AddCloud(params.AddCloudArgs{
+		Name:  "newcloud",
+		Cloud: params.Cloud{Type: "maas", AuthTypes: []string{"oauth1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
+func (s *cloudSuite) TestUpdateCloud(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotFoundf(`cloud "missing"`))
+	results, err := s.api.UpdateCloud(params.UpdateCloudArgs{Clouds: []params.AddCloudArgs{{
+		Name: "my-cloud",
+		Cloud: params.Cloud{
+			Type:      "dummy",
+			AuthTypes: []string{"empty"},
+			Regions:   []params.CloudRegion{{Name: "nether", Endpoint: "new-endpoint"}},
+		},
+	}, {
+		Name:  "missing",
+		Cloud: params.Cloud{Type: "dummy", AuthTypes: []string{"empty"}},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag", "UpdateCloud", "UpdateCloud")
+	s.backend.CheckCall(c, 1, "UpdateCloud", "my-cloud", cloud.Cloud{
+		Type:      "dummy",
+		AuthTypes: []cloud.AuthType{cloud.EmptyAuthType},
+		Regions:   []cloud.Region{{Name: "nether", Endpoint: "new-endpoint"}},
+	})
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `cloud "missing" not found`,
+		Code:    params.CodeNotFound,
+	})
+}
+
+func (s *cloudSuite) TestRemoveCloud(c *gc.C) {
+	results, err := s.api.RemoveCloud(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-my-cloud"}, {Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag", "RemoveCloud")
+	s.backend.CheckCall(c, 1, "RemoveCloud", "my-cloud")
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"machine-0" is not a valid cloud tag`,
+	})
+}
+
+func (s *cloudSuite) TestRemoveCloudNotAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bruce")
+	_, err := s.api.RemoveCloud(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-my-cloud"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
 type mockBackend struct {
 	gitjujutesting.Stub
 	cloud cloud.Cloud
@@ -330,6 +411,21 @@ func (st *mockBackend) RemoveCloudCredential(tag names.CloudCredentialTag) error
 	return st.NextErr()
 }
 
+func (st *mockBackend) AddCloud(name string, cloud cloud.Cloud) error {
+	st.MethodCall(st, "AddCloud", name, cloud)
+	return st.NextErr()
+}
+
+func (st *mockBackend) UpdateCloud(name string, cloud cloud.Cloud) error {
+	st.MethodCall(st, "UpdateCloud", name, cloud)
+	return st.NextErr()
+}
+
+func (st *mockBackend) RemoveCloud(name string) error {
+	st.MethodCall(st, "RemoveCloud", name)
+	return st.NextErr()
+}
+
 func (st *mockBackend) Close() error {
 	st.MethodCall(st, "Close")
 	return st.NextErr()
@@ -4,8 +4,10 @@
 package common
 
 import (
+	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 // ControllerConfigAPI implements two common methods for use by various
@@ -21,13 +23,45 @@ func NewControllerConfig(st state.ControllerAccessor) *ControllerConfigAPI {
 	}
 }
 
-// ControllerConfig returns the controller's configuration.
+// ControllerConfig returns the controller's configuration, without
+// the secrets that only the controller itself needs.
 func (s *ControllerConfigAPI) ControllerConfig() (params.ControllerConfigResult, error) {
 	result := params.ControllerConfigResult{}
 	config, err := s.st.ControllerConfig()
 	if err != nil {
 		return result, err
 	}
-	result.Config = params.ControllerConfig(config)
+	result.Config = params.ControllerConfig(config.WithoutSecrets())
+	return result, nil
+}
+
+// ControllerConfigWatcher implements a common WatchControllerConfig
+// method for use by various facades, so that agents can react to
+// changes to the controller config without restarting.
+type ControllerConfigWatcher struct {
+	st        state.ControllerConfigWatcher
+	resources facade.Resources
+}
+
+// NewControllerConfigWatcher returns a new ControllerConfigWatcher.
+// Active watchers will be stored in the provided Resources.
+func NewControllerConfigWatcher(st state.ControllerConfigWatcher, resources facade.Resources) *ControllerConfigWatcher {
+	return &ControllerConfigWatcher{
+		st:        st,
+		resources: resources,
+	}
+}
+
+// WatchControllerConfig returns a NotifyWatcher that observes
+// changes to the controller configuration.
+func (w *ControllerConfigWatcher) WatchControllerConfig() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := w.st.WatchControllerConfig()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = w.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
 	return result, nil
 }
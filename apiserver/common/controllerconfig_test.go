@@ -10,9 +10,12 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
 
@@ -33,10 +36,11 @@ func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 		return nil, f.controllerConfigError
 	}
 	return map[string]interface{}{
-		controller.ControllerUUIDKey: testing.ControllerTag.Id(),
-		controller.CACertKey:         testing.CACert,
-		controller.APIPort:           4321,
-		controller.StatePort:         1234,
+		controller.ControllerUUIDKey:    testing.ControllerTag.Id(),
+		controller.CACertKey:            testing.CACert,
+		controller.APIPort:              4321,
+		controller.StatePort:            1234,
+		controller.ObjectStoreSecretKey: "sekrit",
 	}, nil
 }
 
@@ -68,3 +72,24 @@ func (*controllerConfigSuite) TestControllerConfigFetchError(c *gc.C) {
 	_, err := cc.ControllerConfig()
 	c.Assert(err, gc.ErrorMatches, "pow")
 }
+
+type fakeControllerConfigWatcher struct {
+	watcher state.NotifyWatcher
+}
+
+func (f *fakeControllerConfigWatcher) WatchControllerConfig() state.NotifyWatcher {
+	return f.watcher
+}
+
+func (s *controllerConfigSuite) TestWatchControllerConfig(c *gc.C) {
+	resources := common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { resources.StopAll() })
+	w := common.NewControllerConfigWatcher(
+		&fakeControllerConfigWatcher{apiservertesting.NewFakeNotifyWatcher()},
+		resources,
+	)
+	result, err := w.WatchControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(resources.Count(), gc.Equals, 1)
+}
@@ -6,7 +6,9 @@ package common
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/txn"
@@ -113,6 +115,30 @@ func OperationBlockedError(msg string) error {
 	}
 }
 
+// QuotaExceededError returns an error which signifies that the named
+// quota, with the given limit, would be exceeded by the operation.
+func QuotaExceededError(quota string, limit int) error {
+	return &params.Error{
+		Message: fmt.Sprintf("quota %q exceeded (limit %d)", quota, limit),
+		Code:    params.CodeQuotaExceeded,
+		Details: map[string]string{
+			params.DetailQuota: quota,
+			params.DetailLimit: strconv.Itoa(limit),
+		},
+	}
+}
+
+// TryAgainAfterError returns an error which signifies that the
+// operation may succeed if retried after the given delay; the message
+// should describe why it failed.
+func TryAgainAfterError(msg string, delay time.Duration) error {
+	return &params.Error{
+		Message: fmt.Sprintf("%s (try again after %v)", msg, delay),
+		Code:    params.CodeTryAgainAfter,
+		Details: map[string]string{params.DetailRetryAfter: delay.String()},
+	}
+}
+
 var singletonErrorCodes = map[error]string{
 	state.ErrCannotEnterScopeYet: params.CodeCannotEnterScopeYet,
 	state.ErrCannotEnterScope:    params.CodeCannotEnterScope,
@@ -182,8 +208,11 @@ func ServerErrorAndStatus(err error) (*params.Error, int) {
 		status = http.StatusForbidden
 	case params.CodeDischargeRequired:
 		status = http.StatusUnauthorized
-	case params.CodeRetry:
+	case params.CodeRetry,
+		params.CodeTryAgainAfter:
 		status = http.StatusServiceUnavailable
+	case params.CodeQuotaExceeded:
+		status = http.StatusTooManyRequests
 	}
 	return err1, status
 }
@@ -201,6 +230,7 @@ func ServerError(err error) *params.Error {
 	err = errors.Cause(err)
 	code, ok := singletonCode(err)
 	var info *params.ErrorInfo
+	details := params.ErrDetails(err)
 	switch {
 	case ok:
 	case errors.IsUnauthorized(err):
@@ -225,6 +255,13 @@ func ServerError(err error) *params.Error {
 		code = params.CodeUpgradeInProgress
 	case state.IsHasAttachmentsError(err):
 		code = params.CodeMachineHasAttachedStorage
+	case state.IsQuotaExceededError(err):
+		code = params.CodeQuotaExceeded
+		quotaErr := err.(*state.QuotaExceededError)
+		details = map[string]string{
+			params.DetailQuota: quotaErr.Quota,
+			params.DetailLimit: strconv.Itoa(quotaErr.Limit),
+		}
 	case isUnknownModelError(err):
 		code = params.CodeModelNotFound
 	case errors.IsNotSupported(err):
@@ -233,6 +270,8 @@ func ServerError(err error) *params.Error {
 		code = params.CodeBadRequest
 	case errors.IsMethodNotAllowed(err):
 		code = params.CodeMethodNotAllowed
+	case leadership.IsNotLeaderError(err):
+		code = params.CodeNotLeader
 	default:
 		if err, ok := err.(*DischargeRequiredError); ok {
 			code = params.CodeDischargeRequired
@@ -249,6 +288,7 @@ func ServerError(err error) *params.Error {
 		Message: msg,
 		Code:    code,
 		Info:    info,
+		Details: details,
 	}
 }
 
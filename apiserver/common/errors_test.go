@@ -6,6 +6,7 @@ package common_test
 import (
 	stderrors "errors"
 	"net/http"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -190,6 +191,32 @@ var errorTransformTests = []struct {
 	code:       params.CodeModelNotFound,
 	status:     http.StatusNotFound,
 	helperFunc: params.IsCodeModelNotFound,
+}, {
+	err:    common.QuotaExceededError("machines", 10),
+	code:   params.CodeQuotaExceeded,
+	status: http.StatusTooManyRequests,
+	helperFunc: func(err error) bool {
+		details := params.ErrDetails(err)
+		return params.IsCodeQuotaExceeded(err) &&
+			details[params.DetailQuota] == "machines" &&
+			details[params.DetailLimit] == "10"
+	},
+}, {
+	err: errors.Annotate(&leadership.NotLeaderError{
+		ApplicationName: "mysql",
+		UnitName:        "mysql/1",
+	}, "cannot set status"),
+	code:       params.CodeNotLeader,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeNotLeader,
+}, {
+	err:    common.TryAgainAfterError("controller busy", 5*time.Second),
+	code:   params.CodeTryAgainAfter,
+	status: http.StatusServiceUnavailable,
+	helperFunc: func(err error) bool {
+		d, ok := params.RetryAfter(err)
+		return ok && d == 5*time.Second && params.IsCodeTryAgain(err)
+	},
 }, {
 	err:    nil,
 	code:   "",
@@ -239,7 +266,8 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeMachineHasAttachedStorage,
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
-			params.CodeRetry:
+			params.CodeRetry,
+			params.CodeNotLeader:
 			continue
 		case params.CodeOperationBlocked:
 			// ServerError doesn't actually have a case for this code.
@@ -285,3 +313,25 @@ func (s *errorsSuite) TestDestroyErr(c *gc.C) {
 	err = common.DestroyErr("entities", ids, errs[1:])
 	c.Assert(err, gc.ErrorMatches, "some entities were not destroyed: error two; error three")
 }
+
+func (s *errorsSuite) TestServerErrorKeepsDetailsOfAnnotatedError(c *gc.C) {
+	err := errors.Annotate(common.QuotaExceededError("units", 3), "cannot add unit")
+	apiErr := common.ServerError(err)
+	c.Check(apiErr.Message, gc.Equals, `cannot add unit: quota "units" exceeded (limit 3)`)
+	c.Check(apiErr.Code, gc.Equals, params.CodeQuotaExceeded)
+	c.Check(apiErr.Details, jc.DeepEquals, map[string]string{
+		params.DetailQuota: "units",
+		params.DetailLimit: "3",
+	})
+}
+
+func (s *errorsSuite) TestServerErrorStateQuotaExceeded(c *gc.C) {
+	err := errors.Annotate(&state.QuotaExceededError{Quota: "max-units", Limit: 3, User: "bob"}, "cannot add unit")
+	apiErr := common.ServerError(err)
+	c.Check(apiErr.Message, gc.Equals, `cannot add unit: quota "max-units" for user "bob" exceeded (limit 3)`)
+	c.Check(apiErr.Code, gc.Equals, params.CodeQuotaExceeded)
+	c.Check(apiErr.Details, jc.DeepEquals, map[string]string{
+		params.DetailQuota: "max-units",
+		params.DetailLimit: "3",
+	})
+}
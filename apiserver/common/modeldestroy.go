@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/metricsender"
+	"github.com/juju/juju/state"
 )
 
 var sendMetrics = func(st metricsender.ModelBackend) error {
@@ -27,7 +28,7 @@ var sendMetrics = func(st metricsender.ModelBackend) error {
 // have been done. If the model is a controller hosting other
 // models, they will also be destroyed.
 func DestroyModelIncludingHosted(st ModelManagerBackend, systemTag names.ModelTag) error {
-	return destroyModel(st, systemTag, true)
+	return destroyModel(st, systemTag, true, state.DestroyModelParams{})
 }
 
 // DestroyModel sets the environment to dying. Cleanup jobs then destroy
@@ -36,10 +37,16 @@ func DestroyModelIncludingHosted(st ModelManagerBackend, systemTag names.ModelTa
 // have been done. An error will be returned if this model is a
 // controller hosting other model.
 func DestroyModel(st ModelManagerBackend, modelTag names.ModelTag) error {
-	return destroyModel(st, modelTag, false)
+	return destroyModel(st, modelTag, false, state.DestroyModelParams{})
 }
 
-func destroyModel(st ModelManagerBackend, modelTag names.ModelTag, destroyHostedModels bool) error {
+// DestroyModelWithParams is like DestroyModel, but additionally records
+// how the model's storage is to be disposed of.
+func DestroyModelWithParams(st ModelManagerBackend, modelTag names.ModelTag, args state.DestroyModelParams) error {
+	return destroyModel(st, modelTag, false, args)
+}
+
+func destroyModel(st ModelManagerBackend, modelTag names.ModelTag, destroyHostedModels bool, args state.DestroyModelParams) error {
 	var err error
 	if modelTag != st.ModelTag() {
 		if st, err = st.ForModel(modelTag); err != nil {
@@ -94,7 +101,7 @@ func destroyModel(st ModelManagerBackend, modelTag names.ModelTag, destroyHosted
 			return err
 		}
 	} else {
-		if err = model.Destroy(); err != nil {
+		if err = model.DestroyWithParams(args); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -42,6 +42,10 @@ type ModelManagerBackend interface {
 	Model() (Model, error)
 	ModelConfigDefaultValues() (config.ModelDefaultAttributes, error)
 	UpdateModelConfigDefaultValues(update map[string]interface{}, remove []string, regionSpec *environs.RegionSpec) error
+	ModelTemplate(name string) (state.ModelTemplate, error)
+	AllModelTemplates() ([]state.ModelTemplate, error)
+	SetModelTemplate(state.ModelTemplate) error
+	RemoveModelTemplate(name string) error
 	Unit(name string) (*state.Unit, error)
 	ModelTag() names.ModelTag
 	ModelConfig() (*config.Config, error)
@@ -76,6 +80,7 @@ type Model interface {
 	CloudRegion() string
 	Users() ([]permission.UserAccess, error)
 	Destroy() error
+	DestroyWithParams(state.DestroyModelParams) error
 	DestroyIncludingHosted() error
 }
 
@@ -49,6 +49,8 @@ func StateToParamsUserAccessPermission(descriptionAccess permission.Access) (par
 	switch descriptionAccess {
 	case permission.ReadAccess:
 		return params.ModelReadAccess, nil
+	case permission.OperatorAccess:
+		return params.ModelOperatorAccess, nil
 	case permission.WriteAccess:
 		return params.ModelWriteAccess, nil
 	case permission.AdminAccess:
@@ -137,6 +137,14 @@ func (s *stateShim) AllSubnets() ([]BackingSubnet, error) {
 	return subnets, nil
 }
 
+func (s *stateShim) RemoveSubnet(cidr string) error {
+	subnet, err := s.st.Subnet(cidr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(subnet.RemoveIfUnused())
+}
+
 func (s *stateShim) AvailabilityZones() ([]providercommon.AvailabilityZone, error) {
 	// TODO(dimitern): Fix this to get them from state when available!
 	return nil, nil
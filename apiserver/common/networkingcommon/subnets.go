@@ -380,6 +380,24 @@ func AddSubnets(api NetworkBacking, args params.AddSubnetsParams) (params.ErrorR
 	return results, nil
 }
 
+// RemoveSubnets removes the subnets with the given tags. Subnets
+// in which any machine has an address are not removed.
+func RemoveSubnets(api NetworkBacking, args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseSubnetTag(entity.Tag)
+		if err == nil {
+			err = api.RemoveSubnet(tag.Id())
+		}
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
 // ListSubnets lists all the available subnets or only those matching
 // all given optional filters.
 func ListSubnets(api NetworkBacking, args params.SubnetsFilters) (results params.ListSubnetsResults, err error) {
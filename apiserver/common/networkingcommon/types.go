@@ -122,6 +122,10 @@ type NetworkBacking interface {
 	// AllSubnets returns all backing subnets.
 	AllSubnets() ([]BackingSubnet, error)
 
+	// RemoveSubnet removes the subnet with the given CIDR. It fails if
+	// any machine has an address in the subnet.
+	RemoveSubnet(cidr string) error
+
 	// ModelTag returns the tag of the model this state is associated to.
 	ModelTag() names.ModelTag
 }
@@ -59,7 +59,7 @@ func HasPermission(userGetter userAccessFunc, utag names.Tag,
 	switch requestedPermission {
 	case permission.LoginAccess, permission.AddModelAccess, permission.SuperuserAccess:
 		validForKind = target.Kind() == names.ControllerTagKind
-	case permission.ReadAccess, permission.WriteAccess, permission.AdminAccess:
+	case permission.ReadAccess, permission.OperatorAccess, permission.WriteAccess, permission.AdminAccess:
 		validForKind = target.Kind() == names.ModelTagKind
 	}
 
@@ -64,6 +64,59 @@ func (r *RebootRequester) RequestReboot(args params.Entities) (params.ErrorResul
 	return result, nil
 }
 
+// RebootScheduler implements the ScheduleReboot API method
+type RebootScheduler struct {
+	st   state.EntityFinder
+	auth GetAuthFunc
+}
+
+func NewRebootScheduler(st state.EntityFinder, auth GetAuthFunc) *RebootScheduler {
+	return &RebootScheduler{
+		st:   st,
+		auth: auth,
+	}
+}
+
+func (r *RebootScheduler) oneSchedule(tag names.Tag) error {
+	entity0, err := r.st.FindEntity(tag)
+	if err != nil {
+		return err
+	}
+	entity, ok := entity0.(state.RebootScheduler)
+	if !ok {
+		return NotSupportedError(tag, "schedule reboot")
+	}
+	return entity.ScheduleReboot()
+}
+
+// ScheduleReboot sets the reboot flag on the provided machines, to
+// be acted on during the model's maintenance window.
+func (r *RebootScheduler) ScheduleReboot(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	auth, err := r.auth()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = ServerError(ErrPerm)
+			continue
+		}
+		err = ErrPerm
+		if auth(tag) {
+			err = r.oneSchedule(tag)
+		}
+		result.Results[i].Error = ServerError(err)
+	}
+	return result, nil
+}
+
 // RebootActionGetter implements the GetRebootAction API method
 type RebootActionGetter struct {
 	st   state.EntityFinder
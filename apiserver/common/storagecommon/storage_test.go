@@ -139,6 +139,20 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoMatchingBlockDevic
 	})
 }
 
+func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoSize(c *gc.C) {
+	// The size of block storage is the size of the block
+	// device as seen by the machine.
+	s.volumeAttachment.info.DeviceName = "sda"
+	s.blockDevices[0].Size = 2048
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindBlock,
+		Location: filepath.FromSlash("/dev/sda"),
+		Size:     2048,
+	})
+}
+
 func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoNoBlockDevice(c *gc.C) {
 	// Neither the volume nor the volume attachment has enough information
 	// to persistently identify the path, so we must enquire about block
@@ -33,6 +33,8 @@ var logger = loggo.GetLogger("juju.apiserver.controller")
 
 func init() {
 	common.RegisterStandardFacade("Controller", 3, NewControllerAPI)
+	// Version 4 adds ConfigSet.
+	common.RegisterStandardFacade("Controller", 4, NewControllerAPIV4)
 }
 
 // Controller defines the methods on the controller API end point.
@@ -93,6 +95,26 @@ func NewControllerAPI(
 	}, nil
 }
 
+// ControllerAPIV4 implements version 4 of the controller API, which
+// adds ConfigSet.
+type ControllerAPIV4 struct {
+	*ControllerAPI
+}
+
+// NewControllerAPIV4 creates a new api server endpoint for managing
+// environments, supporting version 4 of the controller API.
+func NewControllerAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*ControllerAPIV4, error) {
+	api, err := NewControllerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIV4{api}, nil
+}
+
 func (s *ControllerAPI) checkHasAdmin() error {
 	isAdmin, err := s.authorizer.HasPermission(permission.SuperuserAccess, s.state.ControllerTag())
 	if err != nil {
@@ -667,3 +689,17 @@ func (o orderedUserModels) Less(i, j int) bool {
 func (o orderedUserModels) Swap(i, j int) {
 	o[i], o[j] = o[j], o[i]
 }
+
+// ConfigSet changes the value of the specified controller
+// configuration attributes. Only the attributes that may be changed
+// after bootstrap are accepted, and only controller administrators
+// may change them.
+func (s *ControllerAPIV4) ConfigSet(args params.ControllerConfigSet) error {
+	if err := s.checkHasAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.state.UpdateControllerConfig(args.Config, nil); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
@@ -817,3 +817,41 @@ func (s *controllerSuite) TestGetControllerAccessPermissions(c *gc.C) {
 		Message: "permission denied", Code: "unauthorized access",
 	})
 }
+
+func (s *controllerSuite) TestConfigSet(c *gc.C) {
+	api, err := controller.NewControllerAPIV4(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"auditing-enabled": true,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+}
+
+func (s *controllerSuite) TestConfigSetRejectsImmutableAttributes(c *gc.C) {
+	api, err := controller.NewControllerAPIV4(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"api-port": 1234,
+	}})
+	c.Assert(err, gc.ErrorMatches, `can not change "api-port" after bootstrap`)
+}
+
+func (s *controllerSuite) TestConfigSetRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	api, err := controller.NewControllerAPIV4(s.State, s.resources, anAuthoriser)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"auditing-enabled": true,
+	}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -14,11 +14,15 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
 func init() {
 	common.RegisterStandardFacadeForFeature("ApplicationOffers", 1, newApplicationOffersAPI, feature.CrossModelRelations)
+
+	// Version 2 adds ModifyOfferAccess.
+	common.RegisterStandardFacadeForFeature("ApplicationOffers", 2, newApplicationOffersAPI, feature.CrossModelRelations)
 }
 
 // ApplicationOffersAPI implements the cross model interface and is the concrete
@@ -30,6 +34,10 @@ type ApplicationOffersAPI interface {
 
 	// ListOffers returns offers matching the filter from a application directory.
 	ListOffers(filters params.OfferFilters) (params.ApplicationOfferResults, error)
+
+	// ModifyOfferAccess grants or revokes the ability of users to
+	// consume application offers.
+	ModifyOfferAccess(args params.ModifyOfferAccessRequest) (params.ErrorResults, error)
 }
 
 type applicationOffersAPI struct {
@@ -96,6 +104,69 @@ func (api *applicationOffersAPI) AddOffers(offers params.AddApplicationOffers) (
 	return applicationOffers.AddOffers(offers)
 }
 
+// ModifyOfferAccess grants or revokes the ability of users to consume
+// application offers. Only administrators of the model hosting an
+// offered application may change who can consume it.
+func (api *applicationOffersAPI) ModifyOfferAccess(args params.ModifyOfferAccessRequest) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, arg := range args.Changes {
+		result.Results[i].Error = common.ServerError(api.modifyOneOfferAccess(arg))
+	}
+	return result, nil
+}
+
+func (api *applicationOffersAPI) modifyOneOfferAccess(arg params.ModifyOfferAccess) error {
+	directory, err := jujucrossmodel.ApplicationDirectoryForURL(arg.ApplicationURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	applicationOffers, err := api.applicationOffersAPIFactory.ApplicationOffers(directory)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	offers, err := applicationOffers.ListOffers(params.OfferFilters{
+		Directory: directory,
+		Filters:   []params.OfferFilter{{ApplicationURL: arg.ApplicationURL}},
+	})
+	if err == nil && offers.Error != nil {
+		err = offers.Error
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The URL filter matches partial URLs, so look for the exact one.
+	var sourceModelTag string
+	for _, offer := range offers.Offers {
+		if offer.ApplicationURL == arg.ApplicationURL {
+			sourceModelTag = offer.SourceModelTag
+			break
+		}
+	}
+	if sourceModelTag == "" {
+		return errors.NotFoundf("application offer %q", arg.ApplicationURL)
+	}
+	modelTag, err := names.ParseModelTag(sourceModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	isAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	results, err := applicationOffers.ModifyOfferAccess(params.ModifyOfferAccessRequest{
+		Changes: []params.ModifyOfferAccess{arg},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // localApplicationOffers provides access to application offers hosted within
 // a local controller.
 type localApplicationOffers struct {
@@ -139,6 +210,13 @@ func makeOfferFilterFromParams(filters []params.OfferFilter) ([]crossmodel.Appli
 			}
 			offerFilters[i].SourceModelUUID = envTag.Id()
 		}
+		if len(filter.AllowedUserTags) > 0 {
+			users, err := userIds(filter.AllowedUserTags)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			offerFilters[i].AllowedUsers = users
+		}
 		// TODO(wallyworld) - add support for Endpoint filter attribute
 	}
 	return offerFilters, nil
@@ -160,10 +238,59 @@ func (so *localApplicationOffers) AddOffers(offers params.AddApplicationOffers)
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		users, err := userIds(offerParams.UserTags)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		if err := so.applicationDirectory.AddOffer(offer); err != nil {
 			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if len(users) == 0 {
+			continue
+		}
+		if err := so.applicationDirectory.GrantAccess(offer.ApplicationURL, users...); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// ModifyOfferAccess grants or revokes the ability of users to consume
+// application offers.
+func (so *localApplicationOffers) ModifyOfferAccess(args params.ModifyOfferAccessRequest) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, arg := range args.Changes {
+		userTag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		switch arg.Action {
+		case params.GrantOfferAccess:
+			err = so.applicationDirectory.GrantAccess(arg.ApplicationURL, userTag.Id())
+		case params.RevokeOfferAccess:
+			err = so.applicationDirectory.RevokeAccess(arg.ApplicationURL, userTag.Id())
+		default:
+			err = errors.NotValidf("offer action %q", arg.Action)
 		}
+		result.Results[i].Error = common.ServerError(err)
 	}
-	// TODO(wallyworld) - write ACLs with supplied users once we support that
 	return result, nil
 }
+
+// userIds returns the ids of the users with the specified tags.
+func userIds(userTags []string) ([]string, error) {
+	users := make([]string, len(userTags))
+	for i, tag := range userTags {
+		userTag, err := names.ParseUserTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		users[i] = userTag.Id()
+	}
+	return users, nil
+}
@@ -31,6 +31,7 @@ type applicationdirectorySuite struct {
 	calls                []string
 	applicationdirectory *mockApplicationDirectory
 	offers               map[string]jujucrossmodel.ApplicationOffer
+	allowedUsers         map[string][]string
 }
 
 func (s *applicationdirectorySuite) constructApplicationDirectory() *mockApplicationDirectory {
@@ -50,6 +51,16 @@ func (s *applicationdirectorySuite) constructApplicationDirectory() *mockApplica
 			}
 			return result, nil
 		},
+		grantAccess: func(url string, users ...string) error {
+			s.calls = append(s.calls, "grantaccess")
+			s.allowedUsers[url] = append(s.allowedUsers[url], users...)
+			return nil
+		},
+		revokeAccess: func(url string, users ...string) error {
+			s.calls = append(s.calls, "revokeaccess")
+			s.allowedUsers[url] = nil
+			return nil
+		},
 	}
 }
 
@@ -63,6 +74,7 @@ func (s *applicationdirectorySuite) SetUpTest(c *gc.C) {
 
 	s.calls = []string{}
 	s.offers = make(map[string]jujucrossmodel.ApplicationOffer)
+	s.allowedUsers = make(map[string][]string)
 	s.applicationdirectory = s.constructApplicationDirectory()
 
 	var err error
@@ -74,6 +86,16 @@ func (s *applicationdirectorySuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *applicationdirectorySuite) setAuthorizer(c *gc.C, authorizer testing.FakeAuthorizer) {
+	serviceAPIFactory := crossmodel.NewServiceAPIFactory(
+		func() jujucrossmodel.ApplicationDirectory { return s.applicationdirectory },
+		nil,
+	)
+	var err error
+	s.api, err = crossmodel.CreateApplicationOffersAPI(serviceAPIFactory, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *applicationdirectorySuite) TestUnauthorised(c *gc.C) {
 	s.authoriser = testing.FakeAuthorizer{}
 	_, err := crossmodel.CreateApplicationOffersAPI(nil, s.authoriser)
@@ -186,3 +208,120 @@ func (s *applicationdirectorySuite) TestListOffersNoDirectory(c *gc.C) {
 	_, err := s.api.ListOffers(params.OfferFilters{})
 	c.Assert(err, gc.ErrorMatches, "application directory must be specified")
 }
+
+func (s *applicationdirectorySuite) TestAddOfferWithUsers(c *gc.C) {
+	offers := params.AddApplicationOffers{
+		Offers: []params.AddApplicationOffer{{
+			ApplicationOffer: params.ApplicationOffer{
+				ApplicationURL:  "local:/u/user/servicename",
+				ApplicationName: "service",
+				SourceModelTag:  names.NewModelTag(fakeUUID).String(),
+			},
+			UserTags: []string{"user-fred", "user-everyone@external"},
+		}},
+	}
+	results, err := s.api.AddOffers(offers)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.assertCalls(c, []string{"addoffer", "grantaccess"})
+	c.Assert(s.allowedUsers["local:/u/user/servicename"], jc.DeepEquals, []string{"fred", "everyone@external"})
+}
+
+func (s *applicationdirectorySuite) TestAddOfferInvalidUser(c *gc.C) {
+	offers := params.AddApplicationOffers{
+		Offers: []params.AddApplicationOffer{{
+			ApplicationOffer: params.ApplicationOffer{
+				ApplicationURL:  "local:/u/user/servicename",
+				ApplicationName: "service",
+				SourceModelTag:  names.NewModelTag(fakeUUID).String(),
+			},
+			UserTags: []string{"machine-0"},
+		}},
+	}
+	results, err := s.api.AddOffers(offers)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+	s.assertCalls(c, []string{})
+}
+
+func (s *applicationdirectorySuite) TestListOffersAllowedUsers(c *gc.C) {
+	var filters []jujucrossmodel.ApplicationOfferFilter
+	s.applicationdirectory.listOffers = func(f ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error) {
+		filters = f
+		return nil, nil
+	}
+	_, err := s.api.ListOffers(params.OfferFilters{
+		Directory: "local",
+		Filters: []params.OfferFilter{{
+			ApplicationURL:  "local:/u/user/servicename",
+			AllowedUserTags: []string{"user-fred"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filters, gc.HasLen, 1)
+	c.Assert(filters[0].AllowedUsers, jc.DeepEquals, []string{"fred"})
+}
+
+func (s *applicationdirectorySuite) addOfferForAccess() {
+	s.offers["local:/u/user/servicename"] = jujucrossmodel.ApplicationOffer{
+		ApplicationURL:  "local:/u/user/servicename",
+		ApplicationName: "service",
+		SourceModelUUID: fakeUUID,
+	}
+}
+
+func (s *applicationdirectorySuite) TestModifyOfferAccess(c *gc.C) {
+	s.setAuthorizer(c, testing.FakeAuthorizer{
+		Tag:      names.NewUserTag("testuser"),
+		AdminTag: names.NewUserTag("testuser"),
+	})
+	s.addOfferForAccess()
+	results, err := s.api.ModifyOfferAccess(params.ModifyOfferAccessRequest{
+		Changes: []params.ModifyOfferAccess{{
+			UserTag:        "user-fred",
+			Action:         params.GrantOfferAccess,
+			ApplicationURL: "local:/u/user/servicename",
+		}, {
+			UserTag:        "user-fred",
+			Action:         params.RevokeOfferAccess,
+			ApplicationURL: "local:/u/user/servicename",
+		}, {
+			UserTag:        "user-fred",
+			Action:         "steal",
+			ApplicationURL: "local:/u/user/servicename",
+		}, {
+			UserTag:        "user-fred",
+			Action:         params.GrantOfferAccess,
+			ApplicationURL: "local:/u/user/unknown",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `offer action "steal" not valid`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `application offer "local:/u/user/unknown" not found`)
+	s.assertCalls(c, []string{
+		"listoffers", "grantaccess",
+		"listoffers", "revokeaccess",
+		"listoffers",
+		"listoffers",
+	})
+}
+
+func (s *applicationdirectorySuite) TestModifyOfferAccessNotModelAdmin(c *gc.C) {
+	s.addOfferForAccess()
+	results, err := s.api.ModifyOfferAccess(params.ModifyOfferAccessRequest{
+		Changes: []params.ModifyOfferAccess{{
+			UserTag:        "user-fred",
+			Action:         params.GrantOfferAccess,
+			ApplicationURL: "local:/u/user/servicename",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.assertCalls(c, []string{"listoffers"})
+}
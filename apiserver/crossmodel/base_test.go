@@ -18,8 +18,9 @@ import (
 )
 
 const (
-	addOffersBackendCall  = "AddOffers"
-	listOffersBackendCall = "ListOffers"
+	addOffersBackendCall         = "AddOffers"
+	listOffersBackendCall        = "ListOffers"
+	modifyOfferAccessBackendCall = "ModifyOfferAccess"
 )
 
 type baseCrossmodelSuite struct {
@@ -71,8 +72,9 @@ func (s *baseCrossmodelSuite) SetUpTest(c *gc.C) {
 type mockApplicationOffersAPI struct {
 	jtesting.Stub
 
-	addOffers  func(offers params.AddApplicationOffers) (params.ErrorResults, error)
-	listOffers func(filters params.OfferFilters) (params.ApplicationOfferResults, error)
+	addOffers         func(offers params.AddApplicationOffers) (params.ErrorResults, error)
+	listOffers        func(filters params.OfferFilters) (params.ApplicationOfferResults, error)
+	modifyOfferAccess func(args params.ModifyOfferAccessRequest) (params.ErrorResults, error)
 }
 
 func (m *mockApplicationOffersAPI) AddOffers(offers params.AddApplicationOffers) (params.ErrorResults, error) {
@@ -84,3 +86,8 @@ func (m *mockApplicationOffersAPI) ListOffers(filters params.OfferFilters) (para
 	m.MethodCall(m, listOffersBackendCall, filters)
 	return m.listOffers(filters)
 }
+
+func (m *mockApplicationOffersAPI) ModifyOfferAccess(args params.ModifyOfferAccessRequest) (params.ErrorResults, error) {
+	m.MethodCall(m, modifyOfferAccessBackendCall, args)
+	return m.modifyOfferAccess(args)
+}
@@ -82,10 +82,16 @@ func (api *API) Offer(all params.ApplicationOffersParams) (params.ErrorResults,
 			result[i].Error = common.ServerError(err)
 			continue
 		}
+		// If no users are specified, only the offering user
+		// may consume the offer until access is granted to others.
+		userTags := one.AllowedUserTags
+		if len(userTags) == 0 {
+			userTags = []string{api.authorizer.GetAuthTag().String()}
+		}
 		indexInOffersToResult[len(offers.Offers)] = i
 		offers.Offers = append(offers.Offers, params.AddApplicationOffer{
 			ApplicationOffer: applicationOfferParams,
-			UserTags:         one.AllowedUserTags,
+			UserTags:         userTags,
 		})
 	}
 	addOffersErrors, err := api.applicationDirectory.AddOffers(offers)
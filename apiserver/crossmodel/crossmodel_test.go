@@ -52,7 +52,9 @@ func (s *crossmodelSuite) TestOffer(c *gc.C) {
 						Limit:     1,
 						Scope:     "global",
 					},
-				}}}}}
+				}},
+			UserTags: []string{"user-testuser"},
+		}}}
 	s.applicationDirectory.addOffers = func(offers params.AddApplicationOffers) (params.ErrorResults, error) {
 		c.Assert(offers, jc.DeepEquals, expectedOffers)
 		result := params.ErrorResults{}
@@ -110,7 +112,9 @@ func (s *crossmodelSuite) TestOfferSomeFail(c *gc.C) {
 						Limit:     1,
 						Scope:     "global",
 					},
-				}}}, {
+				}},
+			UserTags: []string{"user-testuser"},
+		}, {
 			ApplicationOffer: params.ApplicationOffer{
 				ApplicationURL:         "local:/u/me/two",
 				SourceModelTag:         testing.ModelTag.String(),
@@ -124,7 +128,9 @@ func (s *crossmodelSuite) TestOfferSomeFail(c *gc.C) {
 						Limit:     1,
 						Scope:     "global",
 					},
-				}}}}}
+				}},
+			UserTags: []string{"user-testuser"},
+		}}}
 	s.applicationDirectory.addOffers = func(offers params.AddApplicationOffers) (params.ErrorResults, error) {
 		c.Assert(offers, jc.DeepEquals, expectedOffers)
 		result := params.ErrorResults{}
@@ -148,6 +154,26 @@ func (s *crossmodelSuite) TestOfferSomeFail(c *gc.C) {
 	s.applicationDirectory.CheckCallNames(c, addOffersBackendCall)
 }
 
+func (s *crossmodelSuite) TestOfferAllowedUsers(c *gc.C) {
+	s.addApplication(c, "test")
+	one := params.ApplicationOfferParams{
+		ModelTag:        "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ApplicationURL:  "local:/u/me/test",
+		ApplicationName: "test",
+		Endpoints:       []string{"db"},
+		AllowedUserTags: []string{"user-fred", "user-everyone@external"},
+	}
+	s.applicationDirectory.addOffers = func(offers params.AddApplicationOffers) (params.ErrorResults, error) {
+		c.Assert(offers.Offers, gc.HasLen, 1)
+		c.Assert(offers.Offers[0].UserTags, jc.DeepEquals, []string{"user-fred", "user-everyone@external"})
+		return params.ErrorResults{Results: make([]params.ErrorResult, 1)}, nil
+	}
+	errs, err := s.api.Offer(params.ApplicationOffersParams{Offers: []params.ApplicationOfferParams{one}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results, gc.HasLen, 1)
+	c.Assert(errs.Results[0].Error, gc.IsNil)
+}
+
 func (s *crossmodelSuite) TestOfferError(c *gc.C) {
 	applicationName := "test"
 	s.addApplication(c, applicationName)
@@ -14,17 +14,21 @@ import (
 )
 
 const (
-	addOfferCall    = "addOfferCall"
-	updateOfferCall = "updateOfferCall"
-	listOffersCall  = "listOffersCall"
-	removeOfferCall = "removeOfferCall"
+	addOfferCall     = "addOfferCall"
+	updateOfferCall  = "updateOfferCall"
+	listOffersCall   = "listOffersCall"
+	removeOfferCall  = "removeOfferCall"
+	grantAccessCall  = "grantAccessCall"
+	revokeAccessCall = "revokeAccessCall"
 )
 
 type mockApplicationDirectory struct {
 	jtesting.Stub
 
-	addOffer   func(offer crossmodel.ApplicationOffer) error
-	listOffers func(filters ...crossmodel.ApplicationOfferFilter) ([]crossmodel.ApplicationOffer, error)
+	addOffer     func(offer crossmodel.ApplicationOffer) error
+	listOffers   func(filters ...crossmodel.ApplicationOfferFilter) ([]crossmodel.ApplicationOffer, error)
+	grantAccess  func(url string, users ...string) error
+	revokeAccess func(url string, users ...string) error
 }
 
 func (m *mockApplicationDirectory) AddOffer(offer crossmodel.ApplicationOffer) error {
@@ -47,6 +51,16 @@ func (m *mockApplicationDirectory) Remove(url string) error {
 	panic("not implemented")
 }
 
+func (m *mockApplicationDirectory) GrantAccess(url string, users ...string) error {
+	m.AddCall(grantAccessCall, url, users)
+	return m.grantAccess(url, users...)
+}
+
+func (m *mockApplicationDirectory) RevokeAccess(url string, users ...string) error {
+	m.AddCall(revokeAccessCall, url, users)
+	return m.revokeAccess(url, users...)
+}
+
 type mockState struct {
 	watchOfferedApplications func() state.StringsWatcher
 }
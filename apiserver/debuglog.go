@@ -69,34 +69,31 @@ func newDebugLogHandler(
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	server := websocket.Server{
-		Handler: func(conn *websocket.Conn) {
-			socket := &debugLogSocketImpl{conn}
-			defer conn.Close()
-
-			st, _, err := h.ctxt.stateForRequestAuthenticatedTag(req, names.MachineTagKind, names.UserTagKind)
-			if err != nil {
-				socket.sendError(err)
-				return
-			}
-			defer h.ctxt.release(st)
+	h.ctxt.keepalive().serve(w, req, true, func(conn *websocket.Conn) {
+		socket := &debugLogSocketImpl{conn}
+		defer conn.Close()
 
-			params, err := readDebugLogParams(req.URL.Query())
-			if err != nil {
-				socket.sendError(err)
-				return
-			}
+		st, _, err := h.ctxt.stateForRequestAuthenticatedTag(req, names.MachineTagKind, names.UserTagKind)
+		if err != nil {
+			socket.sendError(err)
+			return
+		}
+		defer h.ctxt.release(st)
+
+		params, err := readDebugLogParams(req.URL.Query())
+		if err != nil {
+			socket.sendError(err)
+			return
+		}
 
-			if err := h.handle(st, params, socket, h.ctxt.stop()); err != nil {
-				if isBrokenPipe(err) {
-					logger.Tracef("debug-log handler stopped (client disconnected)")
-				} else {
-					logger.Errorf("debug-log handler error: %v", err)
-				}
+		if err := h.handle(st, params, socket, h.ctxt.stop()); err != nil {
+			if isBrokenPipe(err) {
+				logger.Tracef("debug-log handler stopped (client disconnected)")
+			} else {
+				logger.Errorf("debug-log handler error: %v", err)
 			}
-		},
-	}
-	server.ServeHTTP(w, req)
+		}
+	})
 }
 
 func isBrokenPipe(err error) bool {
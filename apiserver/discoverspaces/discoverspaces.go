@@ -14,6 +14,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("DiscoverSpaces", 2, NewDiscoverSpacesAPI)
+
+	// Version 3 adds RemoveSubnets.
+	common.RegisterStandardFacade("DiscoverSpaces", 3, NewDiscoverSpacesAPIV3)
 }
 
 // DiscoverSpacesAPI implements the API used by the discoverspaces worker.
@@ -28,6 +31,22 @@ func NewDiscoverSpacesAPI(st *state.State, resources facade.Resources, authorize
 	return NewDiscoverSpacesAPIWithBacking(networkingcommon.NewStateShim(st), resources, authorizer)
 }
 
+// DiscoverSpacesAPIV3 implements version 3 of the DiscoverSpaces API,
+// which adds RemoveSubnets.
+type DiscoverSpacesAPIV3 struct {
+	*DiscoverSpacesAPI
+}
+
+// NewDiscoverSpacesAPIV3 creates a new instance of version 3 of the
+// DiscoverSpaces API.
+func NewDiscoverSpacesAPIV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*DiscoverSpacesAPIV3, error) {
+	api, err := NewDiscoverSpacesAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &DiscoverSpacesAPIV3{api}, nil
+}
+
 func NewDiscoverSpacesAPIWithBacking(st networkingcommon.NetworkBacking, resources facade.Resources, authorizer facade.Authorizer) (*DiscoverSpacesAPI, error) {
 	if !authorizer.AuthModelManager() {
 		return nil, common.ErrPerm
@@ -100,3 +119,9 @@ func (api *DiscoverSpacesAPI) AddSubnets(args params.AddSubnetsParams) (params.E
 func (api *DiscoverSpacesAPI) ListSubnets(args params.SubnetsFilters) (results params.ListSubnetsResults, err error) {
 	return networkingcommon.ListSubnets(api.st, args)
 }
+
+// RemoveSubnets removes the subnets with the given tags, unless
+// any machine has an address in them.
+func (api *DiscoverSpacesAPIV3) RemoveSubnets(args params.Entities) (params.ErrorResults, error) {
+	return networkingcommon.RemoveSubnets(api.st, args)
+}
@@ -132,3 +132,22 @@ func (s *DiscoverSpacesSuite) TestListSpacesFailure(c *gc.C) {
 
 	apiservertesting.BackingInstance.CheckCallNames(c, "AllSpaces")
 }
+
+func (s *DiscoverSpacesSuite) TestRemoveSubnets(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "subnet-192.168.1.0/24"},
+		{Tag: "space-dmz"},
+		{Tag: "subnet-10.0.0.0/8"},
+	}}
+	facade := &discoverspaces.DiscoverSpacesAPIV3{s.facade}
+	result, err := facade.RemoveSubnets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `"space-dmz" is not a valid subnet tag`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `subnet "10.0.0.0/8" not found`)
+	c.Check(result.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	apiservertesting.BackingInstance.CheckCall(c, 0, "RemoveSubnet", "192.168.1.0/24")
+	apiservertesting.BackingInstance.CheckCall(c, 1, "RemoveSubnet", "10.0.0.0/8")
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package enginereport implements the API facade through which agents
+// send their dependency engine reports to the controller, and through
+// which model administrators can read them back to inspect a sick
+// agent remotely.
+package enginereport
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("EngineReport", 1, newFacade)
+}
+
+// Backend defines the State API used by the enginereport facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	SetAgentEngineReport(names.Tag, map[string]interface{}) error
+	AgentEngineReport(names.Tag) (state.AgentEngineReport, error)
+}
+
+// Facade implements the EngineReport API.
+type Facade struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// New returns a new EngineReport API facade. It may be used by
+// machine and unit agents, and by clients.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() && !authorizer.AuthUnitAgent() && !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{backend: backend, authorizer: authorizer}, nil
+}
+
+// SetReports records the dependency engine reports of one or more
+// agents. Agents may only set their own reports.
+func (facade *Facade) SetReports(args params.AgentEngineReports) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Reports)),
+	}
+	for i, arg := range args.Reports {
+		tag, err := names.ParseTag(arg.Tag)
+		if err != nil || !facade.authorizer.AuthOwner(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = facade.backend.SetAgentEngineReport(tag, arg.Report)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// Reports returns the most recent dependency engine reports sent by
+// the given agents. Only model administrators may read them.
+func (facade *Facade) Reports(args params.Entities) (params.AgentEngineReportResults, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.AgentEngineReportResults{}, errors.Trace(err)
+	}
+	results := params.AgentEngineReportResults{
+		Results: make([]params.AgentEngineReportResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		switch tag.(type) {
+		case names.MachineTag, names.UnitTag:
+		default:
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		report, err := facade.backend.AgentEngineReport(tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Report = report.Report
+		results.Results[i].Updated = report.Updated
+	}
+	return results, nil
+}
+
+func (facade *Facade) checkIsModelAdmin() error {
+	if !facade.authorizer.AuthClient() {
+		return common.ErrPerm
+	}
+	isModelAdmin, err := facade.authorizer.HasPermission(permission.AdminAccess, facade.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isModelAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
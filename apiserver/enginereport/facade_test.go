@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginereport_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/enginereport"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+}
+
+func (s *facadeSuite) newFacade(c *gc.C) *enginereport.Facade {
+	facade, err := enginereport.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return facade
+}
+
+func (s *facadeSuite) TestNewRefusesOtherEntities(c *gc.C) {
+	s.authorizer.Tag = names.NewApplicationTag("mysql")
+	_, err := enginereport.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestSetReports(c *gc.C) {
+	report := map[string]interface{}{"state": "started"}
+	result, err := s.newFacade(c).SetReports(params.AgentEngineReports{
+		Reports: []params.AgentEngineReport{
+			{Tag: "machine-0", Report: report},
+			{Tag: "machine-1", Report: report},
+			{Tag: "bad-tag", Report: report},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{nil},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"SetAgentEngineReport", []interface{}{names.NewMachineTag("1"), report},
+	}})
+}
+
+func (s *facadeSuite) TestSetReportsUnitAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	report := map[string]interface{}{"state": "started"}
+	result, err := s.newFacade(c).SetReports(params.AgentEngineReports{
+		Reports: []params.AgentEngineReport{{Tag: "unit-mysql-0", Report: report}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.backend.stub.CheckCallNames(c, "SetAgentEngineReport")
+}
+
+func (s *facadeSuite) TestReports(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.authorizer.AdminTag = names.NewUserTag("admin")
+	updated := time.Date(2016, 11, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.reports = map[string]state.AgentEngineReport{
+		"machine-0": {
+			Report:  map[string]interface{}{"state": "started"},
+			Updated: updated,
+		},
+	}
+
+	result, err := s.newFacade(c).Reports(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "unit-mysql-0"},
+			{Tag: "user-bob"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentEngineReportResults{
+		Results: []params.AgentEngineReportResult{{
+			Report:  map[string]interface{}{"state": "started"},
+			Updated: updated,
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: "engine report for unit mysql/0 not found",
+			},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+	s.backend.stub.CheckCallNames(c, "ModelTag", "AgentEngineReport", "AgentEngineReport")
+}
+
+func (s *facadeSuite) TestReportsRequiresModelAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newFacade(c).Reports(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckCallNames(c, "ModelTag")
+}
+
+func (s *facadeSuite) TestReportsRefusesAgents(c *gc.C) {
+	_, err := s.newFacade(c).Reports(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	stub    jujutesting.Stub
+	reports map[string]state.AgentEngineReport
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.stub.AddCall("ModelTag")
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) SetAgentEngineReport(tag names.Tag, report map[string]interface{}) error {
+	b.stub.AddCall("SetAgentEngineReport", tag, report)
+	return b.stub.NextErr()
+}
+
+func (b *mockBackend) AgentEngineReport(tag names.Tag) (state.AgentEngineReport, error) {
+	b.stub.AddCall("AgentEngineReport", tag)
+	report, ok := b.reports[tag.String()]
+	if !ok {
+		return state.AgentEngineReport{}, errors.NotFoundf("engine report for %s", names.ReadableString(tag))
+	}
+	return report, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
 )
 
 var (
@@ -31,6 +32,16 @@ var (
 	SpritePath            = spritePath
 )
 
+// FilterDeltas returns the deltas that match the filter given by args,
+// as SrvAllWatcherV2.Next does.
+func FilterDeltas(args params.AllWatcherNextArgs, deltas []multiwatcher.Delta) ([]multiwatcher.Delta, error) {
+	filter, err := newDeltaFilter(args)
+	if err != nil {
+		return nil, err
+	}
+	return filter.apply(deltas), nil
+}
+
 func ServerMacaroon(srv *Server) (*macaroon.Macaroon, error) {
 	auth, err := srv.authCtxt.externalMacaroonAuth()
 	if err != nil {
@@ -69,9 +80,15 @@ func DelayLogins() (nextChan chan struct{}, cleanup func()) {
 	cleanup = func() {
 		doCheckCreds = checkCreds
 	}
-	delayedCheckCreds := func(st *state.State, c params.LoginRequest, lookForModelUser bool, authenticator authentication.EntityAuthenticator) (state.Entity, *time.Time, error) {
+	delayedCheckCreds := func(
+		st *state.State,
+		c params.LoginRequest,
+		lookForModelUser bool,
+		authenticator authentication.EntityAuthenticator,
+		lastConnections *lastConnectionRecorder,
+	) (state.Entity, *time.Time, error) {
 		<-nextChan
-		return checkCreds(st, c, lookForModelUser, authenticator)
+		return checkCreds(st, c, lookForModelUser, authenticator, lastConnections)
 	}
 	doCheckCreds = delayedCheckCreds
 	return
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/authentication/ldap"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+// externalUserCreator is recorded as the creator of users added to
+// the controller because they authenticated with an external
+// identity store. Only such users are kept in step with the store.
+const externalUserCreator = "ldap"
+
+// newLDAPChecker returns a PasswordChecker for the LDAP directory
+// configured for the controller, or nil if there is none.
+var newLDAPChecker = func(cfg controller.Config) (authentication.PasswordChecker, error) {
+	if cfg.LDAPURL() == "" {
+		return nil, nil
+	}
+	checker, err := ldap.NewChecker(ldap.Config{
+		URL:         cfg.LDAPURL(),
+		UserDN:      cfg.LDAPUserDN(),
+		GroupAccess: cfg.LDAPGroupAccess(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return checker, nil
+}
+
+// externalUserProvisioner implements authentication.UserProvisioner,
+// adding users to the controller's state.
+type externalUserProvisioner struct {
+	st *state.State
+}
+
+// CanProvision is part of the authentication.UserProvisioner interface.
+func (p externalUserProvisioner) CanProvision(tag names.UserTag) (bool, error) {
+	user, err := p.st.User(tag)
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return user.CreatedBy() == externalUserCreator, nil
+}
+
+// ProvisionUser is part of the authentication.UserProvisioner interface.
+func (p externalUserProvisioner) ProvisionUser(tag names.UserTag, identity authentication.ExternalIdentity) error {
+	user, err := p.st.User(tag)
+	if errors.IsNotFound(err) {
+		// The user's local password is never used: they
+		// always log in with their directory password.
+		password, err := utils.RandomPassword()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		user, err = p.st.AddUser(tag.Name(), identity.DisplayName, password, externalUserCreator)
+		if errors.IsAlreadyExists(err) {
+			// Another login added the user first.
+			user, err = p.st.User(tag)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("added user %q from LDAP", tag.Id())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if user.IsDisabled() {
+		return errors.Trace(common.ErrBadCreds)
+	}
+
+	controllerTag := p.st.ControllerTag()
+	userAccess, err := p.st.UserAccess(tag, controllerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if userAccess.Access != identity.Access {
+		logger.Infof("setting controller access of %q to %q from LDAP", tag.Id(), identity.Access)
+		if _, err := p.st.SetUserAccess(tag, controllerTag, identity.Access); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
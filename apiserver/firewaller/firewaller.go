@@ -20,6 +20,9 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+
+	// Version 4 adds GetExposedCIDRs.
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPIV4)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -41,6 +44,25 @@ type FirewallerAPI struct {
 	accessEnviron     common.GetAuthFunc
 }
 
+// FirewallerAPIV4 provides access to version 4 of the Firewaller API
+// facade, which adds GetExposedCIDRs.
+type FirewallerAPIV4 struct {
+	*FirewallerAPI
+}
+
+// NewFirewallerAPIV4 creates a new server-side FirewallerAPIV4 facade.
+func NewFirewallerAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*FirewallerAPIV4, error) {
+	api, err := NewFirewallerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FirewallerAPIV4{api}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPI facade.
 func NewFirewallerAPI(
 	st *state.State,
@@ -286,6 +308,31 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedCIDRs returns the CIDRs to which each given application
+// is exposed. No CIDRs means the application is exposed to all.
+func (f *FirewallerAPIV4) GetExposedCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = application.ExposedCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
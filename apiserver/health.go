@@ -0,0 +1,220 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// maxEngineReportAge is how old the controller agent's engine
+	// report may become before the workers are reported as a
+	// warning. Reports are sent every few minutes, so anything
+	// older means the agent is no longer reporting.
+	maxEngineReportAge = 15 * time.Minute
+
+	// lowDiskSpace and criticalDiskSpace are the amounts of free
+	// space in the data directory below which the disk is reported
+	// as a warning and an error respectively.
+	lowDiskSpace      = 2 << 30
+	criticalDiskSpace = 512 << 20
+)
+
+// requiredControllerWorkers holds the names of the controller agent's
+// manifolds that must be running for the controller to be healthy.
+var requiredControllerWorkers = []string{
+	"api-caller",
+	"central-hub",
+	"mgo-txn-resumer",
+	"state",
+	"unconverted-state-workers",
+}
+
+// healthSeverity orders the health statuses from least to most severe.
+var healthSeverity = map[string]int{
+	params.HealthOK:      0,
+	params.HealthWarning: 1,
+	params.HealthError:   2,
+}
+
+// healthCheck reports the health of one of the controller's
+// components.
+type healthCheck func() params.ComponentHealth
+
+// healthHandler serves the controller's /health endpoint. It requires
+// no authentication, so that load balancers and monitoring systems can
+// use it, and so reveals no more than the status of each component.
+// The response has status 503 if any component reports an error, and
+// 200 otherwise.
+type healthHandler struct {
+	checks map[string]healthCheck
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		if err := sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method)); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	result := params.ControllerHealth{
+		Status:     params.HealthOK,
+		Components: make(map[string]params.ComponentHealth),
+	}
+	for name, check := range h.checks {
+		health := check()
+		result.Components[name] = health
+		if healthSeverity[health.Status] > healthSeverity[result.Status] {
+			result.Status = health.Status
+		}
+	}
+	statusCode := http.StatusOK
+	if result.Status == params.HealthError {
+		statusCode = http.StatusServiceUnavailable
+	}
+	if err := sendStatusAndJSON(w, statusCode, result); err != nil {
+		logger.Errorf("%v", err)
+	}
+}
+
+// healthChecks returns the checks made by the server's /health
+// endpoint, keyed by component name.
+func (srv *Server) healthChecks() map[string]healthCheck {
+	return map[string]healthCheck{
+		"mongo": func() params.ComponentHealth {
+			session := srv.state.MongoSession().Copy()
+			defer session.Close()
+			return mongoHealth(replicaset.CurrentStatus(session))
+		},
+		"workers": func() params.ComponentHealth {
+			return workersHealth(srv.state, srv.tag, srv.clock.Now())
+		},
+		"state-pool": func() params.ComponentHealth {
+			return statePoolHealth(srv.statePool)
+		},
+		"disk": func() params.ComponentHealth {
+			return diskHealth(diskSpace(srv.dataDir))
+		},
+	}
+}
+
+func healthOK(format string, args ...interface{}) params.ComponentHealth {
+	return params.ComponentHealth{Status: params.HealthOK, Message: fmt.Sprintf(format, args...)}
+}
+
+func healthWarning(format string, args ...interface{}) params.ComponentHealth {
+	return params.ComponentHealth{Status: params.HealthWarning, Message: fmt.Sprintf(format, args...)}
+}
+
+func healthError(format string, args ...interface{}) params.ComponentHealth {
+	return params.ComponentHealth{Status: params.HealthError, Message: fmt.Sprintf(format, args...)}
+}
+
+// mongoHealth reports the health of the mongo replica set from its
+// status. A replica set without a primary cannot accept writes, which
+// is an error; unhealthy members are a warning.
+func mongoHealth(status *replicaset.Status, err error) params.ComponentHealth {
+	if err != nil {
+		logger.Warningf("cannot get replica set status: %v", err)
+		return healthError("cannot get replica set status")
+	}
+	var hasPrimary bool
+	var unhealthy int
+	for _, member := range status.Members {
+		if member.State == replicaset.PrimaryState {
+			hasPrimary = true
+		}
+		if !member.Healthy {
+			unhealthy++
+		}
+	}
+	switch {
+	case !hasPrimary:
+		return healthError("replica set has no primary")
+	case unhealthy > 0:
+		return healthWarning("%d of %d replica set members unhealthy", unhealthy, len(status.Members))
+	}
+	return healthOK("%d replica set members healthy", len(status.Members))
+}
+
+// engineReportGetter is implemented by *state.State.
+type engineReportGetter interface {
+	AgentEngineReport(names.Tag) (state.AgentEngineReport, error)
+}
+
+// workersHealth reports whether the controller agent with the given
+// tag is running the workers a controller needs, according to the
+// most recent dependency engine report the agent sent.
+func workersHealth(st engineReportGetter, tag names.Tag, now time.Time) params.ComponentHealth {
+	report, err := st.AgentEngineReport(tag)
+	if errors.IsNotFound(err) {
+		return healthWarning("no engine report from controller agent")
+	}
+	if err != nil {
+		logger.Warningf("cannot get engine report: %v", err)
+		return healthError("cannot get engine report")
+	}
+	if age := now.Sub(report.Updated); age > maxEngineReportAge {
+		return healthWarning("engine report is %v old", age-age%time.Second)
+	}
+	manifolds, _ := report.Report["manifolds"].(map[string]interface{})
+	var stopped []string
+	for _, name := range requiredControllerWorkers {
+		manifold, _ := manifolds[name].(map[string]interface{})
+		if manifold["state"] != "started" {
+			stopped = append(stopped, name)
+		}
+	}
+	if len(stopped) > 0 {
+		sort.Strings(stopped)
+		return healthError("required workers not running: %s", strings.Join(stopped, ", "))
+	}
+	return healthOK("%d required workers running", len(requiredControllerWorkers))
+}
+
+// statePoolHealth reports whether the controller's state can reach
+// the database, and how many model states are in use.
+func statePoolHealth(pool *state.StatePool) params.ComponentHealth {
+	if err := pool.SystemState().Ping(); err != nil {
+		logger.Warningf("cannot ping controller state: %v", err)
+		return healthError("cannot ping controller state")
+	}
+	stats := pool.Stats()
+	return healthOK(
+		"%d models, %d references, %d pending removal",
+		stats.Models, stats.References, stats.PendingRemoval,
+	)
+}
+
+// diskHealth reports whether the given amount of free disk space is
+// enough for the controller to keep running.
+func diskHealth(free, total uint64, err error) params.ComponentHealth {
+	if errors.IsNotSupported(err) {
+		return healthOK("disk space not checked")
+	}
+	if err != nil {
+		logger.Warningf("cannot get disk space: %v", err)
+		return healthError("cannot get disk space")
+	}
+	message := fmt.Sprintf("%dMiB of %dMiB free", free>>20, total>>20)
+	switch {
+	case free < criticalDiskSpace:
+		return healthError("%s", message)
+	case free < lowDiskSpace:
+		return healthWarning("%s", message)
+	}
+	return healthOK("%s", message)
+}
@@ -0,0 +1,220 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type healthSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&healthSuite{})
+
+func (s *healthSuite) serve(c *gc.C, method string, checks map[string]healthCheck) (int, params.ControllerHealth) {
+	handler := &healthHandler{checks: checks}
+	req, err := http.NewRequest(method, "/health", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	c.Assert(recorder.Header().Get("Content-Type"), gc.Equals, params.ContentTypeJSON)
+	var result params.ControllerHealth
+	err = json.Unmarshal(recorder.Body.Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	return recorder.Code, result
+}
+
+func fixedHealth(status, message string) healthCheck {
+	return func() params.ComponentHealth {
+		return params.ComponentHealth{Status: status, Message: message}
+	}
+}
+
+func (s *healthSuite) TestAllOK(c *gc.C) {
+	code, result := s.serve(c, "GET", map[string]healthCheck{
+		"a": fixedHealth(params.HealthOK, "fine"),
+		"b": fixedHealth(params.HealthOK, ""),
+	})
+	c.Assert(code, gc.Equals, http.StatusOK)
+	c.Assert(result, jc.DeepEquals, params.ControllerHealth{
+		Status: params.HealthOK,
+		Components: map[string]params.ComponentHealth{
+			"a": {Status: params.HealthOK, Message: "fine"},
+			"b": {Status: params.HealthOK},
+		},
+	})
+}
+
+func (s *healthSuite) TestWarning(c *gc.C) {
+	code, result := s.serve(c, "GET", map[string]healthCheck{
+		"a": fixedHealth(params.HealthOK, ""),
+		"b": fixedHealth(params.HealthWarning, "hmm"),
+	})
+	c.Assert(code, gc.Equals, http.StatusOK)
+	c.Assert(result.Status, gc.Equals, params.HealthWarning)
+}
+
+func (s *healthSuite) TestError(c *gc.C) {
+	code, result := s.serve(c, "GET", map[string]healthCheck{
+		"a": fixedHealth(params.HealthError, "broken"),
+		"b": fixedHealth(params.HealthWarning, "hmm"),
+	})
+	c.Assert(code, gc.Equals, http.StatusServiceUnavailable)
+	c.Assert(result.Status, gc.Equals, params.HealthError)
+	c.Assert(result.Components["a"].Message, gc.Equals, "broken")
+}
+
+func (s *healthSuite) TestMethodNotAllowed(c *gc.C) {
+	handler := &healthHandler{}
+	req, err := http.NewRequest("POST", "/health", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	c.Assert(recorder.Code, gc.Equals, http.StatusMethodNotAllowed)
+	var result params.ErrorResult
+	err = json.Unmarshal(recorder.Body.Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error.Message, gc.Equals, `unsupported method: "POST"`)
+}
+
+func (s *healthSuite) TestMongoHealth(c *gc.C) {
+	for i, test := range []struct {
+		status  *replicaset.Status
+		err     error
+		expect  string
+		message string
+	}{{
+		status: &replicaset.Status{Members: []replicaset.MemberStatus{
+			{Healthy: true, State: replicaset.PrimaryState},
+			{Healthy: true, State: replicaset.SecondaryState},
+		}},
+		expect:  params.HealthOK,
+		message: "2 replica set members healthy",
+	}, {
+		status: &replicaset.Status{Members: []replicaset.MemberStatus{
+			{Healthy: true, State: replicaset.PrimaryState},
+			{Healthy: false, State: replicaset.DownState},
+		}},
+		expect:  params.HealthWarning,
+		message: "1 of 2 replica set members unhealthy",
+	}, {
+		status: &replicaset.Status{Members: []replicaset.MemberStatus{
+			{Healthy: true, State: replicaset.SecondaryState},
+		}},
+		expect:  params.HealthError,
+		message: "replica set has no primary",
+	}, {
+		err:     errors.New("boom"),
+		expect:  params.HealthError,
+		message: "cannot get replica set status",
+	}} {
+		c.Logf("test %d", i)
+		health := mongoHealth(test.status, test.err)
+		c.Check(health, gc.Equals, params.ComponentHealth{Status: test.expect, Message: test.message})
+	}
+}
+
+type fakeEngineReportGetter struct {
+	report state.AgentEngineReport
+	err    error
+}
+
+func (f fakeEngineReportGetter) AgentEngineReport(names.Tag) (state.AgentEngineReport, error) {
+	return f.report, f.err
+}
+
+func engineReport(updated time.Time, started ...string) state.AgentEngineReport {
+	manifolds := make(map[string]interface{})
+	for _, name := range requiredControllerWorkers {
+		manifolds[name] = map[string]interface{}{"state": "stopped"}
+	}
+	for _, name := range started {
+		manifolds[name] = map[string]interface{}{"state": "started"}
+	}
+	return state.AgentEngineReport{
+		Report:  map[string]interface{}{"manifolds": manifolds},
+		Updated: updated,
+	}
+}
+
+func (s *healthSuite) TestWorkersHealth(c *gc.C) {
+	now := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	tag := names.NewMachineTag("0")
+	for i, test := range []struct {
+		getter  fakeEngineReportGetter
+		expect  string
+		message string
+	}{{
+		getter:  fakeEngineReportGetter{report: engineReport(now, requiredControllerWorkers...)},
+		expect:  params.HealthOK,
+		message: "5 required workers running",
+	}, {
+		getter:  fakeEngineReportGetter{report: engineReport(now, "state", "api-caller", "mgo-txn-resumer")},
+		expect:  params.HealthError,
+		message: "required workers not running: central-hub, unconverted-state-workers",
+	}, {
+		getter:  fakeEngineReportGetter{report: engineReport(now.Add(-time.Hour), requiredControllerWorkers...)},
+		expect:  params.HealthWarning,
+		message: "engine report is 1h0m0s old",
+	}, {
+		getter:  fakeEngineReportGetter{err: errors.NotFoundf("engine report")},
+		expect:  params.HealthWarning,
+		message: "no engine report from controller agent",
+	}, {
+		getter:  fakeEngineReportGetter{err: errors.New("boom")},
+		expect:  params.HealthError,
+		message: "cannot get engine report",
+	}} {
+		c.Logf("test %d", i)
+		health := workersHealth(test.getter, tag, now)
+		c.Check(health, gc.Equals, params.ComponentHealth{Status: test.expect, Message: test.message})
+	}
+}
+
+func (s *healthSuite) TestDiskHealth(c *gc.C) {
+	for i, test := range []struct {
+		free    uint64
+		err     error
+		expect  string
+		message string
+	}{{
+		free:    4 << 30,
+		expect:  params.HealthOK,
+		message: "4096MiB of 10240MiB free",
+	}, {
+		free:    1 << 30,
+		expect:  params.HealthWarning,
+		message: "1024MiB of 10240MiB free",
+	}, {
+		free:    100 << 20,
+		expect:  params.HealthError,
+		message: "100MiB of 10240MiB free",
+	}, {
+		err:     errors.NotSupportedf("disk space"),
+		expect:  params.HealthOK,
+		message: "disk space not checked",
+	}, {
+		err:     errors.New("boom"),
+		expect:  params.HealthError,
+		message: "cannot get disk space",
+	}} {
+		c.Logf("test %d", i)
+		health := diskHealth(test.free, 10<<30, test.err)
+		c.Check(health, gc.Equals, params.ComponentHealth{Status: test.expect, Message: test.message})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type healthSuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&healthSuite{})
+
+func (s *healthSuite) TestHealthUnauthenticated(c *gc.C) {
+	u := s.baseURL(c)
+	u.Path = "/health"
+	resp := s.sendRequest(c, httpRequestParams{
+		method: "GET",
+		url:    u.String(),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, params.ContentTypeJSON)
+
+	var result params.ControllerHealth
+	err := json.NewDecoder(resp.Body).Decode(&result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Components, gc.HasLen, 4)
+	for _, name := range []string{"mongo", "workers", "state-pool", "disk"} {
+		c.Check(result.Components[name].Status, gc.Not(gc.Equals), "", gc.Commentf("component %q", name))
+	}
+	c.Check(result.Components["state-pool"].Status, gc.Equals, params.HealthOK)
+}
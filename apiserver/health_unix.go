@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package apiserver
+
+import (
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+// diskSpace returns the free and total space, in bytes, of the
+// filesystem containing the given path. Free space is that
+// available to unprivileged users.
+func diskSpace(path string) (free, total uint64, err error) {
+	var statfs syscall.Statfs_t
+	if err := syscall.Statfs(path, &statfs); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	free = uint64(statfs.Bavail) * uint64(statfs.Bsize)
+	total = uint64(statfs.Blocks) * uint64(statfs.Bsize)
+	return free, total, nil
+}
@@ -0,0 +1,14 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+)
+
+// diskSpace is not implemented on Windows, where controllers
+// are not run.
+func diskSpace(path string) (free, total uint64, err error) {
+	return 0, 0, errors.NotSupportedf("disk space on windows")
+}
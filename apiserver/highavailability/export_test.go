@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"github.com/juju/juju/state"
+)
+
+// ReplicaSetSession exposes replicaSetSession for tests.
+type ReplicaSetSession interface {
+	replicaSetSession
+}
+
+type patcher interface {
+	PatchValue(dest, value interface{})
+}
+
+// PatchReplicaSetSession makes the facade use the given session for
+// its replica set operations.
+func PatchReplicaSetSession(p patcher, session ReplicaSetSession) {
+	p.PatchValue(&newReplicaSetSession, func(*state.State) replicaSetSession {
+		return session
+	})
+}
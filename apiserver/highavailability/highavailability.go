@@ -24,11 +24,18 @@ var logger = loggo.GetLogger("juju.apiserver.highavailability")
 
 func init() {
 	common.RegisterStandardFacade("HighAvailability", 2, NewHighAvailabilityAPI)
+
+	// Version 3 adds ReplicaSetStatus, StepDownPrimary and
+	// RemoveReplicaSetMembers.
+	common.RegisterStandardFacade("HighAvailability", 3, NewHighAvailabilityAPI)
 }
 
 // HighAvailability defines the methods on the highavailability API end point.
 type HighAvailability interface {
 	EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error)
+	ReplicaSetStatus() (params.ReplicaSetStatus, error)
+	StepDownPrimary() (params.ErrorResult, error)
+	RemoveReplicaSetMembers(args params.ReplicaSetMemberIds) (params.ErrorResults, error)
 }
 
 // HighAvailabilityAPI implements the HighAvailability interface and is the concrete
@@ -59,14 +66,8 @@ func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authori
 func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error) {
 	results := params.ControllersChangeResults{}
 
-	if api.authorizer.AuthClient() {
-		admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
-		if err != nil && !errors.IsNotFound(err) {
-			return results, errors.Trace(err)
-		}
-		if !admin {
-			return results, common.ServerError(common.ErrPerm)
-		}
+	if err := api.checkControllerAdmin(); err != nil {
+		return results, err
 	}
 
 	if len(args.Specs) == 0 {
@@ -83,6 +84,22 @@ func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.C
 	return results, nil
 }
 
+// checkControllerAdmin returns an error unless the API is being
+// used by a model manager or a controller superuser.
+func (api *HighAvailabilityAPI) checkControllerAdmin() error {
+	if !api.authorizer.AuthClient() {
+		return nil
+	}
+	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !admin {
+		return common.ServerError(common.ErrPerm)
+	}
+	return nil
+}
+
 // Convert machine ids to tags.
 func machineIdsToTags(ids ...string) []string {
 	var result []string
@@ -0,0 +1,257 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// jujuMachineKey is the replica set member tag in which the
+	// peergrouper records the member's machine id.
+	jujuMachineKey = "juju-machine-id"
+
+	// maxElections is the number of recent elections reported by
+	// ReplicaSetStatus.
+	maxElections = 10
+
+	// stepDownPeriod is how long a primary that has been asked to
+	// step down does not stand for election.
+	stepDownPeriod = time.Minute
+)
+
+// replicaSetSession defines the mongo replica set operations used by
+// the replica set methods of the facade.
+type replicaSetSession interface {
+	CurrentMembers() ([]replicaset.Member, error)
+	CurrentStatus() (*replicaset.Status, error)
+	MemberStatuses() ([]mongo.ReplicaSetMemberStatus, error)
+	Elections(limit int) ([]mongo.ReplicaSetElection, error)
+	StepDownPrimary(period time.Duration) error
+	Remove(address string) error
+	Close()
+}
+
+// newReplicaSetSession is overridden in tests.
+var newReplicaSetSession = func(st *state.State) replicaSetSession {
+	return mongoSessionShim{st.MongoSession().Copy()}
+}
+
+type mongoSessionShim struct {
+	session *mgo.Session
+}
+
+func (s mongoSessionShim) CurrentMembers() ([]replicaset.Member, error) {
+	return replicaset.CurrentMembers(s.session)
+}
+
+func (s mongoSessionShim) CurrentStatus() (*replicaset.Status, error) {
+	return replicaset.CurrentStatus(s.session)
+}
+
+func (s mongoSessionShim) MemberStatuses() ([]mongo.ReplicaSetMemberStatus, error) {
+	return mongo.ReplicaSetMemberStatuses(s.session)
+}
+
+func (s mongoSessionShim) Elections(limit int) ([]mongo.ReplicaSetElection, error) {
+	return mongo.ReplicaSetElections(s.session, limit)
+}
+
+func (s mongoSessionShim) StepDownPrimary(period time.Duration) error {
+	return mongo.StepDownPrimary(s.session, period)
+}
+
+func (s mongoSessionShim) Remove(address string) error {
+	return replicaset.Remove(s.session, address)
+}
+
+func (s mongoSessionShim) Close() {
+	s.session.Close()
+}
+
+// ReplicaSetStatus returns the status of the members of the
+// controller's mongo replica set, and its recent elections.
+func (api *HighAvailabilityAPI) ReplicaSetStatus() (params.ReplicaSetStatus, error) {
+	if err := api.checkControllerAdmin(); err != nil {
+		return params.ReplicaSetStatus{}, err
+	}
+	session := newReplicaSetSession(api.state)
+	defer session.Close()
+
+	status, err := session.CurrentStatus()
+	if err != nil {
+		return params.ReplicaSetStatus{}, errors.Trace(err)
+	}
+	members, err := session.CurrentMembers()
+	if err != nil {
+		return params.ReplicaSetStatus{}, errors.Trace(err)
+	}
+	memberStatuses, err := session.MemberStatuses()
+	if err != nil {
+		return params.ReplicaSetStatus{}, errors.Trace(err)
+	}
+	elections, err := session.Elections(maxElections)
+	if err != nil {
+		return params.ReplicaSetStatus{}, errors.Trace(err)
+	}
+
+	configs := make(map[int]replicaset.Member)
+	for _, m := range members {
+		configs[m.Id] = m
+	}
+	optimes := make(map[int]mongo.ReplicaSetMemberStatus)
+	for _, ms := range memberStatuses {
+		optimes[ms.Id] = ms
+	}
+	// Lag is measured against the primary; if there is none, it
+	// cannot be measured.
+	var primaryOptime time.Time
+	for _, m := range status.Members {
+		if m.State == replicaset.PrimaryState {
+			primaryOptime = optimes[m.Id].OptimeDate
+		}
+	}
+
+	var result params.ReplicaSetStatus
+	for _, m := range status.Members {
+		member := params.ReplicaSetMember{
+			Id:      m.Id,
+			Address: m.Address,
+			State:   m.State.String(),
+			Healthy: m.Healthy,
+			Message: m.ErrMsg,
+		}
+		if config, ok := configs[m.Id]; ok {
+			member.Voting = config.Votes == nil || *config.Votes > 0
+			if id, ok := config.Tags[jujuMachineKey]; ok {
+				member.MachineTag = names.NewMachineTag(id).String()
+			}
+		}
+		if ms, ok := optimes[m.Id]; ok {
+			member.LastHeartbeat = ms.LastHeartbeat
+			if !primaryOptime.IsZero() && !ms.OptimeDate.IsZero() && primaryOptime.After(ms.OptimeDate) {
+				member.Lag = primaryOptime.Sub(ms.OptimeDate)
+			}
+		}
+		result.Members = append(result.Members, member)
+	}
+	for _, e := range elections {
+		result.Elections = append(result.Elections, params.ReplicaSetElection{
+			Time: e.Time,
+			Term: e.Term,
+		})
+	}
+	return result, nil
+}
+
+// StepDownPrimary asks the primary of the controller's mongo replica
+// set to step down so that another member is elected. It is refused
+// if there is no healthy voting secondary to take over.
+func (api *HighAvailabilityAPI) StepDownPrimary() (params.ErrorResult, error) {
+	if err := api.checkControllerAdmin(); err != nil {
+		return params.ErrorResult{}, err
+	}
+	session := newReplicaSetSession(api.state)
+	defer session.Close()
+
+	err := stepDownPrimary(session)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+func stepDownPrimary(session replicaSetSession) error {
+	status, err := session.CurrentStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	members, err := session.CurrentMembers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	voting := make(map[int]bool)
+	for _, m := range members {
+		voting[m.Id] = m.Votes == nil || *m.Votes > 0
+	}
+	var electable bool
+	for _, m := range status.Members {
+		if m.State == replicaset.SecondaryState && m.Healthy && voting[m.Id] {
+			electable = true
+			break
+		}
+	}
+	if !electable {
+		return errors.New("cannot step down primary: no healthy voting secondary to replace it")
+	}
+	return errors.Trace(session.StepDownPrimary(stepDownPeriod))
+}
+
+// RemoveReplicaSetMembers removes the members with the given ids from
+// the controller's mongo replica set. The primary may not be removed,
+// and neither may a member whose machine is a controller that wants
+// a vote, as the peergrouper would add it back; such machines should
+// be removed with remove-machine, or demoted with enable-ha.
+func (api *HighAvailabilityAPI) RemoveReplicaSetMembers(args params.ReplicaSetMemberIds) (params.ErrorResults, error) {
+	if err := api.checkControllerAdmin(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	session := newReplicaSetSession(api.state)
+	defer session.Close()
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := api.removeReplicaSetMember(session, id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *HighAvailabilityAPI) removeReplicaSetMember(session replicaSetSession, id int) error {
+	status, err := session.CurrentStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	members, err := session.CurrentMembers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var member *replicaset.Member
+	for i, m := range members {
+		if m.Id == id {
+			member = &members[i]
+			break
+		}
+	}
+	if member == nil {
+		return errors.NotFoundf("replica set member %d", id)
+	}
+	for _, m := range status.Members {
+		if m.Id == id && m.State == replicaset.PrimaryState {
+			return errors.Errorf("cannot remove replica set member %d: it is the primary", id)
+		}
+	}
+	if machineId, ok := member.Tags[jujuMachineKey]; ok {
+		machine, err := api.state.Machine(machineId)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if err == nil && machine.WantsVote() {
+			return errors.Errorf(
+				"cannot remove replica set member %d: machine %s is a voting controller",
+				id, machineId,
+			)
+		}
+	}
+	return errors.Annotatef(session.Remove(member.Address), "removing replica set member %d", id)
+}
@@ -0,0 +1,189 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/mongo"
+)
+
+type replicaSetSuite struct {
+	clientSuite
+	session *fakeReplicaSetSession
+}
+
+var _ = gc.Suite(&replicaSetSuite{})
+
+func (s *replicaSetSuite) SetUpTest(c *gc.C) {
+	s.clientSuite.SetUpTest(c)
+	noVote := 0
+	now := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.session = &fakeReplicaSetSession{
+		status: &replicaset.Status{
+			Members: []replicaset.MemberStatus{
+				{Id: 1, Address: "10.0.0.1:37017", State: replicaset.PrimaryState, Healthy: true},
+				{Id: 2, Address: "10.0.0.2:37017", State: replicaset.SecondaryState, Healthy: true},
+				{Id: 3, Address: "10.0.0.3:37017", State: replicaset.SecondaryState, ErrMsg: "unreachable"},
+			},
+		},
+		members: []replicaset.Member{
+			{Id: 1, Address: "10.0.0.1:37017", Tags: map[string]string{"juju-machine-id": "0"}},
+			{Id: 2, Address: "10.0.0.2:37017", Tags: map[string]string{"juju-machine-id": "5"}},
+			{Id: 3, Address: "10.0.0.3:37017", Votes: &noVote},
+		},
+		memberStatuses: []mongo.ReplicaSetMemberStatus{
+			{Id: 1, OptimeDate: now},
+			{Id: 2, OptimeDate: now.Add(-5 * time.Second), LastHeartbeat: now},
+			{Id: 3, OptimeDate: now.Add(-time.Hour)},
+		},
+		elections: []mongo.ReplicaSetElection{{Time: now.Add(-time.Hour), Term: 3}},
+	}
+	highavailability.PatchReplicaSetSession(s, s.session)
+}
+
+func (s *replicaSetSuite) TestReplicaSetStatus(c *gc.C) {
+	status, err := s.haServer.ReplicaSetStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	c.Assert(status, jc.DeepEquals, params.ReplicaSetStatus{
+		Members: []params.ReplicaSetMember{{
+			Id:         1,
+			Address:    "10.0.0.1:37017",
+			MachineTag: "machine-0",
+			State:      "PRIMARY",
+			Healthy:    true,
+			Voting:     true,
+		}, {
+			Id:            2,
+			Address:       "10.0.0.2:37017",
+			MachineTag:    "machine-5",
+			State:         "SECONDARY",
+			Healthy:       true,
+			Voting:        true,
+			Lag:           5 * time.Second,
+			LastHeartbeat: now,
+		}, {
+			Id:      3,
+			Address: "10.0.0.3:37017",
+			State:   "SECONDARY",
+			Lag:     time.Hour,
+			Message: "unreachable",
+		}},
+		Elections: []params.ReplicaSetElection{{Time: now.Add(-time.Hour), Term: 3}},
+	})
+	s.session.CheckCall(c, 3, "Elections", 10)
+	s.session.CheckCall(c, 4, "Close")
+}
+
+func (s *replicaSetSuite) TestReplicaSetStatusPermission(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	haServer, err := highavailability.NewHighAvailabilityAPI(s.State, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: user.UserTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = haServer.ReplicaSetStatus()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.session.CheckNoCalls(c)
+}
+
+func (s *replicaSetSuite) TestStepDownPrimary(c *gc.C) {
+	result, err := s.haServer.StepDownPrimary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.session.CheckCall(c, 2, "StepDownPrimary", time.Minute)
+}
+
+func (s *replicaSetSuite) TestStepDownPrimaryNoSecondary(c *gc.C) {
+	s.session.status.Members[1].Healthy = false
+	result, err := s.haServer.StepDownPrimary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "cannot step down primary: no healthy voting secondary to replace it")
+	s.session.CheckCallNames(c, "CurrentStatus", "CurrentMembers", "Close")
+}
+
+func (s *replicaSetSuite) TestRemoveReplicaSetMembers(c *gc.C) {
+	results, err := s.haServer.RemoveReplicaSetMembers(params.ReplicaSetMemberIds{
+		Ids: []int{1, 2, 3, 4},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: "cannot remove replica set member 1: it is the primary"}},
+			{},
+			{},
+			{Error: &params.Error{Message: "replica set member 4 not found", Code: params.CodeNotFound}},
+		},
+	})
+	var removed []string
+	for _, call := range s.session.Calls() {
+		if call.FuncName == "Remove" {
+			removed = append(removed, call.Args[0].(string))
+		}
+	}
+	c.Assert(removed, jc.DeepEquals, []string{"10.0.0.2:37017", "10.0.0.3:37017"})
+}
+
+func (s *replicaSetSuite) TestRemoveReplicaSetMemberVotingController(c *gc.C) {
+	s.session.status.Members[0].State = replicaset.SecondaryState
+	results, err := s.haServer.RemoveReplicaSetMembers(params.ReplicaSetMemberIds{Ids: []int{1}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		"cannot remove replica set member 1: machine 0 is a voting controller")
+	s.session.CheckCallNames(c, "CurrentStatus", "CurrentMembers", "Close")
+}
+
+type fakeReplicaSetSession struct {
+	testing.Stub
+	status         *replicaset.Status
+	members        []replicaset.Member
+	memberStatuses []mongo.ReplicaSetMemberStatus
+	elections      []mongo.ReplicaSetElection
+}
+
+func (f *fakeReplicaSetSession) CurrentMembers() ([]replicaset.Member, error) {
+	f.MethodCall(f, "CurrentMembers")
+	return f.members, f.NextErr()
+}
+
+func (f *fakeReplicaSetSession) CurrentStatus() (*replicaset.Status, error) {
+	f.MethodCall(f, "CurrentStatus")
+	return f.status, f.NextErr()
+}
+
+func (f *fakeReplicaSetSession) MemberStatuses() ([]mongo.ReplicaSetMemberStatus, error) {
+	f.MethodCall(f, "MemberStatuses")
+	return f.memberStatuses, f.NextErr()
+}
+
+func (f *fakeReplicaSetSession) Elections(limit int) ([]mongo.ReplicaSetElection, error) {
+	f.MethodCall(f, "Elections", limit)
+	return f.elections, f.NextErr()
+}
+
+func (f *fakeReplicaSetSession) StepDownPrimary(period time.Duration) error {
+	f.MethodCall(f, "StepDownPrimary", period)
+	return f.NextErr()
+}
+
+func (f *fakeReplicaSetSession) Remove(address string) error {
+	f.MethodCall(f, "Remove", address)
+	if err := f.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (f *fakeReplicaSetSession) Close() {
+	f.MethodCall(f, "Close")
+}
@@ -30,6 +30,15 @@ type httpContext struct {
 	srv *Server
 }
 
+// keepalive returns the keepalive to use for websocket connections
+// served in this context.
+func (ctxt *httpContext) keepalive() *websocketKeepalive {
+	if ctxt.srv == nil {
+		return nil
+	}
+	return ctxt.srv.keepalive
+}
+
 // stateForRequestUnauthenticated returns a state instance appropriate for
 // using for the model implicit in the given request
 // without checking any authentication information.
@@ -70,7 +79,7 @@ func (ctxt *httpContext) stateForRequestAuthenticated(r *http.Request) (
 		return nil, nil, errors.NewUnauthorized(err, "")
 	}
 	authenticator := ctxt.srv.authCtxt.authenticator(r.Host)
-	entity, _, err := checkCreds(st, req, true, authenticator)
+	entity, _, err := checkCreds(st, req, true, authenticator, ctxt.srv.lastConnections)
 	if err != nil {
 		if common.IsDischargeRequiredError(err) {
 			return nil, nil, errors.Trace(err)
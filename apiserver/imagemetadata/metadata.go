@@ -6,6 +6,7 @@ package imagemetadata
 import (
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -28,6 +29,7 @@ var logger = loggo.GetLogger("juju.apiserver.imagemetadata")
 
 func init() {
 	common.RegisterStandardFacade("ImageMetadata", 2, NewAPI)
+	common.RegisterStandardFacade("ImageMetadata", 3, NewAPIV3)
 }
 
 // API is the concrete implementation of the api end point
@@ -68,6 +70,25 @@ func NewAPI(
 	return createAPI(getState(st), newEnviron, resources, authorizer)
 }
 
+// APIv3 provides access to version 3 of the ImageMetadata API facade,
+// which adds Expire.
+type APIv3 struct {
+	*API
+}
+
+// NewAPIV3 returns a new cloud image metadata API facade, version 3.
+func NewAPIV3(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv3, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
 // List returns all found cloud image metadata that satisfy
 // given filter.
 // Returned list contains metadata ordered by priority.
@@ -157,6 +178,30 @@ func (api *API) Delete(images params.MetadataImageIds) (params.ErrorResults, err
 	return params.ErrorResults{Results: all}, nil
 }
 
+// Expire deletes the cached cloud image metadata that is older than
+// the given age, other than metadata with the "custom" source. The
+// deleted metadata is fetched afresh the next time the published
+// image metadata is read.
+func (api *APIv3) Expire(args params.ExpireImageMetadataParams) (params.ExpireImageMetadataResult, error) {
+	if api.authorizer.AuthClient() {
+		admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.metadata.ControllerTag())
+		if err != nil {
+			return params.ExpireImageMetadataResult{}, errors.Trace(err)
+		}
+		if !admin {
+			return params.ExpireImageMetadataResult{}, common.ServerError(common.ErrPerm)
+		}
+	}
+	if args.MaxAge < 0 {
+		return params.ExpireImageMetadataResult{}, errors.NotValidf("negative max age")
+	}
+	expired, err := api.metadata.ExpireMetadata(time.Now().Add(-args.MaxAge))
+	if err != nil {
+		return params.ExpireImageMetadataResult{}, common.ServerError(err)
+	}
+	return params.ExpireImageMetadataResult{Expired: expired}, nil
+}
+
 func parseMetadataToParams(p cloudimagemetadata.Metadata) params.CloudImageMetadata {
 	result := params.CloudImageMetadata{
 		ImageId:         p.ImageId,
@@ -170,6 +215,7 @@ func parseMetadataToParams(p cloudimagemetadata.Metadata) params.CloudImageMetad
 		RootStorageSize: p.RootStorageSize,
 		Source:          p.Source,
 		Priority:        p.Priority,
+		DateCreated:     p.DateCreated,
 	}
 	return result
 }
@@ -243,6 +289,16 @@ func (api *API) retrievePublished() error {
 		cons.CloudSpec = cloud
 	}
 
+	// Expire stale metadata before refreshing it, so that anything
+	// still published is cached afresh.
+	if maxAge := env.Config().ImageMetadataMaxAge(); maxAge > 0 {
+		expired, err := api.metadata.ExpireMetadata(time.Now().Add(-maxAge))
+		if err != nil {
+			return errors.Annotatef(err, "expiring image metadata")
+		}
+		logger.Debugf("expired %d image metadata older than %v", expired, maxAge)
+	}
+
 	// We want all relevant metadata from all data sources.
 	for _, source := range sources {
 		logger.Debugf("looking in data source %v", source.Description())
@@ -4,10 +4,13 @@
 package imagemetadata_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/imagemetadata"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state/cloudimagemetadata"
 )
@@ -164,3 +167,37 @@ func (s *metadataSuite) TestDelete(c *gc.C) {
 	c.Assert(errs.Results[1].Error, gc.ErrorMatches, msg)
 	s.assertCalls(c, "ControllerTag", deleteMetadata, deleteMetadata)
 }
+
+func (s *metadataSuite) TestExpire(c *gc.C) {
+	var expiredBefore time.Time
+	s.state.expireMetadata = func(before time.Time) (int, error) {
+		expiredBefore = before
+		return 3, nil
+	}
+
+	api := &imagemetadata.APIv3{s.api}
+	start := time.Now()
+	result, err := api.Expire(params.ExpireImageMetadataParams{MaxAge: time.Hour})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Expired, gc.Equals, 3)
+	c.Assert(expiredBefore.Before(start.Add(-time.Hour)), jc.IsFalse)
+	c.Assert(expiredBefore.After(time.Now().Add(-time.Hour)), jc.IsFalse)
+	s.assertCalls(c, "ControllerTag", expireMetadata)
+}
+
+func (s *metadataSuite) TestExpireNegative(c *gc.C) {
+	api := &imagemetadata.APIv3{s.api}
+	_, err := api.Expire(params.ExpireImageMetadataParams{MaxAge: -time.Hour})
+	c.Assert(err, gc.ErrorMatches, "negative max age not valid")
+	s.assertCalls(c, "ControllerTag")
+}
+
+func (s *metadataSuite) TestExpireError(c *gc.C) {
+	s.state.expireMetadata = func(before time.Time) (int, error) {
+		return 0, errors.New("expire error")
+	}
+
+	api := &imagemetadata.APIv3{s.api}
+	_, err := api.Expire(params.ExpireImageMetadataParams{MaxAge: time.Hour})
+	c.Assert(err, gc.ErrorMatches, "expire error")
+}
@@ -5,6 +5,7 @@ package imagemetadata_test
 
 import (
 	stdtesting "testing"
+	"time"
 
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -62,6 +63,7 @@ const (
 	findMetadata   = "findMetadata"
 	saveMetadata   = "saveMetadata"
 	deleteMetadata = "deleteMetadata"
+	expireMetadata = "expireMetadata"
 	environConfig  = "environConfig"
 )
 
@@ -77,6 +79,9 @@ func (s *baseImageMetadataSuite) constructState(cfg *config.Config, model imagem
 		deleteMetadata: func(imageId string) error {
 			return nil
 		},
+		expireMetadata: func(before time.Time) (int, error) {
+			return 0, nil
+		},
 		environConfig: func() (*config.Config, error) {
 			return cfg, nil
 		},
@@ -95,6 +100,7 @@ type mockState struct {
 	findMetadata   func(f cloudimagemetadata.MetadataFilter) (map[string][]cloudimagemetadata.Metadata, error)
 	saveMetadata   func(m []cloudimagemetadata.Metadata) error
 	deleteMetadata func(imageId string) error
+	expireMetadata func(before time.Time) (int, error)
 	environConfig  func() (*config.Config, error)
 	model          func() (imagemetadata.Model, error)
 	controllerTag  func() names.ControllerTag
@@ -115,6 +121,11 @@ func (st *mockState) DeleteMetadata(imageId string) error {
 	return st.deleteMetadata(imageId)
 }
 
+func (st *mockState) ExpireMetadata(before time.Time) (int, error) {
+	st.Stub.MethodCall(st, expireMetadata, before)
+	return st.expireMetadata(before)
+}
+
 func (st *mockState) ModelConfig() (*config.Config, error) {
 	st.Stub.MethodCall(st, environConfig)
 	return st.environConfig()
@@ -4,6 +4,8 @@
 package imagemetadata
 
 import (
+	"time"
+
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
@@ -14,6 +16,7 @@ type metadataAcess interface {
 	FindMetadata(cloudimagemetadata.MetadataFilter) (map[string][]cloudimagemetadata.Metadata, error)
 	SaveMetadata([]cloudimagemetadata.Metadata) error
 	DeleteMetadata(imageId string) error
+	ExpireMetadata(before time.Time) (int, error)
 	Model() (Model, error)
 	ModelConfig() (*config.Config, error)
 	ControllerTag() names.ControllerTag
@@ -43,6 +46,10 @@ func (s stateShim) DeleteMetadata(imageId string) error {
 	return s.State.CloudImageMetadataStorage.DeleteMetadata(imageId)
 }
 
+func (s stateShim) ExpireMetadata(before time.Time) (int, error) {
+	return s.State.CloudImageMetadataStorage.ExpireMetadata(before)
+}
+
 func (s stateShim) Model() (Model, error) {
 	m, err := s.State.Model()
 	if err != nil {
@@ -8,10 +8,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	apiimagemetadata "github.com/juju/juju/apiserver/imagemetadata"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -186,10 +188,13 @@ func mockConfig() testing.Attrs {
 // mockEnviron is an environment without networking support.
 type mockEnviron struct {
 	environs.Environ
+
+	// attrs holds config attributes overriding mockConfig's.
+	attrs testing.Attrs
 }
 
 func (e mockEnviron) Config() *config.Config {
-	cfg, err := config.New(config.NoDefaults, mockConfig())
+	cfg, err := config.New(config.NoDefaults, mockConfig().Merge(e.attrs))
 	if err != nil {
 		panic("invalid configuration for testing")
 	}
@@ -292,6 +297,28 @@ func (s *regionMetadataSuite) TestUpdateFromPublishedImagesForProviderWithRegion
 	s.checkStoredPublished(c)
 }
 
+func (s *regionMetadataSuite) TestUpdateFromPublishedImagesExpiresStale(c *gc.C) {
+	s.setExpectations(c)
+	var expiredBefore time.Time
+	s.state.expireMetadata = func(before time.Time) (int, error) {
+		expiredBefore = before
+		return 1, nil
+	}
+	env := &mockEnviron{attrs: testing.Attrs{"image-metadata-max-age": "24h"}}
+	api, err := apiimagemetadata.CreateAPI(s.state, func() (environs.Environ, error) {
+		return env, nil
+	}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	start := time.Now()
+	err = api.UpdateFromPublishedImages()
+	c.Assert(err, jc.ErrorIsNil)
+	// Stale metadata is expired before the published metadata is saved.
+	s.assertCalls(c, "ControllerTag", expireMetadata, "ControllerTag", environConfig, saveMetadata)
+	c.Assert(expiredBefore.Before(start.Add(-24*time.Hour)), jc.IsFalse)
+	c.Assert(s.saved, jc.SameContents, s.expected)
+}
+
 const (
 	indexContent = `{
     "index": {
@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state"
+)
+
+// lastConnectionFlushInterval is how often the last model connection
+// times held by a lastConnectionRecorder are written to the database.
+const lastConnectionFlushInterval = time.Minute
+
+// lastConnectionUpdater is the part of *state.State used by a
+// lastConnectionRecorder.
+type lastConnectionUpdater interface {
+	UpdateLastModelConnections([]state.ModelConnection) error
+}
+
+// modelUserKey identifies a user's connections to a model.
+type modelUserKey struct {
+	modelUUID string
+	user      string
+}
+
+// lastConnectionRecorder records the times at which users connect to
+// models. A user's first connection to a model within the flush
+// interval is written straight away, so that it can be seen at once;
+// later connections are held in memory and written together by flush,
+// so that clients which connect often do not cause a database write
+// on every login.
+type lastConnectionRecorder struct {
+	st       lastConnectionUpdater
+	clock    clock.Clock
+	interval time.Duration
+
+	// mu guards the fields below it.
+	mu sync.Mutex
+
+	// written holds the most recent time written for each model user.
+	written map[modelUserKey]time.Time
+
+	// pending holds the connections not yet written.
+	pending map[modelUserKey]state.ModelConnection
+}
+
+func newLastConnectionRecorder(st lastConnectionUpdater, clock clock.Clock, interval time.Duration) *lastConnectionRecorder {
+	return &lastConnectionRecorder{
+		st:       st,
+		clock:    clock,
+		interval: interval,
+		written:  make(map[modelUserKey]time.Time),
+		pending:  make(map[modelUserKey]state.ModelConnection),
+	}
+}
+
+// record records that the given user has just connected to the model
+// with the given UUID.
+func (r *lastConnectionRecorder) record(modelUUID string, user names.UserTag) error {
+	conn := state.ModelConnection{
+		ModelUUID: modelUUID,
+		User:      user,
+		Time:      r.clock.Now().Round(time.Second).UTC(),
+	}
+	key := modelUserKey{modelUUID, strings.ToLower(user.Id())}
+
+	r.mu.Lock()
+	last, ok := r.written[key]
+	if ok && conn.Time.Sub(last) < r.interval {
+		r.pending[key] = conn
+		r.mu.Unlock()
+		return nil
+	}
+	r.written[key] = conn.Time
+	delete(r.pending, key)
+	r.mu.Unlock()
+
+	err := r.st.UpdateLastModelConnections([]state.ModelConnection{conn})
+	return errors.Trace(err)
+}
+
+// flush writes all pending connection times to the database.
+func (r *lastConnectionRecorder) flush() error {
+	r.mu.Lock()
+	now := r.clock.Now()
+	for key, when := range r.written {
+		// Forget connections that are too old to delay any
+		// further writes, so that written does not grow
+		// without bound.
+		if _, ok := r.pending[key]; !ok && now.Sub(when) >= r.interval {
+			delete(r.written, key)
+		}
+	}
+	conns := make([]state.ModelConnection, 0, len(r.pending))
+	for key, conn := range r.pending {
+		r.written[key] = conn.Time
+		conns = append(conns, conn)
+	}
+	r.pending = make(map[modelUserKey]state.ModelConnection)
+	r.mu.Unlock()
+
+	if len(conns) == 0 {
+		return nil
+	}
+	err := r.st.UpdateLastModelConnections(conns)
+	return errors.Annotate(err, "updating last model connections")
+}
+
+// loop flushes the recorder every interval until dying is closed,
+// when it flushes once more and returns.
+func (r *lastConnectionRecorder) loop(dying <-chan struct{}) error {
+	for {
+		select {
+		case <-dying:
+			if err := r.flush(); err != nil {
+				logger.Warningf("%v", err)
+			}
+			return tomb.ErrDying
+		case <-r.clock.After(r.interval):
+			// Connection times are informational only, so
+			// failing to write them is not fatal.
+			if err := r.flush(); err != nil {
+				logger.Warningf("%v", err)
+			}
+		}
+	}
+}
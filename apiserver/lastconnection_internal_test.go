@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type lastConnectionSuite struct {
+	testing.IsolationSuite
+
+	clock   *testing.Clock
+	updater *fakeLastConnectionUpdater
+}
+
+var _ = gc.Suite(&lastConnectionSuite{})
+
+var (
+	bobTag   = names.NewUserTag("bob")
+	model1   = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	model2   = "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+	baseTime = time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+func (s *lastConnectionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(baseTime)
+	s.updater = &fakeLastConnectionUpdater{}
+}
+
+func (s *lastConnectionSuite) newRecorder() *lastConnectionRecorder {
+	return newLastConnectionRecorder(s.updater, s.clock, time.Minute)
+}
+
+func (s *lastConnectionSuite) TestFirstConnectionWrittenImmediately(c *gc.C) {
+	r := s.newRecorder()
+	err := r.record(model1, bobTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.record(model2, bobTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.updater.batches, jc.DeepEquals, [][]state.ModelConnection{
+		{{ModelUUID: model1, User: bobTag, Time: baseTime}},
+		{{ModelUUID: model2, User: bobTag, Time: baseTime}},
+	})
+}
+
+func (s *lastConnectionSuite) TestLaterConnectionsBatched(c *gc.C) {
+	r := s.newRecorder()
+	err := r.record(model1, bobTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(10 * time.Second)
+	err = r.record(model1, bobTag)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(10 * time.Second)
+	err = r.record(model1, bobTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.updater.batches, gc.HasLen, 1)
+
+	err = r.flush()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.updater.batches, gc.HasLen, 2)
+	c.Assert(s.updater.batches[1], jc.DeepEquals, []state.ModelConnection{
+		{ModelUUID: model1, User: bobTag, Time: baseTime.Add(20 * time.Second)},
+	})
+
+	// Nothing is pending, so nothing more is written.
+	err = r.flush()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.updater.batches, gc.HasLen, 2)
+}
+
+func (s *lastConnectionSuite) TestConnectionAfterIntervalWrittenImmediately(c *gc.C) {
+	r := s.newRecorder()
+	err := r.record(model1, bobTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(time.Minute)
+	err = r.record(model1, bobTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.updater.batches, jc.DeepEquals, [][]state.ModelConnection{
+		{{ModelUUID: model1, User: bobTag, Time: baseTime}},
+		{{ModelUUID: model1, User: bobTag, Time: baseTime.Add(time.Minute)}},
+	})
+}
+
+func (s *lastConnectionSuite) TestLoopFlushes(c *gc.C) {
+	r := s.newRecorder()
+	err := r.record(model1, bobTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.record(model1, bobTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dying := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- r.loop(dying)
+	}()
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	close(dying)
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for loop to exit")
+	}
+	c.Assert(s.updater.batches, gc.HasLen, 2)
+}
+
+type fakeLastConnectionUpdater struct {
+	batches [][]state.ModelConnection
+}
+
+func (u *fakeLastConnectionUpdater) UpdateLastModelConnections(conns []state.ModelConnection) error {
+	u.batches = append(u.batches, conns)
+	return nil
+}
@@ -20,3 +20,13 @@ type LeadershipService interface {
 	// released for the given service.
 	BlockUntilLeadershipReleased(ApplicationTag names.ApplicationTag) (params.ErrorResult, error)
 }
+
+// LeadershipServiceV3 extends LeadershipService with access to the
+// model's leadership lease settings.
+type LeadershipServiceV3 interface {
+	LeadershipService
+
+	// LeaseSettings returns the leadership lease duration and renewal
+	// interval configured for the model.
+	LeaseSettings() (params.LeadershipLeaseSettings, error)
+}
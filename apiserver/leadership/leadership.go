@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -24,11 +25,11 @@ const (
 
 	// MinLeaseRequest is the shortest duration for which we will accept
 	// a leadership claim.
-	MinLeaseRequest = 5 * time.Second
+	MinLeaseRequest = config.MinLeadershipLeaseDuration
 
 	// MaxLeaseRequest is the longest duration for which we will accept
 	// a leadership claim.
-	MaxLeaseRequest = 5 * time.Minute
+	MaxLeaseRequest = config.MaxLeadershipLeaseDuration
 )
 
 func init() {
@@ -37,6 +38,13 @@ func init() {
 		2,
 		NewLeadershipServiceFacade,
 	)
+
+	// Version 3 adds LeaseSettings.
+	common.RegisterStandardFacade(
+		FacadeName,
+		3,
+		NewLeadershipServiceFacadeV3,
+	)
 }
 
 // NewLeadershipServiceFacade constructs a new LeadershipService and presents
@@ -47,26 +55,79 @@ func NewLeadershipServiceFacade(
 	return NewLeadershipService(state.LeadershipClaimer(), authorizer)
 }
 
+// NewLeadershipServiceFacadeV3 constructs a new LeadershipServiceV3 and
+// presents a signature that can be used with RegisterStandardFacade.
+func NewLeadershipServiceFacadeV3(
+	state *state.State, resources facade.Resources, authorizer facade.Authorizer,
+) (LeadershipServiceV3, error) {
+	return NewLeadershipServiceV3(state.LeadershipClaimer(), state, authorizer)
+}
+
 // NewLeadershipService constructs a new LeadershipService.
 func NewLeadershipService(
 	claimer leadership.Claimer, authorizer facade.Authorizer,
 ) (LeadershipService, error) {
+	service, err := newLeadershipService(claimer, nil, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// ModelConfigGetter provides access to the model's configuration.
+type ModelConfigGetter interface {
+	ModelConfig() (*config.Config, error)
+}
+
+// NewLeadershipServiceV3 constructs a new LeadershipServiceV3, which
+// reads the leadership lease settings from the supplied model config.
+func NewLeadershipServiceV3(
+	claimer leadership.Claimer, configGetter ModelConfigGetter, authorizer facade.Authorizer,
+) (LeadershipServiceV3, error) {
+	if configGetter == nil {
+		return nil, errors.NotValidf("nil ModelConfigGetter")
+	}
+	service, err := newLeadershipService(claimer, configGetter, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+func newLeadershipService(
+	claimer leadership.Claimer, configGetter ModelConfigGetter, authorizer facade.Authorizer,
+) (*leadershipService, error) {
 
 	if !authorizer.AuthUnitAgent() {
 		return nil, errors.Unauthorizedf("permission denied")
 	}
 
 	return &leadershipService{
-		claimer:    claimer,
-		authorizer: authorizer,
+		claimer:      claimer,
+		configGetter: configGetter,
+		authorizer:   authorizer,
 	}, nil
 }
 
-// leadershipService implements the LeadershipService interface and
-// is the concrete implementation of the API endpoint.
+// leadershipService implements the LeadershipService and
+// LeadershipServiceV3 interfaces and is the concrete implementation
+// of the API endpoint.
 type leadershipService struct {
-	claimer    leadership.Claimer
-	authorizer facade.Authorizer
+	claimer      leadership.Claimer
+	configGetter ModelConfigGetter
+	authorizer   facade.Authorizer
+}
+
+// LeaseSettings is part of the LeadershipServiceV3 interface.
+func (m *leadershipService) LeaseSettings() (params.LeadershipLeaseSettings, error) {
+	cfg, err := m.configGetter.ModelConfig()
+	if err != nil {
+		return params.LeadershipLeaseSettings{}, errors.Trace(err)
+	}
+	return params.LeadershipLeaseSettings{
+		LeaseDurationSeconds:   cfg.LeadershipLeaseDuration().Seconds(),
+		RenewalIntervalSeconds: cfg.LeadershipRenewalInterval().Seconds(),
+	}, nil
 }
 
 // ClaimLeadership is part of the LeadershipService interface.
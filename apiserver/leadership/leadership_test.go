@@ -22,6 +22,8 @@ import (
 	"github.com/juju/juju/apiserver/leadership"
 	"github.com/juju/juju/apiserver/params"
 	coreleadership "github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type leadershipSuite struct {
@@ -277,3 +279,53 @@ func (s *leadershipSuite) TestCreateUnauthorized(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "permission denied")
 	c.Check(err, jc.Satisfies, errors.IsUnauthorized)
 }
+
+type stubConfigGetter struct {
+	cfg *config.Config
+	err error
+}
+
+func (m stubConfigGetter) ModelConfig() (*config.Config, error) {
+	return m.cfg, m.err
+}
+
+func (s *leadershipSuite) TestLeaseSettings(c *gc.C) {
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"leadership-lease-duration":   "20s",
+		"leadership-renewal-interval": "5s",
+	})
+	authorizer := stubAuthorizer{tag: names.NewUnitTag(StubUnitNm)}
+	ldrSvc, err := leadership.NewLeadershipServiceV3(nil, stubConfigGetter{cfg: cfg}, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := ldrSvc.LeaseSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings, jc.DeepEquals, params.LeadershipLeaseSettings{
+		LeaseDurationSeconds:   20,
+		RenewalIntervalSeconds: 5,
+	})
+}
+
+func (s *leadershipSuite) TestLeaseSettingsDefaults(c *gc.C) {
+	authorizer := stubAuthorizer{tag: names.NewUnitTag(StubUnitNm)}
+	getter := stubConfigGetter{cfg: coretesting.ModelConfig(c)}
+	ldrSvc, err := leadership.NewLeadershipServiceV3(nil, getter, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := ldrSvc.LeaseSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings, jc.DeepEquals, params.LeadershipLeaseSettings{
+		LeaseDurationSeconds:   60,
+		RenewalIntervalSeconds: 30,
+	})
+}
+
+func (s *leadershipSuite) TestLeaseSettingsError(c *gc.C) {
+	authorizer := stubAuthorizer{tag: names.NewUnitTag(StubUnitNm)}
+	getter := stubConfigGetter{err: errors.New("boom")}
+	ldrSvc, err := leadership.NewLeadershipServiceV3(nil, getter, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ldrSvc.LeaseSettings()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
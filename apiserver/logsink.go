@@ -4,15 +4,19 @@
 package apiserver
 
 import (
+	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"github.com/juju/version"
 	"golang.org/x/net/websocket"
 	"gopkg.in/juju/names.v2"
@@ -23,6 +27,11 @@ import (
 	"github.com/juju/juju/state"
 )
 
+// DefaultLogSinkBufferSize is the number of log messages from each
+// agent that may wait to be written, if ServerConfig.LogSinkBufferSize
+// is not set.
+const DefaultLogSinkBufferSize = 1000
+
 // LoggingStrategy handles the authentication and logging details for
 // a particular logsink handler.
 type LoggingStrategy interface {
@@ -115,8 +124,40 @@ func (s *agentLoggingStrategy) Stop() {
 	s.ctxt.release(s.st)
 }
 
-func newLogSinkHandler(h httpContext, w io.Writer, newStrategy func(httpContext, io.Writer) LoggingStrategy) http.Handler {
-	return &logSinkHandler{ctxt: h, fileLogger: w, newStrategy: newStrategy}
+// logSinkConfig holds the limits applied to the log messages received
+// on each connection to a logsink handler.
+type logSinkConfig struct {
+	// RateLimit is the number of messages per second that may be
+	// written for a connection, on average; further messages are
+	// dropped. If it is zero, there is no limit.
+	RateLimit int
+
+	// RateLimitBurst is the number of messages that may be written
+	// for a connection in a burst faster than RateLimit. If it is
+	// zero, RateLimit is used.
+	RateLimitBurst int
+
+	// BufferSize is the number of received messages that may wait to
+	// be written; when the buffer is full, further messages are
+	// dropped. If it is zero, messages are not dropped: the handler
+	// stops receiving until each message has been written.
+	BufferSize int
+}
+
+func newLogSinkHandler(
+	h httpContext,
+	w io.Writer,
+	newStrategy func(httpContext, io.Writer) LoggingStrategy,
+	config logSinkConfig,
+	clock clock.Clock,
+) http.Handler {
+	return &logSinkHandler{
+		ctxt:        h,
+		fileLogger:  w,
+		newStrategy: newStrategy,
+		config:      config,
+		clock:       clock,
+	}
 }
 
 func newLogSinkWriter(logPath string) (io.WriteCloser, error) {
@@ -148,45 +189,67 @@ type logSinkHandler struct {
 	ctxt        httpContext
 	newStrategy func(httpContext, io.Writer) LoggingStrategy
 	fileLogger  io.Writer
+	config      logSinkConfig
+	clock       clock.Clock
 }
 
 // ServeHTTP implements the http.Handler interface.
 func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	server := websocket.Server{
-		Handler: func(socket *websocket.Conn) {
-			defer socket.Close()
-			strategy := h.newStrategy(h.ctxt, h.fileLogger)
-			err := strategy.Authenticate(req)
-			if err != nil {
-				h.sendError(socket, req, err)
-				return
-			}
-			strategy.Start()
-			defer strategy.Stop()
+	h.ctxt.keepalive().serve(w, req, false, func(socket *websocket.Conn) {
+		defer socket.Close()
+		strategy := h.newStrategy(h.ctxt, h.fileLogger)
+		err := strategy.Authenticate(req)
+		if err != nil {
+			h.sendError(socket, req, err)
+			return
+		}
+		strategy.Start()
+		defer strategy.Stop()
 
-			// If we get to here, no more errors to report, so we report a nil
-			// error.  This way the first line of the socket is always a json
-			// formatted simple error.
-			h.sendError(socket, req, nil)
+		// If we get to here, no more errors to report, so we report a nil
+		// error.  This way the first line of the socket is always a json
+		// formatted simple error.
+		h.sendError(socket, req, nil)
 
-			logCh := h.receiveLogs(socket)
-			for {
-				select {
-				case <-h.ctxt.stop():
+		var dropped droppedCounter
+		defer func() {
+			// Record messages dropped at the end of the stream.
+			if n := dropped.reset(); n > 0 {
+				strategy.Log(h.droppedRecord(n))
+			}
+		}()
+		logCh := h.receiveLogs(socket, &dropped)
+		for {
+			select {
+			case <-h.ctxt.stop():
+				return
+			case m, ok := <-logCh:
+				if !ok {
 					return
-				case m, ok := <-logCh:
-					if !ok {
-						return
-					}
-					success := strategy.Log(m)
-					if !success {
+				}
+				if n := dropped.reset(); n > 0 {
+					if !strategy.Log(h.droppedRecord(n)) {
 						return
 					}
 				}
+				success := strategy.Log(m)
+				if !success {
+					return
+				}
 			}
-		},
+		}
+	})
+}
+
+// droppedRecord returns a log record reporting that n messages were
+// dropped.
+func (h *logSinkHandler) droppedRecord(n int64) params.LogRecord {
+	return params.LogRecord{
+		Time:    h.clock.Now(),
+		Module:  "juju.apiserver.logsink",
+		Level:   loggo.WARNING.String(),
+		Message: fmt.Sprintf("%d log messages dropped: rate limit or buffer exceeded", n),
 	}
-	server.ServeHTTP(w, req)
 }
 
 func jujuClientVersionFromReq(req *http.Request) (version.Number, error) {
@@ -201,8 +264,12 @@ func jujuClientVersionFromReq(req *http.Request) (version.Number, error) {
 	return ver, nil
 }
 
-func (h *logSinkHandler) receiveLogs(socket *websocket.Conn) <-chan params.LogRecord {
-	logCh := make(chan params.LogRecord)
+// receiveLogs returns a channel on which the messages received on the
+// socket are sent. Messages that exceed the handler's rate limit, or
+// that arrive when its buffer is full, are counted in dropped instead.
+func (h *logSinkHandler) receiveLogs(socket *websocket.Conn, dropped *droppedCounter) <-chan params.LogRecord {
+	logCh := make(chan params.LogRecord, h.config.BufferSize)
+	limiter := newLogRateLimiter(h.clock, h.config.RateLimit, h.config.RateLimitBurst)
 
 	go func() {
 		// Close the channel to signal ServeHTTP to finish. Otherwise
@@ -218,6 +285,20 @@ func (h *logSinkHandler) receiveLogs(socket *websocket.Conn) <-chan params.LogRe
 				logger.Debugf("logsink receive error: %v", err)
 				return
 			}
+			if !limiter.allow() {
+				dropped.add()
+				continue
+			}
+			if h.config.BufferSize > 0 {
+				select {
+				case <-h.ctxt.stop():
+					return
+				case logCh <- m:
+				default:
+					dropped.add()
+				}
+				continue
+			}
 
 			// Send the log message.
 			select {
@@ -231,6 +312,65 @@ func (h *logSinkHandler) receiveLogs(socket *websocket.Conn) <-chan params.LogRe
 	return logCh
 }
 
+// droppedCounter counts the log messages dropped on a connection.
+type droppedCounter struct {
+	n int64
+}
+
+func (c *droppedCounter) add() {
+	atomic.AddInt64(&c.n, 1)
+}
+
+// reset returns the number of messages dropped since the last call.
+func (c *droppedCounter) reset() int64 {
+	return atomic.SwapInt64(&c.n, 0)
+}
+
+// logRateLimiter limits the rate at which messages are accepted, using
+// a token bucket that holds up to burst tokens and is refilled at rate
+// tokens per second.
+type logRateLimiter struct {
+	clock  clock.Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newLogRateLimiter returns a limiter that accepts rate messages a
+// second, in bursts of up to burst messages. If rate is zero, it
+// returns nil, which accepts every message.
+func newLogRateLimiter(clock clock.Clock, rate, burst int) *logRateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = rate
+	}
+	return &logRateLimiter{
+		clock:  clock,
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+// allow reports whether another message may be accepted.
+func (l *logRateLimiter) allow() bool {
+	if l == nil {
+		return true
+	}
+	now := l.clock.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
 // sendError sends a JSON-encoded error response.
 func (h *logSinkHandler) sendError(w io.Writer, req *http.Request, err error) {
 	if err != nil {
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type logSinkIntSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&logSinkIntSuite{})
+
+func (s *logSinkIntSuite) TestRateLimiter(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	limiter := newLogRateLimiter(clock, 10, 3)
+
+	// The burst is available at once.
+	for i := 0; i < 3; i++ {
+		c.Assert(limiter.allow(), jc.IsTrue)
+	}
+	c.Assert(limiter.allow(), jc.IsFalse)
+
+	// One more message is allowed every tenth of a second.
+	clock.Advance(100 * time.Millisecond)
+	c.Assert(limiter.allow(), jc.IsTrue)
+	c.Assert(limiter.allow(), jc.IsFalse)
+
+	// The bucket never holds more than the burst.
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		c.Assert(limiter.allow(), jc.IsTrue)
+	}
+	c.Assert(limiter.allow(), jc.IsFalse)
+}
+
+func (s *logSinkIntSuite) TestRateLimiterDefaultBurst(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	limiter := newLogRateLimiter(clock, 2, 0)
+	c.Assert(limiter.allow(), jc.IsTrue)
+	c.Assert(limiter.allow(), jc.IsTrue)
+	c.Assert(limiter.allow(), jc.IsFalse)
+}
+
+func (s *logSinkIntSuite) TestNoRateLimit(c *gc.C) {
+	limiter := newLogRateLimiter(testing.NewClock(time.Time{}), 0, 0)
+	for i := 0; i < 100; i++ {
+		c.Assert(limiter.allow(), jc.IsTrue)
+	}
+}
+
+func (s *logSinkIntSuite) TestDroppedCounter(c *gc.C) {
+	var dropped droppedCounter
+	c.Assert(dropped.reset(), gc.Equals, int64(0))
+	dropped.add()
+	dropped.add()
+	c.Assert(dropped.reset(), gc.Equals, int64(2))
+	c.Assert(dropped.reset(), gc.Equals, int64(0))
+}
+
+func (s *logSinkIntSuite) TestDroppedRecord(c *gc.C) {
+	now := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	h := &logSinkHandler{clock: testing.NewClock(now)}
+	record := h.droppedRecord(42)
+	c.Assert(record.Time, gc.Equals, now)
+	c.Assert(record.Level, gc.Equals, "WARNING")
+	c.Assert(record.Message, gc.Equals, "42 log messages dropped: rate limit or buffer exceeded")
+}
@@ -28,6 +28,7 @@ type closerFunc func() error
 type logStreamEndpointHandler struct {
 	stopCh    <-chan struct{}
 	newSource func(*http.Request) (logStreamSource, closerFunc, error)
+	keepalive *websocketKeepalive
 }
 
 func newLogStreamEndpointHandler(ctxt httpContext) *logStreamEndpointHandler {
@@ -44,6 +45,7 @@ func newLogStreamEndpointHandler(ctxt httpContext) *logStreamEndpointHandler {
 	return &logStreamEndpointHandler{
 		stopCh:    ctxt.stop(),
 		newSource: newSource,
+		keepalive: ctxt.keepalive(),
 	}
 }
 
@@ -54,26 +56,23 @@ func newLogStreamEndpointHandler(ctxt httpContext) *logStreamEndpointHandler {
 //   sink -> string - the name of the the log forwarding target
 func (eph *logStreamEndpointHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger.Infof("log stream request handler starting")
-	server := websocket.Server{
-		Handler: func(conn *websocket.Conn) {
-			defer conn.Close()
-			reqHandler, err := eph.newLogStreamRequestHandler(req, clock.WallClock)
-			if err == nil {
-				defer reqHandler.close()
-			}
+	eph.keepalive.serve(w, req, true, func(conn *websocket.Conn) {
+		defer conn.Close()
+		reqHandler, err := eph.newLogStreamRequestHandler(req, clock.WallClock)
+		if err == nil {
+			defer reqHandler.close()
+		}
 
-			stream, initErr := initStream(conn, err)
-			if initErr != nil {
-				logger.Debugf("failed to send initial error (%v): %v", err, initErr)
-				return
-			}
-			if err != nil {
-				return
-			}
-			reqHandler.serveWebsocket(conn, stream, eph.stopCh)
-		},
-	}
-	server.ServeHTTP(w, req)
+		stream, initErr := initStream(conn, err)
+		if initErr != nil {
+			logger.Debugf("failed to send initial error (%v): %v", err, initErr)
+			return
+		}
+		if err != nil {
+			return
+		}
+		reqHandler.serveWebsocket(conn, stream, eph.stopCh)
+	})
 }
 
 func (eph *logStreamEndpointHandler) newLogStreamRequestHandler(req *http.Request, clock clock.Clock) (rh *logStreamRequestHandler, err error) {
@@ -211,6 +210,7 @@ func (rh *logStreamRequestHandler) serveWebsocket(conn *websocket.Conn, stream *
 				} else {
 					logger.Errorf("logstream handler error: %v", err)
 				}
+				return
 			}
 		}
 	}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/permission"
+)
+
+// ListCloudInstances returns the instances that the cloud runs for
+// the model, with their tags, and the machines they belong to. It
+// also returns the provisioned machines whose instances the cloud
+// does not report.
+func (mm *MachineManagerAPIV5) ListCloudInstances() (params.CloudInstancesResult, error) {
+	return listCloudInstances(mm.MachineManagerAPI, environs.GetEnviron)
+}
+
+func listCloudInstances(mm *MachineManagerAPI, getEnviron environGetFunc) (params.CloudInstancesResult, error) {
+	var result params.CloudInstancesResult
+	canRead, err := mm.authorizer.HasPermission(permission.ReadAccess, mm.st.ModelTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !canRead {
+		return result, common.ErrPerm
+	}
+
+	backend, err := mm.environConfigGetter()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	env, err := getEnviron(backend, environs.New)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	lister, ok := env.(environs.CloudInstanceLister)
+	if !ok {
+		return result, errors.NotSupportedf("listing cloud instances")
+	}
+	cloudInstances, err := lister.CloudInstances()
+	if err != nil {
+		return result, errors.Annotate(err, "listing cloud instances")
+	}
+
+	machines, err := mm.st.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	machineIds := make(map[instance.Id]string)
+	for _, m := range machines {
+		if names.IsContainerMachine(m.Id()) {
+			continue
+		}
+		if manual, err := m.IsManual(); err != nil {
+			return result, errors.Trace(err)
+		} else if manual {
+			continue
+		}
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		machineIds[instId] = m.Id()
+	}
+
+	result.Instances = make([]params.CloudInstance, len(cloudInstances))
+	for i, inst := range cloudInstances {
+		result.Instances[i] = params.CloudInstance{
+			InstanceId: string(inst.Id),
+			Status:     inst.Status,
+			Tags:       inst.Tags,
+			MachineId:  machineIds[inst.Id],
+		}
+		delete(machineIds, inst.Id)
+	}
+	for _, id := range machineIds {
+		result.MissingMachines = append(result.MissingMachines, id)
+	}
+	sort.Strings(result.MissingMachines)
+	return result, nil
+}
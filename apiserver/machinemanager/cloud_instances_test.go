@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type cloudInstancesSuite struct {
+	coretesting.BaseSuite
+	st         *mockState
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&cloudInstancesSuite{})
+
+func (s *cloudInstancesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.st = &mockState{}
+	s.st.allMachines = []*mockMachine{
+		{st: s.st, id: "0", instId: "i-0"},
+		{st: s.st, id: "0/lxd/0", instId: "juju-lxd-0"},
+		{st: s.st, id: "1", instId: "i-1"},
+		{st: s.st, id: "2", instId: "manual:10.0.0.2", manual: true},
+		{st: s.st, id: "3"},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+}
+
+func (s *cloudInstancesSuite) listCloudInstances(env environs.Environ) (params.CloudInstancesResult, error) {
+	api := machinemanager.NewMachineManagerTestingAPI(s.st, s.authorizer)
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	return machinemanager.ListCloudInstances(&api, getEnviron)
+}
+
+func (s *cloudInstancesSuite) TestListCloudInstances(c *gc.C) {
+	env := &mockCloudInstancesEnviron{
+		instances: []environs.CloudInstance{{
+			Id:     "i-0",
+			Status: "running",
+			Tags:   map[string]string{"juju-machine-id": "default-machine-0"},
+		}, {
+			Id:     "i-orphan",
+			Status: "stopped",
+			Tags:   map[string]string{"juju-machine-id": "default-machine-9"},
+		}},
+	}
+	result, err := s.listCloudInstances(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudInstancesResult{
+		Instances: []params.CloudInstance{{
+			InstanceId: "i-0",
+			Status:     "running",
+			Tags:       map[string]string{"juju-machine-id": "default-machine-0"},
+			MachineId:  "0",
+		}, {
+			InstanceId: "i-orphan",
+			Status:     "stopped",
+			Tags:       map[string]string{"juju-machine-id": "default-machine-9"},
+		}},
+		MissingMachines: []string{"1"},
+	})
+}
+
+func (s *cloudInstancesSuite) TestListCloudInstancesNotSupported(c *gc.C) {
+	_, err := s.listCloudInstances(&mockEnviron{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "listing cloud instances not supported")
+}
+
+func (s *cloudInstancesSuite) TestListCloudInstancesError(c *gc.C) {
+	env := &mockCloudInstancesEnviron{}
+	env.SetErrors(errors.New("boom"))
+	_, err := s.listCloudInstances(env)
+	c.Assert(err, gc.ErrorMatches, "listing cloud instances: boom")
+}
+
+func (s *cloudInstancesSuite) TestListCloudInstancesPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.listCloudInstances(&mockCloudInstancesEnviron{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockCloudInstancesEnviron struct {
+	mockEnviron
+	instances []environs.CloudInstance
+}
+
+func (m *mockCloudInstancesEnviron) CloudInstances() ([]environs.CloudInstance, error) {
+	m.MethodCall(m, "CloudInstances")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.instances, nil
+}
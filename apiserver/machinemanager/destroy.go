@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// ForceDestroyMachines force-destroys each of the given machines,
+// removing its containers, units and their storage attachments along
+// with it, and reports what was removed. If DryRun is set, it reports
+// what would be removed without removing anything.
+//
+// As with Client.DestroyMachines, forced destruction is not subject to
+// the remove block.
+func (mm *MachineManagerAPIV6) ForceDestroyMachines(args params.ForceDestroyMachines) (params.DestroyMachineResults, error) {
+	results := params.DestroyMachineResults{
+		Results: make([]params.DestroyMachineResult, len(args.Entities)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(permission.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	for i, entity := range args.Entities {
+		info, err := mm.forceDestroyOneMachine(entity.Tag, args.DryRun)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Info = info
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) forceDestroyOneMachine(tagString string, dryRun bool) (*params.DestroyMachineInfo, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	teardown, err := m.ForceDestroyTeardown()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !dryRun {
+		if err := m.ForceDestroy(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return destroyMachineInfo(teardown), nil
+}
+
+func destroyMachineInfo(teardown state.MachineTeardown) *params.DestroyMachineInfo {
+	var info params.DestroyMachineInfo
+	for _, id := range teardown.Containers {
+		info.DestroyedContainers = append(info.DestroyedContainers, params.Entity{
+			Tag: names.NewMachineTag(id).String(),
+		})
+	}
+	for _, name := range teardown.Units {
+		info.DestroyedUnits = append(info.DestroyedUnits, params.Entity{
+			Tag: names.NewUnitTag(name).String(),
+		})
+	}
+	for _, attachment := range teardown.StorageAttachments {
+		info.DestroyedStorageAttachments = append(info.DestroyedStorageAttachments, params.StorageAttachmentId{
+			StorageTag: attachment.StorageInstance().String(),
+			UnitTag:    attachment.Unit().String(),
+		})
+	}
+	return &info
+}
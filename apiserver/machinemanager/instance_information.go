@@ -22,13 +22,12 @@ func (mm *MachineManagerAPI) InstanceTypes(cons params.ModelInstanceTypesConstra
 
 type environGetFunc func(st environs.EnvironConfigGetter, newEnviron environs.NewEnvironFunc) (environs.Environ, error)
 
-func instanceTypes(mm *MachineManagerAPI,
-	getEnviron environGetFunc,
-	cons params.ModelInstanceTypesConstraints,
-) (params.InstanceTypesResults, error) {
+// environConfigGetter returns an environs.EnvironConfigGetter for
+// the facade's model.
+func (mm *MachineManagerAPI) environConfigGetter() (environs.EnvironConfigGetter, error) {
 	model, err := mm.st.GetModel(mm.st.ModelTag())
 	if err != nil {
-		return params.InstanceTypesResults{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	cloudSpec := func(tag names.ModelTag) (environs.CloudSpec, error) {
@@ -37,9 +36,19 @@ func instanceTypes(mm *MachineManagerAPI,
 		credentialTag, _ := model.CloudCredential()
 		return stateenvirons.CloudSpec(mm.st, cloudName, regionName, credentialTag)
 	}
-	backend := common.EnvironConfigGetterFuncs{
+	return common.EnvironConfigGetterFuncs{
 		CloudSpecFunc:   cloudSpec,
 		ModelConfigFunc: model.Config,
+	}, nil
+}
+
+func instanceTypes(mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	cons params.ModelInstanceTypesConstraints,
+) (params.InstanceTypesResults, error) {
+	backend, err := mm.environConfigGetter()
+	if err != nil {
+		return params.InstanceTypesResults{}, errors.Trace(err)
 	}
 
 	env, err := getEnviron(backend, environs.New)
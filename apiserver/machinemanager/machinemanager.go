@@ -7,10 +7,12 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/permission"
@@ -19,6 +21,19 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("MachineManager", 2, NewMachineManagerAPI)
+
+	// Version 3 adds cloud-init user-data to AddMachines.
+	common.RegisterStandardFacade("MachineManager", 3, NewMachineManagerAPI)
+
+	// Version 4 adds ScheduleReboot.
+	common.RegisterStandardFacade("MachineManager", 4, NewMachineManagerAPIV4)
+
+	// Version 5 adds ListCloudInstances.
+	common.RegisterStandardFacade("MachineManager", 5, NewMachineManagerAPIV5)
+
+	// Version 6 adds ForceDestroyMachines, which reports what is
+	// removed along with each machine.
+	common.RegisterStandardFacade("MachineManager", 6, NewMachineManagerAPIV6)
 }
 
 // MachineManagerAPI provides access to the MachineManager API facade.
@@ -51,6 +66,66 @@ func NewMachineManagerAPI(
 	}, nil
 }
 
+// MachineManagerAPIV4 provides access to version 4 of the
+// MachineManager API facade.
+type MachineManagerAPIV4 struct {
+	*MachineManagerAPI
+}
+
+// NewMachineManagerAPIV4 creates a new server-side MachineManager
+// API facade, version 4.
+func NewMachineManagerAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*MachineManagerAPIV4, error) {
+	api, err := NewMachineManagerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV4{api}, nil
+}
+
+// MachineManagerAPIV5 provides access to version 5 of the
+// MachineManager API facade.
+type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV4
+}
+
+// NewMachineManagerAPIV5 creates a new server-side MachineManager
+// API facade, version 5.
+func NewMachineManagerAPIV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*MachineManagerAPIV5, error) {
+	api, err := NewMachineManagerAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{api}, nil
+}
+
+// MachineManagerAPIV6 provides access to version 6 of the
+// MachineManager API facade.
+type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV5
+}
+
+// NewMachineManagerAPIV6 creates a new server-side MachineManager
+// API facade, version 6.
+func NewMachineManagerAPIV6(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*MachineManagerAPIV6, error) {
+	api, err := NewMachineManagerAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{api}, nil
+}
+
 // AddMachines adds new machines with the supplied parameters.
 func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMachinesResults, error) {
 	results := params.AddMachinesResults{
@@ -78,6 +153,43 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	return results, nil
 }
 
+// ScheduleReboot requests that each of the given machines reboot
+// during the model's maintenance window. Containers hosted on the
+// machines are shut down before they reboot.
+func (mm *MachineManagerAPIV4) ScheduleReboot(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(permission.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		results.Results[i].Error = common.ServerError(mm.scheduleOneReboot(entity.Tag))
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) scheduleOneReboot(tagString string) error {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.ScheduleReboot()
+}
+
 func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Machine, error) {
 	if p.ParentId != "" && p.ContainerType == "" {
 		return nil, fmt.Errorf("parent machine specified without container type")
@@ -145,6 +257,12 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		}
 	}
 
+	if p.CloudInitUserData != "" {
+		if _, err := cloudinit.ParseUserData(p.CloudInitUserData); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	jobs, err := common.StateJobs(p.Jobs)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -159,6 +277,15 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		HardwareCharacteristics: p.HardwareCharacteristics,
 		Addresses:               params.NetworkAddresses(p.Addrs...),
 		Placement:               placementDirective,
+		CloudInitUserData:       p.CloudInitUserData,
+	}
+	// A container placed on a new machine adds two machines.
+	newMachines := 1
+	if p.ContainerType != "" && p.ParentId == "" {
+		newMachines = 2
+	}
+	if err := mm.st.CheckQuotas(state.QuotaUsage{Machines: newMachines}); err != nil {
+		return nil, errors.Trace(err)
 	}
 	if p.ContainerType == "" {
 		return mm.st.AddOneMachine(template)
@@ -166,5 +293,8 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 	if p.ParentId != "" {
 		return mm.st.AddMachineInsideMachine(template, p.ParentId, p.ContainerType)
 	}
-	return mm.st.AddMachineInsideNewMachine(template, template, p.ContainerType)
+	// The user-data is for the container, not the machine hosting it.
+	parentTemplate := template
+	parentTemplate.CloudInitUserData = ""
+	return mm.st.AddMachineInsideNewMachine(template, parentTemplate, p.ContainerType)
 }
@@ -6,6 +6,7 @@ package machinemanager_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -96,6 +97,54 @@ func (s *MachineManagerSuite) TestAddMachines(c *gc.C) {
 	})
 }
 
+func (s *MachineManagerSuite) TestAddMachinesQuotaExceeded(c *gc.C) {
+	s.st.quotaErr = &state.QuotaExceededError{Quota: "max-machines", Limit: 3}
+	machines, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series: "trusty",
+			Jobs:   []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines.Machines, gc.HasLen, 1)
+	c.Assert(machines.Machines[0].Error, gc.ErrorMatches, `quota "max-machines" exceeded \(limit 3\)`)
+	c.Assert(machines.Machines[0].Error, jc.Satisfies, params.IsCodeQuotaExceeded)
+	c.Assert(s.st.calls, gc.Equals, 0)
+}
+
+func (s *MachineManagerSuite) TestAddMachinesWithCloudInitUserData(c *gc.C) {
+	machines, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:            "trusty",
+			Jobs:              []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+			CloudInitUserData: "packages:\n- htop\n",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines.Machines, gc.HasLen, 1)
+	c.Assert(s.st.calls, gc.Equals, 1)
+	c.Assert(s.st.machines, jc.DeepEquals, []state.MachineTemplate{{
+		Series:            "trusty",
+		Jobs:              []state.MachineJob{state.JobHostUnits},
+		Volumes:           []state.MachineVolumeParams{},
+		CloudInitUserData: "packages:\n- htop\n",
+	}})
+}
+
+func (s *MachineManagerSuite) TestAddMachinesInvalidCloudInitUserData(c *gc.C) {
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:            "trusty",
+			Jobs:              []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+			CloudInitUserData: "users:\n- bob\n",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 1)
+	c.Assert(results.Machines[0].Error, gc.ErrorMatches, `cloud-init user-data key "users" not supported`)
+	c.Assert(s.st.calls, gc.Equals, 0)
+}
+
 func (s *MachineManagerSuite) TestNewMachineManagerAPINonClient(c *gc.C) {
 	tag := names.NewUnitTag("mysql/0")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
@@ -119,10 +168,139 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestScheduleReboot(c *gc.C) {
+	api := &machinemanager.MachineManagerAPIV4{s.api}
+	results, err := api.ScheduleReboot(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-0-lxd-1"},
+			{Tag: "machine-42"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Message: "machine 42 not found"}},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.rebootsScheduled, jc.DeepEquals, []string{"0", "0/lxd/1"})
+}
+
+func (s *MachineManagerSuite) TestScheduleRebootPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api, err := machinemanager.NewMachineManagerAPIV4(nil, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ScheduleReboot(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.rebootsScheduled, gc.HasLen, 0)
+}
+
+func (s *MachineManagerSuite) TestScheduleRebootNotInV3(c *gc.C) {
+	_, ok := interface{}(s.api).(interface {
+		ScheduleReboot(params.Entities) (params.ErrorResults, error)
+	})
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *MachineManagerSuite) forceDestroyAPI(c *gc.C) *machinemanager.MachineManagerAPIV6 {
+	api, err := machinemanager.NewMachineManagerAPIV6(nil, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *MachineManagerSuite) setUpTeardown() {
+	s.st.teardowns = map[string]state.MachineTeardown{
+		"1": {
+			Containers: []string{"1/lxd/0"},
+			Units:      []string{"logging/0", "mysql/0"},
+			StorageAttachments: []state.StorageAttachment{
+				&mockStorageAttachment{
+					storage: names.NewStorageTag("data/0"),
+					unit:    names.NewUnitTag("mysql/0"),
+				},
+			},
+		},
+	}
+}
+
+var expectedTeardownInfo = &params.DestroyMachineInfo{
+	DestroyedContainers: []params.Entity{{Tag: "machine-1-lxd-0"}},
+	DestroyedUnits:      []params.Entity{{Tag: "unit-logging-0"}, {Tag: "unit-mysql-0"}},
+	DestroyedStorageAttachments: []params.StorageAttachmentId{{
+		StorageTag: "storage-data-0",
+		UnitTag:    "unit-mysql-0",
+	}},
+}
+
+func (s *MachineManagerSuite) TestForceDestroyMachines(c *gc.C) {
+	s.setUpTeardown()
+	results, err := s.forceDestroyAPI(c).ForceDestroyMachines(params.ForceDestroyMachines{
+		Entities: []params.Entity{
+			{Tag: "machine-1"},
+			{Tag: "machine-2"},
+			{Tag: "machine-42"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.DestroyMachineResults{
+		Results: []params.DestroyMachineResult{
+			{Info: expectedTeardownInfo},
+			{Info: &params.DestroyMachineInfo{}},
+			{Error: &params.Error{Message: "machine 42 not found"}},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.forceDestroyed, jc.DeepEquals, []string{"1", "2"})
+}
+
+func (s *MachineManagerSuite) TestForceDestroyMachinesDryRun(c *gc.C) {
+	s.setUpTeardown()
+	results, err := s.forceDestroyAPI(c).ForceDestroyMachines(params.ForceDestroyMachines{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+		DryRun:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.DestroyMachineResults{
+		Results: []params.DestroyMachineResult{{Info: expectedTeardownInfo}},
+	})
+	c.Assert(s.st.forceDestroyed, gc.HasLen, 0)
+}
+
+func (s *MachineManagerSuite) TestForceDestroyMachinesPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.forceDestroyAPI(c).ForceDestroyMachines(params.ForceDestroyMachines{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.forceDestroyed, gc.HasLen, 0)
+}
+
+func (s *MachineManagerSuite) TestForceDestroyMachinesNotInV5(c *gc.C) {
+	api, err := machinemanager.NewMachineManagerAPIV5(nil, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := interface{}(api).(interface {
+		ForceDestroyMachines(params.ForceDestroyMachines) (params.DestroyMachineResults, error)
+	})
+	c.Assert(ok, jc.IsFalse)
+}
+
 type mockState struct {
 	calls    int
 	machines []state.MachineTemplate
 	err      error
+	quotaErr error
+
+	rebootsScheduled []string
+	allMachines      []*mockMachine
+	teardowns        map[string]state.MachineTeardown
+	forceDestroyed   []string
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -132,6 +310,10 @@ func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machi
 	return &m, st.err
 }
 
+func (st *mockState) CheckQuotas(add state.QuotaUsage) error {
+	return st.quotaErr
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return &mockBlock{}, false, nil
 }
@@ -176,6 +358,71 @@ func (st *mockState) GetModel(t names.ModelTag) (machinemanager.Model, error) {
 	return &mockModel{}, nil
 }
 
+func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
+	if id == "42" {
+		return nil, errors.New("machine 42 not found")
+	}
+	return &mockMachine{st: st, id: id}, nil
+}
+
+func (st *mockState) AllMachines() ([]machinemanager.Machine, error) {
+	machines := make([]machinemanager.Machine, len(st.allMachines))
+	for i, m := range st.allMachines {
+		machines[i] = m
+	}
+	return machines, nil
+}
+
+type mockMachine struct {
+	st     *mockState
+	id     string
+	instId instance.Id
+	manual bool
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instId == "" {
+		return "", jujuerrors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instId, nil
+}
+
+func (m *mockMachine) IsManual() (bool, error) {
+	return m.manual, nil
+}
+
+func (m *mockMachine) ScheduleReboot() error {
+	m.st.rebootsScheduled = append(m.st.rebootsScheduled, m.id)
+	return nil
+}
+
+func (m *mockMachine) ForceDestroy() error {
+	m.st.forceDestroyed = append(m.st.forceDestroyed, m.id)
+	return nil
+}
+
+func (m *mockMachine) ForceDestroyTeardown() (state.MachineTeardown, error) {
+	return m.st.teardowns[m.id], nil
+}
+
+type mockStorageAttachment struct {
+	state.StorageAttachment
+	storage names.StorageTag
+	unit    names.UnitTag
+}
+
+func (a *mockStorageAttachment) StorageInstance() names.StorageTag {
+	return a.storage
+}
+
+func (a *mockStorageAttachment) Unit() names.UnitTag {
+	return a.unit
+}
+
 type mockBlock struct {
 	state.Block
 }
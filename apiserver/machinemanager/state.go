@@ -19,7 +19,10 @@ type stateInterface interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	CheckQuotas(add state.QuotaUsage) error
 
+	Machine(string) (Machine, error)
+	AllMachines() ([]Machine, error)
 	GetModel(names.ModelTag) (Model, error)
 	Cloud(string) (cloud.Cloud, error)
 	Clouds() (map[names.CloudTag]cloud.Cloud, error)
@@ -58,6 +61,26 @@ func (s stateShim) AddMachineInsideMachine(template state.MachineTemplate, paren
 	return s.State.AddMachineInsideMachine(template, parentId, containerType)
 }
 
+func (s stateShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s stateShim) AllMachines() ([]Machine, error) {
+	all, err := s.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	machines := make([]Machine, len(all))
+	for i, m := range all {
+		machines[i] = m
+	}
+	return machines, nil
+}
+
 func (s stateShim) GetModel(tag names.ModelTag) (Model, error) {
 	m, err := s.State.GetModel(tag)
 	if err != nil {
@@ -74,3 +97,12 @@ type Model interface {
 
 	Config() (*config.Config, error)
 }
+
+type Machine interface {
+	Id() string
+	InstanceId() (instance.Id, error)
+	IsManual() (bool, error)
+	ScheduleReboot() error
+	ForceDestroy() error
+	ForceDestroyTeardown() (state.MachineTeardown, error)
+}
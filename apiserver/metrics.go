@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+)
+
+// metricsHandler serves the controller's Prometheus metrics, which
+// describe the models, machines and units it manages, so that
+// operators can monitor them without scraping "juju status". The
+// metrics are served only while the controller's
+// metrics-endpoint-enabled config attribute is true; like the
+// metrics of other Prometheus exporters, they require no
+// authentication.
+type metricsHandler struct {
+	// enabled reports whether the endpoint is enabled.
+	enabled func() (bool, error)
+
+	// handler serves the metrics.
+	handler http.Handler
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		if err := sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method)); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	enabled, err := h.enabled()
+	if err != nil {
+		if err := sendError(w, errors.Annotate(err, "cannot get controller config")); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	if !enabled {
+		if err := sendError(w, errors.NotFoundf("metrics endpoint")); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	h.handler.ServeHTTP(w, req)
+}
+
+// metricsEndpointEnabled reports whether the controller config
+// enables the /metrics endpoint. The config is read for each request
+// so that the endpoint can be enabled and disabled without restarting
+// the API server.
+func (srv *Server) metricsEndpointEnabled() (bool, error) {
+	cfg, err := srv.state.ControllerConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cfg.MetricsEndpointEnabled(), nil
+}
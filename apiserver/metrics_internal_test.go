@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type metricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) serve(c *gc.C, method string, enabled bool, enabledErr error) *httptest.ResponseRecorder {
+	handler := &metricsHandler{
+		enabled: func() (bool, error) {
+			return enabled, enabledErr
+		},
+		handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte("juju_state_models 1\n"))
+		}),
+	}
+	req, err := http.NewRequest(method, "/metrics", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *metricsSuite) assertError(c *gc.C, recorder *httptest.ResponseRecorder, code int, message string) {
+	c.Assert(recorder.Code, gc.Equals, code)
+	var result params.ErrorResult
+	err := json.Unmarshal(recorder.Body.Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error.Message, gc.Equals, message)
+}
+
+func (s *metricsSuite) TestEnabled(c *gc.C) {
+	recorder := s.serve(c, "GET", true, nil)
+	c.Assert(recorder.Code, gc.Equals, http.StatusOK)
+	c.Assert(recorder.Body.String(), gc.Equals, "juju_state_models 1\n")
+}
+
+func (s *metricsSuite) TestDisabled(c *gc.C) {
+	recorder := s.serve(c, "GET", false, nil)
+	s.assertError(c, recorder, http.StatusNotFound, "metrics endpoint not found")
+}
+
+func (s *metricsSuite) TestConfigError(c *gc.C) {
+	recorder := s.serve(c, "GET", true, errors.New("boom"))
+	s.assertError(c, recorder, http.StatusInternalServerError, "cannot get controller config: boom")
+}
+
+func (s *metricsSuite) TestMethodNotAllowed(c *gc.C) {
+	recorder := s.serve(c, "POST", true, nil)
+	s.assertError(c, recorder, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
@@ -64,6 +64,23 @@ func (c *ModelConfigAPI) isAdmin() error {
 	return nil
 }
 
+// checkQuotaChange returns an error if any of the given keys is a
+// model quota and the user is not a controller administrator, so
+// that model users cannot raise their own quotas.
+func (c *ModelConfigAPI) checkQuotaChange(keys []string) error {
+	for _, key := range keys {
+		for _, quotaKey := range config.QuotaKeys {
+			if key != quotaKey {
+				continue
+			}
+			if err := c.isAdmin(); err != nil {
+				return errors.Annotatef(err, "changing %s", key)
+			}
+		}
+	}
+	return nil
+}
+
 // ModelGet implements the server-side part of the
 // model-config CLI command.
 func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
@@ -103,6 +120,13 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	keys := make([]string, 0, len(args.Config))
+	for key := range args.Config {
+		keys = append(keys, key)
+	}
+	if err := c.checkQuotaChange(keys); err != nil {
+		return errors.Trace(err)
+	}
 	// Make sure we don't allow changing agent-version.
 	checkAgentVersion := func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
 		if v, found := updateAttrs["agent-version"]; found {
@@ -127,5 +151,8 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := c.checkQuotaChange(args.Keys); err != nil {
+		return errors.Trace(err)
+	}
 	return c.backend.UpdateModelConfig(nil, args.Keys, nil)
 }
@@ -126,6 +126,36 @@ func (s *modelconfigSuite) TestModelSetCannotChangeAgentVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelSetQuota(c *gc.C) {
+	err := s.api.ModelSet(params.ModelSet{
+		map[string]interface{}{"max-units": 10},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValue(c, "max-units", 10)
+}
+
+func (s *modelconfigSuite) TestModelSetQuotaNotAdmin(c *gc.C) {
+	user := names.NewUserTag("charlie")
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:         user,
+		HasWriteTag: user,
+	}
+	err := s.api.ModelSet(params.ModelSet{
+		map[string]interface{}{"max-units": 100},
+	})
+	c.Assert(err, gc.ErrorMatches, "changing max-units: permission denied")
+	s.assertConfigValueMissing(c, "max-units")
+
+	err = s.api.ModelUnset(params.ModelUnset{[]string{"max-machines"}})
+	c.Assert(err, gc.ErrorMatches, "changing max-machines: permission denied")
+
+	// Other attributes may still be changed.
+	err = s.api.ModelSet(params.ModelSet{
+		map[string]interface{}{"some-key": "value"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
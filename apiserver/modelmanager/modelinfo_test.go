@@ -368,6 +368,7 @@ type mockState struct {
 	blockMsg        string
 	block           state.BlockType
 	migration       *mockMigration
+	templates       []state.ModelTemplate
 }
 
 type fakeModelDescription struct {
@@ -589,6 +590,31 @@ func (st *mockState) UpdateModelConfigDefaultValues(update map[string]interface{
 	return nil
 }
 
+func (st *mockState) ModelTemplate(name string) (state.ModelTemplate, error) {
+	st.MethodCall(st, "ModelTemplate", name)
+	for _, tmpl := range st.templates {
+		if tmpl.Name == name {
+			return tmpl, st.NextErr()
+		}
+	}
+	return state.ModelTemplate{}, errors.NotFoundf("model template %q", name)
+}
+
+func (st *mockState) AllModelTemplates() ([]state.ModelTemplate, error) {
+	st.MethodCall(st, "AllModelTemplates")
+	return st.templates, st.NextErr()
+}
+
+func (st *mockState) SetModelTemplate(tmpl state.ModelTemplate) error {
+	st.MethodCall(st, "SetModelTemplate", tmpl)
+	return st.NextErr()
+}
+
+func (st *mockState) RemoveModelTemplate(name string) error {
+	st.MethodCall(st, "RemoveModelTemplate", name)
+	return st.NextErr()
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	st.MethodCall(st, "GetBlockForType", t)
 	if st.block == t {
@@ -755,6 +781,11 @@ func (m *mockModel) Destroy() error {
 	return m.NextErr()
 }
 
+func (m *mockModel) DestroyWithParams(args state.DestroyModelParams) error {
+	m.MethodCall(m, "DestroyWithParams", args)
+	return m.NextErr()
+}
+
 func (m *mockModel) DestroyIncludingHosted() error {
 	m.MethodCall(m, "DestroyIncludingHosted")
 	return m.NextErr()
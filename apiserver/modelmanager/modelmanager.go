@@ -38,6 +38,15 @@ var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
 func init() {
 	common.RegisterStandardFacade("ModelManager", 2, newFacade)
+
+	// Version 3 adds the storage disposition to DestroyModels.
+	common.RegisterStandardFacade("ModelManager", 3, newFacadeV3)
+
+	// Version 4 adds the operator model access level.
+	common.RegisterStandardFacade("ModelManager", 4, newFacadeV3)
+
+	// Version 5 adds model templates.
+	common.RegisterStandardFacade("ModelManager", 5, newFacadeV5)
 }
 
 // ModelManager defines the methods on the modelmanager API endpoint.
@@ -68,6 +77,34 @@ func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Mo
 	return NewModelManagerAPI(common.NewModelManagerBackend(st), configGetter, auth)
 }
 
+func newFacadeV3(st *state.State, resources facade.Resources, auth facade.Authorizer) (*ModelManagerAPIV3, error) {
+	api, err := newFacade(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelManagerAPIV3{api}, nil
+}
+
+// ModelManagerAPIV3 provides access to version 3 of the
+// ModelManager API facade.
+type ModelManagerAPIV3 struct {
+	*ModelManagerAPI
+}
+
+// NewModelManagerAPIV3 creates a new server-side ModelManager
+// API facade, version 3.
+func NewModelManagerAPIV3(
+	st common.ModelManagerBackend,
+	configGetter environs.EnvironConfigGetter,
+	authorizer facade.Authorizer,
+) (*ModelManagerAPIV3, error) {
+	api, err := NewModelManagerAPI(st, configGetter, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelManagerAPIV3{api}, nil
+}
+
 // NewModelManagerAPI creates a new api server endpoint for managing
 // models.
 func NewModelManagerAPI(
@@ -179,7 +216,8 @@ func (m *ModelManagerAPI) newModelConfig(
 }
 
 // CreateModel creates a new model using the account and
-// model config specified in the args.
+// model config specified in the args, and the config and
+// constraints of the model template named in the args, if any.
 func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error) {
 	result := params.ModelInfo{}
 	canAddModel, err := m.authorizer.HasPermission(permission.AddModelAccess, m.state.ControllerTag())
@@ -201,6 +239,25 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 		return result, errors.Annotatef(common.ErrPerm, "%q permission does not permit creation of models for different owners", permission.AddModelAccess)
 	}
 
+	// Model quotas are set by controller administrators, so that
+	// users cannot lift the limits on the models they create.
+	if !m.isAdmin {
+		for _, key := range config.QuotaKeys {
+			if _, ok := args.Config[key]; ok {
+				return result, errors.Annotatef(common.ErrPerm, "setting %s", key)
+			}
+		}
+	}
+
+	var template state.ModelTemplate
+	if args.Template != "" {
+		template, err = m.state.ModelTemplate(args.Template)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		args.Config = applyTemplateConfig(template.Config, args.Config)
+	}
+
 	// Get the controller model first. We need it both for the state
 	// server owner and the ability to get the config.
 	controllerModel, err := m.state.ControllerModel()
@@ -321,6 +378,7 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 		CloudRegion:     cloudRegionName,
 		CloudCredential: cloudCredentialTag,
 		Config:          newConfig,
+		Constraints:     template.Constraints,
 		Owner:           ownerTag,
 		StorageProviderRegistry: storageProviderRegistry,
 	})
@@ -494,25 +552,37 @@ func (m *ModelManagerAPI) DestroyModels(args params.Entities) (params.ErrorResul
 	results := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
-
-	destroyModel := func(tag names.ModelTag) error {
-		model, err := m.state.GetModel(tag)
+	for i, arg := range args.Entities {
+		tag, err := names.ParseModelTag(arg.Tag)
 		if err != nil {
-			return errors.Trace(err)
+			results.Results[i].Error = common.ServerError(err)
+			continue
 		}
-		if err := m.authCheck(model.Owner()); err != nil {
-			return errors.Trace(err)
+		if err := m.destroyModel(tag, state.DestroyModelParams{}); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
 		}
-		return errors.Trace(common.DestroyModel(m.state, model.ModelTag()))
 	}
+	return results, nil
+}
 
-	for i, arg := range args.Entities {
-		tag, err := names.ParseModelTag(arg.Tag)
+// DestroyModels will try to destroy the specified models, destroying
+// or releasing their storage as requested. If there is a block on
+// destruction, this method will return an error.
+func (m *ModelManagerAPIV3) DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Models)),
+	}
+	for i, arg := range args.Models {
+		tag, err := names.ParseModelTag(arg.ModelTag)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		if err := destroyModel(tag); err != nil {
+		destroyArgs := state.DestroyModelParams{
+			ReleaseStorage: arg.DestroyStorage != nil && !*arg.DestroyStorage,
+		}
+		if err := m.destroyModel(tag, destroyArgs); err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
@@ -520,6 +590,17 @@ func (m *ModelManagerAPI) DestroyModels(args params.Entities) (params.ErrorResul
 	return results, nil
 }
 
+func (m *ModelManagerAPI) destroyModel(tag names.ModelTag, args state.DestroyModelParams) error {
+	model, err := m.state.GetModel(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.authCheck(model.Owner()); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(common.DestroyModelWithParams(m.state, model.ModelTag(), args))
+}
+
 // ModelInfo returns information about the specified models.
 func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
 	results := params.ModelInfoResults{
@@ -770,8 +851,8 @@ func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 			// Revoking read access removes all access.
 			err := st.RemoveUserAccess(targetUserTag, modelTag)
 			return errors.Annotate(err, "could not revoke model access")
-		case permission.WriteAccess:
-			// Revoking write access sets read-only.
+		case permission.OperatorAccess, permission.WriteAccess:
+			// Revoking operator or write access sets read-only.
 			modelUser, err := st.UserAccess(targetUserTag, modelTag)
 			if err != nil {
 				return errors.Annotate(err, "could not look up model access for user")
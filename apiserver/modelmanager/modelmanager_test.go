@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -333,6 +334,53 @@ func (s *modelManagerSuite) TestCreateModelUnknownCredential(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `getting credential: credential not found`)
 }
 
+func (s *modelManagerSuite) TestCreateModelWithTemplate(c *gc.C) {
+	s.st.templates = []state.ModelTemplate{{
+		Name: "prod",
+		Config: map[string]interface{}{
+			"bar":       "template",
+			"templated": "yes",
+		},
+		Constraints: constraints.MustParse("mem=4G spaces=dmz"),
+	}}
+	args := params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-admin",
+		Config: map[string]interface{}{
+			"bar": "baz",
+		},
+		Template: "prod",
+	}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModelArgs := s.getModelArgs(c)
+	attrs := newModelArgs.Config.AllAttrs()
+	c.Assert(attrs["bar"], gc.Equals, "baz")
+	c.Assert(attrs["templated"], gc.Equals, "yes")
+	c.Assert(newModelArgs.Constraints, jc.DeepEquals, constraints.MustParse("mem=4G spaces=dmz"))
+}
+
+func (s *modelManagerSuite) TestCreateModelTemplateNotFound(c *gc.C) {
+	args := params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-admin",
+		Template: "prod",
+	}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, `model template "prod" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *modelManagerSuite) TestCreateModelQuotaNotAdmin(c *gc.C) {
+	user := names.NewUserTag("add-model")
+	s.setAPIUser(c, user)
+	args := createArgs(user)
+	args.Config["max-units"] = 0
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, "setting max-units: permission denied")
+}
+
 func (s *modelManagerSuite) TestModelDefaults(c *gc.C) {
 	result, err := s.api.ModelDefaults()
 	c.Assert(err, jc.ErrorIsNil)
@@ -931,6 +979,37 @@ func (s *modelManagerStateSuite) TestDestroyOwnModel(c *gc.C) {
 	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
 }
 
+func (s *modelManagerStateSuite) TestDestroyModelReleaseStorage(c *gc.C) {
+	owner := names.NewUserTag("admin")
+	s.setAPIUser(c, owner)
+	m, err := s.modelmanager.CreateModel(createArgs(owner))
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := s.State.ForModel(names.NewModelTag(m.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	api, err := modelmanager.NewModelManagerAPIV3(
+		common.NewModelManagerBackend(st), nil, s.authoriser,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	destroyStorage := false
+	results, err := api.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag:       "model-" + m.UUID,
+			DestroyStorage: &destroyStorage,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
+	c.Assert(model.ReleaseStorage(), jc.IsTrue)
+}
+
 func (s *modelManagerStateSuite) TestAdminDestroysOtherModel(c *gc.C) {
 	// TODO(perrito666) Both users are admins in this case, this tesst is of dubious
 	// usefulness until proper controller permissions are in place.
@@ -1067,6 +1146,18 @@ func (s *modelManagerStateSuite) TestRevokeAdminLeavesReadAccess(c *gc.C) {
 	c.Assert(modelUser.Access, gc.Equals, permission.ReadAccess)
 }
 
+func (s *modelManagerStateSuite) TestRevokeOperatorLeavesReadAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: permission.OperatorAccess})
+
+	err := s.revoke(c, user.UserTag, params.ModelOperatorAccess, user.Object.(names.ModelTag))
+	c.Assert(err, gc.IsNil)
+
+	modelUser, err := s.State.UserAccess(user.UserTag, user.Object)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access, gc.Equals, permission.ReadAccess)
+}
+
 func (s *modelManagerStateSuite) TestRevokeReadRemovesModelUser(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	user := s.Factory.MakeModelUser(c, nil)
@@ -1187,6 +1278,24 @@ func (s *modelManagerStateSuite) TestGrantModelIncreaseAccess(c *gc.C) {
 	c.Assert(modelUser.Access, gc.Equals, permission.WriteAccess)
 }
 
+func (s *modelManagerStateSuite) TestGrantModelOperatorAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	stFactory := factory.NewFactory(st)
+	user := stFactory.MakeModelUser(c, &factory.ModelUserParams{Access: permission.ReadAccess})
+
+	err := s.grant(c, user.UserTag, params.ModelOperatorAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := st.UserAccess(user.UserTag, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access, gc.Equals, permission.OperatorAccess)
+
+	err = s.grant(c, user.UserTag, params.ModelOperatorAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `user already has "operator" access or greater`)
+}
+
 func (s *modelManagerStateSuite) TestGrantToModelNoAccess(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	st := s.Factory.MakeModel(c, nil)
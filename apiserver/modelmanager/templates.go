@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func newFacadeV5(st *state.State, resources facade.Resources, auth facade.Authorizer) (*ModelManagerAPIV5, error) {
+	api, err := newFacadeV3(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelManagerAPIV5{api}, nil
+}
+
+// ModelManagerAPIV5 provides access to version 5 of the
+// ModelManager API facade.
+type ModelManagerAPIV5 struct {
+	*ModelManagerAPIV3
+}
+
+// NewModelManagerAPIV5 creates a new server-side ModelManager
+// API facade, version 5.
+func NewModelManagerAPIV5(
+	st common.ModelManagerBackend,
+	configGetter environs.EnvironConfigGetter,
+	authorizer facade.Authorizer,
+) (*ModelManagerAPIV5, error) {
+	api, err := NewModelManagerAPIV3(st, configGetter, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelManagerAPIV5{api}, nil
+}
+
+// applyTemplateConfig returns the template config overlaid with the
+// given model config.
+func applyTemplateConfig(templateConfig, modelConfig map[string]interface{}) map[string]interface{} {
+	joint := make(map[string]interface{})
+	for key, value := range templateConfig {
+		joint[key] = value
+	}
+	for key, value := range modelConfig {
+		joint[key] = value
+	}
+	return joint
+}
+
+// ModelTemplates returns the controller's model templates. Any user
+// who may add models may list the templates they can choose from.
+func (m *ModelManagerAPIV5) ModelTemplates() (params.ModelTemplates, error) {
+	result := params.ModelTemplates{}
+	canAddModel, err := m.authorizer.HasPermission(permission.AddModelAccess, m.state.ControllerTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !canAddModel {
+		return result, common.ErrPerm
+	}
+	templates, err := m.state.AllModelTemplates()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Templates = make([]params.ModelTemplate, len(templates))
+	for i, tmpl := range templates {
+		result.Templates[i] = params.ModelTemplate{
+			Name:        tmpl.Name,
+			Description: tmpl.Description,
+			Config:      tmpl.Config,
+			Constraints: tmpl.Constraints,
+		}
+	}
+	return result, nil
+}
+
+// SetModelTemplates creates or replaces the given model templates.
+// Models already created from a template are not changed.
+func (m *ModelManagerAPIV5) SetModelTemplates(args params.ModelTemplates) (params.ErrorResults, error) {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Templates))}
+	if !m.isAdmin {
+		return results, common.ErrPerm
+	}
+	if err := m.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Templates {
+		results.Results[i].Error = common.ServerError(m.state.SetModelTemplate(state.ModelTemplate{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Config:      arg.Config,
+			Constraints: arg.Constraints,
+		}))
+	}
+	return results, nil
+}
+
+// RemoveModelTemplates removes the named model templates. Models
+// already created from a template are not changed.
+func (m *ModelManagerAPIV5) RemoveModelTemplates(args params.ModelTemplateNames) (params.ErrorResults, error) {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Names))}
+	if !m.isAdmin {
+		return results, common.ErrPerm
+	}
+	if err := m.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, name := range args.Names {
+		results.Results[i].Error = common.ServerError(m.state.RemoveModelTemplate(name))
+	}
+	return results, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+)
+
+func (s *modelManagerSuite) apiV5(c *gc.C, user names.UserTag) *modelmanager.ModelManagerAPIV5 {
+	s.authoriser.Tag = user
+	api, err := modelmanager.NewModelManagerAPIV5(s.st, nil, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelManagerSuite) TestModelTemplates(c *gc.C) {
+	s.st.templates = []state.ModelTemplate{{
+		Name:        "prod",
+		Description: "production models",
+		Config:      map[string]interface{}{"default-series": "xenial"},
+		Constraints: constraints.MustParse("spaces=dmz"),
+	}}
+	api := s.apiV5(c, names.NewUserTag("add-model"))
+	result, err := api.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelTemplates{
+		Templates: []params.ModelTemplate{{
+			Name:        "prod",
+			Description: "production models",
+			Config:      map[string]interface{}{"default-series": "xenial"},
+			Constraints: constraints.MustParse("spaces=dmz"),
+		}},
+	})
+}
+
+func (s *modelManagerSuite) TestModelTemplatesPermissionDenied(c *gc.C) {
+	api := s.apiV5(c, names.NewUserTag("charlie"))
+	_, err := api.ModelTemplates()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestSetModelTemplates(c *gc.C) {
+	api := s.apiV5(c, names.NewUserTag("admin"))
+	result, err := api.SetModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{{
+			Name:        "prod",
+			Config:      map[string]interface{}{"default-series": "xenial"},
+			Constraints: constraints.MustParse("mem=4G"),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.st.CheckCall(c, len(s.st.Calls())-1, "SetModelTemplate", state.ModelTemplate{
+		Name:        "prod",
+		Config:      map[string]interface{}{"default-series": "xenial"},
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+}
+
+func (s *modelManagerSuite) TestSetModelTemplatesPermissionDenied(c *gc.C) {
+	api := s.apiV5(c, names.NewUserTag("add-model"))
+	_, err := api.SetModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{{Name: "prod"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestSetModelTemplatesBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestSetModelTemplatesBlocked")
+	api := s.apiV5(c, names.NewUserTag("admin"))
+	_, err := api.SetModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{{Name: "prod"}},
+	})
+	s.assertBlocked(c, err, "TestSetModelTemplatesBlocked")
+}
+
+func (s *modelManagerSuite) TestRemoveModelTemplates(c *gc.C) {
+	api := s.apiV5(c, names.NewUserTag("admin"))
+	result, err := api.RemoveModelTemplates(params.ModelTemplateNames{
+		Names: []string{"prod", "dev"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Combine(), jc.ErrorIsNil)
+	calls := s.st.Calls()
+	s.st.CheckCall(c, len(calls)-2, "RemoveModelTemplate", "prod")
+	s.st.CheckCall(c, len(calls)-1, "RemoveModelTemplate", "dev")
+}
+
+func (s *modelManagerSuite) TestRemoveModelTemplatesPermissionDenied(c *gc.C) {
+	api := s.apiV5(c, names.NewUserTag("add-model"))
+	_, err := api.RemoveModelTemplates(params.ModelTemplateNames{
+		Names: []string{"prod"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -25,3 +25,19 @@ type EntityAnnotations struct {
 	EntityTag   string            `json:"entity"`
 	Annotations map[string]string `json:"annotations"`
 }
+
+// AnnotationsQuery holds the parameters for an Annotations.Query call.
+// Entities and Kinds cannot both be specified.
+type AnnotationsQuery struct {
+	// Entities, if not empty, restricts the query to the given
+	// entities.
+	Entities []Entity `json:"entities,omitempty"`
+
+	// Kinds, if not empty, restricts the query to entities of the
+	// given kinds, such as "application" or "unit".
+	Kinds []string `json:"kinds,omitempty"`
+
+	// KeyPrefix, if not empty, restricts the query to annotations
+	// whose keys start with it.
+	KeyPrefix string `json:"key-prefix,omitempty"`
+}
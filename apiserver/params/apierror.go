@@ -5,6 +5,7 @@ package params
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/macaroon.v1"
@@ -21,6 +22,11 @@ type Error struct {
 	Message string     `json:"message"`
 	Code    string     `json:"code"`
 	Info    *ErrorInfo `json:"info,omitempty"`
+
+	// Details holds machine-readable information about the
+	// error. The keys that may be present depend on the code;
+	// see ErrorDetailKeys.
+	Details map[string]string `json:"details,omitempty"`
 }
 
 // ErrorInfo holds additional information provided by an error.
@@ -52,6 +58,11 @@ func (e Error) ErrorCode() string {
 	return e.Code
 }
 
+// ErrorDetails returns the machine-readable details of the error.
+func (e Error) ErrorDetails() map[string]string {
+	return e.Details
+}
+
 // GoString implements fmt.GoStringer.  It means that a *Error shows its
 // contents correctly when printed with %#v.
 func (e Error) GoString() string {
@@ -94,8 +105,34 @@ const (
 	CodeDischargeRequired         = "macaroon discharge required"
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
+	CodeQuotaExceeded             = "quota exceeded"
+	CodeNotLeader                 = "not leader"
+	CodeTryAgainAfter             = "try again after"
 )
 
+// The Detail constants hold the keys of the machine-readable
+// details that may accompany an error.
+const (
+	// DetailQuota holds the name of the quota that was exceeded.
+	DetailQuota = "quota"
+
+	// DetailLimit holds the limit of the quota that was exceeded,
+	// as a decimal integer.
+	DetailLimit = "limit"
+
+	// DetailRetryAfter holds how long the client should wait before
+	// retrying, as parsed by time.ParseDuration.
+	DetailRetryAfter = "retry-after"
+)
+
+// ErrorDetailKeys records, for each error code that carries details,
+// the keys that may be present in its details. Any key may be
+// missing, so clients must cope with their absence.
+var ErrorDetailKeys = map[string][]string{
+	CodeQuotaExceeded: {DetailQuota, DetailLimit},
+	CodeTryAgainAfter: {DetailRetryAfter},
+}
+
 // ErrCode returns the error code associated with
 // the given error, or the empty string if there
 // is none.
@@ -111,6 +148,33 @@ func ErrCode(err error) string {
 	}
 }
 
+// ErrDetails returns the machine-readable details associated
+// with the given error, or nil if there are none.
+func ErrDetails(err error) map[string]string {
+	type ErrorDetailer interface {
+		ErrorDetails() map[string]string
+	}
+	switch err := errors.Cause(err).(type) {
+	case ErrorDetailer:
+		return err.ErrorDetails()
+	default:
+		return nil
+	}
+}
+
+// RetryAfter returns how long the client should wait before retrying
+// the operation that failed with the given error, if the error says.
+func RetryAfter(err error) (time.Duration, bool) {
+	if !IsCodeTryAgainAfter(err) {
+		return 0, false
+	}
+	d, parseErr := time.ParseDuration(ErrDetails(err)[DetailRetryAfter])
+	if parseErr != nil {
+		return 0, false
+	}
+	return d, true
+}
+
 func IsCodeActionNotAvailable(err error) bool {
 	return ErrCode(err) == CodeActionNotAvailable
 }
@@ -196,8 +260,11 @@ func IsCodeNoAddressSet(err error) bool {
 	return ErrCode(err) == CodeNoAddressSet
 }
 
+// IsCodeTryAgain returns whether the error says the operation may
+// succeed if retried, whether or not it says when.
 func IsCodeTryAgain(err error) bool {
-	return ErrCode(err) == CodeTryAgain
+	code := ErrCode(err)
+	return code == CodeTryAgain || code == CodeTryAgainAfter
 }
 
 func IsCodeNotImplemented(err error) bool {
@@ -228,6 +295,18 @@ func IsCodeNotSupported(err error) bool {
 	return ErrCode(err) == CodeNotSupported
 }
 
+func IsCodeQuotaExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaExceeded
+}
+
+func IsCodeNotLeader(err error) bool {
+	return ErrCode(err) == CodeNotLeader
+}
+
+func IsCodeTryAgainAfter(err error) bool {
+	return ErrCode(err) == CodeTryAgainAfter
+}
+
 func IsBadRequest(err error) bool {
 	return ErrCode(err) == CodeBadRequest
 }
@@ -4,7 +4,10 @@
 package params_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
@@ -25,3 +28,43 @@ func (*errorSuite) TestErrCode(c *gc.C) {
 	err = errors.Trace(err)
 	c.Check(params.ErrCode(err), gc.Equals, params.CodeDead)
 }
+
+func (*errorSuite) TestErrDetails(c *gc.C) {
+	var err error
+	err = &params.Error{
+		Code:    params.CodeQuotaExceeded,
+		Message: `quota "units" exceeded (limit 3)`,
+		Details: map[string]string{params.DetailQuota: "units", params.DetailLimit: "3"},
+	}
+	expected := map[string]string{"quota": "units", "limit": "3"}
+	c.Check(params.ErrDetails(err), jc.DeepEquals, expected)
+
+	err = errors.Trace(err)
+	c.Check(params.ErrDetails(err), jc.DeepEquals, expected)
+
+	c.Check(params.ErrDetails(errors.New("no details")), gc.IsNil)
+}
+
+func (*errorSuite) TestRetryAfter(c *gc.C) {
+	err := &params.Error{
+		Code:    params.CodeTryAgainAfter,
+		Message: "busy",
+		Details: map[string]string{params.DetailRetryAfter: "1m30s"},
+	}
+	d, ok := params.RetryAfter(err)
+	c.Check(ok, jc.IsTrue)
+	c.Check(d, gc.Equals, 90*time.Second)
+
+	err.Details[params.DetailRetryAfter] = "soon"
+	_, ok = params.RetryAfter(err)
+	c.Check(ok, jc.IsFalse)
+
+	_, ok = params.RetryAfter(&params.Error{Code: params.CodeTryAgain})
+	c.Check(ok, jc.IsFalse)
+}
+
+func (*errorSuite) TestErrorDetailKeysDocumented(c *gc.C) {
+	for code, keys := range params.ErrorDetailKeys {
+		c.Check(keys, gc.Not(gc.HasLen), 0, gc.Commentf("code %q", code))
+	}
+}
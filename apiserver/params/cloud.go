@@ -11,6 +11,7 @@ type Cloud struct {
 	IdentityEndpoint string        `json:"identity-endpoint,omitempty"`
 	StorageEndpoint  string        `json:"storage-endpoint,omitempty"`
 	Regions          []CloudRegion `json:"regions,omitempty"`
+	CACertificates   []string      `json:"ca-certificates,omitempty"`
 }
 
 // CloudRegion holds information about a cloud region.
@@ -38,6 +39,18 @@ type CloudsResult struct {
 	Clouds map[string]Cloud `json:"clouds,omitempty"`
 }
 
+// AddCloudArgs holds a cloud definition and the name to add
+// or update it as.
+type AddCloudArgs struct {
+	Cloud Cloud  `json:"cloud"`
+	Name  string `json:"name"`
+}
+
+// UpdateCloudArgs holds a set of cloud definitions to update.
+type UpdateCloudArgs struct {
+	Clouds []AddCloudArgs `json:"clouds"`
+}
+
 // CloudCredential contains a cloud credential
 // possibly with secrets redacted.
 type CloudCredential struct {
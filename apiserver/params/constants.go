@@ -29,6 +29,22 @@ const (
 	ResolvedNone       ResolvedMode = ""
 	ResolvedRetryHooks ResolvedMode = "retry-hooks"
 	ResolvedNoHooks    ResolvedMode = "no-hooks"
+
+	// ResolvedSkipOperation and ResolvedFailOperation ask the unit
+	// agent to abandon its pending operation, killing any hook that
+	// is running, and to record it as completed or as failed.
+	ResolvedSkipOperation ResolvedMode = "skip-operation"
+	ResolvedFailOperation ResolvedMode = "fail-operation"
 )
 
 const MachineNonceHeader = "X-Juju-Nonce"
+
+// AcceptCompressionHeader is the HTTP header with which a client
+// opening an API connection tells the server that it accepts
+// compressed responses. Its value names the compression the client
+// accepts; only CompressionGzip is supported.
+const AcceptCompressionHeader = "X-Juju-Accept-Compression"
+
+// CompressionGzip is the AcceptCompressionHeader value with which a
+// client accepts gzipped responses.
+const CompressionGzip = "gzip"
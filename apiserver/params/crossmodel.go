@@ -62,6 +62,30 @@ type AddApplicationOffer struct {
 	UserTags []string `json:"users"`
 }
 
+// ModifyOfferAccessRequest holds the parameters for granting and
+// revoking access to application offers.
+type ModifyOfferAccessRequest struct {
+	Changes []ModifyOfferAccess `json:"changes"`
+}
+
+// ModifyOfferAccess holds a change to the set of users allowed
+// to consume an application offer.
+type ModifyOfferAccess struct {
+	UserTag        string      `json:"user-tag"`
+	Action         OfferAction `json:"action"`
+	ApplicationURL string      `json:"application-url"`
+}
+
+// OfferAction is an action that can be performed on an offer's
+// access list.
+type OfferAction string
+
+// Actions that can be performed on an offer's access list.
+const (
+	GrantOfferAccess  OfferAction = "grant"
+	RevokeOfferAccess OfferAction = "revoke"
+)
+
 // ApplicationOfferResults is a result of listing application offers.
 type ApplicationOfferResults struct {
 	Offers []ApplicationOffer
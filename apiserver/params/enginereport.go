@@ -0,0 +1,33 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AgentEngineReports holds the dependency engine reports for one or
+// more agents.
+type AgentEngineReports struct {
+	Reports []AgentEngineReport `json:"reports"`
+}
+
+// AgentEngineReport holds the dependency engine report for a single
+// agent.
+type AgentEngineReport struct {
+	Tag    string                 `json:"tag"`
+	Report map[string]interface{} `json:"report"`
+}
+
+// AgentEngineReportResults holds the results of a call to
+// EngineReport.Reports.
+type AgentEngineReportResults struct {
+	Results []AgentEngineReportResult `json:"results"`
+}
+
+// AgentEngineReportResult holds the most recent dependency engine
+// report sent by an agent, and the time at which it was received.
+type AgentEngineReportResult struct {
+	Report  map[string]interface{} `json:"report,omitempty"`
+	Updated time.Time              `json:"updated"`
+	Error   *Error                 `json:"error,omitempty"`
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// Health statuses reported by the controller's /health endpoint.
+const (
+	// HealthOK indicates that a component, or the controller
+	// as a whole, is working normally.
+	HealthOK = "ok"
+
+	// HealthWarning indicates that a component is working
+	// but needs attention, for example because disk space
+	// is running low.
+	HealthWarning = "warning"
+
+	// HealthError indicates that a component is not working,
+	// and the controller should not be sent traffic.
+	HealthError = "error"
+)
+
+// ControllerHealth is the response to a request to the controller's
+// /health endpoint. Status is the most severe of the components'
+// statuses.
+type ControllerHealth struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentHealth `json:"components"`
+}
+
+// ComponentHealth reports the health of one part of the controller.
+type ComponentHealth struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
@@ -29,8 +29,16 @@ const (
 
 	// ContentTypeXJS is the outdated HTTP content-type value used for javascript.
 	ContentTypeXJS = "application/x-javascript"
+
+	// ContentTypeToolsDelta is the HTTP content-type value used for
+	// deltas between agent binary tarballs.
+	ContentTypeToolsDelta = "application/x-juju-tools-delta"
 )
 
+// ToolsSHA256Header is the HTTP header that holds the SHA256 hash of
+// the tools tarball reconstructed by a tools delta.
+const ToolsSHA256Header = "X-Juju-Tools-Sha256"
+
 // EncodeChecksum base64 encodes a sha256 checksum according to RFC 4648 and
 // returns a value that can be added to the "Digest" http header.
 func EncodeChecksum(checksum string) string {
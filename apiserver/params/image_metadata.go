@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // ImageMetadataFilter holds filter properties used to search for image metadata.
 // It amalgamates both simplestreams.MetadataLookupParams and simplestreams.LookupParams
 // and adds additional properties to satisfy existing and new use cases.
@@ -65,6 +67,10 @@ type CloudImageMetadata struct {
 	// Higher number means higher priority.
 	// This will allow to sort metadata by importance.
 	Priority int `json:"priority"`
+
+	// DateCreated is when the metadata was cached, in nanoseconds
+	// since the Unix epoch. It is ignored when metadata is saved.
+	DateCreated int64 `json:"date-created,omitempty"`
 }
 
 // ListCloudImageMetadataResult holds the results of querying cloud image metadata.
@@ -87,3 +93,17 @@ type CloudImageMetadataList struct {
 type MetadataImageIds struct {
 	Ids []string `json:"image-ids"`
 }
+
+// ExpireImageMetadataParams holds the arguments for expiring cached
+// cloud image metadata.
+type ExpireImageMetadataParams struct {
+	// MaxAge is the age beyond which cached metadata is expired.
+	MaxAge time.Duration `json:"max-age"`
+}
+
+// ExpireImageMetadataResult holds the result of expiring cached
+// cloud image metadata.
+type ExpireImageMetadataResult struct {
+	// Expired is the number of metadata that were deleted.
+	Expired int `json:"expired"`
+}
@@ -95,6 +95,23 @@ type ModelResult struct {
 	UUID  string `json:"uuid"`
 }
 
+// DestroyModelsParams holds the arguments for destroying models.
+type DestroyModelsParams struct {
+	Models []DestroyModelParams `json:"models"`
+}
+
+// DestroyModelParams holds the arguments for destroying a model.
+type DestroyModelParams struct {
+	// ModelTag is the tag of the model to destroy.
+	ModelTag string `json:"model-tag"`
+
+	// DestroyStorage, if non-nil, states whether the model's volumes
+	// are to be destroyed (true) or released (false). Released volumes
+	// are removed from the model, but left in the cloud so that they
+	// may be attached again later. If nil, the volumes are destroyed.
+	DestroyStorage *bool `json:"destroy-storage,omitempty"`
+}
+
 // ModelCreateArgs holds the arguments that are necessary to create
 // a model.
 type ModelCreateArgs struct {
@@ -128,6 +145,11 @@ type ModelCreateArgs struct {
 	// and the owner is the controller owner, the same credential
 	// used for the controller model will be used.
 	CloudCredentialTag string `json:"credential,omitempty"`
+
+	// Template is the name of a model template whose config and
+	// constraints are applied to the new model. Values in Config
+	// take precedence over those in the template.
+	Template string `json:"template,omitempty"`
 }
 
 // Model holds the result of an API call returning a name and UUID
@@ -250,6 +272,12 @@ type ControllerConfigResult struct {
 	Config ControllerConfig `json:"config"`
 }
 
+// ControllerConfigSet holds the controller configuration attributes
+// to be changed by a ConfigSet call.
+type ControllerConfigSet struct {
+	Config map[string]interface{} `json:"config"`
+}
+
 // RelationUnit holds a relation and a unit tag.
 type RelationUnit struct {
 	Relation string `json:"relation"`
@@ -294,6 +322,103 @@ type RelationUnitsSettings struct {
 	RelationUnits []RelationUnitSettings `json:"relation-units"`
 }
 
+// RelationUnitApplication holds a relation tag, the tag of a unit in
+// the relation, and the tag of an application whose relation settings
+// the unit wants to read.
+type RelationUnitApplication struct {
+	Relation    string `json:"relation"`
+	Unit        string `json:"unit"`
+	Application string `json:"application"`
+}
+
+// RelationUnitApplications holds the parameters for API calls
+// expecting multiple relation, unit and application tags.
+type RelationUnitApplications struct {
+	RelationUnitApplications []RelationUnitApplication `json:"relation-unit-applications"`
+}
+
+// UnitDrainTimeoutResult holds how long a dying unit's pre-remove
+// hook may run for.
+type UnitDrainTimeoutResult struct {
+	// TimeoutSeconds is the number of seconds the hook may run for;
+	// zero means that there is no limit.
+	TimeoutSeconds float64 `json:"timeout"`
+}
+
+// SetSecretArg holds a value to store as a secret of a unit's
+// application.
+type SetSecretArg struct {
+	UnitTag string `json:"unit-tag"`
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+
+	// RotateIntervalSeconds, if not nil, sets how often the secret
+	// should be given a new value; zero means that it need not be.
+	RotateIntervalSeconds *float64 `json:"rotate-interval,omitempty"`
+}
+
+// SetSecretArgs holds the parameters for making a SetSecrets call.
+type SetSecretArgs struct {
+	Args []SetSecretArg `json:"args"`
+}
+
+// GetSecretArg identifies a secret of a unit's application.
+type GetSecretArg struct {
+	UnitTag string `json:"unit-tag"`
+	Name    string `json:"name"`
+}
+
+// GetSecretArgs holds the parameters for making a GetSecrets call.
+type GetSecretArgs struct {
+	Args []GetSecretArg `json:"args"`
+}
+
+// Secret holds the value of a charm's secret and its metadata.
+type Secret struct {
+	Value    string    `json:"value"`
+	Revision int       `json:"revision"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+
+	// RotateIntervalSeconds is how often the secret should be given a
+	// new value; zero means that it need not be.
+	RotateIntervalSeconds float64 `json:"rotate-interval,omitempty"`
+
+	// NextRotation is when the secret is next due a new value, if it
+	// is ever.
+	NextRotation *time.Time `json:"next-rotation,omitempty"`
+}
+
+// SecretResult holds a secret or an error.
+type SecretResult struct {
+	Result *Secret `json:"result,omitempty"`
+	Error  *Error  `json:"error,omitempty"`
+}
+
+// SecretResults holds the results of a GetSecrets call.
+type SecretResults struct {
+	Results []SecretResult `json:"results"`
+}
+
+// UnitPayload identifies a payload tracked by a unit.
+type UnitPayload struct {
+	Class string `json:"class"`
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+}
+
+// UnitPayloadsResult holds the payloads tracked by a unit, or an
+// error.
+type UnitPayloadsResult struct {
+	Payloads []UnitPayload `json:"payloads,omitempty"`
+	Error    *Error        `json:"error,omitempty"`
+}
+
+// UnitPayloadsResults holds the results of a UnitPayloads call.
+type UnitPayloadsResults struct {
+	Results []UnitPayloadsResult `json:"results"`
+}
+
 // RelationResult returns information about a single relation,
 // or an error.
 type RelationResult struct {
@@ -394,6 +519,19 @@ type SetStatus struct {
 	Entities []EntityStatusArgs `json:"entities"`
 }
 
+// StatusEvent holds an event to be added to an entity's status
+// history.
+type StatusEvent struct {
+	Tag     string                 `json:"tag"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// StatusEvents holds the parameters for making an AddStatusEvents call.
+type StatusEvents struct {
+	Events []StatusEvent `json:"events"`
+}
+
 // ConstraintsResult holds machine constraints or an error.
 type ConstraintsResult struct {
 	Error       *Error            `json:"error,omitempty"`
@@ -606,16 +744,26 @@ type AgentVersionResult struct {
 
 // ProvisioningInfo holds machine provisioning info.
 type ProvisioningInfo struct {
-	Constraints      constraints.Value         `json:"constraints"`
-	Series           string                    `json:"series"`
-	Placement        string                    `json:"placement"`
-	Jobs             []multiwatcher.MachineJob `json:"jobs"`
-	Volumes          []VolumeParams            `json:"volumes,omitempty"`
-	Tags             map[string]string         `json:"tags,omitempty"`
-	SubnetsToZones   map[string][]string       `json:"subnets-to-zones,omitempty"`
-	ImageMetadata    []CloudImageMetadata      `json:"image-metadata,omitempty"`
-	EndpointBindings map[string]string         `json:"endpoint-bindings,omitempty"`
-	ControllerConfig map[string]interface{}    `json:"controller-config,omitempty"`
+	Constraints       constraints.Value         `json:"constraints"`
+	Series            string                    `json:"series"`
+	Placement         string                    `json:"placement"`
+	Jobs              []multiwatcher.MachineJob `json:"jobs"`
+	Volumes           []VolumeParams            `json:"volumes,omitempty"`
+	Tags              map[string]string         `json:"tags,omitempty"`
+	SubnetsToZones    map[string][]string       `json:"subnets-to-zones,omitempty"`
+	ImageMetadata     []CloudImageMetadata      `json:"image-metadata,omitempty"`
+	EndpointBindings  map[string]string         `json:"endpoint-bindings,omitempty"`
+	ControllerConfig  map[string]interface{}    `json:"controller-config,omitempty"`
+	CloudInitUserData string                    `json:"cloudinit-userdata,omitempty"`
+	CharmLXDProfiles  map[string]LXDProfile     `json:"charm-lxd-profiles,omitempty"`
+}
+
+// LXDProfile holds an LXD profile shipped with a charm, to be
+// applied to the LXD containers hosting the charm's units.
+type LXDProfile struct {
+	Description string                       `json:"description,omitempty"`
+	Config      map[string]string            `json:"config,omitempty"`
+	Devices     map[string]map[string]string `json:"devices,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -30,6 +30,19 @@ type ClaimLeadershipParams struct {
 // leadership claim.
 type ClaimLeadershipBulkResults ErrorResults
 
+// LeadershipLeaseSettings holds the leadership lease settings
+// configured for a model.
+type LeadershipLeaseSettings struct {
+
+	// LeaseDurationSeconds is the number of seconds for which units
+	// should claim leadership.
+	LeaseDurationSeconds float64 `json:"lease-duration"`
+
+	// RenewalIntervalSeconds is the number of seconds between a
+	// leader's lease renewals.
+	RenewalIntervalSeconds float64 `json:"renewal-interval"`
+}
+
 // ReleaseLeadershipBulkParams is a collection of parameters needed to
 // make a bulk release leadership call.
 type ReleaseLeadershipBulkParams struct {
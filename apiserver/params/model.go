@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/juju/version"
+
+	"github.com/juju/juju/constraints"
 )
 
 // ConfigValue encapsulates a configuration
@@ -100,6 +102,28 @@ type UnsetModelDefaults struct {
 	Keys []ModelUnsetKeys `json:"keys"`
 }
 
+// ModelTemplate holds the model config and constraints applied to
+// models created from a model template.
+type ModelTemplate struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Config      map[string]interface{} `json:"config,omitempty"`
+	Constraints constraints.Value      `json:"constraints"`
+}
+
+// ModelTemplates holds a list of model templates. It is used both
+// to list model templates and as the arguments for the
+// SetModelTemplates API call.
+type ModelTemplates struct {
+	Templates []ModelTemplate `json:"templates"`
+}
+
+// ModelTemplateNames contains the arguments for the
+// RemoveModelTemplates API call.
+type ModelTemplateNames struct {
+	Names []string `json:"names"`
+}
+
 // SetModelAgentVersion contains the arguments for
 // SetModelAgentVersion client API call.
 type SetModelAgentVersion struct {
@@ -247,7 +271,8 @@ type UserAccessPermission string
 
 // Model access permissions that may be set on a user.
 const (
-	ModelAdminAccess UserAccessPermission = "admin"
-	ModelReadAccess  UserAccessPermission = "read"
-	ModelWriteAccess UserAccessPermission = "write"
+	ModelAdminAccess    UserAccessPermission = "admin"
+	ModelReadAccess     UserAccessPermission = "read"
+	ModelOperatorAccess UserAccessPermission = "operator"
+	ModelWriteAccess    UserAccessPermission = "write"
 )
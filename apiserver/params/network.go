@@ -371,6 +371,43 @@ type UnitNetworkConfig struct {
 	BindingName string `json:"binding-name"`
 }
 
+// NetworkInfoParams holds the parameters for calling Uniter.NetworkInfo().
+type NetworkInfoParams struct {
+	Unit     string   `json:"unit"`
+	Bindings []string `json:"bindings"`
+}
+
+// InterfaceAddress describes one address on a network interface.
+type InterfaceAddress struct {
+	Address string `json:"value"`
+	CIDR    string `json:"cidr"`
+}
+
+// NetworkInfo describes a network interface of a unit's machine, and
+// those of its addresses that are in an endpoint's bound space.
+type NetworkInfo struct {
+	MACAddress    string             `json:"mac-address"`
+	InterfaceName string             `json:"interface-name"`
+	Addresses     []InterfaceAddress `json:"addresses"`
+}
+
+// NetworkInfoResult holds everything a unit needs to know about the
+// network of one of its endpoints: the interfaces and addresses in the
+// endpoint's bound space, the addresses it should advertise to others,
+// and the subnets its outgoing traffic will appear to come from.
+type NetworkInfoResult struct {
+	Error            *Error        `json:"error,omitempty"`
+	Info             []NetworkInfo `json:"network-info,omitempty"`
+	EgressSubnets    []string      `json:"egress-subnets,omitempty"`
+	IngressAddresses []string      `json:"ingress-addresses,omitempty"`
+}
+
+// NetworkInfoResults holds a NetworkInfoResult for each requested
+// binding, keyed by binding name.
+type NetworkInfoResults struct {
+	Results map[string]NetworkInfoResult `json:"results"`
+}
+
 // MachineAddresses holds an machine tag and addresses.
 type MachineAddresses struct {
 	Tag       string    `json:"tag"`
@@ -611,9 +648,10 @@ type ProxyConfig struct {
 
 // ProxyConfigResult contains information needed to configure a clients proxy settings
 type ProxyConfigResult struct {
-	ProxySettings    ProxyConfig `json:"proxy-settings"`
-	APTProxySettings ProxyConfig `json:"apt-proxy-settings"`
-	Error            *Error      `json:"error,omitempty"`
+	ProxySettings     ProxyConfig `json:"proxy-settings"`
+	APTProxySettings  ProxyConfig `json:"apt-proxy-settings"`
+	SnapProxySettings ProxyConfig `json:"snap-proxy-settings"`
+	Error             *Error      `json:"error,omitempty"`
 }
 
 // ProxyConfigResults contains information needed to configure multiple clients proxy settings
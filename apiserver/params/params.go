@@ -181,6 +181,11 @@ type AddMachineParams struct {
 	Nonce                   string                           `json:"nonce"`
 	HardwareCharacteristics instance.HardwareCharacteristics `json:"hardware-characteristics"`
 	Addrs                   []Address                        `json:"addresses"`
+
+	// CloudInitUserData optionally holds additional cloud-init
+	// user-data, in YAML, to be merged with the user-data generated
+	// for the machine when it is provisioned.
+	CloudInitUserData string `json:"cloudinit-userdata,omitempty"`
 }
 
 // AddMachines holds the parameters for making the AddMachines call.
@@ -200,12 +205,64 @@ type AddMachinesResult struct {
 	Error   *Error `json:"error,omitempty"`
 }
 
+// CloudInstance describes an instance that the cloud runs for a
+// model, and the machine, if any, that it belongs to.
+type CloudInstance struct {
+	InstanceId string            `json:"instance-id"`
+	Status     string            `json:"status"`
+	Tags       map[string]string `json:"tags,omitempty"`
+
+	// MachineId is the id of the model's machine that the instance
+	// belongs to. It is empty if no machine claims the instance.
+	MachineId string `json:"machine-id,omitempty"`
+}
+
+// CloudInstancesResult holds the results of a ListCloudInstances call.
+type CloudInstancesResult struct {
+	Instances []CloudInstance `json:"instances"`
+
+	// MissingMachines holds the ids of the provisioned machines whose
+	// instances the cloud does not report.
+	MissingMachines []string `json:"missing-machines,omitempty"`
+}
+
 // DestroyMachines holds parameters for the DestroyMachines call.
 type DestroyMachines struct {
 	MachineNames []string `json:"machine-names"`
 	Force        bool     `json:"force"`
 }
 
+// ForceDestroyMachines holds the parameters for the MachineManager
+// ForceDestroyMachines call.
+type ForceDestroyMachines struct {
+	Entities []Entity `json:"entities"`
+
+	// DryRun, if true, reports what would be removed without
+	// removing anything.
+	DryRun bool `json:"dry-run,omitempty"`
+}
+
+// DestroyMachineInfo describes what force-destroying a machine removes
+// along with it, in the order in which it is removed.
+type DestroyMachineInfo struct {
+	DestroyedContainers         []Entity              `json:"destroyed-containers,omitempty"`
+	DestroyedUnits              []Entity              `json:"destroyed-units,omitempty"`
+	DestroyedStorageAttachments []StorageAttachmentId `json:"destroyed-storage-attachments,omitempty"`
+}
+
+// DestroyMachineResult holds the result of force-destroying a single
+// machine.
+type DestroyMachineResult struct {
+	Error *Error              `json:"error,omitempty"`
+	Info  *DestroyMachineInfo `json:"info,omitempty"`
+}
+
+// DestroyMachineResults holds the results of a ForceDestroyMachines
+// call.
+type DestroyMachineResults struct {
+	Results []DestroyMachineResult `json:"results"`
+}
+
 // ApplicationsDeploy holds the parameters for deploying one or more applications.
 type ApplicationsDeploy struct {
 	Applications []ApplicationDeploy `json:"applications"`
@@ -276,11 +333,31 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// AutoRollback, if set, asks for the upgrade to be reverted
+	// automatically if too many units fail to upgrade. This field
+	// is only understood by Application facade version 6 and greater.
+	AutoRollback *CharmRollbackPolicy `json:"auto-rollback,omitempty"`
+}
+
+// CharmRollbackPolicy holds the conditions under which a charm upgrade
+// is automatically reverted.
+type CharmRollbackPolicy struct {
+	// Threshold is the percentage of the application's units that
+	// must fail the upgrade-charm hook for the upgrade to be reverted.
+	Threshold int `json:"threshold"`
+
+	// Window is how long after the upgrade failures are counted.
+	Window time.Duration `json:"window"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ToCIDRs, if specified, restricts access to the application's
+	// open ports to the given CIDRs.
+	ToCIDRs []string `json:"to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -313,6 +390,30 @@ type ApplicationGetResults struct {
 	Series      string                 `json:"series"`
 }
 
+// ApplicationStageConfig holds the parameters for the StagedConfig
+// facade's Stage call. Options are parsed as for Set, and SettingsYAML
+// as for Update; Reset names options to return to their defaults.
+type ApplicationStageConfig struct {
+	ApplicationName string            `json:"application"`
+	Options         map[string]string `json:"options,omitempty"`
+	SettingsYAML    string            `json:"settings-yaml,omitempty"`
+	Reset           []string          `json:"reset,omitempty"`
+}
+
+// ApplicationStagedConfig identifies the application whose staged
+// config changes are read, committed or discarded.
+type ApplicationStagedConfig struct {
+	ApplicationName string `json:"application"`
+}
+
+// ApplicationStagedConfigResults holds the config changes staged for
+// an application.
+type ApplicationStagedConfigResults struct {
+	Application string                 `json:"application"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+	Reset       []string               `json:"reset,omitempty"`
+}
+
 // ApplicationCharmRelations holds parameters for making the application CharmRelations call.
 type ApplicationCharmRelations struct {
 	ApplicationName string `json:"application"`
@@ -365,6 +466,41 @@ type Resolved struct {
 	Retry    bool   `json:"retry"`
 }
 
+// ResolveOperation holds parameters for the ResolveOperation call.
+type ResolveOperation struct {
+	UnitName string `json:"unit-name"`
+	Skip     bool   `json:"skip"`
+}
+
+// ResolveUnitErrors holds parameters for the ResolveUnitErrors call.
+// Exactly one of Applications and All must be set.
+type ResolveUnitErrors struct {
+	// Applications holds the names of the applications whose units
+	// are resolved.
+	Applications []string `json:"applications,omitempty"`
+
+	// All, if true, resolves the units of every application in the
+	// model.
+	All bool `json:"all,omitempty"`
+
+	// NoRetry, if true, skips the failed hooks rather than running
+	// them again.
+	NoRetry bool `json:"no-retry,omitempty"`
+}
+
+// ResolveUnitErrorsResult holds the outcome of resolving a unit's
+// error, or the error preventing an application's units from being
+// resolved.
+type ResolveUnitErrorsResult struct {
+	Tag   string `json:"tag"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// ResolveUnitErrorsResults holds results of the ResolveUnitErrors call.
+type ResolveUnitErrorsResults struct {
+	Results []ResolveUnitErrorsResult `json:"results"`
+}
+
 // ResolvedResults holds results of the Resolved call.
 type ResolvedResults struct {
 	Application string                 `json:"application"`
@@ -385,6 +521,37 @@ type AddApplicationUnits struct {
 	Placement       []*instance.Placement `json:"placement"`
 }
 
+// Unit removal policies for ScaleApplication.
+const (
+	// ScaleRemoveYoungestFirst removes the most recently added units.
+	ScaleRemoveYoungestFirst = "youngest-first"
+
+	// ScaleRemoveEmptiestMachineFirst removes units from the machines
+	// hosting the fewest units, so that those machines may be freed.
+	ScaleRemoveEmptiestMachineFirst = "emptiest-machine-first"
+)
+
+// ScaleApplication holds parameters for the Scale call.
+type ScaleApplication struct {
+	ApplicationName string `json:"application"`
+
+	// Scale is the number of alive units the application should have.
+	Scale int `json:"scale"`
+
+	// Placement is used for any units that must be added.
+	Placement []*instance.Placement `json:"placement,omitempty"`
+
+	// RemovalPolicy chooses the units to remove when scaling down;
+	// it defaults to ScaleRemoveYoungestFirst.
+	RemovalPolicy string `json:"removal-policy,omitempty"`
+}
+
+// ScaleApplicationResult holds the results of the Scale call.
+type ScaleApplicationResult struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
 // DestroyApplicationUnits holds parameters for the DestroyUnits call.
 type DestroyApplicationUnits struct {
 	UnitNames []string `json:"unit-names"`
@@ -478,6 +645,20 @@ type AllWatcherId struct {
 	AllWatcherId string `json:"watcher-id"`
 }
 
+// AllWatcherNextArgs holds the filter for a call to AllWatcher.Next().
+// Empty fields match everything.
+type AllWatcherNextArgs struct {
+	// Kinds holds the kinds of entity, such as "unit" or
+	// "application", for which deltas are returned.
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Applications holds the names of the applications for which
+	// deltas are returned. It applies only to applications and their
+	// units and relations; deltas for other kinds of entity are not
+	// affected.
+	Applications []string `json:"applications,omitempty"`
+}
+
 // AllWatcherNextResults holds deltas returned from calling AllWatcher.Next().
 type AllWatcherNextResults struct {
 	Deltas []multiwatcher.Delta `json:"deltas"`
@@ -728,6 +909,62 @@ type ControllersChanges struct {
 	Converted  []string `json:"converted,omitempty"`
 }
 
+// ReplicaSetStatus holds the status of the controller's mongo
+// replica set.
+type ReplicaSetStatus struct {
+	// Members holds the status of each replica set member.
+	Members []ReplicaSetMember `json:"members"`
+
+	// Elections holds the most recent elections of a primary,
+	// most recent first.
+	Elections []ReplicaSetElection `json:"elections,omitempty"`
+}
+
+// ReplicaSetMember holds the status of a member of the controller's
+// mongo replica set.
+type ReplicaSetMember struct {
+	// Id is the replica set member id.
+	Id int `json:"id"`
+
+	// Address is the member's host:port address.
+	Address string `json:"address"`
+
+	// MachineTag is the tag of the controller machine the member
+	// runs on, if known.
+	MachineTag string `json:"machine-tag,omitempty"`
+
+	// State is the member's replica set state, such as PRIMARY
+	// or SECONDARY.
+	State string `json:"state"`
+
+	// Healthy reports whether the member is reachable.
+	Healthy bool `json:"healthy"`
+
+	// Voting reports whether the member votes in elections.
+	Voting bool `json:"voting"`
+
+	// Lag is how far the member's data is behind the primary's.
+	Lag time.Duration `json:"lag"`
+
+	// LastHeartbeat is when a heartbeat was last received from
+	// the member; it is zero for the member reporting the status.
+	LastHeartbeat time.Time `json:"last-heartbeat,omitempty"`
+
+	// Message holds any error message reported for the member.
+	Message string `json:"message,omitempty"`
+}
+
+// ReplicaSetElection records the election of a replica set primary.
+type ReplicaSetElection struct {
+	Time time.Time `json:"time"`
+	Term int64     `json:"term,omitempty"`
+}
+
+// ReplicaSetMemberIds holds the ids of replica set members.
+type ReplicaSetMemberIds struct {
+	Ids []int `json:"ids"`
+}
+
 // FindToolsParams defines parameters for the FindTools method.
 type FindToolsParams struct {
 	// Number will be used to match tools versions exactly if non-zero.
@@ -791,6 +1028,25 @@ type RebootActionResult struct {
 	Error  *Error       `json:"error,omitempty"`
 }
 
+// RebootScheduleResults holds a list of RebootScheduleResult.
+type RebootScheduleResults struct {
+	Results []RebootScheduleResult `json:"results,omitempty"`
+}
+
+// RebootScheduleResult reports whether a machine's pending reboot
+// or shutdown has been scheduled, and so should wait for the model's
+// maintenance window.
+type RebootScheduleResult struct {
+	Scheduled bool `json:"scheduled"`
+
+	// MaintenanceWindow holds the model's maintenance window, in the
+	// form "HH:MM-HH:MM" UTC. If empty, scheduled reboots may happen
+	// at any time.
+	MaintenanceWindow string `json:"maintenance-window,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
 // LogRecord is used to transmit log messages to the logsink API
 // endpoint.  Single character field names are used for serialisation
 // to keep the size down. These messages are going to be sent a lot.
@@ -840,6 +1096,109 @@ type BundleChange struct {
 	Requires []string `json:"requires"`
 }
 
+// BundleDiffParams holds parameters for making Bundle.DiffBundle calls.
+type BundleDiffParams struct {
+	// BundleDataYAML is the YAML-encoded charm bundle data
+	// (see "github.com/juju/charm.BundleData").
+	BundleDataYAML string `json:"yaml"`
+}
+
+// BundleDiffResults holds results of the Bundle.DiffBundle call.
+type BundleDiffResults struct {
+	// Diff holds the differences between the bundle and the model.
+	// It is omitted if the provided bundle YAML has verification errors.
+	Diff *BundleDiff `json:"diff,omitempty"`
+	// Errors holds possible bundle verification errors.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// BundleDiff holds the differences between a bundle and a model. Only
+// the applications and machines that differ are included.
+type BundleDiff struct {
+	Applications map[string]*ApplicationDiff `json:"applications,omitempty"`
+	Machines     map[string]*MachineDiff     `json:"machines,omitempty"`
+	Relations    *RelationsDiff              `json:"relations,omitempty"`
+}
+
+// BundleDiffMissing values say which side of a diff lacks an
+// application or machine.
+const (
+	// BundleDiffMissingBundle means the model has an application or
+	// machine that the bundle does not.
+	BundleDiffMissingBundle = "bundle"
+
+	// BundleDiffMissingModel means the bundle has an application or
+	// machine that the model does not.
+	BundleDiffMissingModel = "model"
+)
+
+// ApplicationDiff holds the differences between an application in a
+// bundle and in a model.
+type ApplicationDiff struct {
+	// Missing, if set, says which side lacks the application, in
+	// which case no other field is set.
+	Missing string `json:"missing,omitempty"`
+
+	Charm            *StringDiff           `json:"charm,omitempty"`
+	Series           *StringDiff           `json:"series,omitempty"`
+	Constraints      *StringDiff           `json:"constraints,omitempty"`
+	Options          map[string]OptionDiff `json:"options,omitempty"`
+	Expose           *BoolDiff             `json:"expose,omitempty"`
+	NumUnits         *IntDiff              `json:"num-units,omitempty"`
+	Placements       *StringsDiff          `json:"placements,omitempty"`
+	EndpointBindings map[string]StringDiff `json:"bindings,omitempty"`
+}
+
+// MachineDiff holds the differences between a machine in a bundle
+// and in a model.
+type MachineDiff struct {
+	// Missing, if set, says which side lacks the machine, in which
+	// case no other field is set.
+	Missing string `json:"missing,omitempty"`
+
+	Series      *StringDiff `json:"series,omitempty"`
+	Constraints *StringDiff `json:"constraints,omitempty"`
+}
+
+// RelationsDiff holds the relations found on only one side of a
+// bundle diff. Each relation is a pair of "application:endpoint"
+// strings.
+type RelationsDiff struct {
+	BundleAdditions [][]string `json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `json:"model-additions,omitempty"`
+}
+
+// StringDiff holds differing string values from a bundle and a model.
+type StringDiff struct {
+	Bundle string `json:"bundle"`
+	Model  string `json:"model"`
+}
+
+// StringsDiff holds differing string lists from a bundle and a model.
+type StringsDiff struct {
+	Bundle []string `json:"bundle"`
+	Model  []string `json:"model"`
+}
+
+// IntDiff holds differing integer values from a bundle and a model.
+type IntDiff struct {
+	Bundle int `json:"bundle"`
+	Model  int `json:"model"`
+}
+
+// BoolDiff holds differing boolean values from a bundle and a model.
+type BoolDiff struct {
+	Bundle bool `json:"bundle"`
+	Model  bool `json:"model"`
+}
+
+// OptionDiff holds differing application config values from a bundle
+// and a model. A nil value means the option is not set.
+type OptionDiff struct {
+	Bundle interface{} `json:"bundle"`
+	Model  interface{} `json:"model"`
+}
+
 type MongoVersion struct {
 	Major         int    `json:"major"`
 	Minor         int    `json:"minor"`
@@ -897,3 +1256,34 @@ type MacaroonResult struct {
 	Result *macaroon.Macaroon `json:"result,omitempty"`
 	Error  *Error             `json:"error,omitempty"`
 }
+
+// CleanupInfo describes a pending state cleanup.
+type CleanupInfo struct {
+	Id       string `json:"id"`
+	Kind     string `json:"kind"`
+	Prefix   string `json:"prefix"`
+	Attempts int    `json:"attempts"`
+
+	// FirstFailure and LastError record when the cleanup first failed
+	// and why it last failed. They are unset if it has not failed.
+	FirstFailure *time.Time `json:"first-failure,omitempty"`
+	LastError    string     `json:"last-error,omitempty"`
+
+	// NextAttempt is the earliest time at which the cleanup will be
+	// run again. It is unset if the cleanup is due.
+	NextAttempt *time.Time `json:"next-attempt,omitempty"`
+
+	// DeadLetter is true if the cleanup has failed too many times to
+	// be run again until it is retried.
+	DeadLetter bool `json:"dead-letter,omitempty"`
+}
+
+// CleanupsResult holds the results of a ListCleanups call.
+type CleanupsResult struct {
+	Cleanups []CleanupInfo `json:"cleanups"`
+}
+
+// RetryCleanupsResult holds the result of a RetryCleanups call.
+type RetryCleanupsResult struct {
+	Retried int `json:"retried"`
+}
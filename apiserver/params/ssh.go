@@ -20,6 +20,11 @@ type SSHProxyResult struct {
 	UseProxy bool `json:"use-proxy"`
 }
 
+// SSHBastionResult defines the response from the SSHClient.Bastion API.
+type SSHBastionResult struct {
+	Bastion string `json:"bastion"`
+}
+
 // SSHAddressResults defines the response from various APIs on the
 // SSHClient facade.
 type SSHAddressResults struct {
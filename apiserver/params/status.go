@@ -15,6 +15,11 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// Fields, if not empty, limits the result to the named parts of
+	// FullStatus: "machines", "applications", "remote-applications"
+	// and "relations". The model is always included.
+	Fields []string `json:"fields,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
@@ -28,6 +33,25 @@ type FullStatus struct {
 	Relations          []RelationStatus                   `json:"relations"`
 }
 
+// FullStatusWatcherId holds the id of a FullStatusWatcher.
+type FullStatusWatcherId struct {
+	FullStatusWatcherId string `json:"watcher-id"`
+}
+
+// FullStatusDelta holds the changes to a model's status since the
+// previous call to FullStatusWatcher.Next. Map entries with a nil
+// value have been removed; Relations is only meaningful when
+// RelationsChanged is true, in which case it holds the complete
+// set of relations.
+type FullStatusDelta struct {
+	Model              *ModelStatusInfo                    `json:"model,omitempty"`
+	Machines           map[string]*MachineStatus           `json:"machines,omitempty"`
+	Applications       map[string]*ApplicationStatus       `json:"applications,omitempty"`
+	RemoteApplications map[string]*RemoteApplicationStatus `json:"remote-applications,omitempty"`
+	Relations          []RelationStatus                    `json:"relations,omitempty"`
+	RelationsChanged   bool                                `json:"relations-changed,omitempty"`
+}
+
 // ModelStatusInfo holds status information about the model itself.
 type ModelStatusInfo struct {
 	Name             string         `json:"name"`
@@ -133,8 +157,31 @@ type RelationStatus struct {
 	Interface string           `json:"interface"`
 	Scope     string           `json:"scope"`
 	Endpoints []EndpointStatus `json:"endpoints"`
+
+	// Status is one of RelationJoined, RelationBroken or
+	// RelationError.
+	Status string `json:"status,omitempty"`
+
+	// UnitsInError holds the names of the units whose hooks for the
+	// relation have failed.
+	UnitsInError []string `json:"units-in-error,omitempty"`
 }
 
+// These are the values of RelationStatus.Status.
+const (
+	// RelationJoined is the status of a live relation whose hooks
+	// are running normally.
+	RelationJoined = "joined"
+
+	// RelationBroken is the status of a relation that is being
+	// removed.
+	RelationBroken = "broken"
+
+	// RelationError is the status of a relation whose hooks have
+	// failed on one or more units.
+	RelationError = "error"
+)
+
 // EndpointStatus holds status info about a single endpoint.
 type EndpointStatus struct {
 	ApplicationName string `json:"application"`
@@ -198,6 +245,37 @@ type StatusHistoryResults struct {
 	Results []StatusHistoryResult `json:"results"`
 }
 
+// ModelStatusHistoryRequest holds the parameters used to query the
+// status history of the machines and units in a model.
+type ModelStatusHistoryRequest struct {
+	// From and To, if set, restrict the history to entries
+	// recorded in the given time range.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// Tags, if not empty, restricts the history to the given
+	// machines and units, and to the units of the given applications.
+	Tags []string `json:"tags,omitempty"`
+
+	// Size, if positive, restricts the history to the given
+	// number of most recent entries. The server limits the
+	// number of entries it returns whatever the size.
+	Size int `json:"size,omitempty"`
+}
+
+// EntityStatusHistoryEntry holds a status history entry for a
+// machine or unit.
+type EntityStatusHistoryEntry struct {
+	Tag    string         `json:"tag"`
+	Status DetailedStatus `json:"status"`
+}
+
+// ModelStatusHistoryResult holds the status history of a model,
+// oldest entry first.
+type ModelStatusHistoryResult struct {
+	Entries []EntityStatusHistoryEntry `json:"entries"`
+}
+
 // StatusHistoryPruneArgs holds arguments for status history
 // prunning process.
 type StatusHistoryPruneArgs struct {
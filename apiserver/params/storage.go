@@ -94,6 +94,10 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of block storage in MiB, as seen by the
+	// machine that the unit is assigned to.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -212,6 +216,27 @@ type VolumeParams struct {
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for growing a volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volume-tag"`
+	VolumeId  string `json:"volume-id"`
+	Provider  string `json:"provider"`
+	Size      uint64 `json:"size"`
+}
+
+// VolumeResizeParamsResult holds provisioning parameters for
+// resizing a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds provisioning parameters for
+// resizing multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
@@ -501,6 +526,47 @@ type StoragePoolsResults struct {
 	Results []StoragePoolsResult `json:"results,omitempty"`
 }
 
+// StorageUsage holds the number and total size, in MiB, of
+// provisioned volumes or filesystems, and how many of those are
+// attached to or detached from machines.
+type StorageUsage struct {
+	Provisioned     int    `json:"provisioned"`
+	ProvisionedSize uint64 `json:"provisioned-size"`
+	Attached        int    `json:"attached"`
+	AttachedSize    uint64 `json:"attached-size"`
+	Detached        int    `json:"detached"`
+	DetachedSize    uint64 `json:"detached-size"`
+}
+
+// StoragePoolUsage holds the storage consumption of a pool.
+type StoragePoolUsage struct {
+	// Name is the pool's name.
+	Name string `json:"name"`
+
+	// Provider is the type of storage provider the pool represents.
+	Provider string `json:"provider"`
+
+	// Total is the consumption of the pool by all storage.
+	Total StorageUsage `json:"total"`
+
+	// Applications holds the consumption of the pool by the
+	// storage of each application, keyed by application name.
+	// Storage not owned by an application is counted only in
+	// Total.
+	Applications map[string]StorageUsage `json:"applications,omitempty"`
+}
+
+// StoragePoolUsageResult holds a collection of storage pool usage.
+type StoragePoolUsageResult struct {
+	Result []StoragePoolUsage `json:"usage,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// StoragePoolUsageResults holds a collection of storage pool usage results.
+type StoragePoolUsageResults struct {
+	Results []StoragePoolUsageResult `json:"results,omitempty"`
+}
+
 // VolumeFilter holds a filter for volume list API call.
 type VolumeFilter struct {
 	// Machines are machine tags to filter on.
@@ -668,3 +734,47 @@ type StorageAddParams struct {
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// VolumeSnapshot describes a snapshot taken of a volume.
+type VolumeSnapshot struct {
+	// VolumeTag is the tag of the volume that was snapshotted.
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the provider ID of the volume.
+	VolumeId string `json:"volume-id"`
+
+	// SnapshotId is the provider ID of the snapshot, if one was taken.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+
+	// Error holds the reason no snapshot was taken, if any.
+	Error *Error `json:"error,omitempty"`
+}
+
+// SnapshotUnitStorageResult holds the snapshots taken of the volumes
+// backing a unit's storage.
+type SnapshotUnitStorageResult struct {
+	Snapshots []VolumeSnapshot `json:"snapshots,omitempty"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// SnapshotUnitStorageResults holds the results of a
+// Storage.SnapshotUnitStorage call.
+type SnapshotUnitStorageResults struct {
+	Results []SnapshotUnitStorageResult `json:"results"`
+}
+
+// StorageResizeParams holds the parameters for growing a storage
+// instance.
+type StorageResizeParams struct {
+	// StorageTag is the tag of the storage instance to grow.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage instance, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// StoragesResizeParams holds the parameters for a
+// Storage.ResizeStorage call.
+type StoragesResizeParams struct {
+	Storages []StorageResizeParams `json:"storages"`
+}
@@ -10,6 +10,10 @@ type UndertakerModelInfo struct {
 	GlobalName string `json:"global-name"`
 	IsSystem   bool   `json:"is-system"`
 	Life       Life   `json:"life"`
+
+	// ReleaseStorage reports whether the model's volumes are to be
+	// released, rather than destroyed, along with the model.
+	ReleaseStorage bool `json:"release-storage,omitempty"`
 }
 
 // UndertakerModelInfoResult holds the result of an API call that returns an
@@ -18,3 +22,17 @@ type UndertakerModelInfoResult struct {
 	Error  *Error              `json:"error,omitempty"`
 	Result UndertakerModelInfo `json:"result"`
 }
+
+// KnownCloudResources holds the ids of the cloud resources that a
+// model's state records.
+type KnownCloudResources struct {
+	// MachineIds holds the ids of all of the model's machines.
+	MachineIds []string `json:"machine-ids"`
+
+	// InstanceIds holds the ids of the provisioned machines'
+	// instances.
+	InstanceIds []string `json:"instance-ids"`
+
+	// VolumeIds holds the provider ids of the provisioned volumes.
+	VolumeIds []string `json:"volume-ids"`
+}
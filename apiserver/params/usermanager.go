@@ -5,6 +5,8 @@ package params
 
 import (
 	"time"
+
+	"gopkg.in/macaroon.v1"
 )
 
 // UserInfo holds information on a user.
@@ -67,3 +69,47 @@ type AddUserResult struct {
 	SecretKey []byte `json:"secret-key,omitempty"`
 	Error     *Error `json:"error,omitempty"`
 }
+
+// RefreshSessionResult holds the result of a RefreshSession call:
+// a macaroon that authenticates the calling user until Expiry
+// without requiring a discharge.
+type RefreshSessionResult struct {
+	Macaroon *macaroon.Macaroon `json:"macaroon"`
+	Expiry   time.Time          `json:"expiry"`
+}
+
+// UserQuota holds the limits on the total machines, units and
+// storage instances in the models owned by a user. A zero limit
+// means no limit.
+type UserQuota struct {
+	UserTag     string `json:"user-tag"`
+	MaxMachines int    `json:"max-machines,omitempty"`
+	MaxUnits    int    `json:"max-units,omitempty"`
+	MaxStorage  int    `json:"max-storage,omitempty"`
+}
+
+// UserQuotas holds the parameters for a SetUserQuotas call.
+type UserQuotas struct {
+	Quotas []UserQuota `json:"quotas"`
+}
+
+// QuotaUsage holds the numbers of machines, units and storage
+// instances counted against a quota.
+type QuotaUsage struct {
+	Machines int `json:"machines"`
+	Units    int `json:"units"`
+	Storage  int `json:"storage"`
+}
+
+// UserQuotaResult holds a user's quota and the usage counted against
+// it, or an error.
+type UserQuotaResult struct {
+	Quota *UserQuota  `json:"quota,omitempty"`
+	Usage *QuotaUsage `json:"usage,omitempty"`
+	Error *Error      `json:"error,omitempty"`
+}
+
+// UserQuotaResults holds the results of a UserQuotas call.
+type UserQuotaResults struct {
+	Results []UserQuotaResult `json:"results"`
+}
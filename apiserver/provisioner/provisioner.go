@@ -4,6 +4,7 @@
 package provisioner
 
 import (
+	"net"
 	"time"
 
 	"github.com/juju/errors"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -283,6 +285,13 @@ func (p *ProvisionerAPI) MachinesWithTransientErrors() (params.StatusResults, er
 	if err != nil {
 		return results, err
 	}
+	modelConfig, err := p.st.ModelConfig()
+	if err != nil {
+		return results, err
+	}
+	retryInterval := modelConfig.ProvisioningRetryInterval()
+	retryMaxAttempts := modelConfig.ProvisioningRetryMaxAttempts()
+	now := time.Now()
 	// TODO (wallyworld) - add state.State API for more efficient machines query
 	machines, err := p.st.AllMachines()
 	if err != nil {
@@ -309,7 +318,10 @@ func (p *ProvisionerAPI) MachinesWithTransientErrors() (params.StatusResults, er
 			continue
 		}
 		// Transient errors are marked as such in the status data.
-		if transient, ok := result.Data["transient"].(bool); !ok || !transient {
+		// Machines that could not be started are also retried once
+		// the model's provisioning retry interval has passed.
+		transient, _ := result.Data["transient"].(bool)
+		if !transient && !automaticRetryDue(statusInfo, retryInterval, retryMaxAttempts, now) {
 			continue
 		}
 		result.Id = machine.Id()
@@ -319,6 +331,32 @@ func (p *ProvisionerAPI) MachinesWithTransientErrors() (params.StatusResults, er
 	return results, nil
 }
 
+// automaticRetryDue reports whether a machine with the given error
+// status, left by the provisioner after failing to start its instance,
+// should now be provisioned again without waiting for
+// retry-provisioning.
+func automaticRetryDue(statusInfo status.StatusInfo, interval time.Duration, maxAttempts int, now time.Time) bool {
+	if interval <= 0 || statusInfo.Since == nil {
+		return false
+	}
+	if quarantined, _ := statusInfo.Data["quarantined"].(bool); !quarantined {
+		return false
+	}
+	var retries int
+	switch v := statusInfo.Data["automatic-retries"].(type) {
+	case int:
+		retries = v
+	case int64:
+		retries = int(v)
+	case float64:
+		retries = int(v)
+	}
+	if retries >= maxAttempts {
+		return false
+	}
+	return !now.Before(statusInfo.Since.Add(interval))
+}
+
 // Series returns the deployed series for each given machine entity.
 func (p *ProvisionerAPI) Series(args params.Entities) (params.StringResults, error) {
 	result := params.StringResults{
@@ -565,6 +603,11 @@ func (p *ProvisionerAPI) ReleaseContainerAddresses(args params.Entities) (params
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		if err := container.ReleaseStaticAddresses(); err != nil {
+			logger.Warningf("failed to release container %q static addresses: %v", tag, err)
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
 	}
 
 	return result, nil
@@ -714,12 +757,12 @@ func (p *ProvisionerAPI) prepareOrGetContainerInterfaceInfo(args params.Entities
 			continue
 		}
 
-		allocatedInfo, err := netEnviron.AllocateContainerAddresses(instId, machineTag, preparedInfo)
+		allocatedInfo, err := p.allocateContainerAddresses(netEnviron, instId, hostMachine, container, preparedInfo)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		logger.Debugf("got allocated info from provider: %+v", allocatedInfo)
+		logger.Debugf("got allocated info: %+v", allocatedInfo)
 
 		allocatedConfig := networkingcommon.NetworkConfigFromInterfaceInfo(allocatedInfo)
 		logger.Tracef("allocated network config: %+v", allocatedConfig)
@@ -732,7 +775,18 @@ func (p *ProvisionerAPI) prepareOrGetContainerInterfaceInfo(args params.Entities
 // for working with containers.
 func (p *ProvisionerAPI) prepareContainerAccessEnvironment() (environs.NetworkingEnviron, *state.Machine, common.AuthFunc, error) {
 	netEnviron, err := networkingcommon.NetworkingEnvironFromModelConfig(p.configGetter)
-	if err != nil {
+	if errors.IsNotSupported(err) {
+		// Container addresses may still be allocated from the
+		// model's declared ranges, if it has any.
+		ranges, rangesErr := p.containerIPRanges()
+		if rangesErr != nil {
+			return nil, nil, nil, errors.Trace(rangesErr)
+		}
+		if len(ranges) == 0 {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		netEnviron = nil
+	} else if err != nil {
 		return nil, nil, nil, errors.Trace(err)
 	}
 
@@ -755,6 +809,98 @@ func (p *ProvisionerAPI) prepareContainerAccessEnvironment() (environs.Networkin
 	return netEnviron, host, canAccess, nil
 }
 
+// allocateContainerAddresses asks the provider to allocate addresses
+// for the container's interfaces. If the provider cannot do so, each
+// interface connected to a subnet covered by the model's
+// container-ip-ranges is given a static address from that range.
+func (p *ProvisionerAPI) allocateContainerAddresses(
+	netEnviron environs.NetworkingEnviron,
+	hostInstanceID instance.Id,
+	hostMachine, container *state.Machine,
+	preparedInfo []network.InterfaceInfo,
+) ([]network.InterfaceInfo, error) {
+	notSupportedErr := errors.NotSupportedf("container address allocation")
+	if netEnviron != nil {
+		allocatedInfo, err := netEnviron.AllocateContainerAddresses(hostInstanceID, container.MachineTag(), preparedInfo)
+		if !errors.IsNotSupported(err) {
+			return allocatedInfo, errors.Trace(err)
+		}
+		notSupportedErr = err
+	}
+	ranges, err := p.containerIPRanges()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	allocatedInfo := make([]network.InterfaceInfo, len(preparedInfo))
+	allocated := false
+	for i, info := range preparedInfo {
+		allocatedInfo[i] = info
+		if info.CIDR == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(info.CIDR)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ipRange := range ranges {
+			if !ipRange.Within(ipNet) {
+				continue
+			}
+			address, err := container.AllocateStaticAddress(info.CIDR, ipRange.Low, ipRange.High)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			allocatedInfo[i].Address = network.NewAddress(address)
+			allocatedInfo[i].ConfigType = network.ConfigStatic
+			gateway, err := parentDeviceGateway(hostMachine, info.ParentInterfaceName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			allocatedInfo[i].GatewayAddress = gateway
+			allocated = true
+			break
+		}
+		if allocatedInfo[i].Address.Value == "" {
+			// No range covers the subnet, so the interface
+			// has to get its address from DHCP instead.
+			allocatedInfo[i].ConfigType = network.ConfigDHCP
+		}
+	}
+	if !allocated {
+		// Let the caller fall back to configuring the container
+		// with DHCP.
+		return nil, notSupportedErr
+	}
+	return allocatedInfo, nil
+}
+
+// containerIPRanges returns the model's declared container IP ranges.
+func (p *ProvisionerAPI) containerIPRanges() ([]config.IPRange, error) {
+	modelConfig, err := p.configGetter.ModelConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to get model config")
+	}
+	return modelConfig.ContainerIPRanges(), nil
+}
+
+// parentDeviceGateway returns the gateway address of the first
+// address of the named host device, if it has one.
+func parentDeviceGateway(hostMachine *state.Machine, deviceName string) (network.Address, error) {
+	device, err := hostMachine.LinkLayerDevice(deviceName)
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	addresses, err := device.Addresses()
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	if len(addresses) == 0 || addresses[0].GatewayAddress() == "" {
+		return network.Address{}, nil
+	}
+	return network.NewAddress(addresses[0].GatewayAddress()), nil
+}
+
 // InstanceStatus returns the instance status for each given entity.
 // Only machine tags are accepted.
 func (p *ProvisionerAPI) InstanceStatus(args params.Entities) (params.StatusResults, error) {
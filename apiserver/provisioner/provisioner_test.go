@@ -426,6 +426,39 @@ func (s *withoutControllerSuite) TestMachinesWithTransientErrors(c *gc.C) {
 	})
 }
 
+func (s *withoutControllerSuite) TestMachinesWithTransientErrorsAutomaticRetry(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"provisioning-retry-interval":     "1m",
+		"provisioning-retry-max-attempts": 2,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	earlier := now.Add(-2 * time.Minute)
+	setStatus := func(m *state.Machine, since *time.Time, data map[string]interface{}) {
+		err := m.SetStatus(status.StatusInfo{
+			Status:  status.Error,
+			Message: "provider error",
+			Data:    data,
+			Since:   since,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	// Machine 0 failed for another reason, and is not retried.
+	setStatus(s.machines[0], &earlier, nil)
+	// Machine 1 is due for an automatic retry.
+	setStatus(s.machines[1], &earlier, map[string]interface{}{"quarantined": true, "automatic-retries": 1})
+	// Machine 2 failed too recently.
+	setStatus(s.machines[2], &now, map[string]interface{}{"quarantined": true})
+	// Machine 3 has been retried as often as allowed.
+	setStatus(s.machines[3], &earlier, map[string]interface{}{"quarantined": true, "automatic-retries": 2})
+
+	result, err := s.provisioner.MachinesWithTransientErrors()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Id, gc.Equals, "1")
+	c.Assert(result.Results[0].Info, gc.Equals, "provider error")
+}
+
 func (s *withoutControllerSuite) TestMachinesWithTransientErrorsPermission(c *gc.C) {
 	// Machines where there's permission issues are omitted.
 	anAuthorizer := s.authorizer
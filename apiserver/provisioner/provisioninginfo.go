@@ -17,10 +17,12 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/state/multiwatcher"
@@ -89,21 +91,73 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
+	lxdProfiles, err := p.machineLXDProfiles(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get charm lxd profiles")
+	}
 
 	return &params.ProvisioningInfo{
-		Constraints:      cons,
-		Series:           m.Series(),
-		Placement:        m.Placement(),
-		Jobs:             jobs,
-		Volumes:          volumes,
-		Tags:             tags,
-		SubnetsToZones:   subnetsToZones,
-		EndpointBindings: endpointBindings,
-		ImageMetadata:    imageMetadata,
-		ControllerConfig: controllerCfg,
+		Constraints:       cons,
+		Series:            m.Series(),
+		Placement:         m.Placement(),
+		Jobs:              jobs,
+		Volumes:           volumes,
+		Tags:              tags,
+		SubnetsToZones:    subnetsToZones,
+		EndpointBindings:  endpointBindings,
+		ImageMetadata:     imageMetadata,
+		ControllerConfig:  controllerCfg,
+		CloudInitUserData: m.CloudInitUserData(),
+		CharmLXDProfiles:  lxdProfiles,
 	}, nil
 }
 
+// machineLXDProfiles returns the LXD profiles shipped with the charms
+// of the principal units assigned to the machine, keyed by the name
+// of the profile to create for each. Profiles are only returned for
+// LXD containers.
+func (p *ProvisionerAPI) machineLXDProfiles(m *state.Machine) (map[string]params.LXDProfile, error) {
+	if m.ContainerType() != instance.LXD {
+		return nil, nil
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := p.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var profiles map[string]params.LXDProfile
+	for _, unit := range units {
+		if !unit.IsPrincipal() {
+			continue
+		}
+		app, err := unit.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		profile := ch.LXDProfile()
+		if profile.Empty() {
+			continue
+		}
+		if profiles == nil {
+			profiles = make(map[string]params.LXDProfile)
+		}
+		name := lxdprofile.Name(model.UUID(), ch.URL(), ch.BundleSha256())
+		profiles[name] = params.LXDProfile{
+			Description: profile.Description,
+			Config:      profile.Config,
+			Devices:     profile.Devices,
+		}
+	}
+	return profiles, nil
+}
+
 // machineVolumeParams retrieves VolumeParams for the volumes that should be
 // provisioned with, and attached to, the machine. The client should ignore
 // parameters that it does not know how to handle.
@@ -128,6 +128,7 @@ func (api *ProxyUpdaterAPI) proxyConfig() params.ProxyConfigResult {
 
 	result.ProxySettings = proxyUtilsSettingsToProxySettingsParam(env.ProxySettings())
 	result.APTProxySettings = proxyUtilsSettingsToProxySettingsParam(env.AptProxySettings())
+	result.SnapProxySettings = proxyUtilsSettingsToProxySettingsParam(env.SnapProxySettings())
 
 	var noProxy []string
 	if result.ProxySettings.NoProxy != "" {
@@ -112,6 +112,8 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 			HTTP: "http proxy", HTTPS: "https proxy", FTP: "", NoProxy: noProxy},
 		APTProxySettings: params.ProxyConfig{
 			HTTP: "http://http proxy", HTTPS: "https://https proxy", FTP: "", NoProxy: ""},
+		SnapProxySettings: params.ProxyConfig{
+			HTTP: "http://http proxy", HTTPS: "https://https proxy", FTP: "", NoProxy: ""},
 	}
 	c.Assert(cfg.Results[0], jc.DeepEquals, r)
 }
@@ -136,6 +138,8 @@ func (s *ProxyUpdaterSuite) TestProxyConfigExtendsExisting(c *gc.C) {
 			HTTP: "http proxy", HTTPS: "https proxy", FTP: "", NoProxy: expectedNoProxy},
 		APTProxySettings: params.ProxyConfig{
 			HTTP: "http://http proxy", HTTPS: "https://https proxy", FTP: "", NoProxy: ""},
+		SnapProxySettings: params.ProxyConfig{
+			HTTP: "http://http proxy", HTTPS: "https://https proxy", FTP: "", NoProxy: ""},
 	})
 }
 
@@ -159,6 +163,21 @@ func (s *ProxyUpdaterSuite) TestProxyConfigNoDuplicates(c *gc.C) {
 			HTTP: "http proxy", HTTPS: "https proxy", FTP: "", NoProxy: expectedNoProxy},
 		APTProxySettings: params.ProxyConfig{
 			HTTP: "http://http proxy", HTTPS: "https://https proxy", FTP: "", NoProxy: ""},
+		SnapProxySettings: params.ProxyConfig{
+			HTTP: "http://http proxy", HTTPS: "https://https proxy", FTP: "", NoProxy: ""},
+	})
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigSnapProxy(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"http-proxy":       "http proxy",
+		"snap-http-proxy":  "snap http proxy",
+		"snap-https-proxy": "snap https proxy",
+	})
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	c.Assert(cfg.Results[0].Error, gc.IsNil)
+	c.Assert(cfg.Results[0].SnapProxySettings, jc.DeepEquals, params.ProxyConfig{
+		HTTP: "http://snap http proxy", HTTPS: "https://snap https proxy",
 	})
 }
 
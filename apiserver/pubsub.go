@@ -60,37 +60,34 @@ func (h *pubsubHandler) authenticate(req *http.Request) error {
 
 // ServeHTTP implements the http.Handler interface.
 func (h *pubsubHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	server := websocket.Server{
-		Handler: func(socket *websocket.Conn) {
-			logger.Debugf("start of *pubsubHandler.ServeHTTP")
-			defer socket.Close()
+	h.ctxt.keepalive().serve(w, req, false, func(socket *websocket.Conn) {
+		logger.Debugf("start of *pubsubHandler.ServeHTTP")
+		defer socket.Close()
 
-			if err := h.authenticate(req); err != nil {
-				h.sendError(socket, req, err)
-				return
-			}
+		if err := h.authenticate(req); err != nil {
+			h.sendError(socket, req, err)
+			return
+		}
 
-			// If we get to here, no more errors to report, so we report a nil
-			// error.  This way the first line of the socket is always a json
-			// formatted simple error.
-			h.sendError(socket, req, nil)
-
-			messageCh := h.receiveMessages(socket)
-			for {
-				select {
-				case <-h.ctxt.stop():
-					return
-				case m := <-messageCh:
-					logger.Tracef("topic: %q, data: %v", m.Topic, m.Data)
-					_, err := h.hub.Publish(pubsub.Topic(m.Topic), m.Data)
-					if err != nil {
-						logger.Errorf("publish failed: %v", err)
-					}
+		// If we get to here, no more errors to report, so we report a nil
+		// error.  This way the first line of the socket is always a json
+		// formatted simple error.
+		h.sendError(socket, req, nil)
+
+		messageCh := h.receiveMessages(socket)
+		for {
+			select {
+			case <-h.ctxt.stop():
+				return
+			case m := <-messageCh:
+				logger.Tracef("topic: %q, data: %v", m.Topic, m.Data)
+				_, err := h.hub.Publish(pubsub.Topic(m.Topic), m.Data)
+				if err != nil {
+					logger.Errorf("publish failed: %v", err)
 				}
 			}
-		},
-	}
-	server.ServeHTTP(w, req)
+		}
+	})
 }
 
 func (h *pubsubHandler) receiveMessages(socket *websocket.Conn) <-chan params.PubSubMessage {
@@ -32,6 +32,8 @@ type RebootAPI struct {
 
 func init() {
 	common.RegisterStandardFacade("Reboot", 2, NewRebootAPI)
+	// Version 3 adds GetRebootSchedule.
+	common.RegisterStandardFacade("Reboot", 3, NewRebootAPIV3)
 }
 
 // NewRebootAPI creates a new server-side RebootAPI facade.
@@ -64,6 +66,52 @@ func NewRebootAPI(st *state.State, resources facade.Resources, auth facade.Autho
 	}, nil
 }
 
+// RebootAPIV3 provides access to version 3 of the Reboot API facade.
+type RebootAPIV3 struct {
+	*RebootAPI
+}
+
+// NewRebootAPIV3 creates a new server-side Reboot API facade, version 3.
+func NewRebootAPIV3(st *state.State, resources facade.Resources, auth facade.Authorizer) (*RebootAPIV3, error) {
+	api, err := NewRebootAPI(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &RebootAPIV3{api}, nil
+}
+
+// GetRebootSchedule reports, for each machine, whether the action
+// returned by GetRebootAction is due only to scheduled reboot requests,
+// along with the model's maintenance window in which such reboots
+// should happen.
+func (r *RebootAPIV3) GetRebootSchedule(args params.Entities) (params.RebootScheduleResults, error) {
+	result := params.RebootScheduleResults{
+		Results: make([]params.RebootScheduleResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	cfg, err := r.st.ModelConfig()
+	if err != nil {
+		return params.RebootScheduleResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil || !r.auth.AuthOwner(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		scheduled, err := r.machine.RebootScheduled()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Scheduled = scheduled
+		result.Results[i].MaintenanceWindow = cfg.MaintenanceWindow()
+	}
+	return result, nil
+}
+
 // WatchForRebootEvent starts a watcher to track if there is a new
 // reboot request on the machines ID or any of its parents (in case we are a container).
 func (r *RebootAPI) WatchForRebootEvent() (params.NotifyWatchResult, error) {
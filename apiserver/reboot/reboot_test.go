@@ -336,3 +336,53 @@ func (s *rebootSuite) TestRebootRequestFromNestedContainer(c *gc.C) {
 	s.container.wc.AssertNoChange()
 	s.nestedContainer.wc.AssertOneChange()
 }
+
+func (s *rebootSuite) TestGetRebootSchedule(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"maintenance-window": "02:00-04:00",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rebootAPI, err := reboot.NewRebootAPIV3(s.State, s.machine.resources, s.machine.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := rebootAPI.GetRebootSchedule(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.RebootScheduleResults{
+		Results: []params.RebootScheduleResult{
+			{Scheduled: false, MaintenanceWindow: "02:00-04:00"},
+		}})
+
+	err = s.machine.machine.ScheduleReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine.wc.AssertOneChange()
+
+	res, err = rebootAPI.GetRebootSchedule(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.RebootScheduleResults{
+		Results: []params.RebootScheduleResult{
+			{Scheduled: true, MaintenanceWindow: "02:00-04:00"},
+		}})
+
+	action, err := rebootAPI.GetRebootAction(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action, gc.DeepEquals, params.RebootActionResults{
+		Results: []params.RebootActionResult{
+			{Result: params.ShouldReboot},
+		}})
+}
+
+func (s *rebootSuite) TestGetRebootScheduleOtherMachine(c *gc.C) {
+	rebootAPI, err := reboot.NewRebootAPIV3(s.State, s.machine.resources, s.machine.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := rebootAPI.GetRebootSchedule(params.Entities{Entities: []params.Entity{
+		{Tag: s.container.machine.Tag().String()},
+		{Tag: "application-mysql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.RebootScheduleResults{
+		Results: []params.RebootScheduleResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		}})
+}
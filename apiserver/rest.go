@@ -108,7 +108,7 @@ func (h *modelRestHandler) processRemoteApplication(r *http.Request, w http.Resp
 		return errors.Trace(err)
 	}
 
-	store := storage.NewStorage(sourceSt.ModelUUID(), sourceSt.MongoSession())
+	store := storage.NewStorage(sourceSt.ModelUUID(), sourceSt.MongoSession(), sourceSt.ObjectStores())
 	// Use the storage to retrieve and save the charm archive.
 	charmPath, err := common.ReadCharmFromStorage(store, h.dataDir, ch.StoragePath())
 	if errors.IsNotFound(err) {
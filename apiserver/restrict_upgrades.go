@@ -37,6 +37,9 @@ var allowedMethodsDuringUpgrades = map[string]set.Strings{
 		"AbortCurrentUpgrade", // for "juju upgrade-juju", so that we can reset upgrade to re-run
 
 	),
+	"EngineReport": set.NewStrings(
+		"Reports", // for inspecting agents that fail to upgrade
+	),
 	"SSHClient": set.NewStrings( // allow all SSH client related calls
 		"PublicAddress",
 		"PrivateAddress",
@@ -44,6 +47,7 @@ var allowedMethodsDuringUpgrades = map[string]set.Strings{
 		"AllAddresses",
 		"PublicKeys",
 		"Proxy",
+		"Bastion",
 	),
 	"Pinger": set.NewStrings(
 		"Ping",
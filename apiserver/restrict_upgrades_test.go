@@ -28,6 +28,7 @@ func (r *restrictUpgradesSuite) TestAllowedMethods(c *gc.C) {
 	}
 	checkAllowed("Client", "FullStatus")
 	checkAllowed("Client", "AbortCurrentUpgrade")
+	checkAllowed("EngineReport", "Reports")
 	checkAllowed("SSHClient", "PublicAddress")
 	checkAllowed("SSHClient", "Proxy")
 	checkAllowed("Pinger", "Ping")
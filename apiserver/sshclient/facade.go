@@ -20,6 +20,9 @@ func init() {
 
 	// Facade version 2 adds AllAddresses() method.
 	common.RegisterStandardFacade("SSHClient", 2, newFacade)
+
+	// Facade version 3 adds Bastion() method.
+	common.RegisterStandardFacade("SSHClient", 3, newFacade)
 }
 
 // Facade implements the API required by the sshclient worker.
@@ -185,3 +188,17 @@ func (facade *Facade) Proxy() (params.SSHProxyResult, error) {
 	}
 	return params.SSHProxyResult{UseProxy: config.ProxySSH()}, nil
 }
+
+// Bastion returns the host, if any, through which SSH connections to
+// the machines of the model associated with the API connection should
+// be made.
+func (facade *Facade) Bastion() (params.SSHBastionResult, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.SSHBastionResult{}, errors.Trace(err)
+	}
+	config, err := facade.backend.ModelConfig()
+	if err != nil {
+		return params.SSHBastionResult{}, errors.Trace(err)
+	}
+	return params.SSHBastionResult{Bastion: config.SSHBastion()}, nil
+}
@@ -173,9 +173,20 @@ func (s *facadeSuite) TestProxyFalse(c *gc.C) {
 	})
 }
 
+func (s *facadeSuite) TestBastion(c *gc.C) {
+	s.backend.bastion = "admin@jump.example.com"
+	result, err := s.facade.Bastion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Bastion, gc.Equals, "admin@jump.example.com")
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelConfig", []interface{}{}},
+	})
+}
+
 type mockBackend struct {
 	stub     jujutesting.Stub
 	proxySSH bool
+	bastion  string
 }
 
 func (backend *mockBackend) ModelTag() names.ModelTag {
@@ -186,6 +197,9 @@ func (backend *mockBackend) ModelConfig() (*config.Config, error) {
 	backend.stub.AddCall("ModelConfig")
 	attrs := testing.FakeConfig()
 	attrs["proxy-ssh"] = backend.proxySSH
+	if backend.bastion != "" {
+		attrs["ssh-bastion"] = backend.bastion
+	}
 	conf, err := config.New(config.NoDefaults, attrs)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package stagedconfig provides the facade used to stage several
+// application config changes and then apply them together.
+package stagedconfig
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("StagedConfig", 1, NewAPI)
+}
+
+// API implements the StagedConfig facade.
+type API struct {
+	st         *state.State
+	authorizer facade.Authorizer
+	check      *common.BlockChecker
+}
+
+// NewAPI returns a new StagedConfig facade.
+func NewAPI(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:         st,
+		authorizer: authorizer,
+		check:      common.NewBlockChecker(st),
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Stage validates the given config changes against the application's
+// charm and stages them, without changing the application's config.
+func (api *API) Stage(args params.ApplicationStageConfig) error {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.st.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changes := make(charm.Settings)
+	if args.SettingsYAML != "" {
+		parsed, err := ch.Config().ParseSettingsYAML([]byte(args.SettingsYAML), args.ApplicationName)
+		if err != nil {
+			return errors.Annotate(err, "parsing settings YAML")
+		}
+		for name, value := range parsed {
+			changes[name] = value
+		}
+	}
+	if len(args.Options) > 0 {
+		parsed, err := ch.Config().ParseSettingsStrings(args.Options)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for name, value := range parsed {
+			changes[name] = value
+		}
+	}
+	for _, name := range args.Reset {
+		changes[name] = nil
+	}
+	return errors.Trace(app.StageConfigSettings(changes))
+}
+
+// Staged returns the config changes staged for an application.
+func (api *API) Staged(args params.ApplicationStagedConfig) (params.ApplicationStagedConfigResults, error) {
+	result := params.ApplicationStagedConfigResults{Application: args.ApplicationName}
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return result, errors.Trace(err)
+	}
+	app, err := api.st.Application(args.ApplicationName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for name, value := range app.StagedConfigSettings() {
+		if value == nil {
+			result.Reset = append(result.Reset, name)
+			continue
+		}
+		if result.Settings == nil {
+			result.Settings = make(map[string]interface{})
+		}
+		result.Settings[name] = value
+	}
+	sort.Strings(result.Reset)
+	return result, nil
+}
+
+// Commit applies an application's staged config changes in a single
+// update, so that its units see one config change.
+func (api *API) Commit(args params.ApplicationStagedConfig) error {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.st.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.CommitStagedConfigSettings())
+}
+
+// Discard forgets an application's staged config changes.
+func (api *API) Discard(args params.ApplicationStagedConfig) error {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.st.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.DiscardStagedConfigSettings())
+}
@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stagedconfig_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	commontesting "github.com/juju/juju/apiserver/common/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/stagedconfig"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type StagedConfigSuite struct {
+	jujutesting.JujuConnSuite
+	commontesting.BlockHelper
+
+	app *state.Application
+	api *stagedconfig.API
+}
+
+var _ = gc.Suite(&StagedConfigSuite{})
+
+func (s *StagedConfigSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.BlockHelper = commontesting.NewBlockHelper(s.APIState)
+	s.AddCleanup(func(*gc.C) { s.BlockHelper.Close() })
+
+	ch := s.AddTestingCharm(c, "dummy")
+	s.app = s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "dummy", Charm: ch})
+	var err error
+	s.api, err = stagedconfig.NewAPI(s.State, common.NewResources(), apiservertesting.FakeAuthorizer{
+		Tag:      s.AdminUserTag(c),
+		AdminTag: s.AdminUserTag(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StagedConfigSuite) TestNewAPIRequiresClient(c *gc.C) {
+	api, err := stagedconfig.NewAPI(s.State, common.NewResources(), apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *StagedConfigSuite) TestStageRequiresWriteAccess(c *gc.C) {
+	api, err := stagedconfig.NewAPI(s.State, common.NewResources(), apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.Stage(params.ApplicationStageConfig{
+		ApplicationName: "dummy",
+		Options:         map[string]string{"title": "staged"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *StagedConfigSuite) TestStageAndCommit(c *gc.C) {
+	err := s.api.Stage(params.ApplicationStageConfig{
+		ApplicationName: "dummy",
+		Options:         map[string]string{"skill-level": "9"},
+		SettingsYAML:    "dummy:\n  title: staged\n",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.api.Stage(params.ApplicationStageConfig{
+		ApplicationName: "dummy",
+		Reset:           []string{"outlook"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	staged, err := s.api.Staged(params.ApplicationStagedConfig{ApplicationName: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(staged, jc.DeepEquals, params.ApplicationStagedConfigResults{
+		Application: "dummy",
+		Settings: map[string]interface{}{
+			"title":       "staged",
+			"skill-level": int64(9),
+		},
+		Reset: []string{"outlook"},
+	})
+	settings, err := s.app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	err = s.api.Commit(params.ApplicationStagedConfig{ApplicationName: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = s.app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{
+		"title":       "staged",
+		"skill-level": int64(9),
+	})
+}
+
+func (s *StagedConfigSuite) TestStageInvalid(c *gc.C) {
+	err := s.api.Stage(params.ApplicationStageConfig{
+		ApplicationName: "dummy",
+		Options:         map[string]string{"skill-level": "lots"},
+	})
+	c.Assert(err, gc.ErrorMatches, `option "skill-level" expected int, got "lots"`)
+}
+
+func (s *StagedConfigSuite) TestCommitBlocked(c *gc.C) {
+	err := s.api.Stage(params.ApplicationStageConfig{
+		ApplicationName: "dummy",
+		Options:         map[string]string{"title": "staged"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockAllChanges(c, "TestCommitBlocked")
+	err = s.api.Commit(params.ApplicationStagedConfig{ApplicationName: "dummy"})
+	s.AssertBlocked(c, err, "TestCommitBlocked")
+}
+
+func (s *StagedConfigSuite) TestDiscard(c *gc.C) {
+	err := s.api.Stage(params.ApplicationStageConfig{
+		ApplicationName: "dummy",
+		Options:         map[string]string{"title": "staged"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.api.Discard(params.ApplicationStagedConfig{ApplicationName: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	staged, err := s.api.Staged(params.ApplicationStagedConfig{ApplicationName: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(staged, jc.DeepEquals, params.ApplicationStagedConfigResults{Application: "dummy"})
+}
@@ -24,7 +24,7 @@ type baseStorageSuite struct {
 	resources  *common.Resources
 	authorizer testing.FakeAuthorizer
 
-	api   *storage.API
+	api   *storage.APIV6
 	state *mockState
 
 	storageTag      names.StorageTag
@@ -59,7 +59,7 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.poolManager = s.constructPoolManager()
 
 	var err error
-	s.api, err = storage.NewAPI(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
+	s.api, err = storage.NewAPIV6(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -86,6 +86,9 @@ const (
 	addStorageForUnitCall                   = "addStorageForUnit"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
+	unitStorageAttachmentsCall              = "unitStorageAttachments"
+	applicationUnitsCall                    = "applicationUnits"
+	resizeVolumeCall                        = "resizeVolume"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -162,6 +165,20 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.calls = append(s.calls, volumeAttachmentCall)
 			return s.volumeAttachment, nil
 		},
+		unitStorageAttachments: func(u names.UnitTag) ([]state.StorageAttachment, error) {
+			s.calls = append(s.calls, unitStorageAttachmentsCall)
+			if u == s.unitTag {
+				return []state.StorageAttachment{storageInstanceAttachment}, nil
+			}
+			return nil, errors.NotFoundf("%s", names.ReadableString(u))
+		},
+		applicationUnits: func(a names.ApplicationTag) ([]names.UnitTag, error) {
+			s.calls = append(s.calls, applicationUnitsCall)
+			if a.Id() == s.unitTag.ApplicationName() {
+				return []names.UnitTag{s.unitTag}, nil
+			}
+			return nil, errors.NotFoundf("%s", names.ReadableString(a))
+		},
 		unitAssignedMachine: func(u names.UnitTag) (names.MachineTag, error) {
 			s.calls = append(s.calls, unitAssignedMachineCall)
 			if u == s.unitTag {
@@ -224,6 +241,13 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.calls = append(s.calls, addStorageForUnitCall)
 			return nil
 		},
+		checkQuotas: func(add state.QuotaUsage) error {
+			return nil
+		},
+		resizeVolume: func(tag names.VolumeTag, size uint64) error {
+			s.calls = append(s.calls, resizeVolumeCall)
+			return nil
+		},
 		getBlockForType: func(t state.BlockType) (state.Block, bool, error) {
 			s.calls = append(s.calls, getBlockForTypeCall)
 			val, found := s.blocks[t]
@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -40,6 +41,8 @@ type mockState struct {
 	storageInstance                     func(names.StorageTag) (state.StorageInstance, error)
 	allStorageInstances                 func() ([]state.StorageInstance, error)
 	storageInstanceAttachments          func(names.StorageTag) ([]state.StorageAttachment, error)
+	unitStorageAttachments              func(names.UnitTag) ([]state.StorageAttachment, error)
+	applicationUnits                    func(names.ApplicationTag) ([]names.UnitTag, error)
 	unitAssignedMachine                 func(u names.UnitTag) (names.MachineTag, error)
 	storageInstanceVolume               func(names.StorageTag) (state.Volume, error)
 	volumeAttachment                    func(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
@@ -60,8 +63,11 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	checkQuotas                         func(add state.QuotaUsage) error
+	resizeVolume                        func(tag names.VolumeTag, size uint64) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
+	writeModelLog                       func(entity string, level loggo.Level, msg string) error
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -76,6 +82,14 @@ func (st *mockState) StorageAttachments(tag names.StorageTag) ([]state.StorageAt
 	return st.storageInstanceAttachments(tag)
 }
 
+func (st *mockState) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageAttachment, error) {
+	return st.unitStorageAttachments(tag)
+}
+
+func (st *mockState) ApplicationUnits(tag names.ApplicationTag) ([]names.UnitTag, error) {
+	return st.applicationUnits(tag)
+}
+
 func (st *mockState) UnitAssignedMachine(unit names.UnitTag) (names.MachineTag, error) {
 	return st.unitAssignedMachine(unit)
 }
@@ -156,10 +170,25 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) CheckQuotas(add state.QuotaUsage) error {
+	return st.checkQuotas(add)
+}
+
+func (st *mockState) ResizeVolume(tag names.VolumeTag, size uint64) error {
+	return st.resizeVolume(tag, size)
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
 
+func (st *mockState) WriteModelLog(entity string, level loggo.Level, msg string) error {
+	if st.writeModelLog != nil {
+		return st.writeModelLog(entity, level, msg)
+	}
+	return nil
+}
+
 func (st *mockState) BlockDevices(m names.MachineTag) ([]state.BlockDeviceInfo, error) {
 	if st.blockDevices != nil {
 		return st.blockDevices(m)
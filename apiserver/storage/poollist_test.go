@@ -167,7 +167,7 @@ func (s *poolSuite) TestListNoPools(c *gc.C) {
 }
 
 func (s *poolSuite) TestListFilterEmpty(c *gc.C) {
-	err := apiserverstorage.ValidatePoolListFilter(s.api, params.StoragePoolFilter{})
+	err := apiserverstorage.ValidatePoolListFilter(s.api.API, params.StoragePoolFilter{})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -181,14 +181,14 @@ const (
 func (s *poolSuite) TestListFilterValidProviders(c *gc.C) {
 	s.registerProviders(c)
 	err := apiserverstorage.ValidateProviderCriteria(
-		s.api,
+		s.api.API,
 		[]string{validProvider})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *poolSuite) TestListFilterUnregisteredProvider(c *gc.C) {
 	err := apiserverstorage.ValidateProviderCriteria(
-		s.api,
+		s.api.API,
 		[]string{validProvider})
 	c.Assert(err, gc.ErrorMatches, `storage provider "loop" not found`)
 }
@@ -196,21 +196,21 @@ func (s *poolSuite) TestListFilterUnregisteredProvider(c *gc.C) {
 func (s *poolSuite) TestListFilterUnknownProvider(c *gc.C) {
 	s.registerProviders(c)
 	err := apiserverstorage.ValidateProviderCriteria(
-		s.api,
+		s.api.API,
 		[]string{invalidProvider})
 	c.Assert(err, gc.ErrorMatches, `storage provider "invalid" not found`)
 }
 
 func (s *poolSuite) TestListFilterValidNames(c *gc.C) {
 	err := apiserverstorage.ValidateNameCriteria(
-		s.api,
+		s.api.API,
 		[]string{validName})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *poolSuite) TestListFilterInvalidNames(c *gc.C) {
 	err := apiserverstorage.ValidateNameCriteria(
-		s.api,
+		s.api.API,
 		[]string{invalidName})
 	c.Assert(err, gc.ErrorMatches, ".*not valid.*")
 }
@@ -218,7 +218,7 @@ func (s *poolSuite) TestListFilterInvalidNames(c *gc.C) {
 func (s *poolSuite) TestListFilterValidProvidersAndNames(c *gc.C) {
 	s.registerProviders(c)
 	err := apiserverstorage.ValidatePoolListFilter(
-		s.api,
+		s.api.API,
 		params.StoragePoolFilter{
 			Providers: []string{validProvider},
 			Names:     []string{validName}})
@@ -228,7 +228,7 @@ func (s *poolSuite) TestListFilterValidProvidersAndNames(c *gc.C) {
 func (s *poolSuite) TestListFilterValidProvidersAndInvalidNames(c *gc.C) {
 	s.registerProviders(c)
 	err := apiserverstorage.ValidatePoolListFilter(
-		s.api,
+		s.api.API,
 		params.StoragePoolFilter{
 			Providers: []string{validProvider},
 			Names:     []string{invalidName}})
@@ -237,7 +237,7 @@ func (s *poolSuite) TestListFilterValidProvidersAndInvalidNames(c *gc.C) {
 
 func (s *poolSuite) TestListFilterInvalidProvidersAndValidNames(c *gc.C) {
 	err := apiserverstorage.ValidatePoolListFilter(
-		s.api,
+		s.api.API,
 		params.StoragePoolFilter{
 			Providers: []string{invalidProvider},
 			Names:     []string{validName}})
@@ -246,7 +246,7 @@ func (s *poolSuite) TestListFilterInvalidProvidersAndValidNames(c *gc.C) {
 
 func (s *poolSuite) TestListFilterInvalidProvidersAndNames(c *gc.C) {
 	err := apiserverstorage.ValidatePoolListFilter(
-		s.api,
+		s.api.API,
 		params.StoragePoolFilter{
 			Providers: []string{invalidProvider},
 			Names:     []string{invalidName}})
@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolUsageSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolUsageSuite{})
+
+func (s *poolUsageSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	var err error
+	s.pools["fast"], err = storage.NewConfig("fast", provider.LoopProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["slow"], err = storage.NewConfig("slow", provider.LoopProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The volume is attached, and owned by the storage
+	// instance of mysql/0.
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-22", Pool: "fast", Size: 1024}
+
+	// The filesystem is detached, and not owned by any storage
+	// instance.
+	s.filesystem.storage = nil
+	s.filesystem.info = &state.FilesystemInfo{FilesystemId: "fs-104", Pool: "fast", Size: 512}
+	s.state.filesystemAttachments = func(names.FilesystemTag) ([]state.FilesystemAttachment, error) {
+		return nil, nil
+	}
+}
+
+func (s *poolUsageSuite) listPoolUsage(c *gc.C, filter params.StoragePoolFilter) []params.StoragePoolUsage {
+	results, err := s.api.ListPoolUsage(params.StoragePoolFilters{
+		Filters: []params.StoragePoolFilter{filter},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	return results.Results[0].Result
+}
+
+func (s *poolUsageSuite) TestListPoolUsage(c *gc.C) {
+	usage := s.listPoolUsage(c, params.StoragePoolFilter{Names: []string{"fast"}})
+	c.Assert(usage, jc.DeepEquals, []params.StoragePoolUsage{{
+		Name:     "fast",
+		Provider: "loop",
+		Total: params.StorageUsage{
+			Provisioned:     2,
+			ProvisionedSize: 1536,
+			Attached:        1,
+			AttachedSize:    1024,
+			Detached:        1,
+			DetachedSize:    512,
+		},
+		Applications: map[string]params.StorageUsage{
+			"mysql": {
+				Provisioned:     1,
+				ProvisionedSize: 1024,
+				Attached:        1,
+				AttachedSize:    1024,
+			},
+		},
+	}})
+}
+
+func (s *poolUsageSuite) TestListPoolUsageUnused(c *gc.C) {
+	usage := s.listPoolUsage(c, params.StoragePoolFilter{Names: []string{"slow"}})
+	c.Assert(usage, jc.DeepEquals, []params.StoragePoolUsage{{
+		Name:     "slow",
+		Provider: "loop",
+	}})
+}
+
+func (s *poolUsageSuite) TestListPoolUsageUnprovisioned(c *gc.C) {
+	s.volume.info = nil
+	s.filesystem.info = nil
+	usage := s.listPoolUsage(c, params.StoragePoolFilter{Names: []string{"fast"}})
+	c.Assert(usage, jc.DeepEquals, []params.StoragePoolUsage{{
+		Name:     "fast",
+		Provider: "loop",
+	}})
+}
+
+func (s *poolUsageSuite) TestListPoolUsageVolumeBackedFilesystem(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+	usage := s.listPoolUsage(c, params.StoragePoolFilter{Names: []string{"fast"}})
+	c.Assert(usage, gc.HasLen, 1)
+	c.Assert(usage[0].Total, jc.DeepEquals, params.StorageUsage{
+		Provisioned:     1,
+		ProvisionedSize: 1024,
+		Attached:        1,
+		AttachedSize:    1024,
+	})
+}
+
+func (s *poolUsageSuite) TestListPoolUsageInvalidFilter(c *gc.C) {
+	results, err := s.api.ListPoolUsage(params.StoragePoolFilters{
+		Filters: []params.StoragePoolFilter{{Names: []string{"7ool"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `pool name "7ool" not valid`)
+}
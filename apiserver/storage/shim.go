@@ -4,7 +4,10 @@
 package storage
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -22,6 +25,12 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Storage", 3, newAPI)
+	// Version 4 adds SnapshotUnitStorage.
+	common.RegisterStandardFacade("Storage", 4, newAPIV4)
+	// Version 5 adds ResizeStorage.
+	common.RegisterStandardFacade("Storage", 5, newAPIV5)
+	// Version 6 adds ListPoolUsage.
+	common.RegisterStandardFacade("Storage", 6, newAPIV6)
 }
 
 func newAPI(
@@ -38,6 +47,42 @@ func newAPI(
 	return NewAPI(getState(st), registry, pm, resources, authorizer)
 }
 
+func newAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV4, error) {
+	api, err := newAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{api}, nil
+}
+
+func newAPIV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV5, error) {
+	api, err := newAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV5{api}, nil
+}
+
+func newAPIV6(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV6, error) {
+	api, err := newAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV6{api}, nil
+}
+
 type storageAccess interface {
 	// StorageInstance is required for storage functionality.
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
@@ -48,6 +93,12 @@ type storageAccess interface {
 	// StorageAttachments is required for storage functionality.
 	StorageAttachments(names.StorageTag) ([]state.StorageAttachment, error)
 
+	// UnitStorageAttachments is required for storage snapshot functionality.
+	UnitStorageAttachments(names.UnitTag) ([]state.StorageAttachment, error)
+
+	// ApplicationUnits is required for storage snapshot functionality.
+	ApplicationUnits(names.ApplicationTag) ([]names.UnitTag, error)
+
 	// UnitAssignedMachine is required for storage functionality.
 	UnitAssignedMachine(names.UnitTag) (names.MachineTag, error)
 
@@ -111,8 +162,18 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// CheckQuotas is required to enforce storage quotas when
+	// adding storage.
+	CheckQuotas(add state.QuotaUsage) error
+
+	// ResizeVolume is required for storage resize functionality.
+	ResizeVolume(tag names.VolumeTag, size uint64) error
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
+
+	// WriteModelLog is required to record storage snapshots.
+	WriteModelLog(entity string, level loggo.Level, msg string) error
 }
 
 var getState = func(st *state.State) storageAccess {
@@ -123,6 +184,24 @@ type stateShim struct {
 	*state.State
 }
 
+// ApplicationUnits returns the tags of the units of the
+// specified application.
+func (s stateShim) ApplicationUnits(tag names.ApplicationTag) ([]names.UnitTag, error) {
+	app, err := s.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tags := make([]names.UnitTag, len(units))
+	for i, unit := range units {
+		tags[i] = unit.UnitTag()
+	}
+	return tags, nil
+}
+
 // UnitAssignedMachine returns the tag of the machine that the unit
 // is assigned to, or an error if the unit cannot be obtained or is
 // not assigned to a machine.
@@ -138,6 +217,14 @@ func (s stateShim) UnitAssignedMachine(tag names.UnitTag) (names.MachineTag, err
 	return names.NewMachineTag(mid), nil
 }
 
+// WriteModelLog writes a message to the model's log, attributed
+// to the given entity.
+func (s stateShim) WriteModelLog(entity string, level loggo.Level, msg string) error {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+	return errors.Trace(logger.Log(time.Now(), entity, "juju.apiserver.storage", "", level, msg))
+}
+
 // ModelName returns the name of Juju environment,
 // or an error if environment configuration is not retrievable.
 func (s stateShim) ModelName() (string, error) {
@@ -6,7 +6,10 @@
 package storage
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -21,6 +24,8 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.storage")
+
 // API implements the storage interface and is the concrete
 // implementation of the api end point.
 type API struct {
@@ -49,6 +54,70 @@ func NewAPI(
 		authorizer:  authorizer,
 	}, nil
 }
+
+// APIV4 implements version 4 of the storage facade, which adds
+// SnapshotUnitStorage.
+type APIV4 struct {
+	*API
+}
+
+// NewAPIV4 returns a new storage API facade, version 4.
+func NewAPIV4(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV4, error) {
+	api, err := NewAPI(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{api}, nil
+}
+
+// APIV5 implements version 5 of the storage facade, which adds
+// ResizeStorage.
+type APIV5 struct {
+	*APIV4
+}
+
+// NewAPIV5 returns a new storage API facade, version 5.
+func NewAPIV5(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV5, error) {
+	api, err := NewAPIV4(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV5{api}, nil
+}
+
+// APIV6 implements version 6 of the storage facade, which adds
+// ListPoolUsage.
+type APIV6 struct {
+	*APIV5
+}
+
+// NewAPIV6 returns a new storage API facade, version 6.
+func NewAPIV6(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV6, error) {
+	api, err := NewAPIV5(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV6{api}, nil
+}
+
 func (api *API) checkCanRead() error {
 	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.storage.ModelTag())
 	if err != nil {
@@ -364,6 +433,187 @@ func (a *API) CreatePool(p params.StoragePool) error {
 	return err
 }
 
+// ListPoolUsage reports how much of each storage pool is consumed by
+// provisioned volumes and filesystems, in total and by application,
+// and how much of that is attached to machines. Each filter produces
+// an independent list of pools, as with ListPools.
+func (a *APIV6) ListPoolUsage(
+	filters params.StoragePoolFilters,
+) (params.StoragePoolUsageResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StoragePoolUsageResults{}, errors.Trace(err)
+	}
+
+	results := params.StoragePoolUsageResults{
+		Results: make([]params.StoragePoolUsageResult, len(filters.Filters)),
+	}
+	if len(filters.Filters) == 0 {
+		return results, nil
+	}
+	usage, err := poolUsage(a.storage)
+	if err != nil {
+		return params.StoragePoolUsageResults{}, errors.Trace(err)
+	}
+	for i, filter := range filters.Filters {
+		pools, err := a.listPools(filter)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := make([]params.StoragePoolUsage, len(pools))
+		for j, pool := range pools {
+			result[j] = params.StoragePoolUsage{
+				Name:     pool.Name,
+				Provider: pool.Provider,
+			}
+			if u, ok := usage[pool.Name]; ok {
+				result[j].Total = u.Total
+				result[j].Applications = u.Applications
+			}
+		}
+		results.Results[i].Result = result
+	}
+	return results, nil
+}
+
+// poolUsage returns the usage of each pool with provisioned volumes or
+// filesystems, keyed by pool name. Filesystems backed by volumes are
+// not counted, as their volumes already are.
+func poolUsage(st storageAccess) (map[string]*params.StoragePoolUsage, error) {
+	counter := &poolUsageCounter{
+		st:           st,
+		usage:        make(map[string]*params.StoragePoolUsage),
+		applications: make(map[names.StorageTag]string),
+	}
+
+	volumes, err := st.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attachments, err := st.VolumeAttachments(v.VolumeTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageTag, err := v.StorageInstance()
+		if err != nil && !errors.IsNotAssigned(err) {
+			return nil, errors.Trace(err)
+		}
+		if err := counter.add(info.Pool, storageTag, info.Size, len(attachments) > 0); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	filesystems, err := st.AllFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, f := range filesystems {
+		if _, err := f.Volume(); err == nil {
+			continue
+		} else if err != state.ErrNoBackingVolume {
+			return nil, errors.Trace(err)
+		}
+		info, err := f.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attachments, err := st.FilesystemAttachments(f.FilesystemTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageTag, err := f.Storage()
+		if err != nil && !errors.IsNotAssigned(err) {
+			return nil, errors.Trace(err)
+		}
+		if err := counter.add(info.Pool, storageTag, info.Size, len(attachments) > 0); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return counter.usage, nil
+}
+
+// poolUsageCounter accumulates the usage of storage pools.
+type poolUsageCounter struct {
+	st    storageAccess
+	usage map[string]*params.StoragePoolUsage
+
+	// applications caches the name of the application owning each
+	// storage instance, or "" if it is not owned by an application.
+	applications map[names.StorageTag]string
+}
+
+// add records a provisioned volume or filesystem of the given size
+// in the given pool. The storage tag is the zero value if the volume
+// or filesystem is not assigned to a storage instance.
+func (c *poolUsageCounter) add(pool string, storageTag names.StorageTag, size uint64, attached bool) error {
+	usage, ok := c.usage[pool]
+	if !ok {
+		usage = &params.StoragePoolUsage{Name: pool}
+		c.usage[pool] = usage
+	}
+	addStorageUsage(&usage.Total, size, attached)
+	if storageTag.Id() == "" {
+		return nil
+	}
+	application, err := c.application(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if application == "" {
+		return nil
+	}
+	if usage.Applications == nil {
+		usage.Applications = make(map[string]params.StorageUsage)
+	}
+	applicationUsage := usage.Applications[application]
+	addStorageUsage(&applicationUsage, size, attached)
+	usage.Applications[application] = applicationUsage
+	return nil
+}
+
+// application returns the name of the application owning the
+// specified storage instance, or "" if there is none.
+func (c *poolUsageCounter) application(tag names.StorageTag) (string, error) {
+	if application, ok := c.applications[tag]; ok {
+		return application, nil
+	}
+	var application string
+	si, err := c.st.StorageInstance(tag)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	} else if err == nil {
+		switch owner := si.Owner().(type) {
+		case names.UnitTag:
+			application = owner.ApplicationName()
+		case names.ApplicationTag:
+			application = owner.Id()
+		}
+	}
+	c.applications[tag] = application
+	return application, nil
+}
+
+func addStorageUsage(usage *params.StorageUsage, size uint64, attached bool) {
+	usage.Provisioned++
+	usage.ProvisionedSize += size
+	if attached {
+		usage.Attached++
+		usage.AttachedSize += size
+	} else {
+		usage.Detached++
+		usage.DetachedSize += size
+	}
+}
+
 // ListVolumes lists volumes with the given filters. Each filter produces
 // an independent list of volumes, or an error if the filter is invalid
 // or the volumes could not be listed.
@@ -702,10 +952,199 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 			continue
 		}
 
-		err = a.storage.AddStorageForUnit(u, one.StorageName, paramsToState(one.Constraints))
+		cons := paramsToState(one.Constraints)
+		// AddStorageForUnit adds one storage instance if no
+		// count is specified.
+		count := int(cons.Count)
+		if count == 0 {
+			count = 1
+		}
+		if err := a.storage.CheckQuotas(state.QuotaUsage{Storage: count}); err != nil {
+			result[i] = params.ErrorResult{Error: common.ServerError(err)}
+			continue
+		}
+		err = a.storage.AddStorageForUnit(u, one.StorageName, cons)
 		if err != nil {
 			result[i] = params.ErrorResult{Error: common.ServerError(err)}
 		}
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// ResizeStorage requests that each of the specified block-kind storage
+// instances be grown to the given size, in MiB. The storage provisioner
+// grows the backing volumes, and the units that the storage is attached
+// to are notified with a storage-resized hook.
+// A "CHANGE" block can block this operation.
+func (a *APIV5) ResizeStorage(args params.StoragesResizeParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	// Check if changes are allowed and the operation may proceed.
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Storages))
+	for i, arg := range args.Storages {
+		err := a.resizeStorage(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *API) resizeStorage(arg params.StorageResizeParams) error {
+	tag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	storageInstance, err := a.storage.StorageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if storageInstance.Kind() != state.StorageKindBlock {
+		return errors.NotSupportedf(
+			"resizing %s storage", storageInstance.Kind(),
+		)
+	}
+	volume, err := a.storage.StorageInstanceVolume(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(a.storage.ResizeVolume(volume.VolumeTag(), arg.Size))
+}
+
+// SnapshotUnitStorage takes a snapshot of each volume backing the
+// storage attached to the specified units, so that the data can be
+// recovered after the units are removed. An application tag may be
+// supplied in place of a unit tag, in which case the storage of all
+// of the application's units is snapshotted. Volumes whose provider
+// cannot take snapshots are reported with an error, and do not
+// prevent the remaining volumes from being snapshotted.
+func (a *APIV4) SnapshotUnitStorage(args params.Entities) (params.SnapshotUnitStorageResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.SnapshotUnitStorageResults{}, errors.Trace(err)
+	}
+	results := make([]params.SnapshotUnitStorageResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		unitTags, err := a.snapshotUnitTags(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, unitTag := range unitTags {
+			snapshots, err := a.snapshotUnitStorage(unitTag)
+			if err != nil {
+				results[i].Error = common.ServerError(err)
+				break
+			}
+			results[i].Snapshots = append(results[i].Snapshots, snapshots...)
+		}
+	}
+	return params.SnapshotUnitStorageResults{Results: results}, nil
+}
+
+// snapshotUnitTags returns the tags of the units whose storage should
+// be snapshotted for the given unit or application tag.
+func (a *API) snapshotUnitTags(tagString string) ([]names.UnitTag, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		return []names.UnitTag{tag}, nil
+	case names.ApplicationTag:
+		return a.storage.ApplicationUnits(tag)
+	}
+	return nil, errors.NotValidf("%s", names.ReadableString(tag))
+}
+
+func (a *API) snapshotUnitStorage(unitTag names.UnitTag) ([]params.VolumeSnapshot, error) {
+	attachments, err := a.storage.UnitStorageAttachments(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var snapshots []params.VolumeSnapshot
+	for _, attachment := range attachments {
+		volume, err := a.storage.StorageInstanceVolume(attachment.StorageInstance())
+		if errors.IsNotFound(err) {
+			// Not backed by a volume; nothing to snapshot.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := volume.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshot := params.VolumeSnapshot{
+			VolumeTag: volume.VolumeTag().String(),
+			VolumeId:  info.VolumeId,
+		}
+		snapshotId, err := a.snapshotVolume(info.Pool, info.VolumeId)
+		if err != nil {
+			snapshot.Error = common.ServerError(err)
+		} else {
+			snapshot.SnapshotId = snapshotId
+			// Record the snapshot in the model's log, so that it can
+			// be found again after the unit and volume are gone.
+			msg := fmt.Sprintf(
+				"took snapshot %q of %s (%s) for %s",
+				snapshotId, names.ReadableString(volume.VolumeTag()),
+				info.VolumeId, names.ReadableString(unitTag),
+			)
+			logger.Infof("%s", msg)
+			if err := a.storage.WriteModelLog(a.authorizer.GetAuthTag().String(), loggo.INFO, msg); err != nil {
+				logger.Warningf("cannot record snapshot in model log: %v", err)
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// snapshotVolume takes a snapshot of the volume with the given provider
+// ID, from the given pool. Only volumes managed by model-scoped providers
+// can be snapshotted by the controller.
+func (a *API) snapshotVolume(poolName, volumeId string) (string, error) {
+	providerType, cfg, err := storagecommon.StoragePoolConfig(poolName, a.poolManager, a.registry)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if cfg.Name() == "" {
+		if cfg, err = storage.NewConfig(poolName, providerType, map[string]interface{}{}); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return "", errors.NotSupportedf("snapshots of %q volumes", providerType)
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	if !ok {
+		return "", errors.NotSupportedf("snapshots of %q volumes", providerType)
+	}
+	results, err := snapshotter.SnapshotVolumes([]string{volumeId})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return "", errors.Trace(results[0].Error)
+	}
+	return results[0].SnapshotId, nil
+}
@@ -156,3 +156,25 @@ func (s *storageAddSuite) TestStorageAddUnitNotFoundErr(c *gc.C) {
 	c.Assert(failures.Results[0].Error.Error(), gc.Matches, "sanity not found")
 	c.Assert(failures.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
 }
+
+func (s *storageAddSuite) TestStorageAddUnitQuotaExceeded(c *gc.C) {
+	var checked state.QuotaUsage
+	s.state.checkQuotas = func(add state.QuotaUsage) error {
+		checked = add
+		return &state.QuotaExceededError{Quota: "max-storage", Limit: 4}
+	}
+	count := uint64(2)
+	args := params.StorageAddParams{
+		UnitTag:     s.unitTag.String(),
+		StorageName: "data",
+		Constraints: params.StorageConstraints{Count: &count},
+	}
+	failures, err := s.api.AddToUnit(params.StoragesAddParams{[]params.StorageAddParams{args}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures.Results, gc.HasLen, 1)
+	c.Assert(failures.Results[0].Error, gc.ErrorMatches, `quota "max-storage" exceeded \(limit 4\)`)
+	c.Assert(failures.Results[0].Error, jc.Satisfies, params.IsCodeQuotaExceeded)
+	c.Assert(checked, gc.Equals, state.QuotaUsage{Storage: 2})
+
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type storageResizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageResizeSuite{})
+
+func (s *storageResizeSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.storageInstance.kind = state.StorageKindBlock
+}
+
+func (s *storageResizeSuite) resize(c *gc.C, args ...params.StorageResizeParams) params.ErrorResults {
+	results, err := s.api.ResizeStorage(params.StoragesResizeParams{Storages: args})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, len(args))
+	return results
+}
+
+func (s *storageResizeSuite) TestResizeStorage(c *gc.C) {
+	var resized []interface{}
+	s.state.resizeVolume = func(tag names.VolumeTag, size uint64) error {
+		s.calls = append(s.calls, resizeVolumeCall)
+		resized = append(resized, tag, size)
+		return nil
+	}
+	results := s.resize(c, params.StorageResizeParams{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	})
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(resized, jc.DeepEquals, []interface{}{s.volumeTag, uint64(2048)})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceVolumeCall,
+		resizeVolumeCall,
+	})
+}
+
+func (s *storageResizeSuite) TestResizeStorageError(c *gc.C) {
+	s.state.resizeVolume = func(names.VolumeTag, uint64) error {
+		return errors.New("no resize for you")
+	}
+	results := s.resize(c, params.StorageResizeParams{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	})
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "no resize for you")
+}
+
+func (s *storageResizeSuite) TestResizeStorageFilesystem(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindFilesystem
+	results := s.resize(c, params.StorageResizeParams{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	})
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "resizing filesystem storage not supported")
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall})
+}
+
+func (s *storageResizeSuite) TestResizeStorageInvalidTag(c *gc.C) {
+	results := s.resize(c,
+		params.StorageResizeParams{StorageTag: "machine-0", Size: 2048},
+		params.StorageResizeParams{StorageTag: "storage-foo-0", Size: 2048},
+	)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"machine-0" is not a valid storage tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `storage foo/0 not found`)
+}
+
+func (s *storageResizeSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{
+			StorageTag: s.storageTag.String(),
+			Size:       2048,
+		}},
+	})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
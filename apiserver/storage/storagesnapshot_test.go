@@ -0,0 +1,142 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+)
+
+type storageSnapshotSuite struct {
+	baseStorageSuite
+
+	volumeSource *snapshottingVolumeSource
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+// snapshottingVolumeSource is a dummy.VolumeSource that
+// also implements storage.VolumeSnapshotter.
+type snapshottingVolumeSource struct {
+	dummy.VolumeSource
+}
+
+func (s *snapshottingVolumeSource) SnapshotVolumes(volIds []string) ([]jujustorage.SnapshotVolumesResult, error) {
+	s.MethodCall(s, "SnapshotVolumes", volIds)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	results := make([]jujustorage.SnapshotVolumesResult, len(volIds))
+	for i, volId := range volIds {
+		results[i].SnapshotId = "snap-" + volId
+	}
+	return results, nil
+}
+
+func (s *storageSnapshotSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &snapshottingVolumeSource{}
+	s.registry.Providers["ebs"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["loop"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	}
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "ebs",
+	}
+}
+
+func (s *storageSnapshotSuite) snapshot(c *gc.C, tags ...string) params.SnapshotUnitStorageResults {
+	args := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag
+	}
+	results, err := s.api.SnapshotUnitStorage(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, len(tags))
+	return results
+}
+
+func (s *storageSnapshotSuite) TestSnapshotUnitStorage(c *gc.C) {
+	results := s.snapshot(c, s.unitTag.String())
+	c.Assert(results.Results[0], jc.DeepEquals, params.SnapshotUnitStorageResult{
+		Snapshots: []params.VolumeSnapshot{{
+			VolumeTag:  s.volumeTag.String(),
+			VolumeId:   "vol-0",
+			SnapshotId: "snap-vol-0",
+		}},
+	})
+	s.volumeSource.CheckCall(c, 0, "SnapshotVolumes", []string{"vol-0"})
+	s.assertCalls(c, []string{unitStorageAttachmentsCall, storageInstanceVolumeCall})
+}
+
+func (s *storageSnapshotSuite) TestSnapshotUnitStorageNotSupported(c *gc.C) {
+	s.volume.info.Pool = "loop"
+	results := s.snapshot(c, s.unitTag.String())
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Snapshots, gc.HasLen, 1)
+	snapshot := results.Results[0].Snapshots[0]
+	c.Assert(snapshot.SnapshotId, gc.Equals, "")
+	c.Assert(snapshot.Error, gc.ErrorMatches, `snapshots of "loop" volumes not supported`)
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSnapshotSuite) TestSnapshotUnitStorageError(c *gc.C) {
+	s.volumeSource.SetErrors(errors.New("no snapshot for you"))
+	results := s.snapshot(c, s.unitTag.String())
+	c.Assert(results.Results[0].Snapshots, gc.HasLen, 1)
+	c.Assert(results.Results[0].Snapshots[0].Error, gc.ErrorMatches, "no snapshot for you")
+}
+
+func (s *storageSnapshotSuite) TestSnapshotUnitStorageUnprovisioned(c *gc.C) {
+	s.volume.info = nil
+	results := s.snapshot(c, s.unitTag.String())
+	c.Assert(results.Results[0], jc.DeepEquals, params.SnapshotUnitStorageResult{})
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSnapshotSuite) TestSnapshotUnitStorageInvalidTag(c *gc.C) {
+	results := s.snapshot(c, "machine-0", names.NewUnitTag("foo/0").String())
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `machine 0 not valid`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit "foo/0" not found`)
+}
+
+func (s *storageSnapshotSuite) TestSnapshotApplicationStorage(c *gc.C) {
+	results := s.snapshot(c, names.NewApplicationTag("mysql").String())
+	c.Assert(results.Results[0], jc.DeepEquals, params.SnapshotUnitStorageResult{
+		Snapshots: []params.VolumeSnapshot{{
+			VolumeTag:  s.volumeTag.String(),
+			VolumeId:   "vol-0",
+			SnapshotId: "snap-vol-0",
+		}},
+	})
+	s.assertCalls(c, []string{applicationUnitsCall, unitStorageAttachmentsCall, storageInstanceVolumeCall})
+}
+
+func (s *storageSnapshotSuite) TestSnapshotUnitStorageWritesModelLog(c *gc.C) {
+	var messages []string
+	s.state.writeModelLog = func(entity string, level loggo.Level, msg string) error {
+		c.Check(entity, gc.Equals, s.authorizer.Tag.String())
+		c.Check(level, gc.Equals, loggo.INFO)
+		messages = append(messages, msg)
+		return nil
+	}
+	s.snapshot(c, s.unitTag.String())
+	c.Assert(messages, jc.DeepEquals, []string{
+		`took snapshot "snap-vol-0" of volume 22 (vol-0) for unit mysql/0`,
+	})
+}
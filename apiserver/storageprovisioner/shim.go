@@ -24,6 +24,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("StorageProvisioner", 3, newStorageProvisionerAPI)
+
+	// Version 4 adds ReleaseStorage.
+	common.RegisterStandardFacade("StorageProvisioner", 4, newStorageProvisionerAPI)
 }
 
 func newStorageProvisionerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPI, error) {
@@ -43,6 +46,7 @@ type Backend interface {
 	ControllerConfig() (controller.Config, error)
 	MachineInstanceId(names.MachineTag) (instance.Id, error)
 	ModelTag() names.ModelTag
+	ReleaseStorage() (bool, error)
 	BlockDevices(names.MachineTag) ([]state.BlockDeviceInfo, error)
 
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
@@ -56,6 +60,7 @@ type Backend interface {
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	WatchVolumeResizes() state.StringsWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 
@@ -94,6 +99,14 @@ func (s stateShim) MachineInstanceId(tag names.MachineTag) (instance.Id, error)
 	return m.InstanceId()
 }
 
+func (s stateShim) ReleaseStorage() (bool, error) {
+	m, err := s.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return m.ReleaseStorage(), nil
+}
+
 func (s stateShim) WatchMachine(tag names.MachineTag) (state.NotifyWatcher, error) {
 	m, err := s.Machine(tag.Id())
 	if err != nil {
@@ -26,7 +26,7 @@ type Server struct {
 	Addrs []string
 
 	*httptest.Server
-	newRoot func(modelUUID string) interface{}
+	newRoot func(modelUUID string) rpc.Root
 }
 
 // NewAPIServer serves RPC methods on a localhost HTTP server.
@@ -43,6 +43,16 @@ type Server struct {
 //
 // The returned server must be closed after use.
 func NewAPIServer(newRoot func(modelUUID string) interface{}) *Server {
+	return newServer(func(modelUUID string) rpc.Root {
+		return allVersions{
+			rpcreflect.ValueOf(reflect.ValueOf(newRoot(modelUUID))),
+		}
+	})
+}
+
+// newServer serves the rpc.Root returned by newRoot on a localhost
+// HTTP server, as described by NewAPIServer.
+func newServer(newRoot func(modelUUID string) rpc.Root) *Server {
 	tlsCert, err := tls.X509KeyPair([]byte(testing.ServerCert), []byte(testing.ServerKey))
 	if err != nil {
 		panic("bad key pair")
@@ -79,10 +89,7 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, modelUUID string) {
 	codec := jsoncodec.NewWebsocket(wsConn)
 	conn := rpc.NewConn(codec, &fakeobserver.Instance{})
 
-	root := allVersions{
-		rpcreflect.ValueOf(reflect.ValueOf(srv.newRoot(modelUUID))),
-	}
-	conn.ServeRoot(root, nil)
+	conn.ServeRoot(srv.newRoot(modelUUID), nil)
 	conn.Start()
 	<-conn.Dead()
 	conn.Close()
@@ -88,6 +88,8 @@ func nameBasedHasPermission(name string, operation permission.Access, target nam
 		perm = permission.AdminAccess
 	case strings.HasPrefix(name, string(permission.WriteAccess)):
 		perm = permission.WriteAccess
+	case strings.HasPrefix(name, string(permission.OperatorAccess)):
+		perm = permission.OperatorAccess
 	case strings.HasPrefix(name, string(permission.ReadAccess)):
 		perm = permission.ReadAccess
 	default:
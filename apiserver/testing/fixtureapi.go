@@ -0,0 +1,285 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/context"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/version"
+)
+
+// Fixture holds a recorded facade request, and the response
+// that should be served when a matching request is made.
+type Fixture struct {
+	// Facade, Version and Method identify the facade method
+	// that the fixture applies to.
+	Facade  string `json:"facade"`
+	Version int    `json:"version"`
+	Method  string `json:"method"`
+
+	// Id, if non-empty, restricts the fixture to requests made
+	// on the facade object with that id (e.g. a watcher id).
+	Id string `json:"id,omitempty"`
+
+	// Params, if non-empty, restricts the fixture to requests
+	// whose parameters are equivalent to the given JSON.
+	Params json.RawMessage `json:"params,omitempty"`
+
+	// Result holds the JSON result returned by the request.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error, if not nil, is returned instead of Result.
+	Error *params.Error `json:"error,omitempty"`
+}
+
+// ReadFixtures reads a JSON list of fixtures from the file
+// at the given path.
+func ReadFixtures(path string) ([]Fixture, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var fixtures []Fixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse fixtures in %q", path)
+	}
+	return fixtures, nil
+}
+
+// NewFixtureAPIServer serves the given fixtures on a localhost HTTP
+// server, so that programs using the api package can be tested
+// against a fake controller.
+//
+// Each request is answered with the first matching fixture that
+// has not yet been served; once all matching fixtures have been
+// served, the last of them is served again. This allows sequences
+// of responses, such as successive watcher events, to be recorded.
+// Requests with no matching fixture fail with a "not implemented"
+// error.
+//
+// Unless the fixtures include one for Admin.Login, logins are
+// accepted for any model, and report the facade versions
+// present in the fixtures.
+//
+// The returned server must be closed after use.
+func NewFixtureAPIServer(fixtures []Fixture) *Server {
+	served := &fixtureSet{
+		fixtures: fixtures,
+		used:     make([]bool, len(fixtures)),
+	}
+	return newServer(func(modelUUID string) rpc.Root {
+		return &fixtureRoot{
+			fixtures:  served,
+			modelUUID: modelUUID,
+		}
+	})
+}
+
+// fixtureSet records which fixtures have been served.
+type fixtureSet struct {
+	mu       sync.Mutex
+	fixtures []Fixture
+	used     []bool
+}
+
+// has reports whether any fixture applies to the given facade method.
+func (s *fixtureSet) has(facade string, version int, method string) bool {
+	for _, f := range s.fixtures {
+		if f.Facade == facade && f.Version == version && f.Method == method {
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the fixture that should be served for the given request.
+func (s *fixtureSet) next(facade string, version int, id, method string, args json.RawMessage) (Fixture, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last := -1
+	for i, f := range s.fixtures {
+		if f.Facade != facade || f.Version != version || f.Method != method {
+			continue
+		}
+		if f.Id != "" && f.Id != id {
+			continue
+		}
+		if len(f.Params) > 0 {
+			if equal, err := jsonEqual(f.Params, args); err != nil {
+				return Fixture{}, errors.Annotatef(err, "fixture %d", i)
+			} else if !equal {
+				continue
+			}
+		}
+		if !s.used[i] {
+			s.used[i] = true
+			return f, nil
+		}
+		last = i
+	}
+	if last == -1 {
+		return Fixture{}, &params.Error{
+			Message: fmt.Sprintf("no fixture matches %s(%d).%s request with params %s", facade, version, method, args),
+			Code:    params.CodeNotImplemented,
+		}
+	}
+	return s.fixtures[last], nil
+}
+
+// facades returns the facade versions present in the fixtures.
+func (s *fixtureSet) facades() []params.FacadeVersions {
+	versions := make(map[string]map[int]bool)
+	for _, f := range s.fixtures {
+		if versions[f.Facade] == nil {
+			versions[f.Facade] = make(map[int]bool)
+		}
+		versions[f.Facade][f.Version] = true
+	}
+	var result []params.FacadeVersions
+	for name, vs := range versions {
+		fv := params.FacadeVersions{Name: name}
+		for v := range vs {
+			fv.Versions = append(fv.Versions, v)
+		}
+		sort.Ints(fv.Versions)
+		result = append(result, fv)
+	}
+	sort.Sort(facadeVersionsByName(result))
+	return result
+}
+
+type facadeVersionsByName []params.FacadeVersions
+
+func (s facadeVersionsByName) Len() int           { return len(s) }
+func (s facadeVersionsByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+func (s facadeVersionsByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// jsonEqual reports whether the two JSON documents hold
+// equivalent values.
+func jsonEqual(a, b json.RawMessage) (bool, error) {
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &vb); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	return reflect.DeepEqual(va, vb), nil
+}
+
+// fixtureRoot implements rpc.Root by serving fixtures.
+type fixtureRoot struct {
+	fixtures  *fixtureSet
+	modelUUID string
+}
+
+// FindMethod is part of the rpc.Root interface.
+func (r *fixtureRoot) FindMethod(facade string, version int, method string) (rpcreflect.MethodCaller, error) {
+	if facade == "Admin" && method == "Login" && !r.fixtures.has(facade, version, method) {
+		return loginCaller{r}, nil
+	}
+	if !r.fixtures.has(facade, version, method) {
+		return nil, &rpcreflect.CallNotImplementedError{
+			RootMethod: facade,
+			Version:    version,
+			Method:     method,
+		}
+	}
+	return fixtureCaller{
+		fixtures: r.fixtures,
+		facade:   facade,
+		version:  version,
+		method:   method,
+	}, nil
+}
+
+// Kill is part of the rpc.Root interface.
+func (r *fixtureRoot) Kill() {}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// fixtureCaller implements rpcreflect.MethodCaller for a
+// facade method served from fixtures.
+type fixtureCaller struct {
+	fixtures *fixtureSet
+	facade   string
+	version  int
+	method   string
+}
+
+// ParamsType is part of the rpcreflect.MethodCaller interface.
+func (c fixtureCaller) ParamsType() reflect.Type {
+	return rawMessageType
+}
+
+// ResultType is part of the rpcreflect.MethodCaller interface.
+func (c fixtureCaller) ResultType() reflect.Type {
+	return rawMessageType
+}
+
+// Call is part of the rpcreflect.MethodCaller interface.
+func (c fixtureCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
+	args := arg.Interface().(json.RawMessage)
+	f, err := c.fixtures.next(c.facade, c.version, objId, c.method, args)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if f.Error != nil {
+		return reflect.Value{}, f.Error
+	}
+	result := f.Result
+	if len(result) == 0 {
+		result = json.RawMessage("{}")
+	}
+	return reflect.ValueOf(result), nil
+}
+
+// loginCaller implements rpcreflect.MethodCaller for the
+// default Admin.Login method.
+type loginCaller struct {
+	root *fixtureRoot
+}
+
+// ParamsType is part of the rpcreflect.MethodCaller interface.
+func (c loginCaller) ParamsType() reflect.Type {
+	return reflect.TypeOf(params.LoginRequest{})
+}
+
+// ResultType is part of the rpcreflect.MethodCaller interface.
+func (c loginCaller) ResultType() reflect.Type {
+	return reflect.TypeOf(params.LoginResult{})
+}
+
+// Call is part of the rpcreflect.MethodCaller interface.
+func (c loginCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
+	modelTag := testing.ModelTag
+	if names.IsValidModel(c.root.modelUUID) {
+		modelTag = names.NewModelTag(c.root.modelUUID)
+	}
+	return reflect.ValueOf(params.LoginResult{
+		ModelTag:      modelTag.String(),
+		ControllerTag: testing.ControllerTag.String(),
+		UserInfo: &params.AuthUserInfo{
+			DisplayName: "admin",
+			Identity:    names.NewUserTag("admin").String(),
+		},
+		Facades:       c.root.fixtures.facades(),
+		ServerVersion: version.Current.String(),
+	}), nil
+}
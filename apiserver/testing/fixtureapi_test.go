@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jtesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&fixtureAPISuite{})
+
+type fixtureAPISuite struct {
+	testing.IsolationSuite
+}
+
+var testFixtures = []apiservertesting.Fixture{{
+	Facade:  "Thing",
+	Version: 2,
+	Method:  "Get",
+	Params:  json.RawMessage(`{"name": "foo"}`),
+	Result:  json.RawMessage(`{"value": "first"}`),
+}, {
+	Facade:  "Thing",
+	Version: 2,
+	Method:  "Get",
+	Params:  json.RawMessage(`{"name": "foo"}`),
+	Result:  json.RawMessage(`{"value": "second"}`),
+}, {
+	Facade:  "Thing",
+	Version: 2,
+	Method:  "Get",
+	Params:  json.RawMessage(`{"name": "bar"}`),
+	Error:   &params.Error{Message: "bar not found", Code: params.CodeNotFound},
+}, {
+	Facade:  "Thing",
+	Version: 3,
+	Method:  "Watch",
+	Id:      "7",
+	Result:  json.RawMessage(`{"value": "changed"}`),
+}}
+
+type thingResult struct {
+	Value string `json:"value"`
+}
+
+func (s *fixtureAPISuite) open(c *gc.C, fixtures []apiservertesting.Fixture) api.Connection {
+	srv := apiservertesting.NewFixtureAPIServer(fixtures)
+	s.AddCleanup(func(*gc.C) { srv.Close() })
+	conn, err := api.Open(&api.Info{
+		Addrs:    srv.Addrs,
+		CACert:   jtesting.CACert,
+		ModelTag: names.NewModelTag(fakeUUID),
+	}, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn
+}
+
+func (s *fixtureAPISuite) TestLoginReportsFacades(c *gc.C) {
+	conn := s.open(c, testFixtures)
+	c.Assert(conn.AllFacadeVersions(), jc.DeepEquals, map[string][]int{
+		"Thing": {2, 3},
+	})
+	modelTag, ok := conn.ModelTag()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(modelTag.Id(), gc.Equals, fakeUUID)
+}
+
+func (s *fixtureAPISuite) TestCallServesFixturesInOrder(c *gc.C) {
+	conn := s.open(c, testFixtures)
+	for _, expect := range []string{"first", "second", "second"} {
+		var result thingResult
+		err := conn.APICall("Thing", 2, "", "Get", map[string]string{"name": "foo"}, &result)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.Value, gc.Equals, expect)
+	}
+}
+
+func (s *fixtureAPISuite) TestCallError(c *gc.C) {
+	conn := s.open(c, testFixtures)
+	var result thingResult
+	err := conn.APICall("Thing", 2, "", "Get", map[string]string{"name": "bar"}, &result)
+	c.Assert(err, gc.ErrorMatches, "bar not found")
+	c.Assert(params.IsCodeNotFound(err), jc.IsTrue)
+}
+
+func (s *fixtureAPISuite) TestCallMatchesId(c *gc.C) {
+	conn := s.open(c, testFixtures)
+	var result thingResult
+	err := conn.APICall("Thing", 3, "7", "Watch", nil, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Value, gc.Equals, "changed")
+
+	err = conn.APICall("Thing", 3, "8", "Watch", nil, &result)
+	c.Assert(err, gc.ErrorMatches, `no fixture matches Thing\(3\).Watch request .*`)
+	c.Assert(params.IsCodeNotImplemented(err), jc.IsTrue)
+}
+
+func (s *fixtureAPISuite) TestCallUnknownMethod(c *gc.C) {
+	conn := s.open(c, testFixtures)
+	err := conn.APICall("Thing", 2, "", "Set", nil, nil)
+	c.Assert(err, gc.ErrorMatches, `no such request - method Thing\(2\).Set is not implemented`)
+}
+
+func (s *fixtureAPISuite) TestReadFixtures(c *gc.C) {
+	data, err := json.Marshal(testFixtures)
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(c.MkDir(), "fixtures.json")
+	err = ioutil.WriteFile(path, data, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fixtures, err := apiservertesting.ReadFixtures(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fixtures, gc.HasLen, len(testFixtures))
+	c.Assert(fixtures[2].Error, jc.DeepEquals, testFixtures[2].Error)
+}
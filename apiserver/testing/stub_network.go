@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/juju/errors"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
@@ -508,6 +509,20 @@ func (sb *StubBacking) AddSubnet(subnetInfo networkingcommon.BackingSubnetInfo)
 	return fs, nil
 }
 
+func (sb *StubBacking) RemoveSubnet(cidr string) error {
+	sb.MethodCall(sb, "RemoveSubnet", cidr)
+	if err := sb.NextErr(); err != nil {
+		return err
+	}
+	for i, subnet := range sb.Subnets {
+		if subnet.CIDR() == cidr {
+			sb.Subnets = append(sb.Subnets[:i], sb.Subnets[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NotFoundf("subnet %q", cidr)
+}
+
 func (sb *StubBacking) AddSpace(name string, providerId network.Id, subnets []string, public bool) error {
 	sb.MethodCall(sb, "AddSpace", name, providerId, subnets, public)
 	if err := sb.NextErr(); err != nil {
@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"bytes"
+	"compress/gzip"
 	"crypto/sha256"
 	"fmt"
 	"io"
@@ -12,6 +13,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
@@ -25,6 +27,7 @@ import (
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/tools"
+	"github.com/juju/juju/tools/delta"
 )
 
 // toolsHandler handles tool upload through HTTPS in the API server.
@@ -58,6 +61,16 @@ func (h *toolsDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			}
 			return
 		}
+		if from := r.URL.Query().Get("from"); from != "" {
+			data, err := h.toolsDelta(st, from, tarball)
+			if err == nil {
+				if err := h.sendToolsDelta(w, http.StatusOK, data, tarball); err != nil {
+					logger.Errorf("%v", err)
+				}
+				return
+			}
+			logger.Debugf("sending complete tools for GET(%s): %v", r.URL, err)
+		}
 		if err := h.sendTools(w, http.StatusOK, tarball); err != nil {
 			logger.Errorf("%v", err)
 		}
@@ -186,6 +199,130 @@ func (h *toolsDownloadHandler) fetchAndCacheTools(v version.Binary, stor binarys
 	return ioutil.NopCloser(bytes.NewReader(data)), nil
 }
 
+// toolsDelta returns a delta that transforms the tools tarball with
+// the given "from" version, which must be held in tools storage, into
+// the requested tarball. Deltas are computed over the uncompressed
+// tar archives, and are cached as they are typically requested by
+// every agent in the model at once; concurrent requests for the same
+// delta wait for a single computation.
+func (h *toolsDownloadHandler) toolsDelta(st *state.State, from string, tarball []byte) ([]byte, error) {
+	fromVersion, err := version.ParseBinary(from)
+	if err != nil {
+		return nil, errors.Annotate(err, "error parsing base version")
+	}
+	storage, err := st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Annotate(err, "error getting tools storage")
+	}
+	defer storage.Close()
+	baseMetadata, err := storage.Metadata(fromVersion.String())
+	if err != nil {
+		return nil, errors.Annotate(err, "error reading base tools metadata")
+	}
+
+	key := fmt.Sprintf("%s-%x", baseMetadata.SHA256, sha256.Sum256(tarball))
+	return toolsDeltas.get(key, func() ([]byte, error) {
+		_, reader, err := storage.Open(fromVersion.String())
+		if err != nil {
+			return nil, errors.Annotate(err, "error opening base tools")
+		}
+		defer reader.Close()
+		baseTar, err := gunzip(reader)
+		if err != nil {
+			return nil, errors.Annotate(err, "error reading base tools")
+		}
+		targetTar, err := gunzip(bytes.NewReader(tarball))
+		if err != nil {
+			return nil, errors.Annotate(err, "error reading tools")
+		}
+		var buf bytes.Buffer
+		if err := delta.Diff(&buf, baseTar, targetTar); err != nil {
+			return nil, errors.Annotate(err, "error computing tools delta")
+		}
+		if buf.Len() >= len(tarball) {
+			return nil, errors.Errorf("delta from %s is no smaller than tools", fromVersion)
+		}
+		return buf.Bytes(), nil
+	})
+}
+
+// gunzip returns the uncompressed contents of the gzipped data read from r.
+func gunzip(r io.Reader) ([]byte, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer zr.Close()
+	return ioutil.ReadAll(zr)
+}
+
+// maxToolsDeltas is the number of tools deltas
+// held in memory by the API server.
+const maxToolsDeltas = 8
+
+// toolsDeltas caches the most recently computed tools deltas.
+var toolsDeltas = &toolsDeltaCache{
+	deltas: make(map[string]*toolsDeltaResult),
+}
+
+// toolsDeltaCache holds computed tools deltas, keyed by the
+// hashes of the tarballs they are computed between. Failures
+// are cached too, so that a delta that cannot be computed, or
+// that is no smaller than the tools, is not tried again by
+// every agent that asks for it.
+type toolsDeltaCache struct {
+	mu     sync.Mutex
+	keys   []string
+	deltas map[string]*toolsDeltaResult
+}
+
+// toolsDeltaResult holds the outcome of computing a tools delta;
+// done is closed once data and err are set.
+type toolsDeltaResult struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// get returns the delta cached with the given key, computing and
+// caching it if necessary. While a delta is being computed, other
+// callers asking for it wait for that computation to finish.
+func (c *toolsDeltaCache) get(key string, compute func() ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	result, ok := c.deltas[key]
+	if !ok {
+		result = &toolsDeltaResult{done: make(chan struct{})}
+		if len(c.keys) == maxToolsDeltas {
+			delete(c.deltas, c.keys[0])
+			c.keys = c.keys[1:]
+		}
+		c.keys = append(c.keys, key)
+		c.deltas[key] = result
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		result.data, result.err = compute()
+		close(result.done)
+	}
+	<-result.done
+	return result.data, result.err
+}
+
+// sendToolsDelta sends a tools delta to the client, along with the
+// SHA256 hash of the tarball it reconstructs, so that the client can
+// check the delta was computed for the tools it expects.
+func (h *toolsDownloadHandler) sendToolsDelta(w http.ResponseWriter, statusCode int, data, tarball []byte) error {
+	w.Header().Set("Content-Type", params.ContentTypeToolsDelta)
+	w.Header().Set(params.ToolsSHA256Header, fmt.Sprintf("%x", sha256.Sum256(tarball)))
+	w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(data); err != nil {
+		return errors.Annotate(err, "failed to write tools delta")
+	}
+	return nil
+}
+
 // sendTools streams the tools tarball to the client.
 func (h *toolsDownloadHandler) sendTools(w http.ResponseWriter, statusCode int, tarball []byte) error {
 	w.Header().Set("Content-Type", "application/x-tar-gz")
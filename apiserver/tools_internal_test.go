@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type toolsDeltaCacheSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&toolsDeltaCacheSuite{})
+
+func newToolsDeltaCache() *toolsDeltaCache {
+	return &toolsDeltaCache{deltas: make(map[string]*toolsDeltaResult)}
+}
+
+func (s *toolsDeltaCacheSuite) TestConcurrentRequestsComputeOnce(c *gc.C) {
+	cache := newToolsDeltaCache()
+	release := make(chan struct{})
+	var mu sync.Mutex
+	computed := 0
+	compute := func() ([]byte, error) {
+		mu.Lock()
+		computed++
+		mu.Unlock()
+		<-release
+		return []byte("delta"), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := cache.get("key", compute)
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(string(data), gc.Equals, "delta")
+		}()
+	}
+	close(release)
+	wg.Wait()
+	c.Assert(computed, gc.Equals, 1)
+}
+
+func (s *toolsDeltaCacheSuite) TestCachesFailures(c *gc.C) {
+	cache := newToolsDeltaCache()
+	computed := 0
+	compute := func() ([]byte, error) {
+		computed++
+		return nil, errors.New("no smaller")
+	}
+	for i := 0; i < 2; i++ {
+		_, err := cache.get("key", compute)
+		c.Assert(err, gc.ErrorMatches, "no smaller")
+	}
+	c.Assert(computed, gc.Equals, 1)
+}
+
+func (s *toolsDeltaCacheSuite) TestEvictsOldest(c *gc.C) {
+	cache := newToolsDeltaCache()
+	computed := make(map[string]int)
+	get := func(key string) {
+		_, err := cache.get(key, func() ([]byte, error) {
+			computed[key]++
+			return []byte(key), nil
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for i := 0; i <= maxToolsDeltas; i++ {
+		get(fmt.Sprint(i))
+	}
+	get("1")
+	get("0")
+	c.Assert(computed["0"], gc.Equals, 2)
+	c.Assert(computed["1"], gc.Equals, 1)
+}
@@ -4,6 +4,8 @@
 package apiserver_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
@@ -37,6 +39,7 @@ import (
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 	coretools "github.com/juju/juju/tools"
+	"github.com/juju/juju/tools/delta"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -485,6 +488,61 @@ func (s *toolsSuite) TestDownloadFetchesAndVerifiesHash(c *gc.C) {
 	s.assertToolsNotStored(c, tools.Version.String())
 }
 
+func (s *toolsSuite) storeFakeTarball(c *gc.C, vers string, files ...*testing.TarFile) (*coretools.Tools, []byte) {
+	data, checksum := testing.TarGz(files...)
+	tools := s.storeFakeTools(c, s.State, string(data), binarystorage.Metadata{
+		Version: vers,
+		Size:    int64(len(data)),
+		SHA256:  checksum,
+	})
+	return tools, data
+}
+
+func (s *toolsSuite) TestDownloadDelta(c *gc.C) {
+	jujud := strings.Repeat("jujud 1.2.3 ", 5000)
+	baseTools, baseData := s.storeFakeTarball(c, "1.2.3-trusty-amd64",
+		testing.NewTarFile("jujud", 0755, jujud),
+	)
+	_, data := s.storeFakeTarball(c, "1.2.4-trusty-amd64",
+		testing.NewTarFile("jujud", 0755, jujud+"jujud 1.2.4"),
+	)
+
+	url := s.toolsURL(c, "from="+baseTools.Version.String())
+	url.Path = "/tools/1.2.4-trusty-amd64"
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: url.String()})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, params.ContentTypeToolsDelta)
+	c.Assert(resp.Header.Get(params.ToolsSHA256Header), gc.Equals, fmt.Sprintf("%x", sha256.Sum256(data)))
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(body) < len(data), jc.IsTrue)
+
+	target, err := delta.Patch(gunzip(c, baseData), bytes.NewReader(body))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target, jc.DeepEquals, gunzip(c, data))
+}
+
+func (s *toolsSuite) TestDownloadDeltaUnknownBase(c *gc.C) {
+	tools, data := s.storeFakeTarball(c, "1.2.4-trusty-amd64",
+		testing.NewTarFile("jujud", 0755, "jujud 1.2.4"),
+	)
+
+	url := s.toolsURL(c, "from=1.2.3-trusty-amd64")
+	url.Path = "/tools/" + tools.Version.String()
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: url.String()})
+	s.assertGetFileResponse(c, resp, string(data), "application/x-tar-gz")
+}
+
+func gunzip(c *gc.C, data []byte) []byte {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	defer zr.Close()
+	result, err := ioutil.ReadAll(zr)
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
 func (s *toolsSuite) storeFakeTools(c *gc.C, st *state.State, content string, metadata binarystorage.Metadata) *coretools.Tools {
 	storage, err := st.ToolsStorage()
 	c.Assert(err, jc.ErrorIsNil)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/apiserver/undertaker"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 )
@@ -23,6 +24,7 @@ type mockState struct {
 	isSystem bool
 	machines []undertaker.Machine
 	services []undertaker.Service
+	volumes  []state.Volume
 }
 
 var _ undertaker.State = (*mockState)(nil)
@@ -86,6 +88,10 @@ func (m *mockState) AllApplications() ([]undertaker.Service, error) {
 	return m.services, nil
 }
 
+func (m *mockState) AllVolumes() ([]state.Volume, error) {
+	return m.volumes, nil
+}
+
 func (m *mockState) IsController() bool {
 	return m.isSystem
 }
@@ -114,6 +120,8 @@ type mockModel struct {
 	name  string
 	uuid  string
 
+	releaseStorage bool
+
 	status     status.Status
 	statusInfo string
 	statusData map[string]interface{}
@@ -129,6 +137,10 @@ func (m *mockModel) Life() state.Life {
 	return m.life
 }
 
+func (m *mockModel) ReleaseStorage() bool {
+	return m.releaseStorage
+}
+
 func (m *mockModel) Tag() names.Tag {
 	return names.NewModelTag(m.uuid)
 }
@@ -154,8 +166,21 @@ func (m *mockModel) SetStatus(sInfo status.StatusInfo) error {
 }
 
 type mockMachine struct {
-	watcher state.NotifyWatcher
-	err     error
+	id         string
+	instanceId instance.Id
+	watcher    state.NotifyWatcher
+	err        error
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instanceId, nil
 }
 
 func (m *mockMachine) Watch() state.NotifyWatcher {
@@ -171,6 +196,18 @@ func (s *mockService) Watch() state.NotifyWatcher {
 	return s.watcher
 }
 
+type mockVolume struct {
+	state.Volume
+	volumeId string
+}
+
+func (v *mockVolume) Info() (state.VolumeInfo, error) {
+	if v.volumeId == "" {
+		return state.VolumeInfo{}, errors.NotProvisionedf("volume")
+	}
+	return state.VolumeInfo{VolumeId: v.volumeId}, nil
+}
+
 type mockWatcher struct {
 	state.NotifyWatcher
 	changes chan struct{}
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 )
 
@@ -37,6 +38,9 @@ type State interface {
 	// AllApplications returns all deployed services in the model.
 	AllApplications() ([]Service, error)
 
+	// AllVolumes returns all volumes in the model.
+	AllVolumes() ([]state.Volume, error)
+
 	// ModelConfig retrieves the model configuration.
 	ModelConfig() (*config.Config, error)
 }
@@ -62,6 +66,13 @@ func (s *stateShim) AllMachines() ([]Machine, error) {
 // Machine defines the needed methods of state.Machine for
 // the work of the undertaker API.
 type Machine interface {
+	// Id returns the machine id.
+	Id() string
+
+	// InstanceId returns the provider specific instance id for the
+	// machine, or a NotProvisioned error.
+	InstanceId() (instance.Id, error)
+
 	// Watch returns a watcher for observing changes to a machine.
 	Watch() state.NotifyWatcher
 }
@@ -108,6 +119,10 @@ type Model interface {
 	// UUID returns the universally unique identifier of the model.
 	UUID() string
 
+	// ReleaseStorage reports whether the model's volumes are to be
+	// released, rather than destroyed, along with the model.
+	ReleaseStorage() bool
+
 	// Destroy sets the model's lifecycle to Dying, preventing
 	// addition of services or machines to state.
 	Destroy() error
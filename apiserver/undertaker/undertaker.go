@@ -16,6 +16,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Undertaker", 1, NewUndertakerAPI)
+
+	// Version 2 adds KnownCloudResources.
+	common.RegisterStandardFacade("Undertaker", 2, NewUndertakerAPIV2)
 }
 
 // UndertakerAPI implements the API used by the model undertaker worker.
@@ -58,6 +61,22 @@ func newUndertakerAPI(st State, resources facade.Resources, authorizer facade.Au
 	}, nil
 }
 
+// UndertakerAPIV2 implements version 2 of the API used by the model
+// undertaker worker.
+type UndertakerAPIV2 struct {
+	*UndertakerAPI
+}
+
+// NewUndertakerAPIV2 creates a new instance of version 2 of the
+// undertaker API.
+func NewUndertakerAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UndertakerAPIV2, error) {
+	api, err := NewUndertakerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UndertakerAPIV2{api}, nil
+}
+
 // ModelInfo returns information on the model needed by the undertaker worker.
 func (u *UndertakerAPI) ModelInfo() (params.UndertakerModelInfoResult, error) {
 	result := params.UndertakerModelInfoResult{}
@@ -73,6 +92,8 @@ func (u *UndertakerAPI) ModelInfo() (params.UndertakerModelInfoResult, error) {
 		Name:       env.Name(),
 		IsSystem:   u.st.IsController(),
 		Life:       params.Life(env.Life().String()),
+
+		ReleaseStorage: env.ReleaseStorage(),
 	}
 
 	return result, nil
@@ -142,3 +163,38 @@ func (u *UndertakerAPI) ModelConfig() (params.ModelConfigResult, error) {
 	result.Config = allAttrs
 	return result, nil
 }
+
+// KnownCloudResources returns the ids of the machines, instances and
+// volumes that the model's state records, so that they can be compared
+// with the resources that the cloud holds for the model.
+func (u *UndertakerAPIV2) KnownCloudResources() (params.KnownCloudResources, error) {
+	var result params.KnownCloudResources
+	machines, err := u.st.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range machines {
+		result.MachineIds = append(result.MachineIds, m.Id())
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.InstanceIds = append(result.InstanceIds, string(instId))
+	}
+	volumes, err := u.st.AllVolumes()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.VolumeIds = append(result.VolumeIds, info.VolumeId)
+	}
+	return result, nil
+}
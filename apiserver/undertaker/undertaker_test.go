@@ -87,9 +87,20 @@ func (s *undertakerSuite) TestEnvironInfo(c *gc.C) {
 		c.Assert(info.Name, gc.Equals, test.envName)
 		c.Assert(info.IsSystem, gc.Equals, test.isSystem)
 		c.Assert(info.Life, gc.Equals, params.Dying)
+		c.Assert(info.ReleaseStorage, jc.IsFalse)
 	}
 }
 
+func (s *undertakerSuite) TestModelInfoReleaseStorage(c *gc.C) {
+	st, api := s.setupStateAndAPI(c, false, "hostedenv")
+	st.env.releaseStorage = true
+
+	result, err := api.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result.ReleaseStorage, jc.IsTrue)
+}
+
 func (s *undertakerSuite) TestProcessDyingEnviron(c *gc.C) {
 	otherSt, hostedAPI := s.setupStateAndAPI(c, false, "hostedenv")
 	env, err := otherSt.Model()
@@ -197,3 +208,25 @@ func (s *undertakerSuite) TestSetStatusNonControllerPermissions(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
 }
+
+func (s *undertakerSuite) TestKnownCloudResources(c *gc.C) {
+	mock, hostedAPI := s.setupStateAndAPI(c, false, "hostedenv")
+	mock.machines = []undertaker.Machine{
+		&mockMachine{id: "0", instanceId: "i-0"},
+		&mockMachine{id: "0/lxd/0", instanceId: "juju-lxd-0"},
+		&mockMachine{id: "1"},
+	}
+	mock.volumes = []state.Volume{
+		&mockVolume{volumeId: "vol-0"},
+		&mockVolume{},
+	}
+
+	api := &undertaker.UndertakerAPIV2{hostedAPI}
+	result, err := api.KnownCloudResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.KnownCloudResources{
+		MachineIds:  []string{"0", "0/lxd/0", "1"},
+		InstanceIds: []string{"i-0", "juju-lxd-0"},
+		VolumeIds:   []string{"vol-0"},
+	})
+}
@@ -7,6 +7,8 @@ package uniter
 
 import (
 	"fmt"
+	"net"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -19,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/meterstatus"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -29,6 +32,592 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+	// Version 5 adds MaxRelationDataSize.
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV5)
+	// Version 6 adds ScheduleReboot.
+	common.RegisterStandardFacade("Uniter", 6, NewUniterAPIV6)
+	// Version 7 adds UnitDrainTimeout.
+	common.RegisterStandardFacade("Uniter", 7, NewUniterAPIV7)
+	// Version 8 adds AddStatusEvents.
+	common.RegisterStandardFacade("Uniter", 8, NewUniterAPIV8)
+	// Version 9 adds NetworkInfo.
+	common.RegisterStandardFacade("Uniter", 9, NewUniterAPIV9)
+	// Version 10 adds SetSecrets and GetSecrets.
+	common.RegisterStandardFacade("Uniter", 10, NewUniterAPIV10)
+	// Version 11 adds ReadApplicationSettings and UpdateApplicationSettings.
+	common.RegisterStandardFacade("Uniter", 11, NewUniterAPIV11)
+	// Version 12 adds UnitPayloads.
+	common.RegisterStandardFacade("Uniter", 12, NewUniterAPIV12)
+}
+
+// UniterAPIV12 implements the API version 12, used by the uniter worker.
+type UniterAPIV12 struct {
+	*UniterAPIV11
+}
+
+// NewUniterAPIV12 creates a new instance of the Uniter API, version 12.
+func NewUniterAPIV12(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV12, error) {
+	baseAPI, err := NewUniterAPIV11(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV12{baseAPI}, nil
+}
+
+// UnitPayloads returns the payloads tracked by each given unit, so
+// that a dying unit can clean up their workloads before its payload
+// records are removed with it.
+func (u *UniterAPIV12) UnitPayloads(args params.Entities) (params.UnitPayloadsResults, error) {
+	result := params.UnitPayloadsResults{
+		Results: make([]params.UnitPayloadsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UnitPayloadsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		payloads, err := u.oneUnitPayloads(canAccess, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Payloads = payloads
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV12) oneUnitPayloads(canAccess common.AuthFunc, unitTag string) ([]params.UnitPayload, error) {
+	tag, err := names.ParseUnitTag(unitTag)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitPayloads, err := u.st.UnitPayloads(unit)
+	if errors.IsNotAssigned(err) {
+		// A unit that was never assigned cannot have payloads.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := unitPayloads.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var payloads []params.UnitPayload
+	for _, result := range results {
+		if result.Payload == nil {
+			continue
+		}
+		payloads = append(payloads, params.UnitPayload{
+			Class: result.Payload.Name,
+			Type:  result.Payload.Type,
+			ID:    result.Payload.ID,
+		})
+	}
+	return payloads, nil
+}
+
+// UniterAPIV11 implements the API version 11, used by the uniter worker.
+type UniterAPIV11 struct {
+	*UniterAPIV10
+}
+
+// NewUniterAPIV11 creates a new instance of the Uniter API, version 11.
+func NewUniterAPIV11(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV11, error) {
+	baseAPI, err := NewUniterAPIV10(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV11{baseAPI}, nil
+}
+
+// ReadApplicationSettings returns the settings that the leaders of the
+// given applications have published in the given relations. A unit may
+// read the settings of any application in a relation it takes part in.
+func (u *UniterAPIV11) ReadApplicationSettings(args params.RelationUnitApplications) (params.SettingsResults, error) {
+	result := params.SettingsResults{
+		Results: make([]params.SettingsResult, len(args.RelationUnitApplications)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SettingsResults{}, err
+	}
+	for i, arg := range args.RelationUnitApplications {
+		settings, err := u.readOneApplicationSettings(canAccess, arg)
+		if err == nil {
+			result.Results[i].Settings, err = convertRelationSettings(settings)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV11) readOneApplicationSettings(canAccess common.AuthFunc, arg params.RelationUnitApplication) (map[string]interface{}, error) {
+	unitTag, err := names.ParseUnitTag(arg.Unit)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	appTag, err := names.ParseApplicationTag(arg.Application)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	rel, unit, err := u.getRelationAndUnit(canAccess, arg.Relation, unitTag)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rel.Endpoint(unit.ApplicationName()); err != nil {
+		return nil, common.ErrPerm
+	}
+	if _, err := rel.Endpoint(appTag.Id()); err != nil {
+		return nil, common.ErrPerm
+	}
+	return rel.ApplicationSettings(appTag.Id())
+}
+
+// UpdateApplicationSettings changes the settings that the units'
+// applications publish in the given relations. Keys with empty values
+// are removed. Only the leader of an application may change them.
+func (u *UniterAPIV11) UpdateApplicationSettings(args params.RelationUnitsSettings) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	maxSize := cfg.MaxRelationDataSize()
+	for i, arg := range args.RelationUnits {
+		err := u.updateOneApplicationSettings(canAccess, arg, maxSize)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV11) updateOneApplicationSettings(canAccess common.AuthFunc, arg params.RelationUnitSettings, maxSize int) error {
+	unitTag, err := names.ParseUnitTag(arg.Unit)
+	if err != nil {
+		return common.ErrPerm
+	}
+	rel, unit, err := u.getRelationAndUnit(canAccess, arg.Relation, unitTag)
+	if err != nil {
+		return err
+	}
+	appName := unit.ApplicationName()
+	if _, err := rel.Endpoint(appName); err != nil {
+		return common.ErrPerm
+	}
+	current, err := rel.ApplicationSettings(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for k, v := range arg.Settings {
+		if v == "" {
+			delete(current, k)
+		} else {
+			current[k] = v
+		}
+	}
+	if err := checkRelationSettingsSize(current, maxSize); err != nil {
+		return errors.Trace(err)
+	}
+	token := u.st.LeadershipChecker().LeadershipCheck(appName, unit.Name())
+	return rel.UpdateApplicationSettings(appName, token, arg.Settings)
+}
+
+// UniterAPIV10 implements the API version 10, used by the uniter worker.
+type UniterAPIV10 struct {
+	*UniterAPIV9
+}
+
+// NewUniterAPIV10 creates a new instance of the Uniter API, version 10.
+func NewUniterAPIV10(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV10, error) {
+	baseAPI, err := NewUniterAPIV9(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV10{baseAPI}, nil
+}
+
+// SetSecrets stores the given values as secrets of the units'
+// applications.
+func (u *UniterAPIV10) SetSecrets(args params.SetSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		application, err := secretApplication(canAccess, arg.UnitTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		secretArgs := state.SecretArgs{Value: arg.Value}
+		if arg.RotateIntervalSeconds != nil {
+			interval := time.Duration(*arg.RotateIntervalSeconds * float64(time.Second))
+			secretArgs.RotateInterval = &interval
+		}
+		err = u.st.SetSecret(application, arg.Name, secretArgs)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GetSecrets returns the values and metadata of the given secrets of
+// the units' applications.
+func (u *UniterAPIV10) GetSecrets(args params.GetSecretArgs) (params.SecretResults, error) {
+	result := params.SecretResults{
+		Results: make([]params.SecretResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretResults{}, err
+	}
+	for i, arg := range args.Args {
+		secret, err := u.getOneSecret(canAccess, arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = secret
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV10) getOneSecret(canAccess common.AuthFunc, arg params.GetSecretArg) (*params.Secret, error) {
+	application, err := secretApplication(canAccess, arg.UnitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secret, err := u.st.Secret(application, arg.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err := secret.Value()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.Secret{
+		Value:                 value,
+		Revision:              secret.Revision(),
+		Created:               secret.Created(),
+		Updated:               secret.Updated(),
+		RotateIntervalSeconds: secret.RotateInterval().Seconds(),
+	}
+	if next, due := secret.NextRotation(); due {
+		result.NextRotation = &next
+	}
+	return result, nil
+}
+
+// secretApplication returns the name of the application whose secrets
+// the unit with the given tag may use. A unit may only use the secrets
+// of its own application.
+func secretApplication(canAccess common.AuthFunc, unitTag string) (string, error) {
+	tag, err := names.ParseUnitTag(unitTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !canAccess(tag) {
+		return "", common.ErrPerm
+	}
+	application, err := names.UnitApplication(tag.Id())
+	return application, errors.Trace(err)
+}
+
+// UniterAPIV9 implements the API version 9, used by the uniter worker.
+type UniterAPIV9 struct {
+	*UniterAPIV8
+}
+
+// NewUniterAPIV9 creates a new instance of the Uniter API, version 9.
+func NewUniterAPIV9(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV9, error) {
+	baseAPI, err := NewUniterAPIV8(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV9{baseAPI}, nil
+}
+
+// NetworkInfo returns, for each of the given bindings of the unit, the
+// network interfaces and addresses of the unit's machine that are in
+// the binding's space, the addresses the unit should advertise on it,
+// and the subnets its outgoing traffic will come from. Endpoints that
+// are not bound to a space use the machine's preferred private address.
+func (u *UniterAPIV9) NetworkInfo(args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NetworkInfoResults{}, err
+	}
+	unitTag, err := names.ParseUnitTag(args.Unit)
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	if !canAccess(unitTag) {
+		return params.NetworkInfoResults{}, common.ErrPerm
+	}
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	application, err := unit.Application()
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	bindings, err := application.EndpointBindings()
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	machineID, err := unit.AssignedMachineId()
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	machine, err := u.st.Machine(machineID)
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	addresses, err := machine.AllAddresses()
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Annotate(err, "cannot get devices addresses")
+	}
+
+	results := make(map[string]params.NetworkInfoResult, len(args.Bindings))
+	for _, bindingName := range args.Bindings {
+		result, err := oneNetworkInfo(machine, addresses, bindings, bindingName)
+		if err != nil {
+			result = params.NetworkInfoResult{Error: common.ServerError(err)}
+		}
+		results[bindingName] = result
+	}
+	return params.NetworkInfoResults{Results: results}, nil
+}
+
+func oneNetworkInfo(
+	machine *state.Machine,
+	addresses []*state.Address,
+	bindings map[string]string,
+	bindingName string,
+) (params.NetworkInfoResult, error) {
+	if bindingName == "" {
+		return params.NetworkInfoResult{}, errors.Errorf("binding name cannot be empty")
+	}
+	boundSpace, known := bindings[bindingName]
+	if !known {
+		return params.NetworkInfoResult{}, errors.Errorf("binding name %q not defined by the unit's charm", bindingName)
+	}
+
+	var selected []*state.Address
+	if boundSpace == "" {
+		privateAddress, err := machine.PrivateAddress()
+		if err != nil {
+			return params.NetworkInfoResult{}, errors.Annotatef(err, "getting machine %q preferred private address", machine.Id())
+		}
+		for _, addr := range addresses {
+			if addr.Value() == privateAddress.Value {
+				selected = append(selected, addr)
+			}
+		}
+		if len(selected) == 0 {
+			// The address is not known to be on any of the
+			// machine's devices, so there is nothing more to say
+			// about it.
+			return params.NetworkInfoResult{
+				Info: []params.NetworkInfo{{
+					Addresses: []params.InterfaceAddress{{Address: privateAddress.Value}},
+				}},
+				IngressAddresses: []string{privateAddress.Value},
+				EgressSubnets:    hostCIDRs([]string{privateAddress.Value}),
+			}, nil
+		}
+	} else {
+		for _, addr := range addresses {
+			subnet, err := addr.Subnet()
+			if errors.IsNotFound(err) {
+				logger.Debugf("skipping %s: not linked to a known subnet (%v)", addr, err)
+				continue
+			} else if err != nil {
+				return params.NetworkInfoResult{}, errors.Annotatef(err, "cannot get subnet for address %q", addr)
+			}
+			if subnet.SpaceName() == boundSpace {
+				selected = append(selected, addr)
+			}
+		}
+	}
+
+	// Group the addresses by device, keeping the order in which the
+	// devices were first seen.
+	var result params.NetworkInfoResult
+	devices := make(map[string]int)
+	for _, addr := range selected {
+		i, seen := devices[addr.DeviceName()]
+		if !seen {
+			device, err := addr.Device()
+			if err != nil {
+				return params.NetworkInfoResult{}, errors.Annotatef(err, "cannot get device for address %q", addr)
+			}
+			i = len(result.Info)
+			devices[addr.DeviceName()] = i
+			result.Info = append(result.Info, params.NetworkInfo{
+				MACAddress:    device.MACAddress(),
+				InterfaceName: device.Name(),
+			})
+		}
+		result.Info[i].Addresses = append(result.Info[i].Addresses, params.InterfaceAddress{
+			Address: addr.Value(),
+			CIDR:    addr.SubnetCIDR(),
+		})
+		result.IngressAddresses = append(result.IngressAddresses, addr.Value())
+	}
+	result.EgressSubnets = hostCIDRs(result.IngressAddresses)
+	return result, nil
+}
+
+// hostCIDRs returns a CIDR covering just the host for each of the given
+// IP addresses. Without any NAT configuration to say otherwise, that is
+// where a unit's traffic comes from.
+func hostCIDRs(addresses []string) []string {
+	var cidrs []string
+	for _, addr := range addresses {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			continue
+		}
+		bits := 128
+		if ip.To4() != nil {
+			bits = 32
+		}
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", addr, bits))
+	}
+	return cidrs
+}
+
+// UniterAPIV8 implements the API version 8, used by the uniter worker.
+type UniterAPIV8 struct {
+	*UniterAPIV7
+}
+
+// NewUniterAPIV8 creates a new instance of the Uniter API, version 8.
+func NewUniterAPIV8(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV8, error) {
+	baseAPI, err := NewUniterAPIV7(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{baseAPI}, nil
+}
+
+// AddStatusEvents adds the given events to the workload status
+// history of the units, leaving their statuses unchanged.
+func (u *UniterAPIV8) AddStatusEvents(args params.StatusEvents) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Events)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, event := range args.Events {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(event.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		err = unit.AddStatusEvent(event.Message, event.Data)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// UniterAPIV7 implements the API version 7, used by the uniter worker.
+type UniterAPIV7 struct {
+	*UniterAPIV6
+}
+
+// NewUniterAPIV7 creates a new instance of the Uniter API, version 7.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	baseAPI, err := NewUniterAPIV6(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV7{baseAPI}, nil
+}
+
+// UnitDrainTimeout returns how long a dying unit's pre-remove hook may
+// run, as set by the model's unit-drain-timeout; zero means that there
+// is no limit.
+func (u *UniterAPIV7) UnitDrainTimeout() (params.UnitDrainTimeoutResult, error) {
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return params.UnitDrainTimeoutResult{}, errors.Trace(err)
+	}
+	return params.UnitDrainTimeoutResult{
+		TimeoutSeconds: cfg.UnitDrainTimeout().Seconds(),
+	}, nil
+}
+
+// UniterAPIV6 implements the API version 6, used by the uniter worker.
+type UniterAPIV6 struct {
+	*UniterAPIV5
+	*common.RebootScheduler
+}
+
+// NewUniterAPIV6 creates a new instance of the Uniter API, version 6.
+func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
+	baseAPI, err := NewUniterAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV6{
+		UniterAPIV5:     baseAPI,
+		RebootScheduler: common.NewRebootScheduler(st, baseAPI.accessMachine),
+	}, nil
+}
+
+// UniterAPIV5 implements the API version 5, used by the uniter worker.
+type UniterAPIV5 struct {
+	*UniterAPIV3
+}
+
+// NewUniterAPIV5 creates a new instance of the Uniter API, version 5.
+func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV5, error) {
+	baseAPI, err := NewUniterAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV5{baseAPI}, nil
+}
+
+// MaxRelationDataSize returns the maximum size, in bytes, of the
+// settings a unit may write to a relation, as set by the model's
+// max-relation-data-size; zero means there is no limit. The unit
+// agent checks the limit as relation-set runs, so that charms see
+// the error in the hook that broke it.
+func (u *UniterAPIV5) MaxRelationDataSize() (params.IntResult, error) {
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return params.IntResult{}, errors.Trace(err)
+	}
+	return params.IntResult{Result: cfg.MaxRelationDataSize()}, nil
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -1170,6 +1759,11 @@ func (u *UniterAPIV3) UpdateSettings(args params.RelationUnitsSettings) (params.
 	if err != nil {
 		return params.ErrorResults{}, err
 	}
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	maxSize := cfg.MaxRelationDataSize()
 	for i, arg := range args.RelationUnits {
 		unit, err := names.ParseUnitTag(arg.Unit)
 		if err != nil {
@@ -1188,6 +1782,9 @@ func (u *UniterAPIV3) UpdateSettings(args params.RelationUnitsSettings) (params.
 						settings.Set(k, v)
 					}
 				}
+				err = checkRelationSettingsSize(settings.Map(), maxSize)
+			}
+			if err == nil {
 				_, err = settings.Write()
 			}
 		}
@@ -1196,6 +1793,31 @@ func (u *UniterAPIV3) UpdateSettings(args params.RelationUnitsSettings) (params.
 	return result, nil
 }
 
+// checkRelationSettingsSize returns an error if the total size of the
+// keys and values in the supplied relation settings exceeds maxSize
+// bytes. A maxSize of zero means there is no limit.
+func checkRelationSettingsSize(settings map[string]interface{}, maxSize int) error {
+	if maxSize <= 0 {
+		return nil
+	}
+	var size int
+	for k, v := range settings {
+		size += len(k)
+		if s, ok := v.(string); ok {
+			size += len(s)
+		} else {
+			size += len(fmt.Sprint(v))
+		}
+	}
+	if size > maxSize {
+		return errors.Annotatef(
+			common.QuotaExceededError(config.MaxRelationDataSizeKey, maxSize),
+			"relation settings size of %d bytes", size,
+		)
+	}
+	return nil
+}
+
 // WatchRelationUnits returns a RelationUnitsWatcher for observing
 // changes to every unit in the supplied relation that is visible to
 // the supplied unit. See also state/watcher.go:RelationUnit.Watch().
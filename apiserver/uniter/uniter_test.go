@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/uniter"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	statetesting "github.com/juju/juju/state/testing"
@@ -1838,6 +1839,219 @@ func (s *uniterSuite) TestUpdateSettings(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestMaxRelationDataSize(c *gc.C) {
+	uniterAPI, err := uniter.NewUniterAPIV5(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Models are unlimited unless the limit is configured.
+	result, err := uniterAPI.MaxRelationDataSize()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.IntResult{Result: 0})
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"max-relation-data-size": 20}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = uniterAPI.MaxRelationDataSize()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.IntResult{Result: 20})
+}
+
+func (s *uniterSuite) TestUnitDrainTimeout(c *gc.C) {
+	uniterAPI, err := uniter.NewUniterAPIV7(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := uniterAPI.UnitDrainTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitDrainTimeoutResult{TimeoutSeconds: 600})
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"unit-drain-timeout": "90s"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = uniterAPI.UnitDrainTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitDrainTimeoutResult{TimeoutSeconds: 90})
+}
+
+func (s *uniterSuite) TestAddStatusEvents(c *gc.C) {
+	uniterAPI, err := uniter.NewUniterAPIV8(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.StatusEvents{Events: []params.StatusEvent{
+		{Tag: "unit-wordpress-0", Message: "schema migrated", Data: map[string]interface{}{"version": "3"}},
+		{Tag: "unit-wordpress-0", Message: ""},
+		{Tag: "unit-mysql-0", Message: "cluster joined"},
+		{Tag: "application-wordpress", Message: "nope"},
+	}}
+	result, err := uniterAPI.AddStatusEvents(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{&params.Error{Message: "empty status event message not valid"}},
+			{apiservertesting.ErrUnauthorized},
+			{&params.Error{Message: `"application-wordpress" is not a valid unit tag`}},
+		},
+	})
+
+	history, err := s.wordpressUnit.StatusHistory(status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Status, gc.Equals, status.Event)
+	c.Assert(history[0].Message, gc.Equals, "schema migrated")
+	c.Assert(history[0].Data, jc.DeepEquals, map[string]interface{}{"version": "3"})
+
+	// The unit's status itself is untouched.
+	unitStatus, err := s.wordpressUnit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitStatus.Status, gc.Not(gc.Equals), status.Event)
+}
+
+func (s *uniterSuite) TestSecrets(c *gc.C) {
+	uniterAPI, err := uniter.NewUniterAPIV10(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	hour := 3600.0
+	setResult, err := uniterAPI.SetSecrets(params.SetSecretArgs{Args: []params.SetSecretArg{
+		{UnitTag: "unit-wordpress-0", Name: "db-password", Value: "hunter2", RotateIntervalSeconds: &hour},
+		{UnitTag: "unit-wordpress-0", Name: "Bad_Name", Value: "x"},
+		{UnitTag: "unit-mysql-0", Name: "db-password", Value: "x"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setResult.Results, gc.HasLen, 3)
+	c.Assert(setResult.Results[0].Error, gc.IsNil)
+	c.Assert(setResult.Results[1].Error, gc.ErrorMatches, `.*secret name "Bad_Name" not valid`)
+	c.Assert(setResult.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	getResult, err := uniterAPI.GetSecrets(params.GetSecretArgs{Args: []params.GetSecretArg{
+		{UnitTag: "unit-wordpress-0", Name: "db-password"},
+		{UnitTag: "unit-wordpress-0", Name: "missing"},
+		{UnitTag: "unit-mysql-0", Name: "db-password"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(getResult.Results, gc.HasLen, 3)
+	c.Assert(getResult.Results[0].Error, gc.IsNil)
+	secret := getResult.Results[0].Result
+	c.Assert(secret.Value, gc.Equals, "hunter2")
+	c.Assert(secret.Revision, gc.Equals, 1)
+	c.Assert(secret.RotateIntervalSeconds, gc.Equals, hour)
+	c.Assert(secret.NextRotation, gc.NotNil)
+	c.Assert(*secret.NextRotation, gc.Equals, secret.Updated.Add(time.Hour))
+	c.Assert(getResult.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(getResult.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *uniterSuite) TestApplicationSettings(c *gc.C) {
+	uniterAPI, err := uniter.NewUniterAPIV11(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+
+	// Only the leader may write its application's settings.
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Settings: params.Settings{"url": "http://x"}},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0", Settings: params.Settings{"host": "x"}},
+		{Relation: "relation-42", Unit: "unit-wordpress-0", Settings: nil},
+	}}
+	result, err := uniterAPI.UpdateApplicationSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `.*prerequisites failed: .*`)
+	c.Assert(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = uniterAPI.UpdateApplicationSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	// Units may read the settings of either application in the relation.
+	readArgs := params.RelationUnitApplications{RelationUnitApplications: []params.RelationUnitApplication{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Application: "application-wordpress"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Application: "application-mysql"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Application: "application-riak"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0", Application: "application-wordpress"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Application: "unit-mysql-0"},
+	}}
+	readResult, err := uniterAPI.ReadApplicationSettings(readArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readResult, jc.DeepEquals, params.SettingsResults{
+		Results: []params.SettingsResult{
+			{Settings: params.Settings{"url": "http://x"}},
+			{Settings: params.Settings{}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestUnitPayloads(c *gc.C) {
+	uniterAPI, err := uniter.NewUniterAPIV12(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	unitPayloads, err := s.State.UnitPayloads(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unitPayloads.Track(payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "database",
+			Type: "docker",
+		},
+		Status: payload.StateRunning,
+		ID:     "abc123",
+		Unit:   s.wordpressUnit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := uniterAPI.UnitPayloads(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitPayloadsResults{
+		Results: []params.UnitPayloadsResult{
+			{Payloads: []params.UnitPayload{{Class: "database", Type: "docker", ID: "abc123"}}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestUpdateSettingsTooLarge(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"max-relation-data-size": 20}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{"some": "settings"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Settings: params.Settings{
+			"other": "a value that is far too long",
+		}},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Settings: params.Settings{
+			"some":  "",
+			"other": "short",
+		}},
+	}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches,
+		`relation settings size of 45 bytes: quota "max-relation-data-size" exceeded \(limit 20\)`)
+	c.Assert(result.Results[0].Error.Code, gc.Equals, params.CodeQuotaExceeded)
+	c.Assert(result.Results[0].Error.Details, jc.DeepEquals, map[string]string{
+		params.DetailQuota: "max-relation-data-size",
+		params.DetailLimit: "20",
+	})
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"other": "short",
+	})
+}
+
 func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 	// Add a relation between wordpress and mysql and enter scope with
 	// mysqlUnit.
@@ -2067,6 +2281,33 @@ func (s *uniterSuite) TestRequestReboot(c *gc.C) {
 	c.Assert(rFlag, jc.IsFalse)
 }
 
+func (s *uniterSuite) TestScheduleReboot(c *gc.C) {
+	uniterAPI, err := uniter.NewUniterAPIV6(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machine0.Tag().String()},
+		{Tag: s.machine1.Tag().String()},
+		{Tag: "bogus"},
+	}}
+	errResult, err := uniterAPI.ScheduleReboot(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResult, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: nil},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		}})
+
+	scheduled, err := s.machine0.RebootScheduled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsTrue)
+
+	rFlag, err := s.machine1.GetRebootFlag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rFlag, jc.IsFalse)
+}
+
 func checkUnorderedActionIdsEqual(c *gc.C, ids []string, results params.StringsWatchResults) {
 	c.Assert(results, gc.NotNil)
 	content := results.Results
@@ -2656,3 +2897,101 @@ func (s *uniterNetworkConfigSuite) TestNetworkConfigForImplicitlyBoundEndpoint(c
 		},
 	})
 }
+
+func (s *uniterNetworkConfigSuite) newUniterAPIV9(c *gc.C) *uniter.UniterAPIV9 {
+	api, err := uniter.NewUniterAPIV9(s.base.State, s.base.resources, s.base.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *uniterNetworkConfigSuite) TestNetworkInfoPermissions(c *gc.C) {
+	api := s.newUniterAPIV9(c)
+
+	_, err := api.NetworkInfo(params.NetworkInfoParams{Unit: "unit-mysql-0", Bindings: []string{"server"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = api.NetworkInfo(params.NetworkInfoParams{Unit: "invalid", Bindings: []string{"db"}})
+	c.Assert(err, gc.ErrorMatches, `"invalid" is not a valid tag`)
+
+	result, err := api.NetworkInfo(params.NetworkInfoParams{
+		Unit:     s.base.wordpressUnit.Tag().String(),
+		Bindings: []string{"", "unknown"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"":        {Error: apiservertesting.ServerError(`binding name cannot be empty`)},
+			"unknown": {Error: apiservertesting.ServerError(`binding name "unknown" not defined by the unit's charm`)},
+		},
+	})
+}
+
+func (s *uniterNetworkConfigSuite) TestNetworkInfoForExplicitlyBoundEndpoint(c *gc.C) {
+	s.addRelationAndAssertInScope(c)
+	api := s.newUniterAPIV9(c)
+
+	result, err := api.NetworkInfo(params.NetworkInfoParams{
+		Unit:     s.base.wordpressUnit.Tag().String(),
+		Bindings: []string{"db", "admin-api"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			// The relation endpoint sees only the devices and
+			// addresses in the "internal" space it is bound to.
+			"db": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: "eth0.100",
+					Addresses:     []params.InterfaceAddress{{Address: "10.0.0.10", CIDR: "10.0.0.0/24"}},
+				}, {
+					InterfaceName: "eth1.100",
+					Addresses:     []params.InterfaceAddress{{Address: "10.0.0.11", CIDR: "10.0.0.0/24"}},
+				}},
+				IngressAddresses: []string{"10.0.0.10", "10.0.0.11"},
+				EgressSubnets:    []string{"10.0.0.10/32", "10.0.0.11/32"},
+			},
+			// The extra binding sees only those in "public".
+			"admin-api": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: "eth0",
+					Addresses:     []params.InterfaceAddress{{Address: "8.8.8.10", CIDR: "8.8.0.0/16"}},
+				}, {
+					InterfaceName: "eth1",
+					Addresses:     []params.InterfaceAddress{{Address: "8.8.4.10", CIDR: "8.8.0.0/16"}},
+				}},
+				IngressAddresses: []string{"8.8.8.10", "8.8.4.10"},
+				EgressSubnets:    []string{"8.8.8.10/32", "8.8.4.10/32"},
+			},
+		},
+	})
+}
+
+func (s *uniterNetworkConfigSuite) TestNetworkInfoForImplicitlyBoundEndpoint(c *gc.C) {
+	s.setupUniterAPIForUnit(c, s.base.mysqlUnit)
+	api := s.newUniterAPIV9(c)
+
+	privateAddress, err := s.base.machine1.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	devices := map[string]string{
+		"10.0.0.20": "eth0.100",
+		"10.0.0.21": "eth1.100",
+	}
+	c.Assert(devices[privateAddress.Value], gc.Not(gc.Equals), "")
+
+	result, err := api.NetworkInfo(params.NetworkInfoParams{
+		Unit:     s.base.mysqlUnit.Tag().String(),
+		Bindings: []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"server": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: devices[privateAddress.Value],
+					Addresses:     []params.InterfaceAddress{{Address: privateAddress.Value, CIDR: "10.0.0.0/24"}},
+				}},
+				IngressAddresses: []string{privateAddress.Value},
+				EgressSubnets:    []string{privateAddress.Value + "/32"},
+			},
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -21,6 +22,21 @@ var logger = loggo.GetLogger("juju.apiserver.usermanager")
 
 func init() {
 	common.RegisterStandardFacade("UserManager", 1, NewUserManagerAPI)
+	// Version 2 adds RefreshSession.
+	common.RegisterStandardFacade("UserManager", 2, NewUserManagerAPI)
+	// Version 3 adds UserQuotas and SetUserQuotas.
+	common.RegisterStandardFacade("UserManager", 3, NewUserManagerAPI)
+}
+
+// SessionRefresher mints macaroons that extend the login sessions of
+// local users. The API server makes one available to facades as the
+// "sessionRefresher" resource.
+type SessionRefresher interface {
+	facade.Resource
+
+	// RefreshSession returns a macaroon that authenticates the
+	// user with the given tag, and the time at which it expires.
+	RefreshSession(names.UserTag) (*macaroon.Macaroon, time.Time, error)
 }
 
 // UserManagerAPI implements the user manager interface and is the concrete
@@ -31,6 +47,7 @@ type UserManagerAPI struct {
 	check      *common.BlockChecker
 	apiUser    names.UserTag
 	isAdmin    bool
+	refresher  SessionRefresher
 }
 
 func NewUserManagerAPI(
@@ -51,6 +68,9 @@ func NewUserManagerAPI(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The refresher is nil if the resource is not available;
+	// RefreshSession reports that.
+	refresher, _ := resources.Get("sessionRefresher").(SessionRefresher)
 
 	return &UserManagerAPI{
 		state:      st,
@@ -58,6 +78,7 @@ func NewUserManagerAPI(
 		check:      common.NewBlockChecker(st),
 		apiUser:    apiUser,
 		isAdmin:    isAdmin,
+		refresher:  refresher,
 	}, nil
 }
 
@@ -396,3 +417,102 @@ func (api *UserManagerAPI) setPassword(arg params.EntityPassword) error {
 	}
 	return nil
 }
+
+// RefreshSession returns a macaroon that authenticates the calling
+// user for the controller's configured local login expiry time,
+// without requiring a discharge. Long-running clients may call it
+// before their current session expires, so that they need not log
+// in again.
+func (api *UserManagerAPI) RefreshSession() (params.RefreshSessionResult, error) {
+	if api.refresher == nil {
+		return params.RefreshSessionResult{}, errors.NotSupportedf("session refresh")
+	}
+	m, expiry, err := api.refresher.RefreshSession(api.apiUser)
+	if err != nil {
+		return params.RefreshSessionResult{}, errors.Trace(err)
+	}
+	return params.RefreshSessionResult{
+		Macaroon: m,
+		Expiry:   expiry,
+	}, nil
+}
+
+// UserQuotas returns the quotas of the specified users, and the
+// machines, units and storage instances in the models they own.
+// Controller administrators may see any user's quota; other users
+// may only see their own.
+func (api *UserManagerAPI) UserQuotas(args params.Entities) (params.UserQuotaResults, error) {
+	results := params.UserQuotaResults{
+		Results: make([]params.UserQuotaResult, len(args.Entities)),
+	}
+	isAdmin, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		userTag, err := names.ParseUserTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !isAdmin && !api.authorizer.AuthOwner(userTag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		quota, err := api.state.UserQuota(userTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		usage, err := api.state.UserQuotaUsage(userTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Quota = &params.UserQuota{
+			UserTag:     userTag.String(),
+			MaxMachines: quota.MaxMachines,
+			MaxUnits:    quota.MaxUnits,
+			MaxStorage:  quota.MaxStorage,
+		}
+		results.Results[i].Usage = &params.QuotaUsage{
+			Machines: usage.Machines,
+			Units:    usage.Units,
+			Storage:  usage.Storage,
+		}
+	}
+	return results, nil
+}
+
+// SetUserQuotas sets the quotas on the total machines, units and
+// storage instances in the models owned by each of the specified
+// users. Only controller administrators may set quotas.
+func (api *UserManagerAPI) SetUserQuotas(args params.UserQuotas) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Quotas)),
+	}
+	isAdmin, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !isAdmin {
+		return results, common.ErrPerm
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Quotas {
+		userTag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = api.state.SetUserQuota(userTag, state.Quota{
+			MaxMachines: arg.MaxMachines,
+			MaxUnits:    arg.MaxUnits,
+			MaxStorage:  arg.MaxStorage,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -11,6 +11,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
@@ -810,3 +811,108 @@ func (s *userManagerSuite) TestRemoveUserBulkSharedModels(c *gc.C) {
 	c.Assert(alice.IsDeleted(), jc.IsTrue)
 
 }
+
+func (s *userManagerSuite) TestRefreshSession(c *gc.C) {
+	refresher := &fakeSessionRefresher{}
+	err := s.resources.RegisterNamed("sessionRefresher", refresher)
+	c.Assert(err, jc.ErrorIsNil)
+	api, err := usermanager.NewUserManagerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.RefreshSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(refresher.tag, gc.Equals, s.AdminUserTag(c))
+	c.Assert(result.Macaroon.Id(), gc.Equals, "session")
+	c.Assert(result.Expiry, gc.Equals, refresher.expiry)
+}
+
+func (s *userManagerSuite) TestRefreshSessionNotSupported(c *gc.C) {
+	_, err := s.usermanager.RefreshSession()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type fakeSessionRefresher struct {
+	tag    names.UserTag
+	expiry time.Time
+}
+
+func (r *fakeSessionRefresher) Stop() error {
+	return nil
+}
+
+func (r *fakeSessionRefresher) RefreshSession(tag names.UserTag) (*macaroon.Macaroon, time.Time, error) {
+	r.tag = tag
+	r.expiry = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	m, err := macaroon.New([]byte("root-key"), "session", "juju")
+	return m, r.expiry, err
+}
+
+func (s *userManagerSuite) TestSetUserQuotas(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	result, err := s.usermanager.SetUserQuotas(params.UserQuotas{
+		Quotas: []params.UserQuota{{
+			UserTag:     bob.Tag().String(),
+			MaxMachines: 5,
+			MaxUnits:    10,
+		}, {
+			UserTag: "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+
+	quota, err := s.State.UserQuota(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.Quota{MaxMachines: 5, MaxUnits: 10})
+}
+
+func (s *userManagerSuite) TestSetUserQuotasNotAdmin(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{
+			Tag: bob.Tag(),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = usermanager.SetUserQuotas(params.UserQuotas{
+		Quotas: []params.UserQuota{{UserTag: bob.Tag().String(), MaxMachines: 100}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *userManagerSuite) TestSetUserQuotasBlocked(c *gc.C) {
+	s.BlockAllChanges(c, "TestSetUserQuotasBlocked")
+	_, err := s.usermanager.SetUserQuotas(params.UserQuotas{
+		Quotas: []params.UserQuota{{UserTag: "user-bob", MaxMachines: 1}},
+	})
+	s.AssertBlocked(c, err, "TestSetUserQuotasBlocked")
+}
+
+func (s *userManagerSuite) TestUserQuotas(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	err := s.State.SetUserQuota(bob.UserTag(), state.Quota{MaxUnits: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Owner: bob.Tag()})
+	defer st.Close()
+	factory.NewFactory(st).MakeUnit(c, nil)
+
+	alice := s.Factory.MakeUser(c, &factory.UserParams{Name: "alice", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{
+			Tag: bob.Tag(),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := usermanager.UserQuotas(params.Entities{
+		Entities: []params.Entity{{Tag: bob.Tag().String()}, {Tag: alice.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UserQuotaResults{
+		Results: []params.UserQuotaResult{{
+			Quota: &params.UserQuota{UserTag: "user-bob", MaxUnits: 3},
+			Usage: &params.QuotaUsage{Machines: 1, Units: 1},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}},
+	})
+}
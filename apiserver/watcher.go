@@ -7,7 +7,9 @@ import (
 	"reflect"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 
+	"github.com/juju/juju/apiserver/client"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
 )
 
 func init() {
@@ -31,6 +34,18 @@ func init() {
 		"AllModelWatcher", 2, NewAllWatcher,
 		reflect.TypeOf((*SrvAllWatcher)(nil)),
 	)
+	common.RegisterFacade(
+		"AllWatcher", 2, NewAllWatcherV2,
+		reflect.TypeOf((*SrvAllWatcherV2)(nil)),
+	)
+	common.RegisterFacade(
+		"AllModelWatcher", 3, NewAllWatcherV2,
+		reflect.TypeOf((*SrvAllWatcherV2)(nil)),
+	)
+	common.RegisterFacade(
+		"FullStatusWatcher", 1, newFullStatusWatcher,
+		reflect.TypeOf((*srvFullStatusWatcher)(nil)),
+	)
 	common.RegisterFacade(
 		"NotifyWatcher", 1, newNotifyWatcher,
 		reflect.TypeOf((*srvNotifyWatcher)(nil)),
@@ -96,6 +111,17 @@ func NewAllWatcher(context facade.Context) (facade.Facade, error) {
 	}, nil
 }
 
+// NewAllWatcherV2 returns a new API server endpoint for interacting
+// with a watcher created by the WatchAll and WatchAllModels API calls,
+// whose Next method takes a filter.
+func NewAllWatcherV2(context facade.Context) (facade.Facade, error) {
+	watcher, err := NewAllWatcher(context)
+	if err != nil {
+		return nil, err
+	}
+	return &SrvAllWatcherV2{watcher.(*SrvAllWatcher)}, nil
+}
+
 type watcherCommon struct {
 	id        string
 	resources facade.Resources
@@ -132,6 +158,136 @@ func (aw *SrvAllWatcher) Next() (params.AllWatcherNextResults, error) {
 	}, err
 }
 
+// SrvAllWatcherV2 is SrvAllWatcher with a Next method that returns
+// only the deltas that match a filter. It is used by version 2 of the
+// AllWatcher facade and version 3 of the AllModelWatcher facade.
+type SrvAllWatcherV2 struct {
+	*SrvAllWatcher
+}
+
+// Next returns the next deltas that match the given filter. It blocks
+// until there are some. The filter may be different for each call;
+// entities that were not reported because of an earlier filter are
+// not reported again until they change.
+func (aw *SrvAllWatcherV2) Next(args params.AllWatcherNextArgs) (params.AllWatcherNextResults, error) {
+	filter, err := newDeltaFilter(args)
+	if err != nil {
+		return params.AllWatcherNextResults{}, errors.Trace(err)
+	}
+	for {
+		deltas, err := aw.watcher.Next()
+		if err != nil {
+			return params.AllWatcherNextResults{}, err
+		}
+		if deltas = filter.apply(deltas); len(deltas) > 0 {
+			return params.AllWatcherNextResults{Deltas: deltas}, nil
+		}
+	}
+}
+
+// deltaKinds holds the kinds of entity reported by the AllWatcher.
+var deltaKinds = set.NewStrings(
+	"action",
+	"annotation",
+	"application",
+	"block",
+	"machine",
+	"model",
+	"relation",
+	"remoteApplication",
+	"unit",
+)
+
+// deltaFilter selects the deltas returned by SrvAllWatcherV2.Next.
+type deltaFilter struct {
+	kinds        set.Strings
+	applications set.Strings
+}
+
+func newDeltaFilter(args params.AllWatcherNextArgs) (deltaFilter, error) {
+	for _, kind := range args.Kinds {
+		if !deltaKinds.Contains(kind) {
+			return deltaFilter{}, errors.NotValidf("entity kind %q", kind)
+		}
+	}
+	return deltaFilter{
+		kinds:        set.NewStrings(args.Kinds...),
+		applications: set.NewStrings(args.Applications...),
+	}, nil
+}
+
+// apply returns the deltas that match the filter.
+func (f deltaFilter) apply(deltas []multiwatcher.Delta) []multiwatcher.Delta {
+	if f.kinds.IsEmpty() && f.applications.IsEmpty() {
+		return deltas
+	}
+	var result []multiwatcher.Delta
+	for _, delta := range deltas {
+		if f.match(delta.Entity) {
+			result = append(result, delta)
+		}
+	}
+	return result
+}
+
+func (f deltaFilter) match(entity multiwatcher.EntityInfo) bool {
+	if !f.kinds.IsEmpty() && !f.kinds.Contains(entity.EntityId().Kind) {
+		return false
+	}
+	if f.applications.IsEmpty() {
+		return true
+	}
+	switch info := entity.(type) {
+	case *multiwatcher.ApplicationInfo:
+		return f.applications.Contains(info.Name)
+	case *multiwatcher.UnitInfo:
+		return f.applications.Contains(info.Application)
+	case *multiwatcher.RelationInfo:
+		for _, ep := range info.Endpoints {
+			if f.applications.Contains(ep.ApplicationName) {
+				return true
+			}
+		}
+		return false
+	}
+	// Other entities do not belong to an application.
+	return true
+}
+
+// srvFullStatusWatcher defines the API methods on a
+// client.FullStatusWatcher, which reports changes to the
+// status of a model.
+type srvFullStatusWatcher struct {
+	watcherCommon
+	watcher *client.FullStatusWatcher
+}
+
+func newFullStatusWatcher(context facade.Context) (facade.Facade, error) {
+	id := context.ID()
+	auth := context.Auth()
+	resources := context.Resources()
+
+	if !auth.AuthClient() {
+		// As with the AllWatcher, the permission check is
+		// made when the watcher resource is created.
+		return nil, common.ErrPerm
+	}
+	watcher, ok := resources.Get(id).(*client.FullStatusWatcher)
+	if !ok {
+		return nil, common.ErrUnknownWatcher
+	}
+	return &srvFullStatusWatcher{
+		watcherCommon: newWatcherCommon(context),
+		watcher:       watcher,
+	}, nil
+}
+
+// Next returns the changes to the model's status since the
+// previous call to Next.
+func (w *srvFullStatusWatcher) Next() (params.FullStatusDelta, error) {
+	return w.watcher.Next()
+}
+
 // srvNotifyWatcher defines the API access to methods on a state.NotifyWatcher.
 // Each client has its own current set of watchers, stored in resources.
 type srvNotifyWatcher struct {
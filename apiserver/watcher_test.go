@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/testing"
 )
 
@@ -269,6 +270,60 @@ func (m *fakeModelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	}, nil
 }
 
+func (s *watcherSuite) TestAllWatcherV2Registered(c *gc.C) {
+	_, err := common.Facades.GetType("AllWatcher", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = common.Facades.GetType("AllModelWatcher", 3)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *watcherSuite) TestFilterDeltas(c *gc.C) {
+	machine := multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{Id: "0"}}
+	wordpress := multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{Name: "wordpress"}}
+	wordpressUnit := multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{Name: "wordpress/0", Application: "wordpress"}}
+	mysqlUnit := multiwatcher.Delta{Removed: true, Entity: &multiwatcher.UnitInfo{Name: "mysql/0", Application: "mysql"}}
+	relation := multiwatcher.Delta{Entity: &multiwatcher.RelationInfo{
+		Key: "wordpress:db mysql:server",
+		Endpoints: []multiwatcher.Endpoint{
+			{ApplicationName: "wordpress"},
+			{ApplicationName: "mysql"},
+		},
+	}}
+	deltas := []multiwatcher.Delta{machine, wordpress, wordpressUnit, mysqlUnit, relation}
+
+	for i, test := range []struct {
+		about    string
+		args     params.AllWatcherNextArgs
+		expected []multiwatcher.Delta
+	}{{
+		about:    "no filter",
+		expected: deltas,
+	}, {
+		about:    "units",
+		args:     params.AllWatcherNextArgs{Kinds: []string{"unit"}},
+		expected: []multiwatcher.Delta{wordpressUnit, mysqlUnit},
+	}, {
+		about:    "applications",
+		args:     params.AllWatcherNextArgs{Applications: []string{"mysql"}},
+		expected: []multiwatcher.Delta{machine, mysqlUnit, relation},
+	}, {
+		about:    "units of applications",
+		args:     params.AllWatcherNextArgs{Kinds: []string{"unit"}, Applications: []string{"wordpress"}},
+		expected: []multiwatcher.Delta{wordpressUnit},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		result, err := apiserver.FilterDeltas(test.args, deltas)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *watcherSuite) TestFilterDeltasInvalidKind(c *gc.C) {
+	_, err := apiserver.FilterDeltas(params.AllWatcherNextArgs{Kinds: []string{"service"}}, nil)
+	c.Assert(err, gc.ErrorMatches, `entity kind "service" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 type migrationStatusWatcher interface {
 	Next() (params.MigrationStatus, error)
 	Stop() error
@@ -0,0 +1,179 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// DefaultWebsocketPingInterval is how often websocket clients are
+	// pinged if ServerConfig.WebsocketPingInterval is not set.
+	DefaultWebsocketPingInterval = 30 * time.Second
+
+	// DefaultWebsocketMaxMissedPongs is how many consecutive pings a
+	// websocket client may leave unanswered before its connection is
+	// closed, if ServerConfig.WebsocketMaxMissedPongs is not set.
+	DefaultWebsocketMaxMissedPongs = 3
+)
+
+// pingCodec sends an empty websocket ping frame. Sending through a
+// codec takes the connection's write lock, so pings never interleave
+// with the frames written by the connection's handler.
+var pingCodec = websocket.Codec{
+	Marshal: func(interface{}) ([]byte, byte, error) {
+		return nil, websocket.PingFrame, nil
+	},
+}
+
+// websocketKeepalive pings the clients of websocket connections and
+// closes the connections of clients that stop answering, so that
+// connections silently dropped by the network (for example by NAT
+// timeouts) do not linger on the server.
+//
+// The websocket package answers pings itself but never reports the
+// pongs it receives, so any data read from the client after a ping
+// counts as its answer.
+type websocketKeepalive struct {
+	clock     clock.Clock
+	interval  time.Duration
+	maxMissed int
+}
+
+func newWebsocketKeepalive(clock clock.Clock, interval time.Duration, maxMissed int) *websocketKeepalive {
+	if interval <= 0 {
+		interval = DefaultWebsocketPingInterval
+	}
+	if maxMissed <= 0 {
+		maxMissed = DefaultWebsocketMaxMissedPongs
+	}
+	return &websocketKeepalive{
+		clock:     clock,
+		interval:  interval,
+		maxMissed: maxMissed,
+	}
+}
+
+// serve serves the request as a websocket connection handled by the
+// given function, keeping the connection alive while it is handled.
+// Handlers that never read from the connection must set drain, so
+// that the client's pongs are read and seen. A nil keepalive serves
+// the connection without pinging the client.
+func (k *websocketKeepalive) serve(w http.ResponseWriter, req *http.Request, drain bool, handler func(*websocket.Conn)) {
+	if k == nil {
+		websocket.Server{Handler: handler}.ServeHTTP(w, req)
+		return
+	}
+	activity := &activityReader{}
+	server := websocket.Server{
+		Handler: func(conn *websocket.Conn) {
+			done := make(chan struct{})
+			defer close(done)
+			go k.run(conn, activity, done)
+			if drain {
+				go drainWebsocket(conn)
+			}
+			handler(conn)
+		},
+	}
+	server.ServeHTTP(&activityResponseWriter{w, activity}, req)
+}
+
+// run pings the client every interval until done is closed, and
+// closes the connection if maxMissed pings in a row go unanswered.
+func (k *websocketKeepalive) run(conn *websocket.Conn, activity *activityReader, done <-chan struct{}) {
+	lastReads := activity.reads()
+	pinged := false
+	missed := 0
+	for {
+		select {
+		case <-done:
+			return
+		case <-k.clock.After(k.interval):
+		}
+		if reads := activity.reads(); reads != lastReads {
+			lastReads = reads
+			missed = 0
+		} else if pinged {
+			missed++
+		}
+		if missed >= k.maxMissed {
+			logger.Infof("closing websocket connection from %s: %d pings unanswered", conn.Request().RemoteAddr, missed)
+			conn.Close()
+			return
+		}
+		if err := pingCodec.Send(conn, nil); err != nil {
+			logger.Debugf("cannot ping websocket client %s: %v", conn.Request().RemoteAddr, err)
+			conn.Close()
+			return
+		}
+		pinged = true
+	}
+}
+
+// drainWebsocket reads and discards everything the client sends,
+// until the connection is closed.
+func drainWebsocket(conn *websocket.Conn) {
+	for {
+		var discard []byte
+		if err := websocket.Message.Receive(conn, &discard); err != nil {
+			return
+		}
+	}
+}
+
+// activityResponseWriter wraps a response writer so that the data
+// read from a connection it hijacks passes through an activityReader.
+type activityResponseWriter struct {
+	http.ResponseWriter
+	activity *activityReader
+}
+
+// Hijack implements http.Hijacker.
+func (w *activityResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer cannot be hijacked")
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	w.activity.r = buf.Reader
+	return conn, bufio.NewReadWriter(bufio.NewReader(w.activity), buf.Writer), nil
+}
+
+// activityReader counts the reads that return data through it.
+type activityReader struct {
+	r io.Reader
+
+	mu    sync.Mutex
+	count uint64
+}
+
+// Read implements io.Reader.
+func (r *activityReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.mu.Lock()
+		r.count++
+		r.mu.Unlock()
+	}
+	return n, err
+}
+
+func (r *activityReader) reads() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
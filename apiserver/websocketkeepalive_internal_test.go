@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/websocket"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type websocketKeepaliveSuite struct {
+	testing.IsolationSuite
+
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&websocketKeepaliveSuite{})
+
+func (s *websocketKeepaliveSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+}
+
+// serve starts a websocket server using the given keepalive, and
+// returns a client connected to it along with a channel that is
+// closed when the server's handler sees its connection closed.
+func (s *websocketKeepaliveSuite) serve(c *gc.C, keepalive *websocketKeepalive) (*websocket.Conn, <-chan struct{}) {
+	closed := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		keepalive.serve(w, req, false, func(conn *websocket.Conn) {
+			defer close(closed)
+			for {
+				var discard []byte
+				if err := websocket.Message.Receive(conn, &discard); err != nil {
+					return
+				}
+			}
+		})
+	}))
+	s.AddCleanup(func(*gc.C) { server.Close() })
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, err := websocket.Dial(url, "", server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { client.Close() })
+	return client, closed
+}
+
+func (s *websocketKeepaliveSuite) advance(c *gc.C, d time.Duration) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for keepalive")
+	}
+	s.clock.Advance(d)
+}
+
+func (s *websocketKeepaliveSuite) TestDefaults(c *gc.C) {
+	keepalive := newWebsocketKeepalive(s.clock, 0, 0)
+	c.Assert(keepalive.interval, gc.Equals, DefaultWebsocketPingInterval)
+	c.Assert(keepalive.maxMissed, gc.Equals, DefaultWebsocketMaxMissedPongs)
+}
+
+func (s *websocketKeepaliveSuite) TestUnansweredPingsCloseConnection(c *gc.C) {
+	// The client never reads, so never answers the server's pings.
+	_, closed := s.serve(c, newWebsocketKeepalive(s.clock, time.Second, 2))
+
+	// The first tick sends the first ping; each later tick without
+	// a reply counts as a missed pong.
+	for i := 0; i < 2; i++ {
+		s.advance(c, time.Second)
+		select {
+		case <-closed:
+			c.Fatalf("connection closed after %d pings", i+1)
+		case <-time.After(coretesting.ShortWait):
+		}
+	}
+	s.advance(c, time.Second)
+	select {
+	case <-closed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("connection not closed")
+	}
+}
+
+func (s *websocketKeepaliveSuite) TestClientDataCountsAsPong(c *gc.C) {
+	client, closed := s.serve(c, newWebsocketKeepalive(s.clock, time.Second, 1))
+
+	for i := 0; i < 5; i++ {
+		// Writing between ticks is enough to show the client is
+		// alive; give the server a moment to read it.
+		err := websocket.Message.Send(client, "hello")
+		c.Assert(err, jc.ErrorIsNil)
+		time.Sleep(coretesting.ShortWait)
+		s.advance(c, time.Second)
+	}
+	select {
+	case <-closed:
+		c.Fatal("connection closed")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *websocketKeepaliveSuite) TestNilKeepalive(c *gc.C) {
+	var keepalive *websocketKeepalive
+	client, _ := s.serve(c, keepalive)
+	err := websocket.Message.Send(client, "hello")
+	c.Assert(err, jc.ErrorIsNil)
+}
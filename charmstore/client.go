@@ -16,6 +16,8 @@ import (
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/controller"
 )
 
 var logger = loggo.GetLogger("juju.charmstore")
@@ -32,6 +34,21 @@ type MacaroonCache interface {
 	Get(*charm.URL) (macaroon.Slice, error)
 }
 
+// ControllerServerURL returns the charm store URL configured for a
+// controller with the given config, or nil if the controller uses the
+// standard juju charmstore.
+func ControllerServerURL(cfg controller.Config) (*url.URL, error) {
+	server := cfg.CharmStoreURL()
+	if server == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing charm store URL")
+	}
+	return u, nil
+}
+
 // NewCachingClient returns a Juju charm store client that stores and retrieves
 // macaroons for calls in the given cache. If not nil, the client will use server
 // as the charmstore url, otherwise it will default to the standard juju
@@ -109,6 +109,11 @@ type Cloud struct {
 	// regions, may be overridden by a region.
 	StorageEndpoint string
 
+	// CACertificates contains an optional list of PEM-encoded CA
+	// certificates that are trusted when connecting to the cloud's
+	// endpoints.
+	CACertificates []string
+
 	// Regions are the regions available in the cloud.
 	//
 	// Regions is a slice, and not a map, because order is important.
@@ -165,6 +170,7 @@ type cloud struct {
 	Regions          regions                `yaml:"regions,omitempty"`
 	Config           map[string]interface{} `yaml:"config,omitempty"`
 	RegionConfig     RegionConfig           `yaml:"region-config,omitempty"`
+	CACertificates   []string               `yaml:"ca-certificates,omitempty"`
 }
 
 // regions is a collection of regions, either as a map and/or
@@ -402,6 +408,7 @@ func cloudToInternal(in Cloud) *cloud {
 		Regions:          regions,
 		Config:           in.Config,
 		RegionConfig:     in.RegionConfig,
+		CACertificates:   in.CACertificates,
 	}
 }
 
@@ -435,6 +442,7 @@ func cloudFromInternal(in *cloud) Cloud {
 		Config:           in.Config,
 		RegionConfig:     in.RegionConfig,
 		Description:      in.Description,
+		CACertificates:   in.CACertificates,
 	}
 	meta.denormaliseMetadata()
 	return meta
@@ -84,6 +84,24 @@ func (s *cloudSuite) TestParseCloudsConfig(c *gc.C) {
 	})
 }
 
+func (s *cloudSuite) TestParseCloudsCACertificates(c *gc.C) {
+	clouds, err := cloud.ParseCloudMetadata([]byte(`clouds:
+  testing:
+    type: dummy
+    ca-certificates:
+    - cert1
+    - cert2
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clouds, gc.HasLen, 1)
+	testingCloud := clouds["testing"]
+	c.Assert(testingCloud, jc.DeepEquals, cloud.Cloud{
+		Name:           "testing",
+		Type:           "dummy",
+		CACertificates: []string{"cert1", "cert2"},
+	})
+}
+
 func (s *cloudSuite) TestParseCloudsRegionConfig(c *gc.C) {
 	clouds, err := cloud.ParseCloudMetadata([]byte(`clouds:
   testing:
@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit
+
+import (
+	"path"
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// UserData holds additional cloud-init user-data, supplied by the
+// user when adding a machine, to be merged with the user-data that
+// juju generates for the machine.
+type UserData struct {
+	// RunCmd holds commands to run before the juju agent is started.
+	RunCmd []string `yaml:"runcmd,omitempty"`
+
+	// Packages holds the names of packages to install.
+	Packages []string `yaml:"packages,omitempty"`
+
+	// WriteFiles holds files to write when the machine boots.
+	WriteFiles []UserDataFile `yaml:"write_files,omitempty"`
+}
+
+// UserDataFile describes a file to be written by cloud-init.
+type UserDataFile struct {
+	Path        string `yaml:"path"`
+	Content     string `yaml:"content"`
+	Permissions string `yaml:"permissions,omitempty"`
+}
+
+// defaultUserDataFilePermissions holds the permissions given to
+// files that do not specify them.
+const defaultUserDataFilePermissions = 0644
+
+// ParseUserData parses and validates YAML cloud-init user-data.
+// Only the runcmd, packages and write_files keys are supported.
+func ParseUserData(data string) (*UserData, error) {
+	var keys map[string]interface{}
+	if err := yaml.Unmarshal([]byte(data), &keys); err != nil {
+		return nil, errors.Annotate(err, "cannot parse cloud-init user-data")
+	}
+	for key := range keys {
+		switch key {
+		case "runcmd", "packages", "write_files":
+		default:
+			return nil, errors.NotSupportedf("cloud-init user-data key %q", key)
+		}
+	}
+	var userData UserData
+	if err := yaml.Unmarshal([]byte(data), &userData); err != nil {
+		return nil, errors.Annotate(err, "cannot parse cloud-init user-data")
+	}
+	if err := userData.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &userData, nil
+}
+
+// Validate checks that the user-data is well formed.
+func (u *UserData) Validate() error {
+	for _, cmd := range u.RunCmd {
+		if cmd == "" {
+			return errors.NotValidf("empty runcmd")
+		}
+	}
+	for _, pkg := range u.Packages {
+		if pkg == "" {
+			return errors.NotValidf("empty package name")
+		}
+	}
+	for _, file := range u.WriteFiles {
+		if !path.IsAbs(file.Path) {
+			return errors.NotValidf("write_files path %q (must be absolute)", file.Path)
+		}
+		if _, err := file.permissions(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Apply adds the user-data to the given cloud config. Files are
+// written when the machine boots, and commands are run in the order
+// given, after any commands already added to the config.
+func (u *UserData) Apply(conf CloudConfig) error {
+	for _, file := range u.WriteFiles {
+		perms, err := file.permissions()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		conf.AddBootTextFile(file.Path, file.Content, perms)
+	}
+	for _, pkg := range u.Packages {
+		conf.AddPackage(pkg)
+	}
+	for _, cmd := range u.RunCmd {
+		conf.AddRunCmd(cmd)
+	}
+	return nil
+}
+
+func (f UserDataFile) permissions() (uint, error) {
+	if f.Permissions == "" {
+		return defaultUserDataFilePermissions, nil
+	}
+	perms, err := strconv.ParseUint(f.Permissions, 8, 32)
+	if err != nil || perms > 0777 {
+		return 0, errors.NotValidf("write_files permissions %q for %q", f.Permissions, f.Path)
+	}
+	return uint(perms), nil
+}
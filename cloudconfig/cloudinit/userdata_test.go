@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type userDataSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&userDataSuite{})
+
+func (s *userDataSuite) TestParseUserData(c *gc.C) {
+	userData, err := cloudinit.ParseUserData(`
+runcmd:
+- mkdir -p /srv/data
+- touch /srv/data/ready
+packages:
+- htop
+write_files:
+- path: /etc/motd
+  content: hello
+  permissions: "0600"
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(userData, jc.DeepEquals, &cloudinit.UserData{
+		RunCmd:   []string{"mkdir -p /srv/data", "touch /srv/data/ready"},
+		Packages: []string{"htop"},
+		WriteFiles: []cloudinit.UserDataFile{{
+			Path:        "/etc/motd",
+			Content:     "hello",
+			Permissions: "0600",
+		}},
+	})
+}
+
+func (s *userDataSuite) TestParseUserDataInvalid(c *gc.C) {
+	for i, test := range []struct {
+		data string
+		err  string
+	}{{
+		data: "users:\n- bob\n",
+		err:  `cloud-init user-data key "users" not supported`,
+	}, {
+		data: "runcmd: [\n",
+		err:  "cannot parse cloud-init user-data: .*",
+	}, {
+		data: "runcmd:\n- \"\"\n",
+		err:  "empty runcmd not valid",
+	}, {
+		data: "packages:\n- \"\"\n",
+		err:  "empty package name not valid",
+	}, {
+		data: "write_files:\n- path: etc/motd\n  content: hello\n",
+		err:  `write_files path "etc/motd" \(must be absolute\) not valid`,
+	}, {
+		data: "write_files:\n- path: /etc/motd\n  permissions: \"0999\"\n",
+		err:  `write_files permissions "0999" for "/etc/motd" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.data)
+		_, err := cloudinit.ParseUserData(test.data)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *userDataSuite) TestApply(c *gc.C) {
+	userData := &cloudinit.UserData{
+		RunCmd:   []string{"mkdir -p /srv/data", "touch /srv/data/ready"},
+		Packages: []string{"htop"},
+		WriteFiles: []cloudinit.UserDataFile{{
+			Path:    "/etc/motd",
+			Content: "hello",
+		}},
+	}
+	cfg, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg.AddRunCmd("echo juju")
+
+	err = userData.Apply(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.RunCmds(), jc.DeepEquals, []string{
+		"echo juju",
+		"mkdir -p /srv/data",
+		"touch /srv/data/ready",
+	})
+	c.Assert(cfg.Packages(), jc.DeepEquals, []string{"htop"})
+	c.Assert(cfg.BootCmds(), gc.Not(gc.HasLen), 0)
+}
@@ -148,6 +148,17 @@ type InstanceConfig struct {
 	// instances. If enabled, the OS will perform any upgrades
 	// available as part of its provisioning.
 	EnableOSUpgrade bool
+
+	// CloudInitUserData holds additional cloud-init user-data, in
+	// YAML, supplied by the user when the machine was added. It is
+	// merged with the user-data generated by juju, so that the host
+	// can be prepared before the agent starts.
+	CloudInitUserData string
+
+	// CharmLXDProfiles holds the names of LXD profiles, shipped with
+	// the charms of units to be deployed to the machine, that are to
+	// be applied to it. It is only used for LXD containers.
+	CharmLXDProfiles []string
 }
 
 // ControllerConfig represents controller-specific initialization information
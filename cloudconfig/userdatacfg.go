@@ -26,6 +26,16 @@ const (
 	// The file will contain the machine's nonce. The filename is
 	// relative to the Juju data-dir.
 	NonceFile = "nonce.txt"
+
+	// BootstrapToolsInstalledProgress is the progress message logged
+	// by the bootstrap machine's script once the agent binaries have
+	// been unpacked.
+	BootstrapToolsInstalledProgress = "Juju agent binaries installed"
+
+	// BootstrapStateInitializedProgress is the progress message logged
+	// by the bootstrap machine's script once bootstrap-state has
+	// initialised the controller's database.
+	BootstrapStateInitializedProgress = "Controller database initialised"
 )
 
 // UserdataConfig is the bridge between instancecfg and cloudinit
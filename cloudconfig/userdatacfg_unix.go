@@ -82,9 +82,25 @@ func (w *unixConfigure) Configure() error {
 	if err := w.ConfigureBasic(); err != nil {
 		return err
 	}
+	if err := w.configureUserData(); err != nil {
+		return err
+	}
 	return w.ConfigureJuju()
 }
 
+// configureUserData merges any additional user-data supplied for the
+// machine, so that its commands run before the juju agent starts.
+func (w *unixConfigure) configureUserData() error {
+	if w.icfg.CloudInitUserData == "" {
+		return nil
+	}
+	userData, err := cloudinit.ParseUserData(w.icfg.CloudInitUserData)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(userData.Apply(w.conf))
+}
+
 // ConfigureBasic updates the provided cloudinit.Config with
 // basic configuration to initialise an OS image, such that it can
 // be connected to via SSH, and log to a standard location.
@@ -235,11 +251,15 @@ func (w *unixConfigure) ConfigureJuju() error {
 	if err := w.addDownloadToolsCmds(); err != nil {
 		return errors.Trace(err)
 	}
+	if w.icfg.Bootstrap != nil {
+		w.conf.AddRunCmd(cloudinit.LogProgressCmd(BootstrapToolsInstalledProgress))
+	}
 
-	// Don't remove tools tarball until after bootstrap agent
-	// runs, so it has a chance to add it to its catalogue.
+	// The tools tarball is kept, both so the bootstrap agent can
+	// add it to its catalogue and so that later upgrades may be
+	// distributed as deltas against it; only the checksum goes.
 	defer w.conf.AddRunCmd(
-		fmt.Sprintf("rm $bin/tools.tar.gz && rm $bin/juju%s.sha256", w.icfg.AgentVersion()),
+		fmt.Sprintf("rm $bin/juju%s.sha256", w.icfg.AgentVersion()),
 	)
 
 	// We add the machine agent's configuration info
@@ -308,6 +328,7 @@ func (w *unixConfigure) configureBootstrap() error {
 	}
 	w.conf.AddRunCmd(cloudinit.LogProgressCmd("Installing Juju machine agent"))
 	w.conf.AddScripts(strings.Join(bootstrapAgentArgs, " "))
+	w.conf.AddRunCmd(cloudinit.LogProgressCmd(BootstrapStateInitializedProgress))
 
 	return nil
 }
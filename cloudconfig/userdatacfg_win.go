@@ -39,6 +39,9 @@ type windowsConfigure struct {
 // Configure updates the provided cloudinit.Config with
 // configuration to initialize a Juju machine agent.
 func (w *windowsConfigure) Configure() error {
+	if w.icfg.CloudInitUserData != "" {
+		return errors.NotSupportedf("cloud-init user-data on Windows")
+	}
 	if err := w.ConfigureBasic(); err != nil {
 		return err
 	}
@@ -125,7 +128,6 @@ func (w *windowsConfigure) ConfigureJuju() error {
 		fmt.Sprintf(`if ($dToolsHash.ToLower() -ne "%s"){ Throw "Tools checksum mismatch"}`,
 			tools.SHA256),
 		fmt.Sprintf(`GUnZip-File -infile $binDir\tools.tar.gz -outdir $binDir`),
-		`rm "$binDir\tools.tar*"`,
 		fmt.Sprintf(`Set-Content $binDir\downloaded-tools.txt '%s'`, string(toolsJson)),
 	)
 
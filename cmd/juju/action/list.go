@@ -41,11 +41,11 @@ For more information, see also the 'run-action' command, which executes actions.
 // Set up the output.
 func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": c.printTabular,
-	})
+	}))
 	f.BoolVar(&c.fullSchema, "schema", false, "Display the full action schema")
 }
 
@@ -113,8 +113,9 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 	}
 	utils.SortStringsNaturally(sortedNames)
 
+	format := output.BaseFormat(c.out.Name())
 	var output interface{}
-	switch c.out.Name() {
+	switch format {
 	case "yaml", "json":
 		output = shortOutput
 	default:
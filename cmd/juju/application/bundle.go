@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -59,6 +60,7 @@ func deployBundle(
 	apiRoot DeployAPI,
 	log deploymentLogger,
 	bundleStorage map[string]map[string]storage.Constraints,
+	filter bundleFilter,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
@@ -85,15 +87,27 @@ func deployBundle(
 		return nil, errors.Annotate(verifyError, "cannot deploy bundle")
 	}
 
-	// Retrieve bundle changes.
-	changes := bundlechanges.FromData(data)
-	numChanges := len(changes)
-
 	// Initialize the unit status.
 	status, err := apiRoot.Status(nil)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get model status")
 	}
+
+	// Select the part of the bundle to deploy.
+	if !filter.empty() {
+		deployed := make(map[string]bool, len(status.Applications))
+		for name := range status.Applications {
+			deployed[name] = true
+		}
+		if data, err = filterBundle(data, filter, deployed, log); err != nil {
+			return nil, errors.Annotate(err, "cannot deploy bundle")
+		}
+	}
+
+	// Retrieve bundle changes.
+	changes := bundlechanges.FromData(data)
+	numChanges := len(changes)
+
 	unitStatus := make(map[string]string, numChanges)
 	for _, serviceData := range status.Applications {
 		for unit, unitData := range serviceData.Units {
@@ -167,6 +181,152 @@ func deployBundle(
 	return csMacs, nil
 }
 
+// bundleFilter selects the applications of a bundle to deploy.
+type bundleFilter struct {
+	// Only, if not empty, holds the names of the only applications
+	// to deploy, along with the applications they depend on.
+	Only []string
+
+	// Skip holds the names of applications not to deploy.
+	Skip []string
+}
+
+func (f bundleFilter) empty() bool {
+	return len(f.Only) == 0 && len(f.Skip) == 0
+}
+
+// filterBundle returns the part of the given bundle selected by the
+// filter. Applications the selected ones are placed on are selected
+// too, as are applications already deployed, according to deployed,
+// that the selected ones are related to, so that the relations can be
+// added. Only the machines and relations of the selected applications
+// are kept.
+func filterBundle(
+	data *charm.BundleData,
+	filter bundleFilter,
+	deployed map[string]bool,
+	log deploymentLogger,
+) (*charm.BundleData, error) {
+	skipped := make(map[string]bool, len(filter.Skip))
+	for _, name := range filter.Skip {
+		if _, ok := data.Applications[name]; !ok {
+			return nil, errors.Errorf("application %q not found in bundle", name)
+		}
+		skipped[name] = true
+	}
+	selected := make(map[string]bool, len(data.Applications))
+	if len(filter.Only) == 0 {
+		for name := range data.Applications {
+			selected[name] = true
+		}
+	}
+	for _, name := range filter.Only {
+		if _, ok := data.Applications[name]; !ok {
+			return nil, errors.Errorf("application %q not found in bundle", name)
+		}
+		if skipped[name] {
+			return nil, errors.Errorf("application %q both selected and skipped", name)
+		}
+		selected[name] = true
+	}
+	for name := range skipped {
+		delete(selected, name)
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("no applications selected")
+	}
+
+	for _, relation := range data.Relations {
+		if len(relation) != 2 {
+			continue
+		}
+		app1, app2 := relationApplication(relation[0]), relationApplication(relation[1])
+		for _, pair := range [][2]string{{app1, app2}, {app2, app1}} {
+			app, other := pair[0], pair[1]
+			if selected[app] && !selected[other] && !skipped[other] && deployed[other] {
+				log.Infof("Including deployed application %q to relate it to %q", other, app)
+				selected[other] = true
+			}
+		}
+	}
+
+	// Select the applications the selected ones are placed on.
+	pending := make([]string, 0, len(selected))
+	for name := range selected {
+		pending = append(pending, name)
+	}
+	sort.Strings(pending)
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		for _, placement := range data.Applications[name].To {
+			target, _ := parseBundlePlacement(placement)
+			if target == "" || selected[target] {
+				continue
+			}
+			if _, ok := data.Applications[target]; !ok {
+				// Verification reports unknown applications.
+				continue
+			}
+			if skipped[target] {
+				return nil, errors.Errorf("application %q is placed on skipped application %q", name, target)
+			}
+			log.Infof("Including application %q, which %q is placed on", target, name)
+			selected[target] = true
+			pending = append(pending, target)
+		}
+	}
+
+	result := *data
+	result.Applications = make(map[string]*charm.ApplicationSpec, len(selected))
+	result.Machines = nil
+	for name := range selected {
+		spec := data.Applications[name]
+		result.Applications[name] = spec
+		for _, placement := range spec.To {
+			if _, machine := parseBundlePlacement(placement); machine != "" {
+				if m, ok := data.Machines[machine]; ok {
+					if result.Machines == nil {
+						result.Machines = make(map[string]*charm.MachineSpec)
+					}
+					result.Machines[machine] = m
+				}
+			}
+		}
+	}
+	result.Relations = nil
+	for _, relation := range data.Relations {
+		if len(relation) == 2 &&
+			selected[relationApplication(relation[0])] &&
+			selected[relationApplication(relation[1])] {
+			result.Relations = append(result.Relations, relation)
+		}
+	}
+	return &result, nil
+}
+
+// parseBundlePlacement returns the application or the bundle machine
+// a bundle placement directive such as "lxd:mysql/1" or "kvm:2" refers
+// to. Both are empty for directives that refer to new machines.
+func parseBundlePlacement(placement string) (application, machine string) {
+	if i := strings.Index(placement, ":"); i >= 0 {
+		placement = placement[i+1:]
+	}
+	if placement == "" || placement == "new" {
+		return "", ""
+	}
+	if _, err := strconv.Atoi(placement); err == nil {
+		return "", placement
+	}
+	return strings.SplitN(placement, "/", 2)[0], ""
+}
+
+// relationApplication returns the application name of the given bundle
+// relation endpoint, in the form "application[:endpoint]".
+func relationApplication(endpoint string) string {
+	return strings.SplitN(endpoint, ":", 2)[0]
+}
+
 // bundleHandler provides helpers and the state required to deploy a bundle.
 type bundleHandler struct {
 	// bundleDir is the path where the bundle file is located for local bundles.
@@ -197,6 +197,113 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleTwice(c *gc.C) {
 	})
 }
 
+const filteredBundle = `
+        applications:
+            wordpress:
+                charm: xenial/wordpress
+                num_units: 1
+            mysql:
+                charm: xenial/mysql
+                num_units: 1
+            varnish:
+                charm: xenial/varnish
+                num_units: 1
+                to: ["lxd:wordpress/0"]
+        relations:
+            - ["wordpress:db", "mysql:server"]
+            - ["varnish:webcache", "wordpress:cache"]
+    `
+
+func (s *BundleDeployCharmStoreSuite) uploadFilteredBundleCharms(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-0", "wordpress")
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-1", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/varnish-2", "varnish")
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleOnly(c *gc.C) {
+	s.uploadFilteredBundleCharms(c)
+	_, err := s.DeployBundleYAML(c, filteredBundle, "--only", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{
+		"mysql": {charm: "cs:xenial/mysql-1"},
+	})
+	s.assertUnitsCreated(c, map[string]string{
+		"mysql/0": "0",
+	})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleOnlyIncludesPlacementTargets(c *gc.C) {
+	s.uploadFilteredBundleCharms(c)
+	output, err := s.DeployBundleYAML(c, filteredBundle, "--only", "varnish")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.Contains, `Including application "wordpress", which "varnish" is placed on`)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{
+		"varnish":   {charm: "cs:xenial/varnish-2"},
+		"wordpress": {charm: "cs:xenial/wordpress-0"},
+	})
+	s.assertRelationsEstablished(c, "wordpress:cache varnish:webcache")
+	s.assertUnitsCreated(c, map[string]string{
+		"varnish/0":   "0/lxd/0",
+		"wordpress/0": "0",
+	})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleSkip(c *gc.C) {
+	s.uploadFilteredBundleCharms(c)
+	_, err := s.DeployBundleYAML(c, filteredBundle, "--skip", "varnish,mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{
+		"wordpress": {charm: "cs:xenial/wordpress-0"},
+	})
+	s.assertRelationsEstablished(c)
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleInStages(c *gc.C) {
+	s.uploadFilteredBundleCharms(c)
+	_, err := s.DeployBundleYAML(c, filteredBundle, "--only", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	output, err := s.DeployBundleYAML(c, filteredBundle, "--only", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.Contains, `Including deployed application "mysql" to relate it to "wordpress"`)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{
+		"mysql":     {charm: "cs:xenial/mysql-1"},
+		"wordpress": {charm: "cs:xenial/wordpress-0"},
+	})
+	s.assertRelationsEstablished(c, "wordpress:db mysql:server")
+	s.assertUnitsCreated(c, map[string]string{
+		"mysql/0":     "0",
+		"wordpress/0": "1",
+	})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleFilterErrors(c *gc.C) {
+	s.uploadFilteredBundleCharms(c)
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--only", "no-such"},
+		err:  `cannot deploy bundle: application "no-such" not found in bundle`,
+	}, {
+		args: []string{"--skip", "no-such"},
+		err:  `cannot deploy bundle: application "no-such" not found in bundle`,
+	}, {
+		args: []string{"--only", "mysql", "--skip", "mysql"},
+		err:  `cannot deploy bundle: application "mysql" both selected and skipped`,
+	}, {
+		args: []string{"--skip", "wordpress"},
+		err:  `cannot deploy bundle: application "varnish" is placed on skipped application "wordpress"`,
+	}, {
+		args: []string{"--skip", "wordpress,mysql,varnish"},
+		err:  `cannot deploy bundle: no applications selected`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.DeployBundleYAML(c, filteredBundle, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{})
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleGatedCharm(c *gc.C) {
 	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
 	url, _ := testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
@@ -281,7 +388,7 @@ func (s *BundleDeployCharmStoreSuite) Client() *csclient.Client {
 // DeployBundleYAML uses the given bundle content to create a bundle in the
 // local repository and then deploy it. It returns the bundle deployment output
 // and error.
-func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string) (string, error) {
+func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string, args ...string) (string, error) {
 	bundlePath := filepath.Join(c.MkDir(), "example")
 	c.Assert(os.Mkdir(bundlePath, 0777), jc.ErrorIsNil)
 	defer os.RemoveAll(bundlePath)
@@ -289,7 +396,7 @@ func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string)
 	c.Assert(err, jc.ErrorIsNil)
 	err = ioutil.WriteFile(filepath.Join(bundlePath, "README.md"), []byte("README"), 0644)
 	c.Assert(err, jc.ErrorIsNil)
-	return runDeployCommand(c, bundlePath)
+	return runDeployCommand(c, bundlePath, args...)
 }
 
 var deployBundleErrorsTests = []struct {
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/stagedconfig"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -42,6 +43,19 @@ Examples:
     juju config mysql dataset-size=80% backup_dir=/vol1/mysql/backups
     juju config apache2 --model mymodel --file /home/ubuntu/mysql.yaml
 
+Changes made with --staged are validated and recorded, but not applied,
+until --commit applies everything staged in a single update. This lets a
+reconfiguration touching many keys be built up over several commands
+while the application's units see only one config change. A staged
+--file must hold settings under the application's name, as for deploy.
+--staged on its own shows the changes staged so far, and --discard
+forgets them.
+
+    juju config mysql --staged dataset-size=80%
+    juju config mysql --staged --file changes.yaml --reset backup_dir
+    juju config mysql --staged
+    juju config mysql --commit
+
 See also:
     deploy
     status
@@ -58,7 +72,8 @@ type attributes map[string]string
 
 // configCommand get, sets, and resets configuration values of an application.
 type configCommand struct {
-	api configCommandAPI
+	api       configCommandAPI
+	stagedAPI stagedConfigAPI
 	modelcmd.ModelCommandBase
 	out cmd.Output
 
@@ -70,6 +85,11 @@ type configCommand struct {
 	resetKeys       []string // Holds the keys to be reset once parsed.
 	useFile         bool
 	values          attributes
+
+	staged       bool
+	commit       bool
+	discard      bool
+	stagedAction func(stagedConfigAPI, *cmd.Context) error // set in Init when staging
 }
 
 // configCommandAPI is an interface to allow passing in a fake implementation under test.
@@ -81,6 +101,16 @@ type configCommandAPI interface {
 	Unset(application string, options []string) error
 }
 
+// stagedConfigAPI is the part of the StagedConfig facade used by the
+// config command; it allows passing in a fake implementation under test.
+type stagedConfigAPI interface {
+	Close() error
+	Stage(application string, options map[string]string, settingsYAML string, reset []string) error
+	Staged(application string) (params.ApplicationStagedConfigResults, error)
+	Commit(application string) error
+	Discard(application string) error
+}
+
 // Info is part of the cmd.Command interface.
 func (c *configCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -97,6 +127,9 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.Var(&c.configFile, "file", "path to yaml-formatted application config")
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.staged, "staged", false, "Stage the changes to be applied later with --commit, or show the staged changes")
+	f.BoolVar(&c.commit, "commit", false, "Apply all staged changes in a single update")
+	f.BoolVar(&c.discard, "discard", false, "Forget all staged changes")
 }
 
 // getAPI either uses the fake API set at test time or that is nil, gets a real
@@ -113,6 +146,19 @@ func (c *configCommand) getAPI() (configCommandAPI, error) {
 	return client, nil
 }
 
+// getStagedAPI returns the StagedConfig facade client, or the fake set
+// at test time.
+func (c *configCommand) getStagedAPI() (stagedConfigAPI, error) {
+	if c.stagedAPI != nil {
+		return c.stagedAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return stagedconfig.NewClient(root), nil
+}
+
 // Init is part of the cmd.Command interface.
 func (c *configCommand) Init(args []string) error {
 	if len(args) == 0 || len(strings.Split(args[0], "=")) > 1 {
@@ -130,6 +176,10 @@ func (c *configCommand) Init(args []string) error {
 	c.applicationName = args[0]
 	args = args[1:]
 
+	if c.staged || c.commit || c.discard {
+		return c.initStaged(args)
+	}
+
 	switch len(args) {
 	case 0:
 		return c.handleZeroArgs()
@@ -189,6 +239,39 @@ func (c *configCommand) handleArgs(args []string) error {
 	return errors.New("cannot set and retrieve values simultaneously")
 }
 
+// initStaged handles the --staged, --commit and --discard flags.
+func (c *configCommand) initStaged(args []string) error {
+	changes := len(args) > 0 || c.configFile.Path != "" || len(c.resetKeys) > 0
+	switch {
+	case c.commit && c.discard:
+		return errors.New("cannot specify --commit and --discard simultaneously")
+	case c.commit || c.discard:
+		if c.staged || changes {
+			return errors.New("--commit and --discard cannot be combined with other changes")
+		}
+		if c.commit {
+			c.stagedAction = c.commitConfig
+		} else {
+			c.stagedAction = c.discardConfig
+		}
+		return nil
+	case !changes:
+		c.stagedAction = c.getStagedConfig
+		return nil
+	}
+	for _, arg := range args {
+		if !strings.Contains(arg, "=") {
+			return errors.New("--staged cannot be used to retrieve values")
+		}
+	}
+	if err := c.parseSet(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.action = nil
+	c.stagedAction = c.stageConfig
+	return nil
+}
+
 // parseResetKeys splits the keys provided to --reset.
 func (c *configCommand) parseResetKeys() error {
 	if len(c.reset) == 0 {
@@ -234,6 +317,14 @@ func (c *configCommand) parseSet(args []string) error {
 
 // Run implements the cmd.Command interface.
 func (c *configCommand) Run(ctx *cmd.Context) error {
+	if c.stagedAction != nil {
+		client, err := c.getStagedAPI()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer client.Close()
+		return c.stagedAction(client, ctx)
+	}
 	client, err := c.getAPI()
 	if err != nil {
 		return errors.Trace(err)
@@ -295,19 +386,9 @@ func (c *configCommand) setConfig(client configCommandAPI, ctx *cmd.Context) err
 // setConfigFromFile sets the application configuration from settings passed
 // in a YAML file.
 func (c *configCommand) setConfigFromFile(client configCommandAPI, ctx *cmd.Context) error {
-	var (
-		b   []byte
-		err error
-	)
-	if c.configFile.Path == "-" {
-		buf := bytes.Buffer{}
-		buf.ReadFrom(ctx.Stdin)
-		b = buf.Bytes()
-	} else {
-		b, err = c.configFile.Read(ctx)
-		if err != nil {
-			return err
-		}
+	b, err := c.readConfigFile(ctx)
+	if err != nil {
+		return err
 	}
 	return block.ProcessBlockedError(
 		client.Update(
@@ -316,6 +397,69 @@ func (c *configCommand) setConfigFromFile(client configCommandAPI, ctx *cmd.Cont
 				SettingsYAML:    string(b)}), block.BlockChange)
 }
 
+// readConfigFile reads the YAML config file passed with --file, or
+// stdin if the path is "-".
+func (c *configCommand) readConfigFile(ctx *cmd.Context) ([]byte, error) {
+	if c.configFile.Path == "-" {
+		buf := bytes.Buffer{}
+		buf.ReadFrom(ctx.Stdin)
+		return buf.Bytes(), nil
+	}
+	return c.configFile.Read(ctx)
+}
+
+// stageConfig is the run action when staging changes with --staged.
+func (c *configCommand) stageConfig(client stagedConfigAPI, ctx *cmd.Context) error {
+	var settingsYAML []byte
+	if c.useFile {
+		var err error
+		settingsYAML, err = c.readConfigFile(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	settings, err := c.validateValues(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(settings) == 0 {
+		settings = nil
+	}
+	return client.Stage(c.applicationName, settings, string(settingsYAML), c.resetKeys)
+}
+
+// getStagedConfig is the run action to show the staged changes.
+func (c *configCommand) getStagedConfig(client stagedConfigAPI, ctx *cmd.Context) error {
+	result, err := client.Staged(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Settings) == 0 && len(result.Reset) == 0 {
+		ctx.Infof("No config changes staged for %q.", c.applicationName)
+		return nil
+	}
+	staged := map[string]interface{}{
+		"application": result.Application,
+	}
+	if len(result.Settings) > 0 {
+		staged["settings"] = result.Settings
+	}
+	if len(result.Reset) > 0 {
+		staged["reset"] = result.Reset
+	}
+	return c.out.Write(ctx, staged)
+}
+
+// commitConfig is the run action to apply the staged changes.
+func (c *configCommand) commitConfig(client stagedConfigAPI, ctx *cmd.Context) error {
+	return block.ProcessBlockedError(client.Commit(c.applicationName), block.BlockChange)
+}
+
+// discardConfig is the run action to forget the staged changes.
+func (c *configCommand) discardConfig(client stagedConfigAPI, ctx *cmd.Context) error {
+	return client.Discard(c.applicationName)
+}
+
 // getConfig is the run action to return one or all configuration values.
 func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) error {
 	results, err := client.Get(c.applicationName)
@@ -10,12 +10,14 @@ import (
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -297,6 +299,121 @@ func (s *configCommandSuite) TestBlockSetConfig(c *gc.C) {
 	c.Check(stripped, gc.Matches, ".*TestBlockSetConfig.*")
 }
 
+func (s *configCommandSuite) TestStagedCommandInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"application", "--commit", "--discard"},
+		err:  "cannot specify --commit and --discard simultaneously",
+	}, {
+		args: []string{"application", "--commit", "key=value"},
+		err:  "--commit and --discard cannot be combined with other changes",
+	}, {
+		args: []string{"application", "--discard", "--staged"},
+		err:  "--commit and --discard cannot be combined with other changes",
+	}, {
+		args: []string{"application", "--staged", "key"},
+		err:  "--staged cannot be used to retrieve values",
+	}, {
+		args: []string{"application", "--staged", "--file", "testconfig.yaml", "key=value"},
+		err:  "cannot specify --file and key=value arguments simultaneously",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := coretesting.InitCommand(application.NewStagedConfigCommandForTest(s.fake, &fakeStagedConfigAPI{}), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *configCommandSuite) TestStageConfig(c *gc.C) {
+	staged := &fakeStagedConfigAPI{}
+	ctx := coretesting.ContextForDir(c, s.dir)
+	code := cmd.Main(application.NewStagedConfigCommandForTest(s.fake, staged), ctx, []string{
+		"dummy-application", "--staged", "--reset", "outlook", "username=hello", "title=@valid.txt",
+	})
+	c.Assert(code, gc.Equals, 0)
+	staged.CheckCalls(c, []testing.StubCall{
+		{"Stage", []interface{}{
+			"dummy-application",
+			map[string]string{"username": "hello", "title": validSetTestValue},
+			"",
+			[]string{"outlook"},
+		}},
+		{"Close", nil},
+	})
+	// The application's config is untouched.
+	c.Assert(s.fake.values["username"], gc.Equals, "admin001")
+}
+
+func (s *configCommandSuite) TestStageConfigFromFile(c *gc.C) {
+	staged := &fakeStagedConfigAPI{}
+	ctx := coretesting.ContextForDir(c, s.dir)
+	code := cmd.Main(application.NewStagedConfigCommandForTest(s.fake, staged), ctx, []string{
+		"dummy-application", "--staged", "--file", "testconfig.yaml",
+	})
+	c.Assert(code, gc.Equals, 0)
+	staged.CheckCall(c, 0, "Stage", "dummy-application", map[string]string(nil), yamlConfigValue, []string(nil))
+}
+
+func (s *configCommandSuite) TestShowStagedConfig(c *gc.C) {
+	staged := &fakeStagedConfigAPI{result: params.ApplicationStagedConfigResults{
+		Application: "dummy-application",
+		Settings:    map[string]interface{}{"username": "hello"},
+		Reset:       []string{"outlook"},
+	}}
+	ctx := coretesting.Context(c)
+	code := cmd.Main(application.NewStagedConfigCommandForTest(s.fake, staged), ctx, []string{
+		"dummy-application", "--staged",
+	})
+	c.Assert(code, gc.Equals, 0)
+	staged.CheckCallNames(c, "Staged", "Close")
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+application: dummy-application
+reset:
+- outlook
+settings:
+  username: hello
+`[1:])
+}
+
+func (s *configCommandSuite) TestShowStagedConfigEmpty(c *gc.C) {
+	ctx := coretesting.Context(c)
+	code := cmd.Main(application.NewStagedConfigCommandForTest(s.fake, &fakeStagedConfigAPI{}), ctx, []string{
+		"dummy-application", "--staged",
+	})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "No config changes staged for \"dummy-application\".\n")
+}
+
+func (s *configCommandSuite) TestCommitAndDiscard(c *gc.C) {
+	for _, flag := range []string{"--commit", "--discard"} {
+		staged := &fakeStagedConfigAPI{}
+		ctx := coretesting.Context(c)
+		code := cmd.Main(application.NewStagedConfigCommandForTest(s.fake, staged), ctx, []string{
+			"dummy-application", flag,
+		})
+		c.Assert(code, gc.Equals, 0)
+		method := strings.Title(flag[2:])
+		staged.CheckCalls(c, []testing.StubCall{
+			{method, []interface{}{"dummy-application"}},
+			{"Close", nil},
+		})
+	}
+}
+
+func (s *configCommandSuite) TestBlockCommit(c *gc.C) {
+	staged := &fakeStagedConfigAPI{}
+	staged.SetErrors(common.OperationBlockedError("TestBlockCommit"))
+	ctx := coretesting.Context(c)
+	code := cmd.Main(application.NewStagedConfigCommandForTest(s.fake, staged), ctx, []string{
+		"dummy-application", "--commit",
+	})
+	c.Check(code, gc.Equals, 1)
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockCommit.*")
+}
+
 // assertSetSuccess sets configuration options and checks the expected settings.
 func (s *configCommandSuite) assertSetSuccess(c *gc.C, dir string, args []string, expect map[string]interface{}) {
 	ctx := coretesting.ContextForDir(c, dir)
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/constraints"
 )
 
@@ -116,11 +117,11 @@ func formatConstraints(writer io.Writer, value interface{}) error {
 
 func (c *serviceGetConstraintsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "constraints", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "constraints", output.Versioned(map[string]cmd.Formatter{
 		"constraints": formatConstraints,
 		"yaml":        cmd.FormatYaml,
 		"json":        cmd.FormatJson,
-	})
+	}))
 }
 
 func (c *serviceGetConstraintsCommand) Init(args []string) error {
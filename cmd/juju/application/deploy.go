@@ -90,6 +90,10 @@ type DeployAPI interface {
 
 	GetBundle(*charm.URL) (charm.Bundle, error)
 
+	// Get retrieves a charm from the charm store without adding it
+	// to the model.
+	Get(*charm.URL) (charm.Charm, error)
+
 	WatchAll() (*api.AllWatcher, error)
 
 	// AddPendingResources(client.AddPendingResourcesArgs) (ids []string, _ error)
@@ -267,6 +271,15 @@ type DeployCommand struct {
 	// the storage name defined in that application's charm storage metadata.
 	BundleStorage map[string]map[string]storage.Constraints
 
+	// BundleOnly and BundleSkip select the applications to deploy
+	// from a bundle.
+	BundleOnly []string
+	BundleSkip []string
+
+	// DryRun, if true, prints the deployment plan for a charm instead
+	// of deploying it.
+	DryRun bool
+
 	// Resources is a map of resource name to filename to be uploaded on deploy.
 	Resources map[string]string
 
@@ -318,6 +331,15 @@ For example:
 
   juju deploy /path/to/bundle/openstack/bundle.yaml
 
+Parts of a bundle can be deployed with the '--only' and '--skip' options,
+which take comma-delimited application names, so that large bundles can be
+rolled out in stages. Applications that the selected ones are placed on are
+deployed with them. Relations are added between the selected applications,
+and between them and related applications that are already deployed.
+
+  juju deploy ./openstack.yaml --only mysql,rabbitmq-server,keystone
+  juju deploy ./openstack.yaml --skip nova-compute
+
 If an 'application name' is not provided, the application name used is the
 'charm or bundle' name.
 
@@ -333,6 +355,13 @@ than one resource.
 
 Where 'bar' and 'baz' are resources named in the metadata for the 'foo' charm.
 
+The '--dry-run' option resolves the charm and checks the deployment options
+against it, then prints the resulting plan (the charm, series, units and their
+placement, constraints, config, storage, bindings and resources) without adding
+the charm or the application to the model.
+
+  juju deploy mysql -n 3 --constraints mem=8G --dry-run
+
 When using a placement directive to deploy to an existing machine or container
 ('--to' option), the ` + "`juju status`" + ` command should be used for guidance. A few
 placement directives are provider-dependent (e.g.: 'zone').
@@ -416,8 +445,8 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
-	charmOnlyFlags        = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource"}
-	bundleOnlyFlags       = []string{}
+	charmOnlyFlags        = []string{"bind", "config", "constraints", "dry-run", "force", "n", "num-units", "series", "to", "resource"}
+	bundleOnlyFlags       = []string{"only", "skip"}
 	modelCommandBaseFlags = []string{"B", "no-browser-login"}
 )
 
@@ -435,6 +464,9 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
+	f.Var(cmd.NewAppendStringsValue(&c.BundleOnly), "only", "Deploy only the provided comma delimited bundle applications, and those they depend on")
+	f.Var(cmd.NewAppendStringsValue(&c.BundleSkip), "skip", "Do not deploy the provided comma delimited bundle applications")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Print the deployment plan for a charm without deploying it")
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -497,6 +529,7 @@ func (c *DeployCommand) deployBundle(
 		apiRoot,
 		ctx,
 		bundleStorage,
+		bundleFilter{Only: c.BundleOnly, Skip: c.BundleSkip},
 	); err != nil {
 		return errors.Trace(err)
 	}
@@ -516,29 +549,14 @@ func (c *DeployCommand) deployCharm(
 		return err
 	}
 
-	numUnits := c.NumUnits
-	if charmInfo.Meta.Subordinate {
-		if !constraints.IsEmpty(&c.Constraints) {
-			return errors.New("cannot use --constraints with subordinate application")
-		}
-		if numUnits == 1 && c.PlacementSpec == "" {
-			numUnits = 0
-		} else {
-			return errors.New("cannot use --num-units or --to with subordinate application")
-		}
+	plan, err := c.planDeploy(ctx, id, series, charmInfo.Meta)
+	if err != nil {
+		return errors.Trace(err)
 	}
-	serviceName := c.ApplicationName
-	if serviceName == "" {
-		serviceName = charmInfo.Meta.Name
-	}
-
-	var configYAML []byte
-	if c.Config.Path != "" {
-		configYAML, err = c.Config.Read(ctx)
-		if err != nil {
-			return errors.Trace(err)
-		}
+	if c.DryRun {
+		return errors.Trace(writePlan(ctx, plan))
 	}
+	serviceName := plan.Application
 
 	bakeryClient, err := c.BakeryClient()
 	if err != nil {
@@ -595,8 +613,8 @@ func (c *DeployCommand) deployCharm(
 		Cons:             c.Constraints,
 		ApplicationName:  serviceName,
 		Series:           series,
-		NumUnits:         numUnits,
-		ConfigYAML:       string(configYAML),
+		NumUnits:         plan.NumUnits,
+		ConfigYAML:       plan.configYAML,
 		Placement:        c.Placement,
 		Storage:          c.Storage,
 		Resources:        ids,
@@ -819,6 +837,13 @@ func (c *DeployCommand) maybeReadLocalCharm() (deployFn, error) {
 	}
 
 	return func(ctx *cmd.Context, apiRoot DeployAPI) error {
+		if c.DryRun {
+			plan, err := c.planDeploy(ctx, charmstore.CharmID{URL: curl}, curl.Series, ch.Meta())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return errors.Trace(writePlan(ctx, plan))
+		}
 		if curl, err = apiRoot.AddLocalCharm(curl, ch); err != nil {
 			return errors.Trace(err)
 		}
@@ -933,6 +958,24 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 			return errors.Errorf("%v. Use --force to deploy the charm anyway.", err)
 		}
 
+		if c.DryRun {
+			// Read the charm from the store rather than adding it
+			// to the model.
+			ch, err := apiRoot.Get(storeCharmOrBundleURL)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			id := charmstore.CharmID{
+				URL:     storeCharmOrBundleURL,
+				Channel: channel,
+			}
+			plan, err := c.planDeploy(ctx, id, series, ch.Meta())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return errors.Trace(writePlan(ctx, plan))
+		}
+
 		// Store the charm in the controller
 		curl, csMac, err := addCharmFromURL(apiRoot, storeCharmOrBundleURL, channel)
 		if err != nil {
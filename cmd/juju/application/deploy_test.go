@@ -1270,6 +1270,70 @@ func (s *DeployUnitTestSuite) TestDeployLocalCharm_GivesCorrectUserMessage(c *gc
 	c.Check(jtesting.Stderr(context), gc.Equals, `Deploying charm "local:trusty/dummy-1".`+"\n")
 }
 
+func (s *DeployUnitTestSuite) TestDeployLocalCharmDryRun(c *gc.C) {
+	charmsPath := c.MkDir()
+	charmDir := testcharms.Repo.ClonedDir(charmsPath, "dummy")
+
+	fakeAPI := vanillaFakeModelAPI(map[string]interface{}{
+		"name": "name",
+		"uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"type": "foo",
+	})
+	deployCmd := NewDeployCommand(func() (DeployAPI, error) {
+		return fakeAPI, nil
+	}, nil)
+	context, err := jtesting.RunCommand(c, deployCmd, charmDir.Path,
+		"--series", "trusty", "--dry-run", "-n", "2", "--to", "0,lxd:1", "--constraints", "mem=1G")
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, call := range fakeAPI.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Matches), "AddLocalCharm|Deploy")
+	}
+	c.Check(jtesting.Stdout(context), gc.Equals, `
+charm: local:trusty/dummy-1
+application: dummy
+series: trusty
+num-units: 2
+placement:
+- "0"
+- lxd:1
+constraints: mem=1024M
+`[1:])
+}
+
+func (s *DeployUnitTestSuite) TestDeployCharmStoreCharmDryRun(c *gc.C) {
+	charmsPath := c.MkDir()
+	charmDir := testcharms.Repo.ClonedDir(charmsPath, "dummy")
+
+	cfgAttrs := map[string]interface{}{
+		"name": "name",
+		"uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"type": "foo",
+	}
+	fakeAPI := vanillaFakeModelAPI(cfgAttrs)
+	cfg, err := config.New(config.NoDefaults, cfgAttrs)
+	c.Assert(err, jc.ErrorIsNil)
+	dummyURL := charm.MustParseURL("cs:quantal/dummy-3")
+	withCharmRepoResolvable(fakeAPI, dummyURL, cfg)
+	fakeAPI.Call("Get", dummyURL).Returns(charm.Charm(charmDir), error(nil))
+
+	deployCmd := NewDeployCommand(func() (DeployAPI, error) {
+		return fakeAPI, nil
+	}, nil)
+	context, err := jtesting.RunCommand(c, deployCmd, dummyURL.String(), "my-dummy", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, call := range fakeAPI.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Matches), "AddCharm.*|Deploy")
+	}
+	c.Check(jtesting.Stdout(context), gc.Equals, `
+charm: cs:quantal/dummy-3
+application: my-dummy
+series: quantal
+num-units: 1
+`[1:])
+}
+
 func (s *DeployUnitTestSuite) TestAddMetricCredentialsDefaultForUnmeteredCharm(c *gc.C) {
 	charmsPath := c.MkDir()
 	charmDir := testcharms.Repo.ClonedDir(charmsPath, "dummy")
@@ -1483,6 +1547,11 @@ func (f *fakeDeployAPI) GetBundle(url *charm.URL) (charm.Bundle, error) {
 	return results[0].(charm.Bundle), jujutesting.TypeAssertError(results[1])
 }
 
+func (f *fakeDeployAPI) Get(url *charm.URL) (charm.Charm, error) {
+	results := f.MethodCall(f, "Get", url)
+	return results[0].(charm.Charm), jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) Status(patterns []string) (*params.FullStatus, error) {
 	results := f.MethodCall(f, "Status", patterns)
 	return results[0].(*params.FullStatus), jujutesting.TypeAssertError(results[1])
@@ -0,0 +1,132 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageDiffBundleSummary = `
+Compares a bundle with the model.`[1:]
+
+var usageDiffBundleDetails = `
+Shows the differences between a local bundle file and the applications,
+machines and relations in the model. Applications and machines found on
+only one side are reported as missing from the other; for the rest,
+each differing value is shown as it is in the bundle and in the model.
+
+Settings the bundle leaves out, such as a charm's revision or series,
+or the placement of an application's units, are not compared.
+
+Examples:
+    juju diff-bundle mediawiki.yaml
+    juju diff-bundle -m production mediawiki.yaml --format json
+
+See also:
+    deploy
+    export-model`[1:]
+
+// NewDiffBundleCommand returns a command to compare a bundle with the
+// model.
+func NewDiffBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// diffBundleCommand shows the differences between a bundle and the
+// model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	api        DiffBundleAPI
+	out        cmd.Output
+	bundleFile string
+}
+
+// Info implements cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file>",
+		Purpose: usageDiffBundleSummary,
+		Doc:     usageDiffBundleDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle file specified")
+	}
+	c.bundleFile = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// DiffBundleAPI specifies the used function calls of the Bundle facade.
+type DiffBundleAPI interface {
+	Close() error
+	DiffBundle(bundleYAML string) (*params.BundleDiff, error)
+}
+
+func (c *diffBundleCommand) getAPI() (DiffBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	data, err := ioutil.ReadFile(ctx.AbsPath(c.bundleFile))
+	if err != nil {
+		return errors.Annotate(err, "cannot read bundle")
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	diff, err := client.DiffBundle(string(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	formatted, err := formatBundleDiff(diff)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// formatBundleDiff returns the given diff as generic maps, so that
+// every output format uses the same field names as the API.
+func formatBundleDiff(diff *params.BundleDiff) (map[string]interface{}, error) {
+	data, err := json.Marshal(diff)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DiffBundleSuite struct {
+	testing.IsolationSuite
+	mockAPI    *mockDiffBundleAPI
+	bundlePath string
+}
+
+var _ = gc.Suite(&DiffBundleSuite{})
+
+const diffBundleYAML = `
+applications:
+    mysql:
+        charm: cs:mysql
+        num_units: 1
+`
+
+func (s *DiffBundleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockDiffBundleAPI{Stub: &testing.Stub{}}
+	s.bundlePath = filepath.Join(c.MkDir(), "bundle.yaml")
+	err := ioutil.WriteFile(s.bundlePath, []byte(diffBundleYAML), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DiffBundleSuite) runDiffBundle(c *gc.C, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommand(c, application.NewDiffBundleCommandForTest(s.mockAPI), args...)
+}
+
+func (s *DiffBundleSuite) TestNoArguments(c *gc.C) {
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle file specified")
+}
+
+func (s *DiffBundleSuite) TestTooManyArguments(c *gc.C) {
+	_, err := s.runDiffBundle(c, s.bundlePath, "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *DiffBundleSuite) TestMissingFile(c *gc.C) {
+	_, err := s.runDiffBundle(c, filepath.Join(c.MkDir(), "missing.yaml"))
+	c.Assert(err, gc.ErrorMatches, "cannot read bundle: .*")
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *DiffBundleSuite) TestErrorFromAPI(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("infirmary"))
+	_, err := s.runDiffBundle(c, s.bundlePath)
+	c.Assert(err, gc.ErrorMatches, "infirmary")
+}
+
+func (s *DiffBundleSuite) TestDiff(c *gc.C) {
+	s.mockAPI.diff = &params.BundleDiff{
+		Applications: map[string]*params.ApplicationDiff{
+			"mysql": {
+				NumUnits: &params.IntDiff{Bundle: 1, Model: 2},
+			},
+			"wordpress": {Missing: params.BundleDiffMissingBundle},
+		},
+		Relations: &params.RelationsDiff{
+			ModelAdditions: [][]string{{"mysql:server", "wordpress:db"}},
+		},
+	}
+	ctx, err := s.runDiffBundle(c, s.bundlePath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"DiffBundle", []interface{}{diffBundleYAML}},
+		{"Close", nil},
+	})
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+applications:
+  mysql:
+    num-units:
+      bundle: 1
+      model: 2
+  wordpress:
+    missing: bundle
+relations:
+  model-additions:
+  - - mysql:server
+    - wordpress:db
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestNoDiff(c *gc.C) {
+	s.mockAPI.diff = &params.BundleDiff{}
+	ctx, err := s.runDiffBundle(c, s.bundlePath, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+type mockDiffBundleAPI struct {
+	*testing.Stub
+
+	diff *params.BundleDiff
+}
+
+func (a *mockDiffBundleAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockDiffBundleAPI) DiffBundle(bundleYAML string) (*params.BundleDiff, error) {
+	a.MethodCall(a, "DiffBundle", bundleYAML)
+	return a.diff, a.NextErr()
+}
@@ -46,6 +46,15 @@ func NewConfigCommandForTest(api configCommandAPI) cmd.Command {
 	})
 }
 
+// NewStagedConfigCommandForTest returns a config command using the
+// provided application and staged config APIs.
+func NewStagedConfigCommandForTest(api configCommandAPI, stagedAPI stagedConfigAPI) cmd.Command {
+	return modelcmd.Wrap(&configCommand{
+		api:       api,
+		stagedAPI: stagedAPI,
+	})
+}
+
 // NewAddUnitCommandForTest returns an AddUnitCommand with the api provided as specified.
 func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	return modelcmd.Wrap(&addUnitCommand{
@@ -53,6 +62,14 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewScaleApplicationCommandForTest returns a scale-application command
+// with the api provided as specified.
+func NewScaleApplicationCommandForTest(api scaleApplicationAPI) cmd.Command {
+	return modelcmd.Wrap(&scaleApplicationCommand{
+		api: api,
+	})
+}
+
 // NewAddRelationCommandForTest returns an AddRelationCommand with the api provided as specified.
 func NewAddRelationCommandForTest(api ApplicationAddRelationAPI) cmd.Command {
 	cmd := &addRelationCommand{newAPIFunc: func() (ApplicationAddRelationAPI, error) {
@@ -74,6 +91,12 @@ func NewConsumeCommandForTest(api applicationConsumeAPI) cmd.Command {
 	return modelcmd.Wrap(&consumeCommand{api: api})
 }
 
+// NewDiffBundleCommandForTest returns a DiffBundleCommand with the
+// specified api.
+func NewDiffBundleCommandForTest(api DiffBundleAPI) cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{api: api})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
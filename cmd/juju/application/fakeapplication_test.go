@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/testing"
 
 	"github.com/juju/juju/apiserver/params"
 )
@@ -100,3 +101,35 @@ func (f *fakeApplicationAPI) Unset(application string, options []string) error {
 
 	return nil
 }
+
+// fakeStagedConfigAPI is the fake StagedConfig API for testing the
+// config command's --staged, --commit and --discard flags.
+type fakeStagedConfigAPI struct {
+	testing.Stub
+	result params.ApplicationStagedConfigResults
+}
+
+func (f *fakeStagedConfigAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeStagedConfigAPI) Stage(application string, options map[string]string, settingsYAML string, reset []string) error {
+	f.AddCall("Stage", application, options, settingsYAML, reset)
+	return f.NextErr()
+}
+
+func (f *fakeStagedConfigAPI) Staged(application string) (params.ApplicationStagedConfigResults, error) {
+	f.AddCall("Staged", application)
+	return f.result, f.NextErr()
+}
+
+func (f *fakeStagedConfigAPI) Commit(application string) error {
+	f.AddCall("Commit", application)
+	return f.NextErr()
+}
+
+func (f *fakeStagedConfigAPI) Discard(application string) error {
+	f.AddCall("Discard", application)
+	return f.NextErr()
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/storage"
+)
+
+// deployPlan describes what deploying a charm does. It is printed by
+// "juju deploy --dry-run" instead of deploying the charm.
+type deployPlan struct {
+	Charm       string                 `yaml:"charm"`
+	Channel     string                 `yaml:"channel,omitempty"`
+	Application string                 `yaml:"application"`
+	Series      string                 `yaml:"series,omitempty"`
+	NumUnits    int                    `yaml:"num-units"`
+	Placement   []string               `yaml:"placement,omitempty"`
+	Constraints string                 `yaml:"constraints,omitempty"`
+	Config      map[string]interface{} `yaml:"config,omitempty"`
+	Storage     map[string]string      `yaml:"storage,omitempty"`
+	Bindings    map[string]string      `yaml:"bindings,omitempty"`
+	Resources   map[string]string      `yaml:"resources,omitempty"`
+
+	// configYAML holds the contents of the --config file, which are
+	// passed on to the controller unchanged.
+	configYAML string
+}
+
+// upgradePlan describes what upgrading an application's charm does.
+// It is printed by "juju upgrade-charm --dry-run" instead of upgrading
+// the charm.
+type upgradePlan struct {
+	Application string                 `yaml:"application"`
+	From        string                 `yaml:"from"`
+	To          string                 `yaml:"to"`
+	Channel     string                 `yaml:"channel,omitempty"`
+	Config      map[string]interface{} `yaml:"config,omitempty"`
+	Storage     map[string]string      `yaml:"storage,omitempty"`
+	Resources   map[string]string      `yaml:"resources,omitempty"`
+	ForceSeries bool                   `yaml:"force-series,omitempty"`
+	ForceUnits  bool                   `yaml:"force-units,omitempty"`
+}
+
+// planDeploy returns the plan for deploying the charm with the given
+// id and metadata on the given series. The deployment arguments are
+// checked against the charm, but nothing is changed in the model.
+func (c *DeployCommand) planDeploy(
+	ctx *cmd.Context,
+	id charmstore.CharmID,
+	series string,
+	meta *charm.Meta,
+) (*deployPlan, error) {
+	numUnits := c.NumUnits
+	if meta.Subordinate {
+		if !constraints.IsEmpty(&c.Constraints) {
+			return nil, errors.New("cannot use --constraints with subordinate application")
+		}
+		if numUnits == 1 && c.PlacementSpec == "" {
+			numUnits = 0
+		} else {
+			return nil, errors.New("cannot use --num-units or --to with subordinate application")
+		}
+	}
+	applicationName := c.ApplicationName
+	if applicationName == "" {
+		applicationName = meta.Name
+	}
+
+	var configYAML []byte
+	if c.Config.Path != "" {
+		var err error
+		configYAML, err = c.Config.Read(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	config, err := applicationConfig(configYAML, applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	plan := &deployPlan{
+		Charm:       id.URL.String(),
+		Channel:     string(id.Channel),
+		Application: applicationName,
+		Series:      series,
+		NumUnits:    numUnits,
+		Config:      config,
+		Storage:     formatStorage(c.Storage),
+		Bindings:    c.Bindings,
+		Resources:   c.Resources,
+		configYAML:  string(configYAML),
+	}
+	if c.PlacementSpec != "" {
+		plan.Placement = strings.Split(c.PlacementSpec, ",")
+	}
+	if !constraints.IsEmpty(&c.Constraints) {
+		plan.Constraints = c.Constraints.String()
+	}
+	return plan, nil
+}
+
+// applicationConfig returns the settings for the named application in
+// the given application config YAML, as read from a --config file.
+func applicationConfig(configYAML []byte, application string) (map[string]interface{}, error) {
+	if len(configYAML) == 0 {
+		return nil, nil
+	}
+	var config map[string]map[string]interface{}
+	if err := goyaml.Unmarshal(configYAML, &config); err != nil {
+		return nil, errors.Annotate(err, "cannot parse config")
+	}
+	return config[application], nil
+}
+
+// formatStorage returns the given storage constraints in the form
+// they are given on the command line.
+func formatStorage(cons map[string]storage.Constraints) map[string]string {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(cons))
+	for name, sc := range cons {
+		var parts []string
+		if sc.Pool != "" {
+			parts = append(parts, sc.Pool)
+		}
+		if sc.Count > 0 {
+			parts = append(parts, fmt.Sprint(sc.Count))
+		}
+		if sc.Size > 0 {
+			parts = append(parts, fmt.Sprintf("%dM", sc.Size))
+		}
+		result[name] = strings.Join(parts, ",")
+	}
+	return result
+}
+
+// writePlan writes the given deploy or upgrade plan to the context's
+// standard output as YAML.
+func writePlan(ctx *cmd.Context, plan interface{}) error {
+	out, err := goyaml.Marshal(plan)
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal plan")
+	}
+	_, err = ctx.Stdout.Write(out)
+	return errors.Trace(err)
+}
@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/romulus/api/budget"
 	wireformat "github.com/juju/romulus/wireformat/budget"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -28,6 +29,7 @@ func NewRemoveApplicationCommand() cmd.Command {
 type removeApplicationCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	SnapshotStorage bool
 }
 
 var helpSummaryRmApp = `
@@ -42,8 +44,15 @@ before removing them. Removing units which are co-located with units of
 other charms or a Juju controller will not result in the removal of the
 machine.
 
+If --snapshot-storage is specified, the volumes backing the storage attached
+to the application's units are snapshotted before the application is removed,
+where the storage provider supports it. The snapshot IDs are reported, and
+recorded in the controller log. If any snapshot fails, the application is not
+removed.
+
 Examples:
     juju remove-application hadoop
+    juju remove-application --snapshot-storage postgresql
     juju remove-application -m test-model mariadb`[1:]
 
 func (c *removeApplicationCommand) Info() *cmd.Info {
@@ -55,6 +64,11 @@ func (c *removeApplicationCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *removeApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.SnapshotStorage, "snapshot-storage", false, snapshotStorageDoc)
+}
+
 func (c *removeApplicationCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("no application specified")
@@ -83,6 +97,12 @@ func (c *removeApplicationCommand) getAPI() (removeApplicationAPI, error) {
 }
 
 func (c *removeApplicationCommand) Run(ctx *cmd.Context) error {
+	if c.SnapshotStorage {
+		tags := []names.Tag{names.NewApplicationTag(c.ApplicationName)}
+		if err := snapshotStorage(ctx, &c.ModelCommandBase, tags); err != nil {
+			return errors.Annotate(err, "application not removed")
+		}
+	}
 	client, err := c.getAPI()
 	if err != nil {
 		return err
@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
@@ -21,7 +22,8 @@ func NewRemoveUnitCommand() cmd.Command {
 // removeUnitCommand is responsible for destroying application units.
 type removeUnitCommand struct {
 	modelcmd.ModelCommandBase
-	UnitNames []string
+	UnitNames       []string
+	SnapshotStorage bool
 }
 
 const removeUnitDoc = `
@@ -38,9 +40,15 @@ on that machine (including units in containers).
 Removing all units of a service is not equivalent to removing the service
 itself; for that, the ` + "`juju remove-service`" + ` command is used.
 
+If --snapshot-storage is specified, the volumes backing the storage attached
+to the units are snapshotted before the units are removed, where the storage
+provider supports it. The snapshot IDs are reported, and recorded in the
+controller log. If any snapshot fails, no units are removed.
+
 Examples:
 
     juju remove-unit wordpress/2 wordpress/3 wordpress/4
+    juju remove-unit --snapshot-storage postgresql/1
 
 See also:
     remove-service
@@ -55,6 +63,11 @@ func (c *removeUnitCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.SnapshotStorage, "snapshot-storage", false, snapshotStorageDoc)
+}
+
 func (c *removeUnitCommand) Init(args []string) error {
 	c.UnitNames = args
 	if len(c.UnitNames) == 0 {
@@ -78,7 +91,16 @@ func (c *removeUnitCommand) getAPI() (removeApplicationAPI, error) {
 
 // Run connects to the environment specified on the command line and destroys
 // units therein.
-func (c *removeUnitCommand) Run(_ *cmd.Context) error {
+func (c *removeUnitCommand) Run(ctx *cmd.Context) error {
+	if c.SnapshotStorage {
+		tags := make([]names.Tag, len(c.UnitNames))
+		for i, name := range c.UnitNames {
+			tags[i] = names.NewUnitTag(name)
+		}
+		if err := snapshotStorage(ctx, &c.ModelCommandBase, tags); err != nil {
+			return errors.Annotate(err, "no units removed")
+		}
+	}
 	client, err := c.getAPI()
 	if err != nil {
 		return err
@@ -6,11 +6,15 @@ package application
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/series"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
@@ -56,6 +60,82 @@ func (s *RemoveUnitSuite) TestRemoveUnit(c *gc.C) {
 		c.Assert(u.Life(), gc.Equals, state.Dying)
 	}
 }
+
+func (s *RemoveUnitSuite) TestRemoveUnitSnapshotStorage(c *gc.C) {
+	svc := s.setupUnitForRemove(c)
+	api := &fakeStorageSnapshotAPI{
+		results: []params.SnapshotUnitStorageResult{{
+			Snapshots: []params.VolumeSnapshot{{
+				VolumeTag:  "volume-0",
+				VolumeId:   "vol-0",
+				SnapshotId: "snap-0",
+			}, {
+				VolumeTag: "volume-1",
+				VolumeId:  "vol-1",
+				Error:     &params.Error{Code: params.CodeNotSupported, Message: "not supported"},
+			}},
+		}, {}},
+	}
+	s.PatchValue(&getStorageSnapshotAPI, func(*modelcmd.ModelCommandBase) (storageSnapshotAPI, error) {
+		return api, nil
+	})
+
+	ctx, err := testing.RunCommand(c, NewRemoveUnitCommand(), "--snapshot-storage", "dummy/0", "dummy/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "snapshot snap-0 taken of volume 0 (vol-0)\n")
+	c.Assert(api.tags, jc.DeepEquals, []names.Tag{
+		names.NewUnitTag("dummy/0"),
+		names.NewUnitTag("dummy/1"),
+	})
+	units, err := svc.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, u := range units {
+		c.Assert(u.Life(), gc.Equals, state.Dying)
+	}
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitSnapshotStorageFails(c *gc.C) {
+	svc := s.setupUnitForRemove(c)
+	api := &fakeStorageSnapshotAPI{
+		results: []params.SnapshotUnitStorageResult{{
+			Snapshots: []params.VolumeSnapshot{{
+				VolumeTag: "volume-0",
+				VolumeId:  "vol-0",
+				Error:     &params.Error{Message: "quota exceeded"},
+			}},
+		}},
+	}
+	s.PatchValue(&getStorageSnapshotAPI, func(*modelcmd.ModelCommandBase) (storageSnapshotAPI, error) {
+		return api, nil
+	})
+
+	err := runRemoveUnit(c, "--snapshot-storage", "dummy/0")
+	c.Assert(err, gc.ErrorMatches, `no units removed: snapshotting volume 0 \(vol-0\): quota exceeded`)
+	units, err := svc.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, u := range units {
+		c.Assert(u.Life(), gc.Equals, state.Alive)
+	}
+}
+
+type fakeStorageSnapshotAPI struct {
+	tags    []names.Tag
+	results []params.SnapshotUnitStorageResult
+	err     error
+}
+
+func (f *fakeStorageSnapshotAPI) Close() error {
+	return nil
+}
+
+func (f *fakeStorageSnapshotAPI) SnapshotUnitStorage(tags []names.Tag) ([]params.SnapshotUnitStorageResult, error) {
+	f.tags = tags
+	if f.err != nil {
+		return nil, errors.Trace(f.err)
+	}
+	return f.results, nil
+}
+
 func (s *RemoveUnitSuite) TestBlockRemoveUnit(c *gc.C) {
 	svc := s.setupUnitForRemove(c)
 
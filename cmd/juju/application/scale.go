@@ -0,0 +1,177 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/instance"
+)
+
+var usageScaleApplicationSummary = `
+Sets the number of units of a deployed application.`[1:]
+
+var usageScaleApplicationDetails = `
+Adds or removes units so that the application has the given number of
+units. Units that are already being removed are not counted.
+
+When units are added, the placement directive ("--to") may be used to
+target specific machines or containers, as with add-unit. Directives
+are used in order for the new units; any units without a directive are
+placed according to the application and model constraints.
+
+When units are removed, the removal policy ("--remove-policy") decides
+which units go first:
+
+    youngest-first           remove the most recently added units
+    emptiest-machine-first   remove units on machines hosting the fewest
+                             units, so that whole machines are freed
+                             where possible
+
+Examples:
+Scale wordpress to ten units:
+
+    juju scale-application wordpress 10
+
+Scale mysql to three units, placing any new units on machines 4 and 5:
+
+    juju scale-application mysql 3 --to 4,5
+
+Scale haproxy down to two units, freeing the least used machines:
+
+    juju scale-application haproxy 2 --remove-policy emptiest-machine-first
+
+See also:
+    add-unit
+    remove-unit`[1:]
+
+// NewScaleApplicationCommand returns a command that sets the number
+// of units of an application.
+func NewScaleApplicationCommand() cmd.Command {
+	return modelcmd.Wrap(&scaleApplicationCommand{})
+}
+
+// scaleApplicationCommand is responsible for adding or removing units
+// so that an application has a given number of them.
+type scaleApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Scale           int
+	PlacementSpec   string
+	Placement       []*instance.Placement
+	RemovalPolicy   string
+	api             scaleApplicationAPI
+}
+
+// scaleApplicationAPI defines the methods on the client API
+// that the scale-application command calls.
+type scaleApplicationAPI interface {
+	Close() error
+	ModelUUID() string
+	Scale(application string, scale int, placement []*instance.Placement, removalPolicy string) (params.ScaleApplicationResult, error)
+}
+
+func (c *scaleApplicationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "scale-application",
+		Args:    "<application name> <scale>",
+		Purpose: usageScaleApplicationSummary,
+		Doc:     usageScaleApplicationDetails,
+	}
+}
+
+func (c *scaleApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.PlacementSpec, "to", "", "The machines and/or containers to deploy any new units in (bypasses constraints)")
+	f.StringVar(&c.RemovalPolicy, "remove-policy", params.ScaleRemoveYoungestFirst, "How to choose the units to remove")
+}
+
+func (c *scaleApplicationCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application specified")
+	case 1:
+		return errors.New("no scale specified")
+	}
+	c.ApplicationName = args[0]
+	scale, err := strconv.Atoi(args[1])
+	if err != nil || scale < 0 {
+		return errors.Errorf("invalid scale %q: must be a non-negative integer", args[1])
+	}
+	c.Scale = scale
+	if err := cmd.CheckEmpty(args[2:]); err != nil {
+		return err
+	}
+	switch c.RemovalPolicy {
+	case params.ScaleRemoveYoungestFirst, params.ScaleRemoveEmptiestMachineFirst:
+	default:
+		return errors.Errorf("unknown --remove-policy %q", c.RemovalPolicy)
+	}
+	if c.PlacementSpec != "" {
+		placementSpecs := strings.Split(c.PlacementSpec, ",")
+		c.Placement = make([]*instance.Placement, len(placementSpecs))
+		for i, spec := range placementSpecs {
+			placement, err := parsePlacement(spec)
+			if err != nil {
+				return errors.Errorf("invalid --to parameter %q", spec)
+			}
+			c.Placement[i] = placement
+		}
+	}
+	return nil
+}
+
+func (c *scaleApplicationCommand) getAPI() (scaleApplicationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run connects to the model specified on the command line and calls
+// Scale for the given application, reporting the units added or removed.
+func (c *scaleApplicationCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	for _, p := range c.Placement {
+		if p.Scope == "model-uuid" {
+			p.Scope = apiclient.ModelUUID()
+		}
+	}
+	result, err := apiclient.Scale(c.ApplicationName, c.Scale, c.Placement, c.RemovalPolicy)
+	if params.IsCodeUnauthorized(err) {
+		common.PermissionsMessage(ctx.Stderr, "scale an application")
+	}
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	for _, name := range result.Added {
+		ctx.Infof("added unit %s", name)
+	}
+	for _, name := range result.Removed {
+		ctx.Infof("removing unit %s", name)
+	}
+	if len(result.Added) == 0 && len(result.Removed) == 0 {
+		ctx.Infof("%s already has %d unit(s)", c.ApplicationName, c.Scale)
+	}
+	return nil
+}
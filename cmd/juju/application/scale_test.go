@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/testing"
+)
+
+type ScaleApplicationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeScaleApplicationAPI
+}
+
+var _ = gc.Suite(&ScaleApplicationSuite{})
+
+type fakeScaleApplicationAPI struct {
+	jujutesting.Stub
+	result params.ScaleApplicationResult
+}
+
+func (f *fakeScaleApplicationAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeScaleApplicationAPI) ModelUUID() string {
+	return "fake-uuid"
+}
+
+func (f *fakeScaleApplicationAPI) Scale(application string, scale int, placement []*instance.Placement, removalPolicy string) (params.ScaleApplicationResult, error) {
+	f.MethodCall(f, "Scale", application, scale, placement, removalPolicy)
+	return f.result, f.NextErr()
+}
+
+func (s *ScaleApplicationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeScaleApplicationAPI{}
+}
+
+func (s *ScaleApplicationSuite) runScale(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, application.NewScaleApplicationCommandForTest(s.fake), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stderr(ctx), nil
+}
+
+func (s *ScaleApplicationSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no application specified",
+	}, {
+		args: []string{"wordpress"},
+		err:  "no scale specified",
+	}, {
+		args: []string{"wordpress", "lots"},
+		err:  `invalid scale "lots": must be a non-negative integer`,
+	}, {
+		args: []string{"wordpress", "-1"},
+		err:  `invalid scale "-1": must be a non-negative integer`,
+	}, {
+		args: []string{"wordpress", "3", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"wordpress", "3", "--remove-policy", "oldest-first"},
+		err:  `unknown --remove-policy "oldest-first"`,
+	}, {
+		args: []string{"wordpress", "3", "--to", "1,#:foo"},
+		err:  `invalid --to parameter "#:foo"`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(application.NewScaleApplicationCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ScaleApplicationSuite) TestScaleUp(c *gc.C) {
+	s.fake.result = params.ScaleApplicationResult{Added: []string{"wordpress/3", "wordpress/4"}}
+	out, err := s.runScale(c, "wordpress", "5", "--to", "lxd:1,foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "added unit wordpress/3\nadded unit wordpress/4\n")
+	s.fake.CheckCall(c, 0, "Scale", "wordpress", 5, []*instance.Placement{
+		{"lxd", "1"},
+		{"fake-uuid", "foo"},
+	}, params.ScaleRemoveYoungestFirst)
+}
+
+func (s *ScaleApplicationSuite) TestScaleDown(c *gc.C) {
+	s.fake.result = params.ScaleApplicationResult{Removed: []string{"wordpress/1"}}
+	out, err := s.runScale(c, "wordpress", "1", "--remove-policy", "emptiest-machine-first")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "removing unit wordpress/1\n")
+	s.fake.CheckCall(c, 0, "Scale", "wordpress", 1, []*instance.Placement(nil), params.ScaleRemoveEmptiestMachineFirst)
+}
+
+func (s *ScaleApplicationSuite) TestScaleUnchanged(c *gc.C) {
+	out, err := s.runScale(c, "wordpress", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "wordpress already has 2 unit(s)\n")
+}
+
+func (s *ScaleApplicationSuite) TestBlocked(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlocked"))
+	_, err := s.runScale(c, "wordpress", "2")
+	c.Assert(err, gc.NotNil)
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlocked.*")
+}
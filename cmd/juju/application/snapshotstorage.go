@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/storage"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const snapshotStorageDoc = "Snapshot the volumes backing attached storage before removal, where the storage provider supports it"
+
+type storageSnapshotAPI interface {
+	Close() error
+	SnapshotUnitStorage([]names.Tag) ([]params.SnapshotUnitStorageResult, error)
+}
+
+var getStorageSnapshotAPI = func(c *modelcmd.ModelCommandBase) (storageSnapshotAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return storage.NewClient(root), nil
+}
+
+// snapshotStorage takes a snapshot of the volumes backing the storage
+// attached to the specified units or applications, and reports the
+// resulting snapshot IDs. Volumes whose provider does not support
+// snapshots are skipped with a warning; any other failure is returned,
+// so that the caller can refuse to remove anything.
+func snapshotStorage(ctx *cmd.Context, c *modelcmd.ModelCommandBase, tags []names.Tag) error {
+	client, err := getStorageSnapshotAPI(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.SnapshotUnitStorage(tags)
+	if err != nil {
+		return errors.Annotate(err, "snapshotting storage")
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "snapshotting storage of %s", names.ReadableString(tags[i]))
+		}
+		for _, snapshot := range result.Snapshots {
+			volume := snapshot.VolumeTag
+			if tag, err := names.ParseVolumeTag(snapshot.VolumeTag); err == nil {
+				volume = names.ReadableString(tag)
+			}
+			if snapshot.Error != nil {
+				if params.IsCodeNotSupported(snapshot.Error) {
+					logger.Warningf("not snapshotting %s (%s): %v", volume, snapshot.VolumeId, snapshot.Error)
+					continue
+				}
+				return errors.Annotatef(snapshot.Error, "snapshotting %s (%s)", volume, snapshot.VolumeId)
+			}
+			ctx.Infof("snapshot %s taken of %s (%s)", snapshot.SnapshotId, volume, snapshot.VolumeId)
+		}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -119,6 +121,17 @@ type upgradeCharmCommand struct {
 	// Storage is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
+
+	// DryRun, if true, prints the upgrade plan instead of upgrading
+	// the charm.
+	DryRun bool
+
+	// AutoRollback, if true, asks the controller to revert the upgrade
+	// if more than RollbackThreshold percent of the application's units
+	// fail the upgrade-charm hook within RollbackWindow.
+	AutoRollback      bool
+	RollbackThreshold int
+	RollbackWindow    time.Duration
 }
 
 const upgradeCharmDoc = `
@@ -189,6 +202,18 @@ is determined by the contents of the charm at the specified path.
 number with --switch, give it in the charm URL, for instance "cs:wordpress-5"
 would specify revision number 5 of the wordpress charm.
 
+The --dry-run flag resolves the new charm and prints the upgrade plan (the
+current and new charm URLs, and the config, storage and resources that would
+be updated) without adding the charm to the model or upgrading the application.
+
+The --auto-rollback flag asks the controller to remember the application's
+current charm and config, and to return to them if the upgrade-charm hook fails
+on more than --rollback-threshold percent of the application's units within
+--rollback-window of the upgrade. The application's status reports any
+rollback that takes place.
+
+  juju upgrade-charm foo --auto-rollback --rollback-threshold 25 --rollback-window 1h
+
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
@@ -214,6 +239,10 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Print the upgrade plan without upgrading the charm")
+	f.BoolVar(&c.AutoRollback, "auto-rollback", false, "Revert the upgrade if the upgrade-charm hook fails on too many units")
+	f.IntVar(&c.RollbackThreshold, "rollback-threshold", 50, "Percentage of units whose upgrade-charm hook must fail for --auto-rollback to revert the upgrade")
+	f.DurationVar(&c.RollbackWindow, "rollback-window", 30*time.Minute, "How long after the upgrade --auto-rollback watches for failures")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -237,6 +266,12 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.RollbackThreshold < 0 || c.RollbackThreshold >= 100 {
+		return errors.Errorf("--rollback-threshold must be between 0 and 99")
+	}
+	if c.RollbackWindow <= 0 {
+		return errors.Errorf("--rollback-window must be positive")
+	}
 	return nil
 }
 
@@ -295,9 +330,16 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	charmAdder := c.NewCharmAdder(apiRoot, bakeryClient, c.Channel)
 	charmRepo := c.getCharmStore(bakeryClient, modelConfig)
-	chID, csMac, err := c.addCharm(charmAdder, charmRepo, modelConfig, oldURL, newRef)
+	chID, localCharm, err := c.resolveNewCharm(charmRepo, modelConfig, oldURL, newRef)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.DryRun {
+		return errors.Trace(c.writeUpgradePlan(ctx, oldURL, chID))
+	}
+	charmAdder := c.NewCharmAdder(apiRoot, bakeryClient, c.Channel)
+	chID, csMac, err := c.addCharm(charmAdder, chID, localCharm)
 	if err != nil {
 		if termsErr, ok := errors.Cause(err).(*termsRequiredError); ok {
 			terms := strings.Join(termsErr.Terms, " ")
@@ -341,6 +383,12 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
+	if c.AutoRollback {
+		cfg.AutoRollback = &params.CharmRollbackPolicy{
+			Threshold: c.RollbackThreshold,
+			Window:    c.RollbackWindow,
+		}
+	}
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
 
@@ -490,16 +538,16 @@ func (c *upgradeCharmCommand) getCharmStore(
 	).(*charmrepo.CharmStore)
 }
 
-// addCharm interprets the new charmRef and adds the specified charm if
-// the new charm is different to what's already deployed as specified by
-// oldURL.
-func (c *upgradeCharmCommand) addCharm(
-	charmAdder CharmAdder,
+// resolveNewCharm interprets the new charmRef and returns the charm to
+// upgrade to, if it is different to what's already deployed as
+// specified by oldURL. If the charm is read from a local path, it is
+// also returned, ready to be added to the model.
+func (c *upgradeCharmCommand) resolveNewCharm(
 	charmRepo *charmrepo.CharmStore,
 	config *config.Config,
 	oldURL *charm.URL,
 	charmRef string,
-) (charmstore.CharmID, *macaroon.Macaroon, error) {
+) (charmstore.CharmID, charm.Charm, error) {
 	var id charmstore.CharmID
 	// Charm may have been supplied via a path reference.
 	ch, newURL, err := charmrepo.NewCharmAtPathForceSeries(charmRef, oldURL.Series, c.ForceSeries)
@@ -508,9 +556,8 @@ func (c *upgradeCharmCommand) addCharm(
 		if newName != oldURL.Name {
 			return id, nil, errors.Errorf("cannot upgrade %q to %q", oldURL.Name, newName)
 		}
-		addedURL, err := charmAdder.AddLocalCharm(newURL, ch)
-		id.URL = addedURL
-		return id, nil, err
+		id.URL = newURL
+		return id, ch, nil
 	}
 	if _, ok := err.(*charmrepo.NotFoundError); ok {
 		return id, nil, errors.Errorf("no charm found at %q", charmRef)
@@ -553,11 +600,54 @@ func (c *upgradeCharmCommand) addCharm(
 		// available.
 		return id, nil, errors.Errorf("already running latest charm %q", newURL)
 	}
+	id.URL = newURL
+	return id, nil, nil
+}
 
-	curl, csMac, err := addCharmFromURL(charmAdder, newURL, channel)
+// addCharm adds the charm resolved by resolveNewCharm to the model.
+// localCharm holds the charm if it was read from a local path.
+func (c *upgradeCharmCommand) addCharm(
+	charmAdder CharmAdder,
+	id charmstore.CharmID,
+	localCharm charm.Charm,
+) (charmstore.CharmID, *macaroon.Macaroon, error) {
+	if localCharm != nil {
+		addedURL, err := charmAdder.AddLocalCharm(id.URL, localCharm)
+		id.URL = addedURL
+		return id, nil, err
+	}
+	curl, csMac, err := addCharmFromURL(charmAdder, id.URL, id.Channel)
 	if err != nil {
 		return id, nil, errors.Trace(err)
 	}
 	id.URL = curl
 	return id, csMac, nil
 }
+
+// writeUpgradePlan prints the plan for upgrading the application from
+// the charm with oldURL to the charm with the given id.
+func (c *upgradeCharmCommand) writeUpgradePlan(ctx *cmd.Context, oldURL *charm.URL, id charmstore.CharmID) error {
+	var configYAML []byte
+	if c.Config.Path != "" {
+		var err error
+		configYAML, err = c.Config.Read(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	config, err := applicationConfig(configYAML, c.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return writePlan(ctx, &upgradePlan{
+		Application: c.ApplicationName,
+		From:        oldURL.String(),
+		To:          id.URL.String(),
+		Channel:     string(id.Channel),
+		Config:      config,
+		Storage:     formatStorage(c.Storage),
+		Resources:   c.Resources,
+		ForceSeries: c.ForceSeries,
+		ForceUnits:  c.ForceUnits,
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -32,6 +33,7 @@ import (
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/charms"
+	"github.com/juju/juju/apiserver/params"
 	jujucharmstore "github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/config"
@@ -227,6 +229,63 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestAutoRollback(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--auto-rollback", "--rollback-threshold", "25", "--rollback-window", "1h")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL", "SetCharm")
+	s.charmUpgradeClient.CheckCall(c, 1, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		AutoRollback: &params.CharmRollbackPolicy{
+			Threshold: 25,
+			Window:    time.Hour,
+		},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestRollbackFlagsIgnoredWithoutAutoRollback(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--rollback-threshold", "25")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCall(c, 1, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestInvalidRollbackFlags(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--auto-rollback", "--rollback-threshold", "100")
+	c.Assert(err, gc.ErrorMatches, "--rollback-threshold must be between 0 and 99")
+	_, err = s.runUpgradeCharm(c, "foo", "--auto-rollback", "--rollback-window", "0s")
+	c.Assert(err, gc.ErrorMatches, "--rollback-window must be positive")
+}
+
+func (s *UpgradeCharmSuite) TestDryRun(c *gc.C) {
+	configFile := filepath.Join(c.MkDir(), "config.yaml")
+	err := ioutil.WriteFile(configFile, []byte("foo:\n  title: hello\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := s.runUpgradeCharm(c, "foo", "--dry-run", "--config", configFile, "--storage", "bar=baz")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL")
+	s.charmAdder.CheckNoCalls(c)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+application: foo
+from: cs:quantal/foo-1
+to: cs:quantal/foo-2
+channel: stable
+config:
+  title: hello
+storage:
+  bar: baz,1
+`[1:])
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
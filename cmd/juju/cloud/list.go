@@ -63,11 +63,11 @@ func (c *listCloudsCommand) Info() *cmd.Info {
 
 func (c *listCloudsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": formatCloudsTabular,
-	})
+	}))
 }
 
 func (c *listCloudsCommand) Run(ctxt *cmd.Context) error {
@@ -76,8 +76,9 @@ func (c *listCloudsCommand) Run(ctxt *cmd.Context) error {
 		return err
 	}
 
+	format := output.BaseFormat(c.out.Name())
 	var output interface{}
-	switch c.out.Name() {
+	switch format {
 	case "yaml", "json":
 		output = details.all()
 	default:
@@ -114,11 +114,11 @@ func (c *listCredentialsCommand) Info() *cmd.Info {
 func (c *listCredentialsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.showSecrets, "show-secrets", false, "Show secrets")
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": formatCredentialsTabular,
-	})
+	}))
 }
 
 func (c *listCredentialsCommand) Init(args []string) error {
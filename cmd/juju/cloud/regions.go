@@ -53,11 +53,11 @@ func (c *listRegionsCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *listRegionsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": c.formatRegionsListTabular,
-	})
+	}))
 }
 
 // Init implements Command.Init.
@@ -83,7 +83,7 @@ func (c *listRegionsCommand) Run(ctxt *cmd.Context) error {
 		return nil
 	}
 	var regions interface{}
-	if c.out.Name() == "json" {
+	if output.BaseFormat(c.out.Name()) == "json" {
 		details := make(map[string]regionDetails)
 		for _, r := range cloud.Regions {
 			details[r.Name] = regionDetails{
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/output"
 )
 
 type showCloudCommand struct {
@@ -41,9 +42,9 @@ func NewShowCloudCommand() cmd.Command {
 func (c *showCloudCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	// We only support yaml for display purposes.
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "yaml", output.Versioned(map[string]cmd.Formatter{
 		"yaml": cmd.FormatYaml,
-	})
+	}))
 }
 
 func (c *showCloudCommand) Init(args []string) error {
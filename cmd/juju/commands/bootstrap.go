@@ -92,6 +92,20 @@ use in all models for this controller. The full binary version is accepted
 (e.g.: 2.0.1-xenial-amd64) but only the numeric version (e.g.: 2.0.1) is
 used. Otherwise, by default, the version used is that of the client.
 
+Controllers without access to the internet ("air-gapped") may be
+bootstrapped by combining '--metadata-source', pointing at a directory
+containing locally generated agent binary and/or image simplestreams
+metadata, with '--charm-store-url', which sets the URL of a charm store
+mirror reachable from the controller. The metadata source is checked
+before any cloud resources are provisioned.
+
+Bootstrap records its progress in the client's controller details. If
+bootstrap fails after the controller's database has been initialised (for
+example while waiting for the controller's agent to start), and
+'--keep-broken' was specified, it may be resumed with
+'--resume <controller name>' rather than destroying the controller and
+bootstrapping again.
+
 Examples:
     juju bootstrap
     juju bootstrap --clouds
@@ -102,6 +116,8 @@ Examples:
     juju bootstrap --config=~/config-rs.yaml rackspace joe-syd
     juju bootstrap --config agent-version=1.25.3 aws joe-us-east-1
     juju bootstrap --config bootstrap-timeout=1200 azure joe-eastus
+    juju bootstrap --resume joe-eastus
+    juju bootstrap --metadata-source ~/offline --charm-store-url https://charms.example.com maas
 
 See also:
     add-credentials
@@ -132,6 +148,7 @@ type bootstrapCommand struct {
 	BootstrapImage          string
 	BuildAgent              bool
 	MetadataSource          string
+	CharmStoreURL           string
 	Placement               string
 	KeepBrokenEnvironment   bool
 	AutoUpgrade             bool
@@ -149,6 +166,7 @@ type bootstrapCommand struct {
 	Region              string
 	noGUI               bool
 	interactive         bool
+	resume              bool
 }
 
 func (c *bootstrapCommand) Info() *cmd.Info {
@@ -170,6 +188,7 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	}
 	f.BoolVar(&c.BuildAgent, "build-agent", false, "Build local version of agent binary before bootstrapping")
 	f.StringVar(&c.MetadataSource, "metadata-source", "", "Local path to use as tools and/or metadata source")
+	f.StringVar(&c.CharmStoreURL, "charm-store-url", "", "URL of the charm store the controller should use")
 	f.StringVar(&c.Placement, "to", "", "Placement directive indicating an instance to bootstrap")
 	f.BoolVar(&c.KeepBrokenEnvironment, "keep-broken", false, "Do not destroy the model if bootstrap fails")
 	f.BoolVar(&c.AutoUpgrade, "auto-upgrade", false, "Upgrade to the latest patch release tools on first bootstrap")
@@ -182,12 +201,18 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.noGUI, "no-gui", false, "Do not install the Juju GUI in the controller when bootstrapping")
 	f.BoolVar(&c.showClouds, "clouds", false, "Print the available clouds which can be used to bootstrap a Juju environment")
 	f.StringVar(&c.showRegionsForCloud, "regions", "", "Print the available regions for the specified cloud")
+	f.BoolVar(&c.resume, "resume", false, "Resume a failed bootstrap of the named controller")
 }
 
 func (c *bootstrapCommand) Init(args []string) (err error) {
 	if c.showClouds && c.showRegionsForCloud != "" {
 		return errors.New("--clouds and --regions can't be used together")
 	}
+	if c.resume {
+		if err := c.checkResumeFlags(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if c.showClouds {
 		return cmd.CheckEmpty(args)
 	}
@@ -200,6 +225,11 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 	if c.BootstrapSeries != "" && !charm.IsValidSeries(c.BootstrapSeries) {
 		return errors.NotValidf("series %q", c.BootstrapSeries)
 	}
+	if c.CharmStoreURL != "" {
+		if err := controller.ValidateCharmStoreURL(c.CharmStoreURL); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// Parse the placement directive. Bootstrap currently only
 	// supports provider-specific placement directives.
@@ -228,6 +258,14 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 		return errors.New("requested agent version major.minor mismatch")
 	}
 
+	if c.resume {
+		if len(args) == 0 {
+			return errors.New("--resume requires the name of a controller")
+		}
+		c.controllerName = args[0]
+		return cmd.CheckEmpty(args[1:])
+	}
+
 	switch len(args) {
 	case 0:
 		// no args or flags, go interactive.
@@ -245,6 +283,40 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 	return nil
 }
 
+// checkResumeFlags returns an error if any flag that only applies to
+// a new bootstrap was specified with --resume. Resuming continues with
+// the configuration the controller was bootstrapped with, so such
+// flags would otherwise be silently ignored.
+func (c *bootstrapCommand) checkResumeFlags() error {
+	incompatible := []struct {
+		flag string
+		set  bool
+	}{
+		{"--clouds", c.showClouds},
+		{"--regions", c.showRegionsForCloud != ""},
+		{"--constraints", c.ConstraintsStr != ""},
+		{"--bootstrap-constraints", c.BootstrapConstraintsStr != ""},
+		{"--bootstrap-series", c.BootstrapSeries != ""},
+		{"--bootstrap-image", c.BootstrapImage != ""},
+		{"--build-agent", c.BuildAgent},
+		{"--metadata-source", c.MetadataSource != ""},
+		{"--charm-store-url", c.CharmStoreURL != ""},
+		{"--to", c.Placement != ""},
+		{"--auto-upgrade", c.AutoUpgrade},
+		{"--agent-version", c.AgentVersionParam != ""},
+		{"--credential", c.CredentialName != ""},
+		{"--config", c.config.String() != ""},
+		{"--model-default", c.modelDefaults.String() != ""},
+		{"--no-gui", c.noGUI},
+	}
+	for _, f := range incompatible {
+		if f.set {
+			return errors.Errorf("--resume and %s can't be used together", f.flag)
+		}
+	}
+	return nil
+}
+
 // BootstrapInterface provides bootstrap functionality that Run calls to support cleaner testing.
 type BootstrapInterface interface {
 	Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args bootstrap.BootstrapParams) error
@@ -334,6 +406,19 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 		return nil
 	}
 
+	if c.resume {
+		return c.resumeBootstrap(ctx)
+	}
+
+	// Check the local metadata source before doing anything else, so
+	// that a bad source doesn't leave an air-gapped bootstrap failing
+	// after an instance has been provisioned.
+	if c.MetadataSource != "" {
+		if err := bootstrap.ValidateMetadataSource(ctx.AbsPath(c.MetadataSource)); err != nil {
+			return errors.Annotate(err, "invalid --metadata-source")
+		}
+	}
+
 	// Run interactive bootstrap if needed/asked for
 	if c.interactive {
 		if err := c.runInteractive(ctx); err != nil {
@@ -392,10 +477,26 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 		return errors.Annotate(err, "error reading current controller")
 	}
 
+	// Record bootstrap checkpoints in the client store, so that
+	// a failed bootstrap may be resumed with --resume.
+	var checkpoint bootstrap.Checkpoint
+	recordCheckpoint := func(reached bootstrap.Checkpoint) error {
+		if err := setBootstrapCheckpoint(store, c.controllerName, reached); err != nil {
+			return errors.Trace(err)
+		}
+		checkpoint = reached
+		return nil
+	}
+
 	defer func() {
 		if resultErr == nil || errors.IsAlreadyExists(resultErr) {
 			return
 		}
+		if c.KeepBrokenEnvironment && checkpoint != "" {
+			// Leave the controller details in place, so the
+			// bootstrap may be resumed, or the controller killed.
+			return
+		}
 		if oldCurrentController != "" {
 			if err := store.SetCurrentController(oldCurrentController); err != nil {
 				logger.Errorf(
@@ -473,6 +574,12 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 bootstrap failed but --keep-broken was specified so resources are not being destroyed.
 When you have finished diagnosing the problem, remember to clean up the failed controller.
 See `[1:] + "`juju kill-controller`" + `.`)
+				if checkpoint.Resumable() {
+					ctx.Infof(
+						"The controller's database was initialised; to resume bootstrap, run\n    juju bootstrap --resume %s",
+						c.controllerName,
+					)
+				}
 			} else {
 				handleBootstrapError(ctx, resultErr, func() error {
 					return environsDestroy(
@@ -560,6 +667,7 @@ See `[1:] + "`juju kill-controller`" + `.`)
 			RetryDelay:     config.bootstrap.BootstrapRetryDelay,
 			AddressesDelay: config.bootstrap.BootstrapAddressesDelay,
 		},
+		RecordCheckpoint: recordCheckpoint,
 	})
 	if err != nil {
 		return errors.Annotate(err, "failed to bootstrap model")
@@ -581,7 +689,105 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	// To avoid race conditions when running scripted bootstraps, wait
 	// for the controller's machine agent to be ready to accept commands
 	// before exiting this bootstrap command.
-	return waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName, c.hostedModelName)
+	if err := waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName, c.hostedModelName); err != nil {
+		return errors.Trace(err)
+	}
+	clearBootstrapCheckpoint(store, c.controllerName)
+	return nil
+}
+
+// resumeBootstrap continues a failed bootstrap of the named controller
+// from the last checkpoint it recorded. Only a bootstrap that failed
+// after the controller's database was initialised may be resumed.
+func (c *bootstrapCommand) resumeBootstrap(ctx *cmd.Context) error {
+	store := c.ClientStore()
+	bootstrapConfig, err := store.BootstrapConfigForController(c.controllerName)
+	if errors.IsNotFound(err) {
+		return errors.Errorf("no bootstrap of controller %q to resume", c.controllerName)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	checkpoint := bootstrap.Checkpoint(bootstrapConfig.Checkpoint)
+	switch {
+	case checkpoint.Resumable():
+	case checkpoint == "":
+		return errors.Errorf("no bootstrap of controller %q to resume", c.controllerName)
+	default:
+		return errors.Errorf(`cannot resume bootstrap of controller %q: the controller's database was not initialised
+Remove the controller with "juju kill-controller %s" and bootstrap again`,
+			c.controllerName, c.controllerName,
+		)
+	}
+	ctx.Infof("Resuming bootstrap of controller %q", c.controllerName)
+
+	environ, err := openControllerEnviron(ctx, store, c.controllerName)
+	if err != nil {
+		return errors.Annotate(err, "opening controller environ")
+	}
+	if err := store.SetCurrentController(c.controllerName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.SetModelName(modelcmd.JoinModelName(c.controllerName, c.hostedModelName)); err != nil {
+		return errors.Trace(err)
+	}
+	agentVersion, ok := environ.Config().AgentVersion()
+	if !ok {
+		agentVersion = jujuversion.Current
+	}
+	apiPort := bootstrapConfig.ControllerConfig.APIPort()
+	if err := common.SetBootstrapEndpointAddress(store, c.controllerName, agentVersion, apiPort, environ); err != nil {
+		return errors.Annotate(err, "saving bootstrap endpoint address")
+	}
+	if err := waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName, c.hostedModelName); err != nil {
+		return errors.Trace(err)
+	}
+	clearBootstrapCheckpoint(store, c.controllerName)
+	return nil
+}
+
+// openControllerEnviron opens the environ of the named controller
+// using the bootstrap config recorded in the client store.
+var openControllerEnviron = func(
+	ctx *cmd.Context,
+	store jujuclient.ClientStore,
+	controllerName string,
+) (environs.Environ, error) {
+	bootstrapConfig, params, err := modelcmd.NewGetBootstrapConfigParamsFunc(ctx, store)(controllerName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := environs.Provider(bootstrapConfig.CloudType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := provider.PrepareConfig(*params)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return environs.New(environs.OpenParams{
+		Cloud:  params.Cloud,
+		Config: cfg,
+	})
+}
+
+// setBootstrapCheckpoint records the given bootstrap checkpoint
+// in the named controller's bootstrap config.
+func setBootstrapCheckpoint(store jujuclient.BootstrapConfigStore, controllerName string, checkpoint bootstrap.Checkpoint) error {
+	bootstrapConfig, err := store.BootstrapConfigForController(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	bootstrapConfig.Checkpoint = string(checkpoint)
+	return errors.Trace(store.UpdateBootstrapConfig(controllerName, *bootstrapConfig))
+}
+
+// clearBootstrapCheckpoint removes the checkpoint recorded for the
+// named controller once bootstrap has completed. Failure to do so is
+// logged rather than returned, as the controller is usable regardless.
+func clearBootstrapCheckpoint(store jujuclient.BootstrapConfigStore, controllerName string) {
+	if err := setBootstrapCheckpoint(store, controllerName, ""); err != nil {
+		logger.Warningf("cannot clear bootstrap checkpoint for %q: %v", controllerName, err)
+	}
 }
 
 func (c *bootstrapCommand) handleCommandLineErrorsAndInfoRequests(ctx *cmd.Context) (bool, error) {
@@ -861,6 +1067,9 @@ func (c *bootstrapCommand) bootstrapConfigs(
 	for k, v := range userConfigAttrs {
 		combinedConfig[k] = v
 	}
+	if c.CharmStoreURL != "" {
+		combinedConfig[controller.CharmStoreURL] = c.CharmStoreURL
+	}
 
 	// Add in any default attribute values if not already
 	// specified, making the recorded bootstrap config
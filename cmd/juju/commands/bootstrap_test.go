@@ -398,6 +398,26 @@ var bootstrapTests = []bootstrapTest{{
 	version: "1.3.3-saucy-ppc64el",
 	args:    []string{"--agent-version", "1.4.0"},
 	err:     `requested agent version major.minor mismatch`,
+}, {
+	info: "invalid --charm-store-url",
+	args: []string{"--charm-store-url", "charms.example.com"},
+	err:  `charmstore-url: expected http or https URL, got "charms.example.com"`,
+}, {
+	info: "--resume with cloud name",
+	args: []string{"--resume"},
+	err:  `--resume requires the name of a controller`,
+}, {
+	info: "--resume with too many arguments",
+	args: []string{"--resume", "devcontroller", "extra"},
+	err:  `unrecognized args: \["extra"\]`,
+}, {
+	info: "--resume with --config",
+	args: []string{"--resume", "devcontroller", "--config", "bootstrap-timeout=10"},
+	err:  `--resume and --config can't be used together`,
+}, {
+	info: "--resume with --bootstrap-series",
+	args: []string{"--resume", "devcontroller", "--bootstrap-series", "xenial"},
+	err:  `--resume and --bootstrap-series can't be used together`,
 }, {
 	info: "--clouds with --regions",
 	args: []string{"--clouds", "--regions", "aws"},
@@ -483,6 +503,50 @@ func (s *BootstrapSuite) TestBootstrapSetsCurrentModel(c *gc.C) {
 	c.Assert(modelName, gc.Equals, "admin/default")
 }
 
+func (s *BootstrapSuite) TestBootstrapResume(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+
+	s.PatchValue(&waitForAgentInitialisation, func(*cmd.Context, *modelcmd.ModelCommandBase, string, string) error {
+		return errors.New("timed out")
+	})
+	ctx, err := coretesting.RunCommand(c, s.newBootstrapCommand(), "dummy", "devcontroller", "--auto-upgrade", "--keep-broken")
+	c.Assert(err, gc.ErrorMatches, "timed out")
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "juju bootstrap --resume devcontroller")
+	bootstrapConfig, err := s.store.BootstrapConfigForController("devcontroller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bootstrapConfig.Checkpoint, gc.Equals, string(bootstrap.CheckpointControllerInitialized))
+
+	var waited bool
+	s.PatchValue(&waitForAgentInitialisation, func(*cmd.Context, *modelcmd.ModelCommandBase, string, string) error {
+		waited = true
+		return nil
+	})
+	_, err = coretesting.RunCommand(c, s.newBootstrapCommand(), "--resume", "devcontroller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(waited, jc.IsTrue)
+	bootstrapConfig, err = s.store.BootstrapConfigForController("devcontroller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bootstrapConfig.Checkpoint, gc.Equals, "")
+	c.Assert(s.store.CurrentControllerName, gc.Equals, "devcontroller")
+}
+
+func (s *BootstrapSuite) TestBootstrapResumeNothingToResume(c *gc.C) {
+	_, err := coretesting.RunCommand(c, s.newBootstrapCommand(), "--resume", "devcontroller")
+	c.Assert(err, gc.ErrorMatches, `no bootstrap of controller "devcontroller" to resume`)
+}
+
+func (s *BootstrapSuite) TestBootstrapResumeNotInitialised(c *gc.C) {
+	err := s.store.UpdateBootstrapConfig("devcontroller", jujuclient.BootstrapConfig{
+		Config:     map[string]interface{}{"name": "controller"},
+		Cloud:      "dummy",
+		CloudType:  "dummy",
+		Checkpoint: string(bootstrap.CheckpointToolsUploaded),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = coretesting.RunCommand(c, s.newBootstrapCommand(), "--resume", "devcontroller")
+	c.Assert(err, gc.ErrorMatches, `(?s)cannot resume bootstrap of controller "devcontroller": the controller's database was not initialised.*`)
+}
+
 func (s *BootstrapSuite) TestBootstrapSetsControllerDetails(c *gc.C) {
 	s.patchVersionAndSeries(c, "raring")
 
@@ -935,7 +999,7 @@ func (s *BootstrapSuite) TestInvalidLocalSource(c *gc.C) {
 		c, s.newBootstrapCommand(), "--metadata-source", c.MkDir(),
 		"dummy", "devcontroller",
 	)
-	c.Check(err, gc.ErrorMatches, `failed to bootstrap model: Juju cannot bootstrap because no agent binaries are available for your model(.|\n)*`)
+	c.Check(err, gc.ErrorMatches, `invalid --metadata-source: metadata source ".*" contains no "tools" or "images" metadata`)
 }
 
 // createImageMetadata creates some image metadata in a local directory.
@@ -1504,6 +1568,19 @@ func (s *BootstrapSuite) TestBootstrapAutocertDNSNameExplicitAPIPort(c *gc.C) {
 	c.Assert(bootstrap.args.ControllerConfig.APIPort(), gc.Equals, 12345)
 }
 
+func (s *BootstrapSuite) TestBootstrapCharmStoreURL(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+	var bootstrap fakeBootstrapFuncs
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return &bootstrap
+	})
+	coretesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "ctrl",
+		"--charm-store-url", "https://charms.example.com",
+	)
+	c.Assert(bootstrap.args.ControllerConfig.CharmStoreURL(), gc.Equals, "https://charms.example.com")
+}
+
 func (s *BootstrapSuite) TestBootstrapCloudConfigAndAdHoc(c *gc.C) {
 	s.patchVersionAndSeries(c, "raring")
 	_, err := coretesting.RunCommand(
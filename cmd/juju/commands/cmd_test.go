@@ -7,6 +7,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 
 	cmdtesting "github.com/juju/juju/cmd/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -34,6 +35,28 @@ func (*CmdSuite) TestSSHCommandInit(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "no target name specified")
 }
 
+func (*CmdSuite) TestSSHCommandInitTargets(c *gc.C) {
+	com, err := initSSHCommand("--target", "0-2,mysql/leader", "--max-parallel", "2", "uname", "-a")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(com.targets, jc.DeepEquals, []string{"0-2", "mysql/leader"})
+	c.Assert(com.maxParallel, gc.Equals, 2)
+	c.Assert(com.Args, jc.DeepEquals, []string{"uname", "-a"})
+
+	_, err = initSSHCommand("--target", "0")
+	c.Assert(err, gc.ErrorMatches, "no command specified")
+
+	_, err = initSSHCommand("--target", "0,3-1,mysql/0/1", "uname")
+	c.Assert(err, gc.ErrorMatches, `The following ssh targets are not valid:
+  "3-1" is not a valid target
+  "mysql/0/1" is not a valid target`)
+
+	_, err = initSSHCommand("--target", "0", "--max-parallel", "-1", "uname")
+	c.Assert(err, gc.ErrorMatches, "--max-parallel must not be negative")
+
+	_, err = initSSHCommand("--max-parallel", "2", "0", "uname")
+	c.Assert(err, gc.ErrorMatches, "--max-parallel requires --target")
+}
+
 func initSCPCommand(args ...string) (*scpCommand, error) {
 	com := &scpCommand{}
 	return com, coretesting.InitCommand(com, args)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 	"gopkg.in/juju/names.v2"
 
@@ -47,6 +48,12 @@ func (c *debugHooksCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags registers only the flags common to the SSH commands; the
+// flags for running commands on several targets don't apply.
+func (c *debugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SSHCommon.SetFlags(f)
+}
+
 func (c *debugHooksCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.Errorf("no unit name specified")
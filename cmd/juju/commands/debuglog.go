@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/juju/ansiterm"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
 	"github.com/mattn/go-isatty"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -44,12 +46,17 @@ identified by prefixing 'unit-' to its corresponding unit name and replacing
 the slash with a dash. A machine entity is identified by prefixing 'machine-'
 to its corresponding machine id.
 
+The '--unit' option is a shorthand for '--include' that takes a unit name,
+or an application name followed by '/*' to match all of its units. A unit's
+messages include the output of its charm's hooks, logged under the module
+'unit.<unit name>.<hook name>'.
+
 The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
 The filtering options combine as follows:
-* All --include options are logically ORed together.
+* All --include and --unit options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
@@ -68,6 +75,15 @@ exit:
 
     juju debug-log -T --include unit-mysql-0 --lines 50
 
+Show the hook output of unit mysql/0 and then continue to show it as new
+hooks run:
+
+    juju debug-log --replay --unit mysql/0 --include-module unit
+
+Show all messages from units of the wordpress application:
+
+    juju debug-log --unit 'wordpress/*'
+
 Show all messages from unit apache2/3 or machine 1 and then exit:
 
     juju debug-log -T --replay --include unit-apache2-3 --include machine-1
@@ -117,6 +133,7 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	units  []string
 	params common.DebugLogParams
 
 	utc      bool
@@ -138,6 +155,7 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeEntity), "include", "Only show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "x", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
+	f.Var(cmd.NewAppendStringsValue(&c.units), "unit", "Only show log messages for these units")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
 
@@ -168,6 +186,13 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	for _, unit := range c.units {
+		entity, err := unitEntity(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.params.IncludeEntity = append(c.params.IncludeEntity, entity)
+	}
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
@@ -185,6 +210,19 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// unitEntity returns the entity filter matching the given unit name,
+// or all units of an application if the name is of the form
+// "application/*".
+func unitEntity(unit string) (string, error) {
+	if app := strings.TrimSuffix(unit, "/*"); app != unit && names.IsValidApplication(app) {
+		return names.UnitTagKind + "-" + app + "-*", nil
+	}
+	if !names.IsValidUnit(unit) {
+		return "", errors.NotValidf("unit name %q", unit)
+	}
+	return names.NewUnitTag(unit).String(), nil
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params common.DebugLogParams) (<-chan common.LogMessage, error)
 	Close() error
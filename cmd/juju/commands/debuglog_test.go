@@ -53,6 +53,15 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				IncludeEntity: []string{"machine-1", "machine-2"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--unit", "mysql/0", "--unit", "wordpress/*", "-i", "machine-2"},
+			expected: common.DebugLogParams{
+				IncludeEntity: []string{"machine-2", "unit-mysql-0", "unit-wordpress-*"},
+				Backlog:       10,
+			},
+		}, {
+			args:     []string{"--unit", "mysql"},
+			errMatch: `unit name "mysql" not valid`,
 		}, {
 			args: []string{"--exclude", "machine-1", "-x", "machine-2"},
 			expected: common.DebugLogParams{
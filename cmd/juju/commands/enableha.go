@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 )
@@ -153,11 +154,11 @@ func (c *enableHACommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumControllers, "n", 0, "Number of controllers to make available")
 	f.StringVar(&c.PlacementSpec, "to", "", "The machine(s) to become controllers, bypasses constraints")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
-	c.out.AddFlags(f, "simple", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "simple", output.Versioned(map[string]cmd.Formatter{
 		"yaml":   cmd.FormatYaml,
 		"json":   cmd.FormatJson,
 		"simple": formatSimple,
-	})
+	}))
 
 }
 
@@ -270,6 +270,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Error resolution and debugging commands.
 	r.Register(newRunCommand())
+	r.Register(newShowTaskCommand())
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil))
 	r.Register(newResolvedCommand())
@@ -313,6 +314,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewLogoutCommand())
 	r.Register(user.NewRemoveCommand())
 	r.Register(user.NewWhoAmICommand())
+	r.Register(user.NewShowUserQuotaCommand())
+	r.Register(user.NewSetUserQuotaCommand())
 
 	// Manage cached images
 	r.Register(cachedimages.NewRemoveCommand())
@@ -323,6 +326,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewListCloudInstancesCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
@@ -332,6 +336,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewExportCommand())
 
 	r.Register(newMigrateCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -350,8 +355,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage and control services
 	r.Register(application.NewAddUnitCommand())
+	r.Register(application.NewScaleApplicationCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDefaultDeployCommand())
+	r.Register(application.NewDiffBundleCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
@@ -367,6 +374,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewListCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
+	r.Register(storage.NewResizeCommand())
 	r.Register(storage.NewShowCommand())
 
 	// Manage spaces
@@ -390,12 +398,17 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewAddModelCommand())
 	r.Register(controller.NewDestroyCommand())
 	r.Register(controller.NewListModelsCommand())
+	r.Register(controller.NewListModelTemplatesCommand())
+	r.Register(controller.NewSetModelTemplateCommand())
+	r.Register(controller.NewRemoveModelTemplateCommand())
 	r.Register(controller.NewKillCommand())
 	r.Register(controller.NewListControllersCommand())
 	r.Register(controller.NewRegisterCommand())
 	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
+	r.Register(controller.NewStepDownPrimaryCommand())
+	r.Register(controller.NewRemoveReplicaSetMemberCommand())
 	r.Register(controller.NewGetConfigCommand())
 
 	// Debug Metrics
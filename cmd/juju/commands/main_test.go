@@ -409,6 +409,7 @@ var commandNames = []string{
 	"cached-images",
 	"change-user-password",
 	"charm",
+	"cloud-instances",
 	"clouds",
 	"config",
 	"collect-metrics",
@@ -424,6 +425,7 @@ var commandNames = []string{
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -432,6 +434,7 @@ var commandNames = []string{
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-user",
+	"export-model",
 	"expose",
 	"get-constraints",
 	"get-model-constraints",
@@ -446,6 +449,7 @@ var commandNames = []string{
 	"list-backups",
 	"list-budgets",
 	"list-cached-images",
+	"list-cloud-instances",
 	"list-clouds",
 	"list-controllers",
 	"list-credentials",
@@ -467,6 +471,7 @@ var commandNames = []string{
 	"migrate",
 	"model-config",
 	"model-defaults",
+	"model-templates",
 	"models",
 	"plans",
 	"regions",
@@ -478,15 +483,19 @@ var commandNames = []string{
 	"remove-cloud",
 	"remove-credential",
 	"remove-machine",
+	"remove-model-template",
 	"remove-relation",
+	"remove-replica-set-member",
 	"remove-ssh-key",
 	"remove-unit",
+	"resize-storage",
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
 	"revoke",
 	"run",
 	"run-action",
+	"scale-application",
 	"scp",
 	"set-budget",
 	"set-constraints",
@@ -494,7 +503,9 @@ var commandNames = []string{
 	"set-default-region",
 	"set-meter-status",
 	"set-model-constraints",
+	"set-model-template",
 	"set-plan",
+	"set-user-quota",
 	"show-action-output",
 	"show-action-status",
 	"show-backup",
@@ -506,11 +517,13 @@ var commandNames = []string{
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-task",
 	"show-user",
 	"spaces",
 	"ssh",
 	"ssh-keys",
 	"status",
+	"step-down-primary",
 	"storage",
 	"storage-pools",
 	"subnets",
@@ -525,6 +538,7 @@ var commandNames = []string{
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"user-quota",
 	"users",
 	"version",
 	"whoami",
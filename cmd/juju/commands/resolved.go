@@ -4,6 +4,8 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -20,41 +22,98 @@ func newResolvedCommand() cmd.Command {
 // resolvedCommand marks a unit in an error state as ready to continue.
 type resolvedCommand struct {
 	modelcmd.ModelCommandBase
-	UnitName string
-	NoRetry  bool
+	UnitName     string
+	Applications []string
+	All          bool
+	NoRetry      bool
 }
 
+const resolvedDoc = `
+Marks the errors of a unit as resolved, so that the unit's agent
+carries on. By default the failed hook is run again; with --no-retry
+it is skipped.
+
+Instead of a single unit, one or more applications may be given, or
+--all for every application in the model. Each of their units that is
+in an error state is resolved, and the outcome is reported per unit.
+
+Examples:
+    juju resolved mysql/0
+    juju resolved --no-retry mysql wordpress
+    juju resolved --all
+`
+
 func (c *resolvedCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "resolved",
-		Args:    "<unit>",
+		Args:    "[<unit> | <application> ...]",
 		Purpose: "Marks unit errors resolved and re-executes failed hooks",
+		Doc:     resolvedDoc,
 	}
 }
 
 func (c *resolvedCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.NoRetry, "no-retry", false, "Do not re-execute failed hooks on the unit")
+	f.BoolVar(&c.All, "all", false, "Resolve the errors of every unit in the model")
 }
 
 func (c *resolvedCommand) Init(args []string) error {
-	if len(args) > 0 {
+	if c.All {
+		if len(args) > 0 {
+			return errors.New("cannot specify units or applications with --all")
+		}
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.Errorf("no unit or application specified")
+	}
+	if names.IsValidUnit(args[0]) {
 		c.UnitName = args[0]
-		if !names.IsValidUnit(c.UnitName) {
-			return errors.Errorf("invalid unit name %q", c.UnitName)
+		return cmd.CheckEmpty(args[1:])
+	}
+	for _, arg := range args {
+		if !names.IsValidApplication(arg) {
+			return errors.Errorf("invalid unit or application name %q", arg)
 		}
-		args = args[1:]
-	} else {
-		return errors.Errorf("no unit specified")
 	}
-	return cmd.CheckEmpty(args)
+	c.Applications = args
+	return nil
 }
 
-func (c *resolvedCommand) Run(_ *cmd.Context) error {
+func (c *resolvedCommand) Run(ctx *cmd.Context) error {
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Resolved(c.UnitName, c.NoRetry), block.BlockChange)
+	if c.UnitName != "" {
+		return block.ProcessBlockedError(client.Resolved(c.UnitName, c.NoRetry), block.BlockChange)
+	}
+
+	results, err := client.ResolveUnitErrors(c.Applications, c.All, c.NoRetry)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if len(results) == 0 {
+		ctx.Infof("no units in an error state")
+		return nil
+	}
+	failed := false
+	for _, result := range results {
+		entity := result.Tag
+		if tag, err := names.ParseTag(result.Tag); err == nil {
+			entity = fmt.Sprintf("%s %s", tag.Kind(), tag.Id())
+		}
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot resolve %s: %v\n", entity, result.Error)
+			failed = true
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "resolved %s\n", entity)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
 }
@@ -4,8 +4,10 @@
 package commands
 
 import (
+	"strings"
 	"time"
 
+	"github.com/juju/cmd"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -48,10 +50,16 @@ var resolvedTests = []struct {
 	mode state.ResolvedMode
 }{
 	{
-		err: `no unit specified`,
+		err: `no unit or application specified`,
 	}, {
-		args: []string{"jeremy-fisher"},
-		err:  `invalid unit name "jeremy-fisher"`,
+		args: []string{"jeremy-fisher/x"},
+		err:  `invalid unit or application name "jeremy-fisher/x"`,
+	}, {
+		args: []string{"dummy", "jeremy-fisher/99"},
+		err:  `invalid unit or application name "jeremy-fisher/99"`,
+	}, {
+		args: []string{"--all", "dummy"},
+		err:  `cannot specify units or applications with --all`,
 	}, {
 		args: []string{"jeremy-fisher/99"},
 		err:  `unit "jeremy-fisher/99" not found \(not found\)`,
@@ -124,6 +132,44 @@ func (s *ResolvedSuite) TestResolved(c *gc.C) {
 	}
 }
 
+func (s *ResolvedSuite) TestResolvedApplications(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, "-n", "3", ch, "dummy", "--series", "quantal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	for _, name := range []string{"dummy/0", "dummy/2"} {
+		u, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		sInfo := status.StatusInfo{
+			Status:  status.Error,
+			Message: "lol borken",
+			Since:   &now,
+		}
+		err = u.SetAgentStatus(sInfo)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	ctx, err := testing.RunCommand(c, newResolvedCommand(), "--no-retry", "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(strings.TrimSpace(testing.Stdout(ctx)), "\n")
+	c.Assert(lines, jc.SameContents, []string{"resolved unit dummy/0", "resolved unit dummy/2"})
+	for name, mode := range map[string]state.ResolvedMode{
+		"dummy/0": state.ResolvedNoHooks,
+		"dummy/1": state.ResolvedNone,
+		"dummy/2": state.ResolvedNoHooks,
+	} {
+		unit, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(unit.Resolved(), gc.Equals, mode)
+	}
+
+	// Units that are already resolved are reported as failures.
+	ctx, err = testing.RunCommand(c, newResolvedCommand(), "--all")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Matches, `(?s).*cannot resolve unit dummy/0: [^\n]*already resolved\n.*`)
+}
+
 func (s *ResolvedSuite) TestBlockResolved(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	err := runDeploy(c, "-n", "5", ch, "dummy", "--series", "quantal")
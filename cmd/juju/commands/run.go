@@ -6,6 +6,7 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	actionapi "github.com/juju/juju/api/action"
@@ -20,6 +22,7 @@ import (
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 func newRunCommand() cmd.Command {
@@ -35,7 +38,14 @@ type runCommand struct {
 	machines []string
 	services []string
 	units    []string
-	commands string
+	targets  []string
+	// maxParallel limits the number of targets the commands run on
+	// at once; zero means no limit.
+	maxParallel int
+	// background causes the command to return as soon as the
+	// commands are queued, without waiting for their results.
+	background bool
+	commands   string
 }
 
 const runDoc = `
@@ -45,8 +55,17 @@ are able to use this command.
 Targets are specified using either machine ids, application names or unit
 names.  At least one target specifier is needed.
 
-Multiple values can be set for --machine, --application, and --unit by using
-comma separated values.
+Multiple values can be set for --machine, --application, --unit and --target
+by using comma separated values.
+
+A unit may be given as "<application>/leader" to run the commands on the
+application's leader unit.
+
+--target accepts any mix of machine ids, application names and unit names,
+as well as inclusive ranges of machine ids. For example
+  --target mysql/leader,wordpress,0-3
+runs the commands on the leader of mysql, on every unit of wordpress and on
+machines 0, 1, 2 and 3.
 
 If the target is a machine, the command is run as the "ubuntu" user on
 the remote machine.
@@ -65,6 +84,19 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+The commands run on all targets at once unless --max-parallel is given,
+in which case no more than that many targets run the commands at the same
+time; each remaining target starts as soon as an earlier one finishes.
+
+Results are reported for each target, and include the command's output,
+its return code when that is not zero, and how long the command took to
+run.
+
+With --background, juju run queues the commands and prints the id of the
+task created for each target without waiting for them to finish. The
+results are kept by the controller and can be fetched later with
+"juju show-task <id>". --background cannot be combined with --max-parallel.
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 `
@@ -80,17 +112,20 @@ func (c *runCommand) Info() *cmd.Info {
 
 func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "default", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "default", output.Versioned(map[string]cmd.Formatter{
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
 		// default is used to format a single result specially.
 		"default": cmd.FormatYaml,
-	})
+	}))
 	f.BoolVar(&c.all, "all", false, "Run the commands on all the machines")
 	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait before the remote command is considered to have failed")
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	f.Var(cmd.NewStringsValue(nil, &c.targets), "target", "One or more machine ids, machine id ranges, application names or unit names")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "The maximum number of targets to run the commands on at once (0 means no limit)")
+	f.BoolVar(&c.background, "background", false, "Return the ids of the queued tasks without waiting for their results")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -99,6 +134,19 @@ func (c *runCommand) Init(args []string) error {
 	}
 	c.commands, args = args[0], args[1:]
 
+	var nameErrors []string
+	for _, target := range c.targets {
+		if !c.addTarget(target) {
+			nameErrors = append(nameErrors, fmt.Sprintf("  %q is not a valid target", target))
+		}
+	}
+	if c.maxParallel < 0 {
+		return errors.Errorf("--max-parallel must not be negative")
+	}
+	if c.background && c.maxParallel > 0 {
+		return errors.Errorf("You cannot specify --background and --max-parallel")
+	}
+
 	if c.all {
 		if len(c.machines) != 0 {
 			return errors.Errorf("You cannot specify --all and individual machines")
@@ -110,12 +158,11 @@ func (c *runCommand) Init(args []string) error {
 			return errors.Errorf("You cannot specify --all and individual units")
 		}
 	} else {
-		if len(c.machines) == 0 && len(c.services) == 0 && len(c.units) == 0 {
-			return errors.Errorf("You must specify a target, either through --all, --machine, --application or --unit")
+		if len(c.machines) == 0 && len(c.services) == 0 && len(c.units) == 0 && len(c.targets) == 0 {
+			return errors.Errorf("You must specify a target, either through --all, --machine, --application, --unit or --target")
 		}
 	}
 
-	var nameErrors []string
 	for _, machineId := range c.machines {
 		if !names.IsValidMachine(machineId) {
 			nameErrors = append(nameErrors, fmt.Sprintf("  %q is not a valid machine id", machineId))
@@ -127,7 +174,7 @@ func (c *runCommand) Init(args []string) error {
 		}
 	}
 	for _, unit := range c.units {
-		if !names.IsValidUnit(unit) {
+		if !names.IsValidUnit(unit) && !isLeaderUnit(unit) {
 			nameErrors = append(nameErrors, fmt.Sprintf("  %q is not a valid unit name", unit))
 		}
 	}
@@ -139,6 +186,60 @@ func (c *runCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// leaderSuffix, in place of a unit number, refers to an application's
+// leader unit.
+const leaderSuffix = "/leader"
+
+// isLeaderUnit reports whether name refers to an application's leader.
+func isLeaderUnit(name string) bool {
+	return strings.HasSuffix(name, leaderSuffix) &&
+		names.IsValidApplication(strings.TrimSuffix(name, leaderSuffix))
+}
+
+// addTarget adds the targets described by expr, which may be a machine
+// id, an inclusive range of machine ids such as "0-3", an application
+// name, a unit name or "<application>/leader". It reports whether expr
+// was valid.
+func (c *runCommand) addTarget(expr string) bool {
+	switch {
+	case names.IsValidMachine(expr):
+		c.machines = append(c.machines, expr)
+	case names.IsValidApplication(expr):
+		c.services = append(c.services, expr)
+	case names.IsValidUnit(expr) || isLeaderUnit(expr):
+		c.units = append(c.units, expr)
+	default:
+		machines, ok := machineRange(expr)
+		if !ok {
+			return false
+		}
+		c.machines = append(c.machines, machines...)
+	}
+	return true
+}
+
+// machineRange returns the ids of the top level machines in the inclusive
+// range described by expr, which has the form "<first>-<last>".
+func machineRange(expr string) ([]string, bool) {
+	parts := strings.Split(expr, "-")
+	if len(parts) != 2 || !names.IsValidMachine(parts[0]) || !names.IsValidMachine(parts[1]) {
+		return nil, false
+	}
+	first, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, false
+	}
+	last, err := strconv.Atoi(parts[1])
+	if err != nil || last < first {
+		return nil, false
+	}
+	var machines []string
+	for id := first; id <= last; id++ {
+		machines = append(machines, strconv.Itoa(id))
+	}
+	return machines, true
+}
+
 // ConvertActionResults takes the results from the api and creates a map
 // suitable for format converstion to YAML or JSON.
 func ConvertActionResults(result params.ActionResult, query actionQuery) map[string]interface{} {
@@ -162,6 +263,9 @@ func ConvertActionResults(result params.ActionResult, query actionQuery) map[str
 	if result.Message != "" {
 		values["Message"] = result.Message
 	}
+	if !result.Started.IsZero() && !result.Completed.IsZero() {
+		values["Duration"] = result.Completed.Sub(result.Started).String()
+	}
 	// We always want to have a string for stdout, but only show stderr,
 	// code and error if they are there.
 	if res, ok := result.Output["Stdout"].(string); ok {
@@ -194,64 +298,48 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	var runResults []params.ActionResult
-	if c.all {
-		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
+	var actionsToQuery []actionQuery
+	var pending []params.RunParams
+	if c.maxParallel > 0 {
+		pending, err = c.splitTargets()
+		if err != nil {
+			return errors.Trace(err)
+		}
 	} else {
-		params := params.RunParams{
-			Commands:     c.commands,
-			Timeout:      c.timeout,
-			Machines:     c.machines,
-			Applications: c.services,
-			Units:        c.units,
+		var runResults []params.ActionResult
+		if c.all {
+			runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
+		} else {
+			runResults, err = client.Run(c.runParams(c.machines, c.services, c.units))
+		}
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
 		}
-		runResults, err = client.Run(params)
+		actionsToQuery = queriesForResults(ctx, runResults)
 	}
 
-	if err != nil {
-		return block.ProcessBlockedError(err, block.BlockChange)
+	if c.background {
+		if len(actionsToQuery) == 0 {
+			return errors.New("no actions were successfully enqueued, aborting")
+		}
+		return c.out.Write(ctx, backgroundTasks(actionsToQuery))
 	}
 
-	actionsToQuery := []actionQuery{}
-	for _, result := range runResults {
-		if result.Error != nil {
-			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
-			continue
+	values := []interface{}{}
+	for len(actionsToQuery) > 0 || len(pending) > 0 {
+		// Start more targets while there is room for them.
+		for len(pending) > 0 && len(actionsToQuery) < c.maxParallel {
+			runResults, err := client.Run(pending[0])
+			if err != nil {
+				return block.ProcessBlockedError(err, block.BlockChange)
+			}
+			pending = pending[1:]
+			actionsToQuery = append(actionsToQuery, queriesForResults(ctx, runResults)...)
 		}
-		actionTag, err := names.ParseActionTag(result.Action.Tag)
-		if err != nil {
-			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver)
+		if len(actionsToQuery) == 0 {
 			continue
 		}
 
-		receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
-		if err != nil {
-			fmt.Fprintf(ctx.GetStderr(), "got invalid action receiver tag %v for action %v", result.Action.Receiver, result.Action.Tag)
-			continue
-		}
-		var receiverType string
-		switch receiverTag.(type) {
-		case names.UnitTag:
-			receiverType = "UnitId"
-		case names.MachineTag:
-			receiverType = "MachineId"
-		default:
-			receiverType = "ReceiverId"
-		}
-		actionsToQuery = append(actionsToQuery, actionQuery{
-			actionTag: actionTag,
-			receiver: actionReceiver{
-				receiverType: receiverType,
-				tag:          receiverTag,
-			}})
-	}
-
-	if len(actionsToQuery) == 0 {
-		return errors.New("no actions were successfully enqueued, aborting")
-	}
-
-	values := []interface{}{}
-	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
 		if err != nil {
 			return errors.Trace(err)
@@ -277,6 +365,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		<-afterFunc(1 * time.Second)
 	}
 
+	if len(values) == 0 {
+		return errors.New("no actions were successfully enqueued, aborting")
+	}
+
 	// If we are just dealing with one result, AND we are using the default
 	// format, then pretend we were running it locally.
 	if len(values) == 1 && c.out.Name() == "default" {
@@ -284,23 +376,199 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		if !ok {
 			return errors.New("couldn't read action output")
 		}
-		if res, ok := result["Error"].(string); ok {
-			return errors.New(res)
+		return writeLocalResult(ctx, result)
+	}
+
+	return c.out.Write(ctx, values)
+}
+
+// writeLocalResult writes the output of a single converted result as
+// though the commands had been run locally, returning an error carrying
+// the commands' return code if it was not zero.
+func writeLocalResult(ctx *cmd.Context, result map[string]interface{}) error {
+	if res, ok := result["Error"].(string); ok {
+		return errors.New(res)
+	}
+	ctx.Stdout.Write(formatOutput(result, "Stdout"))
+	ctx.Stderr.Write(formatOutput(result, "Stderr"))
+	if code, ok := result["ReturnCode"].(int); ok && code != 0 {
+		return cmd.NewRcPassthroughError(code)
+	}
+	// Message should always contain only errors.
+	if res, ok := result["Message"].(string); ok && res != "" {
+		ctx.Stderr.Write([]byte(res))
+	}
+	return nil
+}
+
+// backgroundTasks returns, for each queued action, a map suitable for
+// format conversion that identifies the action's receiver and the task
+// id that can later be passed to show-task.
+func backgroundTasks(actions []actionQuery) []interface{} {
+	values := make([]interface{}, len(actions))
+	for i, action := range actions {
+		value := map[string]interface{}{"Task": action.actionTag.Id()}
+		value[action.receiver.receiverType] = action.receiver.tag.Id()
+		values[i] = value
+	}
+	return values
+}
+
+// runParams returns the parameters for running the command's commands on
+// the given targets.
+func (c *runCommand) runParams(machines, applications, units []string) params.RunParams {
+	return params.RunParams{
+		Commands:     c.commands,
+		Timeout:      c.timeout,
+		Machines:     machines,
+		Applications: applications,
+		Units:        units,
+	}
+}
+
+// splitTargets returns the parameters for running the commands on each
+// of the command's targets separately. Applications, leaders and --all
+// are resolved to individual units and machines using the model status.
+func (c *runCommand) splitTargets() ([]params.RunParams, error) {
+	machines := c.machines
+	units := c.units
+	needStatus := c.all || len(c.services) > 0
+	for _, unit := range c.units {
+		needStatus = needStatus || isLeaderUnit(unit)
+	}
+	if needStatus {
+		status, err := getRunStatus(c)
+		if err != nil {
+			return nil, errors.Annotate(err, "resolving targets")
 		}
-		ctx.Stdout.Write(formatOutput(result, "Stdout"))
-		ctx.Stderr.Write(formatOutput(result, "Stderr"))
-		if code, ok := result["ReturnCode"].(int); ok && code != 0 {
-			return cmd.NewRcPassthroughError(code)
+		if c.all {
+			machines = statusMachineIds(status.Machines)
 		}
-		// Message should always contain only errors.
-		if res, ok := result["Message"].(string); ok && res != "" {
-			ctx.Stderr.Write([]byte(res))
+		units = nil
+		for _, unit := range c.units {
+			if !isLeaderUnit(unit) {
+				units = append(units, unit)
+				continue
+			}
+			application := strings.TrimSuffix(unit, leaderSuffix)
+			leader := ""
+			for name, unitStatus := range statusUnits(status, application) {
+				if unitStatus.Leader {
+					leader = name
+				}
+			}
+			if leader == "" {
+				return nil, errors.NotFoundf("leader for application %q", application)
+			}
+			units = append(units, leader)
+		}
+		for _, application := range c.services {
+			if _, ok := status.Applications[application]; !ok {
+				return nil, errors.NotFoundf("application %q", application)
+			}
+			appUnits := statusUnits(status, application)
+			unitNames := make([]string, 0, len(appUnits))
+			for name := range appUnits {
+				unitNames = append(unitNames, name)
+			}
+			sort.Strings(unitNames)
+			units = append(units, unitNames...)
+		}
+	}
+
+	var result []params.RunParams
+	seenMachines := set.NewStrings()
+	for _, machine := range machines {
+		if !seenMachines.Contains(machine) {
+			seenMachines.Add(machine)
+			result = append(result, c.runParams([]string{machine}, nil, nil))
+		}
+	}
+	seenUnits := set.NewStrings()
+	for _, unit := range units {
+		if !seenUnits.Contains(unit) {
+			seenUnits.Add(unit)
+			result = append(result, c.runParams(nil, nil, []string{unit}))
 		}
+	}
+	return result, nil
+}
 
-		return nil
+// statusMachineIds returns the ids of the given machines and all their
+// containers.
+func statusMachineIds(machines map[string]params.MachineStatus) []string {
+	var ids []string
+	for id, machine := range machines {
+		ids = append(ids, id)
+		ids = append(ids, statusMachineIds(machine.Containers)...)
 	}
+	sort.Strings(ids)
+	return ids
+}
 
-	return c.out.Write(ctx, values)
+// statusUnits returns the status of each unit of the named application,
+// including the units of subordinate applications, which the status
+// records under their principals.
+func statusUnits(status *params.FullStatus, application string) map[string]params.UnitStatus {
+	units := make(map[string]params.UnitStatus)
+	var add func(map[string]params.UnitStatus)
+	add = func(unitStatuses map[string]params.UnitStatus) {
+		for name, unitStatus := range unitStatuses {
+			if appName, err := names.UnitApplication(name); err == nil && appName == application {
+				units[name] = unitStatus
+			}
+			add(unitStatus.Subordinates)
+		}
+	}
+	for _, app := range status.Applications {
+		add(app.Units)
+	}
+	return units
+}
+
+// queriesForResults returns the queries for the actions that were
+// successfully enqueued, reporting any that were not to stderr.
+func queriesForResults(ctx *cmd.Context, runResults []params.ActionResult) []actionQuery {
+	actionsToQuery := []actionQuery{}
+	for _, result := range runResults {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
+			continue
+		}
+		actionTag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver)
+			continue
+		}
+
+		receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid action receiver tag %v for action %v", result.Action.Receiver, result.Action.Tag)
+			continue
+		}
+		actionsToQuery = append(actionsToQuery, newActionQuery(actionTag, receiverTag))
+	}
+	return actionsToQuery
+}
+
+// newActionQuery returns the query for the given action, which was
+// enqueued for the given receiver.
+func newActionQuery(actionTag names.ActionTag, receiverTag names.Tag) actionQuery {
+	var receiverType string
+	switch receiverTag.(type) {
+	case names.UnitTag:
+		receiverType = "UnitId"
+	case names.MachineTag:
+		receiverType = "MachineId"
+	default:
+		receiverType = "ReceiverId"
+	}
+	return actionQuery{
+		actionTag: actionTag,
+		receiver: actionReceiver{
+			receiverType: receiverType,
+			tag:          receiverTag,
+		}}
 }
 
 type actionReceiver struct {
@@ -330,6 +598,17 @@ var getRunAPIClient = func(c *runCommand) (RunClient, error) {
 	return actionapi.NewClient(root), errors.Trace(err)
 }
 
+// getRunStatus returns the model status, which is used to resolve
+// targets when the number of targets run at once is limited.
+var getRunStatus = func(c *runCommand) (*params.FullStatus, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+	return client.Status(nil)
+}
+
 // getActionResult abstracts over the action CLI function that we use here to fetch results
 var getActionResult = func(c RunClient, actionId string, wait *time.Timer) (params.ActionResult, error) {
 	return action.GetActionResult(c, actionId, wait)
@@ -45,7 +45,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 	}, {
 		message:  "no target",
 		args:     []string{"sudo reboot"},
-		errMatch: "You must specify a target, either through --all, --machine, --application or --unit",
+		errMatch: "You must specify a target, either through --all, --machine, --application, --unit or --target",
 	}, {
 		message:  "too many args",
 		args:     []string{"--all", "sudo reboot", "oops"},
@@ -110,6 +110,39 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		machines: []string{"0"},
 		services: []string{"mysql"},
 		units:    []string{"wordpress/0", "wordpress/1"},
+	}, {
+		message:  "command to an application leader",
+		args:     []string{"--unit=mysql/leader", "sudo reboot"},
+		commands: "sudo reboot",
+		units:    []string{"mysql/leader"},
+	}, {
+		message:  "command to target expressions",
+		args:     []string{"--target=mysql/leader,wordpress,wordpress/3,2-4,1/lxd/0", "sudo reboot"},
+		commands: "sudo reboot",
+		machines: []string{"2", "3", "4", "1/lxd/0"},
+		services: []string{"wordpress"},
+		units:    []string{"mysql/leader", "wordpress/3"},
+	}, {
+		message:  "all and target expressions",
+		args:     []string{"--all", "--target=0-1", "sudo reboot"},
+		errMatch: `You cannot specify --all and individual machines`,
+	}, {
+		message: "bad target expressions",
+		args:    []string{"--target", "3-1,0-x,foo/bar,-1", "sudo reboot"},
+		errMatch: "" +
+			"The following run targets are not valid:\n" +
+			"  \"3-1\" is not a valid target\n" +
+			"  \"0-x\" is not a valid target\n" +
+			"  \"foo/bar\" is not a valid target\n" +
+			"  \"-1\" is not a valid target",
+	}, {
+		message:  "negative max parallel",
+		args:     []string{"--max-parallel=-1", "--all", "sudo reboot"},
+		errMatch: "--max-parallel must not be negative",
+	}, {
+		message:  "background and max parallel",
+		args:     []string{"--background", "--max-parallel=2", "--all", "sudo reboot"},
+		errMatch: "You cannot specify --background and --max-parallel",
 	}} {
 		c.Log(fmt.Sprintf("%v: %s", i, test.message))
 		cmd := &runCommand{}
@@ -220,6 +253,20 @@ func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 			"Message":    "msg",
 			"ReturnCode": 42,
 		},
+	}, {
+		message: "duration is reported once the action has completed",
+		results: func() params.ActionResult {
+			result := makeActionResult(mockResponse{machineTag: "machine-1"}, "action-"+validUUID)
+			result.Started = time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+			result.Completed = result.Started.Add(1500 * time.Millisecond)
+			return result
+		}(),
+		query: makeActionQuery(validUUID, "MachineId", names.NewMachineTag("1")),
+		expected: map[string]interface{}{
+			"MachineId": "1",
+			"Stdout":    "",
+			"Duration":  "1.5s",
+		},
 	}} {
 		c.Log(fmt.Sprintf("%v: %s", i, test.message))
 		result := ConvertActionResults(test.results, test.query)
@@ -399,6 +446,80 @@ func (s *RunSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) TestBackground(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{machineTag: "machine-0"})
+	mock.setResponse("unit/0", mockResponse{unitTag: "unit-unit-0"})
+
+	expected := &bytes.Buffer{}
+	err := cmd.FormatJson(expected, []interface{}{
+		map[string]interface{}{"MachineId": "0", "Task": mock.receiverIdMap["0"]},
+		map[string]interface{}{"UnitId": "unit/0", "Task": mock.receiverIdMap["unit/0"]},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--background", "--machine=0", "--unit=unit/0", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, expected.String())
+	// The results are left for show-task to fetch.
+	c.Check(mock.maxQueried, gc.Equals, 0)
+}
+
+func (s *RunSuite) TestMaxParallel(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.PatchValue(&afterFunc, func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	})
+	s.PatchValue(&getRunStatus, func(*runCommand) (*params.FullStatus, error) {
+		return &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {Units: map[string]params.UnitStatus{
+					"mysql/0": {},
+					"mysql/1": {Leader: true},
+				}},
+			},
+		}, nil
+	})
+	mock.setResponse("0", mockResponse{stdout: "zero", machineTag: "machine-0"})
+	mock.setResponse("mysql/0", mockResponse{stdout: "one", unitTag: "unit-mysql-0"})
+	mock.setResponse("mysql/1", mockResponse{stdout: "two", unitTag: "unit-mysql-1"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:       mock.runResponses["0"],
+		mock.receiverIdMap["mysql/0"]: mock.runResponses["mysql/0"],
+		mock.receiverIdMap["mysql/1"]: mock.runResponses["mysql/1"],
+	}
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--max-parallel=1", "--target=0,mysql,mysql/leader", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(mock.runCalls, jc.DeepEquals, []params.RunParams{
+		{Commands: "hostname", Timeout: 5 * time.Minute, Machines: []string{"0"}},
+		{Commands: "hostname", Timeout: 5 * time.Minute, Units: []string{"mysql/1"}},
+		{Commands: "hostname", Timeout: 5 * time.Minute, Units: []string{"mysql/0"}},
+	})
+	c.Check(mock.maxQueried, gc.Equals, 1)
+	c.Check(testing.Stdout(context), jc.Contains, `"Stdout":"zero"`)
+	c.Check(testing.Stdout(context), jc.Contains, `"Stdout":"one"`)
+	c.Check(testing.Stdout(context), jc.Contains, `"Stdout":"two"`)
+}
+
+func (s *RunSuite) TestMaxParallelUnknownLeader(c *gc.C) {
+	s.setupMockAPI()
+	s.PatchValue(&getRunStatus, func(*runCommand) (*params.FullStatus, error) {
+		return &params.FullStatus{}, nil
+	})
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--max-parallel=2", "--unit=mysql/leader", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, `leader for application "mysql" not found`)
+}
+
 func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	mock := &mockRunAPI{}
 	s.PatchValue(&getRunAPIClient, func(_ *runCommand) (RunClient, error) {
@@ -418,6 +539,10 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+	// runCalls records the parameters of each call to Run.
+	runCalls []params.RunParams
+	// maxQueried records the largest number of actions queried at once.
+	maxQueried int
 }
 
 type mockResponse struct {
@@ -527,6 +652,7 @@ func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, err
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
 	}
+	m.runCalls = append(m.runCalls, runParams)
 	// Just add in ids that match in order.
 	for _, id := range runParams.Machines {
 		response, found := m.runResponses[id]
@@ -547,6 +673,9 @@ func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, err
 
 func (m *mockRunAPI) Actions(actionTags params.Entities) (params.ActionResults, error) {
 	results := params.ActionResults{Results: make([]params.ActionResult, len(actionTags.Entities))}
+	if len(actionTags.Entities) > m.maxQueried {
+		m.maxQueried = len(actionTags.Entities)
+	}
 
 	for i, entity := range actionTags.Entities {
 		response, found := m.actionResponses[entity.Tag[len("action-"):]]
@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func newShowTaskCommand() cmd.Command {
+	return modelcmd.Wrap(&showTaskCommand{})
+}
+
+// showTaskCommand fetches the results of commands started by juju run.
+type showTaskCommand struct {
+	modelcmd.ModelCommandBase
+	out    cmd.Output
+	wait   time.Duration
+	taskId string
+}
+
+const showTaskDoc = `
+Show the results of a task started by "juju run --background". A partial
+task id may be used, as long as it identifies a single task.
+
+If the task has finished and the default format is used, its output and
+return code are reported as though the commands had been run locally.
+Otherwise the task's status is shown, along with any results known so far.
+
+--wait blocks for up to the given duration for the task to finish.
+
+Examples:
+
+    juju run --background --unit mysql/0 "long-running-job"
+    juju show-task 3c1a6e4b
+    juju show-task --wait 10m --format json 3c1a6e4b
+`
+
+func (c *showTaskCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-task",
+		Args:    "<task id>",
+		Purpose: "Show the results of commands started in the background by juju run.",
+		Doc:     showTaskDoc,
+	}
+}
+
+func (c *showTaskCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "default", output.Versioned(map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+		// default is used to show a finished task's output directly.
+		"default": cmd.FormatYaml,
+	}))
+	f.DurationVar(&c.wait, "wait", 0, "How long to wait for the task to finish")
+}
+
+func (c *showTaskCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no task id specified")
+	}
+	c.taskId, args = args[0], args[1:]
+	if c.wait < 0 {
+		return errors.Errorf("--wait must not be negative")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showTaskCommand) Run(ctx *cmd.Context) error {
+	client, err := getShowTaskAPIClient(c)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := getActionResult(client, c.taskId, time.NewTimer(c.wait))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Action == nil {
+		return errors.Errorf("no details for task %q", c.taskId)
+	}
+	actionTag, err := names.ParseActionTag(result.Action.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	values := ConvertActionResults(result, newActionQuery(actionTag, receiverTag))
+
+	finished := result.Status != params.ActionPending && result.Status != params.ActionRunning
+	if finished && c.out.Name() == "default" {
+		return writeLocalResult(ctx, values)
+	}
+	values["Status"] = result.Status
+	values["Task"] = actionTag.Id()
+	return c.out.Write(ctx, values)
+}
+
+// getShowTaskAPIClient returns the client used to fetch task results;
+// it is a variable so that tests can replace it.
+var getShowTaskAPIClient = func(c *showTaskCommand) (RunClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return actionapi.NewClient(root), nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type ShowTaskSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	requested string
+	result    params.ActionResult
+}
+
+var _ = gc.Suite(&ShowTaskSuite{})
+
+func (s *ShowTaskSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.requested = ""
+	s.result = makeActionResult(mockResponse{
+		stdout:  "out\n",
+		stderr:  "err\n",
+		code:    "3",
+		unitTag: "unit-mysql-0",
+	}, "action-"+validUUID)
+	s.result.Status = params.ActionCompleted
+	s.PatchValue(&getShowTaskAPIClient, func(*showTaskCommand) (RunClient, error) {
+		return &mockRunAPI{}, nil
+	})
+	s.PatchValue(&getActionResult, func(_ RunClient, id string, _ *time.Timer) (params.ActionResult, error) {
+		s.requested = id
+		return s.result, nil
+	})
+}
+
+func (s *ShowTaskSuite) TestInit(c *gc.C) {
+	_, err := testing.RunCommand(c, newShowTaskCommand())
+	c.Check(err, gc.ErrorMatches, "no task id specified")
+	_, err = testing.RunCommand(c, newShowTaskCommand(), "0123", "extra")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+	_, err = testing.RunCommand(c, newShowTaskCommand(), "--wait=-1s", "0123")
+	c.Check(err, gc.ErrorMatches, "--wait must not be negative")
+}
+
+func (s *ShowTaskSuite) TestFinishedTask(c *gc.C) {
+	context, err := testing.RunCommand(c, newShowTaskCommand(), "0123")
+	c.Check(err, gc.ErrorMatches, "subprocess encountered error code 3")
+	c.Check(s.requested, gc.Equals, "0123")
+	c.Check(testing.Stdout(context), gc.Equals, "out\n")
+	c.Check(testing.Stderr(context), gc.Equals, "err\n")
+}
+
+func (s *ShowTaskSuite) TestPendingTask(c *gc.C) {
+	s.result.Status = params.ActionPending
+	s.result.Output = nil
+	expected := &bytes.Buffer{}
+	err := cmd.FormatYaml(expected, map[string]interface{}{
+		"Status": "pending",
+		"Stdout": "",
+		"Task":   validUUID,
+		"UnitId": "mysql/0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := testing.RunCommand(c, newShowTaskCommand(), "0123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, expected.String())
+}
+
+func (s *ShowTaskSuite) TestFinishedTaskJSON(c *gc.C) {
+	expected := &bytes.Buffer{}
+	err := cmd.FormatJson(expected, map[string]interface{}{
+		"ReturnCode": 3,
+		"Status":     "completed",
+		"Stderr":     "err\n",
+		"Stdout":     "out\n",
+		"Task":       validUUID,
+		"UnitId":     "mysql/0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := testing.RunCommand(c, newShowTaskCommand(), "--format=json", "0123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, expected.String())
+}
+
+func (s *ShowTaskSuite) TestError(c *gc.C) {
+	s.PatchValue(&getActionResult, func(RunClient, string, *time.Timer) (params.ActionResult, error) {
+		return params.ActionResult{}, errors.New(`actions for identifier "0123" not found`)
+	})
+	_, err := testing.RunCommand(c, newShowTaskCommand(), "0123")
+	c.Check(err, gc.ErrorMatches, `actions for identifier "0123" not found`)
+}
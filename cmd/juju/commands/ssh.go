@@ -4,11 +4,24 @@
 package commands
 
 import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/set"
 	"github.com/juju/utils/ssh"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/network"
 )
 
@@ -28,6 +41,21 @@ The SSH host keys of the target are verified. The --no-host-key-checks option
 can be used to disable these checks. Use of this option is not recommended as
 it opens up the possibility of a man-in-the-middle attack.
 
+With --target, the command is run on several machines and units at once
+instead of on a single <target>, and no interactive session is possible.
+--target accepts the same comma separated machine ids, machine id ranges,
+application names, unit names and "<application>/leader" names as "juju
+run --target". The command runs on all targets at once unless
+--max-parallel is given, in which case no more than that many targets run
+it at the same time. The exit code, output and duration of the command on
+each target are reported in the format chosen with --format.
+
+Machines on private subnets can be reached through a jump host by setting
+the model's 'ssh-bastion' config to [user@]host[:port], or through the
+controller by setting 'proxy-ssh' or passing --proxy. The bastion is
+connected to with the system's ssh client, and --proxy takes precedence
+over it.
+
 Examples:
 Connect to machine 0:
 
@@ -45,6 +73,11 @@ Connect to a jenkins unit as user jenkins:
 
     juju ssh jenkins@jenkins/0
 
+Run 'uptime' on the mysql leader and machines 0 to 3, two at a time,
+reporting the results as JSON:
+
+    juju ssh --target mysql/leader,0-3 --max-parallel 2 --format json uptime
+
 See also: 
     scp`
 
@@ -57,6 +90,11 @@ func newSSHCommand(hostDialer network.Dialer) cmd.Command {
 // sshCommand is responsible for launching a ssh shell on a given unit or machine.
 type sshCommand struct {
 	SSHCommon
+	out     cmd.Output
+	targets []string
+	// maxParallel limits the number of targets the command runs on
+	// at once; zero means no limit.
+	maxParallel int
 }
 
 func (c *sshCommand) Info() *cmd.Info {
@@ -68,7 +106,37 @@ func (c *sshCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *sshCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SSHCommon.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.Var(cmd.NewStringsValue(nil, &c.targets), "target", "One or more machine ids, machine id ranges, application names or unit names to run the command on")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "The maximum number of targets to run the command on at once (0 means no limit)")
+}
+
 func (c *sshCommand) Init(args []string) error {
+	if c.maxParallel < 0 {
+		return errors.Errorf("--max-parallel must not be negative")
+	}
+	if len(c.targets) > 0 {
+		var nameErrors []string
+		for _, target := range c.targets {
+			if !isSSHTarget(target) {
+				nameErrors = append(nameErrors, fmt.Sprintf("  %q is not a valid target", target))
+			}
+		}
+		if len(nameErrors) > 0 {
+			return errors.Errorf("The following ssh targets are not valid:\n%s",
+				strings.Join(nameErrors, "\n"))
+		}
+		if len(args) == 0 {
+			return errors.Errorf("no command specified")
+		}
+		c.Args = args
+		return nil
+	}
+	if c.maxParallel != 0 {
+		return errors.Errorf("--max-parallel requires --target")
+	}
 	if len(args) == 0 {
 		return errors.Errorf("no target name specified")
 	}
@@ -76,6 +144,16 @@ func (c *sshCommand) Init(args []string) error {
 	return nil
 }
 
+// isSSHTarget reports whether expr is a valid --target expression.
+func isSSHTarget(expr string) bool {
+	if names.IsValidMachine(expr) || names.IsValidApplication(expr) ||
+		names.IsValidUnit(expr) || isLeaderUnit(expr) {
+		return true
+	}
+	_, ok := machineRange(expr)
+	return ok
+}
+
 // Run resolves c.Target to a machine, to the address of a i
 // machine or unit forks ssh passing any arguments provided.
 func (c *sshCommand) Run(ctx *cmd.Context) error {
@@ -85,6 +163,10 @@ func (c *sshCommand) Run(ctx *cmd.Context) error {
 	}
 	defer c.cleanupRun()
 
+	if len(c.targets) > 0 {
+		return c.runOnTargets(ctx)
+	}
+
 	target, err := c.resolveTarget(c.Target)
 	if err != nil {
 		return err
@@ -101,3 +183,163 @@ func (c *sshCommand) Run(ctx *cmd.Context) error {
 	cmd.Stderr = ctx.Stderr
 	return cmd.Run()
 }
+
+// runOnTargets runs the command on each of the command's targets, no more
+// than maxParallel at once, and writes the results.
+func (c *sshCommand) runOnTargets(ctx *cmd.Context) error {
+	entities, err := c.targetEntities()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	targets := make([]*resolvedTarget, len(entities))
+	for i, entity := range entities {
+		if targets[i], err = c.resolveTarget(entity); err != nil {
+			return errors.Annotatef(err, "resolving %q", entity)
+		}
+	}
+	options, err := c.getSSHOptions(false, targets...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	limit := c.maxParallel
+	if limit == 0 {
+		limit = len(targets)
+	}
+	running := make(chan struct{}, limit)
+	results := make([]interface{}, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		running <- struct{}{}
+		wg.Add(1)
+		go func(i int, target *resolvedTarget) {
+			defer wg.Done()
+			results[i] = c.runOnTarget(target, options)
+			<-running
+		}(i, target)
+	}
+	wg.Wait()
+	return c.out.Write(ctx, results)
+}
+
+// runOnTarget runs the command on a single target, returning a map
+// suitable for format conversion that holds the command's output, its
+// exit code when that is not zero, and how long it took to run.
+func (c *sshCommand) runOnTarget(target *resolvedTarget, options *ssh.Options) map[string]interface{} {
+	var stdout, stderr bytes.Buffer
+	command := ssh.Command(target.userHost(), c.Args, options)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	start := time.Now()
+	err := command.Run()
+
+	result := map[string]interface{}{
+		"Stdout":   stdout.String(),
+		"Duration": time.Since(start).String(),
+	}
+	if names.IsValidUnit(target.entity) {
+		result["UnitId"] = target.entity
+	} else {
+		result["MachineId"] = target.entity
+	}
+	if stderr.Len() > 0 {
+		result["Stderr"] = stderr.String()
+	}
+	if code, ok := exitCode(err); ok {
+		if code != 0 {
+			result["ReturnCode"] = code
+		}
+	} else if err != nil {
+		result["Error"] = err.Error()
+	}
+	return result
+}
+
+// exitCode returns the exit code of the command that returned err, and
+// whether err was caused by the command exiting.
+func exitCode(err error) (int, bool) {
+	if err == nil {
+		return 0, true
+	}
+	exitErr, ok := errors.Cause(err).(*exec.ExitError)
+	if !ok {
+		return 0, false
+	}
+	status, ok := exitErr.Sys().(interface {
+		ExitStatus() int
+	})
+	if !ok {
+		return 0, false
+	}
+	return status.ExitStatus(), true
+}
+
+// targetEntities returns the machine ids and unit names described by the
+// command's targets. Applications and leaders are resolved to units using
+// the model status.
+func (c *sshCommand) targetEntities() ([]string, error) {
+	var status *params.FullStatus
+	var entities []string
+	seen := set.NewStrings()
+	add := func(entity string) {
+		if !seen.Contains(entity) {
+			seen.Add(entity)
+			entities = append(entities, entity)
+		}
+	}
+	for _, expr := range c.targets {
+		if names.IsValidMachine(expr) || names.IsValidUnit(expr) {
+			add(expr)
+			continue
+		}
+		if machines, ok := machineRange(expr); ok {
+			for _, machine := range machines {
+				add(machine)
+			}
+			continue
+		}
+		if status == nil {
+			var err error
+			if status, err = getSSHStatus(c); err != nil {
+				return nil, errors.Annotate(err, "resolving targets")
+			}
+		}
+		if isLeaderUnit(expr) {
+			application := strings.TrimSuffix(expr, leaderSuffix)
+			leader := ""
+			for name, unitStatus := range statusUnits(status, application) {
+				if unitStatus.Leader {
+					leader = name
+				}
+			}
+			if leader == "" {
+				return nil, errors.NotFoundf("leader for application %q", application)
+			}
+			add(leader)
+			continue
+		}
+		if _, ok := status.Applications[expr]; !ok {
+			return nil, errors.NotFoundf("application %q", expr)
+		}
+		var units []string
+		for name := range statusUnits(status, expr) {
+			units = append(units, name)
+		}
+		sort.Strings(units)
+		for _, unit := range units {
+			add(unit)
+		}
+	}
+	return entities, nil
+}
+
+// getSSHStatus returns the model status, which is used to resolve
+// application and leader targets.
+var getSSHStatus = func(c *sshCommand) (*params.FullStatus, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+	return client.Status(nil)
+}
@@ -10,6 +10,7 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/juju/juju/api/sshclient"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 )
 
@@ -31,6 +33,7 @@ import (
 type SSHCommon struct {
 	modelcmd.ModelCommandBase
 	proxy           bool
+	bastion         *config.SSHBastion
 	pty             bool
 	noHostKeyChecks bool
 	Target          string
@@ -51,6 +54,7 @@ type sshAPIClient interface {
 	AllAddresses(target string) ([]string, error)
 	PublicKeys(target string) ([]string, error)
 	Proxy() (bool, error)
+	Bastion() (string, error)
 	Close() error
 }
 
@@ -131,16 +135,28 @@ func (c *SSHCommon) setHostDialer(dialer network.Dialer) {
 // if SSH proxying is required. It must be called at the top of the
 // command's Run method.
 //
-// The apiClient, apiAddr and proxy fields are initialized after this call.
+// The apiClient, apiAddr, proxy and bastion fields are initialized
+// after this call. An explicit --proxy takes precedence over the
+// model's ssh-bastion, which in turn takes precedence over its
+// proxy-ssh setting.
 func (c *SSHCommon) initRun() error {
 	if err := c.ensureAPIClient(); err != nil {
 		return errors.Trace(err)
 	}
 
-	if proxy, err := c.proxySSH(); err != nil {
-		return errors.Trace(err)
-	} else {
-		c.proxy = proxy
+	if !c.proxy {
+		bastion, err := c.sshBastion()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.bastion = bastion
+	}
+	if c.bastion == nil {
+		if proxy, err := c.proxySSH(); err != nil {
+			return errors.Trace(err)
+		} else {
+			c.proxy = proxy
+		}
 	}
 
 	// Used mostly for testing, but useful for debugging and/or
@@ -198,7 +214,9 @@ func (c *SSHCommon) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (*
 		options.EnablePTY()
 	}
 
-	if c.proxy {
+	if c.bastion != nil {
+		c.setBastionProxyCommand(&options)
+	} else if c.proxy {
 		if err := c.setProxyCommand(&options); err != nil {
 			return nil, err
 		}
@@ -264,6 +282,42 @@ func (c *SSHCommon) proxySSH() (bool, error) {
 	return proxy, nil
 }
 
+// sshBastion returns the model's SSH bastion, or nil if it has none
+// or the controller is too old to report it.
+func (c *SSHCommon) sshBastion() (*config.SSHBastion, error) {
+	value, err := c.apiClient.Bastion()
+	if errors.IsNotSupported(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bastion, err := config.ParseSSHBastion(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if bastion != nil {
+		logger.Debugf("using SSH bastion %s", bastion)
+	}
+	return bastion, nil
+}
+
+// setBastionProxyCommand sets the proxy command option so that
+// connections are forwarded through the bastion. The bastion is
+// connected to with the system's ssh client, so its host key is
+// checked against the user's own known_hosts file.
+func (c *SSHCommon) setBastionProxyCommand(options *ssh.Options) {
+	command := []string{"ssh", "-q", "-W", "%h:%p"}
+	if c.bastion.Port != config.DefaultSSHBastionPort {
+		command = append(command, "-p", strconv.Itoa(c.bastion.Port))
+	}
+	target := c.bastion.Host
+	if c.bastion.User != "" {
+		target = c.bastion.User + "@" + target
+	}
+	options.SetProxyCommand(append(command, target)...)
+}
+
 // setProxyCommand sets the proxy command option.
 func (c *SSHCommon) setProxyCommand(options *ssh.Options) error {
 	apiServerHost, _, err := net.SplitHostPort(c.apiAddr)
@@ -319,7 +373,11 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 	}
 
 	getAddress := c.reachableAddressGetter
-	if c.apiClient.BestAPIVersion() < 2 || c.forceAPIv1 {
+	if c.bastion != nil {
+		// The target's addresses are typically not reachable
+		// from here, so use the address the bastion will reach.
+		getAddress = c.apiClient.PrivateAddress
+	} else if c.apiClient.BestAPIVersion() < 2 || c.forceAPIv1 {
 		logger.Debugf("using legacy SSHClient API v1: no support for AllAddresses()")
 		getAddress = c.legacyAddressGetter
 	} else if c.proxy {
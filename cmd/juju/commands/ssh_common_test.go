@@ -33,6 +33,10 @@ type argsSpec struct {
 	// expected.
 	withProxy bool
 
+	// bastionProxy, if set, specifies the expected ProxyCommand
+	// option used to connect through an SSH bastion.
+	bastionProxy string
+
 	// enablePty specifies if the forced PTY allocation switches are
 	// expected.
 	enablePty bool
@@ -76,6 +80,9 @@ func (s *argsSpec) check(c *gc.C, output string) {
 		expect("-o ProxyCommand juju ssh --proxy=false --no-host-key-checks " +
 			"--pty=false ubuntu@localhost -q \"nc %h %p\"")
 	}
+	if s.bastionProxy != "" {
+		expect("-o ProxyCommand " + regexp.QuoteMeta(s.bastionProxy))
+	}
 	expect("-o PasswordAuthentication no -o ServerAliveInterval 30")
 	if s.enablePty {
 		expect("-t -t")
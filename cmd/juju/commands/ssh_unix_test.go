@@ -7,6 +7,8 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
 
@@ -221,6 +223,68 @@ func (s *SSHSuite) TestSSHCommandModelConfigProxySSH(c *gc.C) {
 
 }
 
+func (s *SSHSuite) TestSSHCommandModelConfigSSHBastion(c *gc.C) {
+	s.setupModel(c)
+
+	// The bastion takes precedence over proxy-ssh, and the
+	// target's private address is used without dialing it.
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"proxy-ssh":   true,
+		"ssh-bastion": "admin@jump.example.com:2222",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setHostDialerFunc(dialerFuncFor())
+
+	ctx, err := coretesting.RunCommand(c, newSSHCommand(s.hostDialer), "0")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs := argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		enablePty:       true,
+		bastionProxy:    "ssh -q -W %h:%p -p 2222 admin@jump.example.com",
+		args:            "ubuntu@0.private",
+	}
+	expectedArgs.check(c, coretesting.Stdout(ctx))
+
+	// An explicit --proxy still proxies through the controller.
+	s.setHostDialerFunc(dialerFuncFor("0.private", "0.public", "0.1.2.3"))
+	ctx, err = coretesting.RunCommand(c, newSSHCommand(s.hostDialer), "--proxy", "0")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs.bastionProxy = ""
+	expectedArgs.withProxy = true
+	expectedArgs.argsMatch = `ubuntu@0.(public|private|1\.2\.3)` // can be any of the 3
+	expectedArgs.check(c, coretesting.Stdout(ctx))
+}
+
+func (s *SSHSuite) TestSSHCommandTargets(c *gc.C) {
+	s.setupModel(c)
+	s.setHostDialerFunc(dialerFuncFor("0.public"))
+
+	ctx, err := coretesting.RunCommand(c, newSSHCommand(s.hostDialer),
+		"--target", "0,mysql", "--max-parallel", "1", "--format", "json", "uname", "-a")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "")
+
+	var results []map[string]interface{}
+	err = json.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &results)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results[0]["MachineId"], gc.Equals, "0")
+	c.Check(results[1]["UnitId"], gc.Equals, "mysql/0")
+	expected := argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		args:            "ubuntu@0.public uname -a",
+	}
+	for _, result := range results {
+		expected.check(c, result["Stdout"].(string))
+		c.Check(result["Duration"], gc.Matches, ".+s")
+		c.Check(result["ReturnCode"], gc.IsNil)
+	}
+}
+
 func (s *SSHSuite) TestSSHWillWorkInUpgrade(c *gc.C) {
 	// Check the API client interface used by "juju ssh" against what
 	// the API server will allow during upgrades. Ensure that the API
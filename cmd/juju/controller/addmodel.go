@@ -51,6 +51,7 @@ type addModelCommand struct {
 	CredentialName string
 	CloudRegion    string
 	Config         common.ConfigFlag
+	Template       string
 }
 
 const addModelHelpDoc = `
@@ -89,6 +90,11 @@ to manage multiple clouds, so the only valid cloud is the same cloud
 as the controller model is deployed to. This may change in a future
 release.
 
+A model may be created from a model template stored in the controller
+using --template. The template's config and constraints are applied to
+the new model; any --config values take precedence over the template's
+config. Use "juju model-templates" to see the available templates.
+
 Examples:
 
     juju add-model mymodel
@@ -96,6 +102,7 @@ Examples:
     juju add-model mymodel aws/us-east-1
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model mymodel --template prod --config logging-config="<root>=DEBUG"
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
@@ -112,6 +119,7 @@ func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.Owner, "owner", "", "The owner of the new model if not the current user")
 	f.StringVar(&c.CredentialName, "credential", "", "Credential used to add the model")
 	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
+	f.StringVar(&c.Template, "template", "", "Model template whose config and constraints are applied to the model")
 }
 
 func (c *addModelCommand) Init(args []string) error {
@@ -141,6 +149,11 @@ type AddModelAPI interface {
 		cloudCredential names.CloudCredentialTag,
 		config map[string]interface{},
 	) (base.ModelInfo, error)
+	CreateModelFromTemplate(
+		template, name, owner, cloudName, cloudRegion string,
+		cloudCredential names.CloudCredentialTag,
+		config map[string]interface{},
+	) (base.ModelInfo, error)
 }
 
 type CloudAPI interface {
@@ -214,7 +227,12 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	}
 
 	addModelClient := c.newAddModelAPI(api)
-	model, err := addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	var model base.ModelInfo
+	if c.Template != "" {
+		model, err = addModelClient.CreateModelFromTemplate(c.Template, c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	} else {
+		model, err = addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	}
 	if err != nil {
 		if params.IsCodeUnauthorized(err) {
 			common.PermissionsMessage(ctx.Stderr, "add a model")
@@ -259,9 +277,14 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		messageArgs = append(messageArgs, credentialName)
 	}
 
+	if c.Template != "" {
+		messageFormat += " from template '%s'"
+		messageArgs = append(messageArgs, c.Template)
+	}
+
 	messageFormat += forUserSuffix
 
-	// "Added '<model>' model [on <cloud>/<region>] [with credential '<credential>'] for user '<user namePart>'"
+	// "Added '<model>' model [on <cloud>/<region>] [with credential '<credential>'] [from template '<template>'] for user '<user namePart>'"
 	ctx.Infof(messageFormat, messageArgs...)
 
 	if _, ok := attrs[config.AuthorizedKeysKey]; !ok {
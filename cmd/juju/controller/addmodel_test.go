@@ -289,6 +289,21 @@ func (s *AddModelSuite) TestComandLineConfigPassedThrough(c *gc.C) {
 	c.Assert(s.fakeAddModelAPI.config["cloud"], gc.Equals, "special")
 }
 
+func (s *AddModelSuite) TestTemplatePassedThrough(c *gc.C) {
+	ctx, err := s.run(c, "test", "--template", "prod", "--config", "account=magic")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "prod")
+	c.Assert(s.fakeAddModelAPI.config["account"], gc.Equals, "magic")
+	c.Assert(testing.Stderr(ctx), jc.Contains, "Added 'test' model from template 'prod' for user 'bob'")
+}
+
+func (s *AddModelSuite) TestNoTemplate(c *gc.C) {
+	_, err := s.run(c, "test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "")
+}
+
 func (s *AddModelSuite) TestConfigFileValuesPassedThrough(c *gc.C) {
 	config := map[string]string{
 		"account": "magic",
@@ -419,6 +434,7 @@ type fakeAddClient struct {
 	cloudRegion     string
 	cloudCredential names.CloudCredentialTag
 	config          map[string]interface{}
+	template        string
 	err             error
 	model           base.ModelInfo
 }
@@ -441,6 +457,14 @@ func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string,
 	return f.model, nil
 }
 
+func (f *fakeAddClient) CreateModelFromTemplate(template, name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}) (base.ModelInfo, error) {
+	if f.err != nil {
+		return base.ModelInfo{}, f.err
+	}
+	f.template = template
+	return f.CreateModel(name, owner, cloudName, cloudRegion, cloudCredential, config)
+}
+
 // TODO(wallyworld) - improve this stub and add test asserts
 type fakeCloudAPI struct {
 	controller.CloudAPI
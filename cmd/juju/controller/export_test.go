@@ -35,6 +35,20 @@ func NewShowControllerCommandForTest(testStore jujuclient.ClientStore, api func(
 	}
 }
 
+// NewShowControllerReplicaSetCommandForTest returns a showControllerCommand
+// with the clientstore and APIs provided as specified.
+func NewShowControllerReplicaSetCommandForTest(
+	testStore jujuclient.ClientStore,
+	api func(string) ControllerAccessAPI,
+	replicaSetAPI func(string) ReplicaSetAPI,
+) *showControllerCommand {
+	return &showControllerCommand{
+		store:         testStore,
+		api:           api,
+		replicaSetAPI: replicaSetAPI,
+	}
+}
+
 type AddModelCommand struct {
 	*addModelCommand
 }
@@ -87,6 +101,22 @@ func NewEnableDestroyControllerCommandForTest(api removeBlocksAPI, store jujucli
 	return modelcmd.WrapController(c)
 }
 
+// NewStepDownPrimaryCommandForTest returns a stepDownPrimaryCommand
+// with the API provided as specified.
+func NewStepDownPrimaryCommandForTest(api ReplicaSetOperationsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &stepDownPrimaryCommand{replicaSetCommandBase: replicaSetCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveReplicaSetMemberCommandForTest returns a
+// removeReplicaSetMemberCommand with the API provided as specified.
+func NewRemoveReplicaSetMemberCommandForTest(api ReplicaSetOperationsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeReplicaSetMemberCommand{replicaSetCommandBase: replicaSetCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -153,6 +183,33 @@ func NewGetConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore)
 	return modelcmd.WrapController(c)
 }
 
+// NewListModelTemplatesCommandForTest returns a model-templates
+// command with the api provided as specified.
+func NewListModelTemplatesCommandForTest(api ModelTemplatesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listModelTemplatesCommand{}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewSetModelTemplateCommandForTest returns a set-model-template
+// command with the api provided as specified.
+func NewSetModelTemplateCommandForTest(api ModelTemplatesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &setModelTemplateCommand{}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveModelTemplateCommandForTest returns a remove-model-template
+// command with the api provided as specified.
+func NewRemoveModelTemplateCommandForTest(api ModelTemplatesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeModelTemplateCommand{}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
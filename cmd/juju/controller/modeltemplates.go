@@ -0,0 +1,303 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// ModelTemplatesAPI defines the API methods used by the model
+// template commands.
+type ModelTemplatesAPI interface {
+	Close() error
+	ModelTemplates() ([]params.ModelTemplate, error)
+	SetModelTemplate(params.ModelTemplate) error
+	RemoveModelTemplate(name string) error
+}
+
+// modelTemplatesCommandBase holds the API access shared by the model
+// template commands.
+type modelTemplatesCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api ModelTemplatesAPI
+}
+
+func (c *modelTemplatesCommandBase) getAPI() (ModelTemplatesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(root), nil
+}
+
+// NewListModelTemplatesCommand returns a command that lists the
+// controller's model templates.
+func NewListModelTemplatesCommand() cmd.Command {
+	return modelcmd.WrapController(&listModelTemplatesCommand{})
+}
+
+type listModelTemplatesCommand struct {
+	modelTemplatesCommandBase
+	out cmd.Output
+}
+
+const listModelTemplatesHelpDoc = `
+Model templates hold model config and constraints that are applied to
+a new model when it is created with "juju add-model --template".
+
+Examples:
+
+    juju model-templates
+    juju model-templates --format yaml
+
+See also:
+    add-model
+    set-model-template
+    remove-model-template
+`
+
+// Info implements Command.Info.
+func (c *listModelTemplatesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "model-templates",
+		Purpose: "Lists the model templates in a controller.",
+		Doc:     strings.TrimSpace(listModelTemplatesHelpDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listModelTemplatesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatModelTemplatesTabular,
+	}))
+}
+
+// modelTemplateDetails holds a model template for yaml and json
+// output.
+type modelTemplateDetails struct {
+	Description string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Config      map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
+	Constraints string                 `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *listModelTemplatesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	templates, err := client.ModelTemplates()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details := make(map[string]modelTemplateDetails)
+	for _, tmpl := range templates {
+		details[tmpl.Name] = modelTemplateDetails{
+			Description: tmpl.Description,
+			Config:      tmpl.Config,
+			Constraints: tmpl.Constraints.String(),
+		}
+	}
+	if len(details) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No model templates to display.")
+		return nil
+	}
+	return c.out.Write(ctx, details)
+}
+
+func formatModelTemplatesTabular(writer io.Writer, value interface{}) error {
+	details, ok := value.(map[string]modelTemplateDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", details, value)
+	}
+	names := make([]string, 0, len(details))
+	for name := range details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Name\tConstraints\tDescription")
+	for _, name := range names {
+		tmpl := details[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", name, tmpl.Constraints, tmpl.Description)
+	}
+	return tw.Flush()
+}
+
+// NewSetModelTemplateCommand returns a command that creates or
+// replaces a model template.
+func NewSetModelTemplateCommand() cmd.Command {
+	return modelcmd.WrapController(&setModelTemplateCommand{})
+}
+
+type setModelTemplateCommand struct {
+	modelTemplatesCommandBase
+
+	Name        string
+	Description string
+	Config      common.ConfigFlag
+	Constraints string
+}
+
+const setModelTemplateHelpDoc = `
+Creates a model template, or replaces the existing template with the
+same name. A model created with "juju add-model --template <name>" has
+the template's config and constraints applied; config specified with
+"juju add-model --config" takes precedence over the template's.
+
+Space requirements for the model's machines are expressed with the
+"spaces" constraint.
+
+Replacing or removing a template does not change models already
+created from it.
+
+Examples:
+
+    juju set-model-template prod --config prod.yaml --constraints "mem=4G spaces=dmz"
+    juju set-model-template dev --description "Developer sandboxes" --config logging-config="<root>=DEBUG"
+
+See also:
+    add-model
+    model-templates
+    remove-model-template
+`
+
+// Info implements Command.Info.
+func (c *setModelTemplateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-model-template",
+		Args:    "<template name>",
+		Purpose: "Creates or replaces a model template.",
+		Doc:     strings.TrimSpace(setModelTemplateHelpDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setModelTemplateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.Description, "description", "", "Description of the template")
+	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
+	f.StringVar(&c.Constraints, "constraints", "", "Constraints applied to models created from the template")
+}
+
+// Init implements Command.Init.
+func (c *setModelTemplateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("template name is required")
+	}
+	c.Name, args = args[0], args[1:]
+	if !names.IsValidModelName(c.Name) {
+		return errors.Errorf("%q is not a valid name: template names may only contain lowercase letters, digits and hyphens", c.Name)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *setModelTemplateCommand) Run(ctx *cmd.Context) error {
+	configValues, err := c.Config.ReadAttrs(ctx)
+	if err != nil {
+		return errors.Annotate(err, "unable to parse config")
+	}
+	coercedValues, err := common.ConformYAML(configValues)
+	if err != nil {
+		return errors.Annotate(err, "unable to parse config")
+	}
+	attrs, ok := coercedValues.(map[string]interface{})
+	if !ok {
+		return errors.New("params must contain a YAML map with string keys")
+	}
+	cons, err := common.ParseConstraints(ctx, c.Constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	return errors.Trace(client.SetModelTemplate(params.ModelTemplate{
+		Name:        c.Name,
+		Description: c.Description,
+		Config:      attrs,
+		Constraints: cons,
+	}))
+}
+
+// NewRemoveModelTemplateCommand returns a command that removes a
+// model template.
+func NewRemoveModelTemplateCommand() cmd.Command {
+	return modelcmd.WrapController(&removeModelTemplateCommand{})
+}
+
+type removeModelTemplateCommand struct {
+	modelTemplatesCommandBase
+	Name string
+}
+
+const removeModelTemplateHelpDoc = `
+Removes a model template. Models already created from the template are
+not changed.
+
+Examples:
+
+    juju remove-model-template dev
+
+See also:
+    model-templates
+    set-model-template
+`
+
+// Info implements Command.Info.
+func (c *removeModelTemplateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-model-template",
+		Args:    "<template name>",
+		Purpose: "Removes a model template.",
+		Doc:     strings.TrimSpace(removeModelTemplateHelpDoc),
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeModelTemplateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("template name is required")
+	}
+	c.Name, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *removeModelTemplateCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	return errors.Trace(client.RemoveModelTemplate(c.Name))
+}
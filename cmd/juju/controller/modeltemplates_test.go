@@ -0,0 +1,149 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type modelTemplatesSuite struct {
+	baseControllerSuite
+	api   *fakeModelTemplatesAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&modelTemplatesSuite{})
+
+func (s *modelTemplatesSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeModelTemplatesAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *modelTemplatesSuite) TestList(c *gc.C) {
+	s.api.templates = []params.ModelTemplate{{
+		Name:        "prod",
+		Description: "production models",
+		Config:      map[string]interface{}{"default-series": "xenial"},
+		Constraints: constraints.MustParse("mem=4G"),
+	}}
+	ctx, err := testing.RunCommand(c, controller.NewListModelTemplatesCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Name  Constraints  Description\n"+
+		"prod  mem=4096M    production models\n")
+}
+
+func (s *modelTemplatesSuite) TestListYAML(c *gc.C) {
+	s.api.templates = []params.ModelTemplate{{
+		Name:        "prod",
+		Config:      map[string]interface{}{"default-series": "xenial"},
+		Constraints: constraints.MustParse("spaces=dmz"),
+	}}
+	ctx, err := testing.RunCommand(c, controller.NewListModelTemplatesCommandForTest(s.api, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"prod:\n"+
+		"  config:\n"+
+		"    default-series: xenial\n"+
+		"  constraints: spaces=dmz\n")
+}
+
+func (s *modelTemplatesSuite) TestListNone(c *gc.C) {
+	ctx, err := testing.RunCommand(c, controller.NewListModelTemplatesCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No model templates to display.\n")
+}
+
+func (s *modelTemplatesSuite) TestSet(c *gc.C) {
+	_, err := testing.RunCommand(c, controller.NewSetModelTemplateCommandForTest(s.api, s.store),
+		"prod", "--description", "production models",
+		"--config", "default-series=xenial",
+		"--constraints", "mem=4G spaces=dmz",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetModelTemplate", params.ModelTemplate{
+		Name:        "prod",
+		Description: "production models",
+		Config:      map[string]interface{}{"default-series": "xenial"},
+		Constraints: constraints.MustParse("mem=4G spaces=dmz"),
+	})
+}
+
+func (s *modelTemplatesSuite) TestSetInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "template name is required",
+	}, {
+		args: []string{"Prod"},
+		err:  `"Prod" is not a valid name: template names may only contain lowercase letters, digits and hyphens`,
+	}, {
+		args: []string{"prod", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := testing.RunCommand(c, controller.NewSetModelTemplateCommandForTest(s.api, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *modelTemplatesSuite) TestSetInvalidConstraints(c *gc.C) {
+	_, err := testing.RunCommand(c, controller.NewSetModelTemplateCommandForTest(s.api, s.store),
+		"prod", "--constraints", "bogus=1",
+	)
+	c.Assert(err, gc.ErrorMatches, `unknown constraint "bogus"`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *modelTemplatesSuite) TestRemove(c *gc.C) {
+	_, err := testing.RunCommand(c, controller.NewRemoveModelTemplateCommandForTest(s.api, s.store), "prod")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "RemoveModelTemplate", "prod")
+}
+
+func (s *modelTemplatesSuite) TestRemoveError(c *gc.C) {
+	s.api.SetErrors(&params.Error{Message: `model template "prod" not found`, Code: params.CodeNotFound})
+	_, err := testing.RunCommand(c, controller.NewRemoveModelTemplateCommandForTest(s.api, s.store), "prod")
+	c.Assert(err, gc.ErrorMatches, `model template "prod" not found`)
+}
+
+type fakeModelTemplatesAPI struct {
+	gitjujutesting.Stub
+	templates []params.ModelTemplate
+}
+
+func (f *fakeModelTemplatesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeModelTemplatesAPI) ModelTemplates() ([]params.ModelTemplate, error) {
+	f.MethodCall(f, "ModelTemplates")
+	return f.templates, f.NextErr()
+}
+
+func (f *fakeModelTemplatesAPI) SetModelTemplate(tmpl params.ModelTemplate) error {
+	f.MethodCall(f, "SetModelTemplate", tmpl)
+	return f.NextErr()
+}
+
+func (f *fakeModelTemplatesAPI) RemoveModelTemplate(name string) error {
+	f.MethodCall(f, "RemoveModelTemplate", name)
+	return f.NextErr()
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageStepDownPrimaryDetails = `
+Asks the primary member of the controller's mongo replica set to step
+down, so that another member is elected primary. The old primary does
+not stand for election for a minute. This is refused if there is no
+healthy voting member to take over.
+
+Stepping down the primary briefly interrupts writes to the database,
+and may disconnect clients of the controller.
+
+Examples:
+    juju step-down-primary
+
+See also:
+    show-controller
+    remove-replica-set-member`[1:]
+
+var usageRemoveReplicaSetMemberDetails = `
+Removes a member from the controller's mongo replica set. Member ids
+are shown by "juju show-controller --replica-set".
+
+This is intended for members left behind when a controller machine
+could not be removed cleanly. The primary cannot be removed, and
+neither can the member of a machine that is still a voting controller;
+use "juju enable-ha" or "juju remove-machine" for those, and the
+replica set is updated to match.
+
+Examples:
+    juju remove-replica-set-member 3
+
+See also:
+    show-controller
+    step-down-primary
+    enable-ha`[1:]
+
+// ReplicaSetOperationsAPI defines the api/highavailability/Client
+// methods used to manage the controller's replica set.
+type ReplicaSetOperationsAPI interface {
+	StepDownPrimary() error
+	RemoveReplicaSetMember(id int) error
+	Close() error
+}
+
+// replicaSetCommandBase holds the API access shared by the replica set
+// commands.
+type replicaSetCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api ReplicaSetOperationsAPI
+}
+
+func (c *replicaSetCommandBase) getAPI() (ReplicaSetOperationsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return highavailability.NewClient(root), nil
+}
+
+// NewStepDownPrimaryCommand returns a command that steps down the
+// primary of the controller's replica set.
+func NewStepDownPrimaryCommand() cmd.Command {
+	return modelcmd.WrapController(&stepDownPrimaryCommand{})
+}
+
+type stepDownPrimaryCommand struct {
+	replicaSetCommandBase
+}
+
+// Info implements Command.Info.
+func (c *stepDownPrimaryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "step-down-primary",
+		Purpose: "Steps down the primary of the controller's replica set.",
+		Doc:     usageStepDownPrimaryDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *stepDownPrimaryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if err := client.StepDownPrimary(); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("primary stepped down")
+	return nil
+}
+
+// NewRemoveReplicaSetMemberCommand returns a command that removes a
+// member from the controller's replica set.
+func NewRemoveReplicaSetMemberCommand() cmd.Command {
+	return modelcmd.WrapController(&removeReplicaSetMemberCommand{})
+}
+
+type removeReplicaSetMemberCommand struct {
+	replicaSetCommandBase
+	MemberId int
+}
+
+// Info implements Command.Info.
+func (c *removeReplicaSetMemberCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-replica-set-member",
+		Args:    "<member id>",
+		Purpose: "Removes a member from the controller's replica set.",
+		Doc:     usageRemoveReplicaSetMemberDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeReplicaSetMemberCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no member id specified")
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id < 0 {
+		return errors.Errorf("invalid member id %q", args[0])
+	}
+	c.MemberId = id
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *removeReplicaSetMemberCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	return errors.Trace(client.RemoveReplicaSetMember(c.MemberId))
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type replicaSetCommandsSuite struct {
+	baseControllerSuite
+	api   *fakeReplicaSetOperationsAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&replicaSetCommandsSuite{})
+
+func (s *replicaSetCommandsSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeReplicaSetOperationsAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *replicaSetCommandsSuite) TestStepDownPrimary(c *gc.C) {
+	ctx, err := testing.RunCommand(c, controller.NewStepDownPrimaryCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "primary stepped down\n")
+	s.api.CheckCallNames(c, "StepDownPrimary", "Close")
+}
+
+func (s *replicaSetCommandsSuite) TestStepDownPrimaryError(c *gc.C) {
+	s.api.SetErrors(&params.Error{Message: "cannot step down primary: no healthy voting secondary to replace it"})
+	_, err := testing.RunCommand(c, controller.NewStepDownPrimaryCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "cannot step down primary: no healthy voting secondary to replace it")
+}
+
+func (s *replicaSetCommandsSuite) TestRemoveReplicaSetMember(c *gc.C) {
+	_, err := testing.RunCommand(c, controller.NewRemoveReplicaSetMemberCommandForTest(s.api, s.store), "3")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "RemoveReplicaSetMember", "Close")
+	s.api.CheckCall(c, 0, "RemoveReplicaSetMember", 3)
+}
+
+func (s *replicaSetCommandsSuite) TestRemoveReplicaSetMemberInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no member id specified",
+	}, {
+		args: []string{"three"},
+		err:  `invalid member id "three"`,
+	}, {
+		args: []string{"3", "4"},
+		err:  `unrecognized args: \["4"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := testing.RunCommand(c, controller.NewRemoveReplicaSetMemberCommandForTest(s.api, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+type fakeReplicaSetOperationsAPI struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeReplicaSetOperationsAPI) StepDownPrimary() error {
+	f.MethodCall(f, "StepDownPrimary")
+	return f.NextErr()
+}
+
+func (f *fakeReplicaSetOperationsAPI) RemoveReplicaSetMember(id int) error {
+	f.MethodCall(f, "RemoveReplicaSetMember", id)
+	return f.NextErr()
+}
+
+func (f *fakeReplicaSetOperationsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
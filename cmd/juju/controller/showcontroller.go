@@ -5,6 +5,7 @@ package controller
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -13,8 +14,10 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/highavailability"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/permission"
@@ -28,9 +31,15 @@ var usageShowControllerDetails = `
 Shows extended information about a controller(s) as well as related models
 and user login details.
 
+With --replica-set, the status of the controller's mongo replica set is
+also shown: each member's state, health, vote and replication lag, and
+the most recent elections of a primary. Only controller administrators
+may see the replica set.
+
 Examples:
     juju show-controller
     juju show-controller aws google
+    juju show-controller --replica-set
     
 See also: 
     controllers`[1:]
@@ -42,8 +51,11 @@ type showControllerCommand struct {
 	store jujuclient.ClientStore
 	api   func(controllerName string) ControllerAccessAPI
 
+	replicaSetAPI func(controllerName string) ReplicaSetAPI
+
 	controllerNames []string
 	showPasswords   bool
+	showReplicaSet  bool
 }
 
 // NewShowControllerCommand returns a command to show details of the desired controllers.
@@ -74,10 +86,11 @@ func (c *showControllerCommand) Info() *cmd.Info {
 func (c *showControllerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.JujuCommandBase.SetFlags(f)
 	f.BoolVar(&c.showPasswords, "show-password", false, "Show password for logged in user")
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+	f.BoolVar(&c.showReplicaSet, "replica-set", false, "Show the status of the controller's mongo replica set")
+	c.out.AddFlags(f, "yaml", output.Versioned(map[string]cmd.Formatter{
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
-	})
+	}))
 }
 
 // ControllerAccessAPI defines a subset of the api/controller/Client API.
@@ -100,6 +113,24 @@ func (c *showControllerCommand) getAPI(controllerName string) (ControllerAccessA
 	return controller.NewClient(api), nil
 }
 
+// ReplicaSetAPI defines the api/highavailability/Client methods used
+// to show the controller's replica set.
+type ReplicaSetAPI interface {
+	ReplicaSetStatus() (params.ReplicaSetStatus, error)
+	Close() error
+}
+
+func (c *showControllerCommand) getReplicaSetAPI(controllerName string) (ReplicaSetAPI, error) {
+	if c.replicaSetAPI != nil {
+		return c.replicaSetAPI(controllerName), nil
+	}
+	api, err := c.NewAPIRoot(c.store, controllerName, "")
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return highavailability.NewClient(api), nil
+}
+
 // Run implements Command.Run
 func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 	controllerNames := c.controllerNames
@@ -150,6 +181,9 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 			continue
 		}
 		c.convertControllerForShow(&details, controllerName, one, access, allModels, modelStatus)
+		if c.showReplicaSet {
+			c.convertReplicaSetForShow(&details, controllerName)
+		}
 		controllers[controllerName] = details
 	}
 	return c.out.Write(ctx, controllers)
@@ -201,6 +235,10 @@ type ShowControllerDetails struct {
 	// CurrentModel is the name of the current model for this controller
 	CurrentModel string `yaml:"current-model,omitempty" json:"current-model,omitempty"`
 
+	// ReplicaSet holds the status of the controller's mongo replica set,
+	// if it was asked for.
+	ReplicaSet *ReplicaSetDetails `yaml:"replica-set,omitempty" json:"replica-set,omitempty"`
+
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
@@ -256,6 +294,43 @@ type ModelDetails struct {
 	CoreCount *int `yaml:"core-count,omitempty" json:"core-count,omitempty"`
 }
 
+// ReplicaSetDetails holds the status of a controller's mongo replica
+// set to show.
+type ReplicaSetDetails struct {
+	// Members holds the status of each replica set member, keyed
+	// by member id.
+	Members map[int]ReplicaSetMemberDetails `yaml:"members" json:"members"`
+
+	// Elections holds the times of the most recent elections of a
+	// primary, most recent first.
+	Elections []string `yaml:"elections,omitempty" json:"elections,omitempty"`
+}
+
+// ReplicaSetMemberDetails holds the status of a replica set member to
+// show.
+type ReplicaSetMemberDetails struct {
+	// Address is the member's host:port address.
+	Address string `yaml:"address" json:"address"`
+
+	// Machine is the id of the controller machine the member runs on.
+	Machine string `yaml:"machine,omitempty" json:"machine,omitempty"`
+
+	// State is the member's replica set state.
+	State string `yaml:"state" json:"state"`
+
+	// Healthy reports whether the member is reachable.
+	Healthy bool `yaml:"healthy" json:"healthy"`
+
+	// Voting reports whether the member votes in elections.
+	Voting bool `yaml:"voting" json:"voting"`
+
+	// Lag is how far the member's data is behind the primary's.
+	Lag string `yaml:"lag,omitempty" json:"lag,omitempty"`
+
+	// Message holds any error reported for the member.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
 // AccountDetails holds details of an account to show.
 type AccountDetails struct {
 	// User is the username for the account.
@@ -384,6 +459,43 @@ func (c *showControllerCommand) convertMachinesForShow(
 	}
 }
 
+func (c *showControllerCommand) convertReplicaSetForShow(controller *ShowControllerDetails, controllerName string) {
+	client, err := c.getReplicaSetAPI(controllerName)
+	if err != nil {
+		controller.Errors = append(controller.Errors, err.Error())
+		return
+	}
+	defer client.Close()
+	status, err := client.ReplicaSetStatus()
+	if err != nil {
+		controller.Errors = append(controller.Errors, err.Error())
+		return
+	}
+	details := &ReplicaSetDetails{
+		Members: make(map[int]ReplicaSetMemberDetails),
+	}
+	for _, m := range status.Members {
+		member := ReplicaSetMemberDetails{
+			Address: m.Address,
+			State:   m.State,
+			Healthy: m.Healthy,
+			Voting:  m.Voting,
+			Message: m.Message,
+		}
+		if tag, err := names.ParseMachineTag(m.MachineTag); err == nil {
+			member.Machine = tag.Id()
+		}
+		if m.Lag > 0 {
+			member.Lag = m.Lag.String()
+		}
+		details.Members[m.Id] = member
+	}
+	for _, e := range status.Elections {
+		details.Elections = append(details.Elections, e.Time.UTC().Format(time.RFC3339))
+	}
+	controller.ReplicaSet = details
+}
+
 func haStatus(hasVote bool, wantsVote bool, statusStr string) string {
 	if statusStr == string(status.Down) {
 		return "down, lost connection"
@@ -4,7 +4,9 @@
 package controller_test
 
 import (
+	"encoding/json"
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -13,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -88,6 +91,91 @@ mallards:
 	s.assertShowController(c, "mallards")
 }
 
+func (s *ShowControllerSuite) TestShowControllerReplicaSet(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints, this-is-one-more-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+    agent-version: 999.99.99
+`
+	s.fakeController.store = s.createTestClientStore(c)
+	replicaSet := &fakeReplicaSetAPI{
+		status: params.ReplicaSetStatus{
+			Members: []params.ReplicaSetMember{{
+				Id:         1,
+				Address:    "10.0.0.1:37017",
+				MachineTag: "machine-0",
+				State:      "PRIMARY",
+				Healthy:    true,
+				Voting:     true,
+			}, {
+				Id:      2,
+				Address: "10.0.0.2:37017",
+				State:   "SECONDARY",
+				Lag:     5 * time.Second,
+				Message: "unreachable",
+			}},
+			Elections: []params.ReplicaSetElection{{
+				Time: time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC),
+				Term: 2,
+			}},
+		},
+	}
+	command := controller.NewShowControllerReplicaSetCommandForTest(
+		s.store, s.api, func(string) controller.ReplicaSetAPI { return replicaSet },
+	)
+	ctx, err := testing.RunCommand(c, command, "mallards", "--replica-set", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var out map[string]controller.ShowControllerDetails
+	err = json.Unmarshal([]byte(testing.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out["mallards"].ReplicaSet, jc.DeepEquals, &controller.ReplicaSetDetails{
+		Members: map[int]controller.ReplicaSetMemberDetails{
+			1: {
+				Address: "10.0.0.1:37017",
+				Machine: "0",
+				State:   "PRIMARY",
+				Healthy: true,
+				Voting:  true,
+			},
+			2: {
+				Address: "10.0.0.2:37017",
+				State:   "SECONDARY",
+				Lag:     "5s",
+				Message: "unreachable",
+			},
+		},
+		Elections: []string{"2017-05-01T12:00:00Z"},
+	})
+	c.Assert(replicaSet.closed, jc.IsTrue)
+}
+
+func (s *ShowControllerSuite) TestShowControllerReplicaSetError(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+`
+	s.fakeController.store = s.createTestClientStore(c)
+	replicaSet := &fakeReplicaSetAPI{err: errors.New("permission denied")}
+	command := controller.NewShowControllerReplicaSetCommandForTest(
+		s.store, s.api, func(string) controller.ReplicaSetAPI { return replicaSet },
+	)
+	ctx, err := testing.RunCommand(c, command, "mallards", "--replica-set", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var out map[string]controller.ShowControllerDetails
+	err = json.Unmarshal([]byte(testing.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out["mallards"].ReplicaSet, gc.IsNil)
+	c.Assert(out["mallards"].Errors, jc.DeepEquals, []string{"permission denied"})
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithPasswords(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -386,3 +474,18 @@ func (c *fakeController) AllModels() (result []base.UserModel, _ error) {
 func (*fakeController) Close() error {
 	return nil
 }
+
+type fakeReplicaSetAPI struct {
+	status params.ReplicaSetStatus
+	err    error
+	closed bool
+}
+
+func (f *fakeReplicaSetAPI) ReplicaSetStatus() (params.ReplicaSetStatus, error) {
+	return f.status, f.err
+}
+
+func (f *fakeReplicaSetAPI) Close() error {
+	f.closed = true
+	return nil
+}
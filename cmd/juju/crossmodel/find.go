@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/crossmodel"
 )
 
@@ -99,11 +100,11 @@ func (c *findCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.user, "user", "", "return results with the user in the URL")
 	f.StringVar(&c.charm, "charm", "", "return results for the charm name")
 	f.StringVar(&c.author, "author", "", "return results matching the charm author")
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": formatFindTabular,
-	})
+	}))
 }
 
 // Run implements Command.Run.
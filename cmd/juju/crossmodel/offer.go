@@ -34,6 +34,11 @@ vendor:/u/<username>/<application-name>
 $ juju offer db2:db vendor:/u/ibm/hosted-db2
 $ juju offer -e prod db2:db,log vendor:/u/ibm/hosted-db2
 $ juju offer hosted-db2:db,log vendor:/u/ibm/hosted-db2
+
+By default, only the offering user may consume the offer. Use --to to
+allow other users, or everyone@external for all external users:
+
+$ juju offer --to fred,everyone@external db2:db local:/myapps/db2
 `
 )
 
@@ -61,6 +66,9 @@ type offerCommand struct {
 
 	// URL stores juju location where these endpoints are offered from.
 	URL string
+
+	// Users stores the names of the users allowed to consume the offer.
+	Users []string
 }
 
 // Info implements Command.Info.
@@ -94,12 +102,19 @@ func (c *offerCommand) Init(args []string) error {
 		return errors.Errorf(`hosted url %q is not valid" `, hostedURL)
 	}
 	c.URL = hostedURL
+
+	for _, user := range c.Users {
+		if !names.IsValidUser(user) {
+			return errors.NotValidf("user name %q", user)
+		}
+	}
 	return nil
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CrossModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.Users), "to", "Comma separated list of users allowed to consume the offer")
 }
 
 // Run implements Command.Run.
@@ -115,7 +130,7 @@ func (c *offerCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	// TODO (anastasiamac 2015-11-16) Add a sensible way for user to specify long-ish (at times) description when offering
-	results, err := api.Offer(model.ModelUUID, c.Application, c.Endpoints, c.URL, "")
+	results, err := api.Offer(model.ModelUUID, c.Application, c.Endpoints, c.URL, c.Users, "")
 	if err != nil {
 		return err
 	}
@@ -125,7 +140,7 @@ func (c *offerCommand) Run(_ *cmd.Context) error {
 // OfferAPI defines the API methods that the offer command uses.
 type OfferAPI interface {
 	Close() error
-	Offer(modelUUID, application string, endpoints []string, url string, desc string) ([]params.ErrorResult, error)
+	Offer(modelUUID, application string, endpoints []string, url string, users []string, desc string) ([]params.ErrorResult, error)
 }
 
 // applicationParse is used to split an application string
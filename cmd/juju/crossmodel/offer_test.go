@@ -101,6 +101,17 @@ func (s *offerSuite) TestOfferMultipleEndpoints(c *gc.C) {
 	s.assertOfferOutput(c, "test", "tst", []string{"db", "admin"}, "local:/u/bob/tst")
 }
 
+func (s *offerSuite) TestOfferToUsers(c *gc.C) {
+	s.args = []string{"--to", "fred,everyone@external", "tst:db", "local:/u/bob/tst"}
+	s.assertOfferOutput(c, "test", "tst", []string{"db"}, "local:/u/bob/tst")
+	c.Assert(s.mockAPI.users, jc.DeepEquals, []string{"fred", "everyone@external"})
+}
+
+func (s *offerSuite) TestOfferInvalidUser(c *gc.C) {
+	s.args = []string{"--to", "foo/23", "tst:db", "local:/u/bob/tst"}
+	s.assertOfferErrorOutput(c, `user name "foo/23" not valid`)
+}
+
 func (s *offerSuite) assertOfferOutput(c *gc.C, expectedModel, expectedApplication string, endpoints []string, url string) {
 	_, err := s.runOffer(c, s.args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -115,6 +126,7 @@ type mockOfferAPI struct {
 	offers           map[string][]string
 	urls             map[string]string
 	descs            map[string]string
+	users            []string
 }
 
 func newMockOfferAPI() *mockOfferAPI {
@@ -129,8 +141,9 @@ func (s *mockOfferAPI) Close() error {
 	return nil
 }
 
-func (s *mockOfferAPI) Offer(model, application string, endpoints []string, url string, desc string) ([]params.ErrorResult, error) {
+func (s *mockOfferAPI) Offer(model, application string, endpoints []string, url string, users []string, desc string) ([]params.ErrorResult, error) {
 	s.model = model
+	s.users = users
 	if s.errCall {
 		return nil, errors.New("aborted")
 	}
@@ -5,6 +5,7 @@ package machine
 
 import (
 	"fmt"
+	"io/ioutil"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -58,6 +60,12 @@ information about how to allocate the machine. For example, one can direct the
 MAAS provider to acquire a particular node by specifying its hostname.
 For more information on placement directives, see "juju help placement".
 
+Additional cloud-init user-data may be supplied in a YAML file with
+--cloudinit-userdata. The user-data is merged with that generated by Juju,
+and its commands are run before the Juju agent is started. Only the
+"runcmd", "packages" and "write_files" keys are supported. User-data
+cannot be supplied for manually provisioned machines.
+
 Examples:
    juju add-machine                      (starts a new machine)
    juju add-machine -n 2                 (starts 2 new machines)
@@ -68,6 +76,8 @@ Examples:
    juju add-machine ssh:user@10.10.0.3   (manually provisions machine with ssh)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
+   juju add-machine --cloudinit-userdata prepare.yaml
+                                         (starts a machine, preparing it with prepare.yaml)
 
 See also:
     remove-machine
@@ -109,6 +119,9 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// CloudInitUserDataFile is the path of a file holding additional
+	// cloud-init user-data for the machine.
+	CloudInitUserDataFile string
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -126,6 +139,7 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.CloudInitUserDataFile, "cloudinit-userdata", "", "Path to a YAML file holding additional cloud-init user-data")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -147,6 +161,9 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.CloudInitUserDataFile != "" && c.Placement != nil && c.Placement.Scope == sshScope {
+		return errors.New("cannot use --cloudinit-userdata when manually provisioning a machine")
+	}
 	return nil
 }
 
@@ -224,16 +241,31 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	var userData string
+	if c.CloudInitUserDataFile != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.CloudInitUserDataFile))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := cloudinit.ParseUserData(string(data)); err != nil {
+			return errors.Annotatef(err, "invalid user-data in %q", c.CloudInitUserDataFile)
+		}
+		userData = string(data)
+	}
+
 	var machineManager MachineManagerAPI
-	if len(c.Disks) > 0 {
+	if len(c.Disks) > 0 || userData != "" {
 		machineManager, err = c.getMachineManagerAPI()
 		if err != nil {
 			return errors.Trace(err)
 		}
 		defer machineManager.Close()
-		if machineManager.BestAPIVersion() < 1 {
+		if len(c.Disks) > 0 && machineManager.BestAPIVersion() < 1 {
 			return errors.New("cannot add machines with disks: not supported by the API server")
 		}
+		if userData != "" && machineManager.BestAPIVersion() < 3 {
+			return errors.New("cannot add machines with cloud-init user-data: not supported by the API server")
+		}
 	}
 
 	logger.Infof("load config")
@@ -283,6 +315,8 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		Constraints: c.Constraints,
 		Jobs:        jobs,
 		Disks:       c.Disks,
+
+		CloudInitUserData: userData,
 	}
 	machines := make([]params.AddMachineParams, c.NumMachines)
 	for i := 0; i < c.NumMachines; i++ {
@@ -290,8 +324,9 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	}
 
 	var results []params.AddMachinesResult
-	// If storage is specified, we attempt to use a new API on the service facade.
-	if len(c.Disks) > 0 {
+	// If storage or user-data is specified, we attempt to use a new API on
+	// the machine manager facade.
+	if machineManager != nil {
 		results, err = machineManager.AddMachines(machines)
 	} else {
 		results, err = client.AddMachines(machines)
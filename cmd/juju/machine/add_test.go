@@ -4,6 +4,8 @@
 package machine_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -85,6 +87,9 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"something:special"},
 			count:     1,
 			placement: "something:special",
+		}, {
+			args:        []string{"ssh:user@10.10.0.3", "--cloudinit-userdata", "userdata.yaml"},
+			errorString: "cannot use --cloudinit-userdata when manually provisioning a machine",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -206,6 +211,40 @@ func (s *AddMachineSuite) TestAddMachineWithDisksUnsupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot add machines with disks: not supported by the API server")
 }
 
+func (s *AddMachineSuite) writeUserData(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "userdata.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *AddMachineSuite) TestAddMachineWithCloudInitUserData(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 3
+	path := s.writeUserData(c, "runcmd:\n- echo hello\n")
+	_, err := s.run(c, "-n", "2", "--cloudinit-userdata", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+	c.Assert(s.fakeMachineManager.args, gc.HasLen, 2)
+	for _, param := range s.fakeMachineManager.args {
+		c.Assert(param.CloudInitUserData, gc.Equals, "runcmd:\n- echo hello\n")
+	}
+}
+
+func (s *AddMachineSuite) TestAddMachineWithCloudInitUserDataUnsupported(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 2
+	path := s.writeUserData(c, "runcmd:\n- echo hello\n")
+	_, err := s.run(c, "--cloudinit-userdata", path)
+	c.Assert(err, gc.ErrorMatches, "cannot add machines with cloud-init user-data: not supported by the API server")
+}
+
+func (s *AddMachineSuite) TestAddMachineWithInvalidCloudInitUserData(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 3
+	path := s.writeUserData(c, "users:\n- bob\n")
+	_, err := s.run(c, "--cloudinit-userdata", path)
+	c.Assert(err, gc.ErrorMatches, `invalid user-data in ".*userdata.yaml": cloud-init user-data key "users" not supported`)
+	c.Assert(s.fakeMachineManager.args, gc.HasLen, 0)
+}
+
 type fakeAddMachineAPI struct {
 	successOrder     []bool
 	currentOp        int
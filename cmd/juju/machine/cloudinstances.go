@@ -0,0 +1,186 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageCloudInstancesSummary = `
+Lists the instances that the cloud runs for a model.`[1:]
+
+var usageCloudInstancesDetails = `
+Instances are listed with their status and tags as reported by the
+cloud, along with the machine each one belongs to. Instances that
+belong to no machine in the model have no machine ID, and may have
+been orphaned by a failed provisioning or an interrupted removal.
+Machines whose instances the cloud does not report are listed after
+the instances.
+
+Containers and manually provisioned machines are not listed.
+
+By default, the tabular format is used.
+
+Examples:
+    juju cloud-instances
+    juju cloud-instances --format yaml
+
+See also:
+    machines
+    remove-machine`
+
+// NewListCloudInstancesCommand returns a command that lists the
+// instances that the cloud runs for a model.
+func NewListCloudInstancesCommand() cmd.Command {
+	return modelcmd.Wrap(&listCloudInstancesCommand{})
+}
+
+// listCloudInstancesCommand lists the instances that the cloud runs
+// for a model.
+type listCloudInstancesCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api CloudInstancesAPI
+}
+
+// CloudInstancesAPI defines the API methods that the cloud-instances
+// command uses.
+type CloudInstancesAPI interface {
+	ListCloudInstances() (params.CloudInstancesResult, error)
+	Close() error
+}
+
+// Info implements Command.Info.
+func (c *listCloudInstancesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cloud-instances",
+		Purpose: usageCloudInstancesSummary,
+		Doc:     usageCloudInstancesDetails,
+		Aliases: []string{"list-cloud-instances"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCloudInstancesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatCloudInstancesTabular,
+	}))
+}
+
+// Init implements Command.Init.
+func (c *listCloudInstancesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *listCloudInstancesCommand) getAPI() (CloudInstancesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *listCloudInstancesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	result, err := api.ListCloudInstances()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Instances) == 0 && len(result.MissingMachines) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No cloud instances to display.")
+		return nil
+	}
+	return c.out.Write(ctx, formatCloudInstances(result))
+}
+
+// cloudInstances defines the serialization behaviour of the
+// cloud-instances command output.
+type cloudInstances struct {
+	Instances       map[string]cloudInstance `yaml:"instances" json:"instances"`
+	MissingMachines []string                 `yaml:"missing-machines,omitempty" json:"missing-machines,omitempty"`
+}
+
+type cloudInstance struct {
+	Status  string            `yaml:"status" json:"status"`
+	Machine string            `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Tags    map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+func formatCloudInstances(result params.CloudInstancesResult) cloudInstances {
+	out := cloudInstances{
+		Instances:       make(map[string]cloudInstance),
+		MissingMachines: result.MissingMachines,
+	}
+	for _, inst := range result.Instances {
+		out.Instances[inst.InstanceId] = cloudInstance{
+			Status:  inst.Status,
+			Machine: inst.MachineId,
+			Tags:    inst.Tags,
+		}
+	}
+	return out
+}
+
+func formatCloudInstancesTabular(writer io.Writer, value interface{}) error {
+	instances, ok := value.(cloudInstances)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", instances, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Instance", "Status", "Machine", "Tags")
+	ids := make([]string, 0, len(instances.Instances))
+	for id := range instances.Instances {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		inst := instances.Instances[id]
+		machine := inst.Machine
+		if machine == "" {
+			machine = "-"
+		}
+		w.Println(id, inst.Status, machine, formatTags(inst.Tags))
+	}
+	tw.Flush()
+
+	if len(instances.MissingMachines) > 0 {
+		fmt.Fprintf(writer, "\nMachines with no cloud instance: %s\n", strings.Join(instances.MissingMachines, ", "))
+	}
+	return nil
+}
+
+func formatTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type CloudInstancesCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeCloudInstancesAPI
+}
+
+var _ = gc.Suite(&CloudInstancesCommandSuite{})
+
+func (s *CloudInstancesCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeCloudInstancesAPI{
+		result: params.CloudInstancesResult{
+			Instances: []params.CloudInstance{{
+				InstanceId: "i-orphan",
+				Status:     "stopped",
+				Tags:       map[string]string{"juju-model-uuid": "deadbeef"},
+			}, {
+				InstanceId: "i-0",
+				Status:     "running",
+				Tags:       map[string]string{"juju-machine-id": "default-machine-0"},
+				MachineId:  "0",
+			}},
+			MissingMachines: []string{"1"},
+		},
+	}
+}
+
+func (s *CloudInstancesCommandSuite) TestTabular(c *gc.C) {
+	context, err := testing.RunCommand(c, machine.NewListCloudInstancesCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"Instance  Status   Machine  Tags\n"+
+		"i-0       running  0        juju-machine-id=default-machine-0\n"+
+		"i-orphan  stopped  -        juju-model-uuid=deadbeef\n"+
+		"\n"+
+		"Machines with no cloud instance: 1\n")
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *CloudInstancesCommandSuite) TestYaml(c *gc.C) {
+	context, err := testing.RunCommand(c, machine.NewListCloudInstancesCommandForTest(s.api), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"instances:\n"+
+		"  i-0:\n"+
+		"    status: running\n"+
+		"    machine: \"0\"\n"+
+		"    tags:\n"+
+		"      juju-machine-id: default-machine-0\n"+
+		"  i-orphan:\n"+
+		"    status: stopped\n"+
+		"    tags:\n"+
+		"      juju-model-uuid: deadbeef\n"+
+		"missing-machines:\n"+
+		"- \"1\"\n")
+}
+
+func (s *CloudInstancesCommandSuite) TestNoInstances(c *gc.C) {
+	s.api.result = params.CloudInstancesResult{}
+	context, err := testing.RunCommand(c, machine.NewListCloudInstancesCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, "")
+	c.Assert(testing.Stderr(context), gc.Equals, "No cloud instances to display.\n")
+}
+
+func (s *CloudInstancesCommandSuite) TestError(c *gc.C) {
+	s.api.err = errors.New("listing cloud instances not supported")
+	_, err := testing.RunCommand(c, machine.NewListCloudInstancesCommandForTest(s.api))
+	c.Assert(err, gc.ErrorMatches, "listing cloud instances not supported")
+}
+
+func (s *CloudInstancesCommandSuite) TestArgsError(c *gc.C) {
+	_, err := testing.RunCommand(c, machine.NewListCloudInstancesCommandForTest(s.api), "0")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["0"\]`)
+}
+
+type fakeCloudInstancesAPI struct {
+	result params.CloudInstancesResult
+	err    error
+	closed bool
+}
+
+func (f *fakeCloudInstancesAPI) ListCloudInstances() (params.CloudInstancesResult, error) {
+	return f.result, f.err
+}
+
+func (f *fakeCloudInstancesAPI) Close() error {
+	f.closed = true
+	return nil
+}
@@ -44,10 +44,11 @@ type RemoveCommand struct {
 	*removeCommand
 }
 
-// NewRemoveCommand returns an RemoveCommand with the api provided as specified.
-func NewRemoveCommandForTest(api RemoveMachineAPI) (cmd.Command, *RemoveCommand) {
+// NewRemoveCommand returns an RemoveCommand with the apis provided as specified.
+func NewRemoveCommandForTest(api RemoveMachineAPI, mmAPI RemoveMachineManagerAPI) (cmd.Command, *RemoveCommand) {
 	cmd := &removeCommand{
-		api: api,
+		api:               api,
+		machineManagerAPI: mmAPI,
 	}
 	return modelcmd.Wrap(cmd), &RemoveCommand{cmd}
 }
@@ -55,3 +56,9 @@ func NewRemoveCommandForTest(api RemoveMachineAPI) (cmd.Command, *RemoveCommand)
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
+
+// NewListCloudInstancesCommandForTest returns a listCloudInstancesCommand
+// with the api provided as specified.
+func NewListCloudInstancesCommandForTest(api CloudInstancesAPI) cmd.Command {
+	return modelcmd.Wrap(&listCloudInstancesCommand{api: api})
+}
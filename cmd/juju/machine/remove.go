@@ -4,11 +4,16 @@
 package machine
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -21,9 +26,11 @@ func NewRemoveCommand() cmd.Command {
 // removeCommand causes an existing machine to be destroyed.
 type removeCommand struct {
 	modelcmd.ModelCommandBase
-	api        RemoveMachineAPI
-	MachineIds []string
-	Force      bool
+	api               RemoveMachineAPI
+	machineManagerAPI RemoveMachineManagerAPI
+	MachineIds        []string
+	Force             bool
+	DryRun            bool
 }
 
 const destroyMachineDoc = `
@@ -32,7 +39,10 @@ output of ` + "`juju status`." + `
 Machines responsible for the model cannot be removed.
 Machines running units or containers can be removed using the '--force'
 option; this will also remove those units and containers without giving
-them an opportunity to shut down cleanly.
+them an opportunity to shut down cleanly. Containers are removed before
+the machines hosting them, and units after their subordinates and
+storage attachments. Add '--dry-run' to list what '--force' would
+remove without removing anything.
 
 Examples:
 
@@ -44,6 +54,11 @@ Remove machine 6 and any running units or containers:
 
     juju remove-machine 6 --force
 
+Show which units, containers and storage attachments would be removed
+along with machine 6:
+
+    juju remove-machine 6 --force --dry-run
+
 See also:
     add-machine
 `
@@ -62,12 +77,16 @@ func (c *removeCommand) Info() *cmd.Info {
 func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.Force, "force", false, "Completely remove a machine and all its dependencies")
+	f.BoolVar(&c.DryRun, "dry-run", false, "List what --force would remove, without removing anything")
 }
 
 func (c *removeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("no machines specified")
 	}
+	if c.DryRun && !c.Force {
+		return errors.New("--dry-run can only be used with --force")
+	}
 	for _, id := range args {
 		if !names.IsValidMachine(id) {
 			return errors.Errorf("invalid machine id %q", id)
@@ -83,6 +102,14 @@ type RemoveMachineAPI interface {
 	Close() error
 }
 
+// RemoveMachineManagerAPI defines the MachineManager API methods that
+// remove-machine uses to force removal and report what it removes.
+type RemoveMachineManagerAPI interface {
+	ForceDestroyMachines(dryRun bool, machines ...string) ([]params.DestroyMachineResult, error)
+	BestAPIVersion() int
+	Close() error
+}
+
 func (c *removeCommand) getRemoveMachineAPI() (RemoveMachineAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -90,8 +117,32 @@ func (c *removeCommand) getRemoveMachineAPI() (RemoveMachineAPI, error) {
 	return c.NewAPIClient()
 }
 
+func (c *removeCommand) getMachineManagerAPI() (RemoveMachineManagerAPI, error) {
+	if c.machineManagerAPI != nil {
+		return c.machineManagerAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
 // Run implements Command.Run.
-func (c *removeCommand) Run(_ *cmd.Context) error {
+func (c *removeCommand) Run(ctx *cmd.Context) error {
+	if c.Force {
+		machineManager, err := c.getMachineManagerAPI()
+		if err != nil {
+			return err
+		}
+		defer machineManager.Close()
+		if machineManager.BestAPIVersion() >= 6 {
+			return c.forceRemove(ctx, machineManager)
+		}
+		if c.DryRun {
+			return errors.New("--dry-run is not supported by this controller")
+		}
+	}
 	client, err := c.getRemoveMachineAPI()
 	if err != nil {
 		return err
@@ -104,3 +155,59 @@ func (c *removeCommand) Run(_ *cmd.Context) error {
 	}
 	return block.ProcessBlockedError(err, block.BlockRemove)
 }
+
+// forceRemove force-destroys the machines, or with --dry-run only asks
+// what that would remove, and lists what goes with each machine.
+func (c *removeCommand) forceRemove(ctx *cmd.Context, api RemoveMachineManagerAPI) error {
+	results, err := api.ForceDestroyMachines(c.DryRun, c.MachineIds...)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	// A dry run's report is the command's output; otherwise it is
+	// progress information.
+	report := ctx.Infof
+	if c.DryRun {
+		report = func(format string, params ...interface{}) {
+			fmt.Fprintf(ctx.Stdout, format+"\n", params...)
+		}
+	}
+	var failures []string
+	for i, result := range results {
+		id := c.MachineIds[i]
+		if result.Error != nil {
+			failures = append(failures, fmt.Sprintf("machine %s: %v", id, result.Error))
+			continue
+		}
+		if c.DryRun {
+			report("will remove machine %s", id)
+		} else {
+			report("removing machine %s", id)
+		}
+		if result.Info == nil {
+			continue
+		}
+		for _, entity := range result.Info.DestroyedContainers {
+			report("- container %s", tagId(entity.Tag))
+		}
+		for _, entity := range result.Info.DestroyedUnits {
+			report("- unit %s", tagId(entity.Tag))
+		}
+		for _, attachment := range result.Info.DestroyedStorageAttachments {
+			report("- storage %s attached to %s", tagId(attachment.StorageTag), tagId(attachment.UnitTag))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.Errorf("some machines were not removed: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// tagId returns the id of the entity with the given tag, or the tag
+// itself if it cannot be parsed.
+func tagId(tagString string) string {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return tagString
+	}
+	return tag.Id()
+}
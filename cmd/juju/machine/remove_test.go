@@ -9,13 +9,15 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/testing"
 )
 
 type RemoveMachineSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
-	fake *fakeRemoveMachineAPI
+	fake   *fakeRemoveMachineAPI
+	fakeMM *fakeRemoveMachineManagerAPI
 }
 
 var _ = gc.Suite(&RemoveMachineSuite{})
@@ -23,10 +25,11 @@ var _ = gc.Suite(&RemoveMachineSuite{})
 func (s *RemoveMachineSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.fake = &fakeRemoveMachineAPI{}
+	s.fakeMM = &fakeRemoveMachineManagerAPI{version: 5}
 }
 
 func (s *RemoveMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	remove, _ := machine.NewRemoveCommandForTest(s.fake)
+	remove, _ := machine.NewRemoveCommandForTest(s.fake, s.fakeMM)
 	return testing.RunCommand(c, remove, args...)
 }
 
@@ -35,6 +38,7 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		args        []string
 		machines    []string
 		force       bool
+		dryRun      bool
 		errorString string
 	}{
 		{
@@ -53,6 +57,14 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 			args:     []string{"--force", "1", "2"},
 			machines: []string{"1", "2"},
 			force:    true,
+		}, {
+			args:     []string{"--force", "--dry-run", "1"},
+			machines: []string{"1"},
+			force:    true,
+			dryRun:   true,
+		}, {
+			args:        []string{"--dry-run", "1"},
+			errorString: "--dry-run can only be used with --force",
 		}, {
 			args:        []string{"lxd"},
 			errorString: `invalid machine id "lxd"`,
@@ -62,11 +74,12 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.fake)
+		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.fake, s.fakeMM)
 		err := testing.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
 			c.Check(removeCmd.Force, gc.Equals, test.force)
+			c.Check(removeCmd.DryRun, gc.Equals, test.dryRun)
 			c.Check(removeCmd.MachineIds, jc.DeepEquals, test.machines)
 		} else {
 			c.Check(err, gc.ErrorMatches, test.errorString)
@@ -102,6 +115,73 @@ func (s *RemoveMachineSuite) TestForceBlockedError(c *gc.C) {
 	testing.AssertOperationWasBlocked(c, err, ".*TestForceBlockedError.*")
 }
 
+var forceDestroyResults = []params.DestroyMachineResult{{
+	Info: &params.DestroyMachineInfo{
+		DestroyedContainers: []params.Entity{{Tag: "machine-1-lxd-0"}},
+		DestroyedUnits:      []params.Entity{{Tag: "unit-logging-0"}, {Tag: "unit-mysql-0"}},
+		DestroyedStorageAttachments: []params.StorageAttachmentId{{
+			StorageTag: "storage-data-0",
+			UnitTag:    "unit-mysql-0",
+		}},
+	},
+}, {
+	Info: &params.DestroyMachineInfo{},
+}}
+
+func (s *RemoveMachineSuite) TestRemoveForceReportsTeardown(c *gc.C) {
+	s.fakeMM.version = 6
+	s.fakeMM.results = forceDestroyResults
+	ctx, err := s.run(c, "--force", "1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeMM.machines, jc.DeepEquals, []string{"1", "2"})
+	c.Assert(s.fakeMM.dryRun, jc.IsFalse)
+	c.Assert(s.fake.machines, gc.IsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+removing machine 1
+- container 1/lxd/0
+- unit logging/0
+- unit mysql/0
+- storage data/0 attached to mysql/0
+removing machine 2
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveForceDryRun(c *gc.C) {
+	s.fakeMM.version = 6
+	s.fakeMM.results = forceDestroyResults
+	ctx, err := s.run(c, "--force", "--dry-run", "1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeMM.machines, jc.DeepEquals, []string{"1", "2"})
+	c.Assert(s.fakeMM.dryRun, jc.IsTrue)
+	c.Assert(s.fake.machines, gc.IsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+will remove machine 1
+- container 1/lxd/0
+- unit logging/0
+- unit mysql/0
+- storage data/0 attached to mysql/0
+will remove machine 2
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveForceMachineErrors(c *gc.C) {
+	s.fakeMM.version = 6
+	s.fakeMM.results = []params.DestroyMachineResult{
+		{Info: &params.DestroyMachineInfo{}},
+		{Error: &params.Error{Message: "machine is required by the model"}},
+	}
+	_, err := s.run(c, "--force", "1", "0")
+	c.Assert(err, gc.ErrorMatches, "some machines were not removed: machine 0: machine is required by the model")
+}
+
+func (s *RemoveMachineSuite) TestRemoveForceDryRunNotSupported(c *gc.C) {
+	_, err := s.run(c, "--force", "--dry-run", "1")
+	c.Assert(err, gc.ErrorMatches, "--dry-run is not supported by this controller")
+	c.Assert(s.fakeMM.machines, gc.IsNil)
+	c.Assert(s.fake.machines, gc.IsNil)
+}
+
 type fakeRemoveMachineAPI struct {
 	forced      bool
 	machines    []string
@@ -123,3 +203,24 @@ func (f *fakeRemoveMachineAPI) ForceDestroyMachines(machines ...string) error {
 	f.machines = machines
 	return f.removeError
 }
+
+type fakeRemoveMachineManagerAPI struct {
+	version  int
+	dryRun   bool
+	machines []string
+	results  []params.DestroyMachineResult
+}
+
+func (f *fakeRemoveMachineManagerAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeRemoveMachineManagerAPI) Close() error {
+	return nil
+}
+
+func (f *fakeRemoveMachineManagerAPI) ForceDestroyMachines(dryRun bool, machines ...string) ([]params.DestroyMachineResult, error) {
+	f.dryRun = dryRun
+	f.machines = machines
+	return f.results, nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/constraints"
 )
 
@@ -110,11 +111,11 @@ func formatConstraints(writer io.Writer, value interface{}) error {
 
 func (c *modelGetConstraintsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "constraints", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "constraints", output.Versioned(map[string]cmd.Formatter{
 		"constraints": formatConstraints,
 		"yaml":        cmd.FormatYaml,
 		"json":        cmd.FormatJson,
-	})
+	}))
 }
 
 func (c *modelGetConstraintsCommand) Run(ctx *cmd.Context) error {
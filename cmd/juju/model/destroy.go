@@ -48,9 +48,11 @@ type destroyCommand struct {
 	// sleepFunc is used when calling the timed function to get model status updates.
 	sleepFunc func(time.Duration)
 
-	envName   string
-	assumeYes bool
-	api       DestroyModelAPI
+	envName        string
+	assumeYes      bool
+	destroyStorage bool
+	releaseStorage bool
+	api            DestroyModelAPI
 }
 
 var destroyDoc = `
@@ -60,10 +62,17 @@ there. Due to the irreversible nature of the command, it will prompt for
 confirmation (unless overridden with the '-y' option) before taking any
 action.
 
+The model's volumes are destroyed along with it by default. The
+--destroy-storage option makes that choice explicit; the --release-storage
+option instead releases the volumes, removing them from the model but
+leaving them in the cloud so that they may be attached again later.
+Releasing volumes is not supported by all clouds.
+
 Examples:
 
     juju destroy-model test
     juju destroy-model -y mymodel
+    juju destroy-model --release-storage mymodel
 
 See also:
     destroy-controller
@@ -79,6 +88,7 @@ Continue [y/N]? `[1:]
 type DestroyModelAPI interface {
 	Close() error
 	DestroyModel(names.ModelTag) error
+	DestroyModelWithStorage(tag names.ModelTag, destroyStorage bool) error
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 }
 
@@ -97,10 +107,15 @@ func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
 	f.BoolVar(&c.assumeYes, "yes", false, "")
+	f.BoolVar(&c.destroyStorage, "destroy-storage", false, "Destroy the model's volumes")
+	f.BoolVar(&c.releaseStorage, "release-storage", false, "Release the model's volumes, leaving them in the cloud")
 }
 
 // Init implements Command.Init.
 func (c *destroyCommand) Init(args []string) error {
+	if c.destroyStorage && c.releaseStorage {
+		return errors.New("--destroy-storage and --release-storage cannot both be specified")
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no model specified")
@@ -165,7 +180,12 @@ func (c *destroyCommand) Run(ctx *cmd.Context) error {
 
 	// Attempt to destroy the model.
 	ctx.Infof("Destroying model")
-	err = api.DestroyModel(names.NewModelTag(modelDetails.ModelUUID))
+	modelTag := names.NewModelTag(modelDetails.ModelUUID)
+	if c.destroyStorage || c.releaseStorage {
+		err = api.DestroyModelWithStorage(modelTag, c.destroyStorage)
+	} else {
+		err = api.DestroyModel(modelTag)
+	}
 	if err != nil {
 		return c.handleError(errors.Annotate(err, "cannot destroy model"), modelName)
 	}
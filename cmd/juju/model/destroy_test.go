@@ -40,6 +40,7 @@ type fakeAPI struct {
 	env             map[string]interface{}
 	statusCallCount int
 	modelInfoErr    []*params.Error
+	destroyStorage  *bool
 }
 
 func (f *fakeAPI) Close() error { return nil }
@@ -48,6 +49,11 @@ func (f *fakeAPI) DestroyModel(names.ModelTag) error {
 	return f.err
 }
 
+func (f *fakeAPI) DestroyModelWithStorage(tag names.ModelTag, destroyStorage bool) error {
+	f.destroyStorage = &destroyStorage
+	return f.err
+}
+
 func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error) {
 	var err *params.Error = &params.Error{Code: params.CodeNotFound}
 	if f.statusCallCount < len(f.modelInfoErr) {
@@ -147,6 +153,31 @@ func (s *DestroySuite) TestDestroy(c *gc.C) {
 	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
 }
 
+func (s *DestroySuite) TestDestroyDefaultStorage(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.destroyStorage, gc.IsNil)
+}
+
+func (s *DestroySuite) TestDestroyDestroyStorage(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--destroy-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.destroyStorage, gc.NotNil)
+	c.Assert(*s.api.destroyStorage, jc.IsTrue)
+}
+
+func (s *DestroySuite) TestDestroyReleaseStorage(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--release-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.destroyStorage, gc.NotNil)
+	c.Assert(*s.api.destroyStorage, jc.IsFalse)
+}
+
+func (s *DestroySuite) TestDestroyStorageFlagsExclusive(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--destroy-storage", "--release-storage")
+	c.Assert(err, gc.ErrorMatches, "--destroy-storage and --release-storage cannot both be specified")
+}
+
 func (s *DestroySuite) TestDestroyBlocks(c *gc.C) {
 	checkModelExistsInStore(c, "test1:admin/test2", s.store)
 	s.api.modelInfoErr = []*params.Error{{}, {Code: params.CodeNotFound}}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewExportCommand returns a fully constructed export-model command.
+func NewExportCommand() cmd.Command {
+	return modelcmd.Wrap(&exportCommand{})
+}
+
+type exportCommand struct {
+	modelcmd.ModelCommandBase
+	api ExportModelAPI
+
+	filename string
+}
+
+const exportModelHelpDoc = `
+Writes a bundle describing the applications in the model, with their
+charms, configuration, constraints, storage and endpoint bindings to
+spaces, along with the machines the units are placed on and the
+relations between the applications.
+
+Deploying the bundle into an empty model with "juju deploy" reproduces
+the model; the spaces the applications are bound to must exist there.
+Unlike dump-model, the output describes what is deployed rather than
+how the controller stores it, so it is suitable for keeping in version
+control and reviewing.
+
+Examples:
+
+    juju export-model
+    juju export-model -m mymodel --filename mymodel.yaml
+    juju deploy mymodel.yaml
+
+See also:
+    deploy
+    dump-model
+`
+
+// Info implements Command.
+func (c *exportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Purpose: "Writes a bundle that reproduces the model.",
+		Doc:     exportModelHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *exportCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.filename, "filename", "", "Write the bundle to this file instead of standard output")
+}
+
+// Init implements Command.
+func (c *exportCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// ExportModelAPI specifies the used function calls of the Bundle facade.
+type ExportModelAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+func (c *exportCommand) getAPI() (ExportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *exportCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ExportBundle()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, result)
+		return err
+	}
+	if err := ioutil.WriteFile(ctx.AbsPath(c.filename), []byte(result), 0644); err != nil {
+		return errors.Annotate(err, "cannot write bundle")
+	}
+	ctx.Infof("Model exported to %s", c.filename)
+	return nil
+}
@@ -20,9 +20,12 @@ By default, the controller is the current controller.
 
 Users with read access are limited in what they can do with models:
 ` + "`juju models`, `juju machines`, and `juju status`" + `.
+Users with operator access can also run actions and resolve units, but
+cannot change model configuration, applications or relations.
 
 Valid access levels for models are:
     read
+    operator
     write
     admin
 
@@ -36,6 +39,10 @@ Grant user 'joe' 'read' access to model 'mymodel':
 
     juju grant joe read mymodel
 
+Grant user 'ann' 'operator' access to model 'mymodel':
+
+    juju grant ann operator mymodel
+
 Grant user 'jim' 'write' access to model 'mymodel':
 
     juju grant jim write mymodel
@@ -58,12 +65,12 @@ Revokes access from a Juju user for a model or controller`[1:]
 var usageRevokeDetails = `
 By default, the controller is the current controller.
 
-Revoking write access, from a user who has that permission, will leave
-that user with read access. Revoking read access, however, also revokes
-write access.
+Revoking write or operator access, from a user who has that permission,
+will leave that user with read access. Revoking read access, however,
+also revokes write and operator access.
 
 Examples:
-Revoke 'read' (and 'write') access from user 'joe' for model 'mymodel':
+Revoke 'read' (and 'operator' or 'write') access from user 'joe' for model 'mymodel':
 
     juju revoke joe read mymodel
 
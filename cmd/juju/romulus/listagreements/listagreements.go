@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/terms-client/api"
 	"github.com/juju/terms-client/api/wireformat"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
@@ -55,10 +56,10 @@ type listAgreementsCommand struct {
 // SetFlags implements Command.SetFlags.
 func (c *listAgreementsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.JujuCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "json", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "json", output.Versioned(map[string]cmd.Formatter{
 		"json": formatJSON,
 		"yaml": cmd.FormatYaml,
-	})
+	}))
 }
 
 // Info implements Command.Info.
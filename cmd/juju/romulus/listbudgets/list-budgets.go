@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
 	api "github.com/juju/romulus/api/budget"
@@ -51,10 +52,10 @@ func (c *listBudgetsCommand) Info() *cmd.Info {
 // SetFlags implements cmd.Command.SetFlags.
 func (c *listBudgetsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.JujuCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
 		"tabular": formatTabular,
 		"json":    cmd.FormatJson,
-	})
+	}))
 }
 
 func (c *listBudgetsCommand) Run(ctx *cmd.Context) error {
@@ -88,13 +88,13 @@ func (c *ListPlansCommand) Init(args []string) error {
 func (c *ListPlansCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.JujuCommandBase.SetFlags(f)
 	defaultFormat := "tabular"
-	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
+	c.out.AddFlags(f, defaultFormat, output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"smart":   cmd.FormatSmart,
 		"summary": formatSummary,
 		"tabular": formatTabular,
-	})
+	}))
 }
 
 // Run implements Command.Run.
@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/status"
+)
+
+// unitColumn describes a column that may be selected with --columns.
+type unitColumn struct {
+	title string
+	// statusOf, if not nil, returns the status to show in the column
+	// in colour; otherwise value returns its text.
+	statusOf func(unitStatus) status.Status
+	value    func(unitStatus) string
+}
+
+// unitColumns holds the columns that may be selected with --columns,
+// keyed by name.
+var unitColumns = map[string]unitColumn{
+	"unit": {
+		title: "Unit",
+	},
+	"workload": {
+		title:    "Workload",
+		statusOf: func(u unitStatus) status.Status { return u.WorkloadStatusInfo.Current },
+	},
+	"agent": {
+		title:    "Agent",
+		statusOf: func(u unitStatus) status.Status { return u.JujuStatusInfo.Current },
+	},
+	"machine": {
+		title: "Machine",
+		value: func(u unitStatus) string { return u.Machine },
+	},
+	"address": {
+		title: "Public address",
+		value: func(u unitStatus) string { return u.PublicAddress },
+	},
+	"ports": {
+		title: "Ports",
+		value: func(u unitStatus) string { return strings.Join(u.OpenedPorts, ",") },
+	},
+	"message": {
+		title: "Message",
+		value: func(u unitStatus) string {
+			if doing := agentDoing(u.JujuStatusInfo); doing != "" {
+				return fmt.Sprintf("(%s) %s", doing, u.WorkloadStatusInfo.Message)
+			}
+			return u.WorkloadStatusInfo.Message
+		},
+	},
+}
+
+// parseColumns parses the value of the --columns flag, a comma
+// separated list of column names.
+func parseColumns(value string) ([]string, error) {
+	var columns []string
+	seen := set.NewStrings()
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := unitColumns[name]; !ok {
+			return nil, errors.NotValidf("column %q (expected one of %s)",
+				name, strings.Join(set.NewStrings(stringKeysFromMap(unitColumns)...).SortedValues(), ", "))
+		}
+		if seen.Contains(name) {
+			return nil, errors.NotValidf("repeated column %q", name)
+		}
+		seen.Add(name)
+		columns = append(columns, name)
+	}
+	return columns, nil
+}
+
+// formatUnitColumns writes a single table of the model's units,
+// including subordinates, showing only the given columns. Each unit
+// is listed once, without indentation or leader markers, so that the
+// output is easy for scripts to read; subordinates are shown on their
+// principal's machine.
+func formatUnitColumns(writer io.Writer, forceColor bool, fs formattedStatus, columns []string) error {
+	tw := output.TabWriter(writer)
+	if forceColor {
+		tw.SetColorCapable(forceColor)
+	}
+	w := output.Wrapper{tw}
+
+	units := make(map[string]unitStatus)
+	var addUnits func(map[string]unitStatus, string)
+	addUnits = func(unitMap map[string]unitStatus, machine string) {
+		for name, u := range unitMap {
+			if u.Machine == "" {
+				u.Machine = machine
+			}
+			units[name] = u
+			addUnits(u.Subordinates, u.Machine)
+		}
+	}
+	for _, app := range fs.Applications {
+		addUnits(app.Units, "")
+	}
+
+	last := len(columns) - 1
+	for i, name := range columns {
+		if i == last {
+			w.Println(unitColumns[name].title)
+		} else {
+			w.Print(unitColumns[name].title)
+		}
+	}
+	for _, unitName := range utils.SortStringsNaturally(stringKeysFromMap(units)) {
+		u := units[unitName]
+		for i, name := range columns {
+			column := unitColumns[name]
+			// Statuses are coloured, except in the last column,
+			// which is written without padding.
+			var value string
+			switch {
+			case column.statusOf != nil && i < last:
+				w.PrintStatus(column.statusOf(u))
+				continue
+			case column.statusOf != nil:
+				value = string(column.statusOf(u))
+			case column.value != nil:
+				value = column.value(u)
+			default:
+				value = unitName
+			}
+			if i == last {
+				w.Println(value)
+			} else {
+				w.Print(value)
+			}
+		}
+	}
+	return tw.Flush()
+}
@@ -18,6 +18,7 @@ type formattedStatus struct {
 	Machines           map[string]machineStatus           `json:"machines"`
 	Applications       map[string]applicationStatus       `json:"applications"`
 	RemoteApplications map[string]remoteApplicationStatus `json:"application-endpoints,omitempty" yaml:"application-endpoints,omitempty"`
+	Relations          []relationStatus                   `json:"-" yaml:"-"`
 }
 
 type formattedMachineStatus struct {
@@ -136,6 +137,16 @@ func (s remoteApplicationStatus) MarshalYAML() (interface{}, error) {
 	return remoteApplicationStatusNoMarshal(s), nil
 }
 
+// relationStatus holds the health of a relation, for the tabular
+// format; the other formats show relations per application.
+type relationStatus struct {
+	// Endpoints holds the relation's endpoints as
+	// "application:endpoint".
+	Endpoints    []string
+	Status       string
+	UnitsInError []string
+}
+
 type meterStatus struct {
 	Color   string `json:"color,omitempty" yaml:"color,omitempty"`
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
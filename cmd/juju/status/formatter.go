@@ -73,9 +73,23 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 	for sn, s := range sf.status.RemoteApplications {
 		out.RemoteApplications[sn] = sf.formatRemoteApplication(sn, s)
 	}
+	for _, r := range sf.status.Relations {
+		out.Relations = append(out.Relations, formatRelation(r))
+	}
 	return out, nil
 }
 
+func formatRelation(relation params.RelationStatus) relationStatus {
+	out := relationStatus{
+		Status:       relation.Status,
+		UnitsInError: relation.UnitsInError,
+	}
+	for _, ep := range relation.Endpoints {
+		out.Endpoints = append(out.Endpoints, ep.String())
+	}
+	return out
+}
+
 // MachineFormat takes stored model information (params.FullStatus) and formats machine status info.
 func (sf *statusFormatter) MachineFormat(machineId []string) formattedMachineStatus {
 	if sf.status == nil {
@@ -27,6 +27,7 @@ type statusRelation struct {
 	application2 string
 	relation     string
 	subordinate  bool
+	health       *relationStatus
 }
 
 func (s *statusRelation) relationType() string {
@@ -54,7 +55,7 @@ func (r *relationFormatter) len() int {
 	return r.relationIndex.Size()
 }
 
-func (r *relationFormatter) add(rel1, rel2, relation string, is2SubOf1 bool) {
+func (r *relationFormatter) add(rel1, rel2, relation string, is2SubOf1 bool, health *relationStatus) {
 	rel := []string{rel1, rel2}
 	if !is2SubOf1 {
 		sort.Sort(sort.StringSlice(rel))
@@ -65,6 +66,7 @@ func (r *relationFormatter) add(rel1, rel2, relation string, is2SubOf1 bool) {
 		application2: rel[1],
 		relation:     relation,
 		subordinate:  is2SubOf1,
+		health:       health,
 	}
 	r.relationIndex.Add(k)
 }
@@ -77,6 +79,28 @@ func (r *relationFormatter) get(k string) *statusRelation {
 	return r.relations[k]
 }
 
+// findRelationStatus returns the status of the relation between the
+// given endpoint of application and the related application, or nil
+// if it is not known.
+func findRelationStatus(relations []relationStatus, application, endpoint, related string) *relationStatus {
+	want := application + ":" + endpoint
+	for i, r := range relations {
+		// A peer relation has a single endpoint.
+		found, foundRelated := false, related == application && len(r.Endpoints) == 1
+		for _, ep := range r.Endpoints {
+			if ep == want {
+				found = true
+			} else if strings.HasPrefix(ep, related+":") {
+				foundRelated = true
+			}
+		}
+		if found && foundRelated {
+			return &relations[i]
+		}
+	}
+	return nil
+}
+
 // FormatTabular writes a tabular summary of machines, applications, and
 // units. Any subordinate items are indented by two spaces beneath
 // their superior.
@@ -189,7 +213,8 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		subs := set.NewStrings(app.SubordinateTo...)
 		for _, relType := range sortedRelTypes {
 			for _, related := range app.Relations[relType] {
-				relations.add(related, appName, relType, subs.Contains(related))
+				health := findRelationStatus(fs.Relations, appName, relType, related)
+				relations.add(related, appName, relType, subs.Contains(related), health)
 			}
 		}
 
@@ -241,12 +266,36 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 	printMachines(tw, fs.Machines)
 
 	if relations.len() > 0 {
-		outputHeaders("Relation", "Provides", "Consumes", "Type")
+		// Controllers that predate relation status don't report it.
+		showHealth := false
+		for _, r := range fs.Relations {
+			showHealth = showHealth || r.Status != ""
+		}
+		if showHealth {
+			outputHeaders("Relation", "Provides", "Consumes", "Type", "Status", "Message")
+		} else {
+			outputHeaders("Relation", "Provides", "Consumes", "Type")
+		}
 		for _, k := range relations.sorted() {
 			r := relations.get(k)
-			if r != nil {
+			if r == nil {
+				continue
+			}
+			if !showHealth {
 				p(r.relation, r.application1, r.application2, r.relationType())
+				continue
+			}
+			w.Print(r.relation, r.application1, r.application2, r.relationType())
+			var message string
+			if r.health != nil {
+				w.PrintStatus(status.Status(r.health.Status))
+				if len(r.health.UnitsInError) > 0 {
+					message = "hook failed on " + strings.Join(r.health.UnitsInError, ", ")
+				}
+			} else {
+				w.Print("")
 			}
+			p(message)
 		}
 	}
 
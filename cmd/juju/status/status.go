@@ -8,14 +8,18 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/juju/osenv"
 )
 
@@ -26,6 +30,18 @@ type statusAPI interface {
 	Close() error
 }
 
+// statusFieldsAPI is implemented by clients that can limit the status
+// returned to the parts that will be displayed.
+type statusFieldsAPI interface {
+	StatusFields(patterns, fields []string) (*params.FullStatus, error)
+}
+
+// statusWatcher reports the status of a model each time it changes.
+type statusWatcher interface {
+	Next() (*params.FullStatus, error)
+	Stop() error
+}
+
 // NewStatusCommand returns a new command, which reports on the
 // runtime state of various system entities.
 func NewStatusCommand() cmd.Command {
@@ -37,8 +53,16 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	watch    time.Duration
 	api      statusAPI
 
+	at         string
+	atTime     time.Time
+	atEntities []names.Tag
+
+	columnsValue string
+	columns      []string
+
 	color bool
 }
 
@@ -73,10 +97,30 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
 
+With --watch, the status is displayed again each time it changes, but no
+more often than the given interval. Changes are pushed by the controller
+rather than polled for, so this requires a controller that supports it.
+
+With --at, the recorded status history is used to display the status of each
+machine and unit as it was at the given time, which is useful for working out
+what happened during an incident. Times are local unless --utc is specified or
+a time zone is given. Filters must be exact machine, application or unit
+names, and only the tabular, yaml and json formats are available. Status
+history is pruned by the controller, so older times may be incomplete.
+
+With --columns, the tabular format shows a single table of units, with
+only the given columns, in the order given. Subordinate units are listed
+alongside their principals. The available columns are unit, workload,
+agent, machine, address, ports and message. This is useful for scripts
+and narrow terminals.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --watch 2s
+    juju show-status --at "2016-03-01 14:00"
+    juju show-status --columns unit,workload,machine,address
 
 See also:
     machines
@@ -99,10 +143,13 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.DurationVar(&c.watch, "watch", 0, "Display the status each time it changes, at most once per the given interval")
+	f.StringVar(&c.at, "at", "", "Display the recorded status at the given time, e.g. \"2016-03-01 14:00\"")
+	f.StringVar(&c.columnsValue, "columns", "", "Display only the given unit columns, e.g. \"unit,workload,machine\"")
 
 	defaultFormat := "tabular"
 
-	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
+	c.out.AddFlags(f, defaultFormat, output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"short":   FormatOneline,
@@ -110,11 +157,14 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 		"line":    FormatOneline,
 		"tabular": c.FormatTabular,
 		"summary": FormatSummary,
-	})
+	}))
 }
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch < 0 {
+		return errors.NotValidf("negative --watch interval")
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -126,13 +176,64 @@ func (c *statusCommand) Init(args []string) error {
 			}
 		}
 	}
+	if c.columnsValue != "" {
+		if err := c.initColumns(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if c.at != "" {
+		return c.initAt()
+	}
 	return nil
 }
 
+func (c *statusCommand) initColumns() error {
+	if c.at != "" {
+		return errors.New("--at and --columns cannot be specified together")
+	}
+	if c.out.Name() != "tabular" {
+		return errors.Errorf("--columns does not support the %q format", c.out.Name())
+	}
+	var err error
+	c.columns, err = parseColumns(c.columnsValue)
+	return errors.Trace(err)
+}
+
+func (c *statusCommand) initAt() error {
+	if c.watch != 0 {
+		return errors.New("--at and --watch cannot be specified together")
+	}
+	switch output.BaseFormat(c.out.Name()) {
+	case "tabular", "yaml", "json":
+	default:
+		return errors.Errorf("--at does not support the %q format", c.out.Name())
+	}
+	var err error
+	if c.atTime, err = parseStatusAt(c.at, c.isoTime); err != nil {
+		return errors.Trace(err)
+	}
+	c.atEntities, err = statusAtEntities(c.patterns)
+	return errors.Trace(err)
+}
+
 var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 	return c.NewAPIClient()
 }
 
+var newStatusWatcher = func(client statusAPI, patterns []string) (statusWatcher, error) {
+	watchClient, ok := client.(interface {
+		WatchFullStatus([]string) (*api.FullStatusWatcher, error)
+	})
+	if !ok {
+		return nil, errors.NotSupportedf("watching status")
+	}
+	watcher, err := watchClient.WatchFullStatus(patterns)
+	if err != nil {
+		return nil, err
+	}
+	return watcher, nil
+}
+
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	apiclient, err := newAPIClientForStatus(c)
 	if err != nil {
@@ -140,7 +241,20 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
+	if c.watch > 0 {
+		return c.runWatch(ctx, apiclient)
+	}
+	if c.at != "" {
+		return c.runAt(ctx, apiclient)
+	}
+
+	var status *params.FullStatus
+	if fieldsClient, ok := apiclient.(statusFieldsAPI); ok && len(c.columns) > 0 {
+		// The unit columns only need the applications.
+		status, err = fieldsClient.StatusFields(c.patterns, []string{"applications"})
+	} else {
+		status, err = apiclient.Status(c.patterns)
+	}
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
@@ -151,7 +265,72 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	} else if status == nil {
 		return errors.Errorf("unable to obtain the current status")
 	}
+	return c.writeStatus(ctx, status)
+}
+
+// runWatch displays the model's status each time the controller
+// reports a change, but no more often than the watch interval,
+// until interrupted.
+func (c *statusCommand) runWatch(ctx *cmd.Context, apiclient statusAPI) error {
+	watcher, err := newStatusWatcher(apiclient, c.patterns)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer watcher.Stop()
 
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	done := make(chan struct{})
+	defer close(done)
+	statuses := make(chan *params.FullStatus)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			status, err := watcher.Next()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case statuses <- status:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var wait <-chan time.Time
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case err := <-errs:
+			return errors.Trace(err)
+		case status := <-statuses:
+			if wait != nil {
+				select {
+				case <-wait:
+				case <-interrupted:
+					return nil
+				}
+				// Display the latest status if it has
+				// changed again while we were waiting.
+				select {
+				case status = <-statuses:
+				default:
+				}
+			}
+			if err := c.writeStatus(ctx, status); err != nil {
+				return errors.Trace(err)
+			}
+			wait = time.After(c.watch)
+		}
+	}
+}
+
+func (c *statusCommand) writeStatus(ctx *cmd.Context, status *params.FullStatus) error {
 	formatter := newStatusFormatter(status, c.ControllerName(), c.isoTime)
 	formatted, err := formatter.format()
 	if err != nil {
@@ -161,5 +340,11 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 }
 
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
+	if hs, ok := value.(historicalStatus); ok {
+		return formatHistoricalTabular(writer, hs)
+	}
+	if fs, ok := value.(formattedStatus); ok && len(c.columns) > 0 {
+		return formatUnitColumns(writer, c.color, fs, c.columns)
+	}
 	return FormatTabular(writer, c.color, value)
 }
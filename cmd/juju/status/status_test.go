@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/version"
@@ -3537,7 +3538,18 @@ func (s *StatusSuite) setupMigrationTest(c *gc.C) *state.State {
 type fakeAPIClient struct {
 	statusReturn *params.FullStatus
 	patternsUsed []string
+	fieldsUsed   []string
 	closeCalled  bool
+
+	historyReturn   []params.EntityStatusHistoryEntry
+	historyTo       *time.Time
+	historyEntities []names.Tag
+}
+
+func (a *fakeAPIClient) ModelStatusHistory(from, to *time.Time, entities []names.Tag, size int) ([]params.EntityStatusHistoryEntry, error) {
+	a.historyTo = to
+	a.historyEntities = entities
+	return a.historyReturn, nil
 }
 
 func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
@@ -3545,6 +3557,12 @@ func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeAPIClient) StatusFields(patterns, fields []string) (*params.FullStatus, error) {
+	a.patternsUsed = patterns
+	a.fieldsUsed = fields
+	return a.statusReturn, nil
+}
+
 func (a *fakeAPIClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -3790,12 +3808,12 @@ Machine  State    DNS       Inst id       Series   AZ
 1        started  10.0.1.1  controller-1  quantal  
 2        started  10.0.2.1  controller-2  quantal  
 
-Relation           Provides   Consumes   Type
-juju-info          logging    mysql      regular
-logging-dir        logging    wordpress  regular
-info               mysql      logging    subordinate
-db                 mysql      wordpress  regular
-logging-directory  wordpress  logging    subordinate
+Relation           Provides   Consumes   Type         Status  Message
+juju-info          logging    mysql      regular      joined  
+logging-dir        logging    wordpress  regular      joined  
+info               mysql      logging    subordinate  joined  
+db                 mysql      wordpress  regular      joined  
+logging-directory  wordpress  logging    subordinate  joined  
 
 `[1:]
 	c.Assert(string(stdout), gc.Equals, expected)
@@ -3879,6 +3897,52 @@ func (s *StatusSuite) TestFormatTabularConsistentPeerRelationName(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestFormatTabularRelationStatus(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"mysql": {
+				Relations: map[string][]string{
+					"server": {"wordpress"},
+				},
+			},
+			"wordpress": {
+				Relations: map[string][]string{
+					"db":           {"mysql"},
+					"cache":        {"memcached"},
+					"loadbalancer": {"wordpress"},
+				},
+			},
+			"memcached": {
+				Relations: map[string][]string{
+					"cache": {"wordpress"},
+				},
+			},
+		},
+		Relations: []relationStatus{{
+			Endpoints:    []string{"mysql:server", "wordpress:db"},
+			Status:       "error",
+			UnitsInError: []string{"wordpress/0", "wordpress/1"},
+		}, {
+			Endpoints: []string{"memcached:cache", "wordpress:cache"},
+			Status:    "broken",
+		}, {
+			Endpoints: []string{"wordpress:loadbalancer"},
+			Status:    "joined",
+		}},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	sections, err := splitTableSections(out.Bytes())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sections["Relation"], gc.DeepEquals, []string{
+		"Relation      Provides   Consumes   Type     Status  Message",
+		"cache         memcached  wordpress  regular  broken  ",
+		"db            mysql      wordpress  regular  error   hook failed on wordpress/0, wordpress/1",
+		"loadbalancer  wordpress  wordpress  peer     joined  ",
+	})
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
@@ -3907,6 +3971,222 @@ func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	c.Check(string(stderr), gc.Equals, "error: unable to obtain the current status\n")
 }
 
+type fakeStatusWatcher struct {
+	statuses []*params.FullStatus
+	stopped  bool
+}
+
+func (w *fakeStatusWatcher) Next() (*params.FullStatus, error) {
+	if len(w.statuses) == 0 {
+		return nil, errors.New("watcher closed")
+	}
+	status := w.statuses[0]
+	w.statuses = w.statuses[1:]
+	return status, nil
+}
+
+func (w *fakeStatusWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+func (s *StatusSuite) TestStatusWatch(c *gc.C) {
+	client := fakeAPIClient{}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+	watcher := &fakeStatusWatcher{
+		statuses: []*params.FullStatus{
+			{Model: params.ModelStatusInfo{Name: "first"}},
+			{Model: params.ModelStatusInfo{Name: "second"}},
+		},
+	}
+	var patternsUsed []string
+	s.PatchValue(&newStatusWatcher, func(_ statusAPI, patterns []string) (statusWatcher, error) {
+		patternsUsed = patterns
+		return watcher, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--format", "yaml", "--watch", "1ms", "mysql")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "error: watcher closed\n")
+	c.Check(patternsUsed, jc.DeepEquals, []string{"mysql"})
+	c.Check(string(stdout), jc.Contains, "name: first")
+	c.Check(string(stdout), jc.Contains, "name: second")
+	c.Check(watcher.stopped, jc.IsTrue)
+	c.Check(client.closeCalled, jc.IsTrue)
+}
+
+func (s *StatusSuite) TestStatusWatchNegativeInterval(c *gc.C) {
+	code, _, stderr := runStatus(c, "--watch", "-1s")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "error: negative --watch interval not valid\n")
+}
+
+func (s *StatusSuite) TestStatusAt(c *gc.C) {
+	earlier := time.Date(2016, 3, 1, 13, 0, 0, 0, time.UTC)
+	later := time.Date(2016, 3, 1, 13, 30, 0, 0, time.UTC)
+	client := fakeAPIClient{
+		historyReturn: []params.EntityStatusHistoryEntry{{
+			Tag:    "machine-0",
+			Status: params.DetailedStatus{Status: "started", Kind: "juju-machine", Since: &earlier},
+		}, {
+			Tag:    "unit-mysql-0",
+			Status: params.DetailedStatus{Status: "active", Kind: "workload", Since: &earlier},
+		}, {
+			Tag:    "unit-mysql-0",
+			Status: params.DetailedStatus{Status: "blocked", Info: "no database", Kind: "workload", Since: &later},
+		}},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--format", "yaml", "--utc", "--at", "2016-03-01 14:00", "mysql", "0")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(client.historyTo, jc.DeepEquals, ptrTime(time.Date(2016, 3, 1, 14, 0, 0, 0, time.UTC)))
+	c.Check(client.historyEntities, jc.DeepEquals, []names.Tag{
+		names.NewApplicationTag("mysql"),
+		names.NewMachineTag("0"),
+	})
+	var result map[string]interface{}
+	err := goyaml.Unmarshal(stdout, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, map[string]interface{}{
+		"at": "2016-03-01 14:00:00Z",
+		"entities": []interface{}{
+			map[interface{}]interface{}{
+				"entity": "0",
+				"kind":   "juju-machine",
+				"status": "started",
+				"since":  "2016-03-01 13:00:00Z",
+			},
+			map[interface{}]interface{}{
+				"entity":  "mysql/0",
+				"kind":    "workload",
+				"status":  "blocked",
+				"message": "no database",
+				"since":   "2016-03-01 13:30:00Z",
+			},
+		},
+	})
+}
+
+func (s *StatusSuite) TestStatusAtInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--at", "yesterday"},
+		err:  `--at time "yesterday" \(expected YYYY-MM-DD \[HH:MM\[:SS\]\] or RFC3339\) not valid`,
+	}, {
+		args: []string{"--at", "2016-03-01", "nova-*"},
+		err:  `filter "nova-\*" with --at \(expected a machine, application or unit name\) not valid`,
+	}, {
+		args: []string{"--at", "2016-03-01", "--watch", "2s"},
+		err:  "--at and --watch cannot be specified together",
+	}, {
+		args: []string{"--at", "2016-03-01", "--format", "oneline"},
+		err:  `--at does not support the "oneline" format`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		code, _, stderr := runStatus(c, test.args...)
+		c.Check(code, gc.Equals, 2)
+		c.Check(string(stderr), gc.Matches, "error: "+test.err+"\n")
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+func (s *StatusSuite) TestStatusColumns(c *gc.C) {
+	client := fakeAPIClient{
+		statusReturn: &params.FullStatus{
+			Model: params.ModelStatusInfo{Name: "default", CloudTag: "cloud-dummy"},
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {
+							WorkloadStatus: params.DetailedStatus{Status: "active"},
+							AgentStatus:    params.DetailedStatus{Status: "idle"},
+							Machine:        "0",
+							PublicAddress:  "10.0.0.1",
+							Subordinates: map[string]params.UnitStatus{
+								"logging/0": {
+									WorkloadStatus: params.DetailedStatus{Status: "active"},
+									AgentStatus:    params.DetailedStatus{Status: "idle"},
+									PublicAddress:  "10.0.0.1",
+								},
+							},
+						},
+					},
+				},
+				"wordpress": {
+					Units: map[string]params.UnitStatus{
+						"wordpress/0": {
+							WorkloadStatus: params.DetailedStatus{Status: "blocked", Info: "needs a database"},
+							AgentStatus:    params.DetailedStatus{Status: "idle"},
+							Machine:        "1",
+							PublicAddress:  "10.0.0.2",
+						},
+					},
+				},
+			},
+		},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--columns", "unit,workload,machine,address", "mysql", "wordpress")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(client.patternsUsed, jc.DeepEquals, []string{"mysql", "wordpress"})
+	c.Check(client.fieldsUsed, jc.DeepEquals, []string{"applications"})
+	c.Check(string(stdout), gc.Equals, `
+Unit         Workload  Machine  Public address
+logging/0    active    0        10.0.0.1
+mysql/0      active    0        10.0.0.1
+wordpress/0  blocked   1        10.0.0.2
+`[1:])
+
+	code, stdout, stderr = runStatus(c, "--columns", "Unit, message")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(string(stdout), gc.Equals, `
+Unit         Message
+logging/0    
+mysql/0      
+wordpress/0  needs a database
+`[1:])
+}
+
+func (s *StatusSuite) TestStatusColumnsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--columns", "unit,charm"},
+		err:  `column "charm" \(expected one of address, agent, machine, message, ports, unit, workload\) not valid`,
+	}, {
+		args: []string{"--columns", "unit,,machine"},
+		err:  `column "" \(expected one of .*\) not valid`,
+	}, {
+		args: []string{"--columns", "unit,machine,unit"},
+		err:  `repeated column "unit" not valid`,
+	}, {
+		args: []string{"--columns", "unit", "--format", "yaml"},
+		err:  `--columns does not support the "yaml" format`,
+	}, {
+		args: []string{"--columns", "unit", "--at", "2016-03-01"},
+		err:  "--at and --columns cannot be specified together",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		code, _, stderr := runStatus(c, test.args...)
+		c.Check(code, gc.Equals, 2)
+		c.Check(string(stderr), gc.Matches, "error: "+test.err+"\n")
+	}
+}
+
 func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/output"
+)
+
+// statusAtTimeFormats holds the formats accepted by --at, in
+// addition to RFC3339.
+var statusAtTimeFormats = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseStatusAt parses the value of the --at flag. Times without
+// a time zone are taken to be local, or UTC if utc is true.
+func parseStatusAt(value string, utc bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	location := time.Local
+	if utc {
+		location = time.UTC
+	}
+	for _, format := range statusAtTimeFormats {
+		if t, err := time.ParseInLocation(format, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.NotValidf("--at time %q (expected YYYY-MM-DD [HH:MM[:SS]] or RFC3339)", value)
+}
+
+// statusAtEntities returns the tags of the machines, applications
+// and units named by the given patterns.
+func statusAtEntities(patterns []string) ([]names.Tag, error) {
+	var tags []names.Tag
+	for _, pattern := range patterns {
+		switch {
+		case names.IsValidMachine(pattern):
+			tags = append(tags, names.NewMachineTag(pattern))
+		case names.IsValidUnit(pattern):
+			tags = append(tags, names.NewUnitTag(pattern))
+		case names.IsValidApplication(pattern):
+			tags = append(tags, names.NewApplicationTag(pattern))
+		default:
+			return nil, errors.NotValidf("filter %q with --at (expected a machine, application or unit name)", pattern)
+		}
+	}
+	return tags, nil
+}
+
+// modelStatusHistoryAPI is implemented by API clients that can
+// query the status history of a whole model.
+type modelStatusHistoryAPI interface {
+	ModelStatusHistory(from, to *time.Time, entities []names.Tag, size int) ([]params.EntityStatusHistoryEntry, error)
+}
+
+// historicalStatus holds the status of the machines and units in
+// a model as recorded at a point in time.
+type historicalStatus struct {
+	At       string                   `json:"at" yaml:"at"`
+	Entities []historicalEntityStatus `json:"entities" yaml:"entities"`
+}
+
+type historicalEntityStatus struct {
+	Entity  string `json:"entity" yaml:"entity"`
+	Kind    string `json:"kind" yaml:"kind"`
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	Since   string `json:"since" yaml:"since"`
+}
+
+// runAt displays the status of each machine and unit as recorded
+// at the time given with --at.
+func (c *statusCommand) runAt(ctx *cmd.Context, apiclient statusAPI) error {
+	historyAPI, ok := apiclient.(modelStatusHistoryAPI)
+	if !ok {
+		return errors.NotSupportedf("status history")
+	}
+	entries, err := historyAPI.ModelStatusHistory(nil, &c.atTime, c.atEntities, 0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 {
+		return errors.Errorf("no status history recorded before %s", common.FormatTime(&c.atTime, c.isoTime))
+	}
+	return c.out.Write(ctx, c.historicalStatus(entries))
+}
+
+// historicalStatus returns the most recent status of each kind for
+// each entity in the given history, which is ordered oldest first.
+func (c *statusCommand) historicalStatus(entries []params.EntityStatusHistoryEntry) historicalStatus {
+	type entityKind struct {
+		tag, kind string
+	}
+	latest := make(map[entityKind]params.EntityStatusHistoryEntry)
+	for _, entry := range entries {
+		latest[entityKind{entry.Tag, entry.Status.Kind}] = entry
+	}
+	result := historicalStatus{
+		At: common.FormatTime(&c.atTime, c.isoTime),
+	}
+	for _, entry := range latest {
+		entity := entry.Tag
+		if tag, err := names.ParseTag(entry.Tag); err == nil {
+			entity = tag.Id()
+		}
+		var since string
+		if entry.Status.Since != nil {
+			since = common.FormatTime(entry.Status.Since, c.isoTime)
+		}
+		result.Entities = append(result.Entities, historicalEntityStatus{
+			Entity:  entity,
+			Kind:    entry.Status.Kind,
+			Status:  entry.Status.Status,
+			Message: entry.Status.Info,
+			Since:   since,
+		})
+	}
+	sort.Sort(byEntityAndKind(result.Entities))
+	return result
+}
+
+type byEntityAndKind []historicalEntityStatus
+
+func (s byEntityAndKind) Len() int {
+	return len(s)
+}
+
+func (s byEntityAndKind) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s byEntityAndKind) Less(i, j int) bool {
+	if s[i].Entity != s[j].Entity {
+		return s[i].Entity < s[j].Entity
+	}
+	return s[i].Kind < s[j].Kind
+}
+
+// formatHistoricalTabular writes the historical status in a
+// tabular format.
+func formatHistoricalTabular(writer io.Writer, hs historicalStatus) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Status at", hs.At)
+	w.Println()
+	w.Println("Entity", "Type", "Status", "Since", "Message")
+	for _, entity := range hs.Entities {
+		w.Println(entity.Entity, entity.Kind, entity.Status, entity.Since, entity.Message)
+	}
+	return tw.Flush()
+}
@@ -76,7 +76,7 @@ func (c *listCommand) generateListFilesystemsOutput(ctx *cmd.Context, api Storag
 	if err != nil {
 		return nil, err
 	}
-	switch c.out.Name() {
+	switch c.formatName() {
 	case "yaml", "json":
 		output = map[string]map[string]FilesystemInfo{"filesystems": info}
 	default:
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // NewListCommand returns a command for listing storage instances.
@@ -57,11 +58,11 @@ func (c *listCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.StorageCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": formatListTabular,
-	})
+	}))
 	f.BoolVar(&c.filesystem, "filesystem", false, "List filesystem storage")
 	f.BoolVar(&c.volume, "volume", false, "List volume storage")
 }
@@ -100,6 +101,12 @@ type StorageListAPI interface {
 	ListVolumes(machines []string) ([]params.VolumeDetailsListResult, error)
 }
 
+// formatName returns the name of the selected output format, without
+// any schema version.
+func (c *listCommand) formatName() string {
+	return output.BaseFormat(c.out.Name())
+}
+
 // generateListOutput returns a map of storage details
 func (c *listCommand) generateListOutput(ctx *cmd.Context, api StorageListAPI) (output interface{}, err error) {
 
@@ -114,7 +121,7 @@ func (c *listCommand) generateListOutput(ctx *cmd.Context, api StorageListAPI) (
 	if err != nil {
 		return nil, err
 	}
-	switch c.out.Name() {
+	switch c.formatName() {
 	case "yaml", "json":
 		output = map[string]map[string]StorageInfo{"storage": details}
 	default:
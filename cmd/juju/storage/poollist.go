@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // PoolCommandBase is a helper base structure for pool commands.
@@ -22,6 +23,54 @@ type PoolInfo struct {
 	Attrs    map[string]interface{} `yaml:"attrs,omitempty" json:"attrs,omitempty"`
 }
 
+// PoolUsageInfo defines the serialization behaviour of the storage pool
+// usage information.
+type PoolUsageInfo struct {
+	Provider     string                      `yaml:"provider" json:"provider"`
+	Total        StorageUsageInfo            `yaml:"total" json:"total"`
+	Applications map[string]StorageUsageInfo `yaml:"applications,omitempty" json:"applications,omitempty"`
+}
+
+// StorageUsageInfo defines the serialization behaviour of the number
+// and total size, in MiB, of volumes and filesystems using a pool.
+type StorageUsageInfo struct {
+	Provisioned     int    `yaml:"provisioned" json:"provisioned"`
+	ProvisionedSize uint64 `yaml:"provisioned-size" json:"provisioned-size"`
+	Attached        int    `yaml:"attached" json:"attached"`
+	AttachedSize    uint64 `yaml:"attached-size" json:"attached-size"`
+	Detached        int    `yaml:"detached" json:"detached"`
+	DetachedSize    uint64 `yaml:"detached-size" json:"detached-size"`
+}
+
+func formatPoolUsageInfo(all []params.StoragePoolUsage) map[string]PoolUsageInfo {
+	output := make(map[string]PoolUsageInfo)
+	for _, one := range all {
+		info := PoolUsageInfo{
+			Provider: one.Provider,
+			Total:    formatStorageUsage(one.Total),
+		}
+		if len(one.Applications) > 0 {
+			info.Applications = make(map[string]StorageUsageInfo)
+			for application, usage := range one.Applications {
+				info.Applications[application] = formatStorageUsage(usage)
+			}
+		}
+		output[one.Name] = info
+	}
+	return output
+}
+
+func formatStorageUsage(usage params.StorageUsage) StorageUsageInfo {
+	return StorageUsageInfo{
+		Provisioned:     usage.Provisioned,
+		ProvisionedSize: usage.ProvisionedSize,
+		Attached:        usage.Attached,
+		AttachedSize:    usage.AttachedSize,
+		Detached:        usage.Detached,
+		DetachedSize:    usage.DetachedSize,
+	}
+}
+
 func formatPoolInfo(all []params.StoragePool) map[string]PoolInfo {
 	output := make(map[string]PoolInfo)
 	for _, one := range all {
@@ -44,6 +93,15 @@ If only types are specified, all pools of the specified types will be listed.
 
 Both pool types and names must be valid.
 Valid pool types are pool types that are registered for Juju model.
+
+With --usage, the number and total size of the provisioned volumes and
+filesystems in each pool are shown, in total and for each application,
+split by whether they are attached to machines. Detached storage is
+still provisioned, and may still be costing money.
+
+Examples:
+    juju storage-pools --usage
+    juju storage-pools --usage --provider ebs
 `
 
 // NewPoolListCommand returns a command that lists storage pools on a model
@@ -61,6 +119,7 @@ type poolListCommand struct {
 	newAPIFunc func() (PoolListAPI, error)
 	Providers  []string
 	Names      []string
+	Usage      bool
 	out        cmd.Output
 }
 
@@ -84,12 +143,13 @@ func (c *poolListCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.StorageCommandBase.SetFlags(f)
 	f.Var(cmd.NewAppendStringsValue(&c.Providers), "provider", "Only show pools of these provider types")
 	f.Var(cmd.NewAppendStringsValue(&c.Names), "name", "Only show pools with these names")
+	f.BoolVar(&c.Usage, "usage", false, "Show the storage consumption of pools")
 
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": formatPoolListTabular,
-	})
+	}))
 }
 
 // Run implements Command.Run.
@@ -99,6 +159,9 @@ func (c *poolListCommand) Run(ctx *cmd.Context) (err error) {
 		return err
 	}
 	defer api.Close()
+	if c.Usage {
+		return c.runUsage(ctx, api)
+	}
 	result, err := api.ListPools(c.Providers, c.Names)
 	if err != nil {
 		return err
@@ -111,8 +174,22 @@ func (c *poolListCommand) Run(ctx *cmd.Context) (err error) {
 	return c.out.Write(ctx, output)
 }
 
+func (c *poolListCommand) runUsage(ctx *cmd.Context, api PoolListAPI) error {
+	result, err := api.ListPoolUsage(c.Providers, c.Names)
+	if err != nil {
+		return err
+	}
+	if len(result) == 0 {
+		ctx.Infof("No storage pools to display.")
+		return nil
+	}
+	output := formatPoolUsageInfo(result)
+	return c.out.Write(ctx, output)
+}
+
 // PoolListAPI defines the API methods that the storage commands use.
 type PoolListAPI interface {
 	Close() error
 	ListPools(providers, names []string) ([]params.StoragePool, error)
+	ListPoolUsage(providers, names []string) ([]params.StoragePoolUsage, error)
 }
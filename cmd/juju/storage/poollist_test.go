@@ -110,6 +110,54 @@ xyz   testType  a=true b=maybe c=well
 `[1:])
 }
 
+func (s *poolListSuite) TestPoolListUsageTabular(c *gc.C) {
+	s.assertValidList(
+		c,
+		[]string{"--usage", "--name", "fast", "--format", "tabular"},
+		`
+Name  Provider  Application  Provisioned  Attached     Detached
+fast  testType               3 (2.5 GiB)  2 (2.0 GiB)  1 (512 MiB)
+                mysql        2 (2.0 GiB)  2 (2.0 GiB)  0
+                wordpress    1 (512 MiB)  0            1 (512 MiB)
+
+`[1:])
+}
+
+func (s *poolListSuite) TestPoolListUsageYAML(c *gc.C) {
+	context, err := s.runPoolList(c, []string{"--usage", "--name", "fast", "--format", "yaml"})
+	c.Assert(err, jc.ErrorIsNil)
+	var result map[string]storage.PoolUsageInfo
+	err = goyaml.Unmarshal(context.Stdout.(*bytes.Buffer).Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, map[string]storage.PoolUsageInfo{
+		"fast": {
+			Provider: "testType",
+			Total: storage.StorageUsageInfo{
+				Provisioned:     3,
+				ProvisionedSize: 2560,
+				Attached:        2,
+				AttachedSize:    2048,
+				Detached:        1,
+				DetachedSize:    512,
+			},
+			Applications: map[string]storage.StorageUsageInfo{
+				"mysql": {
+					Provisioned:     2,
+					ProvisionedSize: 2048,
+					Attached:        2,
+					AttachedSize:    2048,
+				},
+				"wordpress": {
+					Provisioned:     1,
+					ProvisionedSize: 512,
+					Detached:        1,
+					DetachedSize:    512,
+				},
+			},
+		},
+	})
+}
+
 type unmarshaller func(in []byte, out interface{}) (err error)
 
 func (s *poolListSuite) assertUnmarshalledOutput(c *gc.C, unmarshall unmarshaller, args ...string) {
@@ -192,6 +240,39 @@ func (s mockPoolListAPI) ListPools(types []string, names []string) ([]params.Sto
 	return results, nil
 }
 
+func (s mockPoolListAPI) ListPoolUsage(types []string, names []string) ([]params.StoragePoolUsage, error) {
+	results := make([]params.StoragePoolUsage, len(names))
+	for i, aname := range names {
+		results[i] = params.StoragePoolUsage{
+			Name:     aname,
+			Provider: "testType",
+			Total: params.StorageUsage{
+				Provisioned:     3,
+				ProvisionedSize: 2560,
+				Attached:        2,
+				AttachedSize:    2048,
+				Detached:        1,
+				DetachedSize:    512,
+			},
+			Applications: map[string]params.StorageUsage{
+				"mysql": {
+					Provisioned:     2,
+					ProvisionedSize: 2048,
+					Attached:        2,
+					AttachedSize:    2048,
+				},
+				"wordpress": {
+					Provisioned:     1,
+					ProvisionedSize: 512,
+					Detached:        1,
+					DetachedSize:    512,
+				},
+			},
+		}
+	}
+	return results, nil
+}
+
 func (s mockPoolListAPI) createTestPoolInstance(aname, atype string) params.StoragePool {
 	return params.StoragePool{
 		Name:     aname,
@@ -9,18 +9,24 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
+
 	"github.com/juju/juju/cmd/output"
 )
 
 // formatPoolListTabular returns a tabular summary of pool instances or
-// errors out if parameter is not a map of PoolInfo.
+// their usage, or errors out if parameter is not a map of PoolInfo or
+// PoolUsageInfo.
 func formatPoolListTabular(writer io.Writer, value interface{}) error {
-	pools, ok := value.(map[string]PoolInfo)
-	if !ok {
-		return errors.Errorf("expected value of type %T, got %T", pools, value)
+	switch pools := value.(type) {
+	case map[string]PoolInfo:
+		formatPoolsTabular(writer, pools)
+	case map[string]PoolUsageInfo:
+		formatPoolUsageTabular(writer, pools)
+	default:
+		return errors.Errorf("expected value of type %T, got %T", map[string]PoolInfo{}, value)
 	}
-	formatPoolsTabular(writer, pools)
 	return nil
 }
 
@@ -54,3 +60,48 @@ func formatPoolsTabular(writer io.Writer, pools map[string]PoolInfo) {
 	}
 	tw.Flush()
 }
+
+// formatPoolUsageTabular returns a tabular summary of pool usage, with
+// a row for each pool followed by a row for each application using it.
+func formatPoolUsageTabular(writer io.Writer, pools map[string]PoolUsageInfo) {
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	printUsage := func(name, provider, application string, usage StorageUsageInfo) {
+		print(
+			name, provider, application,
+			formatStorageCount(usage.Provisioned, usage.ProvisionedSize),
+			formatStorageCount(usage.Attached, usage.AttachedSize),
+			formatStorageCount(usage.Detached, usage.DetachedSize),
+		)
+	}
+
+	print("Name", "Provider", "Application", "Provisioned", "Attached", "Detached")
+
+	poolNames := make([]string, 0, len(pools))
+	for name := range pools {
+		poolNames = append(poolNames, name)
+	}
+	sort.Strings(poolNames)
+	for _, name := range poolNames {
+		pool := pools[name]
+		printUsage(name, pool.Provider, "", pool.Total)
+		applications := make([]string, 0, len(pool.Applications))
+		for application := range pool.Applications {
+			applications = append(applications, application)
+		}
+		sort.Strings(applications)
+		for _, application := range applications {
+			printUsage("", "", application, pool.Applications[application])
+		}
+	}
+	tw.Flush()
+}
+
+func formatStorageCount(count int, size uint64) string {
+	if count == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%d (%s)", count, humanize.IBytes(size*humanize.MiByte))
+}
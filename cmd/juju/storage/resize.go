@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeCommand returns a command used to grow storage.
+func NewResizeCommand() cmd.Command {
+	cmd := &resizeCommand{}
+	cmd.newAPIFunc = func() (StorageResizeAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const resizeCommandDoc = `
+Grows block storage to the specified size, while it remains attached.
+
+SIZE is a floating point number and multiplier from the set
+(M, G, T, P, E, Z, Y), which are all treated as powers of 1024.
+Only block-kind storage backed by a volume whose provider supports
+resizing (e.g. EBS, Cinder) can be resized, and storage can only
+be grown. Once the volume has been grown, the charm is notified
+with a storage-resized hook so that it can make use of the new
+space, e.g. by growing a filesystem.
+
+Examples:
+    # Grow the storage instance "data/0" to 100GiB:
+
+      juju resize-storage data/0 100G
+`
+
+// resizeCommand grows storage instances.
+type resizeCommand struct {
+	StorageCommandBase
+	storageTag names.StorageTag
+	size       uint64
+	newAPIFunc func() (StorageResizeAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *resizeCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	c.storageTag = names.NewStorageTag(args[0])
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows block storage.",
+		Doc:     resizeCommandDoc,
+		Args:    "<storage ID> <size>",
+	}
+}
+
+// Run implements Command.Run.
+func (c *resizeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ResizeStorage([]params.StorageResizeParams{{
+		StorageTag: c.storageTag.String(),
+		Size:       c.size,
+	}})
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	return nil
+}
+
+// StorageResizeAPI defines the API methods that the resize-storage
+// command uses.
+type StorageResizeAPI interface {
+	Close() error
+	ResizeStorage(storages []params.StorageResizeParams) ([]params.ErrorResult, error)
+}
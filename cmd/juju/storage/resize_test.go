@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/testing"
+)
+
+type resizeSuite struct {
+	SubStorageSuite
+	mockAPI *mockResizeAPI
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockResizeAPI{
+		resizeStorageFunc: func(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+			return make([]params.ErrorResult, len(storages)), nil
+		},
+	}
+}
+
+func (s *resizeSuite) runResize(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewResizeCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *resizeSuite) TestResize(c *gc.C) {
+	var resized []params.StorageResizeParams
+	s.mockAPI.resizeStorageFunc = func(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+		resized = storages
+		return make([]params.ErrorResult, len(storages)), nil
+	}
+	_, err := s.runResize(c, "data/0", "100G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resized, jc.DeepEquals, []params.StorageResizeParams{{
+		StorageTag: "storage-data-0",
+		Size:       100 * 1024,
+	}})
+}
+
+func (s *resizeSuite) TestResizeArgs(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"data/0"},
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"data", "100G"},
+		err:  `storage ID "data" not valid`,
+	}, {
+		args: []string{"data/0", "lots"},
+		err:  `cannot parse size: .*`,
+	}} {
+		c.Logf("test %d: %q", i, t.args)
+		_, err := s.runResize(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *resizeSuite) TestResizeError(c *gc.C) {
+	s.mockAPI.resizeStorageFunc = func(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+		return []params.ErrorResult{{
+			Error: &params.Error{Message: "resizing filesystem storage not supported"},
+		}}, nil
+	}
+	_, err := s.runResize(c, "data/0", "100G")
+	c.Assert(err, gc.ErrorMatches, "resizing filesystem storage not supported")
+}
+
+func (s *resizeSuite) TestResizeAPIError(c *gc.C) {
+	s.mockAPI.resizeStorageFunc = func(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+		return nil, errors.New("aborted")
+	}
+	_, err := s.runResize(c, "data/0", "100G")
+	c.Assert(err, gc.ErrorMatches, "aborted")
+}
+
+func (s *resizeSuite) TestUnauthorizedMentionsJujuGrant(c *gc.C) {
+	s.mockAPI.resizeStorageFunc = func(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+		return nil, &params.Error{
+			Message: "permission denied",
+			Code:    params.CodeUnauthorized,
+		}
+	}
+	ctx, _ := s.runResize(c, "data/0", "100G")
+	errString := strings.Replace(testing.Stderr(ctx), "\n", " ", -1)
+	c.Assert(errString, gc.Matches, `.*juju grant.*`)
+}
+
+type mockResizeAPI struct {
+	resizeStorageFunc func(storages []params.StorageResizeParams) ([]params.ErrorResult, error)
+}
+
+func (s mockResizeAPI) Close() error {
+	return nil
+}
+
+func (s mockResizeAPI) ResizeStorage(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+	return s.resizeStorageFunc(storages)
+}
@@ -83,7 +83,7 @@ func (c *listCommand) generateListVolumeOutput(ctx *cmd.Context, api StorageList
 	if err != nil {
 		return nil, err
 	}
-	switch c.out.Name() {
+	switch c.formatName() {
 	case "yaml", "json":
 		output = map[string]map[string]VolumeInfo{"volumes": info}
 	default:
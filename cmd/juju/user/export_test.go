@@ -126,3 +126,19 @@ func NewWhoAmICommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c := &whoAmICommand{store: store}
 	return c
 }
+
+// NewShowUserQuotaCommandForTest returns a user-quota command with
+// the api provided as specified.
+func NewShowUserQuotaCommandForTest(api UserQuotaAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &showUserQuotaCommand{userQuotaCommandBase: userQuotaCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewSetUserQuotaCommandForTest returns a set-user-quota command with
+// the api provided as specified.
+func NewSetUserQuotaCommandForTest(api UserQuotaAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &setUserQuotaCommand{userQuotaCommandBase: userQuotaCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/usermanager"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -22,9 +24,14 @@ After login, a token ("macaroon") will become active. It has an expiration
 time of 24 hours. Upon expiration, no further Juju commands can be issued
 and the user will be prompted to log in again.
 
+If the controller cannot be reached, --debug-connection shows each
+controller address that was tried, how long the attempt took and why it
+failed, distinguishing DNS, TLS and other connection failures.
+
 Examples:
 
     juju login bob
+    juju login bob --debug-connection
 
 See also:
     disable-user
@@ -51,6 +58,11 @@ type loginCommand struct {
 	modelcmd.ControllerCommandBase
 	newLoginAPI func(juju.NewAPIConnectionParams) (LoginAPI, ConnectionAPI, error)
 	User        string
+
+	// debugConnection holds whether to report the attempts made
+	// to connect to the controller.
+	debugConnection bool
+	diagnostics     *api.DialDiagnostics
 }
 
 // Info implements Command.Info.
@@ -63,6 +75,12 @@ func (c *loginCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *loginCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.debugConnection, "debug-connection", false, "Show each attempt made to connect to the controller")
+}
+
 // Init implements Command.Init.
 func (c *loginCommand) Init(args []string) error {
 	var err error
@@ -86,6 +104,37 @@ type ConnectionAPI interface {
 
 // Run implements Command.Run.
 func (c *loginCommand) Run(ctx *cmd.Context) error {
+	if !c.debugConnection {
+		return c.run(ctx)
+	}
+	c.diagnostics = &api.DialDiagnostics{}
+	err := c.run(ctx)
+	if _, ok := errors.Cause(err).(*api.DialError); !ok {
+		// A DialError already describes the attempts.
+		ctx.Infof("connection attempts:")
+		for _, attempt := range c.diagnostics.Attempts() {
+			ctx.Infof("  %s", attempt)
+		}
+	}
+	return err
+}
+
+// newAPIConnectionParams returns the parameters for connecting to the
+// named controller with the given account details.
+func (c *loginCommand) newAPIConnectionParams(
+	store jujuclient.ClientStore,
+	controllerName string,
+	accountDetails *jujuclient.AccountDetails,
+) (juju.NewAPIConnectionParams, error) {
+	args, err := c.NewAPIConnectionParams(store, controllerName, "", accountDetails)
+	if err != nil {
+		return juju.NewAPIConnectionParams{}, errors.Trace(err)
+	}
+	args.DialOpts.Diagnostics = c.diagnostics
+	return args, nil
+}
+
+func (c *loginCommand) run(ctx *cmd.Context) error {
 	controllerName := c.ControllerName()
 	store := c.ClientStore()
 	accountDetails, err := store.AccountDetails(controllerName)
@@ -98,8 +147,8 @@ func (c *loginCommand) Run(ctx *cmd.Context) error {
 		// The username has not been specified, and there
 		// is no current account. See if the user can log
 		// in with macaroons.
-		args, err := c.NewAPIConnectionParams(
-			store, controllerName, "",
+		args, err := c.newAPIConnectionParams(
+			store, controllerName,
 			&jujuclient.AccountDetails{},
 		)
 		if err != nil {
@@ -153,7 +202,7 @@ Run "juju logout" first before attempting to log in as a different user.
 	accountDetails = &jujuclient.AccountDetails{
 		User: userTag.Id(),
 	}
-	params, err := c.NewAPIConnectionParams(store, controllerName, "", accountDetails)
+	params, err := c.newAPIConnectionParams(store, controllerName, accountDetails)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -7,10 +7,12 @@ import (
 	"strings"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/user"
 	"github.com/juju/juju/juju"
@@ -103,6 +105,25 @@ username: You are now logged in to "testing" as "current-user".
 	})
 }
 
+func (s *LoginCommandSuite) TestLoginDebugConnection(c *gc.C) {
+	context, args, err := s.run(c, "", "current-user", "--debug-connection")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args.DialOpts.Diagnostics, gc.NotNil)
+	c.Assert(coretesting.Stderr(context), gc.Equals, `
+You are now logged in to "testing" as "current-user".
+connection attempts:
+`[1:],
+	)
+}
+
+func (s *LoginCommandSuite) TestLoginDebugConnectionDialError(c *gc.C) {
+	s.loginErr = &api.DialError{Err: errors.New("unable to connect to API: nope")}
+	context, _, err := s.run(c, "", "current-user", "--debug-connection")
+	c.Assert(err, gc.ErrorMatches, `creating API connection: unable to connect to API: nope
+connection attempts:`)
+	c.Assert(coretesting.Stderr(context), gc.Equals, "")
+}
+
 func (s *LoginCommandSuite) TestLoginNewUser(c *gc.C) {
 	err := s.store.RemoveAccount("testing")
 	c.Assert(err, jc.ErrorIsNil)
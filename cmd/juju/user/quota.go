@@ -0,0 +1,251 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/environs/config"
+)
+
+var usageShowUserQuotaSummary = `
+Shows the quota on the models a user owns.`[1:]
+
+var usageShowUserQuotaDetails = `
+A user's quota limits the total machines, units and storage instances
+in all the models the user owns. The number of each counted against
+the quota is shown alongside the limit; a limit that is not shown is
+not set. Controller administrators may see any user's quota; other
+users may only see their own.
+
+Models may also have their own limits, set with the max-machines,
+max-units and max-storage model config keys.
+
+Examples:
+    juju user-quota bob
+    juju user-quota bob --format json
+
+See also:
+    set-user-quota
+    show-user`[1:]
+
+var usageSetUserQuotaSummary = `
+Sets the quota on the models a user owns.`[1:]
+
+var usageSetUserQuotaDetails = `
+A user's quota limits the total machines, units and storage instances
+in all the models the user owns. Each of max-machines, max-units and
+max-storage may be set; a limit of 0 removes it, and limits that are
+not specified are left unchanged. Machines, units and storage that
+already exceed a new limit are not removed, but no more may be added.
+
+Only controller administrators may set quotas.
+
+Examples:
+    juju set-user-quota bob max-machines=10 max-units=20
+    juju set-user-quota bob max-storage=0
+
+See also:
+    user-quota
+    model-config`[1:]
+
+// UserQuotaAPI defines the API methods that the user quota commands
+// use.
+type UserQuotaAPI interface {
+	UserQuota(username string) (params.UserQuota, params.QuotaUsage, error)
+	SetUserQuota(params.UserQuota) error
+	Close() error
+}
+
+// userQuotaCommandBase holds the API access shared by the user quota
+// commands.
+type userQuotaCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api  UserQuotaAPI
+	User string
+}
+
+func (c *userQuotaCommandBase) getAPI() (UserQuotaAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewUserManagerAPIClient()
+}
+
+func (c *userQuotaCommandBase) initUser(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no username supplied")
+	}
+	c.User = args[0]
+	if !names.IsValidUser(c.User) {
+		return nil, errors.Errorf("%q is not a valid username", c.User)
+	}
+	return args[1:], nil
+}
+
+// NewShowUserQuotaCommand returns a command that shows a user's
+// quota.
+func NewShowUserQuotaCommand() cmd.Command {
+	return modelcmd.WrapController(&showUserQuotaCommand{})
+}
+
+type showUserQuotaCommand struct {
+	userQuotaCommandBase
+	out cmd.Output
+}
+
+// quotaDetails holds a quota limit and the usage counted against it,
+// for output.
+type quotaDetails struct {
+	Limit int `yaml:"limit,omitempty" json:"limit,omitempty"`
+	Used  int `yaml:"used" json:"used"`
+}
+
+// userQuotaDetails holds a user's quota for output.
+type userQuotaDetails struct {
+	Machines quotaDetails `yaml:"machines" json:"machines"`
+	Units    quotaDetails `yaml:"units" json:"units"`
+	Storage  quotaDetails `yaml:"storage" json:"storage"`
+}
+
+// Info implements Command.Info.
+func (c *showUserQuotaCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "user-quota",
+		Args:    "<user name>",
+		Purpose: usageShowUserQuotaSummary,
+		Doc:     usageShowUserQuotaDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showUserQuotaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.Versioned(cmd.DefaultFormatters))
+}
+
+// Init implements Command.Init.
+func (c *showUserQuotaCommand) Init(args []string) error {
+	args, err := c.initUser(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *showUserQuotaCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	quota, usage, err := client.UserQuota(c.User)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, userQuotaDetails{
+		Machines: quotaDetails{Limit: quota.MaxMachines, Used: usage.Machines},
+		Units:    quotaDetails{Limit: quota.MaxUnits, Used: usage.Units},
+		Storage:  quotaDetails{Limit: quota.MaxStorage, Used: usage.Storage},
+	})
+}
+
+// NewSetUserQuotaCommand returns a command that sets a user's quota.
+func NewSetUserQuotaCommand() cmd.Command {
+	return modelcmd.WrapController(&setUserQuotaCommand{})
+}
+
+type setUserQuotaCommand struct {
+	userQuotaCommandBase
+	Limits map[string]int
+}
+
+// Info implements Command.Info.
+func (c *setUserQuotaCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-user-quota",
+		Args:    "<user name> <quota>=<limit> ...",
+		Purpose: usageSetUserQuotaSummary,
+		Doc:     usageSetUserQuotaDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *setUserQuotaCommand) Init(args []string) error {
+	args, err := c.initUser(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(args) == 0 {
+		return errors.New("no quotas specified")
+	}
+	options, err := keyvalues.Parse(args, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.Limits = make(map[string]int)
+	for key, value := range options {
+		if !isQuotaKey(key) {
+			return errors.Errorf("unknown quota %q, expected one of %s", key, strings.Join(config.QuotaKeys, ", "))
+		}
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return errors.Errorf("%s must be a non-negative integer, got %q", key, value)
+		}
+		c.Limits[key] = limit
+	}
+	return nil
+}
+
+func isQuotaKey(key string) bool {
+	for _, quotaKey := range config.QuotaKeys {
+		if key == quotaKey {
+			return true
+		}
+	}
+	return false
+}
+
+// Run implements Command.Run.
+func (c *setUserQuotaCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	// Limits that are not specified are left unchanged.
+	quota, _, err := client.UserQuota(c.User)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for key, limit := range c.Limits {
+		switch key {
+		case config.MaxMachinesKey:
+			quota.MaxMachines = limit
+		case config.MaxUnitsKey:
+			quota.MaxUnits = limit
+		case config.MaxStorageKey:
+			quota.MaxStorage = limit
+		}
+	}
+	quota.UserTag = names.NewUserTag(c.User).String()
+	if err := client.SetUserQuota(quota); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
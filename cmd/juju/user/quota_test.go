@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/testing"
+)
+
+type UserQuotaSuite struct {
+	BaseSuite
+	api *fakeUserQuotaAPI
+}
+
+var _ = gc.Suite(&UserQuotaSuite{})
+
+func (s *UserQuotaSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.api = &fakeUserQuotaAPI{
+		quota: params.UserQuota{UserTag: "user-bob", MaxMachines: 10},
+		usage: params.QuotaUsage{Machines: 3, Units: 5},
+	}
+}
+
+func (s *UserQuotaSuite) TestShow(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewShowUserQuotaCommandForTest(s.api, s.store), "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"machines:\n"+
+		"  limit: 10\n"+
+		"  used: 3\n"+
+		"units:\n"+
+		"  used: 5\n"+
+		"storage:\n"+
+		"  used: 0\n")
+	s.api.CheckCall(c, 0, "UserQuota", "bob")
+}
+
+func (s *UserQuotaSuite) TestShowInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no username supplied",
+	}, {
+		args: []string{"not!good"},
+		err:  `"not!good" is not a valid username`,
+	}, {
+		args: []string{"bob", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := testing.RunCommand(c, user.NewShowUserQuotaCommandForTest(s.api, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *UserQuotaSuite) TestSet(c *gc.C) {
+	_, err := testing.RunCommand(c, user.NewSetUserQuotaCommandForTest(s.api, s.store),
+		"bob", "max-units=20", "max-machines=0",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "UserQuota", "SetUserQuota", "Close")
+	s.api.CheckCall(c, 1, "SetUserQuota", params.UserQuota{
+		UserTag:  "user-bob",
+		MaxUnits: 20,
+	})
+}
+
+func (s *UserQuotaSuite) TestSetInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no username supplied",
+	}, {
+		args: []string{"bob"},
+		err:  "no quotas specified",
+	}, {
+		args: []string{"bob", "max-cores=4"},
+		err:  `unknown quota "max-cores", expected one of max-machines, max-units, max-storage`,
+	}, {
+		args: []string{"bob", "max-units=-1"},
+		err:  `max-units must be a non-negative integer, got "-1"`,
+	}, {
+		args: []string{"bob", "max-units"},
+		err:  `expected "key=value", got "max-units"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := testing.RunCommand(c, user.NewSetUserQuotaCommandForTest(s.api, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *UserQuotaSuite) TestSetError(c *gc.C) {
+	s.api.SetErrors(nil, &params.Error{Message: "permission denied", Code: params.CodeUnauthorized})
+	_, err := testing.RunCommand(c, user.NewSetUserQuotaCommandForTest(s.api, s.store), "bob", "max-units=1")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeUserQuotaAPI struct {
+	gitjujutesting.Stub
+	quota params.UserQuota
+	usage params.QuotaUsage
+}
+
+func (f *fakeUserQuotaAPI) UserQuota(username string) (params.UserQuota, params.QuotaUsage, error) {
+	f.MethodCall(f, "UserQuota", username)
+	return f.quota, f.usage, f.NextErr()
+}
+
+func (f *fakeUserQuotaAPI) SetUserQuota(quota params.UserQuota) error {
+	f.MethodCall(f, "SetUserQuota", quota)
+	return f.NextErr()
+}
+
+func (f *fakeUserQuotaAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
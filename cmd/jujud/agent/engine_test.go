@@ -38,6 +38,7 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"charm-revision-updater",
+		"charm-rollback",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
@@ -81,6 +82,7 @@ var (
 	notMigratingUnitWorkers = []string{
 		"api-address-updater",
 		"charm-dir",
+		"engine-reporter",
 		"hook-retry-strategy",
 		"leadership-tracker",
 		"logging-config-updater",
@@ -96,6 +98,7 @@ var (
 		"agent",
 		"api-caller",
 		"api-config-watcher",
+		"audit-config-updater",
 		"log-forwarder",
 		"migration-fortress",
 		"migration-inactive-flag",
@@ -111,6 +114,7 @@ var (
 	notMigratingMachineWorkers = []string{
 		"api-address-updater",
 		"disk-manager",
+		"engine-reporter",
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
 		"logging-config-updater",
@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"fmt"
 	"os"
 	"runtime"
 
@@ -49,9 +50,10 @@ func startIntrospection(cfg introspectionConfig) error {
 
 	socketName := cfg.NewSocketName(cfg.Agent.CurrentConfig().Tag())
 	w, err := cfg.WorkerFunc(introspection.Config{
-		SocketName:         socketName,
-		Reporter:           cfg.Engine,
-		PrometheusGatherer: cfg.PrometheusGatherer,
+		SocketName:          socketName,
+		Reporter:            cfg.Engine,
+		AgentConfigReporter: agentConfigReporter{cfg.Agent},
+		PrometheusGatherer:  cfg.PrometheusGatherer,
 	})
 	if err != nil {
 		return errors.Trace(err)
@@ -67,6 +69,52 @@ func startIntrospection(cfg introspectionConfig) error {
 	return nil
 }
 
+// agentConfigReporter implements introspection.AgentConfigReporter,
+// reporting the parts of the agent's current configuration that are
+// useful when debugging. Passwords, keys and certificates are
+// deliberately omitted.
+type agentConfigReporter struct {
+	agent agent.Agent
+}
+
+// Report is part of the introspection.AgentConfigReporter interface.
+func (r agentConfigReporter) Report() map[string]interface{} {
+	config := r.agent.CurrentConfig()
+	report := map[string]interface{}{
+		"tag":                 config.Tag().String(),
+		"data-dir":            config.DataDir(),
+		"log-dir":             config.LogDir(),
+		"model":               config.Model().Id(),
+		"controller":          config.Controller().Id(),
+		"upgraded-to-version": config.UpgradedToVersion().String(),
+	}
+	if addrs, err := config.APIAddresses(); err == nil {
+		report["api-addresses"] = addrs
+	} else {
+		report["api-addresses"] = fmt.Sprintf("error: %v", err)
+	}
+	if jobs := config.Jobs(); len(jobs) > 0 {
+		report["jobs"] = jobs
+	}
+	values := make(map[string]string)
+	for _, key := range []string{
+		agent.ProviderType,
+		agent.ContainerType,
+		agent.Namespace,
+		agent.AgentServiceName,
+		agent.LxcBridge,
+		agent.LxdBridge,
+	} {
+		if value := config.Value(key); value != "" {
+			values[key] = value
+		}
+	}
+	if len(values) > 0 {
+		report["values"] = values
+	}
+	return report
+}
+
 // newPrometheusRegistry returns a new prometheus.Registry with
 // the Go and process metric collectors registered. This registry
 // is exposed by the introspection abstract domain socket on all
@@ -10,11 +10,13 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/state/multiwatcher"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
@@ -91,6 +93,7 @@ func (s *introspectionSuite) TestStartSuccess(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(fake.config.Reporter, gc.Equals, engine)
+	c.Check(fake.config.AgentConfigReporter, gc.NotNil)
 	c.Check(fake.config.SocketName, gc.Equals, "bananas")
 
 	// Stopping the engine causes the introspection worker to stop.
@@ -108,6 +111,23 @@ func (s *introspectionSuite) TestDefaultIntrospectionSocketName(c *gc.C) {
 	c.Assert(name, gc.Equals, "jujud-machine-42")
 }
 
+func (s *introspectionSuite) TestAgentConfigReport(c *gc.C) {
+	reporter := agentConfigReporter{&dummyAgent{}}
+	c.Assert(reporter.Report(), jc.DeepEquals, map[string]interface{}{
+		"tag":                 "machine-42",
+		"data-dir":            "/var/lib/juju",
+		"log-dir":             "/var/log/juju",
+		"model":               coretesting.ModelTag.Id(),
+		"controller":          coretesting.ControllerTag.Id(),
+		"upgraded-to-version": "2.0.0",
+		"api-addresses":       []string{"10.0.0.1:17070"},
+		"jobs":                []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		"values": map[string]string{
+			agent.ProviderType: "dummy",
+		},
+	})
+}
+
 type dummyAgent struct {
 	agent.Agent
 }
@@ -124,6 +144,41 @@ func (*dummyConfig) Tag() names.Tag {
 	return names.NewMachineTag("42")
 }
 
+func (*dummyConfig) DataDir() string {
+	return "/var/lib/juju"
+}
+
+func (*dummyConfig) LogDir() string {
+	return "/var/log/juju"
+}
+
+func (*dummyConfig) Model() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (*dummyConfig) Controller() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (*dummyConfig) UpgradedToVersion() version.Number {
+	return version.MustParse("2.0.0")
+}
+
+func (*dummyConfig) APIAddresses() ([]string, error) {
+	return []string{"10.0.0.1:17070"}, nil
+}
+
+func (*dummyConfig) Jobs() []multiwatcher.MachineJob {
+	return []multiwatcher.MachineJob{multiwatcher.JobHostUnits}
+}
+
+func (*dummyConfig) Value(key string) string {
+	if key == agent.ProviderType {
+		return "dummy"
+	}
+	return ""
+}
+
 type dummyWorker struct {
 	config introspection.Config
 	done   chan struct{}
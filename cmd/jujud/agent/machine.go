@@ -56,9 +56,9 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
-	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	jujunames "github.com/juju/juju/juju/names"
 	"github.com/juju/juju/juju/paths"
@@ -78,6 +78,7 @@ import (
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/certupdater"
+	"github.com/juju/juju/worker/controllerreplacer"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/dependency"
@@ -91,6 +92,7 @@ import (
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/mongoupgrader"
 	"github.com/juju/juju/worker/peergrouper"
+	"github.com/juju/juju/worker/presencepruner"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/txnpruner"
@@ -99,9 +101,10 @@ import (
 )
 
 var (
-	logger       = loggo.GetLogger("juju.cmd.jujud")
-	jujuRun      = paths.MustSucceed(paths.JujuRun(series.MustHostSeries()))
-	jujuDumpLogs = paths.MustSucceed(paths.JujuDumpLogs(series.MustHostSeries()))
+	logger           = loggo.GetLogger("juju.cmd.jujud")
+	jujuRun          = paths.MustSucceed(paths.JujuRun(series.MustHostSeries()))
+	jujuDumpLogs     = paths.MustSucceed(paths.JujuDumpLogs(series.MustHostSeries()))
+	jujuMigrateBlobs = paths.MustSucceed(paths.JujuMigrateBlobs(series.MustHostSeries()))
 
 	// The following are defined as variables to allow the tests to
 	// intercept calls to the functions. In every case, they should
@@ -344,6 +347,9 @@ type MachineAgent struct {
 	mongoInitMutex   sync.Mutex
 	mongoInitialized bool
 
+	auditingMutex   sync.Mutex
+	auditingEnabled bool
+
 	loopDeviceManager          looputil.LoopDeviceManager
 	newIntrospectionSocketName func(names.Tag) string
 	prometheusRegistry         *prometheus.Registry
@@ -523,6 +529,8 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			ValidateMigration:    a.validateMigration,
 			PrometheusRegisterer: a.prometheusRegistry,
 			CentralHub:           a.centralHub,
+			EngineReporter:       engine,
+			SetAuditingEnabled:   a.setAuditingEnabled,
 		})
 		if err := dependency.Install(engine, manifolds); err != nil {
 			if err := worker.Stop(engine); err != nil {
@@ -1051,7 +1059,23 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			})
 
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
-				return txnpruner.New(st, time.Hour*2, clock.WallClock), nil
+				return newTxnPrunerWorker(st, a.prometheusRegistry)
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "presencepruner", func() (worker.Worker, error) {
+				return presencepruner.New(presencepruner.Config{
+					Backend:  presencepruner.NewStateBackend(st),
+					Clock:    clock.WallClock,
+					Interval: presencepruner.DefaultInterval,
+				})
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "controllerreplacer", func() (worker.Worker, error) {
+				return controllerreplacer.New(controllerreplacer.Config{
+					Backend:  controllerreplacer.NewStateBackend(st),
+					Clock:    clock.WallClock,
+					Interval: time.Minute,
+				})
 			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
@@ -1085,6 +1109,7 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		Clock:                       clock.WallClock,
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
+		CharmRollbackCheckInterval:  30 * time.Second,
 		InstPollerAggregationDelay:  3 * time.Second,
 		// TODO(perrito666) the status history pruning numbers need
 		// to be adjusting, after collecting user data from large install
@@ -1093,6 +1118,8 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		StatusHistoryPrunerMaxHistoryMB:   5120,            // 5G
 		StatusHistoryPrunerInterval:       5 * time.Minute,
 		SpacesImportedGate:                a.discoverSpacesComplete,
+		SpaceDiscoveryInterval:            time.Hour,
+		CloudResourceSweepInterval:        time.Hour,
 		NewEnvironFunc:                    newEnvirons,
 		NewMigrationMaster:                migrationmaster.NewWorker,
 	})
@@ -1144,8 +1171,17 @@ func (a *MachineAgent) newAPIserverWorker(st *state.State, certChanged chan para
 	dataDir := agentConfig.DataDir()
 	logDir := agentConfig.LogDir()
 
+	// The controller model's IPv6 mode determines how the API
+	// server listens. Addresses are selected for each model
+	// according to its own mode.
+	modelConfig, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot fetch the controller model config")
+	}
+	ipv6Mode := modelConfig.IPv6Mode()
+
 	endpoint := net.JoinHostPort("", strconv.Itoa(info.APIPort))
-	listener, err := net.Listen("tcp", endpoint)
+	listener, err := net.Listen(ipv6Mode.ListenNetwork(), endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -1161,9 +1197,12 @@ func (a *MachineAgent) newAPIserverWorker(st *state.State, certChanged chan para
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
+	// The audit config updater keeps this in step with later
+	// changes to the controller config.
+	a.setAuditingEnabled(controllerConfig.AuditingEnabled())
 
 	newObserver, err := newObserverFn(
-		controllerConfig,
+		a.isAuditingEnabled,
 		clock.WallClock,
 		jujuversion.Current,
 		agentConfig.Model().Id(),
@@ -1189,6 +1228,11 @@ func (a *MachineAgent) newAPIserverWorker(st *state.State, certChanged chan para
 		AutocertDNSName:  controllerConfig.AutocertDNSName(),
 		AllowModelAccess: controllerConfig.AllowModelAccess(),
 		NewObserver:      newObserver,
+
+		CompressionThreshold:  controllerConfig.APICompressionThreshold(),
+		LogSinkRateLimit:      controllerConfig.AgentLogSinkRateLimit(),
+		LogSinkRateLimitBurst: controllerConfig.AgentLogSinkRateLimitBurst(),
+		PrometheusGatherer:    a.prometheusRegistry,
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
@@ -1197,6 +1241,22 @@ func (a *MachineAgent) newAPIserverWorker(st *state.State, certChanged chan para
 	return server, nil
 }
 
+// setAuditingEnabled records whether the API server should write
+// audit records.
+func (a *MachineAgent) setAuditingEnabled(enabled bool) {
+	a.auditingMutex.Lock()
+	defer a.auditingMutex.Unlock()
+	a.auditingEnabled = enabled
+}
+
+// isAuditingEnabled reports whether the API server should write
+// audit records.
+func (a *MachineAgent) isAuditingEnabled() bool {
+	a.auditingMutex.Lock()
+	defer a.auditingMutex.Unlock()
+	return a.auditingEnabled
+}
+
 func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 	persistFn := st.PutAuditEntryFn()
 	fileSinkFn := audit.NewLogFileSink(logDir)
@@ -1222,7 +1282,7 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 }
 
 func newObserverFn(
-	controllerConfig controller.Config,
+	auditingEnabled func() bool,
 	clock clock.Clock,
 	jujuServerVersion version.Number,
 	modelUUID string,
@@ -1243,17 +1303,23 @@ func newObserverFn(
 		return observer.NewRequestObserver(ctx)
 	})
 
-	// Auditing observer
+	// Auditing observer. Whether auditing is enabled is checked for
+	// each entry, so that it can be turned on and off without
+	// restarting the API server.
 	// TODO(katco): Auditing needs feature tests (lp:1604551)
-	if controllerConfig.AuditingEnabled() {
-		observerFactories = append(observerFactories, func() observer.Observer {
-			ctx := &observer.AuditContext{
-				JujuServerVersion: jujuServerVersion,
-				ModelUUID:         modelUUID,
-			}
-			return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
-		})
+	persistIfEnabled := func(entry audit.AuditEntry) error {
+		if !auditingEnabled() {
+			return nil
+		}
+		return persistAuditEntry(entry)
 	}
+	observerFactories = append(observerFactories, func() observer.Observer {
+		ctx := &observer.AuditContext{
+			JujuServerVersion: jujuServerVersion,
+			ModelUUID:         modelUUID,
+		}
+		return observer.NewAudit(ctx, persistIfEnabled, auditErrorHandler)
+	})
 
 	// Metrics observer.
 	metricObserver, err := metricobserver.NewObserverFactory(metricobserver.Config{
@@ -1508,7 +1574,7 @@ func (a *MachineAgent) Tag() names.Tag {
 
 func (a *MachineAgent) createJujudSymlinks(dataDir string) error {
 	jujud := filepath.Join(tools.ToolsDir(dataDir, a.Tag().String()), jujunames.Jujud)
-	for _, link := range []string{jujuRun, jujuDumpLogs} {
+	for _, link := range []string{jujuRun, jujuDumpLogs, jujuMigrateBlobs} {
 		err := a.createSymlink(jujud, link)
 		if err != nil {
 			return errors.Annotatef(err, "failed to create %s symlink", link)
@@ -1542,7 +1608,7 @@ func (a *MachineAgent) createSymlink(target, link string) error {
 }
 
 func (a *MachineAgent) removeJujudSymlinks() (errs []error) {
-	for _, link := range []string{jujuRun, jujuDumpLogs} {
+	for _, link := range []string{jujuRun, jujuDumpLogs, jujuMigrateBlobs} {
 		err := os.Remove(utils.EnsureBaseDir(a.rootDir, link))
 		if err != nil && !os.IsNotExist(err) {
 			errs = append(errs, errors.Annotatef(err, "failed to remove %s symlink", link))
@@ -1645,12 +1711,41 @@ func metricAPI(st api.Connection) (metricsmanager.MetricsManagerClient, error) {
 // tests can be run without waiting for the 5s watcher refresh time to which we would
 // otherwise be restricted.
 var newDeployContext = func(st *apideployer.State, agentConfig agent.Config) deployer.Context {
+	if featureflag.Enabled(feature.NestedUnits) {
+		return deployer.NewNestedContext(agentConfig, st, func(unitName string) (worker.Worker, error) {
+			return startNestedUnit(agentConfig.DataDir(), unitName)
+		})
+	}
 	return deployer.NewSimpleContext(agentConfig, st)
 }
 
+// newTxnPrunerWorker returns a txnpruner worker whose progress is
+// reported through the given registry for as long as it runs.
+func newTxnPrunerWorker(st *state.State, registry *prometheus.Registry) (worker.Worker, error) {
+	w, err := txnpruner.New(txnpruner.Config{
+		Pruner:     txnpruner.NewStatePruner(st),
+		Clock:      clock.WallClock,
+		Interval:   txnpruner.DefaultInterval,
+		BatchSize:  txnpruner.DefaultBatchSize,
+		BatchPause: txnpruner.DefaultBatchPause,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := registry.Register(w); err != nil {
+		worker.Stop(w)
+		return nil, errors.Annotate(err, "registering txnpruner collector")
+	}
+	go func() {
+		w.Wait()
+		registry.Unregister(w)
+	}()
+	return w, nil
+}
+
 func newStateMetricsWorker(st *state.State, registry *prometheus.Registry) worker.Worker {
 	return worker.NewSimpleWorker(func(stop <-chan struct{}) error {
-		collector := statemetrics.New(statemetrics.NewState(st))
+		collector := statemetrics.New(statemetrics.NewState(st), clock.WallClock)
 		if err := registry.Register(collector); err != nil {
 			return errors.Annotate(err, "registering statemetrics collector")
 		}
@@ -26,11 +26,13 @@ import (
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
+	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
+	"github.com/juju/juju/worker/enginereporter"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/hostkeyreporter"
@@ -141,6 +143,15 @@ type ManifoldsConfig struct {
 
 	// CentralHub is the primary hub that exists in the apiserver.
 	CentralHub *pubsub.StructuredHub
+
+	// EngineReporter reports on the dependency engine running the
+	// manifolds; its reports are sent periodically to the controller.
+	EngineReporter dependency.Reporter
+
+	// SetAuditingEnabled is called on controller machines with the
+	// controller's auditing-enabled setting whenever it changes, so
+	// that the API server can start or stop writing audit records.
+	SetAuditingEnabled func(bool)
 }
 
 // Manifolds returns a set of co-configured manifolds covering the
@@ -470,14 +481,42 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+
+		// The engine reporter sends the agent's dependency engine
+		// report to the controller, so that the workers of a sick
+		// agent can be inspected remotely.
+		engineReporterName: ifNotMigrating(enginereporter.Manifold(enginereporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Reporter:      config.EngineReporter,
+			Clock:         config.Clock,
+			Period:        enginereporter.DefaultPeriod,
+			NewFacade:     enginereporter.NewFacade,
+			NewWorker:     enginereporter.NewWorker,
+		})),
 		logForwarderName: ifFullyUpgraded(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			StateName:     stateName,
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:             "juju-log-forward-http",
+				OpenControllerFn: sinks.OpenHTTP,
+			}, {
+				Name:             "juju-log-forward-kafka",
+				OpenControllerFn: sinks.OpenKafka,
 			}},
 		})),
+
+		// The audit config updater runs on controllers, and keeps
+		// the API server's auditing in step with the controller
+		// config.
+		auditConfigUpdaterName: ifFullyUpgraded(auditconfigupdater.Manifold(auditconfigupdater.ManifoldConfig{
+			StateName:          stateName,
+			APICallerName:      apiCallerName,
+			SetAuditingEnabled: config.SetAuditingEnabled,
+		})),
 	}
 }
 
@@ -533,5 +572,7 @@ const (
 	toolsVersionCheckerName  = "tools-version-checker"
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
+	engineReporterName       = "engine-reporter"
 	logForwarderName         = "log-forwarder"
+	auditConfigUpdaterName   = "audit-config-updater"
 )
@@ -46,8 +46,10 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-address-updater",
 		"api-caller",
 		"api-config-watcher",
+		"audit-config-updater",
 		"central-hub",
 		"disk-manager",
+		"engine-reporter",
 		"host-key-reporter",
 		"log-forwarder",
 		"log-sender",
@@ -95,6 +97,7 @@ func (*ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"agent",
 		"api-caller",
 		"api-config-watcher",
+		"audit-config-updater",
 		"central-hub",
 		"log-forwarder",
 		"state",
@@ -798,7 +798,7 @@ func (s *MachineSuite) TestMachineAgentSymlinks(c *gc.C) {
 	_, done := s.waitForOpenState(c, a)
 
 	// Symlinks should have been created
-	for _, link := range []string{jujuRun, jujuDumpLogs} {
+	for _, link := range []string{jujuRun, jujuDumpLogs, jujuMigrateBlobs} {
 		_, err := os.Stat(utils.EnsureBaseDir(a.rootDir, link))
 		c.Assert(err, jc.ErrorIsNil, gc.Commentf(link))
 	}
@@ -818,7 +818,7 @@ func (s *MachineSuite) TestMachineAgentSymlinkJujuRunExists(c *gc.C) {
 	defer a.Stop()
 
 	// Pre-create the symlinks, but pointing to the incorrect location.
-	links := []string{jujuRun, jujuDumpLogs}
+	links := []string{jujuRun, jujuDumpLogs, jujuMigrateBlobs}
 	a.rootDir = c.MkDir()
 	for _, link := range links {
 		fullLink := utils.EnsureBaseDir(a.rootDir, link)
@@ -850,7 +850,7 @@ func (s *MachineSuite) TestMachineAgentUninstall(c *gc.C) {
 
 	// juju-run and juju-dumplogs symlinks should have been removed on
 	// termination.
-	for _, link := range []string{jujuRun, jujuDumpLogs} {
+	for _, link := range []string{jujuRun, jujuDumpLogs, jujuMigrateBlobs} {
 		_, err = os.Stat(utils.EnsureBaseDir(a.rootDir, link))
 		c.Assert(err, jc.Satisfies, os.IsNotExist)
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/worker/applicationscaler"
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
+	"github.com/juju/juju/worker/charmrollback"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/discoverspaces"
@@ -81,6 +82,10 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// CharmRollbackCheckInterval determines how often charm upgrades
+	// made with automatic rollback are checked for failures.
+	CharmRollbackCheckInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerMaxHistoryTime time.Duration
@@ -91,6 +96,15 @@ type ManifoldsConfig struct {
 	// have been imported.
 	SpacesImportedGate gate.Lock
 
+	// SpaceDiscoveryInterval determines how often the provider's
+	// spaces and subnets are discovered again after the first import.
+	SpaceDiscoveryInterval time.Duration
+
+	// CloudResourceSweepInterval determines how often the cloud's
+	// resources are compared with the model's, to find resources
+	// that failed operations left behind.
+	CloudResourceSweepInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -234,6 +248,9 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			UnlockerName:  spacesImportedGateName,
 
+			RefreshInterval: config.SpaceDiscoveryInterval,
+			Clock:           config.Clock,
+
 			NewFacade: discoverspaces.NewFacade,
 			NewWorker: discoverspaces.NewWorker,
 		})),
@@ -280,6 +297,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		})),
 		stateCleanerName: ifNotMigrating(cleaner.Manifold(cleaner.ManifoldConfig{
 			APICallerName: apiCallerName,
+			ClockName:     clockName,
 		})),
 		statusHistoryPrunerName: ifNotMigrating(statushistorypruner.Manifold(statushistorypruner.ManifoldConfig{
 			APICallerName:  apiCallerName,
@@ -289,11 +307,25 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			// TODO(fwereade): 2016-03-17 lp:1558657
 			NewTimer: worker.NewTimer,
 		})),
+		charmRollbackName: ifNotMigrating(charmrollback.Manifold(charmrollback.ManifoldConfig{
+			APICallerName: apiCallerName,
+			CheckInterval: config.CharmRollbackCheckInterval,
+			NewTimer:      worker.NewTimer,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
 			NewWorker:     machineundertaker.NewWorker,
 		})),
+		cloudResourceSweeperName: ifNotMigrating(undertaker.SweeperManifold(undertaker.SweeperManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			ClockName:     clockName,
+			Interval:      config.CloudResourceSweepInterval,
+
+			NewFacade: undertaker.NewSweeperFacade,
+			NewWorker: undertaker.NewSweeperWorker,
+		})),
 	}
 	if featureflag.Enabled(feature.CrossModelRelations) {
 		result[remoteRelationsName] = ifNotMigrating(remoterelations.Manifold(remoterelations.ManifoldConfig{
@@ -393,9 +425,11 @@ const (
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
+	charmRollbackName        = "charm-rollback"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	machineUndertakerName    = "machine-undertaker"
+	cloudResourceSweeperName = "cloud-resource-sweeper"
 	remoteRelationsName      = "remote-relations"
 )
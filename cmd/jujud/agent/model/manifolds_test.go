@@ -37,7 +37,9 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"api-config-watcher",
 		"application-scaler",
 		"charm-revision-updater",
+		"charm-rollback",
 		"clock",
+		"cloud-resource-sweeper",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
@@ -146,7 +148,9 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"api-config-watcher",
 		"application-scaler",
 		"charm-revision-updater",
+		"charm-rollback",
 		"clock",
+		"cloud-resource-sweeper",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
@@ -145,15 +145,30 @@ func (a *UnitAgent) Run(ctx *cmd.Context) error {
 
 // APIWorkers returns a dependency.Engine running the unit agent's responsibilities.
 func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
-	manifolds := unitManifolds(unit.ManifoldsConfig{
-		Agent:                agent.APIHostPortsSetter{a},
-		LogSource:            a.bufferedLogger.Logs(),
-		LeadershipGuarantee:  30 * time.Second,
-		AgentConfigChanged:   a.configChangedVal,
-		ValidateMigration:    a.validateMigration,
-		PrometheusRegisterer: a.prometheusRegistry,
-	})
+	engine, err := a.newEngine(a.bufferedLogger.Logs(), false)
+	if err != nil {
+		return nil, err
+	}
+	if err := startIntrospection(introspectionConfig{
+		Agent:              a,
+		Engine:             engine,
+		NewSocketName:      DefaultIntrospectionSocketName,
+		PrometheusGatherer: a.prometheusRegistry,
+		WorkerFunc:         introspection.NewWorker,
+	}); err != nil {
+		// If the introspection worker failed to start, we just log error
+		// but continue. It is very unlikely to happen in the real world
+		// as the only issue is connecting to the abstract domain socket
+		// and the agent is controlled by by the OS to only have one.
+		logger.Errorf("failed to start introspection worker: %v", err)
+	}
+	return engine, nil
+}
 
+// newEngine returns a dependency.Engine running the unit agent's
+// manifolds. The agent's log messages are read from logSource, unless
+// it is nested in a machine agent.
+func (a *UnitAgent) newEngine(logSource logsender.LogRecordCh, nested bool) (*dependency.Engine, error) {
 	config := dependency.EngineConfig{
 		IsFatal:     cmdutil.IsFatal,
 		WorstError:  cmdutil.MoreImportantError,
@@ -164,24 +179,45 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 	if err != nil {
 		return nil, err
 	}
+	manifolds := unitManifolds(unit.ManifoldsConfig{
+		Agent:                agent.APIHostPortsSetter{a},
+		LogSource:            logSource,
+		Nested:               nested,
+		LeadershipGuarantee:  30 * time.Second,
+		AgentConfigChanged:   a.configChangedVal,
+		ValidateMigration:    a.validateMigration,
+		PrometheusRegisterer: a.prometheusRegistry,
+		EngineReporter:       engine,
+	})
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
 			logger.Errorf("while stopping engine with bad manifolds: %v", err)
 		}
 		return nil, err
 	}
-	if err := startIntrospection(introspectionConfig{
-		Agent:              a,
-		Engine:             engine,
-		NewSocketName:      DefaultIntrospectionSocketName,
-		PrometheusGatherer: a.prometheusRegistry,
-		WorkerFunc:         introspection.NewWorker,
-	}); err != nil {
-		// If the introspection worker failed to start, we just log error
-		// but continue. It is very unlikely to happen in the real world
-		// as the only issue is connecting to the abstract domain socket
-		// and the agent is controlled by by the OS to only have one.
-		logger.Errorf("failed to start introspection worker: %v", err)
+	return engine, nil
+}
+
+// startNestedUnit starts the workers of the named unit's agent inside
+// the machine agent process, for a deployer.NestedContext. The unit's
+// log messages are sent to the controller by the machine agent, and it
+// has no introspection socket of its own.
+func startNestedUnit(dataDir, unitName string) (worker.Worker, error) {
+	a := &UnitAgent{
+		AgentConf:        NewAgentConf(dataDir),
+		UnitName:         unitName,
+		configChangedVal: voyeur.NewValue(true),
+		// The unit's metrics are kept apart from the machine
+		// agent's, whose collectors they would clash with.
+		prometheusRegistry: prometheus.NewRegistry(),
+	}
+	if err := a.ReadConfig(a.Tag().String()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	agentLogger.Infof("starting nested unit agent %v", a.Tag())
+	engine, err := a.newEngine(nil, true)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 	return engine, nil
 }
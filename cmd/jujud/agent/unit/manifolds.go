@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/enginereporter"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/leadership"
 	"github.com/juju/juju/worker/logger"
@@ -48,7 +49,15 @@ type ManifoldsConfig struct {
 	// LogSource will be read from by the logsender component.
 	LogSource logsender.LogRecordCh
 
-	// LeadershipGuarantee controls the behaviour of the leadership tracker.
+	// Nested is set when the unit agent runs inside a machine agent,
+	// which already sends the log messages of the whole process to the
+	// controller. No logsender component is run, and LogSource is
+	// ignored.
+	Nested bool
+
+	// LeadershipGuarantee controls the behaviour of the leadership tracker
+	// when the controller does not report the model's leadership lease
+	// settings.
 	LeadershipGuarantee time.Duration
 
 	// AgentConfigChanged is set whenever the unit agent's config
@@ -63,6 +72,10 @@ type ManifoldsConfig struct {
 	// PrometheusRegisterer is a prometheus.Registerer that may be used
 	// by workers to register Prometheus metric collectors.
 	PrometheusRegisterer prometheus.Registerer
+
+	// EngineReporter reports on the dependency engine running the
+	// manifolds; its reports are sent periodically to the controller.
+	EngineReporter dependency.Reporter
 }
 
 // Manifolds returns a set of co-configured manifolds covering the various
@@ -86,7 +99,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		return err
 	}
 
-	return dependency.Manifolds{
+	manifolds := dependency.Manifolds{
 
 		// The agent manifold references the enclosing agent, and is the
 		// foundation stone on which most other manifolds ultimately depend.
@@ -261,7 +274,24 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName:   apiCallerName,
 			MetricSpoolName: metricSpoolName,
 		})),
+
+		// The engine reporter sends the agent's dependency engine
+		// report to the controller, so that the workers of a sick
+		// agent can be inspected remotely.
+		engineReporterName: ifNotMigrating(enginereporter.Manifold(enginereporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Reporter:      config.EngineReporter,
+			Clock:         clock.WallClock,
+			Period:        enginereporter.DefaultPeriod,
+			NewFacade:     enginereporter.NewFacade,
+			NewWorker:     enginereporter.NewWorker,
+		})),
 	}
+	if config.Nested {
+		delete(manifolds, logSenderName)
+	}
+	return manifolds
 }
 
 var ifNotMigrating = engine.Housing{
@@ -295,4 +325,6 @@ const (
 	meterStatusName   = "meter-status"
 	metricCollectName = "metric-collect"
 	metricSenderName  = "metric-sender"
+
+	engineReporterName = "engine-reporter"
 )
@@ -53,6 +53,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"meter-status",
 		"metric-collect",
 		"metric-sender",
+		"engine-reporter",
 	}
 	keys := make([]string, 0, len(manifolds))
 	for k := range manifolds {
@@ -61,6 +62,15 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 	c.Assert(expectedKeys, jc.SameContents, keys)
 }
 
+func (s *ManifoldsSuite) TestNestedManifoldNames(c *gc.C) {
+	config := unit.ManifoldsConfig{Nested: true}
+	manifolds := unit.Manifolds(config)
+	_, found := manifolds["log-sender"]
+	c.Assert(found, jc.IsFalse)
+	_, found = manifolds["uniter"]
+	c.Assert(found, jc.IsTrue)
+}
+
 func (*ManifoldsSuite) TestMigrationGuards(c *gc.C) {
 	exempt := set.NewStrings(
 		"agent",
@@ -22,6 +22,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	agentcmd "github.com/juju/juju/cmd/jujud/agent"
 	"github.com/juju/juju/cmd/jujud/dumplogs"
+	"github.com/juju/juju/cmd/jujud/migrateblobs"
 	components "github.com/juju/juju/component/all"
 	"github.com/juju/juju/juju/names"
 	"github.com/juju/juju/juju/sockets"
@@ -215,6 +216,8 @@ func Main(args []string) int {
 		code = cmd.Main(run, ctx, args[1:])
 	case names.JujuDumpLogs:
 		code = cmd.Main(dumplogs.NewCommand(), ctx, args[1:])
+	case names.JujuMigrateBlobs:
+		code = cmd.Main(migrateblobs.NewCommand(), ctx, args[1:])
 	default:
 		code, err = jujuCMain(commandName, ctx, args)
 	}
@@ -0,0 +1,164 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// A command for moving the blobs stored in MongoDB's GridFS to the
+// external object store configured for the controller.
+
+package migrateblobs
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	jujudagent "github.com/juju/juju/cmd/jujud/agent"
+	"github.com/juju/juju/controller"
+	corenames "github.com/juju/juju/juju/names"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/imagestorage"
+	"github.com/juju/juju/state/objectstore"
+)
+
+// gridFSDatabases holds the names of the databases whose
+// GridFS collections hold the blobs managed by the controller.
+var gridFSDatabases = []string{
+	"blobstore",
+	imagestorage.ImagesDB,
+	"backups",
+}
+
+// NewCommand returns a new Command instance which implements the
+// "juju-migrate-blobs" command.
+func NewCommand() cmd.Command {
+	return &migrateBlobsCommand{
+		agentConfig: jujudagent.NewAgentConf(""),
+	}
+}
+
+type migrateBlobsCommand struct {
+	cmd.CommandBase
+	agentConfig jujudagent.AgentConf
+	machineId   string
+}
+
+// Info implements cmd.Command.
+func (c *migrateBlobsCommand) Info() *cmd.Info {
+	doc := `
+This tool moves the blobs that the controller stores in MongoDB's GridFS
+(charm archives, resources, agent binaries, OS images and backups) to
+the external object store configured with the object-store-* controller
+settings. It must be run on a Juju controller server, once the object
+store has been configured.
+
+Blobs are only removed from MongoDB once they have been stored in the
+object store, and the controller continues to read blobs from MongoDB
+until they have been moved, so the tool may be run while the controller
+is in use, and may safely be run again if interrupted.
+
+In order to connect to the database, the local machine agent's
+configuration is needed. In most circumstances the configuration will
+be found automatically. The --data-dir and/or --machine-id options may
+be required if the agent configuration can't be found automatically.
+`[1:]
+	return &cmd.Info{
+		Name:    corenames.JujuMigrateBlobs,
+		Purpose: "move blobs stored in the local Juju database to the external object store",
+		Doc:     doc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *migrateBlobsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.agentConfig.AddFlags(f)
+	f.StringVar(&c.machineId, "machine-id", "", "id of the machine on this host (optional)")
+}
+
+// Init implements cmd.Command.
+func (c *migrateBlobsCommand) Init(args []string) error {
+	err := c.agentConfig.CheckArgs(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.machineId == "" {
+		machineId, err := c.findMachineId(c.agentConfig.DataDir())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.machineId = machineId
+	} else if !names.IsValidMachine(c.machineId) {
+		return errors.New("--machine-id option expects a non-negative integer")
+	}
+
+	err = c.agentConfig.ReadConfig(names.NewMachineTag(c.machineId).String())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *migrateBlobsCommand) Run(ctx *cmd.Context) error {
+	config := c.agentConfig.CurrentConfig()
+	info, ok := config.MongoInfo()
+	if !ok {
+		return errors.New("no database connection info available (is this a controller host?)")
+	}
+
+	st, err := state.Open(state.OpenParams{
+		Clock:              clock.WallClock,
+		ControllerTag:      config.Controller(),
+		ControllerModelTag: config.Model(),
+		MongoInfo:          info,
+		MongoDialOpts:      mongo.DefaultDialOpts(),
+	})
+	if err != nil {
+		return errors.Annotate(err, "failed to connect to database")
+	}
+	defer st.Close()
+
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "failed to read controller config")
+	}
+	if controllerConfig.ObjectStoreType() == controller.ObjectStoreMongo {
+		return errors.Errorf("no external object store configured (see %s)", controller.ObjectStoreType)
+	}
+
+	session := st.MongoSession()
+	for _, db := range gridFSDatabases {
+		store, err := objectstore.Open(controllerConfig, db)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		migrated, err := objectstore.Migrate(session, db, store)
+		ctx.Infof("moved %d blob(s) from %q", migrated, db)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (c *migrateBlobsCommand) findMachineId(dataDir string) (string, error) {
+	entries, err := ioutil.ReadDir(agent.BaseDir(dataDir))
+	if err != nil {
+		return "", errors.Annotate(err, "failed to read agent configuration base directory")
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			tag, err := names.ParseMachineTag(entry.Name())
+			if err == nil {
+				return tag.Id(), nil
+			}
+		}
+	}
+	return "", errors.New("no machine agent configuration found")
+}
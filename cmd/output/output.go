@@ -14,10 +14,10 @@ import (
 
 // DefaultFormatters holds the formatters that can be
 // specified with the --format flag.
-var DefaultFormatters = map[string]cmd.Formatter{
+var DefaultFormatters = Versioned(map[string]cmd.Formatter{
 	"yaml": cmd.FormatYaml,
 	"json": cmd.FormatJson,
-}
+})
 
 // TabWriter returns a new tab writer with common layout definition.
 func TabWriter(writer io.Writer) *ansiterm.TabWriter {
@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"gopkg.in/yaml.v2"
+)
+
+// SchemaVersion is the latest version of the structure of the YAML
+// and JSON output of juju commands.
+//
+// Version 1 is the original structure, which the plain "yaml" and
+// "json" formats keep producing so that existing tooling continues to
+// work. Version 2 adds a top-level schema-version field; output whose
+// top level is not a mapping is moved into a value field beside it.
+// A particular version is selected with a format such as "json@2".
+const SchemaVersion = 2
+
+// schemaVersionKey is the name of the field holding the schema
+// version.
+const schemaVersionKey = "schema-version"
+
+// Versioned returns a copy of formatters to which, if they include
+// "yaml" or "json" formatters, the formats "yaml@1" to "yaml@N" or
+// "json@1" to "json@N" are added, where N is SchemaVersion.
+func Versioned(formatters map[string]cmd.Formatter) map[string]cmd.Formatter {
+	out := make(map[string]cmd.Formatter)
+	for name, f := range formatters {
+		out[name] = f
+	}
+	if f, ok := formatters["yaml"]; ok {
+		out["yaml@1"] = f
+		out[fmt.Sprintf("yaml@%d", SchemaVersion)] = versionedYaml(f)
+	}
+	if f, ok := formatters["json"]; ok {
+		out["json@1"] = f
+		out[fmt.Sprintf("json@%d", SchemaVersion)] = versionedJson(f)
+	}
+	return out
+}
+
+// BaseFormat returns the name of the given format without any schema
+// version, so that "json@2" and "json" are both "json".
+func BaseFormat(name string) string {
+	if i := strings.Index(name, "@"); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// versionedYaml returns a formatter that adds the schema version to
+// values before formatting them with f.
+func versionedYaml(f cmd.Formatter) cmd.Formatter {
+	return func(writer io.Writer, value interface{}) error {
+		if value == nil {
+			return f(writer, value)
+		}
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		version := yaml.MapItem{Key: schemaVersionKey, Value: SchemaVersion}
+		var generic interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		if _, ok := generic.(map[interface{}]interface{}); !ok {
+			return f(writer, yaml.MapSlice{version, {Key: "value", Value: value}})
+		}
+		// Decoding into a MapSlice keeps the order of the fields,
+		// including those of nested mappings.
+		var fields yaml.MapSlice
+		if err := yaml.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		return f(writer, append(yaml.MapSlice{version}, fields...))
+	}
+}
+
+// versionedJson returns a formatter that adds the schema version to
+// values before formatting them with f.
+func versionedJson(f cmd.Formatter) cmd.Formatter {
+	return func(writer io.Writer, value interface{}) error {
+		if value == nil {
+			return f(writer, value)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, `{"%s":%d`, schemaVersionKey, SchemaVersion)
+		if fields := bytes.TrimSpace(data); bytes.HasPrefix(fields, []byte("{")) {
+			fields = bytes.TrimSpace(fields[1 : len(fields)-1])
+			if len(fields) > 0 {
+				buf.WriteByte(',')
+				buf.Write(fields)
+			}
+		} else {
+			buf.WriteString(`,"value":`)
+			buf.Write(data)
+		}
+		buf.WriteByte('}')
+		raw := json.RawMessage(buf.Bytes())
+		return f(writer, &raw)
+	}
+}
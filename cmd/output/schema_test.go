@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package output_test
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/output"
+)
+
+type schemaSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&schemaSuite{})
+
+type sample struct {
+	Name  string            `yaml:"name" json:"name"`
+	Count int               `yaml:"count" json:"count"`
+	Tags  map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+func (s *schemaSuite) format(c *gc.C, name string, value interface{}) string {
+	formatters := output.Versioned(map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": cmd.FormatSmart,
+	})
+	var buf bytes.Buffer
+	err := formatters[name](&buf, value)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.String()
+}
+
+func (s *schemaSuite) TestVersionedFormats(c *gc.C) {
+	formatters := output.Versioned(map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"tabular": cmd.FormatSmart,
+	})
+	var names []string
+	for name := range formatters {
+		names = append(names, name)
+	}
+	c.Assert(names, jc.SameContents, []string{"yaml", "yaml@1", "yaml@2", "tabular"})
+}
+
+func (s *schemaSuite) TestVersionOneUnchanged(c *gc.C) {
+	value := sample{Name: "foo", Count: 2}
+	c.Assert(s.format(c, "yaml@1", value), gc.Equals, s.format(c, "yaml", value))
+	c.Assert(s.format(c, "json@1", value), gc.Equals, s.format(c, "json", value))
+}
+
+func (s *schemaSuite) TestYamlVersionTwo(c *gc.C) {
+	value := sample{Name: "foo", Count: 2, Tags: map[string]string{"b": "2", "a": "1"}}
+	c.Assert(s.format(c, "yaml@2", value), gc.Equals, `
+schema-version: 2
+name: foo
+count: 2
+tags:
+  a: "1"
+  b: "2"
+`[1:])
+	c.Assert(s.format(c, "yaml@2", []string{"foo", "bar"}), gc.Equals, `
+schema-version: 2
+value:
+- foo
+- bar
+`[1:])
+}
+
+func (s *schemaSuite) TestJsonVersionTwo(c *gc.C) {
+	value := sample{Name: "foo", Count: 2}
+	c.Assert(s.format(c, "json@2", value), jc.JSONEquals, map[string]interface{}{
+		"schema-version": 2,
+		"name":           "foo",
+		"count":          2,
+	})
+	c.Assert(s.format(c, "json@2", struct{}{}), jc.JSONEquals, map[string]interface{}{
+		"schema-version": 2,
+	})
+	c.Assert(s.format(c, "json@2", []string{"foo"}), jc.JSONEquals, map[string]interface{}{
+		"schema-version": 2,
+		"value":          []string{"foo"},
+	})
+}
+
+func (s *schemaSuite) TestBaseFormat(c *gc.C) {
+	c.Assert(output.BaseFormat("json@2"), gc.Equals, "json")
+	c.Assert(output.BaseFormat("yaml"), gc.Equals, "yaml")
+	c.Assert(output.BaseFormat("tabular"), gc.Equals, "tabular")
+}
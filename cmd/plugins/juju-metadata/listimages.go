@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 func newListImagesCommand() cmd.Command {
@@ -103,11 +104,11 @@ func (c *listImagesCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.VirtType, "virt-type", "", "image metadata virtualisation type")
 	f.StringVar(&c.RootStorageType, "storage-type", "", "image metadata root storage type")
 
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.out.AddFlags(f, "tabular", output.Versioned(map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": formatMetadataListTabular,
-	})
+	}))
 }
 
 // Run implements Command.Run.
@@ -132,8 +133,9 @@ func (c *listImagesCommand) Run(ctx *cmd.Context) (err error) {
 		fmt.Fprintf(ctx.Stderr, strings.Join(errs, "\n"))
 	}
 
+	format := output.BaseFormat(c.out.Name())
 	var output interface{}
-	switch c.out.Name() {
+	switch format {
 	case "yaml", "json":
 		output = groupMetadata(info)
 	default:
@@ -32,6 +32,9 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+	GPUs         = "gpus"
+	GPUType      = "gpu-type"
+	MaxPrice     = "max-price"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -85,6 +88,20 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// GPUs, if not nil, indicates that a machine must have at least that
+	// many GPUs or other accelerators attached.
+	GPUs *uint64 `json:"gpus,omitempty" yaml:"gpus,omitempty"`
+
+	// GPUType, if not nil or empty, indicates that a machine's GPUs must
+	// be of the named type, such as "nvidia-tesla-k80". The names are
+	// defined by each provider.
+	GPUType *string `json:"gpu-type,omitempty" yaml:"gpu-type,omitempty"`
+
+	// MaxPrice, if not nil or zero, indicates that a machine must cost no
+	// more than that many US dollars per hour to run. Only valid for
+	// clouds whose instance type prices are known.
+	MaxPrice *float64 `json:"max-price,omitempty" yaml:"max-price,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -180,6 +197,23 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// HasGPUs returns true if the constraints.Value specifies a minimum number
+// of GPUs.
+func (v *Value) HasGPUs() bool {
+	return v.GPUs != nil && *v.GPUs > 0
+}
+
+// HasMaxPrice returns true if the constraints.Value specifies a maximum
+// price.
+func (v *Value) HasMaxPrice() bool {
+	return v.MaxPrice != nil && *v.MaxPrice > 0
+}
+
+// HasGPUType returns true if the constraints.Value specifies a GPU type.
+func (v *Value) HasGPUType() bool {
+	return v.GPUType != nil && *v.GPUType != ""
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -223,6 +257,15 @@ func (v Value) String() string {
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+string(*v.VirtType))
 	}
+	if v.GPUs != nil {
+		strs = append(strs, "gpus="+uintStr(*v.GPUs))
+	}
+	if v.GPUType != nil {
+		strs = append(strs, "gpu-type="+*v.GPUType)
+	}
+	if v.MaxPrice != nil {
+		strs = append(strs, "max-price="+priceStr(*v.MaxPrice))
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -264,6 +307,15 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.GPUs != nil {
+		values = append(values, fmt.Sprintf("GPUs: %v", *v.GPUs))
+	}
+	if v.GPUType != nil {
+		values = append(values, fmt.Sprintf("GPUType: %q", *v.GPUType))
+	}
+	if v.MaxPrice != nil {
+		values = append(values, fmt.Sprintf("MaxPrice: %v", *v.MaxPrice))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -274,6 +326,13 @@ func uintStr(i uint64) string {
 	return fmt.Sprintf("%d", i)
 }
 
+func priceStr(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
 // Parse constructs a constraints.Value from the supplied arguments,
 // each of which must contain only spaces and name=value pairs. If any
 // name is specified more than once, an error is returned.
@@ -420,6 +479,12 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case GPUs:
+		err = v.setGPUs(str)
+	case GPUType:
+		err = v.setGPUType(str)
+	case MaxPrice:
+		err = v.setMaxPrice(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -483,6 +548,12 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case GPUs:
+			v.GPUs, err = parseUint64(vstr)
+		case GPUType:
+			v.GPUType = &vstr
+		case MaxPrice:
+			v.MaxPrice, err = parsePrice(vstr)
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -607,6 +678,30 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setGPUs(str string) (err error) {
+	if v.GPUs != nil {
+		return errors.Errorf("already set")
+	}
+	v.GPUs, err = parseUint64(str)
+	return
+}
+
+func (v *Value) setGPUType(str string) error {
+	if v.GPUType != nil {
+		return errors.Errorf("already set")
+	}
+	v.GPUType = &str
+	return nil
+}
+
+func (v *Value) setMaxPrice(str string) (err error) {
+	if v.MaxPrice != nil {
+		return errors.Errorf("already set")
+	}
+	v.MaxPrice, err = parsePrice(str)
+	return
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
@@ -619,6 +714,18 @@ func parseUint64(str string) (*uint64, error) {
 	return &value, nil
 }
 
+func parsePrice(str string) (*float64, error) {
+	var value float64
+	if str != "" {
+		val, err := strconv.ParseFloat(str, 64)
+		if err != nil || val < 0 || math.IsInf(val, 0) || math.IsNaN(val) {
+			return nil, errors.Errorf("must be a non-negative number")
+		}
+		value = val
+	}
+	return &value, nil
+}
+
 func parseSize(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
@@ -313,19 +313,74 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// "gpus" and "gpu-type" in detail.
+	{
+		summary: "set gpus empty",
+		args:    []string{"gpus="},
+	}, {
+		summary: "set gpus",
+		args:    []string{"gpus=2"},
+	}, {
+		summary: "set nonsense gpus",
+		args:    []string{"gpus=lots"},
+		err:     `bad "gpus" constraint: must be a non-negative integer`,
+	}, {
+		summary: "set negative gpus",
+		args:    []string{"gpus=-1"},
+		err:     `bad "gpus" constraint: must be a non-negative integer`,
+	}, {
+		summary: "double set gpus",
+		args:    []string{"gpus=1 gpus=2"},
+		err:     `bad "gpus" constraint: already set`,
+	}, {
+		summary: "set gpu-type empty",
+		args:    []string{"gpu-type="},
+	}, {
+		summary: "set gpu-type",
+		args:    []string{"gpu-type=nvidia-tesla-k80"},
+	}, {
+		summary: "double set gpu-type",
+		args:    []string{"gpu-type=nvidia-tesla-k80", "gpu-type="},
+		err:     `bad "gpu-type" constraint: already set`,
+	},
+
+	// "max-price" in detail.
+	{
+		summary: "set max-price empty",
+		args:    []string{"max-price="},
+	}, {
+		summary: "set max-price",
+		args:    []string{"max-price=0.25"},
+	}, {
+		summary: "set whole max-price",
+		args:    []string{"max-price=2"},
+	}, {
+		summary: "set nonsense max-price",
+		args:    []string{"max-price=cheap"},
+		err:     `bad "max-price" constraint: must be a non-negative number`,
+	}, {
+		summary: "set negative max-price",
+		args:    []string{"max-price=-0.5"},
+		err:     `bad "max-price" constraint: must be a non-negative number`,
+	}, {
+		summary: "double set max-price",
+		args:    []string{"max-price=1 max-price=2"},
+		err:     `bad "max-price" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
 		args: []string{
 			"root-disk=8G mem=2T  arch=i386  cores=4096 cpu-power=9001 container=lxd " +
 				"tags=foo,bar spaces=space1,^space2 instance-type=foo",
-			"virt-type=kvm"},
+			"virt-type=kvm gpus=2 gpu-type=nvidia-tesla-k80"},
 	}, {
 		summary: "kitchen sink separately",
 		args: []string{
 			"root-disk=8G", "mem=2T", "cores=4096", "cpu-power=9001", "arch=armhf",
 			"container=lxd", "tags=foo,bar", "spaces=space1,^space2",
-			"instance-type=foo", "virt-type=kvm"},
+			"instance-type=foo", "virt-type=kvm", "gpus=2", "gpu-type=nvidia-tesla-k80"},
 	},
 }
 
@@ -463,12 +518,22 @@ func (s *ConstraintsSuite) TestIsEmpty(c *gc.C) {
 	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
 	con = constraints.MustParse("instance-type=")
 	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
+	con = constraints.MustParse("gpus=")
+	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
+	con = constraints.MustParse("gpu-type=")
+	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
+	con = constraints.MustParse("max-price=")
+	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
 }
 
 func uint64p(i uint64) *uint64 {
 	return &i
 }
 
+func floatp(f float64) *float64 {
+	return &f
+}
+
 func strp(s string) *string {
 	return &s
 }
@@ -510,6 +575,12 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"GPUs1", constraints.Value{GPUs: uint64p(0)}},
+	{"GPUs2", constraints.Value{GPUs: uint64p(4)}},
+	{"GPUType1", constraints.Value{GPUType: strp("")}},
+	{"GPUType2", constraints.Value{GPUType: strp("nvidia-tesla-k80")}},
+	{"MaxPrice1", constraints.Value{MaxPrice: floatp(0)}},
+	{"MaxPrice2", constraints.Value{MaxPrice: floatp(0.125)}},
 	{"All", constraints.Value{
 		Arch:         strp("i386"),
 		Container:    ctypep("lxd"),
@@ -520,6 +591,9 @@ var constraintsRoundtripTests = []roundTrip{
 		Tags:         &[]string{"foo", "bar"},
 		Spaces:       &[]string{"space1", "^space2"},
 		InstanceType: strp("foo"),
+		GPUs:         uint64p(2),
+		GPUType:      strp("nvidia-tesla-k80"),
+		MaxPrice:     floatp(1.5),
 	}},
 }
 
@@ -6,6 +6,7 @@ package container
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
 )
@@ -51,6 +52,15 @@ type Manager interface {
 	Namespace() instance.Namespace
 }
 
+// LXDProfileManager is implemented by container managers that can
+// apply LXD profiles shipped with charms to the containers they create.
+type LXDProfileManager interface {
+	// EnsureLXDProfile creates the named LXD profile, if it does
+	// not already exist. Profiles are never updated once created,
+	// so the name must identify the profile's content.
+	EnsureLXDProfile(name string, profile lxdprofile.Profile) error
+}
+
 // Initialiser is responsible for performing the steps required to initialise
 // a host machine so it can run containers.
 type Initialiser interface {
@@ -8,4 +8,6 @@ package lxd
 var (
 	NICDevice      = nicDevice
 	NetworkDevices = networkDevices
+
+	ProfileFromConfig = profileFromConfig
 )
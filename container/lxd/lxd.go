@@ -10,11 +10,13 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/lxc/lxd/shared"
 
 	"github.com/juju/juju/cloudconfig/containerinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -38,8 +40,12 @@ type containerManager struct {
 	client *lxdclient.Client
 }
 
-// containerManager implements container.Manager.
-var _ container.Manager = (*containerManager)(nil)
+// containerManager implements container.Manager and
+// container.LXDProfileManager.
+var (
+	_ container.Manager           = (*containerManager)(nil)
+	_ container.LXDProfileManager = (*containerManager)(nil)
+)
 
 func ConnectLocal() (*lxdclient.Client, error) {
 	cfg := lxdclient.Config{
@@ -155,6 +161,10 @@ func (manager *containerManager) CreateContainer(
 	} else {
 		logger.Infof("instance %q configured with %v network devices", name, nics)
 	}
+	if len(instanceConfig.CharmLXDProfiles) > 0 {
+		logger.Infof("instance %q configured with charm profiles %v", name, instanceConfig.CharmLXDProfiles)
+		profiles = append(profiles, instanceConfig.CharmLXDProfiles...)
+	}
 
 	// Push the required /etc/network/interfaces file to the container.
 	// By pushing this file (which happens after LXD init, and before LXD
@@ -198,6 +208,80 @@ func (manager *containerManager) CreateContainer(
 	return
 }
 
+// EnsureLXDProfile implements container.LXDProfileManager.
+func (manager *containerManager) EnsureLXDProfile(name string, profile lxdprofile.Profile) error {
+	if manager.client == nil {
+		var err error
+		manager.client, err = ConnectLocal()
+		if err != nil {
+			return errors.Annotatef(err, "failed to connect to local LXD")
+		}
+	}
+	exists, err := manager.client.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		existing, err := manager.client.ProfileConfig(name)
+		if err != nil {
+			return errors.Annotatef(err, "reading LXD profile %q", name)
+		}
+		if profileFromConfig(existing).Equal(&profile) {
+			return nil
+		}
+		// The profile was left half created, or was created by
+		// someone else. LXD refuses to delete a profile that is in
+		// use, so a profile applied to containers is never changed.
+		logger.Infof("replacing LXD profile %q with different contents", name)
+		if err := manager.client.ProfileDelete(name); err != nil {
+			return errors.Annotatef(err, "LXD profile %q exists with different contents", name)
+		}
+	}
+	logger.Infof("creating LXD profile %q", name)
+	if err := manager.createLXDProfile(name, profile); err != nil {
+		if deleteErr := manager.client.ProfileDelete(name); deleteErr != nil {
+			logger.Warningf("cannot delete partially created LXD profile %q: %v", name, deleteErr)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (manager *containerManager) createLXDProfile(name string, profile lxdprofile.Profile) error {
+	if err := manager.client.CreateProfile(name, profile.Config); err != nil {
+		return errors.Annotatef(err, "creating LXD profile %q", name)
+	}
+	for deviceName, device := range profile.Devices {
+		var props []string
+		for key, value := range device {
+			if key == "type" {
+				continue
+			}
+			props = append(props, key+"="+value)
+		}
+		if _, err := manager.client.ProfileDeviceAdd(name, deviceName, device["type"], props); err != nil {
+			return errors.Annotatef(err, "adding device %q to LXD profile %q", deviceName, name)
+		}
+	}
+	return nil
+}
+
+// profileFromConfig returns the config and devices of an existing LXD
+// profile, for comparison with a charm's profile.
+func profileFromConfig(config *shared.ProfileConfig) *lxdprofile.Profile {
+	profile := &lxdprofile.Profile{
+		Description: config.Description,
+		Config:      config.Config,
+	}
+	for name, device := range config.Devices {
+		if profile.Devices == nil {
+			profile.Devices = make(map[string]map[string]string)
+		}
+		profile.Devices[name] = map[string]string(device)
+	}
+	return profile
+}
+
 func (manager *containerManager) DestroyContainer(id instance.Id) error {
 	if manager.client == nil {
 		var err error
@@ -10,11 +10,13 @@ import (
 	stdtesting "testing"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/lxdprofile"
 	containertesting "github.com/juju/juju/container/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -201,3 +203,20 @@ func (t *LxdSuite) TestNetworkDevicesWithParentDevice(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, jc.DeepEquals, expected)
 }
+
+func (t *LxdSuite) TestProfileFromConfig(c *gc.C) {
+	profile := lxd.ProfileFromConfig(&shared.ProfileConfig{
+		Name:        "juju-foo-1-0123456789ab",
+		Description: "foo",
+		Config:      map[string]string{"user.foo": "bar"},
+		Devices: shared.Devices{
+			"vfio": shared.Device{"type": "unix-char", "path": "/dev/vfio/vfio"},
+		},
+	})
+	c.Assert(profile.Equal(&lxdprofile.Profile{
+		Config: map[string]string{"user.foo": "bar"},
+		Devices: map[string]map[string]string{
+			"vfio": {"type": "unix-char", "path": "/dev/vfio/vfio"},
+		},
+	}), jc.IsTrue)
+}
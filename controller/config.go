@@ -4,7 +4,11 @@
 package controller
 
 import (
+	"net"
 	"net/url"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -14,6 +18,7 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/permission"
 )
 
 var logger = loggo.GetLogger("juju.controller")
@@ -62,6 +67,130 @@ const (
 	// they don't have any access rights to the controller itself.
 	AllowModelAccessKey = "allow-model-access"
 
+	// ObjectStoreType is the type of store in which the controller
+	// keeps large blobs: charm archives, resources, agent binaries,
+	// OS images and backups. The default, "mongo", keeps them in
+	// MongoDB's GridFS; "s3" keeps them in an S3 bucket.
+	ObjectStoreType = "object-store-type"
+
+	// ObjectStoreURL locates the external object store. For the "s3"
+	// store type it takes the form s3://<region>/<bucket>[/<prefix>].
+	ObjectStoreURL = "object-store-url"
+
+	// ObjectStoreAccessKey and ObjectStoreSecretKey hold the
+	// credentials used to access the external object store.
+	ObjectStoreAccessKey = "object-store-access-key"
+	ObjectStoreSecretKey = "object-store-secret-key"
+
+	// ObjectStoreCacheSize is the maximum size, in MiB, of the cache
+	// of objects read from the external object store that is kept on
+	// each controller machine. Zero disables the cache.
+	ObjectStoreCacheSize = "object-store-cache-size"
+
+	// LogOffloadAge is the age, as a duration string such as "24h",
+	// after which log messages are moved from the controller's
+	// database to the external object store. They can still be read
+	// with debug-log. It may only be set when an external object
+	// store is configured; if it is not set, logs are not offloaded.
+	LogOffloadAge = "log-offload-age"
+
+	// CharmStoreURL is the URL of the charm store from which the
+	// controller fetches charms and resources. If unset, the public
+	// charm store is used; controllers in air-gapped environments
+	// may set it to a local charm store mirror.
+	CharmStoreURL = "charmstore-url"
+
+	// APICompressionThreshold is the size, in bytes, above which the
+	// responses sent on API connections are gzipped, for clients
+	// that accept compressed responses. Zero disables compression.
+	APICompressionThreshold = "api-compression-threshold"
+
+	// AgentLogSinkRateLimit is the number of log messages per second
+	// that each agent may send to the controller, on average. Further
+	// messages are dropped, and the number dropped is logged. Zero
+	// disables the limit.
+	AgentLogSinkRateLimit = "agent-logsink-rate-limit"
+
+	// AgentLogSinkRateLimitBurst is the number of log messages that
+	// each agent may send in a burst faster than
+	// AgentLogSinkRateLimit.
+	AgentLogSinkRateLimitBurst = "agent-logsink-rate-limit-burst"
+
+	// LDAPURL is the URL, of the form ldap://host[:port] or
+	// ldaps://host[:port], of an LDAP directory against which the
+	// passwords of users logging in to the controller are checked.
+	// Users who authenticate with the directory are added to the
+	// controller automatically.
+	LDAPURL = "ldap-url"
+
+	// LDAPUserDN is the template from which the distinguished name
+	// of a user's directory entry is made. It must contain a single
+	// "%s", which is replaced with the user name; for example
+	// "uid=%s,ou=people,dc=example,dc=com".
+	LDAPUserDN = "ldap-user-dn"
+
+	// LDAPGroupAccess maps directory groups to the controller access
+	// given to their members. It holds a semicolon-separated list of
+	// <access>=<group-dn> entries; for example
+	// "superuser=cn=juju-admins,ou=groups,dc=example,dc=com".
+	// Group membership is read from the memberOf attribute of the
+	// user's entry. Users in none of the groups cannot log in.
+	LDAPGroupAccess = "ldap-group-access"
+
+	// LocalLoginExpiry is how long, as a duration string such as
+	// "24h", a local user's login session lasts before they must
+	// present their password again.
+	LocalLoginExpiry = "local-login-expiry"
+
+	// ExternalLoginExpiry is how long a login session of a user
+	// authenticated by the external identity manager lasts before
+	// the identity manager must be asked to discharge a new macaroon.
+	ExternalLoginExpiry = "external-login-expiry"
+
+	// LoginDischargeTimeout is how long a local user has, once they
+	// have presented their password, to obtain the discharge that
+	// completes their login.
+	LoginDischargeTimeout = "login-discharge-timeout"
+
+	// MetricsEndpointEnabled determines whether the controller
+	// serves Prometheus metrics about its models at /metrics.
+	MetricsEndpointEnabled = "metrics-endpoint-enabled"
+
+	// LogForwardHTTPURL is the http or https URL to which the
+	// controller POSTs batches of log records, as JSON, in
+	// addition to storing them. Log forwarding over HTTP is
+	// disabled if it is empty.
+	LogForwardHTTPURL = "log-forward-http-url"
+
+	// LogForwardKafkaBrokers holds the comma-separated host:port
+	// addresses of the Kafka brokers to which the controller
+	// produces log records. Log forwarding to Kafka is disabled if
+	// it is empty.
+	LogForwardKafkaBrokers = "log-forward-kafka-brokers"
+
+	// LogForwardKafkaTopic is the Kafka topic to which log
+	// records are produced.
+	LogForwardKafkaTopic = "log-forward-kafka-topic"
+
+	// AutoReplaceControllers determines whether a controller machine
+	// whose agent has been absent for ControllerReplaceDelay is
+	// automatically replaced, as "juju enable-ha" would, and removed
+	// from the replica set.
+	AutoReplaceControllers = "auto-replace-controllers"
+
+	// ControllerReplaceDelay is how long, as a duration string such
+	// as "10m", a controller machine's agent must be absent before
+	// the machine is replaced, if AutoReplaceControllers is set.
+	ControllerReplaceDelay = "controller-replace-delay"
+
+	// Object store types.
+
+	// ObjectStoreMongo stores blobs in MongoDB's GridFS.
+	ObjectStoreMongo = "mongo"
+
+	// ObjectStoreS3 stores blobs in an S3 bucket.
+	ObjectStoreS3 = "s3"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -77,23 +206,104 @@ const (
 
 	// DefaultAPIPort is the default port the API server is listening on.
 	DefaultAPIPort int = 17070
+
+	// DefaultObjectStoreType is the default value for the
+	// ObjectStoreType config value.
+	DefaultObjectStoreType = ObjectStoreMongo
+
+	// DefaultObjectStoreCacheSize is the default value, in MiB, for
+	// the ObjectStoreCacheSize config value.
+	DefaultObjectStoreCacheSize = 1024
+
+	// DefaultAPICompressionThreshold is the default value, in bytes,
+	// for the APICompressionThreshold config value.
+	DefaultAPICompressionThreshold = 64 * 1024
+
+	// DefaultAgentLogSinkRateLimit is the default value for the
+	// AgentLogSinkRateLimit config value.
+	DefaultAgentLogSinkRateLimit = 100
+
+	// DefaultAgentLogSinkRateLimitBurst is the default value for the
+	// AgentLogSinkRateLimitBurst config value.
+	DefaultAgentLogSinkRateLimitBurst = 1000
+
+	// DefaultLocalLoginExpiry is the default value for the
+	// LocalLoginExpiry config value.
+	DefaultLocalLoginExpiry = 24 * time.Hour
+
+	// DefaultExternalLoginExpiry is the default value for the
+	// ExternalLoginExpiry config value.
+	DefaultExternalLoginExpiry = time.Hour
+
+	// DefaultLoginDischargeTimeout is the default value for the
+	// LoginDischargeTimeout config value.
+	DefaultLoginDischargeTimeout = 2 * time.Minute
+
+	// DefaultLogForwardKafkaTopic is the default value for the
+	// LogForwardKafkaTopic config value.
+	DefaultLogForwardKafkaTopic = "juju-logs"
+
+	// DefaultControllerReplaceDelay is the default value for the
+	// ControllerReplaceDelay config value.
+	DefaultControllerReplaceDelay = 10 * time.Minute
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
 // for a controller, never a model.
 var ControllerOnlyConfigAttributes = []string{
+	AgentLogSinkRateLimit,
+	AgentLogSinkRateLimitBurst,
 	AllowModelAccessKey,
+	APICompressionThreshold,
 	APIPort,
 	AutocertDNSNameKey,
 	AutocertURLKey,
+	AutoReplaceControllers,
 	CACertKey,
+	CharmStoreURL,
+	ControllerReplaceDelay,
 	ControllerUUIDKey,
+	ExternalLoginExpiry,
 	IdentityPublicKey,
 	IdentityURL,
+	LDAPGroupAccess,
+	LDAPURL,
+	LDAPUserDN,
+	LocalLoginExpiry,
+	LogForwardHTTPURL,
+	LogForwardKafkaBrokers,
+	LogForwardKafkaTopic,
+	LogOffloadAge,
+	LoginDischargeTimeout,
+	MetricsEndpointEnabled,
+	ObjectStoreAccessKey,
+	ObjectStoreCacheSize,
+	ObjectStoreSecretKey,
+	ObjectStoreType,
+	ObjectStoreURL,
 	SetNUMAControlPolicyKey,
 	StatePort,
 }
 
+// SecretAttributes are attributes which hold secrets used only by the
+// controller itself. They are never sent to agents.
+var SecretAttributes = []string{
+	ObjectStoreSecretKey,
+}
+
+// WithoutSecrets returns a copy of the config without the
+// attributes listed in SecretAttributes.
+func (c Config) WithoutSecrets() Config {
+	result := make(Config, len(c))
+	for k, v := range c {
+		result[k] = v
+	}
+	for _, attr := range SecretAttributes {
+		delete(result, attr)
+	}
+	return result
+}
+
 // ControllerOnlyAttribute returns true if the specified attribute name
 // is only relevant for a controller.
 func ControllerOnlyAttribute(attr string) bool {
@@ -105,6 +315,36 @@ func ControllerOnlyAttribute(attr string) bool {
 	return false
 }
 
+// AllowedUpdateConfigAttributes are the controller attributes which
+// may be changed after the controller has been bootstrapped. Workers
+// that use them should watch the controller config for changes.
+var AllowedUpdateConfigAttributes = []string{
+	AuditingEnabled,
+	AutoReplaceControllers,
+	ControllerReplaceDelay,
+	ExternalLoginExpiry,
+	LDAPGroupAccess,
+	LDAPURL,
+	LDAPUserDN,
+	LocalLoginExpiry,
+	LogForwardHTTPURL,
+	LogForwardKafkaBrokers,
+	LogForwardKafkaTopic,
+	LoginDischargeTimeout,
+	MetricsEndpointEnabled,
+}
+
+// UpdateAllowed returns true if the specified attribute name may be
+// changed after the controller has been bootstrapped.
+func UpdateAllowed(attr string) bool {
+	for _, a := range AllowedUpdateConfigAttributes {
+		if attr == a {
+			return true
+		}
+	}
+	return false
+}
+
 type Config map[string]interface{}
 
 // Validate validates the controller configuration.
@@ -182,6 +422,15 @@ func (c Config) AuditingEnabled() bool {
 	return false
 }
 
+// MetricsEndpointEnabled returns whether the controller serves
+// Prometheus metrics at /metrics. The default is false.
+func (c Config) MetricsEndpointEnabled() bool {
+	if v, ok := c[MetricsEndpointEnabled]; ok {
+		return v.(bool)
+	}
+	return false
+}
+
 // ControllerUUID returns the uuid for the model's controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -246,6 +495,230 @@ func (c Config) AllowModelAccess() bool {
 	return value
 }
 
+// ObjectStoreType returns the type of store in which the controller
+// keeps large blobs. See ObjectStoreType for more details.
+func (c Config) ObjectStoreType() string {
+	if v := c.asString(ObjectStoreType); v != "" {
+		return v
+	}
+	return DefaultObjectStoreType
+}
+
+// ObjectStoreURL returns the location of the external object store.
+func (c Config) ObjectStoreURL() string {
+	return c.asString(ObjectStoreURL)
+}
+
+// ObjectStoreAccessKey returns the access key for the external
+// object store.
+func (c Config) ObjectStoreAccessKey() string {
+	return c.asString(ObjectStoreAccessKey)
+}
+
+// ObjectStoreSecretKey returns the secret key for the external
+// object store.
+func (c Config) ObjectStoreSecretKey() string {
+	return c.asString(ObjectStoreSecretKey)
+}
+
+// ObjectStoreCacheSize returns the maximum size, in MiB, of the cache
+// of external objects kept on each controller machine.
+func (c Config) ObjectStoreCacheSize() int {
+	// Values obtained over the api are encoded as float64.
+	switch v := c[ObjectStoreCacheSize].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return DefaultObjectStoreCacheSize
+}
+
+// CharmStoreURL returns the URL of the charm store the controller
+// uses, or the empty string if the public charm store is used.
+func (c Config) CharmStoreURL() string {
+	return c.asString(CharmStoreURL)
+}
+
+// APICompressionThreshold returns the size, in bytes, above which
+// API responses are compressed. See APICompressionThreshold for
+// more details.
+func (c Config) APICompressionThreshold() int {
+	return c.intOrDefault(APICompressionThreshold, DefaultAPICompressionThreshold)
+}
+
+// AgentLogSinkRateLimit returns the number of log messages per second
+// that each agent may send to the controller. See AgentLogSinkRateLimit
+// for more details.
+func (c Config) AgentLogSinkRateLimit() int {
+	return c.intOrDefault(AgentLogSinkRateLimit, DefaultAgentLogSinkRateLimit)
+}
+
+// AgentLogSinkRateLimitBurst returns the number of log messages that
+// each agent may send in a burst. See AgentLogSinkRateLimitBurst for
+// more details.
+func (c Config) AgentLogSinkRateLimitBurst() int {
+	return c.intOrDefault(AgentLogSinkRateLimitBurst, DefaultAgentLogSinkRateLimitBurst)
+}
+
+// intOrDefault returns the integer value of the given attribute, or
+// defaultValue if it is not set.
+func (c Config) intOrDefault(name string, defaultValue int) int {
+	// Values obtained over the api are encoded as float64.
+	switch v := c[name].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return defaultValue
+}
+
+// LogOffloadAge returns the age after which log messages are moved to
+// the external object store, or zero if they are not. See
+// LogOffloadAge for more details.
+func (c Config) LogOffloadAge() time.Duration {
+	return c.durationOrDefault(LogOffloadAge, 0)
+}
+
+// LDAPURL returns the URL of the LDAP directory used to authenticate
+// users, or the empty string if there is none.
+func (c Config) LDAPURL() string {
+	return c.asString(LDAPURL)
+}
+
+// LDAPUserDN returns the template from which the distinguished names
+// of users' directory entries are made. See LDAPUserDN for more
+// details.
+func (c Config) LDAPUserDN() string {
+	return c.asString(LDAPUserDN)
+}
+
+// LDAPGroupAccess returns the controller access given to the members
+// of each directory group, keyed by the group's distinguished name.
+// Entries that cannot be parsed are ignored; Validate reports them.
+func (c Config) LDAPGroupAccess() map[string]permission.Access {
+	result, _ := parseLDAPGroupAccess(c.asString(LDAPGroupAccess))
+	return result
+}
+
+// AutoReplaceControllers reports whether controller machines whose
+// agents have been absent for ControllerReplaceDelay are replaced
+// automatically. The default is false.
+func (c Config) AutoReplaceControllers() bool {
+	value, _ := c[AutoReplaceControllers].(bool)
+	return value
+}
+
+// ControllerReplaceDelay returns how long a controller machine's
+// agent must be absent before the machine is replaced. See
+// ControllerReplaceDelay for more details.
+func (c Config) ControllerReplaceDelay() time.Duration {
+	return c.durationOrDefault(ControllerReplaceDelay, DefaultControllerReplaceDelay)
+}
+
+// LocalLoginExpiry returns how long a local user's login session
+// lasts. See LocalLoginExpiry for more details.
+func (c Config) LocalLoginExpiry() time.Duration {
+	return c.durationOrDefault(LocalLoginExpiry, DefaultLocalLoginExpiry)
+}
+
+// ExternalLoginExpiry returns how long the login session of an
+// external user lasts. See ExternalLoginExpiry for more details.
+func (c Config) ExternalLoginExpiry() time.Duration {
+	return c.durationOrDefault(ExternalLoginExpiry, DefaultExternalLoginExpiry)
+}
+
+// LoginDischargeTimeout returns how long a local user has to complete
+// their login. See LoginDischargeTimeout for more details.
+func (c Config) LoginDischargeTimeout() time.Duration {
+	return c.durationOrDefault(LoginDischargeTimeout, DefaultLoginDischargeTimeout)
+}
+
+// durationOrDefault returns the named attribute as a duration, or
+// the supplied default if it is not set. Invalid durations are
+// reported by Validate.
+// LogForwardHTTPURL returns the URL to which log records are POSTed,
+// or "" if they are not forwarded over HTTP.
+func (c Config) LogForwardHTTPURL() string {
+	return c.asString(LogForwardHTTPURL)
+}
+
+// LogForwardKafkaBrokers returns the addresses of the Kafka brokers to
+// which log records are produced, or nil if they are not forwarded to
+// Kafka.
+func (c Config) LogForwardKafkaBrokers() []string {
+	var brokers []string
+	for _, broker := range strings.Split(c.asString(LogForwardKafkaBrokers), ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	return brokers
+}
+
+// LogForwardKafkaTopic returns the Kafka topic to which log records
+// are produced.
+func (c Config) LogForwardKafkaTopic() string {
+	if v := c.asString(LogForwardKafkaTopic); v != "" {
+		return v
+	}
+	return DefaultLogForwardKafkaTopic
+}
+
+func (c Config) durationOrDefault(name string, defaultValue time.Duration) time.Duration {
+	if v := c.asString(name); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+func parseLDAPGroupAccess(s string) (map[string]permission.Access, error) {
+	result := make(map[string]permission.Access)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, errors.Errorf("%s: expected <access>=<group-dn>, got %q", LDAPGroupAccess, entry)
+		}
+		access := permission.Access(strings.TrimSpace(parts[0]))
+		if err := permission.ValidateControllerAccess(access); err != nil {
+			return nil, errors.Annotate(err, LDAPGroupAccess)
+		}
+		result[strings.TrimSpace(parts[1])] = access
+	}
+	return result, nil
+}
+
+func validateLDAP(c Config) error {
+	ldapURL := c.LDAPURL()
+	if ldapURL == "" {
+		if c.LDAPUserDN() != "" || c.asString(LDAPGroupAccess) != "" {
+			return errors.Errorf("%s must be specified to use LDAP authentication", LDAPURL)
+		}
+		return nil
+	}
+	u, err := url.Parse(ldapURL)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", LDAPURL)
+	}
+	if (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+		return errors.Errorf("%s: expected ldap or ldaps URL, got %q", LDAPURL, ldapURL)
+	}
+	if strings.Count(c.LDAPUserDN(), "%s") != 1 {
+		return errors.Errorf("%s: expected a template containing one %%s, got %q", LDAPUserDN, c.LDAPUserDN())
+	}
+	if _, err := parseLDAPGroupAccess(c.asString(LDAPGroupAccess)); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -281,6 +754,114 @@ func Validate(c Config) error {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if v := c.APICompressionThreshold(); v < 0 {
+		return errors.Errorf("%s: expected non-negative value, got %d", APICompressionThreshold, v)
+	}
+
+	if v := c.AgentLogSinkRateLimit(); v < 0 {
+		return errors.Errorf("%s: expected non-negative value, got %d", AgentLogSinkRateLimit, v)
+	}
+	if v := c.AgentLogSinkRateLimitBurst(); v < 0 {
+		return errors.Errorf("%s: expected non-negative value, got %d", AgentLogSinkRateLimitBurst, v)
+	}
+
+	if err := validateObjectStore(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	if v, ok := c[CharmStoreURL].(string); ok {
+		if err := ValidateCharmStoreURL(v); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if err := validateLDAP(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := validateLogForward(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.asString(LogOffloadAge) != "" && c.ObjectStoreType() == ObjectStoreMongo {
+		return errors.Errorf("%s requires an external object store", LogOffloadAge)
+	}
+
+	for _, attr := range []string{LocalLoginExpiry, ExternalLoginExpiry, LoginDischargeTimeout, ControllerReplaceDelay, LogOffloadAge} {
+		v := c.asString(attr)
+		if v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", attr)
+		}
+		if d <= 0 {
+			return errors.Errorf("%s: expected positive duration, got %q", attr, v)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCharmStoreURL returns an error if the given charm store
+// URL is not an absolute http or https URL.
+func ValidateCharmStoreURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", CharmStoreURL)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("%s: expected http or https URL, got %q", CharmStoreURL, s)
+	}
+	return nil
+}
+
+// validKafkaTopic matches the names that Kafka allows for topics.
+var validKafkaTopic = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+func validateLogForward(c Config) error {
+	if v := c.LogForwardHTTPURL(); v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", LogForwardHTTPURL)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("%s: expected http or https URL, got %q", LogForwardHTTPURL, v)
+		}
+	}
+	for _, broker := range c.LogForwardKafkaBrokers() {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			return errors.Errorf("%s: expected host:port, got %q", LogForwardKafkaBrokers, broker)
+		}
+	}
+	if topic := c.LogForwardKafkaTopic(); !validKafkaTopic.MatchString(topic) {
+		return errors.Errorf("%s: invalid topic name %q", LogForwardKafkaTopic, topic)
+	}
+	return nil
+}
+
+func validateObjectStore(c Config) error {
+	if c.ObjectStoreCacheSize() < 0 {
+		return errors.Errorf("%s: expected non-negative value, got %d", ObjectStoreCacheSize, c.ObjectStoreCacheSize())
+	}
+	switch storeType := c.ObjectStoreType(); storeType {
+	case ObjectStoreMongo:
+		return nil
+	case ObjectStoreS3:
+	default:
+		return errors.NotValidf("%s %q", ObjectStoreType, storeType)
+	}
+	u, err := url.Parse(c.ObjectStoreURL())
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", ObjectStoreURL)
+	}
+	if u.Scheme != ObjectStoreS3 || u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return errors.Errorf("%s: expected s3://<region>/<bucket>, got %q", ObjectStoreURL, c.ObjectStoreURL())
+	}
+	if c.ObjectStoreAccessKey() == "" || c.ObjectStoreSecretKey() == "" {
+		return errors.Errorf("%s and %s must be specified for an external object store", ObjectStoreAccessKey, ObjectStoreSecretKey)
+	}
 	return nil
 }
 
@@ -291,23 +872,67 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
+	AuditingEnabled:            schema.Bool(),
+	APIPort:                    schema.ForceInt(),
+	StatePort:                  schema.ForceInt(),
+	IdentityURL:                schema.String(),
+	IdentityPublicKey:          schema.String(),
+	SetNUMAControlPolicyKey:    schema.Bool(),
+	AutocertURLKey:             schema.String(),
+	AutocertDNSNameKey:         schema.String(),
+	AllowModelAccessKey:        schema.Bool(),
+	ObjectStoreType:            schema.OneOf(schema.Const(ObjectStoreMongo), schema.Const(ObjectStoreS3)),
+	ObjectStoreURL:             schema.String(),
+	ObjectStoreAccessKey:       schema.String(),
+	ObjectStoreSecretKey:       schema.String(),
+	ObjectStoreCacheSize:       schema.ForceInt(),
+	LogOffloadAge:              schema.String(),
+	CharmStoreURL:              schema.String(),
+	APICompressionThreshold:    schema.ForceInt(),
+	AgentLogSinkRateLimit:      schema.ForceInt(),
+	AgentLogSinkRateLimitBurst: schema.ForceInt(),
+	LDAPURL:                    schema.String(),
+	LDAPUserDN:                 schema.String(),
+	LDAPGroupAccess:            schema.String(),
+	LocalLoginExpiry:           schema.String(),
+	ExternalLoginExpiry:        schema.String(),
+	LoginDischargeTimeout:      schema.String(),
+	MetricsEndpointEnabled:     schema.Bool(),
+	LogForwardHTTPURL:          schema.String(),
+	LogForwardKafkaBrokers:     schema.String(),
+	LogForwardKafkaTopic:       schema.String(),
+	AutoReplaceControllers:     schema.Bool(),
+	ControllerReplaceDelay:     schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
+	APIPort:                    DefaultAPIPort,
+	AuditingEnabled:            DefaultAuditingEnabled,
+	StatePort:                  DefaultStatePort,
+	IdentityURL:                schema.Omit,
+	IdentityPublicKey:          schema.Omit,
+	SetNUMAControlPolicyKey:    DefaultNUMAControlPolicy,
+	AutocertURLKey:             schema.Omit,
+	AutocertDNSNameKey:         schema.Omit,
+	AllowModelAccessKey:        schema.Omit,
+	ObjectStoreType:            schema.Omit,
+	ObjectStoreURL:             schema.Omit,
+	ObjectStoreAccessKey:       schema.Omit,
+	ObjectStoreSecretKey:       schema.Omit,
+	ObjectStoreCacheSize:       schema.Omit,
+	LogOffloadAge:              schema.Omit,
+	CharmStoreURL:              schema.Omit,
+	APICompressionThreshold:    schema.Omit,
+	AgentLogSinkRateLimit:      schema.Omit,
+	AgentLogSinkRateLimitBurst: schema.Omit,
+	LDAPURL:                    schema.Omit,
+	LDAPUserDN:                 schema.Omit,
+	LDAPGroupAccess:            schema.Omit,
+	LocalLoginExpiry:           schema.Omit,
+	ExternalLoginExpiry:        schema.Omit,
+	LoginDischargeTimeout:      schema.Omit,
+	MetricsEndpointEnabled:     schema.Omit,
+	LogForwardHTTPURL:          schema.Omit,
+	LogForwardKafkaBrokers:     schema.Omit,
+	LogForwardKafkaTopic:       schema.Omit,
+	AutoReplaceControllers:     schema.Omit,
+	ControllerReplaceDelay:     schema.Omit,
 })
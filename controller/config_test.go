@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/testing"
 )
 
@@ -121,6 +122,186 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "S3 object store OK",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.ObjectStoreType:      "s3",
+		controller.ObjectStoreURL:       "s3://us-east-1/juju-blobs",
+		controller.ObjectStoreAccessKey: "access",
+		controller.ObjectStoreSecretKey: "secret",
+	},
+}, {
+	about: "unknown object store type",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.ObjectStoreType: "tape",
+	},
+	expectError: `object-store-type "tape" not valid`,
+}, {
+	about: "S3 object store requires bucket",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.ObjectStoreType:      "s3",
+		controller.ObjectStoreURL:       "s3://us-east-1",
+		controller.ObjectStoreAccessKey: "access",
+		controller.ObjectStoreSecretKey: "secret",
+	},
+	expectError: `object-store-url: expected s3://<region>/<bucket>, got "s3://us-east-1"`,
+}, {
+	about: "S3 object store requires credentials",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.ObjectStoreType: "s3",
+		controller.ObjectStoreURL:  "s3://us-east-1/juju-blobs",
+	},
+	expectError: `object-store-access-key and object-store-secret-key must be specified for an external object store`,
+}, {
+	about: "negative object store cache size",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.ObjectStoreCacheSize: -1,
+	},
+	expectError: `object-store-cache-size: expected non-negative value, got -1`,
+}, {
+	about: "charm store URL OK",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.CharmStoreURL: "http://charms.internal:8080",
+	},
+}, {
+	about: "charm store URL must be http or https",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.CharmStoreURL: "/srv/charms",
+	},
+	expectError: `charmstore-url: expected http or https URL, got "/srv/charms"`,
+}, {
+	about: "negative API compression threshold",
+	config: controller.Config{
+		controller.CACertKey:               testing.CACert,
+		controller.APICompressionThreshold: -1,
+	},
+	expectError: `api-compression-threshold: expected non-negative value, got -1`,
+}, {
+	about: "log offload without an external object store",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.LogOffloadAge: "24h",
+	},
+	expectError: `log-offload-age requires an external object store`,
+}, {
+	about: "negative agent logsink rate limit",
+	config: controller.Config{
+		controller.CACertKey:             testing.CACert,
+		controller.AgentLogSinkRateLimit: -1,
+	},
+	expectError: `agent-logsink-rate-limit: expected non-negative value, got -1`,
+}, {
+	about: "LDAP OK",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.LDAPURL:         "ldaps://ldap.example.com",
+		controller.LDAPUserDN:      "uid=%s,ou=people,dc=example,dc=com",
+		controller.LDAPGroupAccess: "superuser=cn=admins,dc=example,dc=com; login=cn=staff,dc=example,dc=com",
+	},
+}, {
+	about: "LDAP URL must be ldap or ldaps",
+	config: controller.Config{
+		controller.CACertKey:  testing.CACert,
+		controller.LDAPURL:    "https://ldap.example.com",
+		controller.LDAPUserDN: "uid=%s,dc=example,dc=com",
+	},
+	expectError: `ldap-url: expected ldap or ldaps URL, got "https://ldap.example.com"`,
+}, {
+	about: "LDAP user DN must be a template",
+	config: controller.Config{
+		controller.CACertKey:  testing.CACert,
+		controller.LDAPURL:    "ldaps://ldap.example.com",
+		controller.LDAPUserDN: "uid=bob,dc=example,dc=com",
+	},
+	expectError: `ldap-user-dn: expected a template containing one %s, got "uid=bob,dc=example,dc=com"`,
+}, {
+	about: "LDAP settings require URL",
+	config: controller.Config{
+		controller.CACertKey:  testing.CACert,
+		controller.LDAPUserDN: "uid=%s,dc=example,dc=com",
+	},
+	expectError: `ldap-url must be specified to use LDAP authentication`,
+}, {
+	about: "LDAP group access must be controller access",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.LDAPURL:         "ldaps://ldap.example.com",
+		controller.LDAPUserDN:      "uid=%s,dc=example,dc=com",
+		controller.LDAPGroupAccess: "admin=cn=admins,dc=example,dc=com",
+	},
+	expectError: `ldap-group-access: "admin" controller access not valid`,
+}, {
+	about: "login expiries",
+	config: controller.Config{
+		controller.CACertKey:             testing.CACert,
+		controller.LocalLoginExpiry:      "168h",
+		controller.ExternalLoginExpiry:   "30m",
+		controller.LoginDischargeTimeout: "5m",
+	},
+}, {
+	about: "invalid login expiry",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.LocalLoginExpiry: "a day",
+	},
+	expectError: `invalid local-login-expiry: time: invalid duration .*`,
+}, {
+	about: "controller replacement",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.AutoReplaceControllers: true,
+		controller.ControllerReplaceDelay: "30m",
+	},
+}, {
+	about: "negative controller replace delay",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.ControllerReplaceDelay: "-1m",
+	},
+	expectError: `controller-replace-delay: expected positive duration, got "-1m"`,
+}, {
+	about: "non-positive login expiry",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.ExternalLoginExpiry: "0s",
+	},
+	expectError: `external-login-expiry: expected positive duration, got "0s"`,
+}, {
+	about: "log forwarding targets",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.LogForwardHTTPURL:      "https://logs.example.com/juju",
+		controller.LogForwardKafkaBrokers: "kafka-1:9092, kafka-2:9092",
+		controller.LogForwardKafkaTopic:   "controller.logs",
+	},
+}, {
+	about: "invalid log forwarding URL",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.LogForwardHTTPURL: "logs.example.com",
+	},
+	expectError: `log-forward-http-url: expected http or https URL, got "logs.example.com"`,
+}, {
+	about: "invalid Kafka broker",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.LogForwardKafkaBrokers: "kafka-1",
+	},
+	expectError: `log-forward-kafka-brokers: expected host:port, got "kafka-1"`,
+}, {
+	about: "invalid Kafka topic",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.LogForwardKafkaTopic: "juju logs",
+	},
+	expectError: `log-forward-kafka-topic: invalid topic name "juju logs"`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -134,3 +315,126 @@ func (s *ConfigSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *ConfigSuite) TestObjectStoreDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ObjectStoreType(), gc.Equals, controller.ObjectStoreMongo)
+	c.Assert(cfg.ObjectStoreURL(), gc.Equals, "")
+	c.Assert(cfg.ObjectStoreCacheSize(), gc.Equals, controller.DefaultObjectStoreCacheSize)
+}
+
+func (s *ConfigSuite) TestAPICompressionThreshold(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APICompressionThreshold(), gc.Equals, controller.DefaultAPICompressionThreshold)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.APICompressionThreshold: 0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APICompressionThreshold(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestAgentLogSinkRateLimit(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentLogSinkRateLimit(), gc.Equals, controller.DefaultAgentLogSinkRateLimit)
+	c.Assert(cfg.AgentLogSinkRateLimitBurst(), gc.Equals, controller.DefaultAgentLogSinkRateLimitBurst)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AgentLogSinkRateLimit:      0,
+		controller.AgentLogSinkRateLimitBurst: 50,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentLogSinkRateLimit(), gc.Equals, 0)
+	c.Assert(cfg.AgentLogSinkRateLimitBurst(), gc.Equals, 50)
+}
+
+func (s *ConfigSuite) TestLogOffloadAge(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.LogOffloadAge(), gc.Equals, time.Duration(0))
+	cfg = controller.Config{controller.LogOffloadAge: "36h"}
+	c.Assert(cfg.LogOffloadAge(), gc.Equals, 36*time.Hour)
+}
+
+func (s *ConfigSuite) TestLDAPGroupAccess(c *gc.C) {
+	cfg := controller.Config{
+		controller.LDAPGroupAccess: "superuser=cn=admins,dc=example,dc=com;;login=cn=staff,dc=example,dc=com",
+	}
+	c.Assert(cfg.LDAPGroupAccess(), jc.DeepEquals, map[string]permission.Access{
+		"cn=admins,dc=example,dc=com": permission.SuperuserAccess,
+		"cn=staff,dc=example,dc=com":  permission.LoginAccess,
+	})
+	c.Assert(controller.Config{}.LDAPGroupAccess(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestLoginExpiries(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LocalLoginExpiry(), gc.Equals, controller.DefaultLocalLoginExpiry)
+	c.Assert(cfg.ExternalLoginExpiry(), gc.Equals, controller.DefaultExternalLoginExpiry)
+	c.Assert(cfg.LoginDischargeTimeout(), gc.Equals, controller.DefaultLoginDischargeTimeout)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.LocalLoginExpiry:      "168h",
+		controller.ExternalLoginExpiry:   "30m",
+		controller.LoginDischargeTimeout: "5m",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LocalLoginExpiry(), gc.Equals, 168*time.Hour)
+	c.Assert(cfg.ExternalLoginExpiry(), gc.Equals, 30*time.Minute)
+	c.Assert(cfg.LoginDischargeTimeout(), gc.Equals, 5*time.Minute)
+}
+
+func (s *ConfigSuite) TestLogForwardKafka(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.LogForwardKafkaBrokers(), gc.HasLen, 0)
+	c.Assert(cfg.LogForwardKafkaTopic(), gc.Equals, controller.DefaultLogForwardKafkaTopic)
+
+	cfg = controller.Config{
+		controller.LogForwardKafkaBrokers: "kafka-1:9092, kafka-2:9092,",
+		controller.LogForwardKafkaTopic:   "controller.logs",
+	}
+	c.Assert(cfg.LogForwardKafkaBrokers(), jc.DeepEquals, []string{"kafka-1:9092", "kafka-2:9092"})
+	c.Assert(cfg.LogForwardKafkaTopic(), gc.Equals, "controller.logs")
+}
+
+func (s *ConfigSuite) TestMetricsEndpointEnabled(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MetricsEndpointEnabled(), jc.IsFalse)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.MetricsEndpointEnabled: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MetricsEndpointEnabled(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestControllerReplacement(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AutoReplaceControllers(), jc.IsFalse)
+	c.Assert(cfg.ControllerReplaceDelay(), gc.Equals, controller.DefaultControllerReplaceDelay)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AutoReplaceControllers: true,
+		controller.ControllerReplaceDelay: "30m",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AutoReplaceControllers(), jc.IsTrue)
+	c.Assert(cfg.ControllerReplaceDelay(), gc.Equals, 30*time.Minute)
+}
+
+func (s *ConfigSuite) TestWithoutSecrets(c *gc.C) {
+	cfg := controller.Config{
+		controller.ObjectStoreAccessKey: "key",
+		controller.ObjectStoreSecretKey: "secret",
+	}
+	c.Assert(cfg.WithoutSecrets(), jc.DeepEquals, controller.Config{
+		controller.ObjectStoreAccessKey: "key",
+	})
+	// The original config is unchanged.
+	c.Assert(cfg.ObjectStoreSecretKey(), gc.Equals, "secret")
+}
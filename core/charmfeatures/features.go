@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmfeatures defines the juju features that a charm may
+// declare it requires, so that a charm needing something the
+// controller cannot provide is refused when it is deployed rather than
+// failing in its first hook.
+//
+// A charm declares its requirements in its metadata.yaml:
+//
+//	juju-features: [leadership, storage]
+//
+// The minimum juju version a charm needs continues to be declared
+// with the min-juju-version key.
+package charmfeatures
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/yaml.v2"
+)
+
+// MetadataKey is the metadata.yaml key under which a charm lists the
+// juju features it requires.
+const MetadataKey = "juju-features"
+
+// The juju features that charms may require.
+const (
+	Actions    = "actions"
+	Leadership = "leadership"
+	LXDProfile = "lxd-profile"
+	Metrics    = "metrics"
+	Payloads   = "payloads"
+	Resources  = "resources"
+	Storage    = "storage"
+)
+
+// Supported returns the features that this version of juju provides.
+func Supported() set.Strings {
+	return set.NewStrings(
+		Actions,
+		Leadership,
+		LXDProfile,
+		Metrics,
+		Payloads,
+		Resources,
+		Storage,
+	)
+}
+
+var validFeature = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Parse returns the features required by the charm whose metadata.yaml
+// holds the given content, sorted and without duplicates.
+func Parse(metadata []byte) ([]string, error) {
+	var meta struct {
+		Features []string `yaml:"juju-features"`
+	}
+	if err := yaml.Unmarshal(metadata, &meta); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse %s", MetadataKey)
+	}
+	features := set.NewStrings()
+	for _, feature := range meta.Features {
+		if !validFeature.MatchString(feature) {
+			return nil, errors.NotValidf("juju feature %q", feature)
+		}
+		features.Add(feature)
+	}
+	if features.IsEmpty() {
+		return nil, nil
+	}
+	return features.SortedValues(), nil
+}
+
+// ReadArchive returns the features required by the charm archive at
+// the given path.
+func ReadArchive(archivePath string) ([]string, error) {
+	zipr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot open charm archive")
+	}
+	defer zipr.Close()
+	for _, f := range zipr.File {
+		if path.Clean(f.Name) != "metadata.yaml" {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot open metadata.yaml")
+		}
+		defer r.Close()
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read metadata.yaml")
+		}
+		return Parse(data)
+	}
+	return nil, errors.NotFoundf("metadata.yaml")
+}
+
+// UnsupportedError is returned by Check when a charm requires juju
+// features that are not available.
+type UnsupportedError struct {
+	// Features holds the unavailable features, sorted.
+	Features []string
+}
+
+// Error is part of the error interface.
+func (e *UnsupportedError) Error() string {
+	noun := "feature"
+	if len(e.Features) > 1 {
+		noun = "features"
+	}
+	return fmt.Sprintf(
+		"charm requires juju %s not supported by this controller: %s; "+
+			"upgrade the controller before deploying it",
+		noun, strings.Join(e.Features, ", "),
+	)
+}
+
+// IsUnsupportedError reports whether the cause of err is an
+// *UnsupportedError.
+func IsUnsupportedError(err error) bool {
+	_, ok := errors.Cause(err).(*UnsupportedError)
+	return ok
+}
+
+// Check returns an *UnsupportedError if any of the required features
+// is not supported by this version of juju.
+func Check(required []string) error {
+	supported := Supported()
+	var missing []string
+	for _, feature := range required {
+		if !supported.Contains(feature) {
+			missing = append(missing, feature)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return &UnsupportedError{Features: missing}
+}
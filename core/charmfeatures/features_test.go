@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmfeatures_test
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/charmfeatures"
+)
+
+type FeaturesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FeaturesSuite{})
+
+func (s *FeaturesSuite) TestParse(c *gc.C) {
+	features, err := charmfeatures.Parse([]byte(`
+name: foo
+juju-features: [storage, leadership, storage]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"leadership", "storage"})
+}
+
+func (s *FeaturesSuite) TestParseNone(c *gc.C) {
+	features, err := charmfeatures.Parse([]byte("name: foo\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, gc.IsNil)
+}
+
+func (s *FeaturesSuite) TestParseInvalid(c *gc.C) {
+	_, err := charmfeatures.Parse([]byte("juju-features: {a: b}\n"))
+	c.Assert(err, gc.ErrorMatches, "cannot parse juju-features: .*")
+	_, err = charmfeatures.Parse([]byte("juju-features: [Bad Feature]\n"))
+	c.Assert(err, gc.ErrorMatches, `juju feature "Bad Feature" not valid`)
+}
+
+func (s *FeaturesSuite) TestReadArchive(c *gc.C) {
+	archivePath := writeArchive(c, map[string]string{
+		"metadata.yaml": "name: foo\njuju-features: [leadership]\n",
+	})
+	features, err := charmfeatures.ReadArchive(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"leadership"})
+}
+
+func (s *FeaturesSuite) TestReadArchiveNoMetadata(c *gc.C) {
+	archivePath := writeArchive(c, map[string]string{
+		"config.yaml": "options: {}\n",
+	})
+	_, err := charmfeatures.ReadArchive(archivePath)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FeaturesSuite) TestCheck(c *gc.C) {
+	err := charmfeatures.Check(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = charmfeatures.Check([]string{"leadership", "storage"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = charmfeatures.Check([]string{"storage", "teleport"})
+	c.Assert(err, jc.Satisfies, charmfeatures.IsUnsupportedError)
+	c.Assert(err, gc.ErrorMatches, "charm requires juju feature not supported by this controller: teleport; "+
+		"upgrade the controller before deploying it")
+
+	err = charmfeatures.Check([]string{"warp", "teleport"})
+	c.Assert(err, jc.DeepEquals, &charmfeatures.UnsupportedError{
+		Features: []string{"teleport", "warp"},
+	})
+	c.Assert(err, gc.ErrorMatches, "charm requires juju features not supported by this controller: teleport, warp; .*")
+}
+
+func writeArchive(c *gc.C, files map[string]string) string {
+	archivePath := filepath.Join(c.MkDir(), "charm.zip")
+	f, err := os.Create(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	zipw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zipw.Create(name)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = w.Write([]byte(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(zipw.Close(), jc.ErrorIsNil)
+	return archivePath
+}
@@ -43,7 +43,8 @@ func (s *ApplicationOffer) String() string {
 type ApplicationOfferFilter struct {
 	ApplicationOffer
 
-	// AllowedUsers are the users allowed to consume the application.
+	// AllowedUsers, if set, restricts the results to those offers
+	// that any of the specified users are allowed to consume.
 	AllowedUsers []string
 }
 
@@ -61,6 +62,14 @@ type ApplicationDirectory interface {
 
 	// Remove removes the application offer at the specified URL.
 	Remove(url string) error
+
+	// GrantAccess allows the specified users to consume the
+	// application offer at the specified URL.
+	GrantAccess(url string, users ...string) error
+
+	// RevokeAccess prevents the specified users from consuming the
+	// application offer at the specified URL.
+	RevokeAccess(url string, users ...string) error
 }
 
 // OfferedApplication holds the details of applications offered
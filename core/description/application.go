@@ -29,6 +29,7 @@ type Application interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	ExposedCIDRs() []string
 	MinUnits() int
 
 	EndpointBindings() map[string]string
@@ -65,9 +66,10 @@ type application struct {
 
 	// ForceCharm is true if an upgrade charm is forced.
 	// It means upgrade even if the charm is in an error state.
-	ForceCharm_ bool `yaml:"force-charm,omitempty"`
-	Exposed_    bool `yaml:"exposed,omitempty"`
-	MinUnits_   int  `yaml:"min-units,omitempty"`
+	ForceCharm_   bool     `yaml:"force-charm,omitempty"`
+	Exposed_      bool     `yaml:"exposed,omitempty"`
+	ExposedCIDRs_ []string `yaml:"exposed-cidrs,omitempty"`
+	MinUnits_     int      `yaml:"min-units,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -102,6 +104,7 @@ type ApplicationArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedCIDRs         []string
 	MinUnits             int
 	EndpointBindings     map[string]string
 	Settings             map[string]interface{}
@@ -122,6 +125,7 @@ func newApplication(args ApplicationArgs) *application {
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
+		ExposedCIDRs_:         args.ExposedCIDRs,
 		MinUnits_:             args.MinUnits,
 		EndpointBindings_:     args.EndpointBindings,
 		Settings_:             args.Settings,
@@ -186,6 +190,11 @@ func (a *application) Exposed() bool {
 	return a.Exposed_
 }
 
+// ExposedCIDRs implements Application.
+func (a *application) ExposedCIDRs() []string {
+	return a.ExposedCIDRs_
+}
+
 // MinUnits implements Application.
 func (a *application) MinUnits() int {
 	return a.MinUnits_
@@ -394,6 +403,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"exposed-cidrs":       schema.List(schema.String()),
 		"min-units":           schema.Int(),
 		"status":              schema.StringMap(schema.Any()),
 		"endpoint-bindings":   schema.StringMap(schema.String()),
@@ -410,6 +420,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"subordinate":         false,
 		"force-charm":         false,
 		"exposed":             false,
+		"exposed-cidrs":       schema.Omit,
 		"min-units":           int64(0),
 		"leader":              "",
 		"metrics-creds":       "",
@@ -442,6 +453,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		Settings_:             valid["settings"].(map[string]interface{}),
 		Leader_:               valid["leader"].(string),
 		LeadershipSettings_:   valid["leadership-settings"].(map[string]interface{}),
+		ExposedCIDRs_:         convertToStringSlice(valid["exposed-cidrs"]),
 		StatusHistory_:        newStatusHistory(),
 	}
 	result.importAnnotations(valid)
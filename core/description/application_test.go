@@ -201,6 +201,16 @@ func (s *ApplicationSerializationSuite) TestEndpointBindings(c *gc.C) {
 	c.Assert(application.EndpointBindings(), jc.DeepEquals, args.EndpointBindings)
 }
 
+func (s *ApplicationSerializationSuite) TestExposedCIDRs(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.Exposed = true
+	args.ExposedCIDRs = []string{"10.0.0.0/8", "192.168.1.0/24"}
+	initial := minimalApplication(args)
+	application := s.exportImport(c, initial)
+	c.Assert(application.Exposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, args.ExposedCIDRs)
+}
+
 func (s *ApplicationSerializationSuite) TestAnnotations(c *gc.C) {
 	initial := minimalApplication()
 	annotations := map[string]string{
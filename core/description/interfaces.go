@@ -299,6 +299,18 @@ type SSHHostKey interface {
 	Keys() []string
 }
 
+// Secret represents a value that a charm has stored in the controller
+// on behalf of its application.
+type Secret interface {
+	Application() string
+	Name() string
+	Revision() int
+	Value() string
+	Created() time.Time
+	Updated() time.Time
+	RotateInterval() time.Duration
+}
+
 // CloudImageMetadata represents an IP cloudimagemetadata.
 type CloudImageMetadata interface {
 	Stream() string
@@ -64,6 +64,9 @@ type Model interface {
 	SSHHostKeys() []SSHHostKey
 	AddSSHHostKey(SSHHostKeyArgs) SSHHostKey
 
+	Secrets() []Secret
+	AddSecret(SecretArgs) Secret
+
 	CloudImageMetadata() []CloudImageMetadata
 	AddCloudImageMetadata(CloudImageMetadataArgs) CloudImageMetadata
 
@@ -120,6 +123,7 @@ func NewModel(args ModelArgs) Model {
 	m.setSubnets(nil)
 	m.setIPAddresses(nil)
 	m.setSSHHostKeys(nil)
+	m.setSecrets(nil)
 	m.setCloudImageMetadatas(nil)
 	m.setActions(nil)
 	m.setVolumes(nil)
@@ -203,6 +207,8 @@ type model struct {
 
 	SSHHostKeys_ sshHostKeys `yaml:"ssh-host-keys"`
 
+	Secrets_ secrets `yaml:"secrets"`
+
 	Sequences_ map[string]int `yaml:"sequences"`
 
 	Annotations_ `yaml:"annotations,omitempty"`
@@ -480,6 +486,29 @@ func (m *model) setSSHHostKeys(addressesList []*sshHostKey) {
 	}
 }
 
+// Secrets implements Model.
+func (m *model) Secrets() []Secret {
+	var result []Secret
+	for _, secret := range m.Secrets_.Secrets_ {
+		result = append(result, secret)
+	}
+	return result
+}
+
+// AddSecret implements Model.
+func (m *model) AddSecret(args SecretArgs) Secret {
+	secret := newSecret(args)
+	m.Secrets_.Secrets_ = append(m.Secrets_.Secrets_, secret)
+	return secret
+}
+
+func (m *model) setSecrets(secretList []*secret) {
+	m.Secrets_ = secrets{
+		Version:  1,
+		Secrets_: secretList,
+	}
+}
+
 // CloudImageMetadatas implements Model.
 func (m *model) CloudImageMetadata() []CloudImageMetadata {
 	var result []CloudImageMetadata
@@ -983,6 +1012,7 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"applications":         schema.StringMap(schema.Any()),
 		"relations":            schema.StringMap(schema.Any()),
 		"ssh-host-keys":        schema.StringMap(schema.Any()),
+		"secrets":              schema.StringMap(schema.Any()),
 		"cloud-image-metadata": schema.StringMap(schema.Any()),
 		"actions":              schema.StringMap(schema.Any()),
 		"ip-addresses":         schema.StringMap(schema.Any()),
@@ -1001,6 +1031,7 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"blocks":           schema.Omit,
 		"cloud-region":     "",
 		"cloud-credential": schema.Omit,
+		"secrets":          schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -1116,6 +1147,15 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setSSHHostKeys(hostKeys)
 
+	// Models exported before secrets were migrated have none.
+	if secretMap, ok := valid["secrets"]; ok {
+		secrets, err := importSecrets(secretMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "secrets")
+		}
+		result.setSecrets(secrets)
+	}
+
 	cloudimagemetadataMap := valid["cloud-image-metadata"].(map[string]interface{})
 	cloudimagemetadata, err := importCloudImageMetadata(cloudimagemetadataMap)
 	if err != nil {
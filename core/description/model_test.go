@@ -669,6 +669,42 @@ func (s *ModelSerializationSuite) TestSSHHostKey(c *gc.C) {
 	c.Assert(model.SSHHostKeys(), jc.DeepEquals, keys)
 }
 
+func (s *ModelSerializationSuite) TestSecret(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	secret := initial.AddSecret(SecretArgs{
+		Application: "mysql",
+		Name:        "password",
+		Revision:    1,
+		Value:       "sekrit",
+	})
+	c.Assert(secret.Name(), gc.Equals, "password")
+	secrets := initial.Secrets()
+	c.Assert(secrets, gc.HasLen, 1)
+	c.Assert(secrets[0], jc.DeepEquals, secret)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Secrets(), jc.DeepEquals, secrets)
+}
+
+func (s *ModelSerializationSuite) TestSecretsOptional(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	delete(source, "secrets")
+
+	model, err := importModel(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Secrets(), gc.HasLen, 0)
+}
+
 func (s *ModelSerializationSuite) TestCloudImageMetadata(c *gc.C) {
 	storageSize := uint64(3)
 	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
@@ -0,0 +1,156 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+type secrets struct {
+	Version  int       `yaml:"version"`
+	Secrets_ []*secret `yaml:"secrets"`
+}
+
+type secret struct {
+	Application_    string        `yaml:"application"`
+	Name_           string        `yaml:"name"`
+	Revision_       int           `yaml:"revision"`
+	Value_          string        `yaml:"value"`
+	Created_        time.Time     `yaml:"created"`
+	Updated_        time.Time     `yaml:"updated"`
+	RotateInterval_ time.Duration `yaml:"rotate-interval,omitempty"`
+}
+
+// Application implements Secret.
+func (i *secret) Application() string {
+	return i.Application_
+}
+
+// Name implements Secret.
+func (i *secret) Name() string {
+	return i.Name_
+}
+
+// Revision implements Secret.
+func (i *secret) Revision() int {
+	return i.Revision_
+}
+
+// Value implements Secret.
+func (i *secret) Value() string {
+	return i.Value_
+}
+
+// Created implements Secret.
+func (i *secret) Created() time.Time {
+	return i.Created_
+}
+
+// Updated implements Secret.
+func (i *secret) Updated() time.Time {
+	return i.Updated_
+}
+
+// RotateInterval implements Secret.
+func (i *secret) RotateInterval() time.Duration {
+	return i.RotateInterval_
+}
+
+// SecretArgs is an argument struct used to create a
+// new internal secret type that supports the Secret interface.
+type SecretArgs struct {
+	Application    string
+	Name           string
+	Revision       int
+	Value          string
+	Created        time.Time
+	Updated        time.Time
+	RotateInterval time.Duration
+}
+
+func newSecret(args SecretArgs) *secret {
+	return &secret{
+		Application_:    args.Application,
+		Name_:           args.Name,
+		Revision_:       args.Revision,
+		Value_:          args.Value,
+		Created_:        args.Created.UTC(),
+		Updated_:        args.Updated.UTC(),
+		RotateInterval_: args.RotateInterval,
+	}
+}
+
+func importSecrets(source map[string]interface{}) ([]*secret, error) {
+	checker := versionedChecker("secrets")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "secrets version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := secretDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["secrets"].([]interface{})
+	return importSecretList(sourceList, importFunc)
+}
+
+func importSecretList(sourceList []interface{}, importFunc secretDeserializationFunc) ([]*secret, error) {
+	result := make([]*secret, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for secret %d, %T", i, value)
+		}
+		secret, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "secret %d", i)
+		}
+		result = append(result, secret)
+	}
+	return result, nil
+}
+
+type secretDeserializationFunc func(map[string]interface{}) (*secret, error)
+
+var secretDeserializationFuncs = map[int]secretDeserializationFunc{
+	1: importSecretV1,
+}
+
+func importSecretV1(source map[string]interface{}) (*secret, error) {
+	fields := schema.Fields{
+		"application":     schema.String(),
+		"name":            schema.String(),
+		"revision":        schema.Int(),
+		"value":           schema.String(),
+		"created":         schema.Time(),
+		"updated":         schema.Time(),
+		"rotate-interval": schema.Int(),
+	}
+	// Some values don't have to be there.
+	defaults := schema.Defaults{
+		"rotate-interval": int64(0),
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "secret v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	return &secret{
+		Application_:    valid["application"].(string),
+		Name_:           valid["name"].(string),
+		Revision_:       int(valid["revision"].(int64)),
+		Value_:          valid["value"].(string),
+		Created_:        valid["created"].(time.Time).UTC(),
+		Updated_:        valid["updated"].(time.Time).UTC(),
+		RotateInterval_: time.Duration(valid["rotate-interval"].(int64)),
+	}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type SecretSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&SecretSerializationSuite{})
+
+func (s *SecretSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "secrets"
+	s.sliceName = "secrets"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importSecrets(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["secrets"] = []interface{}{}
+	}
+}
+
+func (s *SecretSerializationSuite) TestNewSecret(c *gc.C) {
+	args := SecretArgs{
+		Application:    "mysql",
+		Name:           "password",
+		Revision:       2,
+		Value:          "sekrit",
+		Created:        time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+		Updated:        time.Date(2017, 3, 2, 12, 0, 0, 0, time.UTC),
+		RotateInterval: time.Hour,
+	}
+	secret := newSecret(args)
+	c.Assert(secret.Application(), gc.Equals, args.Application)
+	c.Assert(secret.Name(), gc.Equals, args.Name)
+	c.Assert(secret.Revision(), gc.Equals, args.Revision)
+	c.Assert(secret.Value(), gc.Equals, args.Value)
+	c.Assert(secret.Created(), gc.Equals, args.Created)
+	c.Assert(secret.Updated(), gc.Equals, args.Updated)
+	c.Assert(secret.RotateInterval(), gc.Equals, args.RotateInterval)
+}
+
+func (s *SecretSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := secrets{
+		Version: 1,
+		Secrets_: []*secret{
+			newSecret(SecretArgs{
+				Application:    "mysql",
+				Name:           "password",
+				Revision:       2,
+				Value:          "sekrit",
+				Created:        time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+				Updated:        time.Date(2017, 3, 2, 12, 0, 0, 0, time.UTC),
+				RotateInterval: time.Hour,
+			}),
+			newSecret(SecretArgs{
+				Application: "wordpress",
+				Name:        "token",
+				Revision:    1,
+				Created:     time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+				Updated:     time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	secrets, err := importSecrets(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(secrets, jc.DeepEquals, initial.Secrets_)
+}
@@ -11,6 +11,7 @@ reference to non-core code.
 package leadership
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -20,6 +21,25 @@ import (
 // leadership claim has been denied.
 var ErrClaimDenied = errors.New("leadership claim denied")
 
+// NotLeaderError is the error returned by a Token's Check when the
+// unit is not the leader of the application.
+type NotLeaderError struct {
+	ApplicationName string
+	UnitName        string
+}
+
+// Error is part of the error interface.
+func (e *NotLeaderError) Error() string {
+	return fmt.Sprintf("%q is not leader of %q", e.UnitName, e.ApplicationName)
+}
+
+// IsNotLeaderError returns whether the cause of err is a
+// *NotLeaderError.
+func IsNotLeaderError(err error) bool {
+	_, ok := errors.Cause(err).(*NotLeaderError)
+	return ok
+}
+
 // Claimer exposes leadership acquisition capabilities.
 type Claimer interface {
 
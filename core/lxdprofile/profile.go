@@ -0,0 +1,163 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lxdprofile defines the LXD profiles that charms may ship
+// in order to have extra configuration and devices applied to the
+// LXD containers their units are deployed to.
+package lxdprofile
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+)
+
+// Filename is the name of the file, at the root of a charm, that
+// holds the charm's LXD profile.
+const Filename = "lxd-profile.yaml"
+
+// Profile holds the configuration and devices that a charm needs
+// applied to the LXD containers hosting its units.
+type Profile struct {
+	Description string                       `yaml:"description,omitempty"`
+	Config      map[string]string            `yaml:"config,omitempty"`
+	Devices     map[string]map[string]string `yaml:"devices,omitempty"`
+}
+
+// Parse parses the YAML content of a charm's lxd-profile.yaml file.
+func Parse(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := yaml.Unmarshal(data, &profile); err != nil {
+		return nil, errors.Annotate(err, "cannot parse lxd-profile")
+	}
+	for name, device := range profile.Devices {
+		if device["type"] == "" {
+			return nil, errors.NotValidf("lxd-profile device %q without type", name)
+		}
+	}
+	return &profile, nil
+}
+
+// ReadArchive returns the LXD profile held in the charm archive
+// at the given path, or nil if the charm does not have one.
+func ReadArchive(archivePath string) (*Profile, error) {
+	zipr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot open charm archive")
+	}
+	defer zipr.Close()
+	for _, f := range zipr.File {
+		if path.Clean(f.Name) != Filename {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot open %s", Filename)
+		}
+		defer r.Close()
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot read %s", Filename)
+		}
+		return Parse(data)
+	}
+	return nil, nil
+}
+
+// Empty reports whether the profile has neither config nor devices,
+// in which case there is nothing to apply.
+func (p *Profile) Empty() bool {
+	return p == nil || len(p.Config) == 0 && len(p.Devices) == 0
+}
+
+// Equal reports whether the profiles would configure a container in
+// the same way. Descriptions are not compared.
+func (p *Profile) Equal(other *Profile) bool {
+	if p.Empty() || other.Empty() {
+		return p.Empty() && other.Empty()
+	}
+	return equalStringMaps(p.Config, other.Config) && equalDevices(p.Devices, other.Devices)
+}
+
+func equalStringMaps(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func equalDevices(a, b map[string]map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, device := range a {
+		other, ok := b[name]
+		if !ok || !equalStringMaps(device, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// safeDeviceTypes holds the device types that a charm profile may
+// add without the model allowing unsafe profiles. Block devices are
+// left out, as raw access to the host's disks is as good as root on
+// the host.
+var safeDeviceTypes = set.NewStrings("unix-char", "gpu", "usb")
+
+// safeConfigPrefixes holds the prefixes of the config keys that a
+// charm profile may set without the model allowing unsafe profiles.
+// Every other key, notably security.*, raw.* and linux.*, may weaken
+// the container's isolation from the host or change how juju starts,
+// limits or migrates it.
+var safeConfigPrefixes = []string{"environment.", "user."}
+
+// ValidateSafe returns an error if the profile contains config or
+// devices that would give the charm control over the container that
+// juju does not allow by default.
+func (p *Profile) ValidateSafe() error {
+	if p == nil {
+		return nil
+	}
+	for key := range p.Config {
+		if !hasSafeConfigPrefix(key) {
+			return errors.NotValidf("lxd-profile config %q", key)
+		}
+	}
+	for name, device := range p.Devices {
+		if !safeDeviceTypes.Contains(device["type"]) {
+			return errors.NotValidf("lxd-profile device %q of type %q", name, device["type"])
+		}
+	}
+	return nil
+}
+
+func hasSafeConfigPrefix(key string) bool {
+	for _, prefix := range safeConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the name of the LXD profile to create for the charm
+// with the given URL and archive SHA256, deployed in the model with
+// the given UUID. Each charm archive gets its own profile, so an
+// upgraded charm never changes the profile of containers already
+// running, and charms in different models sharing an LXD host, or
+// local charms uploaded twice with the same revision, never share a
+// profile.
+func Name(modelUUID string, curl *charm.URL, charmSHA256 string) string {
+	hash := sha256.Sum256([]byte(modelUUID + "\n" + curl.String() + "\n" + charmSHA256))
+	return fmt.Sprintf("juju-%s-%d-%x", curl.Name, curl.Revision, hash[:6])
+}
@@ -0,0 +1,195 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile_test
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/lxdprofile"
+)
+
+type ProfileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ProfileSuite{})
+
+const profileYAML = `
+description: sriov passthrough
+config:
+  security.nesting: "true"
+  linux.kernel_modules: openvswitch
+devices:
+  sriov:
+    type: unix-char
+    path: /dev/vfio/vfio
+`
+
+func (s *ProfileSuite) TestParse(c *gc.C) {
+	profile, err := lxdprofile.Parse([]byte(profileYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, &lxdprofile.Profile{
+		Description: "sriov passthrough",
+		Config: map[string]string{
+			"security.nesting":     "true",
+			"linux.kernel_modules": "openvswitch",
+		},
+		Devices: map[string]map[string]string{
+			"sriov": {"type": "unix-char", "path": "/dev/vfio/vfio"},
+		},
+	})
+	c.Assert(profile.Empty(), jc.IsFalse)
+}
+
+func (s *ProfileSuite) TestParseInvalid(c *gc.C) {
+	_, err := lxdprofile.Parse([]byte("config: [1, 2]"))
+	c.Assert(err, gc.ErrorMatches, "cannot parse lxd-profile: .*")
+	_, err = lxdprofile.Parse([]byte("devices:\n  foo:\n    path: /dev/foo\n"))
+	c.Assert(err, gc.ErrorMatches, `lxd-profile device "foo" without type not valid`)
+}
+
+func (s *ProfileSuite) TestEmpty(c *gc.C) {
+	var nilProfile *lxdprofile.Profile
+	c.Assert(nilProfile.Empty(), jc.IsTrue)
+	c.Assert((&lxdprofile.Profile{Description: "nothing"}).Empty(), jc.IsTrue)
+}
+
+func (s *ProfileSuite) TestValidateSafe(c *gc.C) {
+	for _, test := range []struct {
+		profile lxdprofile.Profile
+		err     string
+	}{{
+		profile: lxdprofile.Profile{
+			Config: map[string]string{
+				"environment.http_proxy": "http://proxy.invalid",
+				"user.network-config":    "disabled",
+			},
+			Devices: map[string]map[string]string{
+				"sriov": {"type": "unix-char", "path": "/dev/vfio/vfio"},
+				"gpu":   {"type": "gpu"},
+			},
+		},
+	}, {
+		profile: lxdprofile.Profile{Config: map[string]string{"boot.autostart": "false"}},
+		err:     `lxd-profile config "boot.autostart" not valid`,
+	}, {
+		profile: lxdprofile.Profile{Config: map[string]string{"security.privileged": "true"}},
+		err:     `lxd-profile config "security.privileged" not valid`,
+	}, {
+		profile: lxdprofile.Profile{Config: map[string]string{"raw.lxc": "lxc.aa_profile=unconfined"}},
+		err:     `lxd-profile config "raw.lxc" not valid`,
+	}, {
+		profile: lxdprofile.Profile{Config: map[string]string{"linux.kernel_modules": "openvswitch"}},
+		err:     `lxd-profile config "linux.kernel_modules" not valid`,
+	}, {
+		profile: lxdprofile.Profile{Devices: map[string]map[string]string{
+			"sda": {"type": "unix-block", "path": "/dev/sda"},
+		}},
+		err: `lxd-profile device "sda" of type "unix-block" not valid`,
+	}, {
+		profile: lxdprofile.Profile{Config: map[string]string{"limits.cpu": "4"}},
+		err:     `lxd-profile config "limits.cpu" not valid`,
+	}, {
+		profile: lxdprofile.Profile{Devices: map[string]map[string]string{
+			"root": {"type": "disk", "path": "/"},
+		}},
+		err: `lxd-profile device "root" of type "disk" not valid`,
+	}} {
+		err := test.profile.ValidateSafe()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *ProfileSuite) TestEqual(c *gc.C) {
+	profile := &lxdprofile.Profile{
+		Description: "one",
+		Config:      map[string]string{"user.foo": "bar"},
+		Devices: map[string]map[string]string{
+			"gpu": {"type": "gpu"},
+		},
+	}
+	same := &lxdprofile.Profile{
+		Description: "two",
+		Config:      map[string]string{"user.foo": "bar"},
+		Devices: map[string]map[string]string{
+			"gpu": {"type": "gpu"},
+		},
+	}
+	c.Check(profile.Equal(same), jc.IsTrue)
+
+	changedConfig := &lxdprofile.Profile{
+		Config:  map[string]string{"user.foo": "baz"},
+		Devices: same.Devices,
+	}
+	c.Check(profile.Equal(changedConfig), jc.IsFalse)
+
+	changedDevice := &lxdprofile.Profile{
+		Config: same.Config,
+		Devices: map[string]map[string]string{
+			"gpu": {"type": "gpu", "id": "1"},
+		},
+	}
+	c.Check(profile.Equal(changedDevice), jc.IsFalse)
+
+	var nilProfile *lxdprofile.Profile
+	c.Check(profile.Equal(nilProfile), jc.IsFalse)
+	c.Check(nilProfile.Equal(&lxdprofile.Profile{}), jc.IsTrue)
+}
+
+func (s *ProfileSuite) TestReadArchive(c *gc.C) {
+	archivePath := writeArchive(c, map[string]string{
+		"metadata.yaml":     "name: foo\n",
+		lxdprofile.Filename: profileYAML,
+	})
+	profile, err := lxdprofile.ReadArchive(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Description, gc.Equals, "sriov passthrough")
+}
+
+func (s *ProfileSuite) TestReadArchiveNoProfile(c *gc.C) {
+	archivePath := writeArchive(c, map[string]string{
+		"metadata.yaml": "name: foo\n",
+	})
+	profile, err := lxdprofile.ReadArchive(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+}
+
+func (s *ProfileSuite) TestName(c *gc.C) {
+	curl := charm.MustParseURL("cs:xenial/neutron-gateway-42")
+	name := lxdprofile.Name("deadbeef-0bad-400d-8000-4b1d0d06f00d", curl, "abc123")
+	c.Assert(name, gc.Matches, "juju-neutron-gateway-42-[0-9a-f]{12}")
+
+	// The name is stable, and differs between models and archives.
+	c.Assert(lxdprofile.Name("deadbeef-0bad-400d-8000-4b1d0d06f00d", curl, "abc123"), gc.Equals, name)
+	c.Assert(lxdprofile.Name("deadbeef-0bad-400d-8000-5b1d0d06f00d", curl, "abc123"), gc.Not(gc.Equals), name)
+	c.Assert(lxdprofile.Name("deadbeef-0bad-400d-8000-4b1d0d06f00d", curl, "def456"), gc.Not(gc.Equals), name)
+}
+
+func writeArchive(c *gc.C, files map[string]string) string {
+	archivePath := filepath.Join(c.MkDir(), "charm.zip")
+	f, err := os.Create(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	zipw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zipw.Create(name)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = w.Write([]byte(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(zipw.Close(), jc.ErrorIsNil)
+	return archivePath
+}
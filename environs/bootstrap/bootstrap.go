@@ -143,6 +143,10 @@ type BootstrapParams struct {
 
 	// DialOpts contains the bootstrap dial options.
 	DialOpts environs.BootstrapDialOpts
+
+	// RecordCheckpoint, if non-nil, is called as each checkpoint
+	// is reached. If it returns an error, bootstrap fails.
+	RecordCheckpoint func(Checkpoint) error
 }
 
 // Validate validates the bootstrap parameters.
@@ -373,6 +377,10 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 	if err != nil {
 		return err
 	}
+	checkpoints := newCheckpointer(args.RecordCheckpoint)
+	if err := checkpoints.reach(CheckpointInstanceStarted); err != nil {
+		return errors.Trace(err)
+	}
 
 	matchingTools, err := availableTools.Match(coretools.Filter{
 		Arch:   result.Arch,
@@ -417,9 +425,18 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 	if err := finalizeInstanceBootstrapConfig(ctx, instanceConfig, args, cfg, customImageMetadata); err != nil {
 		return errors.Annotate(err, "finalizing bootstrap instance config")
 	}
-	if err := result.Finalize(ctx, instanceConfig, args.DialOpts); err != nil {
+	// The instance's configuration script reports when the agent
+	// binaries are installed and the database initialised; record
+	// those checkpoints as they are reached.
+	if err := result.Finalize(newCheckpointContext(ctx, checkpoints), instanceConfig, args.DialOpts); err != nil {
 		return err
 	}
+	if err := checkpoints.failed(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkpoints.reach(CheckpointControllerInitialized); err != nil {
+		return errors.Trace(err)
+	}
 	ctx.Infof("Bootstrap agent now started")
 	return nil
 }
@@ -662,6 +679,49 @@ func isCompatibleVersion(v1, v2 version.Number) bool {
 // setPrivateMetadataSources sets the default tools metadata source
 // for tools syncing, and adds an image metadata source after verifying
 // the contents.
+// ValidateMetadataSource checks that the given local directory may be
+// used as the source of agent binaries and image metadata when
+// bootstrapping without access to the public simplestreams sources. It
+// must contain simplestreams metadata for agent binaries, for images,
+// or for both, as generated by "juju metadata generate-tools" and
+// "juju metadata generate-image".
+func ValidateMetadataSource(metadataDir string) error {
+	info, err := os.Stat(metadataDir)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("metadata source directory %q", metadataDir)
+	} else if err != nil {
+		return errors.Annotate(err, "cannot access metadata source")
+	}
+	if !info.IsDir() {
+		return errors.NotValidf("metadata source %q (not a directory)", metadataDir)
+	}
+	var found bool
+	for _, kind := range []string{storage.BaseToolsPath, storage.BaseImagesPath} {
+		kindDir := filepath.Join(metadataDir, kind)
+		if _, err := os.Stat(kindDir); os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "cannot access %s metadata", kind)
+		}
+		streamsDir := filepath.Join(kindDir, "streams", "v1")
+		indexes, err := filepath.Glob(filepath.Join(streamsDir, "index*"))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(indexes) == 0 {
+			return errors.Errorf("no simplestreams index found in %q", streamsDir)
+		}
+		found = true
+	}
+	if !found {
+		return errors.Errorf(
+			"metadata source %q contains no %q or %q metadata",
+			metadataDir, storage.BaseToolsPath, storage.BaseImagesPath,
+		)
+	}
+	return nil
+}
+
 func setPrivateMetadataSources(metadataDir string) ([]*imagemetadata.ImageMetadata, error) {
 	logger.Infof("Setting default tools and image metadata sources: %s", metadataDir)
 	tools.DefaultBaseURL = metadataDir
@@ -27,6 +27,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/cloudconfig"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
@@ -189,6 +190,73 @@ func (s *bootstrapSuite) TestBootstrapSpecifiedPlacement(c *gc.C) {
 	c.Assert(env.args.Placement, gc.DeepEquals, placement)
 }
 
+func (s *bootstrapSuite) TestBootstrapRecordsCheckpoints(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	s.setDummyStorage(c, env)
+	var checkpoints []bootstrap.Checkpoint
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+		AdminSecret:      "admin-secret",
+		CAPrivateKey:     coretesting.CAKey,
+		RecordCheckpoint: func(checkpoint bootstrap.Checkpoint) error {
+			checkpoints = append(checkpoints, checkpoint)
+			return nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checkpoints, jc.DeepEquals, []bootstrap.Checkpoint{
+		bootstrap.CheckpointInstanceStarted,
+		bootstrap.CheckpointToolsUploaded,
+		bootstrap.CheckpointMongoInitialized,
+		bootstrap.CheckpointControllerInitialized,
+	})
+}
+
+func (s *bootstrapSuite) TestBootstrapRecordsProgressCheckpoints(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	s.setDummyStorage(c, env)
+	var checkpoints []bootstrap.Checkpoint
+	env.finalize = func(ctx environs.BootstrapContext) error {
+		fmt.Fprintf(ctx.GetStderr(), "Fetching Juju agent\n%s\n", cloudconfig.BootstrapToolsInstalledProgress)
+		c.Check(checkpoints, jc.DeepEquals, []bootstrap.Checkpoint{
+			bootstrap.CheckpointInstanceStarted,
+			bootstrap.CheckpointToolsUploaded,
+		})
+		fmt.Fprintf(ctx.GetStderr(), "%s\r\nStarting Juju machine agent\n", cloudconfig.BootstrapStateInitializedProgress)
+		return errors.New("cannot start agent")
+	}
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+		AdminSecret:      "admin-secret",
+		CAPrivateKey:     coretesting.CAKey,
+		RecordCheckpoint: func(checkpoint bootstrap.Checkpoint) error {
+			checkpoints = append(checkpoints, checkpoint)
+			return nil
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot start agent")
+	c.Assert(checkpoints, jc.DeepEquals, []bootstrap.Checkpoint{
+		bootstrap.CheckpointInstanceStarted,
+		bootstrap.CheckpointToolsUploaded,
+		bootstrap.CheckpointMongoInitialized,
+	})
+	c.Assert(checkpoints[len(checkpoints)-1].Resumable(), jc.IsTrue)
+}
+
+func (s *bootstrapSuite) TestBootstrapRecordCheckpointError(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	s.setDummyStorage(c, env)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+		AdminSecret:      "admin-secret",
+		CAPrivateKey:     coretesting.CAKey,
+		RecordCheckpoint: func(bootstrap.Checkpoint) error {
+			return errors.New("disk full")
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `recording bootstrap checkpoint "instance-started": disk full`)
+}
+
 func intPtr(i uint64) *uint64 {
 	return &i
 }
@@ -893,6 +961,46 @@ func createImageMetadataForArch(c *gc.C, arch string) (dir string, _ []*imagemet
 	return sourceDir, im
 }
 
+func (s *bootstrapSuite) TestValidateMetadataSource(c *gc.C) {
+	metadataDir, _ := createImageMetadata(c)
+	err := bootstrap.ValidateMetadataSource(metadataDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stor, err := filestorage.NewFileStorageWriter(metadataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	envtesting.UploadFakeTools(c, stor, "released", "released")
+	err = bootstrap.ValidateMetadataSource(metadataDir)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *bootstrapSuite) TestValidateMetadataSourceNotFound(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "missing")
+	err := bootstrap.ValidateMetadataSource(dir)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *bootstrapSuite) TestValidateMetadataSourceNotDirectory(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "file")
+	err := ioutil.WriteFile(path, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = bootstrap.ValidateMetadataSource(path)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *bootstrapSuite) TestValidateMetadataSourceEmpty(c *gc.C) {
+	dir := c.MkDir()
+	err := bootstrap.ValidateMetadataSource(dir)
+	c.Assert(err, gc.ErrorMatches, `metadata source ".*" contains no "tools" or "images" metadata`)
+}
+
+func (s *bootstrapSuite) TestValidateMetadataSourceMissingIndex(c *gc.C) {
+	dir := c.MkDir()
+	err := os.MkdirAll(filepath.Join(dir, "tools", "streams", "v1"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = bootstrap.ValidateMetadataSource(dir)
+	c.Assert(err, gc.ErrorMatches, `no simplestreams index found in ".*/tools/streams/v1"`)
+}
+
 func (s *bootstrapSuite) TestBootstrapMetadata(c *gc.C) {
 	environs.UnregisterImageDataSourceFunc("bootstrap metadata")
 
@@ -1202,6 +1310,9 @@ type bootstrapEnviron struct {
 	args                      environs.BootstrapParams
 	instanceConfig            *instancecfg.InstanceConfig
 	storage                   storage.Storage
+
+	// finalize, if set, is called by the finalizer.
+	finalize func(environs.BootstrapContext) error
 }
 
 func newEnviron(name string, defaultKeys bool, extraAttrs map[string]interface{}) *bootstrapEnviron {
@@ -1235,9 +1346,12 @@ func (s *bootstrapSuite) setDummyStorage(c *gc.C, env *bootstrapEnviron) {
 func (e *bootstrapEnviron) Bootstrap(ctx environs.BootstrapContext, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
 	e.bootstrapCount++
 	e.args = args
-	finalizer := func(_ environs.BootstrapContext, icfg *instancecfg.InstanceConfig, _ environs.BootstrapDialOpts) error {
+	finalizer := func(ctx environs.BootstrapContext, icfg *instancecfg.InstanceConfig, _ environs.BootstrapDialOpts) error {
 		e.finalizerCount++
 		e.instanceConfig = icfg
+		if e.finalize != nil {
+			return e.finalize(ctx)
+		}
 		return nil
 	}
 	series := series.MustHostSeries()
@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloudconfig"
+	"github.com/juju/juju/environs"
+)
+
+// Checkpoint identifies a stage of the bootstrap process that has been
+// completed. Checkpoints are recorded as bootstrap progresses, so that
+// a bootstrap that fails after the controller's database has been
+// initialised may be resumed rather than torn down.
+type Checkpoint string
+
+const (
+	// CheckpointInstanceStarted is reached once the bootstrap
+	// instance has been started.
+	CheckpointInstanceStarted Checkpoint = "instance-started"
+
+	// CheckpointToolsUploaded is reached once the agent binaries
+	// have been transferred to, and unpacked on, the bootstrap
+	// instance.
+	CheckpointToolsUploaded Checkpoint = "tools-uploaded"
+
+	// CheckpointMongoInitialized is reached once the controller's
+	// database has been initialised on the bootstrap instance.
+	CheckpointMongoInitialized Checkpoint = "mongo-initialized"
+
+	// CheckpointControllerInitialized is reached once the bootstrap
+	// instance has been fully configured, and the controller's
+	// machine agent started.
+	CheckpointControllerInitialized Checkpoint = "controller-initialized"
+)
+
+// checkpointOrder holds the checkpoints in the order they are reached.
+var checkpointOrder = []Checkpoint{
+	CheckpointInstanceStarted,
+	CheckpointToolsUploaded,
+	CheckpointMongoInitialized,
+	CheckpointControllerInitialized,
+}
+
+// Resumable reports whether a bootstrap that failed after reaching
+// the checkpoint may be resumed. Once the controller's database has
+// been initialised, all that remains is for the controller's agent to
+// start; earlier stages require the CA private key, which is not kept
+// once bootstrap has failed.
+func (c Checkpoint) Resumable() bool {
+	return c == CheckpointMongoInitialized || c == CheckpointControllerInitialized
+}
+
+// checkpointProgress maps the progress messages logged by the bootstrap
+// instance's configuration script to the checkpoints they signal.
+var checkpointProgress = map[string]Checkpoint{
+	cloudconfig.BootstrapToolsInstalledProgress:   CheckpointToolsUploaded,
+	cloudconfig.BootstrapStateInitializedProgress: CheckpointMongoInitialized,
+}
+
+// checkpointer records bootstrap checkpoints, making sure that each is
+// recorded once and that none is skipped.
+type checkpointer struct {
+	record func(Checkpoint) error
+
+	mu      sync.Mutex
+	reached int
+	err     error
+}
+
+func newCheckpointer(record func(Checkpoint) error) *checkpointer {
+	return &checkpointer{record: record}
+}
+
+// reach records the given checkpoint, along with any earlier ones that
+// have not yet been recorded. Once recording has failed, reach returns
+// that error without recording anything further.
+func (c *checkpointer) reach(checkpoint Checkpoint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	for _, reached := range checkpointOrder[:c.reached] {
+		if reached == checkpoint {
+			return nil
+		}
+	}
+	for _, next := range checkpointOrder[c.reached:] {
+		if c.record != nil {
+			if err := c.record(next); err != nil {
+				c.err = errors.Annotatef(err, "recording bootstrap checkpoint %q", next)
+				return c.err
+			}
+		}
+		c.reached++
+		if next == checkpoint {
+			break
+		}
+	}
+	return nil
+}
+
+// failed returns the error with which recording a checkpoint failed,
+// if any.
+func (c *checkpointer) failed() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// checkpointContext is a BootstrapContext whose standard error watches
+// the progress logged while the bootstrap instance is configured, and
+// records the checkpoints it signals.
+type checkpointContext struct {
+	environs.BootstrapContext
+	stderr io.Writer
+}
+
+func newCheckpointContext(ctx environs.BootstrapContext, checkpoints *checkpointer) environs.BootstrapContext {
+	return checkpointContext{
+		BootstrapContext: ctx,
+		stderr: &progressWriter{
+			Writer:      ctx.GetStderr(),
+			checkpoints: checkpoints,
+		},
+	}
+}
+
+// GetStderr is part of the environs.BootstrapContext interface.
+func (ctx checkpointContext) GetStderr() io.Writer {
+	return ctx.stderr
+}
+
+// progressWriter passes everything written to it through to the
+// underlying writer, and records a checkpoint for each line that
+// matches a checkpoint's progress message.
+type progressWriter struct {
+	io.Writer
+	checkpoints *checkpointer
+	line        []byte
+}
+
+// Write is part of the io.Writer interface.
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.line = append(w.line, p...)
+	for {
+		i := bytes.IndexByte(w.line, '\n')
+		if i < 0 {
+			break
+		}
+		line := strings.TrimSpace(string(w.line[:i]))
+		w.line = w.line[i+1:]
+		if checkpoint, ok := checkpointProgress[line]; ok {
+			// A failure is reported once the instance has
+			// been configured; interrupting the script here
+			// would only leave the instance half configured.
+			w.checkpoints.reach(checkpoint)
+		}
+	}
+	return w.Writer.Write(p)
+}
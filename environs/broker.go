@@ -6,6 +6,7 @@ package environs
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/instance"
@@ -66,6 +67,13 @@ type StartInstanceParams struct {
 	// provider-specific space IDs. It is populated when provisioning a machine
 	// to host a unit of a service with endpoint bindings.
 	EndpointBindings map[string]network.Id
+
+	// CharmLXDProfiles holds the LXD profiles shipped with the charms
+	// of the units to be deployed to the instance, keyed by the name
+	// of the profile to create for each. Only brokers for LXD
+	// containers make use of it.
+	CharmLXDProfiles map[string]lxdprofile.Profile
+
 	// ImageMetadata is a collection of image metadata
 	// that may be used to start this instance.
 	ImageMetadata []*imagemetadata.ImageMetadata
@@ -133,3 +141,27 @@ type InstanceBroker interface {
 	// correct network configuration.
 	MaintainInstance(args StartInstanceParams) error
 }
+
+// BatchInstanceBroker may be implemented by an InstanceBroker whose
+// provider can start several instances with a single API call.
+type BatchInstanceBroker interface {
+	InstanceBroker
+
+	// StartInstances asks for a new instance to be created for each of
+	// the supplied params, as StartInstance does. The results correspond
+	// to the params by index. A non-nil error is returned only if no
+	// instances could be started; failures to start individual
+	// instances are reported in the corresponding results.
+	StartInstances(args []StartInstanceParams) ([]StartInstancesResult, error)
+}
+
+// StartInstancesResult holds the result of starting one of the
+// instances requested in a call to BatchInstanceBroker.StartInstances.
+type StartInstancesResult struct {
+	// Result holds the details of the started instance, if
+	// Error is nil.
+	Result *StartInstanceResult
+
+	// Error holds the reason the instance could not be started.
+	Error error
+}
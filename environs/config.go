@@ -8,6 +8,8 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+
+	"github.com/juju/juju/environs/config"
 )
 
 var logger = loggo.GetLogger("juju.environs")
@@ -86,10 +88,28 @@ func (r *globalProviderRegistry) Provider(providerType string) (EnvironProvider,
 //
 // RegisterProvider will panic if the provider name or any of the aliases
 // are registered more than once.
+//
+// If p implements config.ConfigSchemaSource, its schema is registered
+// for models of the provider's type and its aliases. If p implements
+// ProviderConfigExtensions, its config extensions are registered for
+// models of the provider's type.
 func RegisterProvider(name string, p EnvironProvider, alias ...string) {
 	if err := GlobalProviderRegistry().RegisterProvider(p, name, alias...); err != nil {
 		panic(fmt.Errorf("juju: %v", err))
 	}
+	if cs, ok := p.(config.ConfigSchemaSource); ok {
+		fields := cs.ConfigSchema()
+		for _, providerType := range append([]string{name}, alias...) {
+			config.RegisterProviderSchema(providerType, fields)
+		}
+	}
+	if p, ok := p.(ProviderConfigExtensions); ok {
+		for attrName, attr := range p.ConfigExtensions() {
+			if err := config.RegisterExtension(name, attrName, attr); err != nil {
+				panic(fmt.Errorf("juju: registering config for provider %q: %v", name, err))
+			}
+		}
+	}
 }
 
 // RegisteredProviders enumerate all the environ providers which have been registered.
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -23,6 +24,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/network"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -43,6 +45,23 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// AZDistributionBalanced requests that new instances are started in
+	// the availability zone with the fewest instances of their
+	// distribution group, falling back to other zones if that fails.
+	AZDistributionBalanced = "balanced"
+
+	// AZDistributionStrict requests that new instances are only started
+	// in availability zones holding no instances of their distribution
+	// group. Provisioning fails if there is no such zone.
+	AZDistributionStrict = "strict"
+
+	// AZDistributionNone requests that availability zones are chosen
+	// without regard to the distribution group, spreading all of the
+	// model's instances evenly instead.
+	AZDistributionNone = "none"
+)
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -93,6 +112,12 @@ const (
 	// AptFTPProxyKey stores the key for this setting.
 	AptFTPProxyKey = "apt-ftp-proxy"
 
+	// SnapHTTPProxyKey stores the key for this setting.
+	SnapHTTPProxyKey = "snap-http-proxy"
+
+	// SnapHTTPSProxyKey stores the key for this setting.
+	SnapHTTPSProxyKey = "snap-https-proxy"
+
 	// NoProxyKey stores the key for this setting.
 	NoProxyKey = "no-proxy"
 
@@ -129,6 +154,83 @@ const (
 	// metrics collected in this model for anonymized aggregate analytics.
 	TransmitVendorMetricsKey = "transmit-vendor-metrics"
 
+	// IPv6ModeKey is the key for how the model uses IPv6.
+	IPv6ModeKey = "ipv6-mode"
+
+	// RemoveMissingSubnetsKey is the key for whether space discovery
+	// removes subnets that the provider no longer reports.
+	RemoveMissingSubnetsKey = "remove-missing-subnets"
+
+	// LeadershipLeaseDurationKey is the key for the duration of the
+	// leadership leases claimed by units in the model.
+	LeadershipLeaseDurationKey = "leadership-lease-duration"
+
+	// LeadershipRenewalIntervalKey is the key for the interval at
+	// which units renew the leadership leases they hold.
+	LeadershipRenewalIntervalKey = "leadership-renewal-interval"
+
+	// MaxRelationDataSizeKey is the key for the maximum size, in
+	// bytes, of the settings a unit may write to a relation.
+	MaxRelationDataSizeKey = "max-relation-data-size"
+
+	// MaxMachinesKey is the key for the maximum number of machines
+	// in the model.
+	MaxMachinesKey = "max-machines"
+
+	// MaxUnitsKey is the key for the maximum number of units in the
+	// model.
+	MaxUnitsKey = "max-units"
+
+	// MaxStorageKey is the key for the maximum number of storage
+	// instances in the model.
+	MaxStorageKey = "max-storage"
+
+	// MaintenanceWindowKey is the key for the daily period, in UTC,
+	// during which machines may perform scheduled reboots.
+	MaintenanceWindowKey = "maintenance-window"
+
+	// AllowUnsafeLXDProfilesKey is the key for whether charms may
+	// ship LXD profiles that fail the controller's safety checks.
+	AllowUnsafeLXDProfilesKey = "allow-unsafe-lxd-profiles"
+
+	// ContainerIPRangesKey is the key for the ranges of addresses
+	// from which containers are given static addresses when the
+	// provider cannot allocate container addresses itself.
+	ContainerIPRangesKey = "container-ip-ranges"
+
+	// AZDistributionKey is the key for how instances are spread across
+	// availability zones.
+	AZDistributionKey = "az-distribution"
+
+	// DestroyOrphanedResourcesKey is the key for whether cloud
+	// resources that the model no longer knows about are destroyed.
+	DestroyOrphanedResourcesKey = "destroy-orphaned-resources"
+
+	// SSHBastionKey is the key for the host through which juju ssh
+	// and juju scp connect to the model's machines.
+	SSHBastionKey = "ssh-bastion"
+
+	// UnitDrainTimeoutKey is the key for how long a unit's pre-remove
+	// hook may run before the unit's removal carries on regardless.
+	UnitDrainTimeoutKey = "unit-drain-timeout"
+
+	// ImageMetadataSourcesKey is the key for the ordered list of
+	// sources searched for image metadata.
+	ImageMetadataSourcesKey = "image-metadata-sources"
+
+	// ImageMetadataMaxAgeKey is the key for how long image metadata
+	// cached from published sources is kept.
+	ImageMetadataMaxAgeKey = "image-metadata-max-age"
+
+	// ProvisioningRetryIntervalKey is the key for how long the
+	// provisioner waits before automatically retrying to start the
+	// instance of a machine whose provisioning failed.
+	ProvisioningRetryIntervalKey = "provisioning-retry-interval"
+
+	// ProvisioningRetryMaxAttemptsKey is the key for how many times
+	// the provisioning of a machine is retried automatically.
+	ProvisioningRetryMaxAttemptsKey = "provisioning-retry-max-attempts"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -139,6 +241,33 @@ const (
 	IgnoreMachineAddresses = "ignore-machine-addresses"
 )
 
+const (
+	// DefaultLeadershipLeaseDuration is the default duration of the
+	// leadership leases claimed by units.
+	DefaultLeadershipLeaseDuration = time.Minute
+
+	// DefaultLeadershipRenewalInterval is the default interval at
+	// which units renew their leadership leases.
+	DefaultLeadershipRenewalInterval = 30 * time.Second
+
+	// MinLeadershipLeaseDuration is the shortest leadership lease
+	// duration that may be configured.
+	MinLeadershipLeaseDuration = 5 * time.Second
+
+	// MaxLeadershipLeaseDuration is the longest leadership lease
+	// duration that may be configured.
+	MaxLeadershipLeaseDuration = 5 * time.Minute
+
+	// DefaultUnitDrainTimeout is the default time a unit's pre-remove
+	// hook may run for.
+	DefaultUnitDrainTimeout = 10 * time.Minute
+
+	// DefaultProvisioningRetryMaxAttempts is the default number of
+	// times the provisioning of a machine is retried automatically,
+	// when automatic retries are enabled.
+	DefaultProvisioningRetryMaxAttempts = 5
+)
+
 // ParseHarvestMode parses description of harvesting method and
 // returns the representation.
 func ParseHarvestMode(description string) (HarvestMode, error) {
@@ -264,16 +393,19 @@ func New(withDefaults Defaulting, attrs map[string]interface{}) (*Config, error)
 	if err := c.ensureUnitLogging(); err != nil {
 		return nil, err
 	}
-	// no old config to compare against
-	if err := Validate(c, nil); err != nil {
-		return nil, err
-	}
 	// Copy unknown attributes onto the type-specific map.
 	for k, v := range attrs {
 		if _, ok := fields[k]; !ok {
 			c.unknown[k] = v
 		}
 	}
+	if err := c.coerceExtensions(); err != nil {
+		return nil, err
+	}
+	// no old config to compare against
+	if err := Validate(c, nil); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -295,6 +427,12 @@ var defaultConfigValues = map[string]interface{}{
 	"development":              false,
 	"test-mode":                false,
 	TransmitVendorMetricsKey:   true,
+	IPv6ModeKey:                string(network.IPv6Disabled),
+	RemoveMissingSubnetsKey:    false,
+
+	// Leadership lease settings.
+	LeadershipLeaseDurationKey:   DefaultLeadershipLeaseDuration.String(),
+	LeadershipRenewalIntervalKey: DefaultLeadershipRenewalInterval.String(),
 
 	// Image and agent streams and URLs.
 	"image-stream":       "released",
@@ -451,6 +589,53 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	if err := cfg.validateLeadershipLease(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if size := cfg.MaxRelationDataSize(); size < 0 {
+		return errors.Errorf("%s must not be negative, got %d", MaxRelationDataSizeKey, size)
+	}
+
+	for _, key := range QuotaKeys {
+		if limit, _ := cfg.defined[key].(int); limit < 0 {
+			return errors.Errorf("%s must not be negative, got %d", key, limit)
+		}
+	}
+
+	if _, err := ParseMaintenanceWindow(cfg.MaintenanceWindow()); err != nil {
+		return errors.Trace(err)
+	}
+
+	if _, err := ParseSSHBastion(cfg.SSHBastion()); err != nil {
+		return errors.Trace(err)
+	}
+
+	if attempts := cfg.ProvisioningRetryMaxAttempts(); attempts < 0 {
+		return errors.Errorf("%s must not be negative, got %d", ProvisioningRetryMaxAttemptsKey, attempts)
+	}
+
+	for _, key := range []string{UnitDrainTimeoutKey, ImageMetadataMaxAgeKey, ProvisioningRetryIntervalKey} {
+		if err := cfg.validateNonNegativeDuration(key); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if _, err := ParseIPRanges(cfg.asString(ContainerIPRangesKey)); err != nil {
+		return errors.Annotatef(err, "invalid %s", ContainerIPRangesKey)
+	}
+
+	if sources, err := ParseImageMetadataSources(cfg.asString(ImageMetadataSourcesKey)); err != nil {
+		return errors.Annotatef(err, "invalid %s", ImageMetadataSourcesKey)
+	} else if _, ok := cfg.ImageMetadataURL(); ok && len(sources) > 0 {
+		return errors.Errorf("cannot set both image-metadata-url and %s; list the URL as a custom source instead", ImageMetadataSourcesKey)
+	}
+
+	// Check the registered extension attributes.
+	if err := validateExtensions(cfg, old); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		for _, attr := range immutableAttributes {
@@ -641,6 +826,27 @@ func (c *Config) AptFTPProxy() string {
 	return addSchemeIfMissing("ftp", c.getWithFallback(AptFTPProxyKey, FTPProxyKey))
 }
 
+// SnapProxySettings returns the http and https proxy settings used
+// by snapd.
+func (c *Config) SnapProxySettings() proxy.Settings {
+	return proxy.Settings{
+		Http:  c.SnapHTTPProxy(),
+		Https: c.SnapHTTPSProxy(),
+	}
+}
+
+// SnapHTTPProxy returns the snap http proxy for the environment.
+// Falls back to the default http-proxy if not specified.
+func (c *Config) SnapHTTPProxy() string {
+	return addSchemeIfMissing("http", c.getWithFallback(SnapHTTPProxyKey, HTTPProxyKey))
+}
+
+// SnapHTTPSProxy returns the snap https proxy for the environment.
+// Falls back to the default https-proxy if not specified.
+func (c *Config) SnapHTTPSProxy() string {
+	return addSchemeIfMissing("https", c.getWithFallback(SnapHTTPSProxyKey, HTTPSProxyKey))
+}
+
 // AptMirror sets the apt mirror for the environment.
 func (c *Config) AptMirror() string {
 	return c.asString("apt-mirror")
@@ -778,6 +984,217 @@ func (c *Config) TransmitVendorMetrics() bool {
 	}
 }
 
+// IPv6Mode returns how the model uses IPv6.
+func (c *Config) IPv6Mode() network.IPv6Mode {
+	if v, ok := c.defined[IPv6ModeKey].(string); ok && v != "" {
+		return network.IPv6Mode(v)
+	}
+	return network.IPv6Disabled
+}
+
+// RemoveMissingSubnets reports whether space discovery should remove
+// subnets that the provider no longer reports, and that no machine
+// has an address in.
+func (c *Config) RemoveMissingSubnets() bool {
+	value, _ := c.defined[RemoveMissingSubnetsKey].(bool)
+	return value
+}
+
+// LeadershipLeaseDuration returns the duration of the leadership
+// leases claimed by units in the model.
+func (c *Config) LeadershipLeaseDuration() time.Duration {
+	return c.durationOrDefault(LeadershipLeaseDurationKey, DefaultLeadershipLeaseDuration)
+}
+
+// LeadershipRenewalInterval returns the interval at which units in
+// the model renew the leadership leases they hold.
+func (c *Config) LeadershipRenewalInterval() time.Duration {
+	return c.durationOrDefault(LeadershipRenewalIntervalKey, DefaultLeadershipRenewalInterval)
+}
+
+// MaxRelationDataSize returns the maximum size, in bytes, of the
+// settings a unit may write to a relation. Zero, the default, means
+// no limit.
+func (c *Config) MaxRelationDataSize() int {
+	v, _ := c.defined[MaxRelationDataSizeKey].(int)
+	return v
+}
+
+// QuotaKeys holds the keys of the model quotas. Only controller
+// administrators may change them.
+var QuotaKeys = []string{MaxMachinesKey, MaxUnitsKey, MaxStorageKey}
+
+// MaxMachines returns the maximum number of machines in the model.
+// Zero, the default, means no limit.
+func (c *Config) MaxMachines() int {
+	v, _ := c.defined[MaxMachinesKey].(int)
+	return v
+}
+
+// MaxUnits returns the maximum number of units in the model. Zero,
+// the default, means no limit.
+func (c *Config) MaxUnits() int {
+	v, _ := c.defined[MaxUnitsKey].(int)
+	return v
+}
+
+// MaxStorage returns the maximum number of storage instances in the
+// model. Zero, the default, means no limit.
+func (c *Config) MaxStorage() int {
+	v, _ := c.defined[MaxStorageKey].(int)
+	return v
+}
+
+// MaintenanceWindow returns the daily period, in the form
+// "HH:MM-HH:MM" UTC, during which machines may perform scheduled
+// reboots. An empty string means that they may reboot at any time.
+func (c *Config) MaintenanceWindow() string {
+	return c.asString(MaintenanceWindowKey)
+}
+
+// SSHBastion returns the host, in the form [user@]host[:port],
+// through which juju ssh and juju scp connect to the model's
+// machines. An empty string means that they connect directly, or
+// through the controller if proxy-ssh is set.
+func (c *Config) SSHBastion() string {
+	return c.asString(SSHBastionKey)
+}
+
+// UnitDrainTimeout returns how long a dying unit's pre-remove hook may
+// run before it is killed and the unit's removal carries on. Zero means
+// that there is no limit.
+func (c *Config) UnitDrainTimeout() time.Duration {
+	return c.durationOrDefault(UnitDrainTimeoutKey, DefaultUnitDrainTimeout)
+}
+
+// AllowUnsafeLXDProfiles returns whether charms deployed to the model
+// may ship LXD profiles with config or devices that juju considers
+// unsafe. By default this is false.
+func (c *Config) AllowUnsafeLXDProfiles() bool {
+	val, _ := c.defined[AllowUnsafeLXDProfilesKey].(bool)
+	return val
+}
+
+// ImageMetadataSources returns the sources searched for image
+// metadata, in order, with each one's signing policy. If none are
+// listed, image-metadata-url, the cloud's sources and the official
+// sources are searched, in that order.
+func (c *Config) ImageMetadataSources() []ImageMetadataSource {
+	// The sources have already been validated.
+	sources, _ := ParseImageMetadataSources(c.asString(ImageMetadataSourcesKey))
+	return sources
+}
+
+// ImageMetadataMaxAge returns how long image metadata cached from
+// published sources is kept before it is removed, to be fetched afresh
+// the next time the published sources are read. Zero, the default,
+// means that it is kept indefinitely.
+func (c *Config) ImageMetadataMaxAge() time.Duration {
+	return c.durationOrDefault(ImageMetadataMaxAgeKey, 0)
+}
+
+// ProvisioningRetryInterval returns how long the provisioner waits,
+// after giving up on starting a machine's instance, before it tries
+// again without waiting for retry-provisioning. Zero, the default,
+// means that failed machines are only retried on request.
+func (c *Config) ProvisioningRetryInterval() time.Duration {
+	return c.durationOrDefault(ProvisioningRetryIntervalKey, 0)
+}
+
+// ProvisioningRetryMaxAttempts returns how many times the provisioning
+// of a machine is retried automatically before the machine is left in
+// error until retry-provisioning is run.
+func (c *Config) ProvisioningRetryMaxAttempts() int {
+	if v, ok := c.defined[ProvisioningRetryMaxAttemptsKey].(int); ok {
+		return v
+	}
+	return DefaultProvisioningRetryMaxAttempts
+}
+
+// ContainerIPRanges returns the ranges of addresses from which
+// containers are given static addresses when the provider cannot
+// allocate container addresses itself.
+func (c *Config) ContainerIPRanges() []IPRange {
+	// The ranges have already been validated.
+	ranges, _ := ParseIPRanges(c.asString(ContainerIPRangesKey))
+	return ranges
+}
+
+// AZDistribution returns how new instances are spread across
+// availability zones: one of AZDistributionBalanced (the default),
+// AZDistributionStrict or AZDistributionNone.
+func (c *Config) AZDistribution() string {
+	if v := c.asString(AZDistributionKey); v != "" {
+		return v
+	}
+	return AZDistributionBalanced
+}
+
+// DestroyOrphanedResources reports whether instances, volumes and
+// security groups that the cloud holds for the model, but which the
+// model no longer knows about, should be destroyed rather than only
+// reported. By default this is false.
+func (c *Config) DestroyOrphanedResources() bool {
+	val, _ := c.defined[DestroyOrphanedResourcesKey].(bool)
+	return val
+}
+
+// durationOrDefault returns the named attribute as a duration, or
+// the supplied default if it is not set.
+func (c *Config) durationOrDefault(name string, defaultValue time.Duration) time.Duration {
+	if v, ok := c.defined[name].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// validateNonNegativeDuration checks that the named attribute, if set,
+// is a duration that is not negative.
+func (c *Config) validateNonNegativeDuration(name string) error {
+	v, ok := c.defined[name].(string)
+	if !ok || v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s in model configuration", name)
+	}
+	if d < 0 {
+		return errors.Errorf("%s must not be negative, got %v", name, d)
+	}
+	return nil
+}
+
+// validateLeadershipLease checks that the leadership lease duration
+// and renewal interval are valid durations, and that units will renew
+// their leases before they expire.
+func (c *Config) validateLeadershipLease() error {
+	for _, attr := range []string{LeadershipLeaseDurationKey, LeadershipRenewalIntervalKey} {
+		if v, ok := c.defined[attr].(string); ok && v != "" {
+			if _, err := time.ParseDuration(v); err != nil {
+				return errors.Annotatef(err, "invalid %s in model configuration", attr)
+			}
+		}
+	}
+	lease := c.LeadershipLeaseDuration()
+	if lease < MinLeadershipLeaseDuration || lease > MaxLeadershipLeaseDuration {
+		return errors.Errorf(
+			"%s must be between %v and %v, got %v",
+			LeadershipLeaseDurationKey, MinLeadershipLeaseDuration, MaxLeadershipLeaseDuration, lease,
+		)
+	}
+	renewal := c.LeadershipRenewalInterval()
+	if renewal <= 0 || renewal >= lease {
+		return errors.Errorf(
+			"%s must be positive and shorter than %s (%v), got %v",
+			LeadershipRenewalIntervalKey, LeadershipLeaseDurationKey, lease, renewal,
+		)
+	}
+	return nil
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -944,34 +1361,55 @@ var alwaysOptional = schema.Defaults{
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey: schema.Omit,
 
-	"firewall-mode":              schema.Omit,
-	"logging-config":             schema.Omit,
-	ProvisionerHarvestModeKey:    schema.Omit,
-	HTTPProxyKey:                 schema.Omit,
-	HTTPSProxyKey:                schema.Omit,
-	FTPProxyKey:                  schema.Omit,
-	NoProxyKey:                   schema.Omit,
-	AptHTTPProxyKey:              schema.Omit,
-	AptHTTPSProxyKey:             schema.Omit,
-	AptFTPProxyKey:               schema.Omit,
-	"apt-mirror":                 schema.Omit,
-	AgentStreamKey:               schema.Omit,
-	ResourceTagsKey:              schema.Omit,
-	"cloudimg-base-url":          schema.Omit,
-	"enable-os-refresh-update":   schema.Omit,
-	"enable-os-upgrade":          schema.Omit,
-	"image-stream":               schema.Omit,
-	"image-metadata-url":         schema.Omit,
-	AgentMetadataURLKey:          schema.Omit,
-	"default-series":             schema.Omit,
-	"development":                schema.Omit,
-	"ssl-hostname-verification":  schema.Omit,
-	"proxy-ssh":                  schema.Omit,
-	"disable-network-management": schema.Omit,
-	IgnoreMachineAddresses:       schema.Omit,
-	AutomaticallyRetryHooks:      schema.Omit,
-	"test-mode":                  schema.Omit,
-	TransmitVendorMetricsKey:     schema.Omit,
+	"firewall-mode":                 schema.Omit,
+	"logging-config":                schema.Omit,
+	ProvisionerHarvestModeKey:       schema.Omit,
+	HTTPProxyKey:                    schema.Omit,
+	HTTPSProxyKey:                   schema.Omit,
+	FTPProxyKey:                     schema.Omit,
+	NoProxyKey:                      schema.Omit,
+	AptHTTPProxyKey:                 schema.Omit,
+	AptHTTPSProxyKey:                schema.Omit,
+	AptFTPProxyKey:                  schema.Omit,
+	SnapHTTPProxyKey:                schema.Omit,
+	SnapHTTPSProxyKey:               schema.Omit,
+	"apt-mirror":                    schema.Omit,
+	AgentStreamKey:                  schema.Omit,
+	ResourceTagsKey:                 schema.Omit,
+	"cloudimg-base-url":             schema.Omit,
+	"enable-os-refresh-update":      schema.Omit,
+	"enable-os-upgrade":             schema.Omit,
+	"image-stream":                  schema.Omit,
+	"image-metadata-url":            schema.Omit,
+	AgentMetadataURLKey:             schema.Omit,
+	"default-series":                schema.Omit,
+	"development":                   schema.Omit,
+	"ssl-hostname-verification":     schema.Omit,
+	"proxy-ssh":                     schema.Omit,
+	"disable-network-management":    schema.Omit,
+	IgnoreMachineAddresses:          schema.Omit,
+	AutomaticallyRetryHooks:         schema.Omit,
+	"test-mode":                     schema.Omit,
+	TransmitVendorMetricsKey:        schema.Omit,
+	IPv6ModeKey:                     schema.Omit,
+	RemoveMissingSubnetsKey:         schema.Omit,
+	LeadershipLeaseDurationKey:      schema.Omit,
+	LeadershipRenewalIntervalKey:    schema.Omit,
+	MaxRelationDataSizeKey:          schema.Omit,
+	MaxMachinesKey:                  schema.Omit,
+	MaxUnitsKey:                     schema.Omit,
+	MaxStorageKey:                   schema.Omit,
+	MaintenanceWindowKey:            schema.Omit,
+	AllowUnsafeLXDProfilesKey:       schema.Omit,
+	ContainerIPRangesKey:            schema.Omit,
+	AZDistributionKey:               schema.Omit,
+	DestroyOrphanedResourcesKey:     schema.Omit,
+	SSHBastionKey:                   schema.Omit,
+	UnitDrainTimeoutKey:             schema.Omit,
+	ImageMetadataSourcesKey:         schema.Omit,
+	ImageMetadataMaxAgeKey:          schema.Omit,
+	ProvisioningRetryIntervalKey:    schema.Omit,
+	ProvisioningRetryMaxAttemptsKey: schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1005,6 +1443,7 @@ var immutableAttributes = []string{
 	TypeKey,
 	UUIDKey,
 	"firewall-mode",
+	IPv6ModeKey,
 }
 
 var (
@@ -1082,6 +1521,15 @@ func AptProxyConfigMap(proxySettings proxy.Settings) map[string]interface{} {
 	return settings
 }
 
+// SnapProxyConfigMap returns a map suitable to be applied to a Config to
+// update snap proxy settings.
+func SnapProxyConfigMap(proxySettings proxy.Settings) map[string]interface{} {
+	settings := make(map[string]interface{})
+	addIfNotEmpty(settings, SnapHTTPProxyKey, proxySettings.Http)
+	addIfNotEmpty(settings, SnapHTTPSProxyKey, proxySettings.Https)
+	return settings
+}
+
 // Schema returns a configuration schema that includes both
 // the given extra fields and all the fields defined in this package.
 // It returns an error if extra defines any fields defined in this
@@ -1222,6 +1670,20 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ImageMetadataSourcesKey: {
+		Description: `A YAML list of the sources searched for image metadata, in order of priority. Each source has a type: 'custom', with a url and optionally a public-key used to verify signed metadata; 'cloud', for the sources provided by the model's cloud; or 'default', for the official sources. Any source may set require-signed: true, so that unsigned metadata from it is ignored.
+
+Sources that are not listed are not searched. If empty, image-metadata-url, the cloud's sources and the official sources are searched, in that order.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	ImageMetadataMaxAgeKey: {
+		Description: `How long image metadata cached from published sources is kept, for example 720h. Older metadata is removed each time the published sources are checked, so that it is fetched afresh, and metadata that is no longer published stops being used.
+
+Metadata added with the source 'custom' is never removed. If empty or 0s, cached metadata is kept indefinitely.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
 	"image-stream": {
 		Description: `The simplestreams stream used to identify which image ids to search when starting an instance.`,
 		Type:        environschema.Tstring,
@@ -1287,6 +1749,16 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	SnapHTTPProxyKey: {
+		Description: "The snap store HTTP proxy for the model",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SnapHTTPSProxyKey: {
+		Description: "The snap store HTTPS proxy for the model",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1327,4 +1799,121 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	IPv6ModeKey: {
+		Description: `How the model uses IPv6.
+
+'disabled' prefers IPv4 addresses, using IPv6 addresses only when
+no IPv4 address is available.
+
+'dual-stack' prefers IPv6 addresses, and opens firewall ports to
+both IPv4 and IPv6 traffic.
+
+'ipv6-only' never uses IPv4 addresses, and opens firewall ports to
+IPv6 traffic only.`,
+		Type:      environschema.Tstring,
+		Values:    []interface{}{string(network.IPv6Disabled), string(network.IPv6DualStack), string(network.IPv6Only)},
+		Immutable: true,
+		Group:     environschema.EnvironGroup,
+	},
+	RemoveMissingSubnetsKey: {
+		Description: "Whether space discovery removes subnets that the provider no longer reports, and that no machine has an address in",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	LeadershipLeaseDurationKey: {
+		Description: `The duration of the leadership leases claimed by units, between 5s and 5m.
+
+Shorter leases allow leadership to fail over more quickly when a
+leader's agent dies, at the cost of more frequent lease renewals.
+Changes take effect as unit agents restart their leadership trackers.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	LeadershipRenewalIntervalKey: {
+		Description: "The interval at which units renew the leadership leases they hold; must be shorter than leadership-lease-duration",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxRelationDataSizeKey: {
+		Description: "The maximum size in bytes of the settings a unit may write to a relation; unset or 0 means no limit",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxMachinesKey: {
+		Description: "The maximum number of machines in the model; unset or 0 means no limit. Only controller administrators may change it",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxUnitsKey: {
+		Description: "The maximum number of units in the model; unset or 0 means no limit. Only controller administrators may change it",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxStorageKey: {
+		Description: "The maximum number of storage instances in the model; unset or 0 means no limit. Only controller administrators may change it",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	MaintenanceWindowKey: {
+		Description: `The daily period, in the form HH:MM-HH:MM UTC, during which machines may perform scheduled reboots.
+
+If empty, scheduled reboots happen as soon as they are requested.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	AllowUnsafeLXDProfilesKey: {
+		Description: `Determines whether charms may ship LXD profiles that set config other than environment.* and user.* keys, or that add devices other than unix-char, gpu and usb devices`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	ContainerIPRangesKey: {
+		Description: `Comma-separated ranges of addresses, in the form LOW-HIGH, from which containers are given static addresses when the provider cannot allocate container addresses itself.
+
+Each range must lie within a subnet that the container's host is connected to. If empty, such containers use DHCP.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	AZDistributionKey: {
+		Description: `How new instances are spread across availability zones.
+
+'balanced' starts each instance in the zone with the fewest instances of the same application, trying other zones if that fails.
+
+'strict' only starts an instance in a zone with no instances of the same application, and fails provisioning if there is no such zone.
+
+'none' ignores the application, spreading all of the model's instances evenly.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{AZDistributionBalanced, AZDistributionStrict, AZDistributionNone},
+		Group:  environschema.EnvironGroup,
+	},
+	DestroyOrphanedResourcesKey: {
+		Description: `Determines whether instances, volumes and security groups that the cloud holds for the model, but which the model no longer knows about, are destroyed. If false, they are only reported in the controller's logs`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	SSHBastionKey: {
+		Description: `A host, in the form [user@]host[:port], through which juju ssh and juju scp connect to the model's machines.
+
+This takes precedence over proxy-ssh. If empty, machines are connected to directly, or through the controller if proxy-ssh is true.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	UnitDrainTimeoutKey: {
+		Description: `How long a unit's pre-remove hook may run, draining connections or moving data elsewhere, before it is killed and the unit's removal carries on; defaults to 10m.
+
+If 0s, the hook may run for as long as it needs.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	ProvisioningRetryIntervalKey: {
+		Description: `How long to wait, after giving up on starting a machine's instance, before retrying automatically as retry-provisioning would, for example 10m. Each attempt and the provider's error are recorded in the machine's status history.
+
+If empty or 0s, failed machines are only retried by retry-provisioning.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	ProvisioningRetryMaxAttemptsKey: {
+		Description: "How many times the provisioning of a machine is retried automatically, when provisioning-retry-interval is set, before it is left in error for retry-provisioning; defaults to 5",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
 
@@ -532,6 +533,226 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"transmit-vendor-metrics": false,
 		}),
+	}, {
+		about:       "allow-unsafe-lxd-profiles enabled",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"allow-unsafe-lxd-profiles": true,
+		}),
+	}, {
+		about:       "ipv6-mode dual-stack",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"ipv6-mode": "dual-stack",
+		}),
+	}, {
+		about:       "ipv6-mode ipv6-only",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"ipv6-mode": "ipv6-only",
+		}),
+	}, {
+		about:       "Invalid ipv6-mode",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"ipv6-mode": "sometimes",
+		}),
+		err: `ipv6-mode: expected one of \[disabled dual-stack ipv6-only\], got "sometimes"`,
+	}, {
+		about:       "Leadership lease settings",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"leadership-lease-duration":   "10s",
+			"leadership-renewal-interval": "4s",
+		}),
+	}, {
+		about:       "Invalid leadership-lease-duration",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"leadership-lease-duration": "soon",
+		}),
+		err: `invalid leadership-lease-duration in model configuration: time: invalid duration "?soon"?`,
+	}, {
+		about:       "Too short leadership-lease-duration",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"leadership-lease-duration": "1s",
+		}),
+		err: `leadership-lease-duration must be between 5s and 5m0s, got 1s`,
+	}, {
+		about:       "leadership-renewal-interval not shorter than leadership-lease-duration",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"leadership-lease-duration":   "10s",
+			"leadership-renewal-interval": "10s",
+		}),
+		err: `leadership-renewal-interval must be positive and shorter than leadership-lease-duration \(10s\), got 10s`,
+	}, {
+		about:       "Relation data size limit",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-relation-data-size": 4096,
+		}),
+	}, {
+		about:       "Negative max-relation-data-size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-relation-data-size": -1,
+		}),
+		err: `max-relation-data-size must not be negative, got -1`,
+	}, {
+		about:       "Model quotas",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-machines": 10,
+			"max-units":    20,
+			"max-storage":  5,
+		}),
+	}, {
+		about:       "Negative max-units",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-units": -1,
+		}),
+		err: `max-units must not be negative, got -1`,
+	}, {
+		about:       "Maintenance window",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"maintenance-window": "22:00-02:30",
+		}),
+	}, {
+		about:       "Invalid maintenance window",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"maintenance-window": "22:00",
+		}),
+		err: `maintenance window "22:00" \(expected HH:MM-HH:MM\) not valid`,
+	}, {
+		about:       "Container IP ranges",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"container-ip-ranges": "10.0.0.100-10.0.0.199,10.0.1.10-10.0.1.20",
+		}),
+	}, {
+		about:       "Invalid container IP ranges",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"container-ip-ranges": "10.0.0.199-10.0.0.100",
+		}),
+		err: `invalid container-ip-ranges: IP range "10.0.0.199-10.0.0.100" \(low address is greater than high\) not valid`,
+	}, {
+		about:       "az-distribution strict",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"az-distribution": "strict",
+		}),
+	}, {
+		about:       "az-distribution none",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"az-distribution": "none",
+		}),
+	}, {
+		about:       "Invalid az-distribution",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"az-distribution": "random",
+		}),
+		err: `az-distribution: expected one of \[balanced strict none\], got "random"`,
+	}, {
+		about:       "destroy-orphaned-resources enabled",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"destroy-orphaned-resources": true,
+		}),
+	}, {
+		about:       "SSH bastion",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"ssh-bastion": "admin@jump.example.com:2222",
+		}),
+	}, {
+		about:       "Invalid SSH bastion",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"ssh-bastion": "jump.example.com:ssh",
+		}),
+		err: `SSH bastion "jump.example.com:ssh" \(expected \[user@\]host\[:port\]\) not valid`,
+	}, {
+		about:       "Image metadata sources",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-metadata-sources": "[{type: custom, url: 'http://mirror.invalid/images'}, {type: default}]",
+		}),
+	}, {
+		about:       "Image metadata sources with image-metadata-url",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-metadata-sources": "[{type: default}]",
+			"image-metadata-url":     "http://mirror.invalid/images",
+		}),
+		err: `cannot set both image-metadata-url and image-metadata-sources; list the URL as a custom source instead`,
+	}, {
+		about:       "Image metadata max age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-metadata-max-age": "720h",
+		}),
+	}, {
+		about:       "Negative image metadata max age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-metadata-max-age": "-1h",
+		}),
+		err: `image-metadata-max-age must not be negative, got -1h0m0s`,
+	}, {
+		about:       "Provisioning retry policy",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioning-retry-interval":     "10m",
+			"provisioning-retry-max-attempts": 3,
+		}),
+	}, {
+		about:       "Negative provisioning retry interval",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioning-retry-interval": "-10m",
+		}),
+		err: `provisioning-retry-interval must not be negative, got -10m0s`,
+	}, {
+		about:       "Negative provisioning retry max attempts",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioning-retry-max-attempts": -1,
+		}),
+		err: `provisioning-retry-max-attempts must not be negative, got -1`,
+	}, {
+		about:       "Unit drain timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"unit-drain-timeout": "90s",
+		}),
+	}, {
+		about:       "Unit drain timeout disabled",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"unit-drain-timeout": "0s",
+		}),
+	}, {
+		about:       "Invalid unit drain timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"unit-drain-timeout": "soon",
+		}),
+		err: `invalid unit-drain-timeout in model configuration: time: invalid duration "?soon"?`,
+	}, {
+		about:       "Negative unit drain timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"unit-drain-timeout": "-1m",
+		}),
+		err: `unit-drain-timeout must not be negative, got -1m0s`,
 	}, {
 		about:       "Valid syslog config values",
 		useDefaults: config.UseDefaults,
@@ -713,6 +934,113 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Assert(resourceTags, gc.HasLen, 0)
 	}
 
+	if m, _ := test.attrs["ipv6-mode"].(string); m != "" {
+		c.Assert(cfg.IPv6Mode(), gc.Equals, network.IPv6Mode(m))
+	} else {
+		c.Assert(cfg.IPv6Mode(), gc.Equals, network.IPv6Disabled)
+	}
+
+	if v, _ := test.attrs["leadership-lease-duration"].(string); v != "" {
+		c.Assert(cfg.LeadershipLeaseDuration().String(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.LeadershipLeaseDuration(), gc.Equals, config.DefaultLeadershipLeaseDuration)
+	}
+	if v, _ := test.attrs["leadership-renewal-interval"].(string); v != "" {
+		c.Assert(cfg.LeadershipRenewalInterval().String(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.LeadershipRenewalInterval(), gc.Equals, config.DefaultLeadershipRenewalInterval)
+	}
+	if v, ok := test.attrs["max-relation-data-size"].(int); ok {
+		c.Assert(cfg.MaxRelationDataSize(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.MaxRelationDataSize(), gc.Equals, 0)
+	}
+	if v, ok := test.attrs["max-machines"].(int); ok {
+		c.Assert(cfg.MaxMachines(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.MaxMachines(), gc.Equals, 0)
+	}
+	if v, ok := test.attrs["max-units"].(int); ok {
+		c.Assert(cfg.MaxUnits(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.MaxUnits(), gc.Equals, 0)
+	}
+	if v, ok := test.attrs["max-storage"].(int); ok {
+		c.Assert(cfg.MaxStorage(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.MaxStorage(), gc.Equals, 0)
+	}
+
+	if v, ok := test.attrs["maintenance-window"].(string); ok {
+		c.Assert(cfg.MaintenanceWindow(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.MaintenanceWindow(), gc.Equals, "")
+	}
+
+	if v, ok := test.attrs["container-ip-ranges"].(string); ok {
+		var ranges []string
+		for _, r := range cfg.ContainerIPRanges() {
+			ranges = append(ranges, r.String())
+		}
+		c.Assert(strings.Join(ranges, ","), gc.Equals, v)
+	} else {
+		c.Assert(cfg.ContainerIPRanges(), gc.HasLen, 0)
+	}
+
+	if v, ok := test.attrs["az-distribution"].(string); ok {
+		c.Assert(cfg.AZDistribution(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.AZDistribution(), gc.Equals, config.AZDistributionBalanced)
+	}
+
+	if v, ok := test.attrs["allow-unsafe-lxd-profiles"].(bool); ok {
+		c.Assert(cfg.AllowUnsafeLXDProfiles(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.AllowUnsafeLXDProfiles(), jc.IsFalse)
+	}
+
+	if v, ok := test.attrs["destroy-orphaned-resources"].(bool); ok {
+		c.Assert(cfg.DestroyOrphanedResources(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.DestroyOrphanedResources(), jc.IsFalse)
+	}
+
+	if v, ok := test.attrs["ssh-bastion"].(string); ok {
+		c.Assert(cfg.SSHBastion(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.SSHBastion(), gc.Equals, "")
+	}
+
+	if v, ok := test.attrs["image-metadata-max-age"].(string); ok {
+		expected, err := time.ParseDuration(v)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(cfg.ImageMetadataMaxAge(), gc.Equals, expected)
+	} else {
+		c.Assert(cfg.ImageMetadataMaxAge(), gc.Equals, time.Duration(0))
+	}
+
+	if v, ok := test.attrs["provisioning-retry-interval"].(string); ok {
+		expected, err := time.ParseDuration(v)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(cfg.ProvisioningRetryInterval(), gc.Equals, expected)
+	} else {
+		c.Assert(cfg.ProvisioningRetryInterval(), gc.Equals, time.Duration(0))
+	}
+
+	if v, ok := test.attrs["provisioning-retry-max-attempts"].(int); ok {
+		c.Assert(cfg.ProvisioningRetryMaxAttempts(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.ProvisioningRetryMaxAttempts(), gc.Equals, config.DefaultProvisioningRetryMaxAttempts)
+	}
+
+	if v, ok := test.attrs["unit-drain-timeout"].(string); ok {
+		expected, err := time.ParseDuration(v)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(cfg.UnitDrainTimeout(), gc.Equals, expected)
+	} else {
+		c.Assert(cfg.UnitDrainTimeout(), gc.Equals, config.DefaultUnitDrainTimeout)
+	}
+
 	xmit := cfg.TransmitVendorMetrics()
 	expectedXmit, xmitAsserted := test.attrs["transmit-vendor-metrics"]
 	if xmitAsserted {
@@ -813,6 +1141,11 @@ var validationTests = []validationTest{{
 	old:   testing.Attrs{"firewall-mode": config.FwGlobal},
 	new:   testing.Attrs{"firewall-mode": config.FwNone},
 	err:   `cannot change firewall-mode from "global" to "none"`,
+}, {
+	about: "Can't change the ipv6-mode",
+	old:   testing.Attrs{"ipv6-mode": "disabled"},
+	new:   testing.Attrs{"ipv6-mode": "dual-stack"},
+	err:   `cannot change ipv6-mode from "disabled" to "dual-stack"`,
 }, {
 	about: "Cannot change uuid",
 	old:   testing.Attrs{"uuid": "90168e4c-2f10-4e9c-83c2-1fb55a58e5a9"},
@@ -1003,6 +1336,8 @@ func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	c.Assert(config.AptHTTPSProxy(), gc.Equals, "https://user@10.0.0.1")
 	c.Assert(config.FTPProxy(), gc.Equals, "ftp://user@10.0.0.1")
 	c.Assert(config.AptFTPProxy(), gc.Equals, "ftp://user@10.0.0.1")
+	c.Assert(config.SnapHTTPProxy(), gc.Equals, "http://user@10.0.0.1")
+	c.Assert(config.SnapHTTPSProxy(), gc.Equals, "https://user@10.0.0.1")
 	c.Assert(config.NoProxy(), gc.Equals, "localhost,10.0.3.1")
 }
 
@@ -1027,12 +1362,14 @@ func (s *ConfigSuite) TestProxyValuesWithFallbackNoScheme(c *gc.C) {
 func (s *ConfigSuite) TestProxyValues(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{
-		"http-proxy":      "http://user@10.0.0.1",
-		"https-proxy":     "https://user@10.0.0.1",
-		"ftp-proxy":       "ftp://user@10.0.0.1",
-		"apt-http-proxy":  "http://user@10.0.0.2",
-		"apt-https-proxy": "https://user@10.0.0.2",
-		"apt-ftp-proxy":   "ftp://user@10.0.0.2",
+		"http-proxy":       "http://user@10.0.0.1",
+		"https-proxy":      "https://user@10.0.0.1",
+		"ftp-proxy":        "ftp://user@10.0.0.1",
+		"apt-http-proxy":   "http://user@10.0.0.2",
+		"apt-https-proxy":  "https://user@10.0.0.2",
+		"apt-ftp-proxy":    "ftp://user@10.0.0.2",
+		"snap-http-proxy":  "http://user@10.0.0.3",
+		"snap-https-proxy": "https://user@10.0.0.3",
 	})
 	c.Assert(config.HTTPProxy(), gc.Equals, "http://user@10.0.0.1")
 	c.Assert(config.AptHTTPProxy(), gc.Equals, "http://user@10.0.0.2")
@@ -1040,6 +1377,12 @@ func (s *ConfigSuite) TestProxyValues(c *gc.C) {
 	c.Assert(config.AptHTTPSProxy(), gc.Equals, "https://user@10.0.0.2")
 	c.Assert(config.FTPProxy(), gc.Equals, "ftp://user@10.0.0.1")
 	c.Assert(config.AptFTPProxy(), gc.Equals, "ftp://user@10.0.0.2")
+	c.Assert(config.SnapHTTPProxy(), gc.Equals, "http://user@10.0.0.3")
+	c.Assert(config.SnapHTTPSProxy(), gc.Equals, "https://user@10.0.0.3")
+	c.Assert(config.SnapProxySettings(), gc.Equals, proxy.Settings{
+		Http:  "http://user@10.0.0.3",
+		Https: "https://user@10.0.0.3",
+	})
 }
 
 func (s *ConfigSuite) TestProxyValuesNotSet(c *gc.C) {
@@ -1051,6 +1394,8 @@ func (s *ConfigSuite) TestProxyValuesNotSet(c *gc.C) {
 	c.Assert(config.AptHTTPSProxy(), gc.Equals, "")
 	c.Assert(config.FTPProxy(), gc.Equals, "")
 	c.Assert(config.AptFTPProxy(), gc.Equals, "")
+	c.Assert(config.SnapHTTPProxy(), gc.Equals, "")
+	c.Assert(config.SnapHTTPSProxy(), gc.Equals, "")
 	c.Assert(config.NoProxy(), gc.Equals, "")
 }
 
@@ -1076,6 +1421,23 @@ func (s *ConfigSuite) TestProxyConfigMap(c *gc.C) {
 	c.Assert(cfg.AptProxySettings(), gc.DeepEquals, expectedProxySettings)
 }
 
+func (s *ConfigSuite) TestSnapProxyConfigMap(c *gc.C) {
+	s.addJujuFiles(c)
+	cfg := newTestConfig(c, testing.Attrs{})
+	cfg, err := cfg.Apply(config.SnapProxyConfigMap(proxy.Settings{
+		Http:  "snap.http.proxy",
+		Https: "snap.https.proxy",
+		Ftp:   "ignored",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	// Snap proxy settings always include the scheme.
+	c.Assert(cfg.SnapProxySettings(), gc.DeepEquals, proxy.Settings{
+		Http:  "http://snap.http.proxy",
+		Https: "https://snap.https.proxy",
+	})
+	c.Assert(cfg.HTTPProxy(), gc.Equals, "")
+}
+
 func (s *ConfigSuite) TestAptProxyConfigMap(c *gc.C) {
 	s.addJujuFiles(c)
 	cfg := newTestConfig(c, testing.Attrs{})
@@ -3,6 +3,26 @@
 
 package config
 
+import (
+	"github.com/juju/schema"
+)
+
 var (
 	ConfigSchema = configSchema
 )
+
+// ResetExtensions clears the registered extension attributes and
+// provider schemas, returning a function that restores them.
+func ResetExtensions() (restore func()) {
+	extensions.Lock()
+	defer extensions.Unlock()
+	saved, savedSchemas := extensions.byProvider, extensions.schemas
+	extensions.byProvider = make(map[string]map[string]extension)
+	extensions.schemas = make(map[string]schema.Fields)
+	return func() {
+		extensions.Lock()
+		defer extensions.Unlock()
+		extensions.byProvider = saved
+		extensions.schemas = savedSchemas
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/controller"
+)
+
+// ExtensionAttr describes a model config attribute that is not
+// defined by this package, but is registered with RegisterExtension
+// by a provider or another part of juju, so that its value is checked
+// whenever the config is validated.
+type ExtensionAttr struct {
+	// Description is a human-readable description of the attribute.
+	Description string
+
+	// Type is the type of the attribute's value.
+	Type environschema.FieldType
+
+	// Default, if not nil, is the value of the attribute
+	// when it is not set.
+	Default interface{}
+
+	// Immutable reports whether the attribute may not be
+	// changed once set.
+	Immutable bool
+}
+
+// extension is a registered ExtensionAttr.
+type extension struct {
+	ExtensionAttr
+	checker schema.Checker
+}
+
+var extensions = struct {
+	sync.Mutex
+	// byProvider maps provider types to the extensions registered
+	// for models of that type. Extensions registered for models of
+	// any type are keyed by the empty string.
+	byProvider map[string]map[string]extension
+
+	// schemas maps provider types to the config schemas registered
+	// with RegisterProviderSchema.
+	schemas map[string]schema.Fields
+}{
+	byProvider: make(map[string]map[string]extension),
+	schemas:    make(map[string]schema.Fields),
+}
+
+// validAttrName matches well-formed config attribute names.
+var validAttrName = regexp.MustCompile(`^[a-z][a-z0-9]*([-_][a-z0-9]+)*$`)
+
+// RegisterExtension registers a model config attribute with the given
+// name. If providerType is empty, the attribute applies to models of
+// any provider type; otherwise only to models of the given type.
+//
+// Once registered, the attribute's value is coerced to the registered
+// type, and checked for immutability, when config is validated; and
+// it may be set on models that did not previously have it without
+// being treated as unknown.
+func RegisterExtension(providerType, name string, attr ExtensionAttr) error {
+	if !validAttrName.MatchString(name) {
+		return errors.NotValidf("config attribute name %q", name)
+	}
+	if _, ok := configSchema[name]; ok {
+		return errors.Errorf("config field %q clashes with global config", name)
+	}
+	if controller.ControllerOnlyAttribute(name) {
+		return errors.Errorf("config field %q clashes with controller config", name)
+	}
+	fields, _, err := environschema.Fields{
+		name: {Description: attr.Description, Type: attr.Type},
+	}.ValidationSchema()
+	if err != nil {
+		return errors.Annotatef(err, "config field %q", name)
+	}
+	checker := fields[name]
+	if attr.Default != nil {
+		if _, err := checker.Coerce(attr.Default, []string{name}); err != nil {
+			return errors.Annotate(err, "invalid default")
+		}
+	}
+
+	extensions.Lock()
+	defer extensions.Unlock()
+	for registeredType, attrs := range extensions.byProvider {
+		if providerType != "" && registeredType != "" && registeredType != providerType {
+			continue
+		}
+		if _, ok := attrs[name]; ok {
+			return errors.AlreadyExistsf("config extension %q", name)
+		}
+	}
+	attrs, ok := extensions.byProvider[providerType]
+	if !ok {
+		attrs = make(map[string]extension)
+		extensions.byProvider[providerType] = attrs
+	}
+	attrs[name] = extension{attr, checker}
+	return nil
+}
+
+// RegisterProviderSchema records the config schema of the provider
+// for models of the given type. ValidateNewAttrs uses it to recognise
+// the provider's attributes, so that it can check the config of
+// models that do not exist yet, whose provider cannot be looked up
+// through state.
+func RegisterProviderSchema(providerType string, fields schema.Fields) {
+	extensions.Lock()
+	defer extensions.Unlock()
+	extensions.schemas[providerType] = fields
+}
+
+func providerSchema(providerType string) schema.Fields {
+	extensions.Lock()
+	defer extensions.Unlock()
+	return extensions.schemas[providerType]
+}
+
+// Extensions returns the model config attributes registered with
+// RegisterExtension that apply to models of the given provider type.
+func Extensions(providerType string) map[string]ExtensionAttr {
+	result := make(map[string]ExtensionAttr)
+	for name, ext := range providerExtensions(providerType) {
+		result[name] = ext.ExtensionAttr
+	}
+	return result
+}
+
+func providerExtensions(providerType string) map[string]extension {
+	extensions.Lock()
+	defer extensions.Unlock()
+	result := make(map[string]extension)
+	for _, key := range []string{"", providerType} {
+		for name, ext := range extensions.byProvider[key] {
+			result[name] = ext
+		}
+	}
+	return result
+}
+
+// Extension returns the value of the named extension attribute, or
+// its default if it is not set. The result is false if no extension
+// with that name is registered for the model's provider type, or if
+// it is neither set nor has a default.
+func (c *Config) Extension(name string) (interface{}, bool) {
+	ext, ok := providerExtensions(c.Type())[name]
+	if !ok {
+		return nil, false
+	}
+	if v, ok := c.unknown[name]; ok {
+		return v, true
+	}
+	return ext.Default, ext.Default != nil
+}
+
+// coerceExtensions coerces the values of the registered extension
+// attributes in c to their registered types. It is only called by New,
+// before c is returned, as a Config must not change once it is shared.
+func (c *Config) coerceExtensions() error {
+	for name, ext := range providerExtensions(c.Type()) {
+		v, ok := c.unknown[name]
+		if !ok {
+			continue
+		}
+		coerced, err := ext.checker.Coerce(v, []string{name})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.unknown[name] = coerced
+	}
+	return nil
+}
+
+// validateExtensions checks that the values of the registered
+// extension attributes in cfg have their registered types, and that
+// immutable ones have not changed from those in old, if not nil.
+// Neither config is modified.
+func validateExtensions(cfg, old *Config) error {
+	if len(cfg.unknown) == 0 {
+		return nil
+	}
+	for name, ext := range providerExtensions(cfg.Type()) {
+		newv, ok := cfg.unknown[name]
+		if ok {
+			coerced, err := ext.checker.Coerce(newv, []string{name})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			newv = coerced
+		}
+		if !ext.Immutable || old == nil {
+			continue
+		}
+		oldv, ok := old.unknown[name]
+		if !ok {
+			continue
+		}
+		if coerced, err := ext.checker.Coerce(oldv, []string{name}); err == nil {
+			oldv = coerced
+		}
+		if !reflect.DeepEqual(oldv, newv) {
+			return errors.Errorf("cannot change %s from %#v to %#v", name, oldv, newv)
+		}
+	}
+	return nil
+}
+
+// ValidateNewAttrs returns an error if cfg sets any attribute that is
+// not set in old, or any attribute at all if old is nil, which is not
+// defined by this package, by the schema registered for the model's
+// provider type, or by an extension registered for that type, and
+// whose name is either malformed or a likely misspelling of a known
+// attribute's name. Other unknown attributes are accepted, as before.
+//
+// Attributes already set in old are not checked, so that models whose
+// config was written before the check was introduced remain usable.
+func ValidateNewAttrs(cfg, old *Config) error {
+	providerFields := providerSchema(cfg.Type())
+	known := make(map[string]string)
+	for name := range configSchema {
+		known[normalizeAttrName(name)] = name
+	}
+	for name := range providerFields {
+		known[normalizeAttrName(name)] = name
+	}
+	registered := providerExtensions(cfg.Type())
+	for name := range registered {
+		known[normalizeAttrName(name)] = name
+	}
+
+	var oldAttrs map[string]interface{}
+	if old != nil {
+		oldAttrs = old.AllAttrs()
+	}
+	var added []string
+	for name := range cfg.unknown {
+		if _, ok := registered[name]; ok {
+			continue
+		}
+		if _, ok := providerFields[name]; ok {
+			continue
+		}
+		if _, ok := oldAttrs[name]; ok {
+			continue
+		}
+		added = append(added, name)
+	}
+	sort.Strings(added)
+	for _, name := range added {
+		if suggestion, ok := known[normalizeAttrName(name)]; ok {
+			return errors.NewNotValid(nil, fmt.Sprintf(
+				"unknown model config attribute %q (did you mean %q?)",
+				name, suggestion,
+			))
+		}
+		if !validAttrName.MatchString(name) {
+			return errors.NewNotValid(nil, fmt.Sprintf(
+				"invalid model config attribute name %q", name,
+			))
+		}
+	}
+	return nil
+}
+
+// normalizeAttrName returns the given attribute name without surrounding
+// whitespace, in lower case, and with underscores replaced by hyphens.
+func normalizeAttrName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.Replace(name, "_", "-", -1)
+}
@@ -0,0 +1,205 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+type ExtensionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ExtensionSuite{})
+
+func (s *ExtensionSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	restore := config.ResetExtensions()
+	s.AddCleanup(func(*gc.C) { restore() })
+
+	err := config.RegisterExtension("", "widget-count", config.ExtensionAttr{
+		Description: "The number of widgets",
+		Type:        environschema.Tint,
+		Default:     3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = config.RegisterExtension("my-type", "widget-colour", config.ExtensionAttr{
+		Description: "The colour of the widgets",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ExtensionSuite) TestRegisterExtensionInvalid(c *gc.C) {
+	for i, test := range []struct {
+		providerType string
+		name         string
+		attr         config.ExtensionAttr
+		err          string
+	}{{
+		name: "http-proxy ",
+		attr: config.ExtensionAttr{Type: environschema.Tstring},
+		err:  `config attribute name "http-proxy " not valid`,
+	}, {
+		name: "http-proxy",
+		attr: config.ExtensionAttr{Type: environschema.Tstring},
+		err:  `config field "http-proxy" clashes with global config`,
+	}, {
+		name: "api-port",
+		attr: config.ExtensionAttr{Type: environschema.Tint},
+		err:  `config field "api-port" clashes with controller config`,
+	}, {
+		name: "gadget-count",
+		attr: config.ExtensionAttr{Type: environschema.Tint, Default: "lots"},
+		err:  `invalid default: gadget-count: expected number, got string\("lots"\)`,
+	}, {
+		providerType: "other-type",
+		name:         "widget-count",
+		attr:         config.ExtensionAttr{Type: environschema.Tint},
+		err:          `config extension "widget-count" already exists`,
+	}, {
+		name: "widget-colour",
+		attr: config.ExtensionAttr{Type: environschema.Tstring},
+		err:  `config extension "widget-colour" already exists`,
+	}} {
+		c.Logf("test %d: %s", i, test.name)
+		err := config.RegisterExtension(test.providerType, test.name, test.attr)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+
+	// The same name may be registered for different provider types.
+	err := config.RegisterExtension("other-type", "widget-colour", config.ExtensionAttr{
+		Type: environschema.Tstring,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ExtensionSuite) TestExtensions(c *gc.C) {
+	c.Assert(config.Extensions("my-type"), gc.HasLen, 2)
+	c.Assert(config.Extensions("other-type"), jc.DeepEquals, map[string]config.ExtensionAttr{
+		"widget-count": {
+			Description: "The number of widgets",
+			Type:        environschema.Tint,
+			Default:     3,
+		},
+	})
+}
+
+func (s *ExtensionSuite) TestExtensionValue(c *gc.C) {
+	cfg := newTestConfig(c, nil)
+	value, ok := cfg.Extension("widget-count")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(value, gc.Equals, 3)
+	_, ok = cfg.Extension("widget-colour")
+	c.Assert(ok, jc.IsFalse)
+	_, ok = cfg.Extension("unregistered")
+	c.Assert(ok, jc.IsFalse)
+
+	// Values are coerced to the registered type when the
+	// config is created.
+	cfg = newTestConfig(c, testing.Attrs{"widget-count": "5"})
+	value, ok = cfg.Extension("widget-count")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(value, gc.Equals, 5)
+}
+
+func (s *ExtensionSuite) TestValidateExtensionType(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type":         "my-type",
+		"name":         "my-name",
+		"uuid":         testing.ModelTag.Id(),
+		"widget-count": "many",
+	})
+	c.Assert(err, gc.ErrorMatches, `widget-count: expected number, got string\("many"\)`)
+}
+
+func (s *ExtensionSuite) TestValidateDoesNotModifyConfig(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{"widget-count": 5})
+	attrs := cfg.AllAttrs()
+	c.Assert(config.Validate(cfg, nil), jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs(), jc.DeepEquals, attrs)
+}
+
+func (s *ExtensionSuite) TestValidateExtensionImmutable(c *gc.C) {
+	old := newTestConfig(c, testing.Attrs{"widget-colour": "red"})
+	cfg, err := old.Apply(map[string]interface{}{"widget-count": 4})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.Validate(cfg, old), jc.ErrorIsNil)
+
+	cfg, err = old.Apply(map[string]interface{}{"widget-colour": "blue"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = config.Validate(cfg, old)
+	c.Assert(err, gc.ErrorMatches, `cannot change widget-colour from "red" to "blue"`)
+
+	cfg, err = old.Remove([]string{"widget-colour"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = config.Validate(cfg, old)
+	c.Assert(err, gc.ErrorMatches, `cannot change widget-colour from "red" to <nil>`)
+}
+
+// providerFields holds the fields of a provider's config schema,
+// which may have names that would not be valid for extensions.
+var providerFields = schema.Fields{
+	"subnetId": schema.String(),
+}
+
+func (s *ExtensionSuite) TestValidateNewAttrs(c *gc.C) {
+	config.RegisterProviderSchema("my-type", providerFields)
+	for i, test := range []struct {
+		attrs testing.Attrs
+		err   string
+	}{{
+		attrs: testing.Attrs{"widget-count": 1, "vpc-id": "vpc-123"},
+	}, {
+		attrs: testing.Attrs{"subnetId": "subnet-123"},
+	}, {
+		attrs: testing.Attrs{"subnetid": "subnet-123"},
+		err:   `unknown model config attribute "subnetid" \(did you mean "subnetId"\?\)`,
+	}, {
+		attrs: testing.Attrs{"http-proxy ": "http://proxy"},
+		err:   `unknown model config attribute "http-proxy " \(did you mean "http-proxy"\?\)`,
+	}, {
+		attrs: testing.Attrs{"HTTP_PROXY": "http://proxy"},
+		err:   `unknown model config attribute "HTTP_PROXY" \(did you mean "http-proxy"\?\)`,
+	}, {
+		attrs: testing.Attrs{"widget_count": 1},
+		err:   `unknown model config attribute "widget_count" \(did you mean "widget-count"\?\)`,
+	}, {
+		attrs: testing.Attrs{"vpc id": "vpc-123"},
+		err:   `invalid model config attribute name "vpc id"`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg := newTestConfig(c, test.attrs)
+		err := config.ValidateNewAttrs(cfg, nil)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+		}
+	}
+}
+
+func (s *ExtensionSuite) TestValidateNewAttrsExisting(c *gc.C) {
+	config.RegisterProviderSchema("my-type", providerFields)
+	// Malformed attributes that are already set are not
+	// rejected, so that existing models remain usable.
+	old := newTestConfig(c, testing.Attrs{"http-proxy ": "http://proxy"})
+	cfg, err := old.Apply(map[string]interface{}{"widget-count": 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.ValidateNewAttrs(cfg, old), jc.ErrorIsNil)
+
+	cfg, err = old.Apply(map[string]interface{}{"no_proxy": "localhost"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = config.ValidateNewAttrs(cfg, old)
+	c.Assert(err, gc.ErrorMatches, `unknown model config attribute "no_proxy" \(did you mean "no-proxy"\?\)`)
+}
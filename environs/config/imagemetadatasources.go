@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ImageMetadataSourceCustom identifies a source of image metadata
+	// at a URL chosen by the user, such as a local mirror.
+	ImageMetadataSourceCustom = "custom"
+
+	// ImageMetadataSourceCloud identifies the sources of image metadata
+	// that the model's cloud provides, such as a keystone catalogue.
+	ImageMetadataSourceCloud = "cloud"
+
+	// ImageMetadataSourceDefault identifies the official sources of
+	// image metadata.
+	ImageMetadataSourceDefault = "default"
+)
+
+// ImageMetadataSource describes one of the sources of image metadata
+// listed in a model's image-metadata-sources setting.
+type ImageMetadataSource struct {
+	// Type is one of ImageMetadataSourceCustom,
+	// ImageMetadataSourceCloud or ImageMetadataSourceDefault.
+	Type string `yaml:"type"`
+
+	// URL is the location of a custom source's metadata.
+	URL string `yaml:"url,omitempty"`
+
+	// PublicKey is the armored public key used to verify a custom
+	// source's signed metadata. If it is empty, the user's public
+	// signing key is used.
+	PublicKey string `yaml:"public-key,omitempty"`
+
+	// RequireSigned, if true, causes unsigned metadata from the
+	// source to be ignored. It cannot relax a source that already
+	// requires signed metadata.
+	RequireSigned bool `yaml:"require-signed,omitempty"`
+}
+
+// ParseImageMetadataSources parses a YAML list of image metadata
+// sources, in the order in which they should be searched. Custom
+// sources must have a URL; the cloud and default sources may each be
+// listed at most once, and take no URL or key. An empty string returns
+// no sources, meaning that the default search order is used.
+func ParseImageMetadataSources(s string) ([]ImageMetadataSource, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var sources []ImageMetadataSource
+	if err := yaml.Unmarshal([]byte(s), &sources); err != nil {
+		return nil, errors.NotValidf("image metadata sources (%v)", err)
+	}
+	seen := set.NewStrings()
+	for i, source := range sources {
+		switch source.Type {
+		case ImageMetadataSourceCustom:
+			if source.URL == "" {
+				return nil, errors.NotValidf("image metadata source %d without url", i)
+			}
+		case ImageMetadataSourceCloud, ImageMetadataSourceDefault:
+			if source.URL != "" || source.PublicKey != "" {
+				return nil, errors.NotValidf("%s image metadata source with url or public-key", source.Type)
+			}
+			if seen.Contains(source.Type) {
+				return nil, errors.NotValidf("repeated %s image metadata source", source.Type)
+			}
+			seen.Add(source.Type)
+		default:
+			return nil, errors.NotValidf(
+				"image metadata source %d type %q (expected %q, %q or %q)",
+				i, source.Type,
+				ImageMetadataSourceCustom,
+				ImageMetadataSourceCloud,
+				ImageMetadataSourceDefault,
+			)
+		}
+	}
+	return sources, nil
+}
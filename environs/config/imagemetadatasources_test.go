@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+type ImageMetadataSourcesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ImageMetadataSourcesSuite{})
+
+func (s *ImageMetadataSourcesSuite) TestParseEmpty(c *gc.C) {
+	sources, err := config.ParseImageMetadataSources("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sources, gc.HasLen, 0)
+}
+
+func (s *ImageMetadataSourcesSuite) TestParse(c *gc.C) {
+	sources, err := config.ParseImageMetadataSources(`
+- type: custom
+  url: http://mirror.invalid/images
+  public-key: key
+  require-signed: true
+- type: cloud
+- type: default
+  require-signed: true
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sources, jc.DeepEquals, []config.ImageMetadataSource{{
+		Type:          config.ImageMetadataSourceCustom,
+		URL:           "http://mirror.invalid/images",
+		PublicKey:     "key",
+		RequireSigned: true,
+	}, {
+		Type: config.ImageMetadataSourceCloud,
+	}, {
+		Type:          config.ImageMetadataSourceDefault,
+		RequireSigned: true,
+	}})
+}
+
+func (s *ImageMetadataSourcesSuite) TestParseInvalid(c *gc.C) {
+	for _, test := range []struct {
+		sources string
+		err     string
+	}{{
+		sources: "type: custom",
+		err:     `image metadata sources \(.*\) not valid`,
+	}, {
+		sources: "[{type: mirror}]",
+		err:     `image metadata source 0 type "mirror" \(expected "custom", "cloud" or "default"\) not valid`,
+	}, {
+		sources: "[{type: custom}]",
+		err:     `image metadata source 0 without url not valid`,
+	}, {
+		sources: "[{type: default, url: 'http://mirror.invalid'}]",
+		err:     `default image metadata source with url or public-key not valid`,
+	}, {
+		sources: "[{type: cloud}, {type: cloud}]",
+		err:     `repeated cloud image metadata source not valid`,
+	}} {
+		c.Logf("sources %q", test.sources)
+		_, err := config.ParseImageMetadataSources(test.sources)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
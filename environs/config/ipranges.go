@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"bytes"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// IPRange is an inclusive range of IP addresses.
+type IPRange struct {
+	Low  net.IP
+	High net.IP
+}
+
+// String returns the range in the form accepted by ParseIPRanges.
+func (r IPRange) String() string {
+	return r.Low.String() + "-" + r.High.String()
+}
+
+// Within reports whether the whole range lies inside the given network.
+func (r IPRange) Within(ipNet *net.IPNet) bool {
+	return ipNet.Contains(r.Low) && ipNet.Contains(r.High)
+}
+
+// ParseIPRanges parses a comma-separated list of IP address ranges of
+// the form "LOW-HIGH", such as "10.0.0.100-10.0.0.199". Both ends of
+// a range must be of the same address family, and LOW must not be
+// greater than HIGH.
+func ParseIPRanges(s string) ([]IPRange, error) {
+	var ranges []IPRange
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bounds := strings.Split(part, "-")
+		if len(bounds) != 2 {
+			return nil, errors.NotValidf("IP range %q (expected LOW-HIGH)", part)
+		}
+		low := net.ParseIP(strings.TrimSpace(bounds[0]))
+		high := net.ParseIP(strings.TrimSpace(bounds[1]))
+		if low == nil || high == nil {
+			return nil, errors.NotValidf("IP range %q (expected LOW-HIGH)", part)
+		}
+		if (low.To4() == nil) != (high.To4() == nil) {
+			return nil, errors.NotValidf("IP range %q (mixed address families)", part)
+		}
+		if low4 := low.To4(); low4 != nil {
+			low, high = low4, high.To4()
+		}
+		if bytes.Compare(low, high) > 0 {
+			return nil, errors.NotValidf("IP range %q (low address is greater than high)", part)
+		}
+		ranges = append(ranges, IPRange{Low: low, High: high})
+	}
+	return ranges, nil
+}
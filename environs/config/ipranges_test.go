@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config_test
+
+import (
+	"net"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+type IPRangesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&IPRangesSuite{})
+
+func (s *IPRangesSuite) TestParseEmpty(c *gc.C) {
+	ranges, err := config.ParseIPRanges("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ranges, gc.HasLen, 0)
+}
+
+func (s *IPRangesSuite) TestParse(c *gc.C) {
+	ranges, err := config.ParseIPRanges("10.0.0.100-10.0.0.199, 2001:db8::10-2001:db8::20")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ranges, gc.HasLen, 2)
+	c.Assert(ranges[0].String(), gc.Equals, "10.0.0.100-10.0.0.199")
+	c.Assert(ranges[1].String(), gc.Equals, "2001:db8::10-2001:db8::20")
+
+	_, ipNet, err := net.ParseCIDR("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ranges[0].Within(ipNet), jc.IsTrue)
+	c.Assert(ranges[1].Within(ipNet), jc.IsFalse)
+}
+
+func (s *IPRangesSuite) TestParseInvalid(c *gc.C) {
+	for _, test := range []struct {
+		ranges string
+		err    string
+	}{
+		{"10.0.0.1", `IP range "10.0.0.1" \(expected LOW-HIGH\) not valid`},
+		{"10.0.0.1-foo", `IP range "10.0.0.1-foo" \(expected LOW-HIGH\) not valid`},
+		{"10.0.0.1-2001:db8::1", `IP range "10.0.0.1-2001:db8::1" \(mixed address families\) not valid`},
+		{"10.0.0.9-10.0.0.1", `IP range "10.0.0.9-10.0.0.1" \(low address is greater than high\) not valid`},
+	} {
+		_, err := config.ParseIPRanges(test.ranges)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
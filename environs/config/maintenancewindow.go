@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const day = 24 * time.Hour
+
+// MaintenanceWindow describes a daily period of time, in UTC, during
+// which disruptive operations such as scheduled reboots may happen.
+// The window may span midnight, in which case End is before Start.
+type MaintenanceWindow struct {
+	// Start is the offset from midnight at which the window opens.
+	Start time.Duration
+
+	// End is the offset from midnight at which the window closes.
+	End time.Duration
+}
+
+// ParseMaintenanceWindow parses a maintenance window of the form
+// "HH:MM-HH:MM", with times in UTC. An empty string returns a nil
+// window, meaning that maintenance may happen at any time.
+func ParseMaintenanceWindow(s string) (*MaintenanceWindow, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return nil, errors.NotValidf("maintenance window %q (expected HH:MM-HH:MM)", s)
+	}
+	var offsets [2]time.Duration
+	for i, part := range parts {
+		t, err := time.Parse("15:04", strings.TrimSpace(part))
+		if err != nil {
+			return nil, errors.NotValidf("maintenance window %q (expected HH:MM-HH:MM)", s)
+		}
+		offsets[i] = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if offsets[0] == offsets[1] {
+		return nil, errors.NotValidf("maintenance window %q (start and end must differ)", s)
+	}
+	return &MaintenanceWindow{Start: offsets[0], End: offsets[1]}, nil
+}
+
+// String returns the window in the form accepted by
+// ParseMaintenanceWindow.
+func (w MaintenanceWindow) String() string {
+	midnight := time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)
+	return midnight.Add(w.Start).Format("15:04") + "-" + midnight.Add(w.End).Format("15:04")
+}
+
+// Contains reports whether the window is open at the given time.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	offset := sinceMidnight(t)
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// Until returns how long it is from the given time until the window
+// next opens, or zero if the window is open.
+func (w MaintenanceWindow) Until(t time.Time) time.Duration {
+	if w.Contains(t) {
+		return 0
+	}
+	wait := w.Start - sinceMidnight(t)
+	if wait < 0 {
+		wait += day
+	}
+	return wait
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	t = t.UTC()
+	return t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+}
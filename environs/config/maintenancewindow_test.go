@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+type MaintenanceWindowSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MaintenanceWindowSuite{})
+
+func (s *MaintenanceWindowSuite) TestParseEmpty(c *gc.C) {
+	w, err := config.ParseMaintenanceWindow("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *MaintenanceWindowSuite) TestParse(c *gc.C) {
+	w, err := config.ParseMaintenanceWindow("02:30-04:00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, jc.DeepEquals, &config.MaintenanceWindow{
+		Start: 2*time.Hour + 30*time.Minute,
+		End:   4 * time.Hour,
+	})
+	c.Assert(w.String(), gc.Equals, "02:30-04:00")
+}
+
+func (s *MaintenanceWindowSuite) TestParseInvalid(c *gc.C) {
+	for _, test := range []struct {
+		window string
+		err    string
+	}{
+		{"02:30", `maintenance window "02:30" \(expected HH:MM-HH:MM\) not valid`},
+		{"02:30-25:00", `maintenance window "02:30-25:00" \(expected HH:MM-HH:MM\) not valid`},
+		{"2am-4am", `maintenance window "2am-4am" \(expected HH:MM-HH:MM\) not valid`},
+		{"02:30-02:30", `maintenance window "02:30-02:30" \(start and end must differ\) not valid`},
+	} {
+		_, err := config.ParseMaintenanceWindow(test.window)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MaintenanceWindowSuite) TestContainsAndUntil(c *gc.C) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2017, 3, 1, hour, minute, 0, 0, time.UTC)
+	}
+	for _, test := range []struct {
+		window string
+		t      time.Time
+		until  time.Duration
+	}{
+		{"02:00-04:00", at(1, 0), time.Hour},
+		{"02:00-04:00", at(2, 0), 0},
+		{"02:00-04:00", at(3, 59), 0},
+		{"02:00-04:00", at(4, 0), 22 * time.Hour},
+		{"22:00-02:00", at(23, 0), 0},
+		{"22:00-02:00", at(1, 0), 0},
+		{"22:00-02:00", at(2, 30), 19*time.Hour + 30*time.Minute},
+	} {
+		c.Logf("%s at %s", test.window, test.t)
+		w, err := config.ParseMaintenanceWindow(test.window)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(w.Contains(test.t), gc.Equals, test.until == 0)
+		c.Check(w.Until(test.t), gc.Equals, test.until)
+	}
+}
+
+func (s *MaintenanceWindowSuite) TestUntilUsesUTC(c *gc.C) {
+	w, err := config.ParseMaintenanceWindow("02:00-04:00")
+	c.Assert(err, jc.ErrorIsNil)
+	t := time.Date(2017, 3, 1, 3, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	c.Assert(w.Until(t), gc.Equals, time.Hour)
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// DefaultSSHBastionPort is the port used to connect to an SSH bastion
+// when none is specified.
+const DefaultSSHBastionPort = 22
+
+// SSHBastion describes a host through which SSH connections to a
+// model's machines are made.
+type SSHBastion struct {
+	// User is the user to connect to the bastion as. If it is
+	// empty, the SSH client's default is used.
+	User string
+
+	// Host is the host name or address of the bastion.
+	Host string
+
+	// Port is the port on which the bastion's SSH server listens.
+	Port int
+}
+
+// ParseSSHBastion parses a bastion of the form "[user@]host[:port]".
+// An IPv6 address with a port must be enclosed in square brackets.
+// An empty string returns a nil bastion, meaning that no bastion is
+// used.
+func ParseSSHBastion(s string) (*SSHBastion, error) {
+	if s == "" {
+		return nil, nil
+	}
+	invalid := errors.NotValidf("SSH bastion %q (expected [user@]host[:port])", s)
+	if strings.ContainsAny(s, " \t\n") {
+		return nil, invalid
+	}
+	b := SSHBastion{Port: DefaultSSHBastionPort}
+	hostPort := s
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		b.User, hostPort = s[:i], s[i+1:]
+		if b.User == "" {
+			return nil, invalid
+		}
+	}
+	b.Host = hostPort
+	if host, port, err := net.SplitHostPort(hostPort); err == nil {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return nil, invalid
+		}
+		b.Host, b.Port = host, n
+	} else if strings.Count(hostPort, ":") == 1 {
+		return nil, invalid
+	}
+	if b.Host == "" || strings.ContainsAny(b.Host, "[]") {
+		return nil, invalid
+	}
+	return &b, nil
+}
+
+// String returns the bastion in the form accepted by ParseSSHBastion.
+func (b SSHBastion) String() string {
+	s := b.Host
+	if b.Port != DefaultSSHBastionPort {
+		s = net.JoinHostPort(b.Host, strconv.Itoa(b.Port))
+	}
+	if b.User != "" {
+		s = b.User + "@" + s
+	}
+	return s
+}
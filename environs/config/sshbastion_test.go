@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+type SSHBastionSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SSHBastionSuite{})
+
+func (s *SSHBastionSuite) TestParseEmpty(c *gc.C) {
+	b, err := config.ParseSSHBastion("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(b, gc.IsNil)
+}
+
+func (s *SSHBastionSuite) TestParse(c *gc.C) {
+	for _, test := range []struct {
+		bastion string
+		expect  config.SSHBastion
+	}{
+		{"jump.example.com", config.SSHBastion{Host: "jump.example.com", Port: 22}},
+		{"admin@jump.example.com", config.SSHBastion{User: "admin", Host: "jump.example.com", Port: 22}},
+		{"admin@10.0.0.1:2222", config.SSHBastion{User: "admin", Host: "10.0.0.1", Port: 2222}},
+		{"[2001:db8::1]:2222", config.SSHBastion{Host: "2001:db8::1", Port: 2222}},
+		{"2001:db8::1", config.SSHBastion{Host: "2001:db8::1", Port: 22}},
+	} {
+		c.Logf("bastion %q", test.bastion)
+		b, err := config.ParseSSHBastion(test.bastion)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(*b, jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *SSHBastionSuite) TestString(c *gc.C) {
+	for _, bastion := range []string{
+		"jump.example.com",
+		"admin@10.0.0.1:2222",
+		"[2001:db8::1]:2222",
+	} {
+		b, err := config.ParseSSHBastion(bastion)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(b.String(), gc.Equals, bastion)
+	}
+}
+
+func (s *SSHBastionSuite) TestParseInvalid(c *gc.C) {
+	for _, bastion := range []string{
+		"@jump.example.com",
+		"jump.example.com:ssh",
+		"jump.example.com:0",
+		"jump.example.com:65536",
+		":2222",
+		"jump example",
+		"[2001:db8::1]",
+	} {
+		_, err := config.ParseSSHBastion(bastion)
+		c.Check(err, gc.ErrorMatches, `SSH bastion ".*" \(expected \[user@\]host\[:port\]\) not valid`)
+	}
+}
@@ -6,8 +6,10 @@ package environs_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/manual"
 	"github.com/juju/juju/testing"
@@ -38,6 +40,26 @@ type dummyProvider struct {
 	environs.EnvironProvider
 }
 
+type extendedProvider struct {
+	environs.EnvironProvider
+}
+
+func (extendedProvider) ConfigExtensions() map[string]config.ExtensionAttr {
+	return map[string]config.ExtensionAttr{
+		"extended-widgets": {Type: environschema.Tint, Default: 7},
+	}
+}
+
+func (s *suite) TestRegisterProviderConfigExtensions(c *gc.C) {
+	s.PatchValue(environs.Providers, make(map[string]environs.EnvironProvider))
+	s.PatchValue(environs.ProviderAliases, make(map[string]string))
+	environs.RegisterProvider("extended", extendedProvider{})
+	c.Assert(config.Extensions("extended"), jc.DeepEquals, map[string]config.ExtensionAttr{
+		"extended-widgets": {Type: environschema.Tint, Default: 7},
+	})
+	c.Assert(config.Extensions("dummy"), gc.HasLen, 0)
+}
+
 func (s *suite) TestRegisterProvider(c *gc.C) {
 	s.PatchValue(environs.Providers, make(map[string]environs.EnvironProvider))
 	s.PatchValue(environs.ProviderAliases, make(map[string]string))
@@ -4,11 +4,13 @@
 package environs
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 )
@@ -82,14 +84,17 @@ func UnregisterImageDataSourceFunc(id string) {
 // simplestreams image id metadata for the given stream.
 func ImageMetadataSources(env Environ) ([]simplestreams.DataSource, error) {
 	config := env.Config()
+	verify := utils.VerifySSLHostnames
+	if !config.SSLHostnameVerification() {
+		verify = utils.NoVerifySSLHostnames
+	}
+	if listed := config.ImageMetadataSources(); len(listed) > 0 {
+		return listedImageMetadataSources(env, listed, verify)
+	}
 
 	// Add configured and environment-specific datasources.
 	var sources []simplestreams.DataSource
 	if userURL, ok := config.ImageMetadataURL(); ok {
-		verify := utils.VerifySSLHostnames
-		if !config.SSLHostnameVerification() {
-			verify = utils.NoVerifySSLHostnames
-		}
 		publicKey, _ := simplestreams.UserPublicSigningKey()
 		sources = append(sources, simplestreams.NewURLSignedDataSource("image-metadata-url", userURL, publicKey, verify, simplestreams.SPECIFIC_CLOUD_DATA, false))
 	}
@@ -114,6 +119,75 @@ func ImageMetadataSources(env Environ) ([]simplestreams.DataSource, error) {
 	return sources, nil
 }
 
+// listedImageMetadataSources returns the datasources for the sources
+// listed in the model's image-metadata-sources setting. Each listed
+// source ranks above those listed after it, and its datasources are
+// made to require signed metadata if the listing says so.
+func listedImageMetadataSources(
+	env Environ,
+	listed []config.ImageMetadataSource,
+	verify utils.SSLHostnameVerification,
+) ([]simplestreams.DataSource, error) {
+	var sources []simplestreams.DataSource
+	for i, entry := range listed {
+		var entrySources []simplestreams.DataSource
+		switch entry.Type {
+		case config.ImageMetadataSourceCustom:
+			publicKey := entry.PublicKey
+			if publicKey == "" {
+				publicKey, _ = simplestreams.UserPublicSigningKey()
+			}
+			entrySources = []simplestreams.DataSource{simplestreams.NewURLSignedDataSource(
+				fmt.Sprintf("%s %q", config.ImageMetadataSourcesKey, entry.URL),
+				entry.URL, publicKey, verify,
+				simplestreams.CUSTOM_CLOUD_DATA, entry.RequireSigned,
+			)}
+		case config.ImageMetadataSourceCloud:
+			var err error
+			entrySources, err = environmentDataSources(env)
+			if err != nil {
+				return nil, err
+			}
+		case config.ImageMetadataSourceDefault:
+			var err error
+			entrySources, err = imagemetadata.OfficialDataSources(env.Config().ImageStream())
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, source := range entrySources {
+			sources = append(sources, &listedDataSource{
+				DataSource:    source,
+				priority:      simplestreams.CUSTOM_CLOUD_DATA + len(listed) - i,
+				requireSigned: entry.RequireSigned,
+			})
+		}
+	}
+	for _, ds := range sources {
+		logger.Debugf("obtained image datasource %q", ds.Description())
+	}
+	return sources, nil
+}
+
+// listedDataSource is a datasource listed in a model's
+// image-metadata-sources setting, which determines its priority and
+// may require it to have signed metadata.
+type listedDataSource struct {
+	simplestreams.DataSource
+	priority      int
+	requireSigned bool
+}
+
+// Priority is defined in simplestreams.DataSource.
+func (s *listedDataSource) Priority() int {
+	return s.priority
+}
+
+// RequireSigned is defined in simplestreams.DataSource.
+func (s *listedDataSource) RequireSigned() bool {
+	return s.requireSigned || s.DataSource.RequireSigned()
+}
+
 // environmentDataSources returns simplestreams datasources for the environment
 // by calling the functions registered in RegisterImageDataSourceFunc.
 // The datasources returned will be in the same order the functions were registered.
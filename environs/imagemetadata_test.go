@@ -45,6 +45,10 @@ func (s *ImageMetadataSuite) env(c *gc.C, imageMetadataURL, stream string) envir
 			"image-metadata-url": imageMetadataURL,
 		})
 	}
+	return s.envWithAttrs(c, attrs)
+}
+
+func (s *ImageMetadataSuite) envWithAttrs(c *gc.C, attrs testing.Attrs) environs.Environ {
 	env, err := bootstrap.Prepare(
 		envtesting.BootstrapContext(c),
 		jujuclienttesting.NewMemStore(),
@@ -131,3 +135,35 @@ func (s *ImageMetadataSuite) TestImageMetadataURLsNonReleaseStream(c *gc.C) {
 		{"http://cloud-images.ubuntu.com/daily/", imagemetadata.SimplestreamsImagesPublicKey},
 	})
 }
+
+func (s *ImageMetadataSuite) TestImageMetadataSourcesListed(c *gc.C) {
+	environs.RegisterImageDataSourceFunc("id0", func(environs.Environ) (simplestreams.DataSource, error) {
+		return simplestreams.NewURLDataSource("id0", "betwixt/releases", utils.NoVerifySSLHostnames, simplestreams.SPECIFIC_CLOUD_DATA, false), nil
+	})
+	defer environs.UnregisterImageDataSourceFunc("id0")
+
+	env := s.envWithAttrs(c, dummy.SampleConfig().Merge(testing.Attrs{
+		"image-metadata-sources": `
+- type: default
+- type: custom
+  url: mirror/images
+  public-key: mirror-key
+- type: cloud
+  require-signed: true
+`,
+	}))
+	sources, err := environs.ImageMetadataSources(env)
+	c.Assert(err, jc.ErrorIsNil)
+	sstesting.AssertExpectedSources(c, sources, []sstesting.SourceDetails{
+		{"https://streams.canonical.com/juju/images/releases/", keys.JujuPublicKey},
+		{"http://cloud-images.ubuntu.com/releases/", imagemetadata.SimplestreamsImagesPublicKey},
+		{"mirror/images/", "mirror-key"},
+		{"betwixt/releases/", ""},
+	})
+	// Sources listed first rank highest.
+	c.Assert(sources[0].Priority(), gc.Equals, sources[1].Priority())
+	c.Assert(sources[1].Priority() > sources[2].Priority(), jc.IsTrue)
+	c.Assert(sources[2].Priority() > sources[3].Priority(), jc.IsTrue)
+	c.Assert(sources[2].RequireSigned(), jc.IsFalse)
+	c.Assert(sources[3].RequireSigned(), jc.IsTrue)
+}
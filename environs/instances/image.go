@@ -33,6 +33,10 @@ type InstanceConstraint struct {
 	// eg ["ssd", "ebs"] means find images with ssd storage, but if none
 	// exist, find those with ebs instead.
 	Storage []string
+
+	// Prices provides the prices of instance types, for the max-price
+	// constraint. Instance types cannot be chosen by price without it.
+	Prices PriceSource
 }
 
 // String returns a human readable form of this InstanceConstraint.
@@ -75,6 +79,10 @@ func FindInstanceSpec(possibleImages []Image, ic *InstanceConstraint, allInstanc
 	if len(matchingTypes) == 0 {
 		return nil, fmt.Errorf("no instance types found matching constraint: %s", ic)
 	}
+	matchingTypes, err = matchingTypesWithinPrice(matchingTypes, ic)
+	if err != nil {
+		return nil, err
+	}
 
 	// We check for exact matches (all attributes matching), and also for
 	// partial matches (instance type specifies attribute, but image does
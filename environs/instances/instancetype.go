@@ -24,6 +24,8 @@ type InstanceType struct {
 	CpuPower   *uint64
 	Tags       []string
 	Deprecated bool
+	GPUs       uint64 // The number of GPUs or other accelerators attached.
+	GPUType    string // The type of the attached GPUs, if any.
 }
 
 // InstanceTypesWithCostMetadata holds an array of InstanceType and metadata
@@ -80,6 +82,12 @@ func (itype InstanceType) match(cons constraints.Value) (InstanceType, bool) {
 	if cons.HasVirtType() && (itype.VirtType == nil || *itype.VirtType != *cons.VirtType) {
 		return nothing, false
 	}
+	if cons.GPUs != nil && itype.GPUs < *cons.GPUs {
+		return nothing, false
+	}
+	if cons.HasGPUType() && itype.GPUType != *cons.GPUType {
+		return nothing, false
+	}
 	return itype, true
 }
 
@@ -221,6 +221,24 @@ var getInstanceTypesTest = []struct {
 		cons:           "virt-type=hvm",
 		expectedItypes: []string{"cc1.4xlarge", "cc2.8xlarge"},
 		itypesToUse:    nil,
+	}, {
+		about: "gpus filtered by constraint",
+		cons:  "gpus=2",
+		itypesToUse: []InstanceType{
+			{Id: "3", Name: "it-3", Arches: []string{"amd64"}, Mem: 4096, GPUs: 8, GPUType: "nvidia-tesla-k80", Cost: 720},
+			{Id: "2", Name: "it-2", Arches: []string{"amd64"}, Mem: 4096, GPUs: 1, GPUType: "nvidia-grid-k520", Cost: 65},
+			{Id: "1", Name: "it-1", Arches: []string{"amd64"}, Mem: 4096, Cost: 10},
+		},
+		expectedItypes: []string{"it-3"},
+	}, {
+		about: "gpu-type filtered by constraint",
+		cons:  "gpu-type=nvidia-grid-k520",
+		itypesToUse: []InstanceType{
+			{Id: "3", Name: "it-3", Arches: []string{"amd64"}, Mem: 4096, GPUs: 8, GPUType: "nvidia-tesla-k80", Cost: 720},
+			{Id: "2", Name: "it-2", Arches: []string{"amd64"}, Mem: 4096, GPUs: 1, GPUType: "nvidia-grid-k520", Cost: 65},
+			{Id: "1", Name: "it-1", Arches: []string{"amd64"}, Mem: 4096, Cost: 10},
+		},
+		expectedItypes: []string{"it-2"},
 	}, {
 		about:          "deprecated image type requested by name",
 		cons:           "instance-type=dep.small",
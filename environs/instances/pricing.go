@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instances
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// PriceSource provides the prices of instance types, so that they can
+// be compared with the max-price constraint.
+type PriceSource interface {
+	// Price returns the price, in US dollars per hour, of running an
+	// instance of the given type, and whether the price is known.
+	Price(itype InstanceType) (float64, bool)
+}
+
+// CostPriceSource returns a PriceSource that takes prices from the
+// Cost of instance types, which is in US dollars per hour multiplied
+// by divisor, as described by InstanceTypesWithCostMetadata. Instance
+// types with no cost have no known price.
+func CostPriceSource(divisor uint64) PriceSource {
+	if divisor == 0 {
+		divisor = 1
+	}
+	return costPriceSource(divisor)
+}
+
+type costPriceSource uint64
+
+// Price is part of the PriceSource interface.
+func (divisor costPriceSource) Price(itype InstanceType) (float64, bool) {
+	if itype.Cost == 0 {
+		return 0, false
+	}
+	return float64(itype.Cost) / float64(divisor), true
+}
+
+// WithinPrice returns the instance types whose prices, as given by
+// prices, are no more than maxPrice, in the same order. Instance types
+// whose prices are not known are left out.
+func WithinPrice(itypes []InstanceType, prices PriceSource, maxPrice float64) []InstanceType {
+	var result []InstanceType
+	for _, itype := range itypes {
+		if price, ok := prices.Price(itype); ok && price <= maxPrice {
+			result = append(result, itype)
+		}
+	}
+	return result
+}
+
+// matchingTypesWithinPrice returns the given instance types, which match
+// the constraint ic, restricted to those within its max-price, if any.
+// Providers that do not know the prices of their instance types report
+// max-price as unsupported, so it is ignored if ic has no prices.
+func matchingTypesWithinPrice(itypes []InstanceType, ic *InstanceConstraint) ([]InstanceType, error) {
+	if !ic.Constraints.HasMaxPrice() {
+		return itypes, nil
+	}
+	maxPrice := strconv.FormatFloat(*ic.Constraints.MaxPrice, 'f', -1, 64)
+	if ic.Prices == nil {
+		logger.Warningf("instance type prices in %s are not known, ignoring max-price=%s", ic.Region, maxPrice)
+		return itypes, nil
+	}
+	itypes = WithinPrice(itypes, ic.Prices, *ic.Constraints.MaxPrice)
+	if len(itypes) == 0 {
+		return nil, fmt.Errorf("no instance types in %s matching constraints %q within max-price=%s", ic.Region, ic.Constraints, maxPrice)
+	}
+	return itypes, nil
+}
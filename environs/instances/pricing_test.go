@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instances
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type pricingSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&pricingSuite{})
+
+var pricedInstanceTypes = []InstanceType{
+	{Id: "3", Name: "it-3", Arches: []string{"amd64"}, CpuCores: 4, Mem: 8192, Cost: 400},
+	{Id: "2", Name: "it-2", Arches: []string{"amd64"}, CpuCores: 2, Mem: 4096, Cost: 200},
+	{Id: "1", Name: "it-1", Arches: []string{"amd64"}, CpuCores: 1, Mem: 2048, Cost: 100},
+	{Id: "0", Name: "it-0", Arches: []string{"amd64"}, CpuCores: 1, Mem: 2048},
+}
+
+func (s *pricingSuite) TestCostPriceSource(c *gc.C) {
+	prices := CostPriceSource(1000)
+	price, ok := prices.Price(pricedInstanceTypes[1])
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(price, gc.Equals, 0.2)
+	_, ok = prices.Price(pricedInstanceTypes[3])
+	c.Assert(ok, jc.IsFalse)
+
+	price, ok = CostPriceSource(0).Price(pricedInstanceTypes[1])
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(price, gc.Equals, 200.0)
+}
+
+func (s *pricingSuite) TestWithinPrice(c *gc.C) {
+	itypes := WithinPrice(pricedInstanceTypes, CostPriceSource(1000), 0.2)
+	c.Assert(itypes, jc.DeepEquals, pricedInstanceTypes[1:3])
+}
+
+func (s *pricingSuite) findInstanceSpec(cons string, prices PriceSource) (*InstanceSpec, error) {
+	images := []Image{{Id: "image", Arch: "amd64"}}
+	return FindInstanceSpec(images, &InstanceConstraint{
+		Region:      "test",
+		Series:      "precise",
+		Arches:      []string{"amd64"},
+		Constraints: constraints.MustParse(cons),
+		Prices:      prices,
+	}, pricedInstanceTypes)
+}
+
+func (s *pricingSuite) TestFindInstanceSpecMaxPrice(c *gc.C) {
+	spec, err := s.findInstanceSpec("cores=2 max-price=0.3", CostPriceSource(1000))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.InstanceType.Name, gc.Equals, "it-2")
+}
+
+func (s *pricingSuite) TestFindInstanceSpecMaxPriceTooLow(c *gc.C) {
+	_, err := s.findInstanceSpec("cores=4 max-price=0.3", CostPriceSource(1000))
+	c.Assert(err, gc.ErrorMatches, `no instance types in test matching constraints "cores=4 max-price=0.3" within max-price=0.3`)
+}
+
+func (s *pricingSuite) TestFindInstanceSpecMaxPriceUnknownPrices(c *gc.C) {
+	spec, err := s.findInstanceSpec("cores=4 max-price=0.3", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.InstanceType.Name, gc.Equals, "it-3")
+}
+
+func (s *pricingSuite) TestFindInstanceSpecNoMaxPrice(c *gc.C) {
+	spec, err := s.findInstanceSpec("cores=2", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.InstanceType.Name, gc.Equals, "it-2")
+}
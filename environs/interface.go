@@ -67,6 +67,16 @@ type ProviderSchema interface {
 	Schema() environschema.Fields
 }
 
+// ProviderConfigExtensions can be implemented by a provider to declare
+// typed model config attributes specific to models of its type. They
+// are registered with config.RegisterExtension when the provider is
+// registered.
+type ProviderConfigExtensions interface {
+	// ConfigExtensions returns the provider's config extension
+	// attributes, keyed by name.
+	ConfigExtensions() map[string]config.ExtensionAttr
+}
+
 // PrepareConfigParams contains the parameters for EnvironProvider.PrepareConfig.
 type PrepareConfigParams struct {
 	// Cloud is the cloud specification to use to connect to the cloud.
@@ -345,6 +355,68 @@ type InstanceTagger interface {
 	TagInstance(id instance.Id, tags map[string]string) error
 }
 
+// CloudInstance describes an instance as the cloud reports it.
+type CloudInstance struct {
+	// Id is the provider-specific id of the instance.
+	Id instance.Id
+
+	// Status is the provider-specific status of the instance.
+	Status string
+
+	// Tags holds the tags, or metadata, that the cloud records
+	// for the instance.
+	Tags map[string]string
+}
+
+// CloudInstanceLister is an interface that an Environ may implement to
+// list the instances that the cloud runs for the model, with their
+// tags, so that they can be reconciled against the model's machines.
+type CloudInstanceLister interface {
+	// CloudInstances returns every instance the cloud runs for the
+	// model, including any that the model no longer knows about.
+	CloudInstances() ([]CloudInstance, error)
+}
+
+// CloudResourceKind identifies a kind of resource that a provider
+// holds in the cloud for a model.
+type CloudResourceKind string
+
+const (
+	CloudResourceInstance      CloudResourceKind = "instance"
+	CloudResourceVolume        CloudResourceKind = "volume"
+	CloudResourceSecurityGroup CloudResourceKind = "security-group"
+)
+
+// CloudResource describes a resource that a provider holds in the
+// cloud for a model.
+type CloudResource struct {
+	// Kind is the kind of the resource.
+	Kind CloudResourceKind
+
+	// Id is the provider-specific id of the resource.
+	Id string
+
+	// MachineId is the id of the machine that the resource was
+	// created for, if the resource belongs to a single machine but
+	// is not identified by an instance or volume id recorded in
+	// state. Per-machine security groups set it.
+	MachineId string
+}
+
+// CloudResourceSweeper is an interface that an Environ may implement
+// to let juju find and destroy resources that failed operations left
+// behind in the cloud.
+type CloudResourceSweeper interface {
+	// CloudResources returns the instances, volumes and security
+	// groups that the cloud holds for the model. Resources that the
+	// model always needs, such as model-wide security groups, are
+	// not returned.
+	CloudResources() ([]CloudResource, error)
+
+	// DestroyCloudResources destroys the given resources.
+	DestroyCloudResources([]CloudResource) error
+}
+
 // InstanceTypesFetcher is an interface that allows for instance information from
 // a provider to be obtained.
 type InstanceTypesFetcher interface {
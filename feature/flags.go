@@ -39,3 +39,8 @@ const DeveloperMode = "developer-mode"
 
 // CrossModelRelations allows cross model relations functionality.
 const CrossModelRelations = "cross-model"
+
+// NestedUnits causes machine agents to run the agents of the units they
+// host as workers in their own process, rather than as one service per
+// unit.
+const NestedUnits = "nested-units"
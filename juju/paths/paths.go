@@ -22,32 +22,35 @@ const (
 	metricsSpoolDir
 	uniterStateDir
 	jujuDumpLogs
+	jujuMigrateBlobs
 )
 
 var nixVals = map[osVarType]string{
-	tmpDir:          "/tmp",
-	logDir:          "/var/log",
-	dataDir:         "/var/lib/juju",
-	storageDir:      "/var/lib/juju/storage",
-	confDir:         "/etc/juju",
-	jujuRun:         "/usr/bin/juju-run",
-	jujuDumpLogs:    "/usr/bin/juju-dumplogs",
-	certDir:         "/etc/juju/certs.d",
-	metricsSpoolDir: "/var/lib/juju/metricspool",
-	uniterStateDir:  "/var/lib/juju/uniter/state",
+	tmpDir:           "/tmp",
+	logDir:           "/var/log",
+	dataDir:          "/var/lib/juju",
+	storageDir:       "/var/lib/juju/storage",
+	confDir:          "/etc/juju",
+	jujuRun:          "/usr/bin/juju-run",
+	jujuDumpLogs:     "/usr/bin/juju-dumplogs",
+	jujuMigrateBlobs: "/usr/bin/juju-migrate-blobs",
+	certDir:          "/etc/juju/certs.d",
+	metricsSpoolDir:  "/var/lib/juju/metricspool",
+	uniterStateDir:   "/var/lib/juju/uniter/state",
 }
 
 var winVals = map[osVarType]string{
-	tmpDir:          "C:/Juju/tmp",
-	logDir:          "C:/Juju/log",
-	dataDir:         "C:/Juju/lib/juju",
-	storageDir:      "C:/Juju/lib/juju/storage",
-	confDir:         "C:/Juju/etc",
-	jujuRun:         "C:/Juju/bin/juju-run.exe",
-	jujuDumpLogs:    "C:/Juju/bin/juju-dumplogs.exe",
-	certDir:         "C:/Juju/certs",
-	metricsSpoolDir: "C:/Juju/lib/juju/metricspool",
-	uniterStateDir:  "C:/Juju/lib/juju/uniter/state",
+	tmpDir:           "C:/Juju/tmp",
+	logDir:           "C:/Juju/log",
+	dataDir:          "C:/Juju/lib/juju",
+	storageDir:       "C:/Juju/lib/juju/storage",
+	confDir:          "C:/Juju/etc",
+	jujuRun:          "C:/Juju/bin/juju-run.exe",
+	jujuDumpLogs:     "C:/Juju/bin/juju-dumplogs.exe",
+	jujuMigrateBlobs: "C:/Juju/bin/juju-migrate-blobs.exe",
+	certDir:          "C:/Juju/certs",
+	metricsSpoolDir:  "C:/Juju/lib/juju/metricspool",
+	uniterStateDir:   "C:/Juju/lib/juju/uniter/state",
 }
 
 // osVal will lookup the value of the key valname
@@ -122,6 +125,12 @@ func JujuDumpLogs(series string) (string, error) {
 	return osVal(series, jujuDumpLogs)
 }
 
+// JujuMigrateBlobs returns the absolute path to the juju-migrate-blobs
+// binary for a particular series.
+func JujuMigrateBlobs(series string) (string, error) {
+	return osVal(series, jujuMigrateBlobs)
+}
+
 func MustSucceed(s string, e error) string {
 	if e != nil {
 		panic(e)
@@ -139,6 +139,14 @@ func StartInstanceWithParams(
 	return env.StartInstance(params)
 }
 
+// FillInStartInstanceParams fills in the given parameters with a plausible
+// but invalid configuration for starting an instance for the given machine,
+// as StartInstanceWithParams does, for tests that start instances some
+// other way.
+func FillInStartInstanceParams(env environs.Environ, machineId string, isController bool, params *environs.StartInstanceParams) error {
+	return fillinStartInstanceParams(env, machineId, isController, params)
+}
+
 func fillinStartInstanceParams(env environs.Environ, machineId string, isController bool, params *environs.StartInstanceParams) error {
 	if params.ControllerUUID == "" {
 		return errors.New("missing controller UUID in start instance parameters")
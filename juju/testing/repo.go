@@ -54,7 +54,7 @@ func (s *RepoSuite) AssertCharmUploaded(c *gc.C, curl *charm.URL) {
 	ch, err := s.State.Charm(curl)
 	c.Assert(err, jc.ErrorIsNil)
 
-	storage := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	storage := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession(), s.State.ObjectStores())
 	r, _, err := storage.Get(ch.StoragePath())
 	c.Assert(err, jc.ErrorIsNil)
 	defer r.Close()
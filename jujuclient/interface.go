@@ -124,6 +124,12 @@ type BootstrapConfig struct {
 	// when communicating with the cloud's storage service. This will
 	// be empty for clouds that have no storage-specific API endpoint.
 	CloudStorageEndpoint string `yaml:"storage-endpoint,omitempty"`
+
+	// Checkpoint records the last stage of bootstrap that was
+	// completed, while bootstrap is in progress. It is cleared once
+	// bootstrap completes, and is used to resume a failed bootstrap
+	// with "juju bootstrap --resume".
+	Checkpoint string `yaml:"checkpoint,omitempty"`
 }
 
 // ControllerUpdater stores controller details.
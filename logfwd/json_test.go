@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type JSONRecordSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&JSONRecordSuite{})
+
+func (s *JSONRecordSuite) TestMarshal(c *gc.C) {
+	rec := validRecord
+	rec.ID = 10
+	rec.Timestamp = time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	rec.Level = loggo.WARNING
+
+	data, err := json.Marshal(logfwd.NewJSONRecord(rec))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), jc.JSONEquals, map[string]interface{}{
+		"id":               10,
+		"timestamp":        "2017-01-02T03:04:05Z",
+		"level":            "WARNING",
+		"module":           "spam",
+		"location":         "eggs.go:42",
+		"message":          "uh-oh",
+		"controller-uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model-uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"hostname":         "spam.x.y.z.com",
+		"origin-type":      "user",
+		"origin-name":      "a-user",
+		"software":         "juju",
+		"software-version": "2.0.1",
+	})
+}
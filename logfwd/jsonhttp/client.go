@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package jsonhttp holds the tools needed to perform log forwarding
+// from Juju to an HTTP endpoint, which receives each batch of log
+// records as a POSTed JSON array.
+package jsonhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// DefaultTimeout is used when Config.Timeout is zero.
+const DefaultTimeout = 30 * time.Second
+
+// maxErrorBody is the most of an error response's body that is
+// included in the error returned by Send.
+const maxErrorBody = 512
+
+// Config holds the configuration of a Client.
+type Config struct {
+	// URL is the http or https URL to which records are POSTed.
+	URL string
+
+	// Timeout bounds the time taken by each POST. If it is zero,
+	// DefaultTimeout is used.
+	Timeout time.Duration
+
+	// Transport, if not nil, is used to make the requests.
+	Transport http.RoundTripper
+}
+
+// Validate returns an error if the config cannot be used.
+func (cfg Config) Validate() error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.Annotate(err, "parsing URL")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	return nil
+}
+
+// Client sends log records to an HTTP endpoint.
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// Open returns a new Client using the given configuration. No
+// connection is made until records are sent.
+func Open(cfg Config) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{
+		url: cfg.URL,
+		client: &http.Client{
+			Transport: cfg.Transport,
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+// Send POSTs the records to the endpoint as a JSON array of
+// logfwd.JSONRecord. Any response status other than 2xx is an error.
+func (c *Client) Send(records []logfwd.Record) error {
+	jrecs := make([]logfwd.JSONRecord, len(records))
+	for i, rec := range records {
+		jrecs[i] = logfwd.NewJSONRecord(rec)
+	}
+	body, err := json.Marshal(jrecs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequest("POST", c.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "sending log records")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		errBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return errors.Errorf("sending log records: %s: %s", resp.Status, bytes.TrimSpace(errBody))
+	}
+	// Drain the body so that the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+// Close is part of the logforwarder.SendCloser interface. The client
+// holds no connection of its own, so there is nothing to close.
+func (c *Client) Close() error {
+	return nil
+}
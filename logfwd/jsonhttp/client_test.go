@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonhttp_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/jsonhttp"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	requests []*http.Request
+	bodies   [][]byte
+	status   int
+	server   *httptest.Server
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.status = http.StatusOK
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, body)
+		w.WriteHeader(s.status)
+		if s.status != http.StatusOK {
+			w.Write([]byte("go away\n"))
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+var testRecord = logfwd.Record{
+	ID: 10,
+	Origin: logfwd.Origin{
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Type:           logfwd.OriginTypeMachine,
+		Name:           "0",
+	},
+	Timestamp: time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC),
+	Level:     loggo.INFO,
+	Message:   "hello",
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client, err := jsonhttp.Open(jsonhttp.Config{URL: s.server.URL + "/logs"})
+	c.Assert(err, jc.ErrorIsNil)
+	rec2 := testRecord
+	rec2.ID = 11
+	err = client.Send([]logfwd.Record{testRecord, rec2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Method, gc.Equals, "POST")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/logs")
+	c.Assert(s.requests[0].Header.Get("Content-Type"), gc.Equals, "application/json")
+	var jrecs []logfwd.JSONRecord
+	err = json.Unmarshal(s.bodies[0], &jrecs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(jrecs, jc.DeepEquals, []logfwd.JSONRecord{
+		logfwd.NewJSONRecord(testRecord),
+		logfwd.NewJSONRecord(rec2),
+	})
+}
+
+func (s *ClientSuite) TestSendErrorStatus(c *gc.C) {
+	s.status = http.StatusServiceUnavailable
+	client, err := jsonhttp.Open(jsonhttp.Config{URL: s.server.URL})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Send([]logfwd.Record{testRecord})
+	c.Assert(err, gc.ErrorMatches, "sending log records: 503 Service Unavailable: go away")
+}
+
+func (s *ClientSuite) TestOpenInvalidURL(c *gc.C) {
+	_, err := jsonhttp.Open(jsonhttp.Config{URL: "ftp://logs.example.com"})
+	c.Assert(err, gc.ErrorMatches, `URL "ftp://logs.example.com" not valid`)
+}
@@ -0,0 +1,291 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package kafka holds the tools needed to perform log forwarding
+// from Juju to a Kafka topic. Each log record is produced as a
+// message whose value is the record encoded as a logfwd.JSONRecord
+// and whose key is the record's model UUID.
+//
+// Only the plain (non-TLS, unauthenticated) Kafka protocol is
+// supported, and all messages are produced to partition 0 of the
+// topic so that the order of the records is preserved.
+package kafka
+
+import (
+	"encoding/json"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+var logger = loggo.GetLogger("juju.logfwd.kafka")
+
+// DefaultTimeout is used when Config.Timeout is zero.
+const DefaultTimeout = 30 * time.Second
+
+// clientID identifies Juju to the Kafka brokers.
+const clientID = "juju"
+
+// partition is the topic partition that all messages are sent to.
+const partition = 0
+
+// Config holds the configuration of a Client.
+type Config struct {
+	// Brokers holds the host:port addresses of the Kafka
+	// brokers used to discover the leader of the topic
+	// partition.
+	Brokers []string
+
+	// Topic is the topic to which records are produced.
+	Topic string
+
+	// Timeout bounds the time taken by each request made to a
+	// broker. If it is zero, DefaultTimeout is used.
+	Timeout time.Duration
+}
+
+// Validate returns an error if the config cannot be used.
+func (cfg Config) Validate() error {
+	if len(cfg.Brokers) == 0 {
+		return errors.NotValidf("empty Brokers")
+	}
+	for _, broker := range cfg.Brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			return errors.NotValidf("broker address %q", broker)
+		}
+	}
+	if cfg.Topic == "" {
+		return errors.NotValidf("empty Topic")
+	}
+	return nil
+}
+
+// Client produces log records to a Kafka topic.
+type Client struct {
+	config        Config
+	conn          net.Conn
+	correlationID int32
+}
+
+// Open returns a new Client using the given configuration. No
+// connection is made until records are sent.
+func Open(cfg Config) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	return &Client{config: cfg}, nil
+}
+
+// Send produces the records to the topic, waiting for the leader
+// of the partition to acknowledge them. If sending fails, the
+// connection is dropped and the leader is discovered again on the
+// next call.
+func (c *Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	msgs := make([]message, len(records))
+	for i, rec := range records {
+		value, err := json.Marshal(logfwd.NewJSONRecord(rec))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		msgs[i] = message{value: value}
+		if rec.Origin.ModelUUID != "" {
+			msgs[i].key = []byte(rec.Origin.ModelUUID)
+		}
+	}
+	if err := c.send(msgs); err != nil {
+		c.closeConn()
+		return errors.Annotate(err, "sending log records")
+	}
+	return nil
+}
+
+func (c *Client) send(msgs []message) error {
+	if c.conn == nil {
+		conn, err := c.dialLeader()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.conn = conn
+	}
+	resp, err := c.roundTrip(c.conn, apiKeyProduce, c.produceRequest(msgs))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(checkProduceResponse(resp))
+}
+
+// Close closes the connection to the broker, if any.
+func (c *Client) Close() error {
+	return errors.Trace(c.closeConn())
+}
+
+func (c *Client) closeConn() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// dialLeader asks each of the configured brokers in turn for the
+// leader of the topic partition, and returns a connection to it.
+func (c *Client) dialLeader() (net.Conn, error) {
+	var lastErr error
+	for _, broker := range c.config.Brokers {
+		leader, err := c.findLeader(broker)
+		if err != nil {
+			logger.Debugf("cannot find leader using broker %q: %v", broker, err)
+			lastErr = err
+			continue
+		}
+		conn, err := net.DialTimeout("tcp", leader, c.config.Timeout)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn, nil
+	}
+	return nil, errors.Annotatef(lastErr, "cannot connect to leader for topic %q", c.config.Topic)
+}
+
+// findLeader returns the address of the leader of the topic
+// partition, as reported by the given broker.
+func (c *Client) findLeader(broker string) (string, error) {
+	conn, err := net.DialTimeout("tcp", broker, c.config.Timeout)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer conn.Close()
+
+	var body encoder
+	body.putInt32(1)
+	body.putString(c.config.Topic)
+	resp, err := c.roundTrip(conn, apiKeyMetadata, body.buf)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return leaderFromMetadata(resp, c.config.Topic)
+}
+
+// roundTrip sends a request to the broker and returns the body of
+// its response.
+func (c *Client) roundTrip(conn net.Conn, apiKey int16, body []byte) ([]byte, error) {
+	if err := conn.SetDeadline(time.Now().Add(c.config.Timeout)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.correlationID++
+	id := c.correlationID
+	if _, err := conn.Write(encodeRequest(apiKey, 0, id, clientID, body)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := readFrame(conn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	d := decoder{data: resp}
+	if got := d.int32(); d.err != nil || got != id {
+		return nil, errors.Errorf("unexpected Kafka correlation ID %d, expected %d", got, id)
+	}
+	return d.data, nil
+}
+
+func (c *Client) produceRequest(msgs []message) []byte {
+	set := encodeMessageSet(msgs)
+	var body encoder
+	body.putInt16(1) // required acks: the leader only
+	body.putInt32(int32(c.config.Timeout / time.Millisecond))
+	body.putInt32(1)
+	body.putString(c.config.Topic)
+	body.putInt32(1)
+	body.putInt32(partition)
+	body.putInt32(int32(len(set)))
+	body.buf = append(body.buf, set...)
+	return body.buf
+}
+
+// leaderFromMetadata returns the host:port of the leader of the
+// topic partition from a version 0 metadata response.
+func leaderFromMetadata(resp []byte, topic string) (string, error) {
+	d := decoder{data: resp}
+	brokers := make(map[int32]string)
+	for n := d.arrayLen(); n > 0; n-- {
+		id := d.int32()
+		host := d.string()
+		port := d.int32()
+		brokers[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	leader := int32(-1)
+	for n := d.arrayLen(); n > 0; n-- {
+		topicErr := d.int16()
+		name := d.string()
+		for n := d.arrayLen(); n > 0; n-- {
+			partitionErr := d.int16()
+			id := d.int32()
+			partitionLeader := d.int32()
+			for n := d.arrayLen(); n > 0; n-- {
+				d.int32() // replicas
+			}
+			for n := d.arrayLen(); n > 0; n-- {
+				d.int32() // in-sync replicas
+			}
+			if name != topic || id != partition {
+				continue
+			}
+			if partitionErr != errNone {
+				return "", kafkaError(partitionErr)
+			}
+			leader = partitionLeader
+		}
+		if name == topic && topicErr != errNone {
+			return "", kafkaError(topicErr)
+		}
+	}
+	if d.err != nil {
+		return "", errors.Trace(d.err)
+	}
+	addr, ok := brokers[leader]
+	if !ok {
+		return "", errors.Errorf("no leader for topic %q", topic)
+	}
+	return addr, nil
+}
+
+// checkProduceResponse returns an error if a version 0 produce
+// response reports that the messages were not stored.
+func checkProduceResponse(resp []byte) error {
+	d := decoder{data: resp}
+	for n := d.arrayLen(); n > 0; n-- {
+		d.string() // topic
+		for n := d.arrayLen(); n > 0; n-- {
+			d.int32() // partition
+			code := d.int16()
+			d.int64() // offset
+			if code != errNone && d.err == nil {
+				return kafkaError(code)
+			}
+		}
+	}
+	return errors.Trace(d.err)
+}
+
+func kafkaError(code int16) error {
+	switch code {
+	case errUnknownTopic:
+		return errors.New("unknown topic or partition")
+	case errLeaderNotAvailable:
+		return errors.New("leader not available")
+	}
+	return errors.Errorf("Kafka error code %d", code)
+}
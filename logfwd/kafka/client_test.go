@@ -0,0 +1,295 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kafka
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	broker *fakeBroker
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.broker = newFakeBroker(c)
+	s.AddCleanup(func(*gc.C) { s.broker.close() })
+}
+
+var testRecord = logfwd.Record{
+	ID: 10,
+	Origin: logfwd.Origin{
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Type:           logfwd.OriginTypeMachine,
+		Name:           "0",
+	},
+	Timestamp: time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC),
+	Level:     loggo.INFO,
+	Message:   "hello",
+}
+
+func (s *ClientSuite) open(c *gc.C) *Client {
+	client, err := Open(Config{
+		Brokers: []string{"127.0.0.1:1", s.broker.addr()},
+		Topic:   "juju-logs",
+		Timeout: testing.LongWait,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { client.Close() })
+	return client
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := s.open(c)
+	rec2 := testRecord
+	rec2.ID = 11
+	rec2.Origin.ModelUUID = ""
+	err := client.Send([]logfwd.Record{testRecord, rec2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	msgs := s.broker.produced()
+	c.Assert(msgs, gc.HasLen, 2)
+	c.Check(string(msgs[0].key), gc.Equals, testRecord.Origin.ModelUUID)
+	c.Check(msgs[1].key, gc.IsNil)
+	for i, rec := range []logfwd.Record{testRecord, rec2} {
+		expect, err := json.Marshal(logfwd.NewJSONRecord(rec))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(msgs[i].value), jc.JSONEquals, json.RawMessage(expect))
+	}
+	c.Check(s.broker.topics(), jc.DeepEquals, []string{"juju-logs"})
+}
+
+func (s *ClientSuite) TestSendReusesConnection(c *gc.C) {
+	client := s.open(c)
+	for i := 0; i < 3; i++ {
+		err := client.Send([]logfwd.Record{testRecord})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(s.broker.produced(), gc.HasLen, 3)
+	c.Assert(s.broker.metadataRequests(), gc.Equals, 1)
+}
+
+func (s *ClientSuite) TestSendErrorReconnects(c *gc.C) {
+	client := s.open(c)
+	s.broker.setProduceError(errLeaderNotAvailable)
+	err := client.Send([]logfwd.Record{testRecord})
+	c.Assert(err, gc.ErrorMatches, "sending log records: leader not available")
+
+	s.broker.setProduceError(errNone)
+	err = client.Send([]logfwd.Record{testRecord})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.broker.metadataRequests(), gc.Equals, 2)
+}
+
+func (s *ClientSuite) TestSendUnknownTopic(c *gc.C) {
+	s.broker.setTopicError(errUnknownTopic)
+	client := s.open(c)
+	err := client.Send([]logfwd.Record{testRecord})
+	c.Assert(err, gc.ErrorMatches, `sending log records: cannot connect to leader for topic "juju-logs": unknown topic or partition`)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := Open(Config{Topic: "juju-logs"})
+	c.Check(err, gc.ErrorMatches, "empty Brokers not valid")
+	_, err = Open(Config{Brokers: []string{"kafka"}, Topic: "juju-logs"})
+	c.Check(err, gc.ErrorMatches, `broker address "kafka" not valid`)
+	_, err = Open(Config{Brokers: []string{"kafka:9092"}})
+	c.Check(err, gc.ErrorMatches, "empty Topic not valid")
+}
+
+func (s *ClientSuite) TestMessageSetRoundTrip(c *gc.C) {
+	msgs := []message{
+		{key: []byte("key"), value: []byte("value")},
+		{value: []byte("no key")},
+	}
+	got, err := decodeMessageSet(encodeMessageSet(msgs))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, msgs)
+}
+
+// fakeBroker implements enough of the Kafka protocol to act as
+// the only broker of a cluster, and the leader of all partitions.
+type fakeBroker struct {
+	c        *gc.C
+	listener net.Listener
+
+	mu           sync.Mutex
+	messages     []message
+	topicNames   []string
+	metadata     int
+	produceError int16
+	topicError   int16
+}
+
+func newFakeBroker(c *gc.C) *fakeBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	b := &fakeBroker{c: c, listener: listener}
+	go b.serve()
+	return b
+}
+
+func (b *fakeBroker) addr() string {
+	return b.listener.Addr().String()
+}
+
+func (b *fakeBroker) close() {
+	b.listener.Close()
+}
+
+func (b *fakeBroker) produced() []message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]message(nil), b.messages...)
+}
+
+func (b *fakeBroker) topics() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.topicNames...)
+}
+
+func (b *fakeBroker) metadataRequests() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.metadata
+}
+
+func (b *fakeBroker) setProduceError(code int16) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.produceError = code
+}
+
+func (b *fakeBroker) setTopicError(code int16) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.topicError = code
+}
+
+func (b *fakeBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.serveConn(conn)
+	}
+}
+
+func (b *fakeBroker) serveConn(conn net.Conn) {
+	defer conn.Close()
+	for {
+		req, err := readFrame(conn)
+		if err != nil {
+			return
+		}
+		d := decoder{data: req}
+		apiKey := d.int16()
+		d.int16() // version
+		id := d.int32()
+		d.string() // client ID
+
+		var resp encoder
+		resp.putInt32(id)
+		switch apiKey {
+		case apiKeyMetadata:
+			b.metadataResponse(&resp, &d)
+		case apiKeyProduce:
+			b.produceResponse(&resp, &d)
+		default:
+			b.c.Errorf("unexpected API key %d", apiKey)
+			return
+		}
+		if !b.c.Check(d.err, jc.ErrorIsNil) {
+			return
+		}
+		var frame encoder
+		frame.putBytes(resp.buf)
+		if _, err := conn.Write(frame.buf); err != nil {
+			return
+		}
+	}
+}
+
+func (b *fakeBroker) metadataResponse(resp *encoder, d *decoder) {
+	var topics []string
+	for n := d.arrayLen(); n > 0; n-- {
+		topics = append(topics, d.string())
+	}
+
+	b.mu.Lock()
+	b.metadata++
+	topicError := b.topicError
+	b.mu.Unlock()
+
+	addr := b.listener.Addr().(*net.TCPAddr)
+	resp.putInt32(1)
+	resp.putInt32(0)
+	resp.putString(addr.IP.String())
+	resp.putInt32(int32(addr.Port))
+
+	resp.putInt32(int32(len(topics)))
+	for _, topic := range topics {
+		resp.putInt16(topicError)
+		resp.putString(topic)
+		if topicError != errNone {
+			resp.putInt32(0)
+			continue
+		}
+		resp.putInt32(1)
+		resp.putInt16(errNone)
+		resp.putInt32(partition)
+		resp.putInt32(0) // leader
+		resp.putInt32(1) // replicas
+		resp.putInt32(0)
+		resp.putInt32(1) // in-sync replicas
+		resp.putInt32(0)
+	}
+}
+
+func (b *fakeBroker) produceResponse(resp *encoder, d *decoder) {
+	d.int16() // acks
+	d.int32() // timeout
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	nTopics := d.arrayLen()
+	resp.putInt32(int32(nTopics))
+	for ; nTopics > 0; nTopics-- {
+		topic := d.string()
+		b.topicNames = append(b.topicNames, topic)
+		resp.putString(topic)
+		nPartitions := d.arrayLen()
+		resp.putInt32(int32(nPartitions))
+		for ; nPartitions > 0; nPartitions-- {
+			p := d.int32()
+			set := d.bytes()
+			resp.putInt32(p)
+			resp.putInt16(b.produceError)
+			resp.putInt64(int64(len(b.messages)))
+			if b.produceError != errNone {
+				continue
+			}
+			msgs, err := decodeMessageSet(set)
+			b.c.Check(err, jc.ErrorIsNil)
+			b.messages = append(b.messages, msgs...)
+		}
+	}
+}
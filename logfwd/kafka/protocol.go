@@ -0,0 +1,228 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+
+	"github.com/juju/errors"
+)
+
+// Kafka API keys.
+const (
+	apiKeyProduce  = 0
+	apiKeyMetadata = 3
+)
+
+// Kafka error codes of interest.
+const (
+	errNone               = 0
+	errUnknownTopic       = 3
+	errLeaderNotAvailable = 5
+)
+
+// maxResponseSize bounds the size of the responses read from a
+// broker, to guard against reading garbage as a huge length.
+const maxResponseSize = 16 << 20
+
+// encoder builds a Kafka protocol message. All integers are big
+// endian; strings and byte arrays are prefixed with their lengths.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) putInt8(v int8) {
+	e.buf = append(e.buf, byte(v))
+}
+
+func (e *encoder) putInt16(v int16) {
+	e.buf = append(e.buf, byte(v>>8), byte(v))
+}
+
+func (e *encoder) putInt32(v int32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(v))
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *encoder) putInt64(v int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *encoder) putString(s string) {
+	e.putInt16(int16(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// putBytes appends a byte array. A nil array is encoded as null.
+func (e *encoder) putBytes(b []byte) {
+	if b == nil {
+		e.putInt32(-1)
+		return
+	}
+	e.putInt32(int32(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// decoder parses a Kafka protocol message. The first error
+// encountered is recorded in err, after which all values read
+// are zero.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || len(d.data) < n {
+		d.err = errors.New("malformed Kafka response")
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *decoder) int8() int8 {
+	if b := d.next(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (d *decoder) int16() int16 {
+	if b := d.next(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *decoder) int32() int32 {
+	if b := d.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (d *decoder) int64() int64 {
+	if b := d.next(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (d *decoder) string() string {
+	return string(d.next(int(d.int16())))
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.int32()
+	if n == -1 {
+		return nil
+	}
+	return d.next(int(n))
+}
+
+// arrayLen reads the length of an array.
+func (d *decoder) arrayLen() int {
+	n := int(d.int32())
+	if n < 0 || n > len(d.data) {
+		// Every element takes at least one byte.
+		if d.err == nil {
+			d.err = errors.New("malformed Kafka response")
+		}
+		return 0
+	}
+	return n
+}
+
+// encodeRequest returns a request with the given API key, version,
+// correlation ID and client ID, and the given body, prefixed with
+// its size.
+func encodeRequest(apiKey, apiVersion int16, correlationID int32, clientID string, body []byte) []byte {
+	var header encoder
+	header.putInt16(apiKey)
+	header.putInt16(apiVersion)
+	header.putInt32(correlationID)
+	header.putString(clientID)
+	var req encoder
+	req.putInt32(int32(len(header.buf) + len(body)))
+	req.buf = append(req.buf, header.buf...)
+	req.buf = append(req.buf, body...)
+	return req.buf
+}
+
+// readFrame reads a size-prefixed request or response.
+func readFrame(r io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxResponseSize {
+		return nil, errors.Errorf("Kafka message too large (%d bytes)", n)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+// message is a Kafka message, in the version 0 format.
+type message struct {
+	key   []byte
+	value []byte
+}
+
+// encodeMessageSet encodes the messages as a message set.
+func encodeMessageSet(msgs []message) []byte {
+	var set encoder
+	for _, msg := range msgs {
+		var body encoder
+		body.putInt8(0) // magic
+		body.putInt8(0) // attributes: no compression
+		body.putBytes(msg.key)
+		body.putBytes(msg.value)
+
+		set.putInt64(0) // offset, assigned by the broker
+		set.putInt32(int32(4 + len(body.buf)))
+		set.putInt32(int32(crc32.ChecksumIEEE(body.buf)))
+		set.buf = append(set.buf, body.buf...)
+	}
+	return set.buf
+}
+
+// decodeMessageSet decodes a message set, checking the CRC of each
+// message.
+func decodeMessageSet(data []byte) ([]message, error) {
+	d := decoder{data: data}
+	var msgs []message
+	for len(d.data) > 0 && d.err == nil {
+		d.int64() // offset
+		raw := d.next(int(d.int32()))
+		if d.err != nil {
+			break
+		}
+		md := decoder{data: raw}
+		crc := uint32(md.int32())
+		if crc != crc32.ChecksumIEEE(md.data) {
+			return nil, errors.New("bad message CRC")
+		}
+		md.int8() // magic
+		md.int8() // attributes
+		msg := message{key: md.bytes(), value: md.bytes()}
+		if md.err != nil {
+			return nil, errors.Trace(md.err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, errors.Trace(d.err)
+}
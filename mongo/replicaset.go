@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ReplicaSetMemberStatus holds the parts of a replica set member's
+// status, as reported by replSetGetStatus, that are not reported by
+// the replicaset package.
+type ReplicaSetMemberStatus struct {
+	Id            int       `bson:"_id"`
+	Address       string    `bson:"name"`
+	OptimeDate    time.Time `bson:"optimeDate"`
+	LastHeartbeat time.Time `bson:"lastHeartbeat"`
+	ElectionDate  time.Time `bson:"electionDate"`
+}
+
+// ReplicaSetMemberStatuses returns the status of each member of the
+// replica set the session is connected to.
+func ReplicaSetMemberStatuses(session *mgo.Session) ([]ReplicaSetMemberStatus, error) {
+	var result struct {
+		Members []ReplicaSetMemberStatus `bson:"members"`
+	}
+	if err := session.Run(bson.D{{"replSetGetStatus", 1}}, &result); err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set status")
+	}
+	return result.Members, nil
+}
+
+// ReplicaSetElection records a replica set member being elected
+// primary.
+type ReplicaSetElection struct {
+	// Time is when the new primary took office.
+	Time time.Time
+
+	// Term is the election term, which is only recorded by
+	// replica sets using protocol version 1.
+	Term int64
+}
+
+// ReplicaSetElections returns up to limit of the most recent
+// elections still recorded in the oplog, most recent first. A newly
+// elected primary records its election in the oplog, so elections
+// older than the oplog's window are not returned.
+func ReplicaSetElections(session *mgo.Session, limit int) ([]ReplicaSetElection, error) {
+	var docs []struct {
+		Timestamp bson.MongoTimestamp `bson:"ts"`
+		Term      int64               `bson:"t"`
+	}
+	err := GetOplog(session).Find(bson.D{
+		{"op", "n"},
+		{"o.msg", "new primary"},
+	}).Sort("-$natural").Limit(limit).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set elections")
+	}
+	elections := make([]ReplicaSetElection, len(docs))
+	for i, doc := range docs {
+		elections[i] = ReplicaSetElection{
+			Time: time.Unix(int64(doc.Timestamp)>>32, 0).UTC(),
+			Term: doc.Term,
+		}
+	}
+	return elections, nil
+}
+
+// StepDownPrimary asks the primary of the replica set the session is
+// connected to to step down, and not to stand for election again for
+// the given period. The primary closes all connections when it steps
+// down, so an io.EOF from the command is expected and is not an error.
+func StepDownPrimary(session *mgo.Session, period time.Duration) error {
+	err := session.Run(bson.D{{"replSetStepDown", int(period / time.Second)}}, nil)
+	if err == nil || err == io.EOF {
+		return nil
+	}
+	return errors.Annotate(err, "cannot step down primary")
+}
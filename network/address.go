@@ -365,9 +365,17 @@ func SelectMongoHostPortsByScope(hostPorts []HostPort, machineLocal bool) []stri
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address is then ok is true.
 func SelectPublicAddress(addresses []Address) (Address, bool) {
+	return IPv6Disabled.SelectPublicAddress(addresses)
+}
+
+// SelectPublicAddress picks one address from a slice that would be
+// appropriate to display as a publicly accessible endpoint in a model
+// using the IPv6 mode. If there are no suitable addresses, then ok is
+// false (and an empty address is returned).
+func (m IPv6Mode) SelectPublicAddress(addresses []Address) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, publicMatch)
+	}, publicMatcher(m))
 	if index < 0 {
 		return Address{}, false
 	}
@@ -380,7 +388,7 @@ func SelectPublicAddress(addresses []Address) (Address, bool) {
 func SelectPublicHostPort(hps []HostPort) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, publicMatch)
+	}, publicMatcher(IPv6Disabled))
 	if index < 0 {
 		return ""
 	}
@@ -392,9 +400,17 @@ func SelectPublicHostPort(hps []HostPort) string {
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address was found then ok is true.
 func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
+	return IPv6Disabled.SelectInternalAddress(addresses, machineLocal)
+}
+
+// SelectInternalAddress picks one address from a slice that can be
+// used as an endpoint for juju internal communication in a model using
+// the IPv6 mode. If there are no suitable addresses, then ok is false
+// (and an empty address is returned).
+func (m IPv6Mode) SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, m))
 	if index < 0 {
 		return Address{}, false
 	}
@@ -408,7 +424,7 @@ func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, boo
 func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, IPv6Disabled))
 	if index < 0 {
 		return ""
 	}
@@ -422,7 +438,7 @@ func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	indexes := bestAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, IPv6Disabled))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -438,7 +454,7 @@ func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, IPv6Disabled))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -447,62 +463,70 @@ func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	return out
 }
 
-func publicMatch(addr Address) scopeMatch {
-	switch addr.Scope {
-	case ScopePublic:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
-		}
-		return exactScope
-	case ScopeCloudLocal, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return fallbackScopeIPv4
+func publicMatcher(mode IPv6Mode) scopeMatchFunc {
+	return func(addr Address) scopeMatch {
+		switch addr.Scope {
+		case ScopePublic:
+			return mode.typeMatch(addr, exactScopePreferred, exactScope)
+		case ScopeCloudLocal, ScopeUnknown:
+			return mode.typeMatch(addr, fallbackScopePreferred, fallbackScope)
 		}
-		return fallbackScope
+		return invalidScope
 	}
-	return invalidScope
 }
 
-func internalAddressMatcher(machineLocal bool) scopeMatchFunc {
-	if machineLocal {
-		return cloudOrMachineLocalMatch
+func internalAddressMatcher(machineLocal bool, mode IPv6Mode) scopeMatchFunc {
+	cloudLocalMatch := cloudLocalMatcher(mode)
+	if !machineLocal {
+		return cloudLocalMatch
+	}
+	return func(addr Address) scopeMatch {
+		if addr.Scope == ScopeMachineLocal {
+			return mode.typeMatch(addr, exactScopePreferred, exactScope)
+		}
+		return cloudLocalMatch(addr)
 	}
-	return cloudLocalMatch
 }
 
-func cloudLocalMatch(addr Address) scopeMatch {
-	switch addr.Scope {
-	case ScopeCloudLocal:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
-		}
-		return exactScope
-	case ScopePublic, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return fallbackScopeIPv4
+func cloudLocalMatcher(mode IPv6Mode) scopeMatchFunc {
+	return func(addr Address) scopeMatch {
+		switch addr.Scope {
+		case ScopeCloudLocal:
+			return mode.typeMatch(addr, exactScopePreferred, exactScope)
+		case ScopePublic, ScopeUnknown:
+			return mode.typeMatch(addr, fallbackScopePreferred, fallbackScope)
 		}
-		return fallbackScope
+		return invalidScope
 	}
-	return invalidScope
 }
 
-func cloudOrMachineLocalMatch(addr Address) scopeMatch {
-	if addr.Scope == ScopeMachineLocal {
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+// typeMatch returns preferred if the address is of the type preferred
+// in the IPv6 mode, invalidScope if the address may not be used in the
+// mode, and other otherwise.
+func (m IPv6Mode) typeMatch(addr Address, preferred, other scopeMatch) scopeMatch {
+	switch addr.Type {
+	case IPv4Address:
+		if !m.AllowsIPv4() {
+			return invalidScope
+		}
+		if m == IPv6Disabled {
+			return preferred
+		}
+	case IPv6Address:
+		if m != IPv6Disabled {
+			return preferred
 		}
-		return exactScope
 	}
-	return cloudLocalMatch(addr)
+	return other
 }
 
 type scopeMatch int
 
 const (
 	invalidScope scopeMatch = iota
-	exactScopeIPv4
+	exactScopePreferred
 	exactScope
-	fallbackScopeIPv4
+	fallbackScopePreferred
 	fallbackScope
 )
 
@@ -529,7 +553,7 @@ func bestAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc s
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope}
+	allowedMatchTypes := []scopeMatch{exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope}
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
 		if ok && len(indexes) > 0 {
@@ -544,7 +568,7 @@ func prioritizedAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matc
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope}
+	allowedMatchTypes := []scopeMatch{exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope}
 	var prioritized []int
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
@@ -561,7 +585,7 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 	for i := 0; i < numAddr; i++ {
 		matchType := matchFunc(getAddrFunc(i))
 		switch matchType {
-		case exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope:
+		case exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope:
 			matches[matchType] = append(matches[matchType], i)
 		}
 	}
@@ -575,7 +599,9 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 // - machine-local next;
 // - link-local next;
 // - non-hostnames with unknown scope last.
-func (a Address) sortOrder() int {
+// IPv4 addresses are preferred over IPv6 addresses of the same scope,
+// unless IPv6 is enabled in the given mode.
+func (a Address) sortOrder(mode IPv6Mode) int {
 	order := 0xFF
 	switch a.Scope {
 	case ScopePublic:
@@ -594,22 +620,29 @@ func (a Address) sortOrder() int {
 			order++
 		}
 	case IPv6Address:
-		// Prefer IPv4 over IPv6 addresses.
-		order++
+		if mode == IPv6Disabled {
+			order++
+		}
 	case IPv4Address:
+		if mode != IPv6Disabled {
+			order++
+		}
 	}
 	return order
 }
 
-type addressesPreferringIPv4Slice []Address
+type addressesByMode struct {
+	addrs []Address
+	mode  IPv6Mode
+}
 
-func (a addressesPreferringIPv4Slice) Len() int      { return len(a) }
-func (a addressesPreferringIPv4Slice) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
-	addr1 := a[i]
-	addr2 := a[j]
-	order1 := addr1.sortOrder()
-	order2 := addr2.sortOrder()
+func (a addressesByMode) Len() int      { return len(a.addrs) }
+func (a addressesByMode) Swap(i, j int) { a.addrs[i], a.addrs[j] = a.addrs[j], a.addrs[i] }
+func (a addressesByMode) Less(i, j int) bool {
+	addr1 := a.addrs[i]
+	addr2 := a.addrs[j]
+	order1 := addr1.sortOrder(a.mode)
+	order2 := addr2.sortOrder(a.mode)
 	if order1 == order2 {
 		return addr1.Value < addr2.Value
 	}
@@ -619,7 +652,13 @@ func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
 // SortAddresses sorts the given Address slice according to the sortOrder of
 // each address. See Address.sortOrder() for more info.
 func SortAddresses(addrs []Address) {
-	sort.Sort(addressesPreferringIPv4Slice(addrs))
+	IPv6Disabled.SortAddresses(addrs)
+}
+
+// SortAddresses sorts the given Address slice according to the sortOrder
+// of each address in a model using the IPv6 mode.
+func (m IPv6Mode) SortAddresses(addrs []Address) {
+	sort.Sort(addressesByMode{addrs, m})
 }
 
 // DecimalToIPv4 converts a decimal to the dotted quad IP address format.
@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// IngressRule represents a range of ports which may be accessed from
+// the given source CIDRs.
+type IngressRule struct {
+	PortRange PortRange
+
+	// SourceCIDRs holds the sorted CIDRs from which the ports may be
+	// accessed. No CIDRs means the ports may be accessed from anywhere.
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule for the given port range,
+// allowing access from the given source CIDRs, or from anywhere if
+// none are given.
+func NewIngressRule(portRange PortRange, sourceCIDRs ...string) IngressRule {
+	rule := IngressRule{PortRange: portRange}
+	if len(sourceCIDRs) > 0 {
+		rule.SourceCIDRs = set.NewStrings(sourceCIDRs...).SortedValues()
+	}
+	return rule
+}
+
+// Validate returns an error if the port range or any of the
+// source CIDRs of the rule are not valid.
+func (r IngressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, cidr := range r.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// Unrestricted reports whether the ports may be accessed from anywhere.
+func (r IngressRule) Unrestricted() bool {
+	return len(r.SourceCIDRs) == 0
+}
+
+// String returns a string representation of the rule, which is the
+// same for all equal rules.
+func (r IngressRule) String() string {
+	if r.Unrestricted() {
+		return r.PortRange.String()
+	}
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+// GoString implements fmt.GoStringer.
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (r ingressRuleSlice) Len() int      { return len(r) }
+func (r ingressRuleSlice) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r ingressRuleSlice) Less(i, j int) bool {
+	if r[i].PortRange != r[j].PortRange {
+		return portRangeSlice{r[i].PortRange, r[j].PortRange}.Less(0, 1)
+	}
+	return strings.Join(r[i].SourceCIDRs, ",") < strings.Join(r[j].SourceCIDRs, ",")
+}
+
+// SortIngressRules sorts the given rules, first by port range
+// (as SortPortRanges does), then by source CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRule(c *gc.C) {
+	rule := network.NewIngressRule(network.MustParsePortRange("80/tcp"))
+	c.Check(rule.Unrestricted(), jc.IsTrue)
+	c.Check(rule.SourceCIDRs, gc.IsNil)
+	c.Check(rule.String(), gc.Equals, "80/tcp")
+
+	rule = network.NewIngressRule(
+		network.MustParsePortRange("80-90/tcp"),
+		"192.168.1.0/24", "10.0.0.0/8", "192.168.1.0/24",
+	)
+	c.Check(rule.Unrestricted(), jc.IsFalse)
+	c.Check(rule.SourceCIDRs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Check(rule.String(), gc.Equals, "80-90/tcp from 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*IngressRuleSuite) TestValidate(c *gc.C) {
+	rule := network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8")
+	c.Check(rule.Validate(), jc.ErrorIsNil)
+
+	rule = network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0")
+	c.Check(rule.Validate(), gc.ErrorMatches, `CIDR "10.0.0.0" not valid`)
+
+	rule = network.NewIngressRule(network.PortRange{80, 80, "icmp"})
+	c.Check(rule.Validate(), gc.ErrorMatches, `invalid protocol "icmp", expected "tcp" or "udp"`)
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24"),
+		network.NewIngressRule(network.MustParsePortRange("53/udp")),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.NewIngressRule(network.MustParsePortRange("22/tcp")),
+	}
+	network.SortIngressRules(rules)
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("22/tcp")),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24"),
+		network.NewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+}
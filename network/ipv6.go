@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"github.com/juju/errors"
+)
+
+// IPv6Mode describes how IPv6 is used by a model.
+type IPv6Mode string
+
+const (
+	// IPv6Disabled is the default mode: IPv4 addresses are preferred,
+	// and IPv6 addresses are only used when no suitable IPv4 address
+	// is available.
+	IPv6Disabled IPv6Mode = "disabled"
+
+	// IPv6DualStack prefers IPv6 addresses over IPv4 addresses, and
+	// allows traffic over both.
+	IPv6DualStack IPv6Mode = "dual-stack"
+
+	// IPv6Only never uses IPv4 addresses.
+	IPv6Only IPv6Mode = "ipv6-only"
+)
+
+// Validate returns an error if the mode is not one of
+// IPv6Disabled, IPv6DualStack or IPv6Only.
+func (m IPv6Mode) Validate() error {
+	switch m {
+	case IPv6Disabled, IPv6DualStack, IPv6Only:
+		return nil
+	}
+	return errors.NotValidf("IPv6 mode %q", m)
+}
+
+// AllowsIPv4 reports whether IPv4 addresses may be used in the mode.
+func (m IPv6Mode) AllowsIPv4() bool {
+	return m != IPv6Only
+}
+
+// AllowsIPv6 reports whether IPv6 traffic should be allowed in the
+// mode, e.g. by firewall rules.
+func (m IPv6Mode) AllowsIPv6() bool {
+	return m == IPv6DualStack || m == IPv6Only
+}
+
+// ListenNetwork returns the network, as passed to net.Listen,
+// on which servers should listen in the mode.
+func (m IPv6Mode) ListenNetwork() string {
+	if m == IPv6Only {
+		return "tcp6"
+	}
+	return "tcp"
+}
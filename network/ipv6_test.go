@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IPv6ModeSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IPv6ModeSuite{})
+
+func (s *IPv6ModeSuite) TestValidate(c *gc.C) {
+	for _, mode := range []network.IPv6Mode{
+		network.IPv6Disabled,
+		network.IPv6DualStack,
+		network.IPv6Only,
+	} {
+		c.Check(mode.Validate(), jc.ErrorIsNil)
+	}
+	c.Check(network.IPv6Mode("sometimes").Validate(), gc.ErrorMatches, `IPv6 mode "sometimes" not valid`)
+}
+
+func (s *IPv6ModeSuite) TestListenNetwork(c *gc.C) {
+	c.Check(network.IPv6Disabled.ListenNetwork(), gc.Equals, "tcp")
+	c.Check(network.IPv6DualStack.ListenNetwork(), gc.Equals, "tcp")
+	c.Check(network.IPv6Only.ListenNetwork(), gc.Equals, "tcp6")
+}
+
+var dualStackAddresses = []network.Address{
+	network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+	network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+}
+
+func (s *IPv6ModeSuite) TestSelectDisabled(c *gc.C) {
+	addr, ok := network.SelectPublicAddress(dualStackAddresses)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "8.8.8.8")
+	addr, ok = network.SelectInternalAddress(dualStackAddresses, false)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "10.0.0.1")
+}
+
+func (s *IPv6ModeSuite) TestSelectDualStack(c *gc.C) {
+	mode := network.IPv6DualStack
+	addr, ok := mode.SelectPublicAddress(dualStackAddresses)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+	addr, ok = mode.SelectInternalAddress(dualStackAddresses, false)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "fc00::1")
+
+	// IPv4 addresses are still used when there is no IPv6 address.
+	addr, ok = mode.SelectPublicAddress(dualStackAddresses[:1])
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "8.8.8.8")
+}
+
+func (s *IPv6ModeSuite) TestSelectIPv6Only(c *gc.C) {
+	mode := network.IPv6Only
+	addr, ok := mode.SelectPublicAddress(dualStackAddresses)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+
+	_, ok = mode.SelectPublicAddress(network.NewAddresses("8.8.8.8", "10.0.0.1"))
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *IPv6ModeSuite) TestSortAddresses(c *gc.C) {
+	addrs := network.NewAddresses("8.8.8.8", "2001:db8::1")
+	network.IPv6DualStack.SortAddresses(addrs)
+	c.Check(addrs, jc.DeepEquals, network.NewAddresses("2001:db8::1", "8.8.8.8"))
+
+	// The default mode prefers IPv4 addresses.
+	network.SortAddresses(addrs)
+	c.Check(addrs, jc.DeepEquals, network.NewAddresses("8.8.8.8", "2001:db8::1"))
+}
@@ -36,10 +36,20 @@ func NewPublicClient(raw rawAPI) PublicClient {
 
 // ListFull calls the List API server method.
 func (c PublicClient) ListFull(patterns ...string) ([]payload.FullPayloadInfo, error) {
+	return c.ListFiltered(payload.ListFilter{}, patterns...)
+}
+
+// ListFiltered calls the List API server method, returning only the
+// payloads that also match the given filter.
+func (c PublicClient) ListFiltered(filter payload.ListFilter, patterns ...string) ([]payload.FullPayloadInfo, error) {
 	var result api.EnvListResults
 
 	args := api.EnvListArgs{
 		Patterns: patterns,
+		Status:   filter.Status,
+		Type:     filter.Type,
+		Unit:     filter.Unit,
+		Machine:  filter.Machine,
 	}
 	if err := c.FacadeCall("List", &args, &result); err != nil {
 		return nil, errors.Trace(err)
@@ -54,5 +64,9 @@ func (c PublicClient) ListFull(patterns ...string) ([]payload.FullPayloadInfo, e
 		}
 		payloads[i] = payload
 	}
+	if !filter.IsEmpty() {
+		// Older controllers ignore the filter, so apply it here too.
+		payloads = payload.Filter(payloads, filter.Match)
+	}
 	return payloads, nil
 }
@@ -80,6 +80,36 @@ func (s *publicSuite) TestListAPI(c *gc.C) {
 	}})
 }
 
+func (s *publicSuite) TestListFiltered(c *gc.C) {
+	failed := s.payload
+	failed.ID = "idfailed"
+	failed.Status = payload.StateFailed
+	s.facade.FacadeCallFn = func(_ string, _, response interface{}) error {
+		// Older controllers ignore the filter and return everything.
+		typedResponse, ok := response.(*api.EnvListResults)
+		c.Assert(ok, gc.Equals, true)
+		typedResponse.Results = append(typedResponse.Results, s.payload, failed)
+		return nil
+	}
+
+	pclient := client.NewPublicClient(s.facade)
+
+	filter := payload.ListFilter{Status: "failed", Machine: "1"}
+	payloads, err := pclient.ListFiltered(filter, "a-tag")
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected, _ := api.API2Payload(failed)
+	c.Check(payloads, jc.DeepEquals, []payload.FullPayloadInfo{
+		expected,
+	})
+	s.stub.CheckCallNames(c, "FacadeCall")
+	c.Check(s.stub.Calls()[0].Args[1], jc.DeepEquals, &api.EnvListArgs{
+		Patterns: []string{"a-tag"},
+		Status:   "failed",
+		Machine:  "1",
+	})
+}
+
 type stubFacade struct {
 	stub         *testing.Stub
 	FacadeCallFn func(name string, params, response interface{}) error
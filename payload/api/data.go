@@ -9,6 +9,13 @@ package api
 type EnvListArgs struct {
 	// Patterns is the list of patterns against which to filter.
 	Patterns []string `json:"patterns"`
+
+	// Status, Type, Unit and Machine further restrict the payloads
+	// returned; see payload.ListFilter. Empty fields match any payload.
+	Status  string `json:"status,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Unit    string `json:"unit,omitempty"`
+	Machine string `json:"machine,omitempty"`
 }
 
 type EnvListResults struct {
@@ -29,7 +29,9 @@ func NewPublicAPI(st EnvPayloads) *PublicAPI {
 
 // List builds the list of payloads being tracked for
 // the given unit and IDs. If no IDs are provided then all tracked
-// payloads for the unit are returned.
+// payloads for the unit are returned. The payloads are further
+// restricted to those matching any status, type, unit and machine
+// given in args.
 func (a PublicAPI) List(args api.EnvListArgs) (api.EnvListResults, error) {
 	var r api.EnvListResults
 
@@ -44,6 +46,16 @@ func (a PublicAPI) List(args api.EnvListArgs) (api.EnvListResults, error) {
 	}
 	payloads = payload.Filter(payloads, filters...)
 
+	filter := payload.ListFilter{
+		Status:  args.Status,
+		Type:    args.Type,
+		Unit:    args.Unit,
+		Machine: args.Machine,
+	}
+	if !filter.IsEmpty() {
+		payloads = payload.Filter(payloads, filter.Match)
+	}
+
 	for _, payload := range payloads {
 		apiInfo := api.Payload2api(payload)
 		r.Results = append(r.Results, apiInfo)
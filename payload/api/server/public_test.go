@@ -177,6 +177,33 @@ func (s *publicSuite) TestListPartialMatch(c *gc.C) {
 	})
 }
 
+func (s *publicSuite) TestListFilter(c *gc.C) {
+	payloadA, _ := s.newPayload("spam")
+	payloadB, apiPayloadB := s.newPayload("eggs")
+	payloadB.Status = payload.StateFailed
+	apiPayloadB.Status = payload.StateFailed
+	payloadC, _ := s.newPayload("ham")
+	payloadC.Status = payload.StateFailed
+	payloadC.Machine = "2"
+	s.state.payloads = append(s.state.payloads, payloadA, payloadB, payloadC)
+
+	facade := PublicAPI{s.state}
+	args := api.EnvListArgs{
+		Status:  "failed",
+		Type:    "docker",
+		Unit:    "a-application",
+		Machine: "1",
+	}
+	results, err := facade.List(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, api.EnvListResults{
+		Results: []api.Payload{
+			apiPayloadB,
+		},
+	})
+}
+
 func (s *publicSuite) TestListPartialMultiMatch(c *gc.C) {
 	payloadA, apiPayloadA := s.newPayload("spam")
 	payloadB, _ := s.newPayload("eggs")
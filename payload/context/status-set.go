@@ -43,7 +43,7 @@ func (c StatusSetCmd) Info() *cmd.Info {
 "payload-status-set" is used to update the current status of a registered payload.
 The <class> and <id> provided must match a payload that has been previously
 registered with juju using payload-register. The <status> must be one of the
-follow: starting, started, stopping, stopped
+follow: starting, running, stopping, stopped, failed
 `,
 	}
 }
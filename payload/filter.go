@@ -55,6 +55,56 @@ func BuildPredicatesFor(patterns []string) ([]PayloadPredicate, error) {
 	return predicates, nil
 }
 
+// ListFilter selects payloads by the values of their fields. A payload
+// is selected only if it matches every field that is set; matching is
+// not case-sensitive.
+type ListFilter struct {
+	// Status selects payloads with the given status.
+	Status string
+
+	// Type selects payloads of the given type, e.g. "docker".
+	Type string
+
+	// Unit selects payloads of the given unit or, if it names an
+	// application, of any of the application's units.
+	Unit string
+
+	// Machine selects payloads on the given machine.
+	Machine string
+}
+
+// IsEmpty returns whether the filter selects every payload.
+func (f ListFilter) IsEmpty() bool {
+	return f == ListFilter{}
+}
+
+// Match returns whether the filter selects the given payload.
+func (f ListFilter) Match(payload FullPayloadInfo) bool {
+	if f.Status != "" && !strings.EqualFold(payload.Status, f.Status) {
+		return false
+	}
+	if f.Type != "" && !strings.EqualFold(payload.Type, f.Type) {
+		return false
+	}
+	if f.Unit != "" && !matchUnit(payload.Unit, f.Unit) {
+		return false
+	}
+	if f.Machine != "" && !strings.EqualFold(payload.Machine, f.Machine) {
+		return false
+	}
+	return true
+}
+
+// matchUnit returns whether the unit is, or belongs to, the unit or
+// application named by pattern.
+func matchUnit(unit, pattern string) bool {
+	if strings.Contains(pattern, "/") {
+		return strings.EqualFold(unit, pattern)
+	}
+	parts := strings.SplitN(unit, "/", 2)
+	return strings.EqualFold(parts[0], pattern)
+}
+
 // Match determines if the given payload matches the pattern.
 func Match(payload FullPayloadInfo, pattern string) bool {
 	pattern = strings.ToLower(pattern)
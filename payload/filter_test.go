@@ -246,3 +246,37 @@ func (s *filterSuite) TestMatch(c *gc.C) {
 		c.Check(matched, jc.IsFalse)
 	}
 }
+
+func (s *filterSuite) TestListFilterEmpty(c *gc.C) {
+	var filter payload.ListFilter
+	c.Check(filter.IsEmpty(), jc.IsTrue)
+	c.Check(filter.Match(s.newPayload("spam")), jc.IsTrue)
+}
+
+func (s *filterSuite) TestListFilterMatch(c *gc.C) {
+	pl := s.newPayload("spam")
+	pl.Status = payload.StateFailed
+
+	for i, test := range []struct {
+		filter payload.ListFilter
+		match  bool
+	}{
+		{payload.ListFilter{Status: "failed"}, true},
+		{payload.ListFilter{Status: "FAILED"}, true},
+		{payload.ListFilter{Status: "running"}, false},
+		{payload.ListFilter{Type: "docker"}, true},
+		{payload.ListFilter{Type: "kvm"}, false},
+		{payload.ListFilter{Unit: "a-application/0"}, true},
+		{payload.ListFilter{Unit: "a-application/1"}, false},
+		{payload.ListFilter{Unit: "a-application"}, true},
+		{payload.ListFilter{Unit: "a-app"}, false},
+		{payload.ListFilter{Machine: "1"}, true},
+		{payload.ListFilter{Machine: "2"}, false},
+		{payload.ListFilter{Status: "failed", Type: "docker", Machine: "1"}, true},
+		{payload.ListFilter{Status: "failed", Type: "docker", Machine: "2"}, false},
+	} {
+		c.Logf("test %d: %+v", i, test.filter)
+		c.Check(test.filter.IsEmpty(), jc.IsFalse)
+		c.Check(test.filter.Match(pl), gc.Equals, test.match)
+	}
+}
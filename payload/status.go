@@ -21,6 +21,7 @@ const (
 	StateRunning  = "running"
 	StateStopping = "stopping"
 	StateStopped  = "stopped"
+	StateFailed   = "failed"
 )
 
 var okayStates = set.NewStrings(
@@ -28,6 +29,7 @@ var okayStates = set.NewStrings(
 	StateRunning,
 	StateStopping,
 	StateStopped,
+	StateFailed,
 )
 
 // ValidateState verifies the state passed in is a valid okayState.
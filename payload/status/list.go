@@ -17,7 +17,7 @@ import (
 
 // ListAPI has the API methods needed by ListCommand.
 type ListAPI interface {
-	ListFull(patterns ...string) ([]payload.FullPayloadInfo, error)
+	ListFiltered(filter payload.ListFilter, patterns ...string) ([]payload.FullPayloadInfo, error)
 	io.Closer
 }
 
@@ -26,6 +26,7 @@ type ListCommand struct {
 	modelcmd.ModelCommandBase
 	out      cmd.Output
 	patterns []string
+	filter   payload.ListFilter
 
 	newAPIClient func(c *ListCommand) (ListAPI, error)
 }
@@ -55,6 +56,15 @@ will be checked against the following info in Juju:
 - payload id
 - payload tag
 - payload status
+
+The --status, --type, --unit and --machine options restrict the results
+further, to those payloads matching *all* of the options given. The
+--unit option accepts either a unit or an application name.
+
+Examples:
+
+    juju payloads --status failed --type docker
+    juju payloads --machine 3
 `
 
 func (c *ListCommand) Info() *cmd.Info {
@@ -75,6 +85,10 @@ func (c *ListCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 	})
+	f.StringVar(&c.filter.Status, "status", "", "Only show payloads with this status")
+	f.StringVar(&c.filter.Type, "type", "", "Only show payloads of this type")
+	f.StringVar(&c.filter.Unit, "unit", "", "Only show payloads of this unit or application")
+	f.StringVar(&c.filter.Machine, "machine", "", "Only show payloads on this machine")
 }
 
 func (c *ListCommand) Init(args []string) error {
@@ -89,7 +103,7 @@ func (c *ListCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
-	payloads, err := apiclient.ListFull(c.patterns...)
+	payloads, err := apiclient.ListFiltered(c.filter, c.patterns...)
 	if err != nil {
 		if payloads == nil {
 			// List call completely failed; there is nothing to report.
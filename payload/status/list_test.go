@@ -65,6 +65,15 @@ will be checked against the following info in Juju:
 - payload id
 - payload tag
 - payload status
+
+The --status, --type, --unit and --machine options restrict the results
+further, to those payloads matching *all* of the options given. The
+--unit option accepts either a unit or an application name.
+
+Examples:
+
+    juju payloads --status failed --type docker
+    juju payloads --machine 3
 `,
 		Aliases: []string{"list-payloads"},
 	})
@@ -131,6 +140,7 @@ another-application/1  2        eggs           running  docker  ideggs  a-tag
 	}, {
 		FuncName: "List",
 		Args: []interface{}{
+			payload.ListFilter{},
 			[]string{
 				"a-tag",
 				"other",
@@ -142,6 +152,49 @@ another-application/1  2        eggs           running  docker  ideggs  a-tag
 	}})
 }
 
+func (s *listSuite) TestFilterFlags(c *gc.C) {
+	p1 := status.NewPayload("spam", "a-application", 1, 0)
+	p1.Status = payload.StateFailed
+	s.client.payloads = append(s.client.payloads, p1)
+
+	command := status.NewListCommand(s.newAPIClient)
+	args := []string{
+		"--status", "failed",
+		"--type", "docker",
+		"--unit", "a-application",
+		"--machine", "1",
+	}
+	code, stdout, stderr := runList(c, command, args...)
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(stdout, gc.Equals, `
+[Unit Payloads]
+Unit             Machine  Payload class  Status  Type    Id      Tags  
+a-application/0  1        spam           failed  docker  idspam        
+
+`[1:])
+	c.Check(stderr, gc.Equals, "")
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "newAPIClient",
+		Args: []interface{}{
+			command,
+		},
+	}, {
+		FuncName: "List",
+		Args: []interface{}{
+			payload.ListFilter{
+				Status:  "failed",
+				Type:    "docker",
+				Unit:    "a-application",
+				Machine: "1",
+			},
+			[]string(nil),
+		},
+	}, {
+		FuncName: "Close",
+	}})
+}
+
 func (s *listSuite) TestOutputFormats(c *gc.C) {
 	p1 := status.NewPayload("spam", "a-application", 1, 0)
 	p1.Labels = []string{"a-tag"}
@@ -222,8 +275,8 @@ type stubClient struct {
 	payloads []payload.FullPayloadInfo
 }
 
-func (s *stubClient) ListFull(patterns ...string) ([]payload.FullPayloadInfo, error) {
-	s.stub.AddCall("List", patterns)
+func (s *stubClient) ListFiltered(filter payload.ListFilter, patterns ...string) ([]payload.FullPayloadInfo, error) {
+	s.stub.AddCall("List", filter, patterns)
 	if err := s.stub.NextErr(); err != nil {
 		return nil, errors.Trace(err)
 	}
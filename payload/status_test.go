@@ -18,6 +18,7 @@ var (
 		payload.StateRunning,
 		payload.StateStopping,
 		payload.StateStopped,
+		payload.StateFailed,
 	}
 )
 
@@ -21,6 +21,12 @@ const (
 	// without being able to make any changes.
 	ReadAccess Access = "read"
 
+	// OperatorAccess allows a user to operate the existing applications
+	// of a permission subject, for example by running actions and
+	// resolving units, without being able to change its configuration
+	// or relations.
+	OperatorAccess Access = "operator"
+
 	// WriteAccess allows a user to make changes to a permission subject.
 	WriteAccess Access = "write"
 
@@ -42,7 +48,7 @@ const (
 // Validate returns error if the current is not a valid access level.
 func (a Access) Validate() error {
 	switch a {
-	case NoAccess, AdminAccess, ReadAccess, WriteAccess,
+	case NoAccess, AdminAccess, ReadAccess, OperatorAccess, WriteAccess,
 		LoginAccess, AddModelAccess, SuperuserAccess:
 		return nil
 	}
@@ -53,7 +59,7 @@ func (a Access) Validate() error {
 // model access level.
 func ValidateModelAccess(access Access) error {
 	switch access {
-	case ReadAccess, WriteAccess, AdminAccess:
+	case ReadAccess, OperatorAccess, WriteAccess, AdminAccess:
 		return nil
 	}
 	return errors.NotValidf("%q model access", access)
@@ -90,10 +96,12 @@ func (a Access) modelValue() int {
 		return 0
 	case ReadAccess:
 		return 1
-	case WriteAccess:
+	case OperatorAccess:
 		return 2
-	case AdminAccess:
+	case WriteAccess:
 		return 3
+	case AdminAccess:
+		return 4
 	default:
 		return -1
 	}
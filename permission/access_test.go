@@ -107,6 +107,29 @@ func (*accessSuite) TestGreaterModelAccessThan(c *gc.C) {
 	c.Check(admin.GreaterModelAccessThan(admin), jc.IsFalse)
 }
 
+func (*accessSuite) TestOperatorModelAccess(c *gc.C) {
+	var (
+		read     = permission.ReadAccess
+		operator = permission.OperatorAccess
+		write    = permission.WriteAccess
+		admin    = permission.AdminAccess
+	)
+	c.Check(operator.Validate(), jc.ErrorIsNil)
+	c.Check(permission.ValidateModelAccess(operator), jc.ErrorIsNil)
+	c.Check(permission.ValidateControllerAccess(operator), gc.ErrorMatches, `"operator" controller access not valid`)
+
+	c.Check(operator.EqualOrGreaterModelAccessThan(read), jc.IsTrue)
+	c.Check(operator.EqualOrGreaterModelAccessThan(operator), jc.IsTrue)
+	c.Check(operator.EqualOrGreaterModelAccessThan(write), jc.IsFalse)
+	c.Check(operator.GreaterModelAccessThan(read), jc.IsTrue)
+	c.Check(operator.GreaterModelAccessThan(operator), jc.IsFalse)
+
+	c.Check(read.EqualOrGreaterModelAccessThan(operator), jc.IsFalse)
+	c.Check(write.GreaterModelAccessThan(operator), jc.IsTrue)
+	c.Check(admin.GreaterModelAccessThan(operator), jc.IsTrue)
+	c.Check(operator.EqualOrGreaterControllerAccessThan(permission.LoginAccess), jc.IsFalse)
+}
+
 func (*accessSuite) TestEqualOrGreaterControllerAccessThan(c *gc.C) {
 	// A very boring but necessary test to test explicit responses.
 	var (
@@ -15,7 +15,17 @@ import (
 )
 
 const (
-	configAttrStorageAccountType = "storage-account-type"
+	configAttrStorageAccountType    = "storage-account-type"
+	configAttrAvailabilitySetPolicy = "availability-set-policy"
+
+	// availabilitySetPolicyApplication places the machines hosting
+	// units of an application in an availability set named after
+	// the application.
+	availabilitySetPolicyApplication = "application"
+
+	// availabilitySetPolicyNone leaves machines, other than
+	// controllers, out of any availability set.
+	availabilitySetPolicyNone = "none"
 
 	// The below bits are internal book-keeping things, rather than
 	// configuration. Config is just what we have to work with.
@@ -26,11 +36,13 @@ const (
 )
 
 var configFields = schema.Fields{
-	configAttrStorageAccountType: schema.String(),
+	configAttrStorageAccountType:    schema.String(),
+	configAttrAvailabilitySetPolicy: schema.String(),
 }
 
 var configDefaults = schema.Defaults{
-	configAttrStorageAccountType: string(storage.StandardLRS),
+	configAttrStorageAccountType:    string(storage.StandardLRS),
+	configAttrAvailabilitySetPolicy: availabilitySetPolicyApplication,
 }
 
 var immutableConfigAttributes = []string{
@@ -39,13 +51,18 @@ var immutableConfigAttributes = []string{
 
 type azureModelConfig struct {
 	*config.Config
-	storageAccountType string
+	storageAccountType    string
+	availabilitySetPolicy string
 }
 
 var knownStorageAccountTypes = []string{
 	"Standard_LRS", "Standard_GRS", "Standard_RAGRS", "Standard_ZRS", "Premium_LRS",
 }
 
+var knownAvailabilitySetPolicies = []string{
+	availabilitySetPolicyApplication, availabilitySetPolicyNone,
+}
+
 // Validate ensures that the provided configuration is valid for this
 // provider, and that changes between the old (if provided) and new
 // configurations are valid.
@@ -119,9 +136,18 @@ Please choose a model name of no more than %d characters.`,
 		)
 	}
 
+	availabilitySetPolicy := validated[configAttrAvailabilitySetPolicy].(string)
+	if !isKnownAvailabilitySetPolicy(availabilitySetPolicy) {
+		return nil, errors.Errorf(
+			"invalid availability set policy %q, expected one of: %q",
+			availabilitySetPolicy, knownAvailabilitySetPolicies,
+		)
+	}
+
 	azureConfig := &azureModelConfig{
 		newCfg,
 		storageAccountType,
+		availabilitySetPolicy,
 	}
 	return azureConfig, nil
 }
@@ -137,6 +163,17 @@ func isKnownStorageAccountType(t string) bool {
 	return false
 }
 
+// isKnownAvailabilitySetPolicy reports whether or not the given string
+// identifies a known availability set policy.
+func isKnownAvailabilitySetPolicy(p string) bool {
+	for _, knownPolicy := range knownAvailabilitySetPolicies {
+		if p == knownPolicy {
+			return true
+		}
+	}
+	return false
+}
+
 // canonicalLocation returns the canonicalized location string. This involves
 // stripping whitespace, and lowercasing. The ARM APIs do not support embedded
 // whitespace, whereas the old Service Management APIs used to; we allow the
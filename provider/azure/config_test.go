@@ -46,6 +46,14 @@ func (s *configSuite) TestValidateInvalidStorageAccountType(c *gc.C) {
 	)
 }
 
+func (s *configSuite) TestValidateAvailabilitySetPolicy(c *gc.C) {
+	s.assertConfigValid(c, testing.Attrs{"availability-set-policy": "none"})
+	s.assertConfigInvalid(
+		c, testing.Attrs{"availability-set-policy": "zones"},
+		`invalid availability set policy "zones", expected one of: \["application" "none"\]`,
+	)
+}
+
 func (s *configSuite) TestValidateInvalidFirewallMode(c *gc.C) {
 	s.assertConfigInvalid(
 		c, testing.Attrs{"firewall-mode": "global"},
@@ -373,6 +373,9 @@ func (env *azureEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.GPUs,
+		constraints.GPUType,
+		constraints.MaxPrice,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
@@ -435,6 +438,7 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 		env.config,
 	)
 	storageAccountType := env.config.storageAccountType
+	availabilitySetPolicy := env.config.availabilitySetPolicy
 	imageStream := env.config.ImageStream()
 	instanceTypes, err := env.getInstanceTypesLocked()
 	if err != nil {
@@ -522,7 +526,7 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 	if err := env.createVirtualMachine(
 		vmName, vmTags, envTags,
 		instanceSpec, args.InstanceConfig,
-		storageAccountType,
+		storageAccountType, availabilitySetPolicy,
 	); err != nil {
 		logger.Errorf("creating instance failed, destroying: %v", err)
 		if err := env.StopInstances(instance.Id(vmName)); err != nil {
@@ -558,6 +562,7 @@ func (env *azureEnviron) createVirtualMachine(
 	instanceSpec *instances.InstanceSpec,
 	instanceConfig *instancecfg.InstanceConfig,
 	storageAccountType string,
+	availabilitySetPolicy string,
 ) error {
 
 	deploymentsClient := resources.DeploymentsClient{env.resources}
@@ -618,6 +623,7 @@ func (env *azureEnviron) createVirtualMachine(
 	var availabilitySetSubResource *compute.SubResource
 	availabilitySetName, err := availabilitySetName(
 		vmName, vmTags, instanceConfig.Controller != nil,
+		availabilitySetPolicy,
 	)
 	if err != nil {
 		return errors.Annotate(err, "getting availability set name")
@@ -852,19 +858,28 @@ func (env *azureEnviron) waitCommonResourcesCreatedLocked() error {
 // algorithm used for choosing the availability set is:
 //  - if the machine is a controller, use the availability set name
 //    "juju-controller";
+//  - if the availability set policy is "none", do not assign the
+//    machine to an availability set;
 //  - if the machine has units assigned, create an availability
 //    name with a name based on the value of the tags.JujuUnitsDeployed tag
 //    in vmTags, if it exists;
 //  - otherwise, do not assign the machine to an availability set
+//
+// Controllers are always placed in their availability set, as that is
+// how controller machines are identified.
 func availabilitySetName(
 	vmName string,
 	vmTags map[string]string,
 	controller bool,
+	policy string,
 ) (string, error) {
 	logger.Debugf("selecting availability set for %q", vmName)
 	if controller {
 		return controllerAvailabilitySet, nil
 	}
+	if policy == availabilitySetPolicyNone {
+		return "", nil
+	}
 
 	// We'll have to create an availability set. Use the name of one of the
 	// services assigned to the machine.
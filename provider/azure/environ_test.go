@@ -739,6 +739,25 @@ func (s *environSuite) TestStartInstanceServiceAvailabilitySet(c *gc.C) {
 	})
 }
 
+func (s *environSuite) TestStartInstanceNoAvailabilitySet(c *gc.C) {
+	env := s.openEnviron(c, testing.Attrs{"availability-set-policy": "none"})
+	unitsDeployed := "mysql/0 wordpress/0"
+	s.vmTags[tags.JujuUnitsDeployed] = &unitsDeployed
+	s.sender = s.startInstanceSenders(false)
+	s.requests = nil
+	params := makeStartInstanceParams(c, s.controllerUUID, "quantal")
+	params.InstanceConfig.Tags[tags.JujuUnitsDeployed] = unitsDeployed
+
+	_, err := env.StartInstance(params)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStartInstanceRequests(c, s.requests, assertStartInstanceRequestsParams{
+		imageReference: &quantalImageReference,
+		diskSizeGB:     32,
+		osProfile:      &linuxOsProfile,
+		instanceType:   "Standard_A1",
+	})
+}
+
 // numExpectedStartInstanceRequests is the number of expected requests base
 // by StartInstance method calls. The number is one less for Bootstrap, which
 // does not require a query on the common deployment.
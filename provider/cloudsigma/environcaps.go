@@ -12,6 +12,9 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.GPUs,
+	constraints.GPUType,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator returns a Validator instance which
@@ -6,7 +6,10 @@ package common
 import (
 	"sort"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 )
 
@@ -131,6 +134,45 @@ func AvailabilityZoneAllocations(env ZonedEnviron, group []instance.Id) ([]Avail
 	return zoneInstances, nil
 }
 
+// DistributionGroup returns the instances that a new instance should
+// be spread across availability zones from, under the model's
+// az-distribution policy. distributionGroup is the StartInstanceParams
+// function of the same name, and may be nil. Under AZDistributionNone
+// the group is ignored and nil is returned, so that zones are chosen by
+// the population of all of the model's instances.
+func DistributionGroup(cfg *config.Config, distributionGroup func() ([]instance.Id, error)) ([]instance.Id, error) {
+	if distributionGroup == nil || cfg.AZDistribution() == config.AZDistributionNone {
+		return nil, nil
+	}
+	return distributionGroup()
+}
+
+// DistributionZones returns the availability zones, of those allocated
+// to the given distribution group by AvailabilityZoneAllocations, that
+// a new instance in the group may be started in under the model's
+// az-distribution policy. Under AZDistributionStrict only the zones
+// holding none of the group's instances are returned, and an error is
+// returned if there are no such zones; otherwise the zones are returned
+// unchanged.
+func DistributionZones(cfg *config.Config, group []instance.Id, zoneInstances []AvailabilityZoneInstances) ([]AvailabilityZoneInstances, error) {
+	if cfg.AZDistribution() != config.AZDistributionStrict || len(group) == 0 || len(zoneInstances) == 0 {
+		return zoneInstances, nil
+	}
+	var empty []AvailabilityZoneInstances
+	for _, zone := range zoneInstances {
+		if len(zone.Instances) == 0 {
+			empty = append(empty, zone)
+		}
+	}
+	if len(empty) == 0 {
+		return nil, errors.Errorf(
+			"all %d availability zones already hold instances of the distribution group, and az-distribution is %q",
+			len(zoneInstances), config.AZDistributionStrict,
+		)
+	}
+	return empty, nil
+}
+
 var internalAvailabilityZoneAllocations = AvailabilityZoneAllocations
 
 // DistributeInstances is a common function for implement the
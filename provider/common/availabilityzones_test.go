@@ -254,3 +254,54 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 		c.Assert(eligible, jc.SameContents, test.eligible)
 	}
 }
+
+func (s *AvailabilityZoneSuite) TestDistributionGroup(c *gc.C) {
+	group := func() ([]instance.Id, error) {
+		return []instance.Id{"i0", "i1"}, nil
+	}
+	for _, policy := range []string{"balanced", "strict"} {
+		cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{"az-distribution": policy})
+		ids, err := common.DistributionGroup(cfg, group)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(ids, gc.DeepEquals, []instance.Id{"i0", "i1"})
+
+		ids, err = common.DistributionGroup(cfg, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(ids, gc.HasLen, 0)
+	}
+
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{"az-distribution": "none"})
+	ids, err := common.DistributionGroup(cfg, func() ([]instance.Id, error) {
+		c.Fatal("unexpected call")
+		return nil, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, gc.HasLen, 0)
+}
+
+func (s *AvailabilityZoneSuite) TestDistributionZones(c *gc.C) {
+	zoneInstances := []common.AvailabilityZoneInstances{
+		{ZoneName: "az1"},
+		{ZoneName: "az0", Instances: []instance.Id{"i0"}},
+		{ZoneName: "az2", Instances: []instance.Id{"i2"}},
+	}
+	group := []instance.Id{"i0", "i2"}
+
+	cfg := coretesting.ModelConfig(c)
+	zones, err := common.DistributionZones(cfg, group, zoneInstances)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.DeepEquals, zoneInstances)
+
+	cfg = coretesting.CustomModelConfig(c, coretesting.Attrs{"az-distribution": "strict"})
+	zones, err = common.DistributionZones(cfg, group, zoneInstances)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.DeepEquals, zoneInstances[:1])
+
+	// An empty group may go anywhere.
+	zones, err = common.DistributionZones(cfg, nil, zoneInstances[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.DeepEquals, zoneInstances[1:])
+
+	_, err = common.DistributionZones(cfg, group, zoneInstances[1:])
+	c.Assert(err, gc.ErrorMatches, `all 2 availability zones already hold instances of the distribution group, and az-distribution is "strict"`)
+}
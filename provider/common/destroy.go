@@ -49,6 +49,26 @@ func destroyInstances(env environs.Environ) error {
 
 func destroyStorage(env environs.Environ) error {
 	logger.Infof("destroying storage")
+	return forEachEnvironVolumeSource(env, destroyVolumes)
+}
+
+// ReleaseStorage releases the volumes of the model's dynamic,
+// model-scoped storage providers, so that they are left in the cloud,
+// rather than destroyed, when the model is destroyed. It must be called
+// before Destroy. An error satisfying errors.IsNotSupported is returned
+// if a storage provider cannot release its volumes.
+func ReleaseStorage(env environs.Environ) error {
+	logger.Infof("releasing storage")
+	return forEachEnvironVolumeSource(env, releaseVolumes)
+}
+
+// forEachEnvironVolumeSource calls f with the type and volume source of
+// each of the environ's dynamic, model-scoped storage providers that
+// support block storage.
+func forEachEnvironVolumeSource(
+	env environs.Environ,
+	f func(storage.ProviderType, storage.VolumeSource) error,
+) error {
 	storageProviderTypes, err := env.StorageProviderTypes()
 	if err != nil {
 		return errors.Trace(err)
@@ -64,45 +84,68 @@ func destroyStorage(env environs.Environ) error {
 		if storageProvider.Scope() != storage.ScopeEnviron {
 			continue
 		}
-		if err := destroyVolumes(storageProviderType, storageProvider); err != nil {
+		// TODO(axw) destroy env-level filesystems when we have them.
+		if !storageProvider.Supports(storage.StorageKindBlock) {
+			continue
+		}
+		storageConfig, err := storage.NewConfig(
+			string(storageProviderType),
+			storageProviderType,
+			map[string]interface{}{},
+		)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeSource, err := storageProvider.VolumeSource(storageConfig)
+		if err != nil {
+			return errors.Annotate(err, "getting volume source")
+		}
+		if err := f(storageProviderType, volumeSource); err != nil {
 			return errors.Trace(err)
 		}
-		// TODO(axw) destroy env-level filesystems when we have them.
 	}
 	return nil
 }
 
-func destroyVolumes(
-	storageProviderType storage.ProviderType,
-	storageProvider storage.Provider,
-) error {
-	if !storageProvider.Supports(storage.StorageKindBlock) {
-		return nil
-	}
-
-	storageConfig, err := storage.NewConfig(
-		string(storageProviderType),
-		storageProviderType,
-		map[string]interface{}{},
-	)
+func destroyVolumes(_ storage.ProviderType, volumeSource storage.VolumeSource) error {
+	volumeIds, err := volumeSource.ListVolumes()
 	if err != nil {
-		return errors.Trace(err)
+		return errors.Annotate(err, "listing volumes")
 	}
 
-	volumeSource, err := storageProvider.VolumeSource(storageConfig)
+	var errStrings []string
+	errs, err := volumeSource.DestroyVolumes(volumeIds)
 	if err != nil {
-		return errors.Annotate(err, "getting volume source")
+		return errors.Annotate(err, "destroying volumes")
+	}
+	for _, err := range errs {
+		if err != nil {
+			errStrings = append(errStrings, err.Error())
+		}
+	}
+	if len(errStrings) > 0 {
+		return errors.Errorf("destroying volumes: %s", strings.Join(errStrings, ", "))
 	}
+	return nil
+}
 
+func releaseVolumes(storageProviderType storage.ProviderType, volumeSource storage.VolumeSource) error {
 	volumeIds, err := volumeSource.ListVolumes()
 	if err != nil {
 		return errors.Annotate(err, "listing volumes")
 	}
+	if len(volumeIds) == 0 {
+		return nil
+	}
+	releaser, ok := volumeSource.(storage.VolumeReleaser)
+	if !ok {
+		return errors.NotSupportedf("releasing %q volumes", storageProviderType)
+	}
 
 	var errStrings []string
-	errs, err := volumeSource.DestroyVolumes(volumeIds)
+	errs, err := releaser.ReleaseVolumes(volumeIds)
 	if err != nil {
-		return errors.Annotate(err, "destroying volumes")
+		return errors.Annotate(err, "releasing volumes")
 	}
 	for _, err := range errs {
 		if err != nil {
@@ -110,7 +153,7 @@ func destroyVolumes(
 		}
 	}
 	if len(errStrings) > 0 {
-		return errors.Errorf("destroying volumes: %s", strings.Join(errStrings, ", "))
+		return errors.Errorf("releasing volumes: %s", strings.Join(errStrings, ", "))
 	}
 	return nil
 }
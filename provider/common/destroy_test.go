@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	jujuerrors "github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -278,3 +279,66 @@ func (s *DestroySuite) TestIgnoreNoVolumeSupport(c *gc.C) {
 	// volumes (until we have persistent filesystems, that is).
 	staticProvider.CheckCallNames(c, "Dynamic", "Scope", "Supports")
 }
+
+type releasingVolumeSource struct {
+	*dummy.VolumeSource
+	released []string
+}
+
+func (s *releasingVolumeSource) ReleaseVolumes(ids []string) ([]error, error) {
+	s.released = append(s.released, ids...)
+	return make([]error, len(ids)), nil
+}
+
+func (s *DestroySuite) releaseStorageEnviron(c *gc.C, volumeSource storage.VolumeSource) *mockEnviron {
+	storageProvider := &dummy.StorageProvider{
+		IsDynamic:    true,
+		StorageScope: storage.ScopeEnviron,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	return &mockEnviron{
+		config: configGetter(c),
+		storageProviders: storage.StaticProviderRegistry{
+			map[storage.ProviderType]storage.Provider{
+				"environ": storageProvider,
+			},
+		},
+	}
+}
+
+func (s *DestroySuite) TestReleaseStorage(c *gc.C) {
+	volumeSource := &releasingVolumeSource{
+		VolumeSource: &dummy.VolumeSource{
+			ListVolumesFunc: func() ([]string, error) {
+				return []string{"vol-0", "vol-1"}, nil
+			},
+		},
+	}
+	err := common.ReleaseStorage(s.releaseStorageEnviron(c, volumeSource))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeSource.released, jc.DeepEquals, []string{"vol-0", "vol-1"})
+	volumeSource.CheckCallNames(c, "ListVolumes")
+}
+
+func (s *DestroySuite) TestReleaseStorageNotSupported(c *gc.C) {
+	volumeSource := &dummy.VolumeSource{
+		ListVolumesFunc: func() ([]string, error) {
+			return []string{"vol-0"}, nil
+		},
+	}
+	err := common.ReleaseStorage(s.releaseStorageEnviron(c, volumeSource))
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `releasing "environ" volumes not supported`)
+}
+
+func (s *DestroySuite) TestReleaseStorageNoVolumes(c *gc.C) {
+	volumeSource := &dummy.VolumeSource{
+		ListVolumesFunc: func() ([]string, error) {
+			return nil, nil
+		},
+	}
+	err := common.ReleaseStorage(s.releaseStorageEnviron(c, volumeSource))
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+var _ environs.CloudResourceSweeper = (*environ)(nil)
+
+// CloudResources is part of the environs.CloudResourceSweeper interface.
+// Root disks are not returned, as they are destroyed along with their
+// instances, and nor are the model's shared security groups.
+func (e *environ) CloudResources() ([]environs.CloudResource, error) {
+	var resources []environs.CloudResource
+
+	insts, err := e.AllInstancesByState("pending", "running", "stopping", "stopped")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing instances")
+	}
+	for _, inst := range insts {
+		resources = append(resources, environs.CloudResource{
+			Kind: environs.CloudResourceInstance,
+			Id:   string(inst.Id()),
+		})
+	}
+
+	filter := ec2.NewFilter()
+	e.addModelFilter(filter)
+	volIds, err := listVolumes(e.ec2, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing volumes")
+	}
+	for _, volId := range volIds {
+		resources = append(resources, environs.CloudResource{
+			Kind: environs.CloudResourceVolume,
+			Id:   volId,
+		})
+	}
+
+	filter = ec2.NewFilter()
+	e.addModelFilter(filter)
+	resp, err := e.ec2.SecurityGroups(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing security groups")
+	}
+	machineGroupPrefix := e.machineGroupName("")
+	for _, group := range resp.Groups {
+		machineId := strings.TrimPrefix(group.Name, machineGroupPrefix)
+		if machineId == group.Name || !names.IsValidMachine(machineId) {
+			// Not a per-machine group.
+			continue
+		}
+		resources = append(resources, environs.CloudResource{
+			Kind:      environs.CloudResourceSecurityGroup,
+			Id:        group.Id,
+			MachineId: machineId,
+		})
+	}
+	return resources, nil
+}
+
+// DestroyCloudResources is part of the environs.CloudResourceSweeper
+// interface.
+func (e *environ) DestroyCloudResources(resources []environs.CloudResource) error {
+	var instIds []instance.Id
+	var volIds []string
+	var groups []ec2.SecurityGroup
+	for _, r := range resources {
+		switch r.Kind {
+		case environs.CloudResourceInstance:
+			instIds = append(instIds, instance.Id(r.Id))
+		case environs.CloudResourceVolume:
+			volIds = append(volIds, r.Id)
+		case environs.CloudResourceSecurityGroup:
+			groups = append(groups, ec2.SecurityGroup{Id: r.Id})
+		default:
+			return errors.NotSupportedf("destroying %s resources", r.Kind)
+		}
+	}
+
+	if err := e.terminateInstances(instIds); err != nil {
+		return errors.Annotate(err, "terminating instances")
+	}
+	for i, err := range destroyVolumes(e.ec2, volIds) {
+		if err != nil {
+			return errors.Annotatef(err, "destroying volume %q", volIds[i])
+		}
+	}
+	for _, group := range groups {
+		// Groups still in use by an instance that is shutting down
+		// cannot be deleted yet; they will be tried again on the
+		// next sweep, so we do not retry here.
+		if _, err := e.ec2.DeleteSecurityGroup(group); err != nil && !isNotFoundError(err) {
+			return errors.Annotatef(err, "deleting security group %q", group.Id)
+		}
+	}
+	return nil
+}
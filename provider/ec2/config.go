@@ -26,6 +26,12 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	"application-security-groups": {
+		Description: "Open the ports of machines that host units of a single application in a security group shared by that application, rather than in a group per machine. Only used with firewall-mode instance.",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+		Immutable:   true,
+	},
 }
 
 var configFields = func() schema.Fields {
@@ -37,8 +43,9 @@ var configFields = func() schema.Fields {
 }()
 
 var configDefaults = schema.Defaults{
-	"vpc-id":       "",
-	"vpc-id-force": false,
+	"vpc-id":                      "",
+	"vpc-id-force":                false,
+	"application-security-groups": false,
 }
 
 type environConfig struct {
@@ -54,6 +61,10 @@ func (c *environConfig) forceVPCID() bool {
 	return c.attrs["vpc-id-force"].(bool)
 }
 
+func (c *environConfig) applicationSecurityGroups() bool {
+	return c.attrs["application-security-groups"].(bool)
+}
+
 func (p environProvider) newConfig(cfg *config.Config) (*environConfig, error) {
 	valid, err := p.Validate(cfg, nil)
 	if err != nil {
@@ -99,6 +110,9 @@ func validateConfig(cfg, old *config.Config) (*environConfig, error) {
 	} else if !isVPCIDSet(vpcID) && ecfg.forceVPCID() {
 		return nil, fmt.Errorf("cannot use vpc-id-force without specifying vpc-id as well")
 	}
+	if ecfg.applicationSecurityGroups() && ecfg.FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("cannot use application-security-groups with firewall-mode %q", ecfg.FirewallMode())
+	}
 
 	if old != nil {
 		attrs := old.UnknownAttrs()
@@ -110,6 +124,10 @@ func validateConfig(cfg, old *config.Config) (*environConfig, error) {
 		if forceVPCID, _ := attrs["vpc-id-force"].(bool); forceVPCID != ecfg.forceVPCID() {
 			return nil, fmt.Errorf("cannot change vpc-id-force from %v to %v", forceVPCID, ecfg.forceVPCID())
 		}
+
+		if appGroups, _ := attrs["application-security-groups"].(bool); appGroups != ecfg.applicationSecurityGroups() {
+			return nil, fmt.Errorf("cannot change application-security-groups from %v to %v", appGroups, ecfg.applicationSecurityGroups())
+		}
 	}
 
 	// ssl-hostname-verification cannot be disabled
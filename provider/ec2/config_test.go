@@ -269,6 +269,27 @@ var configTests = []configTest{
 			"firewall-mode": "none",
 		},
 		firewallMode: config.FwNone,
+	}, {
+		config: attrs{
+			"application-security-groups": true,
+		},
+		expect: attrs{
+			"application-security-groups": true,
+		},
+	}, {
+		config: attrs{
+			"firewall-mode":               "global",
+			"application-security-groups": true,
+		},
+		err: `.*cannot use application-security-groups with firewall-mode "global"`,
+	}, {
+		config: attrs{
+			"application-security-groups": true,
+		},
+		change: attrs{
+			"application-security-groups": false,
+		},
+		err: ".*cannot change application-security-groups from true to false",
 	}, {
 		config: attrs{
 			"ssl-hostname-verification": false,
@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"fmt"
 	"regexp"
 	"sync"
 	"time"
@@ -40,6 +41,11 @@ const (
 	// Specifies whether the volume should be encrypted.
 	EBS_Encrypted = "encrypted"
 
+	// The ID or ARN of the AWS Key Management Service key used to
+	// encrypt the volume, in place of the default EBS key. Only
+	// valid for encrypted volumes.
+	EBS_KMSKeyID = "kms-key-id"
+
 	volumeTypeMagnetic        = "magnetic"         // standard
 	volumeTypeSSD             = "ssd"              // gp2
 	volumeTypeProvisionedIops = "provisioned-iops" // io1
@@ -150,6 +156,7 @@ var ebsConfigFields = schema.Fields{
 	),
 	EBS_IOPS:      schema.ForceInt(),
 	EBS_Encrypted: schema.Bool(),
+	EBS_KMSKeyID:  schema.String(),
 }
 
 var ebsConfigChecker = schema.FieldMap(
@@ -158,6 +165,7 @@ var ebsConfigChecker = schema.FieldMap(
 		EBS_VolumeType: volumeTypeMagnetic,
 		EBS_IOPS:       schema.Omit,
 		EBS_Encrypted:  false,
+		EBS_KMSKeyID:   schema.Omit,
 	},
 )
 
@@ -165,6 +173,7 @@ type ebsConfig struct {
 	volumeType string
 	iops       int
 	encrypted  bool
+	kmsKeyID   string
 }
 
 func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
@@ -174,11 +183,13 @@ func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
 	}
 	coerced := out.(map[string]interface{})
 	iops, _ := coerced[EBS_IOPS].(int)
+	kmsKeyID, _ := coerced[EBS_KMSKeyID].(string)
 	volumeType := coerced[EBS_VolumeType].(string)
 	ebsConfig := &ebsConfig{
 		volumeType: volumeType,
 		iops:       iops,
 		encrypted:  coerced[EBS_Encrypted].(bool),
+		kmsKeyID:   kmsKeyID,
 	}
 	switch ebsConfig.volumeType {
 	case volumeTypeMagnetic:
@@ -193,6 +204,15 @@ func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
 	} else if ebsConfig.iops == 0 && ebsConfig.volumeType == volumeTypeIO1 {
 		return nil, errors.Errorf("volume type is %q, IOPS unspecified or zero", volumeTypeIO1)
 	}
+	if ebsConfig.kmsKeyID != "" {
+		if !ebsConfig.encrypted {
+			return nil, errors.Errorf("%s specified, but %s is not true", EBS_KMSKeyID, EBS_Encrypted)
+		}
+		// The pinned EC2 client can't send a KMS key ID with
+		// CreateVolume. Refuse the key rather than silently
+		// encrypt the volume with the default one.
+		return nil, errors.NotSupportedf("%s with this version of the EC2 client", EBS_KMSKeyID)
+	}
 	return ebsConfig, nil
 }
 
@@ -460,6 +480,57 @@ func destroyVolumes(client *ec2.EC2, volIds []string) []error {
 	return results
 }
 
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
+
+// SnapshotVolumes is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) SnapshotVolumes(volIds []string) ([]storage.SnapshotVolumesResult, error) {
+	results := make([]storage.SnapshotVolumesResult, len(volIds))
+	for i, volumeId := range volIds {
+		snapshotId, err := createSnapshot(v.env.ec2, volumeId, fmt.Sprintf("juju snapshot of %s", volumeId))
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %s", volumeId)
+			continue
+		}
+		// Tag the snapshot with the model UUID, so that it can be
+		// identified as belonging to the model. The snapshot outlives
+		// the model, so it is not tagged with the controller UUID,
+		// which would have it destroyed along with the controller.
+		snapshotTags := map[string]string{tags.JujuModel: v.modelUUID}
+		if err := tagResources(v.env.ec2, snapshotTags, snapshotId); err != nil {
+			logger.Warningf("cannot tag snapshot %s of volume %s: %v", snapshotId, volumeId, err)
+		}
+		results[i].SnapshotId = snapshotId
+	}
+	return results, nil
+}
+
+var _ storage.VolumeReleaser = (*ebsVolumeSource)(nil)
+
+// ReleaseVolumes is specified on the storage.VolumeReleaser interface.
+// The volumes' model and controller tags are cleared, so that they are
+// no longer listed, or destroyed, along with the model or controller.
+func (v *ebsVolumeSource) ReleaseVolumes(volIds []string) ([]error, error) {
+	releaseTags := map[string]string{
+		tags.JujuModel:      "",
+		tags.JujuController: "",
+	}
+	results := make([]error, len(volIds))
+	for i, volumeId := range volIds {
+		if err := tagResources(v.env.ec2, releaseTags, volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "releasing volume %s", volumeId)
+		}
+	}
+	return results, nil
+}
+
+var createSnapshot = func(client *ec2.EC2, volumeId, description string) (string, error) {
+	resp, err := client.CreateSnapshot(volumeId, description)
+	if err != nil {
+		return "", err
+	}
+	return resp.Snapshot.Id, nil
+}
+
 var destroyVolumeAttempt = utils.AttemptStrategy{
 	Total: 5 * time.Minute,
 	Delay: 5 * time.Second,
@@ -116,6 +116,37 @@ func (s *ebsSuite) TestValidateConfigUnknownConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil) // unknown attrs ignored
 }
 
+func (s *ebsSuite) TestValidateConfigEncryption(c *gc.C) {
+	p := s.ebsProvider(c)
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"encrypted": true},
+	}, {
+		attrs: map[string]interface{}{"encrypted": "true"},
+	}, {
+		attrs: map[string]interface{}{"encrypted": "maybe"},
+		err:   `validating EBS storage config: encrypted: expected bool, got string\("maybe"\)`,
+	}, {
+		attrs: map[string]interface{}{"kms-key-id": "alias/juju"},
+		err:   "kms-key-id specified, but encrypted is not true",
+	}, {
+		attrs: map[string]interface{}{"encrypted": true, "kms-key-id": "alias/juju"},
+		err:   "kms-key-id with this version of the EC2 client not supported",
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg, err := storage.NewConfig("foo", ec2.EBS_ProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
 func (s *ebsSuite) TestSupports(c *gc.C) {
 	p := s.ebsProvider(c)
 	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
@@ -389,6 +420,28 @@ func (s *ebsSuite) TestDestroyVolumesStillAttached(c *gc.C) {
 	c.Assert(ec2Vols.Volumes[0].Size, gc.Equals, 20)
 }
 
+func (s *ebsSuite) TestSnapshotVolumes(c *gc.C) {
+	var snapshotted []string
+	s.PatchValue(ec2.CreateSnapshot, func(_ *awsec2.EC2, volumeId, description string) (string, error) {
+		if volumeId == "vol-42" {
+			return "", errors.New("volume not found")
+		}
+		c.Check(description, gc.Equals, "juju snapshot of "+volumeId)
+		snapshotted = append(snapshotted, volumeId)
+		return "snap-" + volumeId[len("vol-"):], nil
+	})
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	results, err := vs.(storage.VolumeSnapshotter).SnapshotVolumes([]string{"vol-0", "vol-42", "vol-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Check(results[0], jc.DeepEquals, storage.SnapshotVolumesResult{SnapshotId: "snap-0"})
+	c.Check(results[1].Error, gc.ErrorMatches, "creating snapshot of volume vol-42: volume not found")
+	c.Check(results[2], jc.DeepEquals, storage.SnapshotVolumesResult{SnapshotId: "snap-1"})
+	c.Check(snapshotted, jc.DeepEquals, []string{"vol-0", "vol-1"})
+}
+
 func (s *ebsSuite) TestDescribeVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.assertCreateVolumes(c, vs, "")
@@ -429,6 +482,23 @@ func (s *ebsSuite) TestListVolumes(c *gc.C) {
 	c.Assert(volIds, jc.SameContents, []string{"vol-0"})
 }
 
+func (s *ebsSuite) TestReleaseVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	releaser, ok := vs.(storage.VolumeReleaser)
+	c.Assert(ok, jc.IsTrue)
+	errs, err := releaser.ReleaseVolumes([]string{"vol-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+
+	// The released volume is no longer listed, so it will not be
+	// destroyed along with the model.
+	volIds, err := vs.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volIds, gc.HasLen, 0)
+}
+
 func (s *ebsSuite) TestListVolumesIgnoresRootDisks(c *gc.C) {
 	s.srv.ec2srv.SetCreateRootDisks(true)
 	s.srv.ec2srv.NewInstances(1, "m1.medium", imageId, ec2test.Pending, nil)
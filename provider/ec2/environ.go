@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/retry"
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"gopkg.in/amz.v3/aws"
 	"gopkg.in/amz.v3/ec2"
 	"gopkg.in/juju/names.v2"
@@ -70,6 +72,11 @@ type environ struct {
 	defaultVPCMutex   sync.Mutex
 	defaultVPCChecked bool
 	defaultVPC        *ec2.VPC
+
+	// groupsMutex serialises the setting up of security groups, so
+	// that instances started concurrently do not race to create and
+	// authorize the same groups.
+	groupsMutex sync.Mutex
 }
 
 func (e *environ) Config() *config.Config {
@@ -159,17 +166,22 @@ func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 	validator := constraints.NewValidator()
 	validator.RegisterConflicts(
 		[]string{constraints.InstanceType},
-		[]string{constraints.Mem, constraints.Cores, constraints.CpuPower})
+		[]string{constraints.Mem, constraints.Cores, constraints.CpuPower, constraints.GPUs, constraints.GPUType})
 	validator.RegisterUnsupported(unsupportedConstraints)
 	instanceTypes, err := e.supportedInstanceTypes()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	instTypeNames := make([]string, len(instanceTypes))
+	gpuTypes := set.NewStrings()
 	for i, itype := range instanceTypes {
 		instTypeNames[i] = itype.Name
+		if itype.GPUType != "" {
+			gpuTypes.Add(itype.GPUType)
+		}
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
+	validator.RegisterVocabulary(constraints.GPUType, gpuTypes.SortedValues())
 	return validator, nil
 }
 
@@ -349,21 +361,83 @@ func resourceName(tag names.Tag, envName string) string {
 }
 
 // StartInstance is specified in the InstanceBroker interface.
-func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.StartInstanceResult, resultErr error) {
+func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	if args.ControllerUUID == "" {
 		return nil, errors.New("missing controller UUID")
 	}
-	var inst *ec2Instance
-	defer func() {
-		if resultErr == nil || inst == nil {
-			return
+	availabilityZones, err := e.startInstanceAvailabilityZones(args, nil)
+	if err != nil {
+		return nil, err
+	}
+	return e.startInstance(args, availabilityZones)
+}
+
+var _ environs.BatchInstanceBroker = (*environ)(nil)
+
+// maxConcurrentStartInstances is the maximum number of instances that
+// StartInstances will ask EC2 to run at the same time.
+const maxConcurrentStartInstances = 10
+
+// StartInstances is specified in the BatchInstanceBroker interface.
+//
+// The user data of each instance holds its machine's identity and
+// credentials, and a single RunInstances call can only start instances
+// with the same user data, so the instances are started with concurrent
+// RunInstances calls instead.
+func (e *environ) StartInstances(args []environs.StartInstanceParams) ([]environs.StartInstancesResult, error) {
+	results := make([]environs.StartInstancesResult, len(args))
+	// The instances started by the batch will not show up in the
+	// zone allocations until they are running, so the zones chosen
+	// for earlier instances in the batch are counted when choosing
+	// zones for the later ones.
+	pending := make(map[string]int)
+	availabilityZones := make([][]string, len(args))
+	for i, arg := range args {
+		if arg.ControllerUUID == "" {
+			results[i].Error = errors.New("missing controller UUID")
+			continue
 		}
-		if err := e.StopInstances(inst.Id()); err != nil {
-			logger.Errorf("error stopping failed instance: %v", err)
+		availabilityZones[i], results[i].Error = e.startInstanceAvailabilityZones(arg, pending)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentStartInstances)
+	for i := range args {
+		if results[i].Error != nil {
+			continue
 		}
-	}()
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Result, results[i].Error = e.startInstance(args[i], availabilityZones[i])
+		}(i)
+	}
+	wg.Wait()
+
+	var firstErr error
+	for _, result := range results {
+		if result.Error == nil {
+			return results, nil
+		}
+		if firstErr == nil {
+			firstErr = result.Error
+		}
+	}
+	if firstErr == nil {
+		return results, nil
+	}
+	return nil, errors.Annotate(firstErr, "cannot start any instances")
+}
 
-	var availabilityZones []string
+// startInstanceAvailabilityZones returns the availability zones in which
+// to try to start an instance with the given params, in order of
+// preference. If pending is not nil, it holds the number of instances
+// about to be started in each zone, which are counted along with the
+// zones' existing instances; the number for the most preferred zone is
+// then incremented.
+func (e *environ) startInstanceAvailabilityZones(args environs.StartInstanceParams, pending map[string]int) ([]string, error) {
 	if args.Placement != "" {
 		placement, err := e.parsePlacement(args.Placement)
 		if err != nil {
@@ -372,33 +446,75 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		if placement.availabilityZone.State != availableState {
 			return nil, errors.Errorf("availability zone %q is %s", placement.availabilityZone.Name, placement.availabilityZone.State)
 		}
-		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
+		if pending != nil {
+			pending[placement.availabilityZone.Name]++
+		}
+		return []string{placement.availabilityZone.Name}, nil
 	}
 
 	// If no availability zone is specified, then automatically spread across
 	// the known zones for optimal spread across the instance distribution
 	// group.
-	var zoneInstances []common.AvailabilityZoneInstances
-	if len(availabilityZones) == 0 {
-		var err error
-		var group []instance.Id
-		if args.DistributionGroup != nil {
-			group, err = args.DistributionGroup()
-			if err != nil {
-				return nil, err
-			}
-		}
-		zoneInstances, err = availabilityZoneAllocations(e, group)
-		if err != nil {
-			return nil, err
+	group, err := common.DistributionGroup(e.Config(), args.DistributionGroup)
+	if err != nil {
+		return nil, err
+	}
+	zoneInstances, err := availabilityZoneAllocations(e, group)
+	if err != nil {
+		return nil, err
+	}
+	if len(zoneInstances) == 0 {
+		return nil, errors.New("failed to determine availability zones")
+	}
+	zoneInstances, err = common.DistributionZones(e.Config(), group, zoneInstances)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	zones := make(byPendingPopulation, len(zoneInstances))
+	for i, z := range zoneInstances {
+		zones[i] = zonePopulation{name: z.ZoneName, population: len(z.Instances)}
+		if pending != nil {
+			zones[i].population += pending[z.ZoneName]
 		}
-		for _, z := range zoneInstances {
-			availabilityZones = append(availabilityZones, z.ZoneName)
+	}
+	// The allocations are already ordered by population, and then by
+	// name, so a stable sort keeps that order among equal zones.
+	sort.Stable(zones)
+	if pending != nil {
+		pending[zones[0].name]++
+	}
+	availabilityZones := make([]string, len(zones))
+	for i, z := range zones {
+		availabilityZones[i] = z.name
+	}
+	return availabilityZones, nil
+}
+
+// zonePopulation holds the number of instances, running or about to be
+// started, in an availability zone.
+type zonePopulation struct {
+	name       string
+	population int
+}
+
+type byPendingPopulation []zonePopulation
+
+func (b byPendingPopulation) Len() int           { return len(b) }
+func (b byPendingPopulation) Less(i, j int) bool { return b[i].population < b[j].population }
+func (b byPendingPopulation) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+// startInstance starts an instance with the given params in the first
+// of the given availability zones that is not constrained.
+func (e *environ) startInstance(args environs.StartInstanceParams, availabilityZones []string) (_ *environs.StartInstanceResult, resultErr error) {
+	var inst *ec2Instance
+	defer func() {
+		if resultErr == nil || inst == nil {
+			return
 		}
-		if len(availabilityZones) == 0 {
-			return nil, errors.New("failed to determine availability zones")
+		if err := e.StopInstances(inst.Id()); err != nil {
+			logger.Errorf("error stopping failed instance: %v", err)
 		}
-	}
+	}()
 
 	arches := args.Tools.Arches()
 
@@ -417,6 +533,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 			Arches:      arches,
 			Constraints: args.Constraints,
 			Storage:     []string{ssdStorage, ebsStorage},
+			Prices:      instances.CostPriceSource(costDivisor),
 		},
 	)
 	if err != nil {
@@ -449,7 +566,12 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	} else {
 		apiPort = args.InstanceConfig.APIInfo.Ports()[0]
 	}
-	groups, err := e.setUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPort)
+	groups, err := e.setUpGroups(
+		args.ControllerUUID,
+		args.InstanceConfig.MachineId,
+		e.groupApplication(args.InstanceConfig.Tags),
+		apiPort,
+	)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot set up groups")
 	}
@@ -985,6 +1107,30 @@ func (e *environ) AllInstancesByState(states ...string) ([]instance.Instance, er
 	return e.allInstances(filter)
 }
 
+var _ environs.CloudInstanceLister = (*environ)(nil)
+
+// CloudInstances is part of the environs.CloudInstanceLister interface.
+// Stopped instances are included, as they still hold resources.
+func (e *environ) CloudInstances() ([]environs.CloudInstance, error) {
+	insts, err := e.AllInstancesByState("pending", "running", "shutting-down", "stopping", "stopped")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]environs.CloudInstance, len(insts))
+	for i, inst := range insts {
+		ec2Inst := inst.(*ec2Instance)
+		result[i] = environs.CloudInstance{
+			Id:     inst.Id(),
+			Status: ec2Inst.State.Name,
+			Tags:   make(map[string]string),
+		}
+		for _, tag := range ec2Inst.Tags {
+			result[i].Tags[tag.Key] = tag.Value
+		}
+	}
+	return result, nil
+}
+
 // ControllerInstances is part of the environs.Environ interface.
 func (e *environ) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
 	filter := ec2.NewFilter()
@@ -1112,31 +1258,50 @@ func (e *environ) allControllerManagedVolumes(controllerUUID string) ([]string,
 }
 
 func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(ports))
+	rules := make([]network.IngressRule, len(ports))
 	for i, p := range ports {
+		rules[i] = network.NewIngressRule(p)
+	}
+	return rulesToIPPerms(rules)
+}
+
+func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
+		sourceIPs := r.SourceCIDRs
+		if r.Unrestricted() {
+			sourceIPs = []string{"0.0.0.0/0"}
+		}
 		ipPerms[i] = ec2.IPPerm{
-			Protocol:  p.Protocol,
-			FromPort:  p.FromPort,
-			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+			Protocol:  r.PortRange.Protocol,
+			FromPort:  r.PortRange.FromPort,
+			ToPort:    r.PortRange.ToPort,
+			SourceIPs: sourceIPs,
 		}
 	}
 	return ipPerms
 }
 
 func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+	// Give permissions for anyone to access the given ports.
+	return e.authorizeInGroup(name, portsToIPPerms(ports))
+}
+
+func (e *environ) openIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	return e.authorizeInGroup(name, rulesToIPPerms(rules))
+}
+
+func (e *environ) authorizeInGroup(name string, ipPerms []ec2.IPPerm) error {
+	if len(ipPerms) == 0 {
 		return nil
 	}
-	// Give permissions for anyone to access the given ports.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	ipPerms := portsToIPPerms(ports)
 	_, err = e.ec2.AuthorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
-		if len(ports) == 1 {
+		if len(ipPerms) == 1 {
 			return nil
 		}
 		// If there's more than one port and we get a duplicate error,
@@ -1158,17 +1323,25 @@ func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error
 }
 
 func (e *environ) closePortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+	// Revoke permissions for anyone to access the given ports.
+	return e.revokeInGroup(name, portsToIPPerms(ports))
+}
+
+func (e *environ) closeIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	return e.revokeInGroup(name, rulesToIPPerms(rules))
+}
+
+func (e *environ) revokeInGroup(name string, ipPerms []ec2.IPPerm) error {
+	if len(ipPerms) == 0 {
 		return nil
 	}
-	// Revoke permissions for anyone to access the given ports.
 	// Note that ec2 allows the revocation of permissions that aren't
 	// granted, so this is naturally idempotent.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2.RevokeSecurityGroup(g, portsToIPPerms(ports))
+	_, err = e.ec2.RevokeSecurityGroup(g, ipPerms)
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
@@ -1195,6 +1368,35 @@ func (e *environ) portsInGroup(name string) (ports []network.PortRange, err erro
 	return ports, nil
 }
 
+func (e *environ) ingressRulesInGroup(name string) (rules []network.IngressRule, err error) {
+	group, err := e.groupInfoByName(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range group.IPPerms {
+		portRange := network.PortRange{
+			Protocol: p.Protocol,
+			FromPort: p.FromPort,
+			ToPort:   p.ToPort,
+		}
+		var sourceCIDRs []string
+		for _, ip := range p.SourceIPs {
+			if ip == "0.0.0.0/0" {
+				// EC2 merges permissions for the same port range,
+				// so the port range may also be open to everyone.
+				rules = append(rules, network.NewIngressRule(portRange))
+				continue
+			}
+			sourceCIDRs = append(sourceCIDRs, ip)
+		}
+		if len(sourceCIDRs) > 0 {
+			rules = append(rules, network.NewIngressRule(portRange, sourceCIDRs...))
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
 func (e *environ) OpenPorts(ports []network.PortRange) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
@@ -1364,11 +1566,21 @@ func (e *environ) deleteSecurityGroupsForInstances(ids []instance.Id) {
 	// nor environment group.
 	// https://bugs.launchpad.net/juju-core/+bug/1534289
 	jujuGroup := e.jujuGroupName()
+	applicationGroupPrefix := e.applicationGroupName("")
+	seenApplicationGroups := set.NewStrings()
 
 	for _, deletable := range securityGroups {
 		if deletable.Name == jujuGroup {
 			continue
 		}
+		if strings.HasPrefix(deletable.Name, applicationGroupPrefix) {
+			// Application groups are shared, so are only deleted
+			// along with the application's last instance.
+			if seenApplicationGroups.Contains(deletable.Id) || e.groupInUse(deletable) {
+				continue
+			}
+			seenApplicationGroups.Add(deletable.Id)
+		}
 		if err := deleteSecurityGroupInsistently(e.ec2, deletable, clock.WallClock); err != nil {
 			// In ideal world, we would err out here.
 			// However:
@@ -1382,6 +1594,26 @@ func (e *environ) deleteSecurityGroupsForInstances(ids []instance.Id) {
 	}
 }
 
+// groupInUse reports whether any instance that has not been terminated
+// is in the given security group. If that cannot be determined, the
+// group is assumed to be in use.
+func (e *environ) groupInUse(group ec2.SecurityGroup) bool {
+	filter := ec2.NewFilter()
+	filter.Add("instance.group-id", group.Id)
+	filter.Add("instance-state-name", "pending", "running", "stopping", "stopped")
+	resp, err := e.ec2.Instances(nil, filter)
+	if err != nil {
+		logger.Errorf("cannot determine whether security group %q is in use: %v", group.Name, err)
+		return true
+	}
+	for _, reservation := range resp.Reservations {
+		if len(reservation.Instances) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // SecurityGroupCleaner defines provider instance methods needed to delete
 // a security group.
 type SecurityGroupCleaner interface {
@@ -1435,10 +1667,37 @@ func (e *environ) machineGroupName(machineId string) string {
 	return fmt.Sprintf("%s-%s", e.jujuGroupName(), machineId)
 }
 
+func (e *environ) applicationGroupName(application string) string {
+	return fmt.Sprintf("%s-app-%s", e.jujuGroupName(), application)
+}
+
 func (e *environ) jujuGroupName() string {
 	return "juju-" + e.uuid()
 }
 
+// groupApplication returns the application whose security group a new
+// instance with the given tags should be started in, or "" if it
+// should have a group of its own. Only instances that will host units
+// of a single application, in models with application-security-groups
+// set, share their application's group.
+func (e *environ) groupApplication(instanceTags map[string]string) string {
+	if !e.ecfg().applicationSecurityGroups() {
+		return ""
+	}
+	var application string
+	for _, unitName := range strings.Fields(instanceTags[tags.JujuUnitsDeployed]) {
+		unitApplication, err := names.UnitApplication(unitName)
+		if err != nil {
+			return ""
+		}
+		if application != "" && application != unitApplication {
+			return ""
+		}
+		application = unitApplication
+	}
+	return application
+}
+
 // setUpGroups creates the security groups for the new machine, and
 // returns them.
 //
@@ -1446,7 +1705,11 @@ func (e *environ) jujuGroupName() string {
 // other instances that might be running on the same EC2 account.  In
 // addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
-func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]ec2.SecurityGroup, error) {
+// If application is not empty, the machine instead shares a group with
+// the other machines of that application.
+func (e *environ) setUpGroups(controllerUUID, machineId, application string, apiPort int) ([]ec2.SecurityGroup, error) {
+	e.groupsMutex.Lock()
+	defer e.groupsMutex.Unlock()
 
 	// Ensure there's a global group for Juju-related traffic.
 	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(),
@@ -1481,7 +1744,11 @@ func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]
 	var machineGroup ec2.SecurityGroup
 	switch e.Config().FirewallMode() {
 	case config.FwInstance:
-		machineGroup, err = e.ensureGroup(controllerUUID, e.machineGroupName(machineId), nil)
+		groupName := e.machineGroupName(machineId)
+		if application != "" {
+			groupName = e.applicationGroupName(application)
+		}
+		machineGroup, err = e.ensureGroup(controllerUUID, groupName, nil)
 	case config.FwGlobal:
 		machineGroup, err = e.ensureGroup(controllerUUID, e.globalGroupName(), nil)
 	}
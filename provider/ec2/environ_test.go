@@ -167,3 +167,21 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 		c.Assert(ipperms, gc.DeepEquals, t.expected)
 	}
 }
+
+func (*Suite) TestRulesToIPPerms(c *gc.C) {
+	rules := []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp")),
+		network.NewIngressRule(network.MustParsePortRange("100-120/udp"), "192.168.1.0/24", "10.0.0.0/8"),
+	}
+	c.Assert(rulesToIPPerms(rules), gc.DeepEquals, []amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"0.0.0.0/0"},
+	}, {
+		Protocol:  "udp",
+		FromPort:  100,
+		ToPort:    120,
+		SourceIPs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	}})
+}
@@ -185,6 +185,34 @@ func (s *specSuite) TestFindInstanceSpecNotSetCpuPowerWhenInstanceTypeSet(c *gc.
 	c.Check(instanceConstraint.Constraints.CpuPower, gc.IsNil)
 }
 
+func (s *specSuite) TestFindInstanceSpecMaxPrice(c *gc.C) {
+	imageMetadata := filterImageMetadata(
+		c, TestImageMetadata, "xenial", []string{"amd64"},
+	)
+	findSpec := func(cons string) (*instances.InstanceSpec, error) {
+		return findInstanceSpec(
+			false, // non-controller
+			imageMetadata,
+			ec2instancetypes.RegionInstanceTypes("test"),
+			&instances.InstanceConstraint{
+				Region:      "test",
+				Series:      "xenial",
+				Arches:      []string{"amd64"},
+				Constraints: constraints.MustParse(cons),
+				Storage:     []string{ssdStorage, ebsStorage},
+				Prices:      instances.CostPriceSource(costDivisor),
+			})
+	}
+
+	spec, err := findSpec("cores=4 max-price=0.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.InstanceType.CpuCores >= 4, jc.IsTrue)
+	c.Check(spec.InstanceType.Cost <= 300, jc.IsTrue)
+
+	_, err = findSpec("cores=4 max-price=0.01")
+	c.Assert(err, gc.ErrorMatches, `no instance types in test matching constraints ".*" within max-price=0.01`)
+}
+
 var findInstanceSpecErrorTests = []struct {
 	series string
 	arches []string
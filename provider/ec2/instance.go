@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"fmt"
+	"strings"
 
 	"gopkg.in/amz.v3/ec2"
 
@@ -25,6 +26,7 @@ func (inst *ec2Instance) String() string {
 }
 
 var _ instance.Instance = (*ec2Instance)(nil)
+var _ instance.InstanceIngressRules = (*ec2Instance)(nil)
 
 func (inst *ec2Instance) Id() instance.Id {
 	return instance.Id(inst.InstanceId)
@@ -74,12 +76,25 @@ func (inst *ec2Instance) Addresses() ([]network.Address, error) {
 	return addresses, nil
 }
 
+// groupName returns the name of the security group whose rules are
+// managed for the instance: the group of its application, if it was
+// started in one, and otherwise the group of its machine.
+func (inst *ec2Instance) groupName(machineId string) string {
+	prefix := inst.e.applicationGroupName("")
+	for _, group := range inst.SecurityGroups {
+		if strings.HasPrefix(group.Name, prefix) {
+			return group.Name
+		}
+	}
+	return inst.e.machineGroupName(machineId)
+}
+
 func (inst *ec2Instance) OpenPorts(machineId string, ports []network.PortRange) error {
 	if inst.e.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			inst.e.Config().FirewallMode())
 	}
-	name := inst.e.machineGroupName(machineId)
+	name := inst.groupName(machineId)
 	if err := inst.e.openPortsInGroup(name, ports); err != nil {
 		return err
 	}
@@ -92,7 +107,7 @@ func (inst *ec2Instance) ClosePorts(machineId string, ports []network.PortRange)
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			inst.e.Config().FirewallMode())
 	}
-	name := inst.e.machineGroupName(machineId)
+	name := inst.groupName(machineId)
 	if err := inst.e.closePortsInGroup(name, ports); err != nil {
 		return err
 	}
@@ -105,10 +120,48 @@ func (inst *ec2Instance) Ports(machineId string) ([]network.PortRange, error) {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			inst.e.Config().FirewallMode())
 	}
-	name := inst.e.machineGroupName(machineId)
+	name := inst.groupName(machineId)
 	ranges, err := inst.e.portsInGroup(name)
 	if err != nil {
 		return nil, err
 	}
 	return ranges, nil
 }
+
+// OpenIngressRules implements instance.InstanceIngressRules.
+func (inst *ec2Instance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.groupName(machineId)
+	if err := inst.e.openIngressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// CloseIngressRules implements instance.InstanceIngressRules.
+func (inst *ec2Instance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.groupName(machineId)
+	if err := inst.e.closeIngressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// IngressRules implements instance.InstanceIngressRules.
+func (inst *ec2Instance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.groupName(machineId)
+	return inst.e.ingressRulesInGroup(name)
+}
@@ -13,6 +13,10 @@ import (
 
 var _ environs.InstanceTypesFetcher = (*environ)(nil)
 
+// costDivisor is the number the Cost of EC2 instance types must be
+// divided by to give their price in US dollars per hour.
+const costDivisor = 1000
+
 // InstanceTypes implements InstanceTypesFetcher
 func (e *environ) InstanceTypes(c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
 	iTypes, err := e.supportedInstanceTypes()
@@ -26,6 +30,6 @@ func (e *environ) InstanceTypes(c constraints.Value) (instances.InstanceTypesWit
 	return instances.InstanceTypesWithCostMetadata{
 		InstanceTypes: iTypes,
 		CostUnit:      "$USD/hour",
-		CostDivisor:   1000,
+		CostDivisor:   costDivisor,
 		CostCurrency:  "USD"}, nil
 }
@@ -24,6 +24,35 @@ func RegionInstanceTypes(region string) []instances.InstanceType {
 	return instanceTypes
 }
 
+// gpuInstanceTypes holds the number and type of the GPUs attached to
+// each GPU instance type. The pricing data we generate the instance
+// types from does not describe GPUs, so they are recorded here.
+//
+// See:
+//     https://aws.amazon.com/ec2/instance-types/#accelerated-computing
+var gpuInstanceTypes = map[string]struct {
+	count   uint64
+	gpuType string
+}{
+	"cg1.4xlarge": {2, "nvidia-tesla-m2050"},
+	"g2.2xlarge":  {1, "nvidia-grid-k520"},
+	"g2.8xlarge":  {4, "nvidia-grid-k520"},
+	"p2.xlarge":   {1, "nvidia-tesla-k80"},
+	"p2.8xlarge":  {8, "nvidia-tesla-k80"},
+	"p2.16xlarge": {16, "nvidia-tesla-k80"},
+}
+
+func init() {
+	for _, instanceTypes := range allInstanceTypes {
+		for i, instanceType := range instanceTypes {
+			if gpu, ok := gpuInstanceTypes[instanceType.Name]; ok {
+				instanceTypes[i].GPUs = gpu.count
+				instanceTypes[i].GPUType = gpu.gpuType
+			}
+		}
+	}
+}
+
 // SupportsClassic reports whether the instance type with the given
 // name can be run in EC2-Classic.
 //
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/provider/ec2/internal/ec2instancetypes"
 )
 
@@ -82,6 +83,20 @@ func (s *InstanceTypesSuite) TestRegionInstanceTypesUnknownRegion(c *gc.C) {
 	c.Assert(instanceTypes, jc.DeepEquals, ec2instancetypes.RegionInstanceTypes("us-east-1"))
 }
 
+func (s *InstanceTypesSuite) TestRegionInstanceTypesGPUs(c *gc.C) {
+	gpus := make(map[string]instances.InstanceType)
+	for _, instanceType := range ec2instancetypes.RegionInstanceTypes("us-east-1") {
+		if instanceType.GPUs > 0 {
+			gpus[instanceType.Name] = instanceType
+		}
+	}
+	c.Assert(gpus, gc.HasLen, 6)
+	c.Assert(gpus["g2.8xlarge"].GPUs, gc.Equals, uint64(4))
+	c.Assert(gpus["g2.8xlarge"].GPUType, gc.Equals, "nvidia-grid-k520")
+	c.Assert(gpus["p2.16xlarge"].GPUs, gc.Equals, uint64(16))
+	c.Assert(gpus["p2.16xlarge"].GPUType, gc.Equals, "nvidia-tesla-k80")
+}
+
 func (s *InstanceTypesSuite) TestSupportsClassic(c *gc.C) {
 	assertSupportsClassic := func(name string) {
 		c.Assert(ec2instancetypes.SupportsClassic(name), jc.IsTrue)
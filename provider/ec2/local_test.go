@@ -702,6 +702,49 @@ func (t *localServerSuite) TestDestroyControllerDestroysHostedModelResources(c *
 	assertGroups("default")
 }
 
+func (t *localServerSuite) TestCloudResources(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	t.srv.ec2srv.SetInitialInstanceState(ec2test.Running)
+	inst1, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+
+	sweeper := env.(environs.CloudResourceSweeper)
+	resources, err := sweeper.CloudResources()
+	c.Assert(err, jc.ErrorIsNil)
+	var instIds []string
+	var machine1Group environs.CloudResource
+	groupMachineIds := make([]string, 0, 2)
+	for _, r := range resources {
+		switch r.Kind {
+		case environs.CloudResourceInstance:
+			instIds = append(instIds, r.Id)
+		case environs.CloudResourceSecurityGroup:
+			groupMachineIds = append(groupMachineIds, r.MachineId)
+			if r.MachineId == "1" {
+				machine1Group = r
+			}
+		default:
+			c.Fatalf("unexpected resource %+v", r)
+		}
+	}
+	insts, err := env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Assert(instIds, jc.SameContents, []string{string(insts[0].Id()), string(insts[1].Id())})
+	c.Assert(groupMachineIds, jc.SameContents, []string{"0", "1"})
+
+	err = sweeper.DestroyCloudResources([]environs.CloudResource{
+		{Kind: environs.CloudResourceInstance, Id: string(inst1.Id())},
+		machine1Group,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	resources, err = sweeper.CloudResources()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, r := range resources {
+		c.Check(r.Id, gc.Not(gc.Equals), string(inst1.Id()))
+		c.Check(r.MachineId, gc.Not(gc.Equals), "1")
+	}
+}
+
 // splitAuthKeys splits the given authorized keys
 // into the form expected to be found in the
 // user data.
@@ -738,6 +781,83 @@ func (t *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	c.Check(*hc.CpuCores, gc.Equals, uint64(1))
 }
 
+func (t *localServerSuite) TestStartInstances(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	args := make([]environs.StartInstanceParams, 3)
+	for i := range args {
+		args[i].ControllerUUID = t.ControllerUUID
+		err := testing.FillInStartInstanceParams(env, fmt.Sprint(i+1), false, &args[i])
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	args[2].Placement = "zone=test-impaired"
+
+	results, err := env.(environs.BatchInstanceBroker).StartInstances(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	ids := set.NewStrings()
+	for _, result := range results[:2] {
+		c.Assert(result.Error, jc.ErrorIsNil)
+		c.Check(ec2.InstanceEC2(result.Result.Instance).AvailZone, gc.Equals, "test-available")
+		ids.Add(string(result.Result.Instance.Id()))
+	}
+	c.Check(ids.Size(), gc.Equals, 2)
+	c.Check(results[2].Result, gc.IsNil)
+	c.Check(results[2].Error, gc.ErrorMatches, `availability zone "test-impaired" is impaired`)
+}
+
+func (t *localServerSuite) TestStartInstancesAllFail(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	args := []environs.StartInstanceParams{{
+		ControllerUUID: t.ControllerUUID,
+		Placement:      "zone=test-impaired",
+	}}
+	err := testing.FillInStartInstanceParams(env, "1", false, &args[0])
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := env.(environs.BatchInstanceBroker).StartInstances(args)
+	c.Assert(err, gc.ErrorMatches, `cannot start any instances: availability zone "test-impaired" is impaired`)
+	c.Assert(results, gc.IsNil)
+}
+
+func (t *localServerSuite) TestStartInstanceApplicationSecurityGroup(c *gc.C) {
+	params := t.PrepareParams(c)
+	params.ModelConfig["application-security-groups"] = true
+	env := t.PrepareWithParams(c, params)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+		AdminSecret:      testing.AdminSecret,
+		CAPrivateKey:     coretesting.CAKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := environs.StartInstanceParams{ControllerUUID: t.ControllerUUID}
+	err = testing.FillInStartInstanceParams(env, "1", false, &args)
+	c.Assert(err, jc.ErrorIsNil)
+	args.InstanceConfig.Tags[tags.JujuUnitsDeployed] = "wordpress/0 wordpress/1"
+	result, err := env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	groupName := "juju-" + env.Config().UUID() + "-app-wordpress"
+	var groupNames []string
+	for _, group := range ec2.InstanceEC2(result.Instance).SecurityGroups {
+		groupNames = append(groupNames, group.Name)
+	}
+	c.Assert(groupNames, jc.SameContents, []string{
+		"juju-" + env.Config().UUID(),
+		groupName,
+	})
+
+	// Ports are managed in the application's group.
+	ports := []network.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}}
+	err = result.Instance.OpenPorts("1", ports)
+	c.Assert(err, jc.ErrorIsNil)
+	groupsResp, err := t.client.SecurityGroups([]amzec2.SecurityGroup{{Name: groupName}}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groupsResp.Groups, gc.HasLen, 1)
+	c.Assert(groupsResp.Groups[0].IPPerms, gc.HasLen, 1)
+	c.Assert(groupsResp.Groups[0].IPPerms[0].FromPort, gc.Equals, 80)
+}
+
 func (t *localServerSuite) TestStartInstanceAvailZone(c *gc.C) {
 	inst, err := t.testStartInstanceAvailZone(c, "test-available")
 	c.Assert(err, jc.ErrorIsNil)
@@ -883,6 +1003,59 @@ func (t *localServerSuite) TestStartInstanceDistributionErrors(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.Equals, dgErr)
 }
 
+func (t *localServerSuite) setAZDistribution(c *gc.C, env environs.Environ, policy string) {
+	cfg, err := env.Config().Apply(map[string]interface{}{"az-distribution": policy})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (t *localServerSuite) TestStartInstanceDistributionNone(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	t.setAZDistribution(c, env, "none")
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{{ZoneName: "az1"}},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		DistributionGroup: func() ([]instance.Id, error) {
+			return []instance.Id{"i-0", "i-1"}, nil
+		},
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.group, gc.HasLen, 0)
+}
+
+func (t *localServerSuite) TestStartInstanceDistributionStrict(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	t.setAZDistribution(c, env, "strict")
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{
+			{ZoneName: "az1", Instances: []instance.Id{"i-0"}},
+			{ZoneName: "az2", Instances: []instance.Id{"i-1"}},
+		},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
+		c.Fatalf("unexpected RunInstances in zone %q", ri.AvailZone)
+		return nil, nil
+	})
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		DistributionGroup: func() ([]instance.Id, error) {
+			return []instance.Id{"i-0", "i-1"}, nil
+		},
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `.*all 2 availability zones already hold instances of the distribution group, and az-distribution is "strict"`)
+}
+
 func (t *localServerSuite) TestStartInstanceDistribution(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 
@@ -1158,6 +1331,18 @@ func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-type=foo\nvalid values are:.*")
 }
 
+func (t *localServerSuite) TestConstraintsValidatorGPUs(c *gc.C) {
+	env := t.Prepare(c)
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("gpus=1 gpu-type=nvidia-tesla-k80"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("gpu-type=foo"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: gpu-type=foo\nvalid values are:.*")
+	_, err = validator.Validate(constraints.MustParse("instance-type=m1.small gpus=1"))
+	c.Assert(err, gc.ErrorMatches, `ambiguous constraints: "gpus" overlaps with "instance-type"`)
+}
+
 func (t *localServerSuite) TestConstraintsValidatorVocabNoDefaultOrSpecifiedVPC(c *gc.C) {
 	t.srv.defaultVPC.IsDefault = false
 	err := t.srv.ec2srv.UpdateVPC(*t.srv.defaultVPC)
@@ -1462,6 +1647,27 @@ func (t *localServerSuite) TestInstanceTags(c *gc.C) {
 	})
 }
 
+func (t *localServerSuite) TestCloudInstances(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	instances, err := env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+
+	cloudInstances, err := env.(environs.CloudInstanceLister).CloudInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cloudInstances, jc.DeepEquals, []environs.CloudInstance{{
+		Id:     instances[0].Id(),
+		Status: "running",
+		Tags: map[string]string{
+			"Name":                 "juju-sample-machine-0",
+			"juju-model-uuid":      coretesting.ModelTag.Id(),
+			"juju-controller-uuid": t.ControllerUUID,
+			"juju-is-controller":   "true",
+		},
+	}})
+}
+
 func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 
@@ -99,18 +99,18 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 	// If no availability zone is specified, then automatically spread across
 	// the known zones for optimal spread across the instance distribution
 	// group.
-	var group []instance.Id
-	var err error
-	if args.DistributionGroup != nil {
-		group, err = args.DistributionGroup()
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
+	group, err := common.DistributionGroup(env.Config(), args.DistributionGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 	zoneInstances, err := availabilityZoneAllocations(env, group)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	zoneInstances, err = common.DistributionZones(env.Config(), group, zoneInstances)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	logger.Infof("found %d zones: %v", len(zoneInstances), zoneInstances)
 
 	var zoneNames []string
@@ -28,6 +28,11 @@ func (env *environ) PrecheckInstance(series string, cons constraints.Value, plac
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.MaxPrice,
+	// The version of the compute API we use predates GPU
+	// accelerators, so we cannot attach them to instances.
+	constraints.GPUs,
+	constraints.GPUType,
 }
 
 // instanceTypeConstraints defines the fields defined on each of the
@@ -54,6 +54,9 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.GPUs,
+	constraints.GPUType,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -6,6 +6,8 @@
 package lxd
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/environs"
@@ -116,10 +118,20 @@ func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, e
 
 type instPlacement struct{}
 
+// nodePlacementPrefix prefixes placement directives that name the
+// member of an LXD cluster to start an instance on.
+const nodePlacementPrefix = "node="
+
 func (env *environ) parsePlacement(placement string) (*instPlacement, error) {
 	if placement == "" {
 		return &instPlacement{}, nil
 	}
 
+	if strings.HasPrefix(placement, nodePlacementPrefix) {
+		// The LXD client we use predates LXD clustering, and
+		// cannot target the members of a cluster.
+		node := strings.TrimPrefix(placement, nodePlacementPrefix)
+		return nil, errors.NotSupportedf("placing instances on LXD cluster member %q", node)
+	}
 	return nil, errors.Errorf("unknown placement directive: %v", placement)
 }
@@ -33,6 +33,9 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.GPUs,
+	constraints.GPUType,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
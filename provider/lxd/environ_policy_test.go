@@ -8,6 +8,7 @@ package lxd_test
 import (
 	"strings"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/series"
@@ -40,6 +41,15 @@ func (s *environPolSuite) TestPrecheckInstanceAPI(c *gc.C) {
 	s.CheckNoAPI(c)
 }
 
+func (s *environPolSuite) TestPrecheckInstanceNodePlacement(c *gc.C) {
+	cons := constraints.Value{}
+	placement := "node=host3"
+	err := s.Env.PrecheckInstance(series.LatestLts(), cons, placement)
+
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(err, gc.ErrorMatches, `placing instances on LXD cluster member "host3" not supported`)
+}
+
 func (s *environPolSuite) TestPrecheckInstanceHasInstanceType(c *gc.C) {
 	cons := constraints.MustParse("instance-type=some-instance-type")
 	placement := ""
@@ -897,13 +897,9 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 	// the known zones for optimal spread across the instance distribution
 	// group.
 	if args.Placement == "" {
-		var group []instance.Id
-		var err error
-		if args.DistributionGroup != nil {
-			group, err = args.DistributionGroup()
-			if err != nil {
-				return nil, errors.Annotate(err, "cannot get distribution group")
-			}
+		group, err := common.DistributionGroup(environ.Config(), args.DistributionGroup)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get distribution group")
 		}
 		zoneInstances, err := availabilityZoneAllocations(environ, group)
 		// TODO (mfoord): this branch is for old versions of MAAS and
@@ -914,6 +910,10 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 		} else if err != nil {
 			return nil, errors.Annotate(err, "cannot get availability zone allocations")
 		} else if len(zoneInstances) > 0 {
+			zoneInstances, err = common.DistributionZones(environ.Config(), group, zoneInstances)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
 			for _, z := range zoneInstances {
 				availabilityZones = append(availabilityZones, z.ZoneName)
 			}
@@ -1327,6 +1327,9 @@ func (environ *maasEnviron) selectNode2(args selectNodeArgs) (maasInstance, erro
 				continue
 			}
 		}
+		if gomaasapi.IsNoMatchError(err) && len(args.Volumes) > 0 {
+			return nil, errors.Annotatef(err, "cannot run instance with storage %q", describeVolumes(args.Volumes))
+		}
 		if err != nil {
 			return nil, errors.Annotatef(err, "cannot run instance")
 		}
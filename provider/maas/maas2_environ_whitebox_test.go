@@ -505,6 +505,18 @@ func (suite *maas2EnvironSuite) TestAcquireNodeStorage(c *gc.C) {
 	}
 }
 
+func (suite *maas2EnvironSuite) TestSelectNodeNoMatchingStorage(c *gc.C) {
+	suite.injectController(&fakeController{
+		allocateMachineError: gomaasapi.NewNoMatchError("no match"),
+	})
+	env := suite.makeEnviron(c, nil)
+	_, err := env.selectNode2(selectNodeArgs{
+		AvailabilityZones: []string{""},
+		Volumes:           []volumeInfo{{"root", 0, nil}, {"1", 20, []string{"ssd"}}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot run instance with storage "root:0,1:20\(ssd\)": .*no match`)
+}
+
 func (suite *maas2EnvironSuite) TestAcquireNodeInterfaces(c *gc.C) {
 	var env *maasEnviron
 	var getNegatives func() []string
@@ -4,6 +4,7 @@
 package maas
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"unicode"
@@ -75,8 +76,14 @@ func newStorageConfig(attrs map[string]interface{}) (*storageConfig, error) {
 	coerced := out.(map[string]interface{})
 	var tags []string
 	switch v := coerced[tagsAttribute].(type) {
-	case []string:
-		tags = v
+	case []interface{}:
+		for _, tag := range v {
+			tag := tag.(string)
+			if err := validateDiskTag(tag); err != nil {
+				return nil, errors.Trace(err)
+			}
+			tags = append(tags, tag)
+		}
 	case string:
 		fields := strings.Split(v, ",")
 		for _, f := range fields {
@@ -84,8 +91,8 @@ func newStorageConfig(attrs map[string]interface{}) (*storageConfig, error) {
 			if len(f) == 0 {
 				continue
 			}
-			if i := strings.IndexFunc(f, unicode.IsSpace); i >= 0 {
-				return nil, errors.Errorf("tags may not contain whitespace: %q", f)
+			if err := validateDiskTag(f); err != nil {
+				return nil, errors.Trace(err)
 			}
 			tags = append(tags, f)
 		}
@@ -93,6 +100,25 @@ func newStorageConfig(attrs map[string]interface{}) (*storageConfig, error) {
 	return &storageConfig{tags: tags}, nil
 }
 
+// validateDiskTag returns an error if the given MAAS disk tag cannot be
+// passed to MAAS in a storage constraint, which separates volumes with
+// commas and lists their tags in parentheses.
+func validateDiskTag(tag string) error {
+	if tag == "" {
+		return errors.New("tags may not be empty")
+	}
+	if i := strings.IndexFunc(tag, unicode.IsSpace); i >= 0 {
+		return errors.Errorf("tags may not contain whitespace: %q", tag)
+	}
+	if strings.ContainsAny(tag, ",:()") {
+		return errors.Errorf("tags may not contain any of %q: %q", ",:()", tag)
+	}
+	if strings.HasPrefix(tag, "^") {
+		return errors.Errorf("MAAS does not support excluding disk tags: %q", tag)
+	}
+	return nil
+}
+
 // ValidateConfig is defined on the Provider interface.
 func (maasStorageProvider) ValidateConfig(cfg *storage.Config) error {
 	_, err := newStorageConfig(cfg.Attrs())
@@ -136,6 +162,30 @@ type volumeInfo struct {
 	tags     []string
 }
 
+// String returns the volume in the form MAAS accepts in storage
+// constraints: [volume-name:]sizeinGB[(tag,...)].
+func (v volumeInfo) String() string {
+	var s string
+	if v.name != "" {
+		s = v.name + ":"
+	}
+	s += fmt.Sprintf("%d", v.sizeInGB)
+	if len(v.tags) > 0 {
+		s += fmt.Sprintf("(%s)", strings.Join(v.tags, ","))
+	}
+	return s
+}
+
+// describeVolumes returns the given volumes as a MAAS storage
+// constraint.
+func describeVolumes(volumes []volumeInfo) string {
+	strs := make([]string, len(volumes))
+	for i, v := range volumes {
+		strs[i] = v.String()
+	}
+	return strings.Join(strs, ",")
+}
+
 // mibToGB converts the value in MiB to GB.
 // Juju works in MiB, MAAS expects GB.
 func mibToGb(m uint64) uint64 {
@@ -74,6 +74,27 @@ func (s *volumeSuite) TestBuildMAASVolumeParametersWithTags(c *gc.C) {
 	})
 }
 
+func (s *volumeSuite) TestBuildMAASVolumeParametersWithTagsList(c *gc.C) {
+	vInfo, err := buildMAASVolumeParameters([]storage.VolumeParams{
+		{Tag: names.NewVolumeTag("1"), Size: 2000000, Attributes: map[string]interface{}{
+			"tags": []interface{}{"tag1", "tag2"},
+		}},
+	}, constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vInfo, jc.DeepEquals, []volumeInfo{
+		{"root", 0, nil}, //root disk
+		{"1", 1954, []string{"tag1", "tag2"}},
+	})
+}
+
+func (s *volumeSuite) TestDescribeVolumes(c *gc.C) {
+	c.Assert(describeVolumes([]volumeInfo{
+		{"root", 0, []string{"ssd"}},
+		{"1", 20, []string{"magnetic", "5400rpm"}},
+		{"", 45, nil},
+	}), gc.Equals, "root:0(ssd),1:20(magnetic,5400rpm),45")
+}
+
 func (s *volumeSuite) TestInstanceVolumesMAAS2(c *gc.C) {
 	instance := maas2Instance{
 		machine: &fakeMachine{},
@@ -324,16 +345,41 @@ func (*storageProviderSuite) TestValidateConfigTags(c *gc.C) {
 	validate(" leading, spaces")
 	validate("trailing ,spaces ")
 	validate(" and,everything, in ,  between ")
+	validate([]interface{}{"a", "list"})
 }
 
 func (*storageProviderSuite) TestValidateConfigInvalidConfig(c *gc.C) {
 	p := maasStorageProvider{}
-	cfg, err := storage.NewConfig("foo", maasStorageProviderType, map[string]interface{}{
-		"tags": "white space",
-	})
-	c.Assert(err, jc.ErrorIsNil)
-	err = p.ValidateConfig(cfg)
-	c.Assert(err, gc.ErrorMatches, `tags may not contain whitespace: "white space"`)
+	for _, test := range []struct {
+		tags interface{}
+		err  string
+	}{{
+		tags: "white space",
+		err:  `tags may not contain whitespace: "white space"`,
+	}, {
+		tags: []interface{}{"white space"},
+		err:  `tags may not contain whitespace: "white space"`,
+	}, {
+		tags: []interface{}{"ssd", ""},
+		err:  `tags may not be empty`,
+	}, {
+		tags: []interface{}{"a,b"},
+		err:  `tags may not contain any of ",:\(\)": "a,b"`,
+	}, {
+		tags: "fast(ish)",
+		err:  `tags may not contain any of ",:\(\)": "fast\(ish\)"`,
+	}, {
+		tags: "^rotary",
+		err:  `MAAS does not support excluding disk tags: "\^rotary"`,
+	}} {
+		c.Logf("tags: %#v", test.tags)
+		cfg, err := storage.NewConfig("foo", maasStorageProviderType, map[string]interface{}{
+			"tags": test.tags,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
 }
 
 func (*storageProviderSuite) TestValidateConfigUnknownAttribute(c *gc.C) {
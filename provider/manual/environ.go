@@ -321,6 +321,9 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.GPUs,
+	constraints.GPUType,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
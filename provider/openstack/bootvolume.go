@@ -0,0 +1,133 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/goose.v1/nova"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/provider/common"
+)
+
+// volumeTypeMicroversion is the compute API microversion that first
+// accepts a volume type in a block device mapping.
+const volumeTypeMicroversion = "2.67"
+
+// bootVolume describes the Cinder volume that an instance boots from
+// when boot-from-volume is set.
+type bootVolume struct {
+	// size is the size of the volume, in GiB.
+	size uint64
+
+	// volumeType is the Cinder volume type of the volume, or empty
+	// for the cloud's default volume type.
+	volumeType string
+}
+
+// newBootVolume returns the boot volume for an instance of the given
+// series. The volume is sized by the root-disk constraint, if there is
+// one, and otherwise is the minimum root disk size for the series.
+func newBootVolume(series string, cons constraints.Value, volumeType string) bootVolume {
+	size := common.MinRootDiskSizeGiB(series)
+	if cons.RootDisk != nil && *cons.RootDisk > 0 {
+		// Cinder sizes volumes in whole GiB, so round up.
+		size = (*cons.RootDisk + 1023) / 1024
+	}
+	return bootVolume{size: size, volumeType: volumeType}
+}
+
+// blockDeviceMapping is an entry of the block_device_mapping_v2 list
+// in a Nova create server request.
+type blockDeviceMapping struct {
+	BootIndex           int    `json:"boot_index"`
+	UUID                string `json:"uuid"`
+	SourceType          string `json:"source_type"`
+	DestinationType     string `json:"destination_type"`
+	VolumeSize          uint64 `json:"volume_size"`
+	VolumeType          string `json:"volume_type,omitempty"`
+	DeleteOnTermination bool   `json:"delete_on_termination"`
+}
+
+// bootVolumeServerClient creates Nova servers that boot from a new
+// Cinder volume. The goose nova client cannot pass block device
+// mappings, so the requests are made directly.
+type bootVolumeServerClient struct {
+	endpoint *url.URL
+	token    func() string
+}
+
+// bootVolumeServers returns a client that creates servers booting from
+// volumes, using the environ's compute endpoint.
+func (e *Environ) bootVolumeServers() (*bootVolumeServerClient, error) {
+	client := e.client()
+	if !client.IsAuthenticated() {
+		if err := authenticateClient(client); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	endpoint, ok := client.EndpointsForRegion(e.cloud.Region)["compute"]
+	if !ok {
+		return nil, errors.NotFoundf(`endpoint "compute" in region %q`, e.cloud.Region)
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &bootVolumeServerClient{endpointURL, client.Token}, nil
+}
+
+// RunServer starts a server as nova.Client.RunServer does, but booting
+// from the given volume, which is created from the server's image and
+// deleted along with the server.
+func (c *bootVolumeServerClient) RunServer(opts nova.RunServerOpts, volume bootVolume) (*nova.Entity, error) {
+	server, err := bootVolumeServerRequest(opts, volume)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	header := make(http.Header)
+	if volume.volumeType != "" {
+		header.Set("X-OpenStack-Nova-API-Version", volumeTypeMicroversion)
+	}
+	serversURL := *c.endpoint
+	serversURL.Path = strings.TrimSuffix(serversURL.Path, "/") + "/servers"
+	var resp struct {
+		Server struct {
+			Id string `json:"id"`
+		} `json:"server"`
+	}
+	req := map[string]interface{}{"server": server}
+	if err := postJSON(serversURL.String(), c.token(), header, req, http.StatusAccepted, &resp); err != nil {
+		return nil, errors.Annotate(err, "failed to run a server booting from a volume")
+	}
+	return &nova.Entity{Id: resp.Server.Id}, nil
+}
+
+// bootVolumeServerRequest returns the server part of a create server
+// request with the given options, booting from the given volume.
+func bootVolumeServerRequest(opts nova.RunServerOpts, volume bootVolume) (map[string]interface{}, error) {
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var server map[string]interface{}
+	if err := json.Unmarshal(data, &server); err != nil {
+		return nil, errors.Trace(err)
+	}
+	server["block_device_mapping_v2"] = []blockDeviceMapping{{
+		BootIndex:           0,
+		UUID:                opts.ImageId,
+		SourceType:          "image",
+		DestinationType:     "volume",
+		VolumeSize:          volume.size,
+		VolumeType:          volume.volumeType,
+		DeleteOnTermination: true,
+	}}
+	return server, nil
+}
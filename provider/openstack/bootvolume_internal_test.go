@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v1/nova"
+
+	"github.com/juju/juju/constraints"
+)
+
+type bootVolumeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&bootVolumeSuite{})
+
+func (s *bootVolumeSuite) TestNewBootVolume(c *gc.C) {
+	volume := newBootVolume("trusty", constraints.Value{}, "")
+	c.Assert(volume, jc.DeepEquals, bootVolume{size: 8})
+
+	volume = newBootVolume("trusty", constraints.MustParse("root-disk=20G"), "ssd")
+	c.Assert(volume, jc.DeepEquals, bootVolume{size: 20, volumeType: "ssd"})
+
+	volume = newBootVolume("trusty", constraints.MustParse("root-disk=1025M"), "")
+	c.Assert(volume, jc.DeepEquals, bootVolume{size: 2})
+}
+
+func (s *bootVolumeSuite) TestRunServer(c *gc.C) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/v2/tenant-id/servers")
+		c.Check(req.Header.Get("X-Auth-Token"), gc.Equals, "token")
+		c.Check(req.Header.Get("X-OpenStack-Nova-API-Version"), gc.Equals, "2.67")
+		var body struct {
+			Server map[string]interface{} `json:"server"`
+		}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(body.Server["name"], gc.Equals, "juju-machine-0")
+		c.Check(body.Server["imageRef"], gc.Equals, "image-id")
+		c.Check(body.Server["block_device_mapping_v2"], jc.DeepEquals, []interface{}{
+			map[string]interface{}{
+				"boot_index":            0.0,
+				"uuid":                  "image-id",
+				"source_type":           "image",
+				"destination_type":      "volume",
+				"volume_size":           20.0,
+				"volume_type":           "ssd",
+				"delete_on_termination": true,
+			},
+		})
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"server": {"id": "server-id"}}`))
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL + "/v2/tenant-id")
+	c.Assert(err, jc.ErrorIsNil)
+	servers := &bootVolumeServerClient{endpoint, func() string { return "token" }}
+	entity, err := servers.RunServer(nova.RunServerOpts{
+		Name:     "juju-machine-0",
+		FlavorId: "flavor-id",
+		ImageId:  "image-id",
+	}, bootVolume{size: 20, volumeType: "ssd"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(entity.Id, gc.Equals, "server-id")
+}
+
+func (s *bootVolumeSuite) TestRunServerError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Header.Get("X-OpenStack-Nova-API-Version"), gc.Equals, "")
+		http.Error(w, "quota exceeded", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	servers := &bootVolumeServerClient{endpoint, func() string { return "token" }}
+	_, err = servers.RunServer(nova.RunServerOpts{ImageId: "image-id"}, bootVolume{size: 8})
+	c.Assert(err, gc.ErrorMatches, "failed to run a server booting from a volume: 403 Forbidden: quota exceeded")
+}
@@ -4,8 +4,14 @@
 package openstack
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -84,6 +90,7 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 	return &openstackStorageAdapter{
 		cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)},
 		novaClient{env.novaUnlocked},
+		volumeActionClient{env.volumeURL, client.Token},
 	}, nil
 }
 
@@ -269,6 +276,46 @@ func (s *cinderVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.Desc
 	return results, nil
 }
 
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
+
+// SnapshotVolumes implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) SnapshotVolumes(volumeIds []string) ([]storage.SnapshotVolumesResult, error) {
+	results := make([]storage.SnapshotVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		snapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			VolumeId:    volumeId,
+			Name:        resourceName(s.namespace, s.envName, "snapshot-"+volumeId),
+			Description: fmt.Sprintf("juju snapshot of %s", volumeId),
+			// Units' volumes are snapshotted while they are still
+			// attached, before the units are removed.
+			Force: true,
+		})
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %s", volumeId)
+			continue
+		}
+		results[i].SnapshotId = snapshot.ID
+	}
+	return results, nil
+}
+
+var _ storage.VolumeResizer = (*cinderVolumeSource)(nil)
+
+// ResizeVolumes implements storage.VolumeResizer. Cinder deployments
+// that cannot extend volumes while they are attached report an error
+// for each attached volume.
+func (s *cinderVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		// Cinder sizes volumes in whole GiB, so round up.
+		sizeInGib := int((arg.Size + 1023) / 1024)
+		if err := s.storageAdapter.ExtendVolume(arg.VolumeId, sizeInGib); err != nil {
+			results[i] = errors.Annotatef(err, "extending volume %s", arg.VolumeId)
+		}
+	}
+	return results, nil
+}
+
 // DestroyVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
 	return destroyVolumes(s.storageAdapter, volumeIds), nil
@@ -481,6 +528,8 @@ type OpenstackStorage interface {
 	AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error)
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	ExtendVolume(volumeId string, newSize int) error
 }
 
 type endpointResolver interface {
@@ -511,6 +560,7 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+	volumeActionClient
 }
 
 type cinderClient struct {
@@ -521,6 +571,62 @@ type novaClient struct {
 	*nova.Client
 }
 
+// volumeActionClient posts the Cinder volume actions that the goose
+// cinder client does not provide.
+type volumeActionClient struct {
+	endpoint *url.URL
+	token    func() string
+}
+
+// ExtendVolume is part of the OpenstackStorage interface.
+func (c volumeActionClient) ExtendVolume(volumeId string, newSize int) error {
+	action := map[string]interface{}{
+		"os-extend": map[string]int{"new_size": newSize},
+	}
+	return errors.Trace(c.postAction(volumeId, action))
+}
+
+func (c volumeActionClient) postAction(volumeId string, action interface{}) error {
+	actionURL := *c.endpoint
+	actionURL.Path = strings.TrimSuffix(actionURL.Path, "/") + "/volumes/" + volumeId + "/action"
+	err := postJSON(actionURL.String(), c.token(), nil, action, http.StatusAccepted, nil)
+	return errors.Annotate(err, "volume action failed")
+}
+
+// postJSON posts the JSON encoding of in to the given URL with the
+// given authentication token and extra headers. If the response status
+// is not want, an error holding the response body is returned;
+// otherwise the body is decoded into out, if that is not nil.
+func postJSON(url, token string, header http.Header, in interface{}, want int, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Auth-Token", token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != want {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	if out == nil {
+		return nil
+	}
+	return errors.Trace(json.NewDecoder(resp.Body).Decode(out))
+}
+
 // CreateVolume is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) CreateVolume(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
 	resp, err := ga.cinderClient.CreateVolume(args)
@@ -547,3 +653,12 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 	}
 	return &resp.Volume, nil
 }
+
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
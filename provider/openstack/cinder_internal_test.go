@@ -4,6 +4,11 @@
 package openstack
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -46,6 +51,41 @@ func (s *cinderInternalSuite) TestStorageProviderTypesNotSupported(c *gc.C) {
 	c.Assert(types, gc.HasLen, 0)
 }
 
+func (s *cinderInternalSuite) TestExtendVolume(c *gc.C) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/v2/tenant-id/volumes/vol-0/action")
+		c.Check(req.Header.Get("X-Auth-Token"), gc.Equals, "token")
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(string(body), gc.Equals, `{"os-extend":{"new_size":2}}`)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL + "/v2/tenant-id")
+	c.Assert(err, jc.ErrorIsNil)
+	actions := volumeActionClient{endpoint, func() string { return "token" }}
+	err = actions.ExtendVolume("vol-0", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *cinderInternalSuite) TestExtendVolumeError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "volume is in use", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	actions := volumeActionClient{endpoint, func() string { return "token" }}
+	err = actions.ExtendVolume("vol-0", 2)
+	c.Assert(err, gc.ErrorMatches, "volume action failed: 400 Bad Request: volume is in use")
+}
+
 type testAuthClient struct {
 	client.AuthenticatingClient
 	regionEndpoints map[string]identity.ServiceURLs
@@ -299,6 +299,53 @@ func (s *cinderVolumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	})
 }
 
+func (s *cinderVolumeSourceSuite) TestSnapshotVolumes(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			if args.VolumeId == "bad-vol" {
+				return nil, errors.New("no such volume")
+			}
+			return &cinder.Snapshot{ID: "snap-" + args.VolumeId}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeSnapshotter).SnapshotVolumes([]string{mockVolId, "bad-vol"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results[0], jc.DeepEquals, storage.SnapshotVolumesResult{SnapshotId: "snap-" + mockVolId})
+	c.Check(results[1].Error, gc.ErrorMatches, "creating snapshot of volume bad-vol: no such volume")
+	mockAdapter.CheckCall(c, 0, "CreateSnapshot", cinder.CreateSnapshotSnapshotParams{
+		VolumeId:    mockVolId,
+		Name:        "juju-testenv-snapshot-" + mockVolId,
+		Description: "juju snapshot of " + mockVolId,
+		Force:       true,
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		extendVolume: func(volumeId string, newSize int) error {
+			if volumeId == "bad-vol" {
+				return errors.New("volume is in use")
+			}
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	errs, err := volSource.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{
+		{VolumeId: mockVolId, Size: 2048},
+		{VolumeId: "bad-vol", Size: 2049},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], jc.ErrorIsNil)
+	c.Check(errs[1], gc.ErrorMatches, "extending volume bad-vol: volume is in use")
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExtendVolume", []interface{}{mockVolId, 2}},
+		{"ExtendVolume", []interface{}{"bad-vol", 3}},
+	})
+}
+
 func (s *cinderVolumeSourceSuite) TestDestroyVolumesAttached(c *gc.C) {
 	statuses := []string{"in-use", "detaching", "available"}
 
@@ -473,6 +520,8 @@ type mockAdapter struct {
 	volumeStatusNotifier  func(string, string, int, time.Duration) <-chan error
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	extendVolume          func(string, int) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -534,6 +583,22 @@ func (ma *mockAdapter) ListVolumeAttachments(serverId string) ([]nova.VolumeAtta
 	return nil, nil
 }
 
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) ExtendVolume(volumeId string, newSize int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, newSize)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, newSize)
+	}
+	return errors.NotImplementedf("ExtendVolume")
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
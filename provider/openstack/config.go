@@ -6,6 +6,7 @@ package openstack
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
 
@@ -29,13 +30,23 @@ var configSchema = environschema.Fields{
 		Description: "The network label or UUID to create floating IP addresses on when multiple external networks exist.",
 		Type:        environschema.Tstring,
 	},
+	"boot-from-volume": {
+		Description: "Whether new machine instances should boot from a Cinder volume created from their image, rather than from the flavor's ephemeral disk. The volume is sized by the root-disk constraint, and removed with the instance.",
+		Type:        environschema.Tbool,
+	},
+	"root-disk-volume-type": {
+		Description: "The Cinder volume type of the volumes that new machine instances boot from, when boot-from-volume is set. The cloud's default volume type is used if this is empty. Requires compute API microversion 2.67 or later.",
+		Type:        environschema.Tstring,
+	},
 }
 
 var configDefaults = schema.Defaults{
-	"use-floating-ip":      false,
-	"use-default-secgroup": false,
-	"network":              "",
-	"external-network":     "",
+	"use-floating-ip":       false,
+	"use-default-secgroup":  false,
+	"network":               "",
+	"external-network":      "",
+	"boot-from-volume":      false,
+	"root-disk-volume-type": "",
 }
 
 var configFields = func() schema.Fields {
@@ -67,6 +78,14 @@ func (c *environConfig) externalNetwork() string {
 	return c.attrs["external-network"].(string)
 }
 
+func (c *environConfig) bootFromVolume() bool {
+	return c.attrs["boot-from-volume"].(bool)
+}
+
+func (c *environConfig) rootDiskVolumeType() string {
+	return c.attrs["root-disk-volume-type"].(string)
+}
+
 type AuthMode string
 
 const (
@@ -107,6 +126,9 @@ func (p EnvironProvider) Validate(cfg, old *config.Config) (valid *config.Config
 		return nil, err
 	}
 	ecfg := &environConfig{cfg, validated}
+	if ecfg.rootDiskVolumeType() != "" && !ecfg.bootFromVolume() {
+		return nil, errors.New("root-disk-volume-type requires boot-from-volume to be set")
+	}
 
 	// Check for deprecated fields and log a warning. We also print to stderr to ensure the user sees the message
 	// even if they are not running with --debug.
@@ -38,6 +38,8 @@ type configTest struct {
 	sslHostnameVerification bool
 	sslHostnameSet          bool
 	blockStorageSource      string
+	bootFromVolume          bool
+	rootDiskVolumeType      string
 }
 
 var requiredConfig = testing.Attrs{}
@@ -98,6 +100,8 @@ func (t configTest) check(c *gc.C) {
 	c.Assert(ecfg.useDefaultSecurityGroup(), gc.Equals, t.useDefaultSecurityGroup)
 	c.Assert(ecfg.network(), gc.Equals, t.network)
 	c.Assert(ecfg.externalNetwork(), gc.Equals, t.externalNetwork)
+	c.Assert(ecfg.bootFromVolume(), gc.Equals, t.bootFromVolume)
+	c.Assert(ecfg.rootDiskVolumeType(), gc.Equals, t.rootDiskVolumeType)
 	// Default should be true
 	expectedHostnameVerification := true
 	if t.sslHostnameSet {
@@ -226,6 +230,24 @@ var configTests = []configTest{
 			"storage-default-block-source": "my-cinder",
 		}),
 		blockStorageSource: "my-cinder",
+	}, {
+		summary:        "default boot from volume",
+		config:         requiredConfig,
+		bootFromVolume: false,
+	}, {
+		summary: "boot from volume",
+		config: requiredConfig.Merge(testing.Attrs{
+			"boot-from-volume":      true,
+			"root-disk-volume-type": "ssd",
+		}),
+		bootFromVolume:     true,
+		rootDiskVolumeType: "ssd",
+	}, {
+		summary: "root disk volume type without boot from volume",
+		config: requiredConfig.Merge(testing.Attrs{
+			"root-disk-volume-type": "ssd",
+		}),
+		err: "root-disk-volume-type requires boot-from-volume to be set",
 	},
 }
 
@@ -707,7 +707,7 @@ func (c *neutronFirewaller) openPortsInGroup(nameRegExp string, portRanges []net
 		return errors.Trace(err)
 	}
 	neutronClient := c.environ.neutron()
-	rules := portsToRuleInfo(group.Id, portRanges)
+	rules := portsToRuleInfo(group.Id, portRanges, c.environ.Config().IPv6Mode())
 	for _, rule := range rules {
 		_, err := neutronClient.CreateSecurityGroupRuleV2(rule)
 		if err != nil {
@@ -739,6 +739,7 @@ func (c *neutronFirewaller) closePortsInGroup(nameRegExp string, portRanges []ne
 	neutronClient := c.environ.neutron()
 	// TODO: Hey look ma, it's quadratic
 	for _, portRange := range portRanges {
+		// There may be both IPv4 and IPv6 rules for the range.
 		for _, p := range group.Rules {
 			if !ruleMatchesPortRange(p, portRange) {
 				continue
@@ -747,7 +748,6 @@ func (c *neutronFirewaller) closePortsInGroup(nameRegExp string, portRanges []ne
 			if err != nil {
 				return errors.Trace(err)
 			}
-			break
 		}
 	}
 	return nil
@@ -775,5 +775,12 @@ func (c *neutronFirewaller) portsInGroup(nameRegexp string) (portRanges []networ
 		portRanges = append(portRanges, portRange)
 	}
 	network.SortPortRanges(portRanges)
-	return portRanges, nil
+	// Ports open to both IPv4 and IPv6 traffic have a rule for each.
+	unique := portRanges[:0]
+	for _, portRange := range portRanges {
+		if len(unique) == 0 || portRange != unique[len(unique)-1] {
+			unique = append(unique, portRange)
+		}
+	}
+	return unique, nil
 }
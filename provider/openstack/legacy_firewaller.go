@@ -227,7 +227,8 @@ func (c *legacyNovaFirewaller) openPortsInGroup(nameRegExp string, portRanges []
 		return errors.Trace(err)
 	}
 	novaclient := c.environ.nova()
-	rules := portsToRuleInfo(group.Id, portRanges)
+	// nova-network does not support IPv6 security group rules.
+	rules := portsToRuleInfo(group.Id, portRanges, network.IPv6Disabled)
 	for _, rule := range rules {
 		_, err := novaclient.CreateSecurityGroupRule(legacyRuleInfo(rule))
 		if err != nil {
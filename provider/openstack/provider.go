@@ -476,6 +476,9 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.GPUs,
+	constraints.GPUType,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -943,13 +946,9 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	// the known zones for optimal spread across the instance distribution
 	// group.
 	if len(availabilityZones) == 0 {
-		var group []instance.Id
-		var err error
-		if args.DistributionGroup != nil {
-			group, err = args.DistributionGroup()
-			if err != nil {
-				return nil, err
-			}
+		group, err := common.DistributionGroup(e.Config(), args.DistributionGroup)
+		if err != nil {
+			return nil, err
 		}
 		zoneInstances, err := availabilityZoneAllocations(e, group)
 		if errors.IsNotImplemented(err) {
@@ -958,6 +957,10 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		} else if err != nil {
 			return nil, err
 		} else {
+			zoneInstances, err = common.DistributionZones(e.Config(), group, zoneInstances)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
 			for _, zone := range zoneInstances {
 				availabilityZones = append(availabilityZones, zone.ZoneName)
 			}
@@ -970,11 +973,20 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 
 	series := args.Tools.OneSeries()
 	arches := args.Tools.Arches()
+	cons := args.Constraints
+	var volume *bootVolume
+	if e.ecfg().bootFromVolume() {
+		v := newBootVolume(series, cons, e.ecfg().rootDiskVolumeType())
+		volume = &v
+		// The root disk is the boot volume, so the flavor's
+		// disk need not satisfy the root-disk constraint.
+		cons.RootDisk = nil
+	}
 	spec, err := findInstanceSpec(e, &instances.InstanceConstraint{
 		Region:      e.cloud.Region,
 		Series:      series,
 		Arches:      arches,
-		Constraints: args.Constraints,
+		Constraints: cons,
 	}, args.ImageMetadata)
 	if err != nil {
 		return nil, err
@@ -1037,13 +1049,24 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		args.InstanceConfig.MachineId,
 	)
 
+	runServer := e.nova().RunServer
+	if volume != nil {
+		servers, err := e.bootVolumeServers()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		runServer = func(opts nova.RunServerOpts) (*nova.Entity, error) {
+			return servers.RunServer(opts, *volume)
+		}
+	}
+
 	tryStartNovaInstance := func(
 		attempts utils.AttemptStrategy,
-		client *nova.Client,
+		runServer func(nova.RunServerOpts) (*nova.Entity, error),
 		instanceOpts nova.RunServerOpts,
 	) (server *nova.Entity, err error) {
 		for a := attempts.Start(); a.Next(); {
-			server, err = client.RunServer(instanceOpts)
+			server, err = runServer(instanceOpts)
 			if err == nil || gooseerrors.IsNotFound(err) == false {
 				break
 			}
@@ -1053,14 +1076,14 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 
 	tryStartNovaInstanceAcrossAvailZones := func(
 		attempts utils.AttemptStrategy,
-		client *nova.Client,
+		runServer func(nova.RunServerOpts) (*nova.Entity, error),
 		instanceOpts nova.RunServerOpts,
 		availabilityZones []string,
 	) (server *nova.Entity, err error) {
 		for _, zone := range availabilityZones {
 			instanceOpts.AvailabilityZone = zone
 			e.configurator.ModifyRunServerOptions(&instanceOpts)
-			server, err = tryStartNovaInstance(attempts, client, instanceOpts)
+			server, err = tryStartNovaInstance(attempts, runServer, instanceOpts)
 			if err == nil || isNoValidHostsError(err) == false {
 				break
 			}
@@ -1084,7 +1107,7 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		Networks:           networks,
 		Metadata:           args.InstanceConfig.Tags,
 	}
-	server, err := tryStartNovaInstanceAcrossAvailZones(shortAttempt, e.nova(), opts, availabilityZones)
+	server, err := tryStartNovaInstanceAcrossAvailZones(shortAttempt, runServer, opts, availabilityZones)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1120,9 +1143,14 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		}
 		inst.floatingIP = publicIP
 	}
+	hc := inst.hardwareCharacteristics()
+	if volume != nil {
+		rootDisk := volume.size * 1024
+		hc.RootDisk = &rootDisk
+	}
 	return &environs.StartInstanceResult{
 		Instance: inst,
-		Hardware: inst.hardwareCharacteristics(),
+		Hardware: hc,
 	}, nil
 }
 
@@ -1285,6 +1313,27 @@ func (e *Environ) AllInstances() ([]instance.Instance, error) {
 	return e.allInstances(tagFilter, e.ecfg().useFloatingIP())
 }
 
+var _ environs.CloudInstanceLister = (*Environ)(nil)
+
+// CloudInstances is part of the environs.CloudInstanceLister interface.
+// The tags are the metadata of the instances' servers.
+func (e *Environ) CloudInstances() ([]environs.CloudInstance, error) {
+	insts, err := e.AllInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]environs.CloudInstance, len(insts))
+	for i, inst := range insts {
+		server := inst.(*openstackInstance).getServerDetail()
+		result[i] = environs.CloudInstance{
+			Id:     inst.Id(),
+			Status: server.Status,
+			Tags:   server.Metadata,
+		}
+	}
+	return result, nil
+}
+
 // allControllerManagedInstances returns all instances managed by this
 // environment's controller, matching the optionally specified filter.
 func (e *Environ) allControllerManagedInstances(controllerUUID string, updateFloatingIPAddresses bool) ([]instance.Instance, error) {
@@ -1415,17 +1464,26 @@ func jujuMachineFilter() *nova.Filter {
 	return filter
 }
 
-// portsToRuleInfo maps port ranges to nova rules
-func portsToRuleInfo(groupId string, ports []network.PortRange) []neutron.RuleInfoV2 {
-	rules := make([]neutron.RuleInfoV2, len(ports))
-	for i, portRange := range ports {
-		rules[i] = neutron.RuleInfoV2{
-			Direction:      "ingress",
-			ParentGroupId:  groupId,
-			PortRangeMin:   portRange.FromPort,
-			PortRangeMax:   portRange.ToPort,
-			IPProtocol:     portRange.Protocol,
-			RemoteIPPrefix: "0.0.0.0/0",
+// portsToRuleInfo maps port ranges to nova rules. Rules are created
+// for IPv4 and/or IPv6 traffic, according to the IPv6 mode.
+func portsToRuleInfo(groupId string, ports []network.PortRange, ipv6Mode network.IPv6Mode) []neutron.RuleInfoV2 {
+	var rules []neutron.RuleInfoV2
+	for _, portRange := range ports {
+		rule := neutron.RuleInfoV2{
+			Direction:     "ingress",
+			ParentGroupId: groupId,
+			PortRangeMin:  portRange.FromPort,
+			PortRangeMax:  portRange.ToPort,
+			IPProtocol:    portRange.Protocol,
+		}
+		if ipv6Mode.AllowsIPv4() {
+			rule.RemoteIPPrefix = "0.0.0.0/0"
+			rules = append(rules, rule)
+		}
+		if ipv6Mode.AllowsIPv6() {
+			rule.RemoteIPPrefix = "::/0"
+			rule.EthernetType = "IPv6"
+			rules = append(rules, rule)
 		}
 	}
 	return rules
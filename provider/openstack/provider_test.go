@@ -259,12 +259,44 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
-		rules := PortsToRuleInfo(groupId, t.ports)
+		rules := PortsToRuleInfo(groupId, t.ports, network.IPv6Disabled)
 		c.Check(len(rules), gc.Equals, len(t.expected))
 		c.Check(rules, gc.DeepEquals, t.expected)
 	}
 }
 
+func (*localTests) TestPortsToRuleInfoIPv6(c *gc.C) {
+	groupId := "groupid"
+	ports := []network.PortRange{{
+		FromPort: 80,
+		ToPort:   80,
+		Protocol: "tcp",
+	}}
+	ipv4Rule := neutron.RuleInfoV2{
+		Direction:      "ingress",
+		IPProtocol:     "tcp",
+		PortRangeMin:   80,
+		PortRangeMax:   80,
+		RemoteIPPrefix: "0.0.0.0/0",
+		ParentGroupId:  groupId,
+	}
+	ipv6Rule := neutron.RuleInfoV2{
+		Direction:      "ingress",
+		IPProtocol:     "tcp",
+		PortRangeMin:   80,
+		PortRangeMax:   80,
+		RemoteIPPrefix: "::/0",
+		EthernetType:   "IPv6",
+		ParentGroupId:  groupId,
+	}
+
+	rules := PortsToRuleInfo(groupId, ports, network.IPv6DualStack)
+	c.Check(rules, gc.DeepEquals, []neutron.RuleInfoV2{ipv4Rule, ipv6Rule})
+
+	rules = PortsToRuleInfo(groupId, ports, network.IPv6Only)
+	c.Check(rules, gc.DeepEquals, []neutron.RuleInfoV2{ipv6Rule})
+}
+
 func (*localTests) TestRuleMatchesPortRange(c *gc.C) {
 	proto_tcp := "tcp"
 	proto_udp := "udp"
@@ -37,9 +37,11 @@ func (c *rackspaceConfigurator) GetCloudConfig(args environs.StartInstanceParams
 // GetConfigDefaults implements ProviderConfigurator interface.
 func (c *rackspaceConfigurator) GetConfigDefaults() schema.Defaults {
 	return schema.Defaults{
-		"use-floating-ip":      false,
-		"use-default-secgroup": false,
-		"network":              "",
-		"external-network":     "",
+		"use-floating-ip":       false,
+		"use-default-secgroup":  false,
+		"network":               "",
+		"external-network":      "",
+		"boot-from-volume":      false,
+		"root-disk-volume-type": "",
 	}
 }
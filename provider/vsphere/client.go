@@ -99,6 +99,12 @@ type instanceSpec struct {
 	isController   bool
 	controllerUUID string
 	apiPort        int
+
+	// datastore, resourcePool and folder, if set, name the datastore,
+	// resource pool and folder to use instead of the zone's defaults.
+	datastore    string
+	resourcePool string
+	folder       string
 }
 
 // CreateInstance create new vm in vsphere and run it
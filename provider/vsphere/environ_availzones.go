@@ -90,31 +90,22 @@ var AllAvailabilityZones = func(env common.ZonedEnviron) ([]common.AvailabilityZ
 }
 
 // parseAvailabilityZones returns the availability zones that should be
-// tried for the given instance spec. If a placement argument was
-// provided then only that one is returned. Otherwise the environment is
+// tried for the given instance spec. If the placement names a zone
+// then only that one is returned. Otherwise the environment is
 // queried for available zones. In that case, the resulting list is
 // roughly ordered such that the environment's instances are spread
 // evenly across the region.
-func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
-	if args.Placement != "" {
-		// args.Placement will always be a zone name or empty.
-		placement, err := env.parsePlacement(args.Placement)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		return []string{placement.Name()}, nil
+func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams, placement *vspherePlacement) ([]string, error) {
+	if placement != nil && placement.zone != nil {
+		return []string{placement.zone.Name()}, nil
 	}
 
 	// If no availability zone is specified, then automatically spread across
 	// the known zones for optimal spread across the instance distribution
 	// group.
-	var group []instance.Id
-	var err error
-	if args.DistributionGroup != nil {
-		group, err = args.DistributionGroup()
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
+	group, err := common.DistributionGroup(env.Config(), args.DistributionGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 	var zoneNames []string
 	// Vsphere will misbehave if we call AvailabilityZoneAllocations with empty
@@ -124,6 +115,10 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		zoneInstances, err = common.DistributionZones(env.Config(), group, zoneInstances)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		for _, z := range zoneInstances {
 			zoneNames = append(zoneNames, z.ZoneName)
 		}
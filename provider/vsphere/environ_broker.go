@@ -116,7 +116,14 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, img *OvaFi
 		CpuPower: &cpuPower,
 		RootDisk: &rootDisk,
 	}
-	zones, err := env.parseAvailabilityZones(args)
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if placement == nil {
+		placement = &vspherePlacement{}
+	}
+	zones, err := env.parseAvailabilityZones(args, placement)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -142,6 +149,9 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, img *OvaFi
 			isController:   args.InstanceConfig.Controller != nil,
 			controllerUUID: args.ControllerUUID,
 			apiPort:        apiPort,
+			datastore:      placement.datastore,
+			resourcePool:   placement.resourcePool,
+			folder:         placement.folder,
 		}
 		inst, err = env.client.CreateInstance(env.ecfg, spec)
 		if err != nil {
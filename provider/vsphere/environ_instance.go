@@ -104,26 +104,67 @@ func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, e
 	return results, nil
 }
 
-// parsePlacement extracts the availability zone from the placement
-// string and returns it. If no zone is found there then an error is
-// returned.
-func (env *environ) parsePlacement(placement string) (*vmwareAvailZone, error) {
+// vspherePlacement holds the parts of a placement directive. Any of
+// them may be unset, in which case the provider chooses.
+type vspherePlacement struct {
+	// zone is the availability zone (compute resource) to create the
+	// instance in.
+	zone *vmwareAvailZone
+
+	// datastore is the name of the datastore to put the instance's
+	// disks on.
+	datastore string
+
+	// resourcePool is the inventory path of the resource pool to
+	// create the instance in.
+	resourcePool string
+
+	// folder is the inventory path of the folder to put the instance
+	// in.
+	folder string
+}
+
+// parsePlacement extracts the availability zone, datastore, resource
+// pool and folder from the placement string, which holds
+// comma-separated key=value pairs, and returns them. The availability
+// zone is checked here; the others are looked up in the vCenter
+// inventory when the instance is created.
+func (env *environ) parsePlacement(placement string) (*vspherePlacement, error) {
 	if placement == "" {
 		return nil, nil
 	}
 
-	pos := strings.IndexRune(placement, '=')
-	if pos == -1 {
-		return nil, errors.Errorf("unknown placement directive: %v", placement)
-	}
-
-	switch key, value := placement[:pos], placement[pos+1:]; key {
-	case "zone":
-		zone, err := env.availZone(value)
-		if err != nil {
-			return nil, errors.Trace(err)
+	var result vspherePlacement
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(placement, ",") {
+		pos := strings.IndexRune(part, '=')
+		if pos == -1 {
+			return nil, errors.Errorf("unknown placement directive: %v", placement)
+		}
+		key, value := part[:pos], part[pos+1:]
+		if seen[key] {
+			return nil, errors.Errorf("placement directive %q specified more than once", key)
+		}
+		seen[key] = true
+		if value == "" {
+			return nil, errors.Errorf("placement directive %q has no value", key)
+		}
+		switch key {
+		case "zone":
+			zone, err := env.availZone(value)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result.zone = zone
+		case "datastore":
+			result.datastore = value
+		case "resource-pool":
+			result.resourcePool = value
+		case "folder":
+			result.folder = value
+		default:
+			return nil, errors.Errorf("unknown placement directive: %v", placement)
 		}
-		return zone, nil
 	}
-	return nil, errors.Errorf("unknown placement directive: %v", placement)
+	return &result, nil
 }
@@ -65,3 +65,31 @@ func (s *environPolSuite) TestSupportNetworks(c *gc.C) {
 
 	c.Check(isSupported, jc.IsFalse)
 }
+
+func (s *environPolSuite) TestPrecheckInstanceLocationPlacement(c *gc.C) {
+	err := s.Env.PrecheckInstance("trusty", constraints.Value{}, "datastore=ds1,resource-pool=juju/pool,folder=juju")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceInvalidPlacement(c *gc.C) {
+	for i, test := range []struct {
+		placement string
+		err       string
+	}{{
+		placement: "datastore=ds1,datastore=ds2",
+		err:       `placement directive "datastore" specified more than once`,
+	}, {
+		placement: "folder=",
+		err:       `placement directive "folder" has no value`,
+	}, {
+		placement: "datastore=ds1,host=h1",
+		err:       "unknown placement directive: datastore=ds1,host=h1",
+	}, {
+		placement: "datastore=ds1,juju",
+		err:       "unknown placement directive: datastore=ds1,juju",
+	}} {
+		c.Logf("test %d: %s", i, test.placement)
+		err := s.Env.PrecheckInstance("trusty", constraints.Value{}, test.placement)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/progress"
 	"github.com/vmware/govmomi/vim25/soap"
@@ -51,8 +52,53 @@ type ovaImportManager struct {
 	providerClient *client
 }
 
+// instanceLocation holds where in the vCenter inventory an instance
+// is created.
+type instanceLocation struct {
+	resourcePool *object.ResourcePool
+	datastore    *object.Datastore
+	folder       *object.Folder
+}
+
+// instanceLocation returns where the given instance should be created:
+// the datastore, resource pool and folder named in its placement, or
+// else the zone's first datastore, its root resource pool and the
+// datacenter's VM folder. Named locations are looked up in the
+// inventory, so that mistakes are reported before anything is
+// imported.
+func (m *ovaImportManager) instanceLocation(finder *find.Finder, folders *object.DatacenterFolders, instSpec *instanceSpec) (*instanceLocation, error) {
+	ctx := context.TODO()
+	location := &instanceLocation{
+		resourcePool: object.NewResourcePool(m.client.Client, *instSpec.zone.r.ResourcePool),
+		datastore:    object.NewDatastore(m.client.Client, instSpec.zone.r.Datastore[0]),
+		folder:       folders.VmFolder,
+	}
+	if instSpec.resourcePool != "" {
+		pool, err := finder.ResourcePool(ctx, instSpec.resourcePool)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot find resource pool %q", instSpec.resourcePool)
+		}
+		location.resourcePool = pool
+	}
+	if instSpec.datastore != "" {
+		datastore, err := finder.Datastore(ctx, instSpec.datastore)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot find datastore %q", instSpec.datastore)
+		}
+		location.datastore = datastore
+	}
+	if instSpec.folder != "" {
+		folder, err := finder.Folder(ctx, instSpec.folder)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot find folder %q", instSpec.folder)
+		}
+		location.folder = folder
+	}
+	return location, nil
+}
+
 func (m *ovaImportManager) importOva(ecfg *environConfig, instSpec *instanceSpec) (*object.VirtualMachine, error) {
-	_, datacenter, err := m.providerClient.finder(m.client)
+	finder, datacenter, err := m.providerClient.finder(m.client)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -61,6 +107,10 @@ func (m *ovaImportManager) importOva(ecfg *environConfig, instSpec *instanceSpec
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	location, err := m.instanceLocation(finder, folders, instSpec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	basePath, err := ioutil.TempDir("", "")
 	if err != nil {
@@ -85,9 +135,7 @@ func (m *ovaImportManager) importOva(ecfg *environConfig, instSpec *instanceSpec
 	}
 
 	ovfManager := object.NewOvfManager(m.client.Client)
-	resourcePool := object.NewReference(m.client.Client, *instSpec.zone.r.ResourcePool)
-	datastore := object.NewReference(m.client.Client, instSpec.zone.r.Datastore[0])
-	spec, err := ovfManager.CreateImportSpec(context.TODO(), string(ovf), resourcePool, datastore, cisp)
+	spec, err := ovfManager.CreateImportSpec(context.TODO(), string(ovf), location.resourcePool, location.datastore, cisp)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -159,8 +207,7 @@ func (m *ovaImportManager) importOva(ecfg *environConfig, instSpec *instanceSpec
 			},
 		})
 	}
-	rp := object.NewResourcePool(m.client.Client, *instSpec.zone.r.ResourcePool)
-	lease, err := rp.ImportVApp(context.TODO(), spec.ImportSpec, folders.VmFolder, nil)
+	lease, err := location.resourcePool.ImportVApp(context.TODO(), spec.ImportSpec, location.folder, nil)
 	if err != nil {
 		return nil, errors.Annotatef(err, "failed to import vapp")
 	}
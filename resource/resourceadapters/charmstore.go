@@ -54,7 +54,15 @@ func newCharmstoreOpener(st *state.State) *charmstoreOpener {
 }
 
 func newCharmStoreClient(st *state.State) (charmstore.Client, error) {
-	return charmstore.NewCachingClient(state.MacaroonCache{st}, nil)
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return charmstore.Client{}, errors.Trace(err)
+	}
+	server, err := charmstore.ControllerServerURL(controllerConfig)
+	if err != nil {
+		return charmstore.Client{}, errors.Trace(err)
+	}
+	return charmstore.NewCachingClient(state.MacaroonCache{st}, server)
 }
 
 // NewClient opens a new charm store client.
@@ -5,8 +5,10 @@ package rpc
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
+	"golang.org/x/net/context"
 )
 
 var ErrShutdown = errors.New("connection is shut down")
@@ -22,6 +24,10 @@ type Call struct {
 	Response interface{}
 	Error    error
 	Done     chan *Call
+
+	// Timeout holds how long the server should spend on the call.
+	// Zero means there is no limit.
+	Timeout time.Duration
 }
 
 // RequestError represents an error returned from an RPC request.
@@ -66,6 +72,7 @@ func (conn *Conn) send(call *Call) {
 		RequestId: reqId,
 		Request:   call.Request,
 		Version:   1,
+		Timeout:   call.Timeout,
 	}
 	params := call.Params
 	if params == nil {
@@ -138,13 +145,46 @@ func (call *Call) done() {
 // The params value may be nil if no parameters are provided; the response value
 // may be nil to indicate that any result should be discarded.
 func (conn *Conn) Call(req Request, params, response interface{}) error {
+	return conn.CallContext(context.Background(), req, params, response)
+}
+
+// CallContext is like Call, except that it gives up waiting for the
+// reply, returning the context's error, when the given context is done.
+// If the context has a deadline, the server is told how long remains
+// until it, so that it can abandon the call once the client has given
+// up on it.
+func (conn *Conn) CallContext(ctx context.Context, req Request, params, response interface{}) error {
 	call := &Call{
 		Request:  req,
 		Params:   params,
 		Response: response,
 		Done:     make(chan *Call, 1),
 	}
+	if deadline, ok := ctx.Deadline(); ok {
+		call.Timeout = deadline.Sub(time.Now())
+		if call.Timeout <= 0 {
+			return errors.Trace(context.DeadlineExceeded)
+		}
+	}
 	conn.send(call)
-	result := <-call.Done
-	return errors.Trace(result.Error)
+	select {
+	case result := <-call.Done:
+		return errors.Trace(result.Error)
+	case <-ctx.Done():
+		conn.abandon(call)
+		return errors.Trace(ctx.Err())
+	}
+}
+
+// abandon forgets the given pending call, so that its reply is
+// discarded when it arrives.
+func (conn *Conn) abandon(call *Call) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	for reqId, pending := range conn.clientPending {
+		if pending == call {
+			delete(conn.clientPending, reqId)
+			return
+		}
+	}
 }
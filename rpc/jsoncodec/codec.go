@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -72,6 +73,7 @@ type inMsgV1 struct {
 	Error     string          `json:"error"`
 	ErrorCode string          `json:"error-code"`
 	Response  json.RawMessage `json:"response"`
+	Timeout   time.Duration   `json:"timeout"`
 }
 
 // outMsg holds an outgoing message.
@@ -97,6 +99,9 @@ type outMsgV1 struct {
 	Error     string      `json:"error,omitempty"`
 	ErrorCode string      `json:"error-code,omitempty"`
 	Response  interface{} `json:"response,omitempty"`
+	// Timeout holds, in nanoseconds, how long the client will
+	// wait for the reply to a request.
+	Timeout time.Duration `json:"timeout,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -140,6 +145,7 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
 	hdr.Version = version
+	hdr.Timeout = c.msg.Timeout
 	return nil
 }
 
@@ -275,6 +281,7 @@ func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 	}
 	if hdr.IsRequest() {
 		result.Params = body
+		result.Timeout = hdr.Timeout
 	} else {
 		result.Response = body
 	}
@@ -4,28 +4,52 @@
 package jsoncodec
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"io/ioutil"
 	"net"
 
+	"github.com/juju/errors"
 	"golang.org/x/net/websocket"
 )
 
 // NewWebsocket returns an rpc codec that uses the given websocket
-// connection to send and receive messages.
+// connection to send and receive messages. Messages are always sent
+// uncompressed; compressed messages are accepted from the peer.
 func NewWebsocket(conn *websocket.Conn) *Codec {
-	return New(wsJSONConn{conn})
+	return NewWebsocketCompressing(conn, 0)
+}
+
+// NewWebsocketCompressing returns an rpc codec that uses the given
+// websocket connection to send and receive messages, compressing
+// any message it sends whose JSON encoding is larger than threshold
+// bytes. Compressed messages are sent as gzipped binary frames. A
+// threshold of zero or less disables compression.
+//
+// The peer must be known to accept compressed messages, as codecs
+// returned by NewWebsocket and NewWebsocketCompressing do.
+func NewWebsocketCompressing(conn *websocket.Conn, threshold int) *Codec {
+	return New(wsJSONConn{
+		conn: conn,
+		codec: websocket.Codec{
+			Marshal:   compressingMarshaller(threshold),
+			Unmarshal: unmarshalMaybeCompressed,
+		},
+	})
 }
 
 type wsJSONConn struct {
-	conn *websocket.Conn
+	conn  *websocket.Conn
+	codec websocket.Codec
 }
 
 func (conn wsJSONConn) Send(msg interface{}) error {
-	return websocket.JSON.Send(conn.conn, msg)
+	return conn.codec.Send(conn.conn, msg)
 }
 
 func (conn wsJSONConn) Receive(msg interface{}) error {
-	return websocket.JSON.Receive(conn.conn, msg)
+	return conn.codec.Receive(conn.conn, msg)
 }
 
 func (conn wsJSONConn) Close() error {
@@ -59,3 +83,44 @@ func (conn *netConn) Receive(msg interface{}) error {
 func (conn *netConn) Close() error {
 	return conn.conn.Close()
 }
+
+// compressingMarshaller returns a websocket marshal function that
+// encodes messages as JSON text frames, gzipping those larger than
+// threshold bytes into binary frames.
+func compressingMarshaller(threshold int) func(interface{}) ([]byte, byte, error) {
+	return func(v interface{}) ([]byte, byte, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, 0, err
+		}
+		if threshold <= 0 || len(data) <= threshold {
+			return data, websocket.TextFrame, nil
+		}
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		return buf.Bytes(), websocket.BinaryFrame, nil
+	}
+}
+
+// unmarshalMaybeCompressed decodes a JSON message received in a
+// websocket frame, gunzipping it first if it arrived in a binary
+// frame.
+func unmarshalMaybeCompressed(data []byte, payloadType byte, v interface{}) error {
+	if payloadType == websocket.BinaryFrame {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return errors.Annotate(err, "cannot decompress message")
+		}
+		data, err = ioutil.ReadAll(r)
+		if err != nil {
+			return errors.Annotate(err, "cannot decompress message")
+		}
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsoncodec_test
+
+import (
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/websocket"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/jsoncodec"
+)
+
+type websocketSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&websocketSuite{})
+
+// serve starts a websocket server whose handler writes a response
+// holding each of the given values through a codec compressing
+// messages over the given threshold, and returns a client connected
+// to it.
+func (s *websocketSuite) serve(c *gc.C, threshold int, values ...string) *websocket.Conn {
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		codec := jsoncodec.NewWebsocketCompressing(conn, threshold)
+		for i, v := range values {
+			hdr := &rpc.Header{RequestId: uint64(i + 1), Version: 1}
+			if err := codec.WriteMessage(hdr, &value{X: v}); err != nil {
+				return
+			}
+		}
+		// Wait for the client to hang up.
+		var discard []byte
+		websocket.Message.Receive(conn, &discard)
+	}))
+	s.AddCleanup(func(*gc.C) { server.Close() })
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, err := websocket.Dial(url, "", server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { client.Close() })
+	return client
+}
+
+func (s *websocketSuite) TestCompressesLargeMessages(c *gc.C) {
+	client := s.serve(c, 100, "small", strings.Repeat("x", 200))
+
+	var frameTypes []byte
+	frameType := websocket.Codec{
+		Unmarshal: func(_ []byte, payloadType byte, _ interface{}) error {
+			frameTypes = append(frameTypes, payloadType)
+			return nil
+		},
+	}
+	for i := 0; i < 2; i++ {
+		err := frameType.Receive(client, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(frameTypes, jc.DeepEquals, []byte{websocket.TextFrame, websocket.BinaryFrame})
+}
+
+func (s *websocketSuite) TestReadsCompressedMessages(c *gc.C) {
+	large := strings.Repeat("x", 200)
+	codec := jsoncodec.NewWebsocket(s.serve(c, 100, "small", large))
+
+	for i, expect := range []string{"small", large} {
+		var hdr rpc.Header
+		err := codec.ReadHeader(&hdr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(hdr.RequestId, gc.Equals, uint64(i+1))
+		var body value
+		err = codec.ReadBody(&body, false)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(body.X, gc.Equals, expect)
+	}
+}
+
+func (s *websocketSuite) TestZeroThresholdDisablesCompression(c *gc.C) {
+	large := strings.Repeat("x", 200)
+	client := s.serve(c, 0, large)
+
+	var data string
+	err := websocket.Message.Receive(client, &data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.Contains, large)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
@@ -194,6 +195,7 @@ type DelayedMethods struct {
 	ready     chan struct{}
 	done      chan string
 	doneError chan error
+	ctxDone   chan error
 }
 
 func (a *DelayedMethods) Delay() (stringVal, error) {
@@ -208,6 +210,17 @@ func (a *DelayedMethods) Delay() (stringVal, error) {
 	}
 }
 
+// DelayContext waits until its context is done, and reports
+// the context's error on ctxDone.
+func (a *DelayedMethods) DelayContext(ctx context.Context) (stringVal, error) {
+	if a.ready != nil {
+		a.ready <- struct{}{}
+	}
+	<-ctx.Done()
+	a.ctxDone <- ctx.Err()
+	return stringVal{}, ctx.Err()
+}
+
 type ErrorMethods struct {
 	err error
 }
@@ -297,7 +310,7 @@ func (c customMethodCaller) ResultType() reflect.Type {
 	return c.objMethod.Result
 }
 
-func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+func (c customMethodCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
 	sm, err := c.root.SimpleMethods(objId)
 	if err != nil {
 		return reflect.Value{}, err
@@ -307,7 +320,7 @@ func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value
 		logger.Errorf("got the wrong type back, expected %s got %T", c.expectedType, obj)
 	}
 	logger.Debugf("calling: %T %v %#v", obj, obj, c.objMethod)
-	return c.objMethod.Call(obj, arg)
+	return c.objMethod.Call(ctx, obj, arg)
 }
 
 func (cc *CustomRoot) Kill() {
@@ -800,6 +813,71 @@ func (*rpcSuite) TestServerWaitsForOutstandingCalls(c *gc.C) {
 	start <- "xxx"
 }
 
+func (*rpcSuite) TestCallContextTimeout(c *gc.C) {
+	ctxDone := make(chan error, 1)
+	root := &Root{
+		delayed: map[string]*DelayedMethods{
+			"1": {ctxDone: ctxDone},
+		},
+	}
+	client, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var r stringVal
+	err := client.CallContext(ctx, rpc.Request{"DelayedMethods", 0, "1", "DelayContext"}, nil, &r)
+	c.Assert(errors.Cause(err), gc.Equals, context.DeadlineExceeded)
+
+	// The server method sees its context done once the timeout
+	// sent by the client has passed.
+	select {
+	case err := <-ctxDone:
+		c.Assert(err, gc.Equals, context.DeadlineExceeded)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("server method context not done")
+	}
+}
+
+func (*rpcSuite) TestCallContextExpired(c *gc.C) {
+	client, srvDone, _ := newRPCClientServer(c, &Root{}, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	err := client.CallContext(ctx, rpc.Request{"DelayedMethods", 0, "1", "DelayContext"}, nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, context.DeadlineExceeded)
+}
+
+func (*rpcSuite) TestClientCloseCancelsContext(c *gc.C) {
+	ready := make(chan struct{})
+	ctxDone := make(chan error, 1)
+	root := &Root{
+		delayed: map[string]*DelayedMethods{
+			"1": {ready: ready, ctxDone: ctxDone},
+		},
+	}
+	client, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	go func() {
+		client.Call(rpc.Request{"DelayedMethods", 0, "1", "DelayContext"}, nil, nil)
+	}()
+	chanRead(c, ready, "DelayedMethods.DelayContext ready")
+	// Closing the client cancels the server method's context, so
+	// that the server need not wait for it to run to completion.
+	client.Close()
+	select {
+	case err := <-ctxDone:
+		c.Assert(err, gc.Equals, context.Canceled)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("server method context not done")
+	}
+	select {
+	case <-srvDone:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("server did not finish")
+	}
+}
+
 func chanRead(c *gc.C, ch <-chan struct{}, what string) {
 	select {
 	case <-ch:
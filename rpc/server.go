@@ -7,9 +7,11 @@ import (
 	"io"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"golang.org/x/net/context"
 
 	"github.com/juju/juju/rpc/rpcreflect"
 )
@@ -64,6 +66,12 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// Timeout holds, for requests, how long the client will wait
+	// for the reply. The context passed to the server method is
+	// done once it has passed. Zero means the client waits
+	// indefinitely.
+	Timeout time.Duration
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -147,6 +155,12 @@ type Conn struct {
 	// terminate prematurely.  It is set before dead is closed.
 	inputLoopError error
 
+	// ctx is the context from which the contexts of server requests
+	// are derived. It is cancelled, by cancel, when the input loop
+	// terminates, because there is then no client left to reply to.
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	observerFactory ObserverFactory
 }
 
@@ -155,9 +169,12 @@ type Conn struct {
 // any requests are sent or received. If notifier is non-nil, the
 // appropriate method will be called for every RPC request.
 func NewConn(codec Codec, observerFactory ObserverFactory) *Conn {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Conn{
 		codec:           codec,
 		clientPending:   make(map[uint64]*Call),
+		ctx:             ctx,
+		cancel:          cancel,
 		observerFactory: observerFactory,
 	}
 }
@@ -204,6 +221,12 @@ func (conn *Conn) Start() {
 //	Method(T) (R, error)
 //	Method(T) error
 //
+// Any of these methods may also take a context.Context as its first
+// argument. The context is done when the client stops waiting for the
+// reply, either because the timeout it gave in the request header has
+// passed or because the connection has been closed, so that methods
+// may abandon work whose result would never be seen.
+//
 // If transformErrors is non-nil, it will be called on all returned
 // non-nil errors, for example to transform the errors into ServerErrors
 // with specified codes.  There will be a panic if transformErrors
@@ -320,6 +343,7 @@ type Killer interface {
 // appropriately.
 func (conn *Conn) input() {
 	err := conn.loop()
+	conn.cancel()
 	conn.sending.Lock()
 	defer conn.sending.Unlock()
 	conn.mutex.Lock()
@@ -487,7 +511,9 @@ func (conn *Conn) bindRequest(hdr *Header) (boundRequest, error) {
 // runRequest runs the given request and sends the reply.
 func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, observer Observer) {
 	defer conn.srvPending.Done()
-	rv, err := req.Call(req.hdr.Request.Id, arg)
+	ctx, cancel := conn.requestContext(req.hdr)
+	defer cancel()
+	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
 	if err != nil {
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), observer)
 	} else {
@@ -511,6 +537,15 @@ func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, o
 	}
 }
 
+// requestContext returns the context for the server request with the
+// given header, which is done once the request's timeout has passed.
+func (conn *Conn) requestContext(hdr Header) (context.Context, context.CancelFunc) {
+	if hdr.Timeout > 0 {
+		return context.WithTimeout(conn.ctx, hdr.Timeout)
+	}
+	return context.WithCancel(conn.ctx)
+}
+
 type serverError struct {
 	error
 }
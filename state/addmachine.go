@@ -91,6 +91,11 @@ type MachineTemplate struct {
 	// with the machine.
 	Placement string
 
+	// CloudInitUserData holds additional cloud-init user-data, in
+	// YAML, to be merged with the user-data generated for the machine
+	// when it is provisioned.
+	CloudInitUserData string
+
 	// principals holds the principal units that will
 	// associated with the machine.
 	principals []string
@@ -271,7 +276,10 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	mdoc, err := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -349,7 +357,10 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
@@ -399,7 +410,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 		}
 	}
 
-	parentDoc := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	parentDoc, err := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	newId, err := st.newContainerId(parentDoc.Id, containerType)
 	if err != nil {
 		return nil, nil, err
@@ -408,7 +422,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
 	if err != nil {
@@ -428,13 +445,17 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	return mdoc, append(prereqOps, parentOp, machineOp), nil
 }
 
-func (st *State) machineDocForTemplate(template MachineTemplate, id string) *machineDoc {
+func (st *State) machineDocForTemplate(template MachineTemplate, id string) (*machineDoc, error) {
+	mode, err := st.ipv6Mode()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	// We ignore the error from Select*Address as an error indicates
 	// no address is available, in which case the empty address is returned
 	// and setting the preferred address to an empty one is the correct
 	// thing to do when none is available.
-	privateAddr, _ := network.SelectInternalAddress(template.Addresses, false)
-	publicAddr, _ := network.SelectPublicAddress(template.Addresses)
+	privateAddr, _ := mode.SelectInternalAddress(template.Addresses, false)
+	publicAddr, _ := mode.SelectPublicAddress(template.Addresses)
 	logger.Infof(
 		"new machine %q has preferred addresses: private %q, public %q",
 		id, privateAddr, publicAddr,
@@ -454,7 +475,8 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
-	}
+		CloudInitUserData:       template.CloudInitUserData,
+	}, nil
 }
 
 // insertNewMachineOps returns operations to insert the given machine document
@@ -168,6 +168,14 @@ func allCollections() collectionSchema {
 		// are inherited and then forked by new models.
 		globalSettingsC: {global: true},
 
+		// This collection holds named model templates: model config
+		// and constraints which may be applied to new models.
+		modelTemplatesC: {global: true},
+
+		// This collection holds per-user quotas on the machines,
+		// units and storage in the models each user owns.
+		userQuotasC: {global: true},
+
 		// This collection holds workload metrics reported by certain charms
 		// for passing onward to other tools.
 		metricsC: {global: true},
@@ -205,6 +213,12 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection holds the most recent dependency engine
+		// report sent by each agent in the model.
+		agentEngineReportsC: {
+			rawAccess: true,
+		},
+
 		// -----------------
 
 		// Local collections
@@ -327,6 +341,13 @@ func allCollections() collectionSchema {
 		ipAddressesC:          {},
 		endpointBindingsC:     {},
 		openedPortsC:          {},
+		staticAddressesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "machine-id"},
+			}, {
+				Key: []string{"model-uuid", "subnet-cidr"},
+			}},
+		},
 
 		// -----
 
@@ -340,6 +361,17 @@ func allCollections() collectionSchema {
 
 		// -----
 
+		// These collections hold the values charms store as secrets,
+		// and the per-model keys used to encrypt them.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application"},
+			}},
+		},
+		secretKeysC: {},
+
+		// -----
+
 		// This collection holds information associated with charm payloads.
 		payloadsC: {
 			indexes: []mgo.Index{{
@@ -378,6 +410,10 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "globalkey", "updated"},
+			}, {
+				// Used by ModelStatusHistory, which queries
+				// across all of a model's entities.
+				Key: []string{"model-uuid", "updated"},
 			}},
 		},
 
@@ -476,6 +512,7 @@ const (
 	modelUsersC              = "modelusers"
 	modelsC                  = "models"
 	modelEntityRefsC         = "modelEntityRefs"
+	modelTemplatesC          = "modelTemplates"
 	openedPortsC             = "openedPorts"
 	payloadsC                = "payloads"
 	permissionsC             = "permissions"
@@ -484,11 +521,14 @@ const (
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	secretKeysC              = "secretKeys"
+	secretsC                 = "secrets"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
 	settingsC                = "settings"
 	refcountsC               = "refcounts"
+	agentEngineReportsC      = "agentenginereports"
 	sshHostKeysC             = "sshhostkeys"
 	spacesC                  = "spaces"
 	statusesC                = "statuses"
@@ -500,12 +540,14 @@ const (
 	linkLayerDevicesC        = "linklayerdevices"
 	linkLayerDevicesRefsC    = "linklayerdevicesrefs"
 	ipAddressesC             = "ip.addresses"
+	staticAddressesC         = "staticaddresses"
 	toolsmetadataC           = "toolsmetadata"
 	txnLogC                  = "txns.log"
 	txnsC                    = "txns"
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
 	userLastLoginC           = "userLastLogin"
+	userQuotasC              = "userQuotas"
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
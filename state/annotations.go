@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/juju/errors"
@@ -86,6 +87,36 @@ func (st *State) Annotations(entity GlobalEntity) (map[string]string, error) {
 	return doc.Annotations, nil
 }
 
+// AnnotationsByKind returns the annotations of all the entities of the
+// given kinds (such as "application" or "unit") that have annotations,
+// keyed by the entities' tags. If no kinds are given, the annotations
+// of all entities are returned.
+func (st *State) AnnotationsByKind(kinds ...string) (map[string]map[string]string, error) {
+	annotations, closer := st.getCollection(annotationsC)
+	defer closer()
+
+	query := bson.M{}
+	if len(kinds) > 0 {
+		patterns := make([]string, len(kinds))
+		for i, kind := range kinds {
+			patterns[i] = regexp.QuoteMeta(kind)
+		}
+		query["tag"] = bson.M{"$regex": "^(" + strings.Join(patterns, "|") + ")-"}
+	}
+	var docs []annotatorDoc
+	if err := annotations.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get annotations")
+	}
+	result := make(map[string]map[string]string)
+	for _, doc := range docs {
+		if len(doc.Annotations) == 0 {
+			continue
+		}
+		result[doc.Tag] = doc.Annotations
+	}
+	return result, nil
+}
+
 // Annotation returns the annotation value corresponding to the given key.
 // If the requested annotation is not found, an empty string is returned.
 func (st *State) Annotation(entity GlobalEntity, key string) (string, error) {
@@ -134,6 +134,32 @@ func (s *AnnotationsSuite) TestSetAnnotationsConcurrently(c *gc.C) {
 	assertAnnotation(c, s.State, s.testEntity, key, last)
 }
 
+func (s *AnnotationsSuite) TestAnnotationsByKind(c *gc.C) {
+	s.assertSetAnnotation(c, "gui-x", "10")
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.State.SetAnnotations(app, map[string]string{"gui-x": "20"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	annotations, err := s.State.AnnotationsByKind(names.ApplicationTagKind)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annotations, jc.DeepEquals, map[string]map[string]string{
+		"application-wordpress": {"gui-x": "20"},
+	})
+
+	annotations, err = s.State.AnnotationsByKind()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annotations, jc.DeepEquals, map[string]map[string]string{
+		"application-wordpress":     {"gui-x": "20"},
+		s.testEntity.Tag().String(): {"gui-x": "10"},
+	})
+
+	// Entities whose annotations have all been removed are omitted.
+	s.assertSetAnnotation(c, "gui-x", "")
+	annotations, err = s.State.AnnotationsByKind(names.MachineTagKind)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annotations, gc.HasLen, 0)
+}
+
 type AnnotationsEnvSuite struct {
 	ConnSuite
 }
@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -49,9 +50,19 @@ type applicationDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	ExposedCIDRs         []string   `bson:"exposed-cidrs,omitempty"`
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+	LeadershipPinned     bool       `bson:"leadership-pinned,omitempty"`
+
+	// CharmRollback is set while a charm upgrade made with
+	// automatic rollback may still be reverted.
+	CharmRollback *charmRollbackDoc `bson:"charm-rollback,omitempty"`
+
+	// StagedConfig holds config changes that have been staged but
+	// not yet committed.
+	StagedConfig *stagedConfigDoc `bson:"staged-config,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -301,6 +312,11 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
 	)
+	secretOps, err := removeApplicationSecretsOps(a.st, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretOps...)
 	return ops, nil
 }
 
@@ -311,29 +327,97 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedCIDRs returns the CIDRs from which the explicitly open ports of
+// an exposed application may be accessed. No CIDRs means the ports may be
+// accessed from anywhere. See SetExposedToCIDRs.
+func (a *Application) ExposedCIDRs() []string {
+	return a.doc.ExposedCIDRs
+}
+
+// SetExposed marks the application as exposed, allowing access to its
+// open ports from anywhere.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
-	return a.setExposed(true)
+	return a.setExposed(true, nil)
+}
+
+// SetExposedToCIDRs marks the application as exposed, only allowing
+// access to its open ports from the given CIDRs. Calling it with no
+// CIDRs is the same as calling SetExposed.
+// See ClearExposed, IsExposed and ExposedCIDRs.
+func (a *Application) SetExposedToCIDRs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	return a.setExposed(true, cidrs)
 }
 
 // ClearExposed removes the exposed flag from the application.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
-	return a.setExposed(false)
+	return a.setExposed(false, nil)
 }
 
-func (a *Application) setExposed(exposed bool) (err error) {
+func (a *Application) setExposed(exposed bool, cidrs []string) (err error) {
+	var update bson.D
+	if len(cidrs) > 0 {
+		update = bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-cidrs", cidrs},
+		}}}
+	} else {
+		update = bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-cidrs", nil}}},
+		}
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.runTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedCIDRs = cidrs
+	return nil
+}
+
+// LeadershipPinned returns whether the application's leadership is
+// pinned. See PinLeadership and UnpinLeadership.
+func (a *Application) LeadershipPinned() bool {
+	return a.doc.LeadershipPinned
+}
+
+// PinLeadership prevents the application's leadership lease from
+// expiring, so that its current leader remains leader even if it
+// stops renewing the lease, until UnpinLeadership is called. It is
+// intended to avoid leadership changes during maintenance.
+func (a *Application) PinLeadership() error {
+	return a.setLeadershipPinned(true)
+}
+
+// UnpinLeadership allows the application's leadership lease to expire
+// again once its leader stops renewing it.
+func (a *Application) UnpinLeadership() error {
+	return a.setLeadershipPinned(false)
+}
+
+func (a *Application) setLeadershipPinned(pinned bool) error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"leadership-pinned", pinned}}}},
+	}}
+	if err := a.st.runTransaction(ops); err != nil {
+		return errors.Errorf("cannot set leadership pinned flag for application %q to %v: %v", a, pinned, onAbort(err, errNotAlive))
+	}
+	a.doc.LeadershipPinned = pinned
 	return nil
 }
 
@@ -855,6 +939,10 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// AutoRollback, if set, causes the upgrade to be reverted if
+	// too many units fail to upgrade; see CheckCharmRollback.
+	AutoRollback *CharmRollbackPolicy
 }
 
 // SetCharm changes the charm for the application.
@@ -918,8 +1006,14 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err != nil {
 		return errors.Annotate(err, "validating config settings")
 	}
+	if cfg.AutoRollback != nil {
+		if err := cfg.AutoRollback.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	var newCharmModifiedVersion int
+	var newCharmRollback *charmRollbackDoc
 	channel := string(cfg.Channel)
 	acopy := &Application{a.st, a.doc}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -944,6 +1038,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		// structure. We increment the version only when we change the
 		// charm URL.
 		newCharmModifiedVersion = a.doc.CharmModifiedVersion
+		newCharmRollback = a.doc.CharmRollback
 
 		ops := []txn.Op{{
 			C:  applicationsC,
@@ -977,6 +1072,19 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			}
 			ops = append(ops, chng...)
 			newCharmModifiedVersion++
+
+			// Record how to undo the upgrade, or forget how to
+			// undo an earlier one.
+			rollbackUpdate, rollbackDoc, err := a.charmRollbackUpdate(cfg.AutoRollback)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, txn.Op{
+				C:      applicationsC,
+				Id:     a.doc.DocID,
+				Update: rollbackUpdate,
+			})
+			newCharmRollback = rollbackDoc
 		}
 
 		return ops, nil
@@ -988,6 +1096,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
 	a.doc.CharmModifiedVersion = newCharmModifiedVersion
+	a.doc.CharmRollback = newCharmRollback
 	return nil
 }
 
@@ -1454,6 +1454,81 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestServiceExposedToCIDRs(c *gc.C) {
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	err := s.mysql.SetExposedToCIDRs([]string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	// Exposing without CIDRs removes the restriction.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	err = s.mysql.SetExposedToCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestPinLeadership(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	c.Assert(s.mysql.LeadershipPinned(), jc.IsFalse)
+
+	err := s.mysql.PinLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LeadershipPinned(), jc.IsTrue)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LeadershipPinned(), jc.IsTrue)
+
+	// Pinning is idempotent.
+	err = s.mysql.PinLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+
+	pinned, err := state.PinnedLeadership(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pinned.SortedValues(), jc.DeepEquals, []string{"mysql"})
+
+	err = wordpress.PinLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.UnpinLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LeadershipPinned(), jc.IsFalse)
+
+	pinned, err = state.PinnedLeadership(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pinned.SortedValues(), jc.DeepEquals, []string{"wordpress"})
+}
+
+func (s *ApplicationSuite) TestPinLeadershipNotAlive(c *gc.C) {
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.PinLeadership()
+	c.Assert(err, gc.ErrorMatches, `cannot set leadership pinned flag for application "mysql" to true: not found or not alive`)
+}
+
+func (s *ApplicationSuite) TestSetExposedToInvalidCIDR(c *gc.C) {
+	err := s.mysql.SetExposedToCIDRs([]string{"10.0.0.0/8", "nonsense"})
+	c.Assert(err, gc.ErrorMatches, `CIDR "nonsense" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -40,6 +40,10 @@ type applicationOfferDoc struct {
 
 	// Endpoints are the charm endpoints supported by the applicationbob.
 	Endpoints []remoteEndpointDoc `bson:"endpoints"`
+
+	// AllowedUsers holds the ids of the users and groups that
+	// are allowed to consume the offer.
+	AllowedUsers []string `bson:"allowed-users,omitempty"`
 }
 
 var _ crossmodel.ApplicationDirectory = (*applicationDirectory)(nil)
@@ -190,13 +194,22 @@ func (s *applicationDirectory) UpdateOffer(offer crossmodel.ApplicationOffer) (e
 				return nil, errors.Trace(err)
 			}
 		}
+		// The offer's access list is managed separately via
+		// GrantAccess and RevokeAccess, so it is left untouched.
 		ops := []txn.Op{
 			model.assertActiveOp(),
 			{
 				C:      localApplicationDirectoryC,
 				Id:     doc.DocID,
 				Assert: txn.DocExists,
-				Update: doc,
+				Update: bson.D{{"$set", bson.D{
+					{"url", doc.URL},
+					{"source-model-uuid", doc.SourceModelUUID},
+					{"source-label", doc.SourceLabel},
+					{"application-name", doc.ApplicationName},
+					{"application-description", doc.ApplicationDescription},
+					{"endpoints", doc.Endpoints},
+				}}},
 			},
 		}
 		return ops, nil
@@ -205,6 +218,34 @@ func (s *applicationDirectory) UpdateOffer(offer crossmodel.ApplicationOffer) (e
 	return errors.Trace(err)
 }
 
+// GrantAccess allows the specified users to consume the
+// application offer at url.
+func (s *applicationDirectory) GrantAccess(url string, users ...string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot grant access to application offer %q", url)
+	return s.modifyAccess(url, bson.D{{"$addToSet", bson.D{{"allowed-users", bson.D{{"$each", users}}}}}})
+}
+
+// RevokeAccess prevents the specified users from consuming the
+// application offer at url.
+func (s *applicationDirectory) RevokeAccess(url string, users ...string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot revoke access to application offer %q", url)
+	return s.modifyAccess(url, bson.D{{"$pullAll", bson.D{{"allowed-users", users}}}})
+}
+
+func (s *applicationDirectory) modifyAccess(url string, update bson.D) error {
+	ops := []txn.Op{{
+		C:      localApplicationDirectoryC,
+		Id:     url,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	err := s.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("application offer %q", url)
+	}
+	return errors.Trace(err)
+}
+
 func (s *applicationDirectory) makeApplicationOfferDoc(offer crossmodel.ApplicationOffer) applicationOfferDoc {
 	doc := applicationOfferDoc{
 		DocID:                  offer.ApplicationURL,
@@ -249,6 +290,10 @@ func (s *applicationDirectory) makeFilterTerm(filterTerm crossmodel.ApplicationO
 		desc := regexp.QuoteMeta(filterTerm.ApplicationDescription)
 		filter = append(filter, bson.DocElem{"application-description", bson.D{{"$regex", fmt.Sprintf(".*%s.*", desc)}}})
 	}
+	// We match offers that any of the specified users may consume.
+	if len(filterTerm.AllowedUsers) > 0 {
+		filter = append(filter, bson.DocElem{"allowed-users", bson.D{{"$in", filterTerm.AllowedUsers}}})
+	}
 	return filter
 }
 
@@ -312,3 +312,62 @@ func (s *remoteApplicationSuite) TestUpdateApplicationOfferRemovedAfterInitial(c
 	})
 	c.Assert(err, gc.ErrorMatches, `cannot update application offer "mysql": application offer "local:/u/me/application" not found`)
 }
+
+func (s *applicationDirectorySuite) TestGrantRevokeAccess(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	sd := state.NewApplicationDirectory(s.State)
+	err := sd.GrantAccess(offer.ApplicationURL, "fred", "mary")
+	c.Assert(err, jc.ErrorIsNil)
+	err = sd.GrantAccess(offer.ApplicationURL, "fred", "everyone@external")
+	c.Assert(err, jc.ErrorIsNil)
+	doc, err := state.OfferAtURL(sd, offer.ApplicationURL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.AllowedUsers, jc.DeepEquals, []string{"fred", "mary", "everyone@external"})
+
+	err = sd.RevokeAccess(offer.ApplicationURL, "mary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	doc, err = state.OfferAtURL(sd, offer.ApplicationURL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.AllowedUsers, jc.DeepEquals, []string{"fred", "everyone@external"})
+}
+
+func (s *applicationDirectorySuite) TestGrantAccessNotFound(c *gc.C) {
+	sd := state.NewApplicationDirectory(s.State)
+	err := sd.GrantAccess("local:/u/me/application", "fred")
+	c.Assert(err, gc.ErrorMatches, `cannot grant access to application offer "local:/u/me/application": application offer "local:/u/me/application" not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *applicationDirectorySuite) TestUpdateOfferPreservesAccess(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	sd := state.NewApplicationDirectory(s.State)
+	err := sd.GrantAccess(offer.ApplicationURL, "fred")
+	c.Assert(err, jc.ErrorIsNil)
+	offer.ApplicationDescription = "updated"
+	err = sd.UpdateOffer(offer)
+	c.Assert(err, jc.ErrorIsNil)
+	doc, err := state.OfferAtURL(sd, offer.ApplicationURL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.ApplicationDescription, gc.Equals, "updated")
+	c.Assert(doc.AllowedUsers, jc.DeepEquals, []string{"fred"})
+}
+
+func (s *applicationDirectorySuite) TestListOffersFilterAllowedUsers(c *gc.C) {
+	offer := s.createOffer(c, "offer1", "description for offer1", "uuid-1", "label")
+	s.createOffer(c, "offer2", "description for offer2", "uuid-2", "label")
+	sd := state.NewApplicationDirectory(s.State)
+	err := sd.GrantAccess(offer.ApplicationURL, "fred")
+	c.Assert(err, jc.ErrorIsNil)
+
+	offers, err := sd.ListOffers(crossmodel.ApplicationOfferFilter{
+		AllowedUsers: []string{"mary", "fred"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, jc.DeepEquals, []crossmodel.ApplicationOffer{offer})
+
+	offers, err = sd.ListOffers(crossmodel.ApplicationOfferFilter{
+		AllowedUsers: []string{"mary"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, gc.HasLen, 0)
+}
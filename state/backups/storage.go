@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/objectstore"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/filestorage"
 	"github.com/juju/version"
@@ -273,8 +274,8 @@ func (b *storageDBWrapper) runTransaction(ops []txn.Op) error {
 }
 
 // blobStorage returns a ManagedStorage matching the env storage and the blobDB.
-func (b *storageDBWrapper) blobStorage(blobDB string) blobstore.ManagedStorage {
-	dataStore := blobstore.NewGridFS(blobDB, blobDB, b.session)
+func (b *storageDBWrapper) blobStorage(blobDB string, stores *objectstore.Stores) blobstore.ManagedStorage {
+	dataStore := stores.ResourceStorage(blobDB, b.session)
 	return blobstore.NewManagedStorage(b.db, dataStore)
 }
 
@@ -480,10 +481,10 @@ type backupBlobStorage struct {
 	root      string
 }
 
-func newFileStorage(dbWrap *storageDBWrapper, root string) filestorage.RawFileStorage {
+func newFileStorage(dbWrap *storageDBWrapper, root string, stores *objectstore.Stores) filestorage.RawFileStorage {
 	dbWrap = dbWrap.Copy()
 
-	managed := dbWrap.blobStorage(dbWrap.db.Name)
+	managed := dbWrap.blobStorage(dbWrap.db.Name, stores)
 	stor := backupBlobStorage{
 		dbWrap:    dbWrap,
 		modelUUID: dbWrap.modelUUID,
@@ -546,6 +547,10 @@ type DB interface {
 
 	// StateServingInfo is the secrets of the controller.
 	StateServingInfo() (state.StateServingInfo, error)
+
+	// ObjectStores returns the object stores in which the
+	// controller's blobs are stored.
+	ObjectStores() *objectstore.Stores
 }
 
 // NewStorage returns a new FileStorage to use for storing backup
@@ -556,7 +561,7 @@ func NewStorage(st DB) filestorage.FileStorage {
 	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
 	defer dbWrap.Close()
 
-	files := newFileStorage(dbWrap, backupStorageRoot)
+	files := newFileStorage(dbWrap, backupStorageRoot, st.ObjectStores())
 	docs := newMetadataStorage(dbWrap)
 	return filestorage.NewFileStorage(docs, files)
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/binarystorage"
+	"github.com/juju/juju/state/objectstore"
 )
 
 var binarystorageNew = binarystorage.New
@@ -70,13 +71,13 @@ func (st *State) newBinaryStorageCloser(collectionName, uuid string) binarystora
 		closer2()
 		closer1()
 	}
-	storage := newBinaryStorage(uuid, metadataCollection, txnRunner)
+	storage := newBinaryStorage(uuid, metadataCollection, txnRunner, st.objectStores)
 	return &storageCloser{storage, closer}
 }
 
-func newBinaryStorage(uuid string, metadataCollection mongo.Collection, txnRunner jujutxn.Runner) binarystorage.Storage {
+func newBinaryStorage(uuid string, metadataCollection mongo.Collection, txnRunner jujutxn.Runner, stores *objectstore.Stores) binarystorage.Storage {
 	db := metadataCollection.Writeable().Underlying().Database
-	rs := blobstore.NewGridFS(blobstoreDB, blobstoreDB, db.Session)
+	rs := stores.ResourceStorage(blobstoreDB, db.Session)
 	managedStorage := blobstore.NewManagedStorage(db, rs)
 	return binarystorageNew(uuid, managedStorage, metadataCollection, txnRunner)
 }
@@ -16,6 +16,8 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/charmfeatures"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/storage"
 	jujuversion "github.com/juju/juju/version"
@@ -86,15 +88,25 @@ type charmDoc struct {
 	Config  *charm.Config  `bson:"config"`
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
+
+	// LXDProfile holds the LXD profile shipped with the charm, if
+	// any, with config and device keys escaped as for Config.
+	LXDProfile *lxdprofile.Profile `bson:"lxd-profile,omitempty"`
+
+	// JujuFeatures holds the juju features the charm declares it
+	// requires.
+	JujuFeatures []string `bson:"juju-features,omitempty"`
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
 type CharmInfo struct {
-	Charm       charm.Charm
-	ID          *charm.URL
-	StoragePath string
-	SHA256      string
-	Macaroon    macaroon.Slice
+	Charm        charm.Charm
+	ID           *charm.URL
+	StoragePath  string
+	SHA256       string
+	Macaroon     macaroon.Slice
+	LXDProfile   *lxdprofile.Profile
+	JujuFeatures []string
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -111,6 +123,8 @@ func insertCharmOps(st *State, info CharmInfo) ([]txn.Op, error) {
 		Config:       safeConfig(info.Charm),
 		Metrics:      info.Charm.Metrics(),
 		Actions:      info.Charm.Actions(),
+		LXDProfile:   escapeLXDProfile(info.LXDProfile),
+		JujuFeatures: info.JujuFeatures,
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
 	}
@@ -220,6 +234,12 @@ func updateCharmOps(
 		{"pendingupload", false},
 		{"placeholder", false},
 	}
+	if info.LXDProfile != nil {
+		data = append(data, bson.DocElem{"lxd-profile", escapeLXDProfile(info.LXDProfile)})
+	}
+	if len(info.JujuFeatures) > 0 {
+		data = append(data, bson.DocElem{"juju-features", info.JujuFeatures})
+	}
 	if len(info.Macaroon) > 0 {
 		mac, err := info.Macaroon.MarshalBinary()
 		if err != nil {
@@ -304,6 +324,45 @@ func safeConfig(ch charm.Charm) *charm.Config {
 	return escapedConfig
 }
 
+// escapeLXDProfile returns a copy of the given profile with
+// mongo-significant characters in its config and device keys
+// escaped. LXD config keys are namespaced with dots, as in
+// "security.nesting", so this is not optional.
+func escapeLXDProfile(profile *lxdprofile.Profile) *lxdprofile.Profile {
+	return replaceLXDProfileKeys(profile, escapeReplacer.Replace)
+}
+
+// unescapeLXDProfile reverses escapeLXDProfile.
+func unescapeLXDProfile(profile *lxdprofile.Profile) *lxdprofile.Profile {
+	return replaceLXDProfileKeys(profile, unescapeReplacer.Replace)
+}
+
+func replaceLXDProfileKeys(profile *lxdprofile.Profile, replace func(string) string) *lxdprofile.Profile {
+	if profile == nil {
+		return nil
+	}
+	result := &lxdprofile.Profile{
+		Description: profile.Description,
+	}
+	if profile.Config != nil {
+		result.Config = make(map[string]string)
+		for key, value := range profile.Config {
+			result.Config[replace(key)] = value
+		}
+	}
+	if profile.Devices != nil {
+		result.Devices = make(map[string]map[string]string)
+		for name, device := range profile.Devices {
+			replaced := make(map[string]string)
+			for key, value := range device {
+				replaced[replace(key)] = value
+			}
+			result.Devices[replace(name)] = replaced
+		}
+	}
+	return result
+}
+
 // Charm represents the state of a charm in the model.
 type Charm struct {
 	st  *State
@@ -322,6 +381,9 @@ func newCharm(st *State, cdoc *charmDoc) *Charm {
 		}
 		cdoc.Config = unescapedConfig
 	}
+	if cdoc != nil {
+		cdoc.LXDProfile = unescapeLXDProfile(cdoc.LXDProfile)
+	}
 	ch := Charm{st: st, doc: *cdoc}
 	return &ch
 }
@@ -381,7 +443,7 @@ func (c *Charm) Remove() error {
 		return nil
 	}
 
-	stor := storage.NewStorage(c.st.ModelUUID(), c.st.MongoSession())
+	stor := storage.NewStorage(c.st.ModelUUID(), c.st.MongoSession(), c.st.objectStores)
 	err := stor.Remove(c.doc.StoragePath)
 	if errors.IsNotFound(err) {
 		// Not a problem, but we might still need to run the
@@ -456,6 +518,18 @@ func (c *Charm) Actions() *charm.Actions {
 	return c.doc.Actions
 }
 
+// LXDProfile returns the LXD profile shipped with the charm, or nil
+// if it does not have one.
+func (c *Charm) LXDProfile() *lxdprofile.Profile {
+	return c.doc.LXDProfile
+}
+
+// JujuFeatures returns the juju features the charm declares it
+// requires.
+func (c *Charm) JujuFeatures() []string {
+	return c.doc.JujuFeatures
+}
+
 // StoragePath returns the storage path of the charm bundle.
 func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
@@ -520,6 +594,9 @@ func (st *State) AddCharm(info CharmInfo) (stch *Charm, err error) {
 	if err := validateCharmVersion(info.Charm); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := charmfeatures.Check(info.JujuFeatures); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	query := charms.FindId(info.ID.String()).Select(bson.D{{"placeholder", 1}})
 
@@ -793,6 +870,9 @@ func (st *State) UpdateUploadedCharm(info CharmInfo) (*Charm, error) {
 	if !doc.PendingUpload {
 		return nil, errors.Trace(&ErrCharmAlreadyUploaded{info.ID})
 	}
+	if err := charmfeatures.Check(info.JujuFeatures); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	ops, err := updateCharmOps(st, info, stillPending)
 	if err != nil {
@@ -17,6 +17,8 @@ import (
 	"gopkg.in/macaroon.v1"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/core/charmfeatures"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
@@ -156,7 +158,7 @@ func (s *CharmSuite) TestRemoveDeletesStorage(c *gc.C) {
 	// We normally don't actually set up charm storage in state
 	// tests, but we need it here.
 	path := s.charm.StoragePath()
-	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession(), s.State.ObjectStores())
 	err := stor.Put(path, strings.NewReader("abc"), 3)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -297,6 +299,52 @@ func (s *CharmSuite) TestAddCharmWithAuth(c *gc.C) {
 	c.Assert(ms, gc.DeepEquals, info.Macaroon)
 }
 
+func (s *CharmSuite) TestAddCharmWithLXDProfile(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	info.LXDProfile = &lxdprofile.Profile{
+		Config: map[string]string{"security.nesting": "true"},
+		Devices: map[string]map[string]string{
+			"tun": {"type": "unix-char", "path": "/dev/net/tun"},
+		},
+	}
+	_, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The dots in LXD config keys are escaped for mongo, and
+	// unescaped again when the charm is read.
+	doc := state.CharmDoc{}
+	err = s.charms.FindId(state.DocID(s.State, info.ID.String())).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.LXDProfile.Config, jc.DeepEquals, map[string]string{"security\uff0enesting": "true"})
+
+	dummy, err := s.State.Charm(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.LXDProfile(), jc.DeepEquals, info.LXDProfile)
+}
+
+func (s *CharmSuite) TestAddCharmWithJujuFeatures(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	info.JujuFeatures = []string{"leadership", "storage"}
+	dummy, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.JujuFeatures(), jc.DeepEquals, info.JujuFeatures)
+
+	dummy, err = s.State.Charm(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.JujuFeatures(), jc.DeepEquals, info.JujuFeatures)
+}
+
+func (s *CharmSuite) TestAddCharmWithUnsupportedJujuFeatures(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	info.JujuFeatures = []string{"leadership", "teleport"}
+	_, err := s.State.AddCharm(info)
+	c.Assert(err, jc.Satisfies, charmfeatures.IsUnsupportedError)
+	c.Assert(err, gc.ErrorMatches, "charm requires juju feature not supported by this controller: teleport; .*")
+
+	_, err = s.State.Charm(info.ID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *CharmSuite) TestAddCharmUpdatesPlaceholder(c *gc.C) {
 	// Check that adding charms updates any existing placeholder charm
 	// with the same URL.
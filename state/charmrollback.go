@@ -0,0 +1,213 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// upgradeCharmHook is the name of the hook whose failures count
+// towards an automatic charm rollback.
+const upgradeCharmHook = "upgrade-charm"
+
+// CharmRollbackPolicy controls when a charm upgrade is automatically
+// reverted.
+type CharmRollbackPolicy struct {
+	// Threshold is the percentage of the application's units that
+	// must fail the upgrade-charm hook for the upgrade to be reverted.
+	Threshold int
+
+	// Window is how long after the upgrade hook failures are
+	// watched for. Once it has passed the upgrade is kept.
+	Window time.Duration
+}
+
+// Validate returns an error if the policy is not valid.
+func (p CharmRollbackPolicy) Validate() error {
+	if p.Threshold < 0 || p.Threshold >= 100 {
+		return errors.NotValidf("rollback threshold %d%%", p.Threshold)
+	}
+	if p.Window <= 0 {
+		return errors.NotValidf("rollback window %v", p.Window)
+	}
+	return nil
+}
+
+// CharmRollback records a charm upgrade that will be reverted if too
+// many of the application's units fail to upgrade.
+type CharmRollback struct {
+	CharmRollbackPolicy
+
+	// PreviousCharmURL, PreviousChannel and PreviousSettings record
+	// the charm and config the application had before the upgrade.
+	PreviousCharmURL *charm.URL
+	PreviousChannel  csparams.Channel
+	PreviousSettings charm.Settings
+
+	// Started holds the time of the upgrade.
+	Started time.Time
+}
+
+// charmRollbackDoc is the form in which a CharmRollback is stored on
+// the application document.
+type charmRollbackDoc struct {
+	PreviousCharmURL *charm.URL             `bson:"previous-charmurl"`
+	PreviousChannel  string                 `bson:"previous-cs-channel"`
+	PreviousSettings map[string]interface{} `bson:"previous-settings"`
+	Started          int64                  `bson:"started"`
+	Threshold        int                    `bson:"threshold"`
+	Window           int64                  `bson:"window"`
+}
+
+// CharmRollback returns the details of the application's last charm
+// upgrade if it was made with automatic rollback and has been neither
+// reverted nor kept yet.
+func (a *Application) CharmRollback() (CharmRollback, bool) {
+	doc := a.doc.CharmRollback
+	if doc == nil {
+		return CharmRollback{}, false
+	}
+	return CharmRollback{
+		CharmRollbackPolicy: CharmRollbackPolicy{
+			Threshold: doc.Threshold,
+			Window:    time.Duration(doc.Window),
+		},
+		PreviousCharmURL: doc.PreviousCharmURL,
+		PreviousChannel:  csparams.Channel(doc.PreviousChannel),
+		PreviousSettings: copyMap(doc.PreviousSettings, unescapeReplacer.Replace),
+		Started:          time.Unix(0, doc.Started),
+	}, true
+}
+
+// charmRollbackUpdate returns the update that records, or with a nil
+// policy forgets, the rollback details for an upgrade from the
+// application's current charm.
+func (a *Application) charmRollbackUpdate(policy *CharmRollbackPolicy) (bson.D, *charmRollbackDoc, error) {
+	if policy == nil {
+		return bson.D{{"$unset", bson.D{{"charm-rollback", nil}}}}, nil, nil
+	}
+	var previousSettings map[string]interface{}
+	settings, err := readSettings(a.st, settingsC, a.settingsKey())
+	if err == nil {
+		previousSettings = copyMap(settings.Map(), escapeReplacer.Replace)
+	} else if !errors.IsNotFound(err) {
+		return nil, nil, errors.Trace(err)
+	}
+	doc := &charmRollbackDoc{
+		PreviousCharmURL: a.doc.CharmURL,
+		PreviousChannel:  a.doc.Channel,
+		PreviousSettings: previousSettings,
+		Started:          a.st.clock.Now().UnixNano(),
+		Threshold:        policy.Threshold,
+		Window:           int64(policy.Window),
+	}
+	return bson.D{{"$set", bson.D{{"charm-rollback", doc}}}}, doc, nil
+}
+
+// clearCharmRollback forgets the application's pending charm rollback,
+// keeping its current charm.
+func (a *Application) clearCharmRollback() error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{{"charm-rollback", nil}}}},
+	}}
+	if err := a.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot clear charm rollback for application %q", a)
+	}
+	a.doc.CharmRollback = nil
+	return nil
+}
+
+// CheckCharmRollback reverts the application's last charm upgrade if
+// it was made with automatic rollback and, within the rollback window,
+// more than the threshold percentage of the application's units have
+// failed the upgrade-charm hook. Once the window has passed without
+// that happening, the upgrade is kept. The outcome of a rollback is
+// reported in the application's status. CheckCharmRollback reports
+// whether the upgrade was reverted.
+func (a *Application) CheckCharmRollback() (bool, error) {
+	rollback, ok := a.CharmRollback()
+	if !ok {
+		return false, nil
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	failed := 0
+	for _, unit := range units {
+		info, err := getStatus(a.st, unit.globalAgentKey(), "agent")
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if info.Status == status.Error &&
+			info.Data["hook"] == upgradeCharmHook &&
+			info.Since != nil && !info.Since.Before(rollback.Started) {
+			failed++
+		}
+	}
+	if len(units) > 0 && failed*100 > rollback.Threshold*len(units) {
+		reason := fmt.Sprintf("%d of %d units failed the %s hook", failed, len(units), upgradeCharmHook)
+		return a.rollbackCharm(rollback, reason)
+	}
+	if a.st.clock.Now().Sub(rollback.Started) >= rollback.Window {
+		return false, errors.Trace(a.clearCharmRollback())
+	}
+	return false, nil
+}
+
+// rollbackCharm returns the application to the charm, channel and
+// config it had before its last upgrade, and records why in the
+// application's status. It reports whether the upgrade was reverted.
+func (a *Application) rollbackCharm(rollback CharmRollback, reason string) (bool, error) {
+	failedURL := a.doc.CharmURL
+	ch, err := a.st.Charm(rollback.PreviousCharmURL)
+	if errors.IsNotFound(err) {
+		// The previous charm has been cleaned up, so there is
+		// nothing to go back to.
+		if err := a.clearCharmRollback(); err != nil {
+			return false, errors.Trace(err)
+		}
+		return false, errors.Trace(a.setRollbackStatus(fmt.Sprintf(
+			"cannot roll back upgrade to %s (%s): charm %s is no longer available",
+			failedURL, reason, rollback.PreviousCharmURL,
+		)))
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	// The failed units are in error, so the previous charm must be
+	// forced onto them. SetCharm also forgets the rollback.
+	if err := a.SetCharm(SetCharmConfig{
+		Charm:          ch,
+		Channel:        rollback.PreviousChannel,
+		ConfigSettings: rollback.PreviousSettings,
+		ForceUnits:     true,
+	}); err != nil {
+		return false, errors.Trace(err)
+	}
+	message := fmt.Sprintf("upgrade to %s rolled back: %s", failedURL, reason)
+	return true, errors.Trace(a.setRollbackStatus(message))
+}
+
+func (a *Application) setRollbackStatus(message string) error {
+	now := a.st.clock.Now()
+	return a.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: message,
+		Since:   &now,
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type CharmRollbackSuite struct {
+	ConnSuite
+	clock    *jujutesting.Clock
+	oldCharm *state.Charm
+	newCharm *state.Charm
+	app      *state.Application
+	policy   state.CharmRollbackPolicy
+}
+
+var _ = gc.Suite(&CharmRollbackSuite{})
+
+func (s *CharmRollbackSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(truncateDBTime(time.Now()))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.oldCharm = s.AddConfigCharm(c, "wordpress", stringConfig, 1)
+	s.newCharm = s.AddConfigCharm(c, "wordpress", newStringConfig, 2)
+	s.app = s.AddTestingService(c, "wordpress", s.oldCharm)
+	err = s.app.UpdateConfigSettings(charm.Settings{"key": "old value"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.policy = state.CharmRollbackPolicy{Threshold: 50, Window: 10 * time.Minute}
+}
+
+func (s *CharmRollbackSuite) upgrade(c *gc.C) {
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCharm,
+		ConfigSettings: charm.Settings{"key": "new value"},
+		AutoRollback:   &s.policy,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRollbackSuite) addUnits(c *gc.C, n int) []*state.Unit {
+	units := make([]*state.Unit, n)
+	for i := range units {
+		unit, err := s.app.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		units[i] = unit
+	}
+	return units
+}
+
+func (s *CharmRollbackSuite) failUpgrade(c *gc.C, unit *state.Unit) {
+	now := s.clock.Now()
+	err := unit.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "upgrade-charm"`,
+		Data:    map[string]interface{}{"hook": "upgrade-charm"},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRollbackSuite) TestSetCharmRecordsRollback(c *gc.C) {
+	s.upgrade(c)
+	rollback, ok := s.app.CharmRollback()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(rollback.Started.Equal(s.clock.Now()), jc.IsTrue)
+	rollback.Started = time.Time{}
+	c.Assert(rollback, jc.DeepEquals, state.CharmRollback{
+		CharmRollbackPolicy: s.policy,
+		PreviousCharmURL:    s.oldCharm.URL(),
+		PreviousSettings:    charm.Settings{"key": "old value"},
+	})
+
+	err := s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.app.CharmRollback()
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *CharmRollbackSuite) TestSetCharmWithoutRollbackForgetsRollback(c *gc.C) {
+	s.upgrade(c)
+	err := s.app.SetCharm(state.SetCharmConfig{Charm: s.oldCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.app.CharmRollback()
+	c.Assert(ok, jc.IsFalse)
+
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.app.CharmRollback()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *CharmRollbackSuite) TestSetCharmInvalidPolicy(c *gc.C) {
+	s.policy.Threshold = 100
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:        s.newCharm,
+		AutoRollback: &s.policy,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "wordpress" to charm ".*": rollback threshold 100% not valid`)
+}
+
+func (s *CharmRollbackSuite) TestCheckCharmRollbackReverts(c *gc.C) {
+	units := s.addUnits(c, 3)
+	s.upgrade(c)
+	s.clock.Advance(time.Minute)
+	s.failUpgrade(c, units[0])
+	s.failUpgrade(c, units[2])
+
+	rolledBack, err := s.app.CheckCharmRollback()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rolledBack, jc.IsTrue)
+
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, force := s.app.CharmURL()
+	c.Assert(curl, jc.DeepEquals, s.oldCharm.URL())
+	c.Assert(force, jc.IsTrue)
+	settings, err := s.app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"key": "old value"})
+	_, ok := s.app.CharmRollback()
+	c.Assert(ok, jc.IsFalse)
+
+	statusInfo, err := s.app.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Blocked)
+	c.Assert(statusInfo.Message, gc.Equals,
+		"upgrade to "+s.newCharm.URL().String()+" rolled back: 2 of 3 units failed the upgrade-charm hook")
+}
+
+func (s *CharmRollbackSuite) TestCheckCharmRollbackIgnoresEarlierFailures(c *gc.C) {
+	units := s.addUnits(c, 2)
+	s.failUpgrade(c, units[0])
+	s.failUpgrade(c, units[1])
+	s.clock.Advance(time.Minute)
+	s.upgrade(c)
+
+	rolledBack, err := s.app.CheckCharmRollback()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rolledBack, jc.IsFalse)
+	_, ok := s.app.CharmRollback()
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *CharmRollbackSuite) TestCheckCharmRollbackKeepsUpgradeAfterWindow(c *gc.C) {
+	units := s.addUnits(c, 2)
+	s.upgrade(c)
+	s.clock.Advance(time.Minute)
+	// Half the units failing is not more than the threshold.
+	s.failUpgrade(c, units[1])
+
+	rolledBack, err := s.app.CheckCharmRollback()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rolledBack, jc.IsFalse)
+	_, ok := s.app.CharmRollback()
+	c.Assert(ok, jc.IsTrue)
+
+	s.clock.Advance(s.policy.Window)
+	rolledBack, err = s.app.CheckCharmRollback()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rolledBack, jc.IsFalse)
+	_, ok = s.app.CharmRollback()
+	c.Assert(ok, jc.IsFalse)
+	curl, _ := s.app.CharmURL()
+	c.Assert(curl, jc.DeepEquals, s.newCharm.URL())
+}
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -33,6 +34,21 @@ const (
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
 )
 
+const (
+	// cleanupRetryDelay is how long a cleanup waits before its second
+	// retry. A cleanup that fails once is retried on the next pass,
+	// since most failures are races with the changes that queued it;
+	// after that the delay doubles with each failure, up to
+	// cleanupMaxRetryDelay.
+	cleanupRetryDelay    = 30 * time.Second
+	cleanupMaxRetryDelay = 30 * time.Minute
+
+	// maxCleanupAttempts is the number of times a cleanup is run before
+	// it is dead-lettered: kept for inspection, but no longer run until
+	// it is explicitly retried.
+	maxCleanupAttempts = 10
+)
+
 // cleanupDoc originally represented a set of documents that should be
 // removed, but the Prefix field no longer means anything more than
 // "what will be passed to the cleanup func".
@@ -40,6 +56,57 @@ type cleanupDoc struct {
 	DocID  string      `bson:"_id"`
 	Kind   cleanupKind `bson:"kind"`
 	Prefix string      `bson:"prefix"`
+
+	// The remaining fields record failed attempts to run the cleanup,
+	// and are unset until it first fails.
+	Attempts     int    `bson:"attempts,omitempty"`
+	FirstFailure int64  `bson:"first-failure,omitempty"`
+	LastError    string `bson:"last-error,omitempty"`
+	NextAttempt  int64  `bson:"next-attempt,omitempty"`
+	DeadLetter   bool   `bson:"dead-letter,omitempty"`
+}
+
+// CleanupInfo describes a pending cleanup.
+type CleanupInfo struct {
+	// Id identifies the cleanup.
+	Id string
+
+	// Kind is the kind of cleanup, and Prefix identifies what it
+	// cleans up.
+	Kind   string
+	Prefix string
+
+	// Attempts is the number of times the cleanup has failed.
+	Attempts int
+
+	// FirstFailure and LastError record when the cleanup first failed
+	// and why it last failed. They are unset if it has not failed.
+	FirstFailure time.Time
+	LastError    string
+
+	// NextAttempt is the earliest time at which the cleanup will be
+	// run again. It is unset if the cleanup is due.
+	NextAttempt time.Time
+
+	// DeadLetter is true if the cleanup has failed too many times to
+	// be run again until it is retried.
+	DeadLetter bool
+}
+
+// cleanupRetryDelayAfter returns how long to wait before running a
+// cleanup again after its given number of failed attempts.
+func cleanupRetryDelayAfter(attempts int) time.Duration {
+	if attempts < 2 {
+		return 0
+	}
+	delay := cleanupRetryDelay
+	for i := 2; i < attempts && delay < cleanupMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > cleanupMaxRetryDelay {
+		delay = cleanupMaxRetryDelay
+	}
+	return delay
 }
 
 // newCleanupOp returns a txn.Op that creates a cleanup document with a unique
@@ -68,16 +135,88 @@ func (st *State) NeedsCleanup() (bool, error) {
 	return count > 0, nil
 }
 
+// Cleanups returns the cleanups that are pending, including those that
+// have failed and been dead-lettered.
+func (st *State) Cleanups() ([]CleanupInfo, error) {
+	cleanups, closer := st.getCollection(cleanupsC)
+	defer closer()
+	var docs []cleanupDoc
+	if err := cleanups.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read cleanups")
+	}
+	infos := make([]CleanupInfo, len(docs))
+	for i, doc := range docs {
+		infos[i] = CleanupInfo{
+			Id:         st.localID(doc.DocID),
+			Kind:       string(doc.Kind),
+			Prefix:     doc.Prefix,
+			Attempts:   doc.Attempts,
+			LastError:  doc.LastError,
+			DeadLetter: doc.DeadLetter,
+		}
+		if doc.FirstFailure != 0 {
+			infos[i].FirstFailure = time.Unix(0, doc.FirstFailure)
+		}
+		if doc.NextAttempt != 0 {
+			infos[i].NextAttempt = time.Unix(0, doc.NextAttempt)
+		}
+	}
+	return infos, nil
+}
+
+// RetryCleanups returns all dead-lettered cleanups to the queue, to be
+// run again by the next Cleanup as if they had never failed. It
+// returns the number of cleanups retried.
+func (st *State) RetryCleanups() (int, error) {
+	cleanups, closer := st.getCollection(cleanupsC)
+	defer closer()
+	var docs []cleanupDoc
+	if err := cleanups.Find(bson.D{{"dead-letter", true}}).All(&docs); err != nil {
+		return 0, errors.Annotate(err, "cannot read cleanups")
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		ops = append(ops, txn.Op{
+			C:      cleanupsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$unset", bson.D{
+				{"attempts", nil},
+				{"first-failure", nil},
+				{"last-error", nil},
+				{"next-attempt", nil},
+				{"dead-letter", nil},
+			}}},
+		})
+	}
+	if len(ops) == 0 {
+		return 0, nil
+	}
+	if err := st.runTransaction(ops); err != nil {
+		return 0, errors.Annotate(err, "cannot retry cleanups")
+	}
+	return len(ops), nil
+}
+
 // Cleanup removes all documents that were previously marked for removal, if
 // any such exist. It should be called periodically by at least one element
-// of the system.
+// of the system. Cleanups that fail are retried by later calls with
+// exponential backoff, and dead-lettered once they have failed too often.
 func (st *State) Cleanup() (err error) {
-	var doc cleanupDoc
 	cleanups, closer := st.getCollection(cleanupsC)
 	defer closer()
-	iter := cleanups.Find(nil).Iter()
+	iter := cleanups.Find(bson.D{{"dead-letter", bson.D{{"$ne", true}}}}).Iter()
 	defer closeIter(iter, &err, "reading cleanup document")
-	for iter.Next(&doc) {
+	for {
+		// Use a fresh document each time, so that the optional
+		// failure fields are not carried over from the last one.
+		var doc cleanupDoc
+		if !iter.Next(&doc) {
+			break
+		}
+		if doc.NextAttempt > st.clock.Now().UnixNano() {
+			continue
+		}
 		var err error
 		logger.Debugf("running %q cleanup: %q", doc.Kind, doc.Prefix)
 		switch doc.Kind {
@@ -118,6 +257,9 @@ func (st *State) Cleanup() (err error) {
 		}
 		if err != nil {
 			logger.Errorf("cleanup failed for %v(%q): %v", doc.Kind, doc.Prefix, err)
+			if err := st.recordCleanupFailure(doc, err); err != nil {
+				return errors.Trace(err)
+			}
 			continue
 		}
 		ops := []txn.Op{{
@@ -132,6 +274,44 @@ func (st *State) Cleanup() (err error) {
 	return nil
 }
 
+// recordCleanupFailure records that the cleanup has failed with the
+// given error, and schedules its next attempt or dead-letters it.
+func (st *State) recordCleanupFailure(doc cleanupDoc, cleanupErr error) error {
+	now := st.clock.Now()
+	attempts := doc.Attempts + 1
+	set := bson.D{
+		{"attempts", attempts},
+		{"last-error", cleanupErr.Error()},
+		{"next-attempt", now.Add(cleanupRetryDelayAfter(attempts)).UnixNano()},
+	}
+	if doc.FirstFailure == 0 {
+		set = append(set, bson.DocElem{"first-failure", now.UnixNano()})
+	}
+	if attempts >= maxCleanupAttempts {
+		logger.Errorf("giving up on %v(%q) cleanup after %d attempts", doc.Kind, doc.Prefix, attempts)
+		set = append(set, bson.DocElem{"dead-letter", true})
+	}
+	// Guard against recording the same failure twice if the cleanup is
+	// being run concurrently.
+	assert := bson.D{{"attempts", doc.Attempts}}
+	if doc.Attempts == 0 {
+		assert = bson.D{{"attempts", bson.D{{"$exists", false}}}}
+	}
+	ops := []txn.Op{{
+		C:      cleanupsC,
+		Id:     doc.DocID,
+		Assert: assert,
+		Update: bson.D{{"$set", set}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		// The cleanup has been removed or run concurrently.
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot record cleanup failure")
+	}
+	return nil
+}
+
 // CleanupHandler is a function that state may call during cleanup
 // to perform cleanup actions for some cleanup type.
 type CleanupHandler func(st *State, persist Persistence, prefix string) error
@@ -420,7 +600,11 @@ func (st *State) cleanupDyingMachine(machineId string) error {
 
 // cleanupForceDestroyedMachine systematically destroys and removes all entities
 // that depend upon the supplied machine, and removes the machine from state. It's
-// expected to be used in response to destroy-machine --force.
+// expected to be used in response to destroy-machine --force. Dependencies are
+// removed innermost first: containers, then units (subordinates before their
+// principals, each after its storage attachments), then the machine's own
+// storage attachments. Machine.ForceDestroyTeardown reports the same entities
+// in the same order.
 func (st *State) cleanupForceDestroyedMachine(machineId string) error {
 	machine, err := st.Machine(machineId)
 	if errors.IsNotFound(err) {
@@ -487,7 +671,9 @@ func (st *State) cleanupContainers(machine *Machine) error {
 		}
 		container, err := st.Machine(containerId)
 		if errors.IsNotFound(err) {
-			return nil
+			// Already removed; carry on with its siblings rather
+			// than leaving them behind.
+			continue
 		} else if err != nil {
 			return err
 		}
@@ -548,15 +734,16 @@ func (st *State) obliterateUnit(unitName string) error {
 	} else if err != nil {
 		return err
 	}
-	// Destroy and remove all storage attachments for the unit.
-	if err := st.cleanupUnitStorageAttachments(unit.UnitTag(), true); err != nil {
-		return errors.Annotatef(err, "cannot destroy storage for unit %q", unitName)
-	}
+	// Subordinates depend on their principal, so they go first; then
+	// destroy and remove all storage attachments for the unit.
 	for _, subName := range unit.SubordinateNames() {
 		if err := st.obliterateUnit(subName); err != nil {
 			return err
 		}
 	}
+	if err := st.cleanupUnitStorageAttachments(unit.UnitTag(), true); err != nil {
+		return errors.Annotatef(err, "cannot destroy storage for unit %q", unitName)
+	}
 	if err := unit.EnsureDead(); err != nil {
 		return err
 	}
@@ -5,8 +5,10 @@ package state_test
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -72,7 +74,7 @@ func (s *CleanupSuite) TestCleanupDyingApplicationCharm(c *gc.C) {
 	mysql := s.AddTestingService(c, "mysql", ch)
 
 	// Create a dummy archive blob.
-	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession(), s.State.ObjectStores())
 	storagePath := "dummy-path"
 	err := stor.Put(storagePath, bytes.NewReader([]byte("data")), 4)
 	c.Assert(err, jc.ErrorIsNil)
@@ -759,3 +761,100 @@ func (s *CleanupSuite) assertCleanupCount(c *gc.C, count int) {
 	}
 	s.assertDoesNotNeedCleanup(c)
 }
+
+type CleanupQueueSuite struct {
+	ConnSuite
+	clock *jujutesting.Clock
+}
+
+var _ = gc.Suite(&CleanupQueueSuite{})
+
+func (s *CleanupQueueSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Now())
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// No handler is registered for this kind, so it always fails.
+	err = state.AddCleanup(s.State, "bogus", "prefix")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CleanupQueueSuite) cleanup(c *gc.C) state.CleanupInfo {
+	err := s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	cleanups, err := s.State.Cleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups, gc.HasLen, 1)
+	return cleanups[0]
+}
+
+func (s *CleanupQueueSuite) TestCleanupsPending(c *gc.C) {
+	cleanups, err := s.State.Cleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups, gc.HasLen, 1)
+	c.Assert(cleanups[0].Id, gc.Not(gc.Equals), "")
+	cleanups[0].Id = ""
+	c.Assert(cleanups[0], jc.DeepEquals, state.CleanupInfo{
+		Kind:   "bogus",
+		Prefix: "prefix",
+	})
+}
+
+func (s *CleanupQueueSuite) TestCleanupFailureBacksOff(c *gc.C) {
+	start := s.clock.Now()
+	info := s.cleanup(c)
+	c.Assert(info.Attempts, gc.Equals, 1)
+	c.Assert(info.LastError, gc.Equals, `unknown cleanup kind "bogus"`)
+	c.Assert(info.FirstFailure.UnixNano(), gc.Equals, start.UnixNano())
+	c.Assert(info.DeadLetter, jc.IsFalse)
+
+	// The first retry is immediate...
+	info = s.cleanup(c)
+	c.Assert(info.Attempts, gc.Equals, 2)
+	c.Assert(info.NextAttempt.UnixNano(), gc.Equals, start.Add(30*time.Second).UnixNano())
+
+	// ...but later ones wait, for twice as long each time.
+	info = s.cleanup(c)
+	c.Assert(info.Attempts, gc.Equals, 2)
+	s.clock.Advance(30 * time.Second)
+	info = s.cleanup(c)
+	c.Assert(info.Attempts, gc.Equals, 3)
+	c.Assert(info.NextAttempt.UnixNano(), gc.Equals, s.clock.Now().Add(time.Minute).UnixNano())
+	c.Assert(info.FirstFailure.UnixNano(), gc.Equals, start.UnixNano())
+}
+
+func (s *CleanupQueueSuite) TestCleanupDeadLetter(c *gc.C) {
+	var info state.CleanupInfo
+	for i := 0; i < 10; i++ {
+		info = s.cleanup(c)
+		s.clock.Advance(time.Hour)
+	}
+	c.Assert(info.Attempts, gc.Equals, 10)
+	c.Assert(info.DeadLetter, jc.IsTrue)
+
+	// Dead-lettered cleanups are not run, but are still pending.
+	info = s.cleanup(c)
+	c.Assert(info.Attempts, gc.Equals, 10)
+	needsCleanup, err := s.State.NeedsCleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(needsCleanup, jc.IsTrue)
+
+	retried, err := s.State.RetryCleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retried, gc.Equals, 1)
+	cleanups, err := s.State.Cleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups[0].Attempts, gc.Equals, 0)
+	c.Assert(cleanups[0].DeadLetter, jc.IsFalse)
+	c.Assert(cleanups[0].LastError, gc.Equals, "")
+
+	info = s.cleanup(c)
+	c.Assert(info.Attempts, gc.Equals, 1)
+}
+
+func (s *CleanupQueueSuite) TestRetryCleanupsNothingDeadLettered(c *gc.C) {
+	retried, err := s.State.RetryCleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retried, gc.Equals, 0)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/cloud"
@@ -18,6 +19,29 @@ func cloudGlobalKey(name string) string {
 	return "cloud#" + name
 }
 
+// cloudModelRefCountKey returns a key for refcounting the models
+// that use the named cloud.
+func cloudModelRefCountKey(cloudName string) string {
+	return "cloudModel#" + cloudName
+}
+
+// cloudCredentialRefCountKey returns a key for refcounting the
+// credentials for the named cloud.
+func cloudCredentialRefCountKey(cloudName string) string {
+	return "cloudCredential#" + cloudName
+}
+
+// createCloudRefCountOps returns the txn.Ops that create the model
+// and credential refcount docs for a new cloud. The refcounts let
+// the transactions that update or remove a cloud assert that no
+// model or credential has started using the cloud in the meantime.
+func createCloudRefCountOps(cloudName string, models, credentials int) []txn.Op {
+	return []txn.Op{
+		nsRefcounts.JustCreateOp(controllersC, cloudModelRefCountKey(cloudName), models),
+		nsRefcounts.JustCreateOp(controllersC, cloudCredentialRefCountKey(cloudName), credentials),
+	}
+}
+
 // cloudDoc records information about the cloud that the controller operates in.
 type cloudDoc struct {
 	DocID            string                       `bson:"_id"`
@@ -28,6 +52,7 @@ type cloudDoc struct {
 	IdentityEndpoint string                       `bson:"identity-endpoint,omitempty"`
 	StorageEndpoint  string                       `bson:"storage-endpoint,omitempty"`
 	Regions          map[string]cloudRegionSubdoc `bson:"regions,omitempty"`
+	CACertificates   []string                     `bson:"ca-certificates,omitempty"`
 }
 
 // cloudRegionSubdoc records information about cloud regions.
@@ -40,11 +65,56 @@ type cloudRegionSubdoc struct {
 // createCloudOp returns a list of txn.Ops that will initialize
 // the cloud definition for the controller.
 func createCloudOp(cloud cloud.Cloud, cloudName string) txn.Op {
+	return txn.Op{
+		C:      cloudsC,
+		Id:     cloudName,
+		Assert: txn.DocMissing,
+		Insert: newCloudDoc(cloud, cloudName),
+	}
+}
+
+// updateCloudOp returns a txn.Op that will replace the definition
+// of the named cloud, asserting that its type is unchanged.
+func updateCloudOp(cloud cloud.Cloud, cloudName string) txn.Op {
+	doc := newCloudDoc(cloud, cloudName)
+	set := bson.D{
+		{"type", doc.Type},
+		{"auth-types", doc.AuthTypes},
+	}
+	var unset bson.D
+	setOrUnset := func(field string, value interface{}, empty bool) {
+		if empty {
+			unset = append(unset, bson.DocElem{field, 1})
+		} else {
+			set = append(set, bson.DocElem{field, value})
+		}
+	}
+	setOrUnset("endpoint", doc.Endpoint, doc.Endpoint == "")
+	setOrUnset("identity-endpoint", doc.IdentityEndpoint, doc.IdentityEndpoint == "")
+	setOrUnset("storage-endpoint", doc.StorageEndpoint, doc.StorageEndpoint == "")
+	setOrUnset("regions", doc.Regions, len(doc.Regions) == 0)
+	setOrUnset("ca-certificates", doc.CACertificates, len(doc.CACertificates) == 0)
+	update := bson.D{{"$set", set}}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
+	}
+	return txn.Op{
+		C:      cloudsC,
+		Id:     cloudName,
+		Assert: bson.D{{"type", cloud.Type}},
+		Update: update,
+	}
+}
+
+func newCloudDoc(cloud cloud.Cloud, cloudName string) *cloudDoc {
 	authTypes := make([]string, len(cloud.AuthTypes))
 	for i, authType := range cloud.AuthTypes {
 		authTypes[i] = string(authType)
 	}
-	regions := make(map[string]cloudRegionSubdoc)
+	var regions map[string]cloudRegionSubdoc
+	if len(cloud.Regions) > 0 {
+		regions = make(map[string]cloudRegionSubdoc)
+	}
 	for _, region := range cloud.Regions {
 		regions[region.Name] = cloudRegionSubdoc{
 			region.Endpoint,
@@ -52,19 +122,15 @@ func createCloudOp(cloud cloud.Cloud, cloudName string) txn.Op {
 			region.StorageEndpoint,
 		}
 	}
-	return txn.Op{
-		C:      cloudsC,
-		Id:     cloudName,
-		Assert: txn.DocMissing,
-		Insert: &cloudDoc{
-			Name:             cloudName,
-			Type:             cloud.Type,
-			AuthTypes:        authTypes,
-			Endpoint:         cloud.Endpoint,
-			IdentityEndpoint: cloud.IdentityEndpoint,
-			StorageEndpoint:  cloud.StorageEndpoint,
-			Regions:          regions,
-		},
+	return &cloudDoc{
+		Name:             cloudName,
+		Type:             cloud.Type,
+		AuthTypes:        authTypes,
+		Endpoint:         cloud.Endpoint,
+		IdentityEndpoint: cloud.IdentityEndpoint,
+		StorageEndpoint:  cloud.StorageEndpoint,
+		Regions:          regions,
+		CACertificates:   cloud.CACertificates,
 	}
 }
 
@@ -94,6 +160,7 @@ func (d cloudDoc) toCloud() cloud.Cloud {
 		IdentityEndpoint: d.IdentityEndpoint,
 		StorageEndpoint:  d.StorageEndpoint,
 		Regions:          regions,
+		CACertificates:   d.CACertificates,
 	}
 }
 
@@ -137,7 +204,7 @@ func (st *State) AddCloud(name string, c cloud.Cloud) error {
 	if err := validateCloud(c); err != nil {
 		return errors.Annotate(err, "invalid cloud")
 	}
-	ops := []txn.Op{createCloudOp(c, name)}
+	ops := append([]txn.Op{createCloudOp(c, name)}, createCloudRefCountOps(name, 0, 0)...)
 	if err := st.runTransaction(ops); err != nil {
 		if err == txn.ErrAborted {
 			err = errors.AlreadyExistsf("cloud %q", name)
@@ -147,6 +214,168 @@ func (st *State) AddCloud(name string, c cloud.Cloud) error {
 	return nil
 }
 
+// UpdateCloud replaces the definition of the cloud with the given
+// name. The cloud's type may not be changed, and neither regions
+// nor auth-types that are in use by models or credentials may be
+// removed. As with AddCloud, the Config is ignored.
+func (st *State) UpdateCloud(name string, c cloud.Cloud) error {
+	if err := validateCloud(c); err != nil {
+		return errors.Annotate(err, "invalid cloud")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		existing, err := st.Cloud(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing.Type != c.Type {
+			return nil, errors.Errorf(
+				"cannot change cloud type from %q to %q",
+				existing.Type, c.Type,
+			)
+		}
+		// Read the refcounts before checking the models and
+		// credentials, so that the transaction aborts if any are
+		// added after the checks.
+		refcountOps, _, _, err := st.cloudRefCountOps(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := st.checkCloudRegionsUnused(name, c.Regions); err != nil {
+			return nil, errors.Trace(err)
+		}
+		credentialOps, err := st.checkCloudAuthTypesUnused(name, c.AuthTypes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{updateCloudOp(c, name)}
+		ops = append(ops, refcountOps...)
+		return append(ops, credentialOps...), nil
+	}
+	return errors.Annotatef(st.run(buildTxn), "updating cloud %q", name)
+}
+
+// RemoveCloud removes the cloud with the given name, along with any
+// credentials for it. A cloud that is in use by any model may not be
+// removed.
+func (st *State) RemoveCloud(name string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.Cloud(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		_, models, credentialCount, err := st.cloudRefCountOps(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if models > 0 {
+			return nil, errors.Errorf("cloud is used by %d model(s)", models)
+		}
+		credentials, closer := st.getCollection(cloudCredentialsC)
+		defer closer()
+		var docs []cloudCredentialDoc
+		if err := credentials.Find(bson.D{{"cloud", name}}).All(&docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		// The refcounts are asserted as they were read before
+		// the credentials, so the transaction aborts if a model
+		// or credential is added meanwhile.
+		ops := []txn.Op{{
+			C:      cloudsC,
+			Id:     name,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		ops = append(ops,
+			nsRefcounts.JustRemoveOp(controllersC, cloudModelRefCountKey(name), 0),
+			nsRefcounts.JustRemoveOp(controllersC, cloudCredentialRefCountKey(name), credentialCount),
+		)
+		for _, doc := range docs {
+			ops = append(ops, txn.Op{
+				C:      cloudCredentialsC,
+				Id:     doc.DocID,
+				Remove: true,
+			})
+		}
+		return ops, nil
+	}
+	return errors.Annotatef(st.run(buildTxn), "removing cloud %q", name)
+}
+
+// cloudRefCountOps returns txn.Ops that assert that the model and
+// credential refcounts for the named cloud are unchanged, along with
+// the current counts.
+func (st *State) cloudRefCountOps(cloudName string) ([]txn.Op, int, int, error) {
+	refcounts, closer := st.getCollection(controllersC)
+	defer closer()
+	modelsOp, models, err := nsRefcounts.CurrentOp(refcounts, cloudModelRefCountKey(cloudName))
+	if err != nil {
+		return nil, -1, -1, errors.Trace(err)
+	}
+	credentialsOp, credentials, err := nsRefcounts.CurrentOp(refcounts, cloudCredentialRefCountKey(cloudName))
+	if err != nil {
+		return nil, -1, -1, errors.Trace(err)
+	}
+	return []txn.Op{modelsOp, credentialsOp}, models, credentials, nil
+}
+
+// checkCloudRegionsUnused returns an error if any model in the named
+// cloud is in a region that is not in the given list.
+func (st *State) checkCloudRegionsUnused(cloudName string, regions []cloud.Region) error {
+	regionNames := make([]string, len(regions))
+	for i, region := range regions {
+		regionNames[i] = region.Name
+	}
+	query := bson.D{{"cloud", cloudName}}
+	if len(regionNames) == 0 {
+		query = append(query, bson.DocElem{"cloud-region", bson.D{{"$exists", true}}})
+	} else {
+		// Models with no region will match, as the cloud
+		// must not have regions for them to be valid.
+		query = append(query, bson.DocElem{"cloud-region", bson.D{{"$nin", regionNames}}})
+	}
+	models, closer := st.getCollection(modelsC)
+	defer closer()
+	var doc modelDoc
+	err := models.Find(query).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if doc.CloudRegion == "" {
+		return errors.New("cannot add regions to a cloud with models that have no region")
+	}
+	return errors.Errorf("cannot remove region %q: in use by model %q", doc.CloudRegion, doc.Name)
+}
+
+// checkCloudAuthTypesUnused returns an error if any credential for
+// the named cloud has an auth-type that is not in the given list.
+// Otherwise it returns txn.Ops that assert that the auth-types of the
+// cloud's credentials are unchanged.
+func (st *State) checkCloudAuthTypesUnused(cloudName string, authTypes cloud.AuthTypes) ([]txn.Op, error) {
+	authTypeNames := make(set.Strings)
+	for _, authType := range authTypes {
+		authTypeNames.Add(string(authType))
+	}
+	credentials, closer := st.getCollection(cloudCredentialsC)
+	defer closer()
+	var docs []cloudCredentialDoc
+	if err := credentials.Find(bson.D{{"cloud", cloudName}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		if !authTypeNames.Contains(doc.AuthType) {
+			return nil, errors.Errorf("cannot remove auth-type %q: in use by credential %q", doc.AuthType, doc.Name)
+		}
+		ops[i] = txn.Op{
+			C:      cloudCredentialsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"auth-type", doc.AuthType}},
+		}
+	}
+	return ops, nil
+}
+
 // validateCloud checks that the supplied cloud is valid.
 func validateCloud(cloud cloud.Cloud) error {
 	if cloud.Type == "" {
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
 )
 
 type CloudSuite struct {
@@ -93,3 +94,148 @@ func (s *CloudSuite) TestAddCloudNoAuthTypes(c *gc.C) {
 	})
 	c.Assert(err, gc.ErrorMatches, `invalid cloud: empty auth-types not valid`)
 }
+
+func (s *CloudSuite) TestUpdateCloud(c *gc.C) {
+	err := s.State.AddCloud("stratus", lowCloud)
+	c.Assert(err, jc.ErrorIsNil)
+
+	updated := lowCloud
+	updated.Endpoint = "new-endpoint"
+	updated.CACertificates = []string{"cert"}
+	updated.Regions = []cloud.Region{lowCloud.Regions[0], {
+		Name:     "region3",
+		Endpoint: "region3-endpoint",
+	}}
+	err = s.State.UpdateCloud("stratus", updated)
+	c.Assert(err, jc.ErrorIsNil)
+	cld, err := s.State.Cloud("stratus")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cld, jc.DeepEquals, updated)
+
+	// Removing optional fields unsets them.
+	updated.IdentityEndpoint = ""
+	updated.CACertificates = nil
+	err = s.State.UpdateCloud("stratus", updated)
+	c.Assert(err, jc.ErrorIsNil)
+	cld, err = s.State.Cloud("stratus")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cld, jc.DeepEquals, updated)
+}
+
+func (s *CloudSuite) TestUpdateCloudNotFound(c *gc.C) {
+	err := s.State.UpdateCloud("stratus", lowCloud)
+	c.Assert(err, gc.ErrorMatches, `updating cloud "stratus": cloud "stratus" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloudSuite) TestUpdateCloudChangeType(c *gc.C) {
+	err := s.State.AddCloud("stratus", lowCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	updated := lowCloud
+	updated.Type = "high"
+	err = s.State.UpdateCloud("stratus", updated)
+	c.Assert(err, gc.ErrorMatches, `updating cloud "stratus": cannot change cloud type from "low" to "high"`)
+}
+
+func (s *CloudSuite) TestUpdateCloudRemoveRegionInUse(c *gc.C) {
+	controllerCloud, err := s.State.Cloud("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.ControllerModel()
+	c.Assert(err, jc.ErrorIsNil)
+	controllerCloud.Regions = nil
+	err = s.State.UpdateCloud("dummy", controllerCloud)
+	c.Assert(err, gc.ErrorMatches, `updating cloud "dummy": cannot remove region "dummy-region": in use by model "`+model.Name()+`"`)
+}
+
+func (s *CloudSuite) TestUpdateCloudRemoveAuthTypeInUse(c *gc.C) {
+	err := s.State.AddCloud("stratus", lowCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewCloudCredentialTag("stratus/bob/foobar")
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, nil))
+	c.Assert(err, jc.ErrorIsNil)
+
+	updated := lowCloud
+	updated.AuthTypes = cloud.AuthTypes{cloud.UserPassAuthType}
+	err = s.State.UpdateCloud("stratus", updated)
+	c.Assert(err, gc.ErrorMatches, `updating cloud "stratus": cannot remove auth-type "access-key": in use by credential "foobar"`)
+}
+
+func (s *CloudSuite) TestUpdateCloudRemoveAuthTypeCredentialAddedConcurrently(c *gc.C) {
+	err := s.State.AddCloud("stratus", lowCloud)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		tag := names.NewCloudCredentialTag("stratus/bob/foobar")
+		err := s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, nil))
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	updated := lowCloud
+	updated.AuthTypes = cloud.AuthTypes{cloud.UserPassAuthType}
+	err = s.State.UpdateCloud("stratus", updated)
+	c.Assert(err, gc.ErrorMatches, `updating cloud "stratus": cannot remove auth-type "access-key": in use by credential "foobar"`)
+}
+
+func (s *CloudSuite) TestUpdateCloudRemoveAuthTypeCredentialChangedConcurrently(c *gc.C) {
+	err := s.State.AddCloud("stratus", lowCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewCloudCredentialTag("stratus/bob/foobar")
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.UserPassAuthType, nil))
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, nil))
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	updated := lowCloud
+	updated.AuthTypes = cloud.AuthTypes{cloud.UserPassAuthType}
+	err = s.State.UpdateCloud("stratus", updated)
+	c.Assert(err, gc.ErrorMatches, `updating cloud "stratus": cannot remove auth-type "access-key": in use by credential "foobar"`)
+}
+
+func (s *CloudSuite) TestRemoveCloud(c *gc.C) {
+	err := s.State.AddCloud("stratus", lowCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewCloudCredentialTag("stratus/bob/foobar")
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, nil))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveCloud("stratus")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Cloud("stratus")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.CloudCredential(tag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloudSuite) TestRemoveCloudCredentialAddedConcurrently(c *gc.C) {
+	err := s.State.AddCloud("stratus", lowCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewCloudCredentialTag("stratus/bob/foobar")
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, nil))
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = s.State.RemoveCloud("stratus")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CloudCredential(tag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// The cloud can be added again, with fresh refcounts.
+	err = s.State.AddCloud("stratus", lowCloud)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CloudSuite) TestRemoveCloudInUse(c *gc.C) {
+	err := s.State.RemoveCloud("dummy")
+	c.Assert(err, gc.ErrorMatches, `removing cloud "dummy": cloud is used by \d+ model\(s\)`)
+}
+
+func (s *CloudSuite) TestRemoveCloudNotFound(c *gc.C) {
+	err := s.State.RemoveCloud("stratus")
+	c.Assert(err, gc.ErrorMatches, `removing cloud "stratus": cloud "stratus" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
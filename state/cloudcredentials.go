@@ -95,7 +95,10 @@ func (st *State) UpdateCloudCredential(tag names.CloudCredentialTag, credential
 		if err == nil {
 			ops = append(ops, updateCloudCredentialOp(tag, credential))
 		} else {
-			ops = append(ops, createCloudCredentialOp(tag, credential))
+			ops = append(ops,
+				createCloudCredentialOp(tag, credential),
+				nsRefcounts.JustIncRefOp(controllersC, cloudCredentialRefCountKey(cloudName), 1),
+			)
 		}
 		return ops, nil
 	}
@@ -164,7 +167,7 @@ func removeCloudCredentialOps(tag names.CloudCredentialTag) []txn.Op {
 		Id:     cloudCredentialDocID(tag),
 		Assert: txn.DocExists,
 		Remove: true,
-	}}
+	}, nsRefcounts.justDecRefOp(controllersC, cloudCredentialRefCountKey(tag.Cloud().Id()), 0)}
 }
 
 func cloudCredentialDocID(tag names.CloudCredentialTag) string {
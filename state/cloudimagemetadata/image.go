@@ -67,9 +67,12 @@ func (s *storage) SaveMetadata(metadata []Metadata) error {
 			} else if err != nil {
 				return nil, errors.Trace(err)
 			} else if existing.ImageId != newDocCopy.ImageId {
-				// need to update imageId
+				// need to update imageId, which makes the metadata new
 				op.Assert = txn.DocExists
-				op.Update = bson.D{{"$set", bson.D{{"image_id", newDocCopy.ImageId}}}}
+				op.Update = bson.D{{"$set", bson.D{
+					{"image_id", newDocCopy.ImageId},
+					{"date_created", newDocCopy.DateCreated},
+				}}}
 				ops = append(ops, op)
 				logger.Debugf("updating cloud image id for metadata %v", newDocCopy.Id)
 			}
@@ -131,6 +134,45 @@ func (s *storage) DeleteMetadata(imageId string) error {
 	return nil
 }
 
+// ExpireMetadata implements Storage.ExpireMetadata.
+func (s *storage) ExpireMetadata(before time.Time) (int, error) {
+	var expired int
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		coll, closer := s.store.GetCollection(s.collection)
+		defer closer()
+
+		var docs []imagesMetadataDoc
+		query := bson.D{
+			{"date_created", bson.D{{"$lt", before.UnixNano()}}},
+			{"source", bson.D{{"$ne", CustomSource}}},
+		}
+		if err := coll.Find(query).All(&docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(docs) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := make([]txn.Op, len(docs))
+		for i, doc := range docs {
+			logger.Debugf("expiring metadata (ID=%v) for image (ID=%v)", doc.Id, doc.ImageId)
+			ops[i] = txn.Op{
+				C:  s.collection,
+				Id: doc.Id,
+				// The metadata must not have been refreshed since
+				// it was read.
+				Assert: bson.D{{"date_created", doc.DateCreated}},
+				Remove: true,
+			}
+		}
+		expired = len(ops)
+		return ops, nil
+	}
+	if err := s.store.RunTransaction(buildTxn); err != nil {
+		return 0, errors.Annotate(err, "cannot expire cloud image metadata")
+	}
+	return expired, nil
+}
+
 func (s *storage) metadataForImageId(imageId string) ([]imagesMetadataDoc, error) {
 	coll, closer := s.store.GetCollection(s.collection)
 	defer closer()
@@ -5,6 +5,7 @@ package cloudimagemetadata_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -522,6 +523,53 @@ func (s *cloudImageMetadataSuite) TestDeleteMetadata(c *gc.C) {
 	c.Assert(c.GetTestLog(), jc.Contains, "no metadata for image ID ok-to-delete to delete")
 }
 
+func (s *cloudImageMetadataSuite) TestExpireMetadata(c *gc.C) {
+	attrs := cloudimagemetadata.MetadataAttributes{
+		Stream:  "stream",
+		Version: "14.04",
+		Series:  "trusty",
+		Arch:    "arch",
+		Region:  "wonder",
+		Source:  "published",
+	}
+	now := coretesting.NonZeroTime()
+	old := now.Add(-48 * time.Hour).UnixNano()
+	stale := cloudimagemetadata.Metadata{attrs, 0, "stale", old}
+	fresh := cloudimagemetadata.Metadata{attrs, 0, "fresh", now.UnixNano()}
+	fresh.Stream = "daily"
+	custom := cloudimagemetadata.Metadata{attrs, 0, "custom", old}
+	custom.Source = cloudimagemetadata.CustomSource
+	s.assertRecordMetadata(c, stale)
+	s.assertRecordMetadata(c, fresh)
+	s.assertRecordMetadata(c, custom)
+
+	expired, err := s.storage.ExpireMetadata(now.Add(-24 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expired, gc.Equals, 1)
+	s.assertMetadataRecorded(c, cloudimagemetadata.MetadataAttributes{}, fresh, custom)
+
+	// Nothing more is old enough to expire.
+	expired, err = s.storage.ExpireMetadata(now.Add(-24 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expired, gc.Equals, 0)
+}
+
+func (s *cloudImageMetadataSuite) TestSaveMetadataNewImageRefreshesDateCreated(c *gc.C) {
+	attrs := cloudimagemetadata.MetadataAttributes{
+		Stream:  "stream",
+		Version: "14.04",
+		Series:  "trusty",
+		Arch:    "arch",
+		Region:  "wonder",
+		Source:  "published",
+	}
+	now := coretesting.NonZeroTime()
+	s.assertRecordMetadata(c, cloudimagemetadata.Metadata{attrs, 0, "old", now.Add(-48 * time.Hour).UnixNano()})
+	updated := cloudimagemetadata.Metadata{attrs, 0, "new", now.UnixNano()}
+	s.assertRecordMetadata(c, updated)
+	s.assertMetadataRecorded(c, attrs, updated)
+}
+
 func (s *cloudImageMetadataSuite) TestDeleteDiffMetadataConcurrently(c *gc.C) {
 	imageId := "ok-to-delete"
 	s.addTestImageMetadata(c, imageId)
@@ -4,11 +4,17 @@
 package cloudimagemetadata
 
 import (
+	"time"
+
 	jujutxn "github.com/juju/txn"
 
 	"github.com/juju/juju/mongo"
 )
 
+// CustomSource is the source of image metadata added by users, rather
+// than cached from published image metadata. It is never expired.
+const CustomSource = "custom"
+
 // MetadataAttributes contains cloud image metadata attributes.
 type MetadataAttributes struct {
 	// Stream contains reference to a particular stream,
@@ -66,6 +72,11 @@ type Storage interface {
 	// DeleteMetadata deletes cloud image metadata from state.
 	DeleteMetadata(imageId string) error
 
+	// ExpireMetadata deletes the cloud image metadata created before
+	// the given time, other than that with the CustomSource source,
+	// and returns how many were deleted.
+	ExpireMetadata(before time.Time) (int, error)
+
 	// FindMetadata returns all Metadata that match specified
 	// criteria or a "not found" error if none match.
 	// Empty criteria will return all cloud image metadata.
@@ -28,6 +28,9 @@ type constraintsDoc struct {
 	Tags         *[]string
 	Spaces       *[]string
 	VirtType     *string
+	GPUs         *uint64
+	GPUType      *string
+	MaxPrice     *float64
 }
 
 func (doc constraintsDoc) value() constraints.Value {
@@ -42,6 +45,9 @@ func (doc constraintsDoc) value() constraints.Value {
 		Tags:         doc.Tags,
 		Spaces:       doc.Spaces,
 		VirtType:     doc.VirtType,
+		GPUs:         doc.GPUs,
+		GPUType:      doc.GPUType,
+		MaxPrice:     doc.MaxPrice,
 	}
 	return result
 }
@@ -58,6 +64,9 @@ func newConstraintsDoc(st *State, cons constraints.Value) constraintsDoc {
 		Tags:         cons.Tags,
 		Spaces:       cons.Spaces,
 		VirtType:     cons.VirtType,
+		GPUs:         cons.GPUs,
+		GPUType:      cons.GPUType,
+		MaxPrice:     cons.MaxPrice,
 	}
 	return result
 }
@@ -33,3 +33,45 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	}
 	return settings.Map(), nil
 }
+
+// UpdateControllerConfig updates the controller config, setting the
+// attributes in updateAttrs and removing those in removeAttrs. Only
+// attributes listed in controller.AllowedUpdateConfigAttributes may
+// be changed.
+func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	for name := range updateAttrs {
+		if !jujucontroller.UpdateAllowed(name) {
+			return errors.Errorf("can not change %q after bootstrap", name)
+		}
+	}
+	for _, name := range removeAttrs {
+		if !jujucontroller.UpdateAllowed(name) {
+			return errors.Errorf("can not remove %q after bootstrap", name)
+		}
+	}
+	settings, err := readSettings(st, controllersC, controllerSettingsGlobalKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current := jujucontroller.Config(settings.Map())
+	caCert, _ := current.CACert()
+	attrs := settings.Map()
+	for _, name := range removeAttrs {
+		delete(attrs, name)
+	}
+	for name, value := range updateAttrs {
+		attrs[name] = value
+	}
+	cfg, err := jujucontroller.NewConfig(current.ControllerUUID(), caCert, attrs)
+	if err != nil {
+		return errors.Annotate(err, "invalid controller config")
+	}
+	for _, name := range removeAttrs {
+		settings.Delete(name)
+	}
+	for name := range updateAttrs {
+		settings.Set(name, cfg[name])
+	}
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
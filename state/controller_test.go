@@ -29,6 +29,33 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 		controller.AutocertURLKey:      true,
 		controller.AutocertDNSNameKey:  true,
 		controller.AllowModelAccessKey: true,
+
+		controller.ObjectStoreType:      true,
+		controller.ObjectStoreURL:       true,
+		controller.ObjectStoreAccessKey: true,
+		controller.ObjectStoreSecretKey: true,
+		controller.ObjectStoreCacheSize: true,
+
+		controller.CharmStoreURL:           true,
+		controller.APICompressionThreshold: true,
+
+		controller.AgentLogSinkRateLimit:      true,
+		controller.AgentLogSinkRateLimitBurst: true,
+
+		controller.LDAPURL:         true,
+		controller.LDAPUserDN:      true,
+		controller.LDAPGroupAccess: true,
+
+		controller.LocalLoginExpiry:      true,
+		controller.ExternalLoginExpiry:   true,
+		controller.LoginDischargeTimeout: true,
+
+		controller.MetricsEndpointEnabled: true,
+
+		controller.LogForwardHTTPURL:      true,
+		controller.LogForwardKafkaBrokers: true,
+		controller.LogForwardKafkaTopic:   true,
+		controller.LogOffloadAge:          true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -46,3 +73,35 @@ func (s *ControllerConfigSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg["controller-uuid"], gc.Equals, m.ControllerUUID())
 }
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfig(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.AuditingEnabled})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditingEnabled(), jc.IsFalse)
+}
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfigImmutable(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIPort: 1234,
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `can not change "api-port" after bootstrap`)
+	err = s.State.UpdateControllerConfig(nil, []string{controller.StatePort})
+	c.Assert(err, gc.ErrorMatches, `can not remove "state-port" after bootstrap`)
+}
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfigInvalid(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled: "maybe",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid controller config: auditing-enabled: expected bool, got string\("maybe"\)`)
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+)
+
+// agentEngineReportDoc records the most recent dependency engine
+// report sent by an agent.
+type agentEngineReportDoc struct {
+	ID        string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	Tag       string    `bson:"tag"`
+	Report    string    `bson:"report"`
+	Updated   time.Time `bson:"updated"`
+}
+
+// AgentEngineReport holds the most recent dependency engine report
+// sent by an agent, and the time it was received.
+type AgentEngineReport struct {
+	Report  map[string]interface{}
+	Updated time.Time
+}
+
+// SetAgentEngineReport records the dependency engine report for the
+// agent with the given tag, replacing any previous report. Reports
+// are diagnostic and sent frequently, so they are written outside of
+// any transaction.
+func (st *State) SetAgentEngineReport(tag names.Tag, report map[string]interface{}) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return errors.Annotatef(err, "cannot marshal engine report for %s", names.ReadableString(tag))
+	}
+	reports, closer := st.getCollection(agentEngineReportsC)
+	defer closer()
+
+	doc := agentEngineReportDoc{
+		ID:        st.docID(tag.String()),
+		ModelUUID: st.ModelUUID(),
+		Tag:       tag.String(),
+		Report:    string(data),
+		Updated:   st.clock.Now().UTC(),
+	}
+	if _, err := reports.Writeable().UpsertId(doc.ID, doc); err != nil {
+		return errors.Annotatef(err, "cannot set engine report for %s", names.ReadableString(tag))
+	}
+	return nil
+}
+
+// AgentEngineReport returns the most recent dependency engine report
+// sent by the agent with the given tag.
+func (st *State) AgentEngineReport(tag names.Tag) (AgentEngineReport, error) {
+	reports, closer := st.getCollection(agentEngineReportsC)
+	defer closer()
+
+	var doc agentEngineReportDoc
+	err := reports.FindId(tag.String()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return AgentEngineReport{}, errors.NotFoundf("engine report for %s", names.ReadableString(tag))
+	} else if err != nil {
+		return AgentEngineReport{}, errors.Annotatef(err, "cannot get engine report for %s", names.ReadableString(tag))
+	}
+	var report map[string]interface{}
+	if err := json.Unmarshal([]byte(doc.Report), &report); err != nil {
+		return AgentEngineReport{}, errors.Annotatef(err, "invalid engine report for %s", names.ReadableString(tag))
+	}
+	return AgentEngineReport{
+		Report:  report,
+		Updated: doc.Updated.UTC(),
+	}, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/testing/factory"
+)
+
+type AgentEngineReportSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AgentEngineReportSuite{})
+
+func (s *AgentEngineReportSuite) TestNotFound(c *gc.C) {
+	_, err := s.State.AgentEngineReport(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "engine report for machine 0 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AgentEngineReportSuite) TestSetGet(c *gc.C) {
+	tag := s.Factory.MakeMachine(c, nil).Tag()
+	for i := 0; i < 3; i++ {
+		report := map[string]interface{}{
+			"state": "started",
+			"manifolds": map[string]interface{}{
+				"upgrader": map[string]interface{}{
+					"state":       "started",
+					"start-count": float64(i),
+				},
+			},
+		}
+		err := s.State.SetAgentEngineReport(tag, report)
+		c.Assert(err, jc.ErrorIsNil)
+
+		result, err := s.State.AgentEngineReport(tag)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result.Report, jc.DeepEquals, report)
+		c.Check(result.Updated.IsZero(), jc.IsFalse)
+	}
+}
+
+func (s *AgentEngineReportSuite) TestModelScoped(c *gc.C) {
+	tag := s.Factory.MakeUnit(c, nil).Tag()
+	err := s.State.SetAgentEngineReport(tag, map[string]interface{}{"state": "started"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, &factory.ModelParams{})
+	defer st.Close()
+	_, err = st.AgentEngineReport(tag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
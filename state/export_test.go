@@ -15,6 +15,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	jujutxn "github.com/juju/txn"
 	txntesting "github.com/juju/txn/testing"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -53,6 +54,8 @@ var (
 	ImageStorageNewStorage               = &imageStorageNewStorage
 	MachineIdLessThan                    = machineIdLessThan
 	ControllerAvailable                  = &controllerAvailable
+	MaxModelStatusHistorySize            = &maxModelStatusHistorySize
+	LogArchiveSize                       = &logArchiveSize
 	GetOrCreatePorts                     = getOrCreatePorts
 	GetPorts                             = getPorts
 	AddVolumeOps                         = (*State).addVolumeOps
@@ -598,7 +601,7 @@ func ResourceStoragePath(c *gc.C, st *State, id string) string {
 // IsBlobStored returns true if a given storage path is in used in the
 // managed blob store.
 func IsBlobStored(c *gc.C, st *State, storagePath string) bool {
-	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession(), st.ObjectStores())
 	r, _, err := stor.Get(storagePath)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -611,6 +614,11 @@ func IsBlobStored(c *gc.C, st *State, storagePath string) bool {
 	return true
 }
 
+// AddCleanup schedules a cleanup of the given kind and prefix.
+func AddCleanup(st *State, kind, prefix string) error {
+	return st.runTransaction([]txn.Op{newCleanupOp(cleanupKind(kind), prefix)})
+}
+
 // AssertNoCleanups checks that there are no cleanups scheduled of a
 // given kind.
 func AssertNoCleanups(c *gc.C, st *State, kind cleanupKind) {
@@ -625,3 +633,9 @@ func AssertNoCleanups(c *gc.C, st *State, kind cleanupKind) {
 		}
 	}
 }
+
+// PinnedLeadership returns the names of the applications whose
+// leadership is pinned.
+func PinnedLeadership(st *State) (set.Strings, error) {
+	return st.pinnedLeadership()
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/blobstore.v2"
 	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/state/objectstore"
 )
 
 // ManagedStorage returns the managedStorage attribute for the storage.
@@ -21,7 +23,7 @@ func MetadataCollection(s Storage) *mgo.Collection {
 
 // RemoveFailsManagedStorage returns a patched managedStorage,
 // which fails when Remove is called.
-var RemoveFailsManagedStorage = func(session *mgo.Session) blobstore.ManagedStorage {
+var RemoveFailsManagedStorage = func(session *mgo.Session, _ *objectstore.Stores) blobstore.ManagedStorage {
 	rs := blobstore.NewGridFS(ImagesDB, ImagesDB, session)
 	db := session.DB(ImagesDB)
 	metadataDb := db.With(session)
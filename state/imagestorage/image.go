@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/objectstore"
 )
 
 var logger = loggo.GetLogger("juju.state.imagestorage")
@@ -33,6 +34,7 @@ type imageStorage struct {
 	modelUUID          string
 	metadataCollection *mgo.Collection
 	blobDb             *mgo.Database
+	stores             *objectstore.Stores
 }
 
 var _ Storage = (*imageStorage)(nil)
@@ -43,6 +45,7 @@ var _ Storage = (*imageStorage)(nil)
 func NewStorage(
 	session *mgo.Session,
 	modelUUID string,
+	stores *objectstore.Stores,
 ) Storage {
 	blobDb := session.DB(ImagesDB)
 	metadataCollection := blobDb.C(imagemetadataC)
@@ -50,19 +53,20 @@ func NewStorage(
 		modelUUID,
 		metadataCollection,
 		blobDb,
+		stores,
 	}
 }
 
 // Override for testing.
-var getManagedStorage = func(session *mgo.Session) blobstore.ManagedStorage {
-	rs := blobstore.NewGridFS(ImagesDB, ImagesDB, session)
+var getManagedStorage = func(session *mgo.Session, stores *objectstore.Stores) blobstore.ManagedStorage {
+	rs := stores.ResourceStorage(ImagesDB, session)
 	db := session.DB(ImagesDB)
 	metadataDb := db.With(session)
 	return blobstore.NewManagedStorage(metadataDb, rs)
 }
 
 func (s *imageStorage) getManagedStorage(session *mgo.Session) blobstore.ManagedStorage {
-	return getManagedStorage(session)
+	return getManagedStorage(session, s.stores)
 }
 
 func (s *imageStorage) txnRunner(session *mgo.Session) jujutxn.Runner {
@@ -18,10 +18,13 @@ import (
 	"github.com/juju/txn"
 	jujutxn "github.com/juju/txn"
 	txntesting "github.com/juju/txn/testing"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state/imagestorage"
+	"github.com/juju/juju/state/objectstore"
 	"github.com/juju/juju/testing"
 )
 
@@ -35,6 +38,7 @@ type ImageSuite struct {
 	testing.BaseSuite
 	mongo              *gitjujutesting.MgoInstance
 	session            *mgo.Session
+	stores             *objectstore.Stores
 	storage            imagestorage.Storage
 	metadataCollection *mgo.Collection
 	txnRunner          jujutxn.Runner
@@ -48,7 +52,10 @@ func (s *ImageSuite) SetUpTest(c *gc.C) {
 	var err error
 	s.session, err = s.mongo.Dial()
 	c.Assert(err, gc.IsNil)
-	s.storage = imagestorage.NewStorage(s.session, "my-uuid")
+	s.stores = objectstore.NewStores(func() (controller.Config, error) {
+		return controller.Config{}, nil
+	}, clock.WallClock)
+	s.storage = imagestorage.NewStorage(s.session, "my-uuid", s.stores)
 	s.metadataCollection = imagestorage.MetadataCollection(s.storage)
 	s.txnRunner = jujutxn.NewRunner(jujutxn.RunnerParams{Database: s.metadataCollection.Database})
 	s.patchTransactionRunner()
@@ -185,7 +192,7 @@ func (s *ImageSuite) TestAddImageRemovesExistingRemoveFails(c *gc.C) {
 	err := managedStorage.PutForBucket("my-uuid", "path", strings.NewReader("blah"), 4)
 	c.Assert(err, gc.IsNil)
 
-	storage := imagestorage.NewStorage(s.session, "my-uuid")
+	storage := imagestorage.NewStorage(s.session, "my-uuid", s.stores)
 	s.PatchValue(imagestorage.GetManagedStorage, imagestorage.RemoveFailsManagedStorage)
 	addedMetadata := &imagestorage.Metadata{
 		ModelUUID: "my-uuid",
@@ -216,7 +223,7 @@ func (errorTransactionRunner) Run(transactions txn.TransactionSource) error {
 }
 
 func (s *ImageSuite) TestAddImageRemovesBlobOnFailure(c *gc.C) {
-	storage := imagestorage.NewStorage(s.session, "my-uuid")
+	storage := imagestorage.NewStorage(s.session, "my-uuid", s.stores)
 	s.txnRunner = errorTransactionRunner{s.txnRunner}
 	addedMetadata := &imagestorage.Metadata{
 		ModelUUID: "my-uuid",
@@ -237,7 +244,7 @@ func (s *ImageSuite) TestAddImageRemovesBlobOnFailure(c *gc.C) {
 }
 
 func (s *ImageSuite) TestAddImageRemovesBlobOnFailureRemoveFails(c *gc.C) {
-	storage := imagestorage.NewStorage(s.session, "my-uuid")
+	storage := imagestorage.NewStorage(s.session, "my-uuid", s.stores)
 	s.PatchValue(imagestorage.GetManagedStorage, imagestorage.RemoveFailsManagedStorage)
 	s.txnRunner = errorTransactionRunner{s.txnRunner}
 	addedMetadata := &imagestorage.Metadata{
@@ -120,6 +120,12 @@ type ControllerAccessor interface {
 	ControllerConfig() (controller.Config, error)
 }
 
+// ControllerConfigWatcher defines the methods needed to watch the
+// controller config.
+type ControllerConfigWatcher interface {
+	WatchControllerConfig() NotifyWatcher
+}
+
 // UnitsWatcher defines the methods needed to retrieve an entity (a
 // machine or a service) and watch its units.
 type UnitsWatcher interface {
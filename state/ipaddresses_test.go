@@ -97,6 +97,63 @@ func (s *ipAddressesStateSuite) addNamedDeviceForMachine(c *gc.C, name string, m
 	return device
 }
 
+func (s *ipAddressesStateSuite) TestSubnetHasAddresses(c *gc.C) {
+	s.addNamedDeviceWithAddresses(c, "eth0", "0.1.2.3/24")
+
+	subnet, err := s.State.Subnet("0.1.2.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	inUse, err := subnet.HasAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(inUse, jc.IsTrue)
+
+	subnet, err = s.State.Subnet("10.20.0.0/16")
+	c.Assert(err, jc.ErrorIsNil)
+	inUse, err = subnet.HasAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(inUse, jc.IsFalse)
+
+	// Addresses in other models are not counted.
+	subnet, err = s.otherState.Subnet("0.1.2.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	inUse, err = subnet.HasAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(inUse, jc.IsFalse)
+}
+
+func (s *ipAddressesStateSuite) TestSubnetRemoveIfUnused(c *gc.C) {
+	s.addNamedDeviceWithAddresses(c, "eth0", "0.1.2.3/24")
+
+	subnet, err := s.State.Subnet("0.1.2.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	err = subnet.RemoveIfUnused()
+	c.Assert(err, gc.ErrorMatches, `cannot remove subnet "0.1.2.0/24": subnet is in use`)
+
+	subnet, err = s.State.Subnet("10.20.0.0/16")
+	c.Assert(err, jc.ErrorIsNil)
+	err = subnet.RemoveIfUnused()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Subnet("10.20.0.0/16")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing it again is a no-op.
+	err = subnet.RemoveIfUnused()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ipAddressesStateSuite) TestSubnetRemoveIfUnusedAddressAddedConcurrently(c *gc.C) {
+	subnet, err := s.State.Subnet("10.20.0.0/16")
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		s.addNamedDeviceWithAddresses(c, "eth1", "10.20.0.42/16")
+	}).Check()
+
+	err = subnet.RemoveIfUnused()
+	c.Assert(err, gc.ErrorMatches, `cannot remove subnet "10.20.0.0/16": subnet is in use`)
+	_, err = s.State.Subnet("10.20.0.0/16")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *ipAddressesStateSuite) TestMachineMethodReturnsNotFoundErrorWhenMissing(c *gc.C) {
 	_, addresses := s.addNamedDeviceWithAddresses(c, "eth0", "0.1.2.3/24")
 	s.ensureMachineDeadAndRemove(c, s.machine)
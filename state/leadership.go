@@ -9,7 +9,9 @@ import (
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/core/leadership"
@@ -37,6 +39,26 @@ func (st *State) LeadershipChecker() leadership.Checker {
 	return leadershipChecker{st.workers.LeadershipManager()}
 }
 
+// pinnedLeadership returns the names of the applications in the
+// state's model whose leadership is pinned.
+func (st *State) pinnedLeadership() (set.Strings, error) {
+	applications, closer := st.getCollection(applicationsC)
+	defer closer()
+
+	var docs []struct {
+		Name string `bson:"name"`
+	}
+	query := applications.Find(bson.D{{"leadership-pinned", true}})
+	if err := query.Select(bson.D{{"name", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read pinned leadership")
+	}
+	pinned := set.NewStrings()
+	for _, doc := range docs {
+		pinned.Add(doc.Name)
+	}
+	return pinned, nil
+}
+
 // buildTxnWithLeadership returns a transaction source that combines the supplied source
 // with checks and asserts on the supplied token.
 func buildTxnWithLeadership(buildTxn jujutxn.TransactionSource, token leadership.Token) jujutxn.TransactionSource {
@@ -109,7 +131,10 @@ type leadershipToken struct {
 func (t leadershipToken) Check(out interface{}) error {
 	err := t.token.Check(out)
 	if errors.Cause(err) == corelease.ErrNotHeld {
-		return errors.Errorf("%q is not leader of %q", t.unitName, t.applicationname)
+		return &leadership.NotLeaderError{
+			ApplicationName: t.applicationname,
+			UnitName:        t.unitName,
+		}
 	}
 	return errors.Trace(err)
 }
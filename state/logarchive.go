@@ -0,0 +1,331 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/blobstore.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state/objectstore"
+)
+
+const (
+	// logArchivesC holds a logArchiveDoc for each archive of log
+	// messages that has been offloaded to the external object store.
+	logArchivesC = "archives"
+
+	// logArchiveNamespace prefixes the names of log archives in the
+	// external object store.
+	logArchiveNamespace = "logs"
+)
+
+// logArchiveSize is the number of log messages written to each
+// archive. Archives may hold more, as all the messages with the same
+// timestamp are written to the same archive.
+var logArchiveSize = 50000
+
+// logArchiveDoc describes an archive of a model's log messages.
+type logArchiveDoc struct {
+	// Path is the name of the archive in the object store.
+	Path      string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Start and End are the times, in unix nano UTC, of the oldest
+	// and newest messages in the archive.
+	Start int64 `bson:"start"`
+	End   int64 `bson:"end"`
+	Count int   `bson:"count"`
+}
+
+// LogArchiveStorage returns the external object store to which logs
+// are offloaded. It returns a NotFound error if the controller has no
+// external object store.
+func LogArchiveStorage(st *State) (blobstore.ResourceStorage, error) {
+	return st.objectStores.ExternalStorage(logArchiveNamespace)
+}
+
+// OffloadLogs moves the log messages older than the given time from
+// the logs collection to gzipped archives in the given store. The
+// messages are still returned by a LogTailer that replays the logs
+// from the start or from an earlier time.
+func OffloadLogs(st MongoSessioner, store blobstore.ResourceStorage, before time.Time) error {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+	archivesColl := logsColl.Database.C(logArchivesC)
+
+	modelUUIDs, err := getEnvsInLogs(logsColl)
+	if err != nil {
+		return errors.Annotate(err, "failed to get log counts")
+	}
+	for _, modelUUID := range modelUUIDs {
+		count, err := offloadModelLogs(logsColl, archivesColl, store, modelUUID, before.UnixNano())
+		if count > 0 {
+			logger.Debugf("offloaded %d logs for model %s", count, modelUUID)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "cannot offload logs for model %s", modelUUID)
+		}
+	}
+	return nil
+}
+
+// offloadModelLogs moves the model's log messages older than before,
+// in unix nano UTC, to archives, and returns the number moved.
+func offloadModelLogs(logsColl, archivesColl *mgo.Collection, store blobstore.ResourceStorage, modelUUID string, before int64) (int, error) {
+	total := 0
+	for {
+		docs, err := nextLogArchiveBatch(logsColl, modelUUID, before)
+		if err != nil {
+			return total, errors.Trace(err)
+		}
+		if len(docs) == 0 {
+			return total, nil
+		}
+		if err := writeLogArchive(archivesColl, store, modelUUID, docs); err != nil {
+			return total, errors.Trace(err)
+		}
+		// The batch holds every message in its time range, except
+		// for any written since it was read. Such late messages are
+		// not expected, as only messages older than before are
+		// offloaded.
+		_, err = logsColl.RemoveAll(bson.M{
+			"e": modelUUID,
+			"t": bson.M{"$gte": docs[0].Time, "$lte": docs[len(docs)-1].Time},
+		})
+		if err != nil {
+			return total, errors.Annotate(err, "removing offloaded logs")
+		}
+		total += len(docs)
+	}
+}
+
+// nextLogArchiveBatch returns the oldest of the model's log messages
+// older than before, up to about logArchiveSize of them. The batch
+// always holds all of the messages with each of its timestamps, so
+// that it can be removed from the logs collection by time.
+func nextLogArchiveBatch(logsColl *mgo.Collection, modelUUID string, before int64) ([]logDoc, error) {
+	// Read one message more than the batch size, to see whether the
+	// batch would end part way through the messages with a timestamp.
+	var docs []logDoc
+	err := logsColl.Find(bson.M{
+		"e": modelUUID,
+		"t": bson.M{"$lt": before},
+	}).Sort("e", "t", "_id").Limit(logArchiveSize + 1).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(docs) <= logArchiveSize {
+		return docs, nil
+	}
+	next := docs[logArchiveSize].Time
+	docs = docs[:logArchiveSize]
+	i := len(docs)
+	for i > 0 && docs[i-1].Time == next {
+		i--
+	}
+	if i > 0 {
+		return docs[:i], nil
+	}
+	// Every message in the batch has the same timestamp.
+	err = logsColl.Find(bson.M{"e": modelUUID, "t": next}).Sort("_id").All(&docs)
+	return docs, errors.Trace(err)
+}
+
+// writeLogArchive writes the given log messages, in order, to an
+// archive in the store, and records the archive in archivesColl.
+func writeLogArchive(archivesColl *mgo.Collection, store blobstore.ResourceStorage, modelUUID string, docs []logDoc) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for i := range docs {
+		if err := enc.Encode(&docs[i]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	first, last := docs[0], docs[len(docs)-1]
+	// The name depends only on the first message, so that a batch
+	// offloaded again after a failure replaces the same archive.
+	path := fmt.Sprintf("%s/%d-%s.json.gz", modelUUID, first.Time, first.Id.Hex())
+	if _, err := store.Put(path, &buf, int64(buf.Len())); err != nil {
+		return errors.Annotate(err, "storing log archive")
+	}
+	_, err := archivesColl.UpsertId(path, &logArchiveDoc{
+		Path:      path,
+		ModelUUID: modelUUID,
+		Start:     first.Time,
+		End:       last.Time,
+		Count:     len(docs),
+	})
+	return errors.Annotate(err, "recording log archive")
+}
+
+// processArchives sends the offloaded log messages that match the
+// tailer's parameters, oldest first.
+func (t *logTailer) processArchives() error {
+	matches, err := t.logDocMatcher()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sel := bson.D{}
+	if !t.params.AllModels {
+		sel = append(sel, bson.DocElem{"model-uuid", t.modelUUID})
+	}
+	if !t.params.StartTime.IsZero() {
+		sel = append(sel, bson.DocElem{"end", bson.M{"$gte": t.params.StartTime.UnixNano()}})
+	}
+	iter := t.logsColl.Database.C(logArchivesC).Find(sel).Sort("model-uuid", "start").Iter()
+	var archive logArchiveDoc
+	for iter.Next(&archive) {
+		if err := t.processArchive(archive.Path, matches); err != nil {
+			iter.Close()
+			return errors.Annotatef(err, "reading log archive %q", archive.Path)
+		}
+	}
+	return errors.Trace(iter.Close())
+}
+
+// processArchive sends the log messages in the named archive that
+// satisfy matches.
+func (t *logTailer) processArchive(path string, matches func(*logDoc) bool) error {
+	r, err := t.archive.Get(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer zr.Close()
+	dec := json.NewDecoder(zr)
+	for {
+		doc := new(logDoc)
+		if err := dec.Decode(doc); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if !matches(doc) {
+			continue
+		}
+		rec, err := logDocToRecord(doc)
+		if err != nil {
+			return errors.Annotate(err, "deserialization failed (possible archive corruption)")
+		}
+		select {
+		case <-t.tomb.Dying():
+			return errors.Trace(tomb.ErrDying)
+		case t.logCh <- rec:
+			t.lastID = rec.ID
+			t.lastTime = rec.Time
+		}
+	}
+}
+
+// logDocMatcher returns a function that reports whether a log message
+// matches the tailer's parameters, as the selector returned by
+// paramsToSelector does in the database.
+func (t *logTailer) logDocMatcher() (func(*logDoc) bool, error) {
+	params := t.params
+	compile := func(patterns []string, makePattern func([]string) string) (*regexp.Regexp, error) {
+		if len(patterns) == 0 {
+			return nil, nil
+		}
+		return regexp.Compile(makePattern(patterns))
+	}
+	includeEntity, err := compile(params.IncludeEntity, makeEntityPattern)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	excludeEntity, err := compile(params.ExcludeEntity, makeEntityPattern)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	includeModule, err := compile(params.IncludeModule, makeModulePattern)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	excludeModule, err := compile(params.ExcludeModule, makeModulePattern)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return func(doc *logDoc) bool {
+		switch {
+		case !params.StartTime.IsZero() && doc.Time < params.StartTime.UnixNano():
+			return false
+		case !params.AllModels && doc.ModelUUID != t.modelUUID:
+			return false
+		case params.MinLevel > loggo.UNSPECIFIED && doc.Level < int(params.MinLevel):
+			return false
+		case includeEntity != nil && !includeEntity.MatchString(doc.Entity):
+			return false
+		case excludeEntity != nil && excludeEntity.MatchString(doc.Entity):
+			return false
+		case includeModule != nil && !includeModule.MatchString(doc.Module):
+			return false
+		case excludeModule != nil && excludeModule.MatchString(doc.Module):
+			return false
+		}
+		return true
+	}, nil
+}
+
+// openLogArchiveStorage returns the store from which a LogTailer
+// reads offloaded logs, or nil if there is none.
+func openLogArchiveStorage(stores *objectstore.Stores) blobstore.ResourceStorage {
+	store, err := stores.ExternalStorage(logArchiveNamespace)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		logger.Warningf("cannot read offloaded logs: %v", err)
+		return nil
+	}
+	return store
+}
+
+// removeModelLogArchives removes the archives of the model's offloaded
+// logs.
+func removeModelLogArchives(session *mgo.Session, stores *objectstore.Stores, modelUUID string) error {
+	archivesColl := session.DB(logsDB).C(logArchivesC)
+	var archives []logArchiveDoc
+	if err := archivesColl.Find(bson.M{"model-uuid": modelUUID}).All(&archives); err != nil {
+		return errors.Trace(err)
+	}
+	if len(archives) == 0 {
+		return nil
+	}
+	if store := openLogArchiveStorage(stores); store != nil {
+		for _, archive := range archives {
+			if err := store.Remove(archive.Path); err != nil && !errors.IsNotFound(err) {
+				return errors.Annotatef(err, "removing log archive %q", archive.Path)
+			}
+		}
+	} else {
+		logger.Warningf("cannot remove %d log archives for model %s: no object store", len(archives), modelUUID)
+	}
+	_, err := archivesColl.RemoveAll(bson.M{"model-uuid": modelUUID})
+	return errors.Trace(err)
+}
+
+// logArchiveIndexes defines the indexes we need on the log archives
+// collection.
+var logArchiveIndexes = [][]string{
+	{"model-uuid", "start"},
+	{"end"},
+}
@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type LogArchiveSuite struct {
+	ConnSuite
+	logsColl     *mgo.Collection
+	archivesColl *mgo.Collection
+	store        *memLogStore
+}
+
+var _ = gc.Suite(&LogArchiveSuite{})
+
+func (s *LogArchiveSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	session := s.State.MongoSession()
+	s.logsColl = session.DB("logs").C("logs")
+	s.archivesColl = session.DB("logs").C("archives")
+	s.store = &memLogStore{blobs: make(map[string][]byte)}
+}
+
+func (s *LogArchiveSuite) log(c *gc.C, t time.Time, level loggo.Level, msg string) {
+	dbLogger := state.NewEntityDbLogger(s.State, names.NewMachineTag("0"), jujuversion.Current)
+	defer dbLogger.Close()
+	err := dbLogger.Log(t, "module", "loc", level, msg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LogArchiveSuite) replay(c *gc.C, params *state.LogTailerParams) []string {
+	params.NoTail = true
+	params.Archive = s.store
+	tailer, err := state.NewLogTailer(s.State, params)
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	var messages []string
+	for {
+		select {
+		case rec, ok := <-tailer.Logs():
+			if !ok {
+				c.Assert(tailer.Err(), jc.ErrorIsNil)
+				return messages
+			}
+			messages = append(messages, rec.Message)
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for logs")
+		}
+	}
+}
+
+func (s *LogArchiveSuite) TestOffloadLogs(c *gc.C) {
+	now := truncateDBTime(coretesting.NonZeroTime())
+	cutoff := now.Add(-time.Hour)
+	s.log(c, cutoff.Add(-3*time.Second), loggo.INFO, "old 1")
+	s.log(c, cutoff.Add(-2*time.Second), loggo.DEBUG, "old 2")
+	s.log(c, cutoff.Add(-time.Second), loggo.INFO, "old 3")
+	s.log(c, cutoff, loggo.INFO, "new 1")
+	s.log(c, now, loggo.INFO, "new 2")
+
+	err := state.OffloadLogs(s.State, s.store, cutoff)
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.logsColl.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+	c.Assert(s.store.blobs, gc.HasLen, 1)
+	count, err = s.archivesColl.Find(bson.M{"model-uuid": s.State.ModelUUID()}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+
+	// Offloaded logs are replayed before those in the database.
+	c.Assert(s.replay(c, &state.LogTailerParams{}), jc.DeepEquals, []string{
+		"old 1", "old 2", "old 3", "new 1", "new 2",
+	})
+	// Filters apply to them too.
+	c.Assert(s.replay(c, &state.LogTailerParams{
+		StartTime: cutoff.Add(-2 * time.Second),
+		MinLevel:  loggo.INFO,
+	}), jc.DeepEquals, []string{
+		"old 3", "new 1", "new 2",
+	})
+	// They are too old to be among the most recent lines.
+	c.Assert(s.replay(c, &state.LogTailerParams{InitialLines: 3}), jc.DeepEquals, []string{
+		"new 1", "new 2",
+	})
+}
+
+func (s *LogArchiveSuite) TestOffloadLogsInBatches(c *gc.C) {
+	s.PatchValue(state.LogArchiveSize, 2)
+	now := truncateDBTime(coretesting.NonZeroTime())
+	s.log(c, now.Add(-5*time.Second), loggo.INFO, "1")
+	// Messages with the same time are kept in the same archive.
+	s.log(c, now.Add(-4*time.Second), loggo.INFO, "2")
+	s.log(c, now.Add(-4*time.Second), loggo.INFO, "3")
+	s.log(c, now.Add(-3*time.Second), loggo.INFO, "4")
+	s.log(c, now.Add(-2*time.Second), loggo.INFO, "5")
+
+	err := state.OffloadLogs(s.State, s.store, now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.logsColl.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+	var counts []int
+	iter := s.archivesColl.Find(nil).Sort("start").Iter()
+	var doc struct {
+		Count int `bson:"count"`
+	}
+	for iter.Next(&doc) {
+		counts = append(counts, doc.Count)
+	}
+	c.Assert(iter.Close(), jc.ErrorIsNil)
+	c.Assert(counts, jc.DeepEquals, []int{1, 2, 2})
+	c.Assert(s.replay(c, &state.LogTailerParams{}), jc.DeepEquals, []string{
+		"1", "2", "3", "4", "5",
+	})
+}
+
+func (s *LogArchiveSuite) TestOffloadLogsStoreFailure(c *gc.C) {
+	now := truncateDBTime(coretesting.NonZeroTime())
+	s.log(c, now.Add(-time.Second), loggo.INFO, "old")
+	s.store.putErr = errors.New("boom")
+
+	err := state.OffloadLogs(s.State, s.store, now)
+	c.Assert(err, gc.ErrorMatches, "cannot offload logs for model .*: storing log archive: boom")
+
+	// The logs are kept until they have been stored.
+	count, err := s.logsColl.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+}
+
+// memLogStore is an in-memory blobstore.ResourceStorage.
+type memLogStore struct {
+	blobs  map[string][]byte
+	putErr error
+}
+
+func (s *memLogStore) Get(path string) (io.ReadCloser, error) {
+	data, ok := s.blobs[path]
+	if !ok {
+		return nil, errors.NotFoundf("object %q", path)
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memLogStore) Put(path string, r io.Reader, length int64) (string, error) {
+	if s.putErr != nil {
+		return "", s.putErr
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	s.blobs[path] = data
+	return "", nil
+}
+
+func (s *memLogStore) Remove(path string) error {
+	delete(s.blobs, path)
+	return nil
+}
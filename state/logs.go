@@ -18,12 +18,14 @@ import (
 	"github.com/juju/utils/deque"
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
+	"gopkg.in/juju/blobstore.v2"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/objectstore"
 )
 
 // TODO(wallyworld) - lp:1602508 - collections need to be defined in collections.go
@@ -78,6 +80,13 @@ func InitDbLogs(session *mgo.Session) error {
 			return errors.Annotate(err, "cannot create index for logs collection")
 		}
 	}
+	archivesColl := session.DB(logsDB).C(logArchivesC)
+	for _, key := range logArchiveIndexes {
+		err := archivesColl.EnsureIndex(mgo.Index{Key: key})
+		if err != nil {
+			return errors.Annotate(err, "cannot create index for log archives collection")
+		}
+	}
 	return nil
 }
 
@@ -198,20 +207,21 @@ func (logger *LastSentLogTracker) Get() (int64, int64, error) {
 //
 // Single character field names are used for serialisation to save
 // space. These documents will be inserted 1000's of times and each
-// document includes the field names.
+// document includes the field names. The same names are used when
+// log documents are written to archives as JSON (see OffloadLogs).
 // (alesstimec) It would be really nice if we could store Time as int64
 // for increased precision.
 // TODO: remove version from this structure: https://pad.lv/1643743
 type logDoc struct {
-	Id        bson.ObjectId `bson:"_id"`
-	Time      int64         `bson:"t"` // unix nano UTC
-	ModelUUID string        `bson:"e"`
-	Entity    string        `bson:"n"` // e.g. "machine-0"
-	Version   string        `bson:"r"`
-	Module    string        `bson:"m"` // e.g. "juju.worker.firewaller"
-	Location  string        `bson:"l"` // "filename:lineno"
-	Level     int           `bson:"v"`
-	Message   string        `bson:"x"`
+	Id        bson.ObjectId `bson:"_id" json:"_id"`
+	Time      int64         `bson:"t" json:"t"` // unix nano UTC
+	ModelUUID string        `bson:"e" json:"e"`
+	Entity    string        `bson:"n" json:"n"` // e.g. "machine-0"
+	Version   string        `bson:"r" json:"r"`
+	Module    string        `bson:"m" json:"m"` // e.g. "juju.worker.firewaller"
+	Location  string        `bson:"l" json:"l"` // "filename:lineno"
+	Level     int           `bson:"v" json:"v"`
+	Message   string        `bson:"x" json:"x"`
 }
 
 type DbLogger struct {
@@ -342,6 +352,11 @@ type LogTailerParams struct {
 	ExcludeModule []string
 	Oplog         *mgo.Collection // For testing only
 	AllModels     bool
+
+	// Archive holds the offloaded logs. If it is nil, the
+	// controller's external object store is used, if it has one.
+	// For testing only.
+	Archive blobstore.ResourceStorage
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -368,10 +383,16 @@ type LogTailerState interface {
 
 	// IsController indicates whether or not the model is the admin model.
 	IsController() bool
+
+	// ObjectStores returns the object stores in which offloaded
+	// logs are kept.
+	ObjectStores() *objectstore.Stores
 }
 
 // NewLogTailer returns a LogTailer which filters according to the
-// parameters given.
+// parameters given. Logs that have been offloaded to the external
+// object store (see OffloadLogs) are returned before those in the
+// database, unless params.InitialLines is set.
 func NewLogTailer(st LogTailerState, params *LogTailerParams) (LogTailer, error) {
 	if !st.IsController() && params.AllModels {
 		return nil, errors.NewNotValid(nil, "not allowed to tail logs from all models: not a controller")
@@ -385,6 +406,10 @@ func NewLogTailer(st LogTailerState, params *LogTailerParams) (LogTailer, error)
 		params:    params,
 		logCh:     make(chan *LogRecord),
 		recentIds: newRecentIdTracker(maxRecentLogIds),
+		archive:   params.Archive,
+	}
+	if t.archive == nil {
+		t.archive = openLogArchiveStorage(st.ObjectStores())
 	}
 	go func() {
 		err := t.loop()
@@ -406,6 +431,7 @@ type logTailer struct {
 	lastID    int64
 	lastTime  time.Time
 	recentIds *recentIdTracker
+	archive   blobstore.ResourceStorage
 }
 
 // Logs implements the LogTailer interface.
@@ -430,6 +456,13 @@ func (t *logTailer) Err() error {
 }
 
 func (t *logTailer) loop() error {
+	// Offloaded logs are older than any in the database, so they
+	// are skipped when only the most recent lines are wanted.
+	if t.archive != nil && t.params.InitialLines == 0 {
+		if err := t.processArchives(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	err := t.processCollection()
 	if err != nil {
 		return errors.Trace(err)
@@ -836,7 +869,7 @@ func getLogCountForEnv(coll *mgo.Collection, modelUUID string) (int, error) {
 	return count, nil
 }
 
-func removeModelLogs(session *mgo.Session, modelUUID string) error {
+func removeModelLogs(session *mgo.Session, stores *objectstore.Stores, modelUUID string) error {
 	logsDB := session.DB(logsDB)
 	logsColl := logsDB.C(logsC)
 	_, err := logsColl.RemoveAll(bson.M{"e": modelUUID})
@@ -847,5 +880,10 @@ func removeModelLogs(session *mgo.Session, modelUUID string) error {
 	// Also remove the tracked high-water times.
 	trackersColl := logsDB.C(forwardedC)
 	_, err = trackersColl.RemoveAll(bson.M{"model-uuid": modelUUID})
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// And the offloaded logs.
+	return errors.Trace(removeModelLogArchives(session, stores, modelUUID))
 }
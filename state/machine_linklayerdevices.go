@@ -812,10 +812,13 @@ func (m *Machine) maybeAssertSubnetAliveOps(newDoc *ipAddressDoc, opsSoFar []txn
 	}
 
 	// Subnet exists and is still alive, assert that is stays that way.
+	// The no-op update bumps the subnet's txn-revno, which is what
+	// Subnet.RemoveIfUnused asserts on to detect new addresses.
 	return append(opsSoFar, txn.Op{
 		C:      subnetsC,
 		Id:     m.st.docID(newDoc.SubnetCIDR),
 		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"life", Alive}}}},
 	}), nil
 }
 
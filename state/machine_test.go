@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 	"github.com/juju/juju/worker"
 )
 
@@ -1544,6 +1545,35 @@ func (s *MachineSuite) TestSetProviderAddresses(c *gc.C) {
 	c.Assert(machine.Addresses(), jc.DeepEquals, expectedAddresses)
 }
 
+func (s *MachineSuite) TestSetProviderAddressesUsesModelIPv6Mode(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		ConfigAttrs: coretesting.Attrs{"ipv6-mode": "dual-stack"},
+	})
+	defer st.Close()
+	machine, err := st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addresses := network.NewAddresses("8.8.8.8", "2001:db8::1")
+	err = machine.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(machine.Addresses(), jc.DeepEquals, network.NewAddresses("2001:db8::1", "8.8.8.8"))
+	addr, err := machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "2001:db8::1")
+
+	// Machines in other models still prefer IPv4 addresses.
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = other.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
+}
+
 func (s *MachineSuite) TestSetProviderAddressesWithContainers(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
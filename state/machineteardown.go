@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+)
+
+// MachineTeardown describes everything that force-destroying a machine
+// removes along with it. Each list is in the order in which the
+// cleanup removes its entries.
+type MachineTeardown struct {
+	// Containers holds the ids of the machine's containers, and of
+	// their containers in turn, innermost first.
+	Containers []string
+
+	// Units holds the names of the units on the machine and its
+	// containers. Units in containers come before those on the
+	// machine itself, and subordinates before their principals.
+	Units []string
+
+	// StorageAttachments holds the storage attachments of those
+	// units.
+	StorageAttachments []StorageAttachment
+}
+
+// ForceDestroyTeardown reports what ForceDestroy would remove along
+// with the machine, without changing anything. It follows the same
+// order as the cleanup that ForceDestroy schedules.
+func (m *Machine) ForceDestroyTeardown() (MachineTeardown, error) {
+	var teardown MachineTeardown
+	if m.IsManager() {
+		return teardown, errors.Trace(managerMachineError)
+	}
+	if err := m.st.addMachineTeardown(&teardown, m); err != nil {
+		return MachineTeardown{}, errors.Annotatef(err, "cannot get teardown for machine %v", m)
+	}
+	return teardown, nil
+}
+
+// addMachineTeardown adds the containers, units and storage
+// attachments that cleanupForceDestroyedMachine removes with the
+// machine to the given teardown.
+func (st *State) addMachineTeardown(teardown *MachineTeardown, machine *Machine) error {
+	containerIds, err := machine.Containers()
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	for _, containerId := range containerIds {
+		container, err := st.Machine(containerId)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := st.addMachineTeardown(teardown, container); err != nil {
+			return errors.Trace(err)
+		}
+		teardown.Containers = append(teardown.Containers, containerId)
+	}
+	for _, unitName := range machine.doc.Principals {
+		if err := st.addUnitTeardown(teardown, unitName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// addUnitTeardown adds the unit, its subordinates and their storage
+// attachments to the given teardown, in the order in which
+// obliterateUnit removes them.
+func (st *State) addUnitTeardown(teardown *MachineTeardown, unitName string) error {
+	unit, err := st.Unit(unitName)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, subName := range unit.SubordinateNames() {
+		if err := st.addUnitTeardown(teardown, subName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	attachments, err := st.UnitStorageAttachments(unit.UnitTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	teardown.StorageAttachments = append(teardown.StorageAttachments, attachments...)
+	teardown.Units = append(teardown.Units, unitName)
+	return nil
+}
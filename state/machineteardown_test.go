@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type MachineTeardownSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&MachineTeardownSuite{})
+
+func (s *MachineTeardownSuite) addContainer(c *gc.C, parentId string) *state.Machine {
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, parentId, instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	return container
+}
+
+func (s *MachineTeardownSuite) TestForceDestroyTeardown(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	container := s.addContainer(c, machine.Id())
+	nested := s.addContainer(c, container.Id())
+
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeContainer)
+	err = prr.pu0.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pu1.AssignToMachine(nested)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	})
+	storageUnit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = storageUnit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	teardown, err := machine.ForceDestroyTeardown()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(teardown.Containers, jc.DeepEquals, []string{nested.Id(), container.Id()})
+	c.Assert(teardown.Units, jc.DeepEquals, []string{
+		prr.ru1.Name(), prr.pu1.Name(),
+		prr.ru0.Name(), prr.pu0.Name(),
+		storageUnit.Name(),
+	})
+	c.Assert(teardown.StorageAttachments, gc.HasLen, 1)
+	c.Assert(teardown.StorageAttachments[0].StorageInstance(), gc.Equals, names.NewStorageTag("data/0"))
+	c.Assert(teardown.StorageAttachments[0].Unit(), gc.Equals, storageUnit.UnitTag())
+
+	// Nothing has been changed.
+	assertLife(c, machine, state.Alive)
+	assertLife(c, nested, state.Alive)
+	assertLife(c, prr.pu0, state.Alive)
+}
+
+func (s *MachineTeardownSuite) TestForceDestroyTeardownManager(c *gc.C) {
+	manager, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = manager.ForceDestroyTeardown()
+	c.Assert(err, gc.ErrorMatches, "machine is required by the model")
+}
+
+func (s *MachineTeardownSuite) TestForceDestroyRemovesTeardown(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	container0 := s.addContainer(c, machine.Id())
+	container1 := s.addContainer(c, machine.Id())
+	nested := s.addContainer(c, container0.Id())
+
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeContainer)
+	err = prr.pu0.AssignToMachine(nested)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pu1.AssignToMachine(container1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.ForceDestroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, m := range []*state.Machine{container0, container1, nested} {
+		err := m.Refresh()
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	for _, u := range []*state.Unit{prr.pu0, prr.pu1, prr.ru0, prr.ru1} {
+		assertRemoved(c, u)
+	}
+	assertLife(c, machine, state.Dead)
+}
@@ -123,6 +123,9 @@ func (st *State) Export() (description.Model, error) {
 	if err := export.sshHostKeys(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.secrets(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := export.storage(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -607,6 +610,7 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		CharmModifiedVersion: application.doc.CharmModifiedVersion,
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
+		ExposedCIDRs:         application.doc.ExposedCIDRs,
 		MinUnits:             application.doc.MinUnits,
 		EndpointBindings:     map[string]string(ctx.endpoingBindings[globalKey]),
 		Settings:             applicationSettingsDoc.Settings,
@@ -950,6 +954,43 @@ func (e *exporter) sshHostKeys() error {
 	return nil
 }
 
+// secrets exports the model's secrets with their values decrypted. The
+// secret key is not migrated; the importer encrypts the values again
+// with the target model's key.
+func (e *exporter) secrets() error {
+	secrets, closer := e.st.getCollection(secretsC)
+	defer closer()
+	var docs []secretDoc
+	if err := secrets.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot read secrets")
+	}
+	e.logger.Debugf("read %d secrets", len(docs))
+	if len(docs) == 0 {
+		// Don't create a secret key for a model that has no secrets.
+		return nil
+	}
+	key, err := e.st.secretKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, doc := range docs {
+		value, err := decryptSecret(key, doc.Value)
+		if err != nil {
+			return errors.Annotatef(err, "cannot decrypt secret %q for application %q", doc.Name, doc.Application)
+		}
+		e.model.AddSecret(description.SecretArgs{
+			Application:    doc.Application,
+			Name:           doc.Name,
+			Revision:       doc.Revision,
+			Value:          string(value),
+			Created:        time.Unix(0, doc.Created).UTC(),
+			Updated:        time.Unix(0, doc.Updated).UTC(),
+			RotateInterval: time.Duration(doc.RotateInterval),
+		})
+	}
+	return nil
+}
+
 func (e *exporter) cloudimagemetadata() error {
 	cloudimagemetadata, err := e.st.CloudImageMetadataStorage.AllCloudImageMetadata()
 	if err != nil {
@@ -702,6 +702,32 @@ func (s *MigrationExportSuite) TestSSHHostKeys(c *gc.C) {
 	c.Assert(key.Keys(), jc.DeepEquals, []string{"bam", "mam"})
 }
 
+func (s *MigrationExportSuite) TestSecrets(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	interval := time.Hour
+	err := s.State.SetSecret(application.Name(), "db-password", state.SecretArgs{
+		Value:          "hunter2",
+		RotateInterval: &interval,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(application.Name(), "db-password")
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	secrets := model.Secrets()
+	c.Assert(secrets, gc.HasLen, 1)
+	exported := secrets[0]
+	c.Assert(exported.Application(), gc.Equals, application.Name())
+	c.Assert(exported.Name(), gc.Equals, "db-password")
+	c.Assert(exported.Revision(), gc.Equals, 1)
+	c.Assert(exported.Value(), gc.Equals, "hunter2")
+	c.Assert(exported.Created(), gc.Equals, secret.Created())
+	c.Assert(exported.Updated(), gc.Equals, secret.Updated())
+	c.Assert(exported.RotateInterval(), gc.Equals, time.Hour)
+}
+
 func (s *MigrationExportSuite) TestCloudImageMetadatas(c *gc.C) {
 	storageSize := uint64(3)
 	attrs := cloudimagemetadata.MetadataAttributes{
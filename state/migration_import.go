@@ -156,6 +156,10 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.applications(); err != nil {
 		return nil, nil, errors.Annotate(err, "applications")
 	}
+	// Secrets belong to applications, so they are restored after them.
+	if err := restore.secrets(); err != nil {
+		return nil, nil, errors.Annotate(err, "secrets")
+	}
 	if err := restore.relations(); err != nil {
 		return nil, nil, errors.Annotate(err, "relations")
 	}
@@ -884,6 +888,7 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		ExposedCIDRs:         s.ExposedCIDRs(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
 	}, nil
@@ -1221,6 +1226,48 @@ func (i *importer) addIPAddress(addr description.IPAddress) error {
 	return nil
 }
 
+// secrets imports the model's secrets, encrypting their values with
+// the new model's secret key.
+func (i *importer) secrets() error {
+	i.logger.Debugf("importing secrets")
+	secrets := i.model.Secrets()
+	if len(secrets) == 0 {
+		return nil
+	}
+	key, err := i.st.secretKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, secret := range secrets {
+		value, err := encryptSecret(key, []byte(secret.Value()))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		id := secretDocId(secret.Application(), secret.Name())
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &secretDoc{
+				DocID:          id,
+				Application:    secret.Application(),
+				Name:           secret.Name(),
+				Revision:       secret.Revision(),
+				Value:          value,
+				Created:        secret.Created().UnixNano(),
+				Updated:        secret.Updated().UnixNano(),
+				RotateInterval: int64(secret.RotateInterval()),
+			},
+		})
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("importing secrets succeeded")
+	return nil
+}
+
 func (i *importer) sshHostKeys() error {
 	i.logger.Debugf("importing ssh host keys")
 	for _, key := range i.model.SSHHostKeys() {
@@ -327,7 +327,7 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	err = application.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
 	// Expose the application.
-	c.Assert(application.SetExposed(), jc.ErrorIsNil)
+	c.Assert(application.SetExposedToCIDRs([]string{"10.0.0.0/8"}), jc.ErrorIsNil)
 	err = s.State.SetAnnotations(application, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, application, status.Active, 5)
@@ -348,6 +348,7 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(imported.ApplicationTag(), gc.Equals, exported.ApplicationTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -792,6 +793,29 @@ func (s *MigrationImportSuite) TestSSHHostKey(c *gc.C) {
 	c.Assert(keys, jc.DeepEquals, state.SSHHostKeys{"bam", "mam"})
 }
 
+func (s *MigrationImportSuite) TestSecrets(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := s.State.SetSecret(application.Name(), "db-password", state.SecretArgs{Value: "hunter1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetSecret(application.Name(), "db-password", state.SecretArgs{Value: "hunter2"})
+	c.Assert(err, jc.ErrorIsNil)
+	original, err := s.State.Secret(application.Name(), "db-password")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	// The value is encrypted with the new model's key, so reading it
+	// back shows that it was migrated.
+	secret, err := newSt.Secret(application.Name(), "db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "hunter2")
+	c.Assert(secret.Revision(), gc.Equals, 2)
+	c.Assert(secret.Created(), gc.Equals, original.Created())
+	c.Assert(secret.Updated(), gc.Equals, original.Updated())
+}
+
 func (s *MigrationImportSuite) TestCloudImageMetadata(c *gc.C) {
 	storageSize := uint64(3)
 	attrs := cloudimagemetadata.MetadataAttributes{
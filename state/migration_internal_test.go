@@ -43,6 +43,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		meterStatusC, // red / green status for metrics of units
 		payloadsC,
 		"resources",
+		secretsC,
 
 		// relation
 		relationsC,
@@ -155,6 +156,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		providerIDsC,
 		linkLayerDevicesRefsC,
 
+		// Static address reservations are only needed until a container
+		// reports its addresses, which are migrated, and allocation skips
+		// addresses already in use.
+		staticAddressesC,
+
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
@@ -162,6 +168,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// and are not to be migrated.
 		globalSettingsC,
 
+		// Model templates are controller specific, and are only
+		// used when creating a model.
+		modelTemplatesC,
+
+		// User quotas are controller specific, and span all the
+		// models a user owns.
+		userQuotasC,
+
 		// The auditing collection stores a large amount of historical data
 		// and will be streamed across after migration in a similar way to
 		// logging.
@@ -179,6 +193,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Metrics manager maintains controller specific state relating to
 		// the store and forward of charm metrics. Nothing to migrate here.
 		metricsManagerC,
+
+		// Engine reports are diagnostic, and are sent again by the
+		// agents once they are running against the target controller.
+		agentEngineReportsC,
+
+		// Secret keys are never migrated; secrets must be encrypted
+		// again with the target model's key when they are.
+		secretKeysC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -289,6 +311,9 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// CloudInitUserData is only used when provisioning the
+		// machine, which has already happened for migrated machines.
+		"CloudInitUserData",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -350,6 +375,15 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// LeadershipPinned is a maintenance-time setting, and leadership
+		// leases are not migrated either.
+		"LeadershipPinned",
+		// CharmRollback only lasts for the rollback window after
+		// an upgrade, and models are not migrated mid-upgrade.
+		"CharmRollback",
+		// StagedConfig holds uncommitted changes, which are
+		// discarded rather than migrated.
+		"StagedConfig",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -360,6 +394,7 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedCIDRs",
 		"MinUnits",
 		"MetricCredentials",
 	)
@@ -653,6 +688,26 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 	s.AssertExportedFields(c, sshHostKeysDoc{}, migrated.Union(ignored))
 }
 
+func (s *MigrationSuite) TestSecretDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		// DocID is the env + application + name
+		"DocID",
+		"ModelUUID",
+		// TxnRevno is mgo internals and should not be migrated.
+		"TxnRevno",
+	)
+	migrated := set.NewStrings(
+		"Application",
+		"Name",
+		"Revision",
+		"Value",
+		"Created",
+		"Updated",
+		"RotateInterval",
+	)
+	s.AssertExportedFields(c, secretDoc{}, migrated.Union(ignored))
+}
+
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
@@ -677,6 +732,10 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
+		// RequestedSize is a pending resize request, which is
+		// not carried over; it can be requested again once the
+		// model has been migrated.
+		"RequestedSize",
 	)
 	migrated := set.NewStrings(
 		"Name",
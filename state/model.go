@@ -83,6 +83,10 @@ type modelDoc struct {
 	// LatestAvailableTools is a string representing the newest version
 	// found while checking streams for new versions.
 	LatestAvailableTools string `bson:"available-tools,omitempty"`
+
+	// ReleaseStorage records that the model's volumes are to be
+	// released, rather than destroyed, when the model is destroyed.
+	ReleaseStorage bool `bson:"release-storage,omitempty"`
 }
 
 // modelEntityRefsDoc records references to the top-level entities
@@ -292,6 +296,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 	prereqOps := []txn.Op{
 		assertCloudRegionOp,
 		assertCloudCredentialOp,
+		nsRefcounts.JustIncRefOp(controllersC, cloudModelRefCountKey(args.CloudName), 1),
 	}
 	ops := append(prereqOps, modelOps...)
 	err = newSt.runTransaction(ops)
@@ -502,6 +507,13 @@ func (m *Model) Life() Life {
 	return m.doc.Life
 }
 
+// ReleaseStorage reports whether the model's volumes are to be released,
+// left in the cloud for later use, rather than destroyed as the model is
+// torn down. It is only ever true once the model is no longer Alive.
+func (m *Model) ReleaseStorage() bool {
+	return m.doc.ReleaseStorage
+}
+
 // Owner returns tag representing the owner of the model.
 // The owner is the user that created the model.
 func (m *Model) Owner() names.UserTag {
@@ -669,11 +681,26 @@ func (m *Model) isControllerModel() bool {
 // hosting any non-Dead models, this method will return an
 // error satisfying IsHasHostedsError.
 func (m *Model) Destroy() error {
+	return m.DestroyWithParams(DestroyModelParams{})
+}
+
+// DestroyModelParams contains the parameters for destroying a model.
+type DestroyModelParams struct {
+	// ReleaseStorage, if true, causes the model's volumes to be
+	// released rather than destroyed. Released volumes are removed
+	// from the model, but left in the cloud so that they may be
+	// attached again later.
+	ReleaseStorage bool
+}
+
+// DestroyWithParams is like Destroy, but additionally records how the
+// model's storage is to be disposed of.
+func (m *Model) DestroyWithParams(args DestroyModelParams) error {
 	ensureNoHostedModels := false
 	if m.isControllerModel() {
 		ensureNoHostedModels = true
 	}
-	return m.destroy(ensureNoHostedModels)
+	return m.destroy(ensureNoHostedModels, args)
 }
 
 // DestroyIncludingHosted sets the model's lifecycle to Dying, preventing
@@ -681,10 +708,10 @@ func (m *Model) Destroy() error {
 // hosting other models, they will also be destroyed.
 func (m *Model) DestroyIncludingHosted() error {
 	ensureNoHostedModels := false
-	return m.destroy(ensureNoHostedModels)
+	return m.destroy(ensureNoHostedModels, DestroyModelParams{})
 }
 
-func (m *Model) destroy(ensureNoHostedModels bool) (err error) {
+func (m *Model) destroy(ensureNoHostedModels bool, args DestroyModelParams) (err error) {
 	defer errors.DeferredAnnotatef(&err, "failed to destroy model")
 
 	st, closeState, err := m.getState()
@@ -705,7 +732,7 @@ func (m *Model) destroy(ensureNoHostedModels bool) (err error) {
 			}
 		}
 
-		ops, err := m.destroyOps(ensureNoHostedModels, false)
+		ops, err := m.destroyOps(ensureNoHostedModels, false, args)
 		if err == errModelNotAlive {
 			return nil, jujutxn.ErrNoOperations
 		} else if err != nil {
@@ -738,7 +765,7 @@ func IsHasHostedModelsError(err error) bool {
 //
 // If ensureNoHostedModels is true, then destroyOps will
 // fail if there are any non-Dead hosted models
-func (m *Model) destroyOps(ensureNoHostedModels, ensureEmpty bool) ([]txn.Op, error) {
+func (m *Model) destroyOps(ensureNoHostedModels, ensureEmpty bool, args DestroyModelParams) ([]txn.Op, error) {
 	if m.Life() != Alive {
 		return nil, errModelNotAlive
 	}
@@ -801,7 +828,7 @@ func (m *Model) destroyOps(ensureNoHostedModels, ensureEmpty bool) ([]txn.Op, er
 			}
 			// See if the model is empty, and if it is,
 			// get the ops required to destroy it.
-			ops, err := model.destroyOps(false, true)
+			ops, err := model.destroyOps(false, true, DestroyModelParams{})
 			switch err {
 			case errModelNotAlive:
 				dying++
@@ -841,6 +868,11 @@ func (m *Model) destroyOps(ensureNoHostedModels, ensureEmpty bool) ([]txn.Op, er
 			"time-of-death", timeOfDying,
 		})
 	}
+	if args.ReleaseStorage {
+		modelUpdateValues = append(modelUpdateValues, bson.DocElem{
+			"release-storage", true,
+		})
+	}
 
 	ops := []txn.Op{{
 		C:      modelsC,
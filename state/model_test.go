@@ -259,6 +259,21 @@ func (s *ModelSuite) TestConfigForControllerEnv(c *gc.C) {
 	c.Assert(conf.UUID(), gc.Equals, s.modelTag.Id())
 }
 
+func (s *ModelSuite) TestNewModelRejectsMisspelledAttr(c *gc.C) {
+	cfg, _ := s.createTestModelConfig(c)
+	cfg, err := cfg.Apply(map[string]interface{}{"Secret": "beef"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.State.NewModel(state.ModelArgs{
+		CloudName:   "dummy",
+		CloudRegion: "dummy-region",
+		Config:      cfg,
+		Owner:       names.NewUserTag("test@remote"),
+		StorageProviderRegistry: storage.StaticProviderRegistry{},
+	})
+	c.Assert(err, gc.ErrorMatches, `failed to create new model: unknown model config attribute "Secret" \(did you mean "secret"\?\)`)
+}
+
 func (s *ModelSuite) TestConfigForOtherEnv(c *gc.C) {
 	otherState := s.Factory.MakeModel(c, &factory.ModelParams{Name: "other"})
 	defer otherState.Close()
@@ -330,6 +345,34 @@ func (s *ModelSuite) TestDestroyOtherModel(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ModelSuite) TestDestroyReleaseStorage(c *gc.C) {
+	st2 := s.Factory.MakeModel(c, nil)
+	defer st2.Close()
+	factory.NewFactory(st2).MakeApplication(c, nil)
+
+	model, err := st2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ReleaseStorage(), jc.IsFalse)
+	err = model.DestroyWithParams(state.DestroyModelParams{ReleaseStorage: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Equals, state.Dying)
+	c.Assert(model.ReleaseStorage(), jc.IsTrue)
+}
+
+func (s *ModelSuite) TestDestroyDefaultDestroysStorage(c *gc.C) {
+	st2 := s.Factory.MakeModel(c, nil)
+	defer st2.Close()
+	factory.NewFactory(st2).MakeApplication(c, nil)
+
+	model, err := st2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Destroy(), jc.ErrorIsNil)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	c.Assert(model.ReleaseStorage(), jc.IsFalse)
+}
+
 func (s *ModelSuite) TestDestroyControllerNonEmptyModelFails(c *gc.C) {
 	st2 := s.Factory.MakeModel(c, nil)
 	defer st2.Close()
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
 )
 
 type attrValues map[string]interface{}
@@ -29,6 +30,16 @@ func (st *State) ModelConfig() (*config.Config, error) {
 	return config.New(config.NoDefaults, modelSettings.Map())
 }
 
+// ipv6Mode returns the model's IPv6 mode, which determines the
+// addresses preferred for its machines.
+func (st *State) ipv6Mode() (network.IPv6Mode, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.IPv6Mode(), nil
+}
+
 // checkModelConfig returns an error if the config is definitely invalid.
 func checkModelConfig(cfg *config.Config) error {
 	allAttrs := cfg.AllAttrs()
@@ -268,6 +279,9 @@ func (st *State) buildAndValidateModelConfig(updateAttrs attrValues, removeAttrs
 	if err := checkModelConfig(newConfig); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := config.ValidateNewAttrs(newConfig, oldConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return st.validate(newConfig, oldConfig)
 }
 
@@ -188,6 +188,22 @@ func (s *ModelConfigSuite) TestUpdateModelConfigRejectsControllerConfig(c *gc.C)
 	c.Assert(err, gc.ErrorMatches, `cannot set controller attribute "api-port" on a model`)
 }
 
+func (s *ModelConfigSuite) TestUpdateModelConfigRejectsMisspelledAttr(c *gc.C) {
+	updateAttrs := map[string]interface{}{"http-proxy ": "http://proxy"}
+	err := s.State.UpdateModelConfig(updateAttrs, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `unknown model config attribute "http-proxy " \(did you mean "http-proxy"\?\)`)
+
+	// The dummy provider's schema is registered for models of
+	// its type.
+	updateAttrs = map[string]interface{}{"Secret": "beef"}
+	err = s.State.UpdateModelConfig(updateAttrs, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `unknown model config attribute "Secret" \(did you mean "secret"\?\)`)
+
+	updateAttrs = map[string]interface{}{"arbitrary key": "shazam!"}
+	err = s.State.UpdateModelConfig(updateAttrs, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid model config attribute name "arbitrary key"`)
+}
+
 func (s *ModelConfigSuite) TestUpdateModelConfigRemoveInherited(c *gc.C) {
 	attrs := map[string]interface{}{
 		"apt-mirror":    "http://different-mirror", // controller
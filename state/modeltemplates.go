@@ -0,0 +1,162 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
+)
+
+// ModelTemplate holds the model config and constraints that are
+// applied to a new model created from it.
+type ModelTemplate struct {
+	// Name is the name used to select the template.
+	Name string
+
+	// Description describes the template for users choosing one.
+	Description string
+
+	// Config holds model config attributes. Attributes specified
+	// explicitly when a model is created take precedence.
+	Config map[string]interface{}
+
+	// Constraints holds the initial model constraints. Space
+	// requirements are expressed with the "spaces" constraint.
+	Constraints constraints.Value
+}
+
+// modelTemplateDoc is the mongodb representation of a ModelTemplate.
+type modelTemplateDoc struct {
+	Name        string                 `bson:"_id"`
+	Description string                 `bson:"description,omitempty"`
+	Config      map[string]interface{} `bson:"config,omitempty"`
+	Constraints string                 `bson:"constraints,omitempty"`
+}
+
+func (doc modelTemplateDoc) toModelTemplate() (ModelTemplate, error) {
+	cons, err := constraints.Parse(doc.Constraints)
+	if err != nil {
+		return ModelTemplate{}, errors.Annotatef(err, "model template %q", doc.Name)
+	}
+	return ModelTemplate{
+		Name:        doc.Name,
+		Description: doc.Description,
+		Config:      doc.Config,
+		Constraints: cons,
+	}, nil
+}
+
+// validateModelTemplate returns an error if the template cannot be
+// used to create models.
+func validateModelTemplate(tmpl ModelTemplate) error {
+	if !names.IsValidModelName(tmpl.Name) {
+		return errors.NotValidf("model template name %q", tmpl.Name)
+	}
+	for _, key := range []string{config.NameKey, config.UUIDKey, config.AgentVersionKey} {
+		if _, ok := tmpl.Config[key]; ok {
+			return errors.NotValidf("model template config attribute %q", key)
+		}
+	}
+	return nil
+}
+
+// ModelTemplate returns the model template with the given name.
+func (st *State) ModelTemplate(name string) (ModelTemplate, error) {
+	coll, closer := st.getCollection(modelTemplatesC)
+	defer closer()
+
+	var doc modelTemplateDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ModelTemplate{}, errors.NotFoundf("model template %q", name)
+	} else if err != nil {
+		return ModelTemplate{}, errors.Annotatef(err, "cannot get model template %q", name)
+	}
+	return doc.toModelTemplate()
+}
+
+// AllModelTemplates returns all the model templates in the
+// controller, ordered by name.
+func (st *State) AllModelTemplates() ([]ModelTemplate, error) {
+	coll, closer := st.getCollection(modelTemplatesC)
+	defer closer()
+
+	var docs []modelTemplateDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get model templates")
+	}
+	templates := make([]ModelTemplate, len(docs))
+	for i, doc := range docs {
+		tmpl, err := doc.toModelTemplate()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		templates[i] = tmpl
+	}
+	return templates, nil
+}
+
+// SetModelTemplate creates the given model template, or replaces
+// the existing template with the same name.
+func (st *State) SetModelTemplate(tmpl ModelTemplate) error {
+	if err := validateModelTemplate(tmpl); err != nil {
+		return errors.Trace(err)
+	}
+	doc := modelTemplateDoc{
+		Name:        tmpl.Name,
+		Description: tmpl.Description,
+		Config:      tmpl.Config,
+		Constraints: tmpl.Constraints.String(),
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		_, err := st.ModelTemplate(tmpl.Name)
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      modelTemplatesC,
+				Id:     tmpl.Name,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      modelTemplatesC,
+			Id:     tmpl.Name,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"description", doc.Description},
+				{"config", doc.Config},
+				{"constraints", doc.Constraints},
+			}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set model template %q", tmpl.Name)
+	}
+	return nil
+}
+
+// RemoveModelTemplate removes the model template with the given
+// name. Models already created from the template are unaffected.
+func (st *State) RemoveModelTemplate(name string) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		if _, err := st.ModelTemplate(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      modelTemplatesC,
+			Id:     name,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	return errors.Trace(st.run(buildTxn))
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+)
+
+type ModelTemplateSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelTemplateSuite{})
+
+var prodTemplate = state.ModelTemplate{
+	Name:        "prod",
+	Description: "production models",
+	Config: map[string]interface{}{
+		"default-series":            "xenial",
+		"automatically-retry-hooks": false,
+	},
+	Constraints: constraints.MustParse("mem=4G spaces=dmz"),
+}
+
+func (s *ModelTemplateSuite) TestModelTemplateNotFound(c *gc.C) {
+	_, err := s.State.ModelTemplate("prod")
+	c.Assert(err, gc.ErrorMatches, `model template "prod" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelTemplateSuite) TestSetModelTemplate(c *gc.C) {
+	err := s.State.SetModelTemplate(prodTemplate)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tmpl, err := s.State.ModelTemplate("prod")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tmpl, jc.DeepEquals, prodTemplate)
+}
+
+func (s *ModelTemplateSuite) TestSetModelTemplateReplaces(c *gc.C) {
+	err := s.State.SetModelTemplate(prodTemplate)
+	c.Assert(err, jc.ErrorIsNil)
+
+	replacement := state.ModelTemplate{
+		Name:   "prod",
+		Config: map[string]interface{}{"default-series": "bionic"},
+	}
+	err = s.State.SetModelTemplate(replacement)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tmpl, err := s.State.ModelTemplate("prod")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tmpl, jc.DeepEquals, replacement)
+}
+
+func (s *ModelTemplateSuite) TestSetModelTemplateInvalid(c *gc.C) {
+	err := s.State.SetModelTemplate(state.ModelTemplate{Name: "Not Valid"})
+	c.Assert(err, gc.ErrorMatches, `model template name "Not Valid" not valid`)
+
+	err = s.State.SetModelTemplate(state.ModelTemplate{
+		Name:   "prod",
+		Config: map[string]interface{}{"uuid": "deadbeef"},
+	})
+	c.Assert(err, gc.ErrorMatches, `model template config attribute "uuid" not valid`)
+}
+
+func (s *ModelTemplateSuite) TestAllModelTemplates(c *gc.C) {
+	templates, err := s.State.AllModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, gc.HasLen, 0)
+
+	dev := state.ModelTemplate{Name: "dev"}
+	for _, tmpl := range []state.ModelTemplate{prodTemplate, dev} {
+		err := s.State.SetModelTemplate(tmpl)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	templates, err = s.State.AllModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, jc.DeepEquals, []state.ModelTemplate{dev, prodTemplate})
+}
+
+func (s *ModelTemplateSuite) TestRemoveModelTemplate(c *gc.C) {
+	err := s.State.SetModelTemplate(prodTemplate)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveModelTemplate("prod")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelTemplate("prod")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveModelTemplate("prod")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -83,24 +83,45 @@ func (st *State) UpdateLastModelConnection(user names.UserTag) error {
 }
 
 func (st *State) updateLastModelConnection(user names.UserTag, when time.Time) error {
-	lastConnections, closer := st.getCollection(modelUserLastConnectionC)
-	defer closer()
+	return st.UpdateLastModelConnections([]ModelConnection{{
+		ModelUUID: st.ModelUUID(),
+		User:      user,
+		Time:      when,
+	}})
+}
 
-	lastConnectionsW := lastConnections.Writeable()
+// ModelConnection records when a user connected to a model.
+type ModelConnection struct {
+	ModelUUID string
+	User      names.UserTag
+	Time      time.Time
+}
+
+// UpdateLastModelConnections updates the last connection times of
+// several model users, possibly in different models, at once. It
+// allows the API server to batch up connection times rather than
+// write each one as it happens.
+func (st *State) UpdateLastModelConnections(conns []ModelConnection) error {
+	lastConnections, closer := st.getRawCollection(modelUserLastConnectionC)
+	defer closer()
 
 	// Update the safe mode of the underlying session to not require
 	// write majority, nor sync to disk.
-	session := lastConnectionsW.Underlying().Database.Session
+	session := lastConnections.Database.Session
 	session.SetSafe(&mgo.Safe{})
 
-	lastConn := modelUserLastConnectionDoc{
-		ID:             st.docID(strings.ToLower(user.Id())),
-		ModelUUID:      st.ModelUUID(),
-		UserName:       user.Id(),
-		LastConnection: when,
+	for _, conn := range conns {
+		lastConn := modelUserLastConnectionDoc{
+			ID:             ensureModelUUID(conn.ModelUUID, strings.ToLower(conn.User.Id())),
+			ModelUUID:      conn.ModelUUID,
+			UserName:       conn.User.Id(),
+			LastConnection: conn.Time,
+		}
+		if _, err := lastConnections.UpsertId(lastConn.ID, lastConn); err != nil {
+			return errors.Annotatef(err, "updating last connection of %q to model %q", conn.User.Id(), conn.ModelUUID)
+		}
 	}
-	_, err := lastConnectionsW.UpsertId(lastConn.ID, lastConn)
-	return errors.Trace(err)
+	return nil
 }
 
 // ModelUser a model userAccessDoc.
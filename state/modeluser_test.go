@@ -6,6 +6,7 @@ package state_test
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -362,6 +363,40 @@ func (s *ModelUserSuite) TestUpdateLastConnectionTwoModelUsers(c *gc.C) {
 	c.Assert(when.After(now) || when.Equal(now), jc.IsTrue)
 }
 
+func (s *ModelUserSuite) TestUpdateLastModelConnections(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername"})
+	st2 := s.Factory.MakeModel(c, nil)
+	defer st2.Close()
+	_, err := st2.AddModelUser(
+		st2.ModelUUID(),
+		state.UserAccessSpec{
+			User:      user.UserTag(),
+			CreatedBy: s.Owner,
+			Access:    permission.ReadAccess,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	when1 := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	when2 := when1.Add(time.Hour)
+	err = s.State.UpdateLastModelConnections([]state.ModelConnection{{
+		ModelUUID: s.State.ModelUUID(),
+		User:      user.UserTag(),
+		Time:      when1,
+	}, {
+		ModelUUID: st2.ModelUUID(),
+		User:      user.UserTag(),
+		Time:      when2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	when, err := s.State.LastModelConnection(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(when, gc.Equals, when1)
+	when, err = st2.LastModelConnection(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(when, gc.Equals, when2)
+}
+
 func (s *ModelUserSuite) TestModelsForUserNone(c *gc.C) {
 	tag := names.NewUserTag("non-existent@remote")
 	models, err := s.State.ModelsForUser(tag)
@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/blobstore.v2"
+)
+
+// tempPrefix is the prefix of partially written cache files.
+const tempPrefix = ".tmp-"
+
+// cachingStorage is a blobstore.ResourceStorage which keeps copies
+// of the blobs read from another store on local disk.
+type cachingStorage struct {
+	store   blobstore.ResourceStorage
+	dir     string
+	maxSize int64
+
+	// mu serialises pruning of the cache directory.
+	mu sync.Mutex
+}
+
+// NewCache returns a blobstore.ResourceStorage which reads blobs from
+// store, keeping copies in dir. When the copies exceed maxSize bytes,
+// the least recently read are removed.
+//
+// Blobs are never modified once stored, so cached copies
+// never become stale.
+func NewCache(store blobstore.ResourceStorage, dir string, maxSize int64) blobstore.ResourceStorage {
+	return &cachingStorage{
+		store:   store,
+		dir:     dir,
+		maxSize: maxSize,
+	}
+}
+
+// cachePath returns the path of the cached copy of the blob
+// at the given path.
+func (s *cachingStorage) cachePath(path string) string {
+	hash := sha256.Sum256([]byte(path))
+	return filepath.Join(s.dir, hex.EncodeToString(hash[:]))
+}
+
+// Get is part of the blobstore.ResourceStorage interface.
+func (s *cachingStorage) Get(path string) (io.ReadCloser, error) {
+	cachePath := s.cachePath(path)
+	if f, err := os.Open(cachePath); err == nil {
+		// Record the access, so recently read blobs are kept.
+		now := time.Now()
+		if err := os.Chtimes(cachePath, now, now); err != nil {
+			logger.Debugf("cannot update access time of %q: %v", cachePath, err)
+		}
+		return f, nil
+	}
+	r, err := s.store.Get(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := s.fill(cachePath, r); err != nil {
+		logger.Warningf("cannot cache object %q: %v", path, err)
+		return s.store.Get(path)
+	}
+	s.prune()
+	if f, err := os.Open(cachePath); err == nil {
+		return f, nil
+	}
+	// The blob was pruned as soon as it was cached,
+	// because it is larger than the cache.
+	return s.store.Get(path)
+}
+
+// fill writes the data from r to the cache file at cachePath,
+// closing r.
+func (s *cachingStorage) fill(cachePath string, r io.ReadCloser) error {
+	defer r.Close()
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := ioutil.TempFile(s.dir, tempPrefix)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(f, r)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), cachePath)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// prune removes the least recently read cached blobs
+// until the cache is no larger than its maximum size.
+func (s *cachingStorage) prune() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		logger.Warningf("cannot prune object cache: %v", err)
+		return
+	}
+	var size int64
+	var cached []os.FileInfo
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), tempPrefix) {
+			continue
+		}
+		size += info.Size()
+		cached = append(cached, info)
+	}
+	sort.Sort(byModTime(cached))
+	for _, info := range cached {
+		if size <= s.maxSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(s.dir, info.Name())); err != nil {
+			logger.Warningf("cannot prune object cache: %v", err)
+			return
+		}
+		size -= info.Size()
+	}
+}
+
+// Put is part of the blobstore.ResourceStorage interface.
+func (s *cachingStorage) Put(path string, r io.Reader, length int64) (string, error) {
+	return s.store.Put(path, r, length)
+}
+
+// Remove is part of the blobstore.ResourceStorage interface.
+func (s *cachingStorage) Remove(path string) error {
+	if err := os.Remove(s.cachePath(path)); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return s.store.Remove(path)
+}
+
+type byModTime []os.FileInfo
+
+func (s byModTime) Len() int           { return len(s) }
+func (s byModTime) Less(i, j int) bool { return s[i].ModTime().Before(s[j].ModTime()) }
+func (s byModTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/blobstore.v2"
+
+	"github.com/juju/juju/state/objectstore"
+)
+
+type cacheSuite struct {
+	testing.IsolationSuite
+	store *memStore
+	dir   string
+}
+
+var _ = gc.Suite(&cacheSuite{})
+
+func (s *cacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.store = newMemStore()
+	s.store.blobs["a"] = []byte("aaaa")
+	s.store.blobs["b"] = []byte("bbbb")
+	s.dir = c.MkDir()
+}
+
+func (s *cacheSuite) assertGet(c *gc.C, store blobstore.ResourceStorage, path, expect string) {
+	r, err := store.Get(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, expect)
+}
+
+func (s *cacheSuite) TestGetReadsThrough(c *gc.C) {
+	cache := objectstore.NewCache(s.store, s.dir, 1024)
+	s.assertGet(c, cache, "a", "aaaa")
+	s.assertGet(c, cache, "a", "aaaa")
+	s.store.CheckCallNames(c, "Get")
+
+	entries, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+}
+
+func (s *cacheSuite) TestGetNotFound(c *gc.C) {
+	cache := objectstore.NewCache(s.store, s.dir, 1024)
+	_, err := cache.Get("c")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *cacheSuite) TestPrune(c *gc.C) {
+	cache := objectstore.NewCache(s.store, s.dir, 6)
+	s.assertGet(c, cache, "a", "aaaa")
+	s.assertGet(c, cache, "b", "bbbb")
+	entries, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+
+	// "b" is still cached, "a" has been pruned.
+	s.store.ResetCalls()
+	s.assertGet(c, cache, "b", "bbbb")
+	s.assertGet(c, cache, "a", "aaaa")
+	s.store.CheckCalls(c, []testing.StubCall{{"Get", []interface{}{"a"}}})
+}
+
+func (s *cacheSuite) TestGetLargerThanCache(c *gc.C) {
+	cache := objectstore.NewCache(s.store, s.dir, 2)
+	s.assertGet(c, cache, "a", "aaaa")
+	entries, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *cacheSuite) TestPutAndRemove(c *gc.C) {
+	cache := objectstore.NewCache(s.store, s.dir, 1024)
+	_, err := cache.Put("c", strings.NewReader("cccc"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertGet(c, cache, "c", "cccc")
+
+	err = cache.Remove("c")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cache.Get("c")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	entries, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
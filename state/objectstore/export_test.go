@@ -0,0 +1,12 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore
+
+import (
+	"gopkg.in/juju/blobstore.v2"
+)
+
+func NewFallbackStorage(store, fallback blobstore.ResourceStorage) blobstore.ResourceStorage {
+	return &fallbackStorage{store, fallback}
+}
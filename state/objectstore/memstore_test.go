@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore_test
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+)
+
+// memStore is an in-memory blobstore.ResourceStorage.
+type memStore struct {
+	testing.Stub
+	blobs map[string][]byte
+}
+
+func newMemStore() *memStore {
+	return &memStore{blobs: make(map[string][]byte)}
+}
+
+func (s *memStore) Get(path string) (io.ReadCloser, error) {
+	s.MethodCall(s, "Get", path)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	data, ok := s.blobs[path]
+	if !ok {
+		return nil, errors.NotFoundf("object %q", path)
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memStore) Put(path string, r io.Reader, length int64) (string, error) {
+	s.MethodCall(s, "Put", path, length)
+	if err := s.NextErr(); err != nil {
+		return "", err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	s.blobs[path] = data
+	hash := md5.Sum(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func (s *memStore) Remove(path string) error {
+	s.MethodCall(s, "Remove", path)
+	if err := s.NextErr(); err != nil {
+		return err
+	}
+	if _, ok := s.blobs[path]; !ok {
+		return errors.NotFoundf("object %q", path)
+	}
+	delete(s.blobs, path)
+	return nil
+}
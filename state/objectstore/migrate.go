@@ -0,0 +1,65 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/blobstore.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Migrate moves the blobs stored in the GridFS of the named database
+// to the given store, returning the number of blobs moved. Each blob
+// is removed from GridFS only once it has been stored, and its checksum
+// verified. Migrate may safely be run while the controller is in use,
+// as blobs are read from GridFS until they have been moved.
+func Migrate(session *mgo.Session, db string, store blobstore.ResourceStorage) (int, error) {
+	gridFS := session.DB(db).GridFS(db)
+	iter := gridFS.Find(nil).Select(bson.M{"filename": 1}).Iter()
+	var doc struct {
+		Filename string `bson:"filename"`
+	}
+	var paths []string
+	for iter.Next(&doc) {
+		paths = append(paths, doc.Filename)
+	}
+	if err := iter.Close(); err != nil {
+		return 0, errors.Annotatef(err, "listing blobs in %q", db)
+	}
+
+	migrated := 0
+	for _, path := range paths {
+		if err := migrateBlob(gridFS, path, store); errors.IsNotFound(err) {
+			// Removed since we listed the blobs.
+			continue
+		} else if err != nil {
+			return migrated, errors.Annotatef(err, "migrating blob %q in %q", path, db)
+		}
+		logger.Debugf("migrated blob %q in %q", path, db)
+		migrated++
+	}
+	return migrated, nil
+}
+
+func migrateBlob(gridFS *mgo.GridFS, path string, store blobstore.ResourceStorage) error {
+	f, err := gridFS.Open(path)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("blob %q", path)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	checksum, err := store.Put(path, f, f.Size())
+	expected := f.MD5()
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if checksum != expected {
+		return errors.Errorf("checksum mismatch: expected %q, got %q", expected, checksum)
+	}
+	return errors.Trace(gridFS.Remove(path))
+}
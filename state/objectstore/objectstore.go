@@ -0,0 +1,245 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package objectstore provides the blobstore.ResourceStorage in which
+// the controller keeps large blobs: charm archives, resources, agent
+// binaries, OS images and backups. Depending on the controller config
+// these are stored in MongoDB's GridFS, or in an external object store
+// with a read-through cache on each controller machine.
+package objectstore
+
+import (
+	"io"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/series"
+	"gopkg.in/juju/blobstore.v2"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/juju/paths"
+)
+
+var logger = loggo.GetLogger("juju.state.objectstore")
+
+// configRefreshInterval is how long the controller config is used
+// before it is read again. Blob storage is opened for every blob
+// operation, so the config and the stores opened from it are cached
+// rather than read and opened each time.
+const configRefreshInterval = 30 * time.Second
+
+// CacheDir returns the directory in which objects read from an
+// external object store are cached. It is a variable so that tests
+// can replace it; the default is only computed when a cache is opened,
+// as the host series may not be known when the package is loaded.
+var CacheDir = func() string {
+	return filepath.Join(paths.MustSucceed(paths.DataDir(series.MustHostSeries())), "objectstore-cache")
+}
+
+// Stores opens the blob storage described by the controller config,
+// caching the config and the external object stores opened from it.
+// Each State has a Stores, which the States opened by a StatePool
+// share with the pool's controller State.
+type Stores struct {
+	controllerConfig func() (controller.Config, error)
+	clock            clock.Clock
+
+	mu     sync.Mutex
+	cfg    controller.Config
+	readAt time.Time
+	stores map[string]blobstore.ResourceStorage
+}
+
+// NewStores returns a Stores which reads the controller config by
+// calling controllerConfig, and reads it again once it is older than
+// configRefreshInterval according to the given clock.
+func NewStores(controllerConfig func() (controller.Config, error), clock clock.Clock) *Stores {
+	return &Stores{
+		controllerConfig: controllerConfig,
+		clock:            clock,
+	}
+}
+
+// ResourceStorage returns the blobstore.ResourceStorage in which
+// blobs for the named GridFS database should be stored, using the
+// given session to access GridFS.
+//
+// When an external object store is configured, blobs which are not
+// found there are read from GridFS, so that blobs stored before the
+// object store was configured remain available until they have been
+// migrated (see Migrate).
+func (s *Stores) ResourceStorage(db string, session *mgo.Session) blobstore.ResourceStorage {
+	gridFS := blobstore.NewGridFS(db, db, session)
+	cfg, err := s.config()
+	if err != nil {
+		return errorStorage{errors.Annotate(err, "cannot read object store config")}
+	}
+	if cfg.ObjectStoreType() == controller.ObjectStoreMongo {
+		return gridFS
+	}
+	store, err := s.open(cfg, db)
+	if err != nil {
+		return errorStorage{errors.Trace(err)}
+	}
+	return &fallbackStorage{store, gridFS}
+}
+
+// ExternalStorage returns the external object store described by the
+// controller config, with object names prefixed by the given
+// namespace. Unlike ResourceStorage, it does not fall back to GridFS;
+// it returns a NotFound error if no external object store is
+// configured.
+func (s *Stores) ExternalStorage(namespace string) (blobstore.ResourceStorage, error) {
+	cfg, err := s.config()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read object store config")
+	}
+	if cfg.ObjectStoreType() == controller.ObjectStoreMongo {
+		return nil, errors.NotFoundf("external object store")
+	}
+	store, err := s.open(cfg, namespace)
+	return store, errors.Trace(err)
+}
+
+// config returns the controller config, reading it again if the
+// cached copy is older than configRefreshInterval. If the object
+// store settings have changed, the stores opened from the old
+// settings are discarded.
+func (s *Stores) config() (controller.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	if s.cfg != nil && now.Sub(s.readAt) < configRefreshInterval {
+		return s.cfg, nil
+	}
+	cfg, err := s.controllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.cfg == nil || !sameObjectStore(cfg, s.cfg) {
+		s.stores = nil
+	}
+	s.cfg = cfg
+	s.readAt = now
+	return cfg, nil
+}
+
+// open returns the external object store for the given namespace,
+// opening it if it has not already been opened.
+func (s *Stores) open(cfg controller.Config, namespace string) (blobstore.ResourceStorage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if store, ok := s.stores[namespace]; ok {
+		return store, nil
+	}
+	store, err := Open(cfg, namespace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.stores == nil {
+		s.stores = make(map[string]blobstore.ResourceStorage)
+	}
+	s.stores[namespace] = store
+	return store, nil
+}
+
+// Open returns a blobstore.ResourceStorage for the external object
+// store described by the controller config. Object names are prefixed
+// with the given namespace.
+func Open(cfg controller.Config, namespace string) (blobstore.ResourceStorage, error) {
+	var store blobstore.ResourceStorage
+	switch storeType := cfg.ObjectStoreType(); storeType {
+	case controller.ObjectStoreS3:
+		s3Store, err := openS3(
+			cfg.ObjectStoreURL(),
+			cfg.ObjectStoreAccessKey(),
+			cfg.ObjectStoreSecretKey(),
+			namespace,
+		)
+		if err != nil {
+			return nil, errors.Annotate(err, "opening S3 object store")
+		}
+		store = s3Store
+	default:
+		return nil, errors.NotSupportedf("object store type %q", storeType)
+	}
+	if size := cfg.ObjectStoreCacheSize(); size > 0 {
+		store = NewCache(store, filepath.Join(CacheDir(), namespace), int64(size)<<20)
+	}
+	return store, nil
+}
+
+// sameObjectStore reports whether the two configs describe the
+// same external object store.
+func sameObjectStore(a, b controller.Config) bool {
+	return a.ObjectStoreType() == b.ObjectStoreType() &&
+		a.ObjectStoreURL() == b.ObjectStoreURL() &&
+		a.ObjectStoreAccessKey() == b.ObjectStoreAccessKey() &&
+		a.ObjectStoreSecretKey() == b.ObjectStoreSecretKey() &&
+		a.ObjectStoreCacheSize() == b.ObjectStoreCacheSize()
+}
+
+// fallbackStorage is a blobstore.ResourceStorage which stores
+// blobs in one store, and reads blobs that are not found there
+// from another.
+type fallbackStorage struct {
+	store    blobstore.ResourceStorage
+	fallback blobstore.ResourceStorage
+}
+
+// Get is part of the blobstore.ResourceStorage interface.
+func (s *fallbackStorage) Get(path string) (io.ReadCloser, error) {
+	r, err := s.store.Get(path)
+	if errors.IsNotFound(err) {
+		if r, err := s.fallback.Get(path); err == nil {
+			return r, nil
+		}
+	}
+	return r, err
+}
+
+// Put is part of the blobstore.ResourceStorage interface.
+func (s *fallbackStorage) Put(path string, r io.Reader, length int64) (string, error) {
+	return s.store.Put(path, r, length)
+}
+
+// Remove is part of the blobstore.ResourceStorage interface.
+func (s *fallbackStorage) Remove(path string) error {
+	err := s.store.Remove(path)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	// The blob may have been stored before the object store was
+	// configured, and not yet migrated.
+	fallbackErr := s.fallback.Remove(path)
+	if err == nil && errors.IsNotFound(fallbackErr) {
+		return nil
+	}
+	return errors.Trace(fallbackErr)
+}
+
+// errorStorage is a blobstore.ResourceStorage which
+// fails all operations with the same error.
+type errorStorage struct {
+	err error
+}
+
+// Get is part of the blobstore.ResourceStorage interface.
+func (s errorStorage) Get(path string) (io.ReadCloser, error) {
+	return nil, s.err
+}
+
+// Put is part of the blobstore.ResourceStorage interface.
+func (s errorStorage) Put(path string, r io.Reader, length int64) (string, error) {
+	return "", s.err
+}
+
+// Remove is part of the blobstore.ResourceStorage interface.
+func (s errorStorage) Remove(path string) error {
+	return s.err
+}
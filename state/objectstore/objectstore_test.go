@@ -0,0 +1,208 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/blobstore.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/objectstore"
+	"github.com/juju/juju/testing"
+)
+
+type objectStoreSuite struct {
+	gitjujutesting.MgoSuite
+	testing.BaseSuite
+
+	controllerConfig controller.Config
+	clock            *gitjujutesting.Clock
+	stores           *objectstore.Stores
+}
+
+var _ = gc.Suite(&objectStoreSuite{})
+
+func (s *objectStoreSuite) SetUpSuite(c *gc.C) {
+	s.BaseSuite.SetUpSuite(c)
+	s.MgoSuite.SetUpSuite(c)
+}
+
+func (s *objectStoreSuite) TearDownSuite(c *gc.C) {
+	s.MgoSuite.TearDownSuite(c)
+	s.BaseSuite.TearDownSuite(c)
+}
+
+func (s *objectStoreSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.MgoSuite.SetUpTest(c)
+	s.controllerConfig = controller.Config{}
+	s.clock = gitjujutesting.NewClock(time.Now())
+	s.stores = objectstore.NewStores(func() (controller.Config, error) {
+		return s.controllerConfig, nil
+	}, s.clock)
+}
+
+func (s *objectStoreSuite) TearDownTest(c *gc.C) {
+	s.MgoSuite.TearDownTest(c)
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *objectStoreSuite) setControllerConfig(c *gc.C, attrs map[string]interface{}) {
+	s.controllerConfig = controller.Config(attrs)
+}
+
+func (s *objectStoreSuite) putGridFS(c *gc.C, path, data string) {
+	gridFS := blobstore.NewGridFS("blobstore", "blobstore", s.Session)
+	_, err := gridFS.Put(path, strings.NewReader(data), int64(len(data)))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func assertGet(c *gc.C, store blobstore.ResourceStorage, path, expect string) {
+	r, err := store.Get(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, expect)
+}
+
+func (s *objectStoreSuite) TestResourceStorageDefaultsToGridFS(c *gc.C) {
+	s.putGridFS(c, "a", "aaaa")
+	store := s.stores.ResourceStorage("blobstore", s.Session)
+	assertGet(c, store, "a", "aaaa")
+}
+
+func (s *objectStoreSuite) TestResourceStorageMongo(c *gc.C) {
+	s.setControllerConfig(c, map[string]interface{}{
+		controller.ObjectStoreType: controller.ObjectStoreMongo,
+	})
+	s.putGridFS(c, "a", "aaaa")
+	store := s.stores.ResourceStorage("blobstore", s.Session)
+	assertGet(c, store, "a", "aaaa")
+}
+
+func (s *objectStoreSuite) TestResourceStorageUnsupported(c *gc.C) {
+	s.setControllerConfig(c, map[string]interface{}{
+		controller.ObjectStoreType: "tape",
+	})
+	store := s.stores.ResourceStorage("blobstore", s.Session)
+	_, err := store.Get("a")
+	c.Assert(err, gc.ErrorMatches, `object store type "tape" not supported`)
+	_, err = store.Put("a", strings.NewReader("aaaa"), 4)
+	c.Assert(err, gc.ErrorMatches, `object store type "tape" not supported`)
+}
+
+func (s *objectStoreSuite) TestExternalStorageMongo(c *gc.C) {
+	s.setControllerConfig(c, map[string]interface{}{
+		controller.ObjectStoreType: controller.ObjectStoreMongo,
+	})
+	_, err := s.stores.ExternalStorage("logs")
+	c.Assert(err, gc.ErrorMatches, "external object store not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *objectStoreSuite) TestExternalStorageUnsupported(c *gc.C) {
+	s.setControllerConfig(c, map[string]interface{}{
+		controller.ObjectStoreType: "tape",
+	})
+	_, err := s.stores.ExternalStorage("logs")
+	c.Assert(err, gc.ErrorMatches, `object store type "tape" not supported`)
+}
+
+func (s *objectStoreSuite) TestResourceStorageCachesConfig(c *gc.C) {
+	s.setControllerConfig(c, map[string]interface{}{
+		controller.ObjectStoreType: "tape",
+	})
+	s.stores.ResourceStorage("blobstore", s.Session)
+
+	// Changes to the config are not seen until it is read again.
+	s.setControllerConfig(c, map[string]interface{}{})
+	store := s.stores.ResourceStorage("blobstore", s.Session)
+	_, err := store.Get("a")
+	c.Assert(err, gc.ErrorMatches, `object store type "tape" not supported`)
+
+	s.clock.Advance(30 * time.Second)
+	s.putGridFS(c, "a", "aaaa")
+	store = s.stores.ResourceStorage("blobstore", s.Session)
+	assertGet(c, store, "a", "aaaa")
+}
+
+func (s *objectStoreSuite) TestFallbackStorage(c *gc.C) {
+	s.putGridFS(c, "a", "aaaa")
+	gridFS := blobstore.NewGridFS("blobstore", "blobstore", s.Session)
+	store := newMemStore()
+	fallback := objectstore.NewFallbackStorage(store, gridFS)
+
+	// Blobs are read from the fallback store until migrated.
+	assertGet(c, fallback, "a", "aaaa")
+
+	// New blobs are written only to the primary store.
+	_, err := fallback.Put("b", strings.NewReader("bbbb"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(store.blobs["b"]), gc.Equals, "bbbb")
+	_, err = gridFS.Get("b")
+	c.Assert(err, gc.NotNil)
+
+	// Blobs are removed from whichever store holds them.
+	err = fallback.Remove("a")
+	c.Assert(err, jc.ErrorIsNil)
+	err = fallback.Remove("b")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = fallback.Get("a")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = fallback.Get("b")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *objectStoreSuite) TestFallbackStorageRemoveError(c *gc.C) {
+	s.putGridFS(c, "a", "aaaa")
+	gridFS := blobstore.NewGridFS("blobstore", "blobstore", s.Session)
+	store := newMemStore()
+	store.SetErrors(errors.New("bucket on fire"))
+	fallback := objectstore.NewFallbackStorage(store, gridFS)
+
+	// The error from the primary store is not hidden by the blob being
+	// removed from the fallback store.
+	err := fallback.Remove("a")
+	c.Assert(err, gc.ErrorMatches, "bucket on fire")
+	assertGet(c, gridFS, "a", "aaaa")
+}
+
+func (s *objectStoreSuite) TestMigrate(c *gc.C) {
+	s.putGridFS(c, "a", "aaaa")
+	s.putGridFS(c, "b", "bbbb")
+	store := newMemStore()
+
+	migrated, err := objectstore.Migrate(s.Session, "blobstore", store)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(migrated, gc.Equals, 2)
+	c.Assert(store.blobs, jc.DeepEquals, map[string][]byte{
+		"a": []byte("aaaa"),
+		"b": []byte("bbbb"),
+	})
+
+	// The blobs have been removed from GridFS.
+	n, err := s.Session.DB("blobstore").GridFS("blobstore").Find(nil).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+}
+
+func (s *objectStoreSuite) TestMigrateFailureKeepsBlob(c *gc.C) {
+	s.putGridFS(c, "a", "aaaa")
+	store := newMemStore()
+	store.SetErrors(errors.New("bucket full"))
+
+	_, err := objectstore.Migrate(s.Session, "blobstore", store)
+	c.Assert(err, gc.ErrorMatches, `migrating blob "a" in "blobstore": bucket full`)
+
+	gridFS := blobstore.NewGridFS("blobstore", "blobstore", s.Session)
+	assertGet(c, gridFS, "a", "aaaa")
+}
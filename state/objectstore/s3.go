@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+	"gopkg.in/juju/blobstore.v2"
+)
+
+// s3Storage is a blobstore.ResourceStorage which
+// stores blobs in an S3 bucket.
+type s3Storage struct {
+	bucket *s3.Bucket
+	prefix string
+}
+
+// NewS3Storage returns a blobstore.ResourceStorage which stores
+// blobs in the given bucket, with names prefixed by prefix.
+func NewS3Storage(bucket *s3.Bucket, prefix string) blobstore.ResourceStorage {
+	return &s3Storage{bucket: bucket, prefix: prefix}
+}
+
+// openS3 returns a blobstore.ResourceStorage which stores blobs in
+// the S3 bucket at the given URL, which takes the form
+// s3://<region>/<bucket>[/<prefix>], under the given namespace.
+func openS3(rawURL, accessKey, secretKey, namespace string) (blobstore.ResourceStorage, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	region, ok := aws.Regions[u.Host]
+	if !ok {
+		return nil, errors.NotValidf("S3 region %q", u.Host)
+	}
+	parts := strings.SplitN(strings.Trim(u.Path, "/"), "/", 2)
+	if parts[0] == "" {
+		return nil, errors.NotValidf("S3 URL %q with no bucket", rawURL)
+	}
+	prefix := namespace + "/"
+	if len(parts) == 2 && parts[1] != "" {
+		prefix = strings.TrimSuffix(parts[1], "/") + "/" + prefix
+	}
+	auth := aws.Auth{AccessKey: accessKey, SecretKey: secretKey}
+	bucket, err := s3.New(auth, region).Bucket(parts[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewS3Storage(bucket, prefix), nil
+}
+
+// Get is part of the blobstore.ResourceStorage interface.
+func (s *s3Storage) Get(path string) (io.ReadCloser, error) {
+	r, err := s.bucket.GetReader(s.prefix + path)
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("object %q", path)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get object %q", path)
+	}
+	return r, nil
+}
+
+// Put is part of the blobstore.ResourceStorage interface. The
+// returned checksum is the hex-encoded MD5 hash of the data, as
+// for blobs stored in GridFS.
+func (s *s3Storage) Put(path string, r io.Reader, length int64) (string, error) {
+	hash := md5.New()
+	r = io.TeeReader(r, hash)
+	if err := s.bucket.PutReader(s.prefix+path, r, length, "application/octet-stream", s3.Private); err != nil {
+		return "", errors.Annotatef(err, "cannot put object %q", path)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Remove is part of the blobstore.ResourceStorage interface.
+func (s *s3Storage) Remove(path string) error {
+	if err := s.bucket.Del(s.prefix + path); isS3NotFound(err) {
+		return errors.NotFoundf("object %q", path)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove object %q", path)
+	}
+	return nil
+}
+
+func isS3NotFound(err error) bool {
+	if err, ok := err.(*s3.Error); ok {
+		return err.StatusCode == http.StatusNotFound
+	}
+	return false
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state/objectstore"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -345,6 +346,8 @@ func Initialize(args InitializeParams) (_ *State, err error) {
 		ops = append(ops, createSettingsOp(globalSettingsC, regionSettingsGlobalKey(args.CloudName, k), v))
 	}
 
+	// The controller model is the cloud's first model.
+	ops = append(ops, createCloudRefCountOps(args.CloudName, 1, len(args.CloudCredentials))...)
 	for tag, cred := range args.CloudCredentials {
 		ops = append(ops, createCloudCredentialOp(tag, cred))
 	}
@@ -377,6 +380,14 @@ func (st *State) modelSetupOps(controllerUUID string, args ModelArgs, inherited
 	if err := checkModelConfig(args.Config); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if args.MigrationMode != MigrationModeImporting {
+		// Imported models keep whatever config they had. The model
+		// doc does not exist yet, so the provider's attributes are
+		// found by the config's type rather than through st.
+		if err := config.ValidateNewAttrs(args.Config, nil); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	controllerModelUUID := st.controllerModelTag.Id()
 	modelUUID := args.Config.UUID()
@@ -556,6 +567,7 @@ func newState(
 		newPolicy:              newPolicy,
 		runTransactionObserver: runTransactionObserver,
 	}
+	st.objectStores = objectstore.NewStores(st.ControllerConfig, clock)
 	if newPolicy != nil {
 		st.policy = newPolicy(st)
 	}
@@ -585,6 +597,9 @@ func (st *State) Close() (err error) {
 	if st.workers != nil {
 		handle("standard workers", worker.Stop(st.workers))
 	}
+	if st.pingBatcher != nil {
+		handle("ping batcher", st.pingBatcher.Stop())
+	}
 
 	st.mu.Lock()
 	if st.allManager != nil {
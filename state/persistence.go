@@ -90,7 +90,7 @@ func (sp *statePersistence) NewStorage() storage.Storage {
 	modelUUID := sp.st.ModelUUID()
 	// TODO(ericsnow) Copy the session?
 	session := sp.st.session
-	store := storage.NewStorage(modelUUID, session)
+	store := storage.NewStorage(modelUUID, session, sp.st.objectStores)
 	return store
 }
 
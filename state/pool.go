@@ -59,7 +59,9 @@ func (p *StatePool) Get(modelUUID string) (*State, error) {
 		return item.state, nil
 	}
 
-	st, err := p.systemState.ForModel(names.NewModelTag(modelUUID))
+	// States in the pool share the system state's txn log watcher,
+	// so that watching any number of models costs a single poll.
+	st, err := p.systemState.forModel(names.NewModelTag(modelUUID), p.systemState)
 	if err != nil {
 		return nil, errors.Annotatef(err, "failed to create state for model %v", modelUUID)
 	}
@@ -120,6 +122,35 @@ func (p *StatePool) maybeRemoveItem(modelUUID string, item *PoolItem) error {
 	return nil
 }
 
+// StatePoolStats describes the States cached by a StatePool.
+type StatePoolStats struct {
+	// Models holds the number of models with a cached State.
+	Models int
+
+	// References holds the number of Gets without a
+	// corresponding Release, across all models.
+	References int
+
+	// PendingRemoval holds the number of States that have been
+	// removed from the pool but are still in use.
+	PendingRemoval int
+}
+
+// Stats returns statistics about the States cached by the pool. The
+// controller's State is not included.
+func (p *StatePool) Stats() StatePoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := StatePoolStats{Models: len(p.pool)}
+	for _, item := range p.pool {
+		stats.References += int(item.references)
+		if item.remove {
+			stats.PendingRemoval++
+		}
+	}
+	return stats
+}
+
 // SystemState returns the State passed in to NewStatePool.
 func (p *StatePool) SystemState() *State {
 	return p.systemState
@@ -59,6 +59,27 @@ func (s *statePoolSuite) TestGet(c *gc.C) {
 	c.Assert(st2_, gc.Equals, st2)
 }
 
+func (s *statePoolSuite) TestStats(c *gc.C) {
+	c.Assert(s.Pool.Stats(), gc.Equals, state.StatePoolStats{})
+
+	_, err := s.Pool.Get(s.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Pool.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Pool.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Pool.Get(s.ModelUUID2)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Pool.Remove(s.ModelUUID2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.Pool.Stats(), gc.Equals, state.StatePoolStats{
+		Models:         2,
+		References:     3,
+		PendingRemoval: 1,
+	})
+}
+
 func (s *statePoolSuite) TestGetWithControllerModel(c *gc.C) {
 	// When a State for the controller model is requested, the same
 	// State that was original passed in should be returned.
@@ -210,3 +231,41 @@ func (s *statePoolSuite) TestGetRemovedNotAllowed(c *gc.C) {
 	_, err = s.Pool.Get(s.ModelUUID1)
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("model %v has been removed", s.ModelUUID1))
 }
+
+func (s *statePoolSuite) TestPooledStatesShareTxnLogWatcher(c *gc.C) {
+	st1, err := s.Pool.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	st2, err := s.Pool.Get(s.ModelUUID2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w1 := st1.WatchModelMachines()
+	defer statetesting.AssertStop(c, w1)
+	wc1 := statetesting.NewStringsWatcherC(c, st1, w1)
+	wc1.AssertChange()
+	w2 := st2.WatchModelMachines()
+	wc2 := statetesting.NewStringsWatcherC(c, st2, w2)
+	wc2.AssertChange()
+
+	// Each model only sees its own changes, even though the
+	// underlying log is polled once.
+	m, err := st1.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wc1.AssertChange(m.Id())
+	wc2.AssertNoChange()
+	statetesting.AssertStop(c, w2)
+
+	// Releasing and closing a pooled state leaves the shared
+	// watcher working for everyone else.
+	c.Assert(s.Pool.Release(s.ModelUUID2), jc.ErrorIsNil)
+	c.Assert(s.Pool.Remove(s.ModelUUID2), jc.ErrorIsNil)
+	assertClosed(c, st2)
+
+	w := s.State.WatchModelMachines()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	m, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(m.Id())
+	wc1.AssertNoChange()
+}
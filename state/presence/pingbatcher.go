@@ -0,0 +1,188 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package presence
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/tomb.v1"
+)
+
+// DefaultPingBatcherInterval is how often a PingBatcher started with
+// NewPingBatcher writes the pings it has collected.
+const DefaultPingBatcherInterval = time.Second
+
+// PingRecorder records that a pinger was alive in a time slot.
+type PingRecorder interface {
+	Ping(modelUUID string, slot int64, fieldKey string, fieldBit uint64) error
+}
+
+// directRecorder writes each ping to the database as it is made.
+type directRecorder struct {
+	pings *mgo.Collection
+}
+
+// Ping is part of the PingRecorder interface.
+func (r directRecorder) Ping(modelUUID string, slot int64, fieldKey string, fieldBit uint64) error {
+	session := r.pings.Database.Session.Copy()
+	defer session.Close()
+	pings := r.pings.With(session)
+	_, err := pings.UpsertId(
+		docIDInt64(modelUUID, slot),
+		bson.D{
+			{"$set", bson.D{{"slot", slot}}},
+			{"$bit", bson.D{{"alive." + fieldKey, bson.D{{"or", fieldBit}}}}},
+		})
+	return errors.Trace(err)
+}
+
+// slotKey identifies a time slot document.
+type slotKey struct {
+	modelUUID string
+	slot      int64
+}
+
+type pingRequest struct {
+	slotKey
+	fieldKey string
+	fieldBit uint64
+}
+
+// PingBatcher collects the pings of many pingers and writes them
+// together, with one update per time slot document, rather than one
+// update per ping. With tens of thousands of agents this saves most
+// of the database writes presence would otherwise make.
+//
+// The bits of pings in the same field are ORed together, so a
+// batch records exactly what the individual pings would have.
+type PingBatcher struct {
+	tomb     tomb.Tomb
+	pings    *mgo.Collection
+	interval time.Duration
+	requests chan pingRequest
+	syncs    chan chan error
+}
+
+// NewPingBatcher returns a running PingBatcher that writes the pings
+// it collects to the pings collection of the given base presence
+// collection every interval.
+func NewPingBatcher(base *mgo.Collection, interval time.Duration) *PingBatcher {
+	b := &PingBatcher{
+		pings:    pingsC(base),
+		interval: interval,
+		requests: make(chan pingRequest),
+		syncs:    make(chan chan error),
+	}
+	go func() {
+		defer b.tomb.Done()
+		b.tomb.Kill(b.loop())
+	}()
+	return b
+}
+
+// Kill is part of the worker.Worker interface.
+func (b *PingBatcher) Kill() {
+	b.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (b *PingBatcher) Wait() error {
+	return b.tomb.Wait()
+}
+
+// Stop stops the batcher, after writing any pings it holds.
+func (b *PingBatcher) Stop() error {
+	b.tomb.Kill(nil)
+	return b.tomb.Wait()
+}
+
+// Ping is part of the PingRecorder interface. The ping is written
+// with the next batch; Ping does not wait for that.
+func (b *PingBatcher) Ping(modelUUID string, slot int64, fieldKey string, fieldBit uint64) error {
+	select {
+	case b.requests <- pingRequest{slotKey{modelUUID, slot}, fieldKey, fieldBit}:
+		return nil
+	case <-b.tomb.Dying():
+		return errors.New("ping batcher stopped")
+	}
+}
+
+// Sync writes any pings the batcher holds, and returns once they
+// have been written.
+func (b *PingBatcher) Sync() error {
+	done := make(chan error, 1)
+	select {
+	case b.syncs <- done:
+	case <-b.tomb.Dying():
+		return errors.New("ping batcher stopped")
+	}
+	select {
+	case err := <-done:
+		return errors.Trace(err)
+	case <-b.tomb.Dying():
+		return errors.New("ping batcher stopped")
+	}
+}
+
+func (b *PingBatcher) loop() error {
+	pending := make(map[slotKey]map[string]uint64)
+	next := time.After(b.interval)
+	for {
+		select {
+		case <-b.tomb.Dying():
+			return errors.Trace(b.flush(pending))
+		case req := <-b.requests:
+			fields := pending[req.slotKey]
+			if fields == nil {
+				fields = make(map[string]uint64)
+				pending[req.slotKey] = fields
+			}
+			fields[req.fieldKey] |= req.fieldBit
+		case done := <-b.syncs:
+			err := b.flush(pending)
+			done <- err
+			if err != nil {
+				return errors.Trace(err)
+			}
+		case <-next:
+			if err := b.flush(pending); err != nil {
+				return errors.Trace(err)
+			}
+			next = time.After(b.interval)
+		}
+	}
+}
+
+// flush writes the pending pings, one upsert per slot document, in a
+// single bulk operation, and empties pending.
+func (b *PingBatcher) flush(pending map[slotKey]map[string]uint64) error {
+	if len(pending) == 0 {
+		return nil
+	}
+	session := b.pings.Database.Session.Copy()
+	defer session.Close()
+	bulk := b.pings.With(session).Bulk()
+	bulk.Unordered()
+	for key, fields := range pending {
+		bits := make(bson.D, 0, len(fields))
+		for fieldKey, fieldBit := range fields {
+			bits = append(bits, bson.DocElem{"alive." + fieldKey, bson.D{{"or", fieldBit}}})
+		}
+		bulk.Upsert(
+			bson.D{{"_id", docIDInt64(key.modelUUID, key.slot)}},
+			bson.D{
+				{"$set", bson.D{{"slot", key.slot}}},
+				{"$bit", bits},
+			},
+		)
+		delete(pending, key)
+	}
+	if _, err := bulk.Run(); err != nil {
+		return errors.Annotate(err, "writing pings")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package presence_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/presence"
+)
+
+func (s *PresenceSuite) TestPingBatcher(c *gc.C) {
+	batcher := presence.NewPingBatcher(s.presence, time.Hour)
+	defer assertStopped(c, batcher)
+
+	pa := presence.NewPingerWithRecorder(s.presence, s.modelTag, "a", batcher)
+	pb := presence.NewPingerWithRecorder(s.presence, s.modelTag, "b", batcher)
+	defer assertStopped(c, pa)
+	defer assertStopped(c, pb)
+	c.Assert(pa.Start(), jc.ErrorIsNil)
+	c.Assert(pb.Start(), jc.ErrorIsNil)
+
+	// Nothing is written until the batch is.
+	n, err := s.pings.Find(nil).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+
+	c.Assert(batcher.Sync(), jc.ErrorIsNil)
+	var docs []bson.M
+	err = s.pings.Find(nil).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 1)
+
+	w := presence.NewWatcher(s.presence, s.modelTag)
+	defer assertStopped(c, w)
+	w.Sync()
+	alive, err := w.Alive("a")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alive, jc.IsTrue)
+	alive, err = w.Alive("b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alive, jc.IsTrue)
+}
+
+func (s *PresenceSuite) TestPingBatcherStopFlushes(c *gc.C) {
+	batcher := presence.NewPingBatcher(s.presence, time.Hour)
+	p := presence.NewPingerWithRecorder(s.presence, s.modelTag, "a", batcher)
+	c.Assert(p.Start(), jc.ErrorIsNil)
+	assertStopped(c, p)
+	assertStopped(c, batcher)
+
+	n, err := s.pings.Find(nil).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 1)
+}
@@ -67,7 +67,9 @@ func docIDStr(modelUUID string, localID string) string {
 // }
 //
 // All pingers that have their sequence number under "alive" and not
-// under "dead" are currently alive. This design enables implementing
+// under "dead" are currently alive. Pings set their bit with a bitwise
+// OR, so pings from many pingers may be batched into a single update
+// by a PingBatcher. This design enables implementing
 // a ping with a single update operation, a kill with another operation,
 // and obtaining liveness data with a single query that returns two
 // documents (the last two time slots).
@@ -78,7 +80,8 @@ func docIDStr(modelUUID string, localID string) string {
 // into the beings collection to establish the mapping between pinger sequence
 // and key.
 
-// BUG(gn): The pings and beings collection currently grow without bound.
+// Old time slot documents, and beings documents for pingers that have
+// stopped, are removed model by model by a Pruner.
 
 // A Watcher can watch any number of pinger keys for liveness changes.
 type Watcher struct {
@@ -523,6 +526,7 @@ type Pinger struct {
 	tomb      tomb.Tomb
 	base      *mgo.Collection
 	pings     *mgo.Collection
+	recorder  PingRecorder
 	started   bool
 	beingKey  string
 	beingSeq  int64
@@ -535,9 +539,18 @@ type Pinger struct {
 // NewPinger returns a new Pinger to report that key is alive.
 // It starts reporting after Start is called.
 func NewPinger(base *mgo.Collection, modelTag names.ModelTag, key string) *Pinger {
+	return NewPingerWithRecorder(base, modelTag, key, directRecorder{pingsC(base)})
+}
+
+// NewPingerWithRecorder returns a new Pinger to report that key is
+// alive, which records its pings with the given recorder, such as a
+// PingBatcher, rather than writing each one to the database itself.
+// It starts reporting after Start is called.
+func NewPingerWithRecorder(base *mgo.Collection, modelTag names.ModelTag, key string, recorder PingRecorder) *Pinger {
 	return &Pinger{
 		base:      base,
 		pings:     pingsC(base),
+		recorder:  recorder,
 		beingKey:  key,
 		modelUUID: modelTag.Id(),
 	}
@@ -729,19 +742,11 @@ func (p *Pinger) ping() (err error) {
 	// TODO(perrito666) 2016-05-02 lp:1558657
 	slot := timeSlot(time.Now(), p.delta)
 	if slot == p.lastSlot {
-		// Never, ever, ping the same slot twice.
-		// The increment below would corrupt the slot.
+		// There's no need to ping the same slot twice.
 		return nil
 	}
 	p.lastSlot = slot
-	pings := p.pings.With(session)
-	_, err = pings.UpsertId(
-		docIDInt64(p.modelUUID, slot),
-		bson.D{
-			{"$set", bson.D{{"slot", slot}}},
-			{"$inc", bson.D{{"alive." + p.fieldKey, p.fieldBit}}},
-		})
-	return errors.Trace(err)
+	return errors.Trace(p.recorder.Ping(p.modelUUID, slot, p.fieldKey, p.fieldBit))
 }
 
 // clockDelta returns the approximate skew between
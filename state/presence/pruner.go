@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package presence
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// keepSlots is the number of most recent time slots whose documents
+// are kept by a Pruner. Watchers read the current and the previous
+// slot; one more is kept so that clock skew between controllers
+// cannot remove a slot that is still being read.
+const keepSlots = 3
+
+// Pruner removes old time slot documents and the beings of stopped
+// pingers for one model. Each model is pruned separately, so that
+// pruning a controller with many models is done in small pieces,
+// and one busy model does not hold up the others.
+type Pruner struct {
+	modelUUID string
+	base      *mgo.Collection
+
+	// lastSeq is the highest being sequence allocated when Prune
+	// was last called. Only beings up to that sequence are removed,
+	// so that a pinger that has been allocated a sequence but not
+	// yet pinged cannot lose its being.
+	lastSeq int64
+}
+
+// NewPruner returns a Pruner for the model with the given UUID, using
+// the given base presence collection.
+func NewPruner(base *mgo.Collection, modelUUID string) *Pruner {
+	return &Pruner{
+		modelUUID: modelUUID,
+		base:      base,
+		lastSeq:   -1,
+	}
+}
+
+// Prune removes the model's time slot documents that are too old to
+// be read by watchers, and the beings of pingers not alive in any of
+// the remaining slots. Beings are only removed from the second call
+// on; see lastSeq.
+func (p *Pruner) Prune() error {
+	session := p.base.Database.Session.Copy()
+	defer session.Close()
+	base := p.base.With(session)
+
+	var seq struct{ Seq int64 }
+	err := seqsC(base).FindId(docIDStr(p.modelUUID, "beings")).One(&seq)
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotate(err, "reading being sequence")
+	}
+
+	pings := pingsC(base)
+	idPrefix := bson.RegEx{Pattern: "^" + regexp.QuoteMeta(p.modelUUID+":")}
+	// TODO(perrito666) 2016-05-02 lp:1558657
+	cutoff := timeSlot(time.Now(), 0) - (keepSlots-1)*period
+	info, err := pings.RemoveAll(bson.D{
+		{"_id", idPrefix},
+		{"slot", bson.D{{"$lt", cutoff}}},
+	})
+	if err != nil {
+		return errors.Annotate(err, "removing old pings")
+	}
+	logger.Debugf("[%s] removed %d old ping documents", p.modelUUID[:6], info.Removed)
+
+	lastSeq := p.lastSeq
+	p.lastSeq = seq.Seq
+	if lastSeq < 0 {
+		return nil
+	}
+	var recent []pingInfo
+	if err := pings.Find(bson.D{{"_id", idPrefix}}).All(&recent); err != nil {
+		return errors.Annotate(err, "reading recent pings")
+	}
+	alive, err := aliveSeqs(recent)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err = beingsC(base).RemoveAll(bson.D{
+		{"model-uuid", p.modelUUID},
+		{"seq", bson.D{{"$lte", lastSeq}, {"$nin", alive}}},
+	})
+	if err != nil {
+		return errors.Annotate(err, "removing stopped beings")
+	}
+	logger.Debugf("[%s] removed %d stopped beings", p.modelUUID[:6], info.Removed)
+	return nil
+}
+
+// aliveSeqs returns the sequences of the pingers recorded as alive in
+// the given time slot documents.
+func aliveSeqs(pings []pingInfo) ([]int64, error) {
+	seen := make(map[int64]bool)
+	seqs := []int64{}
+	for _, ping := range pings {
+		for key, value := range ping.Alive {
+			k, err := strconv.ParseInt(key, 16, 64)
+			if err != nil {
+				return nil, errors.Annotatef(err, "presence cannot parse alive key: %q", key)
+			}
+			k *= 63
+			for i := int64(0); i < 63 && value > 0; i++ {
+				on := value&1 == 1
+				value >>= 1
+				if on && !seen[k+i] {
+					seen[k+i] = true
+					seqs = append(seqs, k+i)
+				}
+			}
+		}
+	}
+	return seqs, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package presence_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/presence"
+)
+
+func (s *PresenceSuite) beingKeys(c *gc.C, modelUUID string) []string {
+	var docs []struct {
+		Key string `bson:"key"`
+	}
+	beings := s.presence.Database.C("presence.beings")
+	err := beings.Find(bson.D{{"model-uuid", modelUUID}}).Sort("seq").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	keys := make([]string, len(docs))
+	for i, doc := range docs {
+		keys[i] = doc.Key
+	}
+	return keys
+}
+
+func (s *PresenceSuite) TestPruner(c *gc.C) {
+	pa := presence.NewPinger(s.presence, s.modelTag, "a")
+	c.Assert(pa.Start(), jc.ErrorIsNil)
+	assertStopped(c, pa)
+
+	presence.FakeTimeSlot(5)
+	pb := presence.NewPinger(s.presence, s.modelTag, "b")
+	c.Assert(pb.Start(), jc.ErrorIsNil)
+	defer assertStopped(c, pb)
+
+	pruner := presence.NewPruner(s.presence, s.modelTag.Id())
+	c.Assert(pruner.Prune(), jc.ErrorIsNil)
+	n, err := s.pings.Find(nil).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 1)
+	// Beings are only pruned from the second run.
+	c.Assert(s.beingKeys(c, s.modelTag.Id()), jc.DeepEquals, []string{"a", "b"})
+
+	c.Assert(pruner.Prune(), jc.ErrorIsNil)
+	c.Assert(s.beingKeys(c, s.modelTag.Id()), jc.DeepEquals, []string{"b"})
+
+	w := presence.NewWatcher(s.presence, s.modelTag)
+	defer assertStopped(c, w)
+	w.Sync()
+	alive, err := w.Alive("b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alive, jc.IsTrue)
+}
+
+func (s *PresenceSuite) TestPrunerOtherModels(c *gc.C) {
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	otherTag := names.NewModelTag(uuid.String())
+	p := presence.NewPinger(s.presence, otherTag, "a")
+	c.Assert(p.Start(), jc.ErrorIsNil)
+	assertStopped(c, p)
+
+	presence.FakeTimeSlot(5)
+	pruner := presence.NewPruner(s.presence, s.modelTag.Id())
+	c.Assert(pruner.Prune(), jc.ErrorIsNil)
+	c.Assert(pruner.Prune(), jc.ErrorIsNil)
+
+	n, err := s.pings.Find(nil).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 1)
+	c.Assert(s.beingKeys(c, otherTag.Id()), jc.DeepEquals, []string{"a"})
+}
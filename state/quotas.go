@@ -0,0 +1,282 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// Quota holds limits on the machines, units and storage instances
+// that may exist. A zero limit means no limit.
+type Quota struct {
+	MaxMachines int
+	MaxUnits    int
+	MaxStorage  int
+}
+
+// QuotaUsage holds counts of the machines, units and storage
+// instances limited by a Quota.
+type QuotaUsage struct {
+	Machines int
+	Units    int
+	Storage  int
+}
+
+// QuotaExceededError is returned by CheckQuotas when an operation
+// would take a model, or its owner, past a quota.
+type QuotaExceededError struct {
+	// Quota is the name of the exceeded quota, which is the
+	// same as the model config key for the model quota.
+	Quota string
+
+	// Limit is the quota's limit.
+	Limit int
+
+	// User is the owner whose quota would be exceeded; it is
+	// empty if the model quota would be exceeded.
+	User string
+}
+
+// Error is part of the error interface.
+func (e *QuotaExceededError) Error() string {
+	if e.User != "" {
+		return fmt.Sprintf("quota %q for user %q exceeded (limit %d)", e.Quota, e.User, e.Limit)
+	}
+	return fmt.Sprintf("quota %q exceeded (limit %d)", e.Quota, e.Limit)
+}
+
+// IsQuotaExceededError reports whether or not the error is a
+// QuotaExceededError.
+func IsQuotaExceededError(err error) bool {
+	_, ok := errors.Cause(err).(*QuotaExceededError)
+	return ok
+}
+
+// check returns a QuotaExceededError if adding to the usage would
+// exceed the quota.
+func (q Quota) check(usage, add QuotaUsage, user string) error {
+	for _, c := range []struct {
+		quota        string
+		limit        int
+		usage, added int
+	}{
+		{config.MaxMachinesKey, q.MaxMachines, usage.Machines, add.Machines},
+		{config.MaxUnitsKey, q.MaxUnits, usage.Units, add.Units},
+		{config.MaxStorageKey, q.MaxStorage, usage.Storage, add.Storage},
+	} {
+		if c.limit > 0 && c.added > 0 && c.usage+c.added > c.limit {
+			return &QuotaExceededError{
+				Quota: c.quota,
+				Limit: c.limit,
+				User:  user,
+			}
+		}
+	}
+	return nil
+}
+
+// userQuotaDoc is the mongodb representation of a user's Quota.
+type userQuotaDoc struct {
+	DocID       string `bson:"_id"`
+	MaxMachines int    `bson:"max-machines,omitempty"`
+	MaxUnits    int    `bson:"max-units,omitempty"`
+	MaxStorage  int    `bson:"max-storage,omitempty"`
+}
+
+// UserQuota returns the quota on the total machines, units and
+// storage instances in the models owned by the given user.
+func (st *State) UserQuota(user names.UserTag) (Quota, error) {
+	coll, closer := st.getCollection(userQuotasC)
+	defer closer()
+
+	var doc userQuotaDoc
+	err := coll.FindId(user.Id()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return Quota{}, nil
+	} else if err != nil {
+		return Quota{}, errors.Annotatef(err, "cannot get quota for user %q", user.Id())
+	}
+	return Quota{
+		MaxMachines: doc.MaxMachines,
+		MaxUnits:    doc.MaxUnits,
+		MaxStorage:  doc.MaxStorage,
+	}, nil
+}
+
+// SetUserQuota sets the quota on the total machines, units and
+// storage instances in the models owned by the given user. Existing
+// machines, units and storage are not removed if they exceed the
+// new quota; only further additions are refused.
+func (st *State) SetUserQuota(user names.UserTag, quota Quota) error {
+	if quota.MaxMachines < 0 || quota.MaxUnits < 0 || quota.MaxStorage < 0 {
+		return errors.NotValidf("negative quota %+v", quota)
+	}
+	coll, closer := st.getCollection(userQuotasC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		n, err := coll.FindId(user.Id()).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		exists := n > 0
+		switch {
+		case quota == (Quota{}) && !exists:
+			return nil, jujutxn.ErrNoOperations
+		case quota == (Quota{}):
+			return []txn.Op{{
+				C:      userQuotasC,
+				Id:     user.Id(),
+				Assert: txn.DocExists,
+				Remove: true,
+			}}, nil
+		case !exists:
+			return []txn.Op{{
+				C:      userQuotasC,
+				Id:     user.Id(),
+				Assert: txn.DocMissing,
+				Insert: userQuotaDoc{
+					DocID:       user.Id(),
+					MaxMachines: quota.MaxMachines,
+					MaxUnits:    quota.MaxUnits,
+					MaxStorage:  quota.MaxStorage,
+				},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      userQuotasC,
+			Id:     user.Id(),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"max-machines", quota.MaxMachines},
+				{"max-units", quota.MaxUnits},
+				{"max-storage", quota.MaxStorage},
+			}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set quota for user %q", user.Id())
+	}
+	return nil
+}
+
+// ModelQuotaUsage returns the number of machines, units and storage
+// instances in the model that are not dead.
+func (st *State) ModelQuotaUsage() (QuotaUsage, error) {
+	return st.quotaUsage([]string{st.ModelUUID()})
+}
+
+// UserQuotaUsage returns the number of machines, units and storage
+// instances that are not dead, in all the live or dying models owned
+// by the given user.
+func (st *State) UserQuotaUsage(user names.UserTag) (QuotaUsage, error) {
+	models, closer := st.getRawCollection(modelsC)
+	defer closer()
+
+	var docs []struct {
+		UUID string `bson:"_id"`
+	}
+	err := models.Find(bson.D{
+		{"owner", user.Id()},
+		{"life", bson.D{{"$ne", Dead}}},
+	}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return QuotaUsage{}, errors.Annotatef(err, "cannot get models owned by %q", user.Id())
+	}
+	uuids := make([]string, len(docs))
+	for i, doc := range docs {
+		uuids[i] = doc.UUID
+	}
+	return st.quotaUsage(uuids)
+}
+
+// quotaUsage counts the machines, units and storage instances that
+// are not dead in the models with the given UUIDs.
+func (st *State) quotaUsage(modelUUIDs []string) (QuotaUsage, error) {
+	var usage QuotaUsage
+	if len(modelUUIDs) == 0 {
+		return usage, nil
+	}
+	query := bson.D{
+		{"model-uuid", bson.D{{"$in", modelUUIDs}}},
+		{"life", bson.D{{"$ne", Dead}}},
+	}
+	for _, c := range []struct {
+		collection string
+		count      *int
+	}{
+		{machinesC, &usage.Machines},
+		{unitsC, &usage.Units},
+		{storageInstancesC, &usage.Storage},
+	} {
+		coll, closer := st.getRawCollection(c.collection)
+		n, err := coll.Find(query).Count()
+		closer()
+		if err != nil {
+			return QuotaUsage{}, errors.Annotatef(err, "cannot count %s", c.collection)
+		}
+		*c.count = n
+	}
+	return usage, nil
+}
+
+// CheckQuotas returns a QuotaExceededError if adding the given
+// numbers of machines, units and storage instances to the model
+// would exceed the model's quotas, set in model config, or the
+// quota of the model's owner.
+//
+// The check is not made in the same transaction as the additions,
+// so concurrent additions may together exceed a quota by a little.
+func (st *State) CheckQuotas(add QuotaUsage) error {
+	if add == (QuotaUsage{}) {
+		return nil
+	}
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelQuota := Quota{
+		MaxMachines: cfg.MaxMachines(),
+		MaxUnits:    cfg.MaxUnits(),
+		MaxStorage:  cfg.MaxStorage(),
+	}
+	if modelQuota != (Quota{}) {
+		usage, err := st.ModelQuotaUsage()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := modelQuota.check(usage, add, ""); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner := model.Owner()
+	userQuota, err := st.UserQuota(owner)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if userQuota != (Quota{}) {
+		usage, err := st.UserQuotaUsage(owner)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := userQuota.check(usage, add, owner.Id()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
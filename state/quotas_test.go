@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type QuotaSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&QuotaSuite{})
+
+func (s *QuotaSuite) TestUserQuotaDefault(c *gc.C) {
+	quota, err := s.State.UserQuota(names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.Quota{})
+}
+
+func (s *QuotaSuite) TestSetUserQuota(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	err := s.State.SetUserQuota(bob, state.Quota{MaxMachines: 10, MaxUnits: 20})
+	c.Assert(err, jc.ErrorIsNil)
+	quota, err := s.State.UserQuota(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.Quota{MaxMachines: 10, MaxUnits: 20})
+
+	err = s.State.SetUserQuota(bob, state.Quota{MaxStorage: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	quota, err = s.State.UserQuota(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.Quota{MaxStorage: 5})
+
+	err = s.State.SetUserQuota(bob, state.Quota{})
+	c.Assert(err, jc.ErrorIsNil)
+	quota, err = s.State.UserQuota(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.Quota{})
+}
+
+func (s *QuotaSuite) TestSetUserQuotaNegative(c *gc.C) {
+	err := s.State.SetUserQuota(names.NewUserTag("bob"), state.Quota{MaxUnits: -1})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *QuotaSuite) TestModelQuotaUsage(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, nil)
+	usage, err := s.State.ModelQuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.Equals, state.QuotaUsage{Machines: 2, Units: 1})
+}
+
+func (s *QuotaSuite) TestUserQuotaUsage(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Owner: s.Owner})
+	defer st.Close()
+	factory.NewFactory(st).MakeMachine(c, nil)
+
+	usage, err := s.State.UserQuotaUsage(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.Equals, state.QuotaUsage{Machines: 2})
+
+	usage, err = s.State.UserQuotaUsage(names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.Equals, state.QuotaUsage{})
+}
+
+func (s *QuotaSuite) TestCheckQuotasModel(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"max-machines": 2}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeMachine(c, nil)
+
+	err = s.State.CheckQuotas(state.QuotaUsage{Machines: 1, Units: 100})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckQuotas(state.QuotaUsage{Machines: 2})
+	c.Assert(err, gc.ErrorMatches, `quota "max-machines" exceeded \(limit 2\)`)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+}
+
+func (s *QuotaSuite) TestCheckQuotasUser(c *gc.C) {
+	err := s.State.SetUserQuota(s.Owner, state.Quota{MaxUnits: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Owner: s.Owner})
+	defer st.Close()
+	factory.NewFactory(st).MakeUnit(c, nil)
+
+	err = s.State.CheckQuotas(state.QuotaUsage{Machines: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckQuotas(state.QuotaUsage{Units: 1})
+	c.Assert(err, gc.ErrorMatches, `quota "max-units" for user "test-admin" exceeded \(limit 1\)`)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+}
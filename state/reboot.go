@@ -14,6 +14,7 @@ import (
 )
 
 var _ RebootFlagSetter = (*Machine)(nil)
+var _ RebootScheduler = (*Machine)(nil)
 var _ RebootActionGetter = (*Machine)(nil)
 
 // RebootAction defines the action a machine should
@@ -39,9 +40,13 @@ type rebootDoc struct {
 	DocID     string `bson:"_id"`
 	Id        string `bson:"machineid"`
 	ModelUUID string `bson:"model-uuid"`
+
+	// Scheduled is true if the reboot should only happen during
+	// the model's maintenance window.
+	Scheduled bool `bson:"scheduled,omitempty"`
 }
 
-func (m *Machine) setFlag() error {
+func (m *Machine) setFlag(scheduled bool) error {
 	if m.Life() == Dead {
 		return mgo.ErrNotFound
 	}
@@ -54,9 +59,19 @@ func (m *Machine) setFlag() error {
 		}, {
 			C:      rebootC,
 			Id:     m.doc.DocID,
-			Insert: &rebootDoc{Id: m.Id()},
+			Insert: &rebootDoc{Id: m.Id(), Scheduled: scheduled},
 		},
 	}
+	if !scheduled {
+		// An immediate reboot request supersedes any existing
+		// scheduled request. The insert above is skipped if the
+		// document already exists.
+		ops = append(ops, txn.Op{
+			C:      rebootC,
+			Id:     m.doc.DocID,
+			Update: bson.D{{"$unset", bson.D{{"scheduled", nil}}}},
+		})
+	}
 	err := m.st.runTransaction(ops)
 	if err == txn.ErrAborted {
 		if err := checkModelActive(m.st); err != nil {
@@ -100,11 +115,18 @@ func (m *Machine) clearFlag() error {
 // does not exist yet for this machine, it will create it.
 func (m *Machine) SetRebootFlag(flag bool) error {
 	if flag {
-		return m.setFlag()
+		return m.setFlag(false)
 	}
 	return m.clearFlag()
 }
 
+// ScheduleReboot sets the reboot flag of a machine, requesting that
+// the machine reboot during the model's maintenance window. If the
+// reboot flag is already set, ScheduleReboot does nothing.
+func (m *Machine) ScheduleReboot() error {
+	return m.setFlag(true)
+}
+
 // GetRebootFlag returns the reboot flag for this machine.
 func (m *Machine) GetRebootFlag() (bool, error) {
 	rebootCol, closer := m.st.getCollection(rebootC)
@@ -157,10 +179,40 @@ func (m *Machine) ShouldRebootOrShutdown() (RebootAction, error) {
 	return ShouldDoNothing, nil
 }
 
+// RebootScheduled reports whether the action returned by
+// ShouldRebootOrShutdown is only due to scheduled reboot requests,
+// and so should wait for the model's maintenance window.
+func (m *Machine) RebootScheduled() (bool, error) {
+	rebootCol, closer := m.st.getCollection(rebootC)
+	defer closer()
+
+	machines := m.machinesToCareAboutRebootsFor()
+
+	docs := []rebootDoc{}
+	sel := bson.D{{"machineid", bson.D{{"$in", machines}}}}
+	if err := rebootCol.Find(sel).All(&docs); err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(docs) == 0 {
+		return false, nil
+	}
+	for _, doc := range docs {
+		if !doc.Scheduled {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 type RebootFlagSetter interface {
 	SetRebootFlag(flag bool) error
 }
 
+type RebootScheduler interface {
+	ScheduleReboot() error
+	RebootScheduled() (bool, error)
+}
+
 type RebootActionGetter interface {
 	ShouldRebootOrShutdown() (RebootAction, error)
 }
@@ -224,3 +224,64 @@ func (s *RebootSuite) TestWatchRebootHappensOnContainer3(c *gc.C) {
 	statetesting.AssertStop(c, s.wC3)
 	s.wcC3.AssertClosed()
 }
+
+func (s *RebootSuite) TestScheduleReboot(c *gc.C) {
+	scheduled, err := s.machine.RebootScheduled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsFalse)
+
+	err = s.machine.ScheduleReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.wc.AssertOneChange()
+
+	rFlag, err := s.machine.GetRebootFlag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rFlag, jc.IsTrue)
+	scheduled, err = s.machine.RebootScheduled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsTrue)
+
+	// Containers shut down for their host's scheduled reboot
+	// during the maintenance window too.
+	action, err := s.c1.ShouldRebootOrShutdown()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action, gc.Equals, state.ShouldShutdown)
+	scheduled, err = s.c1.RebootScheduled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsTrue)
+}
+
+func (s *RebootSuite) TestRequestRebootSupersedesScheduled(c *gc.C) {
+	err := s.machine.ScheduleReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetRebootFlag(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	scheduled, err := s.machine.RebootScheduled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsFalse)
+
+	// Scheduling a reboot does not delay one already requested.
+	err = s.machine.ScheduleReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	scheduled, err = s.machine.RebootScheduled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsFalse)
+}
+
+func (s *RebootSuite) TestRebootScheduledMixedRequests(c *gc.C) {
+	err := s.machine.ScheduleReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.c1.SetRebootFlag(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// c2 must shut down now for c1's immediate reboot.
+	scheduled, err := s.c2.RebootScheduled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsFalse)
+
+	// c3 is only affected by the host's scheduled reboot.
+	scheduled, err = s.c3.RebootScheduled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsTrue)
+}
@@ -15,6 +15,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/leadership"
 )
 
 // relationKey returns a string describing the relation defined by
@@ -391,6 +393,112 @@ func (r *Relation) globalScope() string {
 	return fmt.Sprintf("r#%d", r.doc.Id)
 }
 
+// applicationSettingsKey returns the key of the settings document
+// holding the named application's data in the relation. Like the
+// units' settings, it shares the relation's prefix, so that it is
+// removed along with them.
+func (r *Relation) applicationSettingsKey(applicationName string) string {
+	return relationApplicationSettingsKey(r.doc.Id, applicationName)
+}
+
+func relationApplicationSettingsKey(relationId int, applicationName string) string {
+	return fmt.Sprintf("r#%d#%s", relationId, applicationName)
+}
+
+// ApplicationSettings returns the settings that the leader of the named
+// application has published in the relation. Unlike unit settings, they
+// are shared by all of the application's units; they are empty until
+// the leader first writes them.
+func (r *Relation) ApplicationSettings(applicationName string) (map[string]interface{}, error) {
+	if _, err := r.Endpoint(applicationName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc, err := readSettingsDoc(r.st, settingsC, r.applicationSettingsKey(applicationName))
+	if errors.IsNotFound(err) {
+		return map[string]interface{}{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read settings for application %q in relation %q", applicationName, r)
+	}
+	return doc.Settings, nil
+}
+
+// UpdateApplicationSettings changes the settings that the named
+// application publishes in the relation. Keys with empty values are
+// removed. The change is only made while the token shows the writer to
+// be the application's leader.
+func (r *Relation) UpdateApplicationSettings(applicationName string, token leadership.Token, updates map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update settings for application %q in relation %q", applicationName, r)
+	if _, err := r.Endpoint(applicationName); err != nil {
+		return errors.Trace(err)
+	}
+	key := r.applicationSettingsKey(applicationName)
+	initial := make(map[string]interface{})
+	sets := bson.M{}
+	unsets := bson.M{}
+	for unescapedKey, value := range updates {
+		key := escapeReplacer.Replace(unescapedKey)
+		if value == "" {
+			unsets[key] = 1
+		} else {
+			sets[key] = value
+			initial[unescapedKey] = value
+		}
+	}
+
+	buildTxn := func(_ int) ([]txn.Op, error) {
+		ops := []txn.Op{{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: txn.DocExists,
+		}}
+		doc, err := readSettingsDoc(r.st, settingsC, key)
+		if errors.IsNotFound(err) {
+			if len(initial) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			// The document starts at version 1, so that watchers
+			// can tell it apart from a missing one.
+			return append(ops, txn.Op{
+				C:      settingsC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: &settingsDoc{
+					Settings: copyMap(initial, escapeReplacer.Replace),
+					Version:  1,
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isNullSettingsChange(doc.Settings, sets, unsets) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return append(ops, txn.Op{
+			C:      settingsC,
+			Id:     key,
+			Assert: bson.D{{"version", doc.Version}},
+			Update: setUnsetUpdateSettings(sets, unsets),
+		}), nil
+	}
+	return r.st.run(buildTxnWithLeadership(buildTxn, token))
+}
+
+// isNullSettingsChange returns whether applying the escaped sets and
+// unsets to the unescaped current settings would leave them unchanged.
+func isNullSettingsChange(current map[string]interface{}, sets, unsets bson.M) bool {
+	for key := range unsets {
+		if _, found := current[unescapeReplacer.Replace(key)]; found {
+			return false
+		}
+	}
+	for key, value := range sets {
+		if current[unescapeReplacer.Replace(key)] != value {
+			return false
+		}
+	}
+	return true
+}
+
 // relationSettingsCleanupChange removes the settings doc.
 type relationSettingsCleanupChange struct {
 	Prefix string
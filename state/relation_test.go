@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -286,6 +288,81 @@ func (s *RelationSuite) TestDestroyPeerRelation(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *RelationSuite) TestApplicationSettings(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := rel.ApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	err = rel.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{
+		"host":     "10.0.0.1",
+		"dotted.k": "v",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{
+		"host": "",
+		"port": "3306",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = rel.ApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{
+		"dotted.k": "v",
+		"port":     "3306",
+	})
+
+	// The other application's settings are separate.
+	settings, err = rel.ApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+}
+
+func (s *RelationSuite) TestApplicationSettingsErrors(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = rel.ApplicationSettings("riak")
+	c.Assert(err, gc.ErrorMatches, `application "riak" is not a member of "wordpress:db mysql:server"`)
+	err = rel.UpdateApplicationSettings("riak", &fakeToken{}, map[string]string{"a": "b"})
+	c.Assert(err, gc.ErrorMatches, `cannot update settings for application "riak" in relation "wordpress:db mysql:server": application "riak" is not a member of "wordpress:db mysql:server"`)
+
+	// Only the leader may write.
+	err = rel.UpdateApplicationSettings("mysql", &failToken{}, map[string]string{"a": "b"})
+	c.Assert(err, gc.ErrorMatches, `cannot update settings for application "mysql" in relation "wordpress:db mysql:server": prerequisites failed: something bad happened`)
+	settings, err := rel.ApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+}
+
+func (s *RelationSuite) TestApplicationSettingsRemovedWithRelation(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{"a": "b"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ReadSettings(state.SettingsC, fmt.Sprintf("r#%d#mysql", rel.Id()))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func assertNoRelations(c *gc.C, srv *state.Application) {
 	rels, err := srv.Relations()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1373,6 +1373,39 @@ func (s *WatchScopeSuite) TestProviderRequirerContainer(c *gc.C) {
 	// connections are in place.
 }
 
+func (s *WatchScopeSuite) TestApplicationSettings(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.rru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := prr.pru0.Watch()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewRelationUnitsWatcherC(c, s.State, w)
+	wc.AssertChange([]string{"wordpress/0"}, nil)
+	wc.AssertNoChange()
+
+	// A change to the counterpart application's settings is reported
+	// as a change to each of its units in scope.
+	err = prr.rel.UpdateApplicationSettings("wordpress", &fakeToken{}, map[string]string{"a": "b"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange([]string{"wordpress/0"}, nil)
+	wc.AssertNoChange()
+
+	err = prr.rru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange([]string{"wordpress/1"}, nil)
+	wc.AssertNoChange()
+	err = prr.rel.UpdateApplicationSettings("wordpress", &fakeToken{}, map[string]string{"a": "c"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange([]string{"wordpress/0", "wordpress/1"}, nil)
+	wc.AssertNoChange()
+
+	// Changes to the watching unit's own application are not reported.
+	err = prr.rel.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{"a": "b"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 type WatchUnitsSuite struct {
 	ConnSuite
 }
@@ -0,0 +1,311 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// validSecretName matches the names charms may give their secrets.
+var validSecretName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Secret is a value that a charm has stored in the controller, so
+// that it need not be kept in relation data or config. Secrets belong
+// to an application, and only its units may read or change them.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+// secretDoc is the document that stores a secret. The value is
+// encrypted with the model's secret key.
+type secretDoc struct {
+	DocID          string `bson:"_id"`
+	ModelUUID      string `bson:"model-uuid"`
+	Application    string `bson:"application"`
+	Name           string `bson:"name"`
+	Revision       int    `bson:"revision"`
+	Value          []byte `bson:"value"`
+	Created        int64  `bson:"created"`
+	Updated        int64  `bson:"updated"`
+	RotateInterval int64  `bson:"rotate-interval,omitempty"`
+	TxnRevno       int64  `bson:"txn-revno"`
+}
+
+// secretKeyDoc holds the key used to encrypt a model's secrets.
+type secretKeyDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Key       []byte `bson:"key"`
+}
+
+// secretKeyId is the id of the single secretKeyDoc in each model.
+const secretKeyId = "key"
+
+// Application returns the name of the application owning the secret.
+func (s *Secret) Application() string {
+	return s.doc.Application
+}
+
+// Name returns the name of the secret.
+func (s *Secret) Name() string {
+	return s.doc.Name
+}
+
+// Revision returns the number of times the secret's value has been
+// set.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// Value returns the secret's value.
+func (s *Secret) Value() (string, error) {
+	key, err := s.st.secretKey()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	value, err := decryptSecret(key, s.doc.Value)
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot decrypt secret %q", s.doc.Name)
+	}
+	return string(value), nil
+}
+
+// Created returns when the secret was first set.
+func (s *Secret) Created() time.Time {
+	return time.Unix(0, s.doc.Created).UTC()
+}
+
+// Updated returns when the secret's value was last set.
+func (s *Secret) Updated() time.Time {
+	return time.Unix(0, s.doc.Updated).UTC()
+}
+
+// RotateInterval returns how often the secret should be given a new
+// value; zero means that it need not be.
+func (s *Secret) RotateInterval() time.Duration {
+	return time.Duration(s.doc.RotateInterval)
+}
+
+// NextRotation returns when the secret is next due to be given a new
+// value, and false if it need not be.
+func (s *Secret) NextRotation() (time.Time, bool) {
+	if s.doc.RotateInterval == 0 {
+		return time.Time{}, false
+	}
+	return s.Updated().Add(s.RotateInterval()), true
+}
+
+// SecretArgs holds the arguments to SetSecret.
+type SecretArgs struct {
+	// Value is the new value of the secret.
+	Value string
+
+	// RotateInterval, if not nil, sets how often the secret should be
+	// given a new value; zero means that it need not be. If nil, any
+	// existing interval is kept.
+	RotateInterval *time.Duration
+}
+
+// SetSecret sets the value of the named secret of the given
+// application, creating it if necessary. Each change increments the
+// secret's revision.
+func (st *State) SetSecret(application, name string, args SecretArgs) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set secret %q for application %q", name, application)
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	if !validSecretName.MatchString(name) {
+		return errors.NotValidf("secret name %q", name)
+	}
+	if args.RotateInterval != nil && *args.RotateInterval < 0 {
+		return errors.NotValidf("negative rotate interval")
+	}
+	key, err := st.secretKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	value, err := encryptSecret(key, []byte(args.Value))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+	id := secretDocId(application, name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		app, err := st.Application(application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application is not alive")
+		}
+		now := st.clock.Now().UnixNano()
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		var doc secretDoc
+		err = secrets.FindId(id).One(&doc)
+		if err == mgo.ErrNotFound {
+			doc = secretDoc{
+				DocID:       id,
+				Application: application,
+				Name:        name,
+				Revision:    1,
+				Value:       value,
+				Created:     now,
+				Updated:     now,
+			}
+			if args.RotateInterval != nil {
+				doc.RotateInterval = int64(*args.RotateInterval)
+			}
+			return append(ops, txn.Op{
+				C:      secretsC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		set := bson.D{
+			{"revision", doc.Revision + 1},
+			{"value", value},
+			{"updated", now},
+		}
+		if args.RotateInterval != nil {
+			set = append(set, bson.DocElem{"rotate-interval", int64(*args.RotateInterval)})
+		}
+		return append(ops, txn.Op{
+			C:      secretsC,
+			Id:     id,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", set}},
+		}), nil
+	}
+	return st.run(buildTxn)
+}
+
+// Secret returns the named secret of the given application.
+func (st *State) Secret(application, name string) (*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+	var doc secretDoc
+	err := secrets.FindId(secretDocId(application, name)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q for application %q", name, application)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q for application %q", name, application)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// removeApplicationSecretsOps returns the operations needed to remove
+// all the secrets of the named application.
+func removeApplicationSecretsOps(st *State, application string) ([]txn.Op, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	err := secrets.Find(bson.D{{"application", application}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretsC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+func secretDocId(application, name string) string {
+	return application + "#" + name
+}
+
+// secretKey returns the key used to encrypt the model's secrets,
+// creating it the first time it is needed.
+func (st *State) secretKey() ([]byte, error) {
+	keys, closer := st.getCollection(secretKeysC)
+	defer closer()
+	var doc secretKeyDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		err := keys.FindId(secretKeyId).One(&doc)
+		if err == nil {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != mgo.ErrNotFound {
+			return nil, errors.Trace(err)
+		}
+		key := make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc = secretKeyDoc{DocID: secretKeyId, Key: key}
+		return []txn.Op{{
+			C:      secretKeysC,
+			Id:     secretKeyId,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot get secret key")
+	}
+	return doc.Key, nil
+}
+
+// encryptSecret encrypts value with AES-GCM, returning the nonce
+// followed by the sealed value.
+func encryptSecret(key, value []byte) ([]byte, error) {
+	aead, err := secretAEAD(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return aead.Seal(nonce, nonce, value, nil), nil
+}
+
+// decryptSecret reverses encryptSecret.
+func decryptSecret(key, sealed []byte) ([]byte, error) {
+	aead, err := secretAEAD(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("value too short")
+	}
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	value, err := aead.Open(nil, nonce, sealed, nil)
+	return value, errors.Trace(err)
+}
+
+func secretAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	application *state.Application
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.Factory.MakeApplication(c, nil)
+}
+
+func (s *SecretsSuite) TestSetAndGet(c *gc.C) {
+	err := s.State.SetSecret(s.application.Name(), "db-password", state.SecretArgs{Value: "hunter2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.State.Secret(s.application.Name(), "db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Application(), gc.Equals, s.application.Name())
+	c.Assert(secret.Name(), gc.Equals, "db-password")
+	c.Assert(secret.Revision(), gc.Equals, 1)
+	c.Assert(secret.Created().IsZero(), jc.IsFalse)
+	c.Assert(secret.Updated(), gc.Equals, secret.Created())
+	c.Assert(secret.RotateInterval(), gc.Equals, time.Duration(0))
+	_, due := secret.NextRotation()
+	c.Assert(due, jc.IsFalse)
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "hunter2")
+}
+
+func (s *SecretsSuite) TestValueStoredEncrypted(c *gc.C) {
+	err := s.State.SetSecret(s.application.Name(), "db-password", state.SecretArgs{Value: "hunter2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var doc struct {
+		Value []byte `bson:"value"`
+	}
+	secrets := s.State.MongoSession().DB("juju").C("secrets")
+	err = secrets.Find(bson.D{{"name", "db-password"}}).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.Value, gc.Not(gc.HasLen), 0)
+	c.Assert(bytes.Contains(doc.Value, []byte("hunter2")), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestSetUpdatesRevisionAndRotation(c *gc.C) {
+	day := 24 * time.Hour
+	err := s.State.SetSecret(s.application.Name(), "api-key", state.SecretArgs{
+		Value:          "one",
+		RotateInterval: &day,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Without an interval, the existing one is kept.
+	err = s.State.SetSecret(s.application.Name(), "api-key", state.SecretArgs{Value: "two"})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(s.application.Name(), "api-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 2)
+	c.Assert(secret.RotateInterval(), gc.Equals, day)
+	next, due := secret.NextRotation()
+	c.Assert(due, jc.IsTrue)
+	c.Assert(next, gc.Equals, secret.Updated().Add(day))
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "two")
+
+	// A zero interval clears it.
+	never := time.Duration(0)
+	err = s.State.SetSecret(s.application.Name(), "api-key", state.SecretArgs{
+		Value:          "three",
+		RotateInterval: &never,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.State.Secret(s.application.Name(), "api-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 3)
+	_, due = secret.NextRotation()
+	c.Assert(due, jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestSecretsBelongToApplication(c *gc.C) {
+	other := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "other"})
+	err := s.State.SetSecret(s.application.Name(), "db-password", state.SecretArgs{Value: "hunter2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret(other.Name(), "db-password")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestSetInvalid(c *gc.C) {
+	err := s.State.SetSecret(s.application.Name(), "Not_Valid", state.SecretArgs{Value: "x"})
+	c.Assert(err, gc.ErrorMatches, `cannot set secret "Not_Valid" for application ".*": secret name "Not_Valid" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	negative := -time.Second
+	err = s.State.SetSecret(s.application.Name(), "ok", state.SecretArgs{Value: "x", RotateInterval: &negative})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = s.State.SetSecret("missing", "ok", state.SecretArgs{Value: "x"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestSecretsRemovedWithApplication(c *gc.C) {
+	err := s.State.SetSecret(s.application.Name(), "db-password", state.SecretArgs{Value: "hunter2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Secret(s.application.Name(), "db-password")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
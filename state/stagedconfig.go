@@ -0,0 +1,158 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// stagedConfigDoc holds config changes that have been staged for an
+// application but not yet applied.
+type stagedConfigDoc struct {
+	// Settings holds the staged changes, keyed on escaped option
+	// name. A nil value resets the option to its default.
+	Settings map[string]interface{} `bson:"settings"`
+
+	// Version is incremented each time changes are staged, so that
+	// a commit cannot lose changes staged while it was in progress.
+	Version int64 `bson:"version"`
+}
+
+// StagedConfigSettings returns the config changes staged for the
+// application, or nil if there are none. Options that will be reset
+// to their defaults have nil values.
+func (a *Application) StagedConfigSettings() charm.Settings {
+	if a.doc.StagedConfig == nil || len(a.doc.StagedConfig.Settings) == 0 {
+		return nil
+	}
+	return charm.Settings(copyMap(a.doc.StagedConfig.Settings, unescapeReplacer.Replace))
+}
+
+// StageConfigSettings validates the given config changes against the
+// application's charm and adds them to those already staged, without
+// changing the application's config. A nil value stages a reset of the
+// option to its default. The staged changes are applied together by
+// CommitStagedConfigSettings.
+func (a *Application) StageConfigSettings(changes charm.Settings) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot stage config changes for application %q", a)
+	if len(changes) == 0 {
+		return nil
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errors.New("application is not alive")
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		validated, err := ch.Config().ValidateSettings(changes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		set := bson.D{}
+		for name, value := range validated {
+			set = append(set, bson.DocElem{
+				"staged-config.settings." + escapeReplacer.Replace(name), value,
+			})
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: append(isAliveDoc, bson.DocElem{"charmurl", a.doc.CharmURL}),
+			Update: bson.D{
+				{"$set", set},
+				{"$inc", bson.D{{"staged-config.version", 1}}},
+			},
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(a.Refresh())
+}
+
+// CommitStagedConfigSettings applies all of the application's staged
+// config changes in a single transaction, so that units see one config
+// change rather than one per staged key, and then forgets them. It
+// returns an error satisfying errors.IsNotFound if nothing is staged.
+func (a *Application) CommitStagedConfigSettings() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot commit config changes for application %q", a)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errors.New("application is not alive")
+		}
+		staged := a.StagedConfigSettings()
+		if len(staged) == 0 {
+			return nil, errors.NotFoundf("staged config changes")
+		}
+		// The charm may have been upgraded since the changes were
+		// staged, so check them against the current one.
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		staged, err = ch.Config().ValidateSettings(staged)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		node, err := readSettings(a.st, settingsC, a.settingsKey())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for name, value := range staged {
+			if value == nil {
+				node.Delete(name)
+			} else {
+				node.Set(name, value)
+			}
+		}
+		_, ops := node.settingsUpdateOps()
+		return append(ops, txn.Op{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: append(isAliveDoc,
+				bson.DocElem{"charmurl", a.doc.CharmURL},
+				bson.DocElem{"staged-config.version", a.doc.StagedConfig.Version},
+			),
+			Update: bson.D{{"$unset", bson.D{{"staged-config", nil}}}},
+		}), nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return err
+	}
+	a.doc.StagedConfig = nil
+	return nil
+}
+
+// DiscardStagedConfigSettings forgets the application's staged config
+// changes without applying them.
+func (a *Application) DiscardStagedConfigSettings() error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{{"staged-config", nil}}}},
+	}}
+	if err := a.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("application %q", a)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot discard config changes for application %q", a)
+	}
+	a.doc.StagedConfig = nil
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type StagedConfigSuite struct {
+	ConnSuite
+	charm *state.Charm
+	app   *state.Application
+}
+
+var _ = gc.Suite(&StagedConfigSuite{})
+
+func (s *StagedConfigSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "dummy")
+	s.app = s.AddTestingService(c, "dummy", s.charm)
+	err := s.app.UpdateConfigSettings(charm.Settings{"outlook": "sunny"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StagedConfigSuite) TestStageDoesNotChangeConfig(c *gc.C) {
+	err := s.app.StageConfigSettings(charm.Settings{"title": "staged"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.StageConfigSettings(charm.Settings{"skill-level": int64(9), "outlook": nil})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.app.StagedConfigSettings(), jc.DeepEquals, charm.Settings{
+		"title":       "staged",
+		"skill-level": int64(9),
+		"outlook":     nil,
+	})
+	settings, err := s.app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"outlook": "sunny"})
+
+	app, err := s.State.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.StagedConfigSettings(), jc.DeepEquals, s.app.StagedConfigSettings())
+}
+
+func (s *StagedConfigSuite) TestStageValidates(c *gc.C) {
+	err := s.app.StageConfigSettings(charm.Settings{"no-such-option": "x"})
+	c.Assert(err, gc.ErrorMatches, `cannot stage config changes for application "dummy": unknown option "no-such-option"`)
+	err = s.app.StageConfigSettings(charm.Settings{"skill-level": "lots"})
+	c.Assert(err, gc.ErrorMatches, `cannot stage config changes for application "dummy": option "skill-level" expected int, got "lots"`)
+	c.Assert(s.app.StagedConfigSettings(), gc.IsNil)
+}
+
+func (s *StagedConfigSuite) TestCommitAppliesOnce(c *gc.C) {
+	unit, err := s.app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmURL(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := unit.WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.app.StageConfigSettings(charm.Settings{"title": "staged"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.StageConfigSettings(charm.Settings{"outlook": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.app.CommitStagedConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	settings, err := s.app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"title": "staged"})
+	c.Assert(s.app.StagedConfigSettings(), gc.IsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.StagedConfigSettings(), gc.IsNil)
+}
+
+func (s *StagedConfigSuite) TestCommitNothingStaged(c *gc.C) {
+	err := s.app.CommitStagedConfigSettings()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cannot commit config changes for application "dummy": staged config changes not found`)
+}
+
+func (s *StagedConfigSuite) TestDiscard(c *gc.C) {
+	err := s.app.StageConfigSettings(charm.Settings{"title": "staged"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.DiscardStagedConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.StagedConfigSettings(), gc.IsNil)
+
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.StagedConfigSettings(), gc.IsNil)
+	settings, err := s.app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"outlook": "sunny"})
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmfeatures"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
@@ -44,6 +45,8 @@ import (
 	"github.com/juju/juju/state/cloudimagemetadata"
 	stateaudit "github.com/juju/juju/state/internal/audit"
 	statelease "github.com/juju/juju/state/lease"
+	"github.com/juju/juju/state/objectstore"
+	"github.com/juju/juju/state/presence"
 	"github.com/juju/juju/state/workers"
 	"github.com/juju/juju/status"
 	jujuversion "github.com/juju/juju/version"
@@ -107,6 +110,18 @@ type State struct {
 	// folded in as well, but that feels like its own task.
 	workers workers.Workers
 
+	// txnLogParent, if set, is the State whose txn log watcher
+	// this State shares rather than polling the log itself.
+	txnLogParent *State
+
+	// objectStores opens the blob storage described by the
+	// controller config. It is shared with txnLogParent, if set.
+	objectStores *objectstore.Stores
+
+	// pingBatcher collects the pings of the model's agents and
+	// writes them to the presence collection in batches.
+	pingBatcher *presence.PingBatcher
+
 	// mu guards allManager, allModelManager & allModelWatcherBacking
 	mu                     sync.Mutex
 	allManager             *storeManager
@@ -225,7 +240,7 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	}
 	// Logs are in a separate database so don't get caught by that
 	// loop.
-	removeModelLogs(st.MongoSession(), modelUUID)
+	removeModelLogs(st.MongoSession(), st.objectStores, modelUUID)
 
 	// Remove all user permissions for the model.
 	permPattern := bson.M{
@@ -260,7 +275,7 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 		Id:     modelUUID,
 		Assert: modelAssertion,
 		Remove: true,
-	}}
+	}, nsRefcounts.justDecRefOp(controllersC, cloudModelRefCountKey(env.Cloud()), 0)}
 	if !st.IsController() {
 		ops = append(ops, decHostedModelCountOp())
 	}
@@ -307,6 +322,13 @@ func (st *State) removeInCollectionOps(name string, sel interface{}) ([]txn.Op,
 // ForModel returns a connection to mongo for the specified model. The
 // connection uses the same credentials and policy as the existing connection.
 func (st *State) ForModel(modelTag names.ModelTag) (*State, error) {
+	return st.forModel(modelTag, nil)
+}
+
+// forModel is the implementation of ForModel. If txnLogParent is not
+// nil, the new State's txn log watcher is a client of txnLogParent's,
+// which must outlive it.
+func (st *State) forModel(modelTag names.ModelTag, txnLogParent *State) (*State, error) {
 	session := st.session.Copy()
 	newSt, err := newState(
 		modelTag, st.controllerModelTag, session, st.mongoInfo, st.newPolicy, st.clock,
@@ -315,6 +337,10 @@ func (st *State) ForModel(modelTag names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.txnLogParent = txnLogParent
+	if txnLogParent != nil {
+		newSt.objectStores = txnLogParent.objectStores
+	}
 	if err := newSt.start(st.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -373,6 +399,7 @@ func (st *State) start(controllerTag names.ControllerTag) (err error) {
 		return errors.Annotatef(err, "cannot create standard state workers")
 	}
 	st.workers = workers
+	st.pingBatcher = presence.NewPingBatcher(st.getPresenceCollection(), presence.DefaultPingBatcherInterval)
 
 	logger.Infof("creating cloud image metadata storage")
 	st.CloudImageMetadataStorage = cloudimagemetadata.NewStorage(
@@ -506,6 +533,29 @@ func (st *State) getPresenceCollection() *mgo.Collection {
 	return st.session.DB(presenceDB).C(presenceC)
 }
 
+// NewPresencePruner returns a Pruner that removes old presence data
+// for the model with the given UUID.
+func (st *State) NewPresencePruner(modelUUID string) *presence.Pruner {
+	return presence.NewPruner(st.getPresenceCollection(), modelUUID)
+}
+
+// startAgentPinger starts and returns a pinger reporting that the
+// agent with the given key is alive. Its pings are batched with those
+// of the model's other agents, except for the first, which is written
+// before startAgentPinger returns so that the agent is seen to be
+// alive as soon as possible.
+func (st *State) startAgentPinger(key string) (*presence.Pinger, error) {
+	p := presence.NewPingerWithRecorder(st.getPresenceCollection(), st.modelTag, key, st.pingBatcher)
+	if err := p.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := st.pingBatcher.Sync(); err != nil {
+		p.Stop()
+		return nil, errors.Trace(err)
+	}
+	return p, nil
+}
+
 // getTxnLogCollection returns the raw mongodb txns collection, which is
 // needed to interact with the state/watcher package.
 func (st *State) getTxnLogCollection() *mgo.Collection {
@@ -543,6 +593,12 @@ func (st *State) MongoSession() *mgo.Session {
 	return st.session
 }
 
+// ObjectStores returns the object stores in which the controller's
+// blobs are stored.
+func (st *State) ObjectStores() *objectstore.Stores {
+	return st.objectStores
+}
+
 func (st *State) Watch() *Multiwatcher {
 	st.mu.Lock()
 	if st.allManager == nil {
@@ -1001,6 +1057,9 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	if err := validateCharmVersion(args.Charm); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := charmfeatures.Check(args.Charm.JujuFeatures()); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	if exists, err := isNotDead(st, applicationsC, args.Name); err != nil {
 		return nil, errors.Trace(err)
@@ -4,9 +4,16 @@
 package statemetrics
 
 import (
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/status"
 )
 
 const (
@@ -20,6 +27,8 @@ const (
 	domainLabel           = "domain"
 	agentStatusLabel      = "agent_status"
 	machineStatusLabel    = "machine_status"
+	workloadStatusLabel   = "workload_status"
+	modelUUIDLabel        = "model_uuid"
 )
 
 var (
@@ -34,6 +43,23 @@ var (
 		statusLabel,
 	}
 
+	unitLabelNames = []string{
+		agentStatusLabel,
+		lifeLabel,
+		workloadStatusLabel,
+	}
+
+	hookErrorLabelNames = []string{
+		modelUUIDLabel,
+	}
+
+	// provisioningLatencyBuckets covers the time taken to
+	// provision machines, from containers that start in seconds
+	// to cloud instances that take many minutes.
+	provisioningLatencyBuckets = []float64{
+		10, 30, 60, 120, 300, 600, 1200, 1800, 3600,
+	}
+
 	userLabelNames = []string{
 		controllerAccessLabel,
 		deletedLabel,
@@ -46,21 +72,42 @@ var (
 
 // Collector is a prometheus.Collector that collects metrics about
 // the Juju global state.
+//
+// Machine provisioning latency is measured by the collector itself:
+// a machine first seen unprovisioned is timed from when it was added
+// (or, if that is not known, from when it was first seen) until the
+// first collection that finds it provisioned. The latencies are thus
+// only as precise as the interval between collections.
 type Collector struct {
-	st State
+	st    State
+	clock clock.Clock
+
+	// mu serialises collections, which update the metrics below
+	// and the unprovisioned machines.
+	mu sync.Mutex
+
+	// unprovisioned holds the time from which each unprovisioned
+	// machine's provisioning is timed, keyed by model UUID and
+	// machine ID.
+	unprovisioned map[string]time.Time
 
 	scrapeDuration prometheus.Gauge
 	scrapeErrors   prometheus.Gauge
 
-	models   *prometheus.GaugeVec
-	machines *prometheus.GaugeVec
-	users    *prometheus.GaugeVec
+	models              *prometheus.GaugeVec
+	machines            *prometheus.GaugeVec
+	users               *prometheus.GaugeVec
+	units               *prometheus.GaugeVec
+	unitHookErrors      *prometheus.GaugeVec
+	provisioningLatency prometheus.Histogram
 }
 
 // New returns a new Collector.
-func New(st State) *Collector {
+func New(st State, clock clock.Clock) *Collector {
 	return &Collector{
-		st: st,
+		st:            st,
+		clock:         clock,
+		unprovisioned: make(map[string]time.Time),
 		scrapeDuration: prometheus.NewGauge(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
@@ -100,6 +147,30 @@ func New(st State) *Collector {
 			},
 			userLabelNames,
 		),
+		units: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "units",
+				Help:      "Number of units managed by the controller.",
+			},
+			unitLabelNames,
+		),
+		unitHookErrors: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "unit_hook_errors",
+				Help:      "Number of units in each model with a failed hook.",
+			},
+			hookErrorLabelNames,
+		),
+		provisioningLatency: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "machine_provisioning_latency_seconds",
+				Help:      "Time taken to provision machines.",
+				Buckets:   provisioningLatencyBuckets,
+			},
+		),
 	}
 }
 
@@ -108,6 +179,9 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.machines.Describe(ch)
 	c.models.Describe(ch)
 	c.users.Describe(ch)
+	c.units.Describe(ch)
+	c.unitHookErrors.Describe(ch)
+	c.provisioningLatency.Describe(ch)
 
 	c.scrapeErrors.Describe(ch)
 	c.scrapeDuration.Describe(ch)
@@ -115,6 +189,9 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect is part of the prometheus.Collector interface.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(c.scrapeDuration.Set))
 	defer c.scrapeDuration.Collect(ch)
 	defer timer.ObserveDuration()
@@ -124,26 +201,42 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.machines.Reset()
 	c.models.Reset()
 	c.users.Reset()
+	c.units.Reset()
+	c.unitHookErrors.Reset()
 
 	c.updateMetrics()
 
 	c.machines.Collect(ch)
 	c.models.Collect(ch)
 	c.users.Collect(ch)
+	c.units.Collect(ch)
+	c.unitHookErrors.Collect(ch)
+	c.provisioningLatency.Collect(ch)
 }
 
 func (c *Collector) updateMetrics() {
 	logger.Tracef("updating state metrics")
 	defer logger.Tracef("updated state metrics")
 
+	// keep records the unprovisioned machines that are
+	// still being timed after this collection.
+	keep := make(map[string]bool)
+
 	models, err := c.st.AllModels()
 	if err != nil {
 		logger.Debugf("error getting models: %v", err)
 		c.scrapeErrors.Inc()
+		c.keepUnprovisioned(keep, "")
 		models = nil
 	}
 	for _, m := range models {
-		c.updateModelMetrics(m)
+		c.updateModelMetrics(m, keep)
+	}
+	// Forget machines that have been removed.
+	for key := range c.unprovisioned {
+		if !keep[key] {
+			delete(c.unprovisioned, key)
+		}
 	}
 
 	// TODO(axw) AllUsers only returns *local* users. We do not have User
@@ -180,7 +273,8 @@ func (c *Collector) updateMetrics() {
 	}
 }
 
-func (c *Collector) updateModelMetrics(model Model) {
+func (c *Collector) updateModelMetrics(model Model, keep map[string]bool) {
+	modelTag := model.ModelTag()
 	modelStatus, err := model.Status()
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -188,10 +282,10 @@ func (c *Collector) updateModelMetrics(model Model) {
 		}
 		c.scrapeErrors.Inc()
 		logger.Debugf("error getting model status: %v", err)
+		c.keepUnprovisioned(keep, modelTag.Id()+"/")
 		return
 	}
 
-	modelTag := model.ModelTag()
 	st, err := c.st.ForModel(modelTag)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -199,6 +293,7 @@ func (c *Collector) updateModelMetrics(model Model) {
 		}
 		c.scrapeErrors.Inc()
 		logger.Debugf("error getting model state: %v", err)
+		c.keepUnprovisioned(keep, modelTag.Id()+"/")
 		return
 	}
 	defer st.Close()
@@ -207,15 +302,18 @@ func (c *Collector) updateModelMetrics(model Model) {
 	if err != nil {
 		c.scrapeErrors.Inc()
 		logger.Debugf("error getting machines: %v", err)
+		c.keepUnprovisioned(keep, modelTag.Id()+"/")
 		machines = nil
 	}
 	for _, m := range machines {
+		key := modelTag.Id() + "/" + m.Id()
 		agentStatus, err := m.Status()
 		if errors.IsNotFound(err) {
 			continue // Machine removed
 		} else if err != nil {
 			c.scrapeErrors.Inc()
 			logger.Debugf("error getting machine status: %v", err)
+			keep[key] = true
 			continue
 		}
 
@@ -224,10 +322,15 @@ func (c *Collector) updateModelMetrics(model Model) {
 			continue // Machine removed
 		} else if errors.IsNotProvisioned(err) {
 			machineStatus.Status = ""
+			c.machineUnprovisioned(key, agentStatus)
+			keep[key] = true
 		} else if err != nil {
 			c.scrapeErrors.Inc()
 			logger.Debugf("error getting machine status: %v", err)
+			keep[key] = true
 			continue
+		} else {
+			c.machineProvisioned(key)
 		}
 
 		c.machines.With(prometheus.Labels{
@@ -237,8 +340,96 @@ func (c *Collector) updateModelMetrics(model Model) {
 		}).Inc()
 	}
 
+	c.updateUnitMetrics(st, modelTag.Id())
+
 	c.models.With(prometheus.Labels{
 		lifeLabel:   model.Life().String(),
 		statusLabel: string(modelStatus.Status),
 	}).Inc()
 }
+
+func (c *Collector) updateUnitMetrics(st State, modelUUID string) {
+	applications, err := st.AllApplications()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting applications: %v", err)
+		return
+	}
+	hookErrors := c.unitHookErrors.With(prometheus.Labels{
+		modelUUIDLabel: modelUUID,
+	})
+	for _, a := range applications {
+		units, err := a.AllUnits()
+		if errors.IsNotFound(err) {
+			continue // Application removed
+		} else if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting units: %v", err)
+			continue
+		}
+		for _, u := range units {
+			agentStatus, err := u.AgentStatus()
+			if errors.IsNotFound(err) {
+				continue // Unit removed
+			} else if err != nil {
+				c.scrapeErrors.Inc()
+				logger.Debugf("error getting unit agent status: %v", err)
+				continue
+			}
+			workloadStatus, err := u.Status()
+			if errors.IsNotFound(err) {
+				continue // Unit removed
+			} else if err != nil {
+				c.scrapeErrors.Inc()
+				logger.Debugf("error getting unit status: %v", err)
+				continue
+			}
+			// A failed hook is recorded against the unit's
+			// agent, but reported as the workload's status.
+			if workloadStatus.Status == status.Error {
+				hookErrors.Inc()
+			}
+			c.units.With(prometheus.Labels{
+				agentStatusLabel:    string(agentStatus.Status),
+				lifeLabel:           u.Life().String(),
+				workloadStatusLabel: string(workloadStatus.Status),
+			}).Inc()
+		}
+	}
+}
+
+// machineUnprovisioned starts timing the provisioning of the machine
+// with the given key, if it is not already being timed. A machine's
+// agent status remains pending from when the machine is added until
+// its agent starts, so the time of that status is used if available.
+func (c *Collector) machineUnprovisioned(key string, agentStatus status.StatusInfo) {
+	if _, ok := c.unprovisioned[key]; ok {
+		return
+	}
+	start := c.clock.Now()
+	if agentStatus.Status == status.Pending && agentStatus.Since != nil {
+		start = *agentStatus.Since
+	}
+	c.unprovisioned[key] = start
+}
+
+// keepUnprovisioned keeps timing the unprovisioned machines whose keys
+// have the given prefix, when they cannot be read in this collection.
+func (c *Collector) keepUnprovisioned(keep map[string]bool, prefix string) {
+	for key := range c.unprovisioned {
+		if strings.HasPrefix(key, prefix) {
+			keep[key] = true
+		}
+	}
+}
+
+// machineProvisioned records the provisioning latency of the machine
+// with the given key, if it was seen unprovisioned.
+func (c *Collector) machineProvisioned(key string) {
+	start, ok := c.unprovisioned[key]
+	if !ok {
+		return
+	}
+	delete(c.unprovisioned, key)
+	c.provisioningLatency.Observe(c.clock.Now().Sub(start).Seconds())
+}
@@ -6,7 +6,9 @@ package statemetrics_test
 import (
 	"errors"
 	"reflect"
+	"time"
 
+	jujuerrors "github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/prometheus/client_golang/prometheus"
@@ -23,6 +25,7 @@ import (
 type collectorSuite struct {
 	testing.IsolationSuite
 	st        mockState
+	clock     *testing.Clock
 	collector *statemetrics.Collector
 }
 
@@ -52,15 +55,28 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 		life:   state.Alive,
 		status: status.StatusInfo{Status: status.Available},
 		machines: []*mockMachine{{
+			id:             "0",
 			life:           state.Alive,
 			agentStatus:    status.StatusInfo{Status: status.Started},
 			instanceStatus: status.StatusInfo{Status: status.Running},
 		}},
+		applications: []*mockApplication{{
+			units: []*mockUnit{{
+				life:           state.Alive,
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Active},
+			}, {
+				life:           state.Alive,
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Error},
+			}},
+		}},
 	}, {
 		tag:    names.NewModelTag("1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
 		life:   state.Dying,
 		status: status.StatusInfo{Status: status.Destroying},
 		machines: []*mockMachine{{
+			id:             "0",
 			life:           state.Alive,
 			agentStatus:    status.StatusInfo{Status: status.Error},
 			instanceStatus: status.StatusInfo{Status: status.ProvisioningError},
@@ -71,7 +87,8 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 		users:  users,
 		models: models,
 	}
-	s.collector = statemetrics.New(&s.st)
+	s.clock = testing.NewClock(time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC))
+	s.collector = statemetrics.New(&s.st, s.clock)
 }
 
 func (s *collectorSuite) TestDescribe(c *gc.C) {
@@ -88,6 +105,9 @@ func (s *collectorSuite) TestDescribe(c *gc.C) {
 		`.*fqName: "juju_state_machines".*`,
 		`.*fqName: "juju_state_models".*`,
 		`.*fqName: "juju_state_users".*`,
+		`.*fqName: "juju_state_units".*`,
+		`.*fqName: "juju_state_unit_hook_errors".*`,
+		`.*fqName: "juju_state_machine_provisioning_latency_seconds".*`,
 		`.*fqName: "juju_state_scrape_errors".*`,
 		`.*fqName: "juju_state_scrape_duration_seconds".*`,
 	}
@@ -133,12 +153,31 @@ func (s *collectorSuite) checkExpected(c *gc.C, actual, expected []dto.Metric) {
 	}
 }
 
+// removeHistogram removes the provisioning latency histogram from
+// the given metrics, returning it and the remaining metrics.
+func removeHistogram(c *gc.C, metrics []dto.Metric) (*dto.Histogram, []dto.Metric) {
+	var histogram *dto.Histogram
+	var others []dto.Metric
+	for _, m := range metrics {
+		if m.Histogram != nil {
+			c.Assert(histogram, gc.IsNil)
+			histogram = m.Histogram
+			continue
+		}
+		others = append(others, m)
+	}
+	c.Assert(histogram, gc.NotNil)
+	return histogram, others
+}
+
 func float64ptr(v float64) *float64 {
 	return &v
 }
 
 func (s *collectorSuite) TestCollect(c *gc.C) {
 	_, dtoMetrics := s.collect(c)
+	histogram, dtoMetrics := removeHistogram(c, dtoMetrics)
+	c.Assert(histogram.GetSampleCount(), gc.Equals, uint64(0))
 
 	// The scrape time metric has a non-deterministic value,
 	// so we just check that it is non-zero.
@@ -213,6 +252,38 @@ func (s *collectorSuite) TestCollect(c *gc.C) {
 			},
 		},
 
+		// juju_state_units
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "idle"),
+				labelpair("life", "alive"),
+				labelpair("workload_status", "active"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "idle"),
+				labelpair("life", "alive"),
+				labelpair("workload_status", "error"),
+			},
+		},
+
+		// juju_state_unit_hook_errors
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("model_uuid", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(0)},
+			Label: []*dto.LabelPair{
+				labelpair("model_uuid", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
 		// juju_state_scrape_errors
 		{
 			Gauge: &dto.Gauge{Value: float64ptr(0)},
@@ -231,6 +302,7 @@ func (s *collectorSuite) TestCollectErrors(c *gc.C) {
 		errors.New("no users for you"),
 	)
 	_, dtoMetrics := s.collect(c)
+	_, dtoMetrics = removeHistogram(c, dtoMetrics)
 
 	// The scrape time metric has a non-deterministic value,
 	// so we just check that it is non-zero.
@@ -250,3 +322,62 @@ func (s *collectorSuite) TestCollectErrors(c *gc.C) {
 		},
 	})
 }
+
+func (s *collectorSuite) TestProvisioningLatency(c *gc.C) {
+	added := s.clock.Now().Add(-time.Minute)
+	machine := &mockMachine{
+		id:             "1",
+		life:           state.Alive,
+		agentStatus:    status.StatusInfo{Status: status.Pending, Since: &added},
+		instanceStatus: status.StatusInfo{Status: status.Running},
+	}
+	model := s.st.models[0]
+	model.machines = append(model.machines, machine)
+
+	// The machine's instance is not yet provisioned.
+	machine.SetErrors(nil, jujuerrors.NotProvisionedf("machine 1"))
+	_, dtoMetrics := s.collect(c)
+	histogram, _ := removeHistogram(c, dtoMetrics)
+	c.Assert(histogram.GetSampleCount(), gc.Equals, uint64(0))
+
+	// Provisioning is timed from when the machine was added.
+	s.clock.Advance(4 * time.Minute)
+	_, dtoMetrics = s.collect(c)
+	histogram, _ = removeHistogram(c, dtoMetrics)
+	c.Assert(histogram.GetSampleCount(), gc.Equals, uint64(1))
+	c.Assert(histogram.GetSampleSum(), gc.Equals, float64(300))
+
+	// The latency is recorded only once.
+	_, dtoMetrics = s.collect(c)
+	histogram, _ = removeHistogram(c, dtoMetrics)
+	c.Assert(histogram.GetSampleCount(), gc.Equals, uint64(1))
+}
+
+func (s *collectorSuite) TestProvisioningLatencyMachineRemoved(c *gc.C) {
+	machine := &mockMachine{
+		id:          "1",
+		life:        state.Alive,
+		agentStatus: status.StatusInfo{Status: status.Pending},
+	}
+	model := s.st.models[0]
+	model.machines = append(model.machines, machine)
+
+	machine.SetErrors(nil, jujuerrors.NotProvisionedf("machine 1"))
+	s.collect(c)
+
+	// A machine removed before it was provisioned is forgotten,
+	// so that a machine later added with the same ID is timed
+	// afresh.
+	model.machines = model.machines[:1]
+	s.collect(c)
+	model.machines = append(model.machines, machine)
+	machine.SetErrors(nil, jujuerrors.NotProvisionedf("machine 1"))
+	s.clock.Advance(time.Minute)
+	s.collect(c)
+
+	s.clock.Advance(time.Minute)
+	_, dtoMetrics := s.collect(c)
+	histogram, _ := removeHistogram(c, dtoMetrics)
+	c.Assert(histogram.GetSampleCount(), gc.Equals, uint64(1))
+	c.Assert(histogram.GetSampleSum(), gc.Equals, float64(60))
+}
@@ -0,0 +1,199 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"net"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// staticAddressDoc records the reservation of an address, taken from
+// a range declared in model config, for a container whose provider
+// cannot allocate container addresses itself.
+type staticAddressDoc struct {
+	// DocID is the address, prefixed by ModelUUID. Keying the
+	// document on the address ensures that no two containers can
+	// reserve the same one.
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Address is the reserved address.
+	Address string `bson:"address"`
+
+	// SubnetCIDR is the CIDR of the subnet the address belongs to.
+	SubnetCIDR string `bson:"subnet-cidr"`
+
+	// MachineID is the ID of the container holding the address.
+	MachineID string `bson:"machine-id"`
+}
+
+// AllocateStaticAddress reserves for the machine the first address
+// between low and high (inclusive) in the given subnet that is not
+// reserved by another machine or assigned to any machine's network
+// device, and returns it. If the machine already holds an address in
+// the subnet, that address is returned, so that a container keeps
+// its address when its network config is prepared again.
+func (m *Machine) AllocateStaticAddress(subnetCIDR string, low, high net.IP) (string, error) {
+	_, ipNet, err := net.ParseCIDR(subnetCIDR)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !ipNet.Contains(low) || !ipNet.Contains(high) {
+		return "", errors.NotValidf("address range %s-%s for subnet %q", low, high, subnetCIDR)
+	}
+	low, high = copyIP(low), copyIP(high)
+
+	var allocated string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life != Alive {
+			return nil, errors.Errorf("machine %q is not alive", m.Id())
+		}
+		existing, err := m.staticAddress(subnetCIDR)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing != "" {
+			allocated = existing
+			return nil, jujutxn.ErrNoOperations
+		}
+		used, err := m.st.usedSubnetAddresses(subnetCIDR)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		allocated = ""
+		for ip := low; ip != nil && bytes.Compare(ip, high) <= 0; ip = nextIP(ip) {
+			if !used.Contains(ip.String()) {
+				allocated = ip.String()
+				break
+			}
+		}
+		if allocated == "" {
+			return nil, errors.Errorf("no addresses available in range %s-%s", low, high)
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      staticAddressesC,
+			Id:     m.st.docID(allocated),
+			Assert: txn.DocMissing,
+			Insert: &staticAddressDoc{
+				Address:    allocated,
+				SubnetCIDR: subnetCIDR,
+				MachineID:  m.Id(),
+			},
+		}}, nil
+	}
+	if err := m.st.run(buildTxn); err != nil {
+		return "", errors.Annotatef(err, "cannot allocate static address in %q for machine %q", subnetCIDR, m.Id())
+	}
+	return allocated, nil
+}
+
+// ReleaseStaticAddresses releases all the addresses reserved for the
+// machine by AllocateStaticAddress.
+func (m *Machine) ReleaseStaticAddresses() error {
+	ops, err := m.removeStaticAddressesOps()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.st.runTransaction(ops)
+}
+
+func (m *Machine) staticAddress(subnetCIDR string) (string, error) {
+	coll, closer := m.st.getCollection(staticAddressesC)
+	defer closer()
+
+	var doc staticAddressDoc
+	err := coll.Find(bson.D{
+		{"machine-id", m.Id()},
+		{"subnet-cidr", subnetCIDR},
+	}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return doc.Address, nil
+}
+
+func (m *Machine) removeStaticAddressesOps() ([]txn.Op, error) {
+	coll, closer := m.st.getCollection(staticAddressesC)
+	defer closer()
+
+	var docs []staticAddressDoc
+	if err := coll.Find(bson.D{{"machine-id", m.Id()}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      staticAddressesC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// usedSubnetAddresses returns the addresses in the subnet that are
+// either reserved or assigned to a machine's network device.
+func (st *State) usedSubnetAddresses(subnetCIDR string) (set.Strings, error) {
+	used := set.NewStrings()
+
+	staticAddresses, closer := st.getCollection(staticAddressesC)
+	defer closer()
+	var staticDocs []staticAddressDoc
+	if err := staticAddresses.Find(bson.D{{"subnet-cidr", subnetCIDR}}).All(&staticDocs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, doc := range staticDocs {
+		used.Add(doc.Address)
+	}
+
+	err := st.forEachIPAddressDoc(bson.D{{"subnet-cidr", subnetCIDR}}, func(doc *ipAddressDoc) {
+		used.Add(doc.Value)
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return used, nil
+}
+
+// copyIP returns a copy of the given IP, in its 4-byte form if it is
+// an IPv4 address, so that IPv4 addresses compare correctly.
+func copyIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	result := make(net.IP, len(ip))
+	copy(result, ip)
+	return result
+}
+
+// nextIP returns the address following ip, or nil if ip is the last
+// address of its family.
+func nextIP(ip net.IP) net.IP {
+	next := copyIP(ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"net"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type staticAddressesSuite struct {
+	ConnSuite
+
+	host *state.Machine
+}
+
+var _ = gc.Suite(&staticAddressesSuite{})
+
+func (s *staticAddressesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	var err error
+	s.host, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *staticAddressesSuite) addContainer(c *gc.C) *state.Machine {
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	return container
+}
+
+func (s *staticAddressesSuite) allocate(c *gc.C, m *state.Machine) (string, error) {
+	return m.AllocateStaticAddress("10.0.0.0/24", net.ParseIP("10.0.0.10"), net.ParseIP("10.0.0.11"))
+}
+
+func (s *staticAddressesSuite) TestAllocateStaticAddress(c *gc.C) {
+	first := s.addContainer(c)
+	second := s.addContainer(c)
+
+	address, err := s.allocate(c, first)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "10.0.0.10")
+
+	address, err = s.allocate(c, second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "10.0.0.11")
+}
+
+func (s *staticAddressesSuite) TestAllocateStaticAddressIsIdempotent(c *gc.C) {
+	container := s.addContainer(c)
+
+	address, err := s.allocate(c, container)
+	c.Assert(err, jc.ErrorIsNil)
+	again, err := s.allocate(c, container)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(again, gc.Equals, address)
+}
+
+func (s *staticAddressesSuite) TestAllocateStaticAddressSkipsDeviceAddresses(c *gc.C) {
+	err := s.host.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.host.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  "10.0.0.10/24",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	address, err := s.allocate(c, s.addContainer(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "10.0.0.11")
+}
+
+func (s *staticAddressesSuite) TestAllocateStaticAddressRangeExhausted(c *gc.C) {
+	for i := 0; i < 2; i++ {
+		_, err := s.allocate(c, s.addContainer(c))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	container := s.addContainer(c)
+	_, err := s.allocate(c, container)
+	c.Assert(err, gc.ErrorMatches, `cannot allocate static address in "10.0.0.0/24" for machine "0/lxd/2": no addresses available in range 10.0.0.10-10.0.0.11`)
+}
+
+func (s *staticAddressesSuite) TestAllocateStaticAddressRangeOutsideSubnet(c *gc.C) {
+	_, err := s.addContainer(c).AllocateStaticAddress(
+		"10.0.0.0/24", net.ParseIP("10.0.1.10"), net.ParseIP("10.0.1.11"),
+	)
+	c.Assert(err, gc.ErrorMatches, `address range 10.0.1.10-10.0.1.11 for subnet "10.0.0.0/24" not valid`)
+}
+
+func (s *staticAddressesSuite) TestReleaseStaticAddresses(c *gc.C) {
+	first := s.addContainer(c)
+	address, err := s.allocate(c, first)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = first.ReleaseStaticAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+
+	reused, err := s.allocate(c, s.addContainer(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reused, gc.Equals, address)
+}
+
+func (s *staticAddressesSuite) TestRemoveMachineReleasesStaticAddresses(c *gc.C) {
+	first := s.addContainer(c)
+	address, err := s.allocate(c, first)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = first.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = first.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	reused, err := s.allocate(c, s.addContainer(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reused, gc.Equals, address)
+}
@@ -4,10 +4,13 @@
 package state
 
 import (
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -189,6 +192,23 @@ func probablyUpdateStatusHistory(st *State, globalKey string, doc statusDoc) {
 	}
 }
 
+// addStatusEvent writes an entry to the status history of the entity
+// with the given global key. Unlike probablyUpdateStatusHistory, it
+// reports failure, since the entry is the whole point of the call.
+func addStatusEvent(st *State, globalKey string, doc statusDoc) error {
+	history, closer := st.getCollection(statusesHistoryC)
+	defer closer()
+	historyW := history.Writeable()
+	err := historyW.Insert(&historicalStatusDoc{
+		Status:     doc.Status,
+		StatusInfo: doc.StatusInfo,
+		StatusData: doc.StatusData,
+		Updated:    doc.Updated,
+		GlobalKey:  globalKey,
+	})
+	return errors.Annotate(err, "cannot add status event")
+}
+
 // statusHistoryArgs hold the arguments to call statusHistory.
 type statusHistoryArgs struct {
 	st        *State
@@ -242,6 +262,158 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	return results, nil
 }
 
+// maxModelStatusHistorySize is the largest number of entries that
+// ModelStatusHistory returns, however many the filter asks for.
+var maxModelStatusHistorySize = 10000
+
+// ModelStatusHistoryFilter holds arguments used to filter the
+// status history of all the machines and units in a model.
+type ModelStatusHistoryFilter struct {
+	// From and To, if set, restrict the history to entries
+	// recorded at or after, and at or before, the given times.
+	From *time.Time
+	To   *time.Time
+
+	// Entities, if not empty, restricts the history to the given
+	// machines and units, and to the units of the given applications.
+	Entities []names.Tag
+
+	// Size, if positive, restricts the history to the given
+	// number of most recent entries. No more than 10000 entries
+	// are ever returned.
+	Size int
+}
+
+// EntityStatusInfo holds a status history entry for a machine or unit.
+type EntityStatusInfo struct {
+	status.StatusInfo
+
+	// Tag identifies the machine or unit.
+	Tag names.Tag
+
+	// Kind identifies which of the entity's statuses the
+	// entry records.
+	Kind status.HistoryKind
+}
+
+// ModelStatusHistory returns the recorded status history of the
+// machines and units in the model that match the filter, oldest
+// first.
+func (st *State) ModelStatusHistory(filter ModelStatusHistoryFilter) ([]EntityStatusInfo, error) {
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, errors.NotValidf("time range ending before it starts")
+	}
+	var keys []bson.M
+	for _, tag := range filter.Entities {
+		switch tag := tag.(type) {
+		case names.MachineTag:
+			keys = append(keys, bson.M{"globalkey": bson.M{"$in": []string{
+				machineGlobalKey(tag.Id()),
+				machineGlobalInstanceKey(tag.Id()),
+			}}})
+		case names.UnitTag:
+			keys = append(keys, bson.M{"globalkey": bson.M{"$in": []string{
+				unitAgentGlobalKey(tag.Id()),
+				unitGlobalKey(tag.Id()),
+			}}})
+		case names.ApplicationTag:
+			keys = append(keys, bson.M{"globalkey": bson.M{
+				"$regex": "^" + regexp.QuoteMeta(unitAgentGlobalKey(tag.Id()+"/")),
+			}})
+		default:
+			return nil, errors.NotValidf("status history filter entity %q", tag)
+		}
+	}
+	query := bson.M{}
+	if len(keys) > 0 {
+		query["$or"] = keys
+	} else {
+		query["globalkey"] = bson.M{"$regex": "^[mu]#"}
+	}
+	if filter.From != nil || filter.To != nil {
+		updated := bson.M{}
+		if filter.From != nil {
+			updated["$gte"] = filter.From.UnixNano()
+		}
+		if filter.To != nil {
+			updated["$lte"] = filter.To.UnixNano()
+		}
+		query["updated"] = updated
+	}
+
+	statusHistory, closer := st.getCollection(statusesHistoryC)
+	defer closer()
+	size := filter.Size
+	if size <= 0 || size > maxModelStatusHistorySize {
+		size = maxModelStatusHistorySize
+	}
+	q := statusHistory.Find(query).Sort("-updated").Limit(size)
+	var docs []historicalStatusDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get status history")
+	}
+
+	results := make([]EntityStatusInfo, 0, len(docs))
+	for i := len(docs) - 1; i >= 0; i-- {
+		doc := docs[i]
+		tag, kind, ok := statusHistoryEntity(doc.GlobalKey)
+		if !ok {
+			continue
+		}
+		results = append(results, EntityStatusInfo{
+			StatusInfo: status.StatusInfo{
+				Status:  doc.Status,
+				Message: doc.StatusInfo,
+				Data:    utils.UnescapeKeys(doc.StatusData),
+				Since:   unixNanoToTime(doc.Updated),
+			},
+			Tag:  tag,
+			Kind: kind,
+		})
+	}
+	return results, nil
+}
+
+// statusHistoryEntity returns the machine or unit, and the kind of
+// status, recorded under the given status history key. It returns
+// false if the key does not record the status of a machine or unit.
+func statusHistoryEntity(key string) (names.Tag, status.HistoryKind, bool) {
+	switch {
+	case strings.HasPrefix(key, "m#"):
+		id := strings.TrimPrefix(key, "m#")
+		instance := strings.HasSuffix(id, "#instance")
+		id = strings.TrimSuffix(id, "#instance")
+		if !names.IsValidMachine(id) {
+			return nil, "", false
+		}
+		container := strings.Contains(id, "/")
+		var kind status.HistoryKind
+		switch {
+		case instance && container:
+			kind = status.KindContainerInstance
+		case instance:
+			kind = status.KindMachineInstance
+		case container:
+			kind = status.KindContainer
+		default:
+			kind = status.KindMachine
+		}
+		return names.NewMachineTag(id), kind, true
+	case strings.HasPrefix(key, "u#"):
+		name := strings.TrimPrefix(key, "u#")
+		kind := status.KindUnitAgent
+		if strings.HasSuffix(name, "#charm") {
+			name = strings.TrimSuffix(name, "#charm")
+			kind = status.KindWorkload
+		}
+		if !names.IsValidUnit(name) {
+			return nil, "", false
+		}
+		return names.NewUnitTag(name), kind, true
+	}
+	return nil, "", false
+}
+
 // PruneStatusHistory removes status history entries until
 // only logs newer than <maxLogTime> remain and also ensures
 // that the collection is smaller than <maxLogsMB> after the
@@ -4,11 +4,13 @@
 package state_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -228,3 +230,103 @@ func (s *StatusHistorySuite) TestStatusHistoryFiltersByDateAndDelta(c *gc.C) {
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
 	c.Assert(history[2].Message, gc.Equals, "2 days ago")
 }
+
+func (s *StatusHistorySuite) TestModelStatusHistory(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	machine := s.Factory.MakeMachine(c, nil)
+
+	// Use times after the statuses set when the entities were made.
+	base := time.Now().Add(time.Hour)
+	at := func(minutes int) *time.Time {
+		t := base.Add(time.Duration(minutes) * time.Minute)
+		return &t
+	}
+	err := unit0.SetStatus(status.StatusInfo{Status: status.Active, Message: "unit0 ready", Since: at(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit1.Agent().SetStatus(status.StatusInfo{Status: status.Idle, Since: at(2)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetStatus(status.StatusInfo{Status: status.Started, Since: at(3)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit0.SetStatus(status.StatusInfo{Status: status.Blocked, Message: "unit0 blocked", Since: at(4)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.State.ModelStatusHistory(state.ModelStatusHistoryFilter{From: at(0)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 4)
+	c.Check(history[0].Tag, gc.Equals, unit0.Tag())
+	c.Check(history[0].Kind, gc.Equals, status.KindWorkload)
+	c.Check(history[0].Message, gc.Equals, "unit0 ready")
+	c.Check(history[1].Tag, gc.Equals, unit1.Tag())
+	c.Check(history[1].Kind, gc.Equals, status.KindUnitAgent)
+	c.Check(history[2].Tag, gc.Equals, machine.Tag())
+	c.Check(history[2].Kind, gc.Equals, status.KindMachine)
+	c.Check(history[3].Message, gc.Equals, "unit0 blocked")
+
+	// Time range.
+	history, err = s.State.ModelStatusHistory(state.ModelStatusHistoryFilter{From: at(2), To: at(3)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Tag, gc.Equals, unit1.Tag())
+	c.Check(history[1].Tag, gc.Equals, machine.Tag())
+
+	// Entities; an application matches its units.
+	history, err = s.State.ModelStatusHistory(state.ModelStatusHistoryFilter{
+		From:     at(0),
+		Entities: []names.Tag{unit0.Tag()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Message, gc.Equals, "unit0 ready")
+	c.Check(history[1].Message, gc.Equals, "unit0 blocked")
+
+	history, err = s.State.ModelStatusHistory(state.ModelStatusHistoryFilter{
+		From:     at(0),
+		Entities: []names.Tag{application.Tag()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+
+	// Size keeps the most recent entries.
+	history, err = s.State.ModelStatusHistory(state.ModelStatusHistoryFilter{From: at(0), Size: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Tag, gc.Equals, machine.Tag())
+	c.Check(history[1].Message, gc.Equals, "unit0 blocked")
+}
+
+func (s *StatusHistorySuite) TestModelStatusHistoryMaxSize(c *gc.C) {
+	s.PatchValue(state.MaxModelStatusHistorySize, 2)
+	machine := s.Factory.MakeMachine(c, nil)
+	base := time.Now().Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		since := base.Add(time.Duration(i) * time.Minute)
+		err := machine.SetStatus(status.StatusInfo{
+			Status:  status.Started,
+			Message: fmt.Sprint(i),
+			Since:   &since,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	for _, size := range []int{0, 3} {
+		history, err := s.State.ModelStatusHistory(state.ModelStatusHistoryFilter{Size: size})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(history, gc.HasLen, 2)
+		c.Check(history[0].Message, gc.Equals, "1")
+		c.Check(history[1].Message, gc.Equals, "2")
+	}
+}
+
+func (s *StatusHistorySuite) TestModelStatusHistoryInvalidFilter(c *gc.C) {
+	now := time.Now()
+	before := now.Add(-time.Minute)
+	_, err := s.State.ModelStatusHistory(state.ModelStatusHistoryFilter{From: &now, To: &before})
+	c.Assert(err, gc.ErrorMatches, "time range ending before it starts not valid")
+
+	_, err = s.State.ModelStatusHistory(state.ModelStatusHistoryFilter{
+		Entities: []names.Tag{names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")},
+	})
+	c.Assert(err, gc.ErrorMatches, `status history filter entity "model-deadbeef-0bad-400d-8000-4b1d0d06f00d" not valid`)
+}
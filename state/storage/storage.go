@@ -8,6 +8,8 @@ import (
 
 	"gopkg.in/juju/blobstore.v2"
 	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/state/objectstore"
 )
 
 const (
@@ -42,18 +44,19 @@ type Storage interface {
 }
 
 // Storage returns a Storage for the model with the specified UUID.
-func NewStorage(modelUUID string, session *mgo.Session) Storage {
-	return stateStorage{modelUUID, session}
+func NewStorage(modelUUID string, session *mgo.Session, stores *objectstore.Stores) Storage {
+	return stateStorage{modelUUID, session, stores}
 }
 
 type stateStorage struct {
 	modelUUID string
 	session   *mgo.Session
+	stores    *objectstore.Stores
 }
 
 func (s stateStorage) blobstore() (*mgo.Session, blobstore.ManagedStorage) {
 	session := s.session.Copy()
-	rs := blobstore.NewGridFS(blobstoreDB, blobstoreDB, session)
+	rs := s.stores.ResourceStorage(blobstoreDB, session)
 	db := session.DB(metadataDB)
 	return session, blobstore.NewManagedStorage(db, rs)
 }
@@ -10,9 +10,12 @@ import (
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/blobstore.v2"
 
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/objectstore"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testing"
 )
@@ -45,7 +48,10 @@ func (s *StorageSuite) SetUpTest(c *gc.C) {
 	rs := blobstore.NewGridFS("blobstore", "blobstore", s.Session)
 	db := s.Session.DB("juju")
 	s.managedStorage = blobstore.NewManagedStorage(db, rs)
-	s.storage = storage.NewStorage(testUUID, s.Session)
+	stores := objectstore.NewStores(func() (controller.Config, error) {
+		return controller.Config{}, nil
+	}, clock.WallClock)
+	s.storage = storage.NewStorage(testUUID, s.Session, stores)
 }
 
 func (s *StorageSuite) TearDownTest(c *gc.C) {
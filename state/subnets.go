@@ -7,6 +7,7 @@ import (
 	"net"
 
 	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -52,6 +53,7 @@ type subnetDoc struct {
 	// IsPublic to migration import/export.
 	IsPublic  bool   `bson:"is-public,omitempty"`
 	SpaceName string `bson:"space-name,omitempty"`
+	TxnRevno  int64  `bson:"txn-revno"`
 }
 
 // Life returns whether the subnet is Alive, Dying or Dead.
@@ -127,6 +129,62 @@ func (s *Subnet) Remove() (err error) {
 	return onAbort(txnErr, errors.New("not found or not dead"))
 }
 
+// RemoveIfUnused removes the subnet, whatever its life, as long as no
+// link-layer device in the model has an address in it. An error
+// satisfying IsNotFound is never returned; removing a subnet that is
+// already gone succeeds.
+func (s *Subnet) RemoveIfUnused() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove subnet %q", s)
+
+	subnets, closer := s.st.getCollection(subnetsC)
+	defer closer()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var doc subnetDoc
+		err := subnets.FindId(s.doc.DocID).One(&doc)
+		if err == mgo.ErrNotFound {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		inUse, err := s.HasAddresses()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if inUse {
+			return nil, errors.New("subnet is in use")
+		}
+		// Adding an address to the subnet touches the subnet doc (see
+		// maybeAssertSubnetAliveOps), so asserting the revno catches
+		// addresses added after the count above.
+		ops := []txn.Op{{
+			C:      subnetsC,
+			Id:     doc.DocID,
+			Remove: true,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+		}}
+		if doc.ProviderId != "" {
+			op := s.st.networkEntityGlobalKeyRemoveOp("subnet", network.Id(doc.ProviderId))
+			ops = append(ops, op)
+		}
+		return ops, nil
+	}
+	return s.st.run(buildTxn)
+}
+
+// HasAddresses reports whether any link-layer device in the model
+// has an address in the subnet.
+func (s *Subnet) HasAddresses() (bool, error) {
+	addresses, closer := s.st.getCollection(ipAddressesC)
+	defer closer()
+
+	count, err := addresses.Find(bson.D{{"subnet-cidr", s.doc.CIDR}}).Count()
+	if err != nil {
+		return false, errors.Annotatef(err, "cannot count addresses in subnet %q", s)
+	}
+	return count > 0, nil
+}
+
 // ProviderId returns the provider-specific id of the subnet.
 func (s *Subnet) ProviderId() network.Id {
 	return network.Id(s.doc.ProviderId)
@@ -0,0 +1,257 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// DefaultTxnPruneMinAge is how old a completed transaction must
+	// be before it is considered for pruning.
+	DefaultTxnPruneMinAge = time.Hour
+
+	// These are the mgo/txn states of completed transactions.
+	txnStateAborted = 5
+	txnStateApplied = 6
+)
+
+// TxnPruneProgress describes how far an incremental transaction prune
+// has got.
+type TxnPruneProgress struct {
+	// Started is when the prune began.
+	Started time.Time
+
+	// Scanned is the number of documents whose transaction queues
+	// have been read.
+	Scanned int
+
+	// Removed is the number of transactions removed so far.
+	Removed int
+
+	// Backlog is the number of completed transactions that are
+	// still to be examined for removal. It is only known once all
+	// the transaction queues have been read.
+	Backlog int
+}
+
+// TxnPruneParams configures an incremental transaction prune.
+type TxnPruneParams struct {
+	// Clock is used to pause between batches.
+	Clock clock.Clock
+
+	// BatchSize is the number of documents read, or transactions
+	// removed, between pauses.
+	BatchSize int
+
+	// BatchPause is how long to pause between batches.
+	BatchPause time.Duration
+
+	// MinAge is how old a transaction must be to be pruned; it
+	// stops transactions that are still being applied while the
+	// queues are read from being mistaken for unreferenced ones.
+	MinAge time.Duration
+
+	// Stop, when closed, causes the prune to return early.
+	Stop <-chan struct{}
+
+	// Progress, if not nil, is called after every batch.
+	Progress func(TxnPruneProgress)
+}
+
+// Validate returns an error if the params cannot drive a prune.
+func (p TxnPruneParams) Validate() error {
+	if p.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if p.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if p.BatchPause < 0 {
+		return errors.NotValidf("negative BatchPause")
+	}
+	if p.MinAge < 0 {
+		return errors.NotValidf("negative MinAge")
+	}
+	return nil
+}
+
+// errTxnPruneStopped is used internally to unwind a prune whose Stop
+// channel has been closed.
+var errTxnPruneStopped = errors.New("transaction prune stopped")
+
+// PruneTransactions incrementally removes the data for completed
+// transactions that are no longer referenced by any document. Unlike
+// a one-shot prune, the work is split into batches separated by
+// pauses, so that a large transaction collection does not hold up
+// other database users; progress is reported after each batch. It
+// returns nil without finishing if params.Stop is closed.
+func (st *State) PruneTransactions(params TxnPruneParams) error {
+	if err := params.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	session := st.session.Copy()
+	defer session.Close()
+	db := session.DB(jujuDB)
+
+	p := &txnPrune{
+		params: params,
+		db:     db,
+		cutoff: bson.NewObjectIdWithTime(params.Clock.Now().Add(-params.MinAge)),
+		progress: TxnPruneProgress{
+			Started: params.Clock.Now(),
+		},
+	}
+	err := p.run()
+	if errors.Cause(err) == errTxnPruneStopped {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+type txnPrune struct {
+	params   TxnPruneParams
+	db       *mgo.Database
+	cutoff   bson.ObjectId
+	progress TxnPruneProgress
+}
+
+func (p *txnPrune) run() error {
+	referenced, err := p.referencedTxns()
+	if err != nil {
+		return errors.Annotate(err, "reading transaction queues")
+	}
+	completed := p.completedTxns()
+	backlog, err := completed.Count()
+	if err != nil {
+		return errors.Annotate(err, "counting completed transactions")
+	}
+	p.progress.Backlog = backlog
+	p.report()
+	if err := p.removeUnreferenced(completed, referenced); err != nil {
+		return errors.Annotate(err, "removing transactions")
+	}
+	p.progress.Backlog = 0
+	p.report()
+	return nil
+}
+
+// referencedTxns reads the txn-queue of every document that has one,
+// and returns the ids of the transactions older than the cutoff that
+// they refer to.
+func (p *txnPrune) referencedTxns() (map[bson.ObjectId]bool, error) {
+	names, err := p.db.CollectionNames()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	referenced := make(map[bson.ObjectId]bool)
+	for _, name := range names {
+		if name == txnsC || name == txnLogC || strings.HasPrefix(name, "system.") {
+			continue
+		}
+		iter := p.db.C(name).Find(bson.D{{"txn-queue.0", bson.D{{"$exists", true}}}}).
+			Select(bson.D{{"txn-queue", 1}}).
+			Batch(p.params.BatchSize).
+			Iter()
+		var doc struct {
+			Queue []string `bson:"txn-queue"`
+		}
+		for iter.Next(&doc) {
+			for _, token := range doc.Queue {
+				// Tokens are "<transaction id hex>_<nonce>".
+				if len(token) < 24 || !bson.IsObjectIdHex(token[:24]) {
+					continue
+				}
+				if id := bson.ObjectIdHex(token[:24]); id < p.cutoff {
+					referenced[id] = true
+				}
+			}
+			p.progress.Scanned++
+			if p.progress.Scanned%p.params.BatchSize == 0 {
+				p.report()
+				if err := p.pause(); err != nil {
+					iter.Close()
+					return nil, err
+				}
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return nil, errors.Annotatef(err, "reading %q", name)
+		}
+	}
+	return referenced, nil
+}
+
+// completedTxns returns a query matching the ids of all completed
+// transactions older than the cutoff.
+func (p *txnPrune) completedTxns() *mgo.Query {
+	return p.db.C(txnsC).Find(bson.D{
+		{"_id", bson.D{{"$lt", p.cutoff}}},
+		{"s", bson.D{{"$in", []int{txnStateAborted, txnStateApplied}}}},
+	}).Select(bson.D{{"_id", 1}})
+}
+
+// removeUnreferenced removes, a batch at a time, the transactions
+// matched by completed that are not in referenced.
+func (p *txnPrune) removeUnreferenced(completed *mgo.Query, referenced map[bson.ObjectId]bool) error {
+	txns := p.db.C(txnsC)
+	iter := completed.Batch(p.params.BatchSize).Iter()
+	var doc struct {
+		Id bson.ObjectId `bson:"_id"`
+	}
+	var batch []bson.ObjectId
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		info, err := txns.RemoveAll(bson.D{{"_id", bson.D{{"$in", batch}}}})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		p.progress.Removed += info.Removed
+		batch = batch[:0]
+		p.report()
+		return p.pause()
+	}
+	for iter.Next(&doc) {
+		if p.progress.Backlog > 0 {
+			p.progress.Backlog--
+		}
+		if referenced[doc.Id] {
+			continue
+		}
+		batch = append(batch, doc.Id)
+		if len(batch) >= p.params.BatchSize {
+			if err := flush(); err != nil {
+				iter.Close()
+				return err
+			}
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return flush()
+}
+
+func (p *txnPrune) report() {
+	if p.params.Progress != nil {
+		p.params.Progress(p.progress)
+	}
+}
+
+func (p *txnPrune) pause() error {
+	select {
+	case <-p.params.Stop:
+		return errTxnPruneStopped
+	case <-p.params.Clock.After(p.params.BatchPause):
+		return nil
+	}
+}
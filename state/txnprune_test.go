@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type TxnPruneSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&TxnPruneSuite{})
+
+// offsetClock is a wall clock that reports a shifted time.
+type offsetClock struct {
+	clock.Clock
+	offset time.Duration
+}
+
+func (c offsetClock) Now() time.Time {
+	return c.Clock.Now().Add(c.offset)
+}
+
+// futureClock looks from a minute in the future, so that transactions
+// just run are old enough to be pruned.
+var futureClock = offsetClock{clock.WallClock, time.Minute}
+
+func (s *TxnPruneSuite) txnCount(c *gc.C) int {
+	n, err := s.State.MongoSession().DB("juju").C("txns").Count()
+	c.Assert(err, jc.ErrorIsNil)
+	return n
+}
+
+func (s *TxnPruneSuite) params(clk clock.Clock) state.TxnPruneParams {
+	return state.TxnPruneParams{
+		Clock:      clk,
+		BatchSize:  2,
+		BatchPause: 0,
+		MinAge:     0,
+		Stop:       make(chan struct{}),
+	}
+}
+
+func (s *TxnPruneSuite) TestPruneRemovesCompletedTxns(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 5; i++ {
+		err := m.SetPassword("a-password-long-enough-to-pass")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	before := s.txnCount(c)
+
+	params := s.params(futureClock)
+	var reports []state.TxnPruneProgress
+	params.Progress = func(p state.TxnPruneProgress) {
+		reports = append(reports, p)
+	}
+	err = s.State.PruneTransactions(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	after := s.txnCount(c)
+	c.Assert(after < before, jc.IsTrue, gc.Commentf("before %d, after %d", before, after))
+	c.Assert(reports, gc.Not(gc.HasLen), 0)
+	last := reports[len(reports)-1]
+	c.Check(last.Started.IsZero(), jc.IsFalse)
+	c.Check(last.Scanned > 0, jc.IsTrue)
+	c.Check(last.Removed, gc.Equals, before-after)
+	c.Check(last.Backlog, gc.Equals, 0)
+
+	// The documents the pruned transactions touched remain usable.
+	err = m.SetPassword("another-password-long-enough")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TxnPruneSuite) TestPruneIgnoresRecentTxns(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	before := s.txnCount(c)
+
+	params := s.params(clock.WallClock)
+	params.MinAge = time.Hour
+	err = s.State.PruneTransactions(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.txnCount(c), gc.Equals, before)
+}
+
+func (s *TxnPruneSuite) TestPruneStops(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	before := s.txnCount(c)
+
+	stop := make(chan struct{})
+	close(stop)
+	params := s.params(futureClock)
+	params.BatchPause = time.Hour
+	params.Stop = stop
+	err = s.State.PruneTransactions(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The prune stopped at its first pause, while still reading
+	// transaction queues, so nothing was removed.
+	c.Assert(s.txnCount(c), gc.Equals, before)
+}
+
+func (s *TxnPruneSuite) TestValidate(c *gc.C) {
+	params := s.params(nil)
+	err := s.State.PruneTransactions(params)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+
+	params = s.params(clock.WallClock)
+	params.BatchSize = 0
+	err = s.State.PruneTransactions(params)
+	c.Assert(err, gc.ErrorMatches, "non-positive BatchSize not valid")
+}
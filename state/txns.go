@@ -75,14 +75,6 @@ func (st *State) ResumeTransactions() error {
 	return runner.ResumeTransactions()
 }
 
-// MaybePruneTransactions removes data for completed transactions.
-func (st *State) MaybePruneTransactions() error {
-	runner, closer := st.database.TransactionRunner()
-	defer closer()
-	// Prune txns only when txn count has doubled since last prune.
-	return runner.MaybePruneTransactions(2.0)
-}
-
 type multiModelRunner struct {
 	rawRunner jujutxn.Runner
 	schema    collectionSchema
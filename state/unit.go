@@ -63,6 +63,12 @@ const (
 	ResolvedNone       ResolvedMode = ""
 	ResolvedRetryHooks ResolvedMode = "retry-hooks"
 	ResolvedNoHooks    ResolvedMode = "no-hooks"
+
+	// ResolvedSkipOperation and ResolvedFailOperation ask the unit
+	// agent to abandon its pending operation, killing any hook that
+	// is running, and to record it as completed or as failed.
+	ResolvedSkipOperation ResolvedMode = "skip-operation"
+	ResolvedFailOperation ResolvedMode = "fail-operation"
 )
 
 // port identifies a network port number for a particular protocol.
@@ -897,6 +903,22 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 	})
 }
 
+// AddStatusEvent records an event in the unit's workload status
+// history, without changing the unit's status. The message names the
+// event, and data holds any details.
+func (u *Unit) AddStatusEvent(message string, data map[string]interface{}) error {
+	if message == "" {
+		return errors.NotValidf("empty status event message")
+	}
+	now := u.st.clock.Now()
+	return addStatusEvent(u.st, u.globalKey(), statusDoc{
+		Status:     status.Event,
+		StatusInfo: message,
+		StatusData: utils.EscapeKeys(data),
+		Updated:    now.UnixNano(),
+	})
+}
+
 // OpenPortsOnSubnet opens the given port range and protocol for the unit on the
 // given subnet, which can be empty. When non-empty, subnetID must refer to an
 // existing, alive subnet, otherwise an error is returned. Returns an error if
@@ -1185,9 +1207,7 @@ func (u *Unit) WaitAgentPresence(timeout time.Duration) (err error) {
 // SetAgentPresence signals that the agent for unit u is alive.
 // It returns the started pinger.
 func (u *Unit) SetAgentPresence() (*presence.Pinger, error) {
-	presenceCollection := u.st.getPresenceCollection()
-	p := presence.NewPinger(presenceCollection, u.st.ModelTag(), u.globalAgentKey())
-	err := p.Start()
+	p, err := u.st.startAgentPinger(u.globalAgentKey())
 	if err != nil {
 		return nil, err
 	}
@@ -2318,6 +2338,19 @@ func (u *Unit) Resolve(noretryHooks bool) error {
 	return u.SetResolved(mode)
 }
 
+// ResolveOperation asks the unit agent to abandon its pending
+// operation, such as a hook that does not finish, whatever the unit's
+// status. If skip is true, the operation is recorded as completed;
+// otherwise it is recorded as failed, leaving the unit in an error
+// state if the operation was a hook.
+func (u *Unit) ResolveOperation(skip bool) error {
+	mode := ResolvedFailOperation
+	if skip {
+		mode = ResolvedSkipOperation
+	}
+	return u.SetResolved(mode)
+}
+
 // SetResolved marks the unit as having had any previous state transition
 // problems resolved, and informs the unit that it may attempt to
 // reestablish normal workflow. The resolved mode parameter informs
@@ -2326,7 +2359,7 @@ func (u *Unit) Resolve(noretryHooks bool) error {
 func (u *Unit) SetResolved(mode ResolvedMode) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot set resolved mode for unit %q", u)
 	switch mode {
-	case ResolvedRetryHooks, ResolvedNoHooks:
+	case ResolvedRetryHooks, ResolvedNoHooks, ResolvedSkipOperation, ResolvedFailOperation:
 	default:
 		return fmt.Errorf("invalid error resolution mode: %q", mode)
 	}
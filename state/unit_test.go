@@ -943,6 +943,23 @@ func (s *UnitSuite) TestResolve(c *gc.C) {
 	c.Assert(s.unit.Resolved(), gc.Equals, state.ResolvedNoHooks)
 }
 
+func (s *UnitSuite) TestResolveOperation(c *gc.C) {
+	// The unit need not be in an error state.
+	err := s.unit.ResolveOperation(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Resolved(), gc.Equals, state.ResolvedSkipOperation)
+	err = s.unit.ResolveOperation(false)
+	c.Assert(err, gc.ErrorMatches, `cannot set resolved mode for unit "wordpress/0": already resolved`)
+
+	err = s.unit.ClearResolved()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.ResolveOperation(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Resolved(), gc.Equals, state.ResolvedFailOperation)
+}
+
 func (s *UnitSuite) TestGetSetClearResolved(c *gc.C) {
 	mode := s.unit.Resolved()
 	c.Assert(mode, gc.Equals, state.ResolvedNone)
@@ -13,6 +13,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/mongo"
 )
 
 var upgradesLogger = loggo.GetLogger("juju.state.upgrade")
@@ -292,3 +294,82 @@ func extractMigrationAttempt(id interface{}) (int, error) {
 
 	return attempt, nil
 }
+
+// AddCloudRefCounts creates the model and credential refcount docs
+// for clouds that were added before clouds were refcounted.
+func AddCloudRefCounts(st *State) error {
+	clouds, err := st.Clouds()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	refcounts, closer := st.getCollection(controllersC)
+	defer closer()
+	models, closer := st.getCollection(modelsC)
+	defer closer()
+	credentials, closer := st.getCollection(cloudCredentialsC)
+	defer closer()
+
+	var ops []txn.Op
+	for tag := range clouds {
+		cloudName := tag.Id()
+		for key, coll := range map[string]mongo.Collection{
+			cloudModelRefCountKey(cloudName):      models,
+			cloudCredentialRefCountKey(cloudName): credentials,
+		} {
+			if exists, err := nsRefcounts.exists(refcounts, key); err != nil {
+				return errors.Trace(err)
+			} else if exists {
+				continue
+			}
+			count, err := coll.Find(bson.D{{"cloud", cloudName}}).Count()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			ops = append(ops, nsRefcounts.JustCreateOp(controllersC, key, count))
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Trace(st.runTransaction(ops))
+}
+
+// GrantOfferOwnerAccess allows the owner of each application offer's
+// model to consume the offer, if no-one has been granted access to it.
+// Offers made before consumption was restricted to granted users have
+// no access list, and would otherwise be unconsumable.
+func GrantOfferOwnerAccess(st *State) error {
+	offers, closer := st.getCollection(localApplicationDirectoryC)
+	defer closer()
+
+	noAllowedUsers := bson.D{{"$or", []bson.D{
+		{{"allowed-users", bson.D{{"$exists", false}}}},
+		{{"allowed-users", bson.D{{"$size", 0}}}},
+	}}}
+	var docs []applicationOfferDoc
+	if err := offers.Find(noAllowedUsers).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		model, err := st.GetModel(names.NewModelTag(doc.SourceModelUUID))
+		if errors.IsNotFound(err) {
+			// The offering model has gone; leave the
+			// offer to the controller's administrator.
+			model, err = st.ControllerModel()
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:      localApplicationDirectoryC,
+			Id:     doc.DocID,
+			Assert: noAllowedUsers,
+			Update: bson.D{{"$set", bson.D{{"allowed-users", []string{model.Owner().Id()}}}}},
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Trace(st.runTransaction(ops))
+}
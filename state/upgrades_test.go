@@ -8,9 +8,13 @@ import (
 	"time"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/cloud"
 )
 
 type upgradesSuite struct {
@@ -420,6 +424,82 @@ func (s *upgradesSuite) TestAddMigrationAttempt(c *gc.C) {
 	s.assertUpgradedData(c, AddMigrationAttempt, coll, expected)
 }
 
+func (s *upgradesSuite) TestAddCloudRefCounts(c *gc.C) {
+	tag := names.NewCloudCredentialTag("dummy/test-admin/cred")
+	err := s.state.UpdateCloudCredential(tag, cloud.NewEmptyCredential())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Remove the refcounts, as though the cloud predated them.
+	coll, closer := s.state.getRawCollection(controllersC)
+	defer closer()
+	modelKey := cloudModelRefCountKey("dummy")
+	credentialKey := cloudCredentialRefCountKey("dummy")
+	for _, key := range []string{modelKey, credentialKey} {
+		err := coll.RemoveId(key)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Two rounds to check idempotency.
+	for i := 0; i < 2; i++ {
+		err := AddCloudRefCounts(s.state)
+		c.Assert(err, jc.ErrorIsNil)
+
+		refcounts, closer := s.state.getCollection(controllersC)
+		models, err := nsRefcounts.read(refcounts, modelKey)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(models, gc.Equals, 1)
+		credentials, err := nsRefcounts.read(refcounts, credentialKey)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(credentials, gc.Equals, 1)
+		closer()
+	}
+}
+
+func (s *upgradesSuite) TestGrantOfferOwnerAccess(c *gc.C) {
+	model, err := s.state.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	coll, closer := s.state.getRawCollection(localApplicationDirectoryC)
+	defer closer()
+	err = coll.Insert(
+		applicationOfferDoc{
+			DocID:           "local:/u/me/mysql",
+			URL:             "local:/u/me/mysql",
+			SourceModelUUID: model.UUID(),
+		},
+		applicationOfferDoc{
+			DocID:           "local:/u/me/granted",
+			URL:             "local:/u/me/granted",
+			SourceModelUUID: model.UUID(),
+			AllowedUsers:    []string{"fred"},
+		},
+		applicationOfferDoc{
+			DocID:           "local:/u/me/orphaned",
+			URL:             "local:/u/me/orphaned",
+			SourceModelUUID: utils.MustNewUUID().String(),
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := map[string][]string{
+		"local:/u/me/mysql":    {model.Owner().Id()},
+		"local:/u/me/granted":  {"fred"},
+		"local:/u/me/orphaned": {model.Owner().Id()},
+	}
+	// Two rounds to check idempotency.
+	for i := 0; i < 2; i++ {
+		err := GrantOfferOwnerAccess(s.state)
+		c.Assert(err, jc.ErrorIsNil)
+
+		var docs []applicationOfferDoc
+		err = coll.Find(nil).All(&docs)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(docs, gc.HasLen, 3)
+		for _, doc := range docs {
+			c.Check(doc.AllowedUsers, jc.DeepEquals, expected[doc.DocID], gc.Commentf("offer %s", doc.DocID))
+		}
+	}
+}
+
 func hasIndex(coll *mgo.Collection, key []string) (bool, error) {
 	indexes, err := coll.Indexes()
 	if err != nil {
@@ -46,6 +46,10 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// RequestedSize returns the size, in MiB, that the volume has been
+	// requested to grow to, and true; or false if no resize is pending.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +95,7 @@ type volumeDoc struct {
 	Binding         string        `bson:"binding,omitempty"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+	RequestedSize   uint64        `bson:"requested-size,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -236,6 +241,11 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize > 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -979,11 +989,58 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if size, ok := v.RequestedSize(); ok && info.Size >= size {
+			// The requested resize has been completed.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requested-size", size}},
+				Update: bson.D{{"$unset", bson.D{{"requested-size", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
 }
 
+// ResizeVolume requests that the specified volume be grown to the
+// given size, in MiB. The volume must be alive and provisioned, and
+// the size must be larger than the volume's current size. The storage
+// provisioner responsible for the volume will grow it, and record the
+// new size with SetVolumeInfo.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.NotValidf(
+				"size %dMiB (volume is already %dMiB)",
+				size, info.Size,
+			)
+		}
+		if requested, ok := v.RequestedSize(); ok && requested == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: append(isAliveDoc, bson.DocElem{"info.size", info.Size}),
+			Update: bson.D{{"$set", bson.D{{"requested-size", size}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
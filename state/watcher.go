@@ -231,6 +231,22 @@ func (st *State) WatchModelVolumes() StringsWatcher {
 	return st.watchModelMachinestorage(volumesC)
 }
 
+// WatchVolumeResizes returns a StringsWatcher that notifies of changes
+// to any model-scoped volume, including requests to resize the volume.
+// The watcher's initial event contains all model-scoped volumes.
+func (st *State) WatchVolumeResizes() StringsWatcher {
+	return newcollectionWatcher(st, colWCfg{
+		col: volumesC,
+		filter: func(id interface{}) bool {
+			k, err := st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return !strings.Contains(k, "/")
+		},
+	})
+}
+
 // WatchModelFilesystems returns a StringsWatcher that notifies of changes
 // to the lifecycles of all model-scoped filesystems.
 func (st *State) WatchModelFilesystems() StringsWatcher {
@@ -915,19 +931,31 @@ func (w *RelationScopeWatcher) loop() error {
 
 // relationUnitsWatcher sends notifications of units entering and leaving the
 // scope of a RelationUnit, and changes to the settings of those units known
-// to have entered.
+// to have entered. Changes to the settings of the units' application are
+// reported as changes to every unit known to have entered; each unit's
+// version is the sum of its own settings version and the application's.
 type relationUnitsWatcher struct {
 	commonWatcher
 	sw       *RelationScopeWatcher
 	watching set.Strings
 	updates  chan watcher.Change
 	out      chan params.RelationUnitsChange
+
+	// appSettingsKey, if not empty, identifies the application
+	// settings of the watched units.
+	appSettingsKey string
+	appVersion     int64
+	unitVersions   map[string]int64
 }
 
 // Watch returns a watcher that notifies of changes to conterpart units in
 // the relation.
 func (ru *RelationUnit) Watch() RelationUnitsWatcher {
-	return newRelationUnitsWatcher(ru.st, ru.WatchScope())
+	var appSettingsKey string
+	if eps, err := ru.relation.RelatedEndpoints(ru.endpoint.ApplicationName); err == nil {
+		appSettingsKey = ru.relation.applicationSettingsKey(eps[0].ApplicationName)
+	}
+	return newRelationUnitsWatcher(ru.st, ru.WatchScope(), appSettingsKey)
 }
 
 // WatchUnits returns a watcher that notifies of changes to the units of the
@@ -948,18 +976,25 @@ func (r *Relation) watchUnits(applicationName string, counterpart bool) (Relatio
 	role := ep.Role
 	if counterpart {
 		role = counterpartRole(role)
+		eps, err := r.RelatedEndpoints(applicationName)
+		if err != nil {
+			return nil, err
+		}
+		ep = eps[0]
 	}
 	rsw := watchRelationScope(r.st, r.globalScope(), role, "")
-	return newRelationUnitsWatcher(r.st, rsw), nil
+	return newRelationUnitsWatcher(r.st, rsw, r.applicationSettingsKey(ep.ApplicationName)), nil
 }
 
-func newRelationUnitsWatcher(st *State, sw *RelationScopeWatcher) RelationUnitsWatcher {
+func newRelationUnitsWatcher(st *State, sw *RelationScopeWatcher, appSettingsKey string) RelationUnitsWatcher {
 	w := &relationUnitsWatcher{
-		commonWatcher: newCommonWatcher(st),
-		sw:            sw,
-		watching:      make(set.Strings),
-		updates:       make(chan watcher.Change),
-		out:           make(chan params.RelationUnitsChange),
+		commonWatcher:  newCommonWatcher(st),
+		sw:             sw,
+		watching:       make(set.Strings),
+		updates:        make(chan watcher.Change),
+		out:            make(chan params.RelationUnitsChange),
+		appSettingsKey: appSettingsKey,
+		unitVersions:   make(map[string]int64),
 	}
 	go func() {
 		defer w.finish()
@@ -1000,7 +1035,33 @@ func (w *relationUnitsWatcher) mergeSettings(changes *params.RelationUnitsChange
 	if err := readSettingsDocInto(w.st, settingsC, key, &doc); err != nil {
 		return -1, err
 	}
-	setRelationUnitChangeVersion(changes, key, doc.Version)
+	w.unitVersions[unitNameFromScopeKey(key)] = doc.Version
+	setRelationUnitChangeVersion(changes, key, doc.Version+w.appVersion)
+	return doc.TxnRevno, nil
+}
+
+// mergeAppSettings reads the version of the application settings node,
+// and sets a value in the Changed field for every unit known to have
+// entered. It returns the mgo/txn revision number of the settings node,
+// or -1 if the application has not written any settings.
+func (w *relationUnitsWatcher) mergeAppSettings(changes *params.RelationUnitsChange) (int64, error) {
+	var doc struct {
+		TxnRevno int64 `bson:"txn-revno"`
+		Version  int64 `bson:"version"`
+	}
+	err := readSettingsDocInto(w.st, settingsC, w.appSettingsKey, &doc)
+	if errors.IsNotFound(err) {
+		doc.TxnRevno = -1
+	} else if err != nil {
+		return -1, err
+	}
+	if doc.Version == w.appVersion {
+		return doc.TxnRevno, nil
+	}
+	w.appVersion = doc.Version
+	for name, version := range w.unitVersions {
+		setRelationUnitChangeVersion(changes, name, version+w.appVersion)
+	}
 	return doc.TxnRevno, nil
 }
 
@@ -1026,6 +1087,7 @@ func (w *relationUnitsWatcher) mergeScope(changes *params.RelationUnitsChange, c
 		if changes.Changed != nil {
 			delete(changes.Changed, name)
 		}
+		delete(w.unitVersions, name)
 		w.watcher.Unwatch(settingsC, docID, w.updates)
 		w.watching.Remove(docID)
 	}
@@ -1048,6 +1110,9 @@ func (w *relationUnitsWatcher) finish() {
 	for _, watchedValue := range w.watching.Values() {
 		w.watcher.Unwatch(settingsC, watchedValue, w.updates)
 	}
+	if w.appSettingsKey != "" {
+		w.watcher.Unwatch(settingsC, w.st.docID(w.appSettingsKey), w.updates)
+	}
 	close(w.updates)
 	close(w.out)
 	w.tomb.Done()
@@ -1059,6 +1124,15 @@ func (w *relationUnitsWatcher) loop() (err error) {
 		changes     params.RelationUnitsChange
 		out         chan<- params.RelationUnitsChange
 	)
+	var appDocID string
+	if w.appSettingsKey != "" {
+		appDocID = w.st.docID(w.appSettingsKey)
+		revno, err := w.mergeAppSettings(&changes)
+		if err != nil {
+			return err
+		}
+		w.watcher.Watch(settingsC, appDocID, revno, w.updates)
+	}
 	for {
 		select {
 		case <-w.watcher.Dead():
@@ -1082,6 +1156,15 @@ func (w *relationUnitsWatcher) loop() (err error) {
 			if !ok {
 				logger.Warningf("ignoring bad relation scope id: %#v", c.Id)
 			}
+			if id == appDocID {
+				if _, err := w.mergeAppSettings(&changes); err != nil {
+					return err
+				}
+				if !sentInitial || !emptyRelationUnitsChanges(&changes) {
+					out = w.out
+				}
+				continue
+			}
 			if _, err := w.mergeSettings(&changes, id); err != nil {
 				return err
 			}
@@ -1369,6 +1452,12 @@ func (st *State) WatchForModelConfigChanges() NotifyWatcher {
 	return newEntityWatcher(st, settingsC, st.docID(modelGlobalKey))
 }
 
+// WatchControllerConfig returns a NotifyWatcher waiting for the
+// controller config to change.
+func (st *State) WatchControllerConfig() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, controllerSettingsGlobalKey)
+}
+
 // WatchForUnitAssignment watches for new services that request units to be
 // assigned to machines.
 func (st *State) WatchForUnitAssignment() StringsWatcher {
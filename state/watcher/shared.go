@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/worker"
+)
+
+// BaseWatcher holds the methods of a Watcher that a Client needs in
+// order to multiplex its registrations onto it.
+type BaseWatcher interface {
+	Dead() <-chan struct{}
+	Err() error
+	StartSync()
+	Watch(collection string, id interface{}, revno int64, ch chan<- Change)
+	Unwatch(collection string, id interface{}, ch chan<- Change)
+	WatchCollectionWithFilter(collection string, ch chan<- Change, filter func(interface{}) bool)
+	UnwatchCollection(collection string, ch chan<- Change)
+}
+
+// Client shares a single underlying Watcher with any number of other
+// clients, so that one changelog poll can feed every watch made
+// through them. Each client keeps track of the registrations made
+// through it, and removes them from the underlying watcher when it
+// is killed; a client dies when its underlying watcher does.
+type Client struct {
+	tomb tomb.Tomb
+	base BaseWatcher
+
+	// mu protects watches and stopped.
+	mu      sync.Mutex
+	watches map[clientWatch]bool
+	stopped bool
+}
+
+type clientWatch struct {
+	key watchKey
+	ch  chan<- Change
+}
+
+// NewClient returns a new Client that registers its watches with the
+// supplied base watcher.
+func NewClient(base BaseWatcher) *Client {
+	c := &Client{
+		base:    base,
+		watches: make(map[clientWatch]bool),
+	}
+	go func() {
+		defer c.tomb.Done()
+		c.tomb.Kill(c.loop())
+	}()
+	return c
+}
+
+func (c *Client) loop() error {
+	select {
+	case <-c.tomb.Dying():
+		c.unwatchAll()
+		return tomb.ErrDying
+	case <-c.base.Dead():
+		c.mu.Lock()
+		c.stopped = true
+		c.mu.Unlock()
+		if err := c.base.Err(); err != nil {
+			return errors.Annotate(err, "shared watcher failed")
+		}
+		return errors.New("shared watcher stopped")
+	}
+}
+
+// unwatchAll removes every registration made through the client from
+// the base watcher, and prevents any more from being made.
+func (c *Client) unwatchAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	for w := range c.watches {
+		if w.key.id == nil {
+			c.base.UnwatchCollection(w.key.c, w.ch)
+		} else {
+			c.base.Unwatch(w.key.c, w.key.id, w.ch)
+		}
+	}
+	c.watches = nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (c *Client) Kill() {
+	c.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (c *Client) Wait() error {
+	return c.tomb.Wait()
+}
+
+// Stop stops the client, removing all its registrations from the
+// underlying watcher.
+func (c *Client) Stop() error {
+	return worker.Stop(c)
+}
+
+// Dead returns a channel that is closed when the client has stopped.
+func (c *Client) Dead() <-chan struct{} {
+	return c.tomb.Dead()
+}
+
+// Err returns the error with which the client stopped, or
+// tomb.ErrStillAlive if it is still running.
+func (c *Client) Err() error {
+	return c.tomb.Err()
+}
+
+// StartSync forces the underlying watcher to load new events from
+// the database.
+func (c *Client) StartSync() {
+	c.base.StartSync()
+}
+
+// Watch is the Client equivalent of Watcher.Watch.
+func (c *Client) Watch(collection string, id interface{}, revno int64, ch chan<- Change) {
+	if id == nil {
+		panic("watcher: cannot watch a document with nil id")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return
+	}
+	c.watches[clientWatch{watchKey{collection, id}, ch}] = true
+	c.base.Watch(collection, id, revno, ch)
+}
+
+// Unwatch is the Client equivalent of Watcher.Unwatch.
+func (c *Client) Unwatch(collection string, id interface{}, ch chan<- Change) {
+	if id == nil {
+		panic("watcher: cannot unwatch a document with nil id")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return
+	}
+	delete(c.watches, clientWatch{watchKey{collection, id}, ch})
+	c.base.Unwatch(collection, id, ch)
+}
+
+// WatchCollection is the Client equivalent of Watcher.WatchCollection.
+func (c *Client) WatchCollection(collection string, ch chan<- Change) {
+	c.WatchCollectionWithFilter(collection, ch, nil)
+}
+
+// WatchCollectionWithFilter is the Client equivalent of
+// Watcher.WatchCollectionWithFilter.
+func (c *Client) WatchCollectionWithFilter(collection string, ch chan<- Change, filter func(interface{}) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return
+	}
+	c.watches[clientWatch{watchKey{collection, nil}, ch}] = true
+	c.base.WatchCollectionWithFilter(collection, ch, filter)
+}
+
+// UnwatchCollection is the Client equivalent of
+// Watcher.UnwatchCollection.
+func (c *Client) UnwatchCollection(collection string, ch chan<- Change) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return
+	}
+	delete(c.watches, clientWatch{watchKey{collection, nil}, ch})
+	c.base.UnwatchCollection(collection, ch)
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state/watcher"
+)
+
+func (s *FastPeriodSuite) TestClientsShareWatcher(c *gc.C) {
+	client1 := watcher.NewClient(s.w)
+	defer client1.Stop()
+	client2 := watcher.NewClient(s.w)
+	defer client2.Stop()
+
+	ch1 := make(chan watcher.Change)
+	ch2 := make(chan watcher.Change)
+	client1.Watch("test", "a", -1, ch1)
+	client2.WatchCollection("test", ch2)
+
+	revno := s.insert(c, "test", "a")
+	client1.StartSync()
+	assertChange(c, ch1, watcher.Change{"test", "a", revno})
+	assertChange(c, ch2, watcher.Change{"test", "a", revno})
+	assertNoChange(c, ch1)
+	assertNoChange(c, ch2)
+}
+
+func (s *FastPeriodSuite) TestClientStopRemovesWatches(c *gc.C) {
+	client1 := watcher.NewClient(s.w)
+	client2 := watcher.NewClient(s.w)
+	defer client2.Stop()
+
+	ch1 := make(chan watcher.Change)
+	ch2 := make(chan watcher.Change)
+	client1.Watch("test", "a", -1, ch1)
+	client1.WatchCollection("test", ch1)
+	client2.Watch("test", "a", -1, ch2)
+
+	c.Assert(client1.Stop(), jc.ErrorIsNil)
+	c.Assert(client1.Err(), jc.ErrorIsNil)
+
+	// Unwatching through a stopped client is harmless.
+	client1.Unwatch("test", "a", ch1)
+	client1.UnwatchCollection("test", ch1)
+
+	revno := s.insert(c, "test", "a")
+	s.w.StartSync()
+	assertChange(c, ch2, watcher.Change{"test", "a", revno})
+	assertNoChange(c, ch1)
+	c.Assert(s.w.Err(), gc.Equals, tomb.ErrStillAlive)
+}
+
+func (s *FastPeriodSuite) TestClientUnwatch(c *gc.C) {
+	client := watcher.NewClient(s.w)
+	defer client.Stop()
+
+	client.Watch("test", "a", -1, s.ch)
+	client.Unwatch("test", "a", s.ch)
+	s.insert(c, "test", "a")
+	client.StartSync()
+	assertNoChange(c, s.ch)
+
+	// Watching the same channel again must not upset the base watcher.
+	client.Watch("test", "a", -1, s.ch)
+	revno := s.update(c, "test", "a")
+	client.StartSync()
+	assertChange(c, s.ch, watcher.Change{"test", "a", revno})
+}
+
+func (s *FastPeriodSuite) TestClientDiesWithWatcher(c *gc.C) {
+	client := watcher.NewClient(s.w)
+	defer client.Stop()
+	c.Assert(client.Err(), gc.Equals, tomb.ErrStillAlive)
+
+	c.Assert(s.w.Stop(), jc.ErrorIsNil)
+	select {
+	case <-client.Dead():
+	case <-time.After(worstCase):
+		c.Fatalf("client did not die with its watcher")
+	}
+	c.Assert(client.Err(), gc.ErrorMatches, "shared watcher stopped")
+
+	// Further watches are ignored rather than blocking.
+	client.Watch("test", "a", -1, s.ch)
+}
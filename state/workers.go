@@ -21,6 +21,18 @@ type workersFactory struct {
 }
 
 func (wf workersFactory) NewTxnLogWorker() (workers.TxnLogWorker, error) {
+	if parent := wf.st.txnLogParent; parent != nil && parent.workers != nil {
+		base := parent.workers.TxnLogWatcher()
+		select {
+		case <-base.Dead():
+			// The parent's watcher is being replaced, or the parent
+			// has been closed; poll the log ourselves rather than
+			// tying our watchers to a dead one.
+			logger.Debugf("shared txn log watcher unavailable; starting a new one for %s", wf.st.modelTag)
+		default:
+			return watcher.NewClient(base), nil
+		}
+	}
 	coll := wf.st.getTxnLogCollection()
 	worker := watcher.New(coll)
 	return worker, nil
@@ -42,6 +54,7 @@ func (wf workersFactory) NewLeadershipWorker() (workers.LeaseWorker, error) {
 		Client:    client,
 		Clock:     wf.clock,
 		MaxSleep:  time.Minute,
+		Pinned:    wf.st.pinnedLeadership,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
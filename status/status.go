@@ -147,6 +147,11 @@ const (
 	// The unit believes it is correctly offering all the services it has
 	// been asked to offer.
 	Active Status = "active"
+
+	// Event is never set as a status; it marks the entries that charms
+	// add to a unit's workload status history with status-log, to
+	// record milestones without changing the unit's status.
+	Event Status = "event"
 )
 
 const (
@@ -127,6 +127,53 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeSnapshotter may be implemented by a VolumeSource that can take
+// point-in-time snapshots of its volumes.
+type VolumeSnapshotter interface {
+	// SnapshotVolumes takes a snapshot of each of the volumes with
+	// the specified provider volume IDs.
+	SnapshotVolumes(volIds []string) ([]SnapshotVolumesResult, error)
+}
+
+// SnapshotVolumesResult holds the result of a
+// VolumeSnapshotter.SnapshotVolumes call for one volume.
+type SnapshotVolumesResult struct {
+	// SnapshotId is the provider ID of the snapshot.
+	SnapshotId string
+
+	// Error holds the reason the snapshot could not be taken.
+	Error error
+}
+
+// VolumeResizer may be implemented by a VolumeSource that can grow
+// its volumes while they are attached to machines.
+type VolumeResizer interface {
+	// ResizeVolumes grows each of the volumes to the size, in MiB,
+	// specified in the corresponding parameters.
+	ResizeVolumes(params []VolumeResizeParams) ([]error, error)
+}
+
+// VolumeResizeParams holds the parameters for resizing a volume.
+type VolumeResizeParams struct {
+	// Tag is the tag of the volume to resize.
+	Tag names.VolumeTag
+
+	// VolumeId is the provider ID of the volume to resize.
+	VolumeId string
+
+	// Size is the new size of the volume, in MiB.
+	Size uint64
+}
+
+// VolumeReleaser may be implemented by a VolumeSource that can release
+// its volumes: stop managing them, without destroying them, so that they
+// outlive the model and may be attached again later.
+type VolumeReleaser interface {
+	// ReleaseVolumes releases the volumes with the specified provider
+	// volume IDs.
+	ReleaseVolumes(volIds []string) ([]error, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -47,4 +47,9 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the storage in MiB, as seen by the machine
+	// it is attached to. Size is only set for block-kind storage
+	// attachments, and is zero if unknown.
+	Size uint64
 }
@@ -0,0 +1,254 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package delta implements a simple binary delta format used to
+// distribute agent binary upgrades without shipping complete
+// tarballs. A delta describes how to reconstruct a target from a
+// base as a sequence of copies from the base and literal insertions,
+// in the manner of rsync and zsync; it is compressed, and carries
+// the size and SHA-256 hash of the target so that the result of
+// applying it can be verified.
+package delta
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// BlockSize is the size of the base blocks that are matched
+	// against the target when computing a delta.
+	BlockSize = 4096
+
+	// magic identifies a delta stream.
+	magic = "jujudelta1"
+
+	opCopy   = 'c'
+	opInsert = 'i'
+	opEnd    = 'e'
+)
+
+// Diff writes to w a delta that transforms base into target.
+func Diff(w io.Writer, base, target []byte) error {
+	zw := gzip.NewWriter(w)
+	dw := &deltaWriter{w: bufio.NewWriter(zw)}
+
+	dw.writeString(magic)
+	dw.writeUvarint(uint64(len(target)))
+	hash := sha256.Sum256(target)
+	dw.write(hash[:])
+
+	index := indexBlocks(base)
+	var (
+		literalStart int
+		pos          int
+		sum          rollingSum
+	)
+	if len(target) >= BlockSize {
+		sum = newRollingSum(target[:BlockSize])
+	}
+	for pos+BlockSize <= len(target) {
+		if offset, ok := index.match(base, target[pos:pos+BlockSize], sum.digest()); ok {
+			length := BlockSize
+			for offset+length < len(base) && pos+length < len(target) && base[offset+length] == target[pos+length] {
+				length++
+			}
+			dw.writeInsert(target[literalStart:pos])
+			dw.writeCopy(offset, length)
+			pos += length
+			literalStart = pos
+			if pos+BlockSize <= len(target) {
+				sum = newRollingSum(target[pos : pos+BlockSize])
+			}
+			continue
+		}
+		if pos+BlockSize < len(target) {
+			sum.roll(target[pos], target[pos+BlockSize])
+		}
+		pos++
+	}
+	dw.writeInsert(target[literalStart:])
+	dw.writeByte(opEnd)
+
+	if dw.err != nil {
+		return errors.Annotate(dw.err, "writing delta")
+	}
+	if err := dw.w.Flush(); err != nil {
+		return errors.Annotate(err, "writing delta")
+	}
+	return errors.Annotate(zw.Close(), "writing delta")
+}
+
+// Patch applies the delta read from r to base, and returns the
+// reconstructed target. An error is returned if the delta is
+// malformed, or if the result does not match the size and hash
+// recorded in the delta.
+func Patch(base []byte, r io.Reader) ([]byte, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading delta")
+	}
+	defer zr.Close()
+	br := bufio.NewReader(zr)
+
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, header); err != nil || string(header) != magic {
+		return nil, errors.NotValidf("delta header")
+	}
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading delta size")
+	}
+	var expectHash [sha256.Size]byte
+	if _, err := io.ReadFull(br, expectHash[:]); err != nil {
+		return nil, errors.Annotate(err, "reading delta hash")
+	}
+
+	var target bytes.Buffer
+	for {
+		op, err := br.ReadByte()
+		if err != nil {
+			return nil, errors.Annotate(err, "reading delta")
+		}
+		switch op {
+		case opCopy:
+			offset, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, errors.Annotate(err, "reading delta")
+			}
+			length, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, errors.Annotate(err, "reading delta")
+			}
+			if offset > uint64(len(base)) || length > uint64(len(base))-offset {
+				return nil, errors.Errorf("delta copies beyond end of base")
+			}
+			target.Write(base[offset : offset+length])
+		case opInsert:
+			length, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, errors.Annotate(err, "reading delta")
+			}
+			if uint64(target.Len())+length > size {
+				return nil, errors.Errorf("delta inserts beyond end of target")
+			}
+			if _, err := io.CopyN(&target, br, int64(length)); err != nil {
+				return nil, errors.Annotate(err, "reading delta")
+			}
+		case opEnd:
+			if uint64(target.Len()) != size {
+				return nil, errors.Errorf("size mismatch, expected %d, got %d", size, target.Len())
+			}
+			if sha256.Sum256(target.Bytes()) != expectHash {
+				return nil, errors.New("sha256 mismatch")
+			}
+			return target.Bytes(), nil
+		default:
+			return nil, errors.Errorf("unknown delta operation %q", op)
+		}
+		if uint64(target.Len()) > size {
+			return nil, errors.Errorf("delta produces more than %d bytes", size)
+		}
+	}
+}
+
+// deltaWriter writes delta operations, remembering the first
+// error encountered.
+type deltaWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (dw *deltaWriter) write(p []byte) {
+	if dw.err == nil {
+		_, dw.err = dw.w.Write(p)
+	}
+}
+
+func (dw *deltaWriter) writeString(s string) {
+	if dw.err == nil {
+		_, dw.err = dw.w.WriteString(s)
+	}
+}
+
+func (dw *deltaWriter) writeByte(b byte) {
+	if dw.err == nil {
+		dw.err = dw.w.WriteByte(b)
+	}
+}
+
+func (dw *deltaWriter) writeUvarint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	dw.write(buf[:binary.PutUvarint(buf[:], v)])
+}
+
+func (dw *deltaWriter) writeCopy(offset, length int) {
+	dw.writeByte(opCopy)
+	dw.writeUvarint(uint64(offset))
+	dw.writeUvarint(uint64(length))
+}
+
+func (dw *deltaWriter) writeInsert(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	dw.writeByte(opInsert)
+	dw.writeUvarint(uint64(len(data)))
+	dw.write(data)
+}
+
+// blockIndex maps the rolling checksums of the base's blocks
+// to their offsets.
+type blockIndex map[uint32][]int
+
+func indexBlocks(base []byte) blockIndex {
+	index := make(blockIndex)
+	for offset := 0; offset+BlockSize <= len(base); offset += BlockSize {
+		digest := newRollingSum(base[offset : offset+BlockSize]).digest()
+		index[digest] = append(index[digest], offset)
+	}
+	return index
+}
+
+// match returns the offset of a base block with the given
+// checksum and the same content as block.
+func (index blockIndex) match(base, block []byte, digest uint32) (int, bool) {
+	for _, offset := range index[digest] {
+		if bytes.Equal(base[offset:offset+BlockSize], block) {
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+// rollingSum is the weak rolling checksum used by rsync, computed
+// over a window of BlockSize bytes.
+type rollingSum struct {
+	a, b uint32
+}
+
+func newRollingSum(window []byte) rollingSum {
+	var sum rollingSum
+	for i, c := range window {
+		sum.a += uint32(c)
+		sum.b += uint32(len(window)-i) * uint32(c)
+	}
+	return sum
+}
+
+// roll moves the window forward by one byte, removing out and
+// adding in.
+func (sum *rollingSum) roll(out, in byte) {
+	sum.a += uint32(in) - uint32(out)
+	sum.b += sum.a - BlockSize*uint32(out)
+}
+
+func (sum rollingSum) digest() uint32 {
+	return sum.a&0xffff | sum.b<<16
+}
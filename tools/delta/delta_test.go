@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package delta_test
+
+import (
+	"bytes"
+	"math/rand"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/tools/delta"
+)
+
+type deltaSuite struct{}
+
+var _ = gc.Suite(&deltaSuite{})
+
+func randomBytes(r *rand.Rand, n int) []byte {
+	data := make([]byte, n)
+	r.Read(data)
+	return data
+}
+
+func join(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+func (s *deltaSuite) TestRoundTrip(c *gc.C) {
+	r := rand.New(rand.NewSource(1))
+	base := randomBytes(r, 256*delta.BlockSize)
+	for i, test := range []struct {
+		about  string
+		base   []byte
+		target []byte
+	}{{
+		about:  "identical",
+		base:   base,
+		target: base,
+	}, {
+		about:  "empty base",
+		target: base[:1000],
+	}, {
+		about: "empty target",
+		base:  base,
+	}, {
+		about:  "insertion",
+		base:   base,
+		target: join(base[:100000], []byte("inserted"), base[100000:]),
+	}, {
+		about:  "replacement and removal",
+		base:   base,
+		target: join(base[:5000], randomBytes(r, 3000), base[9000:500000], base[600000:]),
+	}, {
+		about:  "reordered",
+		base:   base,
+		target: join(base[700000:], base[:700000]),
+	}, {
+		about:  "shorter than a block",
+		base:   base[:100],
+		target: base[50:150],
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		var buf bytes.Buffer
+		err := delta.Diff(&buf, test.base, test.target)
+		c.Assert(err, jc.ErrorIsNil)
+		target, err := delta.Patch(test.base, &buf)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(target, jc.DeepEquals, test.target)
+	}
+}
+
+func (s *deltaSuite) TestDeltaIsSmall(c *gc.C) {
+	r := rand.New(rand.NewSource(1))
+	base := randomBytes(r, 256*delta.BlockSize)
+	target := join(base[:300000], randomBytes(r, 2000), base[310000:])
+
+	var buf bytes.Buffer
+	err := delta.Diff(&buf, base, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.Len() < 3*delta.BlockSize, jc.IsTrue, gc.Commentf("delta is %d bytes", buf.Len()))
+}
+
+func (s *deltaSuite) TestPatchWrongBase(c *gc.C) {
+	r := rand.New(rand.NewSource(1))
+	base := randomBytes(r, 16*delta.BlockSize)
+	target := join(base[:1000], []byte("changed"), base[2000:])
+
+	var buf bytes.Buffer
+	err := delta.Diff(&buf, base, target)
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherBase := append([]byte{}, base...)
+	otherBase[20000] ^= 0xff
+	_, err = delta.Patch(otherBase, bytes.NewReader(buf.Bytes()))
+	c.Assert(err, gc.ErrorMatches, "sha256 mismatch")
+
+	_, err = delta.Patch(base[:8000], bytes.NewReader(buf.Bytes()))
+	c.Assert(err, gc.ErrorMatches, "delta copies beyond end of base")
+}
+
+func (s *deltaSuite) TestPatchNotDelta(c *gc.C) {
+	_, err := delta.Patch(nil, bytes.NewReader([]byte("not a delta")))
+	c.Assert(err, gc.ErrorMatches, "reading delta: .*")
+}
@@ -19,6 +19,8 @@ type StateBackend interface {
 	RenameAddModelPermission() error
 	DropOldLogIndex() error
 	AddMigrationAttempt() error
+	AddCloudRefCounts() error
+	GrantOfferOwnerAccess() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -65,6 +67,14 @@ func (s stateBackend) AddMigrationAttempt() error {
 	return state.AddMigrationAttempt(s.st)
 }
 
+func (s stateBackend) AddCloudRefCounts() error {
+	return state.AddCloudRefCounts(s.st)
+}
+
+func (s stateBackend) GrantOfferOwnerAccess() error {
+	return state.GrantOfferOwnerAccess(s.st)
+}
+
 type modelShim struct {
 	st *state.State
 	m  *state.Model
@@ -0,0 +1,24 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+// stateStepsFor22 returns upgrade steps for Juju 2.2 that manipulate state directly.
+func stateStepsFor22() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "add cloud model and credential refcounts",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddCloudRefCounts()
+			},
+		},
+		&upgradeStep{
+			description: "grant model owners access to existing application offers",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().GrantOfferOwnerAccess()
+			},
+		},
+	}
+}
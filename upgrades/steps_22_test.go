@@ -0,0 +1,33 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/upgrades"
+)
+
+var v220 = version.MustParse("2.2.0")
+
+type steps22Suite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&steps22Suite{})
+
+func (s *steps22Suite) TestAddCloudRefCounts(c *gc.C) {
+	step := findStateStep(c, v220, "add cloud model and credential refcounts")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
+
+func (s *steps22Suite) TestGrantOfferOwnerAccess(c *gc.C) {
+	step := findStateStep(c, v220, "grant model owners access to existing application offers")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
@@ -714,6 +714,7 @@ func (s *upgradeSuite) TestStateUpgradeOperationsVersions(c *gc.C) {
 	c.Assert(versions, gc.DeepEquals, []string{
 		"2.0.0",
 		"2.1.0",
+		"2.2.0",
 	})
 }
 
@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditconfigupdater
+
+import (
+	"github.com/juju/errors"
+
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a
+// Manifold will depend.
+type ManifoldConfig struct {
+	StateName     string
+	APICallerName string
+
+	// SetAuditingEnabled is passed on to the worker; see Config.
+	SetAuditingEnabled func(bool)
+}
+
+// Manifold returns a dependency manifold that runs an audit config
+// updater worker, using the resource names defined in the supplied
+// config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.StateName, // ...just to force it to run only on the controller.
+			config.APICallerName,
+		},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			var apiCaller base.APICaller
+			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+				return nil, errors.Trace(err)
+			}
+			return New(Config{
+				Facade:             apiagent.NewState(apiCaller),
+				SetAuditingEnabled: config.SetAuditingEnabled,
+			})
+		},
+	}
+}
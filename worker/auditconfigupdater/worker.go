@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditconfigupdater provides a worker that keeps the API
+// server's auditing setting in step with the controller config, so
+// that auditing can be turned on and off without restarting the
+// controller.
+package auditconfigupdater
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
+// Facade exposes the controller config methods needed by the worker.
+type Facade interface {
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() (watcher.NotifyWatcher, error)
+}
+
+// Config holds the dependencies and configuration for a worker.
+type Config struct {
+	// Facade is used to read and watch the controller config.
+	Facade Facade
+
+	// SetAuditingEnabled is called with the controller's
+	// auditing-enabled setting when the worker starts, and again
+	// whenever the controller config changes.
+	SetAuditingEnabled func(bool)
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.SetAuditingEnabled == nil {
+		return errors.NotValidf("nil SetAuditingEnabled")
+	}
+	return nil
+}
+
+// New returns a worker that reports the controller's auditing setting
+// to config.SetAuditingEnabled every time the controller config changes.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &updater{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// updater implements watcher.NotifyHandler.
+type updater struct {
+	config Config
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (u *updater) SetUp() (watcher.NotifyWatcher, error) {
+	return u.config.Facade.WatchControllerConfig()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (u *updater) Handle(_ <-chan struct{}) error {
+	cfg, err := u.config.Facade.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read controller config")
+	}
+	enabled := cfg.AuditingEnabled()
+	logger.Debugf("auditing enabled: %v", enabled)
+	u.config.SetAuditingEnabled(enabled)
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (u *updater) TearDown() error {
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditconfigupdater_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/controller"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/auditconfigupdater"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	facade  *fakeFacade
+	enabled chan bool
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		Stub:    &testing.Stub{},
+		watcher: newMockNotifyWatcher(),
+		config:  controller.Config{controller.AuditingEnabled: true},
+	}
+	s.enabled = make(chan bool, 1)
+}
+
+func (s *workerSuite) config() auditconfigupdater.Config {
+	return auditconfigupdater.Config{
+		Facade: s.facade,
+		SetAuditingEnabled: func(enabled bool) {
+			s.enabled <- enabled
+		},
+	}
+}
+
+func (s *workerSuite) assertEnabled(c *gc.C, expected bool) {
+	select {
+	case enabled := <-s.enabled:
+		c.Assert(enabled, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for auditing setting")
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	_, err := auditconfigupdater.New(config)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.SetAuditingEnabled = nil
+	_, err = auditconfigupdater.New(config)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "nil SetAuditingEnabled not valid")
+}
+
+func (s *workerSuite) TestReportsInitialAndChangedSetting(c *gc.C) {
+	w, err := auditconfigupdater.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+
+	s.facade.watcher.Change()
+	s.assertEnabled(c, true)
+
+	s.facade.setConfig(controller.Config{controller.AuditingEnabled: false})
+	s.facade.watcher.Change()
+	s.assertEnabled(c, false)
+
+	err = worker.Stop(w)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.CheckCallNames(c, "WatchControllerConfig", "ControllerConfig", "ControllerConfig")
+}
+
+func (s *workerSuite) TestControllerConfigError(c *gc.C) {
+	s.facade.SetErrors(nil, errors.New("boom"))
+	w, err := auditconfigupdater.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+
+	s.facade.watcher.Change()
+	err = w.Wait()
+	c.Assert(err, gc.ErrorMatches, "cannot read controller config: boom")
+}
+
+type fakeFacade struct {
+	*testing.Stub
+	watcher *mockNotifyWatcher
+
+	mu     sync.Mutex
+	config controller.Config
+}
+
+func (f *fakeFacade) setConfig(config controller.Config) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
+}
+
+func (f *fakeFacade) ControllerConfig() (controller.Config, error) {
+	f.Stub.AddCall("ControllerConfig")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.config, f.Stub.NextErr()
+}
+
+func (f *fakeFacade) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	f.Stub.AddCall("WatchControllerConfig")
+	return f.watcher, f.Stub.NextErr()
+}
+
+type mockNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	m := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	go func() {
+		defer m.tomb.Done()
+		defer m.tomb.Kill(nil)
+		<-m.tomb.Dying()
+	}()
+	return m
+}
+
+func (m *mockNotifyWatcher) Kill() {
+	m.tomb.Kill(nil)
+}
+
+func (m *mockNotifyWatcher) Wait() error {
+	return m.tomb.Wait()
+}
+
+func (m *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return m.changes
+}
+
+func (m *mockNotifyWatcher) Change() {
+	m.changes <- struct{}{}
+}
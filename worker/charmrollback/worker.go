@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollback
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.charmrollback")
+
+// Facade represents an API that checks applications' charm upgrades
+// and reverts those that have failed.
+type Facade interface {
+	CheckRollbacks() ([]string, error)
+}
+
+// Config holds all necessary attributes to start a charm rollback worker.
+type Config struct {
+	Facade        Facade
+	CheckInterval time.Duration
+	NewTimer      worker.NewTimerFunc
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.New("missing Facade")
+	}
+	if c.NewTimer == nil {
+		return errors.New("missing Timer")
+	}
+	if c.CheckInterval <= 0 {
+		return errors.New("missing CheckInterval")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that periodically reverts charm upgrades
+// made with automatic rollback whose hooks have failed on too many units.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doCheck := func(stop <-chan struct{}) error {
+		rolledBack, err := conf.Facade.CheckRollbacks()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, name := range rolledBack {
+			logger.Infof("rolled back charm upgrade of application %q", name)
+		}
+		return nil
+	}
+	return worker.NewPeriodicWorker(doCheck, conf.CheckInterval, conf.NewTimer), nil
+}
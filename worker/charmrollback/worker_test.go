@@ -0,0 +1,132 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollback_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/charmrollback"
+)
+
+type charmRollbackSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&charmRollbackSuite{})
+
+func (s *charmRollbackSuite) TestValidate(c *gc.C) {
+	timerFunc := func(time.Duration) worker.PeriodicTimer { return nil }
+	for i, test := range []struct {
+		config charmrollback.Config
+		err    string
+	}{{
+		config: charmrollback.Config{NewTimer: timerFunc, CheckInterval: time.Minute},
+		err:    "missing Facade",
+	}, {
+		config: charmrollback.Config{Facade: newFakeFacade(), CheckInterval: time.Minute},
+		err:    "missing Timer",
+	}, {
+		config: charmrollback.Config{Facade: newFakeFacade(), NewTimer: timerFunc},
+		err:    "missing CheckInterval",
+	}} {
+		c.Logf("test %d", i)
+		_, err := charmrollback.New(test.config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *charmRollbackSuite) TestWorkerChecksRollbacks(c *gc.C) {
+	fakeTimer := newMockTimer()
+	fakeTimerFunc := func(d time.Duration) worker.PeriodicTimer {
+		// The check runs once before waiting for the interval.
+		c.Assert(d, gc.Equals, 0*time.Nanosecond)
+		return fakeTimer
+	}
+	facade := newFakeFacade()
+	w, err := charmrollback.New(charmrollback.Config{
+		Facade:        facade,
+		CheckInterval: coretesting.ShortWait,
+		NewTimer:      fakeTimerFunc,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	})
+
+	select {
+	case <-facade.called:
+		c.Fatal("called before firing timer")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	err = fakeTimer.fire()
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-facade.called:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for CheckRollbacks")
+	}
+
+	var period time.Duration
+	select {
+	case period = <-fakeTimer.period:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for period reset")
+	}
+	c.Assert(period, gc.Equals, coretesting.ShortWait)
+}
+
+type mockTimer struct {
+	period chan time.Duration
+	c      chan time.Time
+}
+
+func newMockTimer() *mockTimer {
+	return &mockTimer{
+		period: make(chan time.Duration, 1),
+		c:      make(chan time.Time),
+	}
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	select {
+	case t.period <- d:
+	case <-time.After(coretesting.LongWait):
+		panic("timed out waiting for timer to reset")
+	}
+	return true
+}
+
+func (t *mockTimer) CountDown() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) fire() error {
+	select {
+	case t.c <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for worker to run")
+	}
+	return nil
+}
+
+type fakeFacade struct {
+	called chan struct{}
+}
+
+func newFakeFacade() *fakeFacade {
+	return &fakeFacade{called: make(chan struct{}, 1)}
+}
+
+// CheckRollbacks implements charmrollback.Facade.
+func (f *fakeFacade) CheckRollbacks() ([]string, error) {
+	f.called <- struct{}{}
+	return []string{"mysql"}, nil
+}
@@ -4,15 +4,24 @@
 package cleaner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
 )
 
 var logger = loggo.GetLogger("juju.worker.cleaner")
 
+// period is how often the cleaner runs state cleanups when it has not
+// been told of any new ones, so that failed cleanups are retried once
+// their backoff has expired.
+const period = 30 * time.Second
+
 type StateCleaner interface {
 	Cleanup() error
 	WatchCleanups() (watcher.NotifyWatcher, error)
@@ -20,35 +29,61 @@ type StateCleaner interface {
 
 // Cleaner is responsible for cleaning up the state.
 type Cleaner struct {
-	st StateCleaner
+	catacomb catacomb.Catacomb
+	st       StateCleaner
+	clock    clock.Clock
 }
 
 // NewCleaner returns a worker.Worker that runs state.Cleanup()
-// if the CleanupWatcher signals documents marked for deletion.
-func NewCleaner(st StateCleaner) (worker.Worker, error) {
-	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
-		Handler: &Cleaner{st: st},
+// if the CleanupWatcher signals documents marked for deletion,
+// and periodically to retry cleanups that have failed.
+func NewCleaner(st StateCleaner, clock clock.Clock) (worker.Worker, error) {
+	c := &Cleaner{
+		st:    st,
+		clock: clock,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &c.catacomb,
+		Work: c.loop,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return w, nil
+	return c, nil
 }
 
-func (c *Cleaner) SetUp() (watcher.NotifyWatcher, error) {
-	return c.st.WatchCleanups()
+// Kill is part of the worker.Worker interface.
+func (c *Cleaner) Kill() {
+	c.catacomb.Kill(nil)
 }
 
-func (c *Cleaner) Handle(_ <-chan struct{}) error {
-	if err := c.st.Cleanup(); err != nil {
-		logger.Errorf("cannot cleanup state: %v", err)
-	}
-	// We do not return the err from Cleanup, because we don't want to stop
-	// the loop as a failure
-	return nil
+// Wait is part of the worker.Worker interface.
+func (c *Cleaner) Wait() error {
+	return c.catacomb.Wait()
 }
 
-func (c *Cleaner) TearDown() error {
-	// Nothing to cleanup, only state is the watcher
-	return nil
+func (c *Cleaner) loop() error {
+	watcher, err := c.st.WatchCleanups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-c.catacomb.Dying():
+			return c.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("cleanups watcher closed")
+			}
+		case <-c.clock.After(period):
+		}
+		if err := c.st.Cleanup(); err != nil {
+			// We do not return the err from Cleanup, because we
+			// don't want to stop the loop as a failure.
+			logger.Errorf("cannot cleanup state: %v", err)
+		}
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/tomb.v1"
@@ -20,6 +21,7 @@ import (
 type CleanerSuite struct {
 	coretesting.BaseSuite
 	mockState *cleanerMock
+	clock     *testing.Clock
 }
 
 var _ = gc.Suite(&CleanerSuite{})
@@ -30,6 +32,7 @@ func (s *CleanerSuite) SetUpTest(c *gc.C) {
 		calls: make(chan string),
 	}
 	s.mockState.watcher = s.newMockNotifyWatcher(nil)
+	s.clock = testing.NewClock(time.Time{})
 }
 
 func (s *CleanerSuite) AssertReceived(c *gc.C, expect string) {
@@ -50,7 +53,7 @@ func (s *CleanerSuite) AssertEmpty(c *gc.C) {
 }
 
 func (s *CleanerSuite) TestCleaner(c *gc.C) {
-	cln, err := cleaner.NewCleaner(s.mockState)
+	cln, err := cleaner.NewCleaner(s.mockState, s.clock)
 	c.Assert(err, jc.ErrorIsNil)
 	defer func() { c.Assert(worker.Stop(cln), jc.ErrorIsNil) }()
 
@@ -61,9 +64,22 @@ func (s *CleanerSuite) TestCleaner(c *gc.C) {
 	s.AssertReceived(c, "Cleanup")
 }
 
+func (s *CleanerSuite) TestCleanerRetriesPeriodically(c *gc.C) {
+	cln, err := cleaner.NewCleaner(s.mockState, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(cln), jc.ErrorIsNil) }()
+
+	s.AssertReceived(c, "WatchCleanups")
+	s.AssertReceived(c, "Cleanup")
+
+	err = s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertReceived(c, "Cleanup")
+}
+
 func (s *CleanerSuite) TestWatchCleanupsError(c *gc.C) {
 	s.mockState.err = []error{errors.New("hello")}
-	cln, err := cleaner.NewCleaner(s.mockState)
+	cln, err := cleaner.NewCleaner(s.mockState, s.clock)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.AssertReceived(c, "WatchCleanups")
@@ -74,7 +90,7 @@ func (s *CleanerSuite) TestWatchCleanupsError(c *gc.C) {
 
 func (s *CleanerSuite) TestCleanupError(c *gc.C) {
 	s.mockState.err = []error{nil, errors.New("hello")}
-	cln, err := cleaner.NewCleaner(s.mockState)
+	cln, err := cleaner.NewCleaner(s.mockState, s.clock)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.AssertReceived(c, "WatchCleanups")
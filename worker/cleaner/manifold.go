@@ -5,29 +5,43 @@ package cleaner
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/cleaner"
-	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
 )
 
 // ManifoldConfig describes the resources used by the cleanup worker.
-type ManifoldConfig engine.APIManifoldConfig
+type ManifoldConfig struct {
+	APICallerName string
+	ClockName     string
+}
 
 // Manifold returns a Manifold that encapsulates the cleanup worker.
 func Manifold(config ManifoldConfig) dependency.Manifold {
-	return engine.APIManifold(
-		engine.APIManifoldConfig(config),
-		manifoldStart,
-	)
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+			config.ClockName,
+		},
+		Start: config.start,
+	}
 }
 
-// manifoldStart creates a cleaner worker, given a base.APICaller.
-func manifoldStart(apiCaller base.APICaller) (worker.Worker, error) {
+// start creates a cleaner worker, given a base.APICaller and a clock.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
 	api := cleaner.NewAPI(apiCaller)
-	w, err := NewCleaner(api)
+	w, err := NewCleaner(api, clock)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerreplacer
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.controllerreplacer")
+
+// minVotingControllers is the number of voting controllers there
+// must be before any is replaced. A controller that is not highly
+// available is never made so automatically.
+const minVotingControllers = 3
+
+// Backend defines the state methods the worker uses.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	ControllerInfo() (*state.ControllerInfo, error)
+	Machine(id string) (Machine, error)
+	EnableHA(numControllers int, cons constraints.Value, series string, placement []string) (state.ControllersChanges, error)
+}
+
+// Machine defines the controller machine methods the worker uses.
+type Machine interface {
+	Id() string
+	AgentPresence() (bool, error)
+	Series() string
+	Constraints() (constraints.Value, error)
+}
+
+// Config holds the dependencies of the worker.
+type Config struct {
+	Backend  Backend
+	Clock    clock.Clock
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start
+// the worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// New returns a worker which, every config.Interval, checks for
+// controller machines whose agents have been absent for longer than
+// the controller's controller-replace-delay. If the controller's
+// auto-replace-controllers flag is set, such a machine is replaced
+// as "juju enable-ha" would: a new controller machine is added with
+// the same series and constraints, and the absent machine loses its
+// vote, is dropped from the replica set by the peergrouper and then
+// has its controller job removed.
+//
+// The worker is intended to run on only one controller at a time.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &replacer{
+		config:      config,
+		absentSince: make(map[string]time.Time),
+	}
+	return worker.NewSimpleWorker(w.loop), nil
+}
+
+type replacer struct {
+	config Config
+
+	// absentSince records when each controller machine was first
+	// seen without its agent.
+	absentSince map[string]time.Time
+}
+
+func (w *replacer) loop(stopCh <-chan struct{}) error {
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		case <-w.config.Clock.After(w.config.Interval):
+			if err := w.check(); err != nil {
+				return errors.Annotate(err, "checking controllers")
+			}
+		}
+	}
+}
+
+// check replaces a controller machine if one has been absent for
+// long enough.
+func (w *replacer) check() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !cfg.AutoReplaceControllers() {
+		// Forget any absences so that the delay starts afresh
+		// if the flag is later set.
+		w.absentSince = make(map[string]time.Time)
+		return nil
+	}
+	info, err := w.config.Backend.ControllerInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	now := w.config.Clock.Now()
+	absentSince := make(map[string]time.Time)
+	var dead Machine
+	for _, id := range info.MachineIds {
+		m, err := w.config.Backend.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		present, err := m.AgentPresence()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if present {
+			continue
+		}
+		since, ok := w.absentSince[id]
+		if !ok {
+			logger.Infof("controller machine %q agent is absent", id)
+			since = now
+		}
+		absentSince[id] = since
+		if dead == nil && now.Sub(since) >= cfg.ControllerReplaceDelay() {
+			dead = m
+		}
+	}
+	w.absentSince = absentSince
+	if dead == nil {
+		return nil
+	}
+	if len(info.VotingMachineIds) < minVotingControllers {
+		logger.Warningf(
+			"not replacing controller machine %q: fewer than %d voting controllers",
+			dead.Id(), minVotingControllers,
+		)
+		return nil
+	}
+
+	cons, err := dead.Constraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Asking for no change in the number of controllers replaces
+	// the voting controllers that are unavailable, and removes the
+	// controller job from those that have already lost their vote.
+	changes, err := w.config.Backend.EnableHA(0, cons, dead.Series(), nil)
+	if err != nil {
+		return errors.Annotatef(err, "replacing controller machine %q", dead.Id())
+	}
+	if len(changes.Added) > 0 || len(changes.Demoted) > 0 || len(changes.Removed) > 0 || len(changes.Promoted) > 0 {
+		logger.Infof(
+			"replaced absent controller machine %q: added %v, promoted %v, demoted %v, removed %v",
+			dead.Id(), changes.Added, changes.Promoted, changes.Demoted, changes.Removed,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerreplacer_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/controllerreplacer"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	backend *fakeBackend
+	clock   *testing.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.backend = &fakeBackend{
+		config: controller.Config{
+			controller.AutoReplaceControllers: true,
+			controller.ControllerReplaceDelay: "10m",
+		},
+		info: &state.ControllerInfo{
+			MachineIds:       []string{"0", "1", "2"},
+			VotingMachineIds: []string{"0", "1", "2"},
+		},
+		machines: map[string]*fakeMachine{
+			"0": {id: "0", present: true},
+			"1": {id: "1", present: true},
+			"2": {id: "2", series: "xenial", cons: constraints.MustParse("mem=4G")},
+		},
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := controllerreplacer.New(controllerreplacer.Config{
+		Backend:  s.backend,
+		Clock:    s.clock,
+		Interval: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitAlarm(c)
+	return w
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to wait")
+	}
+}
+
+// tick advances the clock by the given duration, one poll at a
+// time, waiting for each poll to complete.
+func (s *WorkerSuite) tick(c *gc.C, d time.Duration) {
+	for ; d > 0; d -= time.Minute {
+		s.clock.Advance(time.Minute)
+		s.waitAlarm(c)
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := controllerreplacer.New(controllerreplacer.Config{
+		Clock:    s.clock,
+		Interval: time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+	_, err = controllerreplacer.New(controllerreplacer.Config{
+		Backend:  s.backend,
+		Interval: time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+	_, err = controllerreplacer.New(controllerreplacer.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) TestReplacesAfterDelay(c *gc.C) {
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	// The absence is first seen on the first poll.
+	s.tick(c, 10*time.Minute)
+	s.backend.CheckNoCalls(c)
+	s.tick(c, time.Minute)
+	s.backend.CheckCallNames(c, "EnableHA")
+	s.backend.CheckCall(c, 0, "EnableHA", 0, constraints.MustParse("mem=4G"), "xenial", []string(nil))
+}
+
+func (s *WorkerSuite) TestNotReplacedBeforeDelay(c *gc.C) {
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	s.tick(c, 5*time.Minute)
+	s.backend.machines["2"].present = true
+	s.tick(c, 10*time.Minute)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.backend.config[controller.AutoReplaceControllers] = false
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	s.tick(c, 20*time.Minute)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestNotHighlyAvailable(c *gc.C) {
+	s.backend.info = &state.ControllerInfo{
+		MachineIds:       []string{"0", "2"},
+		VotingMachineIds: []string{"0"},
+	}
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	s.tick(c, 20*time.Minute)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestEnableHAError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	w := s.startWorker(c)
+
+	for i := 0; i < 11; i++ {
+		s.clock.Advance(time.Minute)
+		if i < 10 {
+			s.waitAlarm(c)
+		}
+	}
+	err := w.Wait()
+	c.Assert(err, gc.ErrorMatches, `checking controllers: replacing controller machine "2": boom`)
+}
+
+type fakeBackend struct {
+	testing.Stub
+	config   controller.Config
+	info     *state.ControllerInfo
+	machines map[string]*fakeMachine
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	return b.config, nil
+}
+
+func (b *fakeBackend) ControllerInfo() (*state.ControllerInfo, error) {
+	return b.info, nil
+}
+
+func (b *fakeBackend) Machine(id string) (controllerreplacer.Machine, error) {
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	return m, nil
+}
+
+func (b *fakeBackend) EnableHA(
+	numControllers int, cons constraints.Value, series string, placement []string,
+) (state.ControllersChanges, error) {
+	b.MethodCall(b, "EnableHA", numControllers, cons, series, placement)
+	if err := b.NextErr(); err != nil {
+		return state.ControllersChanges{}, err
+	}
+	return state.ControllersChanges{Added: []string{"3"}, Demoted: []string{"2"}}, nil
+}
+
+type fakeMachine struct {
+	id      string
+	present bool
+	series  string
+	cons    constraints.Value
+}
+
+func (m *fakeMachine) Id() string {
+	return m.id
+}
+
+func (m *fakeMachine) AgentPresence() (bool, error) {
+	return m.present, nil
+}
+
+func (m *fakeMachine) Series() string {
+	return m.series
+}
+
+func (m *fakeMachine) Constraints() (constraints.Value, error) {
+	return m.cons, nil
+}
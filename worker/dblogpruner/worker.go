@@ -7,12 +7,15 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker"
 )
 
+var logger = loggo.GetLogger("juju.worker.dblogpruner")
+
 // LogPruneParams specifies how logs should be pruned.
 type LogPruneParams struct {
 	MaxLogAge       time.Duration
@@ -35,8 +38,10 @@ func NewLogPruneParams() *LogPruneParams {
 }
 
 // New returns a worker which periodically wakes up to remove old log
-// entries stored in MongoDB. This worker is intended to run just
-// once, on the MongoDB master.
+// entries stored in MongoDB. If the controller's log-offload-age config
+// attribute is set, logs older than that are first moved to the
+// external object store. This worker is intended to run just once, on
+// the MongoDB master.
 func New(st *state.State, params *LogPruneParams) worker.Worker {
 	w := &pruneWorker{
 		st:     st,
@@ -57,6 +62,9 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 		case <-stopCh:
 			return tomb.ErrDying
 		case <-time.After(p.PruneInterval):
+			if err := w.offload(); err != nil {
+				return errors.Trace(err)
+			}
 			// TODO(fwereade): 2016-03-17 lp:1558657
 			minLogTime := time.Now().Add(-p.MaxLogAge)
 			err := state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB)
@@ -66,3 +74,25 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 		}
 	}
 }
+
+// offload moves logs older than the controller's log-offload-age to
+// the external object store, if the age is set.
+func (w *pruneWorker) offload() error {
+	cfg, err := w.st.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read controller config")
+	}
+	age := cfg.LogOffloadAge()
+	if age == 0 {
+		return nil
+	}
+	store, err := state.LogArchiveStorage(w.st)
+	if errors.IsNotFound(err) {
+		logger.Warningf("not offloading logs: no external object store")
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	err = state.OffloadLogs(w.st, store, time.Now().Add(-age))
+	return errors.Annotate(err, "cannot offload logs")
+}
@@ -234,10 +234,17 @@ func (engine *Engine) manifoldsReport() map[string]interface{} {
 			KeyState:       info.state(),
 			KeyInputs:      engine.manifolds[name].Inputs,
 			KeyResourceLog: resourceLogReport(info.resourceLog),
+			KeyStartCount:  info.startCount,
 		}
 		if info.err != nil {
 			report[KeyError] = info.err.Error()
 		}
+		if len(info.recentErrors) > 0 {
+			report[KeyRecentErrors] = recentErrorsReport(info.recentErrors)
+		}
+		if info.starting && !info.startAfter.IsZero() {
+			report[KeyStartAfter] = formatReportTime(info.startAfter)
+		}
 		if reporter, ok := info.worker.(Reporter); ok {
 			if reporter != engine {
 				report[KeyReport] = reporter.Report()
@@ -330,14 +337,16 @@ func (engine *Engine) requestStart(name string, delay time.Duration) {
 	// goroutine based on current known state.
 	info.starting = true
 	info.abort = make(chan struct{})
-	engine.current[name] = info
-	context := engine.context(name, manifold.Inputs, info.abort)
 
 	// Always fuzz the delay a bit to help randomise the order of workers starting,
 	// which should make bugs more obvious
+	info.startAfter = time.Time{}
 	if delay > time.Duration(0) {
 		delay += time.Duration(rand.Int31n(60)) * time.Millisecond
+		info.startAfter = time.Now().Add(delay)
 	}
+	engine.current[name] = info
+	context := engine.context(name, manifold.Inputs, info.abort)
 
 	go engine.runWorker(name, delay, manifold.Start, context)
 }
@@ -485,8 +494,10 @@ func (engine *Engine) gotStarted(name string, worker worker.Worker, resourceLog
 		// It's fine to use this worker; update info and copy back.
 		logger.Debugf("%q manifold worker started", name)
 		engine.current[name] = workerInfo{
-			worker:      worker,
-			resourceLog: resourceLog,
+			worker:       worker,
+			resourceLog:  resourceLog,
+			startCount:   info.startCount + 1,
+			recentErrors: info.recentErrors,
 		}
 
 		// Any manifold that declares this one as an input needs to be restarted.
@@ -512,9 +523,18 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	}
 
 	// Reset engine info; and bail out if we can be sure there's no need to bounce.
+	recentErrors := info.recentErrors
+	if err != nil {
+		recentErrors = append(recentErrors, errorRecord{err: err, time: time.Now()})
+		if len(recentErrors) > maxRecentErrors {
+			recentErrors = recentErrors[len(recentErrors)-maxRecentErrors:]
+		}
+	}
 	engine.current[name] = workerInfo{
-		err:         err,
-		resourceLog: resourceLog,
+		err:          err,
+		resourceLog:  resourceLog,
+		startCount:   info.startCount,
+		recentErrors: recentErrors,
 	}
 	if engine.isDying() {
 		logger.Tracef("permanently stopped %q manifold worker (shutting down)", name)
@@ -624,6 +644,46 @@ type workerInfo struct {
 	worker      worker.Worker
 	err         error
 	resourceLog []resourceAccess
+
+	// startAfter holds the time at which a starting worker
+	// that was delayed will be started.
+	startAfter time.Time
+
+	// startCount holds the number of times a worker has
+	// been started for the manifold.
+	startCount int
+
+	// recentErrors holds the most recent errors returned
+	// by the manifold's workers, oldest first.
+	recentErrors []errorRecord
+}
+
+// maxRecentErrors is the number of errors recorded
+// for each manifold for use in reports.
+const maxRecentErrors = 5
+
+// errorRecord records an error returned by a
+// manifold's worker, and when it was returned.
+type errorRecord struct {
+	err  error
+	time time.Time
+}
+
+// recentErrorsReport returns a convenient representation of recentErrors.
+func recentErrorsReport(recentErrors []errorRecord) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(recentErrors))
+	for i, record := range recentErrors {
+		result[i] = map[string]interface{}{
+			KeyError: record.err.Error(),
+			KeyTime:  formatReportTime(record.time),
+		}
+	}
+	return result
+}
+
+// formatReportTime returns the representation of t used in reports.
+func formatReportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
 }
 
 // stopped returns true unless the worker is either assigned or starting.
@@ -63,4 +63,20 @@ const (
 	// KeyType holds a string representation of the type by which a resource
 	// was accessed.
 	KeyType = "type"
+
+	// KeyStartCount holds the number of times a manifold's worker has
+	// been started.
+	KeyStartCount = "start-count"
+
+	// KeyRecentErrors holds a slice representing the most recent errors
+	// returned by a manifold's workers, oldest first, each with the time
+	// it was returned.
+	KeyRecentErrors = "recent-errors"
+
+	// KeyStartAfter holds the time at which a manifold's worker, whose
+	// start has been delayed after an error or a bounce, will be started.
+	KeyStartAfter = "start-after"
+
+	// KeyTime holds the time at which something happened.
+	KeyTime = "time"
 )
@@ -4,10 +4,13 @@
 package dependency_test
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
 	coretesting "github.com/juju/juju/testing"
@@ -94,6 +97,7 @@ func (s *ReportSuite) TestReportStopping(c *gc.C) {
 					"state":        "stopping",
 					"inputs":       ([]string)(nil),
 					"resource-log": []map[string]interface{}{},
+					"start-count":  1,
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -123,6 +127,7 @@ func (s *ReportSuite) TestReportInputs(c *gc.C) {
 					"state":        "started",
 					"inputs":       ([]string)(nil),
 					"resource-log": []map[string]interface{}{},
+					"start-count":  1,
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -134,6 +139,7 @@ func (s *ReportSuite) TestReportInputs(c *gc.C) {
 						"name": "task",
 						"type": "<nil>",
 					}},
+					"start-count": 1,
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -153,6 +159,16 @@ func (s *ReportSuite) TestReportError(c *gc.C) {
 
 		workertest.CleanKill(c, engine)
 		report := engine.Report()
+
+		// The recent errors are timestamped, so check them separately.
+		task := report["manifolds"].(map[string]interface{})["task"].(map[string]interface{})
+		recentErrors := task["recent-errors"].([]map[string]interface{})
+		c.Assert(recentErrors, gc.HasLen, 1)
+		c.Check(recentErrors[0]["error"], gc.Equals, `"missing" not running: dependency not available`)
+		_, err = time.Parse(time.RFC3339Nano, recentErrors[0]["time"].(string))
+		c.Check(err, jc.ErrorIsNil)
+		delete(task, "recent-errors")
+
 		c.Check(report, jc.DeepEquals, map[string]interface{}{
 			"state": "stopped",
 			"manifolds": map[string]interface{}{
@@ -165,8 +181,68 @@ func (s *ReportSuite) TestReportError(c *gc.C) {
 						"type":  "<nil>",
 						"error": `"missing" not running: dependency not available`,
 					}},
+					"start-count": 0,
 				},
 			},
 		})
 	})
 }
+
+func (s *ReportSuite) TestReportRestarts(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh1 := newManifoldHarness()
+		err := engine.Install("task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+
+		for i := 0; i < 7; i++ {
+			mh1.InjectError(c, errors.Errorf("boom %d", i))
+			mh1.AssertOneStart(c)
+		}
+
+		report := engine.Report()
+		task := report["manifolds"].(map[string]interface{})["task"].(map[string]interface{})
+		c.Check(task["state"], gc.Equals, "started")
+		c.Check(task["start-count"], gc.Equals, 8)
+
+		// Only the most recent errors are kept, oldest first.
+		recentErrors := task["recent-errors"].([]map[string]interface{})
+		c.Assert(recentErrors, gc.HasLen, 5)
+		for i, recentError := range recentErrors {
+			c.Check(recentError["error"], gc.Equals, fmt.Sprintf("boom %d", i+2))
+		}
+	})
+}
+
+func (s *ReportSuite) TestReportStartAfter(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh1 := newManifoldHarness()
+		err := engine.Install("task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+
+		before := time.Now()
+		mh1.InjectError(c, errors.New("boom"))
+
+		// It may take a short time for the main loop to notice
+		// the error and schedule the restart; poll often, as the
+		// restart itself happens soon afterwards.
+		attempt := utils.AttemptStrategy{
+			Total: coretesting.LongWait,
+			Delay: time.Millisecond,
+		}
+		var task map[string]interface{}
+		for a := attempt.Start(); a.Next(); {
+			report := engine.Report()
+			task = report["manifolds"].(map[string]interface{})["task"].(map[string]interface{})
+			if task["state"] == "starting" {
+				break
+			}
+		}
+		c.Assert(task["state"], gc.Equals, "starting")
+		startAfter, err := time.Parse(time.RFC3339Nano, task["start-after"].(string))
+		c.Assert(err, jc.ErrorIsNil)
+		// The fixture's engine has an ErrorDelay of ShortWait/2.
+		c.Check(startAfter.After(before.Add(coretesting.ShortWait/2)), jc.IsTrue)
+	})
+}
@@ -198,6 +198,10 @@ func (d *Deployer) remove(unit *apideployer.Unit) error {
 }
 
 func (d *Deployer) TearDown() error {
-	// Nothing to do here.
+	// Contexts that run unit agents in this process stop them when
+	// the deployer stops.
+	if w, ok := d.ctx.(worker.Worker); ok {
+		return worker.Stop(w)
+	}
 	return nil
 }
@@ -33,3 +33,7 @@ func NewTestSimpleContext(agentConfig agent.Config, logDir string, data *svctest
 		},
 	}
 }
+
+func NewTestNestedContext(agentConfig agent.Config, startUnit StartUnitFunc, legacy Context) *NestedContext {
+	return newNestedContext(agentConfig, &fakeAPI{}, startUnit, legacy)
+}
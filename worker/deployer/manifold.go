@@ -71,6 +71,9 @@ func (config ManifoldConfig) newWorker(a agent.Agent, apiCaller base.APICaller)
 	context := config.NewDeployContext(deployerFacade, cfg)
 	w, err := NewDeployer(deployerFacade, context)
 	if err != nil {
+		if contextWorker, ok := context.(worker.Worker); ok {
+			worker.Stop(contextWorker)
+		}
 		return nil, errors.Annotate(err, "cannot start unit agent deployer worker")
 	}
 	return w, nil
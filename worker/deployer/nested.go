@@ -0,0 +1,217 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/worker"
+)
+
+// nestedUnitsFile is the name of the file, in the machine agent's
+// directory, that records the units deployed by a NestedContext.
+const nestedUnitsFile = "nested-units"
+
+// StartUnitFunc starts the workers of the named unit's agent, whose
+// agent config has already been written. The returned worker runs
+// until it is killed.
+type StartUnitFunc func(unitName string) (worker.Worker, error)
+
+// NestedContext is a Context that runs unit agents as workers inside
+// the machine agent process, rather than as one jujud service per
+// unit. The units it deploys are recorded in the machine agent's
+// directory, so that their workers are started again when the machine
+// agent restarts.
+//
+// Units that were deployed as services before a NestedContext was used
+// are left running as services until they are recalled.
+//
+// A NestedContext is also a worker.Worker; killing it stops the
+// workers of all the units it runs.
+type NestedContext struct {
+	api         APICalls
+	agentConfig agent.Config
+	startUnit   StartUnitFunc
+
+	// legacy manages the units deployed as services.
+	legacy Context
+
+	runner worker.Runner
+
+	mu      sync.Mutex
+	workers map[string]worker.Worker
+}
+
+var _ Context = (*NestedContext)(nil)
+
+// NewNestedContext returns a new NestedContext, acting on behalf of the
+// machine agent with the given config, that runs unit agents using
+// startUnit.
+func NewNestedContext(agentConfig agent.Config, api APICalls, startUnit StartUnitFunc) *NestedContext {
+	return newNestedContext(agentConfig, api, startUnit, NewSimpleContext(agentConfig, api))
+}
+
+func newNestedContext(agentConfig agent.Config, api APICalls, startUnit StartUnitFunc, legacy Context) *NestedContext {
+	// A unit's workers are restarted whatever error they fail with; a
+	// unit that can no longer run is recalled by the deployer.
+	isFatal := func(error) bool { return false }
+	moreImportant := func(error, error) bool { return false }
+	return &NestedContext{
+		api:         api,
+		agentConfig: agentConfig,
+		startUnit:   startUnit,
+		legacy:      legacy,
+		runner:      worker.NewRunner(isFatal, moreImportant, worker.RestartDelay),
+		workers:     make(map[string]worker.Worker),
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (ctx *NestedContext) Kill() {
+	ctx.runner.Kill()
+}
+
+// Wait is part of the worker.Worker interface.
+func (ctx *NestedContext) Wait() error {
+	return ctx.runner.Wait()
+}
+
+// AgentConfig is part of the Context interface.
+func (ctx *NestedContext) AgentConfig() agent.Config {
+	return ctx.agentConfig
+}
+
+// DeployUnit is part of the Context interface.
+func (ctx *NestedContext) DeployUnit(unitName, initialPassword string) (err error) {
+	units, err := ctx.readUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if units.Contains(unitName) {
+		return errors.Errorf("unit %q is already deployed", unitName)
+	}
+	legacyUnits, err := ctx.legacy.DeployedUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if set.NewStrings(legacyUnits...).Contains(unitName) {
+		return errors.Errorf("unit %q is already deployed", unitName)
+	}
+
+	conf, err := writeUnitAgentConfig(ctx.agentConfig, ctx.api, unitName, initialPassword)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer removeOnErr(&err, conf.Dir())
+
+	units.Add(unitName)
+	if err := ctx.writeUnits(units); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ctx.start(unitName))
+}
+
+// RecallUnit is part of the Context interface.
+func (ctx *NestedContext) RecallUnit(unitName string) error {
+	units, err := ctx.readUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !units.Contains(unitName) {
+		return ctx.legacy.RecallUnit(unitName)
+	}
+	if err := ctx.stop(unitName); err != nil {
+		return errors.Trace(err)
+	}
+	agentDir := agent.Dir(ctx.agentConfig.DataDir(), names.NewUnitTag(unitName))
+	if err := os.RemoveAll(agentDir); err != nil {
+		return errors.Trace(err)
+	}
+	units.Remove(unitName)
+	return errors.Trace(ctx.writeUnits(units))
+}
+
+// DeployedUnits is part of the Context interface. It also starts the
+// workers of the units deployed before the machine agent restarted.
+func (ctx *NestedContext) DeployedUnits() ([]string, error) {
+	units, err := ctx.readUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, unitName := range units.SortedValues() {
+		if err := ctx.start(unitName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	legacyUnits, err := ctx.legacy.DeployedUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return units.Union(set.NewStrings(legacyUnits...)).SortedValues(), nil
+}
+
+// start starts the workers of the named unit, if they are not already
+// running.
+func (ctx *NestedContext) start(unitName string) error {
+	err := ctx.runner.StartWorker(unitName, func() (worker.Worker, error) {
+		w, err := ctx.startUnit(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ctx.mu.Lock()
+		ctx.workers[unitName] = w
+		ctx.mu.Unlock()
+		return w, nil
+	})
+	return errors.Trace(err)
+}
+
+// stop stops the workers of the named unit, and waits for them to
+// finish.
+func (ctx *NestedContext) stop(unitName string) error {
+	if err := ctx.runner.StopWorker(unitName); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.mu.Lock()
+	w := ctx.workers[unitName]
+	delete(ctx.workers, unitName)
+	ctx.mu.Unlock()
+	if w == nil {
+		return nil
+	}
+	if err := w.Wait(); err != nil {
+		logger.Infof("unit %q stopped: %v", unitName, err)
+	}
+	return nil
+}
+
+// readUnits returns the names of the units recorded as deployed.
+func (ctx *NestedContext) readUnits() (set.Strings, error) {
+	var units []string
+	if err := utils.ReadYaml(ctx.unitsPath(), &units); err != nil {
+		if os.IsNotExist(err) {
+			return set.NewStrings(), nil
+		}
+		return nil, errors.Annotate(err, "reading deployed units")
+	}
+	return set.NewStrings(units...), nil
+}
+
+// writeUnits records the names of the units deployed.
+func (ctx *NestedContext) writeUnits(units set.Strings) error {
+	err := utils.WriteYaml(ctx.unitsPath(), units.SortedValues())
+	return errors.Annotate(err, "recording deployed units")
+}
+
+func (ctx *NestedContext) unitsPath() string {
+	return filepath.Join(ctx.agentConfig.Dir(), nestedUnitsFile)
+}
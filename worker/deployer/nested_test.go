@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/deployer"
+)
+
+type NestedContextSuite struct {
+	testing.BaseSuite
+	config  agent.Config
+	legacy  *fakeContext
+	started chan string
+	stopped chan string
+}
+
+var _ = gc.Suite(&NestedContextSuite{})
+
+func (s *NestedContextSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.config = agentConfig(names.NewMachineTag("99"), c.MkDir(), c.MkDir())
+	err := os.MkdirAll(s.config.Dir(), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.legacy = &fakeContext{}
+	s.started = make(chan string, 10)
+	s.stopped = make(chan string, 10)
+}
+
+func (s *NestedContextSuite) newContext(c *gc.C) *deployer.NestedContext {
+	ctx := deployer.NewTestNestedContext(s.config, s.startUnit, s.legacy)
+	s.AddCleanup(func(c *gc.C) {
+		worker.Stop(ctx)
+	})
+	return ctx
+}
+
+func (s *NestedContextSuite) startUnit(unitName string) (worker.Worker, error) {
+	s.started <- unitName
+	return worker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
+		<-stopCh
+		s.stopped <- unitName
+		return nil
+	}), nil
+}
+
+func (s *NestedContextSuite) assertUnit(c *gc.C, ch <-chan string, unitName string) {
+	select {
+	case name := <-ch:
+		c.Assert(name, gc.Equals, unitName)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for unit %q", unitName)
+	}
+}
+
+func (s *NestedContextSuite) TestDeployRecall(c *gc.C) {
+	ctx := s.newContext(c)
+	units, err := ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+
+	err = ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnit(c, s.started, "foo/123")
+	tag := names.NewUnitTag("foo/123")
+	conf, err := agent.ReadConfig(agent.ConfigPath(s.config.DataDir(), tag))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Tag(), gc.Equals, tag)
+	units, err = ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"foo/123"})
+
+	err = ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, gc.ErrorMatches, `unit "foo/123" is already deployed`)
+
+	err = ctx.RecallUnit("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnit(c, s.stopped, "foo/123")
+	_, err = os.Stat(agent.Dir(s.config.DataDir(), tag))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+	units, err = ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+}
+
+func (s *NestedContextSuite) TestDeployedUnitsRestarted(c *gc.C) {
+	ctx := s.newContext(c)
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnit(c, s.started, "foo/123")
+
+	// Stopping the context stops the units' workers...
+	err = worker.Stop(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnit(c, s.stopped, "foo/123")
+
+	// ...and a new context starts them again.
+	ctx = s.newContext(c)
+	units, err := ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"foo/123"})
+	s.assertUnit(c, s.started, "foo/123")
+}
+
+func (s *NestedContextSuite) TestLegacyUnits(c *gc.C) {
+	s.legacy.units = []string{"bar/0"}
+	ctx := s.newContext(c)
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"bar/0", "foo/123"})
+
+	err = ctx.DeployUnit("bar/0", "some-password")
+	c.Assert(err, gc.ErrorMatches, `unit "bar/0" is already deployed`)
+
+	err = ctx.RecallUnit("bar/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.legacy.recalled, jc.DeepEquals, []string{"bar/0"})
+}
+
+// fakeContext is a deployer.Context that records the units recalled
+// from it.
+type fakeContext struct {
+	deployer.Context
+	units    []string
+	recalled []string
+}
+
+func (ctx *fakeContext) DeployedUnits() ([]string, error) {
+	return ctx.units, nil
+}
+
+func (ctx *fakeContext) RecallUnit(unitName string) error {
+	for i, name := range ctx.units {
+		if name == unitName {
+			ctx.units = append(ctx.units[:i], ctx.units[i+1:]...)
+			ctx.recalled = append(ctx.recalled, unitName)
+			return nil
+		}
+	}
+	return errors.Errorf("unit %q is not deployed", unitName)
+}
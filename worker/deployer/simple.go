@@ -110,7 +110,6 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 	// Link the current tools for use by the new agent.
 	tag := names.NewUnitTag(unitName)
 	dataDir := ctx.agentConfig.DataDir()
-	logDir := ctx.agentConfig.LogDir()
 	hostSeries, err := series.HostSeries()
 	if err != nil {
 		return errors.Trace(err)
@@ -127,14 +126,34 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 		return errors.Trace(err)
 	}
 
-	result, err := ctx.api.ConnectionInfo()
+	conf, err := writeUnitAgentConfig(ctx.agentConfig, ctx.api, unitName, initialPassword)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	defer removeOnErr(&err, conf.Dir())
+
+	// Install an init service that runs the unit agent.
+	if err := service.InstallAndStart(svc); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// writeUnitAgentConfig writes the agent config for the named unit,
+// with the given initial password and the controller addresses current
+// at the time, and returns it.
+func writeUnitAgentConfig(machineConfig agent.Config, api APICalls, unitName, initialPassword string) (agent.ConfigSetterWriter, error) {
+	tag := names.NewUnitTag(unitName)
+	dataDir := machineConfig.DataDir()
+	logDir := machineConfig.LogDir()
+	result, err := api.ConnectionInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	logger.Debugf("state addresses: %q", result.StateAddresses)
 	logger.Debugf("API addresses: %q", result.APIAddresses)
-	containerType := ctx.agentConfig.Value(agent.ContainerType)
-	namespace := ctx.agentConfig.Value(agent.Namespace)
+	containerType := machineConfig.Value(agent.ContainerType)
+	namespace := machineConfig.Value(agent.Namespace)
 	conf, err := agent.NewAgentConfig(
 		agent.AgentConfigParams{
 			Paths: agent.Paths{
@@ -146,30 +165,24 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 			Tag:               tag,
 			Password:          initialPassword,
 			Nonce:             "unused",
-			Controller:        ctx.agentConfig.Controller(),
-			Model:             ctx.agentConfig.Model(),
+			Controller:        machineConfig.Controller(),
+			Model:             machineConfig.Model(),
 			// TODO: remove the state addresses here and test when api only.
 			StateAddresses: result.StateAddresses,
 			APIAddresses:   result.APIAddresses,
-			CACert:         ctx.agentConfig.CACert(),
+			CACert:         machineConfig.CACert(),
 			Values: map[string]string{
 				agent.ContainerType: containerType,
 				agent.Namespace:     namespace,
 			},
 		})
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	if err := conf.Write(); err != nil {
-		return err
-	}
-	defer removeOnErr(&err, conf.Dir())
-
-	// Install an init service that runs the unit agent.
-	if err := service.InstallAndStart(svc); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
-	return nil
+	return conf, nil
 }
 
 type deployerService interface {
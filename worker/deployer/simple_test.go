@@ -310,6 +310,10 @@ func (mock *mockConfig) LogDir() string {
 	return mock.logdir
 }
 
+func (mock *mockConfig) Dir() string {
+	return agent.Dir(mock.datadir, mock.tag)
+}
+
 func (mock *mockConfig) Jobs() []multiwatcher.MachineJob {
 	return mock.jobs
 }
@@ -4,6 +4,8 @@
 package discoverspaces_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -65,6 +67,38 @@ func (*ConfigSuite) TestNilNewName(c *gc.C) {
 	checkAlwaysInvalid(c, config, "nil NewName not valid")
 }
 
+func (*ConfigSuite) TestRefreshWithClock(c *gc.C) {
+	config := discoverspaces.Config{
+		Facade:          fakeFacade{},
+		Environ:         fakeEnviron{},
+		NewName:         fakeNewName,
+		RefreshInterval: time.Minute,
+		Clock:           fakeClock{},
+	}
+	checkConfigValid(c, config)
+}
+
+func (*ConfigSuite) TestNegativeRefreshInterval(c *gc.C) {
+	config := discoverspaces.Config{
+		Facade:          fakeFacade{},
+		Environ:         fakeEnviron{},
+		NewName:         fakeNewName,
+		RefreshInterval: -time.Minute,
+		Clock:           fakeClock{},
+	}
+	checkAlwaysInvalid(c, config, "negative RefreshInterval not valid")
+}
+
+func (*ConfigSuite) TestRefreshWithNilClock(c *gc.C) {
+	config := discoverspaces.Config{
+		Facade:          fakeFacade{},
+		Environ:         fakeEnviron{},
+		NewName:         fakeNewName,
+		RefreshInterval: time.Minute,
+	}
+	checkAlwaysInvalid(c, config, "nil Clock not valid")
+}
+
 func checkAlwaysInvalid(c *gc.C, config discoverspaces.Config, message string) {
 	check := func(err error) {
 		c.Check(err.Error(), gc.Equals, message)
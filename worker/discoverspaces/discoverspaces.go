@@ -4,13 +4,17 @@
 package discoverspaces
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/catacomb"
 	"github.com/juju/juju/worker/gate"
@@ -23,6 +27,7 @@ type Facade interface {
 	AddSubnets(params.AddSubnetsParams) (params.ErrorResults, error)
 	ListSpaces() (params.DiscoverSpacesResults, error)
 	ListSubnets(params.SubnetsFilters) (params.ListSubnetsResults, error)
+	RemoveSubnets(params.Entities) (params.ErrorResults, error)
 }
 
 // NameFunc returns a string derived from base that is not contained in used.
@@ -45,6 +50,15 @@ type Config struct {
 	// Unlocker, if not nil, will be unlocked when the first discovery
 	// attempt completes successfully.
 	Unlocker gate.Unlocker
+
+	// RefreshInterval, if not zero, is the interval at which spaces
+	// are discovered again, so that changes made in the provider are
+	// reflected in the model.
+	RefreshInterval time.Duration
+
+	// Clock is used to wait between discovery attempts. It may be
+	// nil if RefreshInterval is zero.
+	Clock clock.Clock
 }
 
 // Validate returns an error if the config cannot be expected to
@@ -59,6 +73,12 @@ func (config Config) Validate() error {
 	if config.NewName == nil {
 		return errors.NotValidf("nil NewName")
 	}
+	if config.RefreshInterval < 0 {
+		return errors.NotValidf("negative RefreshInterval")
+	}
+	if config.RefreshInterval > 0 && config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	// missing Unlocker gate just means "don't bother notifying"
 	return nil
 }
@@ -76,9 +96,11 @@ type discoverspacesWorker struct {
 // supplied Unlocker will be Unlock()ed when the first complete
 // discovery and update succeeds.
 //
-// Once that update completes, the worker just waits to be Kill()ed.
-// We should probably poll for changes, really, but I'm making an
-// effort to preserve existing behaviour where possible.
+// Once that update completes, the worker discovers spaces again every
+// RefreshInterval, adding any new spaces and subnets to the model, and
+// removing missing subnets if the model's remove-missing-subnets
+// setting is true. If RefreshInterval is zero, the worker just waits
+// to be Kill()ed.
 func NewWorker(config Config) (worker.Worker, error) {
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -108,28 +130,32 @@ func (dw *discoverspacesWorker) Wait() error {
 
 func (dw *discoverspacesWorker) loop() (err error) {
 
-	// TODO(mfoord): we'll have a watcher here checking if we need to
-	// update the spaces/subnets definition.
 	// TODO(fwereade): for now, use a changes channel that apes the
 	// standard initial event behaviour, so we can make the loop
 	// follow the standard structure.
 	changes := make(chan struct{}, 1)
 	changes <- struct{}{}
 
+	// Discoveries after the first are triggered by the refresh timer.
+	var refresh <-chan time.Time
 	gate := dw.config.Unlocker
 	for {
 		select {
 		case <-dw.catacomb.Dying():
 			return dw.catacomb.ErrDying()
 		case <-changes:
-			if err := dw.handleSubnets(); err != nil {
-				return errors.Trace(err)
-			}
-			logger.Debugf("space discovery complete")
-			if gate != nil {
-				gate.Unlock()
-				gate = nil
-			}
+		case <-refresh:
+		}
+		if err := dw.handleSubnets(); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Debugf("space discovery complete")
+		if gate != nil {
+			gate.Unlock()
+			gate = nil
+		}
+		if dw.config.RefreshInterval > 0 {
+			refresh = dw.config.Clock.After(dw.config.RefreshInterval)
 		}
 	}
 }
@@ -172,8 +198,8 @@ func (dw *discoverspacesWorker) handleSubnets() error {
 		spaceNames.Add(space.Name)
 	}
 
-	// TODO(mfoord): we need to delete spaces and subnets that no longer
-	// exist, so long as they're not in use.
+	// TODO(mfoord): we need to delete spaces that no longer exist, so
+	// long as they're not in use.
 	var createSpacesArgs params.CreateSpacesParams
 	var addSubnetsArgs params.AddSubnetsParams
 	for _, space := range providerSpaces {
@@ -239,6 +265,60 @@ func (dw *discoverspacesWorker) handleSubnets() error {
 		return errors.Trace(err)
 	}
 
+	if dw.config.Environ.Config().RemoveMissingSubnets() {
+		if err := dw.removeMissingSubnets(providerSpaces, stateSubnets.Results); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	return nil
+}
+
+// removeMissingSubnets removes the subnets in state which have a
+// provider id, but are not among the given provider spaces' subnets.
+// Subnets which cannot be removed, e.g. because a machine has an
+// address in them, are logged and left in place.
+func (dw *discoverspacesWorker) removeMissingSubnets(providerSpaces []network.SpaceInfo, stateSubnets []params.Subnet) error {
+	providerSubnetIds := make(set.Strings)
+	for _, space := range providerSpaces {
+		for _, subnet := range space.Subnets {
+			providerSubnetIds.Add(string(subnet.ProviderId))
+		}
+	}
+	var args params.Entities
+	for _, subnet := range stateSubnets {
+		if subnet.ProviderId == "" || providerSubnetIds.Contains(subnet.ProviderId) {
+			continue
+		}
+		args.Entities = append(args.Entities, params.Entity{
+			Tag: names.NewSubnetTag(subnet.CIDR).String(),
+		})
+	}
+	if len(args.Entities) == 0 {
+		logger.Debugf("no missing subnets to remove")
+		return nil
+	}
+
+	result, err := dw.config.Facade.RemoveSubnets(args)
+	if errors.IsNotSupported(err) {
+		// The controller predates subnet removal.
+		logger.Debugf("not removing missing subnets: %v", err)
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "removing subnets failed")
+	}
+	if len(result.Results) != len(args.Entities) {
+		return errors.Errorf(
+			"unexpected response from RemoveSubnets: expected %d results, got %d",
+			len(args.Entities), len(result.Results),
+		)
+	}
+	for i, res := range result.Results {
+		if res.Error != nil {
+			logger.Warningf("cannot remove missing subnet %q: %v", args.Entities[i].Tag, res.Error)
+		}
+	}
+	logger.Debugf("removed missing subnets: %v", args)
 	return nil
 }
 
@@ -4,6 +4,7 @@
 package discoverspaces_test
 
 import (
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/api/base"
@@ -36,3 +37,7 @@ func fakeNewName(_ string, _ set.Strings) string {
 type fakeUnlocker struct {
 	gate.Unlocker
 }
+
+type fakeClock struct {
+	clock.Clock
+}
@@ -4,7 +4,10 @@
 package discoverspaces
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/discoverspaces"
@@ -20,6 +23,10 @@ type ManifoldConfig struct {
 	EnvironName   string
 	UnlockerName  string
 
+	// RefreshInterval and Clock are passed to the worker; see Config.
+	RefreshInterval time.Duration
+	Clock           clock.Clock
+
 	NewFacade func(base.APICaller) (Facade, error)
 	NewWorker func(Config) (worker.Worker, error)
 }
@@ -69,6 +76,9 @@ func startFunc(config ManifoldConfig) dependency.StartFunc {
 			Environ:  environ,
 			NewName:  network.ConvertSpaceName,
 			Unlocker: unlocker,
+
+			RefreshInterval: config.RefreshInterval,
+			Clock:           config.Clock,
 		})
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -7,6 +7,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -37,6 +39,7 @@ type WorkerSuite struct {
 
 	numCreateSpaceCalls uint32
 	numAddSubnetsCalls  uint32
+	numListSpacesCalls  uint32
 }
 
 type checkingFacade struct {
@@ -44,6 +47,7 @@ type checkingFacade struct {
 
 	createSpacesCallback func()
 	addSubnetsCallback   func()
+	listSpacesCallback   func()
 }
 
 func (cf *checkingFacade) CreateSpaces(args params.CreateSpacesParams) (results params.ErrorResults, err error) {
@@ -60,6 +64,13 @@ func (cf *checkingFacade) AddSubnets(args params.AddSubnetsParams) (params.Error
 	return cf.API.AddSubnets(args)
 }
 
+func (cf *checkingFacade) ListSpaces() (params.DiscoverSpacesResults, error) {
+	if cf.listSpacesCallback != nil {
+		cf.listSpacesCallback()
+	}
+	return cf.API.ListSpaces()
+}
+
 var _ = gc.Suite(&WorkerSuite{})
 
 func (s *WorkerSuite) SetUpTest(c *gc.C) {
@@ -79,6 +90,9 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		addSubnetsCallback: func() {
 			atomic.AddUint32(&s.numAddSubnetsCalls, 1)
 		},
+		listSpacesCallback: func() {
+			atomic.AddUint32(&s.numListSpacesCalls, 1)
+		},
 	}
 }
 
@@ -187,18 +201,82 @@ func (s *WorkerSuite) TestWorkerIgnoresExistingSpacesAndSubnets(c *gc.C) {
 	})
 }
 
+func (s *WorkerSuite) TestWorkerRefreshes(c *gc.C) {
+	dummy.SetSupportsSpaceDiscovery(true)
+	clock := jujutesting.NewClock(time.Time{})
+	worker, lock := s.startWorkerWithConfig(c, func(config *discoverspaces.Config) {
+		config.RefreshInterval = time.Hour
+		config.Clock = clock
+	})
+	defer workertest.CleanKill(c, worker)
+	select {
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("discovery never completed")
+	case <-lock.Unlocked():
+	}
+	c.Assert(atomic.LoadUint32(&s.numListSpacesCalls), gc.Equals, uint32(1))
+
+	c.Assert(clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Check(atomic.LoadUint32(&s.numListSpacesCalls), jc.GreaterThan, uint32(1))
+	s.assertDiscoveredSpaces(c)
+	// Spaces and subnets are only created once.
+	s.assertNumCalls(c, 1, 1)
+}
+
+func (s *WorkerSuite) TestWorkerKeepsMissingSubnetsByDefault(c *gc.C) {
+	dummy.SetSupportsSpaceDiscovery(true)
+	s.addMissingSubnet(c)
+
+	s.unlockCheck(c, func(c *gc.C) {
+		_, err := s.State.Subnet("10.0.0.0/24")
+		c.Assert(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *WorkerSuite) TestWorkerRemovesMissingSubnets(c *gc.C) {
+	dummy.SetSupportsSpaceDiscovery(true)
+	s.addMissingSubnet(c)
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"remove-missing-subnets": true,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.unlockCheck(c, func(c *gc.C) {
+		_, err := s.State.Subnet("10.0.0.0/24")
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+		s.assertDiscoveredSpaces(c)
+	})
+}
+
+// addMissingSubnet adds a subnet to state with a provider id
+// that the dummy provider does not report.
+func (s *WorkerSuite) addMissingSubnet(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:       "10.0.0.0/24",
+		ProviderId: "gone",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *WorkerSuite) startWorker(c *gc.C) (worker.Worker, gate.Lock) {
+	return s.startWorkerWithConfig(c, func(*discoverspaces.Config) {})
+}
+
+func (s *WorkerSuite) startWorkerWithConfig(c *gc.C, configure func(*discoverspaces.Config)) (worker.Worker, gate.Lock) {
 	// create fresh environ to see any injected broken-ness
 	environ, err := stateenvirons.GetNewEnvironFunc(environs.New)(s.State)
 	c.Assert(err, jc.ErrorIsNil)
 
 	lock := gate.NewLock()
-	worker, err := discoverspaces.NewWorker(discoverspaces.Config{
+	config := discoverspaces.Config{
 		Facade:   s.API,
 		Environ:  environ,
 		NewName:  network.ConvertSpaceName,
 		Unlocker: lock,
-	})
+	}
+	configure(&config)
+	worker, err := discoverspaces.NewWorker(config)
 	c.Assert(err, jc.ErrorIsNil)
 	return worker, lock
 }
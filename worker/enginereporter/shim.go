@@ -0,0 +1,14 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginereporter
+
+import (
+	"github.com/juju/juju/api/base"
+	apienginereport "github.com/juju/juju/api/enginereport"
+)
+
+// NewFacade returns a Facade backed by the EngineReport API.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return apienginereport.NewFacade(apiCaller), nil
+}
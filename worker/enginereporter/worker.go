@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package enginereporter provides a worker that periodically sends the
+// agent's dependency engine report to the controller, so that the
+// state of a sick agent can be inspected remotely.
+package enginereporter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+var logger = loggo.GetLogger("juju.worker.enginereporter")
+
+// DefaultPeriod is the time between engine reports sent by agents.
+const DefaultPeriod = 5 * time.Minute
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	SetReport(tag names.Tag, report map[string]interface{}) error
+}
+
+// Config defines the operation of an enginereporter worker.
+type Config struct {
+
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Tag identifies the agent whose report is sent.
+	Tag names.Tag
+
+	// Reporter supplies the report, and is usually the agent's
+	// dependency engine.
+	Reporter dependency.Reporter
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between reports.
+	Period time.Duration
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Tag == nil {
+		return errors.NotValidf("nil Tag")
+	}
+	if config.Reporter == nil {
+		return errors.NotValidf("nil Reporter")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that sends the configured Reporter's
+// report to the controller, once when started and subsequently every
+// Period.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &reportWorker{
+		config: config,
+	}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop())
+	}()
+	return w, nil
+}
+
+type reportWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *reportWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+			report := w.config.Reporter.Report()
+			if err := w.config.Facade.SetReport(w.config.Tag, report); err != nil {
+				return errors.Annotate(err, "cannot send engine report")
+			}
+			logger.Tracef("engine report sent for %s", names.ReadableString(w.config.Tag))
+		}
+		delay = w.config.Period
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *reportWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *reportWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginereporter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/enginereporter"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	fix := newFixture()
+	for i, test := range []struct {
+		mutate func(*enginereporter.Config)
+		err    string
+	}{{
+		func(config *enginereporter.Config) { config.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(config *enginereporter.Config) { config.Tag = nil },
+		"nil Tag not valid",
+	}, {
+		func(config *enginereporter.Config) { config.Reporter = nil },
+		"nil Reporter not valid",
+	}, {
+		func(config *enginereporter.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *enginereporter.Config) { config.Period = 0 },
+		"non-positive Period not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := fix.config()
+		test.mutate(&config)
+		_, err := enginereporter.NewWorker(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestReportsImmediately(c *gc.C) {
+	fix := newFixture()
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
+		fix.waitNoCall(c)
+	})
+	fix.facade.stub.CheckCalls(c, []testing.StubCall{{
+		"SetReport", []interface{}{names.NewMachineTag("42"), fix.report()},
+	}})
+}
+
+func (s *WorkerSuite) TestReportsAfterPeriod(c *gc.C) {
+	fix := newFixture()
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
+		fix.clock.Advance(time.Minute - time.Nanosecond)
+		fix.waitNoCall(c)
+		if err := fix.clock.WaitAdvance(time.Nanosecond, coretesting.LongWait, 1); err != nil {
+			c.Fatal(err)
+		}
+		fix.waitCall(c)
+		fix.waitNoCall(c)
+	})
+	fix.facade.stub.CheckCallNames(c, "SetReport", "SetReport")
+}
+
+func (s *WorkerSuite) TestReportError(c *gc.C) {
+	fix := newFixture()
+	fix.facade.stub.SetErrors(errors.New("blam"))
+	fix.dirtyTest(c, func(w worker.Worker) {
+		fix.waitCall(c)
+		err := workertest.CheckKilled(c, w)
+		c.Check(err, gc.ErrorMatches, "cannot send engine report: blam")
+	})
+	fix.facade.stub.CheckCallNames(c, "SetReport")
+}
+
+// workerFixture isolates an enginereporter worker for testing.
+type workerFixture struct {
+	facade *mockFacade
+	clock  *testing.Clock
+}
+
+func newFixture() workerFixture {
+	return workerFixture{
+		facade: &mockFacade{calls: make(chan struct{}, 1000)},
+		clock:  testing.NewClock(coretesting.ZeroTime()),
+	}
+}
+
+func (fix workerFixture) report() map[string]interface{} {
+	return map[string]interface{}{"state": "started"}
+}
+
+func (fix workerFixture) config() enginereporter.Config {
+	return enginereporter.Config{
+		Facade:   fix.facade,
+		Tag:      names.NewMachineTag("42"),
+		Reporter: mockReporter(fix.report()),
+		Clock:    fix.clock,
+		Period:   time.Minute,
+	}
+}
+
+type testFunc func(worker.Worker)
+
+func (fix workerFixture) cleanTest(c *gc.C, test testFunc) {
+	fix.runTest(c, test, true)
+}
+
+func (fix workerFixture) dirtyTest(c *gc.C, test testFunc) {
+	fix.runTest(c, test, false)
+}
+
+func (fix workerFixture) runTest(c *gc.C, test testFunc, checkWaitErr bool) {
+	w, err := enginereporter.NewWorker(fix.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := worker.Stop(w)
+		if checkWaitErr {
+			c.Check(err, jc.ErrorIsNil)
+		}
+	}()
+	test(w)
+}
+
+func (fix workerFixture) waitCall(c *gc.C) {
+	select {
+	case <-fix.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out")
+	}
+}
+
+func (fix workerFixture) waitNoCall(c *gc.C) {
+	select {
+	case <-fix.facade.calls:
+		c.Fatalf("unexpected SetReport call")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// mockFacade records (and notifies of) calls made to SetReport.
+type mockFacade struct {
+	stub  testing.Stub
+	calls chan struct{}
+}
+
+func (mock *mockFacade) SetReport(tag names.Tag, report map[string]interface{}) error {
+	mock.stub.AddCall("SetReport", tag, report)
+	mock.calls <- struct{}{}
+	return mock.stub.NextErr()
+}
+
+type mockReporter map[string]interface{}
+
+func (mock mockReporter) Report() map[string]interface{} {
+	return mock
+}
@@ -4,9 +4,11 @@
 package firewaller
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/firewaller"
@@ -150,6 +152,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.cidrs = change.cidrs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -168,7 +171,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
+		openedRules:  make([]network.IngressRule, 0),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -281,16 +284,21 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	cidrs, err := service.ExposedCIDRs()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
 		fw:          fw,
 		application: service,
 		exposed:     exposed,
+		cidrs:       cidrs,
 		unitds:      make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, cidrs)
 		},
 	})
 	if err != nil {
@@ -320,6 +328,11 @@ func (fw *Firewaller) reconcileGlobal() error {
 				continue
 			}
 			if unitd.serviced.exposed {
+				rule := network.NewIngressRule(portRange, unitd.serviced.cidrs...)
+				if !rule.Unrestricted() {
+					logger.Warningf("cannot restrict access to %v in global firewall mode", rule)
+					continue
+				}
 				collector[portRange] = true
 			}
 		}
@@ -379,31 +392,29 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		initialRules, err := instanceIngressRules(instances[0], machineId)
 		if err != nil {
 			return err
 		}
 
 		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
+		toOpen := diffRules(machined.openedRules, initialRules)
+		toClose := diffRules(initialRules, machined.openedRules)
 		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
+			logger.Infof("opening instance ingress rules %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			if err := openInstanceIngressRules(instances[0], machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
 		}
 		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
+			logger.Infof("closing instance ingress rules %v for %q",
 				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+			if err := closeInstanceIngressRules(instances[0], machineId, toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
 		}
 	}
 	return nil
@@ -525,7 +536,7 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
 	// Gather ports to open and close.
-	want := []network.PortRange{}
+	want := []network.IngressRule{}
 	for portRange, unitTag := range machined.definedPorts {
 		unitd, known := machined.unitds[unitTag]
 		if !known {
@@ -533,14 +544,17 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 			continue
 		}
 		if unitd.serviced.exposed {
-			want = append(want, portRange)
+			want = append(want, network.NewIngressRule(portRange, unitd.serviced.cidrs...))
 		}
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	toOpen := diffRules(want, machined.openedRules)
+	toClose := diffRules(machined.openedRules, want)
+	machined.openedRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		return fw.flushGlobalPorts(
+			unrestrictedPortRanges(toOpen, "global firewall mode"),
+			unrestrictedPortRanges(toClose, "global firewall mode"),
+		)
 	}
 	return fw.flushInstancePorts(machined, toOpen, toClose)
 }
@@ -585,7 +599,7 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) er
 }
 
 // flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -611,24 +625,86 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	}
 	// Open and close the ports.
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		if err := openInstanceIngressRules(instances[0], machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if err := closeInstanceIngressRules(instances[0], machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		logger.Infof("closed ingress rules %v on %q", toClose, machined.tag)
 	}
 	return nil
 }
 
+// instanceIngressRules returns the ingress rules applied to the
+// instance. Instances that do not support ingress rules are
+// treated as having their open ports accessible from anywhere.
+func instanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if rulesInst, ok := inst.(instance.InstanceIngressRules); ok {
+		return rulesInst.IngressRules(machineId)
+	}
+	ports, err := inst.Ports(machineId)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]network.IngressRule, len(ports))
+	for i, portRange := range ports {
+		rules[i] = network.NewIngressRule(portRange)
+	}
+	return rules, nil
+}
+
+// openInstanceIngressRules opens the given ingress rules on the
+// instance. If the instance does not support ingress rules, only
+// the unrestricted rules are opened, as ports.
+func openInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	network.SortIngressRules(rules)
+	if rulesInst, ok := inst.(instance.InstanceIngressRules); ok {
+		return rulesInst.OpenIngressRules(machineId, rules)
+	}
+	ports := unrestrictedPortRanges(rules, fmt.Sprintf("instance %q", inst.Id()))
+	if len(ports) == 0 {
+		return nil
+	}
+	return inst.OpenPorts(machineId, ports)
+}
+
+// closeInstanceIngressRules closes the given ingress rules on the
+// instance. If the instance does not support ingress rules, only
+// the unrestricted rules are closed, as ports.
+func closeInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	network.SortIngressRules(rules)
+	if rulesInst, ok := inst.(instance.InstanceIngressRules); ok {
+		return rulesInst.CloseIngressRules(machineId, rules)
+	}
+	ports := unrestrictedPortRanges(rules, fmt.Sprintf("instance %q", inst.Id()))
+	if len(ports) == 0 {
+		return nil
+	}
+	return inst.ClosePorts(machineId, ports)
+}
+
+// unrestrictedPortRanges returns the port ranges of the given rules
+// which allow access from anywhere. Rules restricting access to some
+// sources are skipped, with a warning, as they cannot be enforced
+// by the named firewall.
+func unrestrictedPortRanges(rules []network.IngressRule, firewall string) []network.PortRange {
+	var ports []network.PortRange
+	for _, rule := range rules {
+		if !rule.Unrestricted() {
+			logger.Warningf("cannot restrict access to %v in %s", rule, firewall)
+			continue
+		}
+		ports = append(ports, rule.PortRange)
+	}
+	return ports
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -723,7 +799,7 @@ type machineData struct {
 	fw          *Firewaller
 	tag         names.MachineTag
 	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	openedRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -773,10 +849,12 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag and CIDRs for one
+// specific service.
 type exposedChange struct {
 	serviced *serviceData
 	exposed  bool
+	cidrs    []string
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -785,11 +863,12 @@ type serviceData struct {
 	fw          *Firewaller
 	application *firewaller.Application
 	exposed     bool
+	cidrs       []string
 	unitds      map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag and CIDRs for changes.
+func (sd *serviceData) watchLoop(exposed bool, cidrs []string) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -815,13 +894,18 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			changeCIDRs, err := sd.application.ExposedCIDRs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if change == exposed && sameCIDRs(changeCIDRs, cidrs) {
 				continue
 			}
 
 			exposed = change
+			cidrs = changeCIDRs
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, changeCIDRs}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -839,6 +923,26 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
+// sameCIDRs reports whether a and b hold the same CIDRs.
+func sameCIDRs(a, b []string) bool {
+	setA, setB := set.NewStrings(a...), set.NewStrings(b...)
+	return setA.Size() == setB.Size() && setA.Difference(setB).IsEmpty()
+}
+
+// diffRules returns all the ingress rules that exist in A but not B.
+func diffRules(A, B []network.IngressRule) (missing []network.IngressRule) {
+next:
+	for _, a := range A {
+		for _, b := range B {
+			if a.String() == b.String() {
+				continue next
+			}
+		}
+		missing = append(missing, a)
+	}
+	return
+}
+
 // diffRanges returns all the port rangess that exist in A but not B.
 func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
 next:
@@ -193,6 +193,36 @@ func (s *machineSuite) TestLongPollIntervalWhenHasAllInstanceInfo(c *gc.C) {
 	clock.CheckCall(c, 0, "After", LongPoll)
 }
 
+func (s *machineSuite) TestShortPollAfterInstanceChange(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: sequenceInstanceInfoGetter(c, "i1234", "running", "running", "stopping"),
+		dyingc:          make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		refresh:    func() error { return nil },
+		addresses:  testAddrs,
+		life:       params.Alive,
+		status:     status.Started,
+	}
+	died := make(chan machine)
+
+	clock := newTestClock()
+	go runMachine(context, m, nil, died, clock)
+	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
+	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
+	c.Assert(clock.WaitAdvance(2*ShortPoll, 0, 1), jc.ErrorIsNil)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	c.Assert(m.instStatusInfo, gc.Equals, "stopping")
+	clock.CheckCall(c, 0, "After", LongPoll)
+	clock.CheckCall(c, 1, "After", LongPoll)
+	clock.CheckCall(c, 2, "After", 2*ShortPoll)
+	clock.CheckCall(c, 3, "After", 4*ShortPoll)
+}
+
 func testRunMachine(
 	c *gc.C,
 	addrs []network.Address,
@@ -306,7 +336,6 @@ func (*machineSuite) TestTerminatingErrors(c *gc.C) {
 	}
 }
 
-//
 // testTerminatingErrors checks that when a testMachine is
 // changed with the given mutate function, the machine goroutine
 // will die having called its context's killAll function with the
@@ -357,6 +386,26 @@ func instanceInfoGetter(
 	}
 }
 
+// sequenceInstanceInfoGetter returns a function which reports the
+// testAddrs and, on successive calls, the given instance statuses
+// for the instance with the expected id. Once the statuses are
+// exhausted the last is reported.
+func sequenceInstanceInfoGetter(
+	c *gc.C, expectId instance.Id, instanceStatuses ...string,
+) func(id instance.Id) (instanceInfo, error) {
+	var mu sync.Mutex
+	return func(id instance.Id) (instanceInfo, error) {
+		c.Check(id, gc.Equals, expectId)
+		mu.Lock()
+		defer mu.Unlock()
+		instanceStatus := instanceStatuses[0]
+		if len(instanceStatuses) > 1 {
+			instanceStatuses = instanceStatuses[1:]
+		}
+		return instanceInfo{testAddrs, instance.InstanceStatus{Status: status.Unknown, Message: instanceStatus}}, nil
+	}
+}
+
 type testMachineContext struct {
 	killErr         error
 	getInstanceInfo func(instance.Id) (instanceInfo, error)
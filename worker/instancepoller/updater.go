@@ -26,7 +26,10 @@ var logger = loggo.GetLogger("juju.worker.instancepoller")
 // with an exponent of ShortPollBackoff until a maximum(ish) of LongPoll.
 //
 // When a machine has an address and is started LongPoll will be used to
-// check that the instance address or status has not changed.
+// check that the instance address or status has not changed. If the
+// address or status of such a machine does change, it is polled at
+// ShortPoll intervals again, backing off to LongPoll, as further
+// changes are likely to follow.
 var (
 	ShortPoll        = 1 * time.Second
 	ShortPollBackoff = 2.0
@@ -175,11 +178,23 @@ func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{},
 	// a machine's address and machine agent to start, and a long one when it already
 	// has an address and the machine agent is started.
 	pollInterval := ShortPoll
+	// recentlyChanged is true while backing off after
+	// a change to a machine that was already started.
+	recentlyChanged := false
+	polled := false
+
 	pollInstance := func() error {
-		instInfo, err := pollInstanceInfo(context, m)
+		instInfo, changed, err := pollInstanceInfo(context, m)
 		if err != nil {
 			return err
 		}
+		if changed && polled {
+			// The instance has changed since we last polled it, so poll
+			// frequently again for a while.
+			pollInterval = ShortPoll
+			recentlyChanged = true
+		}
+		polled = true
 
 		machineStatus := status.Pending
 		if err == nil {
@@ -194,15 +209,17 @@ func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{},
 		// the extra condition below (checking allocating/pending) is here to improve user experience
 		// without it the instance status will say "pending" for +10 minutes after the agent comes up to "started"
 		if instInfo.status.Status != status.Allocating && instInfo.status.Status != status.Pending {
-			if len(instInfo.addresses) > 0 && machineStatus == status.Started {
+			if len(instInfo.addresses) > 0 && machineStatus == status.Started && !recentlyChanged {
 				// We've got at least one address and a status and instance is started, so poll infrequently.
 				pollInterval = LongPoll
 			} else if pollInterval < LongPoll {
-				// We have no addresses or not started - poll increasingly rarely
-				// until we do.
+				// We have no addresses or not started, or the instance
+				// has recently changed - poll increasingly rarely until
+				// we do, or it settles.
 				pollInterval = time.Duration(float64(pollInterval) * ShortPollBackoff)
-				if pollInterval > LongPoll {
+				if pollInterval >= LongPoll {
 					pollInterval = LongPoll
+					recentlyChanged = false
 				}
 			}
 		}
@@ -236,25 +253,26 @@ func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{},
 }
 
 // pollInstanceInfo checks the current provider addresses and status
-// for the given machine's instance, and sets them on the machine if they've changed.
-func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo, err error) {
+// for the given machine's instance, and sets them on the machine if they've
+// changed, reporting whether they did.
+func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo, changed bool, err error) {
 	instInfo = instanceInfo{}
 	instId, err := m.InstanceId()
 	// We can't ask the machine for its addresses if it isn't provisioned yet.
 	if params.IsCodeNotProvisioned(err) {
-		return instanceInfo{}, err
+		return instanceInfo{}, false, err
 	}
 	if err != nil {
-		return instanceInfo{}, errors.Annotate(err, "cannot get machine's instance id")
+		return instanceInfo{}, false, errors.Annotate(err, "cannot get machine's instance id")
 	}
 	instInfo, err = context.instanceInfo(instId)
 	if err != nil {
 		// TODO (anastasiamac 2016-02-01) This does not look like it needs to be removed now.
 		if params.IsCodeNotImplemented(err) {
-			return instanceInfo{}, err
+			return instanceInfo{}, false, err
 		}
 		logger.Warningf("cannot get instance info for instance %q: %v", instId, err)
-		return instInfo, nil
+		return instInfo, false, nil
 	}
 	if instStat, err := m.InstanceStatus(); err != nil {
 		// This should never occur since the machine is provisioned.
@@ -271,25 +289,27 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 			logger.Infof("machine %q instance status changed from %q to %q", m.Id(), currentInstStatus, instInfo.status)
 			if err = m.SetInstanceStatus(instInfo.status.Status, instInfo.status.Message, nil); err != nil {
 				logger.Errorf("cannot set instance status on %q: %v", m, err)
-				return instanceInfo{}, err
+				return instanceInfo{}, false, err
 			}
+			changed = true
 		}
 
 	}
 	if m.Life() != params.Dead {
 		providerAddresses, err := m.ProviderAddresses()
 		if err != nil {
-			return instanceInfo{}, err
+			return instanceInfo{}, false, err
 		}
 		if !addressesEqual(providerAddresses, instInfo.addresses) {
 			logger.Infof("machine %q has new addresses: %v", m.Id(), instInfo.addresses)
 			if err := m.SetProviderAddresses(instInfo.addresses...); err != nil {
 				logger.Errorf("cannot set addresses on %q: %v", m, err)
-				return instanceInfo{}, err
+				return instanceInfo{}, false, err
 			}
+			changed = true
 		}
 	}
-	return instInfo, nil
+	return instInfo, changed, nil
 }
 
 // addressesEqual compares the addresses of the machine and the instance information.
@@ -9,4 +9,8 @@
 //   - prints out all the goroutines in the agent
 // * `/debug/pprof/heap?debug=1`
 //   - prints out the heap profile
+// * `/depengine/`
+//   - prints out the dependency engine report
+// * `/agent/config`
+//   - prints out the agent's current configuration, without secrets
 package introspection
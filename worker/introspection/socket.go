@@ -29,11 +29,21 @@ type DepEngineReporter interface {
 	Report() map[string]interface{}
 }
 
+// AgentConfigReporter provides insight into the current configuration
+// of the agent.
+type AgentConfigReporter interface {
+	// Report returns a map describing the agent's current configuration.
+	// It must not include any secrets, and is expected to be
+	// goroutine-safe.
+	Report() map[string]interface{}
+}
+
 // Config describes the arguments required to create the introspection worker.
 type Config struct {
-	SocketName         string
-	Reporter           DepEngineReporter
-	PrometheusGatherer prometheus.Gatherer
+	SocketName          string
+	Reporter            DepEngineReporter
+	AgentConfigReporter AgentConfigReporter
+	PrometheusGatherer  prometheus.Gatherer
 }
 
 // Validate checks the config values to assert they are valid to create the worker.
@@ -49,11 +59,12 @@ func (c *Config) Validate() error {
 
 // socketListener is a worker and constructed with NewWorker.
 type socketListener struct {
-	tomb               tomb.Tomb
-	listener           *net.UnixListener
-	reporter           DepEngineReporter
-	prometheusGatherer prometheus.Gatherer
-	done               chan struct{}
+	tomb                tomb.Tomb
+	listener            *net.UnixListener
+	reporter            DepEngineReporter
+	agentConfigReporter AgentConfigReporter
+	prometheusGatherer  prometheus.Gatherer
+	done                chan struct{}
 }
 
 // NewWorker starts an http server listening on an abstract domain socket
@@ -79,10 +90,11 @@ func NewWorker(config Config) (worker.Worker, error) {
 	logger.Debugf("introspection worker listening on %q", path)
 
 	w := &socketListener{
-		listener:           l,
-		reporter:           config.Reporter,
-		prometheusGatherer: config.PrometheusGatherer,
-		done:               make(chan struct{}),
+		listener:            l,
+		reporter:            config.Reporter,
+		agentConfigReporter: config.AgentConfigReporter,
+		prometheusGatherer:  config.PrometheusGatherer,
+		done:                make(chan struct{}),
 	}
 	go w.serve()
 	go w.run()
@@ -96,6 +108,7 @@ func (w *socketListener) serve() {
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
 	mux.Handle("/depengine/", http.HandlerFunc(w.depengineReport))
+	mux.Handle("/agent/config", http.HandlerFunc(w.agentConfigReport))
 	mux.Handle("/metrics", promhttp.HandlerFor(w.prometheusGatherer, promhttp.HandlerOpts{}))
 
 	srv := http.Server{
@@ -146,3 +159,22 @@ func (s *socketListener) depengineReport(w http.ResponseWriter, r *http.Request)
 	fmt.Fprint(w, "Dependency Engine Report\n\n")
 	w.Write(bytes)
 }
+
+func (s *socketListener) agentConfigReport(w http.ResponseWriter, r *http.Request) {
+	if s.agentConfigReporter == nil {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, "missing agent config reporter")
+		return
+	}
+	bytes, err := yaml.Marshal(s.agentConfigReporter.Report())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	fmt.Fprint(w, "Agent Config Report\n\n")
+	w.Write(bytes)
+}
@@ -51,10 +51,11 @@ func (s *suite) TestStartStop(c *gc.C) {
 type introspectionSuite struct {
 	testing.IsolationSuite
 
-	name     string
-	worker   worker.Worker
-	reporter introspection.DepEngineReporter
-	gatherer prometheus.Gatherer
+	name           string
+	worker         worker.Worker
+	reporter       introspection.DepEngineReporter
+	configReporter introspection.AgentConfigReporter
+	gatherer       prometheus.Gatherer
 }
 
 var _ = gc.Suite(&introspectionSuite{})
@@ -65,6 +66,7 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 	}
 	s.IsolationSuite.SetUpTest(c)
 	s.reporter = nil
+	s.configReporter = nil
 	s.worker = nil
 	s.gatherer = newPrometheusGatherer()
 	s.startWorker(c)
@@ -73,9 +75,10 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 func (s *introspectionSuite) startWorker(c *gc.C) {
 	s.name = fmt.Sprintf("introspection-test-%d", os.Getpid())
 	w, err := introspection.NewWorker(introspection.Config{
-		SocketName:         s.name,
-		Reporter:           s.reporter,
-		PrometheusGatherer: s.gatherer,
+		SocketName:          s.name,
+		Reporter:            s.reporter,
+		AgentConfigReporter: s.configReporter,
+		PrometheusGatherer:  s.gatherer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.worker = w
@@ -132,6 +135,27 @@ func (s *introspectionSuite) TestEngineReporter(c *gc.C) {
 	matches(c, buf, "working: true")
 }
 
+func (s *introspectionSuite) TestMissingAgentConfigReporter(c *gc.C) {
+	buf := s.call(c, "/agent/config")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "missing agent config reporter")
+}
+
+func (s *introspectionSuite) TestAgentConfigReporter(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.configReporter = &reporter{
+		values: map[string]interface{}{
+			"tag": "machine-42",
+		},
+	}
+	s.startWorker(c)
+	buf := s.call(c, "/agent/config")
+
+	matches(c, buf, "200 OK")
+	matches(c, buf, "Agent Config Report")
+	matches(c, buf, "tag: machine-42")
+}
+
 func (s *introspectionSuite) TestPrometheusMetrics(c *gc.C) {
 	buf := s.call(c, "/metrics")
 	c.Assert(buf, gc.NotNil)
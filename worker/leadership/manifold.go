@@ -59,7 +59,9 @@ func startFunc(config ManifoldConfig) dependency.StartFunc {
 	}
 }
 
-// NewManifoldWorker wraps NewTracker for the convenience of startFunc. It
+// NewManifoldWorker wraps NewLeaseTracker for the convenience of startFunc,
+// using the leadership lease settings configured for the model, or the
+// supplied guarantee if the controller does not report them. It
 // exists primarily to be patched out via NewManifoldWorker for ease of testing,
 // and is not itself directly tested. It would almost certainly be better to
 // pass the constructor dependencies in as explicit manifold config.
@@ -70,7 +72,14 @@ var NewManifoldWorker = func(agent agent.Agent, apiCaller base.APICaller, clock
 		return nil, fmt.Errorf("expected a unit tag; got %q", tag)
 	}
 	claimer := leadership.NewClient(apiCaller)
-	return NewTracker(unitTag, claimer, clock, guarantee), nil
+	settings, err := leadership.GetLeaseSettings(apiCaller)
+	if errors.IsNotSupported(err) {
+		logger.Debugf("controller does not report leadership lease settings; using defaults")
+		return NewTracker(unitTag, claimer, clock, guarantee), nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewLeaseTracker(unitTag, claimer, clock, settings.LeaseDuration, settings.RenewalInterval), nil
 }
 
 // outputFunc extracts the coreleadership.Tracker from a *Tracker passed in as a Worker.
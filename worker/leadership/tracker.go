@@ -24,6 +24,7 @@ type Tracker struct {
 	applicationName string
 	clock           clock.Clock
 	duration        time.Duration
+	leaseDuration   time.Duration
 	isMinion        bool
 
 	claimLease        chan struct{}
@@ -44,6 +45,16 @@ type Tracker struct {
 // calls to the supplied manager (which may very well be on the other side of
 // a network connection).
 func NewTracker(tag names.UnitTag, claimer leadership.Claimer, clock clock.Clock, duration time.Duration) *Tracker {
+	return NewLeaseTracker(tag, claimer, clock, 2*duration, duration)
+}
+
+// NewLeaseTracker returns a *Tracker that attempts to claim and retain
+// service leadership for the supplied unit. It will claim leadership for
+// leaseDuration, and once it's leader it will renew leadership every
+// renewalInterval, which must be shorter than leaseDuration.
+// Successful leadership claims on the resulting Tracker will thus
+// guarantee leadership for the difference between the two.
+func NewLeaseTracker(tag names.UnitTag, claimer leadership.Claimer, clock clock.Clock, leaseDuration, renewalInterval time.Duration) *Tracker {
 	unitName := tag.Id()
 	serviceName, _ := names.UnitApplication(unitName)
 	t := &Tracker{
@@ -51,7 +62,8 @@ func NewTracker(tag names.UnitTag, claimer leadership.Claimer, clock clock.Clock
 		applicationName:   serviceName,
 		claimer:           claimer,
 		clock:             clock,
-		duration:          duration,
+		duration:          leaseDuration - renewalInterval,
+		leaseDuration:     leaseDuration,
 		claimTickets:      make(chan chan bool),
 		waitLeaderTickets: make(chan chan bool),
 		waitMinionTickets: make(chan chan bool),
@@ -166,9 +178,8 @@ func (t *Tracker) loop() error {
 // latest known reality.
 func (t *Tracker) refresh() error {
 	logger.Tracef("checking %s for %s leadership", t.unitName, t.applicationName)
-	leaseDuration := 2 * t.duration
-	untilTime := t.clock.Now().Add(leaseDuration)
-	err := t.claimer.ClaimLeadership(t.applicationName, t.unitName, leaseDuration)
+	untilTime := t.clock.Now().Add(t.leaseDuration)
+	err := t.claimer.ClaimLeadership(t.applicationName, t.unitName, t.leaseDuration)
 	switch {
 	case err == nil:
 		return t.setLeader(untilTime)
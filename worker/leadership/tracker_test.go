@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -114,6 +115,37 @@ func (s *TrackerSuite) TestOnLeaderSuccess(c *gc.C) {
 	}})
 }
 
+func (s *TrackerSuite) TestLeaseTracker(c *gc.C) {
+	tracker := leadership.NewLeaseTracker(s.unitTag, s.claimer, s.clock, 20*time.Second, 5*time.Second)
+	s.AddCleanup(func(c *gc.C) {
+		workertest.DirtyKill(c, tracker)
+	})
+	c.Check(tracker.ClaimDuration(), gc.Equals, 15*time.Second)
+
+	// Check the first ticket succeeds...
+	assertClaimLeader(c, tracker, true)
+
+	// ...and that the lease is renewed once the renewal interval has
+	// passed.
+	err := s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	assertClaimLeader(c, tracker, true)
+
+	// Stop the tracker before trying to look at its stub.
+	workertest.CleanKill(c, tracker)
+	s.claimer.CheckCalls(c, []testing.StubCall{{
+		FuncName: "ClaimLeadership",
+		Args: []interface{}{
+			"led-service", "led-service/123", 20 * time.Second,
+		},
+	}, {
+		FuncName: "ClaimLeadership",
+		Args: []interface{}{
+			"led-service", "led-service/123", 20 * time.Second,
+		},
+	}})
+}
+
 func (s *TrackerSuite) TestOnLeaderFailure(c *gc.C) {
 	s.claimer.Stub.SetErrors(coreleadership.ErrClaimDenied, nil)
 	tracker := s.newTracker()
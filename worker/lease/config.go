@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/core/lease"
 )
@@ -42,6 +43,12 @@ type ManagerConfig struct {
 	// MaxSleep is the longest time the Manager should sleep before
 	// refreshing its client's leases and checking for expiries.
 	MaxSleep time.Duration
+
+	// Pinned, if not nil, returns the names of the leases that must not
+	// be expired, even once their holders stop extending them. It is
+	// consulted whenever the Manager checks for expiries, so changes
+	// take effect within MaxSleep.
+	Pinned func() (set.Strings, error)
 }
 
 // Validate returns an error if the configuration contains invalid information
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
 	corelease "github.com/juju/juju/core/lease"
@@ -65,6 +66,10 @@ type Fixture struct {
 	// reported leases to change.
 	expectCalls []call
 
+	// pinned, if not nil, contains the names of the leases the manager
+	// should consider pinned.
+	pinned []string
+
 	// expectDirty should be set for tests that purposefully abuse the manager
 	// to the extent that it returns an error on Wait(); tests that don't set
 	// this flag will check that the manager's shutdown error is nil.
@@ -76,12 +81,18 @@ type Fixture struct {
 func (fix *Fixture) RunTest(c *gc.C, test func(*lease.Manager, *testing.Clock)) {
 	clock := testing.NewClock(defaultClockStart)
 	client := NewClient(fix.leases, fix.expectCalls)
-	manager, err := lease.NewManager(lease.ManagerConfig{
+	config := lease.ManagerConfig{
 		Clock:     clock,
 		Client:    client,
 		Secretary: Secretary{},
 		MaxSleep:  defaultMaxSleep,
-	})
+	}
+	if fix.pinned != nil {
+		config.Pinned = func() (set.Strings, error) {
+			return set.NewStrings(fix.pinned...), nil
+		}
+	}
+	manager, err := lease.NewManager(config)
 	c.Assert(err, jc.ErrorIsNil)
 	defer func() {
 		// Dirty tests will probably have stopped the manager anyway, but no
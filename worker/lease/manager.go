@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/worker/catacomb"
@@ -58,6 +59,11 @@ type Manager struct {
 
 	// blocks is used to deliver expiry block requests to the loop.
 	blocks chan block
+
+	// pinned holds the names of the leases that were pinned when
+	// the loop last checked for expiries. It is only accessed by the
+	// loop goroutine.
+	pinned set.Strings
 }
 
 // Kill is part of the worker.Worker interface.
@@ -208,10 +214,16 @@ func (manager *Manager) WaitUntilExpired(leaseName string) error {
 func (manager *Manager) nextTick() <-chan time.Time {
 	now := manager.config.Clock.Now()
 	nextTick := now.Add(manager.config.MaxSleep)
-	for _, info := range manager.config.Client.Leases() {
+	for name, info := range manager.config.Client.Leases() {
 		if info.Expiry.After(nextTick) {
 			continue
 		}
+		if manager.pinned.Contains(name) {
+			// Pinned leases will not be expired, so there's no
+			// point waking for them; pins are rechecked after
+			// MaxSleep at the latest.
+			continue
+		}
 		nextTick = info.Expiry
 	}
 	logger.Debugf("waking to check leases at %s", nextTick)
@@ -233,6 +245,13 @@ func (manager *Manager) tick() error {
 		return errors.Trace(err)
 	}
 	leases := client.Leases()
+	if manager.config.Pinned != nil {
+		pinned, err := manager.config.Pinned()
+		if err != nil {
+			return errors.Annotate(err, "cannot read pinned leases")
+		}
+		manager.pinned = pinned
+	}
 
 	// Sort lease names so we expire in a predictable order for the tests.
 	names := make([]string, 0, len(leases))
@@ -247,6 +266,10 @@ func (manager *Manager) tick() error {
 		if leases[name].Expiry.After(now) {
 			continue
 		}
+		if manager.pinned.Contains(name) {
+			logger.Tracef("not expiring pinned lease %q", name)
+			continue
+		}
 		switch err := client.ExpireLease(name); err {
 		case nil, lease.ErrInvalid:
 		default:
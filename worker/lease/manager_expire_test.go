@@ -39,6 +39,45 @@ func (s *ExpireSuite) TestStartup_ExpiryInPast(c *gc.C) {
 	fix.RunTest(c, func(_ *lease.Manager, _ *testing.Clock) {})
 }
 
+func (s *ExpireSuite) TestStartup_ExpiryInPast_Pinned(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{Expiry: offset(-time.Second)},
+		},
+		pinned: []string{"redis"},
+		expectCalls: []call{{
+			method: "Refresh",
+		}},
+	}
+	fix.RunTest(c, func(_ *lease.Manager, _ *testing.Clock) {})
+}
+
+func (s *ExpireSuite) TestExpire_Pinned_TimePasses(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{Expiry: offset(time.Second)},
+			"store": corelease.Info{Expiry: offset(time.Second)},
+		},
+		pinned: []string{"store"},
+		expectCalls: []call{{
+			method: "Refresh",
+		}, {
+			method: "ExpireLease",
+			args:   []interface{}{"redis"},
+			callback: func(leases map[string]corelease.Info) {
+				delete(leases, "redis")
+			},
+		}},
+	}
+	fix.RunTest(c, func(_ *lease.Manager, clock *testing.Clock) {
+		clock.Advance(time.Second)
+		// The pinned lease must not cause the manager to wake again
+		// before MaxSleep has passed.
+		waitAlarms(c, clock, 1)
+		clock.Advance(almostSeconds(3600))
+	})
+}
+
 func (s *ExpireSuite) TestStartup_ExpiryInFuture(c *gc.C) {
 	fix := &Fixture{
 		leases: map[string]corelease.Info{
@@ -14,11 +14,18 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 )
 
 var logger = loggo.GetLogger("juju.worker.logforwarder")
 
+// DefaultBufferSize is the number of batches of log records that a
+// LogForwarder reads ahead of its sink, when
+// OpenLogForwarderArgs.BufferSize is zero. Once the buffer is full,
+// no more records are read from the stream until the sink catches up.
+const DefaultBufferSize = 64
+
 // LogStream streams log entries from a log source (e.g. the Juju controller).
 type LogStream interface {
 	// Next returns the next batch of log records from the stream.
@@ -40,9 +47,6 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
 type LogForwarder struct {
@@ -64,6 +68,11 @@ type OpenLogForwarderArgs struct {
 	// LogForwardConfig is the API used to access log forwarding config.
 	LogForwardConfig LogForwardConfig
 
+	// ControllerConfig is the API used to access the controller
+	// config. It is used instead of LogForwardConfig when
+	// OpenControllerSink is set.
+	ControllerConfig ControllerConfig
+
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
@@ -74,12 +83,21 @@ type OpenLogForwarderArgs struct {
 	// will be wrapped.
 	OpenSink LogSinkFn
 
+	// OpenControllerSink, if set, is used instead of OpenSink to
+	// open a log sink configured by the controller config.
+	OpenControllerSink ControllerLogSinkFn
+
+	// BufferSize is the number of batches of log records that may
+	// be read from the stream ahead of the sink. If it is zero,
+	// DefaultBufferSize is used.
+	BufferSize int
+
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
 	OpenLogStream LogStreamFn
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -93,6 +111,10 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 		return nil
 	}
 
+	if lf.args.OpenControllerSink != nil {
+		return lf.processNewControllerConfig(closeExisting)
+	}
+
 	// Get the new config and set up log forwarding if enabled.
 	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
 	if err != nil {
@@ -126,10 +148,44 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	lf.enabledCh <- true
+	lf.notifyEnabled()
 	return sink, nil
 }
 
+// processNewControllerConfig opens the log sink configured by the
+// current controller config, closing the existing one. It must be
+// called with lf.mu held.
+func (lf *LogForwarder) processNewControllerConfig(closeExisting func() error) (SendCloser, error) {
+	cfg, err := lf.args.ControllerConfig.ControllerConfig()
+	if err != nil {
+		closeExisting()
+		return nil, errors.Trace(err)
+	}
+	if err := closeExisting(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sink, err := lf.args.OpenControllerSink(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if sink == nil {
+		logger.Infof("config change - log forwarding to %s not enabled", lf.args.Name)
+		return nil, nil
+	}
+	lf.notifyEnabled()
+	return newTrackingSink(sink, lf.args.Name, lf.args.Caller), nil
+}
+
+// notifyEnabled tells the stream reader that forwarding is enabled.
+// The reader only needs to be told once, so this never blocks when
+// a notification is already pending.
+func (lf *LogForwarder) notifyEnabled() {
+	select {
+	case lf.enabledCh <- true:
+	default:
+	}
+}
+
 // waitForEnabled returns true if streaming is enabled.
 // Otherwise if blocks and waits for enabled to be true.
 func (lf *LogForwarder) waitForEnabled() (bool, error) {
@@ -149,7 +205,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %s", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -174,8 +230,22 @@ func NewLogForwarder(args OpenLogForwarderArgs) (*LogForwarder, error) {
 	return lf, nil
 }
 
+// streamResult holds a batch of records read from the log stream, or
+// the error that stopped the stream.
+type streamResult struct {
+	records []logfwd.Record
+	err     error
+}
+
+func (lf *LogForwarder) watchConfig() (watcher.NotifyWatcher, error) {
+	if lf.args.OpenControllerSink != nil {
+		return lf.args.ControllerConfig.WatchControllerConfig()
+	}
+	return lf.args.LogForwardConfig.WatchForLogForwardConfigChanges()
+}
+
 func (lf *LogForwarder) loop() error {
-	configWatcher, err := lf.args.LogForwardConfig.WatchForLogForwardConfigChanges()
+	configWatcher, err := lf.watchConfig()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -183,9 +253,17 @@ func (lf *LogForwarder) loop() error {
 		return errors.Trace(err)
 	}
 
-	records := make(chan []logfwd.Record)
-	var stream LogStream
+	// The records are buffered so that a slow sink does not hold up
+	// reading from the stream until the buffer is full. Errors are
+	// passed along with the records, so that the records read before
+	// an error are still sent.
+	bufferSize := lf.args.BufferSize
+	if bufferSize == 0 {
+		bufferSize = DefaultBufferSize
+	}
+	records := make(chan streamResult, bufferSize)
 	go func() {
+		var stream LogStream
 		for {
 			enabled, err := lf.waitForEnabled()
 			if err == tomb.ErrDying {
@@ -194,6 +272,7 @@ func (lf *LogForwarder) loop() error {
 			if !enabled {
 				continue
 			}
+			var result streamResult
 			// Lazily create log streamer if needed.
 			if stream == nil {
 				streamCfg := params.LogStreamConfig{
@@ -204,20 +283,22 @@ func (lf *LogForwarder) loop() error {
 				}
 				stream, err = lf.args.OpenLogStream(lf.args.Caller, streamCfg, lf.args.ControllerUUID)
 				if err != nil {
-					lf.catacomb.Kill(errors.Annotate(err, "creating log stream"))
-					break
+					result.err = errors.Annotate(err, "creating log stream")
 				}
-
 			}
-			rec, err := stream.Next()
-			if err != nil {
-				lf.catacomb.Kill(errors.Annotate(err, "getting next log record"))
-				break
+			if result.err == nil {
+				result.records, err = stream.Next()
+				if err != nil {
+					result.err = errors.Annotate(err, "getting next log record")
+				}
 			}
 			select {
 			case <-lf.catacomb.Dying():
 				return
-			case records <- rec: // Wait until the last one is sent.
+			case records <- result:
+			}
+			if result.err != nil {
+				return
 			}
 		}
 	}()
@@ -235,16 +316,19 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
 			}
-		case rec := <-records:
+		case result := <-records:
+			if result.err != nil {
+				return errors.Trace(result.err)
+			}
 			if sender == nil {
 				continue
 			}
-			if err := sender.Send(rec); err != nil {
+			if err := sender.Send(result.records); err != nil {
 				return errors.Trace(err)
 			}
 		}
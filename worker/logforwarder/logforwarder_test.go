@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
@@ -207,6 +208,64 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestControllerSink(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+
+	api := &mockControllerConfig{url: "https://logs.example.com"}
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.LogForwardConfig = nil
+	args.OpenSink = nil
+	args.ControllerConfig = api
+	args.OpenControllerSink = func(cfg controller.Config) (*logforwarder.LogSink, error) {
+		if cfg.LogForwardHTTPURL() == "" {
+			return nil, nil
+		}
+		s.sender.host = cfg.LogForwardHTTPURL()
+		return &logforwarder.LogSink{s.sender}, nil
+	}
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, rec0)
+	s.sender.waitForSend(c)
+
+	// Disable forwarding; records are no longer sent.
+	api.url = ""
+	api.changes <- struct{}{}
+	s.sender.waitForClose(c)
+	s.stream.addRecords(c, rec1)
+	time.Sleep(coretesting.ShortWait)
+
+	workertest.CleanKill(c, lf)
+	rec0.Message = "send to https://logs.example.com"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec0}}},
+		{"Close", nil},
+	})
+}
+
+type mockControllerConfig struct {
+	url     string
+	changes chan struct{}
+}
+
+func (c *mockControllerConfig) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	c.changes = make(chan struct{}, 1)
+	c.changes <- struct{}{}
+	return &mockWatcher{
+		changes: c.changes,
+	}, nil
+}
+
+func (c *mockControllerConfig) ControllerConfig() (controller.Config, error) {
+	return controller.Config{
+		controller.LogForwardHTTPURL: c.url,
+	}, nil
+}
+
 type mockLogForwardConfig struct {
 	enabled bool
 	host    string
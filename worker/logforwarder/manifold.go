@@ -68,6 +68,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			orchestrator, err := newOrchestratorForController(OrchestratorArgs{
 				ControllerUUID:   controllerCfg.ControllerUUID(),
 				LogForwardConfig: agentFacade,
+				ControllerConfig: agentFacade,
 				Caller:           apiCaller,
 				Sinks:            config.Sinks,
 				OpenLogStream:    openLogStream,
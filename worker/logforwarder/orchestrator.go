@@ -7,10 +7,14 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker"
 )
 
+// orchestrator runs a log forwarder for each log sink. Each forwarder
+// is restarted independently if it fails, so that a sink that cannot
+// be reached does not hold up forwarding to the others.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	worker.Runner
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -22,6 +26,10 @@ type OrchestratorArgs struct {
 	// LogForwardConfig is the API used to access log forward config.
 	LogForwardConfig LogForwardConfig
 
+	// ControllerConfig is the API used to access the controller
+	// config, for sinks configured by it.
+	ControllerConfig ControllerConfig
+
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
@@ -38,22 +46,41 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
 	if len(args.Sinks) == 0 {
 		return nil, nil
 	}
-	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
+	runner := worker.NewRunner(neverFatal, neverImportant, worker.RestartDelay)
+	for _, spec := range args.Sinks {
+		lfArgs := OpenLogForwarderArgs{
+			AllModels:          true,
+			ControllerUUID:     args.ControllerUUID,
+			LogForwardConfig:   args.LogForwardConfig,
+			ControllerConfig:   args.ControllerConfig,
+			Caller:             args.Caller,
+			Name:               spec.Name,
+			OpenSink:           spec.OpenFn,
+			OpenControllerSink: spec.OpenControllerFn,
+			OpenLogStream:      args.OpenLogStream,
+		}
+		err := runner.StartWorker(spec.Name, func() (worker.Worker, error) {
+			lf, err := args.OpenLogForwarder(lfArgs)
+			if err != nil {
+				return nil, errors.Annotate(err, "opening log forwarder")
+			}
+			return lf, nil
+		})
+		if err != nil {
+			worker.Stop(runner)
+			return nil, errors.Trace(err)
+		}
 	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		AllModels:        true,
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
-	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	return &orchestrator{runner}, nil
+}
+
+func neverFatal(error) bool {
+	return false
+}
+
+func neverImportant(error, error) bool {
+	return false
 }
@@ -4,6 +4,7 @@
 package logforwarder
 
 import (
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/watcher"
 )
@@ -18,17 +19,40 @@ type LogForwardConfig interface {
 	LogForwardConfig() (*syslog.RawConfig, bool, error)
 }
 
+// ControllerConfig provides access to the controller config, which
+// configures the log sinks opened by a ControllerLogSinkFn.
+type ControllerConfig interface {
+	// WatchControllerConfig returns a NotifyWatcher waiting for the
+	// controller config to change.
+	WatchControllerConfig() (watcher.NotifyWatcher, error)
+
+	// ControllerConfig returns the current controller config.
+	ControllerConfig() (controller.Config, error)
+}
+
+// LogSinkSpec describes a log sink to which records may be forwarded.
+// Exactly one of OpenFn and OpenControllerFn should be set.
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
 
-	// OpenFn is a function that opens a log sink.
+	// OpenFn is a function that opens a log sink configured by
+	// the log forwarding config.
 	OpenFn LogSinkFn
+
+	// OpenControllerFn is a function that opens a log sink
+	// configured by the controller config.
+	OpenControllerFn ControllerLogSinkFn
 }
 
 // LogSinkFn is a function that opens a log sink.
 type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
 
+// ControllerLogSinkFn is a function that opens a log sink using the
+// controller config. It returns a nil sink if the controller config
+// does not enable forwarding to it.
+type ControllerLogSinkFn func(cfg controller.Config) (*LogSink, error)
+
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
 	SendCloser
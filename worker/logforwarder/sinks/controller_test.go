@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type controllerSinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&controllerSinksSuite{})
+
+func (s *controllerSinksSuite) TestOpenHTTP(c *gc.C) {
+	sink, err := sinks.OpenHTTP(controller.Config{
+		controller.LogForwardHTTPURL: "https://logs.example.com/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink, gc.NotNil)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+}
+
+func (s *controllerSinksSuite) TestOpenHTTPNotConfigured(c *gc.C) {
+	sink, err := sinks.OpenHTTP(controller.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink, gc.IsNil)
+}
+
+func (s *controllerSinksSuite) TestOpenKafka(c *gc.C) {
+	sink, err := sinks.OpenKafka(controller.Config{
+		controller.LogForwardKafkaBrokers: "kafka-0:9092,kafka-1:9092",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink, gc.NotNil)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+}
+
+func (s *controllerSinksSuite) TestOpenKafkaNotConfigured(c *gc.C) {
+	sink, err := sinks.OpenKafka(controller.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink, gc.IsNil)
+}
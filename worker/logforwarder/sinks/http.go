@@ -0,0 +1,26 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/logfwd/jsonhttp"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTP returns a sink that POSTs log records to the HTTP endpoint
+// named in the controller config, or nil if there is none.
+func OpenHTTP(cfg controller.Config) (*logforwarder.LogSink, error) {
+	url := cfg.LogForwardHTTPURL()
+	if url == "" {
+		return nil, nil
+	}
+	client, err := jsonhttp.Open(jsonhttp.Config{URL: url})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{SendCloser: client}, nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/logfwd/kafka"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenKafka returns a sink that produces log records to the Kafka
+// topic named in the controller config, or nil if no brokers are
+// configured.
+func OpenKafka(cfg controller.Config) (*logforwarder.LogSink, error) {
+	brokers := cfg.LogForwardKafkaBrokers()
+	if len(brokers) == 0 {
+		return nil, nil
+	}
+	client, err := kafka.Open(kafka.Config{
+		Brokers: brokers,
+		Topic:   cfg.LogForwardKafkaTopic(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{SendCloser: client}, nil
+}
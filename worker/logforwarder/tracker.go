@@ -40,12 +40,18 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 		return nil, errors.Trace(err)
 	}
 
+	return newTrackingSink(sink, args.Name, args.Caller), nil
+}
+
+// newTrackingSink wraps the sink so that the records successfully
+// sent to it are recorded against the given sink name.
+func newTrackingSink(sink SendCloser, name string, caller base.APICaller) *LogSink {
 	return &LogSink{
 		&trackingSender{
 			SendCloser: sink,
-			tracker:    newLastSentTracker(args.Name, args.Caller),
+			tracker:    newLastSentTracker(name, caller),
 		},
-	}, nil
+	}
 }
 
 type trackingSender struct {
@@ -0,0 +1,37 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package presencepruner
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// NewStateBackend returns a Backend backed by the given state.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+type stateShim struct {
+	st *state.State
+}
+
+// ModelUUIDs is part of the Backend interface.
+func (s stateShim) ModelUUIDs() ([]string, error) {
+	models, err := s.st.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	uuids := make([]string, len(models))
+	for i, model := range models {
+		uuids[i] = model.UUID()
+	}
+	return uuids, nil
+}
+
+// NewPresencePruner is part of the Backend interface.
+func (s stateShim) NewPresencePruner(modelUUID string) ModelPruner {
+	return s.st.NewPresencePruner(modelUUID)
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package presencepruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.presencepruner")
+
+// DefaultInterval is how often presence data is pruned.
+const DefaultInterval = 5 * time.Minute
+
+// ModelPruner removes old presence data for one model.
+type ModelPruner interface {
+	Prune() error
+}
+
+// Backend defines the state methods the worker uses.
+type Backend interface {
+	// ModelUUIDs returns the UUIDs of all the controller's models.
+	ModelUUIDs() ([]string, error)
+
+	// NewPresencePruner returns a ModelPruner for the model with
+	// the given UUID.
+	NewPresencePruner(modelUUID string) ModelPruner
+}
+
+// Config holds the dependencies of the worker.
+type Config struct {
+	Backend  Backend
+	Clock    clock.Clock
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start
+// the worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// New returns a worker which, every config.Interval, prunes the
+// presence data of each of the controller's models in turn. A model
+// that cannot be pruned is logged and skipped, so that it does not
+// hold up the others. The worker is intended to run on only one
+// controller at a time.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &prunerWorker{
+		config:  config,
+		pruners: make(map[string]ModelPruner),
+	}
+	return worker.NewSimpleWorker(w.loop), nil
+}
+
+type prunerWorker struct {
+	config Config
+
+	// pruners holds each model's pruner, which is kept between
+	// runs as it remembers what it may safely remove next time.
+	pruners map[string]ModelPruner
+}
+
+func (w *prunerWorker) loop(stopCh <-chan struct{}) error {
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		case <-w.config.Clock.After(w.config.Interval):
+			if err := w.prune(stopCh); err != nil {
+				return errors.Annotate(err, "pruning presence")
+			}
+		}
+	}
+}
+
+func (w *prunerWorker) prune(stopCh <-chan struct{}) error {
+	uuids, err := w.config.Backend.ModelUUIDs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pruners := make(map[string]ModelPruner, len(uuids))
+	for _, uuid := range uuids {
+		select {
+		case <-stopCh:
+			return nil
+		default:
+		}
+		pruner, ok := w.pruners[uuid]
+		if !ok {
+			pruner = w.config.Backend.NewPresencePruner(uuid)
+		}
+		pruners[uuid] = pruner
+		if err := pruner.Prune(); err != nil {
+			logger.Warningf("cannot prune presence for model %s: %v", uuid, err)
+		}
+	}
+	w.pruners = pruners
+	return nil
+}
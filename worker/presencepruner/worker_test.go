@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package presencepruner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/presencepruner"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	backend *fakeBackend
+	clock   *testing.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.backend = &fakeBackend{
+		uuids:   []string{"uuid-a", "uuid-b"},
+		pruners: make(map[string]*fakePruner),
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := presencepruner.New(presencepruner.Config{
+		Backend:  s.backend,
+		Clock:    s.clock,
+		Interval: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitAlarm(c)
+	return w
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to wait")
+	}
+}
+
+func (s *WorkerSuite) tick(c *gc.C) {
+	s.clock.Advance(time.Minute)
+	s.waitAlarm(c)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := presencepruner.New(presencepruner.Config{
+		Clock:    s.clock,
+		Interval: time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+	_, err = presencepruner.New(presencepruner.Config{
+		Backend:  s.backend,
+		Interval: time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+	_, err = presencepruner.New(presencepruner.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) TestPrunesEachModel(c *gc.C) {
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	s.tick(c)
+	s.tick(c)
+	c.Assert(s.backend.pruners["uuid-a"].count, gc.Equals, 2)
+	c.Assert(s.backend.pruners["uuid-b"].count, gc.Equals, 2)
+	// Pruners are kept between runs.
+	s.backend.CheckCallNames(c, "ModelUUIDs", "NewPresencePruner", "NewPresencePruner", "ModelUUIDs")
+}
+
+func (s *WorkerSuite) TestModelErrorDoesNotStopOthers(c *gc.C) {
+	s.backend.pruners["uuid-a"] = &fakePruner{err: errors.New("boom")}
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	s.tick(c)
+	c.Assert(s.backend.pruners["uuid-a"].count, gc.Equals, 1)
+	c.Assert(s.backend.pruners["uuid-b"].count, gc.Equals, 1)
+}
+
+func (s *WorkerSuite) TestModelUUIDsError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	w := s.startWorker(c)
+
+	s.clock.Advance(time.Minute)
+	err := w.Wait()
+	c.Assert(err, gc.ErrorMatches, "pruning presence: boom")
+}
+
+type fakeBackend struct {
+	testing.Stub
+	uuids   []string
+	pruners map[string]*fakePruner
+}
+
+func (b *fakeBackend) ModelUUIDs() ([]string, error) {
+	b.MethodCall(b, "ModelUUIDs")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.uuids, nil
+}
+
+func (b *fakeBackend) NewPresencePruner(modelUUID string) presencepruner.ModelPruner {
+	b.MethodCall(b, "NewPresencePruner", modelUUID)
+	p, ok := b.pruners[modelUUID]
+	if !ok {
+		p = &fakePruner{}
+		b.pruners[modelUUID] = p
+	}
+	return p
+}
+
+type fakePruner struct {
+	count int
+	err   error
+}
+
+func (p *fakePruner) Prune() error {
+	p.count++
+	return p.err
+}
@@ -4,6 +4,8 @@
 package provisioner
 
 import (
+	"time"
+
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/watcher"
 )
@@ -32,6 +34,13 @@ var (
 	ResolvConf              = &resolvConf
 	RetryStrategyDelay      = &retryStrategyDelay
 	RetryStrategyCount      = &retryStrategyCount
+	BatchStartWindow        = &batchStartWindow
 )
 
 var ClassifyMachine = classifyMachine
+
+// Delay returns the time the strategy waits after the given number
+// of failed attempts to start an instance.
+func (s RetryStrategy) Delay(failures int) time.Duration {
+	return s.delay(failures)
+}
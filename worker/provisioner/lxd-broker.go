@@ -4,6 +4,8 @@
 package provisioner
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
@@ -101,6 +103,10 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, err
 	}
 
+	if err := broker.ensureLXDProfiles(args); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	storageConfig := &container.StorageConfig{}
 	inst, hardware, err := broker.manager.CreateContainer(
 		args.InstanceConfig, args.Constraints,
@@ -117,6 +123,30 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 	}, nil
 }
 
+// ensureLXDProfiles creates the LXD profiles shipped with the
+// charms of the units to be deployed to the container, and records
+// their names in the instance config so they are applied to it.
+func (broker *lxdBroker) ensureLXDProfiles(args environs.StartInstanceParams) error {
+	if len(args.CharmLXDProfiles) == 0 {
+		return nil
+	}
+	profileManager, ok := broker.manager.(container.LXDProfileManager)
+	if !ok {
+		lxdLogger.Warningf("container manager does not support charm LXD profiles, ignoring %d profile(s)", len(args.CharmLXDProfiles))
+		return nil
+	}
+	names := make([]string, 0, len(args.CharmLXDProfiles))
+	for name, profile := range args.CharmLXDProfiles {
+		if err := profileManager.EnsureLXDProfile(name, profile); err != nil {
+			return errors.Trace(err)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args.InstanceConfig.CharmLXDProfiles = names
+	return nil
+}
+
 func (broker *lxdBroker) StopInstances(ids ...instance.Id) error {
 	// TODO: potentially parallelise.
 	for _, id := range ids {
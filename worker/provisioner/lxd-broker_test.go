@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -138,6 +139,45 @@ func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `need tools for arch amd64, only found \[arm64\]`)
 }
 
+func (s *lxdBrokerSuite) TestStartInstanceWithCharmLXDProfiles(c *gc.C) {
+	profiles := map[string]lxdprofile.Profile{
+		"juju-default-nested-2": {Config: map[string]string{"security.nesting": "true"}},
+		"juju-default-kvm-1": {Devices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		}},
+	}
+	_, err := s.broker.StartInstance(environs.StartInstanceParams{
+		Tools:            makePossibleTools(),
+		InstanceConfig:   makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback:   makeNoOpStatusCallback(),
+		CharmLXDProfiles: profiles,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCallNames(c, "EnsureLXDProfile", "EnsureLXDProfile", "CreateContainer")
+	for _, call := range s.manager.Calls()[:2] {
+		name := call.Args[0].(string)
+		c.Check(call.Args[1], jc.DeepEquals, profiles[name])
+	}
+	instanceConfig := s.manager.Calls()[2].Args[0].(*instancecfg.InstanceConfig)
+	c.Assert(instanceConfig.CharmLXDProfiles, jc.DeepEquals, []string{
+		"juju-default-kvm-1", "juju-default-nested-2",
+	})
+}
+
+func (s *lxdBrokerSuite) TestStartInstanceCharmLXDProfileError(c *gc.C) {
+	s.manager.SetErrors(errors.New("boom"))
+	_, err := s.broker.StartInstance(environs.StartInstanceParams{
+		Tools:          makePossibleTools(),
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback: makeNoOpStatusCallback(),
+		CharmLXDProfiles: map[string]lxdprofile.Profile{
+			"juju-default-nested-2": {Config: map[string]string{"security.nesting": "true"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.manager.CheckCallNames(c, "EnsureLXDProfile")
+}
+
 type fakeContainerManager struct {
 	gitjujutesting.Stub
 }
@@ -153,6 +193,11 @@ func (m *fakeContainerManager) CreateContainer(instanceConfig *instancecfg.Insta
 	return nil, nil, m.NextErr()
 }
 
+func (m *fakeContainerManager) EnsureLXDProfile(name string, profile lxdprofile.Profile) error {
+	m.MethodCall(m, "EnsureLXDProfile", name, profile)
+	return m.NextErr()
+}
+
 func (m *fakeContainerManager) DestroyContainer(id instance.Id) error {
 	m.MethodCall(m, "DestroyContainer", id)
 	return m.NextErr()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
@@ -29,8 +30,14 @@ var _ Provisioner = (*environProvisioner)(nil)
 var _ Provisioner = (*containerProvisioner)(nil)
 
 var (
-	retryStrategyDelay = 10 * time.Second
-	retryStrategyCount = 3
+	retryStrategyDelay    = 10 * time.Second
+	retryStrategyMaxDelay = 5 * time.Minute
+	retryStrategyCount    = 5
+
+	// batchStartWindow is how long a provisioner task whose broker
+	// can start instances in batches waits for further machines to
+	// be added before starting instances.
+	batchStartWindow = 2 * time.Second
 )
 
 // Provisioner represents a running provisioner worker.
@@ -72,19 +79,45 @@ type provisioner struct {
 //
 // TODO(katco): 2016-08-09: lp:1611427
 type RetryStrategy struct {
-	retryDelay time.Duration
-	retryCount int
+	retryDelay    time.Duration
+	retryMaxDelay time.Duration
+	retryCount    int
 }
 
 // NewRetryStrategy returns a new retry strategy with the specified delay and
 // count for use with retryable provisioning errors.
 func NewRetryStrategy(delay time.Duration, count int) RetryStrategy {
 	return RetryStrategy{
-		retryDelay: delay,
-		retryCount: count,
+		retryDelay:    delay,
+		retryMaxDelay: delay,
+		retryCount:    count,
 	}
 }
 
+// NewBackoffRetryStrategy returns a new retry strategy which waits for
+// the specified delay before the first retry, doubling the delay for
+// each subsequent retry up to maxDelay.
+func NewBackoffRetryStrategy(delay, maxDelay time.Duration, count int) RetryStrategy {
+	return RetryStrategy{
+		retryDelay:    delay,
+		retryMaxDelay: maxDelay,
+		retryCount:    count,
+	}
+}
+
+// delay returns the time to wait after the given number of failed
+// attempts, which must be at least 1.
+func (s RetryStrategy) delay(failures int) time.Duration {
+	delay := s.retryDelay
+	for i := 1; i < failures && delay < s.retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.retryMaxDelay {
+		delay = s.retryMaxDelay
+	}
+	return delay
+}
+
 // configObserver is implemented so that tests can see
 // when the environment configuration changes.
 type configObserver struct {
@@ -160,7 +193,8 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
-		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		NewBackoffRetryStrategy(retryStrategyDelay, retryStrategyMaxDelay, retryStrategyCount),
+		clock.WallClock,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
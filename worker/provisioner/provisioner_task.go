@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 
 	apiprovisioner "github.com/juju/juju/api/provisioner"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -72,6 +74,7 @@ func NewProvisionerTask(
 	auth authentication.AuthenticationProvider,
 	imageStream string,
 	retryStartInstanceStrategy RetryStrategy,
+	clock clock.Clock,
 ) (ProvisionerTask, error) {
 	machineChanges := machineWatcher.Changes()
 	workers := []worker.Worker{machineWatcher}
@@ -92,8 +95,14 @@ func NewProvisionerTask(
 		harvestMode:                harvestMode,
 		harvestModeChan:            make(chan config.HarvestMode, 1),
 		machines:                   make(map[string]*apiprovisioner.Machine),
+		retries:                    make(map[string]*startRetry),
+		automaticRetries:           make(map[string]int),
 		imageStream:                imageStream,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
+		clock:                      clock,
+	}
+	if _, ok := broker.(environs.BatchInstanceBroker); ok {
+		task.batchWindow = batchStartWindow
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &task.catacomb,
@@ -120,10 +129,33 @@ type provisionerTask struct {
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
 	retryStartInstanceStrategy RetryStrategy
+	// clock times the batch window and start instance retries.
+	clock clock.Clock
+	// batchWindow is how long to collect machine changes for
+	// before starting instances, if the broker can start
+	// instances in batches.
+	batchWindow time.Duration
 	// instance id -> instance
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
 	machines map[string]*apiprovisioner.Machine
+	// machine id -> scheduled retry of StartInstance
+	retries map[string]*startRetry
+	// retryTimer fires when the earliest scheduled retry is due.
+	retryTimer <-chan time.Time
+	// machine id -> number of times the controller has retried
+	// provisioning the machine automatically since it was last
+	// started or retried on request.
+	automaticRetries map[string]int
+}
+
+// startRetry records a machine whose instance failed to start, and
+// when the provisioner task should next try to start it.
+type startRetry struct {
+	machine  *apiprovisioner.Machine
+	params   environs.StartInstanceParams
+	failures int
+	due      time.Time
 }
 
 // Kill implements worker.Worker.Kill.
@@ -144,6 +176,12 @@ func (task *provisionerTask) loop() error {
 	// as unknown.
 	var harvestModeChan chan config.HarvestMode
 
+	// When the broker can start several instances at once, machine
+	// changes are collected for a short window before being processed,
+	// so that machines added together are started together.
+	var batchIds []string
+	var batchTimer <-chan time.Time
+
 	// When the watcher is started, it will have the initial changes be all
 	// the machines that are relevant. Also, since this is available straight
 	// away, we know there will be some changes right off the bat.
@@ -156,6 +194,13 @@ func (task *provisionerTask) loop() error {
 			if !ok {
 				return errors.New("machine watcher closed channel")
 			}
+			if task.batchWindow > 0 {
+				batchIds = append(batchIds, ids...)
+				if batchTimer == nil {
+					batchTimer = task.clock.After(task.batchWindow)
+				}
+				break
+			}
 			if err := task.processMachines(ids); err != nil {
 				return errors.Annotate(err, "failed to process updated machines")
 			}
@@ -163,6 +208,13 @@ func (task *provisionerTask) loop() error {
 			// We've seen a set of changes. Enable modification of
 			// harvesting mode.
 			harvestModeChan = task.harvestModeChan
+		case <-batchTimer:
+			ids := batchIds
+			batchIds, batchTimer = nil, nil
+			if err := task.processMachines(ids); err != nil {
+				return errors.Annotate(err, "failed to process updated machines")
+			}
+			harvestModeChan = task.harvestModeChan
 		case harvestMode := <-harvestModeChan:
 			if harvestMode == task.harvestMode {
 				break
@@ -179,6 +231,11 @@ func (task *provisionerTask) loop() error {
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
 			}
+		case <-task.retryTimer:
+			task.retryTimer = nil
+			if err := task.processStartRetries(); err != nil {
+				return errors.Annotate(err, "failed to retry starting instances")
+			}
 		}
 	}
 }
@@ -204,7 +261,24 @@ func (task *provisionerTask) processMachinesWithTransientErrors() error {
 			continue
 		}
 		machine := machines[i]
-		if err := machine.SetStatus(status.Pending, "", nil); err != nil {
+		// Machines not marked as transient by retry-provisioning
+		// are being retried under the model's provisioning retry
+		// policy; the attempt is recorded in the machine's status.
+		var message string
+		var data map[string]interface{}
+		if transient, _ := statusResult.Data["transient"].(bool); transient {
+			delete(task.automaticRetries, machine.Id())
+		} else {
+			retries := statusDataInt(statusResult.Data, "automatic-retries") + 1
+			task.automaticRetries[machine.Id()] = retries
+			message = fmt.Sprintf("automatic provisioning retry %d", retries)
+			data = map[string]interface{}{
+				"automatic-retries": retries,
+				"provider-error":    statusResult.Info,
+			}
+			logger.Infof("machine %q: %s", statusResult.Id, message)
+		}
+		if err := machine.SetStatus(status.Pending, message, data); err != nil {
 			logger.Errorf("cannot reset status of machine %q: %v", statusResult.Id, err)
 			continue
 		}
@@ -214,6 +288,20 @@ func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	return task.startMachines(pending)
 }
 
+// statusDataInt returns the named integer from a machine's status
+// data, which may have been decoded as any numeric type, or zero.
+func statusDataInt(data map[string]interface{}, key string) int {
+	switch v := data[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
 func (task *provisionerTask) processMachines(ids []string) error {
 	logger.Tracef("processMachines(%v)", ids)
 
@@ -276,6 +364,8 @@ func (task *provisionerTask) processMachines(ids []string) error {
 			logger.Errorf("failed to remove dead machine %q", machine)
 		}
 		delete(task.machines, machine.Id())
+		delete(task.retries, machine.Id())
+		delete(task.automaticRetries, machine.Id())
 	}
 
 	// Any machines that require maintenance get pinged
@@ -513,6 +603,7 @@ func (task *provisionerTask) constructInstanceConfig(
 	}
 
 	instanceConfig.Tags = pInfo.Tags
+	instanceConfig.CloudInitUserData = pInfo.CloudInitUserData
 	if len(pInfo.Jobs) > 0 {
 		instanceConfig.Jobs = pInfo.Jobs
 	}
@@ -594,6 +685,17 @@ func constructStartInstanceParams(
 			endpointBindings[endpoint] = network.Id(space)
 		}
 	}
+	var lxdProfiles map[string]lxdprofile.Profile
+	if len(provisioningInfo.CharmLXDProfiles) != 0 {
+		lxdProfiles = make(map[string]lxdprofile.Profile)
+		for name, profile := range provisioningInfo.CharmLXDProfiles {
+			lxdProfiles[name] = lxdprofile.Profile{
+				Description: profile.Description,
+				Config:      profile.Config,
+				Devices:     profile.Devices,
+			}
+		}
+	}
 	possibleImageMetadata := make([]*imagemetadata.ImageMetadata, len(provisioningInfo.ImageMetadata))
 	for i, metadata := range provisioningInfo.ImageMetadata {
 		possibleImageMetadata[i] = &imagemetadata.ImageMetadata{
@@ -618,6 +720,7 @@ func constructStartInstanceParams(
 		Volumes:           volumes,
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
+		CharmLXDProfiles:  lxdProfiles,
 		ImageMetadata:     possibleImageMetadata,
 		StatusCallback:    machine.SetInstanceStatus,
 	}, nil
@@ -637,46 +740,112 @@ func (task *provisionerTask) maintainMachines(machines []*apiprovisioner.Machine
 }
 
 func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) error {
+	var batch []*apiprovisioner.Machine
+	var batchParams []environs.StartInstanceParams
 	for _, m := range machines {
-
-		pInfo, err := m.ProvisioningInfo()
-		if err != nil {
-			return task.setErrorStatus("fetching provisioning info for machine %q: %v", m, err)
+		if _, ok := task.retries[m.Id()]; ok {
+			// Already scheduled to be retried; leave it to the
+			// retry timer.
+			continue
 		}
-
-		instanceCfg, err := task.constructInstanceConfig(m, task.auth, pInfo)
-		if err != nil {
-			return task.setErrorStatus("creating instance config for machine %q: %v", m, err)
+		startInstanceParams, ok, err := task.startInstanceParams(m)
+		if !ok {
+			// Start the machines we have already prepared,
+			// before giving up on the rest.
+			if err := task.startMachineBatch(batch, batchParams); err != nil {
+				return errors.Trace(err)
+			}
+			return err
 		}
+		batch = append(batch, m)
+		batchParams = append(batchParams, startInstanceParams)
+	}
+	return task.startMachineBatch(batch, batchParams)
+}
 
-		assocProvInfoAndMachCfg(pInfo, instanceCfg)
+// startInstanceParams gathers the information required to start an
+// instance for the supplied machine. If the information cannot be
+// gathered, the machine's status is set to error and false is returned,
+// along with any error encountered setting the status.
+func (task *provisionerTask) startInstanceParams(m *apiprovisioner.Machine) (environs.StartInstanceParams, bool, error) {
+	pInfo, err := m.ProvisioningInfo()
+	if err != nil {
+		return environs.StartInstanceParams{}, false, task.setErrorStatus("fetching provisioning info for machine %q: %v", m, err)
+	}
 
-		var arch string
-		if pInfo.Constraints.Arch != nil {
-			arch = *pInfo.Constraints.Arch
-		}
+	instanceCfg, err := task.constructInstanceConfig(m, task.auth, pInfo)
+	if err != nil {
+		return environs.StartInstanceParams{}, false, task.setErrorStatus("creating instance config for machine %q: %v", m, err)
+	}
 
-		possibleTools, err := task.toolsFinder.FindTools(
-			jujuversion.Current,
-			pInfo.Series,
-			arch,
-		)
-		if err != nil {
-			return task.setErrorStatus("cannot find tools for machine %q: %v", m, err)
-		}
+	assocProvInfoAndMachCfg(pInfo, instanceCfg)
 
-		startInstanceParams, err := constructStartInstanceParams(
-			task.controllerUUID,
-			m,
-			instanceCfg,
-			pInfo,
-			possibleTools,
-		)
-		if err != nil {
-			return task.setErrorStatus("cannot construct params for machine %q: %v", m, err)
+	var arch string
+	if pInfo.Constraints.Arch != nil {
+		arch = *pInfo.Constraints.Arch
+	}
+
+	possibleTools, err := task.toolsFinder.FindTools(
+		jujuversion.Current,
+		pInfo.Series,
+		arch,
+	)
+	if err != nil {
+		return environs.StartInstanceParams{}, false, task.setErrorStatus("cannot find tools for machine %q: %v", m, err)
+	}
+
+	startInstanceParams, err := constructStartInstanceParams(
+		task.controllerUUID,
+		m,
+		instanceCfg,
+		pInfo,
+		possibleTools,
+	)
+	if err != nil {
+		return environs.StartInstanceParams{}, false, task.setErrorStatus("cannot construct params for machine %q: %v", m, err)
+	}
+	return startInstanceParams, true, nil
+}
+
+// startMachineBatch starts instances for the supplied machines. If the
+// broker supports it, the instances are requested with a single call;
+// any machine whose instance could not be started that way is retried
+// individually.
+func (task *provisionerTask) startMachineBatch(
+	machines []*apiprovisioner.Machine,
+	startInstanceParams []environs.StartInstanceParams,
+) error {
+	broker, ok := task.broker.(environs.BatchInstanceBroker)
+	if !ok || len(machines) < 2 {
+		for i, m := range machines {
+			if err := task.startMachine(m, startInstanceParams[i]); err != nil {
+				return errors.Annotatef(err, "cannot start machine %v", m)
+			}
 		}
+		return nil
+	}
 
-		if err := task.startMachine(m, pInfo, startInstanceParams); err != nil {
+	logger.Infof("starting instances for %d machines in one batch", len(machines))
+	results, err := broker.StartInstances(startInstanceParams)
+	if err == nil && len(results) != len(machines) {
+		err = errors.Errorf("expected %d results, got %d", len(machines), len(results))
+	}
+	if err != nil {
+		logger.Warningf("cannot start instances in batch: %v", err)
+		results = make([]environs.StartInstancesResult, len(machines))
+		for i := range results {
+			results[i].Error = err
+		}
+	}
+	for i, m := range machines {
+		var err error
+		if result := results[i]; result.Error == nil {
+			err = task.registerInstance(m, startInstanceParams[i], result.Result)
+		} else {
+			logger.Warningf("cannot start instance for machine %q in batch, starting individually: %v", m, result.Error)
+			err = task.startMachine(m, startInstanceParams[i])
+		}
+		if err != nil {
 			return errors.Annotatef(err, "cannot start machine %v", m)
 		}
 	}
@@ -692,38 +861,128 @@ func (task *provisionerTask) setErrorStatus(message string, machine *apiprovisio
 	return nil
 }
 
+// quarantineMachine sets the error status of a machine whose instance
+// could not be started after the given number of attempts, preserving
+// the provider's error in the status message. The machine will not be
+// provisioned again until the error is resolved with retry-provisioning,
+// or it is retried automatically under the model's provisioning retry
+// policy.
+func (task *provisionerTask) quarantineMachine(machine *apiprovisioner.Machine, attempts int, err error) error {
+	logger.Errorf("cannot start instance for machine %q after %d attempts: %v", machine, attempts, err)
+	data := map[string]interface{}{
+		"attempts":    attempts,
+		"quarantined": true,
+	}
+	if retries := task.automaticRetries[machine.Id()]; retries > 0 {
+		data["automatic-retries"] = retries
+	}
+	if err1 := machine.SetStatus(status.Error, err.Error(), data); err1 != nil {
+		// Something is wrong with this machine, better report it back.
+		return errors.Annotatef(err1, "cannot set error status for machine %q", machine)
+	}
+	return nil
+}
+
 func (task *provisionerTask) startMachine(
 	machine *apiprovisioner.Machine,
-	provisioningInfo *params.ProvisioningInfo,
 	startInstanceParams environs.StartInstanceParams,
 ) error {
-	var result *environs.StartInstanceResult
-	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; attemptsLeft-- {
-		attemptResult, err := task.broker.StartInstance(startInstanceParams)
-		if err == nil {
-			result = attemptResult
-			break
-		} else if attemptsLeft <= 0 {
-			// Set the state to error, so the machine will be skipped
-			// next time until the error is resolved, but don't return
-			// an error; just keep going with the other machines.
-			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
-		}
+	return task.attemptStartMachine(machine, startInstanceParams, 0)
+}
+
+// attemptStartMachine makes a single attempt to start an instance for
+// the supplied machine, which has already failed to start the given
+// number of times. If the attempt fails, another is scheduled after
+// the retry strategy's delay; the provisioner task carries on with
+// other work in the meantime.
+func (task *provisionerTask) attemptStartMachine(
+	machine *apiprovisioner.Machine,
+	startInstanceParams environs.StartInstanceParams,
+	failures int,
+) error {
+	result, err := task.broker.StartInstance(startInstanceParams)
+	if err == nil {
+		return task.registerInstance(machine, startInstanceParams, result)
+	}
+	failures++
+	strategy := task.retryStartInstanceStrategy
+	if failures > strategy.retryCount {
+		// Quarantine the machine: set the status to error, so the
+		// machine will be skipped next time until the error is
+		// resolved, but don't return an error; just keep going
+		// with the other machines.
+		return task.quarantineMachine(machine, failures, err)
+	}
 
-		logger.Warningf("%v", errors.Annotate(err, "starting instance"))
-		retryMsg := fmt.Sprintf("will retry to start instance in %v", task.retryStartInstanceStrategy.retryDelay)
-		if err2 := machine.SetStatus(status.Pending, retryMsg, nil); err2 != nil {
-			logger.Errorf("%v", err2)
+	logger.Warningf("%v", errors.Annotate(err, "starting instance"))
+	delay := strategy.delay(failures)
+	retryMsg := fmt.Sprintf("will retry to start instance in %v", delay)
+	retryData := map[string]interface{}{
+		"attempts":       failures,
+		"provider-error": err.Error(),
+	}
+	if err2 := machine.SetStatus(status.Pending, retryMsg, retryData); err2 != nil {
+		logger.Errorf("%v", err2)
+	}
+	logger.Infof(retryMsg)
+	task.retries[machine.Id()] = &startRetry{
+		machine:  machine,
+		params:   startInstanceParams,
+		failures: failures,
+		due:      task.clock.Now().Add(delay),
+	}
+	task.resetRetryTimer()
+	return nil
+}
+
+// processStartRetries retries starting the instances of machines
+// whose retries are due. Machines that are no longer alive are
+// forgotten.
+func (task *provisionerTask) processStartRetries() error {
+	now := task.clock.Now()
+	for id, retry := range task.retries {
+		if retry.due.After(now) {
+			continue
+		}
+		delete(task.retries, id)
+		if err := retry.machine.Refresh(); err != nil {
+			logger.Warningf("not retrying machine %q: %v", retry.machine, err)
+			continue
+		}
+		if retry.machine.Life() != params.Alive {
+			continue
 		}
-		logger.Infof(retryMsg)
+		if err := task.attemptStartMachine(retry.machine, retry.params, retry.failures); err != nil {
+			return errors.Annotatef(err, "cannot start machine %v", retry.machine)
+		}
+	}
+	task.resetRetryTimer()
+	return nil
+}
 
-		select {
-		case <-task.catacomb.Dying():
-			return task.catacomb.ErrDying()
-		case <-time.After(task.retryStartInstanceStrategy.retryDelay):
+// resetRetryTimer sets the retry timer to fire when the earliest
+// scheduled retry is due.
+func (task *provisionerTask) resetRetryTimer() {
+	task.retryTimer = nil
+	var next time.Time
+	for _, retry := range task.retries {
+		if next.IsZero() || retry.due.Before(next) {
+			next = retry.due
 		}
 	}
+	if !next.IsZero() {
+		task.retryTimer = task.clock.After(next.Sub(task.clock.Now()))
+	}
+}
 
+// registerInstance records the details of the instance started for
+// the supplied machine. If they cannot be recorded, the instance is
+// stopped.
+func (task *provisionerTask) registerInstance(
+	machine *apiprovisioner.Machine,
+	startInstanceParams environs.StartInstanceParams,
+	result *environs.StartInstanceResult,
+) error {
 	networkConfig := networkingcommon.NetworkConfigFromInterfaceInfo(result.NetworkInfo)
 	volumes := volumesToAPIserver(result.Volumes)
 	volumeNameToAttachmentInfo := volumeAttachmentsToAPIserver(result.VolumeAttachments)
@@ -745,6 +1004,7 @@ func (task *provisionerTask) startMachine(
 		}
 		return errors.Annotate(err, "cannot set instance info")
 	}
+	delete(task.automaticRetries, machine.Id())
 
 	logger.Infof(
 		"started machine %s as instance %s with hardware %q, network config %+v, volumes %v, volume attachments %v, subnets to zones %v",
@@ -6,12 +6,15 @@ package provisioner_test
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/utils/arch"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
@@ -581,6 +584,11 @@ func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceWithInjectedCreatio
 		c.Assert(statusInfo.Status, gc.Equals, status.Error)
 		// check that the status matches the error message
 		c.Assert(statusInfo.Message, gc.Equals, destroyError.Error())
+		// and that the machine is quarantined.
+		c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+			"attempts":    3,
+			"quarantined": true,
+		})
 		return
 	}
 	c.Fatal("Test took too long to complete")
@@ -612,6 +620,25 @@ func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedRetrya
 	s.checkStartInstance(c, m)
 }
 
+func (s *ProvisionerSuite) TestRetryStrategyBackoff(c *gc.C) {
+	strategy := provisioner.NewBackoffRetryStrategy(10*time.Second, time.Minute, 5)
+	var delays []time.Duration
+	for failures := 1; failures <= 5; failures++ {
+		delays = append(delays, strategy.Delay(failures))
+	}
+	c.Assert(delays, jc.DeepEquals, []time.Duration{
+		10 * time.Second,
+		20 * time.Second,
+		40 * time.Second,
+		time.Minute,
+		time.Minute,
+	})
+
+	strategy = provisioner.NewRetryStrategy(10*time.Second, 5)
+	c.Assert(strategy.Delay(1), gc.Equals, 10*time.Second)
+	c.Assert(strategy.Delay(5), gc.Equals, 10*time.Second)
+}
+
 func (s *ProvisionerSuite) TestProvisionerStopRetryingIfDying(c *gc.C) {
 	// Create the error injection channel and inject
 	// a retryable error
@@ -639,6 +666,36 @@ func (s *ProvisionerSuite) TestProvisionerStopRetryingIfDying(c *gc.C) {
 	s.checkNoOperations(c)
 }
 
+func (s *ProvisionerSuite) TestProvisionerStartsOtherMachinesWhileRetrying(c *gc.C) {
+	// Use a long retry delay, so the first machine is still waiting
+	// to be retried when the second is added.
+	s.PatchValue(provisioner.RetryStrategyDelay, time.Minute)
+	errorInjectionChannel := make(chan error, 1)
+	cleanup := dummy.PatchTransientErrorInjectionChannel(errorInjectionChannel)
+	defer cleanup()
+
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	errorInjectionChannel <- errors.New("container failed to start and was destroyed")
+	m0, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	for t0 := time.Now(); ; time.Sleep(coretesting.ShortWait) {
+		statusInfo, err := m0.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if statusInfo.Message == "will retry to start instance in 1m0s" {
+			break
+		}
+		if time.Since(t0) > coretesting.LongWait {
+			c.Fatalf("machine status not updated, got %+v", statusInfo)
+		}
+	}
+
+	m1, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m1)
+}
+
 func (s *ProvisionerSuite) TestProvisioningDoesNotOccurForLXD(c *gc.C) {
 	p := s.newEnvironProvisioner(c)
 	defer stop(c, p)
@@ -1118,6 +1175,21 @@ func (s *ProvisionerSuite) newProvisionerTask(
 	machineGetter provisioner.MachineGetter,
 	toolsFinder provisioner.ToolsFinder,
 ) provisioner.ProvisionerTask {
+	retryStrategy := provisioner.NewRetryStrategy(0*time.Second, 0)
+	return s.newProvisionerTaskWithRetryStrategy(
+		c, harvestingMethod, broker, machineGetter, toolsFinder, retryStrategy, clock.WallClock,
+	)
+}
+
+func (s *ProvisionerSuite) newProvisionerTaskWithRetryStrategy(
+	c *gc.C,
+	harvestingMethod config.HarvestMode,
+	broker environs.InstanceBroker,
+	machineGetter provisioner.MachineGetter,
+	toolsFinder provisioner.ToolsFinder,
+	retryStrategy provisioner.RetryStrategy,
+	clock clock.Clock,
+) provisioner.ProvisionerTask {
 
 	machineWatcher, err := s.provisioner.WatchModelMachines()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1126,8 +1198,6 @@ func (s *ProvisionerSuite) newProvisionerTask(
 	auth, err := authentication.NewAPIAuthenticator(s.provisioner)
 	c.Assert(err, jc.ErrorIsNil)
 
-	retryStrategy := provisioner.NewRetryStrategy(0*time.Second, 0)
-
 	w, err := provisioner.NewProvisionerTask(
 		s.ControllerConfig.ControllerUUID(),
 		names.NewMachineTag("0"),
@@ -1140,6 +1210,7 @@ func (s *ProvisionerSuite) newProvisionerTask(
 		auth,
 		imagemetadata.ReleasedStream,
 		retryStrategy,
+		clock,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
@@ -1293,6 +1364,90 @@ func (s *ProvisionerSuite) TestProvisionerRetriesTransientErrors(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
 }
 
+func (s *ProvisionerSuite) TestProvisionerBacksOffStartInstanceRetries(c *gc.C) {
+	clock := gitjujutesting.NewClock(time.Now())
+	e := &mockBroker{Environ: s.Environ, retryCount: make(map[string]int)}
+	retryStrategy := provisioner.NewBackoffRetryStrategy(10*time.Second, time.Minute, 3)
+	task := s.newProvisionerTaskWithRetryStrategy(
+		c, config.HarvestAll, e, s.provisioner, mockToolsFinder{}, retryStrategy, clock,
+	)
+	defer stop(c, task)
+
+	m1, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m1)
+	m2, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m2)
+
+	// mockBroker fails to start machine-3 three times; each retry
+	// waits twice as long as the one before.
+	m3, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, delay := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second} {
+		s.waitForStatusMessage(c, m3, fmt.Sprintf("will retry to start instance in %v", delay))
+		_, err := m3.InstanceId()
+		c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+		err = clock.WaitAdvance(delay, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.checkStartInstance(c, m3)
+}
+
+func (s *ProvisionerSuite) waitForStatusMessage(c *gc.C, m *state.Machine, message string) {
+	for t0 := time.Now(); ; time.Sleep(coretesting.ShortWait) {
+		statusInfo, err := m.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if statusInfo.Message == message {
+			return
+		}
+		if time.Since(t0) > coretesting.LongWait {
+			c.Fatalf("machine status not updated, got %+v", statusInfo)
+		}
+	}
+}
+
+func (s *ProvisionerSuite) TestProvisionerRetriesAutomatically(c *gc.C) {
+	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"provisioning-retry-interval":     "1ms",
+		"provisioning-retry-max-attempts": 3,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	e := &mockBroker{Environ: s.Environ, retryCount: make(map[string]int)}
+	task := s.newProvisionerTask(c, config.HarvestAll, e, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+
+	m1, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m1)
+	m2, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m2)
+
+	// mockBroker fails to start machine-3 three times; the third
+	// automatic retry succeeds without retry-provisioning.
+	m3, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m3)
+
+	// Each automatic retry is recorded with the provider's error.
+	history, err := m3.StatusHistory(status.StatusHistoryFilter{Size: 100})
+	c.Assert(err, jc.ErrorIsNil)
+	var retries []string
+	for _, entry := range history {
+		if strings.HasPrefix(entry.Message, "automatic provisioning retry") {
+			c.Check(entry.Data["provider-error"], gc.Equals, "error: some error")
+			retries = append(retries, entry.Message)
+		}
+	}
+	c.Assert(retries, jc.SameContents, []string{
+		"automatic provisioning retry 1",
+		"automatic provisioning retry 2",
+		"automatic provisioning retry 3",
+	})
+}
+
 func (s *ProvisionerSuite) TestProvisionerObservesMachineJobs(c *gc.C) {
 	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
 	broker := &mockBroker{Environ: s.Environ, retryCount: make(map[string]int)}
@@ -1310,6 +1465,66 @@ func (s *ProvisionerSuite) TestProvisionerObservesMachineJobs(c *gc.C) {
 	}
 }
 
+func (s *ProvisionerSuite) TestProvisionerStartsInstancesInBatches(c *gc.C) {
+	s.PatchValue(provisioner.BatchStartWindow, coretesting.ShortWait)
+	// Add the machines before starting the task, so that they are
+	// all reported in the watcher's initial event.
+	byId := make(map[string]*state.Machine)
+	for i := 0; i < 3; i++ {
+		m, err := s.addMachine()
+		c.Assert(err, jc.ErrorIsNil)
+		byId[m.Id()] = m
+	}
+	broker := &batchBroker{Environ: s.Environ}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+	s.BackingState.StartSync()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(broker.startedIds()) == 3 {
+			break
+		}
+	}
+	for _, id := range broker.startedIds() {
+		s.checkStartInstance(c, byId[id])
+	}
+	c.Assert(broker.batchSizes(), jc.DeepEquals, []int{3})
+}
+
+// batchBroker is an environs.BatchInstanceBroker that starts
+// instances one at a time, recording the batches requested.
+type batchBroker struct {
+	environs.Environ
+	mu      sync.Mutex
+	ids     []string
+	batches []int
+}
+
+func (b *batchBroker) StartInstances(args []environs.StartInstanceParams) ([]environs.StartInstancesResult, error) {
+	b.mu.Lock()
+	b.batches = append(b.batches, len(args))
+	for _, arg := range args {
+		b.ids = append(b.ids, arg.InstanceConfig.MachineId)
+	}
+	b.mu.Unlock()
+	results := make([]environs.StartInstancesResult, len(args))
+	for i, arg := range args {
+		results[i].Result, results[i].Error = b.Environ.StartInstance(arg)
+	}
+	return results, nil
+}
+
+func (b *batchBroker) startedIds() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.ids...)
+}
+
+func (b *batchBroker) batchSizes() []int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]int(nil), b.batches...)
+}
+
 type mockBroker struct {
 	environs.Environ
 	retryCount map[string]int
@@ -37,18 +37,19 @@ type Config struct {
 // API is an interface that is provided to New
 // which can be used to fetch the API host ports
 type API interface {
-	ProxyConfig() (proxyutils.Settings, proxyutils.Settings, error)
+	ProxyConfig() (proxyutils.Settings, proxyutils.Settings, proxyutils.Settings, error)
 	WatchForProxyConfigAndAPIHostPortChanges() (watcher.NotifyWatcher, error)
 }
 
 // proxyWorker is responsible for monitoring the juju environment
 // configuration and making changes on the physical (or virtual) machine as
 // necessary to match the environment changes.  Examples of these types of
-// changes are apt proxy configuration and the juju proxies stored in the juju
-// proxy file.
+// changes are apt and snap proxy configuration and the juju proxies stored in
+// the juju proxy file.
 type proxyWorker struct {
-	aptProxy proxyutils.Settings
-	proxy    proxyutils.Settings
+	aptProxy  proxyutils.Settings
+	snapProxy proxyutils.Settings
+	proxy     proxyutils.Settings
 
 	// The whole point of the first value is to make sure that the the files
 	// are written out the first time through, even if they are the same as
@@ -193,13 +194,54 @@ func (w *proxyWorker) handleAptProxyValues(aptSettings proxyutils.Settings) erro
 	return nil
 }
 
+// setSnapProxy configures snapd, if it is installed, to use the given
+// proxy settings when talking to the snap store.
+var setSnapProxy = func(snapSettings proxyutils.Settings) error {
+	result, err := exec.RunCommands(exec.RunParams{
+		Commands: fmt.Sprintf(
+			`command -v snap >/dev/null 2>&1 || exit 0; snap set system proxy.http=%s proxy.https=%s`,
+			utils.ShQuote(snapSettings.Http),
+			utils.ShQuote(snapSettings.Https)),
+	})
+	if err != nil {
+		return err
+	}
+	if result.Code != 0 {
+		return errors.Errorf("failed setting snap proxy values: \n%s\n%s", result.Stdout, result.Stderr)
+	}
+	return nil
+}
+
+// handleSnapProxyValues applies the snap proxy settings when they differ
+// from those last applied. Unlike the other proxy settings, they are not
+// applied the first time through when empty: running snap is slow, and
+// there is nothing to undo on a machine juju has never set a snap proxy
+// on.
+func (w *proxyWorker) handleSnapProxyValues(snapSettings proxyutils.Settings) {
+	if os.HostOS() == os.Windows {
+		return
+	}
+	if snapSettings == w.snapProxy {
+		return
+	}
+	logger.Debugf("new snap proxy settings %#v", snapSettings)
+	if err := setSnapProxy(snapSettings); err != nil {
+		// It isn't really fatal, but we should record it. The
+		// settings are applied again on the next change.
+		logger.Errorf("error setting snap proxy: %v", err)
+		return
+	}
+	w.snapProxy = snapSettings
+}
+
 func (w *proxyWorker) onChange() error {
-	proxySettings, APTProxySettings, err := w.config.API.ProxyConfig()
+	proxySettings, APTProxySettings, snapProxySettings, err := w.config.API.ProxyConfig()
 	if err != nil {
 		return err
 	}
 
 	w.handleProxyValues(proxySettings)
+	w.handleSnapProxyValues(snapProxySettings)
 	return w.handleAptProxyValues(APTProxySettings)
 }
 
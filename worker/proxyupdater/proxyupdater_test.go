@@ -33,6 +33,7 @@ type ProxyUpdaterSuite struct {
 	proxyFile        string
 	detectedSettings proxy.Settings
 	config           proxyupdater.Config
+	snapSettings     chan proxy.Settings
 }
 
 var _ = gc.Suite(&ProxyUpdaterSuite{})
@@ -50,10 +51,11 @@ func (w notAWatcher) Changes() watcher.NotifyChannel {
 }
 
 type fakeAPI struct {
-	Proxy    proxyutils.Settings
-	APTProxy proxyutils.Settings
-	Err      error
-	Watcher  *notAWatcher
+	Proxy     proxyutils.Settings
+	APTProxy  proxyutils.Settings
+	SnapProxy proxyutils.Settings
+	Err       error
+	Watcher   *notAWatcher
 }
 
 func NewFakeAPI() *fakeAPI {
@@ -61,8 +63,8 @@ func NewFakeAPI() *fakeAPI {
 	return f
 }
 
-func (api fakeAPI) ProxyConfig() (proxyutils.Settings, proxyutils.Settings, error) {
-	return api.Proxy, api.APTProxy, api.Err
+func (api fakeAPI) ProxyConfig() (proxyutils.Settings, proxyutils.Settings, proxyutils.Settings, error) {
+	return api.Proxy, api.APTProxy, api.SnapProxy, api.Err
 
 }
 
@@ -85,6 +87,11 @@ func (s *ProxyUpdaterSuite) SetUpTest(c *gc.C) {
 	}
 	s.PatchValue(&pacconfig.AptProxyConfigFile, path.Join(s.config.Directory, "juju-apt-proxy"))
 	s.proxyFile = path.Join(s.config.Directory, s.config.Filename)
+	s.snapSettings = make(chan proxy.Settings, 10)
+	s.PatchValue(proxyupdater.SetSnapProxy, func(settings proxy.Settings) error {
+		s.snapSettings <- settings
+		return nil
+	})
 }
 
 func (s *ProxyUpdaterSuite) TearDownTest(c *gc.C) {
@@ -161,6 +168,11 @@ func (s *ProxyUpdaterSuite) updateConfig(c *gc.C) (proxy.Settings, proxy.Setting
 		Ftp:   "ftp://apt.ftp.proxy",
 	}
 
+	s.api.SnapProxy = proxy.Settings{
+		Http:  "http://snap.http.proxy",
+		Https: "https://snap.https.proxy",
+	}
+
 	return s.api.Proxy, s.api.APTProxy
 }
 
@@ -242,3 +254,41 @@ func (s *ProxyUpdaterSuite) TestExternalFuncCalled(c *gc.C) {
 
 	c.Assert(externalSettings, jc.DeepEquals, proxySettings)
 }
+
+func (s *ProxyUpdaterSuite) TestSnapProxySet(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("snap proxy settings are not applied on windows")
+	}
+	s.updateConfig(c)
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(updater)
+
+	select {
+	case settings := <-s.snapSettings:
+		c.Assert(settings, jc.DeepEquals, s.api.SnapProxy)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("snap proxy not set")
+	}
+}
+
+func (s *ProxyUpdaterSuite) TestSnapProxyNotSetWhenEmpty(c *gc.C) {
+	_, aptProxySettings := s.updateConfig(c)
+	s.api.SnapProxy = proxy.Settings{}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(updater)
+
+	// The apt proxy is written after the snap proxy is handled.
+	paccmder, err := commands.NewPackageCommander(series.MustHostSeries())
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForFile(c, pacconfig.AptProxyConfigFile, paccmder.ProxyConfigContents(aptProxySettings)+"\n")
+
+	select {
+	case settings := <-s.snapSettings:
+		c.Fatalf("snap proxy unexpectedly set to %#v", settings)
+	default:
+	}
+}
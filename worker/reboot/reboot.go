@@ -16,12 +16,18 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/reboot"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 )
 
 var logger = loggo.GetLogger("juju.worker.reboot")
 
+// maxScheduleWait is the longest the worker waits for a maintenance
+// window before checking the reboot flag again, so that immediate
+// reboot requests and changes to the window are noticed.
+const maxScheduleWait = time.Minute
+
 // The reboot worker listens for changes to the reboot flag and
 // exists with worker.ErrRebootMachine if the machine should reboot or
 // with worker.ErrShutdownMachine if it should shutdown. This will be picked
@@ -57,12 +63,35 @@ func (r *Reboot) SetUp() (watcher.NotifyWatcher, error) {
 	return watcher, errors.Trace(err)
 }
 
-func (r *Reboot) Handle(_ <-chan struct{}) error {
-	rAction, err := r.st.GetRebootAction()
-	if err != nil {
-		return errors.Trace(err)
+func (r *Reboot) Handle(abort <-chan struct{}) error {
+	var rAction params.RebootAction
+	for {
+		var err error
+		rAction, err = r.st.GetRebootAction()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Debugf("Reboot worker got action: %v", rAction)
+		if rAction == params.ShouldDoNothing {
+			return nil
+		}
+		wait, err := r.untilMaintenanceWindow()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if wait <= 0 {
+			break
+		}
+		logger.Debugf("%s scheduled; waiting %v for maintenance window", rAction, wait)
+		if wait > maxScheduleWait {
+			wait = maxScheduleWait
+		}
+		select {
+		case <-abort:
+			return nil
+		case <-r.clock.After(wait):
+		}
 	}
-	logger.Debugf("Reboot worker got action: %v", rAction)
 	// NOTE: Here we explicitly avoid stopping on the abort channel as we are
 	// wanting to make sure that we grab the lock and return an error
 	// sufficiently heavyweight to get the agent to restart.
@@ -92,6 +121,27 @@ func (r *Reboot) Handle(_ <-chan struct{}) error {
 	}
 }
 
+// untilMaintenanceWindow returns how long to wait before acting on
+// the current reboot action. This is zero unless the action is due
+// to a scheduled reboot and the maintenance window is closed.
+func (r *Reboot) untilMaintenanceWindow() (time.Duration, error) {
+	scheduled, window, err := r.st.GetRebootSchedule()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if !scheduled {
+		return 0, nil
+	}
+	w, err := config.ParseMaintenanceWindow(window)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if w == nil {
+		return 0, nil
+	}
+	return w.Until(r.clock.Now()), nil
+}
+
 func (r *Reboot) TearDown() error {
 	// nothing to teardown.
 	return nil
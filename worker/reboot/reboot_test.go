@@ -4,6 +4,7 @@
 package reboot_test
 
 import (
+	"sync"
 	"time"
 
 	jc "github.com/juju/testing/checkers"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/reboot"
 )
@@ -31,7 +33,7 @@ type rebootSuite struct {
 	ct            *state.Machine
 	ctRebootState apireboot.State
 
-	clock clock.Clock
+	clock *fakeClock
 }
 
 var _ = gc.Suite(&rebootSuite{})
@@ -65,7 +67,7 @@ func (s *rebootSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.ctRebootState, gc.NotNil)
 
-	s.clock = &fakeClock{delay: time.Millisecond}
+	s.clock = &fakeClock{delay: time.Millisecond, now: time.Now()}
 }
 
 func (s *rebootSuite) TearDownTest(c *gc.C) {
@@ -106,11 +108,71 @@ func (s *rebootSuite) TestContainerCatchesParentFlag(c *gc.C) {
 	c.Assert(wrk.Wait(), gc.Equals, worker.ErrShutdownMachine)
 }
 
+func (s *rebootSuite) TestScheduledRebootWaitsForMaintenanceWindow(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"maintenance-window": "02:00-04:00",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.setNow(time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	wrk, err := reboot.NewReboot(s.rebootState, s.AgentConfigForTag(c, s.machine.Tag()), "test-reboot-scheduled", s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.ScheduleReboot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- wrk.Wait()
+	}()
+	select {
+	case err := <-done:
+		c.Fatalf("worker finished outside maintenance window: %v", err)
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	s.clock.setNow(time.Date(2017, 3, 2, 2, 30, 0, 0, time.UTC))
+	select {
+	case err := <-done:
+		c.Assert(err, gc.Equals, worker.ErrRebootMachine)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("worker did not reboot in maintenance window")
+	}
+}
+
+func (s *rebootSuite) TestRebootRequestIgnoresMaintenanceWindow(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"maintenance-window": "02:00-04:00",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.setNow(time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	wrk, err := reboot.NewReboot(s.rebootState, s.AgentConfigForTag(c, s.machine.Tag()), "test-reboot-immediate", s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.rebootState.RequestReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(wrk.Wait(), gc.Equals, worker.ErrRebootMachine)
+}
+
 type fakeClock struct {
 	clock.Clock
 	delay time.Duration
+
+	mu  sync.Mutex
+	now time.Time
 }
 
 func (f *fakeClock) After(time.Duration) <-chan time.Time {
 	return time.After(f.delay)
 }
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) setNow(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
@@ -60,6 +60,15 @@ type VolumeAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for requests to resize model-scoped
+	// volumes. It is only used by model-scoped storage provisioners.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
+	// ReleaseStorage reports whether model-scoped volumes are to be
+	// released, rather than destroyed, when they are deprovisioned.
+	// It is only used by model-scoped storage provisioners.
+	ReleaseStorage() (bool, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -79,6 +88,10 @@ type VolumeAccessor interface {
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
 
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
 	// SetVolumeInfo records the details of newly provisioned volumes.
 	SetVolumeInfo([]params.Volume) ([]params.ErrorResult, error)
 
@@ -202,6 +215,7 @@ func (w *storageProvisioner) loop() error {
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
 		machineBlockDevicesChanges   <-chan struct{}
+		volumeResizesChanges         watcher.StringsChannel
 	)
 	machineChanges := make(chan names.MachineTag)
 
@@ -218,6 +232,19 @@ func (w *storageProvisioner) loop() error {
 		machineBlockDevicesChanges = machineBlockDevicesWatcher.Changes()
 	}
 
+	// Model-scoped provisioners need to watch for volume resize
+	// requests; only model-scoped volumes may be resized.
+	if _, ok := w.config.Scope.(names.ModelTag); ok {
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+		if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		}
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+	}
+
 	volumesWatcher, err := w.config.Volumes.WatchVolumes()
 	if err != nil {
 		return errors.Annotate(err, "watching volumes")
@@ -292,6 +319,13 @@ func (w *storageProvisioner) loop() error {
 			if err := volumesChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-volumeAttachmentsChanges:
 			if !ok {
 				return errors.New("volume attachments watcher closed")
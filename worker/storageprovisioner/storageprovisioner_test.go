@@ -1532,6 +1532,189 @@ func (s *storageProvisionerSuite) TestDestroyVolumes(c *gc.C) {
 	assertNoEvent(c, removedChan, "volumes removed")
 }
 
+func (s *storageProvisionerSuite) TestReleaseVolumes(c *gc.C) {
+	provisionedVolume := names.NewVolumeTag("1")
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(provisionedVolume)
+	volumeAccessor.releaseStorage = true
+
+	life := func(tags []names.Tag) ([]params.LifeResult, error) {
+		results := make([]params.LifeResult, len(tags))
+		for i := range results {
+			results[i].Life = params.Dead
+		}
+		return results, nil
+	}
+
+	destroyedChan := make(chan interface{}, 1)
+	s.provider.destroyVolumesFunc = func(volumeIds []string) ([]error, error) {
+		destroyedChan <- volumeIds
+		return make([]error, len(volumeIds)), nil
+	}
+	releasedChan := make(chan interface{}, 1)
+	s.provider.volumeSourceFunc = func(*storage.Config) (storage.VolumeSource, error) {
+		return &releasingVolumeSource{
+			dummyVolumeSource: &dummyVolumeSource{provider: s.provider},
+			released:          releasedChan,
+		}, nil
+	}
+
+	removedChan := make(chan interface{}, 1)
+	remove := func(tags []names.Tag) ([]params.ErrorResult, error) {
+		removedChan <- tags
+		return make([]params.ErrorResult, len(tags)), nil
+	}
+
+	args := &workerArgs{
+		volumes: volumeAccessor,
+		life: &mockLifecycleManager{
+			life:   life,
+			remove: remove,
+		},
+		registry: s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{provisionedVolume.Id()}
+
+	// The volume should be released rather than destroyed,
+	// and then removed from state.
+	released := waitChannel(c, releasedChan, "waiting for volume to be released")
+	c.Assert(released, jc.DeepEquals, []string{"vol-1"})
+	removed := waitChannel(c, removedChan, "waiting for volume to be removed")
+	c.Assert(removed, jc.DeepEquals, []names.Tag{provisionedVolume})
+	assertNoEvent(c, destroyedChan, "volumes destroyed")
+}
+
+func (s *storageProvisionerSuite) TestReleaseVolumesNotSupported(c *gc.C) {
+	provisionedVolume := names.NewVolumeTag("1")
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(provisionedVolume)
+	volumeAccessor.releaseStorage = true
+
+	life := func(tags []names.Tag) ([]params.LifeResult, error) {
+		results := make([]params.LifeResult, len(tags))
+		for i := range results {
+			results[i].Life = params.Dead
+		}
+		return results, nil
+	}
+
+	destroyedChan := make(chan interface{}, 1)
+	s.provider.destroyVolumesFunc = func(volumeIds []string) ([]error, error) {
+		destroyedChan <- volumeIds
+		return make([]error, len(volumeIds)), nil
+	}
+
+	statusChan := make(chan interface{}, 1)
+	statusSetter := &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			select {
+			case statusChan <- args:
+			default:
+			}
+			return nil
+		},
+	}
+	args := &workerArgs{
+		volumes:      volumeAccessor,
+		life:         &mockLifecycleManager{life: life},
+		registry:     s.registry,
+		statusSetter: statusSetter,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{provisionedVolume.Id()}
+
+	// The volume cannot be released, and must not be destroyed.
+	statuses := waitChannel(c, statusChan, "waiting for volume status").([]params.EntityStatusArgs)
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "volume-1",
+		Status: "destroying",
+		Info:   `releasing volumes from "dummy" not supported`,
+	}})
+	assertNoEvent(c, destroyedChan, "volumes destroyed")
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]error, error) {
+		resizedChan <- args
+		return make([]error, len(args)), nil
+	}
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volume 2 has no pending resize, and should be ignored.
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     2048,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesError(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]error, error) {
+		return []error{errors.New("no space")}, nil
+	}
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		c.Fatalf("unexpected call to SetVolumeInfo")
+		return nil, nil
+	}
+	statusSet := make(chan interface{}, 1)
+	statusSetter := &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			statusSet <- args
+			return nil
+		},
+	}
+
+	args := &workerArgs{
+		volumes:      volumeAccessor,
+		registry:     s.registry,
+		statusSetter: statusSetter,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	statuses := waitChannel(c, statusSet, "waiting for volume status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "error", Info: "no space"},
+	})
+}
+
 func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	volume := names.NewVolumeTag("1")
 	volumeAccessor := newMockVolumeAccessor()
@@ -95,6 +95,51 @@ func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageI
 	return nil
 }
 
+// volumeResizesChanged is called when model-scoped volumes with the
+// provided IDs, which may have pending resize requests, have been seen
+// to have changed.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize parameters")
+	}
+	resizeParams := make([]storage.VolumeResizeParams, 0, len(results))
+	providers := make([]storage.ProviderType, 0, len(results))
+	for i, result := range results {
+		if params.IsCodeNotFound(result.Error) {
+			// The volume has been removed, or
+			// there is no resize pending.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		resizeParams = append(resizeParams, storage.VolumeResizeParams{
+			Tag:      tags[i],
+			VolumeId: result.Result.VolumeId,
+			Size:     result.Result.Size,
+		})
+		providers = append(providers, storage.ProviderType(result.Result.Provider))
+	}
+	if len(resizeParams) == 0 {
+		return nil
+	}
+	logger.Debugf("resizing volumes: %v", resizeParams)
+	if err := resizeVolumes(ctx, resizeParams, providers); err != nil {
+		return errors.Annotate(err, "resizing volumes")
+	}
+	return nil
+}
+
 // processDyingVolumes processes the VolumeResults for Dying volumes,
 // removing them from provisioning-pending as necessary.
 func processDyingVolumes(ctx *context, tags []names.Tag) error {
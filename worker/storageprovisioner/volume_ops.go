@@ -199,11 +199,24 @@ func destroyVolumes(ctx *context, ops map[names.VolumeTag]*destroyVolumeOp) erro
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// Model-scoped volumes are released, rather than destroyed, if
+	// the model is being destroyed and its storage released.
+	var release bool
+	if _, ok := ctx.config.Scope.(names.ModelTag); ok {
+		release, err = ctx.config.Volumes.ReleaseStorage()
+		if err != nil {
+			return errors.Annotate(err, "checking whether to release volumes")
+		}
+	}
 	var remove []names.Tag
 	var reschedule []scheduleOp
 	var statuses []params.EntityStatusArgs
 	for sourceName, volumeParams := range paramsBySource {
-		logger.Debugf("destroying volumes from %q: %v", sourceName, volumeParams)
+		if release {
+			logger.Debugf("releasing volumes from %q: %v", sourceName, volumeParams)
+		} else {
+			logger.Debugf("destroying volumes from %q: %v", sourceName, volumeParams)
+		}
 		volumeSource := volumeSources[sourceName]
 		validVolumeParams, validationErrors := validateVolumeParams(volumeSource, volumeParams)
 		for i, err := range validationErrors {
@@ -232,7 +245,12 @@ func destroyVolumes(ctx *context, ops map[names.VolumeTag]*destroyVolumeOp) erro
 			}
 			volumeIds[i] = volume.VolumeId
 		}
-		errs, err := volumeSource.DestroyVolumes(volumeIds)
+		var errs []error
+		if release {
+			errs, err = releaseVolumes(sourceName, volumeSource, volumeIds)
+		} else {
+			errs, err = volumeSource.DestroyVolumes(volumeIds)
+		}
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -242,7 +260,8 @@ func destroyVolumes(ctx *context, ops map[names.VolumeTag]*destroyVolumeOp) erro
 				remove = append(remove, tag)
 				continue
 			}
-			// Failed to destroy volume; reschedule and update status.
+			// Failed to destroy or release volume; reschedule
+			// and update status.
 			reschedule = append(reschedule, ops[tag])
 			statuses = append(statuses, params.EntityStatusArgs{
 				Tag:    tag.String(),
@@ -259,6 +278,129 @@ func destroyVolumes(ctx *context, ops map[names.VolumeTag]*destroyVolumeOp) erro
 	return nil
 }
 
+// resizeVolumes grows volumes with the specified parameters, using
+// the volume sources of the corresponding providers. Volumes that
+// fail to be resized have their status set to error; the resize
+// will be attempted again the next time the volume changes.
+func resizeVolumes(
+	ctx *context,
+	resizeParams []storage.VolumeResizeParams,
+	providers []storage.ProviderType,
+) error {
+	paramsBySource := make(map[storage.ProviderType][]storage.VolumeResizeParams)
+	for i, params := range resizeParams {
+		paramsBySource[providers[i]] = append(paramsBySource[providers[i]], params)
+	}
+	var resized []storage.VolumeResizeParams
+	var statuses []params.EntityStatusArgs
+	setError := func(tag names.VolumeTag, err error) {
+		statuses = append(statuses, params.EntityStatusArgs{
+			Tag:    tag.String(),
+			Status: status.Error.String(),
+			Info:   err.Error(),
+		})
+	}
+	for providerType, resizeParams := range paramsBySource {
+		resizer, err := volumeResizer(ctx, providerType)
+		if err != nil {
+			if !errors.IsNotSupported(err) {
+				return errors.Trace(err)
+			}
+			for _, params := range resizeParams {
+				setError(params.Tag, err)
+			}
+			continue
+		}
+		errs, err := resizer.ResizeVolumes(resizeParams)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i, err := range errs {
+			if err != nil {
+				logger.Errorf(
+					"failed to resize %s: %v",
+					names.ReadableString(resizeParams[i].Tag), err,
+				)
+				setError(resizeParams[i].Tag, err)
+				continue
+			}
+			resized = append(resized, resizeParams[i])
+		}
+	}
+	setStatus(ctx, statuses)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// Record the new sizes of the resized volumes.
+	tags := make([]names.VolumeTag, len(resized))
+	for i, params := range resized {
+		tags[i] = params.Tag
+	}
+	volumeResults, err := ctx.config.Volumes.Volumes(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	volumes := make([]params.Volume, len(resized))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting information for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		volumes[i] = result.Result
+		volumes[i].Info.Size = resized[i].Size
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumes)
+	if err != nil {
+		return errors.Annotate(err, "setting volume information")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "setting information for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+	}
+	return nil
+}
+
+// releaseVolumes releases the volumes with the specified provider IDs
+// from the given source, failing each of them if the source cannot
+// release volumes.
+func releaseVolumes(sourceName string, source storage.VolumeSource, volumeIds []string) ([]error, error) {
+	releaser, ok := source.(storage.VolumeReleaser)
+	if !ok {
+		errs := make([]error, len(volumeIds))
+		for i := range errs {
+			errs[i] = errors.NotSupportedf("releasing volumes from %q", sourceName)
+		}
+		return errs, nil
+	}
+	return releaser.ReleaseVolumes(volumeIds)
+}
+
+// volumeResizer returns the storage.VolumeResizer for the volume
+// source of the given provider type, or a not-supported error if the
+// provider's volumes cannot be resized.
+func volumeResizer(ctx *context, providerType storage.ProviderType) (storage.VolumeResizer, error) {
+	source, err := volumeSource(
+		ctx.config.StorageDir, string(providerType), providerType, ctx.config.Registry,
+	)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("resizing %q volumes", providerType)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	resizer, ok := source.(storage.VolumeResizer)
+	if !ok {
+		return nil, errors.NotSupportedf("resizing %q volumes", providerType)
+	}
+	return resizer, nil
+}
+
 // detachVolumes destroys volume attachments with the specified parameters.
 func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp) error {
 	volumeAttachmentParams := make([]storage.VolumeAttachmentParams, 0, len(ops))
@@ -0,0 +1,39 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package txnpruner
+
+import (
+	"github.com/juju/juju/state"
+)
+
+// NewStatePruner returns a TransactionPruner backed by the given state.
+func NewStatePruner(st *state.State) TransactionPruner {
+	return stateShim{st}
+}
+
+type stateShim struct {
+	st *state.State
+}
+
+// PruneTransactions is part of the TransactionPruner interface.
+func (s stateShim) PruneTransactions(params PruneParams) error {
+	return s.st.PruneTransactions(state.TxnPruneParams{
+		Clock:      params.Clock,
+		BatchSize:  params.BatchSize,
+		BatchPause: params.BatchPause,
+		MinAge:     state.DefaultTxnPruneMinAge,
+		Stop:       params.Stop,
+		Progress: func(progress state.TxnPruneProgress) {
+			if params.Progress == nil {
+				return
+			}
+			params.Progress(Progress{
+				Started: progress.Started,
+				Scanned: progress.Scanned,
+				Removed: progress.Removed,
+				Backlog: progress.Backlog,
+			})
+		},
+	})
+}
@@ -4,34 +4,240 @@
 package txnpruner
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/juju/juju/worker"
 )
 
+const (
+	// DefaultInterval is the time between the start of one prune
+	// and the next.
+	DefaultInterval = 2 * time.Hour
+
+	// DefaultBatchSize is the number of documents handled between
+	// pauses of a prune.
+	DefaultBatchSize = 1000
+
+	// DefaultBatchPause is the pause between batches of a prune.
+	DefaultBatchPause = 100 * time.Millisecond
+)
+
+// Progress describes how far a prune has got.
+type Progress struct {
+	// Started is when the prune began.
+	Started time.Time
+
+	// Scanned is the number of documents whose transaction queues
+	// have been read.
+	Scanned int
+
+	// Removed is the number of transactions removed.
+	Removed int
+
+	// Backlog is the number of completed transactions still to be
+	// examined for removal.
+	Backlog int
+}
+
+// PruneParams is passed to TransactionPruner.PruneTransactions.
+type PruneParams struct {
+	// Clock is used to pause between batches.
+	Clock clock.Clock
+
+	// BatchSize is the number of documents handled between pauses.
+	BatchSize int
+
+	// BatchPause is how long to pause between batches.
+	BatchPause time.Duration
+
+	// Stop, when closed, causes the prune to return early.
+	Stop <-chan struct{}
+
+	// Progress is called after every batch.
+	Progress func(Progress)
+}
+
 // TransactionPruner defines the interface for types capable of
-// pruning transactions.
+// incrementally pruning transactions.
 type TransactionPruner interface {
-	MaybePruneTransactions() error
+	PruneTransactions(PruneParams) error
+}
+
+// Config holds the configuration and dependencies for a txnpruner
+// worker.
+type Config struct {
+	Pruner     TransactionPruner
+	Clock      clock.Clock
+	Interval   time.Duration
+	BatchSize  int
+	BatchPause time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional worker.
+func (config Config) Validate() error {
+	if config.Pruner == nil {
+		return errors.NotValidf("nil Pruner")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if config.BatchPause < 0 {
+		return errors.NotValidf("negative BatchPause")
+	}
+	return nil
+}
+
+// Worker periodically runs an incremental prune of the data for
+// completed transactions. It is a prometheus.Collector, so that the
+// progress of the current prune, and the size of its backlog, can be
+// seen through the agent's introspection endpoint.
+type Worker struct {
+	worker.Worker
+	config Config
+
+	mu       sync.Mutex
+	running  bool
+	progress Progress
+	lastRun  time.Duration
+
+	runningGauge  prometheus.Gauge
+	scannedGauge  prometheus.Gauge
+	removedGauge  prometheus.Gauge
+	backlogGauge  prometheus.Gauge
+	lastRunGauge  prometheus.Gauge
+	removedTotal  prometheus.Counter
+	lastRemovedAt int
 }
 
 // New returns a worker which periodically prunes the data for
 // completed transactions.
-func New(tp TransactionPruner, interval time.Duration, clock clock.Clock) worker.Worker {
-	return worker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
-		for {
-			select {
-			case <-clock.After(interval):
-				err := tp.MaybePruneTransactions()
-				if err != nil {
-					return errors.Annotate(err, "pruning failed, txnpruner stopping")
-				}
-			case <-stopCh:
-				return nil
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:       config,
+		runningGauge: newGauge("running", "Whether a transaction prune is in progress."),
+		scannedGauge: newGauge("scanned_documents", "Documents scanned by the current or last transaction prune."),
+		removedGauge: newGauge("removed_txns", "Transactions removed by the current or last transaction prune."),
+		backlogGauge: newGauge("backlog_txns", "Completed transactions awaiting examination by the current transaction prune."),
+		lastRunGauge: newGauge("last_duration_seconds", "How long the last complete transaction prune took."),
+		removedTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "juju",
+			Subsystem: "txnpruner",
+			Name:      "removed_txns_total",
+			Help:      "Total number of transactions removed.",
+		}),
+	}
+	w.Worker = worker.NewSimpleWorker(w.loop)
+	return w, nil
+}
+
+func newGauge(name, help string) prometheus.Gauge {
+	return prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "juju",
+		Subsystem: "txnpruner",
+		Name:      name,
+		Help:      help,
+	})
+}
+
+func (w *Worker) loop(stopCh <-chan struct{}) error {
+	for {
+		select {
+		case <-w.config.Clock.After(w.config.Interval):
+			if err := w.prune(stopCh); err != nil {
+				return errors.Annotate(err, "pruning failed, txnpruner stopping")
 			}
+		case <-stopCh:
+			return nil
 		}
+	}
+}
+
+func (w *Worker) prune(stopCh <-chan struct{}) error {
+	started := w.config.Clock.Now()
+	w.mu.Lock()
+	w.running = true
+	w.progress = Progress{Started: started}
+	w.lastRemovedAt = 0
+	w.mu.Unlock()
+
+	err := w.config.Pruner.PruneTransactions(PruneParams{
+		Clock:      w.config.Clock,
+		BatchSize:  w.config.BatchSize,
+		BatchPause: w.config.BatchPause,
+		Stop:       stopCh,
+		Progress:   w.setProgress,
 	})
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.running = false
+	if err == nil {
+		w.lastRun = w.config.Clock.Now().Sub(started)
+	}
+	return errors.Trace(err)
+}
+
+func (w *Worker) setProgress(progress Progress) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if progress.Removed > w.lastRemovedAt {
+		w.removedTotal.Add(float64(progress.Removed - w.lastRemovedAt))
+		w.lastRemovedAt = progress.Removed
+	}
+	w.progress = progress
+}
+
+// Progress returns the progress of the current prune, or of the last
+// one if none is running, and whether a prune is running.
+func (w *Worker) Progress() (Progress, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.progress, w.running
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (w *Worker) Describe(ch chan<- *prometheus.Desc) {
+	w.runningGauge.Describe(ch)
+	w.scannedGauge.Describe(ch)
+	w.removedGauge.Describe(ch)
+	w.backlogGauge.Describe(ch)
+	w.lastRunGauge.Describe(ch)
+	w.removedTotal.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (w *Worker) Collect(ch chan<- prometheus.Metric) {
+	w.mu.Lock()
+	running := 0.0
+	if w.running {
+		running = 1
+	}
+	w.runningGauge.Set(running)
+	w.scannedGauge.Set(float64(w.progress.Scanned))
+	w.removedGauge.Set(float64(w.progress.Removed))
+	w.backlogGauge.Set(float64(w.progress.Backlog))
+	w.lastRunGauge.Set(w.lastRun.Seconds())
+	w.mu.Unlock()
+
+	w.runningGauge.Collect(ch)
+	w.scannedGauge.Collect(ch)
+	w.removedGauge.Collect(ch)
+	w.backlogGauge.Collect(ch)
+	w.lastRunGauge.Collect(ch)
+	w.removedTotal.Collect(ch)
 }
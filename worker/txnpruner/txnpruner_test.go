@@ -4,11 +4,14 @@
 package txnpruner_test
 
 import (
+	"strings"
 	"time"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	gc "gopkg.in/check.v1"
 
 	coretesting "github.com/juju/juju/testing"
@@ -21,42 +24,102 @@ type TxnPrunerSuite struct {
 
 var _ = gc.Suite(&TxnPrunerSuite{})
 
+func (s *TxnPrunerSuite) config(pruner txnpruner.TransactionPruner, clock clock.Clock) txnpruner.Config {
+	return txnpruner.Config{
+		Pruner:     pruner,
+		Clock:      clock,
+		Interval:   time.Minute,
+		BatchSize:  10,
+		BatchPause: time.Second,
+	}
+}
+
+func (s *TxnPrunerSuite) TestValidate(c *gc.C) {
+	config := s.config(newFakeTransactionPruner(), clock.WallClock)
+	config.Pruner = nil
+	_, err := txnpruner.New(config)
+	c.Assert(err, gc.ErrorMatches, "nil Pruner not valid")
+
+	config = s.config(newFakeTransactionPruner(), nil)
+	_, err = txnpruner.New(config)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config(newFakeTransactionPruner(), clock.WallClock)
+	config.BatchSize = 0
+	_, err = txnpruner.New(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive BatchSize not valid")
+}
+
 func (s *TxnPrunerSuite) TestPrunes(c *gc.C) {
 	fakePruner := newFakeTransactionPruner()
 	testClock := testing.NewClock(time.Now())
-	interval := time.Minute
-	p := txnpruner.New(fakePruner, interval, testClock)
+	p, err := txnpruner.New(s.config(fakePruner, testClock))
+	c.Assert(err, jc.ErrorIsNil)
 	defer p.Kill()
 
-	select {
-	case <-testClock.Alarms():
-	case <-time.After(coretesting.LongWait):
-		c.Fatalf("timed out waiting for worker to stat")
-	}
-	c.Logf("pruner running and waiting: %s (%s)", testClock.Now(), time.Now())
+	s.waitAlarm(c, testClock)
 	// Show that we prune every minute
 	for i := 0; i < 5; i++ {
-		testClock.Advance(interval)
+		testClock.Advance(time.Minute)
 		c.Logf("loop %d: %s (%s)", i, testClock.Now(), time.Now())
 		select {
-		case <-fakePruner.pruneCh:
+		case params := <-fakePruner.pruneCh:
+			c.Check(params.BatchSize, gc.Equals, 10)
+			c.Check(params.BatchPause, gc.Equals, time.Second)
+			c.Check(params.Clock, gc.Equals, testClock)
 		case <-time.After(coretesting.LongWait):
 			c.Fatal("timed out waiting for pruning to happen")
 		}
 		// Now we need to wait for the txn pruner to call clock.After again
 		// before we advance the clock, or it will be waiting for the wrong time.
-		select {
-		case <-testClock.Alarms():
-		case <-time.After(coretesting.LongWait):
-			c.Fatalf("timed out waiting for worker to loop around")
-		}
+		s.waitAlarm(c, testClock)
+	}
+}
+
+func (s *TxnPrunerSuite) TestReportsProgress(c *gc.C) {
+	fakePruner := newFakeTransactionPruner()
+	fakePruner.progress = []txnpruner.Progress{
+		{Scanned: 10, Backlog: 0},
+		{Scanned: 20, Backlog: 8},
+		{Scanned: 20, Removed: 5, Backlog: 3},
+	}
+	testClock := testing.NewClock(time.Now())
+	p, err := txnpruner.New(s.config(fakePruner, testClock))
+	c.Assert(err, jc.ErrorIsNil)
+	defer p.Kill()
+
+	progress, running := p.Progress()
+	c.Assert(running, jc.IsFalse)
+	c.Assert(progress, jc.DeepEquals, txnpruner.Progress{})
+
+	s.waitAlarm(c, testClock)
+	testClock.Advance(time.Minute)
+	select {
+	case <-fakePruner.pruneCh:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for pruning to happen")
 	}
+	s.waitAlarm(c, testClock)
+
+	progress, running = p.Progress()
+	c.Assert(running, jc.IsFalse)
+	c.Assert(progress, jc.DeepEquals, txnpruner.Progress{
+		Scanned: 20, Removed: 5, Backlog: 3,
+	})
+
+	metrics := collectMetrics(c, p)
+	c.Check(metrics["juju_txnpruner_running"], gc.Equals, 0.0)
+	c.Check(metrics["juju_txnpruner_scanned_documents"], gc.Equals, 20.0)
+	c.Check(metrics["juju_txnpruner_removed_txns"], gc.Equals, 5.0)
+	c.Check(metrics["juju_txnpruner_backlog_txns"], gc.Equals, 3.0)
+	c.Check(metrics["juju_txnpruner_removed_txns_total"], gc.Equals, 5.0)
 }
 
 func (s *TxnPrunerSuite) TestStops(c *gc.C) {
 	success := make(chan bool)
 	check := func() {
-		p := txnpruner.New(newFakeTransactionPruner(), time.Minute, clock.WallClock)
+		p, err := txnpruner.New(s.config(newFakeTransactionPruner(), clock.WallClock))
+		c.Check(err, jc.ErrorIsNil)
 		p.Kill()
 		c.Check(p.Wait(), jc.ErrorIsNil)
 		success <- true
@@ -70,19 +133,63 @@ func (s *TxnPrunerSuite) TestStops(c *gc.C) {
 	}
 }
 
+func (s *TxnPrunerSuite) waitAlarm(c *gc.C, clock *testing.Clock) {
+	select {
+	case <-clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to wait")
+	}
+}
+
+func collectMetrics(c *gc.C, collector prometheus.Collector) map[string]float64 {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		collector.Collect(ch)
+	}()
+	values := make(map[string]float64)
+	for metric := range ch {
+		var dtoMetric dto.Metric
+		err := metric.Write(&dtoMetric)
+		c.Assert(err, jc.ErrorIsNil)
+		var value float64
+		if dtoMetric.Gauge != nil {
+			value = dtoMetric.Gauge.GetValue()
+		} else {
+			value = dtoMetric.Counter.GetValue()
+		}
+		values[fqName(metric.Desc())] = value
+	}
+	return values
+}
+
+// fqName extracts the fully-qualified metric name from a Desc, which
+// does not expose it directly.
+func fqName(desc *prometheus.Desc) string {
+	s := desc.String()
+	const prefix = `fqName: "`
+	start := strings.Index(s, prefix) + len(prefix)
+	end := start + strings.Index(s[start:], `"`)
+	return s[start:end]
+}
+
 func newFakeTransactionPruner() *fakeTransactionPruner {
 	return &fakeTransactionPruner{
-		pruneCh: make(chan bool),
+		pruneCh: make(chan txnpruner.PruneParams),
 	}
 }
 
 type fakeTransactionPruner struct {
-	pruneCh chan bool
+	pruneCh  chan txnpruner.PruneParams
+	progress []txnpruner.Progress
 }
 
-// MaybePruneTransactions implements the txnpruner.TransactionPruner
+// PruneTransactions implements the txnpruner.TransactionPruner
 // interface.
-func (p *fakeTransactionPruner) MaybePruneTransactions() error {
-	p.pruneCh <- true
+func (p *fakeTransactionPruner) PruneTransactions(params txnpruner.PruneParams) error {
+	for _, progress := range p.progress {
+		params.Progress(progress)
+	}
+	p.pruneCh <- params
 	return nil
 }
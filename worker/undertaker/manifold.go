@@ -4,7 +4,11 @@
 package undertaker
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker"
@@ -57,3 +61,64 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		Start: config.start,
 	}
 }
+
+// SweeperManifoldConfig holds the names of the resources used by, and
+// the additional dependencies of, a sweeper worker.
+type SweeperManifoldConfig struct {
+	APICallerName string
+	EnvironName   string
+	ClockName     string
+	Interval      time.Duration
+
+	NewFacade func(base.APICaller) (SweeperFacade, error)
+	NewWorker func(SweeperConfig) (worker.Worker, error)
+}
+
+func (config SweeperManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var environ environs.Environ
+	if err := context.Get(config.EnvironName, &environ); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sweeperEnviron, ok := environ.(SweeperEnviron)
+	if !ok {
+		logger.Debugf("provider cannot list cloud resources, orphans will not be swept")
+		return nil, dependency.ErrUninstall
+	}
+
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	worker, err := config.NewWorker(SweeperConfig{
+		Facade:   facade,
+		Environ:  sweeperEnviron,
+		Clock:    clock,
+		Interval: config.Interval,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// SweeperManifold returns a dependency.Manifold that runs a worker
+// responsible for finding, and optionally destroying, cloud resources
+// that failed operations left behind.
+func SweeperManifold(config SweeperManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+			config.EnvironName,
+			config.ClockName,
+		},
+		Start: config.start,
+	}
+}
@@ -4,9 +4,12 @@
 package undertaker_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base"
@@ -103,6 +106,61 @@ func (*ManifoldSuite) TestNewWorkerSuccess(c *gc.C) {
 	c.Check(worker, gc.Equals, expectWorker)
 }
 
+func (*ManifoldSuite) TestSweeperInputs(c *gc.C) {
+	manifold := undertaker.SweeperManifold(sweeperNamesConfig())
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{
+		"api-caller", "environ", "clock",
+	})
+}
+
+func (*ManifoldSuite) TestSweeperUninstallsIfUnsupported(c *gc.C) {
+	config := sweeperNamesConfig()
+	config.NewFacade = func(base.APICaller) (undertaker.SweeperFacade, error) {
+		c.Fatalf("unexpected facade creation")
+		return nil, nil
+	}
+	manifold := undertaker.SweeperManifold(config)
+	resources := resourcesMissing()
+	resources["clock"] = dt.StubResource{Output: &fakeClock{}}
+
+	worker, err := manifold.Start(resources.Context())
+	c.Check(err, gc.Equals, dependency.ErrUninstall)
+	c.Check(worker, gc.IsNil)
+}
+
+func (*ManifoldSuite) TestSweeperNewWorkerSuccess(c *gc.C) {
+	expectWorker := &fakeWorker{}
+	expectFacade := &fakeSweeperFacade{}
+	config := sweeperNamesConfig()
+	config.NewFacade = func(base.APICaller) (undertaker.SweeperFacade, error) {
+		return expectFacade, nil
+	}
+	resources := resourcesMissing()
+	resources["environ"] = dt.StubResource{Output: &fakeSweeperEnviron{}}
+	resources["clock"] = dt.StubResource{Output: &fakeClock{}}
+	config.NewWorker = func(cfg undertaker.SweeperConfig) (worker.Worker, error) {
+		c.Check(cfg.Facade, gc.Equals, expectFacade)
+		checkResource(c, cfg.Environ, resources, "environ")
+		checkResource(c, cfg.Clock, resources, "clock")
+		c.Check(cfg.Interval, gc.Equals, time.Hour)
+		return expectWorker, nil
+	}
+	manifold := undertaker.SweeperManifold(config)
+
+	worker, err := manifold.Start(resources.Context())
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
+
+func sweeperNamesConfig() undertaker.SweeperManifoldConfig {
+	return undertaker.SweeperManifoldConfig{
+		APICallerName: "api-caller",
+		EnvironName:   "environ",
+		ClockName:     "clock",
+		Interval:      time.Hour,
+	}
+}
+
 func namesConfig() undertaker.ManifoldConfig {
 	return undertaker.ManifoldConfig{
 		APICallerName: "api-caller",
@@ -133,6 +191,19 @@ type fakeEnviron struct {
 	environs.Environ
 }
 
+type fakeSweeperEnviron struct {
+	environs.Environ
+	environs.CloudResourceSweeper
+}
+
+type fakeClock struct {
+	clock.Clock
+}
+
+type fakeSweeperFacade struct {
+	undertaker.SweeperFacade
+}
+
 type fakeFacade struct {
 	undertaker.Facade
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/undertaker"
@@ -71,6 +72,14 @@ func (mock *mockEnviron) Destroy() error {
 	return mock.stub.NextErr()
 }
 
+func (mock *mockEnviron) StorageProviderTypes() ([]storage.ProviderType, error) {
+	mock.stub.AddCall("StorageProviderTypes")
+	if err := mock.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
 type mockWatcher struct {
 	worker.Worker
 	changes chan struct{}
@@ -31,3 +31,21 @@ func NewWorker(config Config) (worker.Worker, error) {
 	}
 	return worker, nil
 }
+
+// NewSweeperFacade creates a SweeperFacade from a base.APICaller.
+func NewSweeperFacade(apiCaller base.APICaller) (SweeperFacade, error) {
+	facade, err := undertaker.NewClient(apiCaller, watcher.NewNotifyWatcher)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+// NewSweeperWorker creates a worker.Worker from a SweeperConfig.
+func NewSweeperWorker(config SweeperConfig) (worker.Worker, error) {
+	worker, err := NewSweeper(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package undertaker
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.undertaker")
+
+// SweeperFacade covers the parts of the api/undertaker.Client that the
+// sweeper needs.
+type SweeperFacade interface {
+	KnownCloudResources() (params.KnownCloudResources, error)
+}
+
+// SweeperEnviron covers the parts of an environs.Environ that the
+// sweeper needs.
+type SweeperEnviron interface {
+	environs.ConfigGetter
+	environs.CloudResourceSweeper
+}
+
+// SweeperConfig holds the resources and configuration necessary to
+// run a sweeper worker.
+type SweeperConfig struct {
+	Facade   SweeperFacade
+	Environ  SweeperEnviron
+	Clock    clock.Clock
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional sweeper worker.
+func (config SweeperConfig) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewSweeper returns a worker which periodically compares the resources
+// that the cloud holds for a model with those that the model's state
+// records. Resources that state does not record are orphans, left
+// behind by failed operations; they are reported, and destroyed if the
+// model's destroy-orphaned-resources setting is true.
+func NewSweeper(config SweeperConfig) (*Sweeper, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &Sweeper{
+		config:   config,
+		suspects: make(map[environs.CloudResource]bool),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &s.catacomb,
+		Work: s.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+// Sweeper is a worker that finds, and optionally destroys, orphaned
+// cloud resources.
+type Sweeper struct {
+	catacomb catacomb.Catacomb
+	config   SweeperConfig
+
+	// suspects holds the orphans found by the previous sweep.
+	suspects map[environs.CloudResource]bool
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *Sweeper) Kill() {
+	s.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *Sweeper) Wait() error {
+	return s.catacomb.Wait()
+}
+
+func (s *Sweeper) loop() error {
+	for {
+		select {
+		case <-s.catacomb.Dying():
+			return s.catacomb.ErrDying()
+		case <-s.config.Clock.After(s.config.Interval):
+			if err := s.sweep(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (s *Sweeper) sweep() error {
+	// The cloud must be listed before state is read: a resource
+	// that is created and recorded between the two calls is then
+	// either missing from the cloud's list or found in state.
+	resources, err := s.config.Environ.CloudResources()
+	if err != nil {
+		return errors.Annotate(err, "listing cloud resources")
+	}
+	known, err := s.config.Facade.KnownCloudResources()
+	if err != nil {
+		return errors.Annotate(err, "listing known cloud resources")
+	}
+
+	// A resource is only treated as an orphan once two sweeps in a
+	// row have found it, so that one created by an operation still
+	// in progress, such as an instance that has been started but not
+	// yet recorded, is left alone.
+	var orphans []environs.CloudResource
+	suspects := make(map[environs.CloudResource]bool)
+	for _, r := range findOrphans(resources, known) {
+		if s.suspects[r] {
+			orphans = append(orphans, r)
+		}
+		suspects[r] = true
+	}
+	s.suspects = suspects
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	if !s.config.Environ.Config().DestroyOrphanedResources() {
+		for _, r := range orphans {
+			logger.Warningf(
+				"found orphaned %s %q; set %s to destroy it",
+				r.Kind, r.Id, config.DestroyOrphanedResourcesKey,
+			)
+		}
+		return nil
+	}
+	for _, r := range orphans {
+		logger.Infof("destroying orphaned %s %q", r.Kind, r.Id)
+	}
+	if err := s.config.Environ.DestroyCloudResources(orphans); err != nil {
+		return errors.Annotate(err, "destroying orphaned cloud resources")
+	}
+	for _, r := range orphans {
+		delete(s.suspects, r)
+	}
+	return nil
+}
+
+// findOrphans returns the resources that are not recorded in the known
+// resources.
+func findOrphans(resources []environs.CloudResource, known params.KnownCloudResources) []environs.CloudResource {
+	machineIds := set.NewStrings(known.MachineIds...)
+	instanceIds := set.NewStrings(known.InstanceIds...)
+	volumeIds := set.NewStrings(known.VolumeIds...)
+	var orphans []environs.CloudResource
+	for _, r := range resources {
+		var orphaned bool
+		switch r.Kind {
+		case environs.CloudResourceInstance:
+			orphaned = !instanceIds.Contains(r.Id)
+		case environs.CloudResourceVolume:
+			orphaned = !volumeIds.Contains(r.Id)
+		case environs.CloudResourceSecurityGroup:
+			orphaned = r.MachineId != "" && !machineIds.Contains(r.MachineId)
+		}
+		if orphaned {
+			orphans = append(orphans, r)
+		}
+	}
+	return orphans
+}
@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package undertaker_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/undertaker"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type SweeperSuite struct {
+	jujutesting.IsolationSuite
+	clock   *jujutesting.Clock
+	facade  *mockSweeperFacade
+	environ *mockSweeperEnviron
+}
+
+var _ = gc.Suite(&SweeperSuite{})
+
+var (
+	knownInstance  = environs.CloudResource{Kind: environs.CloudResourceInstance, Id: "i-0"}
+	orphanInstance = environs.CloudResource{Kind: environs.CloudResourceInstance, Id: "i-9"}
+	knownVolume    = environs.CloudResource{Kind: environs.CloudResourceVolume, Id: "vol-0"}
+	orphanVolume   = environs.CloudResource{Kind: environs.CloudResourceVolume, Id: "vol-9"}
+	knownGroup     = environs.CloudResource{Kind: environs.CloudResourceSecurityGroup, Id: "sg-0", MachineId: "0"}
+	orphanGroup    = environs.CloudResource{Kind: environs.CloudResourceSecurityGroup, Id: "sg-9", MachineId: "9"}
+
+	allResources = []environs.CloudResource{
+		knownInstance, orphanInstance,
+		knownVolume, orphanVolume,
+		knownGroup, orphanGroup,
+	}
+)
+
+func (s *SweeperSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Time{})
+	s.facade = &mockSweeperFacade{
+		known: params.KnownCloudResources{
+			MachineIds:  []string{"0"},
+			InstanceIds: []string{"i-0"},
+			VolumeIds:   []string{"vol-0"},
+		},
+	}
+	s.environ = &mockSweeperEnviron{
+		resources: make(chan []environs.CloudResource, 3),
+		destroyed: make(chan []environs.CloudResource, 1),
+	}
+}
+
+func (s *SweeperSuite) setDestroyOrphans(c *gc.C, destroy bool) {
+	s.environ.config = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		config.DestroyOrphanedResourcesKey: destroy,
+	})
+}
+
+func (s *SweeperSuite) newSweeper(c *gc.C) *undertaker.Sweeper {
+	w, err := undertaker.NewSweeper(undertaker.SweeperConfig{
+		Facade:   s.facade,
+		Environ:  s.environ,
+		Clock:    s.clock,
+		Interval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *SweeperSuite) advance(c *gc.C) {
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SweeperSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		config undertaker.SweeperConfig
+		err    string
+	}{{
+		config: undertaker.SweeperConfig{Environ: s.environ, Clock: s.clock, Interval: time.Hour},
+		err:    "nil Facade not valid",
+	}, {
+		config: undertaker.SweeperConfig{Facade: s.facade, Clock: s.clock, Interval: time.Hour},
+		err:    "nil Environ not valid",
+	}, {
+		config: undertaker.SweeperConfig{Facade: s.facade, Environ: s.environ, Interval: time.Hour},
+		err:    "nil Clock not valid",
+	}, {
+		config: undertaker.SweeperConfig{Facade: s.facade, Environ: s.environ, Clock: s.clock},
+		err:    "non-positive Interval not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := undertaker.NewSweeper(test.config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SweeperSuite) TestDestroysOrphans(c *gc.C) {
+	s.setDestroyOrphans(c, true)
+	s.environ.resources <- allResources
+	s.environ.resources <- allResources
+	w := s.newSweeper(c)
+	defer workertest.CleanKill(c, w)
+
+	s.advance(c)
+	s.advance(c)
+	select {
+	case destroyed := <-s.environ.destroyed:
+		c.Assert(destroyed, jc.SameContents, []environs.CloudResource{
+			orphanInstance, orphanVolume, orphanGroup,
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for orphans to be destroyed")
+	}
+}
+
+func (s *SweeperSuite) TestReportsOrphans(c *gc.C) {
+	s.setDestroyOrphans(c, false)
+	s.environ.resources <- allResources
+	s.environ.resources <- allResources
+	w := s.newSweeper(c)
+	defer workertest.CleanKill(c, w)
+
+	s.advance(c)
+	s.advance(c)
+	// Wait for the second sweep to complete.
+	s.advance(c)
+	c.Assert(s.environ.destroyed, gc.HasLen, 0)
+	c.Assert(c.GetTestLog(), jc.Contains,
+		`found orphaned instance "i-9"; set destroy-orphaned-resources to destroy it`)
+}
+
+func (s *SweeperSuite) TestIgnoresOrphansFoundOnce(c *gc.C) {
+	s.setDestroyOrphans(c, true)
+	s.environ.resources <- []environs.CloudResource{knownInstance, orphanInstance}
+	s.environ.resources <- []environs.CloudResource{knownInstance, orphanVolume}
+	w := s.newSweeper(c)
+	defer workertest.CleanKill(c, w)
+
+	s.advance(c)
+	s.advance(c)
+	// Wait for the second sweep to complete.
+	s.advance(c)
+	c.Assert(s.environ.destroyed, gc.HasLen, 0)
+}
+
+func (s *SweeperSuite) TestListError(c *gc.C) {
+	s.setDestroyOrphans(c, false)
+	s.environ.err = errors.New("boom")
+	w := s.newSweeper(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.advance(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "listing cloud resources: boom")
+}
+
+type mockSweeperFacade struct {
+	known params.KnownCloudResources
+}
+
+func (f *mockSweeperFacade) KnownCloudResources() (params.KnownCloudResources, error) {
+	return f.known, nil
+}
+
+type mockSweeperEnviron struct {
+	config    *config.Config
+	err       error
+	resources chan []environs.CloudResource
+	destroyed chan []environs.CloudResource
+}
+
+func (e *mockSweeperEnviron) Config() *config.Config {
+	return e.config
+}
+
+func (e *mockSweeperEnviron) CloudResources() ([]environs.CloudResource, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	select {
+	case resources := <-e.resources:
+		return resources, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (e *mockSweeperEnviron) DestroyCloudResources(resources []environs.CloudResource) error {
+	e.destroyed <- resources
+	return nil
+}
@@ -4,10 +4,13 @@
 package undertaker
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
@@ -123,7 +126,23 @@ func (u *Undertaker) run() error {
 	}
 
 	// Now the model is known to be hosted and dead, we can tidy up any
-	// provider resources it might have used.
+	// provider resources it might have used. Volumes that are to be
+	// released must be released first, so that they are not destroyed
+	// along with everything else.
+	if modelInfo.ReleaseStorage {
+		if err := u.setStatus(status.Destroying, "releasing storage"); err != nil {
+			return errors.Trace(err)
+		}
+		if err := common.ReleaseStorage(u.config.Environ); err != nil {
+			// Leave the reason in the model's status, as the
+			// model cannot be removed until it is dealt with.
+			message := fmt.Sprintf("cannot release storage: %v", err)
+			if err := u.setStatus(status.Destroying, message); err != nil {
+				logger.Errorf("setting model status: %v", err)
+			}
+			return errors.Annotate(err, "cannot release storage")
+		}
+	}
 	if err := u.setStatus(
 		status.Destroying, "tearing down cloud environment",
 	); err != nil {
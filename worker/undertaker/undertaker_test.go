@@ -67,6 +67,42 @@ func (s *UndertakerSuite) TestDyingDeadRemoved(c *gc.C) {
 	)
 }
 
+func (s *UndertakerSuite) TestReleaseStorage(c *gc.C) {
+	s.fix.info.Result.Life = "dead"
+	s.fix.info.Result.ReleaseStorage = true
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c,
+		"ModelInfo",
+		"SetStatus",
+		"StorageProviderTypes",
+		"SetStatus",
+		"Destroy",
+		"RemoveModel",
+	)
+	stub.CheckCall(
+		c, 1, "SetStatus", status.Destroying,
+		"releasing storage", map[string]interface{}(nil),
+	)
+}
+
+func (s *UndertakerSuite) TestReleaseStorageErrorFatal(c *gc.C) {
+	s.fix.errors = []error{nil, nil, errors.New("pow")}
+	s.fix.info.Result.Life = "dead"
+	s.fix.info.Result.ReleaseStorage = true
+	s.fix.dirty = true
+	stub := s.fix.run(c, func(w worker.Worker) {
+		err := workertest.CheckKilled(c, w)
+		c.Check(err, gc.ErrorMatches, "cannot release storage: pow")
+	})
+	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "StorageProviderTypes", "SetStatus")
+	stub.CheckCall(
+		c, 3, "SetStatus", status.Destroying,
+		"cannot release storage: pow", map[string]interface{}(nil),
+	)
+}
+
 func (s *UndertakerSuite) TestSetStatusDestroying(c *gc.C) {
 	stub := s.fix.run(c, func(w worker.Worker) {
 		workertest.CheckKilled(c, w)
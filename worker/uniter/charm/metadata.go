@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ParallelRelationHooksKey is the charm metadata key with which a charm
+// opts in to having the hooks of independent relations run concurrently.
+const ParallelRelationHooksKey = "parallel-relation-hooks"
+
+// uniterMetadata holds the charm metadata fields that are interpreted by
+// the uniter, rather than by the charm package.
+type uniterMetadata struct {
+	ParallelRelationHooks bool `yaml:"parallel-relation-hooks"`
+}
+
+// ReadParallelRelationHooks reports whether the charm deployed in the
+// supplied directory has opted in to concurrent execution of hooks for
+// independent relations. A missing metadata file is not an error; it
+// just means that the charm has not opted in.
+func ReadParallelRelationHooks(charmDir string) (bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	var meta uniterMetadata
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return false, errors.Annotate(err, "parsing charm metadata")
+	}
+	return meta.ParallelRelationHooks, nil
+}
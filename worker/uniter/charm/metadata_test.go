@@ -0,0 +1,54 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/charm"
+)
+
+type MetadataSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&MetadataSuite{})
+
+func (s *MetadataSuite) writeMetadata(c *gc.C, content string) string {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return dir
+}
+
+func (s *MetadataSuite) TestReadParallelRelationHooksMissingMetadata(c *gc.C) {
+	parallel, err := charm.ReadParallelRelationHooks(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parallel, jc.IsFalse)
+}
+
+func (s *MetadataSuite) TestReadParallelRelationHooksUnset(c *gc.C) {
+	dir := s.writeMetadata(c, "name: wordpress\nsummary: blog\n")
+	parallel, err := charm.ReadParallelRelationHooks(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parallel, jc.IsFalse)
+}
+
+func (s *MetadataSuite) TestReadParallelRelationHooksSet(c *gc.C) {
+	dir := s.writeMetadata(c, "name: wordpress\nparallel-relation-hooks: true\n")
+	parallel, err := charm.ReadParallelRelationHooks(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parallel, jc.IsTrue)
+}
+
+func (s *MetadataSuite) TestReadParallelRelationHooksInvalid(c *gc.C) {
+	dir := s.writeMetadata(c, "parallel-relation-hooks: [not, a, bool]\n")
+	_, err := charm.ReadParallelRelationHooks(dir)
+	c.Assert(err, gc.ErrorMatches, "parsing charm metadata: .*")
+}
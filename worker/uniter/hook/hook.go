@@ -16,8 +16,31 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// StorageResized is run when block storage attached to the
+	// unit has been grown, so that the charm may make use of the
+	// additional space, e.g. by growing a filesystem.
+	StorageResized hooks.Kind = "storage-resized"
+
+	// PreRemove is run when the unit becomes dying, before any of its
+	// relations are departed, so that the charm may drain connections
+	// or move data elsewhere. It may run for no longer than the
+	// model's unit-drain-timeout.
+	PreRemove hooks.Kind = "pre-remove"
+
+	// PayloadRemoved is run, once for each payload the unit still
+	// tracks, when the unit becomes dying, so that the charm may stop
+	// the workload rather than leak it when the payload's record is
+	// removed with the unit.
+	PayloadRemoved hooks.Kind = "payload-removed"
 )
 
+// IsStorage reports whether the hook kind is one of the storage hooks,
+// including those defined in this package.
+func IsStorage(kind hooks.Kind) bool {
+	return kind.IsStorage() || kind == StorageResized
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -37,6 +60,18 @@ type Info struct {
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// StorageSize is the size of block storage in MiB, as seen by the
+	// machine when the hook was queued. It is only set when Kind
+	// indicates a storage hook for block storage.
+	StorageSize uint64 `yaml:"storage-size,omitempty"`
+
+	// PayloadClass, PayloadType and PayloadID identify the payload
+	// relevant to the hook. They are only set when Kind is
+	// PayloadRemoved.
+	PayloadClass string `yaml:"payload-class,omitempty"`
+	PayloadType  string `yaml:"payload-type,omitempty"`
+	PayloadID    string `yaml:"payload-id,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -48,15 +83,20 @@ func (hi Info) Validate() error {
 		}
 		fallthrough
 	case hooks.Install, hooks.Start, hooks.ConfigChanged, hooks.UpgradeCharm, hooks.Stop, hooks.RelationBroken,
-		hooks.CollectMetrics, hooks.MeterStatusChanged, hooks.UpdateStatus:
+		hooks.CollectMetrics, hooks.MeterStatusChanged, hooks.UpdateStatus, PreRemove:
 		return nil
 	case hooks.Action:
 		return fmt.Errorf("hooks.Kind Action is deprecated")
-	case hooks.StorageAttached, hooks.StorageDetaching:
+	case hooks.StorageAttached, hooks.StorageDetaching, StorageResized:
 		if !names.IsValidStorage(hi.StorageId) {
 			return fmt.Errorf("invalid storage ID %q", hi.StorageId)
 		}
 		return nil
+	case PayloadRemoved:
+		if hi.PayloadClass == "" {
+			return fmt.Errorf("%q hook requires a payload", hi.Kind)
+		}
+		return nil
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
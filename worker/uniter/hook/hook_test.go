@@ -43,6 +43,7 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.Action}, "hooks.Kind Action is deprecated"},
 	{hook.Info{Kind: hooks.UpgradeCharm}, ""},
 	{hook.Info{Kind: hooks.Stop}, ""},
+	{hook.Info{Kind: hook.PreRemove}, ""},
 	{hook.Info{Kind: hooks.RelationJoined, RemoteUnit: "x"}, ""},
 	{hook.Info{Kind: hooks.RelationChanged, RemoteUnit: "x"}, ""},
 	{hook.Info{Kind: hooks.RelationDeparted, RemoteUnit: "x"}, ""},
@@ -50,6 +51,10 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.StorageResized}, `invalid storage ID ""`},
+	{hook.Info{Kind: hook.StorageResized, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.PayloadRemoved}, `"payload-removed" hook requires a payload`},
+	{hook.Info{Kind: hook.PayloadRemoved, PayloadClass: "db", PayloadID: "abc123"}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -63,3 +68,11 @@ func (s *InfoSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *InfoSuite) TestIsStorage(c *gc.C) {
+	c.Check(hook.IsStorage(hooks.StorageAttached), jc.IsTrue)
+	c.Check(hook.IsStorage(hooks.StorageDetaching), jc.IsTrue)
+	c.Check(hook.IsStorage(hook.StorageResized), jc.IsTrue)
+	c.Check(hook.IsStorage(hooks.Install), jc.IsFalse)
+	c.Check(hook.IsStorage(hook.LeaderElected), jc.IsFalse)
+}
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/juju/errors"
 	"github.com/juju/mutex"
@@ -28,8 +29,12 @@ var (
 
 type executor struct {
 	file               *StateFile
-	state              *State
 	acquireMachineLock func() (mutex.Releaser, error)
+
+	// mu guards state, which is read by State from other goroutines
+	// than the one running operations.
+	mu    sync.Mutex
+	state *State
 }
 
 // NewExecutor returns an Executor which takes its starting state from the
@@ -61,6 +66,8 @@ func NewExecutor(stateFilePath string, getInstallCharm func() (*corecharm.URL, e
 
 // State is part of the Executor interface.
 func (x *executor) State() State {
+	x.mu.Lock()
+	defer x.mu.Unlock()
 	return *x.state
 }
 
@@ -117,6 +124,8 @@ func (x *executor) writeState(newState State) error {
 	if err := x.file.Write(&newState); err != nil {
 		return errors.Annotatef(err, "writing state")
 	}
+	x.mu.Lock()
 	x.state = &newState
+	x.mu.Unlock()
 	return nil
 }
@@ -93,6 +93,43 @@ func (f *factory) NewSkipHook(hookInfo hook.Info) (Operation, error) {
 	return &skipOperation{hookOp}, nil
 }
 
+// NewFailHook is part of the Factory interface.
+func (f *factory) NewFailHook(hookInfo hook.Info) (Operation, error) {
+	if err := hookInfo.Validate(); err != nil {
+		return nil, err
+	}
+	return &failHook{info: hookInfo}, nil
+}
+
+// NewRunRelationHooks is part of the Factory interface.
+func (f *factory) NewRunRelationHooks(hookInfos []hook.Info) (Operation, error) {
+	if len(hookInfos) == 0 {
+		return nil, errors.New("no hooks specified")
+	}
+	callbacks := &serialCallbacks{callbacks: f.config.Callbacks}
+	seen := make(map[int]bool)
+	ops := make([]*runHook, len(hookInfos))
+	for i, hookInfo := range hookInfos {
+		if !hookInfo.Kind.IsRelation() {
+			return nil, errors.Errorf("not a relation hook: %#v", hookInfo)
+		}
+		if seen[hookInfo.RelationId] {
+			return nil, errors.Errorf("multiple hooks specified for relation %d", hookInfo.RelationId)
+		}
+		seen[hookInfo.RelationId] = true
+		op, err := f.NewRunHook(hookInfo)
+		if err != nil {
+			return nil, err
+		}
+		ops[i] = op.(*runHook)
+		ops[i].callbacks = callbacks
+	}
+	return &runRelationHooks{
+		ops:       ops,
+		callbacks: callbacks,
+	}, nil
+}
+
 // NewAction is part of the Factory interface.
 func (f *factory) NewAction(actionId string) (Operation, error) {
 	if !names.IsValidAction(actionId) {
@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"fmt"
+
+	"github.com/juju/juju/worker/uniter/hook"
+)
+
+type failHook struct {
+	info hook.Info
+	DoesNotRequireMachineLock
+}
+
+// String is part of the Operation interface.
+func (fh *failHook) String() string {
+	return fmt.Sprintf("fail %s hook", fh.info.Kind)
+}
+
+// Prepare records the hook as started but not completed, which is the
+// state a hook is left in when it fails, without running it.
+// Prepare is part of the Operation interface.
+func (fh *failHook) Prepare(state State) (*State, error) {
+	return stateChange{
+		Kind: RunHook,
+		Step: Pending,
+		Hook: &fh.info,
+	}.apply(state), ErrSkipExecute
+}
+
+// Execute is part of the Operation interface.
+func (fh *failHook) Execute(state State) (*State, error) {
+	return nil, ErrSkipExecute
+}
+
+// Commit leaves the hook recorded as failed.
+// Commit is part of the Operation interface.
+func (fh *failHook) Commit(state State) (*State, error) {
+	return nil, nil
+}
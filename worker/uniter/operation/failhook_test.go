@@ -0,0 +1,57 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type FailHookSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FailHookSuite{})
+
+func (s *FailHookSuite) TestInvalidHook(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	_, err := factory.NewFailHook(hook.Info{Kind: hooks.RelationJoined})
+	c.Assert(err, gc.ErrorMatches, `"relation-joined" hook requires a remote unit`)
+}
+
+func (s *FailHookSuite) TestPrepare(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewFailHook(hook.Info{Kind: hooks.UpdateStatus})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "fail update-status hook")
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+
+	newState, err := op.Prepare(operation.State{
+		Kind:    operation.RunHook,
+		Step:    operation.Queued,
+		Hook:    &hook.Info{Kind: hooks.UpdateStatus},
+		Started: true,
+	})
+	c.Assert(err, gc.Equals, operation.ErrSkipExecute)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind:    operation.RunHook,
+		Step:    operation.Pending,
+		Hook:    &hook.Info{Kind: hooks.UpdateStatus},
+		Started: true,
+	})
+}
+
+func (s *FailHookSuite) TestCommit(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewFailHook(hook.Info{Kind: hooks.UpdateStatus})
+	c.Assert(err, jc.ErrorIsNil)
+	newState, err := op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.IsNil)
+}
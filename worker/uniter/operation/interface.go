@@ -87,6 +87,15 @@ type Factory interface {
 	// completed successfully, without executing the hook.
 	NewSkipHook(hookInfo hook.Info) (Operation, error)
 
+	// NewFailHook creates an operation to mark the supplied hook as
+	// failed, without executing the hook.
+	NewFailHook(hookInfo hook.Info) (Operation, error)
+
+	// NewRunRelationHooks creates an operation to execute the supplied
+	// relation hooks concurrently. Each hook must belong to a different
+	// relation.
+	NewRunRelationHooks(hookInfos []hook.Info) (Operation, error)
+
 	// NewAction creates an operation to execute the supplied action.
 	NewAction(actionId string) (Operation, error)
 
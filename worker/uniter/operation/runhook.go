@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -38,8 +39,10 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
+	case rh.info.Kind == hook.PayloadRemoved:
+		suffix = fmt.Sprintf(" (%s)", payload.BuildID(rh.info.PayloadClass, rh.info.PayloadID))
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
 }
@@ -89,6 +92,7 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	rh.runner.Context().ResetExecutionSetUnitStatus()
 
 	ranHook := true
+	timedOut := false
 	step := Done
 
 	err := rh.runner.RunHook(rh.name)
@@ -97,6 +101,12 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case context.IsMissingHookError(cause):
 		ranHook = false
 		err = nil
+	case cause == context.ErrHookTimedOut:
+		// Only hooks with a time limit, such as pre-remove, can
+		// time out; once the time is up the unit carries on.
+		logger.Warningf("hook %q timed out", rh.name)
+		timedOut = true
+		err = nil
 	case cause == context.ErrRequeueAndReboot:
 		step = Queued
 		fallthrough
@@ -116,6 +126,16 @@ func (rh *runHook) Execute(state State) (*State, error) {
 		logger.Infof("skipped %q hook (missing)", rh.name)
 	}
 
+	if timedOut {
+		if err := rh.runner.Context().SetUnitStatus(jujuc.StatusInfo{
+			Status: string(status.Maintenance),
+			Info:   fmt.Sprintf("%s hook timed out", rh.name),
+		}); err != nil {
+			logger.Errorf("error updating workload status after %v hook timed out: %v", rh.info.Kind, err)
+			return nil, err
+		}
+	}
+
 	var hasRunStatusSet bool
 	var afterHookErr error
 	if hasRunStatusSet, afterHookErr = rh.afterHook(state); afterHookErr != nil {
@@ -142,6 +162,11 @@ func (rh *runHook) beforeHook() error {
 			Status: string(status.Maintenance),
 			Info:   "cleaning up prior to charm deletion",
 		})
+	case hook.PreRemove:
+		err = rh.runner.Context().SetUnitStatus(jujuc.StatusInfo{
+			Status: string(status.Maintenance),
+			Info:   "draining prior to removal",
+		})
 	}
 	if err != nil {
 		logger.Errorf("error updating workload status before %v hook: %v", rh.info.Kind, err)
@@ -220,6 +245,13 @@ func (rh *runHook) Commit(state State) (*State, error) {
 		newState.Started = true
 	case hooks.Stop:
 		newState.Stopped = true
+	case hook.PreRemove:
+		newState.Drained = true
+	case hook.PayloadRemoved:
+		id := payload.BuildID(rh.info.PayloadClass, rh.info.PayloadID)
+		removed := make([]string, len(state.RemovedPayloads), len(state.RemovedPayloads)+1)
+		copy(removed, state.RemovedPayloads)
+		newState.RemovedPayloads = append(removed, id)
 	}
 
 	return newState, nil
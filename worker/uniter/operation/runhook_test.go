@@ -272,6 +272,29 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteTimedOut(c *gc.C) {
+	runErr := context.ErrHookTimedOut
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hook.PreRemove, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:      operation.RunHook,
+		Step:      operation.Done,
+		Hook:      &hook.Info{Kind: hook.PreRemove},
+		StatusSet: true,
+	})
+	c.Assert(*callbacks.MockNotifyHookCompleted.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+
+	status, err := runnerFactory.MockNewHookRunner.runner.Context().UnitStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(status.Status), gc.Equals, "maintenance")
+	c.Assert(status.Info, gc.Equals, "some-hook-name hook timed out")
+}
+
 func (s *RunHookSuite) testExecuteSuccess(
 	c *gc.C, before, after operation.State, setStatusCalled bool,
 ) {
@@ -343,6 +366,9 @@ func testBeforeHookStatus(c *gc.C, kind hooks.Kind, status *jujuc.StatusInfo) {
 	case hooks.Stop:
 		c.Assert(string(status.Status), gc.Equals, "maintenance")
 		c.Assert(status.Info, gc.Equals, "cleaning up prior to charm deletion")
+	case hook.PreRemove:
+		c.Assert(string(status.Status), gc.Equals, "maintenance")
+		c.Assert(status.Info, gc.Equals, "draining prior to removal")
 	default:
 		c.Assert(string(status.Status), gc.Equals, "")
 	}
@@ -391,6 +417,10 @@ func (s *RunHookSuite) TestBeforeHookStatus(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestBeforeHookStatusPreRemove(c *gc.C) {
+	s.testBeforeHookExecute(c, (operation.Factory).NewRunHook, hook.PreRemove)
+}
+
 func (s *RunHookSuite) testExecuteHookWithSetStatus(c *gc.C, kind hooks.Kind, setStatusCalled bool) {
 	s.testExecuteThenCharmStatus(c,
 		overwriteState,
@@ -528,6 +558,46 @@ func (s *RunHookSuite) TestCommitSuccess_Start_Preserve(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestCommitSuccess_PreRemove_SetDrained(c *gc.C) {
+	for i, newHook := range []newHook{
+		(operation.Factory).NewRunHook,
+		(operation.Factory).NewSkipHook,
+	} {
+		c.Logf("variant %d", i)
+		s.testCommitSuccess(c,
+			newHook,
+			hook.Info{Kind: hook.PreRemove},
+			overwriteState,
+			operation.State{
+				Started: true,
+				Drained: true,
+				Kind:    operation.Continue,
+				Step:    operation.Pending,
+			},
+		)
+	}
+}
+
+func (s *RunHookSuite) TestCommitSuccess_PayloadRemoved_RecordsPayload(c *gc.C) {
+	for i, newHook := range []newHook{
+		(operation.Factory).NewRunHook,
+		(operation.Factory).NewSkipHook,
+	} {
+		c.Logf("variant %d", i)
+		s.testCommitSuccess(c,
+			newHook,
+			hook.Info{Kind: hook.PayloadRemoved, PayloadClass: "db", PayloadType: "docker", PayloadID: "abc123"},
+			overwriteState,
+			operation.State{
+				Started:         true,
+				RemovedPayloads: []string{"db/abc123"},
+				Kind:            operation.Continue,
+				Step:            operation.Pending,
+			},
+		)
+	}
+}
+
 func (s *RunHookSuite) testQueueHook_BlankSlate(c *gc.C, cause hooks.Kind) {
 	for i, newHook := range []newHook{
 		(operation.Factory).NewRunHook,
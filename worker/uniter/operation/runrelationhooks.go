@@ -0,0 +1,229 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	corecharm "gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+// runRelationHooks runs hooks for several independent relations
+// concurrently. Only the first hook is recorded in the operation state
+// while the hooks run; if any of them fails, the hooks that completed are
+// committed, and the failed hook is recorded so that it can be resolved
+// in the usual way. Hooks that were not committed will be requested again
+// by the relations resolver.
+//
+// If the agent is interrupted while the hooks run, no hook in the batch
+// has been committed. On restart the recorded hook is treated as having
+// failed, exactly as a single interrupted hook would be, and once it is
+// resolved the other hooks are requested and run again. Hooks must
+// already tolerate being rerun after an interruption, so nothing more
+// than the first hook needs to be persisted.
+//
+// The hooks share the operation's callbacks, which are not safe for
+// concurrent use, so the hooks are given serialCallbacks that call them
+// one at a time.
+type runRelationHooks struct {
+	ops       []*runHook
+	callbacks Callbacks
+
+	RequiresMachineLock
+}
+
+// String is part of the Operation interface.
+func (rh *runRelationHooks) String() string {
+	names := make([]string, len(rh.ops))
+	for i, op := range rh.ops {
+		names[i] = op.String()
+	}
+	return fmt.Sprintf("concurrently %s", strings.Join(names, ", "))
+}
+
+// Prepare ensures that all the hooks can be executed.
+// Prepare is part of the Operation interface.
+func (rh *runRelationHooks) Prepare(state State) (*State, error) {
+	for _, op := range rh.ops {
+		if _, err := op.Prepare(state); err != nil {
+			return nil, err
+		}
+	}
+	return stateChange{
+		Kind: RunHook,
+		Step: Pending,
+		Hook: &rh.ops[0].info,
+	}.apply(state), nil
+}
+
+type runHookResult struct {
+	state *State
+	err   error
+}
+
+// completed reports whether the hook ran to completion, including
+// hooks that asked for a reboot once they had finished.
+func (r runHookResult) completed() bool {
+	switch r.err {
+	case nil:
+		return true
+	case ErrNeedsReboot:
+		return r.state.Step == Done
+	}
+	return false
+}
+
+// Execute runs the hooks concurrently, and waits for them all to finish.
+// Execute is part of the Operation interface.
+func (rh *runRelationHooks) Execute(state State) (*State, error) {
+	results := make([]runHookResult, len(rh.ops))
+	var wg sync.WaitGroup
+	for i, op := range rh.ops {
+		wg.Add(1)
+		go func(i int, op *runHook) {
+			defer wg.Done()
+			newState, err := op.Execute(state)
+			results[i] = runHookResult{newState, err}
+		}(i, op)
+	}
+	wg.Wait()
+
+	// A reboot request takes precedence over hook failures, just as
+	// it would if the hooks had run one at a time; a hook that asked
+	// to be queued again after the reboot is the one recorded, as it
+	// must run again. Failed hooks are not committed, so the relations
+	// resolver will request them again once the machine is back.
+	var hasRunStatusSet bool
+	failed, reboot := -1, -1
+	for i, result := range results {
+		switch result.err {
+		case nil:
+		case ErrNeedsReboot:
+			if reboot == -1 || (result.state.Step == Queued && results[reboot].state.Step != Queued) {
+				reboot = i
+			}
+		default:
+			if failed == -1 {
+				failed = i
+			}
+			continue
+		}
+		hasRunStatusSet = hasRunStatusSet || result.state.StatusSet
+	}
+	if failed == -1 && reboot == -1 {
+		return stateChange{
+			Kind:            RunHook,
+			Step:            Done,
+			Hook:            &rh.ops[0].info,
+			HasRunStatusSet: hasRunStatusSet,
+		}.apply(state), nil
+	}
+	recorded := failed
+	if reboot != -1 {
+		recorded = reboot
+	}
+
+	// Commit the other hooks that completed, so that only the
+	// recorded hook is left outstanding.
+	for i, op := range rh.ops {
+		if i == recorded || !results[i].completed() {
+			continue
+		}
+		if err := rh.callbacks.CommitHook(op.info); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	result := results[recorded]
+	if result.state != nil {
+		return result.state, result.err
+	}
+	return stateChange{
+		Kind: RunHook,
+		Step: Pending,
+		Hook: &rh.ops[recorded].info,
+	}.apply(state), result.err
+}
+
+// Commit updates relation state to include the fact of the hooks' execution.
+// Commit is part of the Operation interface.
+func (rh *runRelationHooks) Commit(state State) (*State, error) {
+	newState := &state
+	for _, op := range rh.ops {
+		var err error
+		if newState, err = op.Commit(*newState); err != nil {
+			return nil, err
+		}
+	}
+	return newState, nil
+}
+
+// serialCallbacks wraps a Callbacks so that its methods are never called
+// concurrently, for the use of hooks run by runRelationHooks.
+type serialCallbacks struct {
+	mu        sync.Mutex
+	callbacks Callbacks
+}
+
+// PrepareHook is part of the Callbacks interface.
+func (cb *serialCallbacks) PrepareHook(info hook.Info) (string, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.callbacks.PrepareHook(info)
+}
+
+// CommitHook is part of the Callbacks interface.
+func (cb *serialCallbacks) CommitHook(info hook.Info) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.callbacks.CommitHook(info)
+}
+
+// SetExecutingStatus is part of the Callbacks interface.
+func (cb *serialCallbacks) SetExecutingStatus(message string) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.callbacks.SetExecutingStatus(message)
+}
+
+// NotifyHookCompleted is part of the Callbacks interface.
+func (cb *serialCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.callbacks.NotifyHookCompleted(hookName, ctx)
+}
+
+// NotifyHookFailed is part of the Callbacks interface.
+func (cb *serialCallbacks) NotifyHookFailed(hookName string, ctx runner.Context) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.callbacks.NotifyHookFailed(hookName, ctx)
+}
+
+// FailAction is part of the Callbacks interface.
+func (cb *serialCallbacks) FailAction(actionId, message string) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.callbacks.FailAction(actionId, message)
+}
+
+// GetArchiveInfo is part of the Callbacks interface.
+func (cb *serialCallbacks) GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.callbacks.GetArchiveInfo(charmURL)
+}
+
+// SetCurrentCharm is part of the Callbacks interface.
+func (cb *serialCallbacks) SetCurrentCharm(charmURL *corecharm.URL) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.callbacks.SetCurrentCharm(charmURL)
+}
@@ -0,0 +1,273 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type RunRelationHooksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RunRelationHooksSuite{})
+
+// relationHooksCallbacks is an operation.Callbacks that records the
+// hooks it is asked to commit. It is not goroutine-safe, as the
+// operation must never call it concurrently.
+type relationHooksCallbacks struct {
+	operation.Callbacks
+	calling   bool
+	committed []hook.Info
+}
+
+func (cb *relationHooksCallbacks) enter() func() {
+	if cb.calling {
+		panic("concurrent callback")
+	}
+	cb.calling = true
+	return func() { cb.calling = false }
+}
+
+func (cb *relationHooksCallbacks) PrepareHook(hookInfo hook.Info) (string, error) {
+	defer cb.enter()()
+	return string(hookInfo.Kind), nil
+}
+
+func (cb *relationHooksCallbacks) SetExecutingStatus(string) error {
+	defer cb.enter()()
+	time.Sleep(time.Millisecond)
+	return nil
+}
+
+func (cb *relationHooksCallbacks) NotifyHookCompleted(string, runner.Context) {
+	defer cb.enter()()
+	time.Sleep(time.Millisecond)
+}
+
+func (cb *relationHooksCallbacks) NotifyHookFailed(string, runner.Context) {
+	defer cb.enter()()
+}
+
+func (cb *relationHooksCallbacks) CommitHook(hookInfo hook.Info) error {
+	defer cb.enter()()
+	cb.committed = append(cb.committed, hookInfo)
+	return nil
+}
+
+// relationHooksRunnerFactory creates a separate runner for each hook,
+// failing the hooks of the relations listed in failures.
+type relationHooksRunnerFactory struct {
+	runner.Factory
+	failures map[int]error
+}
+
+func (f *relationHooksRunnerFactory) NewHookRunner(hookInfo hook.Info) (runner.Runner, error) {
+	return &MockRunner{
+		MockRunHook: &MockRunHook{err: f.failures[hookInfo.RelationId]},
+		context:     &MockContext{},
+	}, nil
+}
+
+var (
+	relationHook0 = hook.Info{Kind: hooks.RelationJoined, RelationId: 0, RemoteUnit: "mysql/0"}
+	relationHook1 = hook.Info{Kind: hooks.RelationChanged, RelationId: 1, RemoteUnit: "logging/0"}
+	relationHook2 = hook.Info{Kind: hooks.RelationBroken, RelationId: 2}
+)
+
+func (s *RunRelationHooksSuite) newOp(c *gc.C, failures map[int]error) (operation.Operation, *relationHooksCallbacks) {
+	callbacks := &relationHooksCallbacks{}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: &relationHooksRunnerFactory{failures: failures},
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewRunRelationHooks([]hook.Info{relationHook0, relationHook1, relationHook2})
+	c.Assert(err, jc.ErrorIsNil)
+	return op, callbacks
+}
+
+func (s *RunRelationHooksSuite) TestNewRunRelationHooksErrors(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	for i, test := range []struct {
+		hooks []hook.Info
+		err   string
+	}{{
+		err: "no hooks specified",
+	}, {
+		hooks: []hook.Info{relationHook0, {Kind: hooks.ConfigChanged}},
+		err:   "not a relation hook: .*",
+	}, {
+		hooks: []hook.Info{relationHook0, {Kind: hooks.RelationDeparted, RelationId: 0, RemoteUnit: "mysql/1"}},
+		err:   "multiple hooks specified for relation 0",
+	}, {
+		hooks: []hook.Info{{Kind: hooks.RelationJoined, RelationId: 1}},
+		err:   `"relation-joined" hook requires a remote unit`,
+	}} {
+		c.Logf("test %d", i)
+		op, err := factory.NewRunRelationHooks(test.hooks)
+		c.Check(op, gc.IsNil)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RunRelationHooksSuite) TestString(c *gc.C) {
+	op, _ := s.newOp(c, nil)
+	c.Assert(op.String(), gc.Equals, "concurrently "+
+		"run relation-joined (0; mysql/0) hook, "+
+		"run relation-changed (1; logging/0) hook, "+
+		"run relation-broken (2) hook")
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
+}
+
+func (s *RunRelationHooksSuite) TestPrepare(c *gc.C) {
+	op, _ := s.newOp(c, nil)
+	newState, err := op.Prepare(operation.State{Kind: operation.Continue})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Pending,
+		Hook: &relationHook0,
+	})
+}
+
+func (s *RunRelationHooksSuite) TestExecuteAndCommitSuccess(c *gc.C) {
+	op, callbacks := s.newOp(c, nil)
+	state, err := op.Prepare(operation.State{Kind: operation.Continue})
+	c.Assert(err, jc.ErrorIsNil)
+
+	state, err = op.Execute(*state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Done,
+		Hook: &relationHook0,
+	})
+	c.Assert(callbacks.committed, gc.HasLen, 0)
+
+	state, err = op.Commit(*state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, jc.DeepEquals, &operation.State{
+		Kind: operation.Continue,
+		Step: operation.Pending,
+	})
+	c.Assert(callbacks.committed, jc.DeepEquals, []hook.Info{
+		relationHook0, relationHook1, relationHook2,
+	})
+}
+
+func (s *RunRelationHooksSuite) TestExecuteHookFailure(c *gc.C) {
+	op, callbacks := s.newOp(c, map[int]error{
+		1: errors.New("kerblam"),
+	})
+	state, err := op.Prepare(operation.State{Kind: operation.Continue})
+	c.Assert(err, jc.ErrorIsNil)
+
+	state, err = op.Execute(*state)
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(state, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Pending,
+		Hook: &relationHook1,
+	})
+	c.Assert(callbacks.committed, jc.SameContents, []hook.Info{
+		relationHook0, relationHook2,
+	})
+}
+
+func (s *RunRelationHooksSuite) TestExecuteMissingHook(c *gc.C) {
+	op, _ := s.newOp(c, map[int]error{
+		2: context.NewMissingHookError("relation-broken"),
+	})
+	state, err := op.Prepare(operation.State{Kind: operation.Continue})
+	c.Assert(err, jc.ErrorIsNil)
+
+	state, err = op.Execute(*state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.Step, gc.Equals, operation.Done)
+}
+
+func (s *RunRelationHooksSuite) TestExecuteNeedsReboot(c *gc.C) {
+	op, callbacks := s.newOp(c, map[int]error{
+		1: context.ErrReboot,
+	})
+	state, err := op.Prepare(operation.State{Kind: operation.Continue})
+	c.Assert(err, jc.ErrorIsNil)
+
+	state, err = op.Execute(*state)
+	c.Assert(err, gc.Equals, operation.ErrNeedsReboot)
+	c.Assert(state, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Done,
+		Hook: &relationHook1,
+	})
+	c.Assert(callbacks.committed, jc.SameContents, []hook.Info{
+		relationHook0, relationHook2,
+	})
+}
+
+func (s *RunRelationHooksSuite) TestExecuteNeedsRebootTakesPrecedence(c *gc.C) {
+	op, callbacks := s.newOp(c, map[int]error{
+		0: context.ErrReboot,
+		1: context.ErrRequeueAndReboot,
+		2: errors.New("kerblam"),
+	})
+	state, err := op.Prepare(operation.State{Kind: operation.Continue})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The hook to be run again after the reboot is recorded; the
+	// failed hook is not committed, so it will be requested again.
+	state, err = op.Execute(*state)
+	c.Assert(err, gc.Equals, operation.ErrNeedsReboot)
+	c.Assert(state, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Queued,
+		Hook: &relationHook1,
+	})
+	c.Assert(callbacks.committed, jc.DeepEquals, []hook.Info{relationHook0})
+}
+
+func (s *RunRelationHooksSuite) TestInterruptedExecuteReplaysHooks(c *gc.C) {
+	op, callbacks := s.newOp(c, nil)
+	state, err := op.Prepare(operation.State{Kind: operation.Continue})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The agent is interrupted while the hooks run: only the first hook
+	// has been persisted, and none of the hooks has been committed.
+	stateFile := operation.NewStateFile(filepath.Join(c.MkDir(), "operation"))
+	err = stateFile.Write(state)
+	c.Assert(err, jc.ErrorIsNil)
+	state, err = stateFile.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Pending,
+		Hook: &relationHook0,
+	})
+	c.Assert(callbacks.committed, gc.HasLen, 0)
+
+	// Once the recorded hook has been resolved, the relations resolver
+	// requests all of the uncommitted hooks again.
+	op, callbacks = s.newOp(c, nil)
+	state, err = op.Prepare(operation.State{Kind: operation.Continue})
+	c.Assert(err, jc.ErrorIsNil)
+	state, err = op.Execute(*state)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Commit(*state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(callbacks.committed, jc.DeepEquals, []hook.Info{
+		relationHook0, relationHook1, relationHook2,
+	})
+}
@@ -65,6 +65,13 @@ type State struct {
 	// Stopped indicates whether the stop hook has run.
 	Stopped bool `yaml:"stopped"`
 
+	// Drained indicates whether the pre-remove hook has run.
+	Drained bool `yaml:"drained"`
+
+	// RemovedPayloads holds the IDs, as built by payload.BuildID, of
+	// the payloads for which the payload-removed hook has run.
+	RemovedPayloads []string `yaml:"removed-payloads,omitempty"`
+
 	// Installed indicates whether the install hook has run.
 	Installed bool `yaml:"installed"`
 
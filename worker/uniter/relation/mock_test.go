@@ -8,6 +8,9 @@ import (
 
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/relation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
 )
 
 type mockOperations struct {
@@ -42,3 +45,24 @@ func (m *mockOperation) Execute(state operation.State) (*operation.State, error)
 func (m *mockOperation) Commit(state operation.State) (*operation.State, error) {
 	return &state, nil
 }
+
+func (m *mockOperations) NewRunRelationHooks(hookInfos []hook.Info) (operation.Operation, error) {
+	return &mockRelationHooksOperation{hookInfos: hookInfos}, nil
+}
+
+type mockRelationHooksOperation struct {
+	operation.Operation
+	hookInfos []hook.Info
+}
+
+type mockRelations struct {
+	relation.Relations
+	hookInfos []hook.Info
+}
+
+func (m *mockRelations) NextHooks(resolver.LocalState, remotestate.Snapshot) ([]hook.Info, error) {
+	if len(m.hookInfos) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	return m.hookInfos, nil
+}
@@ -4,6 +4,8 @@
 package relation
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
@@ -45,6 +47,11 @@ type Relations interface {
 	// NextHook returns details on the next hook to execute, based on the local
 	// and remote states.
 	NextHook(resolver.LocalState, remotestate.Snapshot) (hook.Info, error)
+
+	// NextHooks returns details on the next hook to execute for each relation
+	// that has work outstanding, based on the local and remote states. The
+	// hooks are ordered by relation id, and no two of them share a relation.
+	NextHooks(resolver.LocalState, remotestate.Snapshot) ([]hook.Info, error)
 }
 
 // NewRelationsResolver returns a new Resolver that handles differences in
@@ -70,6 +77,34 @@ func (s *relationsResolver) NextOp(
 	return opFactory.NewRunHook(hook)
 }
 
+// NewParallelRelationsResolver returns a new Resolver that handles
+// differences in relation state by running the next hook of every relation
+// with outstanding work concurrently. It should only be used for charms
+// that have opted in to parallel relation hook execution.
+func NewParallelRelationsResolver(r Relations) resolver.Resolver {
+	return &parallelRelationsResolver{r}
+}
+
+type parallelRelationsResolver struct {
+	relations Relations
+}
+
+// NextOp implements resolver.Resolver.
+func (s *parallelRelationsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	hooks, err := s.relations.NextHooks(localState, remoteState)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(hooks) == 1 {
+		return opFactory.NewRunHook(hooks[0])
+	}
+	return opFactory.NewRunRelationHooks(hooks)
+}
+
 // relations implements Relations.
 type relations struct {
 	st           *uniter.State
@@ -149,6 +184,29 @@ func (r *relations) NextHook(
 	localState resolver.LocalState,
 	remoteState remotestate.Snapshot,
 ) (hook.Info, error) {
+	hooks, err := r.nextHooks(localState, remoteState, false)
+	if err != nil {
+		return hook.Info{}, err
+	}
+	return hooks[0], nil
+}
+
+// NextHooks implements Relations.
+func (r *relations) NextHooks(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+) ([]hook.Info, error) {
+	return r.nextHooks(localState, remoteState, true)
+}
+
+// nextHooks returns the next hook for each relation with outstanding work,
+// ordered by relation id. If all is false, it stops after finding the first
+// such hook. If no hooks need to be executed, it returns ErrNoOperation.
+func (r *relations) nextHooks(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	all bool,
+) ([]hook.Info, error) {
 
 	if remoteState.Life == params.Dying {
 		// The unit is Dying, so make sure all subordinates are dying.
@@ -169,22 +227,30 @@ func (r *relations) NextHook(
 		}
 		if destroyAllSubordinates {
 			if err := r.unit.DestroyAllSubordinates(); err != nil {
-				return hook.Info{}, errors.Trace(err)
+				return nil, errors.Trace(err)
 			}
 		}
 	}
 
 	// Add/remove local relation state; enter and leave scope as necessary.
 	if err := r.update(remoteState.Relations); err != nil {
-		return hook.Info{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	if localState.Kind != operation.Continue {
-		return hook.Info{}, resolver.ErrNoOperation
+		return nil, resolver.ErrNoOperation
 	}
 
-	// See if any of the relations have operations to perform.
-	for relationId, relationSnapshot := range remoteState.Relations {
+	// See if any of the relations have operations to perform. We visit
+	// the relations in id order, so that hooks are produced consistently.
+	relationIds := make([]int, 0, len(remoteState.Relations))
+	for relationId := range remoteState.Relations {
+		relationIds = append(relationIds, relationId)
+	}
+	sort.Ints(relationIds)
+	var result []hook.Info
+	for _, relationId := range relationIds {
+		relationSnapshot := remoteState.Relations[relationId]
 		relationer, ok := r.relationers[relationId]
 		if !ok || relationer.IsImplicit() {
 			continue
@@ -200,10 +266,18 @@ func (r *relations) NextHook(
 		hook, err := nextRelationHook(relationer.dir.State(), relationSnapshot, remoteBroken)
 		if err == resolver.ErrNoOperation {
 			continue
+		} else if err != nil {
+			return nil, err
+		}
+		result = append(result, hook)
+		if !all {
+			break
 		}
-		return hook, err
 	}
-	return hook.Info{}, resolver.ErrNoOperation
+	if len(result) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	return result, nil
 }
 
 // nextRelationHook returns the next hook op that should be executed in the
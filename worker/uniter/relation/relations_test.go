@@ -78,7 +78,7 @@ func mockAPICaller(c *gc.C, callNumber *int32, apiCalls ...apiCall) apitesting.A
 			c.Check(index < len(apiCalls), jc.IsTrue)
 			call := apiCalls[index]
 			c.Logf("request %d, %s", index, request)
-			c.Check(version, gc.Equals, 4)
+			c.Check(version, gc.Equals, 12)
 			c.Check(id, gc.Equals, "")
 			c.Check(request, gc.Equals, call.request)
 			c.Check(arg, jc.DeepEquals, call.args)
@@ -522,3 +522,32 @@ func (s *relationsSuite) TestImplicitRelationNoHooks(c *gc.C) {
 	_, err = relationsResolver.NextOp(localState, remoteState, &mockOperations{})
 	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
 }
+
+func (s *relationsSuite) TestParallelResolverNothing(c *gc.C) {
+	relationsResolver := relation.NewParallelRelationsResolver(&mockRelations{})
+	_, err := relationsResolver.NextOp(resolver.LocalState{}, remotestate.Snapshot{}, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *relationsSuite) TestParallelResolverSingleHook(c *gc.C) {
+	hookInfo := hook.Info{Kind: hooks.RelationBroken, RelationId: 1}
+	relationsResolver := relation.NewParallelRelationsResolver(&mockRelations{
+		hookInfos: []hook.Info{hookInfo},
+	})
+	op, err := relationsResolver.NextOp(resolver.LocalState{}, remotestate.Snapshot{}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.(*mockOperation).hookInfo, jc.DeepEquals, hookInfo)
+}
+
+func (s *relationsSuite) TestParallelResolverMultipleHooks(c *gc.C) {
+	hookInfos := []hook.Info{
+		{Kind: hooks.RelationBroken, RelationId: 1},
+		{Kind: hooks.RelationJoined, RelationId: 2, RemoteUnit: "mysql/0"},
+	}
+	relationsResolver := relation.NewParallelRelationsResolver(&mockRelations{
+		hookInfos: hookInfos,
+	})
+	op, err := relationsResolver.NextOp(resolver.LocalState{}, remotestate.Snapshot{}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.(*mockRelationHooksOperation).hookInfos, jc.DeepEquals, hookInfos)
+}
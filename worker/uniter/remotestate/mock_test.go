@@ -185,6 +185,7 @@ type mockUnit struct {
 	tag                   names.UnitTag
 	life                  params.Life
 	resolved              params.ResolvedMode
+	payloads              []params.UnitPayload
 	service               mockService
 	unitWatcher           *mockNotifyWatcher
 	addressesWatcher      *mockNotifyWatcher
@@ -205,6 +206,10 @@ func (u *mockUnit) Resolved() (params.ResolvedMode, error) {
 	return u.resolved, nil
 }
 
+func (u *mockUnit) Payloads() ([]params.UnitPayload, error) {
+	return u.payloads, nil
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.service, nil
 }
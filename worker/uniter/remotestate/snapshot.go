@@ -68,6 +68,10 @@ type Snapshot struct {
 	// Commands is the list of IDs of commands to be
 	// executed by this unit.
 	Commands []string
+
+	// Payloads is the list of payloads tracked by the
+	// unit. It is only read once the unit is dying.
+	Payloads []params.UnitPayload
 }
 
 type RelationSnapshot struct {
@@ -82,4 +86,8 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+
+	// Size is the size of block storage in MiB, as seen
+	// by the machine, or zero if unknown.
+	Size uint64
 }
@@ -35,6 +35,7 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       func() <-chan time.Time
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	interruptOperation        func()
 
 	catacomb catacomb.Catacomb
 
@@ -52,6 +53,11 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+
+	// InterruptOperation, if not nil, is called when the unit is
+	// asked to skip or fail its pending operation, so that a hook or
+	// action that is still running can be stopped.
+	InterruptOperation func()
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
@@ -67,6 +73,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		interruptOperation:        config.InterruptOperation,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -475,10 +482,28 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	var payloads []params.UnitPayload
+	if w.unit.Life() != params.Alive {
+		// A dying unit gives its charm a chance to clean up each
+		// of the payloads it still tracks.
+		payloads, err = w.unit.Payloads()
+		if errors.IsNotSupported(err) {
+			payloads, err = nil, nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	w.mu.Lock()
-	defer w.mu.Unlock()
+	interrupt := resolved != w.current.ResolvedMode &&
+		(resolved == params.ResolvedSkipOperation || resolved == params.ResolvedFailOperation)
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = resolved
+	w.current.Payloads = payloads
+	w.mu.Unlock()
+	if interrupt && w.interruptOperation != nil {
+		w.interruptOperation()
+	}
 	return nil
 }
 
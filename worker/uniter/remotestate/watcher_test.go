@@ -25,6 +25,8 @@ type WatcherSuite struct {
 	leadership *mockLeadershipTracker
 	watcher    *remotestate.RemoteStateWatcher
 	clock      *testing.Clock
+
+	interrupted chan struct{}
 }
 
 // Duration is arbitrary, we'll trigger the ticker
@@ -71,11 +73,13 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		return s.clock.After(statusTickDuration)
 	}
 
+	s.interrupted = make(chan struct{}, 1)
 	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
 		State:               s.st,
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
+		InterruptOperation:  func() { s.interrupted <- struct{}{} },
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -156,9 +160,11 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	initial := s.watcher.Snapshot()
 
 	s.st.unit.life = params.Dying
+	s.st.unit.payloads = []params.UnitPayload{{Class: "db", Type: "docker", ID: "abc123"}}
 	s.st.unit.unitWatcher.changes <- struct{}{}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().Life, gc.Equals, params.Dying)
+	c.Assert(s.watcher.Snapshot().Payloads, jc.DeepEquals, s.st.unit.payloads)
 
 	s.st.unit.addressesWatcher.changes <- struct{}{}
 	assertOneChange()
@@ -209,6 +215,23 @@ func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	c.Assert(snap.ResolvedMode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *WatcherSuite) TestResolveOperationInterrupts(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	assertNoNotifyEvent(c, s.interrupted, "interruption")
+
+	s.st.unit.resolved = params.ResolvedFailOperation
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	assertNotifyEvent(c, s.interrupted, "waiting for interruption")
+	c.Assert(s.watcher.Snapshot().ResolvedMode, gc.Equals, params.ResolvedFailOperation)
+
+	// An unchanged request does not interrupt again.
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	assertNoNotifyEvent(c, s.interrupted, "interruption")
+}
+
 func (s *WatcherSuite) TestLeadershipChanged(c *gc.C) {
 	s.leadership.claimTicket.result = false
 	signalAll(s.st, s.leadership)
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -50,6 +51,14 @@ func (s *uniterResolver) NextOp(
 		return nil, resolver.ErrTerminate
 	}
 
+	switch remoteState.ResolvedMode {
+	case params.ResolvedSkipOperation, params.ResolvedFailOperation:
+		op, err := s.nextOpResolveOperation(localState, remoteState, opFactory)
+		if errors.Cause(err) != resolver.ErrNoOperation {
+			return op, err
+		}
+	}
+
 	if localState.Kind == operation.Upgrade {
 		if localState.Conflicted {
 			return s.nextOpConflicted(localState, remoteState, opFactory)
@@ -146,6 +155,53 @@ func (s *uniterResolver) nextOpConflicted(
 	return nil, resolver.ErrWaiting
 }
 
+// nextOpResolveOperation is called when the unit has been asked to skip
+// or fail its pending operation, whether or not it is in an error state.
+// A queued hook is skipped or marked as failed; a failed hook is left to
+// nextOpHookError. If there is nothing to resolve, the request is
+// cleared and ErrNoOperation is returned.
+func (s *uniterResolver) nextOpResolveOperation(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	skip := remoteState.ResolvedMode == params.ResolvedSkipOperation
+	switch localState.Kind {
+	case operation.RunHook:
+		switch localState.Step {
+		case operation.Pending:
+			return nil, resolver.ErrNoOperation
+		case operation.Queued:
+			if err := s.config.ClearResolved(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if skip {
+				logger.Infof("skipping queued %q hook", localState.Hook.Kind)
+				return opFactory.NewSkipHook(*localState.Hook)
+			}
+			logger.Infof("failing queued %q hook", localState.Hook.Kind)
+			return opFactory.NewFailHook(*localState.Hook)
+		}
+	case operation.Upgrade:
+		if localState.Conflicted {
+			if skip {
+				// Skipping a conflicted upgrade resolves it, as
+				// any other resolved mode does.
+				return nil, resolver.ErrNoOperation
+			}
+			if err := s.config.ClearResolved(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			return nil, resolver.ErrWaiting
+		}
+	}
+	logger.Infof("no pending operation to resolve")
+	if err := s.config.ClearResolved(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return nil, resolver.ErrNoOperation
+}
+
 func (s *uniterResolver) nextOpHookError(
 	localState resolver.LocalState,
 	remoteState remotestate.Snapshot,
@@ -189,13 +245,20 @@ func (s *uniterResolver) nextOpHookError(
 			return nil, errors.Trace(err)
 		}
 		return opFactory.NewRunHook(*localState.Hook)
-	case params.ResolvedNoHooks:
+	case params.ResolvedNoHooks, params.ResolvedSkipOperation:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
 		return opFactory.NewSkipHook(*localState.Hook)
+	case params.ResolvedFailOperation:
+		// The hook has already failed; it stays failed until
+		// the error is resolved.
+		if err := s.config.ClearResolved(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, resolver.ErrNoOperation
 	default:
 		return nil, errors.Errorf(
 			"unknown resolved mode %q", remoteState.ResolvedMode,
@@ -203,6 +266,21 @@ func (s *uniterResolver) nextOpHookError(
 	}
 }
 
+// nextRemovedPayload returns the first of the unit's payloads for which
+// the payload-removed hook has not yet run, if there is one.
+func nextRemovedPayload(local resolver.LocalState, remote remotestate.Snapshot) (params.UnitPayload, bool) {
+	removed := make(map[string]bool)
+	for _, id := range local.RemovedPayloads {
+		removed[id] = true
+	}
+	for _, p := range remote.Payloads {
+		if !removed[payload.BuildID(p.Class, p.ID)] {
+			return p, true
+		}
+	}
+	return params.UnitPayload{}, false
+}
+
 func charmModified(local resolver.LocalState, remote remotestate.Snapshot) bool {
 	if *local.CharmURL != *remote.CharmURL {
 		logger.Debugf("upgrade from %v to %v", local.CharmURL, remote.CharmURL)
@@ -225,6 +303,25 @@ func (s *uniterResolver) nextOp(
 	switch remoteState.Life {
 	case params.Alive:
 	case params.Dying:
+		// Before the unit departs its relations, give the charm a
+		// chance to drain connections or move its data elsewhere.
+		if localState.Started && !localState.Drained {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PreRemove})
+		}
+
+		// The unit's payloads are forgotten when it is removed, so
+		// let the charm stop each workload it still tracks first.
+		if localState.Started {
+			if p, ok := nextRemovedPayload(localState, remoteState); ok {
+				return opFactory.NewRunHook(hook.Info{
+					Kind:         hook.PayloadRemoved,
+					PayloadClass: p.Class,
+					PayloadType:  p.Type,
+					PayloadID:    p.ID,
+				})
+			}
+		}
+
 		// Normally we handle relations last, but if we're dying we
 		// must ensure that all relations are broken first.
 		op, err := s.config.Relations.NextOp(localState, remoteState, opFactory)
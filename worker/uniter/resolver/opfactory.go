@@ -48,6 +48,14 @@ func (s *resolverOpFactory) NewSkipHook(info hook.Info) (operation.Operation, er
 	return s.wrapHookOp(op, info), nil
 }
 
+func (s *resolverOpFactory) NewRunRelationHooks(infos []hook.Info) (operation.Operation, error) {
+	op, err := s.Factory.NewRunRelationHooks(infos)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.wrapHookOp(op, infos[0]), nil
+}
+
 func (s *resolverOpFactory) NewUpgrade(charmURL *charm.URL) (operation.Operation, error) {
 	op, err := s.Factory.NewUpgrade(charmURL)
 	if err != nil {
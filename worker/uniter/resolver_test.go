@@ -252,3 +252,138 @@ func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
 }
+
+func (s *resolverSuite) TestDyingRunsPreRemove(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Life = params.Dying
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run pre-remove hook")
+}
+
+func (s *resolverSuite) TestDyingDrainedRunsPayloadRemoved(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:            operation.Continue,
+			Installed:       true,
+			Started:         true,
+			Drained:         true,
+			RemovedPayloads: []string{"db/abc123"},
+		},
+	}
+	s.remoteState.Life = params.Dying
+	s.remoteState.Payloads = []params.UnitPayload{
+		{Class: "db", Type: "docker", ID: "abc123"},
+		{Class: "cache", Type: "docker", ID: "def456"},
+	}
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run payload-removed (cache/def456) hook")
+
+	localState.RemovedPayloads = append(localState.RemovedPayloads, "cache/def456")
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
+
+func (s *resolverSuite) TestDyingDrainedRunsStop(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+			Drained:   true,
+		},
+	}
+	s.remoteState.Life = params.Dying
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
+
+func (s *resolverSuite) TestSkipOperationQueuedHook(c *gc.C) {
+	s.testResolveOperationQueuedHook(c, params.ResolvedSkipOperation, "skip run config-changed hook")
+}
+
+func (s *resolverSuite) TestFailOperationQueuedHook(c *gc.C) {
+	s.testResolveOperationQueuedHook(c, params.ResolvedFailOperation, "fail config-changed hook")
+}
+
+func (s *resolverSuite) testResolveOperationQueuedHook(c *gc.C, mode params.ResolvedMode, expect string) {
+	s.clearResolved = func() error {
+		s.stub.AddCall("ClearResolved")
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Queued,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+	s.remoteState.ResolvedMode = mode
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, expect)
+	s.stub.CheckCallNames(c, "ClearResolved")
+}
+
+func (s *resolverSuite) TestSkipOperationFailedHook(c *gc.C) {
+	s.clearResolved = func() error { return nil }
+	s.reportHookError = func(hook.Info) error { return nil }
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+	s.remoteState.ResolvedMode = params.ResolvedSkipOperation
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "skip run config-changed hook")
+}
+
+func (s *resolverSuite) TestResolveOperationNothingPending(c *gc.C) {
+	s.clearResolved = func() error {
+		s.stub.AddCall("ClearResolved")
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.ResolvedMode = params.ResolvedFailOperation
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "ClearResolved")
+}
@@ -153,6 +153,10 @@ type HookContext struct {
 	// of, keyed on relation id.
 	relations map[int]*ContextRelation
 
+	// maxRelationDataSize is the cached limit on the size of the
+	// unit's relation settings, or nil if it has not been fetched.
+	maxRelationDataSize *int
+
 	// apiAddrs contains the API server addresses.
 	apiAddrs []string
 
@@ -189,6 +193,10 @@ type HookContext struct {
 	// storageId is the tag of the storage instance associated with the running hook.
 	storageTag names.StorageTag
 
+	// payload identifies the payload whose removal the running
+	// hook reports; it is nil for other hooks.
+	payload *params.UnitPayload
+
 	// hasRunSetStatus is true if a call to the status-set was made during the
 	// invocation of a hook.
 	// This attribute is persisted to local uniter state at the end of the hook
@@ -376,6 +384,13 @@ func (ctx *HookContext) SetApplicationStatus(serviceStatus jujuc.StatusInfo) err
 	)
 }
 
+// AddStatusEvent records an event in this unit's status history without
+// changing its status.
+func (ctx *HookContext) AddStatusEvent(message string, data map[string]interface{}) error {
+	logger.Tracef("[STATUS-EVENT] %s", message)
+	return ctx.unit.AddStatusEvent(message, data)
+}
+
 func (ctx *HookContext) HasExecutionSetUnitStatus() bool {
 	return ctx.hasRunStatusSet
 }
@@ -548,6 +563,20 @@ func (ctx *HookContext) RelationIds() ([]int, error) {
 	return ids, nil
 }
 
+// MaxRelationDataSize returns the maximum size, in bytes, of the
+// settings the unit may write to a relation, as configured for the
+// model. Zero means there is no limit.
+func (ctx *HookContext) MaxRelationDataSize() (int, error) {
+	if ctx.maxRelationDataSize == nil {
+		size, err := ctx.state.MaxRelationDataSize()
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		ctx.maxRelationDataSize = &size
+	}
+	return *ctx.maxRelationDataSize, nil
+}
+
 // AddMetric adds metrics to the hook context.
 func (ctx *HookContext) AddMetric(key, value string, created time.Time) error {
 	return errors.New("metrics not allowed in this context")
@@ -591,6 +620,13 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.payload != nil {
+		vars = append(vars,
+			"JUJU_PAYLOAD_CLASS="+context.payload.Class,
+			"JUJU_PAYLOAD_TYPE="+context.payload.Type,
+			"JUJU_PAYLOAD_ID="+context.payload.ID,
+		)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -607,6 +643,16 @@ func (ctx *HookContext) handleReboot(err *error) {
 	switch rebootPriority {
 	case jujuc.RebootSkip:
 		return
+	case jujuc.RebootScheduled:
+		// The machine agent reboots during the maintenance window;
+		// until then the unit carries on as normal.
+		if *err != nil {
+			return
+		}
+		if reqErr := ctx.unit.ScheduleReboot(); reqErr != nil {
+			*err = reqErr
+		}
+		return
 	case jujuc.RebootAfterHook:
 		// Reboot should happen only after hook has finished.
 		if *err != nil {
@@ -792,6 +838,11 @@ func (ctx *HookContext) NetworkConfig(bindingName string) ([]params.NetworkConfi
 	return ctx.unit.NetworkConfig(bindingName)
 }
 
+// NetworkInfo returns the network info for the given bindingNames.
+func (ctx *HookContext) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	return ctx.unit.NetworkInfo(bindingNames)
+}
+
 // UnitWorkloadVersion returns the version of the workload reported by
 // the current unit.
 func (ctx *HookContext) UnitWorkloadVersion() (string, error) {
@@ -828,3 +879,13 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 	}
 	return result.OneError()
 }
+
+// SetSecret stores value as the named secret of the unit's application.
+func (ctx *HookContext) SetSecret(name, value string, rotateInterval *time.Duration) error {
+	return ctx.unit.SetSecret(name, value, rotateInterval)
+}
+
+// Secret returns the named secret of the unit's application.
+func (ctx *HookContext) Secret(name string) (params.Secret, error) {
+	return ctx.unit.Secret(name)
+}
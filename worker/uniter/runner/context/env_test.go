@@ -94,6 +94,15 @@ func (s *EnvSuite) setRelation(ctx *context.HookContext) (expectVars []string) {
 	}
 }
 
+func (s *EnvSuite) setPayload(ctx *context.HookContext) (expectVars []string) {
+	context.SetEnvironmentHookContextPayload(ctx, "db", "docker", "abc123")
+	return []string{
+		"JUJU_PAYLOAD_CLASS=db",
+		"JUJU_PAYLOAD_TYPE=docker",
+		"JUJU_PAYLOAD_ID=abc123",
+	}
+}
+
 func (s *EnvSuite) TestEnvSetsPath(c *gc.C) {
 	paths := context.OSDependentEnvVars(MockEnvPaths{})
 	c.Assert(paths, gc.Not(gc.HasLen), 0)
@@ -146,4 +155,9 @@ func (s *EnvSuite) TestEnvUbuntu(c *gc.C) {
 	actualVars, err = ctx.HookVars(paths)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, relationVars)
+
+	payloadVars := s.setPayload(ctx)
+	actualVars, err = ctx.HookVars(paths)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, relationVars, payloadVars)
 }
@@ -10,6 +10,8 @@ import (
 var ErrRequeueAndReboot = errors.New("reboot now")
 var ErrReboot = errors.New("reboot after hook")
 var ErrNoProcess = errors.New("no process to kill")
+var ErrHookTimedOut = errors.New("hook timed out")
+var ErrHookInterrupted = errors.New("hook interrupted")
 
 type missingHookError struct {
 	hookName string
@@ -103,6 +103,14 @@ func SetEnvironmentHookContextRelation(
 	}
 }
 
+func SetEnvironmentHookContextPayload(context *HookContext, class, payloadType, id string) {
+	context.payload = &params.UnitPayload{
+		Class: class,
+		Type:  payloadType,
+		ID:    id,
+	}
+}
+
 func PatchCachedStatus(ctx jujuc.Context, status, info string, data map[string]interface{}) func() {
 	hctx := ctx.(*HookContext)
 	oldStatus := hctx.status
@@ -5,6 +5,7 @@ package context
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
@@ -25,6 +26,11 @@ type ContextRelation struct {
 	// settings allows read and write access to the relation unit settings.
 	settings *uniter.Settings
 
+	// appSettings allows read and write access to the settings of the
+	// unit's application, which held appSettingsRead when first read.
+	appSettings     *uniter.Settings
+	appSettingsRead params.Settings
+
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
 }
@@ -71,10 +77,34 @@ func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 	return ctx.settings, nil
 }
 
-// WriteSettings persists all changes made to the unit's relation settings.
+func (ctx *ContextRelation) ApplicationSettings() (jujuc.Settings, error) {
+	if ctx.appSettings == nil {
+		node, err := ctx.ru.ApplicationSettings()
+		if err != nil {
+			return nil, err
+		}
+		ctx.appSettings = node
+		ctx.appSettingsRead = node.Map()
+	}
+	return ctx.appSettings, nil
+}
+
+func (ctx *ContextRelation) ReadApplicationSettings(application string) (params.Settings, error) {
+	return ctx.ru.ReadApplicationSettings(application)
+}
+
+// WriteSettings persists all changes made to the unit's relation settings,
+// and to its application's if they were changed. The application's
+// settings are only written when changed because only the leader may
+// write them, while any unit may read them.
 func (ctx *ContextRelation) WriteSettings() (err error) {
 	if ctx.settings != nil {
-		err = ctx.settings.Write()
+		if err = ctx.settings.Write(); err != nil {
+			return
+		}
+	}
+	if ctx.appSettings != nil && !reflect.DeepEqual(ctx.appSettings.Map(), ctx.appSettingsRead) {
+		err = ctx.appSettings.Write()
 	}
 	return
 }
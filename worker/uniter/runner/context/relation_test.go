@@ -4,6 +4,8 @@
 package context_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -161,6 +163,32 @@ func (s *ContextRelationSuite) TestLocalSettings(c *gc.C) {
 	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"change": "exciting"})
 }
 
+func (s *ContextRelationSuite) TestApplicationSettings(c *gc.C) {
+	ctx := context.NewContextRelation(s.apiRelUnit, nil)
+
+	// Any unit may read its application's settings; unchanged
+	// settings are not written, so that needs no leadership.
+	node, err := ctx.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(node.Map(), gc.HasLen, 0)
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Changes are written by the leader.
+	err = s.State.LeadershipClaimer().ClaimLeadership("u", "u/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	node.Set("change", "exciting")
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := s.rel.ApplicationSettings("u")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"change": "exciting"})
+
+	m, err := ctx.ReadApplicationSettings("u")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, gc.DeepEquals, params.Settings{"change": "exciting"})
+}
+
 func convertSettings(settings params.Settings) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range settings {
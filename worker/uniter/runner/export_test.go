@@ -4,6 +4,8 @@
 package runner
 
 import (
+	"time"
+
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
 
@@ -17,3 +19,15 @@ var (
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+func RunnerHookTimeout(rnr Runner) time.Duration {
+	return rnr.(*runner).hookTimeout
+}
+
+func NewRunnerWithHookTimeout(ctx Context, paths context.Paths, timeout time.Duration) Runner {
+	return &runner{context: ctx, paths: paths, hookTimeout: timeout}
+}
+
+func NewRunnerWithInterrupter(ctx Context, paths context.Paths, interrupter *Interrupter) Runner {
+	return &runner{context: ctx, paths: paths, interrupter: interrupter}
+}
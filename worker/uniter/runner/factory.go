@@ -4,6 +4,9 @@
 package runner
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -11,6 +14,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
@@ -33,11 +37,13 @@ type Factory interface {
 }
 
 // NewFactory returns a Factory capable of creating runners for executing
-// charm hooks, actions and commands.
+// charm hooks, actions and commands. The charm hooks and actions are
+// killed when interrupter, if not nil, is interrupted.
 func NewFactory(
 	state *uniter.State,
 	paths context.Paths,
 	contextFactory context.ContextFactory,
+	interrupter *Interrupter,
 ) (
 	Factory, error,
 ) {
@@ -45,6 +51,7 @@ func NewFactory(
 		state:          state,
 		paths:          paths,
 		contextFactory: contextFactory,
+		interrupter:    interrupter,
 	}
 
 	return f, nil
@@ -57,7 +64,8 @@ type factory struct {
 	state *uniter.State
 
 	// Fields that shouldn't change in a factory's lifetime.
-	paths context.Paths
+	paths       context.Paths
+	interrupter *Interrupter
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -80,8 +88,46 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
-	return runner, nil
+	paths := f.paths
+	if hookInfo.Kind.IsRelation() {
+		// Hooks for different relations may run concurrently, so
+		// each relation's hooks get their own hook tool socket.
+		paths = relationPaths{paths, hookInfo.RelationId}
+	}
+	rnr := &runner{context: ctx, paths: paths, interrupter: f.interrupter}
+	if hookInfo.Kind == hook.PreRemove {
+		timeout, err := f.unitDrainTimeout()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rnr.hookTimeout = timeout
+	}
+	return rnr, nil
+}
+
+// unitDrainTimeout returns how long the pre-remove hook may run for.
+// Controllers that cannot report the model's setting are assumed to
+// use the default.
+func (f *factory) unitDrainTimeout() (time.Duration, error) {
+	timeout, err := f.state.UnitDrainTimeout()
+	if errors.IsNotSupported(err) {
+		return config.DefaultUnitDrainTimeout, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return timeout, nil
+}
+
+// relationPaths wraps a context.Paths so that hook tools are served on a
+// socket specific to a single relation.
+type relationPaths struct {
+	context.Paths
+	relationId int
+}
+
+// GetJujucSocket is part of the context.Paths interface.
+func (p relationPaths) GetJujucSocket() string {
+	return fmt.Sprintf("%s-relation-%d", p.Paths.GetJujucSocket(), p.relationId)
 }
 
 // NewActionRunner exists to satisfy the Factory interface.
@@ -123,8 +169,8 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 
 	actionData := context.NewActionData(name, &tag, params)
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
-	return runner, nil
+	rnr := &runner{context: ctx, paths: f.paths, interrupter: f.interrupter}
+	return rnr, nil
 }
 
 func getCharm(charmPath string) (charm.Charm, error) {
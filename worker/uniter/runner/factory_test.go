@@ -113,6 +113,20 @@ func (s *FactorySuite) TestNewHookRunner(c *gc.C) {
 	s.AssertPaths(c, rnr)
 }
 
+func (s *FactorySuite) TestNewHookRunnerPreRemove(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"unit-drain-timeout": "90s"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hook.PreRemove})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertPaths(c, rnr)
+	c.Assert(runner.RunnerHookTimeout(rnr), gc.Equals, 90*time.Second)
+
+	// Other hooks are not limited.
+	rnr, err = s.factory.NewHookRunner(hook.Info{Kind: hooks.Stop})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerHookTimeout(rnr), gc.Equals, time.Duration(0))
+}
+
 func (s *FactorySuite) TestNewHookRunnerWithBadHook(c *gc.C) {
 	rnr, err := s.factory.NewHookRunner(hook.Info{})
 	c.Assert(rnr, gc.IsNil)
@@ -174,6 +188,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		uniter,
 		s.paths,
 		contextFactory,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"sync"
+)
+
+// Interrupter kills the charm hooks and actions run by the runners of
+// a Factory on request, so that an operation stuck in a hook can be
+// abandoned without restarting the uniter.
+type Interrupter struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+// NewInterrupter returns a new Interrupter.
+func NewInterrupter() *Interrupter {
+	return &Interrupter{ch: make(chan struct{})}
+}
+
+// Interrupt kills the hooks and actions that are running. It does not
+// affect those started later.
+func (i *Interrupter) Interrupt() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	close(i.ch)
+	i.ch = make(chan struct{})
+}
+
+// interrupted returns a channel that is closed when the hooks and
+// actions currently running are interrupted. It returns nil if i is
+// nil.
+func (i *Interrupter) interrupted() <-chan struct{} {
+	if i == nil {
+		return nil
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.ch
+}
@@ -30,6 +30,9 @@ const (
 	// RebootNow means reboot immediately, killing and requeueing the
 	// calling hook
 	RebootNow
+	// RebootScheduled means wait for current hook to finish, then
+	// schedule a reboot for the model's maintenance window.
+	RebootScheduled
 )
 
 // Context is the interface that all hook helper commands
@@ -53,6 +56,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -122,6 +126,10 @@ type ContextStatus interface {
 
 	// SetApplicationStatus updates the status for the unit's service.
 	SetApplicationStatus(StatusInfo) error
+
+	// AddStatusEvent records an event in the unit's status history
+	// without changing its status.
+	AddStatusEvent(message string, data map[string]interface{}) error
 }
 
 // ContextInstance is the part of a hook context related to the unit's instance.
@@ -167,6 +175,10 @@ type ContextNetworking interface {
 	//
 	// LKK Card: https://canonical.leankit.com/Boards/View/101652562/119258804
 	NetworkConfig(bindingName string) ([]params.NetworkConfig, error)
+
+	// NetworkInfo returns the network interfaces, ingress addresses and
+	// egress subnets of the unit for each of the given binding names.
+	NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error)
 }
 
 // ContextLeadership is the part of a hook context related to the
@@ -230,6 +242,11 @@ type ContextRelations interface {
 	// RelationIds returns the ids of all relations the executing unit is
 	// currently participating in or an error if they are not available.
 	RelationIds() ([]int, error)
+
+	// MaxRelationDataSize returns the maximum size, in bytes, of the
+	// settings the unit may write to a relation. Zero means there is
+	// no limit.
+	MaxRelationDataSize() (int, error)
 }
 
 // ContextComponent is a single modular Juju component as it relates to
@@ -270,6 +287,15 @@ type ContextRelation interface {
 
 	// ReadSettings returns the settings of any remote unit in the relation.
 	ReadSettings(unit string) (params.Settings, error)
+
+	// ApplicationSettings allows read/write access to the settings that
+	// the local unit's application publishes in this relation. Only the
+	// application's leader may change them.
+	ApplicationSettings() (Settings, error)
+
+	// ReadApplicationSettings returns the settings that the leader of the
+	// named application has published in the relation.
+	ReadApplicationSettings(application string) (params.Settings, error)
 }
 
 // ContextStorageAttachment expresses the capabilities of a hook with
@@ -300,6 +326,18 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextSecrets is the part of a hook context related to the secrets
+// of the unit's application.
+type ContextSecrets interface {
+	// SetSecret stores value as the named secret. If rotateInterval is
+	// not nil, it sets how often the secret should be given a new
+	// value; zero means that it need not be.
+	SetSecret(name, value string, rotateInterval *time.Duration) error
+
+	// Secret returns the named secret.
+	Secret(name string) (params.Secret, error)
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
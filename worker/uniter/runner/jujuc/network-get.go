@@ -9,6 +9,8 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
 )
 
 // NetworkGetCommand implements the network-get command.
@@ -18,6 +20,9 @@ type NetworkGetCommand struct {
 
 	bindingName    string
 	primaryAddress bool
+	bindAddress    bool
+	ingressAddress bool
+	egressSubnets  bool
 
 	out cmd.Output
 }
@@ -29,11 +34,19 @@ func NewNetworkGetCommand(ctx Context) (cmd.Command, error) {
 
 // Info is part of the cmd.Command interface.
 func (c *NetworkGetCommand) Info() *cmd.Info {
-	args := "<binding-name> --primary-address"
+	args := "<binding-name> [--bind-address] [--ingress-address] [--egress-subnets]"
 	doc := `
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. By default
+it returns everything known about the binding: the network interfaces of the
+unit's machine in the binding's space along with their addresses, the
+addresses the unit should advertise to others (ingress addresses), and the
+subnets its outgoing traffic comes from (egress subnets).
+
+Flags select individual values instead: --bind-address returns the address
+the unit should listen on, --ingress-address the address it should advertise
+to its peers, and --egress-subnets the subnets its traffic comes from.
+--primary-address is kept for older charms, and is the same as
+--bind-address.
 `
 	return &cmd.Info{
 		Name:    "network-get",
@@ -47,6 +60,9 @@ the IP address the local unit should advertise as its endpoint to its peers.
 func (c *NetworkGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.BoolVar(&c.primaryAddress, "primary-address", false, "get the primary address for the binding")
+	f.BoolVar(&c.bindAddress, "bind-address", false, "get the address for the binding on which the unit should listen")
+	f.BoolVar(&c.ingressAddress, "ingress-address", false, "get the ingress address for the binding")
+	f.BoolVar(&c.egressSubnets, "egress-subnets", false, "get the egress subnets for the binding")
 }
 
 // Init is part of the cmd.Command interface.
@@ -60,14 +76,72 @@ func (c *NetworkGetCommand) Init(args []string) error {
 		return fmt.Errorf("no binding name specified")
 	}
 
-	if !c.primaryAddress {
-		return fmt.Errorf("--primary-address is currently required")
-	}
-
 	return cmd.CheckEmpty(args[1:])
 }
 
 func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
+	results, err := c.ctx.NetworkInfo([]string{c.bindingName})
+	if errors.IsNotSupported(err) {
+		return c.runNetworkConfig(ctx)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	result, ok := results[c.bindingName]
+	if !ok {
+		return fmt.Errorf("no network config found for binding %q", c.bindingName)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+
+	bindAddress := ""
+	for _, info := range result.Info {
+		if len(info.Addresses) > 0 {
+			bindAddress = info.Addresses[0].Address
+			break
+		}
+	}
+	if (c.primaryAddress || c.bindAddress) && bindAddress == "" {
+		return fmt.Errorf("no network config found for binding %q", c.bindingName)
+	}
+	ingressAddress := ""
+	if len(result.IngressAddresses) > 0 {
+		ingressAddress = result.IngressAddresses[0]
+	}
+	if c.ingressAddress && ingressAddress == "" {
+		return fmt.Errorf("no ingress address found for binding %q", c.bindingName)
+	}
+
+	values := make(map[string]interface{})
+	if c.primaryAddress {
+		values["primary-address"] = bindAddress
+	}
+	if c.bindAddress {
+		values["bind-address"] = bindAddress
+	}
+	if c.ingressAddress {
+		values["ingress-address"] = ingressAddress
+	}
+	if c.egressSubnets {
+		values["egress-subnets"] = result.EgressSubnets
+	}
+	switch len(values) {
+	case 0:
+		return c.out.Write(ctx, formatNetworkInfo(result))
+	case 1:
+		for _, value := range values {
+			return c.out.Write(ctx, value)
+		}
+	}
+	return c.out.Write(ctx, values)
+}
+
+// runNetworkConfig implements the command for controllers that do not
+// support NetworkInfo, which can only report the primary address.
+func (c *NetworkGetCommand) runNetworkConfig(ctx *cmd.Context) error {
+	if !c.primaryAddress || c.bindAddress || c.ingressAddress || c.egressSubnets {
+		return errors.New("the controller only supports --primary-address; upgrade it for the full network config")
+	}
 	netConfig, err := c.ctx.NetworkConfig(c.bindingName)
 	if err != nil {
 		return errors.Trace(err)
@@ -75,10 +149,44 @@ func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 	if len(netConfig) < 1 {
 		return fmt.Errorf("no network config found for binding %q", c.bindingName)
 	}
+	return c.out.Write(ctx, netConfig[0].Address)
+}
 
-	if c.primaryAddress {
-		return c.out.Write(ctx, netConfig[0].Address)
-	}
+// networkInfo is the full output of network-get.
+type networkInfo struct {
+	BindAddresses    []interfaceInfo `json:"bind-addresses" yaml:"bind-addresses"`
+	IngressAddresses []string        `json:"ingress-addresses" yaml:"ingress-addresses"`
+	EgressSubnets    []string        `json:"egress-subnets" yaml:"egress-subnets"`
+}
+
+type interfaceInfo struct {
+	MACAddress    string        `json:"mac-address" yaml:"mac-address"`
+	InterfaceName string        `json:"interface-name" yaml:"interface-name"`
+	Addresses     []addressInfo `json:"addresses" yaml:"addresses"`
+}
 
-	return nil // never reached as --primary-address is required.
+type addressInfo struct {
+	Address string `json:"address" yaml:"address"`
+	CIDR    string `json:"cidr" yaml:"cidr"`
+}
+
+func formatNetworkInfo(result params.NetworkInfoResult) networkInfo {
+	out := networkInfo{
+		IngressAddresses: result.IngressAddresses,
+		EgressSubnets:    result.EgressSubnets,
+	}
+	for _, info := range result.Info {
+		iface := interfaceInfo{
+			MACAddress:    info.MACAddress,
+			InterfaceName: info.InterfaceName,
+		}
+		for _, addr := range info.Addresses {
+			iface.Addresses = append(iface.Addresses, addressInfo{
+				Address: addr.Address,
+				CIDR:    addr.CIDR,
+			})
+		}
+		out.BindAddresses = append(out.BindAddresses, iface)
+	}
+	return out
 }
@@ -62,10 +62,10 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 		args:    []string{""},
 		out:     `no binding name specified`,
 	}, {
-		summary: "binding name given, no --primary-address given",
-		code:    2,
-		args:    []string{"foo"},
-		out:     `--primary-address is currently required`,
+		summary: "binding name given, no --primary-address given, controller without network info",
+		code:    1,
+		args:    []string{"known-relation"},
+		out:     `the controller only supports --primary-address; upgrade it for the full network config`,
 	}, {
 		summary: "unknown binding given, with --primary-address",
 		args:    []string{"unknown", "--primary-address"},
@@ -109,26 +109,162 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 	}
 }
 
+func (s *NetworkGetSuite) createNetworkInfoCommand(c *gc.C) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.NetworkInterface.BindingsToNetworkInfo = map[string]params.NetworkInfoResult{
+		"known-relation": {
+			Info: []params.NetworkInfo{{
+				MACAddress:    "aa:bb:cc:dd:ee:f0",
+				InterfaceName: "eth0",
+				Addresses: []params.InterfaceAddress{
+					{Address: "10.10.0.23", CIDR: "10.10.0.0/24"},
+					{Address: "10.10.0.24", CIDR: "10.10.0.0/24"},
+				},
+			}, {
+				MACAddress:    "aa:bb:cc:dd:ee:f1",
+				InterfaceName: "eth1",
+				Addresses: []params.InterfaceAddress{
+					{Address: "192.168.1.111", CIDR: "192.168.1.0/24"},
+				},
+			}},
+			IngressAddresses: []string{"10.10.0.23", "10.10.0.24", "192.168.1.111"},
+			EgressSubnets:    []string{"10.10.0.23/32", "10.10.0.24/32", "192.168.1.111/32"},
+		},
+		"valid-no-config": {},
+		"failing": {
+			Error: &params.Error{Message: "no machine assigned"},
+		},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("network-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *NetworkGetSuite) TestNetworkGetInfo(c *gc.C) {
+	for i, t := range []struct {
+		summary string
+		args    []string
+		code    int
+		out     string
+	}{{
+		summary: "unknown binding",
+		args:    []string{"unknown"},
+		code:    1,
+		out:     "insert server error for unknown binding here",
+	}, {
+		summary: "binding with an error",
+		args:    []string{"failing"},
+		code:    1,
+		out:     "no machine assigned",
+	}, {
+		summary: "no flags gives the full network info",
+		args:    []string{"known-relation"},
+		out: `
+bind-addresses:
+- mac-address: aa:bb:cc:dd:ee:f0
+  interface-name: eth0
+  addresses:
+  - address: 10.10.0.23
+    cidr: 10.10.0.0/24
+  - address: 10.10.0.24
+    cidr: 10.10.0.0/24
+- mac-address: aa:bb:cc:dd:ee:f1
+  interface-name: eth1
+  addresses:
+  - address: 192.168.1.111
+    cidr: 192.168.1.0/24
+ingress-addresses:
+- 10.10.0.23
+- 10.10.0.24
+- 192.168.1.111
+egress-subnets:
+- 10.10.0.23/32
+- 10.10.0.24/32
+- 192.168.1.111/32
+`[1:],
+	}, {
+		summary: "--primary-address",
+		args:    []string{"known-relation", "--primary-address"},
+		out:     "10.10.0.23\n",
+	}, {
+		summary: "--bind-address",
+		args:    []string{"known-relation", "--bind-address"},
+		out:     "10.10.0.23\n",
+	}, {
+		summary: "--ingress-address",
+		args:    []string{"known-relation", "--ingress-address"},
+		out:     "10.10.0.23\n",
+	}, {
+		summary: "--egress-subnets",
+		args:    []string{"known-relation", "--egress-subnets"},
+		out:     "10.10.0.23/32\n10.10.0.24/32\n192.168.1.111/32\n",
+	}, {
+		summary: "several flags give a map",
+		args:    []string{"known-relation", "--bind-address", "--ingress-address", "--egress-subnets"},
+		out: `
+bind-address: 10.10.0.23
+egress-subnets:
+- 10.10.0.23/32
+- 10.10.0.24/32
+- 192.168.1.111/32
+ingress-address: 10.10.0.23
+`[1:],
+	}, {
+		summary: "--bind-address with no addresses",
+		args:    []string{"valid-no-config", "--bind-address"},
+		code:    1,
+		out:     `no network config found for binding "valid-no-config"`,
+	}} {
+		c.Logf("test %d: %s", i, t.summary)
+		com := s.createNetworkInfoCommand(c)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		if code == 0 {
+			c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+			c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+		} else {
+			c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+			expect := fmt.Sprintf(`(.|\n)*error: %s\n`, t.out)
+			c.Check(bufferString(ctx.Stderr), gc.Matches, expect)
+		}
+	}
+}
+
 func (s *NetworkGetSuite) TestHelp(c *gc.C) {
 
 	var helpTemplate = `
-Usage: network-get [options] <binding-name> --primary-address
+Usage: network-get [options] <binding-name> [--bind-address] [--ingress-address] [--egress-subnets]
 
 Summary:
 get network config
 
 Options:
+--bind-address  (= false)
+    get the address for the binding on which the unit should listen
+--egress-subnets  (= false)
+    get the egress subnets for the binding
 --format  (= smart)
     Specify output format (json|smart|yaml)
+--ingress-address  (= false)
+    get the ingress address for the binding
 -o, --output (= "")
     Specify an output file
 --primary-address  (= false)
     get the primary address for the binding
 
 Details:
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. By default
+it returns everything known about the binding: the network interfaces of the
+unit's machine in the binding's space along with their addresses, the
+addresses the unit should advertise to others (ingress addresses), and the
+subnets its outgoing traffic comes from (egress subnets).
+
+Flags select individual values instead: --bind-address returns the address
+the unit should listen on, --ingress-address the address it should advertise
+to its peers, and --egress-subnets the subnets its traffic comes from.
+--primary-address is kept for older charms, and is the same as
+--bind-address.
 `[1:]
 
 	com := s.createCommand(c)
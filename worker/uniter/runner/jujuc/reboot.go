@@ -13,8 +13,9 @@ import (
 // JujuRebootCommand implements the juju-reboot command.
 type JujuRebootCommand struct {
 	cmd.CommandBase
-	ctx Context
-	Now bool
+	ctx       Context
+	Now       bool
+	Scheduled bool
 }
 
 func NewJujuRebootCommand(ctx Context) (cmd.Command, error) {
@@ -37,6 +38,10 @@ func (c *JujuRebootCommand) Info() *cmd.Info {
 	be sure to terminate on unexpected errors, so as to guarantee expected behaviour
 	in all situations.
 
+	If the --scheduled flag is passed, the reboot is requested once the current
+	hook completes successfully, but the machine only reboots during the model's
+	maintenance-window. The unit carries on running hooks until then.
+
 	juju-reboot is not supported when running actions.
 	`
 	return &cmd.Info{
@@ -49,9 +54,13 @@ func (c *JujuRebootCommand) Info() *cmd.Info {
 
 func (c *JujuRebootCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Now, "now", false, "reboot immediately, killing the invoking process")
+	f.BoolVar(&c.Scheduled, "scheduled", false, "reboot during the model's maintenance window")
 }
 
 func (c *JujuRebootCommand) Init(args []string) error {
+	if c.Now && c.Scheduled {
+		return errors.New("cannot specify both --now and --scheduled")
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -61,8 +70,11 @@ func (c *JujuRebootCommand) Run(ctx *cmd.Context) error {
 	}
 
 	rebootPriority := RebootAfterHook
-	if c.Now {
+	switch {
+	case c.Now:
 		rebootPriority = RebootNow
+	case c.Scheduled:
+		rebootPriority = RebootScheduled
 	}
 
 	return c.ctx.RequestReboot(rebootPriority)
@@ -44,6 +44,8 @@ func (s *JujuRebootSuite) TestSetFlags(c *gc.C) {
 
 	flag := fs.Lookup("now")
 	c.Assert(flag, gc.NotNil)
+	flag = fs.Lookup("scheduled")
+	c.Assert(flag, gc.NotNil)
 }
 
 func (s *JujuRebootSuite) TestJujuRebootCommand(c *gc.C) {
@@ -65,6 +67,18 @@ func (s *JujuRebootSuite) TestJujuRebootCommand(c *gc.C) {
 		args:     []string{"--now"},
 		code:     0,
 		priority: jujuc.RebootNow,
+	}, {
+		summary:  "test reboot priority being set to RebootScheduled",
+		hctx:     &Context{shouldError: false, rebootPriority: jujuc.RebootSkip},
+		args:     []string{"--scheduled"},
+		code:     0,
+		priority: jujuc.RebootScheduled,
+	}, {
+		summary:  "test --now and --scheduled together",
+		hctx:     &Context{shouldError: false, rebootPriority: jujuc.RebootSkip},
+		args:     []string{"--now", "--scheduled"},
+		code:     2,
+		priority: jujuc.RebootSkip,
 	}, {
 		summary:  "test a failed running of juju-reboot",
 		hctx:     &Context{shouldError: true, rebootPriority: jujuc.RebootSkip},
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 )
@@ -24,6 +25,11 @@ type RelationGetCommand struct {
 	Key      string
 	UnitName string
 	out      cmd.Output
+
+	// Application is set to read the settings published by an
+	// application rather than by one of its units.
+	Application     bool
+	ApplicationName string
 }
 
 func NewRelationGetCommand(ctx Context) (cmd.Command, error) {
@@ -43,6 +49,10 @@ func (c *RelationGetCommand) Info() *cmd.Info {
 	doc := `
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+
+With --app, the settings published by the leader of an application are
+printed instead; the application may be named directly, or by any of its
+units.
 `
 	// There's nothing we can really do about the error here.
 	if name, err := c.ctx.RemoteUnitName(); err == nil {
@@ -64,6 +74,7 @@ func (c *RelationGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(c.relationIdProxy, "r", "specify a relation by id")
 	f.Var(c.relationIdProxy, "relation", "")
+	f.BoolVar(&c.Application, "app", false, "get an application's settings rather than a unit's")
 }
 
 // Init is part of the cmd.Command interface.
@@ -88,19 +99,44 @@ func (c *RelationGetCommand) Init(args []string) error {
 		c.UnitName = args[0]
 		args = args[1:]
 	}
-	if c.UnitName == "" {
+	if c.Application {
+		if err := c.initApplicationName(); err != nil {
+			return errors.Trace(err)
+		}
+	} else if c.UnitName == "" {
 		return fmt.Errorf("no unit id specified")
 	}
 	return cmd.CheckEmpty(args)
 }
 
+// initApplicationName sets the application whose settings are read,
+// which is named either directly or by one of its units.
+func (c *RelationGetCommand) initApplicationName() error {
+	switch {
+	case c.UnitName == "":
+		return fmt.Errorf("no application specified")
+	case names.IsValidUnit(c.UnitName):
+		c.ApplicationName, _ = names.UnitApplication(c.UnitName)
+	case names.IsValidApplication(c.UnitName):
+		c.ApplicationName = c.UnitName
+	default:
+		return fmt.Errorf("invalid application or unit name %q", c.UnitName)
+	}
+	return nil
+}
+
 func (c *RelationGetCommand) Run(ctx *cmd.Context) error {
 	r, err := c.ctx.Relation(c.RelationId)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	var settings params.Settings
-	if c.UnitName == c.ctx.UnitName() {
+	if c.Application {
+		settings, err = c.readApplicationSettings(r)
+		if err != nil {
+			return err
+		}
+	} else if c.UnitName == c.ctx.UnitName() {
 		node, err := r.Settings()
 		if err != nil {
 			return err
@@ -121,3 +157,21 @@ func (c *RelationGetCommand) Run(ctx *cmd.Context) error {
 	}
 	return c.out.Write(ctx, nil)
 }
+
+// readApplicationSettings returns the settings of the command's
+// application; the unit's own application's settings include any
+// changes made earlier in the hook.
+func (c *RelationGetCommand) readApplicationSettings(r ContextRelation) (params.Settings, error) {
+	localApplication, err := names.UnitApplication(c.ctx.UnitName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if c.ApplicationName != localApplication {
+		return r.ReadApplicationSettings(c.ApplicationName)
+	}
+	node, err := r.ApplicationSettings()
+	if err != nil {
+		return nil, err
+	}
+	return node.Map(), nil
+}
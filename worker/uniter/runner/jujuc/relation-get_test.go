@@ -29,6 +29,8 @@ func (s *RelationGetSuite) newHookContext(relid int, remote string) (jujuc.Conte
 	info.rels[0].Units["u/0"]["private-address"] = "foo: bar\n"
 	info.rels[1].SetRelated("m/0", jujuctesting.Settings{"pew": "pew\npew\n"})
 	info.rels[1].SetRelated("u/1", jujuctesting.Settings{"value": "12345"})
+	info.rels[1].SetApplication("m", jujuctesting.Settings{"endpoint": "db.example.com"})
+	info.rels[1].SetApplication("u", jujuctesting.Settings{"leader": "u/0"})
 	return hctx, info
 }
 
@@ -137,6 +139,41 @@ var relationGetTests = []struct {
 		relid:   1,
 		args:    []string{"missing", "u/1", "--format", "smart"},
 		out:     "",
+	}, {
+		summary: "application of implicit member",
+		relid:   1,
+		unit:    "m/0",
+		args:    []string{"--app"},
+		out:     "endpoint: db.example.com",
+	}, {
+		summary: "explicit application",
+		relid:   1,
+		args:    []string{"--app", "endpoint", "m"},
+		out:     "db.example.com",
+	}, {
+		summary: "local application",
+		relid:   1,
+		unit:    "m/0",
+		args:    []string{"--app", "leader", "u"},
+		out:     "u/0",
+	}, {
+		summary: "application, none chosen",
+		relid:   1,
+		code:    2,
+		args:    []string{"--app"},
+		out:     `no application specified`,
+	}, {
+		summary: "application, invalid name",
+		relid:   1,
+		code:    2,
+		args:    []string{"--app", "-", "Bad!"},
+		out:     `invalid application or unit name "Bad!"`,
+	}, {
+		summary: "missing application",
+		relid:   1,
+		code:    1,
+		args:    []string{"--app", "-", "other"},
+		out:     `unknown application other`,
 	},
 }
 
@@ -222,6 +259,8 @@ Summary:
 get relation settings
 
 Options:
+--app  (= false)
+    get an application's settings rather than a unit's
 --format  (= smart)
     Specify output format (json|smart|yaml)
 -o, --output (= "")
@@ -232,6 +271,10 @@ Options:
 Details:
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+
+With --app, the settings published by the leader of an application are
+printed instead; the application may be named directly, or by any of its
+units.
 %s`[1:]
 
 var relationGetHelpTests = []struct {
@@ -27,6 +27,10 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+With --app, the settings of the local unit's application are written
+instead; all units of the related applications can read them. Only
+the application's leader may write them.
 `
 
 // RelationSetCommand implements the relation-set command.
@@ -38,6 +42,7 @@ type RelationSetCommand struct {
 	Settings        map[string]string
 	settingsFile    cmd.FileVar
 	formatFlag      string // deprecated
+	Application     bool
 }
 
 func NewRelationSetCommand(ctx Context) (cmd.Command, error) {
@@ -69,6 +74,7 @@ func (c *RelationSetCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.settingsFile, "file", "file containing key-value pairs")
 
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	f.BoolVar(&c.Application, "app", false, "set the settings of the unit's application")
 }
 
 func (c *RelationSetCommand) Init(args []string) error {
@@ -135,10 +141,13 @@ func (c *RelationSetCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	settings, err := r.Settings()
+	settings, err := c.targetSettings(r)
 	if err != nil {
 		return errors.Annotate(err, "cannot read relation settings")
 	}
+	if err := c.checkSettingsSize(settings); err != nil {
+		return errors.Trace(err)
+	}
 	for k, v := range c.Settings {
 		if v != "" {
 			settings.Set(k, v)
@@ -148,3 +157,51 @@ func (c *RelationSetCommand) Run(ctx *cmd.Context) (err error) {
 	}
 	return nil
 }
+
+// targetSettings returns the settings that the command changes: the
+// unit's own, or its application's if the unit is the leader.
+func (c *RelationSetCommand) targetSettings(r ContextRelation) (Settings, error) {
+	if !c.Application {
+		return r.Settings()
+	}
+	isLeader, err := c.ctx.IsLeader()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine leadership")
+	}
+	if !isLeader {
+		return nil, errors.New("only the leader can set application settings")
+	}
+	return r.ApplicationSettings()
+}
+
+// checkSettingsSize returns an error if applying the command's changes
+// to the given settings would take them over the model's limit on the
+// size of relation data. The settings are left untouched.
+func (c *RelationSetCommand) checkSettingsSize(settings Settings) error {
+	limit, err := c.ctx.MaxRelationDataSize()
+	if err != nil {
+		return errors.Annotate(err, "cannot read relation data size limit")
+	}
+	if limit <= 0 {
+		return nil
+	}
+	updated := settings.Map()
+	for k, v := range c.Settings {
+		if v != "" {
+			updated[k] = v
+		} else {
+			delete(updated, k)
+		}
+	}
+	size := 0
+	for k, v := range updated {
+		size += len(k) + len(v)
+	}
+	if size > limit {
+		return errors.Errorf(
+			"relation settings size of %d bytes exceeds the limit of %d bytes set by max-relation-data-size",
+			size, limit,
+		)
+	}
+	return nil
+}
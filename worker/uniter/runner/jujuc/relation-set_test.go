@@ -46,6 +46,8 @@ Summary:
 set relation settings
 
 Options:
+--app  (= false)
+    set the settings of the unit's application
 --file  (= )
     file containing key-value pairs
 --format (= "")
@@ -65,6 +67,10 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+With --app, the settings of the local unit's application are written
+instead; all units of the related applications can read them. Only
+the application's leader may write them.
 `[1:], t.expect))
 		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
 	}
@@ -373,6 +379,52 @@ func (s *RelationSetSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *RelationSetSuite) TestRunMaxRelationDataSize(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	info.rels[1].Units["u/0"] = jujuctesting.Settings{"base": "value"}
+	info.Relations.MaxDataSize = 20
+
+	// "base" + "value" + "foo" + "bar" is 15 bytes.
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, com, "foo=bar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.rels[1].Units["u/0"], gc.DeepEquals, jujuctesting.Settings{"base": "value", "foo": "bar"})
+
+	// Adding 9 more bytes goes over the limit, and changes nothing.
+	com, err = jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, com, "big=sixbyt")
+	c.Assert(err, gc.ErrorMatches, "relation settings size of 24 bytes exceeds the limit of 20 bytes set by max-relation-data-size")
+	c.Assert(info.rels[1].Units["u/0"], gc.DeepEquals, jujuctesting.Settings{"base": "value", "foo": "bar"})
+
+	// Removing settings while adding others is measured after both.
+	com, err = jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, com, "base=", "big=sixbyt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.rels[1].Units["u/0"], gc.DeepEquals, jujuctesting.Settings{"foo": "bar", "big": "sixbyt"})
+}
+
+func (s *RelationSetSuite) TestRunApplication(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	info.rels[1].SetApplication("u", jujuctesting.Settings{"base": "value"})
+
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, com, "--app", "foo=bar")
+	c.Assert(err, gc.ErrorMatches, "cannot read relation settings: only the leader can set application settings")
+	c.Assert(info.rels[1].Applications["u"], gc.DeepEquals, jujuctesting.Settings{"base": "value"})
+
+	info.Leadership.IsLeader = true
+	com, err = jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, com, "--app", "foo=bar", "base=")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.rels[1].Applications["u"], gc.DeepEquals, jujuctesting.Settings{"foo": "bar"})
+	c.Assert(info.rels[1].Units["u/0"], gc.DeepEquals, jujuctesting.Settings{"private-address": "u-0.testing.invalid"})
+}
+
 func (s *RelationSetSuite) TestRunDeprecationWarning(c *gc.C) {
 	hctx, _ := s.newHookContext(0, "")
 	com, _ := jujuc.NewCommand(hctx, cmdString("relation-set"))
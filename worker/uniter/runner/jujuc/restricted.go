@@ -39,6 +39,11 @@ func (*RestrictedContext) ApplicationStatus() (ApplicationStatusInfo, error) {
 // SetApplicationStatus implements jujuc.Context.
 func (*RestrictedContext) SetApplicationStatus(StatusInfo) error { return ErrRestrictedContext }
 
+// AddStatusEvent implements jujuc.Context.
+func (*RestrictedContext) AddStatusEvent(string, map[string]interface{}) error {
+	return ErrRestrictedContext
+}
+
 // AvailabilityZone implements jujuc.Context.
 func (*RestrictedContext) AvailabilityZone() (string, error) { return "", ErrRestrictedContext }
 
@@ -69,6 +74,11 @@ func (*RestrictedContext) NetworkConfig(bindingName string) ([]params.NetworkCon
 	return nil, ErrRestrictedContext
 }
 
+// NetworkInfo implements jujuc.Context.
+func (*RestrictedContext) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	return nil, ErrRestrictedContext
+}
+
 // IsLeader implements jujuc.Context.
 func (*RestrictedContext) IsLeader() (bool, error) { return false, ErrRestrictedContext }
 
@@ -109,6 +119,9 @@ func (*RestrictedContext) Relation(id int) (ContextRelation, error) {
 // RelationIds implements jujuc.Context.
 func (*RestrictedContext) RelationIds() ([]int, error) { return nil, ErrRestrictedContext }
 
+// MaxRelationDataSize implements jujuc.Context.
+func (*RestrictedContext) MaxRelationDataSize() (int, error) { return 0, ErrRestrictedContext }
+
 // HookRelation implements jujuc.Context.
 func (*RestrictedContext) HookRelation() (ContextRelation, error) {
 	return nil, ErrRestrictedContext
@@ -147,3 +160,13 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// SetSecret implements jujuc.Context.
+func (*RestrictedContext) SetSecret(string, string, *time.Duration) error {
+	return ErrRestrictedContext
+}
+
+// Secret implements jujuc.Context.
+func (*RestrictedContext) Secret(string) (params.Secret, error) {
+	return params.Secret{}, ErrRestrictedContext
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// SecretGetCommand implements the secret-get command.
+type SecretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	name     string
+	metadata bool
+	out      cmd.Output
+}
+
+// NewSecretGetCommand makes a jujuc secret-get command.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &SecretGetCommand{ctx: ctx}, nil
+}
+
+func (c *SecretGetCommand) Info() *cmd.Info {
+	doc := `
+Prints the value of a secret of the unit's application, as stored by
+secret-set. With --metadata, prints the secret's revision, when it was
+created and last updated, and its rotation schedule instead.
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<name>",
+		Purpose: "print a secret",
+		Doc:     doc,
+	}
+}
+
+func (c *SecretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.BoolVar(&c.metadata, "metadata", false, "print the secret's metadata rather than its value")
+}
+
+func (c *SecretGetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no secret name specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *SecretGetCommand) Run(ctx *cmd.Context) error {
+	secret, err := c.ctx.Secret(c.name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !c.metadata {
+		return c.out.Write(ctx, secret.Value)
+	}
+	metadata := map[string]interface{}{
+		"revision": secret.Revision,
+		"created":  secret.Created.Format(time.RFC3339),
+		"updated":  secret.Updated.Format(time.RFC3339),
+	}
+	if secret.RotateIntervalSeconds > 0 {
+		interval := time.Duration(secret.RotateIntervalSeconds * float64(time.Second))
+		metadata["rotate-interval"] = interval.String()
+	}
+	if secret.NextRotation != nil {
+		metadata["next-rotation"] = secret.NextRotation.Format(time.RFC3339)
+	}
+	return c.out.Write(ctx, metadata)
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type secretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&secretGetSuite{})
+
+func (s *secretGetSuite) newCommand(c *gc.C) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	created := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	next := updated.Add(24 * time.Hour)
+	hctx.info.Secrets.Secrets = map[string]params.Secret{
+		"db-password": {
+			Value:                 "hunter2",
+			Revision:              2,
+			Created:               created,
+			Updated:               updated,
+			RotateIntervalSeconds: (24 * time.Hour).Seconds(),
+			NextRotation:          &next,
+		},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *secretGetSuite) TestInit(c *gc.C) {
+	com := s.newCommand(c)
+	testing.TestInit(c, com, []string{}, `no secret name specified`)
+	com = s.newCommand(c)
+	testing.TestInit(c, com, []string{"a", "b"}, `unrecognized args: \["b"\]`)
+}
+
+func (s *secretGetSuite) TestGetValue(c *gc.C) {
+	ctx := testing.Context(c)
+	code := cmd.Main(s.newCommand(c), ctx, []string{"db-password"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "hunter2\n")
+}
+
+func (s *secretGetSuite) TestGetMetadata(c *gc.C) {
+	ctx := testing.Context(c)
+	code := cmd.Main(s.newCommand(c), ctx, []string{"db-password", "--metadata", "--format", "json"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, ""+
+		`{"created":"2017-03-01T12:00:00Z",`+
+		`"next-rotation":"2017-03-02T13:00:00Z",`+
+		`"revision":2,`+
+		`"rotate-interval":"24h0m0s",`+
+		`"updated":"2017-03-01T13:00:00Z"}`+"\n")
+}
+
+func (s *secretGetSuite) TestGetMissing(c *gc.C) {
+	ctx := testing.Context(c)
+	code := cmd.Main(s.newCommand(c), ctx, []string{"missing"})
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "error: secret \"missing\" not found\n")
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// SecretSetCommand implements the secret-set command.
+type SecretSetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	name      string
+	value     string
+	valueFile cmd.FileVar
+	rotate    string
+
+	rotateInterval *time.Duration
+}
+
+// NewSecretSetCommand makes a jujuc secret-set command.
+func NewSecretSetCommand(ctx Context) (cmd.Command, error) {
+	return &SecretSetCommand{ctx: ctx}, nil
+}
+
+func (c *SecretSetCommand) Info() *cmd.Info {
+	doc := `
+Stores a value as a secret of the unit's application. The controller keeps
+secrets encrypted, and only the units of the application can read them back
+with secret-get. Each change increments the secret's revision.
+
+Values given on the command line may be recorded in the agent's logs; use
+--file, with "-" for stdin, to keep them out. A single trailing newline is
+removed from values read from a file.
+
+--rotate records how often the charm should give the secret a new value,
+such as 720h; secret-get --metadata reports when that is next due. Use 0
+to stop rotating the secret.
+`
+	return &cmd.Info{
+		Name:    "secret-set",
+		Args:    "<name> [<value>]",
+		Purpose: "store a secret",
+		Doc:     doc,
+	}
+}
+
+func (c *SecretSetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.valueFile.SetStdin()
+	f.Var(&c.valueFile, "file", "file containing the value")
+	f.StringVar(&c.rotate, "rotate", "", "how often the secret should be given a new value")
+}
+
+func (c *SecretSetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no secret name specified")
+	}
+	c.name = args[0]
+	args = args[1:]
+	switch {
+	case len(args) == 0 && c.valueFile.Path == "":
+		return errors.New("no value specified")
+	case len(args) > 0 && c.valueFile.Path != "":
+		return errors.New("cannot specify both a value and --file")
+	case len(args) > 0:
+		c.value = args[0]
+		args = args[1:]
+	}
+	c.rotateInterval = nil
+	if c.rotate != "" {
+		interval, err := time.ParseDuration(c.rotate)
+		if err != nil {
+			return errors.Annotate(err, "invalid --rotate")
+		}
+		if interval < 0 {
+			return errors.New("invalid --rotate: negative interval")
+		}
+		c.rotateInterval = &interval
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *SecretSetCommand) Run(ctx *cmd.Context) error {
+	value := c.value
+	if c.valueFile.Path != "" {
+		data, err := c.valueFile.Read(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		value = strings.TrimSuffix(string(data), "\n")
+	}
+	return c.ctx.SetSecret(c.name, value, c.rotateInterval)
+}
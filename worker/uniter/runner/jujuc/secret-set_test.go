@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type secretSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&secretSetSuite{})
+
+var secretSetInitTests = []struct {
+	args []string
+	err  string
+}{
+	{[]string{"db-password", "hunter2"}, ""},
+	{[]string{"db-password", "--file", "-"}, ""},
+	{[]string{"db-password", "hunter2", "--rotate", "720h"}, ""},
+	{[]string{}, `no secret name specified`},
+	{[]string{"db-password"}, `no value specified`},
+	{[]string{"db-password", "hunter2", "--file", "-"}, `cannot specify both a value and --file`},
+	{[]string{"db-password", "hunter2", "extra"}, `unrecognized args: \["extra"\]`},
+	{[]string{"db-password", "hunter2", "--rotate", "often"}, `invalid --rotate: .*`},
+	{[]string{"db-password", "hunter2", "--rotate", "-1h"}, `invalid --rotate: negative interval`},
+}
+
+func (s *secretSetSuite) TestInit(c *gc.C) {
+	for i, t := range secretSetInitTests {
+		c.Logf("test %d: %#v", i, t.args)
+		hctx := s.GetHookContext(c, -1, "")
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+		c.Assert(err, jc.ErrorIsNil)
+		testing.TestInit(c, com, t.args, t.err)
+	}
+}
+
+func (s *secretSetSuite) TestSetFromArgs(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"db-password", "hunter2", "--rotate", "24h"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+
+	c.Assert(hctx.info.Secrets.Secrets, jc.DeepEquals, map[string]params.Secret{
+		"db-password": {
+			Value:                 "hunter2",
+			Revision:              1,
+			RotateIntervalSeconds: (24 * time.Hour).Seconds(),
+		},
+	})
+}
+
+func (s *secretSetSuite) TestSetFromStdin(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	ctx.Stdin = strings.NewReader("hunter2\n")
+	code := cmd.Main(com, ctx, []string{"db-password", "--file", "-"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+
+	c.Assert(hctx.info.Secrets.Secrets["db-password"].Value, gc.Equals, "hunter2")
+}
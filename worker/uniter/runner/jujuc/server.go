@@ -58,7 +58,10 @@ var baseCommands = map[string]creator{
 	"unit-get" + cmdSuffix:                NewUnitGetCommand,
 	"add-metric" + cmdSuffix:              NewAddMetricCommand,
 	"juju-reboot" + cmdSuffix:             NewJujuRebootCommand,
+	"secret-get" + cmdSuffix:              NewSecretGetCommand,
+	"secret-set" + cmdSuffix:              NewSecretSetCommand,
 	"status-get" + cmdSuffix:              NewStatusGetCommand,
+	"status-log" + cmdSuffix:              NewStatusLogCommand,
 	"status-set" + cmdSuffix:              NewStatusSetCommand,
 	"network-get" + cmdSuffix:             NewNetworkGetCommand,
 	"application-version-set" + cmdSuffix: NewApplicationVersionSetCommand,
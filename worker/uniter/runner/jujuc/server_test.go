@@ -263,7 +263,10 @@ var newCommandTests = []struct {
 	{"unit-get", ""},
 	{"storage-add", ""},
 	{"storage-get", ""},
+	{"secret-get", ""},
+	{"secret-set", ""},
 	{"status-get", ""},
+	{"status-log", ""},
 	{"status-set", ""},
 	// The error message contains .exe on Windows
 	{"random", "unknown command: random(.exe)?"},
@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// StatusLogCommand implements the status-log command.
+type StatusLogCommand struct {
+	cmd.CommandBase
+	ctx     Context
+	message string
+	data    map[string]interface{}
+}
+
+// NewStatusLogCommand makes a jujuc status-log command.
+func NewStatusLogCommand(ctx Context) (cmd.Command, error) {
+	return &StatusLogCommand{ctx: ctx}, nil
+}
+
+func (c *StatusLogCommand) Info() *cmd.Info {
+	doc := `
+Records an event in the unit's status history without changing its
+workload status. Events can carry key=value data, and are shown by
+"juju show-status-log" with the status "event". Use them to record
+milestones such as a completed schema migration or joining a cluster.
+`
+	return &cmd.Info{
+		Name:    "status-log",
+		Args:    "<message> [key=value ...]",
+		Purpose: "record an event in the unit's status history",
+		Doc:     doc,
+	}
+}
+
+func (c *StatusLogCommand) Init(args []string) error {
+	if len(args) < 1 || args[0] == "" {
+		return errors.Errorf("invalid args, require <message> [key=value ...]")
+	}
+	c.message = args[0]
+	c.data = nil
+	for _, arg := range args[1:] {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("expected key=value, got %q", arg)
+		}
+		if c.data == nil {
+			c.data = make(map[string]interface{})
+		}
+		c.data[parts[0]] = parts[1]
+	}
+	return nil
+}
+
+func (c *StatusLogCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.AddStatusEvent(c.message, c.data)
+}
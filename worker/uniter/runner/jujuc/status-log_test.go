@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type statusLogSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&statusLogSuite{})
+
+var statusLogInitTests = []struct {
+	args []string
+	err  string
+}{
+	{[]string{"schema migrated"}, ""},
+	{[]string{"schema migrated", "version=3", "empty="}, ""},
+	{[]string{}, `invalid args, require <message> \[key=value ...\]`},
+	{[]string{""}, `invalid args, require <message> \[key=value ...\]`},
+	{[]string{"schema migrated", "version"}, `expected key=value, got "version"`},
+	{[]string{"schema migrated", "=3"}, `expected key=value, got "=3"`},
+}
+
+func (s *statusLogSuite) TestStatusLogInit(c *gc.C) {
+	for i, t := range statusLogInitTests {
+		c.Logf("test %d: %#v", i, t.args)
+		hctx := s.GetStatusHookContext(c)
+		com, err := jujuc.NewCommand(hctx, cmdString("status-log"))
+		c.Assert(err, jc.ErrorIsNil)
+		testing.TestInit(c, com, t.args, t.err)
+	}
+}
+
+func (s *statusLogSuite) TestHelp(c *gc.C) {
+	hctx := s.GetStatusHookContext(c)
+	com, err := jujuc.NewCommand(hctx, cmdString("status-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+	expectedHelp := "" +
+		"Usage: status-log <message> [key=value ...]\n" +
+		"\n" +
+		"Summary:\n" +
+		"record an event in the unit's status history\n" +
+		"\n" +
+		"Details:\n" +
+		"Records an event in the unit's status history without changing its\n" +
+		"workload status. Events can carry key=value data, and are shown by\n" +
+		"\"juju show-status-log\" with the status \"event\". Use them to record\n" +
+		"milestones such as a completed schema migration or joining a cluster.\n"
+
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, expectedHelp)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *statusLogSuite) TestStatusLog(c *gc.C) {
+	hctx := s.GetStatusHookContext(c)
+	com, err := jujuc.NewCommand(hctx, cmdString("status-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"cluster joined", "peers=3", "leader=db/0"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "")
+
+	c.Assert(hctx.info.Status.Events, jc.DeepEquals, []jujuc.StatusInfo{{
+		Status: "event",
+		Info:   "cluster joined",
+		Data:   map[string]interface{}{"peers": "3", "leader": "db/0"},
+	}})
+	// The unit's status is untouched.
+	c.Assert(hctx.info.Status.UnitStatus, jc.DeepEquals, jujuc.StatusInfo{})
+}
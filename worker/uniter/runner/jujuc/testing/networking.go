@@ -18,6 +18,11 @@ type NetworkInterface struct {
 	PrivateAddress           string
 	Ports                    []network.PortRange
 	BindingsToNetworkConfigs map[string][]params.NetworkConfig
+
+	// BindingsToNetworkInfo, when not nil, holds the results of
+	// NetworkInfo; when nil, NetworkInfo behaves as it would with a
+	// controller that does not support it.
+	BindingsToNetworkInfo map[string]params.NetworkInfoResult
 }
 
 // CheckPorts checks the current ports.
@@ -116,3 +121,26 @@ func (c *ContextNetworking) NetworkConfig(bindingName string) ([]params.NetworkC
 	}
 	return netConfig, nil
 }
+
+// NetworkInfo implements jujuc.ContextNetworking.
+func (c *ContextNetworking) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	c.stub.AddCall("NetworkInfo", bindingNames)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if c.info.BindingsToNetworkInfo == nil {
+		return nil, errors.NotSupportedf("network info")
+	}
+
+	results := make(map[string]params.NetworkInfoResult)
+	for _, name := range bindingNames {
+		result, isBindingKnown := c.info.BindingsToNetworkInfo[name]
+		if !isBindingKnown {
+			result = params.NetworkInfoResult{
+				Error: &params.Error{Message: "insert server error for unknown binding here"},
+			}
+		}
+		results[name] = result
+	}
+	return results, nil
+}
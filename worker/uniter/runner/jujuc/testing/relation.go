@@ -8,6 +8,7 @@ import (
 	"sort"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -23,6 +24,8 @@ type Relation struct {
 	Units map[string]Settings
 	// UnitName is data for jujuc.ContextRelation.
 	UnitName string
+	// Applications is data for jujuc.ContextRelation.
+	Applications map[string]Settings
 }
 
 // Reset clears the Relation's settings.
@@ -38,6 +41,14 @@ func (r *Relation) SetRelated(name string, settings Settings) {
 	r.Units[name] = settings
 }
 
+// SetApplication sets the relation settings for the application.
+func (r *Relation) SetApplication(name string, settings Settings) {
+	if r.Applications == nil {
+		r.Applications = make(map[string]Settings)
+	}
+	r.Applications[name] = settings
+}
+
 // ContextRelation is a test double for jujuc.ContextRelation.
 type ContextRelation struct {
 	contextBase
@@ -108,3 +119,36 @@ func (r *ContextRelation) ReadSettings(name string) (params.Settings, error) {
 	}
 	return s.Map(), nil
 }
+
+// ApplicationSettings implements jujuc.ContextRelation.
+func (r *ContextRelation) ApplicationSettings() (jujuc.Settings, error) {
+	r.stub.AddCall("ApplicationSettings")
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	name, err := names.UnitApplication(r.info.UnitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	settings, ok := r.info.Applications[name]
+	if !ok {
+		settings = Settings{}
+		r.info.SetApplication(name, settings)
+	}
+	return settings, nil
+}
+
+// ReadApplicationSettings implements jujuc.ContextRelation.
+func (r *ContextRelation) ReadApplicationSettings(name string) (params.Settings, error) {
+	r.stub.AddCall("ReadApplicationSettings", name)
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	s, found := r.info.Applications[name]
+	if !found {
+		return nil, fmt.Errorf("unknown application %s", name)
+	}
+	return s.Map(), nil
+}
@@ -15,11 +15,15 @@ import (
 // Relations holds the values for the hook context.
 type Relations struct {
 	Relations map[int]jujuc.ContextRelation
+
+	// MaxDataSize is the limit on the size of relation settings.
+	MaxDataSize int
 }
 
 // Reset clears the Relations data.
 func (r *Relations) Reset() {
 	r.Relations = nil
+	r.MaxDataSize = 0
 }
 
 // SetRelation adds the relation to the set of known relations.
@@ -80,3 +84,10 @@ func (c *ContextRelations) RelationIds() ([]int, error) {
 	}
 	return ids, c.stub.NextErr()
 }
+
+// MaxRelationDataSize implements jujuc.ContextRelations.
+func (c *ContextRelations) MaxRelationDataSize() (int, error) {
+	c.stub.AddCall("MaxRelationDataSize")
+
+	return c.info.MaxDataSize, c.stub.NextErr()
+}
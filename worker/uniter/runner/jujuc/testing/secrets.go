@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Secrets holds values for the hook context.
+type Secrets struct {
+	Secrets map[string]params.Secret
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// SetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) SetSecret(name, value string, rotateInterval *time.Duration) error {
+	c.stub.AddCall("SetSecret", name, value, rotateInterval)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.Secrets == nil {
+		c.info.Secrets = make(map[string]params.Secret)
+	}
+	secret := c.info.Secrets[name]
+	secret.Value = value
+	secret.Revision++
+	if rotateInterval != nil {
+		secret.RotateIntervalSeconds = rotateInterval.Seconds()
+	}
+	c.info.Secrets[name] = secret
+	return nil
+}
+
+// Secret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) Secret(name string) (params.Secret, error) {
+	c.stub.AddCall("Secret", name)
+	if err := c.stub.NextErr(); err != nil {
+		return params.Secret{}, errors.Trace(err)
+	}
+	secret, ok := c.info.Secrets[name]
+	if !ok {
+		return params.Secret{}, errors.NotFoundf("secret %q", name)
+	}
+	return secret, nil
+}
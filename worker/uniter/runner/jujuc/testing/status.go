@@ -13,6 +13,7 @@ import (
 type Status struct {
 	UnitStatus        jujuc.StatusInfo
 	ApplicationStatus jujuc.ApplicationStatusInfo
+	Events            []jujuc.StatusInfo
 }
 
 // SetApplicationStatus builds a service status and sets it on the Status.
@@ -70,3 +71,18 @@ func (c *ContextStatus) SetApplicationStatus(status jujuc.StatusInfo) error {
 	c.info.SetApplicationStatus(status, nil)
 	return nil
 }
+
+// AddStatusEvent implements jujuc.ContextStatus.
+func (c *ContextStatus) AddStatusEvent(message string, data map[string]interface{}) error {
+	c.stub.AddCall("AddStatusEvent", message, data)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.Events = append(c.info.Events, jujuc.StatusInfo{
+		Status: "event",
+		Info:   message,
+		Data:   data,
+	})
+	return nil
+}
@@ -59,13 +59,21 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return &runner{context: context, paths: paths}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+
+	// hookTimeout, if positive, is how long a charm hook may run
+	// before it is killed.
+	hookTimeout time.Duration
+
+	// interrupter, if not nil, kills charm hooks and actions when it
+	// is interrupted.
+	interrupter *Interrupter
 }
 
 func (runner *runner) Context() Context {
@@ -258,13 +266,49 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
-		// Block until execution finishes
-		err = ps.Wait()
+		// Block until execution finishes, or the hook runs out of time.
+		err = runner.wait(ps, clock.WallClock)
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// wait waits for the hook process to finish. If the runner has a hook
+// timeout and the process runs for longer, the process is killed and
+// context.ErrHookTimedOut is returned. If the runner's interrupter is
+// interrupted first, the process is killed and
+// context.ErrHookInterrupted is returned.
+func (runner *runner) wait(ps *exec.Cmd, clock clock.Clock) error {
+	interrupted := runner.interrupter.interrupted()
+	if runner.hookTimeout <= 0 && interrupted == nil {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	var timeout <-chan time.Time
+	if runner.hookTimeout > 0 {
+		timeout = clock.After(runner.hookTimeout)
+	}
+	var result error
+	select {
+	case err := <-done:
+		return err
+	case <-timeout:
+		logger.Warningf("hook did not finish within %v, killing process %d", runner.hookTimeout, ps.Process.Pid)
+		result = context.ErrHookTimedOut
+	case <-interrupted:
+		logger.Warningf("hook interrupted, killing process %d", ps.Process.Pid)
+		result = context.ErrHookInterrupted
+	}
+	if err := ps.Process.Kill(); err != nil {
+		logger.Warningf("cannot kill hook process %d: %v", ps.Process.Pid, err)
+	}
+	<-done
+	return result
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
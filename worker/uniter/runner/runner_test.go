@@ -252,6 +252,81 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook scripts sleep using bash")
+	}
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.GetCharmDir())
+	t0 := time.Now()
+	rnr := runner.NewRunnerWithHookTimeout(ctx, s.paths, 100*time.Millisecond)
+	err := rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.Equals, context.ErrHookTimedOut)
+	if time.Now().Sub(t0) > 5*time.Second {
+		c.Errorf("hook was not killed when it timed out")
+	}
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+func (s *RunMockContextSuite) TestRunHookInterrupted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook scripts sleep using bash")
+	}
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.GetCharmDir())
+	interrupter := runner.NewInterrupter()
+	rnr := runner.NewRunnerWithInterrupter(ctx, s.paths, interrupter)
+	done := make(chan error, 1)
+	go func() {
+		done <- rnr.RunHook("something-happened")
+	}()
+	// Interrupting has no effect on hooks started later, so keep
+	// interrupting until the hook has started and been killed.
+	var err error
+	timeout := time.After(5 * time.Second)
+loop:
+	for {
+		select {
+		case err = <-done:
+			break loop
+		case <-time.After(10 * time.Millisecond):
+			interrupter.Interrupt()
+		case <-timeout:
+			c.Fatalf("hook was not killed when interrupted")
+		}
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.Equals, context.ErrHookInterrupted)
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+func (s *RunMockContextSuite) TestRunHookWithinTimeout(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	rnr := runner.NewRunnerWithHookTimeout(ctx, s.paths, time.Minute)
+	err := rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -130,14 +130,20 @@ func (s *storageResolver) nextHookOp(
 		return nil, resolver.ErrNoOperation
 	}
 
-	hookInfo := hook.Info{StorageId: tag.Id()}
+	hookInfo := hook.Info{StorageId: tag.Id(), StorageSize: snap.Size}
 	switch snap.Life {
 	case params.Alive:
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes, and block storage growing.
+			// Storage attached before sizes were recorded has
+			// an unknown size, and is never reported as resized.
+			if storageAttachment.size == 0 || snap.Size <= storageAttachment.size {
+				return nil, resolver.ErrNoOperation
+			}
+			hookInfo.Kind = hook.StorageResized
+			break
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -72,6 +72,7 @@ type Uniter struct {
 
 	operationFactory     operation.Factory
 	operationExecutor    operation.Executor
+	executorMutex        sync.Mutex
 	newOperationExecutor NewExecutorFunc
 	translateResolverErr func(error) error
 
@@ -80,6 +81,10 @@ type Uniter struct {
 
 	hookLockName string
 
+	// interrupter stops the hooks and actions that are running when
+	// the unit is asked to skip or fail its pending operation.
+	interrupter *runner.Interrupter
+
 	// TODO(axw) move the runListener and run-command code outside of the
 	// uniter, and introduce a separate worker. Each worker would feed
 	// operations to a single, synchronized runner to execute.
@@ -147,6 +152,7 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		observer:             uniterParams.Observer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
+		interrupter:          runner.NewInterrupter(),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -245,6 +251,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+				InterruptOperation:  u.interrupter.Interrupt,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -281,6 +288,20 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			break
 		}
 
+		// Charms may opt in to running the hooks of independent
+		// relations concurrently. The charm may change on upgrade,
+		// which restarts this loop, so we check on each iteration.
+		var parallelRelationHooks bool
+		parallelRelationHooks, err = charm.ReadParallelRelationHooks(u.paths.State.CharmDir)
+		if err != nil {
+			err = errors.Annotate(err, "reading charm metadata")
+			break
+		}
+		relationsResolver := relation.NewRelationsResolver(u.relations)
+		if parallelRelationHooks {
+			relationsResolver = relation.NewParallelRelationsResolver(u.relations)
+		}
+
 		uniterResolver := NewUniterResolver(ResolverConfig{
 			ClearResolved:       clearResolved,
 			ReportHookError:     u.reportHookError,
@@ -289,7 +310,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			StopRetryHookTimer:  retryHookTimer.Reset,
 			Actions:             actions.NewResolver(),
 			Leadership:          uniterleadership.NewResolver(),
-			Relations:           relation.NewRelationsResolver(u.relations),
+			Relations:           relationsResolver,
 			Storage:             storage.NewResolver(u.storage),
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
@@ -465,7 +486,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
+		u.st, u.paths, contextFactory, u.interrupter,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -482,7 +503,9 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	u.executorMutex.Lock()
 	u.operationExecutor = operationExecutor
+	u.executorMutex.Unlock()
 
 	logger.Debugf("starting juju-run listener on unix:%s", u.paths.Runtime.JujuRunSocket)
 	commandRunner, err := NewChannelCommandRunner(ChannelCommandRunnerConfig{
@@ -519,6 +542,46 @@ func (u *Uniter) Wait() error {
 	return u.catacomb.Wait()
 }
 
+// Report is part of the dependency.Reporter interface. It describes the
+// unit's local operation state, as recorded in its operations file.
+func (u *Uniter) Report() map[string]interface{} {
+	u.executorMutex.Lock()
+	executor := u.operationExecutor
+	u.executorMutex.Unlock()
+	if executor == nil {
+		return map[string]interface{}{"initialized": false}
+	}
+	opState := executor.State()
+	report := map[string]interface{}{
+		"initialized": true,
+		"op":          string(opState.Kind),
+		"op-step":     string(opState.Step),
+		"leader":      opState.Leader,
+		"installed":   opState.Installed,
+		"started":     opState.Started,
+		"stopped":     opState.Stopped,
+	}
+	if opState.Hook != nil {
+		hookReport := map[string]interface{}{
+			"kind": string(opState.Hook.Kind),
+		}
+		if opState.Hook.Kind.IsRelation() {
+			hookReport["relation-id"] = opState.Hook.RelationId
+			if opState.Hook.RemoteUnit != "" {
+				hookReport["remote-unit"] = opState.Hook.RemoteUnit
+			}
+		}
+		report["hook"] = hookReport
+	}
+	if opState.ActionId != nil {
+		report["action-id"] = *opState.ActionId
+	}
+	if opState.CharmURL != nil {
+		report["charm"] = opState.CharmURL.String()
+	}
+	return report
+}
+
 func (u *Uniter) getServiceCharmURL() (*corecharm.URL, error) {
 	// TODO(fwereade): pretty sure there's no reason to make 2 API calls here.
 	service, err := u.st.Application(u.unit.ApplicationTag())
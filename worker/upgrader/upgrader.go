@@ -226,6 +226,30 @@ func (u *Upgrader) loop() error {
 	}
 }
 
+// ensureToolsFromDelta fetches and unpacks the given tools
+// using a delta from the current tools, served by the controller
+// over the authenticated API connection.
+func (u *Upgrader) ensureToolsFromDelta(current version.Binary, agentTools *coretools.Tools) error {
+	logger.Infof("fetching tools %s as delta from %s", agentTools.Version, current)
+	r, archiveSHA256, err := u.st.ToolsDelta(current, agentTools.Version)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+	if archiveSHA256 != "" {
+		err = agenttools.UnpackToolsDelta(u.dataDir, current, agentTools, archiveSHA256, r)
+	} else {
+		// The controller couldn't produce a delta and has sent
+		// the complete tarball instead, so just use that.
+		err = agenttools.UnpackTools(u.dataDir, agentTools, r)
+	}
+	if err != nil {
+		return errors.Annotate(err, "cannot unpack tools")
+	}
+	logger.Infof("unpacked tools %s to %s", agentTools.Version, u.dataDir)
+	return nil
+}
+
 func toBinaryVersion(vers version.Number) version.Binary {
 	outVers := version.Binary{
 		Number: vers,
@@ -250,6 +274,16 @@ func (u *Upgrader) newUpgradeReadyError(newVersion version.Binary) *UpgradeReady
 }
 
 func (u *Upgrader) ensureTools(agentTools *coretools.Tools) error {
+	// If we still have the archive our current tools were unpacked
+	// from, ask the controller for just the changes since then.
+	current := toBinaryVersion(jujuversion.Current)
+	if agenttools.HasToolsArchive(u.dataDir, current) {
+		err := u.ensureToolsFromDelta(current, agentTools)
+		if err == nil {
+			return nil
+		}
+		logger.Warningf("cannot upgrade tools from %s using delta: %v", current, err)
+	}
 	logger.Infof("fetching tools from %q", agentTools.URL)
 	// The reader MUST verify the tools' hash, so there is no
 	// need to validate the peer. We cannot anyway: see http://pad.lv/1261780.